@@ -0,0 +1,103 @@
+// Package diagnostics downloads the diagnostics bundle of a node from its asset service, for support cases.
+//
+// Usage:
+//
+//	This package is used as a command-line tool against a running node. The bundle is a gzipped tar archive with
+//	the catchup status, the peer registry, the recent errors, the goroutine and heap profiles, the build
+//	information and the effective settings of the node, with credentials and keys redacted.
+//
+// Functions:
+//   - DownloadBundle: Requests the diagnostics bundle from the asset service and writes it to a file.
+//
+// Side effects:
+//
+//	Writes the bundle to the output file. The bundle is recorded in the audit log of the node.
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/asset/apiclient"
+	"github.com/bsv-blockchain/teranode/settings"
+)
+
+// downloadTimeout bounds the creation and the download of the bundle
+const downloadTimeout = 2 * time.Minute
+
+// Config is the node the bundle is downloaded from and where it is written to
+type Config struct {
+	URL      string // Asset API URL including the API prefix, asset_httpAddress when empty
+	Output   string // File the bundle is written to, teranode-diagnostics-<time>.tar.gz in the working directory when empty
+	User     string // Dashboard user, when the dashboard authentication is enabled
+	Password string // Dashboard password
+}
+
+// basicAuthTransport adds the basic auth credentials to the requests
+type basicAuthTransport struct {
+	user     string
+	password string
+	next     http.RoundTripper
+}
+
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(t.user, t.password)
+
+	return t.next.RoundTrip(req)
+}
+
+// DownloadBundle requests the diagnostics bundle from the asset service of the node and writes it to the output file.
+// Returns the path of the written bundle.
+func DownloadBundle(ctx context.Context, tSettings *settings.Settings, cfg Config) (string, error) {
+	baseURL := cfg.URL
+	if baseURL == "" {
+		baseURL = tSettings.Asset.HTTPAddress
+	}
+
+	if baseURL == "" {
+		return "", errors.NewConfigurationError("no asset API URL, set -url or asset_httpAddress")
+	}
+
+	httpClient := &http.Client{Timeout: downloadTimeout}
+	if cfg.User != "" {
+		httpClient.Transport = &basicAuthTransport{user: cfg.User, password: cfg.Password, next: http.DefaultTransport}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, downloadTimeout)
+	defer cancel()
+
+	bundle, err := apiclient.NewClient(baseURL, httpClient).CreateDiagnosticsBundle(ctx)
+	if err != nil {
+		return "", errors.NewServiceError("failed to create the diagnostics bundle at %s", baseURL, err)
+	}
+
+	output := cfg.Output
+	if output == "" {
+		output = "teranode-diagnostics-" + time.Now().UTC().Format("20060102T150405Z") + ".tar.gz"
+	}
+
+	// the bundle holds the peers and profiles of the node, it is only readable by the user
+	if err = os.WriteFile(filepath.Clean(output), bundle, 0o600); err != nil {
+		return "", errors.NewProcessingError("failed to write the diagnostics bundle to %s", output, err)
+	}
+
+	return output, nil
+}
+
+// RunDownloadBundle downloads the diagnostics bundle and prints where it was written
+func RunDownloadBundle(tSettings *settings.Settings, cfg Config) error {
+	output, err := DownloadBundle(context.Background(), tSettings, cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Diagnostics bundle written to %s\n", output)
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadBundle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/diagnostics/bundle" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"Authentication required"}`))
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/gzip")
+		_, _ = w.Write([]byte("bundle"))
+	}))
+	defer server.Close()
+
+	tSettings := &settings.Settings{}
+	tSettings.Asset.HTTPAddress = server.URL + "/api/v1"
+
+	t.Run("written to the output file", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+
+		written, err := DownloadBundle(context.Background(), tSettings, Config{Output: output, User: "admin", Password: "secret"})
+		require.NoError(t, err)
+		assert.Equal(t, output, written)
+
+		data, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Equal(t, "bundle", string(data))
+
+		info, err := os.Stat(output)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("authentication required", func(t *testing.T) {
+		_, err := DownloadBundle(context.Background(), tSettings, Config{Output: filepath.Join(t.TempDir(), "bundle.tar.gz")})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Authentication required")
+	})
+
+	t.Run("no asset API URL", func(t *testing.T) {
+		_, err := DownloadBundle(context.Background(), &settings.Settings{}, Config{})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,374 @@
+// Package p2pload simulates the peer interactions of many block validation clients against a p2p service, to load
+// test the peer registry behind its gRPC API.
+//
+// Usage:
+//
+//	This package is used as a command-line tool against a test instance of the p2p service. Every simulated client
+//	runs catchup rounds as block validation does: it asks for the peers for catchup, records a catchup attempt
+//	with one of them and reports the outcome as a success, failure or malicious catchup.
+//
+// Functions:
+//   - RunP2PLoad: Connects to the p2p service, runs the simulation and prints the report.
+//   - Simulate: Runs the simulation with the provided clients and returns the throughput and latencies per call.
+//
+// Side effects:
+//
+//	The reported catchups change the reputation of the peers in the registry of the p2p service, and are
+//	recorded in its peer event log. Do not run it against a node in production.
+package p2pload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// The simulated calls, in the order they are reported
+const (
+	OperationGetPeersForCatchup     = "GetPeersForCatchup"
+	OperationRecordCatchupAttempt   = "RecordCatchupAttempt"
+	OperationRecordCatchupSuccess   = "RecordCatchupSuccess"
+	OperationRecordCatchupFailure   = "RecordCatchupFailure"
+	OperationRecordCatchupMalicious = "RecordCatchupMalicious"
+)
+
+var operations = []string{
+	OperationGetPeersForCatchup,
+	OperationRecordCatchupAttempt,
+	OperationRecordCatchupSuccess,
+	OperationRecordCatchupFailure,
+	OperationRecordCatchupMalicious,
+}
+
+// Config is the load of the simulation
+type Config struct {
+	Clients       int           // Number of simulated block validation clients
+	Connections   int           // Number of gRPC connections the clients are spread over
+	Duration      time.Duration // How long the simulation runs
+	Rate          float64       // Catchup rounds per second of each client, 0 for as fast as possible
+	FailureRate   float64       // Fraction of the catchups reported as failed
+	MaliciousRate float64       // Fraction of the catchups reported as malicious
+	Peers         int           // Number of random peer IDs used when the service returns no peers for catchup
+}
+
+// OperationStats is the throughput and latency of one of the simulated calls
+type OperationStats struct {
+	Operation  string
+	Calls      int64
+	Errors     int64
+	Throughput float64 // Calls per second
+	P50        time.Duration
+	P90        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+}
+
+// Report is the outcome of a simulation
+type Report struct {
+	Clients    int
+	Duration   time.Duration
+	Rounds     int64 // Completed catchup rounds over all clients
+	Operations []OperationStats
+}
+
+// recorder collects the latencies of the calls of a single client, so the clients never contend on it
+type recorder struct {
+	latencies map[string][]time.Duration
+	errors    map[string]int64
+	rounds    int64
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		latencies: make(map[string][]time.Duration, len(operations)),
+		errors:    make(map[string]int64, len(operations)),
+	}
+}
+
+// call times the call and records its latency, or the error it returned
+func (r *recorder) call(operation string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	latency := time.Since(start)
+
+	if err != nil {
+		r.errors[operation]++
+		return err
+	}
+
+	r.latencies[operation] = append(r.latencies[operation], latency)
+
+	return nil
+}
+
+// RunP2PLoad connects to the p2p service at the address, or at p2p_grpcAddress when empty, simulates the load of
+// the configuration and prints the report to stdout.
+//
+// Parameters:
+//   - logger: ulogger.Logger for logging messages.
+//   - tSettings: pointer to settings.Settings containing configuration details.
+//   - address: gRPC address of the p2p service, empty for p2p_grpcAddress.
+//   - cfg: the load to simulate.
+//
+// Returns:
+//   - error: when the p2p service can not be connected to or the configuration is invalid.
+func RunP2PLoad(logger ulogger.Logger, tSettings *settings.Settings, address string, cfg Config) error {
+	if cfg.Clients <= 0 || cfg.Duration <= 0 {
+		return errors.NewInvalidArgumentError("clients and duration must be positive")
+	}
+
+	ctx := context.Background()
+
+	clients := make([]p2p.ClientI, 0, max(1, min(cfg.Connections, cfg.Clients)))
+
+	for i := 0; i < cap(clients); i++ {
+		var (
+			client p2p.ClientI
+			err    error
+		)
+
+		if address != "" {
+			client, err = p2p.NewClientWithAddress(ctx, logger, address, tSettings)
+		} else {
+			client, err = p2p.NewClient(ctx, logger, tSettings)
+		}
+
+		if err != nil {
+			return errors.NewServiceError("failed to connect to the p2p service", err)
+		}
+
+		clients = append(clients, client)
+	}
+
+	logger.Infof("simulating %d clients over %d connections for %v", cfg.Clients, len(clients), cfg.Duration)
+
+	report, err := Simulate(ctx, clients, cfg)
+	if err != nil {
+		return err
+	}
+
+	report.Print(os.Stdout)
+
+	return nil
+}
+
+// Simulate runs cfg.Clients simulated block validation clients for cfg.Duration, spread over the clients, and
+// returns the throughput and latencies of their calls.
+//
+// Parameters:
+//   - ctx: context to stop the simulation early.
+//   - clients: the p2p service clients, one per connection.
+//   - cfg: the load to simulate.
+//
+// Returns:
+//   - *Report: the throughput and latencies per call.
+//   - error: when the configuration is invalid.
+func Simulate(ctx context.Context, clients []p2p.ClientI, cfg Config) (*Report, error) {
+	if len(clients) == 0 || cfg.Clients <= 0 || cfg.Duration <= 0 {
+		return nil, errors.NewInvalidArgumentError("clients and duration must be positive")
+	}
+
+	fallbackPeers, err := randomPeerIDs(max(1, cfg.Peers))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	recorders := make([]*recorder, cfg.Clients)
+
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for i := range recorders {
+		recorders[i] = newRecorder()
+
+		wg.Add(1)
+
+		go func(client p2p.ClientI, r *recorder) {
+			defer wg.Done()
+
+			simulateClient(ctx, client, cfg, fallbackPeers, r)
+		}(clients[i%len(clients)], recorders[i])
+	}
+
+	wg.Wait()
+
+	return newReport(cfg.Clients, time.Since(start), recorders), nil
+}
+
+// simulateClient runs catchup rounds until the context is done
+func simulateClient(ctx context.Context, client p2p.ClientI, cfg Config, fallbackPeers []string, r *recorder) {
+	var ticker *time.Ticker
+
+	if cfg.Rate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / cfg.Rate))
+		defer ticker.Stop()
+	}
+
+	for {
+		if ticker != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		} else if ctx.Err() != nil {
+			return
+		}
+
+		if catchupRound(ctx, client, cfg, fallbackPeers, r) {
+			r.rounds++
+		}
+	}
+}
+
+// catchupRound selects a peer for catchup, records the attempt and reports its outcome, as block validation does.
+// Returns whether all calls of the round succeeded.
+func catchupRound(ctx context.Context, client p2p.ClientI, cfg Config, fallbackPeers []string, r *recorder) bool {
+	var peers []*p2p.PeerInfo
+
+	if err := r.call(OperationGetPeersForCatchup, func() (err error) {
+		peers, err = client.GetPeersForCatchup(ctx)
+		return err
+	}); err != nil {
+		return false
+	}
+
+	peerID := fallbackPeers[rand.IntN(len(fallbackPeers))] //nolint:gosec // no security impact
+	if len(peers) > 0 {
+		peerID = peers[rand.IntN(len(peers))].ID.String() //nolint:gosec // no security impact
+	}
+
+	if err := r.call(OperationRecordCatchupAttempt, func() error {
+		return client.RecordCatchupAttempt(ctx, peerID)
+	}); err != nil {
+		return false
+	}
+
+	outcome := rand.Float64() //nolint:gosec // no security impact
+
+	var err error
+
+	switch {
+	case outcome < cfg.MaliciousRate:
+		err = r.call(OperationRecordCatchupMalicious, func() error {
+			return client.RecordCatchupMalicious(ctx, peerID)
+		})
+	case outcome < cfg.MaliciousRate+cfg.FailureRate:
+		err = r.call(OperationRecordCatchupFailure, func() error {
+			return client.RecordCatchupFailure(ctx, peerID)
+		})
+	default:
+		err = r.call(OperationRecordCatchupSuccess, func() error {
+			return client.RecordCatchupSuccess(ctx, peerID, 50+rand.Int64N(1000)) //nolint:gosec // no security impact
+		})
+	}
+
+	return err == nil
+}
+
+// randomPeerIDs returns n valid peer IDs of random keys
+func randomPeerIDs(n int) ([]string, error) {
+	ids := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		privKey, _, err := crypto.GenerateEd25519Key(nil)
+		if err != nil {
+			return nil, errors.NewProcessingError("failed to generate peer key", err)
+		}
+
+		id, err := peer.IDFromPrivateKey(privKey)
+		if err != nil {
+			return nil, errors.NewProcessingError("failed to derive peer ID", err)
+		}
+
+		ids = append(ids, id.String())
+	}
+
+	return ids, nil
+}
+
+// newReport merges the recorders of all clients into the report
+func newReport(clients int, elapsed time.Duration, recorders []*recorder) *Report {
+	report := &Report{
+		Clients:    clients,
+		Duration:   elapsed,
+		Operations: make([]OperationStats, 0, len(operations)),
+	}
+
+	for _, operation := range operations {
+		stats := OperationStats{Operation: operation}
+
+		var latencies []time.Duration
+
+		for _, r := range recorders {
+			latencies = append(latencies, r.latencies[operation]...)
+			stats.Errors += r.errors[operation]
+		}
+
+		stats.Calls = int64(len(latencies)) + stats.Errors
+
+		if stats.Calls == 0 {
+			continue
+		}
+
+		if elapsed > 0 {
+			stats.Throughput = float64(stats.Calls) / elapsed.Seconds()
+		}
+
+		if len(latencies) > 0 {
+			sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+			stats.P50 = percentile(latencies, 50)
+			stats.P90 = percentile(latencies, 90)
+			stats.P99 = percentile(latencies, 99)
+			stats.Max = latencies[len(latencies)-1]
+		}
+
+		report.Operations = append(report.Operations, stats)
+	}
+
+	for _, r := range recorders {
+		report.Rounds += r.rounds
+	}
+
+	return report
+}
+
+// percentile returns the latency below which p percent of the sorted latencies fall
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p+99)/100 - 1
+
+	return sorted[max(0, min(i, len(sorted)-1))]
+}
+
+// Print writes the report as a table
+func (r *Report) Print(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "\nP2P load: %d clients, %v, %d catchup rounds (%.1f/s)\n\n",
+		r.Clients, r.Duration.Round(time.Millisecond), r.Rounds, float64(r.Rounds)/max(r.Duration.Seconds(), 1e-9))
+
+	_, _ = fmt.Fprintf(w, "%-24s %10s %8s %10s %10s %10s %10s %10s\n", "operation", "calls", "errors", "calls/s", "p50", "p90", "p99", "max")
+
+	for _, op := range r.Operations {
+		_, _ = fmt.Fprintf(w, "%-24s %10d %8d %10.1f %10v %10v %10v %10v\n",
+			op.Operation, op.Calls, op.Errors, op.Throughput,
+			op.P50.Round(time.Microsecond), op.P90.Round(time.Microsecond), op.P99.Round(time.Microsecond), op.Max.Round(time.Microsecond))
+	}
+
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,162 @@
+package p2pload
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeP2PClient counts the catchup calls per peer, the other methods of the interface are not used
+type fakeP2PClient struct {
+	p2p.ClientI
+
+	mu       sync.Mutex
+	peers    []*p2p.PeerInfo
+	calls    map[string]int
+	peerIDs  map[string]struct{}
+	failWith error
+}
+
+func newFakeP2PClient(peers ...peer.ID) *fakeP2PClient {
+	c := &fakeP2PClient{
+		calls:   make(map[string]int),
+		peerIDs: make(map[string]struct{}),
+	}
+
+	for _, id := range peers {
+		c.peers = append(c.peers, &p2p.PeerInfo{ID: id})
+	}
+
+	return c
+}
+
+func (c *fakeP2PClient) record(operation, peerID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls[operation]++
+
+	if peerID != "" {
+		c.peerIDs[peerID] = struct{}{}
+	}
+
+	return c.failWith
+}
+
+func (c *fakeP2PClient) GetPeersForCatchup(_ context.Context) ([]*p2p.PeerInfo, error) {
+	return c.peers, c.record(OperationGetPeersForCatchup, "")
+}
+
+func (c *fakeP2PClient) RecordCatchupAttempt(_ context.Context, peerID string) error {
+	return c.record(OperationRecordCatchupAttempt, peerID)
+}
+
+func (c *fakeP2PClient) RecordCatchupSuccess(_ context.Context, peerID string, _ int64) error {
+	return c.record(OperationRecordCatchupSuccess, peerID)
+}
+
+func (c *fakeP2PClient) RecordCatchupFailure(_ context.Context, peerID string) error {
+	return c.record(OperationRecordCatchupFailure, peerID)
+}
+
+func (c *fakeP2PClient) RecordCatchupMalicious(_ context.Context, peerID string) error {
+	return c.record(OperationRecordCatchupMalicious, peerID)
+}
+
+func TestSimulate(t *testing.T) {
+	t.Run("rounds report on the peers for catchup", func(t *testing.T) {
+		client := newFakeP2PClient("peer-a", "peer-b")
+
+		report, err := Simulate(context.Background(), []p2p.ClientI{client}, Config{
+			Clients:     4,
+			Duration:    50 * time.Millisecond,
+			Rate:        200,
+			FailureRate: 0.5,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, report.Clients)
+		assert.Positive(t, report.Rounds)
+
+		stats := make(map[string]OperationStats)
+		for _, op := range report.Operations {
+			stats[op.Operation] = op
+		}
+
+		assert.Equal(t, int64(client.calls[OperationGetPeersForCatchup]), stats[OperationGetPeersForCatchup].Calls)
+		assert.Equal(t, stats[OperationRecordCatchupAttempt].Calls,
+			stats[OperationRecordCatchupSuccess].Calls+stats[OperationRecordCatchupFailure].Calls)
+		assert.NotContains(t, stats, OperationRecordCatchupMalicious)
+		assert.Positive(t, stats[OperationGetPeersForCatchup].Throughput)
+		assert.LessOrEqual(t, stats[OperationGetPeersForCatchup].P50, stats[OperationGetPeersForCatchup].Max)
+
+		assert.Equal(t, map[string]struct{}{peer.ID("peer-a").String(): {}, peer.ID("peer-b").String(): {}}, client.peerIDs)
+
+		var out bytes.Buffer
+		report.Print(&out)
+		assert.Contains(t, out.String(), OperationRecordCatchupAttempt)
+	})
+
+	t.Run("random peers without peers for catchup", func(t *testing.T) {
+		client := newFakeP2PClient()
+
+		_, err := Simulate(context.Background(), []p2p.ClientI{client}, Config{
+			Clients:  2,
+			Duration: 20 * time.Millisecond,
+			Rate:     200,
+			Peers:    3,
+		})
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, client.peerIDs)
+		assert.LessOrEqual(t, len(client.peerIDs), 3)
+
+		for id := range client.peerIDs {
+			_, err = peer.Decode(id)
+			require.NoError(t, err, "random peer IDs are valid")
+		}
+	})
+
+	t.Run("errors are counted", func(t *testing.T) {
+		client := newFakeP2PClient("peer-a")
+		client.failWith = errors.NewServiceUnavailableError("unavailable")
+
+		report, err := Simulate(context.Background(), []p2p.ClientI{client}, Config{
+			Clients:  1,
+			Duration: 20 * time.Millisecond,
+			Rate:     200,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, report.Operations, 1)
+		assert.Equal(t, report.Operations[0].Calls, report.Operations[0].Errors)
+		assert.Zero(t, report.Rounds)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := Simulate(context.Background(), []p2p.ClientI{newFakeP2PClient()}, Config{Duration: time.Second})
+		require.Error(t, err)
+
+		_, err = Simulate(context.Background(), nil, Config{Clients: 1, Duration: time.Second})
+		require.Error(t, err)
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	assert.Equal(t, 50*time.Millisecond, percentile(sorted, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(sorted, 99))
+	assert.Equal(t, time.Millisecond, percentile(sorted[:1], 99))
+}
@@ -40,3 +40,30 @@ func ResetBlockAssembly(logger ulogger.Logger, settings *settings.Settings, full
 
 	return nil
 }
+
+// ResetBlockAssemblyScoped repairs the unmined state of the transactions in a height range of the main chain, or in a
+// fork, and then resets the block assembly process.
+//
+// Parameters:
+//   - logger: A ulogger.Logger instance for logging purposes.
+//   - settings: A pointer to settings.Settings containing configuration details.
+//   - scope: The height range or fork tip to reset.
+//
+// Returns:
+//   - The number of blocks and repaired transactions.
+//   - An error if any step in the process fails.
+func ResetBlockAssemblyScoped(logger ulogger.Logger, settings *settings.Settings, scope blockassembly.ResetScope) (blockassembly.ResetScopeResult, error) {
+	ctx := context.Background()
+
+	ba, err := blockassembly.NewClient(ctx, logger, settings)
+	if err != nil {
+		return blockassembly.ResetScopeResult{}, errors.NewConfigurationError("failed to create block assembly client: %w", err)
+	}
+
+	result, err := ba.ResetBlockAssemblyScoped(ctx, scope)
+	if err != nil {
+		return blockassembly.ResetScopeResult{}, errors.NewProcessingError("failed to reset block assembly: %w", err)
+	}
+
+	return result, nil
+}
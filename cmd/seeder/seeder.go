@@ -54,6 +54,9 @@ import (
 
 const (
 	errMsgFailedToReadUTXO = "failed to read UTXO set header"
+
+	// headersBatchSize is the number of headers stored in the blockchain store at once
+	headersBatchSize = 1000
 )
 
 // usage prints the usage message and exits the program with an error code.
@@ -266,6 +269,38 @@ func processHeaders(ctx context.Context, logger ulogger.Logger, appSettings *set
 		txCount          uint64
 	)
 
+	// the headers are stored in ranges of contiguous blocks, instead of one insert per header
+	batch := make([]*model.Block, 0, headersBatchSize)
+
+	storeBatch := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if _, err := blockchainStore.StoreBlocks(
+			ctx,
+			batch,
+			"headers",
+			blockchainoptions.WithMinedSet(true),
+			blockchainoptions.WithSubtreesSet(true),
+		); err != nil {
+			return errors.NewProcessingError("failed to add blocks", err)
+		}
+
+		for _, block := range batch {
+			headersProcessed++
+			txCount += block.TransactionCount
+
+			if block.Height%10000 == 0 {
+				fmt.Printf("Processed to block height %d\n", block.Height)
+			}
+		}
+
+		batch = batch[:0]
+
+		return nil
+	}
+
 	var blockIndex *utxopersister.BlockIndex
 
 	for {
@@ -283,29 +318,21 @@ func processHeaders(ctx context.Context, logger ulogger.Logger, appSettings *set
 			continue
 		}
 
-		block := &model.Block{
+		batch = append(batch, &model.Block{
 			Header:           blockIndex.BlockHeader,
 			TransactionCount: blockIndex.TxCount,
 			Height:           blockIndex.Height,
-		}
+		})
 
-		_, _, err = blockchainStore.StoreBlock(
-			ctx,
-			block,
-			"headers",
-			blockchainoptions.WithMinedSet(true),
-			blockchainoptions.WithSubtreesSet(true),
-		)
-		if err != nil {
-			return errors.NewProcessingError("failed to add block", err)
+		if len(batch) == headersBatchSize {
+			if err = storeBatch(); err != nil {
+				return err
+			}
 		}
+	}
 
-		headersProcessed++
-		txCount += blockIndex.TxCount
-
-		if blockIndex.Height%10000 == 0 {
-			fmt.Printf("Processed to block height %d\n", blockIndex.Height)
-		}
+	if err = storeBatch(); err != nil {
+		return err
 	}
 
 	logger.Infof("FINISHED  %16s transactions with %16s utxos", formatNumber(headersProcessed), formatNumber(txCount))
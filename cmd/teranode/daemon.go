@@ -13,13 +13,15 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/blob/file"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
 	"github.com/ordishs/gocore"
 )
 
 // RunDaemon starts the teranode daemon with all necessary initialization
-func RunDaemon(progname, version, commit string) {
+func RunDaemon(progname, version, commit, buildDate string) {
 	// Initialize gocore with version info
 	gocore.SetInfo(progname, version, commit)
+	buildinfo.SetBuildDate(buildDate)
 
 	// Call the gocore.Log function to initialize the logger and start the Unix domain socket that allows us to configure settings at runtime.
 	gocore.Log(progname)
@@ -72,6 +74,6 @@ func RunDaemon(progname, version, commit string) {
 	logger.Infof("STATS\n%s\nVERSION\n-------\n%s (%s)\n\n", stats, version, commit)
 
 	daemon.New(daemon.WithLoggerFactory(func(serviceName string) ulogger.Logger {
-		return ulogger.New(serviceName, ulogger.WithLevel(tSettings.LogLevel))
+		return ulogger.ComponentLevels().Logger(serviceName, ulogger.New(serviceName, ulogger.WithLevel(tSettings.LogLevel)))
 	})).Start(logger, os.Args[1:], tSettings)
 }
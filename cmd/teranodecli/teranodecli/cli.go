@@ -6,14 +6,18 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"time"
 
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
 	"github.com/bsv-blockchain/teranode/cmd/aerospikekafkaconnector"
 	"github.com/bsv-blockchain/teranode/cmd/aerospikereader"
 	"github.com/bsv-blockchain/teranode/cmd/bitcointoutxoset"
 	"github.com/bsv-blockchain/teranode/cmd/checkblock"
 	"github.com/bsv-blockchain/teranode/cmd/checkblocktemplate"
+	"github.com/bsv-blockchain/teranode/cmd/diagnostics"
 	"github.com/bsv-blockchain/teranode/cmd/filereader"
 	"github.com/bsv-blockchain/teranode/cmd/getfsmstate"
+	"github.com/bsv-blockchain/teranode/cmd/p2pload"
 	"github.com/bsv-blockchain/teranode/cmd/resetblockassembly"
 	"github.com/bsv-blockchain/teranode/cmd/seeder"
 	"github.com/bsv-blockchain/teranode/cmd/setfsmstate"
@@ -21,6 +25,7 @@ import (
 	"github.com/bsv-blockchain/teranode/cmd/utxopersister"
 	"github.com/bsv-blockchain/teranode/cmd/utxovalidator"
 	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/blockassembly"
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/sql"
 	"github.com/bsv-blockchain/teranode/ulogger"
@@ -45,6 +50,9 @@ var commandHelp = map[string]string{
 	"resetblockassembly":      "Reset block assembly state",
 	"fix-chainwork":           "Fix incorrect chainwork values in blockchain database",
 	"validate-utxo-set":       "Validate UTXO set file",
+	"p2pload":                 "Simulate block validation clients to load test the p2p gRPC API",
+	"diagnostics":             "Download a diagnostics bundle of a running node for support cases",
+	"blockchain-migrations":   "Show, apply or roll back the schema migrations of the blockchain database",
 }
 
 var dangerousCommands = map[string]bool{}
@@ -333,8 +341,41 @@ func Start(args []string, version, commit string) {
 		}
 	case "resetblockassembly":
 		fullReset := cmd.FlagSet.Bool("full-reset", false, "Perform a full reset, including clearing mempool and unmined transactions")
+		fromHeight := cmd.FlagSet.Uint("from-height", 0, "First block height of a scoped reset of the main chain")
+		toHeight := cmd.FlagSet.Uint("to-height", 0, "Last block height of a scoped reset of the main chain")
+		fork := cmd.FlagSet.String("fork", "", "Tip hash of a fork for a scoped reset, marking its transactions as unmined")
 
 		cmd.Execute = func(args []string) error {
+			if *fork != "" || *toHeight > 0 {
+				if *fullReset {
+					return errors.NewProcessingError("--full-reset cannot be combined with a scoped reset")
+				}
+
+				scope := blockassembly.ResetScope{
+					FromHeight: uint32(*fromHeight), //nolint:gosec // block heights fit in a uint32
+					ToHeight:   uint32(*toHeight),   //nolint:gosec // block heights fit in a uint32
+				}
+
+				if *fork != "" {
+					forkHash, err := chainhash.NewHashFromStr(*fork)
+					if err != nil {
+						return errors.NewProcessingError("Invalid fork hash", err)
+					}
+
+					scope.ForkHash = forkHash
+				}
+
+				result, err := resetblockassembly.ResetBlockAssemblyScoped(logger, tSettings, scope)
+				if err != nil {
+					return errors.NewProcessingError("Failed to reset block assembly", err)
+				}
+
+				fmt.Printf("Reset block assembly for %d blocks: %d transactions marked as mined, %d marked as unmined\n",
+					result.Blocks, result.MarkedMined, result.MarkedUnmined)
+
+				return nil
+			}
+
 			err := resetblockassembly.ResetBlockAssembly(logger, tSettings, *fullReset)
 			if err != nil {
 				return errors.NewProcessingError("Failed to reset block assembly", err)
@@ -403,6 +444,55 @@ func Start(args []string, version, commit string) {
 
 			return nil
 		}
+	case "p2pload":
+		address := cmd.FlagSet.String("address", "", "gRPC address of the p2p service (default: p2p_grpcAddress)")
+		clients := cmd.FlagSet.Int("clients", 200, "Number of simulated block validation clients")
+		connections := cmd.FlagSet.Int("connections", 10, "Number of gRPC connections the clients are spread over")
+		duration := cmd.FlagSet.Duration("duration", 30*time.Second, "How long the simulation runs")
+		rate := cmd.FlagSet.Float64("rate", 0, "Catchup rounds per second of each client (0 for as fast as possible)")
+		failureRate := cmd.FlagSet.Float64("failure-rate", 0.1, "Fraction of the catchups reported as failed")
+		maliciousRate := cmd.FlagSet.Float64("malicious-rate", 0, "Fraction of the catchups reported as malicious")
+		peers := cmd.FlagSet.Int("peers", 50, "Number of random peer IDs used when the service returns no peers for catchup")
+
+		cmd.Execute = func(args []string) error {
+			return p2pload.RunP2PLoad(logger, tSettings, *address, p2pload.Config{
+				Clients:       *clients,
+				Connections:   *connections,
+				Duration:      *duration,
+				Rate:          *rate,
+				FailureRate:   *failureRate,
+				MaliciousRate: *maliciousRate,
+				Peers:         *peers,
+			})
+		}
+	case "diagnostics":
+		url := cmd.FlagSet.String("url", "", "Asset API URL including the API prefix (default: asset_httpAddress)")
+		output := cmd.FlagSet.String("output", "", "File the bundle is written to (default: teranode-diagnostics-<time>.tar.gz)")
+		user := cmd.FlagSet.String("user", "", "Dashboard user, when the dashboard authentication is enabled")
+		password := cmd.FlagSet.String("password", "", "Dashboard password")
+
+		cmd.Execute = func(args []string) error {
+			return diagnostics.RunDownloadBundle(tSettings, diagnostics.Config{
+				URL:      *url,
+				Output:   *output,
+				User:     *user,
+				Password: *password,
+			})
+		}
+	case "blockchain-migrations":
+		dbURL := cmd.FlagSet.String("db-url", "", "Database URL (postgres://... or sqlite://...) (default: blockchain_store)")
+		to := cmd.FlagSet.Int("to", -1, "Schema version to migrate up or down to, the node must be stopped when rolling back")
+		clearDirty := cmd.FlagSet.Uint("clear-dirty", 0, "Version of a dirty migration to clear after the schema was repaired by hand")
+		keepApplied := cmd.FlagSet.Bool("keep-applied", false, "Keep the cleared migration as applied instead of removing it")
+
+		cmd.Execute = func(args []string) error {
+			return blockchainMigrations(logger, tSettings, migrationsConfig{
+				DBURL:       *dbURL,
+				To:          *to,
+				ClearDirty:  *clearDirty,
+				KeepApplied: *keepApplied,
+			})
+		}
 	default:
 		fmt.Printf("Unknown command: %s\n\n", command)
 		printUsage()
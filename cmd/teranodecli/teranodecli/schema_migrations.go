@@ -0,0 +1,106 @@
+package teranodecli
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"text/tabwriter"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/stores/blockchain/sql"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util"
+)
+
+// migrationsConfig is the action of the blockchain-migrations command, the status is printed when no action is set
+type migrationsConfig struct {
+	DBURL       string // blockchain database URL, blockchain_store when empty
+	To          int    // schema version to migrate up or down to, -1 for none
+	ClearDirty  uint   // version of the dirty migration to clear, 0 for none
+	KeepApplied bool   // keep the cleared migration as applied instead of removing it
+}
+
+// blockchainMigrations prints the schema migrations of the blockchain database, migrates it to a version, or clears
+// the dirty state of a migration after the schema was repaired by hand. The node must be stopped when rolling back.
+func blockchainMigrations(logger ulogger.Logger, tSettings *settings.Settings, cfg migrationsConfig) error {
+	storeURL := tSettings.BlockChain.StoreURL
+
+	if cfg.DBURL != "" {
+		var err error
+		if storeURL, err = url.Parse(cfg.DBURL); err != nil {
+			return errors.NewConfigurationError("invalid blockchain store URL", err)
+		}
+	}
+
+	if storeURL == nil {
+		return errors.NewConfigurationError("Store URL not configured in settings")
+	}
+
+	db, err := util.InitSQLDB(logger, storeURL, tSettings)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	migrator, err := sql.NewMigrator(logger, db, util.SQLEngine(storeURL.Scheme))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch {
+	case cfg.ClearDirty > 0:
+		if !confirmDangerousAction("clear-dirty") {
+			return errors.NewProcessingError("clearing the dirty state cancelled")
+		}
+
+		if err = migrator.ClearDirty(ctx, uint32(cfg.ClearDirty), cfg.KeepApplied); err != nil {
+			return err
+		}
+	case cfg.To >= 0:
+		version, _, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+
+		if uint32(cfg.To) < version && !confirmDangerousAction("rollback") {
+			return errors.NewProcessingError("rollback cancelled")
+		}
+
+		if err = migrator.MigrateTo(ctx, uint32(cfg.To)); err != nil {
+			return err
+		}
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED\tDIRTY\tNOTE")
+
+	for _, status := range statuses {
+		applied := "no"
+		if status.Applied {
+			applied = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+
+		note := ""
+
+		switch {
+		case !status.Known:
+			note = "unknown by this binary"
+		case status.Modified:
+			note = "modified after it was applied"
+		}
+
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%t\t%s\n", status.Version, status.Name, applied, status.Dirty, note)
+	}
+
+	return w.Flush()
+}
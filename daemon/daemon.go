@@ -36,6 +36,7 @@ import (
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
 	"github.com/bsv-blockchain/teranode/util/servicemanager"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/ordishs/gocore"
@@ -47,6 +48,8 @@ var (
 	metricsRegistered atomic.Bool
 	pprofRegistered   atomic.Bool
 	traceCloser       io.Closer
+
+	retryStatsRegistered atomic.Bool
 )
 
 const (
@@ -90,6 +93,8 @@ const (
 	serviceBlockValidation         = "blockvalidation"
 	serviceBlockValidationFormal   = "BlockValidation"
 	serviceBlockchainFormal        = "Blockchain"
+	serviceCanary                  = "canary"
+	serviceCanaryFormal            = "Canary"
 	serviceHelp                    = "help"
 	serviceLegacy                  = "legacy"
 	serviceLegacyFormal            = "Legacy"
@@ -299,6 +304,8 @@ func (d *Daemon) Start(logger ulogger.Logger, args []string, appSettings *settin
 		logger.Errorf("error starting services: %v", err)
 		sm.ForceShutdown()
 		d.closeDoneOnce.Do(func() { close(d.doneCh) })
+	} else {
+		logger.Infof("\n%s", buildinfo.Get(appSettings).Banner())
 	}
 
 	util.RegisterPrometheusMetrics()
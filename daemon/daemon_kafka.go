@@ -139,6 +139,18 @@ func getKafkaRejectedTxConsumerGroup(logger ulogger.Logger, settings *settings.S
 	return getKafkaConsumerGroup(logger, kafkaRejectedTxConfig, consumerGroupID, true, &settings.Kafka)
 }
 
+// getKafkaDoubleSpendsConsumerGroup creates a new Kafka consumer group for double spends using the configuration from
+// settings, returning nil when no double spends topic is configured.
+func getKafkaDoubleSpendsConsumerGroup(logger ulogger.Logger, settings *settings.Settings,
+	consumerGroupID string) (kafka.KafkaConsumerGroupI, error) {
+	kafkaDoubleSpendsConfig := settings.Kafka.DoubleSpendsConfig
+	if kafkaDoubleSpendsConfig == nil {
+		return nil, nil
+	}
+
+	return getKafkaConsumerGroup(logger, kafkaDoubleSpendsConfig, consumerGroupID, true, &settings.Kafka)
+}
+
 // getKafkaSubtreesConsumerGroup creates a new Kafka consumer group for subtrees using the configuration from settings.
 func getKafkaSubtreesConsumerGroup(logger ulogger.Logger, settings *settings.Settings,
 	consumerGroupID string) (*kafka.KafkaConsumerGroup, error) {
@@ -147,7 +159,18 @@ func getKafkaSubtreesConsumerGroup(logger ulogger.Logger, settings *settings.Set
 		return nil, errors.NewConfigurationError("missing Kafka URL for subtrees consumer - subtreesConfig")
 	}
 
-	return getKafkaConsumerGroup(logger, kafkaSubtreesConfig, consumerGroupID, true, &settings.Kafka)
+	// with the processed strategy offsets are only marked once the subtree message has been processed
+	autoCommit := true
+
+	switch settings.SubtreeValidation.KafkaCommitStrategy {
+	case "", "auto":
+	case "processed":
+		autoCommit = false
+	default:
+		return nil, errors.NewConfigurationError("invalid subtreevalidation_kafka_commit_strategy %s, expected auto or processed", settings.SubtreeValidation.KafkaCommitStrategy)
+	}
+
+	return getKafkaConsumerGroup(logger, kafkaSubtreesConfig, consumerGroupID, autoCommit, &settings.Kafka)
 }
 
 // getKafkaTxConsumerGroup creates a new Kafka consumer group for validator transactions using the configuration from gocore.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/pprof"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/bsv-blockchain/teranode/services/blockchain"
 	"github.com/bsv-blockchain/teranode/services/blockpersister"
 	"github.com/bsv-blockchain/teranode/services/blockvalidation"
+	"github.com/bsv-blockchain/teranode/services/canary"
 	"github.com/bsv-blockchain/teranode/services/legacy"
 	"github.com/bsv-blockchain/teranode/services/legacy/peer"
 	"github.com/bsv-blockchain/teranode/services/p2p"
@@ -28,11 +30,17 @@ import (
 	blockchainstore "github.com/bsv-blockchain/teranode/stores/blockchain"
 	"github.com/bsv-blockchain/teranode/stores/utxo"
 	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/blocktrace"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
+	"github.com/bsv-blockchain/teranode/util/jobs"
 	"github.com/bsv-blockchain/teranode/util/kafka"
+	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
+	"github.com/bsv-blockchain/teranode/util/retry"
 	"github.com/bsv-blockchain/teranode/util/servicemanager"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/felixge/fgprof"
 	"github.com/ordishs/gocore"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -102,6 +110,13 @@ func (d *Daemon) startServices(ctx context.Context, logger ulogger.Logger, appSe
 		tracing.SetTracingEnabled(false)
 	}
 
+	// check the Kafka message schemas before any service produces or consumes a message
+	if err := kafkamessage.Configure(appSettings.Kafka.MessageVersion); err != nil {
+		return err
+	}
+
+	logger.Infof("Producing Kafka messages at version %d", appSettings.Kafka.MessageVersion)
+
 	// Create a slice of service starters
 	starters := []serviceStarter{
 		{startBlockchain, func() error { return d.startBlockchainService(ctx, appSettings, args, createLogger) }},
@@ -117,6 +132,8 @@ func (d *Daemon) startServices(ctx context.Context, logger ulogger.Logger, appSe
 		{startValidator, func() error { return d.startValidatorService(ctx, appSettings, createLogger) }},
 		{startPropagation, func() error { return d.startPropagationService(ctx, appSettings, createLogger) }},
 		{startLegacy, func() error { return d.startLegacyService(ctx, appSettings, createLogger) }},
+		// the canary comparison runs next to the blockchain service, whose chain it compares
+		{startBlockchain && appSettings.Canary.Enabled, func() error { return d.startCanaryService(ctx, appSettings, createLogger) }},
 	}
 
 	// Loop through and start each service if needed
@@ -181,6 +198,15 @@ func startProfilerAndMetrics(logger ulogger.Logger, appSettings *settings.Settin
 
 			mux.Handle("/debug/fgprof", fgprof.Handler())
 
+			// effective retry policies and live retry counters per call site
+			mux.HandleFunc("/debug/retries", retry.StatsHandler)
+
+			// processing timeline of the blocks seen recently, by block hash or lifecycle ID
+			mux.HandleFunc("/debug/blocktrace", blocktrace.TimelineHandler)
+
+			// last and next run of the maintenance jobs of the services
+			mux.HandleFunc("/debug/jobs", jobs.StatusHandler)
+
 			if appSettings.StatsPrefix != "" {
 				gocore.RegisterStatsHandlers(mux)
 			}
@@ -189,7 +215,7 @@ func startProfilerAndMetrics(logger ulogger.Logger, appSettings *settings.Settin
 			if prometheusEndpoint != "" && !metricsRegistered.Load() {
 				metricsRegistered.Store(true)
 				logger.Infof("Starting prometheus endpoint on %s", prometheusEndpoint)
-				mux.Handle(prometheusEndpoint, promhttp.Handler())
+				mux.Handle(prometheusEndpoint, metricsHandler())
 			}
 
 			// add mux to the server
@@ -202,16 +228,30 @@ func startProfilerAndMetrics(logger ulogger.Logger, appSettings *settings.Settin
 			gocore.RegisterStatsHandlers()
 		}
 
+		if !retryStatsRegistered.Load() {
+			retryStatsRegistered.Store(true)
+			http.HandleFunc("/debug/retries", retry.StatsHandler)
+			http.HandleFunc("/debug/blocktrace", blocktrace.TimelineHandler)
+			http.HandleFunc("/debug/jobs", jobs.StatusHandler)
+		}
+
 		// start prometheus metrics endpoint if enabled
 		prometheusEndpoint := appSettings.PrometheusEndpoint
 		if prometheusEndpoint != "" && !metricsRegistered.Load() {
 			metricsRegistered.Store(true)
 			logger.Infof("Starting prometheus endpoint on %s", prometheusEndpoint)
-			http.Handle(prometheusEndpoint, promhttp.Handler())
+			http.Handle(prometheusEndpoint, metricsHandler())
 		}
 	}
 }
 
+// metricsHandler serves the prometheus metrics, in the OpenMetrics format to scrapers that accept it, which carries
+// the exemplars of the metrics, e.g. the lifecycle IDs of the block stage histograms
+func metricsHandler() http.Handler {
+	return promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}))
+}
+
 // startBlockchainService initializes and starts the Blockchain service.
 func (d *Daemon) startBlockchainService(ctx context.Context, appSettings *settings.Settings,
 	args []string, createLogger func(string) ulogger.Logger) error {
@@ -303,6 +343,11 @@ func (d *Daemon) startP2PService(ctx context.Context, appSettings *settings.Sett
 		return err
 	}
 
+	doubleSpendsKafkaConsumerClient, err := getKafkaDoubleSpendsConsumerGroup(createLogger("kpds"), appSettings, serviceNameP2P+"."+appSettings.ClientName)
+	if err != nil {
+		return err
+	}
+
 	// Create Kafka producers for subtrees and blocks
 	var subtreeKafkaProducerClient *kafka.KafkaAsyncProducer
 
@@ -335,6 +380,7 @@ func (d *Daemon) startP2PService(ctx context.Context, appSettings *settings.Sett
 		rejectedTxKafkaConsumerClient,
 		invalidBlocksKafkaConsumerClient,
 		invalidSubtreeKafkaConsumerClient,
+		doubleSpendsKafkaConsumerClient,
 		subtreeKafkaProducerClient,
 		blocksKafkaProducerClient,
 	)
@@ -342,6 +388,8 @@ func (d *Daemon) startP2PService(ctx context.Context, appSettings *settings.Sett
 		return err
 	}
 
+	buildinfo.SetProtocolVersion(serviceNameP2PFormal, "bitcoin", p2p.BitcoinProtocolID(appSettings.ChainCfgParams.Name))
+
 	return d.ServiceManager.AddService(serviceNameP2PFormal, p2pService)
 }
 
@@ -542,6 +590,8 @@ func (d *Daemon) startAlertService(ctx context.Context, appSettings *settings.Se
 		return err
 	}
 
+	buildinfo.SetProtocolVersion(serviceAlertFormal, "alert", appSettings.Alert.ProtocolID)
+
 	// Create the Alert service with the necessary parts
 	return d.ServiceManager.AddService(serviceAlertFormal, alert.New(
 		createLogger(serviceAlert),
@@ -600,6 +650,25 @@ func (d *Daemon) startBlockPersisterService(ctx context.Context, appSettings *se
 	))
 }
 
+// startCanaryService initializes and adds the Canary service to the ServiceManager.
+func (d *Daemon) startCanaryService(ctx context.Context, appSettings *settings.Settings,
+	createLogger func(string) ulogger.Logger) error {
+	// Create the blockchain client for the Canary service
+	blockchainClient, err := d.daemonStores.GetBlockchainClient(
+		ctx, createLogger(loggerBlockchainClient), appSettings, serviceCanary,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Add the Canary service to the ServiceManager
+	return d.ServiceManager.AddService(serviceCanaryFormal, canary.New(
+		createLogger(serviceCanary),
+		appSettings,
+		blockchainClient,
+	))
+}
+
 // startUTXOPersisterService initializes and adds the UTXOPersister service to the ServiceManager.
 func (d *Daemon) startUTXOPersisterService(ctx context.Context, appSettings *settings.Settings,
 	createLogger func(string) ulogger.Logger) error {
@@ -1080,6 +1149,8 @@ func (d *Daemon) startLegacyService(
 		return err
 	}
 
+	buildinfo.SetProtocolVersion(serviceLegacyFormal, "wire", strconv.FormatUint(uint64(peer.MaxProtocolVersion), 10))
+
 	// Add the Legacy service to the ServiceManager
 	return d.ServiceManager.AddService(serviceLegacyFormal, legacy.New(
 		createLogger(serviceLegacy),
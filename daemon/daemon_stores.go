@@ -85,9 +85,9 @@ func (d *Stores) GetBlockValidationClient(ctx context.Context, logger ulogger.Lo
 	return d.mainBlockValidationClient, err
 }
 
-// GetP2PClient creates and returns a new P2P client instance. Unlike other store getters, this function
-// always creates a new client instance to maintain source information. The source parameter
-// identifies the origin or purpose of the client.
+// GetP2PClient returns the P2P client shared by all services of the daemon, creating it on first use.
+// The client keeps its single connection to the P2P service up in the background, reconnecting with
+// an exponential backoff, so services asking for it while P2P is briefly down are not failed.
 //
 // Parameters:
 //   - ctx: The context for managing the client's lifecycle.
@@ -95,7 +95,7 @@ func (d *Stores) GetBlockValidationClient(ctx context.Context, logger ulogger.Lo
 //   - appSettings: The application settings containing configuration details.
 //
 // Returns:
-//   - p2p.ClientI: The newly created P2P client instance.
+//   - p2p.ClientI: The shared P2P client instance.
 //   - error: An error object if the client creation fails; otherwise, nil.
 func (d *Stores) GetP2PClient(ctx context.Context, logger ulogger.Logger, appSettings *settings.Settings) (p2p.ClientI, error) {
 	if d.mainP2PClient != nil {
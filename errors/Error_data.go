@@ -64,6 +64,14 @@ func GetErrorData(code ERR, dataBytes []byte) (ErrDataI, error) {
 			return errData, err
 		}
 
+	case ERR_NETWORK_PEER_ATTRIBUTABLE:
+		errData = &PeerErrData{}
+
+		err := json.Unmarshal(dataBytes, errData)
+		if err != nil {
+			return errData, err
+		}
+
 	default:
 		// get generic error data
 		errData = &ErrData{}
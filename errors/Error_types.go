@@ -66,6 +66,8 @@ var (
 	ErrNetworkConnectionRefused   = New(ERR_NETWORK_CONNECTION_REFUSED, "network connection refused")
 	ErrNetworkInvalidResponse     = New(ERR_NETWORK_INVALID_RESPONSE, "network invalid response")
 	ErrNetworkPeerMalicious       = New(ERR_NETWORK_PEER_MALICIOUS, "network peer malicious")
+	ErrNetworkPeerThrottled       = New(ERR_NETWORK_PEER_THROTTLED, "network peer throttled")
+	ErrNetworkPeerAttributable    = New(ERR_NETWORK_PEER_ATTRIBUTABLE, "network peer attributable")
 )
 
 // NewUnknownError creates a new error with the unknown error code.
@@ -341,3 +343,8 @@ func NewNetworkInvalidResponseError(message string, params ...interface{}) *Erro
 func NewNetworkPeerMaliciousError(message string, params ...interface{}) *Error {
 	return New(ERR_NETWORK_PEER_MALICIOUS, message, params...)
 }
+
+// NewNetworkPeerThrottledError creates a new error with the network peer throttled error code.
+func NewNetworkPeerThrottledError(message string, params ...interface{}) *Error {
+	return New(ERR_NETWORK_PEER_THROTTLED, message, params...)
+}
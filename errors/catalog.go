@@ -0,0 +1,198 @@
+package errors
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// CatalogEntry describes an error code in the error catalog. The numeric code and the name are stable: codes are
+// never renumbered or reused, so API consumers can handle failures on the code instead of the error message.
+type CatalogEntry struct {
+	Code        int32  `json:"code"`
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+// codeDescriptions describes every error code of the ERR enum, TestCatalog fails when a code is not described
+var codeDescriptions = map[ERR]string{
+	ERR_UNKNOWN:            "An unknown error occurred",
+	ERR_INVALID_ARGUMENT:   "The request or one of its parameters is invalid",
+	ERR_THRESHOLD_EXCEEDED: "A limit was exceeded, the request can be retried later or with a smaller size",
+	ERR_NOT_FOUND:          "The requested item does not exist",
+	ERR_PROCESSING:         "The request could not be processed",
+	ERR_CONFIGURATION:      "The node is not configured to handle the request",
+	ERR_CONTEXT:            "The operation was aborted because its context ended",
+	ERR_CONTEXT_CANCELED:   "The operation was canceled, e.g. because the request timed out or the client disconnected",
+	ERR_EXTERNAL:           "An external dependency of the node failed",
+	ERR_ERROR:              "An error occurred that does not have a more specific code",
+
+	ERR_BLOCK_NOT_FOUND:               "The block does not exist",
+	ERR_BLOCK_INVALID:                 "The block is invalid",
+	ERR_BLOCK_EXISTS:                  "The block was already stored",
+	ERR_BLOCK_INVALID_FORMAT:          "The block could not be decoded",
+	ERR_BLOCK_PARENT_NOT_MINED:        "The parent of the block has not been mined yet",
+	ERR_BLOCK_COINBASE_MISSING_HEIGHT: "The coinbase of the block does not contain the block height",
+	ERR_BLOCK_ASSEMBLY_RESET:          "Block assembly was reset while the request was processed",
+	ERR_BLOCK_ERROR:                   "A block error occurred that does not have a more specific code",
+
+	ERR_SUBTREE_NOT_FOUND:         "The subtree does not exist",
+	ERR_SUBTREE_INVALID:           "The subtree is invalid",
+	ERR_SUBTREE_SERIALIZE_ERROR:   "The subtree could not be serialized",
+	ERR_SUBTREE_DESERIALIZE_ERROR: "The subtree could not be deserialized",
+	ERR_SUBTREE_INVALID_FORMAT:    "The subtree could not be decoded",
+	ERR_SUBTREE_EXISTS:            "The subtree was already stored",
+	ERR_SUBTREE_ERROR:             "A subtree error occurred that does not have a more specific code",
+
+	ERR_TX_NOT_FOUND:            "The transaction does not exist",
+	ERR_TX_INVALID:              "The transaction is invalid",
+	ERR_TX_INVALID_DOUBLE_SPEND: "The transaction spends an output that was already spent by another transaction",
+	ERR_TX_EXISTS:               "The transaction was already stored",
+	ERR_TX_MISSING_PARENT:       "A parent of the transaction is not known",
+	ERR_TX_LOCK_TIME:            "The lock time of the transaction has not been reached",
+	ERR_TX_CONFLICTING:          "The transaction conflicts with a transaction that was mined",
+	ERR_TX_LOCKED:               "The outputs spent by the transaction are locked",
+	ERR_TX_COINBASE_IMMATURE:    "The transaction spends a coinbase output that has not matured",
+	ERR_TX_POLICY:               "The transaction does not meet the policy of the node",
+	ERR_TX_CONSENSUS:            "The transaction does not meet the consensus rules",
+	ERR_TX_CREATING:             "The transaction is being created by another request",
+	ERR_TX_ERROR:                "A transaction error occurred that does not have a more specific code",
+
+	ERR_SERVICE_UNAVAILABLE: "A service of the node is unavailable, the request can be retried later",
+	ERR_SERVICE_NOT_STARTED: "A service of the node has not started yet, the request can be retried later",
+	ERR_SERVICE_ERROR:       "A service error occurred that does not have a more specific code",
+
+	ERR_STORAGE_UNAVAILABLE: "A store of the node is unavailable, the request can be retried later",
+	ERR_STORAGE_NOT_STARTED: "A store of the node has not started yet, the request can be retried later",
+	ERR_STORAGE_ERROR:       "A storage error occurred that does not have a more specific code",
+
+	ERR_UTXO_SPENT:        "The output was already spent",
+	ERR_UTXO_NON_FINAL:    "The output cannot be spent yet",
+	ERR_UTXO_FROZEN:       "The output is frozen",
+	ERR_UTXO_NOT_FOUND:    "The output does not exist",
+	ERR_UTXO_MISMATCH:     "The output does not match the spending request",
+	ERR_UTXO_INVALID_SIZE: "The output has an invalid size",
+	ERR_UTXO_ERROR:        "A UTXO error occurred that does not have a more specific code",
+
+	ERR_KAFKA_DECODE_ERROR: "A Kafka message could not be decoded",
+	ERR_KAFKA_ERROR:        "A Kafka error occurred that does not have a more specific code",
+
+	ERR_BLOB_EXISTS:               "The blob was already stored",
+	ERR_BLOB_NOT_FOUND:            "The blob does not exist",
+	ERR_BLOB_FOOTER_SIZE_MISMATCH: "The footer of the blob does not match its size",
+	ERR_BLOB_ERROR:                "A blob error occurred that does not have a more specific code",
+
+	ERR_STATE_INITIALIZATION: "The node is initializing, the request can be retried later",
+	ERR_CATCHUP_IN_PROGRESS:  "The node is catching up with the network, the request can be retried later",
+	ERR_STATE_ERROR:          "A state error occurred that does not have a more specific code",
+
+	ERR_NETWORK_ERROR:              "A network error occurred that does not have a more specific code",
+	ERR_INVALID_SUBNET:             "The subnet is invalid",
+	ERR_INVALID_IP:                 "The IP address is invalid",
+	ERR_NETWORK_TIMEOUT:            "A network request timed out",
+	ERR_NETWORK_CONNECTION_REFUSED: "A network connection was refused",
+	ERR_NETWORK_INVALID_RESPONSE:   "A peer returned an invalid response",
+	ERR_NETWORK_PEER_MALICIOUS:     "A peer returned data that was fabricated or otherwise malicious",
+	ERR_NETWORK_PEER_THROTTLED:     "The node served its limit of catchup data to the peer, the request can be retried after the Retry-After delay or with another node",
+	ERR_NETWORK_PEER_ATTRIBUTABLE:  "The data received from a peer was rejected, the peer is held responsible for the failure",
+}
+
+// Description returns the description of the error code in the error catalog
+func (x ERR) Description() string {
+	if description, ok := codeDescriptions[x]; ok {
+		return description
+	}
+
+	return codeDescriptions[ERR_UNKNOWN]
+}
+
+// Category returns the category of the error code, derived from the range the code is in
+func (x ERR) Category() string {
+	switch {
+	case x >= 0 && x <= 9:
+		return "general"
+	case x >= 10 && x <= 19:
+		return "block"
+	case x >= 20 && x <= 29:
+		return "subtree"
+	case x >= 30 && x <= 49:
+		return "transaction"
+	case x >= 50 && x <= 59:
+		return "service"
+	case x >= 60 && x <= 69:
+		return "storage"
+	case x >= 70 && x <= 79:
+		return "utxo"
+	case x >= 80 && x <= 89:
+		return "kafka"
+	case x >= 90 && x <= 99:
+		return "blob"
+	case x >= 100 && x <= 109:
+		return "state"
+	case x >= 110 && x <= 119:
+		return "network"
+	default:
+		return "unknown"
+	}
+}
+
+// Catalog returns all error codes of the ERR enum, ordered by code
+func Catalog() []CatalogEntry {
+	entries := make([]CatalogEntry, 0, len(ERR_name))
+
+	for code, name := range ERR_name {
+		entries = append(entries, CatalogEntry{
+			Code:        code,
+			Name:        name,
+			Category:    ERR(code).Category(),
+			Description: ERR(code).Description(),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Code < entries[j].Code
+	})
+
+	return entries
+}
+
+// CodeOf returns the code of err, ERR_UNKNOWN when err is not an *Error
+func CodeOf(err error) ERR {
+	var tErr *Error
+	if As(err, &tErr) && tErr != nil {
+		return tErr.Code()
+	}
+
+	return ERR_UNKNOWN
+}
+
+// codeMessagePattern matches the code an error message starts with, "NAME (code): " as formatted by Error,
+// or "code: " when the error carries data
+var codeMessagePattern = regexp.MustCompile(`^(?:([A-Z_]+) \((\d+)\)|(\d+)):`)
+
+// ParseCode returns the code a message formatted by Error starts with, e.g. an error message returned by a service
+// as a string. ok is false when the message does not start with a known code.
+func ParseCode(message string) (code ERR, ok bool) {
+	match := codeMessagePattern.FindStringSubmatch(message)
+	if match == nil {
+		return ERR_UNKNOWN, false
+	}
+
+	number := match[2]
+	if number == "" {
+		number = match[3]
+	}
+
+	n, err := strconv.ParseInt(number, 10, 32)
+	if err != nil {
+		return ERR_UNKNOWN, false
+	}
+
+	name, known := ERR_name[int32(n)]
+	if !known || (match[1] != "" && match[1] != name) {
+		return ERR_UNKNOWN, false
+	}
+
+	return ERR(n), true
+}
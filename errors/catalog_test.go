@@ -0,0 +1,92 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+func TestCatalog(t *testing.T) {
+	catalog := Catalog()
+	require.Len(t, catalog, len(ERR_name))
+
+	for i, entry := range catalog {
+		_, described := codeDescriptions[ERR(entry.Code)]
+		assert.True(t, described, "error code %s (%d) has no description", entry.Name, entry.Code)
+
+		assert.Equal(t, ERR_name[entry.Code], entry.Name)
+		assert.NotEqual(t, "unknown", entry.Category, "error code %s (%d) is outside the code ranges", entry.Name, entry.Code)
+
+		if i > 0 {
+			assert.Less(t, catalog[i-1].Code, entry.Code)
+		}
+	}
+
+	// codes are stable, API consumers rely on them
+	assert.Equal(t, CatalogEntry{
+		Code:        3,
+		Name:        "NOT_FOUND",
+		Category:    "general",
+		Description: "The requested item does not exist",
+	}, catalog[3])
+}
+
+func TestParseCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		code    ERR
+		ok      bool
+	}{
+		{name: "error message", message: NewBlockNotFoundError("block not found").Error(), code: ERR_BLOCK_NOT_FOUND, ok: true},
+		{name: "wrapped error message", message: NewServiceError("failed", NewStorageError("store down")).Error(), code: ERR_SERVICE_ERROR, ok: true},
+		{name: "error message with data", message: "70: utxo already spent \"data\"", code: ERR_UTXO_SPENT, ok: true},
+		{name: "name does not match the code", message: "NOT_FOUND (4): item not found", ok: false},
+		{name: "unknown code", message: "BOGUS (999): bogus", ok: false},
+		{name: "plain message", message: "something failed", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := ParseCode(tt.message)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	assert.Equal(t, ERR_TX_INVALID, CodeOf(NewTxInvalidError("invalid")))
+	assert.Equal(t, ERR_UNKNOWN, CodeOf(New(ERR_UNKNOWN, "unknown")))
+	assert.Equal(t, ERR_UNKNOWN, CodeOf(nil))
+}
+
+func TestWrapGRPC_ErrorInfo(t *testing.T) {
+	err := WrapGRPC(NewTxPolicyError("fee too low", NewProcessingError("processing")))
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+
+	var info *errdetails.ErrorInfo
+
+	for _, detail := range st.Details() {
+		if errorInfo, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = errorInfo
+		}
+	}
+
+	require.NotNil(t, info)
+	assert.Equal(t, "TX_POLICY", info.Reason)
+	assert.Equal(t, "teranode", info.Domain)
+	assert.Equal(t, "39", info.Metadata["code"])
+
+	// the error info does not change the unwrapped error
+	unwrapped := UnwrapGRPC(err)
+	require.NotNil(t, unwrapped)
+	assert.Equal(t, ERR_TX_POLICY, unwrapped.Code())
+	require.NotNil(t, unwrapped.WrappedErr())
+	assert.True(t, Is(unwrapped, ErrProcessing))
+}
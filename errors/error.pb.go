@@ -102,6 +102,8 @@ const (
 	ERR_NETWORK_CONNECTION_REFUSED ERR = 114
 	ERR_NETWORK_INVALID_RESPONSE   ERR = 115
 	ERR_NETWORK_PEER_MALICIOUS     ERR = 116
+	ERR_NETWORK_PEER_THROTTLED     ERR = 117
+	ERR_NETWORK_PEER_ATTRIBUTABLE  ERR = 118
 )
 
 // Enum value maps for ERR.
@@ -174,6 +176,8 @@ var (
 		114: "NETWORK_CONNECTION_REFUSED",
 		115: "NETWORK_INVALID_RESPONSE",
 		116: "NETWORK_PEER_MALICIOUS",
+		117: "NETWORK_PEER_THROTTLED",
+		118: "NETWORK_PEER_ATTRIBUTABLE",
 	}
 	ERR_value = map[string]int32{
 		"UNKNOWN":                       0,
@@ -243,6 +247,8 @@ var (
 		"NETWORK_CONNECTION_REFUSED":    114,
 		"NETWORK_INVALID_RESPONSE":      115,
 		"NETWORK_PEER_MALICIOUS":        116,
+		"NETWORK_PEER_THROTTLED":        117,
+		"NETWORK_PEER_ATTRIBUTABLE":     118,
 	}
 )
 
@@ -377,8 +383,7 @@ const file_errors_error_proto_rawDesc = "" +
 	"\fwrappedError\x18\x04 \x01(\v2\x0e.errors.TErrorR\fwrappedError\x12\x12\n" +
 	"\x04file\x18\x05 \x01(\tR\x04file\x12\x12\n" +
 	"\x04line\x18\x06 \x01(\x05R\x04line\x12\x1a\n" +
-	"\bfunction\x18\a \x01(\tR\bfunction*\xff\n" +
-	"\n" +
+	"\bfunction\x18\a \x01(\tR\bfunction*\xba\v\n" +
 	"\x03ERR\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\x14\n" +
 	"\x10INVALID_ARGUMENT\x10\x01\x12\x16\n" +
@@ -453,7 +458,9 @@ const file_errors_error_proto_rawDesc = "" +
 	"\x0fNETWORK_TIMEOUT\x10q\x12\x1e\n" +
 	"\x1aNETWORK_CONNECTION_REFUSED\x10r\x12\x1c\n" +
 	"\x18NETWORK_INVALID_RESPONSE\x10s\x12\x1a\n" +
-	"\x16NETWORK_PEER_MALICIOUS\x10tB+Z)github.com/bsv-blockchain/teranode/errorsb\x06proto3"
+	"\x16NETWORK_PEER_MALICIOUS\x10t\x12\x1a\n" +
+	"\x16NETWORK_PEER_THROTTLED\x10u\x12\x1d\n" +
+	"\x19NETWORK_PEER_ATTRIBUTABLE\x10vB+Z)github.com/bsv-blockchain/teranode/errorsb\x06proto3"
 
 var (
 	file_errors_error_proto_rawDescOnce sync.Once
@@ -0,0 +1,120 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PeerSeverity is the severity of a failure caused by data received from a peer.
+type PeerSeverity string
+
+const (
+	// PeerSeverityLow is a failure that an honest peer can cause, e.g. by relaying stale data.
+	PeerSeverityLow PeerSeverity = "low"
+
+	// PeerSeverityMedium is a failure caused by data that an honest peer should not have sent, e.g. a bogus block announcement.
+	PeerSeverityMedium PeerSeverity = "medium"
+
+	// PeerSeverityHigh is a failure caused by data that violates the consensus rules, e.g. an invalid transaction.
+	PeerSeverityHigh PeerSeverity = "high"
+
+	// PeerSeverityCritical is a failure caused by data that was fabricated to attack the node, e.g. a fake chain.
+	PeerSeverityCritical PeerSeverity = "critical"
+)
+
+// peerSeverityBanScores are the ban-score deltas suggested for the peer severities, a critical failure reaches
+// the default ban threshold of 100 and bans the peer at once
+var peerSeverityBanScores = map[PeerSeverity]int{
+	PeerSeverityLow:      5,
+	PeerSeverityMedium:   10,
+	PeerSeverityHigh:     20,
+	PeerSeverityCritical: 100,
+}
+
+// BanScore returns the ban-score delta suggested for a failure of the severity, 0 for an unknown severity.
+func (s PeerSeverity) BanScore() int {
+	return peerSeverityBanScores[s]
+}
+
+// PeerErrData is the error data structure for peer-attributable errors: failures caused by data received from a
+// peer, which the peer is held responsible for in the P2P reputation system.
+type PeerErrData struct {
+	PeerID        string       `json:"peer_id"`
+	Severity      PeerSeverity `json:"severity"`
+	BanScoreDelta int          `json:"ban_score_delta"`
+}
+
+// SetData sets the data for the PeerErrData structure.
+func (e *PeerErrData) SetData(key string, value interface{}) {
+	switch key {
+	case "peer_id":
+		e.PeerID = value.(string)
+	case "severity":
+		e.Severity = value.(PeerSeverity)
+	case "ban_score_delta":
+		e.BanScoreDelta = value.(int)
+	}
+}
+
+// GetData retrieves the data for the PeerErrData structure based on the key.
+func (e *PeerErrData) GetData(key string) interface{} {
+	switch key {
+	case "peer_id":
+		return e.PeerID
+	case "severity":
+		return e.Severity
+	case "ban_score_delta":
+		return e.BanScoreDelta
+	}
+
+	return nil
+}
+
+// Error returns a string representation of the PeerErrData error.
+func (e *PeerErrData) Error() string {
+	return fmt.Sprintf("attributed to peer %s with %s severity, ban score %d", e.PeerID, e.Severity, e.BanScoreDelta)
+}
+
+// EncodeErrorData encodes the PeerErrData to a byte slice using JSON encoding.
+func (e *PeerErrData) EncodeErrorData() []byte {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
+
+// NewPeerAttributableError creates a new error attributing a failure to the peer the data came from, wrapping the
+// error of the failure given as last parameter. A banScoreDelta of 0 uses the ban score suggested for the severity.
+//
+// Call sites wrap the failure once, errors.Is still matches the wrapped error, and the service handling the error
+// reports it to the P2P service with the attribution returned by GetPeerAttribution.
+func NewPeerAttributableError(peerID string, severity PeerSeverity, banScoreDelta int, message string, params ...interface{}) *Error {
+	if banScoreDelta == 0 {
+		banScoreDelta = severity.BanScore()
+	}
+
+	peerError := New(ERR_NETWORK_PEER_ATTRIBUTABLE, message, params...)
+	peerError.data = &PeerErrData{
+		PeerID:        peerID,
+		Severity:      severity,
+		BanScoreDelta: banScoreDelta,
+	}
+
+	return peerError
+}
+
+// GetPeerAttribution returns the attribution of the first peer-attributable error in the chain of err, also when err
+// was returned by a gRPC service. ok is false when the failure is not attributed to a peer.
+func GetPeerAttribution(err error) (attribution *PeerErrData, ok bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	if !AsData(err, &attribution) || attribution == nil || attribution.PeerID == "" {
+		return nil, false
+	}
+
+	return attribution, true
+}
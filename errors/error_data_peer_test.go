@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPeerSeverity_BanScore tests the ban scores suggested for the peer severities.
+func TestPeerSeverity_BanScore(t *testing.T) {
+	assert.Equal(t, 5, PeerSeverityLow.BanScore())
+	assert.Equal(t, 10, PeerSeverityMedium.BanScore())
+	assert.Equal(t, 20, PeerSeverityHigh.BanScore())
+	assert.Equal(t, 100, PeerSeverityCritical.BanScore())
+	assert.Equal(t, 0, PeerSeverity("unknown").BanScore())
+}
+
+// TestPeerErrData tests the SetData, GetData and EncodeErrorData methods of the PeerErrData type.
+func TestPeerErrData(t *testing.T) {
+	var errData PeerErrData
+
+	errData.SetData("peer_id", "peer1")
+	errData.SetData("severity", PeerSeverityMedium)
+	errData.SetData("ban_score_delta", 15)
+	errData.SetData("nonexistent", "value")
+
+	assert.Equal(t, "peer1", errData.GetData("peer_id"))
+	assert.Equal(t, PeerSeverityMedium, errData.GetData("severity"))
+	assert.Equal(t, 15, errData.GetData("ban_score_delta"))
+	assert.Nil(t, errData.GetData("nonexistent"))
+	assert.Equal(t, "attributed to peer peer1 with medium severity, ban score 15", errData.Error())
+
+	var decoded PeerErrData
+	require.NoError(t, json.Unmarshal(errData.EncodeErrorData(), &decoded))
+	assert.Equal(t, errData, decoded)
+}
+
+// TestNewPeerAttributableError tests creating peer-attributable errors and reading their attribution.
+func TestNewPeerAttributableError(t *testing.T) {
+	t.Run("wraps the failure", func(t *testing.T) {
+		cause := NewBlockInvalidError("bad header")
+		err := NewPeerAttributableError("peer1", PeerSeverityMedium, 0, "bogus block %s", "abc", cause)
+
+		assert.Equal(t, ERR_NETWORK_PEER_ATTRIBUTABLE, err.Code())
+		assert.True(t, Is(err, ErrNetworkPeerAttributable))
+		assert.True(t, Is(err, ErrBlockInvalid))
+		assert.Contains(t, err.Error(), "bogus block abc")
+		assert.Contains(t, err.Error(), "bad header")
+
+		attribution, ok := GetPeerAttribution(err)
+		require.True(t, ok)
+		assert.Equal(t, &PeerErrData{PeerID: "peer1", Severity: PeerSeverityMedium, BanScoreDelta: 10}, attribution)
+	})
+
+	t.Run("custom ban score", func(t *testing.T) {
+		err := NewPeerAttributableError("peer1", PeerSeverityLow, 3, "stale data")
+
+		attribution, ok := GetPeerAttribution(err)
+		require.True(t, ok)
+		assert.Equal(t, 3, attribution.BanScoreDelta)
+	})
+
+	t.Run("attribution of a wrapped error", func(t *testing.T) {
+		err := NewServiceError("validation failed", NewPeerAttributableError("peer2", PeerSeverityHigh, 0, "invalid tx", NewTxInvalidError("invalid")))
+
+		attribution, ok := GetPeerAttribution(err)
+		require.True(t, ok)
+		assert.Equal(t, "peer2", attribution.PeerID)
+		assert.Equal(t, 20, attribution.BanScoreDelta)
+		assert.True(t, Is(err, ErrTxInvalid))
+	})
+
+	t.Run("attribution over gRPC", func(t *testing.T) {
+		err := WrapGRPC(NewProcessingError("failed", NewPeerAttributableError("peer3", PeerSeverityCritical, 0, "fake chain")))
+
+		attribution, ok := GetPeerAttribution(err)
+		require.True(t, ok)
+		assert.Equal(t, &PeerErrData{PeerID: "peer3", Severity: PeerSeverityCritical, BanScoreDelta: 100}, attribution)
+		assert.True(t, Is(err, ErrNetworkPeerAttributable))
+	})
+
+	t.Run("not attributed", func(t *testing.T) {
+		for _, err := range []error{
+			nil,
+			NewBlockInvalidError("bad header"),
+			fmt.Errorf("plain error"),
+			NewPeerAttributableError("", PeerSeverityHigh, 0, "no peer"),
+		} {
+			_, ok := GetPeerAttribution(err)
+			assert.False(t, ok, "%v", err)
+		}
+	})
+}
@@ -223,6 +223,12 @@ func TestGetErrorData(t *testing.T) {
 			input:      &UtxoSpentErrData{Hash: chainhash.Hash{}, Vout: 1},
 			expectType: &UtxoSpentErrData{},
 		},
+		{
+			name:       "valid_peer_data",
+			code:       ERR_NETWORK_PEER_ATTRIBUTABLE,
+			input:      &PeerErrData{PeerID: "peer", Severity: PeerSeverityHigh, BanScoreDelta: 20},
+			expectType: &PeerErrData{},
+		},
 		{
 			name:       "valid_generic_data",
 			code:       ERR(9999), // unknown code
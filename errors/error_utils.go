@@ -35,6 +35,9 @@ func IsRetryableError(err error) bool {
 			ERR_NETWORK_PEER_MALICIOUS:
 			// These are not retryable - indicates a problem with the peer
 			return false
+		case ERR_NETWORK_PEER_THROTTLED:
+			// Not retryable with the same peer until its serving limit resets, another peer should be used
+			return false
 		}
 	}
 
@@ -62,7 +65,8 @@ func IsNetworkError(err error) bool {
 			ERR_NETWORK_TIMEOUT,
 			ERR_NETWORK_CONNECTION_REFUSED,
 			ERR_NETWORK_INVALID_RESPONSE,
-			ERR_NETWORK_PEER_MALICIOUS:
+			ERR_NETWORK_PEER_MALICIOUS,
+			ERR_NETWORK_PEER_THROTTLED:
 			return true
 		}
 	}
@@ -164,7 +168,8 @@ func IsTemporaryError(err error) bool {
 	if As(err, &tErr) {
 		switch tErr.Code() {
 		case ERR_SERVICE_UNAVAILABLE,
-			ERR_STORAGE_UNAVAILABLE:
+			ERR_STORAGE_UNAVAILABLE,
+			ERR_NETWORK_PEER_THROTTLED:
 			return true
 		}
 	}
@@ -236,29 +241,9 @@ func GetErrorCategory(err error) string {
 
 	var tErr *Error
 	if As(err, &tErr) {
-		// Group by error code ranges
-		code := tErr.Code()
-		switch {
-		case code >= 10 && code <= 19:
-			return "block"
-		case code >= 20 && code <= 29:
-			return "subtree"
-		case code >= 30 && code <= 49:
-			return "transaction"
-		case code >= 50 && code <= 59:
-			return "service"
-		case code >= 60 && code <= 69:
-			return "storage"
-		case code >= 70 && code <= 79:
-			return "utxo"
-		case code >= 80 && code <= 89:
-			return "kafka"
-		case code >= 90 && code <= 99:
-			return "blob"
-		case code >= 100 && code <= 109:
-			return "state"
-		case code >= 110 && code <= 119:
-			return "network"
+		// Group by error code ranges, the general codes are reported as unknown
+		if category := tErr.Code().Category(); category != "general" {
+			return category
 		}
 	}
 
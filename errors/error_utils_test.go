@@ -49,6 +49,11 @@ func TestIsRetryableError(t *testing.T) {
 			err:      NewNetworkInvalidResponseError("malformed response"),
 			expected: false,
 		},
+		{
+			name:     "throttled peer - not retryable",
+			err:      NewNetworkPeerThrottledError("catchup serving limit reached"),
+			expected: false,
+		},
 		{
 			name:     "context canceled - not retryable",
 			err:      context.Canceled,
@@ -95,6 +100,11 @@ func TestIsNetworkError(t *testing.T) {
 			err:      NewNetworkPeerMaliciousError("malicious"),
 			expected: true,
 		},
+		{
+			name:     "network peer throttled",
+			err:      NewNetworkPeerThrottledError("throttled"),
+			expected: true,
+		},
 		{
 			name:     "generic network error",
 			err:      NewNetworkError("network error"),
@@ -15,9 +15,11 @@ import (
 	"fmt"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -554,6 +556,9 @@ func WrapGRPC(err error) error {
 			}
 		}
 
+		// the code of the error as a standard ErrorInfo detail, readable by gRPC clients without the TError message
+		wrappedErrDetails = append(wrappedErrDetails, errorInfo(castedErr.code))
+
 		st := status.New(ErrorCodeToGRPCCode(castedErr.code), castedErr.message)
 		st, detailsErr := st.WithDetails(wrappedErrDetails...)
 
@@ -600,6 +605,19 @@ func WrapGRPC(err error) error {
 	}
 }
 
+// errorInfoDomain is the domain of the ErrorInfo details of the gRPC errors of teranode
+const errorInfoDomain = "teranode"
+
+// errorInfo returns the ErrorInfo detail of a gRPC error for code, the reason is the name of the code and the
+// numeric code is in the "code" metadata, see Catalog
+func errorInfo(code ERR) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{
+		Reason:   code.String(),
+		Domain:   errorInfoDomain,
+		Metadata: map[string]string{"code": strconv.Itoa(int(code))},
+	}
+}
+
 // UnwrapGRPC unwraps a gRPC error and returns the underlying Error type.
 func UnwrapGRPC(err error) *Error {
 	if err == nil {
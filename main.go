@@ -13,15 +13,20 @@ import (
 // Name used by build script for the binaries. (Please keep on single line)
 const progname = "teranode"
 
-// Version & commit strings injected at build with -ldflags -X...
+// Version, commit & build date strings injected at build with -ldflags -X...
 var version string
 var commit string
+var buildDate string
 
 func init() {
 	// If version and commit are empty (running via go run), populate them at runtime
 	if version == "" && commit == "" {
 		populateVersionInfo()
 	}
+
+	if buildDate == "" {
+		buildDate = time.Now().UTC().Format(time.RFC3339)
+	}
 }
 
 func populateVersionInfo() {
@@ -74,5 +79,5 @@ func main() {
 	}
 
 	// If not showing version, run the daemon
-	teranode.RunDaemon(progname, version, commit)
+	teranode.RunDaemon(progname, version, commit, buildDate)
 }
@@ -0,0 +1,222 @@
+package model
+
+import (
+	"encoding/binary"
+	mathbits "math/bits"
+	"strconv"
+	"strings"
+
+	base58 "github.com/bitcoin-sv/go-sdk/compat/base58" //nolint:depguard
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/bscript"
+	"github.com/bsv-blockchain/go-chaincfg"
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+const (
+	// CoinbaseTemplateTotalBasisPoints is the sum of the shares of the outputs of a coinbase template, 100%
+	CoinbaseTemplateTotalBasisPoints = 10_000
+
+	// MaxCoinbaseTemplateOutputs limits the number of payout outputs of a coinbase template
+	MaxCoinbaseTemplateOutputs = 100
+
+	// MaxCoinbaseMinerTagSize is the maximum size in bytes of the miner tag of a coinbase template,
+	// the standard data carrier size of an OP_RETURN output
+	MaxCoinbaseMinerTagSize = 80
+)
+
+// CoinbaseOutputTemplate is a payout address of a coinbase template and its share of the coinbase value
+type CoinbaseOutputTemplate struct {
+	Address     string // P2PKH or P2SH address of the network
+	BasisPoints uint32 // Share of the coinbase value in hundredths of a percent, 10000 is 100%
+}
+
+// CoinbaseTemplate describes the outputs of the coinbase transactions created by block assembly: the payout
+// addresses with their share of the coinbase value and an optional miner tag in an unspendable OP_RETURN output.
+type CoinbaseTemplate struct {
+	Outputs  []CoinbaseOutputTemplate
+	MinerTag string
+}
+
+// ParseCoinbaseTemplate parses the coinbase outputs setting, a list of address:percentage entries with a
+// percentage of up to 2 decimals, e.g. 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa:62.5, and the miner tag.
+// Returns nil when no outputs and no miner tag are configured.
+func ParseCoinbaseTemplate(outputs []string, minerTag string) (*CoinbaseTemplate, error) {
+	if len(outputs) == 0 && minerTag == "" {
+		return nil, nil
+	}
+
+	template := &CoinbaseTemplate{
+		Outputs:  make([]CoinbaseOutputTemplate, 0, len(outputs)),
+		MinerTag: minerTag,
+	}
+
+	for _, output := range outputs {
+		address, percentage, found := strings.Cut(strings.TrimSpace(output), ":")
+		if !found {
+			return nil, errors.NewConfigurationError("coinbase output %q is not of the form address:percentage", output)
+		}
+
+		basisPoints, err := parseBasisPoints(strings.TrimSpace(percentage))
+		if err != nil {
+			return nil, errors.NewConfigurationError("invalid percentage of coinbase output %q", output, err)
+		}
+
+		template.Outputs = append(template.Outputs, CoinbaseOutputTemplate{
+			Address:     strings.TrimSpace(address),
+			BasisPoints: basisPoints,
+		})
+	}
+
+	return template, nil
+}
+
+// parseBasisPoints parses a percentage with up to 2 decimals into basis points
+func parseBasisPoints(percentage string) (uint32, error) {
+	whole, fraction, _ := strings.Cut(percentage, ".")
+
+	if len(fraction) > 2 {
+		return 0, errors.NewInvalidArgumentError("percentage %s has more than 2 decimals", percentage)
+	}
+
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	wholeValue, err := strconv.ParseUint(whole, 10, 32)
+	if err != nil {
+		return 0, errors.NewInvalidArgumentError("invalid percentage %s", percentage, err)
+	}
+
+	fractionValue, err := strconv.ParseUint(fraction, 10, 32)
+	if err != nil {
+		return 0, errors.NewInvalidArgumentError("invalid percentage %s", percentage, err)
+	}
+
+	if wholeValue > 100 {
+		return 0, errors.NewInvalidArgumentError("percentage %s exceeds 100", percentage)
+	}
+
+	return uint32(wholeValue*100 + fractionValue), nil //nolint:gosec // at most 10099
+}
+
+// Validate checks the template can be used for the coinbase transactions of the network: the outputs pay to
+// distinct P2PKH or P2SH addresses of the network, each output has a share of the coinbase value and the shares
+// add up to exactly 100%, so the coinbase never claims more than the block reward. The miner tag must fit in a
+// standard OP_RETURN output.
+func (t *CoinbaseTemplate) Validate(params *chaincfg.Params) error {
+	if len(t.Outputs) == 0 {
+		return errors.NewInvalidArgumentError("coinbase template has no outputs")
+	}
+
+	if len(t.Outputs) > MaxCoinbaseTemplateOutputs {
+		return errors.NewInvalidArgumentError("coinbase template has %d outputs, more than the maximum of %d", len(t.Outputs), MaxCoinbaseTemplateOutputs)
+	}
+
+	if len(t.MinerTag) > MaxCoinbaseMinerTagSize {
+		return errors.NewInvalidArgumentError("coinbase miner tag of %d bytes exceeds the maximum of %d bytes", len(t.MinerTag), MaxCoinbaseMinerTagSize)
+	}
+
+	var totalBasisPoints uint64
+
+	addresses := make(map[string]struct{}, len(t.Outputs))
+
+	for _, output := range t.Outputs {
+		if _, err := AddressToScript(output.Address); err != nil {
+			return errors.NewInvalidArgumentError("invalid coinbase output address %s", output.Address, err)
+		}
+
+		if params != nil {
+			decoded, _ := base58.Decode(output.Address)
+			if decoded[0] != params.LegacyPubKeyHashAddrID && decoded[0] != params.LegacyScriptHashAddrID {
+				return errors.NewInvalidArgumentError("coinbase output address %s is not an address of %s", output.Address, params.Name)
+			}
+		}
+
+		if _, ok := addresses[output.Address]; ok {
+			return errors.NewInvalidArgumentError("duplicate coinbase output address %s", output.Address)
+		}
+
+		addresses[output.Address] = struct{}{}
+
+		if output.BasisPoints == 0 {
+			return errors.NewInvalidArgumentError("coinbase output address %s has no share of the coinbase value", output.Address)
+		}
+
+		totalBasisPoints += uint64(output.BasisPoints)
+	}
+
+	if totalBasisPoints != CoinbaseTemplateTotalBasisPoints {
+		return errors.NewInvalidArgumentError("shares of the coinbase outputs add up to %d.%02d%%, must be 100%%", totalBasisPoints/100, totalBasisPoints%100)
+	}
+
+	return nil
+}
+
+// CreateCoinbaseFromTemplate creates a coinbase transaction paying the coinbase value to the outputs of the template
+// in proportion to their shares, with any rounding remainder added to the first output, followed by the miner tag
+// output when the template has a miner tag
+func CreateCoinbaseFromTemplate(height uint32, coinbaseValue uint64, arbitraryText string, template *CoinbaseTemplate) (*bt.Tx, error) {
+	if err := template.Validate(nil); err != nil {
+		return nil, errors.NewProcessingError("invalid coinbase template", err)
+	}
+
+	outputs, err := makeCoinbaseTemplateOutputs(coinbaseValue, template)
+	if err != nil {
+		return nil, errors.NewProcessingError("error creating coinbase transaction", err)
+	}
+
+	return newCoinbaseTx(makeCoinbase1(height, arbitraryText), makeCoinbase2(outputs))
+}
+
+// makeCoinbaseTemplateOutputs returns the serialized outputs of a coinbase created from a template, prefixed with
+// the number of outputs
+func makeCoinbaseTemplateOutputs(coinbaseValue uint64, template *CoinbaseTemplate) ([]byte, error) {
+	values := make([]uint64, len(template.Outputs))
+
+	var total uint64
+
+	for i, output := range template.Outputs {
+		// the product of a coinbase value and a share can exceed 64 bits, the quotient can not
+		hi, lo := mathbits.Mul64(coinbaseValue, uint64(output.BasisPoints))
+		values[i], _ = mathbits.Div64(hi, lo, CoinbaseTemplateTotalBasisPoints)
+		total += values[i]
+	}
+
+	values[0] += coinbaseValue - total
+
+	numberOfOutputs := uint64(len(template.Outputs))
+	if template.MinerTag != "" {
+		numberOfOutputs++
+	}
+
+	buf := VarInt(numberOfOutputs)
+
+	for i, output := range template.Outputs {
+		lockingScript, err := AddressToScript(output.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		buf = appendCoinbaseOutput(buf, values[i], lockingScript)
+	}
+
+	if template.MinerTag != "" {
+		lockingScript := &bscript.Script{}
+		_ = lockingScript.AppendOpcodes(bscript.OpFALSE, bscript.OpRETURN)
+
+		if err := lockingScript.AppendPushData([]byte(template.MinerTag)); err != nil {
+			return nil, err
+		}
+
+		buf = appendCoinbaseOutput(buf, 0, lockingScript.Bytes())
+	}
+
+	return buf, nil
+}
+
+// appendCoinbaseOutput appends a serialized output to buf
+func appendCoinbaseOutput(buf []byte, satoshis uint64, lockingScript []byte) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, satoshis)
+	buf = append(buf, VarInt(uint64(len(lockingScript)))...)
+
+	return append(buf, lockingScript...)
+}
@@ -0,0 +1,180 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/bscript"
+	"github.com/bsv-blockchain/go-chaincfg"
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCoinbaseAddress returns a P2PKH address of a new key, of mainnet or of the test networks
+func testCoinbaseAddress(t *testing.T, mainnet bool) string {
+	t.Helper()
+
+	privateKey, err := primitives.NewPrivateKey()
+	require.NoError(t, err)
+
+	address, err := bscript.NewAddressFromPublicKey(privateKey.PubKey(), mainnet)
+	require.NoError(t, err)
+
+	return address.AddressString
+}
+
+func TestParseCoinbaseTemplate(t *testing.T) {
+	t.Run("no outputs and no miner tag", func(t *testing.T) {
+		template, err := ParseCoinbaseTemplate(nil, "")
+		require.NoError(t, err)
+		assert.Nil(t, template)
+	})
+
+	t.Run("outputs and miner tag", func(t *testing.T) {
+		template, err := ParseCoinbaseTemplate([]string{"addr1:62.5", " addr2 : 37.25 ", "addr3:0.25"}, "/miner/")
+		require.NoError(t, err)
+
+		assert.Equal(t, &CoinbaseTemplate{
+			Outputs: []CoinbaseOutputTemplate{
+				{Address: "addr1", BasisPoints: 6250},
+				{Address: "addr2", BasisPoints: 3725},
+				{Address: "addr3", BasisPoints: 25},
+			},
+			MinerTag: "/miner/",
+		}, template)
+	})
+
+	for _, output := range []string{"addr1", "addr1:abc", "addr1:10.125", "addr1:101", "addr1:-5", "addr1:5.x"} {
+		t.Run("invalid "+output, func(t *testing.T) {
+			_, err := ParseCoinbaseTemplate([]string{output}, "")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestCoinbaseTemplate_Validate(t *testing.T) {
+	address1 := testCoinbaseAddress(t, false)
+	address2 := testCoinbaseAddress(t, false)
+
+	t.Run("valid", func(t *testing.T) {
+		template := &CoinbaseTemplate{
+			Outputs:  []CoinbaseOutputTemplate{{Address: address1, BasisPoints: 9000}, {Address: address2, BasisPoints: 1000}},
+			MinerTag: "/miner/",
+		}
+
+		require.NoError(t, template.Validate(&chaincfg.RegressionNetParams))
+		require.NoError(t, template.Validate(nil))
+	})
+
+	tests := map[string]*CoinbaseTemplate{
+		"no outputs":        {MinerTag: "/miner/"},
+		"invalid address":   {Outputs: []CoinbaseOutputTemplate{{Address: "ADD8E55", BasisPoints: 10000}}},
+		"mainnet address":   {Outputs: []CoinbaseOutputTemplate{{Address: testCoinbaseAddress(t, true), BasisPoints: 10000}}},
+		"duplicate address": {Outputs: []CoinbaseOutputTemplate{{Address: address1, BasisPoints: 5000}, {Address: address1, BasisPoints: 5000}}},
+		"zero share":        {Outputs: []CoinbaseOutputTemplate{{Address: address1, BasisPoints: 10000}, {Address: address2}}},
+		"below 100%":        {Outputs: []CoinbaseOutputTemplate{{Address: address1, BasisPoints: 9999}}},
+		"above 100%":        {Outputs: []CoinbaseOutputTemplate{{Address: address1, BasisPoints: 9000}, {Address: address2, BasisPoints: 1001}}},
+		"miner tag too long": {
+			Outputs:  []CoinbaseOutputTemplate{{Address: address1, BasisPoints: 10000}},
+			MinerTag: strings.Repeat("x", MaxCoinbaseMinerTagSize+1),
+		},
+	}
+
+	for name, template := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, template.Validate(&chaincfg.RegressionNetParams))
+		})
+	}
+
+	t.Run("too many outputs", func(t *testing.T) {
+		template := &CoinbaseTemplate{}
+
+		for i := 0; i <= MaxCoinbaseTemplateOutputs; i++ {
+			template.Outputs = append(template.Outputs, CoinbaseOutputTemplate{Address: testCoinbaseAddress(t, false), BasisPoints: 1})
+		}
+
+		require.Error(t, template.Validate(nil))
+	})
+}
+
+func TestCreateCoinbaseFromTemplate(t *testing.T) {
+	address1 := testCoinbaseAddress(t, false)
+	address2 := testCoinbaseAddress(t, false)
+	address3 := testCoinbaseAddress(t, false)
+
+	script := func(address string) []byte {
+		lockingScript, err := AddressToScript(address)
+		require.NoError(t, err)
+
+		return lockingScript
+	}
+
+	t.Run("percentage split", func(t *testing.T) {
+		template := &CoinbaseTemplate{Outputs: []CoinbaseOutputTemplate{
+			{Address: address1, BasisPoints: 3333},
+			{Address: address2, BasisPoints: 3333},
+			{Address: address3, BasisPoints: 3334},
+		}}
+
+		coinbaseTx, err := CreateCoinbaseFromTemplate(100, 1_000_001, "/test/", template)
+		require.NoError(t, err)
+
+		require.True(t, coinbaseTx.IsCoinbase())
+		require.Len(t, coinbaseTx.Outputs, 3)
+
+		// the rounding remainder goes to the first output
+		assert.Equal(t, uint64(333_301), coinbaseTx.Outputs[0].Satoshis)
+		assert.Equal(t, uint64(333_300), coinbaseTx.Outputs[1].Satoshis)
+		assert.Equal(t, uint64(333_400), coinbaseTx.Outputs[2].Satoshis)
+		assert.Equal(t, uint64(1_000_001), coinbaseTx.TotalOutputSatoshis())
+
+		assert.Equal(t, script(address1), coinbaseTx.Outputs[0].LockingScript.Bytes())
+		assert.Equal(t, script(address2), coinbaseTx.Outputs[1].LockingScript.Bytes())
+		assert.Equal(t, script(address3), coinbaseTx.Outputs[2].LockingScript.Bytes())
+	})
+
+	t.Run("miner tag", func(t *testing.T) {
+		minerTag := strings.Repeat("t", MaxCoinbaseMinerTagSize)
+
+		template := &CoinbaseTemplate{
+			Outputs:  []CoinbaseOutputTemplate{{Address: address1, BasisPoints: 10000}},
+			MinerTag: minerTag,
+		}
+
+		coinbaseTx, err := CreateCoinbaseFromTemplate(100, 5_000_000_000, "/test/", template)
+		require.NoError(t, err)
+
+		require.Len(t, coinbaseTx.Outputs, 2)
+		assert.Equal(t, uint64(5_000_000_000), coinbaseTx.Outputs[0].Satoshis)
+
+		tagOutput := coinbaseTx.Outputs[1]
+		assert.Equal(t, uint64(0), tagOutput.Satoshis)
+		assert.True(t, tagOutput.LockingScript.IsData())
+
+		parts, err := bscript.DecodeParts(tagOutput.LockingScript.Bytes())
+		require.NoError(t, err)
+		require.Len(t, parts, 3)
+		assert.Equal(t, []byte(minerTag), parts[2])
+	})
+
+	t.Run("maximum coinbase value", func(t *testing.T) {
+		template := &CoinbaseTemplate{Outputs: []CoinbaseOutputTemplate{
+			{Address: address1, BasisPoints: 9999},
+			{Address: address2, BasisPoints: 1},
+		}}
+
+		// the product with the share exceeds 64 bits
+		coinbaseValue := uint64(21_000_000 * 100_000_000)
+
+		coinbaseTx, err := CreateCoinbaseFromTemplate(1, coinbaseValue, "", template)
+		require.NoError(t, err)
+		assert.Equal(t, coinbaseValue/10000, coinbaseTx.Outputs[1].Satoshis)
+		assert.Equal(t, coinbaseValue, coinbaseTx.TotalOutputSatoshis())
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := CreateCoinbaseFromTemplate(1, 100, "", &CoinbaseTemplate{})
+		require.Error(t, err)
+	})
+}
@@ -86,6 +86,11 @@ func CreateCoinbase(height uint32, coinbaseValue uint64, arbitraryText string, a
 		return nil, errors.NewProcessingError("error creating coinbase transaction", err)
 	}
 
+	return newCoinbaseTx(a, b)
+}
+
+// newCoinbaseTx returns the coinbase transaction of the two coinbase parts, with a random extranonce between them
+func newCoinbaseTx(a, b []byte) (*bt.Tx, error) {
 	// The extranonce length is 12 bytes.  We need to add 12 bytes to the coinbase a part
 	extranonce := make([]byte, 12)
 	_, _ = rand.Read(extranonce)
@@ -28,6 +28,7 @@ const (
 	FileTypeBatchData      FileType = "batch-data"
 	FileTypeBatchKeys      FileType = "batch-keys"
 	FileTypePreserveUntil  FileType = "preserveUntil"
+	FileTypeBlockUndo      FileType = "blockUndo"
 	FileTypeUnknown        FileType = ""
 )
 
@@ -59,6 +60,7 @@ var (
 	magicBatchData      = [8]byte{'B', 'D', '-', '1', '.', '0', ' ', ' '} // BD-1.0
 	magicBatchKeys      = [8]byte{'B', 'K', '-', '1', '.', '0', ' ', ' '} // BK-1.0
 	magicPreserveUntil  = [8]byte{'P', 'U', '-', '1', '.', '0', ' ', ' '} // PU-1.0
+	magicBlockUndo      = [8]byte{'B', 'U', '-', '1', '.', '0', ' ', ' '} // BU-1.0
 )
 
 var fileTypeToMagic = map[FileType][8]byte{
@@ -80,6 +82,7 @@ var fileTypeToMagic = map[FileType][8]byte{
 	FileTypeBatchData:      magicBatchData,
 	FileTypeBatchKeys:      magicBatchKeys,
 	FileTypePreserveUntil:  magicPreserveUntil,
+	FileTypeBlockUndo:      magicBlockUndo,
 }
 
 var magicToFileType = map[[8]byte]FileType{
@@ -101,6 +104,7 @@ var magicToFileType = map[[8]byte]FileType{
 	magicBatchData:      FileTypeBatchData,
 	magicBatchKeys:      FileTypeBatchKeys,
 	magicPreserveUntil:  FileTypePreserveUntil,
+	magicBlockUndo:      FileTypeBlockUndo,
 }
 
 type Header struct {
@@ -97,6 +97,7 @@ func TestNewHeader_AllFileTypes(t *testing.T) {
 		FileTypeBatchData,
 		FileTypeBatchKeys,
 		FileTypePreserveUntil,
+		FileTypeBlockUndo,
 	}
 
 	for _, fileType := range allTypes {
@@ -279,6 +280,7 @@ func TestHeader_FileType(t *testing.T) {
 		{FileTypeBatchData, magicBatchData},
 		{FileTypeBatchKeys, magicBatchKeys},
 		{FileTypePreserveUntil, magicPreserveUntil},
+		{FileTypeBlockUndo, magicBlockUndo},
 	}
 
 	for _, tc := range testCases {
@@ -327,6 +329,7 @@ func TestFileTypeFromExtension(t *testing.T) {
 		{"batch-data", FileTypeBatchData, false},
 		{"batch-keys", FileTypeBatchKeys, false},
 		{"preserveUntil", FileTypePreserveUntil, false},
+		{"blockUndo", FileTypeBlockUndo, false},
 		{"invalid-extension", "", true},
 		{"", "", true},
 	}
@@ -366,6 +369,7 @@ func TestMagicConstants(t *testing.T) {
 		FileTypeBatchData:      magicBatchData,
 		FileTypeBatchKeys:      magicBatchKeys,
 		FileTypePreserveUntil:  magicPreserveUntil,
+		FileTypeBlockUndo:      magicBlockUndo,
 	}
 
 	for fileType, expectedMagic := range expectedMagics {
@@ -417,6 +421,7 @@ func TestHeader_RoundTripAllTypes(t *testing.T) {
 		FileTypeBatchData,
 		FileTypeBatchKeys,
 		FileTypePreserveUntil,
+		FileTypeBlockUndo,
 	}
 
 	for _, fileType := range allTypes {
@@ -33,7 +33,9 @@ type HealthResponse struct {
 	// details provides additional information about the health status
 	Details string `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
 	// timestamp indicates when the health check was performed
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// build_info is the JSON build metadata of the node, as returned by the /api/v1/version endpoint
+	BuildInfo     string `protobuf:"bytes,4,opt,name=build_info,json=buildInfo,proto3" json:"build_info,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,15 +91,24 @@ func (x *HealthResponse) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *HealthResponse) GetBuildInfo() string {
+	if x != nil {
+		return x.BuildInfo
+	}
+	return ""
+}
+
 var File_services_alert_alert_api_alert_api_proto protoreflect.FileDescriptor
 
 const file_services_alert_alert_api_alert_api_proto_rawDesc = "" +
 	"\n" +
-	"(services/alert/alert_api/alert_api.proto\x12\talert_api\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"t\n" +
+	"(services/alert/alert_api/alert_api.proto\x12\talert_api\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x93\x01\n" +
 	"\x0eHealthResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x18\n" +
 	"\adetails\x18\x02 \x01(\tR\adetails\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp2M\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"build_info\x18\x04 \x01(\tR\tbuildInfo2M\n" +
 	"\bAlertAPI\x12A\n" +
 	"\n" +
 	"HealthGRPC\x12\x16.google.protobuf.Empty\x1a\x19.alert_api.HealthResponse\"\x00B\x0eZ\f./;alert_apib\x06proto3"
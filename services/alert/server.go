@@ -26,6 +26,7 @@ import (
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/stores/utxo"
 	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
 	"github.com/bsv-blockchain/teranode/util/health"
 	"github.com/mrz1836/go-datastore"
 	"github.com/ordishs/gocore"
@@ -193,6 +194,7 @@ func (s *Server) HealthGRPC(ctx context.Context, _ *emptypb.Empty) (*alert_api.H
 		Ok:        status == http.StatusOK,
 		Details:   details,
 		Timestamp: timestamppb.New(time.Now()),
+		BuildInfo: buildinfo.Get(s.settings).JSON(),
 	}, errors.WrapGRPC(err)
 }
 
@@ -260,6 +260,19 @@ func (v *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
 
 			return nil
 		})
+
+		for _, addr := range v.settings.Asset.HTTPListenAddresses {
+			g.Go(func() error {
+				v.logger.Infof("[Asset] Starting HTTP server on additional address %s", addr)
+
+				if err := v.httpServer.StartAdditional(ctx, addr); err != nil {
+					v.logger.Errorf("[Asset] error in http server on %s: %v", addr, err)
+					return err
+				}
+
+				return nil
+			})
+		}
 	}
 
 	// Blocks until the FSM transitions from the IDLE state
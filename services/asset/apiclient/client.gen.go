@@ -0,0 +1,1637 @@
+// Code generated by openapigen from the OpenAPI document of the asset HTTP API. DO NOT EDIT.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// BanRequest is the BanRequest schema of the asset API.
+type BanRequest struct {
+	Duration string   `json:"duration"`
+	Reason   string   `json:"reason"`
+	Targets  []string `json:"targets"`
+}
+
+// BanResult is the BanResult schema of the asset API.
+type BanResult struct {
+	Error  string `json:"error"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// BanResultsResponse is the BanResultsResponse schema of the asset API.
+type BanResultsResponse struct {
+	Failed    int64       `json:"failed"`
+	Results   []BanResult `json:"results"`
+	Succeeded int64       `json:"succeeded"`
+	Until     int64       `json:"until"`
+}
+
+// BandwidthClassStats is the BandwidthClassStats schema of the asset API.
+type BandwidthClassStats struct {
+	Active     bool    `json:"active"`
+	BytesTotal int64   `json:"bytes_total"`
+	Class      string  `json:"class"`
+	RateLimit  int64   `json:"rate_limit"`
+	Weight     float64 `json:"weight"`
+}
+
+// BandwidthRequest is the BandwidthRequest schema of the asset API.
+type BandwidthRequest struct {
+	Limit   int64              `json:"limit"`
+	Weights map[string]float64 `json:"weights"`
+}
+
+// BandwidthResponse is the BandwidthResponse schema of the asset API.
+type BandwidthResponse struct {
+	Classes []BandwidthClassStats `json:"classes"`
+	Limit   int64                 `json:"limit"`
+}
+
+// BlockExtended is the BlockExtended schema of the asset API.
+type BlockExtended struct {
+	CoinbaseTx json.RawMessage   `json:"coinbase_tx"`
+	Header     *ModelBlockHeader `json:"header"`
+	Height     int64             `json:"height"`
+	ID         int64             `json:"id"`
+
+	// Hash in hex
+	Nextblock        string   `json:"nextblock"`
+	SizeInBytes      int64    `json:"size_in_bytes"`
+	Subtrees         []string `json:"subtrees"`
+	TransactionCount int64    `json:"transaction_count"`
+}
+
+// BlockRequest is the BlockRequest schema of the asset API.
+type BlockRequest struct {
+	BlockHash string `json:"blockHash"`
+}
+
+// BuildinfoInfo is the BuildinfoInfo schema of the asset API.
+type BuildinfoInfo struct {
+	BuildDate string                 `json:"build_date"`
+	Commit    string                 `json:"commit"`
+	Features  []string               `json:"features"`
+	GoVersion string                 `json:"go_version"`
+	Network   string                 `json:"network"`
+	Services  []BuildinfoServiceInfo `json:"services"`
+	Version   string                 `json:"version"`
+}
+
+// BuildinfoServiceInfo is the BuildinfoServiceInfo schema of the asset API.
+type BuildinfoServiceInfo struct {
+	Name             string            `json:"name"`
+	ProtocolVersions map[string]string `json:"protocol_versions"`
+}
+
+// BumpFormat is the BumpFormat schema of the asset API.
+type BumpFormat struct {
+	BlockHeight int64        `json:"blockHeight"`
+	Path        [][]BumpNode `json:"path"`
+}
+
+// BumpNode is the BumpNode schema of the asset API.
+type BumpNode struct {
+	Duplicate bool   `json:"duplicate"`
+	Hash      string `json:"hash"`
+	Offset    int64  `json:"offset"`
+	Txid      bool   `json:"txid"`
+}
+
+// ChainTipResponse is the ChainTipResponse schema of the asset API.
+type ChainTipResponse struct {
+	BlockHash string `json:"block_hash"`
+	Height    int32  `json:"height"`
+	PeerCount int64  `json:"peer_count"`
+}
+
+// ErrorResponse is the ErrorResponse schema of the asset API.
+type ErrorResponse struct {
+
+	// Error code, see the error catalog at /errors
+	Code int32 `json:"code"`
+
+	// Error message
+	Error string `json:"error"`
+
+	// Name of the error code
+	Name string `json:"name"`
+
+	// HTTP status code
+	Status int32 `json:"status"`
+}
+
+// ErrorsCatalogEntry is the ErrorsCatalogEntry schema of the asset API.
+type ErrorsCatalogEntry struct {
+	Category    string `json:"category"`
+	Code        int32  `json:"code"`
+	Description string `json:"description"`
+	Name        string `json:"name"`
+}
+
+// ExtendedResponse is the ExtendedResponse schema of the asset API.
+type ExtendedResponse struct {
+	Data       json.RawMessage `json:"data"`
+	Pagination *Pagination     `json:"pagination"`
+}
+
+// Forks is the Forks schema of the asset API.
+type Forks struct {
+	Tree *ForksTree `json:"tree"`
+}
+
+// ForksLink is the ForksLink schema of the asset API.
+type ForksLink struct {
+	Direction string `json:"direction"`
+	Name      string `json:"name"`
+	NodeName  string `json:"nodeName"`
+}
+
+// ForksTree is the ForksTree schema of the asset API.
+type ForksTree struct {
+	BlockTime int64       `json:"block_time"`
+	Children  []ForksTree `json:"children"`
+	Hash      string      `json:"hash"`
+	Height    int64       `json:"height"`
+	ID        int64       `json:"id"`
+	Link      *ForksLink  `json:"link"`
+	Miner     string      `json:"miner"`
+	Size      int64       `json:"size"`
+	Timestamp int64       `json:"timestamp"`
+	TxCount   int64       `json:"tx_count"`
+}
+
+// HeightCountResponse is the HeightCountResponse schema of the asset API.
+type HeightCountResponse struct {
+	Height    int32 `json:"height"`
+	PeerCount int64 `json:"peer_count"`
+}
+
+// LogLevelRequest is the LogLevelRequest schema of the asset API.
+type LogLevelRequest struct {
+	Component string `json:"component"`
+	Level     string `json:"level"`
+}
+
+// LogLevelsResponse is the LogLevelsResponse schema of the asset API.
+type LogLevelsResponse struct {
+	Components   []UloggerComponentLevel `json:"components"`
+	DefaultLevel string                  `json:"default_level"`
+}
+
+// ModelBlockHeader is the ModelBlockHeader schema of the asset API.
+type ModelBlockHeader struct {
+	Bits json.RawMessage `json:"bits"`
+
+	// Hash in hex
+	HashMerkleRoot string `json:"hash_merkle_root"`
+
+	// Hash in hex
+	HashPrevBlock string `json:"hash_prev_block"`
+	Nonce         int64  `json:"nonce"`
+	Timestamp     int64  `json:"timestamp"`
+	Version       int64  `json:"version"`
+}
+
+// NetworkOverviewResponse is the NetworkOverviewResponse schema of the asset API.
+type NetworkOverviewResponse struct {
+	AvgResponseTimeMs  int64                 `json:"avg_response_time_ms"`
+	BestHeight         int32                 `json:"best_height"`
+	ChainTips          []ChainTipResponse    `json:"chain_tips"`
+	ChurnRate          float64               `json:"churn_rate"`
+	ChurnWindowSeconds int64                 `json:"churn_window_seconds"`
+	ConnectedPeers     int64                 `json:"connected_peers"`
+	HeightDistribution []HeightCountResponse `json:"height_distribution"`
+	PeersJoined        int64                 `json:"peers_joined"`
+	PeersLeft          int64                 `json:"peers_left"`
+	TotalPeers         int64                 `json:"total_peers"`
+}
+
+// OperatorMessageResponse is the OperatorMessageResponse schema of the asset API.
+type OperatorMessageResponse struct {
+	At        int64  `json:"at"`
+	Direction string `json:"direction"`
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	PeerID    string `json:"peer_id"`
+	SentAt    int64  `json:"sent_at"`
+	Text      string `json:"text"`
+}
+
+// OperatorMessagesResponse is the OperatorMessagesResponse schema of the asset API.
+type OperatorMessagesResponse struct {
+	Count    int64                     `json:"count"`
+	Messages []OperatorMessageResponse `json:"messages"`
+}
+
+// Pagination is the Pagination schema of the asset API.
+type Pagination struct {
+	Limit        int64 `json:"limit"`
+	Offset       int64 `json:"offset"`
+	TotalRecords int64 `json:"totalRecords"`
+}
+
+// PeerContributionResponse is the PeerContributionResponse schema of the asset API.
+type PeerContributionResponse struct {
+	BlocksReceived      int64  `json:"blocks_received"`
+	BlocksServed        int64  `json:"blocks_served"`
+	BytesReceived       int64  `json:"bytes_received"`
+	BytesServed         int64  `json:"bytes_served"`
+	FirstSeen           int64  `json:"first_seen"`
+	LastUpdated         int64  `json:"last_updated"`
+	PeerID              string `json:"peer_id"`
+	SubtreeDataReceived int64  `json:"subtree_data_received"`
+	SubtreeDataServed   int64  `json:"subtree_data_served"`
+	SubtreesReceived    int64  `json:"subtrees_received"`
+	SubtreesServed      int64  `json:"subtrees_served"`
+}
+
+// PeerContributionsResponse is the PeerContributionsResponse schema of the asset API.
+type PeerContributionsResponse struct {
+	Contributions []PeerContributionResponse `json:"contributions"`
+	Count         int64                      `json:"count"`
+}
+
+// PeerInfoResponse is the PeerInfoResponse schema of the asset API.
+type PeerInfoResponse struct {
+	AsOrganization         string  `json:"as_organization"`
+	Asn                    int64   `json:"asn"`
+	BanScore               int64   `json:"ban_score"`
+	BlockHash              string  `json:"block_hash"`
+	BytesReceived          int64   `json:"bytes_received"`
+	BytesSent              int64   `json:"bytes_sent"`
+	CatchupAttempts        int64   `json:"catchup_attempts"`
+	CatchupAvgResponseMs   int64   `json:"catchup_avg_response_ms"`
+	CatchupFailures        int64   `json:"catchup_failures"`
+	CatchupLastAttempt     int64   `json:"catchup_last_attempt"`
+	CatchupLastFailure     int64   `json:"catchup_last_failure"`
+	CatchupLastSuccess     int64   `json:"catchup_last_success"`
+	CatchupMaliciousCount  int64   `json:"catchup_malicious_count"`
+	CatchupReputationScore float64 `json:"catchup_reputation_score"`
+	CatchupSuccesses       int64   `json:"catchup_successes"`
+	ClientName             string  `json:"client_name"`
+	ConnectedAt            int64   `json:"connected_at"`
+	Country                string  `json:"country"`
+	DataHubURL             string  `json:"data_hub_url"`
+	HealthCheckFailures    int64   `json:"health_check_failures"`
+	HealthDurationMs       int64   `json:"health_duration_ms"`
+	Height                 int32   `json:"height"`
+	ID                     string  `json:"id"`
+	IsBanned               bool    `json:"is_banned"`
+	IsConnected            bool    `json:"is_connected"`
+	IsDatahubDown          bool    `json:"is_datahub_down"`
+	IsDatahubURLVerified   bool    `json:"is_datahub_url_verified"`
+	IsHealthy              bool    `json:"is_healthy"`
+	IsOnProbation          bool    `json:"is_on_probation"`
+	IsProtocolIncompatible bool    `json:"is_protocol_incompatible"`
+	IsRelayOnly            bool    `json:"is_relay_only"`
+	IsThrottled            bool    `json:"is_throttled"`
+	LastBlockTime          int64   `json:"last_block_time"`
+	LastCatchupError       string  `json:"last_catchup_error"`
+	LastCatchupErrorTime   int64   `json:"last_catchup_error_time"`
+	LastMessageTime        int64   `json:"last_message_time"`
+	LastURLCheck           int64   `json:"last_url_check"`
+	MinerContact           string  `json:"miner_contact"`
+	MinerID                string  `json:"miner_id"`
+	MinerIDAnnouncedAt     int64   `json:"miner_id_announced_at"`
+	MinerPublicKey         string  `json:"miner_public_key"`
+	ProbationUntil         int64   `json:"probation_until"`
+	ProtocolVersion        int64   `json:"protocol_version"`
+	Transport              string  `json:"transport"`
+	URLResponsive          bool    `json:"url_responsive"`
+}
+
+// PeerStatsTopPeer is the PeerStatsTopPeer schema of the asset API.
+type PeerStatsTopPeer struct {
+	ClientName      string  `json:"client_name"`
+	Height          int32   `json:"height"`
+	ID              string  `json:"id"`
+	IsConnected     bool    `json:"is_connected"`
+	ReputationScore float64 `json:"reputation_score"`
+}
+
+// PeersResponse is the PeersResponse schema of the asset API.
+type PeersResponse struct {
+	Count int64              `json:"count"`
+	Peers []PeerInfoResponse `json:"peers"`
+}
+
+// PeersStatsResponse is the PeersStatsResponse schema of the asset API.
+type PeersStatsResponse struct {
+	BannedPeers        int64                 `json:"banned_peers"`
+	ConnectedPeers     int64                 `json:"connected_peers"`
+	HealthyPeers       int64                 `json:"healthy_peers"`
+	HeightDistribution []HeightCountResponse `json:"height_distribution"`
+	MedianReputation   float64               `json:"median_reputation"`
+	TopPeers           []PeerStatsTopPeer    `json:"top_peers"`
+	TotalPeers         int64                 `json:"total_peers"`
+}
+
+// Res is the Res schema of the asset API.
+type Res struct {
+	Hash string `json:"hash"`
+	Type string `json:"type"`
+}
+
+// SendFSMEventRequest is the SendFSMEventRequest schema of the asset API.
+type SendFSMEventRequest struct {
+	Event string `json:"event"`
+}
+
+// SendOperatorMessageRequest is the SendOperatorMessageRequest schema of the asset API.
+type SendOperatorMessageRequest struct {
+	Kind   string `json:"kind"`
+	PeerID string `json:"peer_id"`
+	Text   string `json:"text"`
+}
+
+// SpendingData is the SpendingData schema of the asset API.
+type SpendingData struct {
+
+	// Hash in hex
+	TxId string `json:"txId"`
+	Vin  int64  `json:"vin"`
+}
+
+// UTXOItem is the UTXOItem schema of the asset API.
+type UTXOItem struct {
+	LockTime      int64           `json:"lockTime"`
+	LockingScript json.RawMessage `json:"lockingScript"`
+	Satoshis      int64           `json:"satoshis"`
+	SpendingData  *SpendingData   `json:"spendingData"`
+	Status        string          `json:"status"`
+
+	// Hash in hex
+	Txid string `json:"txid"`
+
+	// Hash in hex
+	UtxoHash string `json:"utxoHash"`
+	Vout     int64  `json:"vout"`
+}
+
+// UloggerComponentLevel is the UloggerComponentLevel schema of the asset API.
+type UloggerComponentLevel struct {
+	Component  string `json:"component"`
+	Level      string `json:"level"`
+	Overridden bool   `json:"overridden"`
+}
+
+// UnbanRequest is the UnbanRequest schema of the asset API.
+type UnbanRequest struct {
+	Targets []string `json:"targets"`
+}
+
+// BanPeers calls POST /bans.
+//
+// Bans the targets of the request for the requested duration.
+func (c *Client) BanPeers(ctx context.Context, body *BanRequest) (*BanResultsResponse, error) {
+	out := new(BanResultsResponse)
+
+	if err := c.doJSON(ctx, http.MethodPost, "/bans", nil, body, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// CreateDiagnosticsBundle calls POST /diagnostics/bundle.
+//
+// Gathers the catchup status, the peer registry, the recent errors, the goroutine and heap profiles, the build information and the effective settings of this node into a gzipped tar archive for support cases.
+func (c *Client) CreateDiagnosticsBundle(ctx context.Context) ([]byte, error) {
+	return c.do(ctx, http.MethodPost, "/diagnostics/bundle", nil, nil, "", "application/octet-stream")
+}
+
+// GetAuditLogParams are the query parameters of GetAuditLog.
+type GetAuditLogParams struct {
+	Limit  string
+	Offset string
+}
+
+// GetAuditLog calls GET /audit.
+//
+// Returns the audit records of the privileged operations performed on the node, newest first, with pagination through the offset and limit query parameters.
+func (c *Client) GetAuditLog(ctx context.Context, params *GetAuditLogParams) (*ExtendedResponse, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Limit != "" {
+			query.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			query.Set("offset", params.Offset)
+		}
+	}
+
+	out := new(ExtendedResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/audit", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetBandwidth calls GET /bandwidth.
+//
+// Returns the bandwidth budget and the current share of each traffic class.
+func (c *Client) GetBandwidth(ctx context.Context) (*BandwidthResponse, error) {
+	out := new(BandwidthResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/bandwidth", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetBansParams are the query parameters of GetBans.
+type GetBansParams struct {
+	Limit  string
+	Offset string
+}
+
+// GetBans calls GET /bans.
+//
+// Returns the banned peer IDs, IP addresses and CIDR subnets, ordered by target, with pagination through the offset and limit query parameters.
+func (c *Client) GetBans(ctx context.Context, params *GetBansParams) (*ExtendedResponse, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Limit != "" {
+			query.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			query.Set("offset", params.Offset)
+		}
+	}
+
+	out := new(ExtendedResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/bans", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetBestBlockHeader calls GET /bestblockheader.
+//
+// Retrieving the most recent block header in the blockchain (binary).
+func (c *Client) GetBestBlockHeader(ctx context.Context) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/bestblockheader", nil, nil, "", "application/octet-stream")
+}
+
+// GetBestBlockHeaderHex calls GET /bestblockheader/hex.
+//
+// Retrieving the most recent block header in the blockchain (hex).
+func (c *Client) GetBestBlockHeaderHex(ctx context.Context) (string, error) {
+	b, err := c.do(ctx, http.MethodGet, "/bestblockheader/hex", nil, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetBestBlockHeaderJSON calls GET /bestblockheader/json.
+//
+// Retrieving the most recent block header in the blockchain (JSON).
+func (c *Client) GetBestBlockHeaderJSON(ctx context.Context) (json.RawMessage, error) {
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/bestblockheader/json", nil, nil, &out)
+
+	return out, err
+}
+
+// GetBlockByHash calls GET /block/{hash}.
+//
+// Retrieving blocks by their hash (binary).
+func (c *Client) GetBlockByHash(ctx context.Context, hash string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/block/"+url.PathEscape(hash), nil, nil, "", "application/octet-stream")
+}
+
+// GetBlockByHashHex calls GET /block/{hash}/hex.
+//
+// Retrieving blocks by their hash (hex).
+func (c *Client) GetBlockByHashHex(ctx context.Context, hash string) (string, error) {
+	b, err := c.do(ctx, http.MethodGet, "/block/"+url.PathEscape(hash)+"/hex", nil, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetBlockByHashJSON calls GET /block/{hash}/json.
+//
+// Retrieving blocks by their hash (JSON).
+func (c *Client) GetBlockByHashJSON(ctx context.Context, hash string) (*BlockExtended, error) {
+	out := new(BlockExtended)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/block/"+url.PathEscape(hash)+"/json", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetBlockForksParams are the query parameters of GetBlockForks.
+type GetBlockForksParams struct {
+	Limit string
+}
+
+// GetBlockForks calls GET /block/{hash}/forks.
+//
+// Retrieves a tree structure showing the blockchain's fork history starting from a specified block.
+func (c *Client) GetBlockForks(ctx context.Context, hash string, params *GetBlockForksParams) (*Forks, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Limit != "" {
+			query.Set("limit", params.Limit)
+		}
+	}
+
+	out := new(Forks)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/block/"+url.PathEscape(hash)+"/forks", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetBlockGraphData calls GET /blockgraphdata/{period}.
+//
+// Retrieves time-series data points showing transaction count over time.
+func (c *Client) GetBlockGraphData(ctx context.Context, period string) (json.RawMessage, error) {
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/blockgraphdata/"+url.PathEscape(period), nil, nil, &out)
+
+	return out, err
+}
+
+// GetBlockHeader calls GET /header/{hash}.
+//
+// Retrieving block header information by block hash (binary).
+func (c *Client) GetBlockHeader(ctx context.Context, hash string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/header/"+url.PathEscape(hash), nil, nil, "", "application/octet-stream")
+}
+
+// GetBlockHeaderHex calls GET /header/{hash}/hex.
+//
+// Retrieving block header information by block hash (hex).
+func (c *Client) GetBlockHeaderHex(ctx context.Context, hash string) (string, error) {
+	b, err := c.do(ctx, http.MethodGet, "/header/"+url.PathEscape(hash)+"/hex", nil, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetBlockHeaderJSON calls GET /header/{hash}/json.
+//
+// Retrieving block header information by block hash (JSON).
+func (c *Client) GetBlockHeaderJSON(ctx context.Context, hash string) (json.RawMessage, error) {
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/header/"+url.PathEscape(hash)+"/json", nil, nil, &out)
+
+	return out, err
+}
+
+// GetBlockHeadersParams are the query parameters of GetBlockHeaders.
+type GetBlockHeadersParams struct {
+	N string
+}
+
+// GetBlockHeaders calls GET /headers/{hash}.
+//
+// Retrieving multiple consecutive block headers starting from a specific block hash (binary).
+func (c *Client) GetBlockHeaders(ctx context.Context, hash string, params *GetBlockHeadersParams) ([]byte, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	return c.do(ctx, http.MethodGet, "/headers/"+url.PathEscape(hash), query, nil, "", "application/octet-stream")
+}
+
+// GetBlockHeadersFromCommonAncestorParams are the query parameters of GetBlockHeadersFromCommonAncestor.
+type GetBlockHeadersFromCommonAncestorParams struct {
+	BlockLocatorHashes string
+	N                  string
+}
+
+// GetBlockHeadersFromCommonAncestor calls GET /headers_from_common_ancestor/{hash}.
+//
+// Retrieving multiple consecutive block headers starting from a given block hash, up to a specified number of headers (binary).
+func (c *Client) GetBlockHeadersFromCommonAncestor(ctx context.Context, hash string, params *GetBlockHeadersFromCommonAncestorParams) ([]byte, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.BlockLocatorHashes != "" {
+			query.Set("block_locator_hashes", params.BlockLocatorHashes)
+		}
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	return c.do(ctx, http.MethodGet, "/headers_from_common_ancestor/"+url.PathEscape(hash), query, nil, "", "application/octet-stream")
+}
+
+// GetBlockHeadersFromCommonAncestorHexParams are the query parameters of GetBlockHeadersFromCommonAncestorHex.
+type GetBlockHeadersFromCommonAncestorHexParams struct {
+	BlockLocatorHashes string
+	N                  string
+}
+
+// GetBlockHeadersFromCommonAncestorHex calls GET /headers_from_common_ancestor/{hash}/hex.
+//
+// Retrieving multiple consecutive block headers starting from a given block hash, up to a specified number of headers (hex).
+func (c *Client) GetBlockHeadersFromCommonAncestorHex(ctx context.Context, hash string, params *GetBlockHeadersFromCommonAncestorHexParams) (string, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.BlockLocatorHashes != "" {
+			query.Set("block_locator_hashes", params.BlockLocatorHashes)
+		}
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	b, err := c.do(ctx, http.MethodGet, "/headers_from_common_ancestor/"+url.PathEscape(hash)+"/hex", query, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetBlockHeadersFromCommonAncestorJSONParams are the query parameters of GetBlockHeadersFromCommonAncestorJSON.
+type GetBlockHeadersFromCommonAncestorJSONParams struct {
+	BlockLocatorHashes string
+	N                  string
+}
+
+// GetBlockHeadersFromCommonAncestorJSON calls GET /headers_from_common_ancestor/{hash}/json.
+//
+// Retrieving multiple consecutive block headers starting from a given block hash, up to a specified number of headers (JSON).
+func (c *Client) GetBlockHeadersFromCommonAncestorJSON(ctx context.Context, hash string, params *GetBlockHeadersFromCommonAncestorJSONParams) ([]json.RawMessage, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.BlockLocatorHashes != "" {
+			query.Set("block_locator_hashes", params.BlockLocatorHashes)
+		}
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	var out []json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/headers_from_common_ancestor/"+url.PathEscape(hash)+"/json", query, nil, &out)
+
+	return out, err
+}
+
+// GetBlockHeadersHexParams are the query parameters of GetBlockHeadersHex.
+type GetBlockHeadersHexParams struct {
+	N string
+}
+
+// GetBlockHeadersHex calls GET /headers/{hash}/hex.
+//
+// Retrieving multiple consecutive block headers starting from a specific block hash (hex).
+func (c *Client) GetBlockHeadersHex(ctx context.Context, hash string, params *GetBlockHeadersHexParams) (string, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	b, err := c.do(ctx, http.MethodGet, "/headers/"+url.PathEscape(hash)+"/hex", query, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetBlockHeadersJSONParams are the query parameters of GetBlockHeadersJSON.
+type GetBlockHeadersJSONParams struct {
+	N string
+}
+
+// GetBlockHeadersJSON calls GET /headers/{hash}/json.
+//
+// Retrieving multiple consecutive block headers starting from a specific block hash (JSON).
+func (c *Client) GetBlockHeadersJSON(ctx context.Context, hash string, params *GetBlockHeadersJSONParams) ([]json.RawMessage, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	var out []json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/headers/"+url.PathEscape(hash)+"/json", query, nil, &out)
+
+	return out, err
+}
+
+// GetBlockHeadersToCommonAncestorParams are the query parameters of GetBlockHeadersToCommonAncestor.
+type GetBlockHeadersToCommonAncestorParams struct {
+	BlockLocatorHashes string
+	N                  string
+}
+
+// GetBlockHeadersToCommonAncestor calls GET /headers_to_common_ancestor/{hash}.
+//
+// Retrieving multiple consecutive block headers starting from a specific block hash (binary).
+func (c *Client) GetBlockHeadersToCommonAncestor(ctx context.Context, hash string, params *GetBlockHeadersToCommonAncestorParams) ([]byte, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.BlockLocatorHashes != "" {
+			query.Set("block_locator_hashes", params.BlockLocatorHashes)
+		}
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	return c.do(ctx, http.MethodGet, "/headers_to_common_ancestor/"+url.PathEscape(hash), query, nil, "", "application/octet-stream")
+}
+
+// GetBlockHeadersToCommonAncestorHexParams are the query parameters of GetBlockHeadersToCommonAncestorHex.
+type GetBlockHeadersToCommonAncestorHexParams struct {
+	BlockLocatorHashes string
+	N                  string
+}
+
+// GetBlockHeadersToCommonAncestorHex calls GET /headers_to_common_ancestor/{hash}/hex.
+//
+// Retrieving multiple consecutive block headers starting from a specific block hash (hex).
+func (c *Client) GetBlockHeadersToCommonAncestorHex(ctx context.Context, hash string, params *GetBlockHeadersToCommonAncestorHexParams) (string, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.BlockLocatorHashes != "" {
+			query.Set("block_locator_hashes", params.BlockLocatorHashes)
+		}
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	b, err := c.do(ctx, http.MethodGet, "/headers_to_common_ancestor/"+url.PathEscape(hash)+"/hex", query, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetBlockHeadersToCommonAncestorJSONParams are the query parameters of GetBlockHeadersToCommonAncestorJSON.
+type GetBlockHeadersToCommonAncestorJSONParams struct {
+	BlockLocatorHashes string
+	N                  string
+}
+
+// GetBlockHeadersToCommonAncestorJSON calls GET /headers_to_common_ancestor/{hash}/json.
+//
+// Retrieving multiple consecutive block headers starting from a specific block hash (JSON).
+func (c *Client) GetBlockHeadersToCommonAncestorJSON(ctx context.Context, hash string, params *GetBlockHeadersToCommonAncestorJSONParams) ([]json.RawMessage, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.BlockLocatorHashes != "" {
+			query.Set("block_locator_hashes", params.BlockLocatorHashes)
+		}
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	var out []json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/headers_to_common_ancestor/"+url.PathEscape(hash)+"/json", query, nil, &out)
+
+	return out, err
+}
+
+// GetBlockLocatorParams are the query parameters of GetBlockLocator.
+type GetBlockLocatorParams struct {
+	Hash   string
+	Height string
+}
+
+// GetBlockLocator calls GET /block_locator.
+//
+// Handles HTTP GET requests for retrieving a block locator.
+func (c *Client) GetBlockLocator(ctx context.Context, params *GetBlockLocatorParams) (map[string]json.RawMessage, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Hash != "" {
+			query.Set("hash", params.Hash)
+		}
+		if params.Height != "" {
+			query.Set("height", params.Height)
+		}
+	}
+
+	var out map[string]json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/block_locator", query, nil, &out)
+
+	return out, err
+}
+
+// GetBlockStats calls GET /blockstats.
+//
+// Handles HTTP GET requests to retrieve aggregate statistics about the blockchain.
+func (c *Client) GetBlockStats(ctx context.Context) (json.RawMessage, error) {
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/blockstats", nil, nil, &out)
+
+	return out, err
+}
+
+// GetBlockSubtreesJSONParams are the query parameters of GetBlockSubtreesJSON.
+type GetBlockSubtreesJSONParams struct {
+	Limit  string
+	Offset string
+}
+
+// GetBlockSubtreesJSON calls GET /block/{hash}/subtrees/json.
+//
+// Retrieving paginated subtree information for a specific block (JSON).
+func (c *Client) GetBlockSubtreesJSON(ctx context.Context, hash string, params *GetBlockSubtreesJSONParams) (*ExtendedResponse, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Limit != "" {
+			query.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			query.Set("offset", params.Offset)
+		}
+	}
+
+	out := new(ExtendedResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/block/"+url.PathEscape(hash)+"/subtrees/json", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetBlocksParams are the query parameters of GetBlocks.
+type GetBlocksParams struct {
+	Format         string
+	IncludeOrphans string
+	Limit          string
+	Offset         string
+}
+
+// GetBlocks calls GET /blocks.
+//
+// Handles HTTP GET requests for retrieving a paginated list of blocks.
+func (c *Client) GetBlocks(ctx context.Context, params *GetBlocksParams) (*ExtendedResponse, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+		if params.IncludeOrphans != "" {
+			query.Set("includeOrphans", params.IncludeOrphans)
+		}
+		if params.Limit != "" {
+			query.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			query.Set("offset", params.Offset)
+		}
+	}
+
+	out := new(ExtendedResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/blocks", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetCatchupStatus calls GET /catchup/status.
+//
+// Returns the current catchup status from the BlockValidation service.
+func (c *Client) GetCatchupStatus(ctx context.Context) (map[string]json.RawMessage, error) {
+	var out map[string]json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/catchup/status", nil, nil, &out)
+
+	return out, err
+}
+
+// GetErrorCatalog calls GET /errors.
+//
+// Returns the catalog of the error codes in the code field of the error responses, with their name, category and description, ordered by code.
+func (c *Client) GetErrorCatalog(ctx context.Context) ([]ErrorsCatalogEntry, error) {
+	var out []ErrorsCatalogEntry
+
+	err := c.doJSON(ctx, http.MethodGet, "/errors", nil, nil, &out)
+
+	return out, err
+}
+
+// GetFSMEvents calls GET /fsm/events.
+//
+// Returns the available events for the blockchain FSM.
+func (c *Client) GetFSMEvents(ctx context.Context) (map[string]json.RawMessage, error) {
+	var out map[string]json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/fsm/events", nil, nil, &out)
+
+	return out, err
+}
+
+// GetFSMState calls GET /fsm/state.
+//
+// Retrieves the current FSM state from the blockchain service.
+func (c *Client) GetFSMState(ctx context.Context) (map[string]json.RawMessage, error) {
+	var out map[string]json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/fsm/state", nil, nil, &out)
+
+	return out, err
+}
+
+// GetFSMStates calls GET /fsm/states.
+//
+// Returns all possible FSM states.
+func (c *Client) GetFSMStates(ctx context.Context) ([]map[string]json.RawMessage, error) {
+	var out []map[string]json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/fsm/states", nil, nil, &out)
+
+	return out, err
+}
+
+// GetIdentityParams are the query parameters of GetIdentity.
+type GetIdentityParams struct {
+	Challenge string
+}
+
+// GetIdentity calls GET /identity.
+//
+// Returns the identity document of this node for the challenge query parameter: the peer ID and the DataHub URL of the node, signed with its peer key.
+func (c *Client) GetIdentity(ctx context.Context, params *GetIdentityParams) (json.RawMessage, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Challenge != "" {
+			query.Set("challenge", params.Challenge)
+		}
+	}
+
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/identity", query, nil, &out)
+
+	return out, err
+}
+
+// GetLastNBlocksParams are the query parameters of GetLastNBlocks.
+type GetLastNBlocksParams struct {
+	Format         string
+	FromHeight     string
+	IncludeOrphans string
+	N              string
+}
+
+// GetLastNBlocks calls GET /lastblocks.
+//
+// Handles HTTP GET requests to retrieve the most recent blocks in the blockchain.
+func (c *Client) GetLastNBlocks(ctx context.Context, params *GetLastNBlocksParams) (json.RawMessage, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+		if params.FromHeight != "" {
+			query.Set("fromHeight", params.FromHeight)
+		}
+		if params.IncludeOrphans != "" {
+			query.Set("includeOrphans", params.IncludeOrphans)
+		}
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/lastblocks", query, nil, &out)
+
+	return out, err
+}
+
+// GetLastNInvalidBlocksParams are the query parameters of GetLastNInvalidBlocks.
+type GetLastNInvalidBlocksParams struct {
+	Count string
+}
+
+// GetLastNInvalidBlocks calls GET /blocks/invalid.
+//
+// Handles HTTP requests to retrieve the last N invalid blocks.
+func (c *Client) GetLastNInvalidBlocks(ctx context.Context, params *GetLastNInvalidBlocksParams) (map[string]json.RawMessage, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Count != "" {
+			query.Set("count", params.Count)
+		}
+	}
+
+	var out map[string]json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/blocks/invalid", query, nil, &out)
+
+	return out, err
+}
+
+// GetLegacyBlockParams are the query parameters of GetLegacyBlock.
+type GetLegacyBlockParams struct {
+	Wire string
+}
+
+// GetLegacyBlock calls GET /block_legacy/{hash}.
+//
+// Streams a block in the legacy Bitcoin protocol format.
+func (c *Client) GetLegacyBlock(ctx context.Context, hash string, params *GetLegacyBlockParams) ([]byte, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Wire != "" {
+			query.Set("wire", params.Wire)
+		}
+	}
+
+	return c.do(ctx, http.MethodGet, "/block_legacy/"+url.PathEscape(hash), query, nil, "", "application/octet-stream")
+}
+
+// GetLogLevels calls GET /loglevels.
+//
+// Returns the default log level and the level of every logger component of this process.
+func (c *Client) GetLogLevels(ctx context.Context) (*LogLevelsResponse, error) {
+	out := new(LogLevelsResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/loglevels", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetMerkleProof calls GET /merkle_proof/{hash}.
+//
+// Retrieving a merkle proof for a transaction in BSV Unified Merkle Path (BUMP) format as defined in BRC-74 (binary).
+func (c *Client) GetMerkleProof(ctx context.Context, hash string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/merkle_proof/"+url.PathEscape(hash), nil, nil, "", "application/octet-stream")
+}
+
+// GetMerkleProofHex calls GET /merkle_proof/{hash}/hex.
+//
+// Retrieving a merkle proof for a transaction in BSV Unified Merkle Path (BUMP) format as defined in BRC-74 (hex).
+func (c *Client) GetMerkleProofHex(ctx context.Context, hash string) (string, error) {
+	b, err := c.do(ctx, http.MethodGet, "/merkle_proof/"+url.PathEscape(hash)+"/hex", nil, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetMerkleProofJSON calls GET /merkle_proof/{hash}/json.
+//
+// Retrieving a merkle proof for a transaction in BSV Unified Merkle Path (BUMP) format as defined in BRC-74 (JSON).
+func (c *Client) GetMerkleProofJSON(ctx context.Context, hash string) (*BumpFormat, error) {
+	out := new(BumpFormat)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/merkle_proof/"+url.PathEscape(hash)+"/json", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetNBlocksParams are the query parameters of GetNBlocks.
+type GetNBlocksParams struct {
+	N string
+}
+
+// GetNBlocks calls GET /blocks/{hash}.
+//
+// Retrieving multiple consecutive blocks starting from a specific block hash (binary).
+func (c *Client) GetNBlocks(ctx context.Context, hash string, params *GetNBlocksParams) ([]byte, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	return c.do(ctx, http.MethodGet, "/blocks/"+url.PathEscape(hash), query, nil, "", "application/octet-stream")
+}
+
+// GetNBlocksHexParams are the query parameters of GetNBlocksHex.
+type GetNBlocksHexParams struct {
+	N string
+}
+
+// GetNBlocksHex calls GET /blocks/{hash}/hex.
+//
+// Retrieving multiple consecutive blocks starting from a specific block hash (hex).
+func (c *Client) GetNBlocksHex(ctx context.Context, hash string, params *GetNBlocksHexParams) (string, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	b, err := c.do(ctx, http.MethodGet, "/blocks/"+url.PathEscape(hash)+"/hex", query, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetNBlocksJSONParams are the query parameters of GetNBlocksJSON.
+type GetNBlocksJSONParams struct {
+	N string
+}
+
+// GetNBlocksJSON calls GET /blocks/{hash}/json.
+//
+// Retrieving multiple consecutive blocks starting from a specific block hash (JSON).
+func (c *Client) GetNBlocksJSON(ctx context.Context, hash string, params *GetNBlocksJSONParams) (json.RawMessage, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.N != "" {
+			query.Set("n", params.N)
+		}
+	}
+
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/blocks/"+url.PathEscape(hash)+"/json", query, nil, &out)
+
+	return out, err
+}
+
+// GetNetworkOverview calls GET /network/overview.
+//
+// Returns an aggregate view of the network as seen by this node, computed by the P2P service from its peer registry.
+func (c *Client) GetNetworkOverview(ctx context.Context) (*NetworkOverviewResponse, error) {
+	out := new(NetworkOverviewResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/network/overview", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetOpenAPI calls GET /openapi.json.
+//
+// Returns the OpenAPI 3 document of the asset API, describing the parameters, request bodies and responses of its routes.
+func (c *Client) GetOpenAPI(ctx context.Context) (json.RawMessage, error) {
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/openapi.json", nil, nil, &out)
+
+	return out, err
+}
+
+// GetOperatorMessagesParams are the query parameters of GetOperatorMessages.
+type GetOperatorMessagesParams struct {
+	Limit  string
+	PeerID string
+}
+
+// GetOperatorMessages calls GET /operator-messages.
+//
+// Returns the most recent direct messages sent to and received from the operators of other nodes, newest first.
+func (c *Client) GetOperatorMessages(ctx context.Context, params *GetOperatorMessagesParams) (*OperatorMessagesResponse, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Limit != "" {
+			query.Set("limit", params.Limit)
+		}
+		if params.PeerID != "" {
+			query.Set("peer_id", params.PeerID)
+		}
+	}
+
+	out := new(OperatorMessagesResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/operator-messages", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetPeerContributionsParams are the query parameters of GetPeerContributions.
+type GetPeerContributionsParams struct {
+	PeerID string
+}
+
+// GetPeerContributions calls GET /peers/contributions.
+//
+// Returns the lifetime totals of the data each peer served to us and we served to it, the peers contributing the least listed first.
+func (c *Client) GetPeerContributions(ctx context.Context, params *GetPeerContributionsParams) (*PeerContributionsResponse, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.PeerID != "" {
+			query.Set("peer_id", params.PeerID)
+		}
+	}
+
+	out := new(PeerContributionsResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/peers/contributions", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetPeersParams are the query parameters of GetPeers.
+type GetPeersParams struct {
+	Format string
+}
+
+// GetPeers calls GET /peers.
+//
+// Returns the current peer registry data from the P2P service.
+func (c *Client) GetPeers(ctx context.Context, params *GetPeersParams) (*PeersResponse, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Format != "" {
+			query.Set("format", params.Format)
+		}
+	}
+
+	out := new(PeersResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/peers", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetPeersStats calls GET /peers/stats.
+//
+// Returns aggregate statistics over the peer registry, so dashboards don't have to pull the full peer list to compute summaries.
+func (c *Client) GetPeersStats(ctx context.Context) (*PeersStatsResponse, error) {
+	out := new(PeersStatsResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/peers/stats", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetSubtree calls GET /subtree/{hash}.
+//
+// Retrieving subtree data in multiple formats (binary).
+func (c *Client) GetSubtree(ctx context.Context, hash string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/subtree/"+url.PathEscape(hash), nil, nil, "", "application/octet-stream")
+}
+
+// GetSubtreeData calls GET /subtree_data/{hash}.
+//
+// Streams all transactions of a subtree.
+func (c *Client) GetSubtreeData(ctx context.Context, hash string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/subtree_data/"+url.PathEscape(hash), nil, nil, "", "application/octet-stream")
+}
+
+// GetSubtreeHex calls GET /subtree/{hash}/hex.
+//
+// Retrieving subtree data in multiple formats (hex).
+func (c *Client) GetSubtreeHex(ctx context.Context, hash string) (string, error) {
+	b, err := c.do(ctx, http.MethodGet, "/subtree/"+url.PathEscape(hash)+"/hex", nil, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetSubtreeJSON calls GET /subtree/{hash}/json.
+//
+// Retrieving subtree data in multiple formats (JSON).
+func (c *Client) GetSubtreeJSON(ctx context.Context, hash string) (json.RawMessage, error) {
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/subtree/"+url.PathEscape(hash)+"/json", nil, nil, &out)
+
+	return out, err
+}
+
+// GetSubtreeTxsJSONParams are the query parameters of GetSubtreeTxsJSON.
+type GetSubtreeTxsJSONParams struct {
+	Limit  string
+	Offset string
+}
+
+// GetSubtreeTxsJSON calls GET /subtree/{hash}/txs/json.
+//
+// Retrieving transaction details from a subtreepkg with pagination support (JSON).
+func (c *Client) GetSubtreeTxsJSON(ctx context.Context, hash string, params *GetSubtreeTxsJSONParams) (*ExtendedResponse, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Limit != "" {
+			query.Set("limit", params.Limit)
+		}
+		if params.Offset != "" {
+			query.Set("offset", params.Offset)
+		}
+	}
+
+	out := new(ExtendedResponse)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/subtree/"+url.PathEscape(hash)+"/txs/json", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetTransaction calls GET /tx/{hash}.
+//
+// Retrieving transaction data in multiple formats (binary).
+func (c *Client) GetTransaction(ctx context.Context, hash string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/tx/"+url.PathEscape(hash), nil, nil, "", "application/octet-stream")
+}
+
+// GetTransactionHex calls GET /tx/{hash}/hex.
+//
+// Retrieving transaction data in multiple formats (hex).
+func (c *Client) GetTransactionHex(ctx context.Context, hash string) (string, error) {
+	b, err := c.do(ctx, http.MethodGet, "/tx/"+url.PathEscape(hash)+"/hex", nil, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetTransactionJSON calls GET /tx/{hash}/json.
+//
+// Retrieving transaction data in multiple formats (JSON).
+func (c *Client) GetTransactionJSON(ctx context.Context, hash string) (json.RawMessage, error) {
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/tx/"+url.PathEscape(hash)+"/json", nil, nil, &out)
+
+	return out, err
+}
+
+// GetTransactionMetaJSON calls GET /txmeta/{hash}/json.
+//
+// Retrieving transaction metadata (JSON).
+func (c *Client) GetTransactionMetaJSON(ctx context.Context, hash string) (map[string]json.RawMessage, error) {
+	var out map[string]json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/txmeta/"+url.PathEscape(hash)+"/json", nil, nil, &out)
+
+	return out, err
+}
+
+// GetTransactions calls POST /txs.
+//
+// Retrieving multiple transactions in a single request.
+func (c *Client) GetTransactions(ctx context.Context, body []byte) ([]byte, error) {
+	return c.do(ctx, http.MethodPost, "/txs", nil, body, "application/octet-stream", "application/octet-stream")
+}
+
+// GetTransactionsSubtreeTxsByHash calls POST /subtree/{hash}/txs.
+//
+// Retrieving multiple transactions in a single request.
+func (c *Client) GetTransactionsSubtreeTxsByHash(ctx context.Context, hash string, body []byte) ([]byte, error) {
+	return c.do(ctx, http.MethodPost, "/subtree/"+url.PathEscape(hash)+"/txs", nil, body, "application/octet-stream", "application/octet-stream")
+}
+
+// GetTransactionsTxsByHash calls POST /{hash}/txs.
+//
+// Retrieving multiple transactions in a single request.
+func (c *Client) GetTransactionsTxsByHash(ctx context.Context, hash string, body []byte) ([]byte, error) {
+	return c.do(ctx, http.MethodPost, "/"+url.PathEscape(hash)+"/txs", nil, body, "application/octet-stream", "application/octet-stream")
+}
+
+// GetTxMetaByTxID calls GET /txmeta_raw/{hash}.
+//
+// Retrieving transaction metadata directly from the Aerospike store (binary).
+func (c *Client) GetTxMetaByTxID(ctx context.Context, hash string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/txmeta_raw/"+url.PathEscape(hash), nil, nil, "", "application/octet-stream")
+}
+
+// GetTxMetaByTxIDHex calls GET /txmeta_raw/{hash}/hex.
+//
+// Retrieving transaction metadata directly from the Aerospike store (hex).
+func (c *Client) GetTxMetaByTxIDHex(ctx context.Context, hash string) (string, error) {
+	b, err := c.do(ctx, http.MethodGet, "/txmeta_raw/"+url.PathEscape(hash)+"/hex", nil, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetTxMetaByTxIDJSON calls GET /txmeta_raw/{hash}/json.
+//
+// Retrieving transaction metadata directly from the Aerospike store (JSON).
+func (c *Client) GetTxMetaByTxIDJSON(ctx context.Context, hash string) error {
+	_, err := c.do(ctx, http.MethodGet, "/txmeta_raw/"+url.PathEscape(hash)+"/json", nil, nil, "", "application/json")
+
+	return err
+}
+
+// GetUTXO calls GET /utxo/{hash}.
+//
+// Retrieving unspent transaction output (UTXO) information (binary).
+func (c *Client) GetUTXO(ctx context.Context, hash string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/utxo/"+url.PathEscape(hash), nil, nil, "", "application/octet-stream")
+}
+
+// GetUTXOHex calls GET /utxo/{hash}/hex.
+//
+// Retrieving unspent transaction output (UTXO) information (hex).
+func (c *Client) GetUTXOHex(ctx context.Context, hash string) (string, error) {
+	b, err := c.do(ctx, http.MethodGet, "/utxo/"+url.PathEscape(hash)+"/hex", nil, nil, "", "text/plain")
+
+	return string(b), err
+}
+
+// GetUTXOJSON calls GET /utxo/{hash}/json.
+//
+// Retrieving unspent transaction output (UTXO) information (JSON).
+func (c *Client) GetUTXOJSON(ctx context.Context, hash string) (json.RawMessage, error) {
+	var out json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodGet, "/utxo/"+url.PathEscape(hash)+"/json", nil, nil, &out)
+
+	return out, err
+}
+
+// GetUTXOsByTxIDJSON calls GET /utxos/{hash}/json.
+//
+// Retrieving all UTXOs associated with a transaction (JSON).
+func (c *Client) GetUTXOsByTxIDJSON(ctx context.Context, hash string) ([]UTXOItem, error) {
+	var out []UTXOItem
+
+	err := c.doJSON(ctx, http.MethodGet, "/utxos/"+url.PathEscape(hash)+"/json", nil, nil, &out)
+
+	return out, err
+}
+
+// GetVersion calls GET /version.
+//
+// Returns the build version, commit and build date of this node, the features it was built with, the chain network and the services running in this process with their protocol versions.
+func (c *Client) GetVersion(ctx context.Context) (*BuildinfoInfo, error) {
+	out := new(BuildinfoInfo)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/version", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// InvalidateBlock calls POST /block/invalidate.
+//
+// Handles HTTP requests to invalidate a block.
+func (c *Client) InvalidateBlock(ctx context.Context, body *BlockRequest) (map[string]json.RawMessage, error) {
+	var out map[string]json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodPost, "/block/invalidate", nil, body, &out)
+
+	return out, err
+}
+
+// RevalidateBlock calls POST /block/revalidate.
+//
+// Handles HTTP requests to revalidate a previously invalidated block.
+func (c *Client) RevalidateBlock(ctx context.Context, body *BlockRequest) (map[string]json.RawMessage, error) {
+	var out map[string]json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodPost, "/block/revalidate", nil, body, &out)
+
+	return out, err
+}
+
+// SearchParams are the query parameters of Search.
+type SearchParams struct {
+	Q string
+}
+
+// Search calls GET /search.
+//
+// Searches for blockchain entities by hash or block height.
+func (c *Client) Search(ctx context.Context, params *SearchParams) (*Res, error) {
+	query := url.Values{}
+
+	if params != nil {
+		if params.Q != "" {
+			query.Set("q", params.Q)
+		}
+	}
+
+	out := new(Res)
+
+	if err := c.doJSON(ctx, http.MethodGet, "/search", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// SendFSMEvent calls POST /fsm/state.
+//
+// Sends a custom event to the blockchain FSM.
+func (c *Client) SendFSMEvent(ctx context.Context, body *SendFSMEventRequest) (map[string]json.RawMessage, error) {
+	var out map[string]json.RawMessage
+
+	err := c.doJSON(ctx, http.MethodPost, "/fsm/state", nil, body, &out)
+
+	return out, err
+}
+
+// SendOperatorMessage calls POST /operator-messages.
+//
+// Sends a direct message to the operator of the peer of the request, encrypted to the peer.
+func (c *Client) SendOperatorMessage(ctx context.Context, body *SendOperatorMessageRequest) (*OperatorMessageResponse, error) {
+	out := new(OperatorMessageResponse)
+
+	if err := c.doJSON(ctx, http.MethodPost, "/operator-messages", nil, body, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// SetBandwidth calls POST /bandwidth.
+//
+// Adjusts the bandwidth budget and class weights at runtime.
+func (c *Client) SetBandwidth(ctx context.Context, body *BandwidthRequest) (*BandwidthResponse, error) {
+	out := new(BandwidthResponse)
+
+	if err := c.doJSON(ctx, http.MethodPost, "/bandwidth", nil, body, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// SetLogLevel calls POST /loglevels.
+//
+// Changes the log level of a single component, e.g.
+func (c *Client) SetLogLevel(ctx context.Context, body *LogLevelRequest) (*LogLevelsResponse, error) {
+	out := new(LogLevelsResponse)
+
+	if err := c.doJSON(ctx, http.MethodPost, "/loglevels", nil, body, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// UnbanPeersParams are the query parameters of UnbanPeers.
+type UnbanPeersParams struct {
+	Target []string
+}
+
+// UnbanPeers calls DELETE /bans.
+//
+// Lifts the bans of the targets of the request, given in the body or as target query parameters, e.g.
+func (c *Client) UnbanPeers(ctx context.Context, params *UnbanPeersParams, body *UnbanRequest) (*BanResultsResponse, error) {
+	query := url.Values{}
+
+	if params != nil {
+		for _, v := range params.Target {
+			query.Add("target", v)
+		}
+	}
+
+	out := new(BanResultsResponse)
+
+	if err := c.doJSON(ctx, http.MethodDelete, "/bans", query, body, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
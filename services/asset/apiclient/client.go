@@ -0,0 +1,154 @@
+// Package apiclient is a typed Go client of the HTTP API of the asset service, for integrators reading blocks,
+// transactions, subtrees and the peer registry of a Teranode node.
+//
+// The types and the methods of Client are generated in client.gen.go from the OpenAPI document the asset service
+// serves at /api/v1/openapi.json, and are regenerated with it by go generate ./services/asset/httpimpl.
+//
+// Usage:
+//
+//	client := apiclient.NewClient("http://localhost:8090/api/v1", nil)
+//
+//	peers, err := client.GetPeers(ctx)
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// maxErrorBodySize is the maximum size of an error response read for its message
+const maxErrorBodySize = 64 * 1024
+
+// Client calls the HTTP API of an asset service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the asset API at baseURL, including the API prefix, e.g.
+// http://localhost:8090/api/v1. The requests are sent with http.DefaultClient when httpClient is nil.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// doJSON sends the request with the JSON encoding of in as body, when not nil, and decodes the JSON response into
+// out, when not nil
+func (c *Client) doJSON(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	var (
+		body        []byte
+		contentType string
+		err         error
+	)
+
+	if in != nil {
+		if body, err = json.Marshal(in); err != nil {
+			return errors.NewInvalidArgumentError("failed to encode the request of %s %s", method, path, err)
+		}
+
+		contentType = "application/json"
+	}
+
+	b, err := c.do(ctx, method, path, query, body, contentType, "application/json")
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err = json.Unmarshal(b, out); err != nil {
+		return errors.NewProcessingError("failed to decode the response of %s %s", method, path, err)
+	}
+
+	return nil
+}
+
+// do sends the request and returns the body of a successful response. A response with any other status than 2xx
+// is returned as an error with the message of its body.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body []byte, contentType, accept string) ([]byte, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, errors.NewInvalidArgumentError("invalid request %s %s", method, u, err)
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.NewServiceUnavailableError("asset API %s %s failed", method, u, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, responseError(method, u, resp)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.NewServiceUnavailableError("failed to read the response of %s %s", method, u, err)
+	}
+
+	return b, nil
+}
+
+// responseError returns the error of a response with a status other than 2xx, with the error message of the JSON
+// error response when the body is one
+func responseError(method, u string, resp *http.Response) error {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	message := strings.TrimSpace(string(b))
+
+	var errResp struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+
+	if err := json.Unmarshal(b, &errResp); err == nil {
+		if errResp.Error != "" {
+			message = errResp.Error
+		} else if errResp.Message != "" {
+			message = errResp.Message
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return errors.NewNotFoundError("asset API %s %s: %s", method, u, message)
+	case http.StatusBadRequest:
+		return errors.NewInvalidArgumentError("asset API %s %s: %s", method, u, message)
+	case http.StatusServiceUnavailable:
+		return errors.NewServiceUnavailableError("asset API %s %s: %s", method, u, message)
+	default:
+		return errors.NewServiceError("asset API %s %s responded with status %d: %s", method, u, resp.StatusCode, message)
+	}
+}
@@ -0,0 +1,123 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_JSONResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v1/peers", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":1,"peers":[{"id":"peer-a"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/api/v1/", server.Client())
+
+	peers, err := client.GetPeers(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), peers.Count)
+	require.Len(t, peers.Peers, 1)
+	assert.Equal(t, "peer-a", peers.Peers[0].ID)
+}
+
+func TestClient_QueryParamsAndBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/v1/bans", r.URL.Path)
+		assert.Equal(t, []string{"1.2.3.4", "5.6.7.8"}, r.URL.Query()["target"])
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var req UnbanRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []string{"peer-a"}, req.Targets)
+
+		_, _ = w.Write([]byte(`{"succeeded":3}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/api/v1", nil)
+
+	resp, err := client.UnbanPeers(context.Background(), &UnbanPeersParams{Target: []string{"1.2.3.4", "5.6.7.8"}}, &UnbanRequest{Targets: []string{"peer-a"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), resp.Succeeded)
+}
+
+func TestClient_BinaryResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/tx/abc%2Fdef", r.URL.EscapedPath())
+		assert.Equal(t, "application/octet-stream", r.Header.Get("Accept"))
+
+		_, _ = w.Write([]byte{0x01, 0x02})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/api/v1", nil)
+
+	b, err := client.GetTransaction(context.Background(), "abc/def")
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte{0x01, 0x02}, b)
+}
+
+func TestClient_BinaryRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/api/v1", nil)
+
+	b, err := client.GetTransactions(context.Background(), []byte("hashes"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("hashes"), b)
+}
+
+func TestClient_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		target error
+	}{
+		{name: "not found", status: http.StatusNotFound, body: `{"status":404,"code":3,"error":"block not found"}`, target: errors.ErrNotFound},
+		{name: "bad request", status: http.StatusBadRequest, body: `{"error":"invalid hash"}`, target: errors.ErrInvalidArgument},
+		{name: "unavailable", status: http.StatusServiceUnavailable, body: `{"message":"P2P service not available"}`, target: errors.ErrServiceUnavailable},
+		{name: "internal", status: http.StatusInternalServerError, body: "boom", target: errors.ErrServiceError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL, nil).GetBlockByHashJSON(context.Background(), "hash")
+			require.Error(t, err)
+
+			assert.True(t, errors.Is(err, tt.target), err.Error())
+		})
+	}
+}
@@ -28,6 +28,9 @@ import (
 //   - includeOrphans: Whether to include orphaned blocks (default: false)
 //     Example: ?includeOrphans=true
 //
+//   - format: Output format, json, csv or ndjson (default: json)
+//     Example: ?format=ndjson
+//
 // Returns:
 //   - error: Any error encountered during processing
 //
@@ -98,6 +101,7 @@ import (
 //   - Total records includes genesis block (height 0)
 //   - Response is pretty-printed JSON for readability
 //   - When includeOrphans=true, orphaned blocks at the same height are included
+//   - With format=csv or format=ndjson only the blocks are streamed, one per row or line, without the pagination
 func (h *HTTP) GetBlocks(c echo.Context) error {
 	ctx, _, deferFn := tracing.Tracer("asset").Start(c.Request().Context(), "GetBlocks_http",
 		tracing.WithParentStat(AssetStat),
@@ -113,6 +117,11 @@ func (h *HTTP) GetBlocks(c echo.Context) error {
 
 	includeOrphans := c.QueryParam("includeOrphans") == "true"
 
+	format, err := listFormat(c)
+	if err != nil {
+		return err
+	}
+
 	// First we find the latest block height
 	_, blockMeta, err := h.repository.GetBestBlockHeader(ctx)
 	if err != nil {
@@ -139,6 +148,10 @@ func (h *HTTP) GetBlocks(c echo.Context) error {
 
 	prometheusAssetHTTPGetLastNBlocks.WithLabelValues("OK", "200").Inc()
 
+	if format != listFormatJSON {
+		return writeList(c, format, "blocks", blocks)
+	}
+
 	response := ExtendedResponse{
 		Data: blocks,
 		Pagination: Pagination{
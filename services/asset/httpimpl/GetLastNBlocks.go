@@ -29,6 +29,9 @@ import (
 //   - includeOrphans: Whether to include orphaned blocks (default: false)
 //     Example: ?includeOrphans=true
 //
+//   - format: Output format, json, csv or ndjson (default: json)
+//     Example: ?format=csv
+//
 // Returns:
 //   - error: Any error encountered during processing
 //
@@ -58,6 +61,8 @@ import (
 //   - Invalid 'n' parameter
 //
 //   - Invalid fromHeight parameter
+//
+//   - Invalid format parameter
 //     Example: {"message": "strconv.ParseInt: parsing \"invalid\": invalid syntax"}
 //
 //   - 404 Not Found:
@@ -93,6 +98,7 @@ import (
 //   - When fromHeight is specified, counting starts from that height downward
 //   - Response is pretty-printed JSON for readability
 //   - When includeOrphans=true, orphaned blocks at the same height are included
+//   - With format=csv or format=ndjson the blocks are streamed one per row or line
 func (h *HTTP) GetLastNBlocks(c echo.Context) error {
 	queryN := c.QueryParam("n")
 	queryFromHeight := c.QueryParam("fromHeight")
@@ -124,6 +130,11 @@ func (h *HTTP) GetLastNBlocks(c echo.Context) error {
 
 	includeOrphans := c.QueryParam("includeOrphans") == "true"
 
+	format, err := listFormat(c)
+	if err != nil {
+		return err
+	}
+
 	fromHeightUint32, err := safeconversion.Uint64ToUint32(fromHeight)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, errors.NewInvalidArgumentError("invalid 'fromHeight' parameter", err).Error())
@@ -140,5 +151,9 @@ func (h *HTTP) GetLastNBlocks(c echo.Context) error {
 
 	prometheusAssetHTTPGetLastNBlocks.WithLabelValues("OK", "200").Inc()
 
+	if format != listFormatJSON {
+		return writeList(c, format, "blocks", blocks)
+	}
+
 	return c.JSONPretty(200, blocks, "  ")
 }
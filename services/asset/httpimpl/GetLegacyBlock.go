@@ -11,6 +11,8 @@ import (
 )
 
 // GetLegacyBlock creates an HTTP handler that streams a block in the legacy Bitcoin protocol format.
+// The block is streamed in chunks as it is generated. Byte ranges of the block can be requested with the Range header
+// to resume a download, and HEAD requests return the size of the block without streaming it.
 //
 // Parameters:
 //   - c: Echo context containing the HTTP request and response
@@ -18,14 +20,24 @@ import (
 // URL Parameters:
 //   - hash: Block hash (hex string)
 //
+// Query Parameters:
+//   - wire: Stream the block in wire format, without the magic number and block size
+//
+// Request Headers:
+//   - Range: Optional single byte range, e.g. "bytes=1048576-"
+//   - If-Range: Optional ETag, the range is only served when it matches the ETag of the block
+//
 // Returns:
 //   - error: Any error encountered during processing
 //
 // HTTP Response:
 //
-//	Status: 200 OK
+//	Status: 200 OK, or 206 Partial Content for a range request
 //	Content-Type: application/octet-stream
-//	Body: Legacy format block data:
+//	Accept-Ranges: bytes
+//	ETag: "<hash>", or "<hash>-wire" for the wire format
+//	Content-Range: bytes <start>-<end>/<size>, for a range request
+//	Body: Legacy format block data, or the requested range of it:
 //	  - Magic number (4 bytes): 0xf9, 0xbe, 0xb4, 0xd9
 //	  - Block size (4 bytes): little-endian uint32
 //	  - Block header (80 bytes)
@@ -36,6 +48,7 @@ import (
 //
 // Error Responses:
 //   - 404 Not Found: Block not found
+//   - 416 Range Not Satisfiable: The range starts after the end of the block
 //   - 500 Internal Server Error:
 //   - Invalid block hash format
 //   - Block retrieval errors
@@ -68,8 +81,7 @@ func (h *HTTP) GetLegacyBlock() func(c echo.Context) error {
 
 		wireBlock := c.QueryParam("wire") != ""
 
-		r, err := h.repository.GetLegacyBlockReader(ctx, hash, wireBlock)
-		if err != nil {
+		return h.serveLegacyBlock(ctx, c, hash, wireBlock, func(err error) error {
 			if errors.Is(err, errors.ErrNotFound) || strings.Contains(err.Error(), "not found") {
 				prometheusAssetHTTPGetBlockLegacy.WithLabelValues("ERROR", http.StatusText(http.StatusNotFound)).Inc()
 				return echo.NewHTTPError(http.StatusNotFound, err.Error())
@@ -77,11 +89,7 @@ func (h *HTTP) GetLegacyBlock() func(c echo.Context) error {
 				prometheusAssetHTTPGetBlockLegacy.WithLabelValues("ERROR", http.StatusText(http.StatusInternalServerError)).Inc()
 				return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 			}
-		}
-
-		prometheusAssetHTTPGetBlockLegacy.WithLabelValues("OK", "200").Inc()
-
-		return c.Stream(http.StatusOK, echo.MIMEOctetStream, r)
+		})
 	}
 }
 
@@ -92,7 +100,7 @@ func (h *HTTP) GetLegacyBlock() func(c echo.Context) error {
 //   - hash: Block hash followed by ".bin" extension
 //     Example: "000000...hash.bin"
 //
-// All other aspects (response format, errors, monitoring) are identical to GetLegacyBlock.
+// All other aspects (response format, range requests, errors, monitoring) are identical to GetLegacyBlock.
 //
 // Example Usage:
 //
@@ -122,8 +130,7 @@ func (h *HTTP) GetRestLegacyBlock() func(c echo.Context) error {
 			return echo.NewHTTPError(http.StatusBadRequest, errors.NewInvalidArgumentError("invalid block hash string", err).Error())
 		}
 
-		r, err := h.repository.GetLegacyBlockReader(ctx, hash)
-		if err != nil {
+		return h.serveLegacyBlock(ctx, c, hash, false, func(err error) error {
 			if errors.Is(err, errors.ErrNotFound) || strings.Contains(err.Error(), "not found") {
 				prometheusAssetHTTPGetBlockLegacy.WithLabelValues("ERROR", http.StatusText(http.StatusNotFound)).Inc()
 
@@ -133,10 +140,6 @@ func (h *HTTP) GetRestLegacyBlock() func(c echo.Context) error {
 
 				return echo.NewHTTPError(http.StatusInternalServerError, errors.NewProcessingError("error getting block", err).Error())
 			}
-		}
-
-		prometheusAssetHTTPGetBlockLegacy.WithLabelValues("OK", "200").Inc()
-
-		return c.Stream(http.StatusOK, echo.MIMEOctetStream, r)
+		})
 	}
 }
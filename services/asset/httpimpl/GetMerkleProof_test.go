@@ -618,6 +618,10 @@ func (m *MockRepositoryForMerkleProof) GetLegacyBlockReader(ctx context.Context,
 	return nil, nil
 }
 
+func (m *MockRepositoryForMerkleProof) GetLegacyBlockSize(ctx context.Context, hash *chainhash.Hash, wireBlock ...bool) (uint64, error) {
+	return 0, nil
+}
+
 func (m *MockRepositoryForMerkleProof) GetBlockLocator(ctx context.Context, blockHeaderHash *chainhash.Hash, height uint32) ([]*chainhash.Hash, error) {
 	return nil, nil
 }
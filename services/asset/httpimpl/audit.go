@@ -0,0 +1,60 @@
+package httpimpl
+
+import (
+	"net/http"
+
+	"github.com/bsv-blockchain/teranode/util/audit"
+	"github.com/labstack/echo/v4"
+)
+
+// anonymousActor is the user of the audit records of requests without basic auth credentials
+const anonymousActor = "anonymous"
+
+// GetAuditLog returns the audit records of the privileged operations performed on the node, newest first, with
+// pagination through the offset and limit query parameters
+func (h *HTTP) GetAuditLog(c echo.Context) error {
+	offset, limit, err := h.getLimitOffset(c)
+	if err != nil {
+		return err
+	}
+
+	if offset < 0 || limit <= 0 {
+		return sendStatusError(c, http.StatusBadRequest, "offset must not be negative and limit must be positive")
+	}
+
+	if h.auditLog == nil {
+		return sendStatusError(c, http.StatusServiceUnavailable, "Audit log not available")
+	}
+
+	records, total, err := h.auditLog.Records(c.Request().Context(), offset, limit)
+	if err != nil {
+		h.logger.Errorf("[GetAuditLog] Failed to get audit records: %v", err)
+
+		return sendStatusError(c, http.StatusInternalServerError, "Failed to get audit records")
+	}
+
+	return c.JSON(http.StatusOK, ExtendedResponse{
+		Data: records,
+		Pagination: Pagination{
+			Offset:       offset,
+			Limit:        limit,
+			TotalRecords: total,
+		},
+	})
+}
+
+// auditActor returns the actor of the request, the basic auth user at the address of the client
+func auditActor(c echo.Context) string {
+	user, _, ok := c.Request().BasicAuth()
+	if !ok || user == "" {
+		user = anonymousActor
+	}
+
+	return user + "@" + c.RealIP()
+}
+
+// recordAudit records the privileged operation performed by the request in the audit log, nothing is recorded
+// without an audit log
+func recordAudit(auditLog *audit.Logger, c echo.Context, action, target, details string) {
+	auditLog.Record(c.Request().Context(), auditActor(c), action, target, details)
+}
@@ -0,0 +1,102 @@
+package httpimpl
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bsv-blockchain/teranode/util/audit"
+	"github.com/bsv-blockchain/teranode/util/bandwidth"
+	"github.com/labstack/echo/v4"
+)
+
+// BandwidthResponse represents the JSON response of the bandwidth budget endpoints
+type BandwidthResponse struct {
+	Limit   uint64                 `json:"limit"`
+	Classes []bandwidth.ClassStats `json:"classes"`
+}
+
+// BandwidthRequest represents the JSON body to adjust the bandwidth budget at runtime.
+// Fields that are not set are left unchanged.
+type BandwidthRequest struct {
+	Limit   *uint64                     `json:"limit,omitempty"`
+	Weights map[bandwidth.Class]float64 `json:"weights,omitempty"`
+}
+
+// bandwidthResponseWriter paces the response body through the bandwidth budget
+type bandwidthResponseWriter struct {
+	http.ResponseWriter
+	w io.Writer
+}
+
+func (b *bandwidthResponseWriter) Write(p []byte) (int, error) {
+	return b.w.Write(p)
+}
+
+func (b *bandwidthResponseWriter) Flush() {
+	if flusher, ok := b.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap gives http.ResponseController access to the underlying writer
+func (b *bandwidthResponseWriter) Unwrap() http.ResponseWriter {
+	return b.ResponseWriter
+}
+
+// bandwidthMiddleware accounts DataHub responses to the bandwidth budget. It is registered with Echo#Pre,
+// so it wraps the response writer before the gzip middleware and the compressed bytes are accounted.
+func (h *HTTP) bandwidthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		path := c.Request().URL.Path
+		if !strings.HasPrefix(path, h.settings.Asset.APIPrefix) && !strings.HasPrefix(path, "/rest/") {
+			return next(c)
+		}
+
+		res := c.Response()
+		res.Writer = &bandwidthResponseWriter{
+			ResponseWriter: res.Writer,
+			w:              bandwidth.NewWriter(c.Request().Context(), h.bandwidth, bandwidth.ClassDataHub, res.Writer),
+		}
+
+		return next(c)
+	}
+}
+
+// GetBandwidth returns the bandwidth budget and the current share of each traffic class
+func (h *HTTP) GetBandwidth(c echo.Context) error {
+	return c.JSON(http.StatusOK, BandwidthResponse{
+		Limit:   h.bandwidth.Limit(),
+		Classes: h.bandwidth.Stats(),
+	})
+}
+
+// SetBandwidth adjusts the bandwidth budget and class weights at runtime
+func (h *HTTP) SetBandwidth(c echo.Context) error {
+	var req BandwidthRequest
+	if err := c.Bind(&req); err != nil {
+		return sendStatusError(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	for class, weight := range req.Weights {
+		if weight <= 0 {
+			return sendStatusError(c, http.StatusBadRequest, "Weight of class "+string(class)+" must be positive")
+		}
+	}
+
+	if req.Limit != nil {
+		h.bandwidth.SetLimit(*req.Limit)
+	}
+
+	for class, weight := range req.Weights {
+		h.bandwidth.SetWeight(class, weight)
+	}
+
+	h.logger.Infof("[SetBandwidth] bandwidth budget adjusted: limit=%d bytes/s, weights=%v", h.bandwidth.Limit(), req.Weights)
+
+	recordAudit(h.auditLog, c, audit.ActionSetBandwidth, "bandwidth",
+		fmt.Sprintf("limit=%d weights=%v", h.bandwidth.Limit(), req.Weights))
+
+	return h.GetBandwidth(c)
+}
@@ -0,0 +1,81 @@
+package httpimpl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/bandwidth"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidthEndpoints(t *testing.T) {
+	newHTTP := func() *HTTP {
+		return &HTTP{
+			logger:    ulogger.TestLogger{},
+			bandwidth: bandwidth.NewScheduler(bandwidth.Config{Limit: 1000}),
+		}
+	}
+
+	doRequest := func(h *HTTP, method string, body string) *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(method, "/api/v1/bandwidth", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var err error
+		if method == http.MethodPost {
+			err = h.SetBandwidth(c)
+		} else {
+			err = h.GetBandwidth(c)
+		}
+
+		require.NoError(t, err)
+
+		return rec
+	}
+
+	t.Run("get", func(t *testing.T) {
+		rec := doRequest(newHTTP(), http.MethodGet, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp BandwidthResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, uint64(1000), resp.Limit)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		h := newHTTP()
+
+		rec := doRequest(h, http.MethodPost, `{"limit": 5000, "weights": {"catchup": 3}}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp BandwidthResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, uint64(5000), resp.Limit)
+		assert.Equal(t, uint64(5000), h.bandwidth.Limit())
+
+		require.Len(t, resp.Classes, 1)
+		assert.Equal(t, bandwidth.ClassCatchup, resp.Classes[0].Class)
+		assert.InDelta(t, 3.0, resp.Classes[0].Weight, 0.001)
+	})
+
+	t.Run("invalid weight", func(t *testing.T) {
+		h := newHTTP()
+
+		rec := doRequest(h, http.MethodPost, `{"limit": 5000, "weights": {"catchup": 0}}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, uint64(1000), h.bandwidth.Limit(), "nothing is applied when the request is invalid")
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := doRequest(newHTTP(), http.MethodPost, `{`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,260 @@
+package httpimpl
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/audit"
+	"github.com/labstack/echo/v4"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// maxBanTargets is the maximum number of targets of a single bulk ban request
+const maxBanTargets = 1000
+
+// ban target types
+const (
+	banTargetPeerID = "peer_id"
+	banTargetIP     = "ip"
+	banTargetCIDR   = "cidr"
+)
+
+// BanRequest represents the JSON body to ban peer IDs, IP addresses or CIDR subnets in bulk,
+// e.g. {"targets": ["10.0.0.0/24", "12D3KooW..."], "duration": "24h", "reason": "spam"}
+type BanRequest struct {
+	Targets  []string `json:"targets"`
+	Duration string   `json:"duration"`
+	Reason   string   `json:"reason,omitempty"`
+}
+
+// UnbanRequest represents the JSON body to lift the bans of peer IDs, IP addresses or CIDR subnets in bulk
+type UnbanRequest struct {
+	Targets []string `json:"targets"`
+}
+
+// BanEntry is a banned peer ID, IP address or CIDR subnet
+type BanEntry struct {
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// BanResult is the outcome of banning or unbanning a single target of a bulk request
+type BanResult struct {
+	Target string `json:"target"`
+	Type   string `json:"type,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BanResultsResponse represents the JSON response of the bulk ban and unban endpoints
+type BanResultsResponse struct {
+	Succeeded int         `json:"succeeded"`
+	Failed    int         `json:"failed"`
+	Until     int64       `json:"until,omitempty"` // unix time the bans expire
+	Results   []BanResult `json:"results"`
+}
+
+// GetBans returns the banned peer IDs, IP addresses and CIDR subnets, ordered by target, with pagination
+// through the offset and limit query parameters
+func (h *HTTP) GetBans(c echo.Context) error {
+	offset, limit, err := h.getLimitOffset(c)
+	if err != nil {
+		return err
+	}
+
+	p2pClient := h.repository.GetP2PClient()
+	if p2pClient == nil {
+		return sendStatusError(c, http.StatusServiceUnavailable, "P2P service not available")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	banned, err := p2pClient.ListBanned(ctx)
+	if err != nil {
+		h.logger.Errorf("[GetBans] Failed to list banned peers: %v", err)
+
+		return sendStatusError(c, http.StatusInternalServerError, "Failed to list banned peers")
+	}
+
+	sort.Strings(banned)
+
+	entries := make([]BanEntry, 0, limit)
+
+	for i := offset; i >= 0 && i < len(banned) && len(entries) < limit; i++ {
+		targetType, _ := banTargetType(banned[i])
+		entries = append(entries, BanEntry{Target: banned[i], Type: targetType})
+	}
+
+	return c.JSON(http.StatusOK, ExtendedResponse{
+		Data: entries,
+		Pagination: Pagination{
+			Offset:       offset,
+			Limit:        limit,
+			TotalRecords: len(banned),
+		},
+	})
+}
+
+// BanPeers bans the targets of the request for the requested duration. Every target is banned
+// separately, the response reports the outcome per target and is sent with status 207 when some of the
+// targets could not be banned.
+func (h *HTTP) BanPeers(c echo.Context) error {
+	var req BanRequest
+	if err := c.Bind(&req); err != nil {
+		return sendStatusError(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := validateBanTargets(req.Targets); err != nil {
+		return sendStatusError(c, http.StatusBadRequest, err.Error())
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration <= 0 {
+		return sendStatusError(c, http.StatusBadRequest, "Duration must be a positive duration, e.g. 24h")
+	}
+
+	p2pClient := h.repository.GetP2PClient()
+	if p2pClient == nil {
+		return sendStatusError(c, http.StatusServiceUnavailable, "P2P service not available")
+	}
+
+	until := time.Now().Add(duration).Unix()
+
+	resp := h.applyBans(c.Request().Context(), req.Targets, func(ctx context.Context, target string) error {
+		return p2pClient.BanPeerWithReason(ctx, target, until, req.Reason)
+	})
+	resp.Until = until
+
+	h.logger.Infof("[BanPeers] banned %d of %d targets until %s, reason: %q", resp.Succeeded, len(req.Targets),
+		time.Unix(until, 0).UTC().Format(time.RFC3339), req.Reason)
+
+	if resp.Succeeded > 0 {
+		recordAudit(h.auditLog, c, audit.ActionBan, resp.succeededTargets(),
+			fmt.Sprintf("until=%s reason=%q", time.Unix(until, 0).UTC().Format(time.RFC3339), req.Reason))
+	}
+
+	return c.JSON(banResultsStatus(resp), resp)
+}
+
+// UnbanPeers lifts the bans of the targets of the request, given in the body or as target query
+// parameters, e.g. DELETE /api/v1/bans?target=10.0.0.1&target=10.0.0.0/24
+func (h *HTTP) UnbanPeers(c echo.Context) error {
+	var req UnbanRequest
+	if err := c.Bind(&req); err != nil {
+		return sendStatusError(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	req.Targets = append(req.Targets, c.QueryParams()["target"]...)
+
+	if err := validateBanTargets(req.Targets); err != nil {
+		return sendStatusError(c, http.StatusBadRequest, err.Error())
+	}
+
+	p2pClient := h.repository.GetP2PClient()
+	if p2pClient == nil {
+		return sendStatusError(c, http.StatusServiceUnavailable, "P2P service not available")
+	}
+
+	resp := h.applyBans(c.Request().Context(), req.Targets, p2pClient.UnbanPeer)
+
+	h.logger.Infof("[UnbanPeers] unbanned %d of %d targets", resp.Succeeded, len(req.Targets))
+
+	if resp.Succeeded > 0 {
+		recordAudit(h.auditLog, c, audit.ActionUnban, resp.succeededTargets(), "")
+	}
+
+	return c.JSON(banResultsStatus(resp), resp)
+}
+
+// applyBans calls fn for every valid target, recording the outcome per target
+func (h *HTTP) applyBans(ctx context.Context, targets []string, fn func(ctx context.Context, target string) error) BanResultsResponse {
+	resp := BanResultsResponse{
+		Results: make([]BanResult, 0, len(targets)),
+	}
+
+	for _, target := range targets {
+		target = strings.TrimSpace(target)
+		result := BanResult{Target: target}
+
+		targetType, err := banTargetType(target)
+		if err == nil {
+			result.Type = targetType
+
+			callCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			err = fn(callCtx, target)
+			cancel()
+		}
+
+		if err != nil {
+			result.Error = err.Error()
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp
+}
+
+// succeededTargets returns the targets banned or unbanned successfully, comma separated
+func (resp BanResultsResponse) succeededTargets() string {
+	targets := make([]string, 0, resp.Succeeded)
+
+	for _, result := range resp.Results {
+		if result.Error == "" {
+			targets = append(targets, result.Target)
+		}
+	}
+
+	return strings.Join(targets, ",")
+}
+
+// banResultsStatus returns 200 when all targets succeeded and 207 otherwise
+func banResultsStatus(resp BanResultsResponse) int {
+	if resp.Failed > 0 {
+		return http.StatusMultiStatus
+	}
+
+	return http.StatusOK
+}
+
+func validateBanTargets(targets []string) error {
+	if len(targets) == 0 {
+		return errors.NewInvalidArgumentError("at least one target is required")
+	}
+
+	if len(targets) > maxBanTargets {
+		return errors.NewInvalidArgumentError("at most %d targets are allowed per request, got %d", maxBanTargets, len(targets))
+	}
+
+	return nil
+}
+
+// banTargetType returns whether a ban target is a CIDR subnet, an IP address or a peer ID
+func banTargetType(target string) (string, error) {
+	if strings.Contains(target, "/") {
+		if _, _, err := net.ParseCIDR(target); err != nil {
+			return "", errors.NewInvalidArgumentError("invalid CIDR subnet %s", target)
+		}
+
+		return banTargetCIDR, nil
+	}
+
+	if net.ParseIP(target) != nil {
+		return banTargetIP, nil
+	}
+
+	if _, err := peer.Decode(target); err == nil {
+		return banTargetPeerID, nil
+	}
+
+	return "", errors.NewInvalidArgumentError("invalid ban target %s, expected a peer ID, IP address or CIDR subnet", target)
+}
@@ -0,0 +1,145 @@
+package httpimpl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/asset/repository"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBanPeerID = "12D3KooWKd2kacFFXWtbYtkDAsTP8fhEX1TbunV9Afimr7m1E8Yg"
+
+// banListP2PClient is a P2P client keeping bans in memory
+type banListP2PClient struct {
+	p2p.ClientI
+
+	mu      sync.Mutex
+	banned  map[string]string // target -> reason
+	failFor string
+}
+
+func (c *banListP2PClient) BanPeerWithReason(_ context.Context, addr string, _ int64, reason string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if addr == c.failFor {
+		return errors.NewServiceError("ban failed")
+	}
+
+	c.banned[addr] = reason
+
+	return nil
+}
+
+func (c *banListP2PClient) UnbanPeer(_ context.Context, addr string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.banned, addr)
+
+	return nil
+}
+
+func (c *banListP2PClient) ListBanned(_ context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	banned := make([]string, 0, len(c.banned))
+	for target := range c.banned {
+		banned = append(banned, target)
+	}
+
+	return banned, nil
+}
+
+func TestBanEndpoints(t *testing.T) {
+	newHTTP := func() (*HTTP, *banListP2PClient) {
+		client := &banListP2PClient{banned: make(map[string]string)}
+
+		repo := &repository.Mock{}
+		repo.On("GetP2PClient").Return(client)
+
+		return &HTTP{logger: ulogger.TestLogger{}, repository: repo}, client
+	}
+
+	doRequest := func(handler echo.HandlerFunc, method string, target string, body string) *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, handler(e.NewContext(req, rec)))
+
+		return rec
+	}
+
+	t.Run("ban, list and unban", func(t *testing.T) {
+		h, client := newHTTP()
+
+		rec := doRequest(h.BanPeers, http.MethodPost, "/api/v1/bans",
+			`{"targets": ["10.0.0.1", "10.0.0.0/24", "`+testBanPeerID+`"], "duration": "24h", "reason": "spam"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var results BanResultsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &results))
+		assert.Equal(t, 3, results.Succeeded)
+		assert.Positive(t, results.Until)
+		assert.Equal(t, banTargetIP, results.Results[0].Type)
+		assert.Equal(t, banTargetCIDR, results.Results[1].Type)
+		assert.Equal(t, banTargetPeerID, results.Results[2].Type)
+		assert.Equal(t, "spam", client.banned["10.0.0.1"])
+
+		rec = doRequest(h.GetBans, http.MethodGet, "/api/v1/bans?offset=1&limit=1", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var list struct {
+			Data       []BanEntry `json:"data"`
+			Pagination Pagination `json:"pagination"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		assert.Equal(t, 3, list.Pagination.TotalRecords)
+		assert.Equal(t, []BanEntry{{Target: "10.0.0.1", Type: banTargetIP}}, list.Data, "ordered by target")
+
+		rec = doRequest(h.UnbanPeers, http.MethodDelete, "/api/v1/bans?target=10.0.0.1", `{"targets": ["`+testBanPeerID+`"]}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, map[string]string{"10.0.0.0/24": "spam"}, client.banned)
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		h, client := newHTTP()
+		client.failFor = "10.0.0.2"
+
+		rec := doRequest(h.BanPeers, http.MethodPost, "/api/v1/bans",
+			`{"targets": ["10.0.0.1", "10.0.0.2", "not-a-target"], "duration": "1h"}`)
+		require.Equal(t, http.StatusMultiStatus, rec.Code)
+
+		var results BanResultsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &results))
+		assert.Equal(t, 1, results.Succeeded)
+		assert.Equal(t, 2, results.Failed)
+		assert.NotEmpty(t, results.Results[1].Error)
+		assert.NotEmpty(t, results.Results[2].Error)
+		assert.Len(t, client.banned, 1)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		h, _ := newHTTP()
+
+		assert.Equal(t, http.StatusBadRequest, doRequest(h.BanPeers, http.MethodPost, "/api/v1/bans", `{"targets": [], "duration": "1h"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, doRequest(h.BanPeers, http.MethodPost, "/api/v1/bans", `{"targets": ["10.0.0.1"]}`).Code)
+		assert.Equal(t, http.StatusBadRequest, doRequest(h.BanPeers, http.MethodPost, "/api/v1/bans", `{"targets": ["10.0.0.1"], "duration": "-1h"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, doRequest(h.BanPeers, http.MethodPost, "/api/v1/bans", `not json`).Code)
+		assert.Equal(t, http.StatusBadRequest, doRequest(h.UnbanPeers, http.MethodDelete, "/api/v1/bans", "").Code)
+	})
+}
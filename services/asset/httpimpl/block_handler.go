@@ -10,6 +10,7 @@ import (
 	"github.com/bsv-blockchain/teranode/services/blockchain"
 	"github.com/bsv-blockchain/teranode/services/blockvalidation"
 	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/labstack/echo/v4"
 )
@@ -28,6 +29,8 @@ type BlockHandler struct {
 	blockvalidationClient blockvalidation.Interface
 	// logger enables structured logging of handler operations and errors
 	logger ulogger.Logger
+	// auditLog records the blocks invalidated and revalidated, nil when not audited
+	auditLog *audit.Logger
 }
 
 // blockOperation represents a function that performs an operation on a block.
@@ -36,6 +39,12 @@ type BlockHandler struct {
 // The function takes an Echo context and block hash as parameters and returns any error encountered.
 type blockOperation func(ctx echo.Context, blockHash *chainhash.Hash) error
 
+// blockOperationActions are the audit actions of the block operations
+var blockOperationActions = map[string]string{
+	"invalidate": audit.ActionInvalidateBlock,
+	"revalidate": audit.ActionRevalidateBlock,
+}
+
 // blockRequest represents the common request structure for block operations.
 // It defines the JSON format expected in request bodies for block-related API endpoints,
 // providing a consistent interface for all block operations that require a block hash.
@@ -126,6 +135,8 @@ func (h *BlockHandler) handleBlockOperation(c echo.Context, operationName string
 		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to %s block: %s", operationName, err.Error()))
 	}
 
+	recordAudit(h.auditLog, c, blockOperationActions[operationName], blockHash.String(), "")
+
 	// Return success response
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"success": true,
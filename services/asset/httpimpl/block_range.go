@@ -0,0 +1,250 @@
+package httpimpl
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	safeconversion "github.com/bsv-blockchain/go-safe-conversion"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerAcceptRanges = "Accept-Ranges"
+	headerContentRange = "Content-Range"
+	headerETag         = "ETag"
+	headerIfRange      = "If-Range"
+	headerRange        = "Range"
+
+	// legacyBlockChunkSize is the size of the chunks a legacy block is streamed in, every chunk is flushed to the
+	// client as soon as it has been generated
+	legacyBlockChunkSize = 256 * 1024
+)
+
+// byteRange is a range of bytes of a block of size bytes, both ends included
+type byteRange struct {
+	start int64
+	end   int64
+	size  int64
+}
+
+func (br *byteRange) length() int64 {
+	return br.end - br.start + 1
+}
+
+// contentRange returns the value of the Content-Range header of the range
+func (br *byteRange) contentRange() string {
+	return "bytes " + strconv.FormatInt(br.start, 10) + "-" + strconv.FormatInt(br.end, 10) + "/" + strconv.FormatInt(br.size, 10)
+}
+
+// parseByteRange parses the Range header of a request for a resource of size bytes.
+//
+// Only a single range of bytes is supported. A header with another unit, multiple ranges or an invalid syntax is
+// ignored, as allowed by RFC 9110, and a nil range is returned to serve the full resource. The returned bool is false
+// when the range does not overlap the resource and cannot be satisfied.
+func parseByteRange(header string, size int64) (*byteRange, bool) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return nil, true
+	}
+
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return nil, true
+	}
+
+	if startStr == "" {
+		// suffix range, the last n bytes of the resource
+		n, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || n < 0 {
+			return nil, true
+		}
+
+		if n == 0 || size == 0 {
+			return nil, false
+		}
+
+		return &byteRange{start: max(size-n, 0), end: size - 1, size: size}, true
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return nil, true
+	}
+
+	end := size - 1
+
+	if endStr != "" {
+		if end, err = strconv.ParseInt(endStr, 10, 64); err != nil || end < start {
+			return nil, true
+		}
+	}
+
+	if start >= size {
+		return nil, false
+	}
+
+	return &byteRange{start: start, end: min(end, size-1), size: size}, true
+}
+
+// serveLegacyBlock streams a block in legacy format, honouring HEAD requests and the Range and If-Range headers so
+// that clients can resume the download of large blocks. Blocks are generated on the fly, the bytes before the start of
+// a range are generated but not sent.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - c: Echo context containing the HTTP request and response
+//   - hash: Hash of the block
+//   - wireBlock: Whether to stream the block in wire format, without the magic number and size
+//   - onError: Converts the errors of the repository into the error response of the endpoint
+//
+// Returns:
+//   - error: Any error encountered during processing
+func (h *HTTP) serveLegacyBlock(ctx context.Context, c echo.Context, hash *chainhash.Hash, wireBlock bool, onError func(err error) error) error {
+	req := c.Request()
+	header := c.Response().Header()
+
+	// the content of a block never changes, the hash identifies it
+	etag := `"` + hash.String() + `"`
+	if wireBlock {
+		etag = `"` + hash.String() + `-wire"`
+	}
+
+	header.Set(headerAcceptRanges, "bytes")
+	header.Set(headerETag, etag)
+
+	rangeHeader := req.Header.Get(headerRange)
+
+	if ifRange := req.Header.Get(headerIfRange); ifRange != "" && ifRange != etag {
+		// the client holds the start of another representation, send the full block
+		rangeHeader = ""
+	}
+
+	var rng *byteRange
+
+	if rangeHeader != "" || req.Method == http.MethodHead {
+		blockSize, err := h.repository.GetLegacyBlockSize(ctx, hash, wireBlock)
+		if err != nil {
+			return onError(err)
+		}
+
+		size, err := safeconversion.Uint64ToInt64(blockSize)
+		if err != nil {
+			return onError(err)
+		}
+
+		if req.Method == http.MethodHead {
+			prometheusAssetHTTPGetBlockLegacy.WithLabelValues("OK", "200").Inc()
+
+			header.Set(echo.HeaderContentType, echo.MIMEOctetStream)
+			header.Set(echo.HeaderContentLength, strconv.FormatInt(size, 10))
+
+			return c.NoContent(http.StatusOK)
+		}
+
+		var satisfiable bool
+
+		if rng, satisfiable = parseByteRange(rangeHeader, size); !satisfiable {
+			prometheusAssetHTTPGetBlockLegacy.WithLabelValues("ERROR", http.StatusText(http.StatusRequestedRangeNotSatisfiable)).Inc()
+
+			header.Set(headerContentRange, "bytes */"+strconv.FormatInt(size, 10))
+
+			return c.NoContent(http.StatusRequestedRangeNotSatisfiable)
+		}
+	}
+
+	r, err := h.repository.GetLegacyBlockReader(ctx, hash, wireBlock)
+	if err != nil {
+		return onError(err)
+	}
+
+	// closing the reader stops the generation of the block when the client went away or the range has been sent
+	defer func() {
+		_ = r.Close()
+	}()
+
+	if rng == nil {
+		prometheusAssetHTTPGetBlockLegacy.WithLabelValues("OK", "200").Inc()
+
+		return c.Stream(http.StatusOK, echo.MIMEOctetStream, newLegacyBlockStream(r, -1))
+	}
+
+	if rng.start > 0 {
+		if _, err = io.CopyN(io.Discard, r, rng.start); err != nil {
+			return onError(errors.NewProcessingError("error skipping to byte %d of block %s", rng.start, hash.String(), err))
+		}
+	}
+
+	prometheusAssetHTTPGetBlockLegacy.WithLabelValues("OK", "206").Inc()
+
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(rng.length(), 10))
+	header.Set(headerContentRange, rng.contentRange())
+
+	return c.Stream(http.StatusPartialContent, echo.MIMEOctetStream, newLegacyBlockStream(r, rng.length()))
+}
+
+// legacyBlockStream streams n bytes of a legacy block, or the whole block when n is negative. The block reader ends
+// with io.ErrClosedPipe once the whole block has been written.
+//
+// It implements io.WriterTo, which c.Stream uses to copy the block to the response in chunks, flushing every chunk.
+type legacyBlockStream struct {
+	r io.Reader
+	n int64
+}
+
+func newLegacyBlockStream(r io.Reader, n int64) *legacyBlockStream {
+	if n >= 0 {
+		r = io.LimitReader(r, n)
+	}
+
+	return &legacyBlockStream{r: r, n: n}
+}
+
+func (s *legacyBlockStream) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if errors.Is(err, io.ErrClosedPipe) {
+		err = io.EOF
+	}
+
+	return n, err
+}
+
+func (s *legacyBlockStream) WriteTo(w io.Writer) (int64, error) {
+	var (
+		buf     = make([]byte, legacyBlockChunkSize)
+		written int64
+	)
+
+	flusher, _ := w.(http.Flusher)
+
+	for {
+		read, err := io.ReadFull(s.r, buf)
+		if read > 0 {
+			if _, writeErr := w.Write(buf[:read]); writeErr != nil {
+				return written, writeErr
+			}
+
+			if flusher != nil {
+				flusher.Flush()
+			}
+
+			written += int64(read)
+		}
+
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.ErrClosedPipe) {
+				return written, err
+			}
+
+			if s.n >= 0 && written < s.n {
+				return written, errors.NewProcessingError("block ended after %d of %d bytes", written, s.n)
+			}
+
+			return written, nil
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package httpimpl
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		size        int64
+		expected    *byteRange
+		satisfiable bool
+	}{
+		{"start and end", "bytes=2-5", 10, &byteRange{start: 2, end: 5, size: 10}, true},
+		{"open end", "bytes=4-", 10, &byteRange{start: 4, end: 9, size: 10}, true},
+		{"end after the resource", "bytes=4-100", 10, &byteRange{start: 4, end: 9, size: 10}, true},
+		{"suffix", "bytes=-3", 10, &byteRange{start: 7, end: 9, size: 10}, true},
+		{"suffix larger than the resource", "bytes=-30", 10, &byteRange{start: 0, end: 9, size: 10}, true},
+		{"start after the resource", "bytes=10-", 10, nil, false},
+		{"empty suffix", "bytes=-0", 10, nil, false},
+		{"empty resource", "bytes=-5", 0, nil, false},
+		{"multiple ranges", "bytes=0-1,5-6", 10, nil, true},
+		{"other unit", "items=0-1", 10, nil, true},
+		{"end before start", "bytes=5-2", 10, nil, true},
+		{"invalid syntax", "bytes=a-b", 10, nil, true},
+		{"no dash", "bytes=5", 10, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng, satisfiable := parseByteRange(tt.header, tt.size)
+			assert.Equal(t, tt.satisfiable, satisfiable)
+			assert.Equal(t, tt.expected, rng)
+		})
+	}
+}
+
+func TestGetLegacyBlockRange(t *testing.T) {
+	initPrometheusMetrics()
+
+	const (
+		hashStr   = "9d45ad79ad3c6baecae872c0e35022d60c3bbbd024ccce06690321ece15ea995"
+		blockData = "0123456789"
+	)
+
+	// blockReader mimics the repository, which closes the pipe with io.ErrClosedPipe after the block
+	blockReader := func() *io.PipeReader {
+		reader, writer := io.Pipe()
+
+		go func() {
+			_, _ = writer.Write([]byte(blockData))
+			_ = writer.CloseWithError(io.ErrClosedPipe)
+		}()
+
+		return reader
+	}
+
+	setup := func(t *testing.T, method string, headers map[string]string) (*HTTP, echo.Context, func() *http.Response) {
+		httpServer, mockRepo, echoContext, responseRecorder := GetMockHTTP(t, nil)
+
+		mockRepo.On("GetLegacyBlockReader", mock.Anything, mock.Anything, mock.Anything).Return(blockReader(), nil).Maybe()
+		mockRepo.On("GetLegacyBlockSize", mock.Anything).Return(uint64(len(blockData)), nil).Maybe()
+
+		echoContext.Request().Method = method
+		for key, value := range headers {
+			echoContext.Request().Header.Set(key, value)
+		}
+
+		echoContext.SetPath("/block/legacy/:hash")
+		echoContext.SetParamNames("hash")
+		echoContext.SetParamValues(hashStr)
+
+		return httpServer, echoContext, responseRecorder.Result
+	}
+
+	t.Run("full block", func(t *testing.T) {
+		httpServer, echoContext, result := setup(t, http.MethodGet, nil)
+
+		require.NoError(t, httpServer.GetLegacyBlock()(echoContext))
+
+		response := result()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "bytes", response.Header.Get(headerAcceptRanges))
+		assert.Equal(t, `"`+hashStr+`"`, response.Header.Get(headerETag))
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, blockData, string(body))
+	})
+
+	t.Run("range", func(t *testing.T) {
+		httpServer, echoContext, result := setup(t, http.MethodGet, map[string]string{headerRange: "bytes=3-6"})
+
+		require.NoError(t, httpServer.GetLegacyBlock()(echoContext))
+
+		response := result()
+		assert.Equal(t, http.StatusPartialContent, response.StatusCode)
+		assert.Equal(t, "bytes 3-6/10", response.Header.Get(headerContentRange))
+		assert.Equal(t, "4", response.Header.Get(echo.HeaderContentLength))
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "3456", string(body))
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		httpServer, echoContext, result := setup(t, http.MethodGet, map[string]string{
+			headerRange:   "bytes=8-",
+			headerIfRange: `"` + hashStr + `"`,
+		})
+
+		require.NoError(t, httpServer.GetLegacyBlock()(echoContext))
+
+		response := result()
+		assert.Equal(t, http.StatusPartialContent, response.StatusCode)
+		assert.Equal(t, "bytes 8-9/10", response.Header.Get(headerContentRange))
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "89", string(body))
+	})
+
+	t.Run("suffix range of the rest endpoint", func(t *testing.T) {
+		httpServer, echoContext, result := setup(t, http.MethodGet, map[string]string{headerRange: "bytes=-2"})
+
+		echoContext.SetPath("/rest/block/:hash.bin")
+		echoContext.SetParamNames("hash.bin")
+		echoContext.SetParamValues(hashStr + ".bin")
+
+		require.NoError(t, httpServer.GetRestLegacyBlock()(echoContext))
+
+		response := result()
+		assert.Equal(t, http.StatusPartialContent, response.StatusCode)
+		assert.Equal(t, "bytes 8-9/10", response.Header.Get(headerContentRange))
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "89", string(body))
+	})
+
+	t.Run("if-range mismatch sends the full block", func(t *testing.T) {
+		httpServer, echoContext, result := setup(t, http.MethodGet, map[string]string{
+			headerRange:   "bytes=8-",
+			headerIfRange: `"other"`,
+		})
+
+		require.NoError(t, httpServer.GetLegacyBlock()(echoContext))
+
+		response := result()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Empty(t, response.Header.Get(headerContentRange))
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, blockData, string(body))
+	})
+
+	t.Run("range not satisfiable", func(t *testing.T) {
+		httpServer, echoContext, result := setup(t, http.MethodGet, map[string]string{headerRange: "bytes=10-"})
+
+		require.NoError(t, httpServer.GetLegacyBlock()(echoContext))
+
+		response := result()
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, response.StatusCode)
+		assert.Equal(t, "bytes */10", response.Header.Get(headerContentRange))
+	})
+
+	t.Run("head", func(t *testing.T) {
+		httpServer, echoContext, result := setup(t, http.MethodHead, nil)
+
+		require.NoError(t, httpServer.GetLegacyBlock()(echoContext))
+
+		response := result()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "10", response.Header.Get(echo.HeaderContentLength))
+		assert.Equal(t, "bytes", response.Header.Get(headerAcceptRanges))
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Empty(t, body)
+	})
+
+	t.Run("block not found", func(t *testing.T) {
+		httpServer, mockRepo, echoContext, _ := GetMockHTTP(t, nil)
+
+		mockRepo.On("GetLegacyBlockSize", mock.Anything).Return(uint64(0), errors.NewNotFoundError("block not found"))
+
+		echoContext.Request().Header.Set(headerRange, "bytes=0-")
+		echoContext.SetPath("/block/legacy/:hash")
+		echoContext.SetParamNames("hash")
+		echoContext.SetParamValues(hashStr)
+
+		err := httpServer.GetLegacyBlock()(echoContext)
+		echoErr := &echo.HTTPError{}
+		require.True(t, errors.As(err, &echoErr))
+		assert.Equal(t, http.StatusNotFound, echoErr.Code)
+	})
+}
+
+func TestLegacyBlockStream(t *testing.T) {
+	data := bytes.Repeat([]byte{0x01, 0x02, 0x03}, legacyBlockChunkSize)
+
+	blockReader := func() *io.PipeReader {
+		reader, writer := io.Pipe()
+
+		go func() {
+			_, _ = writer.Write(data)
+			_ = writer.CloseWithError(io.ErrClosedPipe)
+		}()
+
+		return reader
+	}
+
+	t.Run("whole block in chunks", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		n, err := io.Copy(recorder, newLegacyBlockStream(blockReader(), -1))
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(data)), n)
+		assert.Equal(t, data, recorder.Body.Bytes())
+		assert.True(t, recorder.Flushed)
+	})
+
+	t.Run("range", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		n, err := io.Copy(recorder, newLegacyBlockStream(blockReader(), legacyBlockChunkSize+1))
+		require.NoError(t, err)
+		assert.Equal(t, int64(legacyBlockChunkSize+1), n)
+		assert.Equal(t, data[:legacyBlockChunkSize+1], recorder.Body.Bytes())
+	})
+
+	t.Run("block shorter than the range", func(t *testing.T) {
+		_, err := io.Copy(io.Discard, newLegacyBlockStream(blockReader(), int64(len(data)+1)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "block ended after")
+	})
+}
@@ -0,0 +1,21 @@
+package httpimpl
+
+import (
+	"net/http"
+
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/labstack/echo/v4"
+)
+
+// canaryMiddleware refuses the requests of peers, identified by the util.PeerIDHeader, on a node running in canary
+// mode: a canary validates the network but does not serve its DataHub. Requests of operators and the dashboard are
+// served as usual.
+func canaryMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c.Request().Header.Get(util.PeerIDHeader) != "" {
+			return sendStatusError(c, http.StatusServiceUnavailable, "DataHub is not served by a node in canary mode")
+		}
+
+		return next(c)
+	}
+}
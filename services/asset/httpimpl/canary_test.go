@@ -0,0 +1,39 @@
+package httpimpl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanaryMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Pre(canaryMiddleware)
+	e.GET("/api/v1/block/:hash", func(c echo.Context) error {
+		return c.String(http.StatusOK, "block")
+	})
+
+	t.Run("refuses peers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/block/abc", nil)
+		req.Header.Set(util.PeerIDHeader, "12D3KooWPeer")
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Contains(t, rec.Body.String(), "canary mode")
+	})
+
+	t.Run("serves operators", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/block/abc", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "block", rec.Body.String())
+	})
+}
@@ -0,0 +1,238 @@
+package httpimpl
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/labstack/echo/v4"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// catchupServeWindow is the window the catchup data served to a peer is limited over
+	catchupServeWindow = time.Hour
+
+	// catchupServeTierTTL is how long the limit tier of a peer, derived from its reputation, is cached
+	catchupServeTierTTL = time.Minute
+)
+
+// catchupServeUsage is the catchup data served to a peer in its current window
+type catchupServeUsage struct {
+	windowStart time.Time
+	bytes       uint64
+	blocks      uint64
+
+	multiplier    float64 // limit multiplier of the tier of the peer
+	tierCheckedAt time.Time
+}
+
+// catchupServeLimiter limits the blocks and bytes of catchup data served to a single peer per hour.
+// All operations are thread-safe.
+type catchupServeLimiter struct {
+	mu        sync.Mutex
+	settings  settings.AssetSettings
+	pinned    map[string]struct{}
+	peers     map[string]*catchupServeUsage
+	lastPrune time.Time
+}
+
+// newCatchupServeLimiter creates the catchup serving limiter, the static P2P peers and the configured pinned
+// peers are not limited
+func newCatchupServeLimiter(tSettings *settings.Settings) *catchupServeLimiter {
+	pinned := make(map[string]struct{}, len(tSettings.P2P.StaticPeers)+len(tSettings.Asset.CatchupServePinnedPeers))
+
+	for _, addr := range tSettings.P2P.StaticPeers {
+		if info, err := peer.AddrInfoFromString(addr); err == nil {
+			pinned[info.ID.String()] = struct{}{}
+		}
+	}
+
+	for _, peerID := range tSettings.Asset.CatchupServePinnedPeers {
+		pinned[peerID] = struct{}{}
+	}
+
+	return &catchupServeLimiter{
+		settings: tSettings.Asset,
+		pinned:   pinned,
+		peers:    make(map[string]*catchupServeUsage),
+	}
+}
+
+// isPinned returns whether the peer is pinned and not limited
+func (l *catchupServeLimiter) isPinned(peerID string) bool {
+	_, pinned := l.pinned[peerID]
+
+	return pinned
+}
+
+// tier returns the cached limit multiplier of the peer, ok is false when it has to be looked up
+func (l *catchupServeLimiter) tier(peerID string, now time.Time) (multiplier float64, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	usage, exists := l.peers[peerID]
+	if !exists || usage.tierCheckedAt.IsZero() || now.Sub(usage.tierCheckedAt) >= catchupServeTierTTL {
+		return 0, false
+	}
+
+	return usage.multiplier, true
+}
+
+// setTier caches the limit multiplier of the peer for its reputation
+func (l *catchupServeLimiter) setTier(peerID string, reputation float64, now time.Time) float64 {
+	multiplier := 1.0
+	if l.settings.CatchupServeTrustedMultiplier > 0 && reputation >= l.settings.CatchupServeTrustedReputation {
+		multiplier = l.settings.CatchupServeTrustedMultiplier
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	usage := l.usage(peerID, now)
+	usage.multiplier = multiplier
+	usage.tierCheckedAt = now
+
+	return multiplier
+}
+
+// usage returns the usage of the peer in its current window, starting a new window when the previous one ended.
+// The caller holds the lock.
+func (l *catchupServeLimiter) usage(peerID string, now time.Time) *catchupServeUsage {
+	if now.Sub(l.lastPrune) >= catchupServeWindow {
+		for id, usage := range l.peers {
+			if now.Sub(usage.windowStart) >= catchupServeWindow && now.Sub(usage.tierCheckedAt) >= catchupServeTierTTL {
+				delete(l.peers, id)
+			}
+		}
+
+		l.lastPrune = now
+	}
+
+	usage, exists := l.peers[peerID]
+	if !exists {
+		usage = &catchupServeUsage{windowStart: now, multiplier: 1}
+		l.peers[peerID] = usage
+	}
+
+	if now.Sub(usage.windowStart) >= catchupServeWindow {
+		usage.windowStart = now
+		usage.bytes = 0
+		usage.blocks = 0
+	}
+
+	return usage
+}
+
+// allow returns whether catchup data may be served to the peer, and when its window ends if it may not. A request is
+// refused once the peer reached one of its limits, so a peer exceeds a limit by at most the size of one request.
+func (l *catchupServeLimiter) allow(peerID string, multiplier float64, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	usage := l.usage(peerID, now)
+
+	if reached(usage.bytes, l.settings.CatchupServeMaxBytesPerHour, multiplier) ||
+		reached(usage.blocks, l.settings.CatchupServeMaxBlocksPerHour, multiplier) {
+		return false, usage.windowStart.Add(catchupServeWindow).Sub(now)
+	}
+
+	return true, 0
+}
+
+// record accounts the catchup data served to the peer
+func (l *catchupServeLimiter) record(peerID string, bytes uint64, blocks uint64, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	usage := l.usage(peerID, now)
+	usage.bytes += bytes
+	usage.blocks += blocks
+}
+
+// reached returns whether used reached the limit scaled by the multiplier, a limit of 0 is unlimited
+func reached(used uint64, limit uint64, multiplier float64) bool {
+	if limit == 0 {
+		return false
+	}
+
+	return float64(used) >= float64(limit)*multiplier
+}
+
+// catchupServeMultiplier returns the limit multiplier of the peer, looking up its reputation in the peer registry
+// when it is not cached. Peers that are not in the registry get the base limits.
+func (h *HTTP) catchupServeMultiplier(ctx context.Context, peerID string, now time.Time) float64 {
+	if multiplier, ok := h.catchupLimiter.tier(peerID, now); ok {
+		return multiplier
+	}
+
+	var reputation float64
+
+	if p2pClient := h.repository.GetP2PClient(); p2pClient != nil {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		info, err := p2pClient.GetPeer(ctx, peerID)
+		if err != nil {
+			h.logger.Debugf("[catchupServeLimitMiddleware] failed to get the reputation of peer %s: %v", peerID, err)
+		} else if info != nil {
+			reputation = info.ReputationScore
+		}
+	}
+
+	return h.catchupLimiter.setTier(peerID, reputation, now)
+}
+
+// catchupServeLimitMiddleware limits the blocks, subtrees and subtree data served to nodes that identify themselves
+// with their peer ID in the util.PeerIDHeader, see catchupServeLimiter. Requests over the limits are refused with a
+// 429 NETWORK_PEER_THROTTLED error and a Retry-After header. It is registered with Echo#Use, as it needs the route
+// of the request, so the bytes before compression are accounted.
+func (h *HTTP) catchupServeLimitMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		peerID := c.Request().Header.Get(util.PeerIDHeader)
+		if peerID == "" || h.catchupLimiter.isPinned(peerID) {
+			return next(c)
+		}
+
+		dataType, items := h.servedDataType(c)
+		if dataType == "" {
+			return next(c)
+		}
+
+		now := time.Now()
+		multiplier := h.catchupServeMultiplier(c.Request().Context(), peerID, now)
+
+		if allowed, retryAfter := h.catchupLimiter.allow(peerID, multiplier, now); !allowed {
+			h.logger.Debugf("[catchupServeLimitMiddleware] peer %s reached its catchup serving limit, retry after %v", peerID, retryAfter)
+
+			c.Response().Header().Set(echo.HeaderRetryAfter, strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+
+			return sendError(c, http.StatusTooManyRequests, int32(errors.ERR_NETWORK_PEER_THROTTLED),
+				errors.NewNetworkPeerThrottledError("catchup serving limit of peer %s reached, retry after %v", peerID, retryAfter.Round(time.Second)))
+		}
+
+		res := c.Response()
+		counter := &countingResponseWriter{ResponseWriter: res.Writer}
+		res.Writer = counter
+
+		err := next(c)
+
+		if res.Status == http.StatusOK {
+			var blocks uint64
+			if dataType == p2p.PeerDataTypeBlock {
+				blocks = items
+			}
+
+			h.catchupLimiter.record(peerID, counter.bytes, blocks, time.Now())
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,181 @@
+package httpimpl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/asset/repository"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reputationP2PClient is a P2P client returning fixed peer reputations
+type reputationP2PClient struct {
+	p2p.ClientI
+
+	reputations map[string]float64
+	lookups     int
+}
+
+func (c *reputationP2PClient) GetPeer(_ context.Context, peerID string) (*p2p.PeerInfo, error) {
+	c.lookups++
+
+	reputation, ok := c.reputations[peerID]
+	if !ok {
+		return nil, errors.NewNotFoundError("peer %s not found", peerID)
+	}
+
+	return &p2p.PeerInfo{ReputationScore: reputation}, nil
+}
+
+func newCatchupLimitTestServer(t *testing.T, tSettings *settings.Settings, client p2p.ClientI) (*echo.Echo, *HTTP) {
+	t.Helper()
+
+	repo := &repository.Mock{}
+	repo.On("GetP2PClient").Return(client)
+
+	h := &HTTP{logger: ulogger.TestLogger{}, settings: tSettings, repository: repo}
+	h.catchupLimiter = newCatchupServeLimiter(tSettings)
+
+	e := echo.New()
+	e.HTTPErrorHandler = httpErrorHandler
+	e.Use(h.catchupServeLimitMiddleware)
+
+	e.GET("/api/v1/block/:hash", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, echo.MIMEOctetStream, make([]byte, 100))
+	})
+	e.GET("/api/v1/blocks/:hash", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, echo.MIMEOctetStream, make([]byte, 10))
+	})
+	e.GET("/api/v1/bestblockheader", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, echo.MIMEOctetStream, make([]byte, 80))
+	})
+
+	return e, h
+}
+
+func catchupLimitTestSettings() *settings.Settings {
+	return &settings.Settings{
+		Asset: settings.AssetSettings{
+			APIPrefix:                     "/api/v1",
+			CatchupServeTrustedReputation: 80,
+			CatchupServeTrustedMultiplier: 2,
+		},
+	}
+}
+
+func serveCatchupRequest(e *echo.Echo, path string, peerID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if peerID != "" {
+		req.Header.Set(util.PeerIDHeader, peerID)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestCatchupServeLimitMiddleware(t *testing.T) {
+	t.Run("bytes limit", func(t *testing.T) {
+		tSettings := catchupLimitTestSettings()
+		tSettings.Asset.CatchupServeMaxBytesPerHour = 250
+
+		e, _ := newCatchupLimitTestServer(t, tSettings, &reputationP2PClient{})
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusOK, serveCatchupRequest(e, "/api/v1/block/abc", "peer-1").Code)
+		}
+
+		rec := serveCatchupRequest(e, "/api/v1/block/abc", "peer-1")
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get(echo.HeaderRetryAfter))
+
+		var resp errorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, int32(errors.ERR_NETWORK_PEER_THROTTLED), resp.Code)
+		assert.True(t, strings.HasPrefix(resp.Err, "NETWORK_PEER_THROTTLED (117):"))
+
+		// other peers, requests without a peer ID and other routes are not limited
+		assert.Equal(t, http.StatusOK, serveCatchupRequest(e, "/api/v1/block/abc", "peer-2").Code)
+		assert.Equal(t, http.StatusOK, serveCatchupRequest(e, "/api/v1/block/abc", "").Code)
+		assert.Equal(t, http.StatusOK, serveCatchupRequest(e, "/api/v1/bestblockheader", "peer-1").Code)
+	})
+
+	t.Run("blocks limit", func(t *testing.T) {
+		tSettings := catchupLimitTestSettings()
+		tSettings.Asset.CatchupServeMaxBlocksPerHour = 100
+
+		e, _ := newCatchupLimitTestServer(t, tSettings, &reputationP2PClient{})
+
+		assert.Equal(t, http.StatusOK, serveCatchupRequest(e, "/api/v1/blocks/abc?n=60", "peer-1").Code)
+		assert.Equal(t, http.StatusOK, serveCatchupRequest(e, "/api/v1/blocks/abc?n=60", "peer-1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveCatchupRequest(e, "/api/v1/block/abc", "peer-1").Code)
+	})
+
+	t.Run("trusted peers get higher limits", func(t *testing.T) {
+		tSettings := catchupLimitTestSettings()
+		tSettings.Asset.CatchupServeMaxBlocksPerHour = 1
+
+		client := &reputationP2PClient{reputations: map[string]float64{"trusted": 90, "untrusted": 50}}
+		e, _ := newCatchupLimitTestServer(t, tSettings, client)
+
+		for _, peerID := range []string{"trusted", "untrusted", "unknown"} {
+			assert.Equal(t, http.StatusOK, serveCatchupRequest(e, "/api/v1/block/abc", peerID).Code)
+		}
+
+		assert.Equal(t, http.StatusOK, serveCatchupRequest(e, "/api/v1/block/abc", "trusted").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveCatchupRequest(e, "/api/v1/block/abc", "trusted").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveCatchupRequest(e, "/api/v1/block/abc", "untrusted").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveCatchupRequest(e, "/api/v1/block/abc", "unknown").Code)
+
+		// the reputation of every peer is looked up once and cached
+		assert.Equal(t, 3, client.lookups)
+	})
+
+	t.Run("pinned peers are not limited", func(t *testing.T) {
+		tSettings := catchupLimitTestSettings()
+		tSettings.Asset.CatchupServeMaxBlocksPerHour = 1
+		tSettings.Asset.CatchupServePinnedPeers = []string{"pinned"}
+		tSettings.P2P.StaticPeers = []string{"/ip4/10.0.0.1/tcp/9905/p2p/" + testBanPeerID}
+
+		e, _ := newCatchupLimitTestServer(t, tSettings, &reputationP2PClient{})
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusOK, serveCatchupRequest(e, "/api/v1/block/abc", "pinned").Code)
+			assert.Equal(t, http.StatusOK, serveCatchupRequest(e, "/api/v1/block/abc", testBanPeerID).Code)
+		}
+	})
+}
+
+func TestCatchupServeLimiter_Window(t *testing.T) {
+	tSettings := catchupLimitTestSettings()
+	tSettings.Asset.CatchupServeMaxBytesPerHour = 100
+
+	l := newCatchupServeLimiter(tSettings)
+	now := time.Now()
+
+	allowed, _ := l.allow("peer-1", 1, now)
+	require.True(t, allowed)
+
+	l.record("peer-1", 100, 0, now)
+
+	allowed, retryAfter := l.allow("peer-1", 1, now.Add(10*time.Minute))
+	assert.False(t, allowed)
+	assert.Equal(t, 50*time.Minute, retryAfter)
+
+	// the usage is reset once the window ended
+	allowed, _ = l.allow("peer-1", 1, now.Add(catchupServeWindow))
+	assert.True(t, allowed)
+}
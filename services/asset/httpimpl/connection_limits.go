@@ -0,0 +1,116 @@
+package httpimpl
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// limitListener is a listener accepting at most a fixed number of concurrent connections. Accept blocks
+// while the limit is reached, so further clients wait in the kernel accept backlog instead of being dropped.
+type limitListener struct {
+	net.Listener
+	sem       chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newLimitListener wraps a listener to accept at most maxConnections concurrent connections, a limit of
+// 0 or lower returns the listener unchanged
+func newLimitListener(listener net.Listener, maxConnections int) net.Listener {
+	if maxConnections <= 0 {
+		return listener
+	}
+
+	return &limitListener{
+		Listener: listener,
+		sem:      make(chan struct{}, maxConnections),
+		done:     make(chan struct{}),
+	}
+}
+
+// limitConnections wraps a listener to share the asset_httpMaxConnections connection slots with the listeners of
+// the other listen addresses, a limit of 0 or lower returns the listener unchanged
+func (h *HTTP) limitConnections(listener net.Listener) net.Listener {
+	maxConnections := h.settings.Asset.HTTPMaxConnections
+	if maxConnections <= 0 {
+		return listener
+	}
+
+	h.connectionSlotsOnce.Do(func() {
+		h.connectionSlots = make(chan struct{}, maxConnections)
+	})
+
+	return &limitListener{
+		Listener: listener,
+		sem:      h.connectionSlots,
+		done:     make(chan struct{}),
+	}
+}
+
+// Accept waits for a free connection slot and the next connection
+func (l *limitListener) Accept() (net.Conn, error) {
+	select {
+	case l.sem <- struct{}{}:
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		<-l.sem
+		return nil, err
+	}
+
+	return &limitConn{Conn: conn, release: func() { <-l.sem }}, nil
+}
+
+// Close closes the listener and unblocks any Accept waiting for a free connection slot
+func (l *limitListener) Close() error {
+	l.closeOnce.Do(func() { close(l.done) })
+
+	return l.Listener.Close()
+}
+
+// limitConn releases its connection slot when it is closed
+type limitConn struct {
+	net.Conn
+	releaseOnce sync.Once
+	release     func()
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+
+	return err
+}
+
+// shutdown stops accepting new connections and waits for in-flight requests, including streamed blocks
+// and subtrees, to complete for at most the asset_http_shutdown_timeout. Connections still active after
+// the timeout are closed.
+func (h *HTTP) shutdown(ctx context.Context) error {
+	if h.settings != nil && h.settings.Asset.HTTPShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, h.settings.Asset.HTTPShutdownTimeout)
+		defer cancel()
+	}
+
+	h.shutdownAdditional(ctx)
+
+	err := h.e.Shutdown(ctx)
+	if err == nil {
+		return nil
+	}
+
+	h.logger.Warnf("[Asset] HTTP server did not drain in-flight requests in time, closing remaining connections: %v", err)
+
+	if closeErr := h.e.Close(); closeErr != nil {
+		return errors.NewServiceError("[Asset] failed to close HTTP server", closeErr)
+	}
+
+	return errors.NewServiceError("[Asset] HTTP server shutdown did not complete in-flight requests", err)
+}
@@ -0,0 +1,190 @@
+package httpimpl
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitListener(t *testing.T) {
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	listener := newLimitListener(inner, 1)
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 2)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				close(accepted)
+				return
+			}
+
+			accepted <- conn
+		}
+	}()
+
+	client1, err := net.Dial("tcp", inner.Addr().String())
+	require.NoError(t, err)
+
+	defer client1.Close()
+
+	client2, err := net.Dial("tcp", inner.Addr().String())
+	require.NoError(t, err)
+
+	defer client2.Close()
+
+	first := <-accepted
+
+	select {
+	case <-accepted:
+		t.Fatal("second connection accepted while the limit is reached")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// closing the first connection frees its slot
+	require.NoError(t, first.Close())
+
+	select {
+	case second := <-accepted:
+		require.NotNil(t, second)
+		_ = second.Close()
+	case <-time.After(2 * time.Second):
+		t.Fatal("second connection not accepted after the first closed")
+	}
+
+	require.NoError(t, listener.Close())
+
+	_, open := <-accepted
+	assert.False(t, open, "accept returns once the listener is closed")
+}
+
+func TestLimitListener_Unlimited(t *testing.T) {
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer inner.Close()
+
+	assert.Same(t, inner, newLimitListener(inner, 0))
+}
+
+func TestHTTPShutdown_DrainsInFlightRequests(t *testing.T) {
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+
+	started := make(chan struct{})
+
+	e.GET("/slow", func(c echo.Context) error {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+
+		return c.String(http.StatusOK, "done")
+	})
+
+	tSettings := &settings.Settings{}
+	tSettings.Asset.HTTPShutdownTimeout = 5 * time.Second
+
+	h := &HTTP{logger: ulogger.TestLogger{}, settings: tSettings, e: e}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	e.Listener = listener
+
+	go func() {
+		_ = e.Start("")
+	}()
+
+	type result struct {
+		status int
+		err    error
+	}
+
+	resultCh := make(chan result, 1)
+
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err != nil {
+			resultCh <- result{err: err}
+			return
+		}
+
+		_ = resp.Body.Close()
+		resultCh <- result{status: resp.StatusCode}
+	}()
+
+	<-started
+
+	require.NoError(t, h.shutdown(context.Background()))
+
+	res := <-resultCh
+	require.NoError(t, res.err)
+	assert.Equal(t, http.StatusOK, res.status, "the in-flight request completes during shutdown")
+
+	_, err = http.Get("http://" + listener.Addr().String() + "/slow")
+	assert.Error(t, err, "no new connections after shutdown")
+}
+
+func TestHTTPStartAdditional(t *testing.T) {
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+
+	e.GET("/ping", func(c echo.Context) error {
+		return c.String(http.StatusOK, "pong")
+	})
+
+	tSettings := &settings.Settings{}
+	tSettings.Asset.HTTPShutdownTimeout = time.Second
+	tSettings.Asset.HTTPMaxConnections = 4
+
+	h := &HTTP{logger: ulogger.TestLogger{}, settings: tSettings, e: e}
+
+	// reserve a free port for the additional listen address
+	reserved, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := reserved.Addr().String()
+	require.NoError(t, reserved.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- h.StartAdditional(ctx, addr)
+	}()
+
+	var resp *http.Response
+
+	require.Eventually(t, func() bool {
+		resp, err = http.Get("http://" + addr + "/ping")
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "the additional address serves the same routes")
+
+	assert.Same(t, h.connectionSlots, h.limitConnections(reserved).(*limitListener).sem, "listeners share the connection limit")
+
+	cancel()
+
+	select {
+	case err = <-errCh:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("additional server not shut down with the context")
+	}
+}
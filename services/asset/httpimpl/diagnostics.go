@@ -0,0 +1,284 @@
+package httpimpl
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"runtime/pprof"
+	"strconv"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/audit"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// diagnosticsBundleTimeout bounds the collection of the sections of a diagnostics bundle
+	diagnosticsBundleTimeout = 30 * time.Second
+
+	// diagnosticsManifestFile lists the files of a diagnostics bundle and the sections that could not be collected
+	diagnosticsManifestFile = "manifest.json"
+
+	// redactedSetting replaces the value of the settings holding credentials or keys in a diagnostics bundle
+	redactedSetting = "REDACTED"
+)
+
+// sensitiveSetting matches the names of the settings, and of the URL query parameters, holding credentials or keys
+var sensitiveSetting = regexp.MustCompile(`(?i)(pass|secret|token|private_?key|api_?key|credential)`)
+
+// DiagnosticsManifest describes a diagnostics bundle: the files it contains and the errors of the sections that
+// could not be collected, by file name
+type DiagnosticsManifest struct {
+	CreatedAt time.Time         `json:"created_at"`
+	Files     []string          `json:"files"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// diagnosticsSection is a file of a diagnostics bundle and the function collecting its content
+type diagnosticsSection struct {
+	file    string
+	collect func(ctx context.Context) ([]byte, error)
+}
+
+// CreateDiagnosticsBundle gathers the catchup status, the peer registry, the recent errors, the goroutine and heap
+// profiles, the build information and the effective settings of this node into a gzipped tar archive for support
+// cases. Credentials and keys in the settings are redacted. Sections that can not be collected, e.g. because a
+// service is not running, are listed in the manifest of the bundle instead of failing the request.
+func (h *HTTP) CreateDiagnosticsBundle(c echo.Context) error {
+	if !h.diagnosticsInFlight.CompareAndSwap(false, true) {
+		return sendStatusError(c, http.StatusTooManyRequests, "A diagnostics bundle is already being created")
+	}
+	defer h.diagnosticsInFlight.Store(false)
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), diagnosticsBundleTimeout)
+	defer cancel()
+
+	createdAt := time.Now().UTC()
+
+	var bundle bytes.Buffer
+	if err := writeDiagnosticsBundle(ctx, &bundle, createdAt, h.diagnosticsSections()); err != nil {
+		h.logger.Errorf("[CreateDiagnosticsBundle] Failed to create diagnostics bundle: %v", err)
+
+		return sendStatusError(c, http.StatusInternalServerError, "Failed to create diagnostics bundle")
+	}
+
+	recordAudit(h.auditLog, c, audit.ActionDiagnosticsBundle, "", strconv.Itoa(bundle.Len())+" bytes")
+
+	filename := "teranode-diagnostics-" + createdAt.Format("20060102T150405Z") + ".tar.gz"
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+
+	return c.Blob(http.StatusOK, "application/gzip", bundle.Bytes())
+}
+
+// diagnosticsSections returns the sections of the diagnostics bundle of this node
+func (h *HTTP) diagnosticsSections() []diagnosticsSection {
+	return []diagnosticsSection{
+		{file: "version.json", collect: func(context.Context) ([]byte, error) {
+			return json.MarshalIndent(buildinfo.Get(h.settings), "", "  ")
+		}},
+		{file: "settings.json", collect: func(context.Context) ([]byte, error) {
+			return redactedSettingsJSON(h.settings)
+		}},
+		{file: "catchup_status.json", collect: h.collectCatchupStatus},
+		{file: "peers.json", collect: h.collectPeerRegistry},
+		{file: "recent_errors.json", collect: func(context.Context) ([]byte, error) {
+			return json.MarshalIndent(h.logLevels.RecentErrors(), "", "  ")
+		}},
+		{file: "goroutines.txt", collect: func(context.Context) ([]byte, error) {
+			return collectProfile("goroutine", 2)
+		}},
+		{file: "heap.pprof", collect: func(context.Context) ([]byte, error) {
+			return collectProfile("heap", 0)
+		}},
+	}
+}
+
+func (h *HTTP) collectCatchupStatus(ctx context.Context) ([]byte, error) {
+	blockValidationClient := h.repository.GetBlockvalidationClient()
+	if blockValidationClient == nil {
+		return nil, errors.NewServiceUnavailableError("BlockValidation service not available")
+	}
+
+	status, err := blockValidationClient.GetCatchupStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(catchupStatusResponse(status), "", "  ")
+}
+
+func (h *HTTP) collectPeerRegistry(ctx context.Context) ([]byte, error) {
+	p2pClient := h.repository.GetP2PClient()
+	if p2pClient == nil {
+		return nil, errors.NewServiceUnavailableError("P2P service not available")
+	}
+
+	peers, err := p2pClient.GetPeerRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	peerResponses := peerInfoResponses(peers)
+
+	return json.MarshalIndent(PeersResponse{Peers: peerResponses, Count: len(peerResponses)}, "", "  ")
+}
+
+// collectProfile writes the runtime profile of this process, see pprof.Profile.WriteTo for the debug levels
+func collectProfile(name string, debug int) ([]byte, error) {
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		return nil, errors.NewProcessingError("unknown profile %s", name)
+	}
+
+	var buf bytes.Buffer
+	if err := profile.WriteTo(&buf, debug); err != nil {
+		return nil, errors.NewProcessingError("failed to write %s profile", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeDiagnosticsBundle collects the sections and writes them, followed by the manifest, as a gzipped tar archive
+func writeDiagnosticsBundle(ctx context.Context, w io.Writer, createdAt time.Time, sections []diagnosticsSection) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifest := DiagnosticsManifest{
+		CreatedAt: createdAt,
+		Files:     make([]string, 0, len(sections)+1),
+		Errors:    make(map[string]string),
+	}
+
+	for _, section := range sections {
+		data, err := section.collect(ctx)
+		if err != nil {
+			manifest.Errors[section.file] = err.Error()
+			continue
+		}
+
+		if err = writeTarFile(tw, section.file, createdAt, data); err != nil {
+			return err
+		}
+
+		manifest.Files = append(manifest.Files, section.file)
+	}
+
+	manifest.Files = append(manifest.Files, diagnosticsManifestFile)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.NewProcessingError("failed to encode the diagnostics manifest", err)
+	}
+
+	if err = writeTarFile(tw, diagnosticsManifestFile, createdAt, data); err != nil {
+		return err
+	}
+
+	if err = tw.Close(); err != nil {
+		return errors.NewProcessingError("failed to close the diagnostics archive", err)
+	}
+
+	if err = gz.Close(); err != nil {
+		return errors.NewProcessingError("failed to compress the diagnostics archive", err)
+	}
+
+	return nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, modTime time.Time, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+
+	if err := tw.WriteHeader(header); err != nil {
+		return errors.NewProcessingError("failed to write diagnostics file %s", name, err)
+	}
+
+	if _, err := tw.Write(data); err != nil {
+		return errors.NewProcessingError("failed to write diagnostics file %s", name, err)
+	}
+
+	return nil
+}
+
+// redactedSettingsJSON encodes the settings with the values of the settings holding credentials or keys, and of
+// such query parameters of URLs, replaced
+func redactedSettingsJSON(tSettings any) ([]byte, error) {
+	data, err := json.Marshal(tSettings)
+	if err != nil {
+		return nil, errors.NewProcessingError("failed to encode the settings", err)
+	}
+
+	var decoded any
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		return nil, errors.NewProcessingError("failed to decode the settings", err)
+	}
+
+	return json.MarshalIndent(redactSettings(decoded), "", "  ")
+}
+
+func redactSettings(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			switch {
+			case key == "RawQuery":
+				if query, ok := field.(string); ok {
+					v[key] = redactQuery(query)
+				}
+			case sensitiveSetting.MatchString(key):
+				v[key] = redactValue(field)
+			default:
+				v[key] = redactSettings(field)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactSettings(v[i])
+		}
+	}
+
+	return value
+}
+
+// redactValue redacts a string, or the strings of a list, leaving other values and empty strings as they are
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return redactedSetting
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+
+	return value
+}
+
+func redactQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return redactedSetting
+	}
+
+	for key := range values {
+		if sensitiveSetting.MatchString(key) {
+			values[key] = []string{redactedSetting}
+		}
+	}
+
+	return values.Encode()
+}
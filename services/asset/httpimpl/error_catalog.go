@@ -0,0 +1,14 @@
+package httpimpl
+
+import (
+	"net/http"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/labstack/echo/v4"
+)
+
+// GetErrorCatalog returns the catalog of the error codes in the code field of the error responses, with their name,
+// category and description, ordered by code
+func (h *HTTP) GetErrorCatalog(c echo.Context) error {
+	return c.JSON(http.StatusOK, errors.Catalog())
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/bsv-blockchain/teranode/services/blockchain"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
 	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/labstack/echo/v4"
 )
 
@@ -13,6 +14,7 @@ import (
 type FSMHandler struct {
 	blockchainClient blockchain.ClientI
 	logger           ulogger.Logger
+	auditLog         *audit.Logger // records the events sent, nil when not audited
 }
 
 // NewFSMHandler creates a new FSM handler
@@ -138,6 +140,8 @@ func (h *FSMHandler) SendFSMEvent(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to send FSM event: "+err.Error())
 	}
 
+	recordAudit(h.auditLog, c, audit.ActionFSMEvent, eventType.String(), "")
+
 	state, err := h.getCurrentState(c)
 	if err != nil {
 		return err
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/bsv-blockchain/teranode/services/blockvalidation"
 	"github.com/labstack/echo/v4"
 )
 
@@ -33,7 +34,11 @@ func (h *HTTP) GetCatchupStatus(c echo.Context) error {
 		})
 	}
 
-	// Convert to JSON response
+	return c.JSON(http.StatusOK, catchupStatusResponse(status))
+}
+
+// catchupStatusResponse converts the catchup status of the BlockValidation service to its JSON response
+func catchupStatusResponse(status *blockvalidation.CatchupStatus) map[string]interface{} {
 	jsonResp := map[string]interface{}{
 		"is_catching_up":         status.IsCatchingUp,
 		"peer_id":                status.PeerID,
@@ -44,6 +49,10 @@ func (h *HTTP) GetCatchupStatus(c echo.Context) error {
 		"total_blocks":           status.TotalBlocks,
 		"blocks_fetched":         status.BlocksFetched,
 		"blocks_validated":       status.BlocksValidated,
+		"bytes_fetched":          status.BytesFetched,
+		"blocks_per_second":      status.BlocksPerSecond,
+		"bytes_per_second":       status.BytesPerSecond,
+		"eta_ms":                 status.EtaMs,
 		"start_time":             status.StartTime,
 		"duration_ms":            status.DurationMs,
 		"fork_depth":             status.ForkDepth,
@@ -66,5 +75,5 @@ func (h *HTTP) GetCatchupStatus(c echo.Context) error {
 		}
 	}
 
-	return c.JSON(http.StatusOK, jsonResp)
+	return jsonResp
 }
@@ -0,0 +1,85 @@
+package httpimpl
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeightCountResponse represents the number of peers at a given height
+type HeightCountResponse struct {
+	Height    int32 `json:"height"`
+	PeerCount int   `json:"peer_count"`
+}
+
+// ChainTipResponse represents a chain tip advertised by one or more peers
+type ChainTipResponse struct {
+	BlockHash string `json:"block_hash"`
+	Height    int32  `json:"height"`
+	PeerCount int    `json:"peer_count"`
+}
+
+// NetworkOverviewResponse represents the JSON response for the network overview
+type NetworkOverviewResponse struct {
+	TotalPeers         int                   `json:"total_peers"`
+	ConnectedPeers     int                   `json:"connected_peers"`
+	BestHeight         int32                 `json:"best_height"`
+	HeightDistribution []HeightCountResponse `json:"height_distribution"`
+	ChainTips          []ChainTipResponse    `json:"chain_tips"`
+	AvgResponseTimeMs  int64                 `json:"avg_response_time_ms"`
+	PeersJoined        int                   `json:"peers_joined"`
+	PeersLeft          int                   `json:"peers_left"`
+	ChurnRate          float64               `json:"churn_rate"`
+	ChurnWindowSeconds int64                 `json:"churn_window_seconds"`
+}
+
+// GetNetworkOverview returns an aggregate view of the network as seen by this node,
+// computed by the P2P service from its peer registry
+func (h *HTTP) GetNetworkOverview(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	p2pClient := h.repository.GetP2PClient()
+	if p2pClient == nil {
+		h.logger.Errorf("[GetNetworkOverview] P2P client not available")
+		return sendStatusError(c, http.StatusServiceUnavailable, "P2P service not available")
+	}
+
+	overview, err := p2pClient.GetNetworkOverview(ctx)
+	if err != nil {
+		h.logger.Errorf("[GetNetworkOverview] Failed to get network overview: %v", err)
+		return sendStatusError(c, http.StatusInternalServerError, "Failed to get network overview")
+	}
+
+	resp := NetworkOverviewResponse{
+		TotalPeers:         overview.TotalPeers,
+		ConnectedPeers:     overview.ConnectedPeers,
+		BestHeight:         overview.BestHeight,
+		HeightDistribution: make([]HeightCountResponse, 0, len(overview.HeightDistribution)),
+		ChainTips:          make([]ChainTipResponse, 0, len(overview.ChainTips)),
+		AvgResponseTimeMs:  overview.AvgResponseTime.Milliseconds(),
+		PeersJoined:        overview.PeersJoined,
+		PeersLeft:          overview.PeersLeft,
+		ChurnRate:          overview.ChurnRate,
+		ChurnWindowSeconds: int64(overview.ChurnWindow.Seconds()),
+	}
+
+	for _, hc := range overview.HeightDistribution {
+		resp.HeightDistribution = append(resp.HeightDistribution, HeightCountResponse{
+			Height:    hc.Height,
+			PeerCount: hc.PeerCount,
+		})
+	}
+
+	for _, tip := range overview.ChainTips {
+		resp.ChainTips = append(resp.ChainTips, ChainTipResponse{
+			BlockHash: tip.BlockHash,
+			Height:    tip.Height,
+			PeerCount: tip.PeerCount,
+		})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
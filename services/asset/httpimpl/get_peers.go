@@ -39,6 +39,42 @@ type PeerInfoResponse struct {
 	CatchupAvgResponseTime int64   `json:"catchup_avg_response_ms"`
 	LastCatchupError       string  `json:"last_catchup_error"`
 	LastCatchupErrorTime   int64   `json:"last_catchup_error_time"`
+
+	// Probation state after a ban
+	IsOnProbation  bool  `json:"is_on_probation"`
+	ProbationUntil int64 `json:"probation_until"`
+
+	// Bandwidth accounting
+	BytesSent   uint64 `json:"bytes_sent"`
+	IsThrottled bool   `json:"is_throttled"`
+
+	// DataHub health probing
+	IsHealthy           bool  `json:"is_healthy"`
+	HealthDurationMs    int64 `json:"health_duration_ms"`
+	HealthCheckFailures int   `json:"health_check_failures"`
+	IsDataHubDown       bool  `json:"is_datahub_down"`
+
+	// NAT traversal and transports
+	IsRelayOnly bool   `json:"is_relay_only"`
+	Transport   string `json:"transport"`
+
+	// GeoIP location
+	Country        string `json:"country"`
+	ASN            uint32 `json:"asn"`
+	ASOrganization string `json:"as_organization"`
+
+	// Wire protocol version negotiation
+	ProtocolVersion        uint32 `json:"protocol_version"`
+	IsProtocolIncompatible bool   `json:"is_protocol_incompatible"`
+
+	// DataHub identity verification
+	IsDataHubURLVerified bool `json:"is_datahub_url_verified"`
+
+	// Miner identification
+	MinerID            string `json:"miner_id"`
+	MinerPublicKey     string `json:"miner_public_key"`
+	MinerContact       string `json:"miner_contact"`
+	MinerIDAnnouncedAt int64  `json:"miner_id_announced_at"`
 }
 
 // PeersResponse represents the JSON response containing all peers
@@ -47,8 +83,14 @@ type PeersResponse struct {
 	Count int                `json:"count"`
 }
 
-// GetPeers returns the current peer registry data from the P2P service
+// GetPeers returns the current peer registry data from the P2P service. The peers are exported one per
+// row or line with ?format=csv or ?format=ndjson.
 func (h *HTTP) GetPeers(c echo.Context) error {
+	format, err := listFormat(c)
+	if err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
 	defer cancel()
 
@@ -75,9 +117,24 @@ func (h *HTTP) GetPeers(c echo.Context) error {
 	}
 
 	// Convert native PeerInfo to JSON response
+	peerResponses := peerInfoResponses(peers)
+
+	if format != listFormatJSON {
+		return writeList(c, format, "peers", peerResponses)
+	}
+
+	response := PeersResponse{
+		Peers: peerResponses,
+		Count: len(peerResponses),
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// peerInfoResponses converts the peers of the peer registry to their JSON response
+func peerInfoResponses(peers []*p2p.PeerInfo) []PeerInfoResponse {
 	peerResponses := make([]PeerInfoResponse, 0, len(peers))
-	for _, peerPtr := range peers {
-		peer := (*p2p.PeerInfo)(peerPtr) // Explicit type assertion to satisfy import checker
+	for _, peer := range peers {
 		peerResponses = append(peerResponses, PeerInfoResponse{
 			ID:              peer.ID.String(),
 			ClientName:      peer.ClientName,
@@ -106,13 +163,28 @@ func (h *HTTP) GetPeers(c echo.Context) error {
 			CatchupAvgResponseTime: peer.AvgResponseTime.Milliseconds(),
 			LastCatchupError:       peer.LastCatchupError,
 			LastCatchupErrorTime:   peer.LastCatchupErrorTime.Unix(),
+			IsOnProbation:          peer.IsOnProbation,
+			ProbationUntil:         peer.ProbationUntil.Unix(),
+			BytesSent:              peer.BytesSent,
+			IsThrottled:            peer.IsThrottled,
+			IsHealthy:              peer.IsHealthy,
+			HealthDurationMs:       peer.HealthDuration.Milliseconds(),
+			HealthCheckFailures:    peer.HealthCheckFailures,
+			IsDataHubDown:          peer.IsDataHubDown,
+			IsRelayOnly:            peer.IsRelayOnly,
+			Transport:              peer.Transport,
+			Country:                peer.Country,
+			ASN:                    peer.ASN,
+			ASOrganization:         peer.ASOrganization,
+			ProtocolVersion:        peer.ProtocolVersion,
+			IsProtocolIncompatible: peer.IsProtocolIncompatible,
+			IsDataHubURLVerified:   peer.IsDataHubURLVerified,
+			MinerID:                peer.MinerID,
+			MinerPublicKey:         peer.MinerPublicKey,
+			MinerContact:           peer.MinerContact,
+			MinerIDAnnouncedAt:     peer.MinerIDAnnouncedAt.Unix(),
 		})
 	}
 
-	response := PeersResponse{
-		Peers: peerResponses,
-		Count: len(peerResponses),
-	}
-
-	return c.JSON(http.StatusOK, response)
+	return peerResponses
 }
@@ -0,0 +1,135 @@
+package httpimpl
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// peerStatsTopCount is the number of top peers by reputation included in the stats
+	peerStatsTopCount = 10
+
+	// peerStatsHealthyReputation is the reputation at or above which a peer is counted as healthy,
+	// matching the threshold the P2P service uses to ignore messages from unhealthy peers
+	peerStatsHealthyReputation = 20.0
+)
+
+// PeerStatsTopPeer represents one of the top peers by reputation in the stats response
+type PeerStatsTopPeer struct {
+	ID              string  `json:"id"`
+	ClientName      string  `json:"client_name"`
+	Height          int32   `json:"height"`
+	ReputationScore float64 `json:"reputation_score"`
+	IsConnected     bool    `json:"is_connected"`
+}
+
+// PeersStatsResponse represents the JSON response for the aggregate peer statistics
+type PeersStatsResponse struct {
+	TotalPeers         int                   `json:"total_peers"`
+	ConnectedPeers     int                   `json:"connected_peers"`
+	BannedPeers        int                   `json:"banned_peers"`
+	HealthyPeers       int                   `json:"healthy_peers"`
+	MedianReputation   float64               `json:"median_reputation"`
+	HeightDistribution []HeightCountResponse `json:"height_distribution"`
+	TopPeers           []PeerStatsTopPeer    `json:"top_peers"`
+}
+
+// GetPeersStats returns aggregate statistics over the peer registry, so dashboards
+// don't have to pull the full peer list to compute summaries
+func (h *HTTP) GetPeersStats(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	p2pClient := h.repository.GetP2PClient()
+	if p2pClient == nil {
+		h.logger.Errorf("[GetPeersStats] P2P client not available")
+		return sendStatusError(c, http.StatusServiceUnavailable, "P2P service not available")
+	}
+
+	peers, err := p2pClient.GetPeerRegistry(ctx)
+	if err != nil {
+		h.logger.Errorf("[GetPeersStats] Failed to get peer registry: %v", err)
+		return sendStatusError(c, http.StatusInternalServerError, "Failed to get peer registry")
+	}
+
+	return c.JSON(http.StatusOK, buildPeersStats(peers))
+}
+
+// buildPeersStats aggregates registry peers into a PeersStatsResponse
+func buildPeersStats(peers []*p2p.PeerInfo) PeersStatsResponse {
+	resp := PeersStatsResponse{
+		TotalPeers:         len(peers),
+		HeightDistribution: []HeightCountResponse{},
+		TopPeers:           []PeerStatsTopPeer{},
+	}
+
+	heights := make(map[int32]int)
+	reputations := make([]float64, 0, len(peers))
+
+	for _, peer := range peers {
+		if peer.IsConnected {
+			resp.ConnectedPeers++
+		}
+
+		if peer.IsBanned {
+			resp.BannedPeers++
+		} else if peer.ReputationScore >= peerStatsHealthyReputation {
+			resp.HealthyPeers++
+		}
+
+		reputations = append(reputations, peer.ReputationScore)
+
+		// Peers that have not reported a height yet are left out of the distribution
+		if peer.Height > 0 {
+			heights[peer.Height]++
+		}
+	}
+
+	if len(reputations) > 0 {
+		sort.Float64s(reputations)
+
+		mid := len(reputations) / 2
+		if len(reputations)%2 == 0 {
+			resp.MedianReputation = (reputations[mid-1] + reputations[mid]) / 2
+		} else {
+			resp.MedianReputation = reputations[mid]
+		}
+	}
+
+	for height, count := range heights {
+		resp.HeightDistribution = append(resp.HeightDistribution, HeightCountResponse{Height: height, PeerCount: count})
+	}
+
+	sort.Slice(resp.HeightDistribution, func(i, j int) bool {
+		return resp.HeightDistribution[i].Height > resp.HeightDistribution[j].Height
+	})
+
+	sorted := make([]*p2p.PeerInfo, len(peers))
+	copy(sorted, peers)
+
+	// Highest reputation first, peer ID for a stable order on equal scores
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].ReputationScore != sorted[j].ReputationScore {
+			return sorted[i].ReputationScore > sorted[j].ReputationScore
+		}
+
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	for i := 0; i < len(sorted) && i < peerStatsTopCount; i++ {
+		resp.TopPeers = append(resp.TopPeers, PeerStatsTopPeer{
+			ID:              sorted[i].ID.String(),
+			ClientName:      sorted[i].ClientName,
+			Height:          sorted[i].Height,
+			ReputationScore: sorted[i].ReputationScore,
+			IsConnected:     sorted[i].IsConnected,
+		})
+	}
+
+	return resp
+}
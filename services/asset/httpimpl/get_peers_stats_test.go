@@ -0,0 +1,60 @@
+package httpimpl
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPeersStats(t *testing.T) {
+	t.Run("empty registry", func(t *testing.T) {
+		stats := buildPeersStats(nil)
+
+		assert.Equal(t, 0, stats.TotalPeers)
+		assert.Zero(t, stats.MedianReputation)
+		assert.NotNil(t, stats.HeightDistribution)
+		assert.NotNil(t, stats.TopPeers)
+	})
+
+	t.Run("aggregates", func(t *testing.T) {
+		peers := []*p2p.PeerInfo{
+			{ID: peer.ID("a"), Height: 100, ReputationScore: 90, IsConnected: true},
+			{ID: peer.ID("b"), Height: 100, ReputationScore: 50, IsConnected: true},
+			{ID: peer.ID("c"), Height: 99, ReputationScore: 10},
+			{ID: peer.ID("d"), Height: 0, ReputationScore: 70, IsBanned: true},
+		}
+
+		stats := buildPeersStats(peers)
+
+		assert.Equal(t, 4, stats.TotalPeers)
+		assert.Equal(t, 2, stats.ConnectedPeers)
+		assert.Equal(t, 1, stats.BannedPeers)
+		assert.Equal(t, 2, stats.HealthyPeers, "banned and low reputation peers are not healthy")
+		assert.InDelta(t, 60.0, stats.MedianReputation, 0.001)
+
+		require.Len(t, stats.HeightDistribution, 2)
+		assert.Equal(t, HeightCountResponse{Height: 100, PeerCount: 2}, stats.HeightDistribution[0])
+		assert.Equal(t, HeightCountResponse{Height: 99, PeerCount: 1}, stats.HeightDistribution[1])
+
+		require.Len(t, stats.TopPeers, 4)
+		assert.Equal(t, peer.ID("a").String(), stats.TopPeers[0].ID)
+		assert.Equal(t, peer.ID("d").String(), stats.TopPeers[1].ID)
+		assert.Equal(t, peer.ID("c").String(), stats.TopPeers[3].ID)
+	})
+
+	t.Run("top peers capped", func(t *testing.T) {
+		peers := make([]*p2p.PeerInfo, 0, 15)
+		for i := 0; i < 15; i++ {
+			peers = append(peers, &p2p.PeerInfo{ID: peer.ID(string(rune('A' + i))), ReputationScore: float64(i)})
+		}
+
+		stats := buildPeersStats(peers)
+
+		require.Len(t, stats.TopPeers, peerStatsTopCount)
+		assert.InDelta(t, 14.0, stats.TopPeers[0].ReputationScore, 0.001)
+		assert.InDelta(t, 7.0, stats.MedianReputation, 0.001)
+	})
+}
@@ -3,9 +3,12 @@ package httpimpl
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bsv-blockchain/teranode/errors"
@@ -14,11 +17,15 @@ import (
 	"github.com/bsv-blockchain/teranode/ui/dashboard"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/audit"
+	"github.com/bsv-blockchain/teranode/util/bandwidth"
 	"github.com/bsv-blockchain/teranode/util/servicemanager"
+	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/ordishs/gocore"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var AssetStat = gocore.NewStat("Asset")
@@ -31,6 +38,28 @@ type HTTP struct {
 	e          *echo.Echo
 	startTime  time.Time
 	privKey    crypto.PrivKey
+	bandwidth  *bandwidth.Scheduler
+	logLevels  *ulogger.LevelRegistry
+
+	// limits the catchup data served to a single peer, nil when the catchup serving limits are disabled
+	catchupLimiter *catchupServeLimiter
+
+	// caches the responses of the endpoints polled by dashboards and serves their ETags
+	responseCache *responseCache
+
+	// records the privileged operations performed through the API, nil when there is no blockchain client
+	auditLog *audit.Logger
+
+	// set while a diagnostics bundle is created, a single bundle is created at a time
+	diagnosticsInFlight atomic.Bool
+
+	// connection slots shared by the listeners of all listen addresses
+	connectionSlots     chan struct{}
+	connectionSlotsOnce sync.Once
+
+	// servers of the additional listen addresses
+	additionalMu      sync.Mutex
+	additionalServers []*http.Server
 }
 
 // New creates and configures a new HTTP server instance with all routes and middleware.
@@ -48,6 +77,7 @@ type HTTP struct {
 //	Health and Status:
 //	- GET /alive: Service liveness check
 //	- GET /health: Service health check with dependency status
+//	- GET /swagger: API reference of the OpenAPI document
 //
 //	Transaction Related:
 //	- GET /api/v1/tx/{hash}: Get transaction (binary/hex/json)
@@ -72,12 +102,31 @@ type HTTP struct {
 //	- GET /api/v1/search: Search for blockchain entities
 //
 //	Legacy Compatibility:
-//	- GET /rest/block/{hash}.bin: Get block in legacy format
-//	- GET /api/v1/block_legacy/{hash}: Get block in legacy format
+//	- GET /rest/block/{hash}.bin: Get block in legacy format, supports HEAD and range requests
+//	- GET /api/v1/block_legacy/{hash}: Get block in legacy format, supports HEAD and range requests
 //
 //	Network and P2P:
 //	- GET /api/v1/catchup/status: Get blockchain catchup status
 //	- GET /api/v1/peers: Get peer registry data
+//	- GET /api/v1/peers/stats: Get aggregate peer registry statistics
+//	- GET /api/v1/peers/contributions: Get the data each peer served to us and we served to it
+//	- GET /api/v1/network/overview: Get aggregate network view from the peer registry
+//	- GET /api/v1/identity: Get the identity document of this node, signed with its peer key over a challenge
+//	- GET /api/v1/bandwidth: Get the shared bandwidth budget and per-class shares
+//	- POST /api/v1/bandwidth: Adjust the bandwidth budget and class weights at runtime
+//
+//	Administration:
+//	- GET /api/v1/openapi.json: Get the OpenAPI 3 document of the API
+//	- GET /api/v1/version: Get the build version, commit, features, network and running services of this node
+//	- GET /api/v1/errors: Get the catalog of the error codes returned in the error responses
+//	- GET /api/v1/loglevels: Get the default log level and the level of every logger component
+//	- POST /api/v1/loglevels: Change the log level of a component, e.g. blockvalidation, at runtime
+//	- GET /api/v1/bans: List the banned peer IDs, IP addresses and subnets, paginated
+//	- POST /api/v1/bans: Ban peer IDs, IP addresses and subnets in bulk
+//	- DELETE /api/v1/bans: Lift the bans of peer IDs, IP addresses and subnets in bulk
+//	- GET /api/v1/operator-messages: Get the direct messages exchanged with the operators of other nodes
+//	- POST /api/v1/operator-messages: Send a direct message to the operator of another node
+//	- GET /api/v1/audit: Get the audit records of the privileged operations, newest first, paginated
 //
 // Configuration:
 //   - ECHO_DEBUG: Enable debug logging
@@ -113,6 +162,9 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 	e.HideBanner = true
 	e.HidePort = true
 
+	// error responses carry the code of the error, see GET /api/v1/errors
+	e.HTTPErrorHandler = httpErrorHandler
+
 	e.Use(middleware.Recover())
 
 	// Default CORS config for non-dashboard endpoints
@@ -124,12 +176,18 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 		},
 		AllowMethods:     []string{echo.GET, echo.HEAD, echo.PUT, echo.PATCH, echo.POST, echo.DELETE, echo.OPTIONS},
 		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, echo.HeaderXRequestedWith},
-		ExposeHeaders:    []string{echo.HeaderContentLength, echo.HeaderContentType},
+		ExposeHeaders:    []string{echo.HeaderContentLength, echo.HeaderContentType, headerAcceptRanges, headerContentRange, headerETag},
 		AllowCredentials: true,
 		MaxAge:           86400,
 	}))
 
-	e.Use(middleware.Gzip())
+	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
+		// ranges are served on the uncompressed bytes
+		Skipper: func(c echo.Context) bool {
+			return c.Request().Header.Get(headerRange) != "" || c.Request().Method == http.MethodHead
+		},
+	}))
+	e.Use(tracingMiddleware())
 
 	if e.Debug {
 		e.Use(customLoggerMiddleware(logger))
@@ -141,6 +199,27 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 		repository: repo,
 		e:          e,
 		startTime:  time.Now(),
+		bandwidth:  bandwidth.Default(),
+		logLevels:  ulogger.ComponentLevels(),
+
+		responseCache: newResponseCache(tSettings),
+	}
+
+	if repo.BlockchainClient != nil {
+		h.auditLog = audit.NewLogger(logger, repo.BlockchainClient, tSettings.AuditLogRateLimit)
+	}
+
+	h.bandwidth.Configure(bandwidth.ConfigFromSettings(tSettings))
+	e.Pre(h.bandwidthMiddleware)
+	e.Pre(h.peerContributionMiddleware)
+
+	if tSettings.Canary.Enabled {
+		e.Pre(canaryMiddleware)
+	}
+
+	if tSettings.Asset.CatchupServeMaxBytesPerHour > 0 || tSettings.Asset.CatchupServeMaxBlocksPerHour > 0 {
+		h.catchupLimiter = newCatchupServeLimiter(tSettings)
+		e.Use(h.catchupServeLimitMiddleware)
 	}
 
 	// add the private key for signing responses
@@ -176,8 +255,11 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 		return c.String(http.StatusOK, details)
 	})
 
+	e.GET("/swagger", h.GetSwagger)
+
 	apiRestGroup := e.Group("/rest")
 	apiRestGroup.GET("/block/:hash.bin", h.GetRestLegacyBlock()) // BINARY_STREAM
+	apiRestGroup.HEAD("/block/:hash.bin", h.GetRestLegacyBlock())
 
 	apiPrefix := tSettings.Asset.APIPrefix
 	apiGroup := e.Group(apiPrefix)
@@ -204,22 +286,22 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 
 	apiGroup.GET("/subtree/:hash/txs/json", h.GetSubtreeTxs(JSON))
 
-	apiGroup.GET("/headers/:hash", h.GetBlockHeaders(BINARY_STREAM))
-	apiGroup.GET("/headers/:hash/hex", h.GetBlockHeaders(HEX))
-	apiGroup.GET("/headers/:hash/json", h.GetBlockHeaders(JSON))
+	apiGroup.GET("/headers/:hash", h.GetBlockHeaders(BINARY_STREAM), h.responseCache.middleware)
+	apiGroup.GET("/headers/:hash/hex", h.GetBlockHeaders(HEX), h.responseCache.middleware)
+	apiGroup.GET("/headers/:hash/json", h.GetBlockHeaders(JSON), h.responseCache.middleware)
 
 	// this needs to be removed in the future, after all clients have migrated to the new endpoint
-	apiGroup.GET("/headers_to_common_ancestor/:hash", h.GetBlockHeadersToCommonAncestor(BINARY_STREAM))
-	apiGroup.GET("/headers_to_common_ancestor/:hash/hex", h.GetBlockHeadersToCommonAncestor(HEX))
-	apiGroup.GET("/headers_to_common_ancestor/:hash/json", h.GetBlockHeadersToCommonAncestor(JSON))
+	apiGroup.GET("/headers_to_common_ancestor/:hash", h.GetBlockHeadersToCommonAncestor(BINARY_STREAM), h.responseCache.middleware)
+	apiGroup.GET("/headers_to_common_ancestor/:hash/hex", h.GetBlockHeadersToCommonAncestor(HEX), h.responseCache.middleware)
+	apiGroup.GET("/headers_to_common_ancestor/:hash/json", h.GetBlockHeadersToCommonAncestor(JSON), h.responseCache.middleware)
 
-	apiGroup.GET("/headers_from_common_ancestor/:hash", h.GetBlockHeadersFromCommonAncestor(BINARY_STREAM))
-	apiGroup.GET("/headers_from_common_ancestor/:hash/hex", h.GetBlockHeadersFromCommonAncestor(HEX))
-	apiGroup.GET("/headers_from_common_ancestor/:hash/json", h.GetBlockHeadersFromCommonAncestor(JSON))
+	apiGroup.GET("/headers_from_common_ancestor/:hash", h.GetBlockHeadersFromCommonAncestor(BINARY_STREAM), h.responseCache.middleware)
+	apiGroup.GET("/headers_from_common_ancestor/:hash/hex", h.GetBlockHeadersFromCommonAncestor(HEX), h.responseCache.middleware)
+	apiGroup.GET("/headers_from_common_ancestor/:hash/json", h.GetBlockHeadersFromCommonAncestor(JSON), h.responseCache.middleware)
 
-	apiGroup.GET("/header/:hash", h.GetBlockHeader(BINARY_STREAM))
-	apiGroup.GET("/header/:hash/hex", h.GetBlockHeader(HEX))
-	apiGroup.GET("/header/:hash/json", h.GetBlockHeader(JSON))
+	apiGroup.GET("/header/:hash", h.GetBlockHeader(BINARY_STREAM), h.responseCache.middleware)
+	apiGroup.GET("/header/:hash/hex", h.GetBlockHeader(HEX), h.responseCache.middleware)
+	apiGroup.GET("/header/:hash/json", h.GetBlockHeader(JSON), h.responseCache.middleware)
 
 	apiGroup.GET("/blocks", h.GetBlocks)
 	apiGroup.GET("/block_locator", h.GetBlockLocator)
@@ -229,6 +311,7 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 	apiGroup.GET("/blocks/:hash/json", h.GetNBlocks(JSON))
 
 	apiGroup.GET("/block_legacy/:hash", h.GetLegacyBlock()) // BINARY_STREAM
+	apiGroup.HEAD("/block_legacy/:hash", h.GetLegacyBlock())
 
 	apiGroup.GET("/block/:hash", h.GetBlockByHash(BINARY_STREAM))
 	apiGroup.GET("/block/:hash/hex", h.GetBlockByHash(HEX))
@@ -249,9 +332,9 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 
 	apiGroup.GET("/utxos/:hash/json", h.GetUTXOsByTxID(JSON))
 
-	apiGroup.GET("/bestblockheader", h.GetBestBlockHeader(BINARY_STREAM))
-	apiGroup.GET("/bestblockheader/hex", h.GetBestBlockHeader(HEX))
-	apiGroup.GET("/bestblockheader/json", h.GetBestBlockHeader(JSON))
+	apiGroup.GET("/bestblockheader", h.GetBestBlockHeader(BINARY_STREAM), h.responseCache.middleware)
+	apiGroup.GET("/bestblockheader/hex", h.GetBestBlockHeader(HEX), h.responseCache.middleware)
+	apiGroup.GET("/bestblockheader/json", h.GetBestBlockHeader(JSON), h.responseCache.middleware)
 
 	apiGroup.GET("/merkle_proof/:hash", h.GetMerkleProof(BINARY_STREAM))
 	apiGroup.GET("/merkle_proof/:hash/hex", h.GetMerkleProof(HEX))
@@ -267,7 +350,7 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 		// Initialize dashboard with settings
 		dashboard.InitDashboard(h.settings)
 
-		// Apply authentication middleware for all POST endpoints
+		// Apply authentication middleware for all POST and DELETE endpoints
 		authHandler := dashboard.NewAuthHandler(h.logger, h.settings)
 		apiGroup.Use(authHandler.PostAuthMiddleware)
 
@@ -279,7 +362,7 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 			},
 			AllowMethods:     []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete, http.MethodOptions},
 			AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, echo.HeaderXRequestedWith, "X-CSRF-Token"},
-			ExposeHeaders:    []string{echo.HeaderContentLength, echo.HeaderContentType},
+			ExposeHeaders:    []string{echo.HeaderContentLength, echo.HeaderContentType, headerAcceptRanges, headerContentRange, headerETag},
 			AllowCredentials: true,
 			MaxAge:           86400,
 		}
@@ -299,6 +382,7 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 	}
 
 	fsmHandler := NewFSMHandler(repo.BlockchainClient, logger)
+	fsmHandler.auditLog = h.auditLog
 
 	const (
 		pathFsmState  = "/fsm/state"
@@ -325,6 +409,7 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 
 	// Create and register block handler for block operations
 	blockHandler := NewBlockHandler(repo.BlockchainClient, repo.BlockvalidationClient, logger)
+	blockHandler.auditLog = h.auditLog
 
 	// Register block invalidation/revalidation endpoints
 	apiGroup.POST("/block/invalidate", blockHandler.InvalidateBlock)
@@ -332,19 +417,59 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, repo *repository.R
 	apiGroup.GET("/blocks/invalid", blockHandler.GetLastNInvalidBlocks)
 
 	// Register catchup status endpoint
-	apiGroup.GET("/catchup/status", h.GetCatchupStatus)
+	apiGroup.GET("/catchup/status", h.GetCatchupStatus, h.responseCache.middleware)
 
 	// Register peers endpoint
-	apiGroup.GET("/peers", h.GetPeers)
+	apiGroup.GET("/peers", h.GetPeers, h.responseCache.middleware)
+	apiGroup.GET("/peers/stats", h.GetPeersStats, h.responseCache.middleware)
+	apiGroup.GET("/peers/contributions", h.GetPeerContributions)
+
+	// Register network overview endpoint
+	apiGroup.GET("/network/overview", h.GetNetworkOverview)
+
+	// Register identity endpoint, verifying that the DataHub URL advertised by this node is served by it
+	apiGroup.GET("/identity", h.GetIdentity)
+
+	// Register bandwidth budget endpoints
+	apiGroup.GET("/bandwidth", h.GetBandwidth)
+	apiGroup.POST("/bandwidth", h.SetBandwidth)
+
+	// Register the OpenAPI document of the routes of this group
+	apiGroup.GET("/openapi.json", h.GetOpenAPI)
+
+	// Register build metadata endpoint
+	apiGroup.GET("/version", h.GetVersion)
+
+	// Register the catalog of the error codes of the error responses
+	apiGroup.GET("/errors", h.GetErrorCatalog)
+
+	// Register runtime log level endpoints
+	apiGroup.GET("/loglevels", h.GetLogLevels)
+	apiGroup.POST("/loglevels", h.SetLogLevel)
+
+	// Register ban management endpoints
+	apiGroup.GET("/bans", h.GetBans)
+	apiGroup.POST("/bans", h.BanPeers)
+	apiGroup.DELETE("/bans", h.UnbanPeers)
+
+	// Register the audit log of the privileged operations
+	apiGroup.GET("/audit", h.GetAuditLog)
+
+	// Register the diagnostics bundle endpoint, a POST as the bundle exposes the settings and profiles of the node
+	apiGroup.POST("/diagnostics/bundle", h.CreateDiagnosticsBundle)
+
+	// Register direct operator message endpoints
+	apiGroup.GET("/operator-messages", h.GetOperatorMessages)
+	apiGroup.POST("/operator-messages", h.SendOperatorMessage)
 
 	// Register dashboard-compatible API routes
 	// The dashboard's SvelteKit +server.ts endpoints don't work in production (adapter-static)
 	// so we need to provide the same endpoints directly in the Go backend
 	apiP2PGroup := e.Group("/api/p2p")
-	apiP2PGroup.GET("/peers", h.GetPeers)
+	apiP2PGroup.GET("/peers", h.GetPeers, h.responseCache.middleware)
 
 	apiCatchupGroup := e.Group("/api/catchup")
-	apiCatchupGroup.GET("/status", h.GetCatchupStatus)
+	apiCatchupGroup.GET("/status", h.GetCatchupStatus, h.responseCache.middleware)
 
 	// Add OPTIONS handlers for block operations
 	apiGroup.OPTIONS("/block/invalidate", func(c echo.Context) error {
@@ -390,31 +515,38 @@ func (h *HTTP) Start(ctx context.Context, addr string) error {
 
 		h.logger.Infof("[Asset] %s (impl) service shutting down", mode)
 
-		err := h.e.Shutdown(context.Background())
+		err := h.shutdown(context.Background())
 		if err != nil {
 			h.logger.Errorf("[Asset] %s (impl) service shutdown error: %s", mode, err)
 		}
 	}()
 
-	// Set the listener on the Echo server
-	h.e.Listener = listener
+	// Limit the concurrent connections and close keep-alive connections that stay idle
+	listener = h.limitConnections(listener)
+
+	for _, server := range []*http.Server{h.e.Server, h.e.TLSServer} {
+		server.IdleTimeout = h.settings.Asset.HTTPIdleTimeout
+	}
 
 	if mode == "HTTP" {
+		// Set the listener on the Echo server
+		h.e.Listener = listener
+
 		servicemanager.AddListenerInfo(fmt.Sprintf("Asset HTTP listening on %s", address))
 		err = h.e.Start(address)
 	} else {
-		certFile := h.settings.ServerCertFile
-		if certFile == "" {
-			return errors.NewConfigurationError("server_certFile is required for HTTPS")
+		tlsConfig, tlsErr := h.tlsConfig()
+		if tlsErr != nil {
+			return tlsErr
 		}
 
-		keyFile := h.settings.ServerKeyFile
-		if keyFile == "" {
-			return errors.NewConfigurationError("server_keyFile is required for HTTPS")
-		}
+		// Serve TLS on the limited listener, Echo#StartTLS would open a listener of its own
+		h.e.TLSServer.Addr = address
+		h.e.TLSServer.TLSConfig = tlsConfig
+		h.e.TLSListener = tls.NewListener(listener, h.e.TLSServer.TLSConfig)
 
 		servicemanager.AddListenerInfo(fmt.Sprintf("Asset HTTPS listening on %s", address))
-		err = h.e.StartTLS(address, certFile, keyFile)
+		err = h.e.StartServer(h.e.TLSServer)
 	}
 
 	if !errors.Is(err, http.ErrServerClosed) {
@@ -424,8 +556,32 @@ func (h *HTTP) Start(ctx context.Context, addr string) error {
 	return nil
 }
 
+// tlsConfig returns the TLS configuration of the HTTPS server, with the configured server certificate
+func (h *HTTP) tlsConfig() (*tls.Config, error) {
+	certFile := h.settings.ServerCertFile
+	if certFile == "" {
+		return nil, errors.NewConfigurationError("server_certFile is required for HTTPS")
+	}
+
+	keyFile := h.settings.ServerKeyFile
+	if keyFile == "" {
+		return nil, errors.NewConfigurationError("server_keyFile is required for HTTPS")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.NewConfigurationError("failed to load server_certFile and server_keyFile", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2"},
+	}, nil
+}
+
 func (h *HTTP) Stop(ctx context.Context) error {
-	return h.e.Shutdown(ctx)
+	return h.shutdown(ctx)
 }
 
 func (h *HTTP) AddHTTPHandler(pattern string, handler http.Handler) error {
@@ -450,6 +606,35 @@ func (h *HTTP) Sign(resp *echo.Response, hash []byte) error {
 }
 
 // Middleware to log HTTP requests using the custom logger
+// tracingMiddleware continues the trace propagated by the requesting node and wraps the request in a span,
+// so the spans of the handlers are part of the trace of the caller, e.g. the catchup of a peer
+func tracingMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !tracing.IsTracingEnabled() {
+				return next(c)
+			}
+
+			req := c.Request()
+			ctx := tracing.ExtractHTTPHeaders(req.Context(), req.Header)
+
+			ctx, span, deferFn := tracing.Tracer("asset").Start(ctx, "HTTP "+req.Method+" "+c.Path(),
+				tracing.WithTag("http.method", req.Method),
+				tracing.WithTag("http.route", c.Path()),
+			)
+
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+
+			span.SetAttributes(attribute.Int("http.status_code", c.Response().Status))
+			deferFn(err)
+
+			return err
+		}
+	}
+}
+
 func customLoggerMiddleware(logger ulogger.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -0,0 +1,36 @@
+package httpimpl
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetIdentity returns the identity document of this node for the challenge query parameter: the peer ID and
+// the DataHub URL of the node, signed with its peer key. Other nodes request it from the DataHub URL we advertise
+// to verify it is served by us, so no other peer can claim it.
+func (h *HTTP) GetIdentity(c echo.Context) error {
+	challenge := c.QueryParam("challenge")
+	if challenge == "" {
+		return sendStatusError(c, http.StatusBadRequest, "challenge query parameter is required")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	p2pClient := h.repository.GetP2PClient()
+	if p2pClient == nil {
+		h.logger.Errorf("[GetIdentity] P2P client not available")
+		return sendStatusError(c, http.StatusServiceUnavailable, "P2P service not available")
+	}
+
+	doc, err := p2pClient.SignIdentity(ctx, challenge)
+	if err != nil {
+		h.logger.Errorf("[GetIdentity] Failed to sign identity document: %v", err)
+		return sendStatusError(c, http.StatusServiceUnavailable, "Failed to sign identity document")
+	}
+
+	return c.JSON(http.StatusOK, doc)
+}
@@ -0,0 +1,160 @@
+package httpimpl
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/labstack/echo/v4"
+)
+
+// Output formats of the list endpoints, selected with the format query parameter
+const (
+	listFormatJSON   = "json"   // The JSON document of the endpoint, the default
+	listFormatCSV    = "csv"    // A header row with the JSON field names, then one row per record
+	listFormatNDJSON = "ndjson" // One JSON object per line, one line per record
+)
+
+const (
+	mimeTextCSV           = "text/csv; charset=utf-8"
+	mimeApplicationNDJSON = "application/x-ndjson"
+
+	// listFlushInterval is the number of records written between flushes of the response to the client
+	listFlushInterval = 100
+)
+
+// listFormat returns the output format requested with the format query parameter, json when none is requested
+func listFormat(c echo.Context) (string, error) {
+	switch format := c.QueryParam("format"); format {
+	case "", listFormatJSON:
+		return listFormatJSON, nil
+	case listFormatCSV, listFormatNDJSON:
+		return format, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusBadRequest, errors.NewInvalidArgumentError("invalid format %q, expected json, csv or ndjson", format).Error())
+	}
+}
+
+// writeList streams the records in the csv or ndjson format, flushing the response every listFlushInterval
+// records so large exports are not buffered in memory. The records are encoded with their JSON encoding, the
+// CSV columns are the fields of the first record, nested values are written as JSON. name is the file name
+// suggested to the client, without extension.
+func writeList[T any](c echo.Context, format string, name string, records []T) error {
+	res := c.Response()
+
+	switch format {
+	case listFormatCSV:
+		res.Header().Set(echo.HeaderContentType, mimeTextCSV)
+	case listFormatNDJSON:
+		res.Header().Set(echo.HeaderContentType, mimeApplicationNDJSON)
+	default:
+		return errors.NewInvalidArgumentError("unsupported list format %q", format)
+	}
+
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+name+"."+format+`"`)
+	res.WriteHeader(http.StatusOK)
+
+	csvWriter := csv.NewWriter(res)
+
+	var columns []string
+
+	for i, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return errors.NewProcessingError("failed to encode record %d", i, err)
+		}
+
+		if format == listFormatNDJSON {
+			if _, err = res.Write(append(data, '\n')); err != nil {
+				return err
+			}
+		} else {
+			keys, fields, err := jsonRecordFields(data)
+			if err != nil {
+				return errors.NewProcessingError("failed to encode record %d", i, err)
+			}
+
+			if columns == nil {
+				columns = keys
+
+				if err = csvWriter.Write(columns); err != nil {
+					return err
+				}
+			}
+
+			row := make([]string, len(columns))
+			for j, column := range columns {
+				row[j] = fields[column]
+			}
+
+			if err = csvWriter.Write(row); err != nil {
+				return err
+			}
+		}
+
+		if (i+1)%listFlushInterval == 0 {
+			csvWriter.Flush()
+			res.Flush()
+		}
+	}
+
+	csvWriter.Flush()
+
+	if err := csvWriter.Error(); err != nil {
+		return err
+	}
+
+	res.Flush()
+
+	return nil
+}
+
+// jsonRecordFields returns the field names of the JSON object in the order they are encoded, and the fields as CSV
+// cells: strings unquoted, null empty, and numbers, booleans and nested values as JSON
+func jsonRecordFields(data []byte) ([]string, map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := decoder.Token()
+	if err != nil {
+		return nil, nil, errors.NewProcessingError("failed to read record", err)
+	}
+
+	if tok != json.Delim('{') {
+		return nil, nil, errors.NewProcessingError("record is not a JSON object")
+	}
+
+	var keys []string
+
+	fields := make(map[string]string)
+
+	for decoder.More() {
+		if tok, err = decoder.Token(); err != nil {
+			return nil, nil, errors.NewProcessingError("failed to read field name", err)
+		}
+
+		key, _ := tok.(string)
+
+		var raw json.RawMessage
+		if err = decoder.Decode(&raw); err != nil {
+			return nil, nil, errors.NewProcessingError("failed to read field %s", key, err)
+		}
+
+		cell := string(raw)
+
+		switch {
+		case cell == "null":
+			cell = ""
+		case len(raw) > 0 && raw[0] == '"':
+			if err = json.Unmarshal(raw, &cell); err != nil {
+				return nil, nil, errors.NewProcessingError("failed to read field %s", key, err)
+			}
+		}
+
+		keys = append(keys, key)
+		fields[key] = cell
+	}
+
+	return keys, fields, nil
+}
@@ -0,0 +1,137 @@
+package httpimpl
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type listTestRecord struct {
+	Name    string            `json:"name"`
+	Height  int               `json:"height"`
+	Banned  bool              `json:"banned"`
+	Note    *string           `json:"note"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Comment string            `json:"comment"`
+}
+
+func newListTestContext(target string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+
+	return echo.New().NewContext(req, rec), rec
+}
+
+func TestListFormat(t *testing.T) {
+	for query, expected := range map[string]string{
+		"/peers":               listFormatJSON,
+		"/peers?format=json":   listFormatJSON,
+		"/peers?format=csv":    listFormatCSV,
+		"/peers?format=ndjson": listFormatNDJSON,
+	} {
+		c, _ := newListTestContext(query)
+
+		format, err := listFormat(c)
+		require.NoError(t, err, query)
+		assert.Equal(t, expected, format, query)
+	}
+
+	c, _ := newListTestContext("/peers?format=xml")
+
+	_, err := listFormat(c)
+	require.Error(t, err)
+
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func TestWriteList(t *testing.T) {
+	records := []listTestRecord{
+		{Name: "peer, one", Height: 100, Banned: true, Labels: map[string]string{"a": "b"}, Comment: `say "hi"`},
+		{Name: "peer-two", Height: 99},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		c, rec := newListTestContext("/peers?format=csv")
+
+		require.NoError(t, writeList(c, listFormatCSV, "peers", records))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, mimeTextCSV, rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="peers.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+
+		rows, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+
+		// the columns are the fields of the first record, in the order of its JSON encoding
+		assert.Equal(t, []string{"name", "height", "banned", "note", "labels", "comment"}, rows[0])
+		assert.Equal(t, []string{"peer, one", "100", "true", "", `{"a":"b"}`, `say "hi"`}, rows[1])
+		assert.Equal(t, []string{"peer-two", "99", "false", "", "", ""}, rows[2])
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		c, rec := newListTestContext("/peers?format=ndjson")
+
+		require.NoError(t, writeList(c, listFormatNDJSON, "peers", records))
+
+		assert.Equal(t, mimeApplicationNDJSON, rec.Header().Get(echo.HeaderContentType))
+
+		scanner := bufio.NewScanner(rec.Body)
+
+		var decoded []listTestRecord
+
+		for scanner.Scan() {
+			var record listTestRecord
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+
+			decoded = append(decoded, record)
+		}
+
+		assert.Equal(t, records, decoded)
+	})
+
+	t.Run("flushes large exports", func(t *testing.T) {
+		many := make([]listTestRecord, listFlushInterval*3+1)
+		for i := range many {
+			many[i] = listTestRecord{Name: "peer-" + strconv.Itoa(i), Height: i}
+		}
+
+		c, rec := newListTestContext("/peers?format=ndjson")
+
+		require.NoError(t, writeList(c, listFormatNDJSON, "peers", many))
+
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, len(many), strings.Count(rec.Body.String(), "\n"))
+	})
+
+	t.Run("no records", func(t *testing.T) {
+		c, rec := newListTestContext("/peers?format=csv")
+
+		require.NoError(t, writeList(c, listFormatCSV, "peers", []listTestRecord{}))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+}
+
+func TestJSONRecordFields(t *testing.T) {
+	_, _, err := jsonRecordFields([]byte(`[1, 2]`))
+	require.Error(t, err)
+
+	keys, fields, err := jsonRecordFields([]byte(`{"b": 1.5, "a": null, "c": [1, "x"]}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"b", "a", "c"}, keys)
+	assert.Equal(t, map[string]string{"b": "1.5", "a": "", "c": `[1, "x"]`}, fields)
+}
@@ -0,0 +1,98 @@
+package httpimpl
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/servicemanager"
+)
+
+// StartAdditional serves the asset API on an additional listen address, e.g. an internal interface next to the
+// external one. The routes, middleware, TLS mode and connection limit are shared with the main listen address. It
+// blocks until the server is shut down with the main server or fails.
+//
+// Parameters:
+//   - ctx: Context of the server, the server shuts down when it is cancelled
+//   - addr: Address to listen on, e.g. "10.0.0.1:8090"
+//
+// Returns:
+//   - error: If the address cannot be listened on or the server fails
+func (h *HTTP) StartAdditional(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.NewServiceError("[Asset] failed to listen on additional address %s", addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           h.e,
+		IdleTimeout:       h.settings.Asset.HTTPIdleTimeout,
+		ReadHeaderTimeout: h.e.Server.ReadHeaderTimeout,
+		ErrorLog:          h.e.StdLogger,
+	}
+
+	listener = h.limitConnections(listener)
+
+	mode := "HTTP"
+
+	if h.settings.SecurityLevelHTTP != 0 {
+		mode = "HTTPS"
+
+		tlsConfig, tlsErr := h.tlsConfig()
+		if tlsErr != nil {
+			_ = listener.Close()
+			return tlsErr
+		}
+
+		server.TLSConfig = tlsConfig
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	h.additionalMu.Lock()
+	h.additionalServers = append(h.additionalServers, server)
+	h.additionalMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		h.shutdownAdditional(context.Background())
+	}()
+
+	servicemanager.AddListenerInfo(fmt.Sprintf("Asset %s listening on %s", mode, listener.Addr().String()))
+
+	if err = server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.NewServiceError("[Asset] %s server on %s failed", mode, addr, err)
+	}
+
+	return nil
+}
+
+// shutdownAdditional shuts down the servers of the additional listen addresses, closing the connections still
+// active after the asset_http_shutdown_timeout
+func (h *HTTP) shutdownAdditional(ctx context.Context) {
+	h.additionalMu.Lock()
+	servers := h.additionalServers
+	h.additionalServers = nil
+	h.additionalMu.Unlock()
+
+	if len(servers) == 0 {
+		return
+	}
+
+	if h.settings.Asset.HTTPShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, h.settings.Asset.HTTPShutdownTimeout)
+		defer cancel()
+	}
+
+	for _, server := range servers {
+		if err := server.Shutdown(ctx); err != nil {
+			h.logger.Warnf("[Asset] HTTP server on an additional address did not drain in-flight requests in time, closing remaining connections: %v", err)
+			_ = server.Close()
+		}
+	}
+}
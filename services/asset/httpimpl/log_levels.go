@@ -0,0 +1,61 @@
+package httpimpl
+
+import (
+	"net/http"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/audit"
+	"github.com/labstack/echo/v4"
+)
+
+// LogLevelsResponse represents the JSON response of the log level endpoints
+type LogLevelsResponse struct {
+	DefaultLevel string                   `json:"default_level"`
+	Components   []ulogger.ComponentLevel `json:"components"`
+}
+
+// LogLevelRequest represents the JSON body to change a log level at runtime. An empty component sets the
+// default level, an empty level makes the component follow the default level again.
+type LogLevelRequest struct {
+	Component string `json:"component"`
+	Level     string `json:"level"`
+}
+
+// GetLogLevels returns the default log level and the level of every logger component of this process
+func (h *HTTP) GetLogLevels(c echo.Context) error {
+	return c.JSON(http.StatusOK, LogLevelsResponse{
+		DefaultLevel: h.logLevels.DefaultLevel(),
+		Components:   h.logLevels.Levels(),
+	})
+}
+
+// SetLogLevel changes the log level of a single component, e.g. {"component": "bval", "level": "DEBUG"},
+// or the default level of all components without a level of their own
+func (h *HTTP) SetLogLevel(c echo.Context) error {
+	var req LogLevelRequest
+	if err := c.Bind(&req); err != nil {
+		return sendStatusError(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	var err error
+
+	if req.Level == "" {
+		err = h.logLevels.ResetLevel(req.Component)
+	} else {
+		err = h.logLevels.SetLevel(req.Component, req.Level)
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		return sendStatusError(c, http.StatusNotFound, err.Error())
+	case err != nil:
+		return sendStatusError(c, http.StatusBadRequest, err.Error())
+	}
+
+	h.logger.Infof("[SetLogLevel] log level of component %q set to %q", req.Component, req.Level)
+
+	recordAudit(h.auditLog, c, audit.ActionSetLogLevel, req.Component, "level="+req.Level)
+
+	return h.GetLogLevels(c)
+}
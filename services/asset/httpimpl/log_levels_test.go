@@ -0,0 +1,76 @@
+package httpimpl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevelEndpoints(t *testing.T) {
+	newHTTP := func() *HTTP {
+		registry := ulogger.NewLevelRegistry("INFO")
+		registry.Logger("bval", ulogger.TestLogger{})
+		registry.Logger("p2p", ulogger.TestLogger{})
+
+		return &HTTP{
+			logger:    ulogger.TestLogger{},
+			logLevels: registry,
+		}
+	}
+
+	doRequest := func(h *HTTP, method string, body string) *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(method, "/api/v1/loglevels", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		var err error
+		if method == http.MethodPost {
+			err = h.SetLogLevel(c)
+		} else {
+			err = h.GetLogLevels(c)
+		}
+
+		require.NoError(t, err)
+
+		return rec
+	}
+
+	t.Run("get", func(t *testing.T) {
+		rec := doRequest(newHTTP(), http.MethodGet, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp LogLevelsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "INFO", resp.DefaultLevel)
+		assert.Len(t, resp.Components, 2)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		h := newHTTP()
+
+		rec := doRequest(h, http.MethodPost, `{"component": "bval", "level": "debug"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp LogLevelsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, ulogger.ComponentLevel{Component: "bval", Level: "DEBUG", Overridden: true}, resp.Components[0])
+		assert.Equal(t, ulogger.ComponentLevel{Component: "p2p", Level: "INFO"}, resp.Components[1])
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		h := newHTTP()
+
+		assert.Equal(t, http.StatusBadRequest, doRequest(h, http.MethodPost, `{"component": "bval", "level": "LOUD"}`).Code)
+		assert.Equal(t, http.StatusNotFound, doRequest(h, http.MethodPost, `{"component": "unknown"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, doRequest(h, http.MethodPost, `not json`).Code)
+	})
+}
@@ -44,6 +44,9 @@ var (
 
 	// prometheusAssetHTTPGetMerkleProof tracks merkle proof retrievals
 	prometheusAssetHTTPGetMerkleProof *prometheus.CounterVec
+
+	// prometheusAssetHTTPResponseCache tracks the requests to cached endpoints by result: hit, miss or not_modified
+	prometheusAssetHTTPResponseCache *prometheus.CounterVec
 )
 
 // prometheusMetricsInitOnce ensures metrics are initialized exactly once
@@ -84,6 +87,7 @@ func initPrometheusMetrics() {
 //   - http_get_last_n_blocks: Multiple block retrievals
 //   - http_get_utxo: UTXO retrievals
 //   - http_get_merkle_proof: Merkle proof retrievals
+//   - http_response_cache: Requests to cached endpoints by result
 func _initPrometheusMetrics() {
 	prometheusAssetHTTPGetTransaction = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -227,4 +231,16 @@ func _initPrometheusMetrics() {
 			"operation", // type of operation achieved
 		},
 	)
+
+	prometheusAssetHTTPResponseCache = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "asset",
+			Name:      "http_response_cache",
+			Help:      "Number of requests to cached endpoints",
+		},
+		[]string{
+			"result", // hit, miss or not_modified
+		},
+	)
 }
@@ -0,0 +1,48 @@
+package httpimpl
+
+import (
+	_ "embed"
+	"html/template"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go run ./openapigen -dir . -out openapi.json -client ../apiclient/client.gen.go
+
+// openAPISpec is the OpenAPI document of the routes of the API group, generated from the handlers by openapigen
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// swaggerPage renders the API reference of the OpenAPI document with Swagger UI
+var swaggerPage = template.Must(template.New("swagger").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Teranode Asset API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui" data-url="{{.}}"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: document.getElementById("swagger-ui").dataset.url, dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`))
+
+// GetOpenAPI returns the OpenAPI 3 document of the asset API, describing the parameters, request bodies and
+// responses of its routes
+func (h *HTTP) GetOpenAPI(c echo.Context) error {
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, openAPISpec)
+}
+
+// GetSwagger returns the Swagger UI page of the OpenAPI document served under the API prefix
+func (h *HTTP) GetSwagger(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	c.Response().WriteHeader(http.StatusOK)
+
+	return swaggerPage.Execute(c.Response(), h.settings.Asset.APIPrefix+"/openapi.json")
+}
@@ -0,0 +1,63 @@
+package httpimpl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOpenAPI(t *testing.T) {
+	h := &HTTP{
+		logger:   ulogger.TestLogger{},
+		settings: test.CreateBaseTestSettings(t),
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.GetOpenAPI(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+
+	var doc struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/peers")
+	assert.Contains(t, doc.Paths, "/catchup/status")
+	assert.Contains(t, doc.Paths, "/bans")
+	assert.Contains(t, doc.Paths, "/block/{hash}")
+	assert.Contains(t, doc.Paths, "/tx/{hash}")
+	assert.Contains(t, doc.Paths, "/openapi.json")
+}
+
+func TestGetSwagger(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.Asset.APIPrefix = "/api/v1"
+
+	h := &HTTP{
+		logger:   ulogger.TestLogger{},
+		settings: tSettings,
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/swagger", nil)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.GetSwagger(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/html")
+	assert.Contains(t, rec.Body.String(), `"/api/v1/openapi.json"`)
+}
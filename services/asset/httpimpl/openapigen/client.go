@@ -0,0 +1,401 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// initialisms are written in upper case in the Go names of the client
+var initialisms = map[string]string{
+	"api":  "API",
+	"cidr": "CIDR",
+	"fsm":  "FSM",
+	"http": "HTTP",
+	"id":   "ID",
+	"ip":   "IP",
+	"json": "JSON",
+	"url":  "URL",
+	"utxo": "UTXO",
+}
+
+// httpMethods are the constants of the net/http package of the methods of the document
+var httpMethods = map[string]string{
+	"get":    "http.MethodGet",
+	"post":   "http.MethodPost",
+	"put":    "http.MethodPut",
+	"delete": "http.MethodDelete",
+}
+
+// clientWriter writes the source of the Go client of a document
+type clientWriter struct {
+	sb      strings.Builder
+	imports map[string]bool
+}
+
+func (w *clientWriter) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&w.sb, format, args...)
+}
+
+// generateClient returns the formatted source of the Go client of the document: a type per component and a method
+// of Client per operation, implemented with the request helpers of the hand-written part of the package
+func generateClient(doc *document, pkg string) ([]byte, error) {
+	w := &clientWriter{imports: map[string]bool{"context": true, "net/http": true}}
+
+	var body clientWriter
+
+	body.imports = w.imports
+
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		body.writeType(name, doc.Components.Schemas[name])
+	}
+
+	type namedOperation struct {
+		method, path string
+		op           *operation
+	}
+
+	var ops []namedOperation
+
+	for p, item := range doc.Paths {
+		for method, op := range *item {
+			ops = append(ops, namedOperation{method: method, path: p, op: op})
+		}
+	}
+
+	sort.Slice(ops, func(i, j int) bool { return ops[i].op.OperationID < ops[j].op.OperationID })
+
+	for _, o := range ops {
+		if err := body.writeOperation(o.method, o.path, o.op); err != nil {
+			return nil, err
+		}
+	}
+
+	w.printf("// Code generated by openapigen from the OpenAPI document of the asset HTTP API. DO NOT EDIT.\n\n")
+	w.printf("package %s\n\nimport (\n", pkg)
+
+	for _, imp := range sortedKeys(w.imports) {
+		w.printf("\t%q\n", imp)
+	}
+
+	w.printf(")\n\n%s", body.sb.String())
+
+	src, err := format.Source([]byte(w.sb.String()))
+	if err != nil {
+		return nil, errors.NewProcessingError("failed to format the generated client", err)
+	}
+
+	return src, nil
+}
+
+// writeType writes the struct type of an object component, or the named type of any other component
+func (w *clientWriter) writeType(name string, s *schema) {
+	w.printf("// %s is the %s schema of the asset API.\n", name, name)
+
+	if s.Type != "object" || s.AdditionalProperties != nil || len(s.Properties) == 0 {
+		w.printf("type %s %s\n\n", name, w.goType(s))
+		return
+	}
+
+	w.printf("type %s struct {\n", name)
+
+	used := make(map[string]bool)
+
+	for _, prop := range sortedKeys(s.Properties) {
+		field := goName(prop)
+		for used[field] {
+			field += "_"
+		}
+
+		used[field] = true
+
+		ps := s.Properties[prop]
+		if ps.Description != "" {
+			w.printf("\n\t// %s\n", exportedName(ps.Description))
+		}
+
+		fieldType := w.goType(ps)
+		if ps.refName() != "" {
+			fieldType = "*" + fieldType
+		}
+
+		w.printf("\t%s %s `json:%q`\n", field, fieldType, prop)
+	}
+
+	w.printf("}\n\n")
+}
+
+// goType returns the Go type of the values of a schema
+func (w *clientWriter) goType(s *schema) string {
+	if name := s.refName(); name != "" {
+		return name
+	}
+
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "byte", "binary":
+			return "[]byte"
+		case "date-time":
+			w.imports["time"] = true
+			return "time.Time"
+		}
+
+		return "string"
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+
+		return "int64"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}"
+		}
+
+		return "[]" + w.goType(s.Items)
+	case "object":
+		if s.AdditionalProperties != nil {
+			return "map[string]" + w.goType(s.AdditionalProperties)
+		}
+	}
+
+	w.imports["encoding/json"] = true
+
+	return "json.RawMessage"
+}
+
+// writeOperation writes the method calling an operation, with the path parameters as arguments, the query
+// parameters in a params struct and the request body as the last argument
+func (w *clientWriter) writeOperation(method, p string, op *operation) error {
+	httpMethod, ok := httpMethods[method]
+	if !ok {
+		return errors.NewProcessingError("unsupported method %s of operation %s", method, op.OperationID)
+	}
+
+	name := goName(op.OperationID)
+	args := []string{"ctx context.Context"}
+
+	var query []*parameter
+
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			args = append(args, goArgName(param.Name)+" string")
+		case "query":
+			query = append(query, param)
+		}
+	}
+
+	if len(query) > 0 {
+		w.printf("// %sParams are the query parameters of %s.\ntype %sParams struct {\n", name, name, name)
+
+		for _, param := range query {
+			w.printf("\t%s %s\n", goName(param.Name), w.goType(param.Schema))
+		}
+
+		w.printf("}\n\n")
+
+		args = append(args, "params *"+name+"Params")
+	}
+
+	bodyArg, contentType := "nil", ""
+
+	if op.RequestBody != nil {
+		for media, mt := range op.RequestBody.Content {
+			contentType = media
+			bodyType := w.goType(mt.Schema)
+
+			if mt.Schema.refName() != "" {
+				bodyType = "*" + bodyType
+			}
+
+			args = append(args, "body "+bodyType)
+			bodyArg = "body"
+		}
+	}
+
+	returnMedia, returnSchema := "", (*schema)(nil)
+
+	if ok := op.Responses["200"]; ok != nil {
+		for media, mt := range ok.Content {
+			returnMedia, returnSchema = media, mt.Schema
+		}
+	}
+
+	returns := "error"
+
+	switch returnMedia {
+	case mediaJSON:
+		returnType := w.goType(returnSchema)
+		if returnSchema.refName() != "" {
+			returnType = "*" + returnType
+		}
+
+		returns = "(" + returnType + ", error)"
+	case mediaText:
+		returns = "(string, error)"
+	case mediaBinary:
+		returns = "([]byte, error)"
+	}
+
+	w.printf("// %s calls %s %s.\n", name, strings.ToUpper(method), p)
+
+	if op.Summary != "" {
+		w.printf("//\n// %s.\n", op.Summary)
+	}
+
+	w.printf("func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), returns)
+
+	queryArg := "nil"
+
+	if len(query) > 0 {
+		w.imports["net/url"] = true
+		queryArg = "query"
+
+		w.printf("\tquery := url.Values{}\n\n\tif params != nil {\n")
+
+		for _, param := range query {
+			field := goName(param.Name)
+
+			if param.Schema.Type == "array" {
+				w.printf("\t\tfor _, v := range params.%s {\n\t\t\tquery.Add(%q, v)\n\t\t}\n", field, param.Name)
+				continue
+			}
+
+			w.printf("\t\tif params.%s != \"\" {\n\t\t\tquery.Set(%q, params.%s)\n\t\t}\n", field, param.Name, field)
+		}
+
+		w.printf("\t}\n\n")
+	}
+
+	path := w.pathExpr(p)
+
+	switch {
+	case contentType == mediaJSON || (contentType == "" && returnMedia == mediaJSON):
+		w.writeJSONCall(httpMethod, path, queryArg, bodyArg, returnMedia, returnSchema)
+	default:
+		accept := returnMedia
+		if accept == "" {
+			accept = mediaJSON
+		}
+
+		call := fmt.Sprintf("c.do(ctx, %s, %s, %s, %s, %q, %q)", httpMethod, path, queryArg, bodyArg, contentType, accept)
+
+		switch returnMedia {
+		case mediaText:
+			w.printf("\tb, err := %s\n\n\treturn string(b), err\n", call)
+		case mediaBinary:
+			w.printf("\treturn %s\n", call)
+		case mediaJSON:
+			w.printf("\tb, err := %s\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\n", call)
+			w.writeDecode(returnSchema)
+		default:
+			w.printf("\t_, err := %s\n\n\treturn err\n", call)
+		}
+	}
+
+	w.printf("}\n\n")
+
+	return nil
+}
+
+// writeJSONCall writes the call of an operation with a JSON request, response, or both
+func (w *clientWriter) writeJSONCall(httpMethod, path, queryArg, bodyArg, returnMedia string, returnSchema *schema) {
+	switch {
+	case returnMedia == mediaJSON && returnSchema.refName() != "":
+		w.printf("\tout := new(%s)\n\n", w.goType(returnSchema))
+		w.printf("\tif err := c.doJSON(ctx, %s, %s, %s, %s, out); err != nil {\n\t\treturn nil, err\n\t}\n\n\treturn out, nil\n",
+			httpMethod, path, queryArg, bodyArg)
+	case returnMedia == mediaJSON:
+		w.printf("\tvar out %s\n\n", w.goType(returnSchema))
+		w.printf("\terr := c.doJSON(ctx, %s, %s, %s, %s, &out)\n\n\treturn out, err\n", httpMethod, path, queryArg, bodyArg)
+	default:
+		w.printf("\treturn c.doJSON(ctx, %s, %s, %s, %s, nil)\n", httpMethod, path, queryArg, bodyArg)
+	}
+}
+
+// writeDecode writes the decoding of the JSON response b of an operation with a request body that is not JSON
+func (w *clientWriter) writeDecode(s *schema) {
+	w.imports["encoding/json"] = true
+
+	if s.refName() != "" {
+		w.printf("\tout := new(%s)\n\n\tif err = json.Unmarshal(b, out); err != nil {\n\t\treturn nil, err\n\t}\n\n\treturn out, nil\n", w.goType(s))
+		return
+	}
+
+	w.printf("\tvar out %s\n\n\terr = json.Unmarshal(b, &out)\n\n\treturn out, err\n", w.goType(s))
+}
+
+// pathExpr returns the Go expression of a path with its parameters escaped, e.g. "/block/" + url.PathEscape(hash)
+func (w *clientWriter) pathExpr(p string) string {
+	var (
+		parts  []string
+		static strings.Builder
+	)
+
+	for i, segment := range strings.Split(p, "/") {
+		if i > 0 {
+			static.WriteString("/")
+		}
+
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			w.imports["net/url"] = true
+
+			parts = append(parts, strconv.Quote(static.String()), "url.PathEscape("+goArgName(segment[1:len(segment)-1])+")")
+			static.Reset()
+
+			continue
+		}
+
+		static.WriteString(segment)
+	}
+
+	if static.Len() > 0 || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(static.String()))
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+// goName returns the exported Go name of a JSON property, parameter or operation ID, e.g. PeerID for peer_id
+func goName(name string) string {
+	var sb strings.Builder
+
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == '.' || r == ' ' }) {
+		if initialism, ok := initialisms[strings.ToLower(part)]; ok {
+			sb.WriteString(initialism)
+			continue
+		}
+
+		sb.WriteString(exportedName(part))
+	}
+
+	return sb.String()
+}
+
+// goArgName returns the Go name of the argument of a path parameter
+func goArgName(name string) string {
+	n := goName(name)
+	if n == "" {
+		return "param"
+	}
+
+	if strings.ToUpper(n) == n {
+		return strings.ToLower(n)
+	}
+
+	return strings.ToLower(n[:1]) + n[1:]
+}
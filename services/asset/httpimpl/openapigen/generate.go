@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// errorComponent is the component of the error responses of all operations
+const errorComponent = "ErrorResponse"
+
+// generator generates the OpenAPI document of the routes of the asset HTTP server
+type generator struct {
+	loader      *loader
+	constructor string // function registering the routes
+	group       string // variable of the echo group the routes are registered on
+	serverURL   string
+}
+
+// generate parses the package in dir and returns its OpenAPI document
+func (g *generator) generate(dir string) (*document, error) {
+	importPath, err := g.loader.importPathOf(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := g.loader.load(importPath)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := findRoutes(pkg, g.constructor, g.group)
+	if err != nil {
+		return nil, err
+	}
+
+	b := newSchemaBuilder(g.loader, importPath)
+
+	doc := &document{
+		OpenAPI: "3.0.3",
+		Info: info{
+			Title:       "Teranode Asset API",
+			Description: "HTTP API of the asset service, generated from the routes of the asset HTTP server.",
+			Version:     "1.0.0",
+		},
+		Servers: []server{{URL: g.serverURL, Description: "API prefix of the asset service (asset_apiPrefix)"}},
+		Paths:   make(map[string]*pathItem),
+	}
+
+	b.components[errorComponent] = &schema{
+		Type: "object",
+		Properties: map[string]*schema{
+			"status": {Type: "integer", Format: "int32", Description: "HTTP status code"},
+			"code":   {Type: "integer", Format: "int32", Description: "error code, see the error catalog at /errors"},
+			"name":   {Type: "string", Description: "name of the error code"},
+			"error":  {Type: "string", Description: "error message"},
+		},
+	}
+
+	tags := make(map[string]bool)
+	operationIDs := make(map[string]bool)
+
+	for _, r := range routes {
+		op := g.operation(b, pkg, r)
+
+		if operationIDs[op.OperationID] {
+			op.OperationID += pathSuffix(r.path)
+		}
+
+		operationIDs[op.OperationID] = true
+
+		item, ok := doc.Paths[r.path]
+		if !ok {
+			item = &pathItem{}
+			doc.Paths[r.path] = item
+		}
+
+		(*item)[r.method] = op
+
+		tags[op.Tags[0]] = true
+	}
+
+	for _, name := range sortedKeys(tags) {
+		doc.Tags = append(doc.Tags, tag{Name: name})
+	}
+
+	doc.Components.Schemas = b.components
+
+	return doc, nil
+}
+
+// operation returns the operation of a route, with the parameters, request body and response read from its handler
+func (g *generator) operation(b *schemaBuilder, pkg *pkgSource, r route) *operation {
+	operationID := r.handler + modeSuffixes[r.mode]
+
+	op := &operation{
+		OperationID: operationID,
+		Tags:        []string{pathTag(r.path)},
+		Responses: map[string]*response{
+			"default": {
+				Description: "Error",
+				Content:     map[string]*mediaType{mediaJSON: {Schema: refSchema(errorComponent)}},
+			},
+		},
+	}
+
+	for _, name := range pathParams(r.path) {
+		op.Parameters = append(op.Parameters, &parameter{Name: name, In: "path", Required: true, Schema: &schema{Type: "string"}})
+	}
+
+	funcs := handlerFuncs(pkg, r.handler)
+	if len(funcs) == 0 {
+		op.Responses["200"] = &response{Description: "OK"}
+		return op
+	}
+
+	op.Summary = docSummary(funcs[0].decl.Doc, r.handler)
+
+	switch r.mode {
+	case "JSON":
+		op.Summary = strings.TrimSpace(op.Summary + " (JSON)")
+	case "HEX":
+		op.Summary = strings.TrimSpace(op.Summary + " (hex)")
+	case "BINARY_STREAM":
+		op.Summary = strings.TrimSpace(op.Summary + " (binary)")
+	}
+
+	names, lists := queryParams(funcs)
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := &schema{Type: "string"}
+		if lists[name] {
+			s = &schema{Type: "array", Items: &schema{Type: "string"}}
+		}
+
+		op.Parameters = append(op.Parameters, &parameter{Name: name, In: "query", Schema: s})
+	}
+
+	if r.method != "get" {
+		if media, s := b.requestSchema(funcs, operationID+"Request"); media != "" {
+			op.RequestBody = &requestBody{Required: true, Content: map[string]*mediaType{media: {Schema: s}}}
+		}
+	}
+
+	ok := &response{Description: "OK"}
+
+	if media, s := b.responseSchema(funcs, r.mode, operationID+"Response"); media != "" {
+		ok.Content = map[string]*mediaType{media: {Schema: s}}
+	}
+
+	op.Responses["200"] = ok
+
+	return op
+}
+
+// marshalDocument returns the indented JSON encoding of the document
+func marshalDocument(doc *document) ([]byte, error) {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+// generateFiles returns the OpenAPI document of the package in dir and the source of its Go client
+func generateFiles(dir, clientPkg string) ([]byte, []byte, error) {
+	l, err := newLoader(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	g := &generator{loader: l, constructor: "New", group: "apiGroup", serverURL: "/api/v1"}
+
+	doc, err := g.generate(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spec, err := marshalDocument(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := generateClient(doc, clientPkg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return spec, client, nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	l, err := newLoader("..")
+	require.NoError(t, err)
+
+	g := &generator{loader: l, constructor: "New", group: "apiGroup", serverURL: "/api/v1"}
+
+	doc, err := g.generate("..")
+	require.NoError(t, err)
+
+	t.Run("peers response schema", func(t *testing.T) {
+		require.Contains(t, doc.Paths, "/peers")
+
+		op := (*doc.Paths["/peers"])["get"]
+		require.NotNil(t, op)
+
+		assert.Equal(t, "GetPeers", op.OperationID)
+		assert.Equal(t, []string{"peers"}, op.Tags)
+		assert.Equal(t, "PeersResponse", op.Responses["200"].Content[mediaJSON].Schema.refName())
+
+		peers := doc.Components.Schemas["PeersResponse"].Properties["peers"]
+		require.NotNil(t, peers)
+		assert.Equal(t, "array", peers.Type)
+	})
+
+	t.Run("ban request body", func(t *testing.T) {
+		op := (*doc.Paths["/bans"])["post"]
+		require.NotNil(t, op)
+		require.NotNil(t, op.RequestBody)
+
+		assert.Equal(t, "BanRequest", op.RequestBody.Content[mediaJSON].Schema.refName())
+		assert.Contains(t, doc.Components.Schemas["BanRequest"].Properties, "targets")
+	})
+
+	t.Run("unban target query parameter", func(t *testing.T) {
+		op := (*doc.Paths["/bans"])["delete"]
+		require.NotNil(t, op)
+		require.Len(t, op.Parameters, 1)
+
+		assert.Equal(t, "target", op.Parameters[0].Name)
+		assert.Equal(t, "query", op.Parameters[0].In)
+		assert.Equal(t, "array", op.Parameters[0].Schema.Type)
+	})
+
+	t.Run("read modes", func(t *testing.T) {
+		binary := (*doc.Paths["/block/{hash}"])["get"]
+		hex := (*doc.Paths["/block/{hash}/hex"])["get"]
+		json := (*doc.Paths["/block/{hash}/json"])["get"]
+
+		require.NotNil(t, binary)
+		require.NotNil(t, hex)
+		require.NotNil(t, json)
+
+		assert.Equal(t, "GetBlockByHash", binary.OperationID)
+		assert.Contains(t, binary.Responses["200"].Content, mediaBinary)
+		assert.Equal(t, "GetBlockByHashHex", hex.OperationID)
+		assert.Contains(t, hex.Responses["200"].Content, mediaText)
+		assert.Equal(t, "GetBlockByHashJSON", json.OperationID)
+		assert.Equal(t, "BlockExtended", json.Responses["200"].Content[mediaJSON].Schema.refName())
+
+		require.Len(t, json.Parameters, 1)
+		assert.Equal(t, "hash", json.Parameters[0].Name)
+		assert.True(t, json.Parameters[0].Required)
+	})
+
+	t.Run("promoted fields of embedded structs", func(t *testing.T) {
+		block := doc.Components.Schemas["BlockExtended"]
+		require.NotNil(t, block)
+
+		assert.Contains(t, block.Properties, "nextblock")
+		assert.Contains(t, block.Properties, "header")
+		assert.Contains(t, block.Properties, "height")
+	})
+
+	t.Run("unique operation IDs", func(t *testing.T) {
+		seen := make(map[string]bool)
+
+		for _, item := range doc.Paths {
+			for _, op := range *item {
+				assert.False(t, seen[op.OperationID], op.OperationID)
+				seen[op.OperationID] = true
+			}
+		}
+
+		assert.True(t, seen["GetTransactions"])
+		assert.True(t, seen["GetTransactionsSubtreeTxsByHash"])
+	})
+}
+
+// TestGeneratedFilesUpToDate fails when the routes changed without running go generate ./services/asset/httpimpl
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	spec, client, err := generateFiles("..", "apiclient")
+	require.NoError(t, err)
+
+	committedSpec, err := os.ReadFile(filepath.Join("..", "openapi.json"))
+	require.NoError(t, err)
+
+	committedClient, err := os.ReadFile(filepath.Join("..", "..", "apiclient", "client.gen.go"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(committedSpec), string(spec), "openapi.json is out of date, run go generate ./services/asset/httpimpl")
+	assert.Equal(t, string(committedClient), string(client), "client.gen.go is out of date, run go generate ./services/asset/httpimpl")
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	assert.Equal(t, "/block/{hash}/json", openAPIPath("/block/:hash/json"))
+	assert.Equal(t, "/peers", openAPIPath("/peers"))
+	assert.Equal(t, []string{"hash"}, pathParams("/subtree/{hash}/txs"))
+	assert.Equal(t, "SubtreeTxsByHash", pathSuffix("/subtree/{hash}/txs"))
+	assert.Equal(t, "openapi", pathTag("/openapi.json"))
+}
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "PeerID", goName("peer_id"))
+	assert.Equal(t, "DataHubURL", goName("data_hub_url"))
+	assert.Equal(t, "BlockHeight", goName("blockHeight"))
+	assert.Equal(t, "hash", goArgName("hash"))
+	assert.Equal(t, "id", goArgName("id"))
+}
+
+func TestDocSummary(t *testing.T) {
+	l, err := newLoader("..")
+	require.NoError(t, err)
+
+	importPath, err := l.importPathOf("..")
+	require.NoError(t, err)
+
+	pkg, err := l.load(importPath)
+	require.NoError(t, err)
+
+	fn := pkg.methods["GetPeers"]
+	require.NotNil(t, fn)
+
+	summary := docSummary(fn.Doc, "GetPeers")
+	assert.NotEmpty(t, summary)
+	assert.NotContains(t, summary, "GetPeers")
+}
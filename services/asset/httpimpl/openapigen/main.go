@@ -0,0 +1,45 @@
+// Package main generates the OpenAPI document of the asset HTTP API from the routes registered by the HTTP server
+// in services/asset/httpimpl, and the typed Go client of the document in services/asset/apiclient.
+//
+// The routes, their parameters, request bodies and responses are read from the source of the handlers, so the
+// document is regenerated with go generate whenever the routes change:
+//
+//	go generate ./services/asset/httpimpl
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package registering the routes")
+	out := flag.String("out", "openapi.json", "file to write the OpenAPI document to")
+	clientOut := flag.String("client", "", "file to write the Go client to, empty to not generate the client")
+	clientPkg := flag.String("client-package", "apiclient", "package name of the Go client")
+
+	flag.Parse()
+
+	if err := run(*dir, *out, *clientOut, *clientPkg); err != nil {
+		fmt.Fprintf(os.Stderr, "openapigen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, out, clientOut, clientPkg string) error {
+	spec, client, err := generateFiles(dir, clientPkg)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(out, spec, 0o600); err != nil {
+		return err
+	}
+
+	if clientOut == "" {
+		return nil
+	}
+
+	return os.WriteFile(clientOut, client, 0o600)
+}
@@ -0,0 +1,663 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// The media types of the responses of the read modes of the handlers
+const (
+	mediaJSON   = "application/json"
+	mediaText   = "text/plain"
+	mediaBinary = "application/octet-stream"
+)
+
+// routeMethods are the methods of the echo group registering the routes of the API
+var routeMethods = map[string]string{
+	"GET":    "get",
+	"POST":   "post",
+	"PUT":    "put",
+	"DELETE": "delete",
+}
+
+// modeSuffixes are the suffixes of the operation IDs of the read modes of a handler
+var modeSuffixes = map[string]string{
+	"BINARY_STREAM": "",
+	"HEX":           "Hex",
+	"JSON":          "JSON",
+}
+
+// route is an endpoint registered on the API group
+type route struct {
+	method  string // lower case HTTP method
+	path    string // path in OpenAPI form, e.g. /block/{hash}
+	handler string // name of the handler method
+	mode    string // read mode the handler is created with, empty for plain handlers
+}
+
+// findRoutes returns the routes registered on the API group in the constructor of the HTTP server, in the order
+// they are registered. Routes with inline handlers are skipped.
+func findRoutes(pkg *pkgSource, constructor, group string) ([]route, error) {
+	fn, ok := pkg.funcs[constructor]
+	if !ok || fn.Body == nil {
+		return nil, errors.NewProcessingError("function %s not found in package %s", constructor, pkg.importPath)
+	}
+
+	consts := make(map[string]string)
+
+	var routes []route
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.GenDecl:
+			if node.Tok == token.CONST {
+				collectStringConsts(node, consts)
+			}
+		case *ast.CallExpr:
+			if r, ok := parseRoute(node, group, consts); ok {
+				routes = append(routes, r)
+			}
+		}
+
+		return true
+	})
+
+	if len(routes) == 0 {
+		return nil, errors.NewProcessingError("no routes registered on %s in %s", group, constructor)
+	}
+
+	return routes, nil
+}
+
+func collectStringConsts(decl *ast.GenDecl, consts map[string]string) {
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+
+		for i, name := range vs.Names {
+			if i >= len(vs.Values) {
+				break
+			}
+
+			if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				if value, err := strconv.Unquote(lit.Value); err == nil {
+					consts[name.Name] = value
+				}
+			}
+		}
+	}
+}
+
+// parseRoute returns the route of a call like apiGroup.GET("/block/:hash", h.GetBlockByHash(JSON))
+func parseRoute(call *ast.CallExpr, group string, consts map[string]string) (route, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) < 2 {
+		return route{}, false
+	}
+
+	if x, ok := sel.X.(*ast.Ident); !ok || x.Name != group {
+		return route{}, false
+	}
+
+	method, ok := routeMethods[sel.Sel.Name]
+	if !ok {
+		return route{}, false
+	}
+
+	var echoPath string
+
+	switch p := call.Args[0].(type) {
+	case *ast.BasicLit:
+		echoPath, _ = strconv.Unquote(p.Value)
+	case *ast.Ident:
+		echoPath = consts[p.Name]
+	}
+
+	if echoPath == "" || strings.Contains(echoPath, "*") {
+		return route{}, false
+	}
+
+	r := route{method: method, path: openAPIPath(echoPath)}
+
+	switch h := call.Args[1].(type) {
+	case *ast.SelectorExpr:
+		r.handler = h.Sel.Name
+	case *ast.CallExpr:
+		hSel, ok := h.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return route{}, false
+		}
+
+		r.handler = hSel.Sel.Name
+
+		if len(h.Args) == 1 {
+			if mode, ok := h.Args[0].(*ast.Ident); ok {
+				if _, known := modeSuffixes[mode.Name]; known {
+					r.mode = mode.Name
+				}
+			}
+		}
+	default:
+		return route{}, false
+	}
+
+	return r, true
+}
+
+// openAPIPath converts the parameters of an echo path to OpenAPI, e.g. /block/:hash to /block/{hash}
+func openAPIPath(echoPath string) string {
+	segments := strings.Split(echoPath, "/")
+
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// pathParams returns the names of the parameters of an OpenAPI path
+func pathParams(p string) []string {
+	var params []string
+
+	for _, segment := range strings.Split(p, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params = append(params, segment[1:len(segment)-1])
+		}
+	}
+
+	return params
+}
+
+// pathTag returns the first static segment of a path, which groups the operations of the document
+func pathTag(p string) string {
+	for _, segment := range strings.Split(p, "/") {
+		if segment != "" && !strings.HasPrefix(segment, "{") {
+			name, _, _ := strings.Cut(segment, ".")
+			return name
+		}
+	}
+
+	return "api"
+}
+
+// pathSuffix returns the suffix of the operation ID of a route sharing its handler with another route, e.g.
+// SubtreeTxsByHash for /subtree/{hash}/txs
+func pathSuffix(p string) string {
+	var static, params []string
+
+	for _, segment := range strings.Split(p, "/") {
+		switch {
+		case segment == "":
+		case strings.HasPrefix(segment, "{"):
+			params = append(params, camelCase(segment[1:len(segment)-1]))
+		default:
+			static = append(static, camelCase(segment))
+		}
+	}
+
+	suffix := strings.Join(static, "")
+	if len(params) > 0 {
+		suffix += "By" + strings.Join(params, "And")
+	}
+
+	return suffix
+}
+
+// camelCase converts a snake, kebab or dotted name to CamelCase
+func camelCase(name string) string {
+	var sb strings.Builder
+
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		sb.WriteString(exportedName(part))
+	}
+
+	return sb.String()
+}
+
+// funcCtx is a function whose body is analysed for the requests it reads and the responses it writes
+type funcCtx struct {
+	sc   scope
+	decl *ast.FuncDecl
+}
+
+// receiverName returns the name of the receiver of a method, empty for functions
+func (f funcCtx) receiverName() string {
+	if f.decl.Recv == nil || len(f.decl.Recv.List) == 0 || len(f.decl.Recv.List[0].Names) == 0 {
+		return ""
+	}
+
+	return f.decl.Recv.List[0].Names[0].Name
+}
+
+func newFuncCtx(pkg *pkgSource, decl *ast.FuncDecl) funcCtx {
+	fc := funcCtx{
+		sc: scope{
+			pkg:    pkg,
+			file:   pkg.fileOf[decl],
+			locals: make(map[string]*ast.TypeSpec),
+			prefix: exportedName(decl.Name.Name),
+		},
+		decl: decl,
+	}
+
+	if decl.Body != nil {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			if ts, ok := n.(*ast.TypeSpec); ok {
+				fc.sc.locals[ts.Name.Name] = ts
+			}
+
+			return true
+		})
+	}
+
+	return fc
+}
+
+// handlerFuncs returns the handler and the functions of its package it passes the echo context to, which read
+// the request and write the response on its behalf
+func handlerFuncs(pkg *pkgSource, handler string) []funcCtx {
+	decl, ok := pkg.methods[handler]
+	if !ok || decl.Body == nil {
+		return nil
+	}
+
+	handlerFn := newFuncCtx(pkg, decl)
+	funcs := []funcCtx{handlerFn}
+	seen := map[*ast.FuncDecl]bool{decl: true}
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !passesContext(call) {
+			return true
+		}
+
+		var callee *ast.FuncDecl
+
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			callee = pkg.funcs[fun.Name]
+		case *ast.SelectorExpr:
+			if x, ok := fun.X.(*ast.Ident); ok && x.Name == handlerFn.receiverName() {
+				callee = pkg.methods[fun.Sel.Name]
+			}
+		}
+
+		if callee != nil && callee.Body != nil && !seen[callee] {
+			seen[callee] = true
+			funcs = append(funcs, newFuncCtx(pkg, callee))
+		}
+
+		return true
+	})
+
+	return funcs
+}
+
+// passesContext returns whether the echo context c is an argument of the call
+func passesContext(call *ast.CallExpr) bool {
+	for _, arg := range call.Args {
+		if ident, ok := arg.(*ast.Ident); ok && ident.Name == "c" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contextCall returns the name of the method of the echo context c called, e.g. JSON for c.JSON(...)
+func contextCall(call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+
+	if x, ok := sel.X.(*ast.Ident); ok && x.Name == "c" {
+		return sel.Sel.Name
+	}
+
+	return ""
+}
+
+// queryParams returns the query parameters the functions read, and whether they are read as a list
+func queryParams(funcs []funcCtx) ([]string, map[string]bool) {
+	var names []string
+
+	lists := make(map[string]bool)
+
+	add := func(expr ast.Expr, list bool) {
+		lit, ok := expr.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return
+		}
+
+		name, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return
+		}
+
+		if _, exists := lists[name]; !exists {
+			names = append(names, name)
+		}
+
+		lists[name] = lists[name] || list
+	}
+
+	for _, f := range funcs {
+		ast.Inspect(f.decl.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CallExpr:
+				if contextCall(node) == "QueryParam" && len(node.Args) == 1 {
+					add(node.Args[0], false)
+				}
+			case *ast.IndexExpr:
+				if call, ok := node.X.(*ast.CallExpr); ok && contextCall(call) == "QueryParams" {
+					add(node.Index, true)
+				}
+			}
+
+			return true
+		})
+	}
+
+	return names, lists
+}
+
+// requestSchema returns the media type and schema of the request body the functions read, nil when they read none
+func (b *schemaBuilder) requestSchema(funcs []funcCtx, hint string) (string, *schema) {
+	for _, f := range funcs {
+		var (
+			media string
+			s     *schema
+		)
+
+		ast.Inspect(f.decl.Body, func(n ast.Node) bool {
+			if media != "" {
+				return false
+			}
+
+			switch node := n.(type) {
+			case *ast.CallExpr:
+				if contextCall(node) == "Bind" && len(node.Args) == 1 {
+					media, s = mediaJSON, b.valueSchema(f, node.Args[0], hint, 0)
+				}
+			case *ast.SelectorExpr:
+				// the raw body, e.g. c.Request().Body
+				if call, ok := node.X.(*ast.CallExpr); ok && node.Sel.Name == "Body" && contextCall(call) == "Request" {
+					media, s = mediaBinary, &schema{Type: "string", Format: "binary"}
+				}
+			}
+
+			return true
+		})
+
+		if media != "" {
+			return media, s
+		}
+	}
+
+	return "", nil
+}
+
+// responseSchema returns the media type and schema of the successful response the functions write in the read
+// mode, an empty media type when none is found
+func (b *schemaBuilder) responseSchema(funcs []funcCtx, mode, hint string) (string, *schema) {
+	switch mode {
+	case "HEX":
+		return mediaText, &schema{Type: "string"}
+	case "BINARY_STREAM":
+		return mediaBinary, &schema{Type: "string", Format: "binary"}
+	}
+
+	var (
+		fallbackMedia  string
+		fallbackSchema *schema
+	)
+
+	for _, f := range funcs {
+		var (
+			media string
+			s     *schema
+		)
+
+		ast.Inspect(f.decl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || media != "" || len(call.Args) < 2 {
+				return media == ""
+			}
+
+			name := contextCall(call)
+
+			callMedia := ""
+
+			switch name {
+			case "JSON", "JSONPretty":
+				callMedia = mediaJSON
+			case "String":
+				callMedia = mediaText
+			case "Blob", "Stream":
+				callMedia = blobMedia(call.Args[1])
+			default:
+				return true
+			}
+
+			// in JSON mode only the JSON encoded responses are written
+			if mode == "JSON" && callMedia != mediaJSON {
+				return true
+			}
+
+			ok, computed := successStatus(call.Args[0])
+			if !ok && !computed {
+				return true
+			}
+
+			callSchema := &schema{Type: "string"}
+
+			switch {
+			case callMedia == mediaJSON && name != "Blob" && name != "Stream":
+				callSchema = b.valueSchema(f, call.Args[1], hint, 0)
+			case callMedia == mediaJSON:
+				callSchema = &schema{}
+			case callMedia == mediaBinary:
+				callSchema = &schema{Type: "string", Format: "binary"}
+			}
+
+			if ok {
+				media, s = callMedia, callSchema
+			} else if fallbackMedia == "" {
+				fallbackMedia, fallbackSchema = callMedia, callSchema
+			}
+
+			return true
+		})
+
+		if media != "" {
+			return media, s
+		}
+	}
+
+	return fallbackMedia, fallbackSchema
+}
+
+// blobMedia returns the media type of the content type argument of c.Blob or c.Stream
+func blobMedia(expr ast.Expr) string {
+	name := ""
+
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		name = e.Sel.Name
+	case *ast.BasicLit:
+		name, _ = strconv.Unquote(e.Value)
+	}
+
+	switch {
+	case strings.Contains(name, "JSON") || strings.Contains(name, "json"):
+		return mediaJSON
+	case strings.Contains(name, "Text") || strings.Contains(name, "text"):
+		return mediaText
+	default:
+		return mediaBinary
+	}
+}
+
+// successStatus returns whether the status expression is 200, and whether it is computed when the response is
+// written
+func successStatus(expr ast.Expr) (bool, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return e.Value == "200", false
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok && x.Name == "http" {
+			return e.Sel.Name == "StatusOK", false
+		}
+	}
+
+	return false, true
+}
+
+// valueSchema returns the schema of the JSON encoding of the value of an expression in the function
+func (b *schemaBuilder) valueSchema(f funcCtx, expr ast.Expr, hint string, depth int) *schema {
+	if depth > 4 {
+		return &schema{}
+	}
+
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return b.valueSchema(f, e.X, hint, depth+1)
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return b.valueSchema(f, e.X, hint, depth+1)
+		}
+	case *ast.CompositeLit:
+		if e.Type != nil {
+			return nonNil(b.typeSchema(f.sc, e.Type, hint))
+		}
+	case *ast.CallExpr:
+		return b.callSchema(f, e, 0, hint, depth+1)
+	case *ast.Ident:
+		return b.identSchema(f, e.Name, hint, depth+1)
+	}
+
+	return &schema{}
+}
+
+// identSchema returns the schema of a variable declared in the function
+func (b *schemaBuilder) identSchema(f funcCtx, name, hint string, depth int) *schema {
+	var found *schema
+
+	ast.Inspect(f.decl.Body, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+
+		switch node := n.(type) {
+		case *ast.ValueSpec:
+			for i, ident := range node.Names {
+				if ident.Name != name {
+					continue
+				}
+
+				switch {
+				case node.Type != nil:
+					found = nonNil(b.typeSchema(f.sc, node.Type, hint))
+				case i < len(node.Values):
+					found = b.valueSchema(f, node.Values[i], hint, depth)
+				}
+			}
+		case *ast.AssignStmt:
+			if node.Tok != token.DEFINE {
+				return true
+			}
+
+			for i, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); !ok || ident.Name != name {
+					continue
+				}
+
+				switch {
+				case len(node.Rhs) == len(node.Lhs):
+					found = b.valueSchema(f, node.Rhs[i], hint, depth)
+				case len(node.Rhs) == 1:
+					if call, ok := node.Rhs[0].(*ast.CallExpr); ok {
+						found = b.callSchema(f, call, i, hint, depth)
+					}
+				}
+			}
+		}
+
+		return found == nil
+	})
+
+	if found == nil {
+		return &schema{}
+	}
+
+	return found
+}
+
+// callSchema returns the schema of a result of a call of a builtin or a function of the module
+func (b *schemaBuilder) callSchema(f funcCtx, call *ast.CallExpr, result int, hint string, depth int) *schema {
+	var callee *ast.FuncDecl
+
+	calleePkg := f.sc.pkg
+
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if (fun.Name == "make" || fun.Name == "new") && len(call.Args) > 0 {
+			return nonNil(b.typeSchema(f.sc, call.Args[0], hint))
+		}
+
+		callee = f.sc.pkg.funcs[fun.Name]
+	case *ast.SelectorExpr:
+		x, ok := fun.X.(*ast.Ident)
+		if !ok {
+			return &schema{}
+		}
+
+		if x.Name == f.receiverName() {
+			callee = f.sc.pkg.methods[fun.Sel.Name]
+			break
+		}
+
+		pkg, err := b.l.load(b.l.resolveImport(f.sc.file, x.Name))
+		if err != nil || pkg == nil {
+			return &schema{}
+		}
+
+		calleePkg = pkg
+		callee = pkg.funcs[fun.Sel.Name]
+	}
+
+	if callee == nil || callee.Type.Results == nil {
+		return &schema{}
+	}
+
+	var results []ast.Expr
+
+	for _, field := range callee.Type.Results.List {
+		for range max(1, len(field.Names)) {
+			results = append(results, field.Type)
+		}
+	}
+
+	if result >= len(results) {
+		return &schema{}
+	}
+
+	return nonNil(b.typeSchema(newFuncCtx(calleePkg, callee).sc, results[result], hint))
+}
+
+func nonNil(s *schema) *schema {
+	if s == nil {
+		return &schema{}
+	}
+
+	return s
+}
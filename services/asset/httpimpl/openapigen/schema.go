@@ -0,0 +1,355 @@
+package main
+
+import (
+	"go/ast"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// scope is where a type expression is resolved: the package and file it appears in, and the types declared in the
+// function it appears in, if any
+type scope struct {
+	pkg    *pkgSource
+	file   *ast.File
+	locals map[string]*ast.TypeSpec
+	prefix string // prefix of the components of local types, the name of the function declaring them
+}
+
+// schemaBuilder builds the schemas of Go types and collects the schemas of named struct types as components
+type schemaBuilder struct {
+	l          *loader
+	root       string // import path of the package of the handlers, whose types are not qualified
+	components map[string]*schema
+	names      map[string]string // component name by qualified type name
+}
+
+func newSchemaBuilder(l *loader, root string) *schemaBuilder {
+	return &schemaBuilder{
+		l:          l,
+		root:       root,
+		components: make(map[string]*schema),
+		names:      make(map[string]string),
+	}
+}
+
+// typeSchema returns the schema of the JSON encoding of a type expression. Inline struct types are added as a
+// component with the hint as name, when one is given. Returns nil for types that have no JSON encoding.
+func (b *schemaBuilder) typeSchema(sc scope, expr ast.Expr, hint string) *schema {
+	switch t := expr.(type) {
+	case *ast.ParenExpr:
+		return b.typeSchema(sc, t.X, hint)
+	case *ast.StarExpr:
+		return b.typeSchema(sc, t.X, hint)
+	case *ast.Ident:
+		if s, ok := basicSchema(t.Name); ok {
+			return s
+		}
+
+		if ts, ok := sc.locals[t.Name]; ok {
+			return b.namedSchema(sc, ts, sc.prefix+exportedName(t.Name), sc.file.Name.Name+"."+sc.prefix+"."+t.Name)
+		}
+
+		if ts, ok := sc.pkg.types[t.Name]; ok {
+			if sc.pkg.marshalers[t.Name] {
+				return &schema{}
+			}
+
+			return b.namedSchema(scope{pkg: sc.pkg, file: sc.pkg.fileOf[ts]}, ts, exportedName(t.Name), sc.pkg.importPath+"."+t.Name)
+		}
+
+		return &schema{}
+	case *ast.SelectorExpr:
+		return b.selectorSchema(sc, t)
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" && t.Len == nil {
+			return &schema{Type: "string", Format: "byte"}
+		}
+
+		items := b.typeSchema(sc, t.Elt, singular(hint))
+		if items == nil {
+			return nil
+		}
+
+		return &schema{Type: "array", Items: items}
+	case *ast.MapType:
+		values := b.typeSchema(sc, t.Value, singular(hint))
+		if values == nil {
+			return nil
+		}
+
+		return &schema{Type: "object", AdditionalProperties: values}
+	case *ast.StructType:
+		s := b.structSchema(sc, t, hint)
+		if hint == "" {
+			return s
+		}
+
+		b.components[hint] = s
+
+		return refSchema(hint)
+	case *ast.InterfaceType, *ast.IndexExpr, *ast.IndexListExpr:
+		return &schema{}
+	default:
+		// functions and channels have no JSON encoding
+		return nil
+	}
+}
+
+// selectorSchema returns the schema of a type of another package
+func (b *schemaBuilder) selectorSchema(sc scope, t *ast.SelectorExpr) *schema {
+	pkgIdent, ok := t.X.(*ast.Ident)
+	if !ok {
+		return &schema{}
+	}
+
+	importPath := b.l.resolveImport(sc.file, pkgIdent.Name)
+
+	switch importPath + "." + t.Sel.Name {
+	case "time.Time":
+		return &schema{Type: "string", Format: "date-time"}
+	case "time.Duration":
+		return &schema{Type: "integer", Format: "int64", Description: "duration in nanoseconds"}
+	case "encoding/json.RawMessage":
+		return &schema{}
+	}
+
+	if strings.HasSuffix(importPath, "/chainhash") && t.Sel.Name == "Hash" {
+		return &schema{Type: "string", Description: "hash in hex"}
+	}
+
+	pkg, err := b.l.load(importPath)
+	if err != nil || pkg == nil {
+		return &schema{}
+	}
+
+	ts, ok := pkg.types[t.Sel.Name]
+	if !ok || pkg.marshalers[t.Sel.Name] {
+		return &schema{}
+	}
+
+	return b.namedSchema(scope{pkg: pkg, file: pkg.fileOf[ts]}, ts, exportedName(t.Sel.Name), importPath+"."+t.Sel.Name)
+}
+
+// namedSchema returns a reference to the component of a named struct type, and the schema of the underlying type
+// of other named types
+func (b *schemaBuilder) namedSchema(sc scope, ts *ast.TypeSpec, name, qualified string) *schema {
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		if ts.TypeParams != nil {
+			return &schema{}
+		}
+
+		if b.names[qualified] == "" {
+			// guards against recursive type definitions
+			b.names[qualified] = "-"
+			defer delete(b.names, qualified)
+
+			return b.typeSchema(sc, ts.Type, name)
+		}
+
+		return &schema{}
+	}
+
+	if existing, ok := b.names[qualified]; ok {
+		if existing == "-" {
+			return &schema{}
+		}
+
+		return refSchema(existing)
+	}
+
+	// types of other packages are qualified with the package, e.g. ModelBlockHeader, as their names are often only
+	// meaningful within it
+	if sc.pkg.importPath != b.root && !strings.HasPrefix(strings.ToLower(name), strings.ToLower(sc.pkg.name)) {
+		name = exportedName(sc.pkg.name) + name
+	}
+
+	if _, taken := b.components[name]; taken {
+		name = exportedName(sc.pkg.name) + name
+	}
+
+	b.names[qualified] = name
+	b.components[name] = &schema{Type: "object"} // reserves the name while the fields are resolved
+	b.components[name] = b.structSchema(sc, st, name)
+
+	return refSchema(name)
+}
+
+// structSchema returns the object schema of the exported fields of a struct, including the promoted fields of
+// embedded structs
+func (b *schemaBuilder) structSchema(sc scope, st *ast.StructType, name string) *schema {
+	s := &schema{Type: "object", Properties: make(map[string]*schema)}
+
+	var embedded []*ast.Field
+
+	for _, field := range st.Fields.List {
+		tagName, skip := jsonTagName(field)
+		if skip {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			if tagName == "" {
+				embedded = append(embedded, field)
+				continue
+			}
+
+			if fs := b.typeSchema(sc, field.Type, name+exportedName(tagName)); fs != nil {
+				s.Properties[tagName] = fs
+			}
+
+			continue
+		}
+
+		for _, fieldName := range field.Names {
+			if !fieldName.IsExported() {
+				continue
+			}
+
+			propName := tagName
+			if propName == "" {
+				propName = fieldName.Name
+			}
+
+			if fs := b.typeSchema(sc, field.Type, name+fieldName.Name); fs != nil {
+				s.Properties[propName] = fs
+			}
+		}
+	}
+
+	// fields of the struct shadow the promoted fields
+	for _, field := range embedded {
+		promoted := b.embeddedSchema(sc, field.Type, name)
+		if promoted == nil {
+			continue
+		}
+
+		for propName, fs := range promoted.Properties {
+			if _, exists := s.Properties[propName]; !exists {
+				s.Properties[propName] = fs
+			}
+		}
+	}
+
+	if len(s.Properties) == 0 {
+		s.Properties = nil
+	}
+
+	return s
+}
+
+// embeddedSchema returns the object schema of an embedded struct
+func (b *schemaBuilder) embeddedSchema(sc scope, expr ast.Expr, name string) *schema {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ts, ok := sc.pkg.types[t.Name]; ok {
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				return b.structSchema(scope{pkg: sc.pkg, file: sc.pkg.fileOf[ts]}, st, name)
+			}
+		}
+	case *ast.SelectorExpr:
+		pkgIdent, ok := t.X.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+
+		pkg, err := b.l.load(b.l.resolveImport(sc.file, pkgIdent.Name))
+		if err != nil || pkg == nil {
+			return nil
+		}
+
+		if ts, ok := pkg.types[t.Sel.Name]; ok {
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				return b.structSchema(scope{pkg: pkg, file: pkg.fileOf[ts]}, st, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// jsonTagName returns the name in the json tag of a field, and whether the field is never encoded
+func jsonTagName(field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+
+	tag, ok := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Lookup("json")
+	if !ok {
+		return "", false
+	}
+
+	if tag == "-" {
+		return "", true
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	return name, false
+}
+
+// basicSchema returns the schema of a predeclared type
+func basicSchema(name string) (*schema, bool) {
+	switch name {
+	case "string":
+		return &schema{Type: "string"}, true
+	case "bool":
+		return &schema{Type: "boolean"}, true
+	case "int", "int64", "uint", "uint32", "uint64", "uintptr":
+		return &schema{Type: "integer", Format: "int64"}, true
+	case "int8", "int16", "int32", "uint8", "uint16", "byte", "rune":
+		return &schema{Type: "integer", Format: "int32"}, true
+	case "float32":
+		return &schema{Type: "number", Format: "float"}, true
+	case "float64":
+		return &schema{Type: "number", Format: "double"}, true
+	case "any":
+		return &schema{}, true
+	case "error":
+		return &schema{Type: "string"}, true
+	default:
+		return nil, false
+	}
+}
+
+// exportedName returns the name with an upper case first letter
+func exportedName(name string) string {
+	if name == "" {
+		return name
+	}
+
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+
+	return string(r)
+}
+
+// singular returns the component name hint of the elements of a slice or map
+func singular(hint string) string {
+	if hint == "" {
+		return ""
+	}
+
+	if trimmed, ok := strings.CutSuffix(hint, "s"); ok && trimmed != "" {
+		return trimmed
+	}
+
+	return hint + "Item"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
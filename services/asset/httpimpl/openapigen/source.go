@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// pkgSource is a parsed package of the module
+type pkgSource struct {
+	importPath string
+	name       string
+	files      []*ast.File
+
+	types      map[string]*ast.TypeSpec
+	funcs      map[string]*ast.FuncDecl // package functions
+	methods    map[string]*ast.FuncDecl // methods by name, over all receivers
+	marshalers map[string]bool          // types with a MarshalJSON method
+	fileOf     map[ast.Node]*ast.File   // file of each type and function declaration
+}
+
+// loader parses the packages of the module on demand, packages of other modules are never parsed
+type loader struct {
+	fset       *token.FileSet
+	modulePath string
+	moduleDir  string
+	pkgs       map[string]*pkgSource
+}
+
+// newLoader returns a loader of the module containing dir
+func newLoader(dir string) (*loader, error) {
+	moduleDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.NewProcessingError("failed to resolve %s", dir, err)
+	}
+
+	for {
+		if _, err = os.Stat(filepath.Join(moduleDir, "go.mod")); err == nil {
+			break
+		}
+
+		parent := filepath.Dir(moduleDir)
+		if parent == moduleDir {
+			return nil, errors.NewProcessingError("no go.mod found above %s", dir)
+		}
+
+		moduleDir = parent
+	}
+
+	modulePath, err := readModulePath(filepath.Join(moduleDir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &loader{
+		fset:       token.NewFileSet(),
+		modulePath: modulePath,
+		moduleDir:  moduleDir,
+		pkgs:       make(map[string]*pkgSource),
+	}, nil
+}
+
+func readModulePath(goMod string) (string, error) {
+	f, err := os.Open(goMod)
+	if err != nil {
+		return "", errors.NewProcessingError("failed to open %s", goMod, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if modulePath, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.TrimSpace(modulePath), nil
+		}
+	}
+
+	return "", errors.NewProcessingError("no module directive in %s", goMod)
+}
+
+// importPathOf returns the import path of a directory of the module
+func (l *loader) importPathOf(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errors.NewProcessingError("failed to resolve %s", dir, err)
+	}
+
+	rel, err := filepath.Rel(l.moduleDir, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", errors.NewProcessingError("%s is not part of the module in %s", dir, l.moduleDir)
+	}
+
+	if rel == "." {
+		return l.modulePath, nil
+	}
+
+	return path.Join(l.modulePath, filepath.ToSlash(rel)), nil
+}
+
+// load returns the parsed package of the module, nil for packages of other modules
+func (l *loader) load(importPath string) (*pkgSource, error) {
+	if pkg, ok := l.pkgs[importPath]; ok {
+		return pkg, nil
+	}
+
+	rel, ok := strings.CutPrefix(importPath, l.modulePath)
+	if !ok || (rel != "" && !strings.HasPrefix(rel, "/")) {
+		return nil, nil
+	}
+
+	dir := filepath.Join(l.moduleDir, filepath.FromSlash(strings.TrimPrefix(rel, "/")))
+
+	pkgs, err := parser.ParseDir(l.fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, errors.NewProcessingError("failed to parse %s", dir, err)
+	}
+
+	pkg := &pkgSource{
+		importPath: importPath,
+		types:      make(map[string]*ast.TypeSpec),
+		funcs:      make(map[string]*ast.FuncDecl),
+		methods:    make(map[string]*ast.FuncDecl),
+		marshalers: make(map[string]bool),
+		fileOf:     make(map[ast.Node]*ast.File),
+	}
+
+	for name, p := range pkgs {
+		if strings.HasSuffix(name, "_test") || name == "main" && len(pkgs) > 1 {
+			continue
+		}
+
+		pkg.name = name
+
+		for _, fileName := range sortedKeys(p.Files) {
+			pkg.addFile(p.Files[fileName])
+		}
+	}
+
+	l.pkgs[importPath] = pkg
+
+	return pkg, nil
+}
+
+func (p *pkgSource) addFile(file *ast.File) {
+	p.files = append(p.files, file)
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					p.types[ts.Name.Name] = ts
+					p.fileOf[ts] = file
+				}
+			}
+		case *ast.FuncDecl:
+			p.fileOf[d] = file
+
+			if d.Recv == nil {
+				p.funcs[d.Name.Name] = d
+				continue
+			}
+
+			if _, exists := p.methods[d.Name.Name]; !exists {
+				p.methods[d.Name.Name] = d
+			}
+
+			if d.Name.Name == "MarshalJSON" {
+				p.marshalers[receiverTypeName(d)] = true
+			}
+		}
+	}
+}
+
+// receiverTypeName returns the name of the receiver type of a method
+func receiverTypeName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+
+	return ""
+}
+
+// resolveImport returns the import path the package name refers to in the file
+func (l *loader) resolveImport(file *ast.File, name string) string {
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		if imp.Name != nil {
+			if imp.Name.Name == name {
+				return importPath
+			}
+
+			continue
+		}
+
+		if pkg, _ := l.load(importPath); pkg != nil {
+			if pkg.name == name {
+				return importPath
+			}
+
+			continue
+		}
+
+		if guessPackageName(importPath) == name {
+			return importPath
+		}
+	}
+
+	return ""
+}
+
+// guessPackageName returns the likely name of a package of another module, e.g. subtree for go-subtree
+func guessPackageName(importPath string) string {
+	elems := strings.Split(importPath, "/")
+	name := elems[len(elems)-1]
+
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+
+	name = strings.TrimPrefix(name, "go-")
+
+	return strings.ReplaceAll(name, "-", "")
+}
+
+// docSummary returns the first sentence of a doc comment, without the leading name of the declaration
+func docSummary(doc *ast.CommentGroup, name string) string {
+	if doc == nil {
+		return ""
+	}
+
+	text := strings.TrimSpace(doc.Text())
+	if i := strings.Index(text, "\n\n"); i >= 0 {
+		text = text[:i]
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+
+	if i := strings.Index(text, ". "); i >= 0 {
+		text = text[:i]
+	}
+
+	text = strings.TrimSuffix(text, ".")
+
+	for _, prefix := range []string{name + " ", "Creates an HTTP handler for ", "Creates an HTTP handler that "} {
+		if rest, ok := strings.CutPrefix(text, prefix); ok && rest != "" {
+			text = strings.ToUpper(rest[:1]) + rest[1:]
+		}
+	}
+
+	return text
+}
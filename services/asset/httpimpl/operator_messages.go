@@ -0,0 +1,135 @@
+package httpimpl
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/util/audit"
+	"github.com/labstack/echo/v4"
+)
+
+// SendOperatorMessageRequest represents the JSON body to send a direct message to the operator of another node,
+// e.g. {"peer_id": "12D3KooW...", "kind": "restart", "text": "restarting for an upgrade in 5 minutes"}
+type SendOperatorMessageRequest struct {
+	PeerID string `json:"peer_id"`
+	Kind   string `json:"kind"`
+	Text   string `json:"text"`
+}
+
+// OperatorMessageResponse represents the JSON response for a direct message sent to or received from the operator
+// of another node
+type OperatorMessageResponse struct {
+	ID        string `json:"id"`
+	PeerID    string `json:"peer_id"`
+	Direction string `json:"direction"`
+	Kind      string `json:"kind"`
+	Text      string `json:"text"`
+	SentAt    int64  `json:"sent_at"`
+	At        int64  `json:"at"`
+}
+
+// OperatorMessagesResponse represents the JSON response containing the most recent operator messages
+type OperatorMessagesResponse struct {
+	Messages []OperatorMessageResponse `json:"messages"`
+	Count    int                       `json:"count"`
+}
+
+// GetOperatorMessages returns the most recent direct messages sent to and received from the operators of other
+// nodes, newest first. The optional peer_id query parameter selects a single peer, limit the number of messages.
+func (h *HTTP) GetOperatorMessages(c echo.Context) error {
+	limit := 0
+
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit < 0 {
+			return sendStatusError(c, http.StatusBadRequest, "Limit must be a non-negative number")
+		}
+	}
+
+	p2pClient := h.repository.GetP2PClient()
+	if p2pClient == nil {
+		h.logger.Errorf("[GetOperatorMessages] P2P client not available")
+		return sendStatusError(c, http.StatusServiceUnavailable, "P2P service not available")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	messages, err := p2pClient.GetOperatorMessages(ctx, c.QueryParam("peer_id"), limit)
+	if err != nil {
+		h.logger.Errorf("[GetOperatorMessages] Failed to get operator messages: %v", err)
+		return sendStatusError(c, http.StatusInternalServerError, "Failed to get operator messages")
+	}
+
+	resp := OperatorMessagesResponse{
+		Messages: make([]OperatorMessageResponse, 0, len(messages)),
+		Count:    len(messages),
+	}
+
+	for _, msg := range messages {
+		resp.Messages = append(resp.Messages, operatorMessageResponse(msg))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// SendOperatorMessage sends a direct message to the operator of the peer of the request, encrypted to the peer.
+// Invalid messages are refused with status 400, messages over the rate limit of the peer with status 429.
+func (h *HTTP) SendOperatorMessage(c echo.Context) error {
+	var req SendOperatorMessageRequest
+	if err := c.Bind(&req); err != nil {
+		return sendStatusError(c, http.StatusBadRequest, "Invalid request body")
+	}
+
+	if req.PeerID == "" {
+		return sendStatusError(c, http.StatusBadRequest, "peer_id is required")
+	}
+
+	p2pClient := h.repository.GetP2PClient()
+	if p2pClient == nil {
+		h.logger.Errorf("[SendOperatorMessage] P2P client not available")
+		return sendStatusError(c, http.StatusServiceUnavailable, "P2P service not available")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	msg, err := p2pClient.SendOperatorMessage(ctx, req.PeerID, p2p.OperatorMessageKind(req.Kind), req.Text)
+	if err != nil {
+		h.logger.Warnf("[SendOperatorMessage] Failed to send operator message to %s: %v", req.PeerID, err)
+
+		switch {
+		case errors.Is(err, errors.ErrInvalidArgument):
+			return sendStatusError(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, errors.ErrThresholdExceeded):
+			return sendStatusError(c, http.StatusTooManyRequests, err.Error())
+		case errors.Is(err, errors.ErrServiceUnavailable):
+			return sendStatusError(c, http.StatusServiceUnavailable, err.Error())
+		default:
+			return sendStatusError(c, http.StatusInternalServerError, "Failed to send operator message")
+		}
+	}
+
+	h.logger.Infof("[SendOperatorMessage] sent %s operator message to %s", msg.Kind, msg.PeerID)
+
+	recordAudit(h.auditLog, c, audit.ActionOperatorMessage, msg.PeerID, "kind="+string(msg.Kind))
+
+	return c.JSON(http.StatusOK, operatorMessageResponse(*msg))
+}
+
+// operatorMessageResponse converts an operator message to its JSON response
+func operatorMessageResponse(msg p2p.OperatorMessage) OperatorMessageResponse {
+	return OperatorMessageResponse{
+		ID:        msg.ID,
+		PeerID:    msg.PeerID,
+		Direction: msg.Direction,
+		Kind:      string(msg.Kind),
+		Text:      msg.Text,
+		SentAt:    msg.SentAt.Unix(),
+		At:        msg.At.Unix(),
+	}
+}
@@ -0,0 +1,114 @@
+package httpimpl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/asset/repository"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// operatorMessagesP2PClient is a P2P client keeping the operator messages sent through it
+type operatorMessagesP2PClient struct {
+	p2p.ClientI
+
+	messages []p2p.OperatorMessage
+	sendErr  error
+	peerID   string
+	limit    int
+}
+
+func (c *operatorMessagesP2PClient) SendOperatorMessage(_ context.Context, peerID string, kind p2p.OperatorMessageKind, text string) (*p2p.OperatorMessage, error) {
+	if c.sendErr != nil {
+		return nil, c.sendErr
+	}
+
+	msg := p2p.OperatorMessage{
+		ID:        "0123456789abcdef",
+		PeerID:    peerID,
+		Direction: p2p.OperatorMessageSent,
+		Kind:      kind,
+		Text:      text,
+		SentAt:    time.Unix(1700000000, 0),
+		At:        time.Unix(1700000000, 0),
+	}
+
+	c.messages = append(c.messages, msg)
+
+	return &msg, nil
+}
+
+func (c *operatorMessagesP2PClient) GetOperatorMessages(_ context.Context, peerID string, limit int) ([]p2p.OperatorMessage, error) {
+	c.peerID = peerID
+	c.limit = limit
+
+	return c.messages, nil
+}
+
+func TestOperatorMessages(t *testing.T) {
+	client := &operatorMessagesP2PClient{}
+
+	repo := &repository.Mock{}
+	repo.On("GetP2PClient").Return(client)
+
+	h := &HTTP{logger: ulogger.TestLogger{}, repository: repo}
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/operator-messages", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.SendOperatorMessage(echo.New().NewContext(req, rec)))
+
+		return rec
+	}
+
+	rec := send(`{"peer_id": "` + testBanPeerID + `", "kind": "restart", "text": "back in 5 minutes"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var sent OperatorMessageResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &sent))
+	assert.Equal(t, testBanPeerID, sent.PeerID)
+	assert.Equal(t, "restart", sent.Kind)
+	assert.Equal(t, "sent", sent.Direction)
+	assert.Equal(t, int64(1700000000), sent.SentAt)
+
+	assert.Equal(t, http.StatusBadRequest, send(`{"kind": "restart"}`).Code, "missing peer ID")
+
+	client.sendErr = errors.NewInvalidArgumentError("invalid operator message kind")
+	assert.Equal(t, http.StatusBadRequest, send(`{"peer_id": "`+testBanPeerID+`", "kind": "reboot"}`).Code)
+
+	client.sendErr = errors.NewThresholdExceededError("more than 6 operator messages per minute")
+	assert.Equal(t, http.StatusTooManyRequests, send(`{"peer_id": "`+testBanPeerID+`", "kind": "info"}`).Code)
+
+	client.sendErr = nil
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/operator-messages?peer_id="+testBanPeerID+"&limit=10", nil)
+	rec = httptest.NewRecorder()
+
+	require.NoError(t, h.GetOperatorMessages(echo.New().NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, testBanPeerID, client.peerID)
+	assert.Equal(t, 10, client.limit)
+
+	var resp OperatorMessagesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, 1, resp.Count)
+	assert.Equal(t, "back in 5 minutes", resp.Messages[0].Text)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/operator-messages?limit=-1", nil)
+	rec = httptest.NewRecorder()
+
+	require.NoError(t, h.GetOperatorMessages(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
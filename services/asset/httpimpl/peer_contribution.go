@@ -0,0 +1,168 @@
+package httpimpl
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/labstack/echo/v4"
+)
+
+// PeerContributionResponse represents the JSON response for the data contribution of a single peer
+type PeerContributionResponse struct {
+	PeerID              string `json:"peer_id"`
+	BlocksReceived      uint64 `json:"blocks_received"`
+	SubtreesReceived    uint64 `json:"subtrees_received"`
+	SubtreeDataReceived uint64 `json:"subtree_data_received"`
+	BytesReceived       uint64 `json:"bytes_received"`
+	BlocksServed        uint64 `json:"blocks_served"`
+	SubtreesServed      uint64 `json:"subtrees_served"`
+	SubtreeDataServed   uint64 `json:"subtree_data_served"`
+	BytesServed         uint64 `json:"bytes_served"`
+	FirstSeen           int64  `json:"first_seen"`
+	LastUpdated         int64  `json:"last_updated"`
+}
+
+// PeerContributionsResponse represents the JSON response containing the data contribution of all peers
+type PeerContributionsResponse struct {
+	Contributions []PeerContributionResponse `json:"contributions"`
+	Count         int                        `json:"count"`
+}
+
+// countingResponseWriter counts the bytes of the response body
+type countingResponseWriter struct {
+	http.ResponseWriter
+	bytes uint64
+}
+
+func (w *countingResponseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += uint64(n) //nolint:gosec // n is never negative
+
+	return n, err
+}
+
+func (w *countingResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap gives http.ResponseController access to the underlying writer
+func (w *countingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// servedDataType returns the contribution data type and number of items of a successful response to a
+// binary block, blocks, subtree or subtree data request, or an empty data type for any other route
+func (h *HTTP) servedDataType(c echo.Context) (string, uint64) {
+	route, found := strings.CutPrefix(c.Path(), h.settings.Asset.APIPrefix)
+	if !found {
+		return "", 0
+	}
+
+	switch route {
+	case "/block/:hash":
+		return p2p.PeerDataTypeBlock, 1
+	case "/blocks/:hash":
+		// the number of blocks requested, the response holds fewer blocks when the chain is shorter
+		n, err := strconv.ParseUint(c.QueryParam("n"), 10, 64)
+		if err != nil || n == 0 {
+			n = 100
+		}
+
+		return p2p.PeerDataTypeBlock, n
+	case "/subtree/:hash":
+		return p2p.PeerDataTypeSubtree, 1
+	case "/subtree_data/:hash":
+		return p2p.PeerDataTypeSubtreeData, 1
+	default:
+		return "", 0
+	}
+}
+
+// peerContributionMiddleware accounts the blocks and subtrees served to nodes that identify themselves with
+// their peer ID in the util.PeerIDHeader. It is registered with Echo#Pre, so the compressed bytes are accounted.
+func (h *HTTP) peerContributionMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		peerID := c.Request().Header.Get(util.PeerIDHeader)
+		if peerID == "" {
+			return next(c)
+		}
+
+		res := c.Response()
+		counter := &countingResponseWriter{ResponseWriter: res.Writer}
+		res.Writer = counter
+
+		err := next(c)
+
+		if err != nil || res.Status != http.StatusOK {
+			return err
+		}
+
+		dataType, items := h.servedDataType(c)
+		if dataType == "" {
+			return nil
+		}
+
+		p2pClient := h.repository.GetP2PClient()
+		if p2pClient == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+		defer cancel()
+
+		if recordErr := p2pClient.RecordDataUploaded(ctx, peerID, dataType, items, counter.bytes); recordErr != nil {
+			h.logger.Debugf("[peerContributionMiddleware] failed to record %d bytes served to peer %s: %v", counter.bytes, peerID, recordErr)
+		}
+
+		return nil
+	}
+}
+
+// GetPeerContributions returns the lifetime totals of the data each peer served to us and we served to it,
+// the peers contributing the least listed first. The optional peer_id query parameter selects a single peer.
+func (h *HTTP) GetPeerContributions(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	p2pClient := h.repository.GetP2PClient()
+	if p2pClient == nil {
+		h.logger.Errorf("[GetPeerContributions] P2P client not available")
+		return sendStatusError(c, http.StatusServiceUnavailable, "P2P service not available")
+	}
+
+	contributions, err := p2pClient.GetPeerContributions(ctx, c.QueryParam("peer_id"))
+	if err != nil {
+		h.logger.Errorf("[GetPeerContributions] Failed to get peer contributions: %v", err)
+		return sendStatusError(c, http.StatusInternalServerError, "Failed to get peer contributions")
+	}
+
+	resp := PeerContributionsResponse{
+		Contributions: make([]PeerContributionResponse, 0, len(contributions)),
+		Count:         len(contributions),
+	}
+
+	for _, contribution := range contributions {
+		resp.Contributions = append(resp.Contributions, PeerContributionResponse{
+			PeerID:              contribution.PeerID,
+			BlocksReceived:      contribution.BlocksReceived,
+			SubtreesReceived:    contribution.SubtreesReceived,
+			SubtreeDataReceived: contribution.SubtreeDataReceived,
+			BytesReceived:       contribution.BytesReceived,
+			BlocksServed:        contribution.BlocksServed,
+			SubtreesServed:      contribution.SubtreesServed,
+			SubtreeDataServed:   contribution.SubtreeDataServed,
+			BytesServed:         contribution.BytesServed,
+			FirstSeen:           contribution.FirstSeen.Unix(),
+			LastUpdated:         contribution.LastUpdated.Unix(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,235 @@
+package httpimpl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	headerCacheControl = "Cache-Control"
+	headerIfNoneMatch  = "If-None-Match"
+
+	// Results of the requests to cached endpoints, the labels of the response cache metric
+	responseCacheHit         = "hit"
+	responseCacheMiss        = "miss"
+	responseCacheNotModified = "not_modified"
+)
+
+// cachedResponse is a response of a cached endpoint, with the headers set by the handler
+type cachedResponse struct {
+	status  int
+	header  http.Header
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// responseCache caches the responses of the read endpoints polled by dashboards, the peer registry, block headers
+// and catchup status, for a short TTL so polling every second does not query the gRPC backends for every request.
+// The responses carry an ETag and requests with a matching If-None-Match header get a 304 Not Modified, also when
+// the cache is disabled. Concurrent requests for an uncached response share a single call of the handler.
+// All operations are thread-safe.
+type responseCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+	group   singleflight.Group
+}
+
+// newResponseCache creates the response cache of the settings, a TTL of 0 disables the caching of responses
+func newResponseCache(tSettings *settings.Settings) *responseCache {
+	initPrometheusMetrics()
+
+	return &responseCache{
+		ttl:        tSettings.Asset.ResponseCacheTTL,
+		maxEntries: tSettings.Asset.ResponseCacheMaxEntries,
+		entries:    make(map[string]*cachedResponse),
+	}
+}
+
+// enabled returns whether responses are cached
+func (rc *responseCache) enabled() bool {
+	return rc.ttl > 0 && rc.maxEntries > 0
+}
+
+// get returns the unexpired cached response of the key
+func (rc *responseCache) get(key string, now time.Time) (*cachedResponse, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, exists := rc.entries[key]
+	if !exists || !now.Before(entry.expires) {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// set caches the response of the key. When the cache is full the expired responses are removed first, and the
+// response is not cached when none expired.
+func (rc *responseCache) set(key string, entry *cachedResponse, now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if _, exists := rc.entries[key]; !exists && len(rc.entries) >= rc.maxEntries {
+		for k, e := range rc.entries {
+			if !now.Before(e.expires) {
+				delete(rc.entries, k)
+			}
+		}
+
+		if len(rc.entries) >= rc.maxEntries {
+			return
+		}
+	}
+
+	rc.entries[key] = entry
+}
+
+// middleware serves the response of the route from the cache, or calls the handler and caches its response when
+// it succeeded. It is registered on the routes of the cached endpoints, so the response is cached before
+// compression.
+func (rc *responseCache) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().URL.RequestURI()
+
+		if !rc.enabled() {
+			response, err := recordResponse(c, next)
+			if err != nil {
+				return err
+			}
+
+			return writeCachedResponse(c, response)
+		}
+
+		if response, ok := rc.get(key, time.Now()); ok {
+			prometheusAssetHTTPResponseCache.WithLabelValues(responseCacheHit).Inc()
+			return writeCachedResponse(c, response)
+		}
+
+		result, err, _ := rc.group.Do(key, func() (interface{}, error) {
+			prometheusAssetHTTPResponseCache.WithLabelValues(responseCacheMiss).Inc()
+
+			response, err := recordResponse(c, next)
+			if err == nil && response.status == http.StatusOK {
+				response.expires = time.Now().Add(rc.ttl)
+				rc.set(key, response, time.Now())
+			}
+
+			return response, err
+		})
+		if err != nil {
+			return err
+		}
+
+		return writeCachedResponse(c, result.(*cachedResponse))
+	}
+}
+
+// responseRecorder records the response of a handler without writing it to the client
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	return r.body.Write(p)
+}
+
+// recordResponse calls the handler and returns the response it wrote, with the ETag of a successful response.
+// An error returned by the handler before it wrote a response is returned for the error handler of the server.
+func recordResponse(c echo.Context, next echo.HandlerFunc) (*cachedResponse, error) {
+	res := c.Response()
+	writer, status, committed := res.Writer, res.Status, res.Committed
+
+	recorder := &responseRecorder{header: make(http.Header), status: http.StatusOK}
+	res.Writer = recorder
+
+	err := next(c)
+
+	wrote := res.Committed
+	res.Writer, res.Status, res.Committed = writer, status, committed
+
+	if err != nil && !wrote {
+		return nil, err
+	}
+
+	response := &cachedResponse{
+		status: recorder.status,
+		header: recorder.header,
+		body:   recorder.body.Bytes(),
+	}
+
+	if response.status == http.StatusOK {
+		sum := sha256.Sum256(response.body)
+		response.etag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+
+	return response, nil
+}
+
+// writeCachedResponse writes a recorded response, or a 304 Not Modified when the If-None-Match header of the
+// request matches its ETag
+func writeCachedResponse(c echo.Context, response *cachedResponse) error {
+	header := c.Response().Header()
+
+	// the cached header values are shared by all requests served from the cache
+	for name, values := range response.header {
+		header[name] = append([]string(nil), values...)
+	}
+
+	if response.etag != "" {
+		header.Set(headerETag, response.etag)
+		header.Set(headerCacheControl, "no-cache")
+
+		if etagMatches(c.Request().Header.Get(headerIfNoneMatch), response.etag) {
+			prometheusAssetHTTPResponseCache.WithLabelValues(responseCacheNotModified).Inc()
+			return c.NoContent(http.StatusNotModified)
+		}
+	}
+
+	c.Response().WriteHeader(response.status)
+
+	_, err := c.Response().Write(response.body)
+
+	return err
+}
+
+// etagMatches returns whether an If-None-Match header matches the ETag, using the weak comparison of RFC 9110
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
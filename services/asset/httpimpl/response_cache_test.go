@@ -0,0 +1,206 @@
+package httpimpl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newResponseCacheTestServer returns a server with a cached /peers route returning the current value, and the
+// number of calls of its handler
+func newResponseCacheTestServer(t *testing.T, ttl time.Duration, value *atomic.Value) (*echo.Echo, *atomic.Int32) {
+	t.Helper()
+
+	tSettings := settings.NewSettings()
+	tSettings.Asset.ResponseCacheTTL = ttl
+	tSettings.Asset.ResponseCacheMaxEntries = 10
+
+	cache := newResponseCache(tSettings)
+	calls := &atomic.Int32{}
+
+	e := echo.New()
+	e.HTTPErrorHandler = httpErrorHandler
+	e.Use(middleware.Gzip())
+
+	e.GET("/peers", func(c echo.Context) error {
+		calls.Add(1)
+
+		if value.Load() == nil {
+			return errors.NewServiceError("p2p service unavailable")
+		}
+
+		c.Response().Header().Set("X-Signature", "sig")
+
+		return c.JSON(http.StatusOK, map[string]string{"value": value.Load().(string)})
+	}, cache.middleware)
+
+	return e, calls
+}
+
+func serveCached(e *echo.Echo, uri string, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, uri, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set(headerIfNoneMatch, ifNoneMatch)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestResponseCache(t *testing.T) {
+	t.Run("serves the cached response within the TTL", func(t *testing.T) {
+		value := &atomic.Value{}
+		value.Store("a")
+
+		e, calls := newResponseCacheTestServer(t, time.Hour, value)
+
+		first := serveCached(e, "/peers", "")
+		require.Equal(t, http.StatusOK, first.Code)
+		assert.JSONEq(t, `{"value":"a"}`, first.Body.String())
+		assert.NotEmpty(t, first.Header().Get(headerETag))
+		assert.Equal(t, "no-cache", first.Header().Get(headerCacheControl))
+		assert.Equal(t, "sig", first.Header().Get("X-Signature"))
+
+		value.Store("b")
+
+		second := serveCached(e, "/peers", "")
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, first.Header().Get(headerETag), second.Header().Get(headerETag))
+		assert.Equal(t, "sig", second.Header().Get("X-Signature"))
+		assert.Equal(t, int32(1), calls.Load())
+
+		// another query is another response
+		serveCached(e, "/peers?limit=1", "")
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		value := &atomic.Value{}
+		value.Store("a")
+
+		e, calls := newResponseCacheTestServer(t, 0, value)
+
+		etag := serveCached(e, "/peers", "").Header().Get(headerETag)
+
+		rec := serveCached(e, "/peers", etag)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.Bytes())
+		assert.Equal(t, etag, rec.Header().Get(headerETag))
+
+		assert.Equal(t, http.StatusNotModified, serveCached(e, "/peers", `"other", W/`+etag).Code)
+
+		// the cache is disabled, every request calls the handler
+		assert.Equal(t, int32(3), calls.Load())
+
+		value.Store("b")
+
+		rec = serveCached(e, "/peers", etag)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"value":"b"}`, rec.Body.String())
+		assert.NotEqual(t, etag, rec.Header().Get(headerETag))
+	})
+
+	t.Run("expired response", func(t *testing.T) {
+		value := &atomic.Value{}
+		value.Store("a")
+
+		e, calls := newResponseCacheTestServer(t, time.Millisecond, value)
+
+		serveCached(e, "/peers", "")
+		time.Sleep(5 * time.Millisecond)
+
+		value.Store("b")
+
+		assert.JSONEq(t, `{"value":"b"}`, serveCached(e, "/peers", "").Body.String())
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		value := &atomic.Value{}
+
+		e, calls := newResponseCacheTestServer(t, time.Hour, value)
+
+		rec := serveCached(e, "/peers", "")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Empty(t, rec.Header().Get(headerETag))
+
+		value.Store("a")
+
+		rec = serveCached(e, "/peers", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("concurrent requests", func(t *testing.T) {
+		value := &atomic.Value{}
+		value.Store("a")
+
+		e, calls := newResponseCacheTestServer(t, time.Hour, value)
+
+		var wg sync.WaitGroup
+
+		for range 20 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				rec := serveCached(e, "/peers", "")
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.JSONEq(t, `{"value":"a"}`, rec.Body.String())
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestResponseCache_MaxEntries(t *testing.T) {
+	rc := &responseCache{ttl: time.Minute, maxEntries: 2, entries: make(map[string]*cachedResponse)}
+	now := time.Now()
+
+	rc.set("a", &cachedResponse{expires: now.Add(time.Second)}, now)
+	rc.set("b", &cachedResponse{expires: now.Add(time.Minute)}, now)
+
+	// the cache is full
+	rc.set("c", &cachedResponse{expires: now.Add(time.Minute)}, now)
+
+	_, ok := rc.get("c", now)
+	assert.False(t, ok)
+
+	// the expired responses make room
+	later := now.Add(2 * time.Second)
+	rc.set("c", &cachedResponse{expires: later.Add(time.Minute)}, later)
+
+	_, ok = rc.get("a", later)
+	assert.False(t, ok)
+
+	_, ok = rc.get("b", later)
+	assert.True(t, ok)
+
+	_, ok = rc.get("c", later)
+	assert.True(t, ok)
+}
+
+func TestEtagMatches(t *testing.T) {
+	assert.False(t, etagMatches("", `"a"`))
+	assert.True(t, etagMatches(`"a"`, `"a"`))
+	assert.True(t, etagMatches(`W/"a"`, `"a"`))
+	assert.True(t, etagMatches(`"b", "a"`, `"a"`))
+	assert.True(t, etagMatches("*", `"a"`))
+	assert.False(t, etagMatches(`"b"`, `"a"`))
+}
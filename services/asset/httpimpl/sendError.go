@@ -3,9 +3,11 @@
 package httpimpl
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/labstack/echo/v4"
 )
 
@@ -17,11 +19,16 @@ type errorResponse struct {
 	// Example: 400
 	Status int32 `json:"status"`
 
-	// Code contains the application-specific error code
+	// Code contains the application-specific error code, see GET /api/v1/errors for the catalog of codes
 	// Required: true
-	// Example: 1001
+	// Example: 3
 	Code int32 `json:"code"`
 
+	// Name contains the name of the error code
+	// Required: true
+	// Example: "NOT_FOUND"
+	Name string `json:"name"`
+
 	// Err contains the human-readable error message
 	// Required: true
 	// Example: "invalid block hash format"
@@ -49,7 +56,7 @@ type errorResponse struct {
 // Example Usage:
 //
 //	if err != nil {
-//	    return sendError(c, http.StatusBadRequest, 1001, errors.New("invalid hash format"))
+//	    return sendError(c, http.StatusBadRequest, int32(errors.ERR_INVALID_ARGUMENT), errors.NewInvalidArgumentError("invalid hash format"))
 //	}
 //
 // Example Response:
@@ -59,8 +66,9 @@ type errorResponse struct {
 //	Body:
 //	  {
 //	    "status": 400,
-//	    "code": 1001,
-//	    "error": "invalid hash format"
+//	    "code": 1,
+//	    "name": "INVALID_ARGUMENT",
+//	    "error": "INVALID_ARGUMENT (1): invalid hash format"
 //	  }
 //
 // Notes:
@@ -73,11 +81,84 @@ func sendError(c echo.Context, status int, code int32, err error) error {
 		status = http.StatusBadRequest
 	}
 
+	return writeError(c, status, errors.ERR(code), err.Error())
+}
+
+// sendStatusError sends an error response with the code the message starts with, or the error code of the HTTP
+// status for messages that are not teranode errors, see codeForStatus.
+func sendStatusError(c echo.Context, status int, message string) error {
+	code, ok := errors.ParseCode(message)
+	if !ok {
+		code = codeForStatus(status)
+	}
+
+	return writeError(c, status, code, message)
+}
+
+// writeError writes the error response
+func writeError(c echo.Context, status int, code errors.ERR, message string) error {
 	e := &errorResponse{
 		Status: int32(status), //nolint:gosec
-		Code:   code,
-		Err:    err.Error(),
+		Code:   int32(code),
+		Name:   code.String(),
+		Err:    message,
+	}
+
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(status)
 	}
 
 	return c.JSON(status, e)
 }
+
+// codeForStatus returns the error code of an error response that does not carry a teranode error
+func codeForStatus(status int) errors.ERR {
+	switch {
+	case status == http.StatusNotFound:
+		return errors.ERR_NOT_FOUND
+	case status == http.StatusTooManyRequests || status == http.StatusRequestEntityTooLarge:
+		return errors.ERR_THRESHOLD_EXCEEDED
+	case status == http.StatusServiceUnavailable:
+		return errors.ERR_SERVICE_UNAVAILABLE
+	case status >= 400 && status < 500:
+		return errors.ERR_INVALID_ARGUMENT
+	case status >= 500:
+		return errors.ERR_SERVICE_ERROR
+	default:
+		return errors.ERR_UNKNOWN
+	}
+}
+
+// httpErrorHandler writes the errors returned by the handlers as error responses. The code is the code of the
+// teranode error the handler returned, or the code its message starts with, as most handlers return an
+// echo.HTTPError with the message of a teranode error, and the code of the HTTP status otherwise.
+func httpErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status := http.StatusInternalServerError
+	message := err.Error()
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		status = httpErr.Code
+
+		if m, ok := httpErr.Message.(string); ok {
+			message = m
+		} else {
+			message = fmt.Sprint(httpErr.Message)
+		}
+	}
+
+	code, ok := errors.ParseCode(message)
+	if !ok {
+		if code = errors.CodeOf(err); code == errors.ERR_UNKNOWN {
+			code = codeForStatus(status)
+		}
+	}
+
+	if writeErr := writeError(c, status, code, message); writeErr != nil {
+		c.Logger().Error(writeErr)
+	}
+}
@@ -0,0 +1,84 @@
+package httpimpl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPErrorHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = httpErrorHandler
+
+	e.GET("/http-error", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, errors.NewBlockNotFoundError("block abc not found").Error())
+	})
+	e.GET("/teranode-error", func(c echo.Context) error {
+		return errors.NewStorageUnavailableError("store unavailable")
+	})
+	e.GET("/plain-error", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid limit")
+	})
+	e.GET("/status-error", func(c echo.Context) error {
+		return sendStatusError(c, http.StatusServiceUnavailable, "P2P service not available")
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		code   errors.ERR
+	}{
+		{path: "/http-error", status: http.StatusNotFound, code: errors.ERR_BLOCK_NOT_FOUND},
+		{path: "/teranode-error", status: http.StatusInternalServerError, code: errors.ERR_STORAGE_UNAVAILABLE},
+		{path: "/plain-error", status: http.StatusBadRequest, code: errors.ERR_INVALID_ARGUMENT},
+		{path: "/status-error", status: http.StatusServiceUnavailable, code: errors.ERR_SERVICE_UNAVAILABLE},
+		{path: "/no-such-route", status: http.StatusNotFound, code: errors.ERR_NOT_FOUND},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			require.Equal(t, tt.status, rec.Code)
+
+			var resp errorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+			assert.Equal(t, int32(tt.status), resp.Status)
+			assert.Equal(t, int32(tt.code), resp.Code)
+			assert.Equal(t, tt.code.String(), resp.Name)
+			assert.NotEmpty(t, resp.Err)
+		})
+	}
+
+	t.Run("head request", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/no-such-route", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+}
+
+func TestGetErrorCatalog(t *testing.T) {
+	h := &HTTP{}
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/errors", nil), rec)
+
+	require.NoError(t, h.GetErrorCatalog(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var catalog []errors.CatalogEntry
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &catalog))
+
+	assert.Equal(t, errors.Catalog(), catalog)
+}
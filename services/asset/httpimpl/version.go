@@ -0,0 +1,14 @@
+package httpimpl
+
+import (
+	"net/http"
+
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
+	"github.com/labstack/echo/v4"
+)
+
+// GetVersion returns the build version, commit and build date of this node, the features it was built with, the
+// chain network and the services running in this process with their protocol versions
+func (h *HTTP) GetVersion(c echo.Context) error {
+	return c.JSON(http.StatusOK, buildinfo.Get(h.settings))
+}
@@ -0,0 +1,43 @@
+package httpimpl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVersion(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.Version = "v1.2.3"
+	tSettings.Commit = "abc1234"
+
+	buildinfo.RegisterService("Asset")
+
+	h := &HTTP{
+		logger:   ulogger.TestLogger{},
+		settings: tSettings,
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/version", nil)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.GetVersion(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response buildinfo.Info
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	assert.Equal(t, "v1.2.3", response.Version)
+	assert.Equal(t, "abc1234", response.Commit)
+	assert.Equal(t, tSettings.ChainCfgParams.Name, response.Network)
+	assert.Contains(t, response.Services, buildinfo.ServiceInfo{Name: "Asset"})
+}
@@ -145,6 +145,45 @@ func (repo *Repository) GetLegacyBlockReader(ctx context.Context, hash *chainhas
 	return r, nil
 }
 
+// GetLegacyBlockSize returns the size in bytes of a block in legacy format, as streamed by GetLegacyBlockReader,
+// without streaming the block. The size is needed to serve byte ranges of the block.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - hash: Hash of the block
+//   - wireBlock: Optional flag to return the size of the wire format, without the magic number and size
+//
+// Returns:
+//   - uint64: Size of the block in bytes
+//   - error: Any error encountered during retrieval
+func (repo *Repository) GetLegacyBlockSize(ctx context.Context, hash *chainhash.Hash, wireBlock ...bool) (uint64, error) {
+	returnWireBlock := len(wireBlock) > 0 && wireBlock[0]
+
+	block, err := repo.GetBlockByHash(ctx, hash)
+	if err != nil {
+		return 0, err
+	}
+
+	return legacyBlockSize(block, returnWireBlock)
+}
+
+// legacyBlockSize returns the number of bytes written by GetLegacyBlockReader for the block
+func legacyBlockSize(block *model.Block, returnWireBlock bool) (uint64, error) {
+	headerSize, err := safeconversion.IntToUint64(model.BlockHeaderSize + bt.VarInt(block.TransactionCount).Length())
+	if err != nil {
+		return 0, err
+	}
+
+	size := headerSize + block.SizeInBytes
+
+	if !returnWireBlock {
+		// magic number and block size
+		size += 8
+	}
+
+	return size, nil
+}
+
 // writeLegacyBlockHeader writes a block header in legacy format to the provided writer.
 //
 // Parameters:
@@ -172,6 +172,44 @@ func TestGetLegacyWireBlockWithSubtreeStore(t *testing.T) {
 	assertBlockFromReader(t, r, bytes, block)
 }
 
+func TestGetLegacyBlockSize(t *testing.T) {
+	tracing.SetupMockTracer()
+
+	for _, wireBlock := range []bool{false, true} {
+		ctx := setup(t)
+
+		block, subtree := newBlock(ctx, t, params)
+
+		//nolint:gosec
+		block.SizeInBytes = uint64(coinbase.Size() + tx1.Size())
+
+		blockchainClientMock := ctx.repo.BlockchainClient.(*blockchain.Mock)
+		blockchainClientMock.On("GetBlock", mock.Anything, mock.Anything).Return(block, nil).Twice()
+
+		for _, tx := range params.txs[1:] {
+			_, err := ctx.repo.UtxoStore.Create(context.Background(), tx, params.height)
+			require.NoError(t, err)
+		}
+
+		subtreeBytes, err := subtree.Serialize()
+		require.NoError(t, err)
+		err = ctx.repo.SubtreeStore.Set(context.Background(), subtree.RootHash()[:], fileformat.FileTypeSubtree, subtreeBytes)
+		require.NoError(t, err)
+
+		size, err := ctx.repo.GetLegacyBlockSize(context.Background(), &chainhash.Hash{}, wireBlock)
+		require.NoError(t, err)
+
+		r, err := ctx.repo.GetLegacyBlockReader(context.Background(), &chainhash.Hash{}, wireBlock)
+		require.NoError(t, err)
+
+		blockBytes, err := io.ReadAll(r)
+		require.ErrorIs(t, err, io.ErrClosedPipe)
+
+		// the size must match the streamed block, byte ranges of the block are served based on it
+		assert.Equal(t, uint64(len(blockBytes)), size, "wire block: %v", wireBlock)
+	}
+}
+
 func assertBlockFromReader(t *testing.T, r *io.PipeReader, bytes []byte, block *model.Block) {
 	// version, 4 bytes
 	n, err := io.ReadFull(r, bytes[:4])
@@ -44,6 +44,17 @@ func (m *Mock) GetLegacyBlockReader(_ context.Context, hash *chainhash.Hash, _ .
 	return args.Get(0).(*io.PipeReader), args.Error(1)
 }
 
+func (m *Mock) GetLegacyBlockSize(_ context.Context, hash *chainhash.Hash, _ ...bool) (uint64, error) {
+	args := m.Called(hash)
+
+	if args.Error(1) != nil {
+		return 0, args.Error(1)
+	}
+
+	// return the mocked response
+	return args.Get(0).(uint64), args.Error(1)
+}
+
 func (m *Mock) Health(_ context.Context, _ bool) (int, string, error) {
 	return 0, "", nil
 }
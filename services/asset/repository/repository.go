@@ -58,6 +58,7 @@ type Interface interface {
 	GetUtxo(ctx context.Context, spend *utxo.Spend) (*utxo.SpendResponse, error)
 	GetBestBlockHeader(ctx context.Context) (*model.BlockHeader, *model.BlockHeaderMeta, error)
 	GetLegacyBlockReader(ctx context.Context, hash *chainhash.Hash, wireBlock ...bool) (*io.PipeReader, error)
+	GetLegacyBlockSize(ctx context.Context, hash *chainhash.Hash, wireBlock ...bool) (uint64, error)
 	GetBlockLocator(ctx context.Context, blockHeaderHash *chainhash.Hash, height uint32) ([]*chainhash.Hash, error)
 	GetBlockByID(ctx context.Context, id uint64) (*model.Block, error)
 	GetBlockchainClient() blockchain.ClientI
@@ -18,6 +18,7 @@ import (
 	"github.com/bsv-blockchain/go-subtree"
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/pkg/fileformat"
 	"github.com/bsv-blockchain/teranode/services/blockassembly/subtreeprocessor"
 	"github.com/bsv-blockchain/teranode/services/blockchain"
 	"github.com/bsv-blockchain/teranode/settings"
@@ -28,6 +29,7 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/utxo/meta"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/blocktrace"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/ordishs/go-utils"
 	"github.com/ordishs/gocore"
@@ -128,6 +130,10 @@ type BlockAssembler struct {
 	stateChangeMu sync.RWMutex
 	stateChangeCh chan BestBlockInfo
 
+	// bestBlockSubscribers receive the best block every time it changes, for state subscriptions
+	bestBlockSubscribersMu sync.Mutex
+	bestBlockSubscribers   map[chan BestBlockInfo]struct{}
+
 	// lastPersistedHeight tracks the last block height processed by block persister
 	// This is updated via BlockPersisted notifications and used to coordinate with cleanup
 	lastPersistedHeight atomic.Uint32
@@ -175,6 +181,9 @@ type BlockAssembler struct {
 
 	// unminedTransactionsLoading indicates if unmined transactions are currently being loaded
 	unminedTransactionsLoading atomic.Bool
+
+	// reorgs tracks the progress of the reorgs and the caches to invalidate after a chain switch
+	reorgs reorgCoordinator
 }
 
 // BestBlockInfo holds both the block header and height atomically
@@ -374,7 +383,13 @@ func (b *BlockAssembler) startChannelListeners(ctx context.Context) (err error)
 				b.setCurrentRunningState(StateBlockchainSubscription)
 
 				if notification.Type == model.NotificationType_Block {
-					b.processNewBlockAnnouncement(ctx)
+					// the lifecycle ID of the block is passed on in the metadata of the notification
+					blockCtx := ctx
+					if notification.Metadata != nil {
+						blockCtx = blocktrace.ContextWithID(ctx, notification.Metadata.Metadata[blocktrace.MetadataKey])
+					}
+
+					b.processNewBlockAnnouncement(blockCtx)
 				} else if notification.Type == model.NotificationType_BlockPersisted {
 					// RUNTIME COORDINATION: Update persisted height from block persister
 					//
@@ -540,6 +555,18 @@ func (b *BlockAssembler) reset(ctx context.Context, fullScan bool) error {
 			}
 
 			block := blockWithMeta.block
+
+			// during legacy sync the blocks were quick-validated, their UTXO changes are reverted with the undo record
+			// of the block instead of keeping their transactions as unmined
+			if isLegacySync {
+				reverted, err := b.revertBlockUndo(ctx, block, moveForwardTxMap)
+				if err != nil {
+					b.logger.Errorf("[BlockAssembler][Reset] error reverting moveBack block %s, marking its transactions as unmined: %v", block.Hash().String(), err)
+				} else if reverted {
+					continue
+				}
+			}
+
 			blockSubtrees, err := block.GetSubtrees(ctx, b.logger, b.subtreeStore, b.settings.Block.GetAndValidateSubtreesConcurrency)
 			if err != nil {
 				b.logger.Warnf("[BlockAssembler][Reset] error getting subtrees for moveBack block %s: %v (will skip)", block.Hash().String(), err)
@@ -685,6 +712,8 @@ func (b *BlockAssembler) processNewBlockAnnouncement(ctx context.Context) {
 
 	b.setBestBlockHeader(bestBlockchainBlockHeader, bestBlockchainBlockHeaderMeta.Height)
 
+	blocktrace.Record(ctx, b.logger, bestBlockchainBlockHeader.Hash(), blocktrace.StageAssemblyUpdated)
+
 	_, height := b.CurrentBlock()
 	prometheusBlockAssemblyCurrentBlockHeight.Set(float64(height))
 
@@ -736,6 +765,11 @@ func (b *BlockAssembler) setBestBlockHeader(bestBlockchainBlockHeader *model.Blo
 		}()
 	}
 
+	b.notifyBestBlockSubscribers(BestBlockInfo{
+		Header: bestBlockchainBlockHeader,
+		Height: height,
+	})
+
 	// Invalidate cache when block height changes
 	b.invalidateMiningCandidateCache()
 
@@ -1080,6 +1114,49 @@ func (b *BlockAssembler) SetStateChangeCh(ch chan BestBlockInfo) {
 	b.stateChangeCh = ch
 }
 
+// SubscribeBestBlock subscribes to changes of the best block. The channel only holds the latest best block, a
+// subscriber that does not keep up misses intermediate blocks. The returned function ends the subscription.
+//
+// Returns:
+//   - <-chan BestBlockInfo: Channel receiving the best block every time it changes
+//   - func(): Function ending the subscription
+func (b *BlockAssembler) SubscribeBestBlock() (<-chan BestBlockInfo, func()) {
+	ch := make(chan BestBlockInfo, 1)
+
+	b.bestBlockSubscribersMu.Lock()
+	if b.bestBlockSubscribers == nil {
+		b.bestBlockSubscribers = make(map[chan BestBlockInfo]struct{})
+	}
+
+	b.bestBlockSubscribers[ch] = struct{}{}
+	b.bestBlockSubscribersMu.Unlock()
+
+	return ch, func() {
+		b.bestBlockSubscribersMu.Lock()
+		delete(b.bestBlockSubscribers, ch)
+		b.bestBlockSubscribersMu.Unlock()
+	}
+}
+
+// notifyBestBlockSubscribers sends the best block to all subscribers without blocking, replacing a best block the
+// subscriber did not receive yet
+func (b *BlockAssembler) notifyBestBlockSubscribers(info BestBlockInfo) {
+	b.bestBlockSubscribersMu.Lock()
+	defer b.bestBlockSubscribersMu.Unlock()
+
+	for ch := range b.bestBlockSubscribers {
+		select {
+		case <-ch:
+		default:
+		}
+
+		select {
+		case ch <- info:
+		default:
+		}
+	}
+}
+
 // CurrentBlock returns the current best block header and height atomically.
 // This is the preferred method to access the best block state as it ensures
 // the header and height are always consistent with each other.
@@ -1103,6 +1180,21 @@ func (b *BlockAssembler) AddTx(node subtree.Node, txInpoints subtree.TxInpoints)
 	b.subtreeProcessor.Add(node, txInpoints)
 }
 
+// AddTxWithPriority adds a transaction to the given priority lane of the block assembler.
+//
+// Parameters:
+//   - node: Transaction node to add
+//   - txInpoints: Transaction inpoints for the node
+//   - priority: Priority lane of the transaction
+func (b *BlockAssembler) AddTxWithPriority(node subtree.Node, txInpoints subtree.TxInpoints, priority subtreeprocessor.TxPriority) {
+	if priority == subtreeprocessor.TxPriorityDefault {
+		b.subtreeProcessor.Add(node, txInpoints)
+		return
+	}
+
+	b.subtreeProcessor.AddWithPriority(node, txInpoints, priority)
+}
+
 // RemoveTx removes a transaction from the block assembler.
 //
 // Parameters:
@@ -1137,6 +1229,233 @@ func (b *BlockAssembler) Reset(fullReset bool) {
 	}()
 }
 
+// maxScopedResetBlocks is the maximum number of blocks in the scope of a scoped reset
+const maxScopedResetBlocks = 1000
+
+// ResetScope selects the blocks of a scoped reset, either a height range of the main chain or a fork.
+type ResetScope struct {
+	// FromHeight is the first height of the main chain range
+	FromHeight uint32
+
+	// ToHeight is the last height of the main chain range
+	ToHeight uint32
+
+	// ForkHash is the tip of a fork, the height range is ignored when it is set
+	ForkHash *chainhash.Hash
+}
+
+// ResetScopeResult reports what a scoped reset repaired.
+type ResetScopeResult struct {
+	// Blocks is the number of blocks in the scope
+	Blocks int
+
+	// MarkedMined is the number of transactions marked as mined on the longest chain
+	MarkedMined int
+
+	// MarkedUnmined is the number of transactions marked as unmined
+	MarkedUnmined int
+}
+
+// ResetScoped repairs the unmined state of the transactions in the scope and then triggers a reset, which reloads
+// the unmined transactions from the UTXO store into a new block template.
+//
+// For a height range of the main chain, the transactions of the blocks are marked as mined on the longest chain, so
+// block assembly stops offering transactions that were already mined. For a fork, the transactions of the fork blocks
+// that are not also in the main chain since the fork point are marked as unmined, so they are mined again. Unlike a
+// full reset, only the transactions of the scope are touched and the reload uses the unmined index of the UTXO store.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - scope: Blocks to repair
+//
+// Returns:
+//   - ResetScopeResult: Number of blocks and repaired transactions
+//   - error: If the scope is invalid or the transactions could not be marked
+func (b *BlockAssembler) ResetScoped(ctx context.Context, scope ResetScope) (ResetScopeResult, error) {
+	var result ResetScopeResult
+
+	if scope.ForkHash != nil {
+		forkBlocks, mainBlocks, err := b.getForkResetBlocks(ctx, scope.ForkHash)
+		if err != nil {
+			return result, err
+		}
+
+		mainTxs, err := b.getBlocksTransactions(ctx, mainBlocks)
+		if err != nil {
+			return result, err
+		}
+
+		forkTxs, err := b.getBlocksTransactions(ctx, forkBlocks)
+		if err != nil {
+			return result, err
+		}
+
+		inMainChain := make(map[chainhash.Hash]struct{}, len(mainTxs))
+		for _, hash := range mainTxs {
+			inMainChain[hash] = struct{}{}
+		}
+
+		unmined := make([]chainhash.Hash, 0, len(forkTxs))
+
+		for _, hash := range forkTxs {
+			if _, ok := inMainChain[hash]; !ok {
+				unmined = append(unmined, hash)
+			}
+		}
+
+		if len(unmined) > 0 {
+			if err = b.utxoStore.MarkTransactionsOnLongestChain(ctx, unmined, false); err != nil {
+				return result, errors.NewProcessingError("[ResetScoped] error marking fork transactions as unmined", err)
+			}
+		}
+
+		result.Blocks = len(forkBlocks)
+		result.MarkedUnmined = len(unmined)
+	} else {
+		blocks, err := b.getRangeResetBlocks(ctx, scope.FromHeight, scope.ToHeight)
+		if err != nil {
+			return result, err
+		}
+
+		mined, err := b.getBlocksTransactions(ctx, blocks)
+		if err != nil {
+			return result, err
+		}
+
+		if len(mined) > 0 {
+			if err = b.utxoStore.MarkTransactionsOnLongestChain(ctx, mined, true); err != nil {
+				return result, errors.NewProcessingError("[ResetScoped] error marking transactions of heights %d-%d as mined", scope.FromHeight, scope.ToHeight, err)
+			}
+		}
+
+		result.Blocks = len(blocks)
+		result.MarkedMined = len(mined)
+	}
+
+	b.logger.Warnf("[BlockAssembler][ResetScoped] repaired %d blocks, marked %d transactions as mined and %d as unmined, resetting",
+		result.Blocks, result.MarkedMined, result.MarkedUnmined)
+
+	b.Reset(false)
+
+	return result, nil
+}
+
+// getRangeResetBlocks returns the main chain blocks of a height range
+func (b *BlockAssembler) getRangeResetBlocks(ctx context.Context, fromHeight, toHeight uint32) ([]*model.Block, error) {
+	if fromHeight > toHeight {
+		return nil, errors.NewInvalidArgumentError("[ResetScoped] from height %d is above to height %d", fromHeight, toHeight)
+	}
+
+	if toHeight-fromHeight >= maxScopedResetBlocks {
+		return nil, errors.NewInvalidArgumentError("[ResetScoped] height range %d-%d is larger than %d blocks, use a full reset", fromHeight, toHeight, maxScopedResetBlocks)
+	}
+
+	_, bestMeta, err := b.blockchainClient.GetBestBlockHeader(ctx)
+	if err != nil {
+		return nil, errors.NewProcessingError("[ResetScoped] error getting best block header", err)
+	}
+
+	if toHeight > bestMeta.Height {
+		return nil, errors.NewInvalidArgumentError("[ResetScoped] to height %d is above the best height %d", toHeight, bestMeta.Height)
+	}
+
+	blocks, err := b.blockchainClient.GetBlocksByHeight(ctx, fromHeight, toHeight)
+	if err != nil {
+		return nil, errors.NewProcessingError("[ResetScoped] error getting blocks of heights %d-%d", fromHeight, toHeight, err)
+	}
+
+	return blocks, nil
+}
+
+// getForkResetBlocks returns the blocks of a fork, newest first, and the main chain blocks after the fork point
+func (b *BlockAssembler) getForkResetBlocks(ctx context.Context, forkHash *chainhash.Hash) ([]*model.Block, []*model.Block, error) {
+	headers, metas, err := b.blockchainClient.GetBlockHeaders(ctx, forkHash, maxScopedResetBlocks+1)
+	if err != nil {
+		return nil, nil, errors.NewProcessingError("[ResetScoped] error getting headers of fork %s", forkHash.String(), err)
+	}
+
+	if len(headers) == 0 {
+		return nil, nil, errors.NewInvalidArgumentError("[ResetScoped] fork %s not found", forkHash.String())
+	}
+
+	var (
+		forkBlocks     []*model.Block
+		ancestorHeight uint32
+		foundAncestor  bool
+	)
+
+	for i, header := range headers {
+		onMainChain, err := b.blockchainClient.CheckBlockIsInCurrentChain(ctx, []uint32{metas[i].ID})
+		if err != nil {
+			return nil, nil, errors.NewProcessingError("[ResetScoped] error checking whether block %s is on the main chain", header.Hash().String(), err)
+		}
+
+		if onMainChain {
+			if i == 0 {
+				return nil, nil, errors.NewInvalidArgumentError("[ResetScoped] block %s is on the main chain, use a height range", forkHash.String())
+			}
+
+			ancestorHeight = metas[i].Height
+			foundAncestor = true
+
+			break
+		}
+
+		block, err := b.blockchainClient.GetBlock(ctx, header.Hash())
+		if err != nil {
+			return nil, nil, errors.NewProcessingError("[ResetScoped] error getting fork block %s", header.Hash().String(), err)
+		}
+
+		forkBlocks = append(forkBlocks, block)
+	}
+
+	if !foundAncestor {
+		return nil, nil, errors.NewInvalidArgumentError("[ResetScoped] fork %s is longer than %d blocks, use a full reset", forkHash.String(), maxScopedResetBlocks)
+	}
+
+	_, bestMeta, err := b.blockchainClient.GetBestBlockHeader(ctx)
+	if err != nil {
+		return nil, nil, errors.NewProcessingError("[ResetScoped] error getting best block header", err)
+	}
+
+	if bestMeta.Height <= ancestorHeight {
+		return forkBlocks, nil, nil
+	}
+
+	if bestMeta.Height-ancestorHeight > maxScopedResetBlocks {
+		return nil, nil, errors.NewInvalidArgumentError("[ResetScoped] fork %s forked more than %d blocks ago, use a full reset", forkHash.String(), maxScopedResetBlocks)
+	}
+
+	mainBlocks, err := b.blockchainClient.GetBlocksByHeight(ctx, ancestorHeight+1, bestMeta.Height)
+	if err != nil {
+		return nil, nil, errors.NewProcessingError("[ResetScoped] error getting main chain blocks after height %d", ancestorHeight, err)
+	}
+
+	return forkBlocks, mainBlocks, nil
+}
+
+// getBlocksTransactions returns the hashes of the transactions of the blocks, without the coinbase placeholders
+func (b *BlockAssembler) getBlocksTransactions(ctx context.Context, blocks []*model.Block) ([]chainhash.Hash, error) {
+	var txs []chainhash.Hash
+
+	for _, block := range blocks {
+		blockSubtrees, err := block.GetSubtrees(ctx, b.logger, b.subtreeStore, b.settings.Block.GetAndValidateSubtreesConcurrency)
+		if err != nil {
+			return nil, errors.NewProcessingError("[ResetScoped] error getting subtrees of block %s", block.Hash().String(), err)
+		}
+
+		for _, st := range blockSubtrees {
+			for _, node := range st.Nodes {
+				if !node.Hash.IsEqual(subtree.CoinbasePlaceholderHash) {
+					txs = append(txs, node.Hash)
+				}
+			}
+		}
+	}
+
+	return txs, nil
+}
+
 // GetMiningCandidate retrieves a candidate block for mining.
 //
 // Parameters:
@@ -1528,6 +1847,12 @@ func (b *BlockAssembler) getMiningCandidate() (*model.MiningCandidate, []*subtre
 
 // handleReorg handles blockchain reorganization.
 //
+// The reorg is coordinated as a single tracked operation, moving through the reorg phases: the blocks to move back and
+// forward are collected, the subtree processor unwinds the moved back blocks, returning their transactions to block
+// assembly, and replays the moved forward blocks, falling back to a reset of block assembly when the reorg is too
+// large, contains an invalid block or fails, and finally the caches holding the subtrees of the old chain are
+// invalidated. The progress is available from ReorgStatus.
+//
 // Parameters:
 //   - ctx: Context for cancellation
 //   - header: New block header
@@ -1535,27 +1860,55 @@ func (b *BlockAssembler) getMiningCandidate() (*model.MiningCandidate, []*subtre
 //
 // Returns:
 //   - error: Any error encountered during reorganization
-func (b *BlockAssembler) handleReorg(ctx context.Context, header *model.BlockHeader, height uint32) error {
+func (b *BlockAssembler) handleReorg(ctx context.Context, header *model.BlockHeader, height uint32) (err error) {
 	startTime := time.Now()
 
 	prometheusBlockAssemblerReorg.Inc()
 
+	currentHeader, currentHeight := b.CurrentBlock()
+
+	var currentHash, newHash *chainhash.Hash
+	if currentHeader != nil {
+		currentHash = currentHeader.Hash()
+	}
+
+	// incomplete headers cannot be hashed, they are rejected when collecting the blocks
+	if header != nil && header.HashPrevBlock != nil && header.HashMerkleRoot != nil {
+		newHash = header.Hash()
+	}
+
+	b.reorgs.begin(currentHash, currentHeight, newHash, height)
+
+	defer func() {
+		// the caches hold the subtrees of the old chain, whatever the outcome of the reorg
+		b.reorgs.setPhase(ReorgPhaseInvalidatingCaches)
+		b.invalidateMiningCandidateCache()
+		b.reorgs.invalidateCaches()
+
+		status := b.reorgs.finish(err)
+		b.logger.Infof("[BlockAssembler] reorg %d from %d: %s to %d: %s %s in %s, moveBackBlocks: %d, moveForwardBlocks: %d, reset: %t",
+			status.ID, status.FromHeight, status.FromHash, status.ToHeight, status.ToHash, status.Phase, status.CompletedAt.Sub(status.StartedAt),
+			status.MoveBackBlocks, status.MoveForwardBlocks, status.FullReset)
+	}()
+
 	moveBackBlocksWithMeta, moveForwardBlocksWithMeta, err := b.getReorgBlocks(ctx, header, height)
 	if err != nil {
 		return errors.NewProcessingError("error getting reorg blocks", err)
 	}
 
-	b.logger.Infof("[BlockAssembler] handling reorg, moveBackBlocks: %d, moveForwardBlocks: %d", len(moveBackBlocksWithMeta), len(moveForwardBlocksWithMeta))
+	b.reorgs.setBlocks(len(moveBackBlocksWithMeta), len(moveForwardBlocksWithMeta))
 
-	_, currentHeight := b.CurrentBlock()
+	b.logger.Infof("[BlockAssembler] handling reorg, moveBackBlocks: %d, moveForwardBlocks: %d", len(moveBackBlocksWithMeta), len(moveForwardBlocksWithMeta))
 
 	if (len(moveBackBlocksWithMeta) >= int(b.settings.ChainCfgParams.CoinbaseMaturity) || len(moveForwardBlocksWithMeta) >= int(b.settings.ChainCfgParams.CoinbaseMaturity)) && currentHeight > 1000 {
 		// large reorg, log it and Reset the block assembler
 		b.logger.Warnf("[BlockAssembler] large reorg detected, resetting block assembly, moveBackBlocks: %d, moveForwardBlocks: %d", len(moveBackBlocksWithMeta), len(moveForwardBlocksWithMeta))
 
+		b.reorgs.setPhase(ReorgPhaseResetting)
+
 		// make sure we wait for the reset to complete
 		if err = b.reset(ctx, false); err != nil {
-			b.logger.Errorf("[BlockAssembler] error resetting after large reorg: %v", err)
+			return errors.NewProcessingError("error resetting block assembly after large reorg", err)
 		}
 
 		// return an error to indicate we reset due to a large reorg
@@ -1580,6 +1933,8 @@ func (b *BlockAssembler) handleReorg(ctx context.Context, header *model.BlockHea
 
 	reset := hasInvalidBlock
 
+	b.reorgs.setPhase(ReorgPhaseReorging)
+
 	// now do the reorg in the subtree processor
 	if err = b.subtreeProcessor.Reorg(moveBackBlocks, moveForwardBlocks); err != nil {
 		b.logger.Warnf("[BlockAssembler] error doing reorg, will reset instead: %v", err)
@@ -1591,6 +1946,8 @@ func (b *BlockAssembler) handleReorg(ctx context.Context, header *model.BlockHea
 		// we have an invalid block in the reorg or reorg failed, we need to reset the block assembly and load the unmined transactions again
 		b.logger.Warnf("[BlockAssembler] reorg contains invalid block, resetting block assembly, moveBackBlocks: %d, moveForwardBlocks: %d", len(moveBackBlocks), len(moveForwardBlocks))
 
+		b.reorgs.setPhase(ReorgPhaseResetting)
+
 		if err = b.reset(ctx, false); err != nil {
 			return errors.NewProcessingError("error resetting block assembly after reorg with invalid block", err)
 		}
@@ -1601,6 +1958,51 @@ func (b *BlockAssembler) handleReorg(ctx context.Context, header *model.BlockHea
 	return nil
 }
 
+// revertBlockUndo reverts the UTXO changes of a block removed by a reorg with the undo record block validation stored
+// when it quick-validated the block: the transactions created for the block are deleted and their spends reversed,
+// except the transactions also mined in the new chain. The blocks are moved back newest first, so the spends of the
+// outputs of a block are reverted before the block itself.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - block: Block removed by the reorg
+//   - moveForwardTxMap: Transactions mined in the blocks of the new chain
+//
+// Returns:
+//   - bool: Whether the block was reverted, false when it has no undo record
+//   - error: Any error encountered while reverting
+func (b *BlockAssembler) revertBlockUndo(ctx context.Context, block *model.Block, moveForwardTxMap map[chainhash.Hash]bool) (bool, error) {
+	undoBytes, err := b.subtreeStore.Get(ctx, block.Hash()[:], fileformat.FileTypeBlockUndo)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, errors.NewStorageError("[revertBlockUndo][%s] error getting the undo record", block.Hash().String(), err)
+	}
+
+	undo, err := utxo.NewBlockUndoFromBytes(undoBytes)
+	if err != nil {
+		return false, err
+	}
+
+	reverted, err := undo.Revert(ctx, b.utxoStore, func(txHash chainhash.Hash) bool {
+		return moveForwardTxMap[txHash]
+	})
+	if err != nil {
+		return false, errors.NewProcessingError("[revertBlockUndo][%s] error reverting the UTXOs of the block", block.Hash().String(), err)
+	}
+
+	b.logger.Infof("[revertBlockUndo][%s] reverted %d transactions of the block", block.Hash().String(), reverted)
+
+	// the block can come back in a later reorg, it is then validated again, storing a new undo record
+	if err = b.subtreeStore.Del(ctx, block.Hash()[:], fileformat.FileTypeBlockUndo); err != nil && !errors.Is(err, errors.ErrNotFound) {
+		b.logger.Warnf("[revertBlockUndo][%s] error deleting the undo record: %v", block.Hash().String(), err)
+	}
+
+	return true, nil
+}
+
 // getReorgBlocks retrieves blocks involved in reorganization.
 //
 // Parameters:
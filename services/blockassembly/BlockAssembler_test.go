@@ -20,6 +20,7 @@ import (
 	"github.com/bsv-blockchain/go-wire"
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/pkg/fileformat"
 	"github.com/bsv-blockchain/teranode/services/blockassembly/mining"
 	"github.com/bsv-blockchain/teranode/services/blockassembly/subtreeprocessor"
 	"github.com/bsv-blockchain/teranode/services/blockchain"
@@ -1913,6 +1914,39 @@ func TestBlockAssembly_CurrentBlock(t *testing.T) {
 	})
 }
 
+func TestBlockAssembler_SubscribeBestBlock(t *testing.T) {
+	initPrometheusMetrics()
+	testItems := setupBlockAssemblyTest(t)
+	require.NotNil(t, testItems)
+
+	ba := testItems.blockAssembler
+	currentHeader, _ := ba.CurrentBlock()
+
+	ch, unsubscribe := ba.SubscribeBestBlock()
+
+	// a subscriber that does not keep up only receives the latest best block
+	ba.setBestBlockHeader(currentHeader, 1)
+	ba.setBestBlockHeader(currentHeader, 2)
+
+	select {
+	case bestBlock := <-ch:
+		assert.Equal(t, uint32(2), bestBlock.Height)
+		assert.Equal(t, currentHeader.Hash(), bestBlock.Header.Hash())
+	default:
+		t.Fatal("expected a best block notification")
+	}
+
+	unsubscribe()
+
+	ba.setBestBlockHeader(currentHeader, 3)
+
+	select {
+	case <-ch:
+		t.Fatal("unexpected best block notification after unsubscribing")
+	default:
+	}
+}
+
 func TestBlockAssembly_RemoveTx(t *testing.T) {
 	t.Run("RemoveTx removes transaction from subtree processor", func(t *testing.T) {
 		initPrometheusMetrics()
@@ -2720,3 +2754,100 @@ func TestGetLastPersistedHeight(t *testing.T) {
 		assert.Equal(t, uint32(100), height)
 	})
 }
+
+func TestBlockAssembler_ResetScoped(t *testing.T) {
+	initPrometheusMetrics()
+
+	ctx := context.Background()
+
+	t.Run("invalid height ranges", func(t *testing.T) {
+		ba := setupBlockAssemblyTest(t).blockAssembler
+
+		_, err := ba.ResetScoped(ctx, ResetScope{FromHeight: 10, ToHeight: 5})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidArgument))
+
+		_, err = ba.ResetScoped(ctx, ResetScope{FromHeight: 0, ToHeight: maxScopedResetBlocks})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidArgument))
+
+		_, err = ba.ResetScoped(ctx, ResetScope{FromHeight: 0, ToHeight: 5})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidArgument), "range above the best height")
+	})
+
+	t.Run("height range of the main chain", func(t *testing.T) {
+		testItems := setupBlockAssemblyTest(t)
+		require.NoError(t, testItems.addBlock(blockHeader1))
+
+		result, err := testItems.blockAssembler.ResetScoped(ctx, ResetScope{FromHeight: 0, ToHeight: 1})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, result.Blocks)
+		assert.Equal(t, 0, result.MarkedMined)
+		assert.Equal(t, 0, result.MarkedUnmined)
+	})
+
+	t.Run("fork on the main chain", func(t *testing.T) {
+		ba := setupBlockAssemblyTest(t).blockAssembler
+
+		_, err := ba.ResetScoped(ctx, ResetScope{ForkHash: ba.settings.ChainCfgParams.GenesisHash})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidArgument))
+	})
+
+	t.Run("unknown fork", func(t *testing.T) {
+		ba := setupBlockAssemblyTest(t).blockAssembler
+
+		_, err := ba.ResetScoped(ctx, ResetScope{ForkHash: &chainhash.Hash{1}})
+		require.Error(t, err)
+	})
+}
+
+func TestBlockAssembler_RevertBlockUndo(t *testing.T) {
+	initPrometheusMetrics()
+
+	ctx := context.Background()
+	block := &model.Block{Header: blockHeader1}
+
+	t.Run("block without undo record", func(t *testing.T) {
+		reverted, err := setupBlockAssemblyTest(t).blockAssembler.revertBlockUndo(ctx, block, nil)
+		require.NoError(t, err)
+		assert.False(t, reverted)
+	})
+
+	t.Run("transactions not in the new chain are reverted", func(t *testing.T) {
+		testItems := setupBlockAssemblyTest(t)
+
+		blockTx := utxoStore.BeginBlockTransaction(testItems.utxoStore)
+
+		for _, tx := range []*bt.Tx{tx1, tx2} {
+			_, err := blockTx.Create(ctx, tx, 1)
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, blockTx.Commit(ctx))
+
+		undo, err := blockTx.Undo()
+		require.NoError(t, err)
+
+		undoBytes, err := undo.Bytes()
+		require.NoError(t, err)
+		require.NoError(t, testItems.blobStore.Set(ctx, block.Hash()[:], fileformat.FileTypeBlockUndo, undoBytes))
+
+		// tx2 is also mined in the new chain
+		reverted, err := testItems.blockAssembler.revertBlockUndo(ctx, block, map[chainhash.Hash]bool{*hash2: true})
+		require.NoError(t, err)
+		assert.True(t, reverted)
+
+		_, err = testItems.utxoStore.Get(ctx, hash1)
+		assert.True(t, errors.Is(err, errors.ErrTxNotFound))
+
+		_, err = testItems.utxoStore.Get(ctx, hash2)
+		require.NoError(t, err)
+
+		exists, err := testItems.blobStore.Exists(ctx, block.Hash()[:], fileformat.FileTypeBlockUndo)
+		require.NoError(t, err)
+		assert.False(t, exists, "the undo record is removed with the block")
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockassembly/blockassembly_api"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/subtreeprocessor"
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
@@ -87,6 +88,7 @@ func NewClient(ctx context.Context, logger ulogger.Logger, tSettings *settings.S
 		&util.ConnectionOptions{
 			MaxRetries:   maxRetries,
 			RetryBackoff: retryBackoff,
+			APIKey:       tSettings.GRPCAdminAPIKey,
 		}, tSettings,
 	)
 	if err != nil {
@@ -133,6 +135,7 @@ func NewClientWithAddress(ctx context.Context, logger ulogger.Logger, tSettings
 	baConn, err := util.GetGRPCClient(ctx, blockAssemblyGrpcAddress, &util.ConnectionOptions{
 		MaxRetries:   tSettings.GRPCMaxRetries,
 		RetryBackoff: tSettings.GRPCRetryBackoff,
+		APIKey:       tSettings.GRPCAdminAPIKey,
 	}, tSettings)
 	if err != nil {
 		return nil, errors.NewServiceError("failed to connect to block assembly", err)
@@ -201,6 +204,26 @@ func (s *Client) Health(ctx context.Context, checkLiveness bool) (int, string, e
 	return http.StatusOK, "OK", nil
 }
 
+// StoreOption configures how a transaction is stored in block assembly
+type StoreOption func(*storeOptions)
+
+type storeOptions struct {
+	priority subtreeprocessor.TxPriority
+}
+
+// WithTxPriority sets the priority lane of the transaction in the block candidate.
+//
+// Parameters:
+//   - priority: Priority lane of the transaction
+//
+// Returns:
+//   - StoreOption: Option setting the priority lane
+func WithTxPriority(priority subtreeprocessor.TxPriority) StoreOption {
+	return func(o *storeOptions) {
+		o.priority = priority
+	}
+}
+
 // Store stores a transaction in block assembly.
 //
 // Parameters:
@@ -208,21 +231,28 @@ func (s *Client) Health(ctx context.Context, checkLiveness bool) (int, string, e
 //   - hash: Transaction hash
 //   - fee: Transaction fee in satoshis
 //   - size: Transaction size in bytes
+//   - opts: Options for storing the transaction, such as its priority lane
 //
 // Returns:
 //   - bool: True if storage was successful
 //   - error: Any error encountered during storage
-func (s *Client) Store(ctx context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints) (bool, error) {
+func (s *Client) Store(ctx context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints, opts ...StoreOption) (bool, error) {
 	txInpointsBytes, err := txInpoints.Serialize()
 	if err != nil {
 		return false, err
 	}
 
+	options := &storeOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	req := &blockassembly_api.AddTxRequest{
 		Txid:       hash[:],
 		Fee:        fee,
 		Size:       size,
 		TxInpoints: txInpointsBytes,
+		Priority:   uint32(options.priority),
 	}
 
 	if s.batchSize == 0 {
@@ -293,6 +323,27 @@ func (s *Client) GetMiningCandidate(ctx context.Context, includeSubtreeHashes ..
 	return res, nil
 }
 
+// GetMiningCandidateDiff retrieves a candidate block for mining with only the subtrees added and removed since the
+// candidate with the given id, or all subtrees when the response is full.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - candidateID: Id of the mining candidate the caller has, nil for a full candidate
+//
+// Returns:
+//   - *blockassembly_api.GetMiningCandidateDiffResponse: Mining candidate and the subtree changes
+//   - error: Any error encountered during retrieval
+func (s *Client) GetMiningCandidateDiff(ctx context.Context, candidateID []byte) (*blockassembly_api.GetMiningCandidateDiffResponse, error) {
+	res, err := s.client.GetMiningCandidateDiff(ctx, &blockassembly_api.GetMiningCandidateDiffRequest{
+		CandidateId: candidateID,
+	})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	return res, nil
+}
+
 // GetCurrentDifficulty retrieves the current mining difficulty.
 //
 // Parameters:
@@ -422,6 +473,38 @@ func (s *Client) ResetBlockAssemblyFully(ctx context.Context) error {
 	return unwrappedErr
 }
 
+// ResetBlockAssemblyScoped repairs the unmined state of the transactions in a height range of the main chain, or in
+// a fork, and then resets the block assembly state.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - scope: Height range or fork tip to reset
+//
+// Returns:
+//   - ResetScopeResult: Number of blocks and repaired transactions
+//   - error: Any error encountered during reset
+func (s *Client) ResetBlockAssemblyScoped(ctx context.Context, scope ResetScope) (ResetScopeResult, error) {
+	req := &blockassembly_api.ResetBlockAssemblyScopedRequest{
+		FromHeight: scope.FromHeight,
+		ToHeight:   scope.ToHeight,
+	}
+
+	if scope.ForkHash != nil {
+		req.ForkHash = scope.ForkHash.CloneBytes()
+	}
+
+	resp, err := s.client.ResetBlockAssemblyScoped(ctx, req)
+	if err != nil {
+		return ResetScopeResult{}, errors.UnwrapGRPC(err)
+	}
+
+	return ResetScopeResult{
+		Blocks:        int(resp.GetBlocks()),
+		MarkedMined:   int(resp.GetMarkedMined()),   //nolint:gosec // counts fit in an int
+		MarkedUnmined: int(resp.GetMarkedUnmined()), //nolint:gosec // counts fit in an int
+	}, nil
+}
+
 // GetBlockAssemblyState retrieves the current state of block assembly.
 //
 // Parameters:
@@ -439,6 +522,66 @@ func (s *Client) GetBlockAssemblyState(ctx context.Context) (*blockassembly_api.
 	return state, nil
 }
 
+// GetReorgStatus retrieves the progress of the reorg being coordinated by block assembly, if any, and the outcome
+// of the last completed reorg.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//
+// Returns:
+//   - *blockassembly_api.GetReorgStatusResponse: The reorg in progress and the last completed reorg
+//   - error: Any error encountered during retrieval
+func (s *Client) GetReorgStatus(ctx context.Context) (*blockassembly_api.GetReorgStatusResponse, error) {
+	status, err := s.client.GetReorgStatus(ctx, &blockassembly_api.EmptyMessage{})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	return status, nil
+}
+
+// SubscribeState subscribes to the chaintip of block assembly. The current chaintip is received first, followed
+// by a notification every time it changes. The channel is closed when the stream ends, either because the context
+// is done or because of an error.
+//
+// Parameters:
+//   - ctx: Context for cancellation, ends the subscription
+//
+// Returns:
+//   - <-chan *blockassembly_api.StateNotification: Channel receiving the chaintip notifications
+//   - error: Any error encountered opening the stream
+func (s *Client) SubscribeState(ctx context.Context) (<-chan *blockassembly_api.StateNotification, error) {
+	stream, err := s.client.SubscribeState(ctx, &blockassembly_api.EmptyMessage{})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	ch := make(chan *blockassembly_api.StateNotification)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			notification, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					s.logger.Debugf("[BlockAssembly] state subscription ended: %v", errors.UnwrapGRPC(err))
+				}
+
+				return
+			}
+
+			select {
+			case ch <- notification:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
 // BlockAssemblyAPIClient returns the underlying gRPC client for block assembly API.
 //
 // Returns:
@@ -485,3 +628,39 @@ func (s *Client) GetTransactionHashes(ctx context.Context) ([]string, error) {
 
 	return resp.Txs, nil
 }
+
+// GetCoinbaseTemplate retrieves the coinbase template used for the coinbase transactions of block assembly.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//
+// Returns:
+//   - *model.CoinbaseTemplate: Coinbase template, nil when the coinbase pays to the miner wallet keys
+//   - error: Any error encountered during retrieval
+func (s *Client) GetCoinbaseTemplate(ctx context.Context) (*model.CoinbaseTemplate, error) {
+	resp, err := s.client.GetCoinbaseTemplate(ctx, &blockassembly_api.EmptyMessage{})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	return coinbaseTemplateFromAPI(resp), nil
+}
+
+// SetCoinbaseTemplate sets the coinbase template used for the coinbase transactions of block assembly. The call
+// is authenticated with the admin API key of the settings when one is configured.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - template: Coinbase template, nil to pay to the miner wallet keys
+//
+// Returns:
+//   - *model.CoinbaseTemplate: Coinbase template now in use
+//   - error: Any error encountered, e.g. an invalid template
+func (s *Client) SetCoinbaseTemplate(ctx context.Context, template *model.CoinbaseTemplate) (*model.CoinbaseTemplate, error) {
+	resp, err := s.client.SetCoinbaseTemplate(ctx, coinbaseTemplateToAPI(template))
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	return coinbaseTemplateFromAPI(resp), nil
+}
@@ -2,6 +2,7 @@ package blockassembly
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"testing"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -572,6 +574,61 @@ func TestClient_GetBlockAssemblyState(t *testing.T) {
 	})
 }
 
+// stateNotificationStream is a state subscription stream returning the given notifications, followed by the error
+type stateNotificationStream struct {
+	grpc.ClientStream
+	notifications []*blockassembly_api.StateNotification
+	err           error
+}
+
+func (s *stateNotificationStream) Recv() (*blockassembly_api.StateNotification, error) {
+	if len(s.notifications) == 0 {
+		return nil, s.err
+	}
+
+	notification := s.notifications[0]
+	s.notifications = s.notifications[1:]
+
+	return notification, nil
+}
+
+func TestClient_SubscribeState(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &mockBlockAssemblyAPIClient{}
+	client := createTestClient(mockClient, 0)
+
+	t.Run("successful", func(t *testing.T) {
+		mockClient.ExpectedCalls = nil
+		mockClient.On("SubscribeState", ctx, &blockassembly_api.EmptyMessage{}, mock.Anything).Return(&stateNotificationStream{
+			notifications: []*blockassembly_api.StateNotification{{CurrentHeight: 1}, {CurrentHeight: 2}},
+			err:           io.EOF,
+		}, nil)
+
+		ch, err := client.SubscribeState(ctx)
+		require.NoError(t, err)
+
+		heights := make([]uint32, 0, 2)
+		for notification := range ch {
+			heights = append(heights, notification.CurrentHeight)
+		}
+
+		// the channel is closed when the stream ends
+		assert.Equal(t, []uint32{1, 2}, heights)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("grpc error", func(t *testing.T) {
+		mockClient.ExpectedCalls = nil
+		mockClient.On("SubscribeState", ctx, &blockassembly_api.EmptyMessage{}, mock.Anything).Return(
+			nil, status.Error(codes.Unimplemented, "method SubscribeState not implemented"))
+
+		ch, err := client.SubscribeState(ctx)
+		assert.Nil(t, ch)
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
 func TestClient_BlockAssemblyAPIClient(t *testing.T) {
 	mockClient := &mockBlockAssemblyAPIClient{}
 	client := createTestClient(mockClient, 0)
@@ -751,3 +808,112 @@ func TestNewClientWithAddress_ConfigErrors(t *testing.T) {
 	}
 	// Note: Connection might not fail immediately in test environment, so we don't assert.Error
 }
+
+func TestClient_GetMiningCandidateDiff(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &mockBlockAssemblyAPIClient{}
+	client := createTestClient(mockClient, 0)
+
+	candidateID := []byte("base-id")
+
+	t.Run("successful", func(t *testing.T) {
+		expected := &blockassembly_api.GetMiningCandidateDiffResponse{
+			Candidate:          &model.MiningCandidate{Id: []byte("test-id")},
+			BaseCandidateId:    candidateID,
+			AddedSubtreeHashes: [][]byte{[]byte("subtree")},
+		}
+
+		mockClient.ExpectedCalls = nil
+		mockClient.On("GetMiningCandidateDiff", ctx, &blockassembly_api.GetMiningCandidateDiffRequest{CandidateId: candidateID}, mock.Anything).Return(expected, nil)
+
+		resp, err := client.GetMiningCandidateDiff(ctx, candidateID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("grpc error", func(t *testing.T) {
+		mockClient.ExpectedCalls = nil
+		mockClient.On("GetMiningCandidateDiff", ctx, &blockassembly_api.GetMiningCandidateDiffRequest{CandidateId: candidateID}, mock.Anything).Return(
+			nil, status.Error(codes.Internal, "candidate failed"))
+
+		_, err := client.GetMiningCandidateDiff(ctx, candidateID)
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestClient_CoinbaseTemplate(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &mockBlockAssemblyAPIClient{}
+	client := createTestClient(mockClient, 0)
+
+	template := &model.CoinbaseTemplate{
+		Outputs:  []model.CoinbaseOutputTemplate{{Address: "addr1", BasisPoints: 10000}},
+		MinerTag: "/miner/",
+	}
+
+	apiTemplate := &blockassembly_api.CoinbaseTemplate{
+		Outputs:  []*blockassembly_api.CoinbaseOutputTemplate{{Address: "addr1", BasisPoints: 10000}},
+		MinerTag: "/miner/",
+	}
+
+	t.Run("get", func(t *testing.T) {
+		mockClient.ExpectedCalls = nil
+		mockClient.On("GetCoinbaseTemplate", ctx, &blockassembly_api.EmptyMessage{}, mock.Anything).Return(apiTemplate, nil)
+
+		resp, err := client.GetCoinbaseTemplate(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, template, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		mockClient.ExpectedCalls = nil
+		mockClient.On("SetCoinbaseTemplate", ctx, apiTemplate, mock.Anything).Return(apiTemplate, nil)
+
+		resp, err := client.SetCoinbaseTemplate(ctx, template)
+		require.NoError(t, err)
+		assert.Equal(t, template, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("set invalid template", func(t *testing.T) {
+		mockClient.ExpectedCalls = nil
+		mockClient.On("SetCoinbaseTemplate", ctx, apiTemplate, mock.Anything).Return(
+			nil, status.Error(codes.InvalidArgument, "invalid coinbase template"))
+
+		_, err := client.SetCoinbaseTemplate(ctx, template)
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestClient_ResetBlockAssemblyScoped(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &mockBlockAssemblyAPIClient{}
+	client := createTestClient(mockClient, 0)
+
+	forkHash := chainhash.Hash{1}
+
+	t.Run("successful", func(t *testing.T) {
+		mockClient.ExpectedCalls = nil
+		mockClient.On("ResetBlockAssemblyScoped", ctx, &blockassembly_api.ResetBlockAssemblyScopedRequest{ForkHash: forkHash.CloneBytes()}, mock.Anything).Return(
+			&blockassembly_api.ResetBlockAssemblyScopedResponse{Blocks: 2, MarkedUnmined: 10}, nil)
+
+		result, err := client.ResetBlockAssemblyScoped(ctx, ResetScope{ForkHash: &forkHash})
+		require.NoError(t, err)
+		assert.Equal(t, ResetScopeResult{Blocks: 2, MarkedUnmined: 10}, result)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("grpc error", func(t *testing.T) {
+		mockClient.ExpectedCalls = nil
+		mockClient.On("ResetBlockAssemblyScoped", ctx, &blockassembly_api.ResetBlockAssemblyScopedRequest{FromHeight: 5, ToHeight: 10}, mock.Anything).Return(
+			nil, status.Error(codes.Internal, "reset failed"))
+
+		_, err := client.ResetBlockAssemblyScoped(ctx, ResetScope{FromHeight: 5, ToHeight: 10})
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
@@ -43,11 +43,12 @@ type ClientI interface {
 	//   - hash: Transaction hash
 	//   - fee: Transaction fee in satoshis
 	//   - size: Transaction size in bytes
+	//   - opts: Options for storing the transaction, such as its priority lane
 	//
 	// Returns:
 	//   - bool: True if storage was successful
 	//   - error: Any error encountered during storage
-	Store(ctx context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints) (bool, error)
+	Store(ctx context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints, opts ...StoreOption) (bool, error)
 
 	// RemoveTx removes a transaction from block assembly.
 	//
@@ -69,6 +70,18 @@ type ClientI interface {
 	//   - error: Any error encountered during retrieval
 	GetMiningCandidate(ctx context.Context, includeSubtreeHashes ...bool) (*model.MiningCandidate, error)
 
+	// GetMiningCandidateDiff retrieves a candidate block for mining with only the subtrees added and removed since
+	// the candidate with the given id, or all subtrees when the response is full.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation
+	//   - candidateID: Id of the mining candidate the caller has, nil for a full candidate
+	//
+	// Returns:
+	//   - *blockassembly_api.GetMiningCandidateDiffResponse: Mining candidate and the subtree changes
+	//   - error: Any error encountered during retrieval
+	GetMiningCandidateDiff(ctx context.Context, candidateID []byte) (*blockassembly_api.GetMiningCandidateDiffResponse, error)
+
 	// GetCurrentDifficulty retrieves the current mining difficulty.
 	//
 	// Parameters:
@@ -117,6 +130,18 @@ type ClientI interface {
 	//   - error: Any error encountered during reset
 	ResetBlockAssemblyFully(ctx context.Context) error
 
+	// ResetBlockAssemblyScoped repairs the unmined state of the transactions in a height range of the main chain,
+	// or in a fork, and then resets the block assembly state.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation
+	//   - scope: Height range or fork tip to reset
+	//
+	// Returns:
+	//   - ResetScopeResult: Number of blocks and repaired transactions
+	//   - error: Any error encountered during reset
+	ResetBlockAssemblyScoped(ctx context.Context, scope ResetScope) (ResetScopeResult, error)
+
 	// GetBlockAssemblyState retrieves the current state of block assembly.
 	//
 	// Parameters:
@@ -127,6 +152,17 @@ type ClientI interface {
 	//   - error: Any error encountered during retrieval
 	GetBlockAssemblyState(ctx context.Context) (*blockassembly_api.StateMessage, error)
 
+	// GetReorgStatus retrieves the progress of the reorg being coordinated by block assembly, if any, and the
+	// outcome of the last completed reorg.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation
+	//
+	// Returns:
+	//   - *blockassembly_api.GetReorgStatusResponse: The reorg in progress and the last completed reorg
+	//   - error: Any error encountered during retrieval
+	GetReorgStatus(ctx context.Context) (*blockassembly_api.GetReorgStatusResponse, error)
+
 	// GetBlockAssemblyBlockCandidate retrieves the block candidate for block assembly.
 	//
 	// Parameters:
@@ -146,6 +182,41 @@ type ClientI interface {
 	//   - []*chainhash.Hash: List of transaction hashes
 	//   - error: Any error encountered during retrieval
 	GetTransactionHashes(ctx context.Context) ([]string, error)
+
+	// GetCoinbaseTemplate retrieves the coinbase template used for the coinbase transactions of block assembly.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation
+	//
+	// Returns:
+	//   - *model.CoinbaseTemplate: Coinbase template, nil when the coinbase pays to the miner wallet keys
+	//   - error: Any error encountered during retrieval
+	GetCoinbaseTemplate(ctx context.Context) (*model.CoinbaseTemplate, error)
+
+	// SetCoinbaseTemplate sets the coinbase template used for the coinbase transactions of block assembly.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation
+	//   - template: Coinbase template, nil to pay to the miner wallet keys
+	//
+	// Returns:
+	//   - *model.CoinbaseTemplate: Coinbase template now in use
+	//   - error: Any error encountered, e.g. an invalid template
+	SetCoinbaseTemplate(ctx context.Context, template *model.CoinbaseTemplate) (*model.CoinbaseTemplate, error)
+}
+
+// StateSubscriber is implemented by block assembly clients that can stream the chaintip of block assembly, as an
+// alternative to polling GetBlockAssemblyState.
+type StateSubscriber interface {
+	// SubscribeState subscribes to the chaintip of block assembly.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation, ends the subscription
+	//
+	// Returns:
+	//   - <-chan *blockassembly_api.StateNotification: Channel receiving the chaintip notifications, closed when the stream ends or the context is done
+	//   - error: Any error encountered opening the stream
+	SubscribeState(ctx context.Context) (<-chan *blockassembly_api.StateNotification, error)
 }
 
 // Store defines the interface for block assembly storage operations.
@@ -162,11 +233,12 @@ type Store interface {
 	//   - hash: Transaction hash
 	//   - fee: Transaction fee in satoshis
 	//   - size: Transaction size in bytes
+	//   - opts: Options for storing the transaction, such as its priority lane
 	//
 	// Returns:
 	//   - bool: True if storage was successful
 	//   - error: Any error encountered during storage
-	Store(ctx context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints) (bool, error)
+	Store(ctx context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints, opts ...StoreOption) (bool, error)
 
 	// RemoveTx removes a transaction from storage.
 	//
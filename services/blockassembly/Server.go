@@ -15,6 +15,7 @@
 package blockassembly
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"sync"
@@ -37,6 +38,7 @@ import (
 	utxostore "github.com/bsv-blockchain/teranode/stores/utxo"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
 	"github.com/bsv-blockchain/teranode/util/health"
 	"github.com/bsv-blockchain/teranode/util/retry"
 	"github.com/bsv-blockchain/teranode/util/tracing"
@@ -46,6 +48,7 @@ import (
 	"go.uber.org/atomic"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -107,6 +110,10 @@ type BlockAssembly struct {
 
 	// skipWaitForPendingBlocks stores the flag value for tests
 	skipWaitForPendingBlocks bool
+
+	// coinbaseTemplate is the template of the coinbase transactions created by block assembly,
+	// nil when the coinbase pays to the addresses of the miner wallet keys
+	coinbaseTemplate atomic.Pointer[model.CoinbaseTemplate]
 }
 
 // subtreeRetrySend encapsulates the data needed for retrying subtree storage operations
@@ -235,6 +242,7 @@ func (ba *BlockAssembly) HealthGRPC(ctx context.Context, _ *blockassembly_api.Em
 		Ok:        status == http.StatusOK,
 		Details:   details,
 		Timestamp: timestamppb.Now(),
+		BuildInfo: buildinfo.Get(ba.settings).JSON(),
 	}, errors.WrapGRPC(err)
 }
 
@@ -246,6 +254,13 @@ func (ba *BlockAssembly) HealthGRPC(ctx context.Context, _ *blockassembly_api.Em
 // Returns:
 //   - error: Any error encountered during initialization
 func (ba *BlockAssembly) Init(ctx context.Context) (err error) {
+	coinbaseTemplate, err := coinbaseTemplateFromSettings(ba.settings)
+	if err != nil {
+		return err
+	}
+
+	ba.coinbaseTemplate.Store(coinbaseTemplate)
+
 	// this is passed into the block assembler and subtree processor where new subtrees are created
 	newSubtreeChan := make(chan subtreeprocessor.NewSubtreeRequest, ba.settings.BlockAssembly.NewSubtreeChanBuffer)
 
@@ -263,6 +278,9 @@ func (ba *BlockAssembly) Init(ctx context.Context) (err error) {
 		ba.blockAssembler.SetSkipWaitForPendingBlocks(true)
 	}
 
+	// the mining jobs hold the subtrees of the chain they were created on, drop them when the chain switched
+	ba.blockAssembler.reorgs.addCacheInvalidator(ba.jobStore.DeleteAll)
+
 	// start background processors
 	go ba.runSubtreeRetryProcessor(ctx, subtreeRetryChan)
 	go ba.runNewSubtreeListener(ctx, newSubtreeChan, subtreeRetryChan)
@@ -660,6 +678,9 @@ func (ba *BlockAssembly) Start(ctx context.Context, readyCh chan<- struct{}) (er
 	// Create errgroup for coordinating goroutines
 	g, gCtx := errgroup.WithContext(ctx)
 
+	// changing the coinbase template is protected by the admin API key or a client certificate when configured
+	authOptions := util.NewAuthOptions(ba.settings.GRPCAdminAPIKey, ba.settings.GRPCAuthClientCert, setCoinbaseTemplateMethod)
+
 	// Start gRPC server in errgroup to properly handle shutdown
 	g.Go(func() error {
 		// StartGRPCServer blocks until the server shuts down or encounters an error
@@ -672,7 +693,7 @@ func (ba *BlockAssembly) Start(ctx context.Context, readyCh chan<- struct{}) (er
 			// Signal that the service is ready to accept requests
 			// This is called once the gRPC server is successfully listening
 			grpcReady <- struct{}{}
-		}, nil)
+		}, authOptions)
 	})
 
 	<-grpcReady
@@ -754,11 +775,11 @@ func (ba *BlockAssembly) AddTx(ctx context.Context, req *blockassembly_api.AddTx
 	}
 
 	if !ba.settings.BlockAssembly.Disabled {
-		ba.blockAssembler.AddTx(subtreepkg.Node{
+		ba.blockAssembler.AddTxWithPriority(subtreepkg.Node{
 			Hash:        chainhash.Hash(req.Txid),
 			Fee:         req.Fee,
 			SizeInBytes: req.Size,
-		}, txInpoints)
+		}, txInpoints, subtreeprocessor.TxPriority(req.Priority))
 	}
 
 	return &blockassembly_api.AddTxResponse{
@@ -849,11 +870,11 @@ func (ba *BlockAssembly) AddTxBatch(ctx context.Context, batch *blockassembly_ap
 
 		// create the subtree node
 		if !ba.settings.BlockAssembly.Disabled {
-			ba.blockAssembler.AddTx(subtreepkg.Node{
+			ba.blockAssembler.AddTxWithPriority(subtreepkg.Node{
 				Hash:        chainhash.Hash(req.Txid),
 				Fee:         req.Fee,
 				SizeInBytes: req.Size,
-			}, txInpoints)
+			}, txInpoints, subtreeprocessor.TxPriority(req.Priority))
 
 			prometheusBlockAssemblyAddTx.Observe(float64(time.Since(startTxTime).Microseconds()) / 1_000_000)
 		}
@@ -891,32 +912,13 @@ func (ba *BlockAssembly) GetMiningCandidate(ctx context.Context, req *blockassem
 	)
 	defer endSpan()
 
-	isRunning, err := ba.blockchainClient.IsFSMCurrentState(ctx, blockchain.FSMStateRUNNING)
-	if err != nil {
-		return nil, errors.WrapGRPC(err)
-	}
-
-	if !isRunning {
-		return nil, errors.WrapGRPC(errors.NewStateError("cannot get mining candidate when FSM is not in RUNNING state"))
-	}
-
 	includeSubtreeHashes := req.IncludeSubtrees
 
-	miningCandidate, subtrees, err := ba.blockAssembler.GetMiningCandidate(ctx)
+	miningCandidate, subtrees, err := ba.newMiningJob(ctx)
 	if err != nil {
 		return nil, errors.WrapGRPC(err)
 	}
 
-	ba.logger.Debugf("in GetMiningCandidate: miningCandidate: %+v", miningCandidate.Stringify(true))
-
-	id, _ := chainhash.NewHash(miningCandidate.Id)
-
-	ba.jobStore.Set(*id, &subtreeprocessor.Job{
-		ID:              id,
-		Subtrees:        subtrees,
-		MiningCandidate: miningCandidate,
-	}, jobTTL) // create a new job with a TTL, will be cleaned up automatically
-
 	if includeSubtreeHashes {
 		miningCandidate.SubtreeHashes = make([][]byte, len(subtrees))
 		for i, subtree := range subtrees {
@@ -934,6 +936,90 @@ func (ba *BlockAssembly) GetMiningCandidate(ctx context.Context, req *blockassem
 	return miningCandidate, nil
 }
 
+// GetMiningCandidateDiff retrieves a candidate block for mining with only the subtrees added and removed since the
+// candidate of the request. When that candidate is unknown, e.g. because its job expired or was dropped on a reorg,
+// or the subtrees can not be expressed as a diff of it, all subtrees are returned as added and full is set.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - req: Request with the id of the candidate the client has
+//
+// Returns:
+//   - *blockassembly_api.GetMiningCandidateDiffResponse: Mining candidate and the subtree changes
+//   - error: Any error encountered during retrieval
+func (ba *BlockAssembly) GetMiningCandidateDiff(ctx context.Context, req *blockassembly_api.GetMiningCandidateDiffRequest) (*blockassembly_api.GetMiningCandidateDiffResponse, error) {
+	ctx, _, endSpan := tracing.Tracer("blockassembly").Start(ctx, "GetMiningCandidateDiff",
+		tracing.WithParentStat(ba.stats),
+		tracing.WithDebugLogMessage(ba.logger, "[GetMiningCandidateDiff] called for base candidate %x", req.GetCandidateId()),
+	)
+	defer endSpan()
+
+	miningCandidate, subtrees, err := ba.newMiningJob(ctx)
+	if err != nil {
+		return nil, errors.WrapGRPC(err)
+	}
+
+	// the candidate may be shared with the candidate cache, it is returned without the subtree hashes a
+	// GetMiningCandidate call may have set on it
+	candidate := proto.Clone(miningCandidate).(*model.MiningCandidate)
+	candidate.SubtreeHashes = nil
+
+	// a candidate on another parent shares no subtrees with this candidate
+	diff := fullMiningCandidateSubtreeDiff(subtrees)
+
+	if baseID, err := chainhash.NewHash(req.GetCandidateId()); err == nil {
+		if item := ba.jobStore.Get(*baseID); item != nil && bytes.Equal(item.Value().MiningCandidate.PreviousHash, candidate.PreviousHash) {
+			diff = diffMiningCandidateSubtrees(item.Value().Subtrees, subtrees)
+		}
+	}
+
+	resp := &blockassembly_api.GetMiningCandidateDiffResponse{
+		Candidate:            candidate,
+		Full:                 diff.full,
+		AddedSubtreeHashes:   diff.added,
+		RemovedSubtreeHashes: diff.removed,
+	}
+
+	if !resp.Full {
+		resp.BaseCandidateId = req.GetCandidateId()
+	}
+
+	ba.logger.Debugf("[GetMiningCandidateDiff][%s] returning mining candidate with %d added and %d removed subtrees, full %t",
+		utils.ReverseAndHexEncodeSlice(candidate.Id), len(resp.AddedSubtreeHashes), len(resp.RemovedSubtreeHashes), resp.Full)
+
+	return resp, nil
+}
+
+// newMiningJob gets a mining candidate from the block assembler and stores it as a job, so a solution for it can
+// be submitted and later candidates can be diffed against it.
+func (ba *BlockAssembly) newMiningJob(ctx context.Context) (*model.MiningCandidate, []*subtreepkg.Subtree, error) {
+	isRunning, err := ba.blockchainClient.IsFSMCurrentState(ctx, blockchain.FSMStateRUNNING)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !isRunning {
+		return nil, nil, errors.NewStateError("cannot get mining candidate when FSM is not in RUNNING state")
+	}
+
+	miningCandidate, subtrees, err := ba.blockAssembler.GetMiningCandidate(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ba.logger.Debugf("in GetMiningCandidate: miningCandidate: %+v", miningCandidate.Stringify(true))
+
+	id, _ := chainhash.NewHash(miningCandidate.Id)
+
+	ba.jobStore.Set(*id, &subtreeprocessor.Job{
+		ID:              id,
+		Subtrees:        subtrees,
+		MiningCandidate: miningCandidate,
+	}, jobTTL) // create a new job with a TTL, will be cleaned up automatically
+
+	return miningCandidate, subtrees, nil
+}
+
 // SubmitMiningSolution processes a mining solution submission.
 // It validates the solution, creates a block, and adds it to the blockchain.
 //
@@ -1039,7 +1125,7 @@ func (ba *BlockAssembly) submitMiningSolution(ctx context.Context, req *BlockSub
 		}
 	} else {
 		// recreate coinbase tx here, nothing was passed in
-		coinbaseTx, err = jobItem.Value().MiningCandidate.CreateCoinbaseTxCandidate(ba.blockAssembler.settings)
+		coinbaseTx, err = ba.createCoinbaseTx(jobItem.Value().MiningCandidate)
 		if err != nil {
 			return nil, errors.NewProcessingError("[BlockAssembly][%s] failed to create coinbase tx", jobID, err)
 		}
@@ -1345,6 +1431,56 @@ func (ba *BlockAssembly) ResetBlockAssemblyFully(ctx context.Context, _ *blockas
 	return &blockassembly_api.EmptyMessage{}, nil
 }
 
+// ResetBlockAssemblyScoped repairs the unmined state of the transactions in a height range of the main chain, or in
+// a fork, and then resets block assembly, which reloads the unmined transactions from the UTXO store. The fork hash
+// takes precedence over the height range when both are set.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - req: Height range or fork tip to reset
+//
+// Returns:
+//   - *blockassembly_api.ResetBlockAssemblyScopedResponse: Number of blocks and repaired transactions
+//   - error: If the scope is invalid or the reset failed
+func (ba *BlockAssembly) ResetBlockAssemblyScoped(ctx context.Context, req *blockassembly_api.ResetBlockAssemblyScopedRequest) (*blockassembly_api.ResetBlockAssemblyScopedResponse, error) {
+	ctx, _, deferFn := tracing.Tracer("blockassembly").Start(ctx, "ResetBlockAssemblyScoped",
+		tracing.WithParentStat(ba.stats),
+		tracing.WithLogMessage(ba.logger, "[ResetBlockAssemblyScoped] called for heights %d-%d, fork %x", req.GetFromHeight(), req.GetToHeight(), req.GetForkHash()),
+	)
+	defer deferFn()
+
+	// Check if unmined transactions are still being loaded
+	if ba.blockAssembler.unminedTransactionsLoading.Load() {
+		ba.logger.Warnf("[ResetBlockAssemblyScoped] service not ready - unmined transactions are still being loaded")
+		return nil, errors.WrapGRPC(errors.NewServiceError("service not ready - unmined transactions are still being loaded"))
+	}
+
+	scope := ResetScope{
+		FromHeight: req.GetFromHeight(),
+		ToHeight:   req.GetToHeight(),
+	}
+
+	if len(req.GetForkHash()) > 0 {
+		forkHash, err := chainhash.NewHash(req.GetForkHash())
+		if err != nil {
+			return nil, errors.WrapGRPC(errors.NewInvalidArgumentError("[ResetBlockAssemblyScoped] invalid fork hash", err))
+		}
+
+		scope.ForkHash = forkHash
+	}
+
+	result, err := ba.blockAssembler.ResetScoped(ctx, scope)
+	if err != nil {
+		return nil, errors.WrapGRPC(err)
+	}
+
+	return &blockassembly_api.ResetBlockAssemblyScopedResponse{
+		Blocks:        uint32(result.Blocks),        //nolint:gosec // bounded by maxScopedResetBlocks
+		MarkedMined:   uint64(result.MarkedMined),   //nolint:gosec // counts are never negative
+		MarkedUnmined: uint64(result.MarkedUnmined), //nolint:gosec // counts are never negative
+	}, nil
+}
+
 // GetBlockAssemblyState retrieves the current operational state of the block assembly service.
 //
 // This method provides comprehensive diagnostic information about the current state
@@ -1390,6 +1526,11 @@ func (ba *BlockAssembly) GetBlockAssemblyState(ctx context.Context, _ *blockasse
 		return nil, errors.NewProcessingError("error converting remove map length", err)
 	}
 
+	subtreeSize32, err := safeconversion.IntToUint32(ba.blockAssembler.subtreeProcessor.CurrentItemsPerFile())
+	if err != nil {
+		return nil, errors.NewProcessingError("error converting subtree size", err)
+	}
+
 	currentHeader, currentHeight := ba.blockAssembler.CurrentBlock()
 
 	return &blockassembly_api.StateMessage{
@@ -1402,9 +1543,75 @@ func (ba *BlockAssembly) GetBlockAssemblyState(ctx context.Context, _ *blockasse
 		CurrentHash:           currentHeader.Hash().String(),
 		RemoveMapCount:        removeMapLen32,
 		Subtrees:              subtreeHashesStrings,
+		SubtreeSize:           subtreeSize32,
+		TxArrivalRate:         ba.blockAssembler.subtreeProcessor.TxArrivalRate(),
 	}, nil
 }
 
+// GetReorgStatus retrieves the progress of the reorg being coordinated by block assembly, if any, and the outcome
+// of the last completed reorg.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - _: Empty message request (unused)
+//
+// Returns:
+//   - *blockassembly_api.GetReorgStatusResponse: The reorg in progress and the last completed reorg
+//   - error: Always nil
+func (ba *BlockAssembly) GetReorgStatus(ctx context.Context, _ *blockassembly_api.EmptyMessage) (*blockassembly_api.GetReorgStatusResponse, error) {
+	_, _, deferFn := tracing.Tracer("blockassembly").Start(ctx, "GetReorgStatus",
+		tracing.WithParentStat(ba.stats),
+		tracing.WithDebugLogMessage(ba.logger, "[GetReorgStatus] called"),
+	)
+	defer deferFn()
+
+	current, last := ba.blockAssembler.ReorgStatus()
+
+	return &blockassembly_api.GetReorgStatusResponse{
+		Current: current.toProto(),
+		Last:    last.toProto(),
+	}, nil
+}
+
+// SubscribeState streams the chaintip of block assembly to the client. The current chaintip is sent first,
+// followed by a notification every time the best block changes, until the client ends the stream. Notifications
+// the client does not keep up with are replaced by the latest chaintip.
+//
+// Parameters:
+//   - _: Empty message request (unused)
+//   - stream: Stream to send the notifications on
+//
+// Returns:
+//   - error: Any error encountered while sending a notification
+func (ba *BlockAssembly) SubscribeState(_ *blockassembly_api.EmptyMessage, stream blockassembly_api.BlockAssemblyAPI_SubscribeStateServer) error {
+	ch, unsubscribe := ba.blockAssembler.SubscribeBestBlock()
+	defer unsubscribe()
+
+	currentHeader, currentHeight := ba.blockAssembler.CurrentBlock()
+	if currentHeader != nil {
+		if err := stream.Send(&blockassembly_api.StateNotification{
+			CurrentHeight: currentHeight,
+			CurrentHash:   currentHeader.Hash().String(),
+		}); err != nil {
+			return errors.WrapGRPC(errors.NewServiceError("[SubscribeState] error sending state notification", err))
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case bestBlock := <-ch:
+			if err := stream.Send(&blockassembly_api.StateNotification{
+				CurrentHeight: bestBlock.Height,
+				CurrentHash:   bestBlock.Header.Hash().String(),
+			}); err != nil {
+				return errors.WrapGRPC(errors.NewServiceError("[SubscribeState] error sending state notification", err))
+			}
+		}
+	}
+}
+
 func (ba *BlockAssembly) GetBlockAssemblyTxs(ctx context.Context, _ *blockassembly_api.EmptyMessage) (*blockassembly_api.GetBlockAssemblyTxsResponse, error) {
 	_, _, deferFn := tracing.Tracer("blockassembly").Start(ctx, "GetBlockAssemblyTxsResponse",
 		tracing.WithParentStat(ba.stats),
@@ -1429,6 +1636,61 @@ func (ba *BlockAssembly) GetBlockAssemblyTxs(ctx context.Context, _ *blockassemb
 	}, nil
 }
 
+// GetCoinbaseTemplate returns the coinbase template used for the coinbase transactions created by block assembly.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - _: Empty message request (unused)
+//
+// Returns:
+//   - *blockassembly_api.CoinbaseTemplate: The coinbase template, empty when the coinbase pays to the addresses
+//     of the miner wallet keys
+//   - error: Any error encountered during retrieval
+func (ba *BlockAssembly) GetCoinbaseTemplate(ctx context.Context, _ *blockassembly_api.EmptyMessage) (*blockassembly_api.CoinbaseTemplate, error) {
+	_, _, deferFn := tracing.Tracer("blockassembly").Start(ctx, "GetCoinbaseTemplate",
+		tracing.WithParentStat(ba.stats),
+		tracing.WithDebugLogMessage(ba.logger, "[GetCoinbaseTemplate] called"),
+	)
+	defer deferFn()
+
+	return coinbaseTemplateToAPI(ba.coinbaseTemplate.Load()), nil
+}
+
+// SetCoinbaseTemplate validates and sets the coinbase template at runtime. Coinbase transactions created after
+// the call use the new template, including the coinbase recreated for a solution of an earlier mining candidate.
+// An empty template reverts to the addresses of the miner wallet keys.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - req: The new coinbase template
+//
+// Returns:
+//   - *blockassembly_api.CoinbaseTemplate: The coinbase template now in use
+//   - error: Any error encountered during validation
+func (ba *BlockAssembly) SetCoinbaseTemplate(ctx context.Context, req *blockassembly_api.CoinbaseTemplate) (*blockassembly_api.CoinbaseTemplate, error) {
+	_, _, deferFn := tracing.Tracer("blockassembly").Start(ctx, "SetCoinbaseTemplate",
+		tracing.WithParentStat(ba.stats),
+		tracing.WithLogMessage(ba.logger, "[SetCoinbaseTemplate] called"),
+	)
+	defer deferFn()
+
+	template := coinbaseTemplateFromAPI(req)
+
+	if template != nil {
+		if err := template.Validate(ba.settings.ChainCfgParams); err != nil {
+			return nil, errors.WrapGRPC(errors.NewInvalidArgumentError("[SetCoinbaseTemplate] invalid coinbase template", err))
+		}
+
+		ba.logger.Infof("[SetCoinbaseTemplate] coinbase pays to %d outputs, miner tag %q", len(template.Outputs), template.MinerTag)
+	} else {
+		ba.logger.Infof("[SetCoinbaseTemplate] coinbase pays to the addresses of the miner wallet keys")
+	}
+
+	ba.coinbaseTemplate.Store(template)
+
+	return coinbaseTemplateToAPI(template), nil
+}
+
 // GetCurrentDifficulty retrieves the current mining difficulty target.
 //
 // This method provides access to the current difficulty target required for valid
@@ -1631,8 +1893,20 @@ func (ba *BlockAssembly) generateBlock(ctx context.Context, address *string) err
 		return errors.NewProcessingError("error getting mining candidate", err)
 	}
 
+	var coinbaseTx *bt.Tx
+
+	if address != nil {
+		coinbaseTx, err = miningCandidate.CreateCoinbaseTxCandidateForAddress(ba.blockAssembler.settings, address)
+	} else {
+		coinbaseTx, err = ba.createCoinbaseTx(miningCandidate)
+	}
+
+	if err != nil {
+		return errors.NewProcessingError("error creating coinbase tx", err)
+	}
+
 	// mine the block
-	miningSolution, err := mining.Mine(ctx, ba.blockAssembler.settings, miningCandidate, address)
+	miningSolution, err := mining.MineWithCoinbase(ctx, miningCandidate, coinbaseTx)
 	if err != nil {
 		return errors.NewProcessingError("error mining block", err)
 	}
@@ -63,9 +63,10 @@ func (*EmptyMessage) Descriptor() ([]byte, []int) {
 // Contains the health status of the service. Includes an 'ok' flag indicating health status, details providing more context, and a timestamp.
 type HealthResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`              // true if the service is healthy
-	Details       string                 `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`     // optional, human-readable details
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // unix timestamp
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`                               // true if the service is healthy
+	Details       string                 `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`                      // optional, human-readable details
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                  // unix timestamp
+	BuildInfo     string                 `protobuf:"bytes,4,opt,name=build_info,json=buildInfo,proto3" json:"build_info,omitempty"` // JSON build metadata of the node, as returned by /api/v1/version
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -121,6 +122,13 @@ func (x *HealthResponse) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *HealthResponse) GetBuildInfo() string {
+	if x != nil {
+		return x.BuildInfo
+	}
+	return ""
+}
+
 // Request for adding a new chaintip and height information.
 type NewChaintipAndHeightRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -183,6 +191,7 @@ type AddTxRequest struct {
 	Locktime      uint32                 `protobuf:"varint,2,opt,name=locktime,proto3" json:"locktime,omitempty"`    // the earliest time a transaction can be mined into a block
 	Utxos         [][]byte               `protobuf:"bytes,5,rep,name=utxos,proto3" json:"utxos,omitempty"`           // the UTXOs consumed by this transaction
 	TxInpoints    []byte                 `protobuf:"bytes,6,opt,name=txInpoints,proto3" json:"txInpoints,omitempty"` // a serialized list of input outpoints for this transaction
+	Priority      uint32                 `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`    // the priority lane of the transaction in the block candidate, 0 is the default lane
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -259,6 +268,13 @@ func (x *AddTxRequest) GetTxInpoints() []byte {
 	return nil
 }
 
+func (x *AddTxRequest) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
 // Request for adding a batch of transactions to the mining candidate block.
 type AddTxBatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -349,6 +365,130 @@ func (x *GetMiningCandidateRequest) GetIncludeSubtrees() bool {
 	return false
 }
 
+// Request for retrieving a mining candidate with the subtree changes since a previous candidate.
+type GetMiningCandidateDiffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CandidateId   []byte                 `protobuf:"bytes,1,opt,name=candidate_id,json=candidateId,proto3" json:"candidate_id,omitempty"` // the id of the mining candidate the client has, empty for a full candidate
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMiningCandidateDiffRequest) Reset() {
+	*x = GetMiningCandidateDiffRequest{}
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMiningCandidateDiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMiningCandidateDiffRequest) ProtoMessage() {}
+
+func (x *GetMiningCandidateDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMiningCandidateDiffRequest.ProtoReflect.Descriptor instead.
+func (*GetMiningCandidateDiffRequest) Descriptor() ([]byte, []int) {
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetMiningCandidateDiffRequest) GetCandidateId() []byte {
+	if x != nil {
+		return x.CandidateId
+	}
+	return nil
+}
+
+// Response with a mining candidate and the subtree changes since the candidate of the request.
+// The subtrees of the candidate are the subtrees of the base candidate without the removed subtrees, followed by
+// the added subtrees, or only the added subtrees when full is set.
+type GetMiningCandidateDiffResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Candidate            *model.MiningCandidate `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`                                                     // the mining candidate, without subtree hashes
+	BaseCandidateId      []byte                 `protobuf:"bytes,2,opt,name=base_candidate_id,json=baseCandidateId,proto3" json:"base_candidate_id,omitempty"`                // the id of the candidate the diff is relative to, empty when full is set
+	Full                 bool                   `protobuf:"varint,3,opt,name=full,proto3" json:"full,omitempty"`                                                              // true when the requested candidate is unknown, expired or not a base of this candidate
+	AddedSubtreeHashes   [][]byte               `protobuf:"bytes,4,rep,name=added_subtree_hashes,json=addedSubtreeHashes,proto3" json:"added_subtree_hashes,omitempty"`       // the subtrees added since the base candidate, in block order
+	RemovedSubtreeHashes [][]byte               `protobuf:"bytes,5,rep,name=removed_subtree_hashes,json=removedSubtreeHashes,proto3" json:"removed_subtree_hashes,omitempty"` // the subtrees of the base candidate that are not in this candidate
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetMiningCandidateDiffResponse) Reset() {
+	*x = GetMiningCandidateDiffResponse{}
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMiningCandidateDiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMiningCandidateDiffResponse) ProtoMessage() {}
+
+func (x *GetMiningCandidateDiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMiningCandidateDiffResponse.ProtoReflect.Descriptor instead.
+func (*GetMiningCandidateDiffResponse) Descriptor() ([]byte, []int) {
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMiningCandidateDiffResponse) GetCandidate() *model.MiningCandidate {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+func (x *GetMiningCandidateDiffResponse) GetBaseCandidateId() []byte {
+	if x != nil {
+		return x.BaseCandidateId
+	}
+	return nil
+}
+
+func (x *GetMiningCandidateDiffResponse) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+func (x *GetMiningCandidateDiffResponse) GetAddedSubtreeHashes() [][]byte {
+	if x != nil {
+		return x.AddedSubtreeHashes
+	}
+	return nil
+}
+
+func (x *GetMiningCandidateDiffResponse) GetRemovedSubtreeHashes() [][]byte {
+	if x != nil {
+		return x.RemovedSubtreeHashes
+	}
+	return nil
+}
+
 // Request for removing a transaction from the mining candidate block.
 type RemoveTxRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -359,7 +499,7 @@ type RemoveTxRequest struct {
 
 func (x *RemoveTxRequest) Reset() {
 	*x = RemoveTxRequest{}
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[6]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -371,7 +511,7 @@ func (x *RemoveTxRequest) String() string {
 func (*RemoveTxRequest) ProtoMessage() {}
 
 func (x *RemoveTxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[6]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,7 +524,7 @@ func (x *RemoveTxRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveTxRequest.ProtoReflect.Descriptor instead.
 func (*RemoveTxRequest) Descriptor() ([]byte, []int) {
-	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{6}
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RemoveTxRequest) GetTxid() []byte {
@@ -404,7 +544,7 @@ type AddTxResponse struct {
 
 func (x *AddTxResponse) Reset() {
 	*x = AddTxResponse{}
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[7]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -416,7 +556,7 @@ func (x *AddTxResponse) String() string {
 func (*AddTxResponse) ProtoMessage() {}
 
 func (x *AddTxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[7]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,7 +569,7 @@ func (x *AddTxResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddTxResponse.ProtoReflect.Descriptor instead.
 func (*AddTxResponse) Descriptor() ([]byte, []int) {
-	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{7}
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AddTxResponse) GetOk() bool {
@@ -449,7 +589,7 @@ type AddTxBatchResponse struct {
 
 func (x *AddTxBatchResponse) Reset() {
 	*x = AddTxBatchResponse{}
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[8]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -461,7 +601,7 @@ func (x *AddTxBatchResponse) String() string {
 func (*AddTxBatchResponse) ProtoMessage() {}
 
 func (x *AddTxBatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[8]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -474,7 +614,7 @@ func (x *AddTxBatchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddTxBatchResponse.ProtoReflect.Descriptor instead.
 func (*AddTxBatchResponse) Descriptor() ([]byte, []int) {
-	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{8}
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AddTxBatchResponse) GetOk() bool {
@@ -498,7 +638,7 @@ type SubmitMiningSolutionRequest struct {
 
 func (x *SubmitMiningSolutionRequest) Reset() {
 	*x = SubmitMiningSolutionRequest{}
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[9]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -510,7 +650,7 @@ func (x *SubmitMiningSolutionRequest) String() string {
 func (*SubmitMiningSolutionRequest) ProtoMessage() {}
 
 func (x *SubmitMiningSolutionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[9]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,7 +663,7 @@ func (x *SubmitMiningSolutionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubmitMiningSolutionRequest.ProtoReflect.Descriptor instead.
 func (*SubmitMiningSolutionRequest) Descriptor() ([]byte, []int) {
-	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{9}
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SubmitMiningSolutionRequest) GetId() []byte {
@@ -561,6 +701,128 @@ func (x *SubmitMiningSolutionRequest) GetVersion() uint32 {
 	return 0
 }
 
+// Request for a reset of block assembly scoped to a height range of the main chain or to a fork.
+type ResetBlockAssemblyScopedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromHeight    uint32                 `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"` // the first height of the main chain range, used when fork_hash is not set
+	ToHeight      uint32                 `protobuf:"varint,2,opt,name=to_height,json=toHeight,proto3" json:"to_height,omitempty"`       // the last height of the main chain range, used when fork_hash is not set
+	ForkHash      []byte                 `protobuf:"bytes,3,opt,name=fork_hash,json=forkHash,proto3" json:"fork_hash,omitempty"`        // optional, the tip of a fork whose transactions are returned to block assembly
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetBlockAssemblyScopedRequest) Reset() {
+	*x = ResetBlockAssemblyScopedRequest{}
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetBlockAssemblyScopedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetBlockAssemblyScopedRequest) ProtoMessage() {}
+
+func (x *ResetBlockAssemblyScopedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetBlockAssemblyScopedRequest.ProtoReflect.Descriptor instead.
+func (*ResetBlockAssemblyScopedRequest) Descriptor() ([]byte, []int) {
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResetBlockAssemblyScopedRequest) GetFromHeight() uint32 {
+	if x != nil {
+		return x.FromHeight
+	}
+	return 0
+}
+
+func (x *ResetBlockAssemblyScopedRequest) GetToHeight() uint32 {
+	if x != nil {
+		return x.ToHeight
+	}
+	return 0
+}
+
+func (x *ResetBlockAssemblyScopedRequest) GetForkHash() []byte {
+	if x != nil {
+		return x.ForkHash
+	}
+	return nil
+}
+
+// Response of a scoped reset of block assembly.
+type ResetBlockAssemblyScopedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        uint32                 `protobuf:"varint,1,opt,name=blocks,proto3" json:"blocks,omitempty"`                                    // the number of blocks in the scope
+	MarkedMined   uint64                 `protobuf:"varint,2,opt,name=marked_mined,json=markedMined,proto3" json:"marked_mined,omitempty"`       // the number of transactions marked as mined on the longest chain
+	MarkedUnmined uint64                 `protobuf:"varint,3,opt,name=marked_unmined,json=markedUnmined,proto3" json:"marked_unmined,omitempty"` // the number of transactions marked as unmined
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetBlockAssemblyScopedResponse) Reset() {
+	*x = ResetBlockAssemblyScopedResponse{}
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetBlockAssemblyScopedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetBlockAssemblyScopedResponse) ProtoMessage() {}
+
+func (x *ResetBlockAssemblyScopedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetBlockAssemblyScopedResponse.ProtoReflect.Descriptor instead.
+func (*ResetBlockAssemblyScopedResponse) Descriptor() ([]byte, []int) {
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ResetBlockAssemblyScopedResponse) GetBlocks() uint32 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *ResetBlockAssemblyScopedResponse) GetMarkedMined() uint64 {
+	if x != nil {
+		return x.MarkedMined
+	}
+	return 0
+}
+
+func (x *ResetBlockAssemblyScopedResponse) GetMarkedUnmined() uint64 {
+	if x != nil {
+		return x.MarkedUnmined
+	}
+	return 0
+}
+
 // Response indicating whether the call was successful.
 type OKResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -571,7 +833,7 @@ type OKResponse struct {
 
 func (x *OKResponse) Reset() {
 	*x = OKResponse{}
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[10]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -583,7 +845,7 @@ func (x *OKResponse) String() string {
 func (*OKResponse) ProtoMessage() {}
 
 func (x *OKResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[10]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -596,7 +858,7 @@ func (x *OKResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OKResponse.ProtoReflect.Descriptor instead.
 func (*OKResponse) Descriptor() ([]byte, []int) {
-	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{10}
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *OKResponse) GetOk() bool {
@@ -618,13 +880,15 @@ type StateMessage struct {
 	CurrentHash           string                 `protobuf:"bytes,7,opt,name=currentHash,proto3" json:"currentHash,omitempty"`                     // the hash of the chaintip
 	RemoveMapCount        uint32                 `protobuf:"varint,8,opt,name=removeMapCount,proto3" json:"removeMapCount,omitempty"`              // the number of transactions in the remove map
 	Subtrees              []string               `protobuf:"bytes,9,rep,name=subtrees,proto3" json:"subtrees,omitempty"`                           // the hashes of the current subtrees
+	SubtreeSize           uint32                 `protobuf:"varint,10,opt,name=subtreeSize,proto3" json:"subtreeSize,omitempty"`                   // the effective size of new subtrees
+	TxArrivalRate         float64                `protobuf:"fixed64,11,opt,name=txArrivalRate,proto3" json:"txArrivalRate,omitempty"`              // the smoothed transaction arrival rate in transactions per second, when subtrees are sized to it
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
 
 func (x *StateMessage) Reset() {
 	*x = StateMessage{}
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[11]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -636,7 +900,7 @@ func (x *StateMessage) String() string {
 func (*StateMessage) ProtoMessage() {}
 
 func (x *StateMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[11]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -649,7 +913,7 @@ func (x *StateMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateMessage.ProtoReflect.Descriptor instead.
 func (*StateMessage) Descriptor() ([]byte, []int) {
-	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{11}
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *StateMessage) GetBlockAssemblyState() string {
@@ -715,6 +979,267 @@ func (x *StateMessage) GetSubtrees() []string {
 	return nil
 }
 
+func (x *StateMessage) GetSubtreeSize() uint32 {
+	if x != nil {
+		return x.SubtreeSize
+	}
+	return 0
+}
+
+func (x *StateMessage) GetTxArrivalRate() float64 {
+	if x != nil {
+		return x.TxArrivalRate
+	}
+	return 0
+}
+
+// Notification of the chaintip of block assembly, sent by SubscribeState.
+type StateNotification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrentHeight uint32                 `protobuf:"varint,1,opt,name=currentHeight,proto3" json:"currentHeight,omitempty"` // the height of the chaintip
+	CurrentHash   string                 `protobuf:"bytes,2,opt,name=currentHash,proto3" json:"currentHash,omitempty"`      // the hash of the chaintip
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateNotification) Reset() {
+	*x = StateNotification{}
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateNotification) ProtoMessage() {}
+
+func (x *StateNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateNotification.ProtoReflect.Descriptor instead.
+func (*StateNotification) Descriptor() ([]byte, []int) {
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StateNotification) GetCurrentHeight() uint32 {
+	if x != nil {
+		return x.CurrentHeight
+	}
+	return 0
+}
+
+func (x *StateNotification) GetCurrentHash() string {
+	if x != nil {
+		return x.CurrentHash
+	}
+	return ""
+}
+
+// Progress of a reorg coordinated by block assembly.
+type ReorgStatus struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                                          // the sequence number of the reorg since block assembly started
+	FromHash          []byte                 `protobuf:"bytes,2,opt,name=from_hash,json=fromHash,proto3" json:"from_hash,omitempty"`                               // the chaintip of block assembly before the reorg
+	FromHeight        uint32                 `protobuf:"varint,3,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`                        // the height of the chaintip before the reorg
+	ToHash            []byte                 `protobuf:"bytes,4,opt,name=to_hash,json=toHash,proto3" json:"to_hash,omitempty"`                                     // the new best block of the blockchain
+	ToHeight          uint32                 `protobuf:"varint,5,opt,name=to_height,json=toHeight,proto3" json:"to_height,omitempty"`                              // the height of the new best block
+	MoveBackBlocks    uint32                 `protobuf:"varint,6,opt,name=move_back_blocks,json=moveBackBlocks,proto3" json:"move_back_blocks,omitempty"`          // the number of blocks moved back, known once the blocks are collected
+	MoveForwardBlocks uint32                 `protobuf:"varint,7,opt,name=move_forward_blocks,json=moveForwardBlocks,proto3" json:"move_forward_blocks,omitempty"` // the number of blocks moved forward, known once the blocks are collected
+	Phase             string                 `protobuf:"bytes,8,opt,name=phase,proto3" json:"phase,omitempty"`                                                     // the current phase, completed or failed once the reorg ended
+	FullReset         bool                   `protobuf:"varint,9,opt,name=full_reset,json=fullReset,proto3" json:"full_reset,omitempty"`                           // true if block assembly was reset instead of reorged incrementally
+	StartedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`                           // when the reorg started
+	PhaseStartedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=phase_started_at,json=phaseStartedAt,proto3" json:"phase_started_at,omitempty"`          // when the current phase started
+	CompletedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`                     // when the reorg ended, not set while it is in progress
+	Error             string                 `protobuf:"bytes,13,opt,name=error,proto3" json:"error,omitempty"`                                                    // the error of a failed reorg
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReorgStatus) Reset() {
+	*x = ReorgStatus{}
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorgStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorgStatus) ProtoMessage() {}
+
+func (x *ReorgStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorgStatus.ProtoReflect.Descriptor instead.
+func (*ReorgStatus) Descriptor() ([]byte, []int) {
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReorgStatus) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReorgStatus) GetFromHash() []byte {
+	if x != nil {
+		return x.FromHash
+	}
+	return nil
+}
+
+func (x *ReorgStatus) GetFromHeight() uint32 {
+	if x != nil {
+		return x.FromHeight
+	}
+	return 0
+}
+
+func (x *ReorgStatus) GetToHash() []byte {
+	if x != nil {
+		return x.ToHash
+	}
+	return nil
+}
+
+func (x *ReorgStatus) GetToHeight() uint32 {
+	if x != nil {
+		return x.ToHeight
+	}
+	return 0
+}
+
+func (x *ReorgStatus) GetMoveBackBlocks() uint32 {
+	if x != nil {
+		return x.MoveBackBlocks
+	}
+	return 0
+}
+
+func (x *ReorgStatus) GetMoveForwardBlocks() uint32 {
+	if x != nil {
+		return x.MoveForwardBlocks
+	}
+	return 0
+}
+
+func (x *ReorgStatus) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *ReorgStatus) GetFullReset() bool {
+	if x != nil {
+		return x.FullReset
+	}
+	return false
+}
+
+func (x *ReorgStatus) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ReorgStatus) GetPhaseStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PhaseStartedAt
+	}
+	return nil
+}
+
+func (x *ReorgStatus) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *ReorgStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Response containing the reorg in progress and the last completed reorg.
+type GetReorgStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       *ReorgStatus           `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"` // the reorg in progress, not set when no reorg is in progress
+	Last          *ReorgStatus           `protobuf:"bytes,2,opt,name=last,proto3" json:"last,omitempty"`       // the last completed reorg, not set when there has been no reorg
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReorgStatusResponse) Reset() {
+	*x = GetReorgStatusResponse{}
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReorgStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReorgStatusResponse) ProtoMessage() {}
+
+func (x *GetReorgStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReorgStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetReorgStatusResponse) Descriptor() ([]byte, []int) {
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetReorgStatusResponse) GetCurrent() *ReorgStatus {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *GetReorgStatusResponse) GetLast() *ReorgStatus {
+	if x != nil {
+		return x.Last
+	}
+	return nil
+}
+
 // Response containing the current difficulty of the blockchain.
 type GetCurrentDifficultyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -725,7 +1250,7 @@ type GetCurrentDifficultyResponse struct {
 
 func (x *GetCurrentDifficultyResponse) Reset() {
 	*x = GetCurrentDifficultyResponse{}
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[12]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -737,7 +1262,7 @@ func (x *GetCurrentDifficultyResponse) String() string {
 func (*GetCurrentDifficultyResponse) ProtoMessage() {}
 
 func (x *GetCurrentDifficultyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[12]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +1275,7 @@ func (x *GetCurrentDifficultyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCurrentDifficultyResponse.ProtoReflect.Descriptor instead.
 func (*GetCurrentDifficultyResponse) Descriptor() ([]byte, []int) {
-	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{12}
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetCurrentDifficultyResponse) GetDifficulty() float64 {
@@ -772,7 +1297,7 @@ type GenerateBlocksRequest struct {
 
 func (x *GenerateBlocksRequest) Reset() {
 	*x = GenerateBlocksRequest{}
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[13]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -784,7 +1309,7 @@ func (x *GenerateBlocksRequest) String() string {
 func (*GenerateBlocksRequest) ProtoMessage() {}
 
 func (x *GenerateBlocksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[13]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,7 +1322,7 @@ func (x *GenerateBlocksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateBlocksRequest.ProtoReflect.Descriptor instead.
 func (*GenerateBlocksRequest) Descriptor() ([]byte, []int) {
-	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{13}
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GenerateBlocksRequest) GetCount() int32 {
@@ -831,7 +1356,7 @@ type GetBlockAssemblyBlockCandidateResponse struct {
 
 func (x *GetBlockAssemblyBlockCandidateResponse) Reset() {
 	*x = GetBlockAssemblyBlockCandidateResponse{}
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[14]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -843,7 +1368,7 @@ func (x *GetBlockAssemblyBlockCandidateResponse) String() string {
 func (*GetBlockAssemblyBlockCandidateResponse) ProtoMessage() {}
 
 func (x *GetBlockAssemblyBlockCandidateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[14]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -856,7 +1381,7 @@ func (x *GetBlockAssemblyBlockCandidateResponse) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use GetBlockAssemblyBlockCandidateResponse.ProtoReflect.Descriptor instead.
 func (*GetBlockAssemblyBlockCandidateResponse) Descriptor() ([]byte, []int) {
-	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{14}
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetBlockAssemblyBlockCandidateResponse) GetBlock() []byte {
@@ -877,7 +1402,7 @@ type GetBlockAssemblyTxsResponse struct {
 
 func (x *GetBlockAssemblyTxsResponse) Reset() {
 	*x = GetBlockAssemblyTxsResponse{}
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[15]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -889,7 +1414,7 @@ func (x *GetBlockAssemblyTxsResponse) String() string {
 func (*GetBlockAssemblyTxsResponse) ProtoMessage() {}
 
 func (x *GetBlockAssemblyTxsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[15]
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,7 +1427,7 @@ func (x *GetBlockAssemblyTxsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockAssemblyTxsResponse.ProtoReflect.Descriptor instead.
 func (*GetBlockAssemblyTxsResponse) Descriptor() ([]byte, []int) {
-	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{15}
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetBlockAssemblyTxsResponse) GetTxCount() uint64 {
@@ -919,19 +1444,127 @@ func (x *GetBlockAssemblyTxsResponse) GetTxs() []string {
 	return nil
 }
 
+// A payout output of a coinbase template.
+type CoinbaseOutputTemplate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`                             // the P2PKH or P2SH address to pay to
+	BasisPoints   uint32                 `protobuf:"varint,2,opt,name=basis_points,json=basisPoints,proto3" json:"basis_points,omitempty"` // the share of the coinbase value in hundredths of a percent, 10000 is 100%
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoinbaseOutputTemplate) Reset() {
+	*x = CoinbaseOutputTemplate{}
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoinbaseOutputTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoinbaseOutputTemplate) ProtoMessage() {}
+
+func (x *CoinbaseOutputTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoinbaseOutputTemplate.ProtoReflect.Descriptor instead.
+func (*CoinbaseOutputTemplate) Descriptor() ([]byte, []int) {
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CoinbaseOutputTemplate) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CoinbaseOutputTemplate) GetBasisPoints() uint32 {
+	if x != nil {
+		return x.BasisPoints
+	}
+	return 0
+}
+
+// Template of the outputs of the coinbase transactions created by block assembly.
+type CoinbaseTemplate struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Outputs       []*CoinbaseOutputTemplate `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`                   // the payout outputs, the shares must add up to 100%
+	MinerTag      string                    `protobuf:"bytes,2,opt,name=miner_tag,json=minerTag,proto3" json:"miner_tag,omitempty"` // optional miner tag written to an OP_RETURN output
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoinbaseTemplate) Reset() {
+	*x = CoinbaseTemplate{}
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoinbaseTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoinbaseTemplate) ProtoMessage() {}
+
+func (x *CoinbaseTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoinbaseTemplate.ProtoReflect.Descriptor instead.
+func (*CoinbaseTemplate) Descriptor() ([]byte, []int) {
+	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CoinbaseTemplate) GetOutputs() []*CoinbaseOutputTemplate {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *CoinbaseTemplate) GetMinerTag() string {
+	if x != nil {
+		return x.MinerTag
+	}
+	return ""
+}
+
 var File_services_blockassembly_blockassembly_api_blockassembly_api_proto protoreflect.FileDescriptor
 
 const file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDesc = "" +
 	"\n" +
 	"@services/blockassembly/blockassembly_api/blockassembly_api.proto\x12\x11blockassembly_api\x1a\x11model/model.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x0e\n" +
-	"\fEmptyMessage\"t\n" +
+	"\fEmptyMessage\"\x93\x01\n" +
 	"\x0eHealthResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x18\n" +
 	"\adetails\x18\x02 \x01(\tR\adetails\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"Q\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"build_info\x18\x04 \x01(\tR\tbuildInfo\"Q\n" +
 	"\x1bNewChaintipAndHeightRequest\x12\x1a\n" +
 	"\bchaintip\x18\x01 \x01(\fR\bchaintip\x12\x16\n" +
-	"\x06height\x18\x02 \x01(\rR\x06height\"\x9a\x01\n" +
+	"\x06height\x18\x02 \x01(\rR\x06height\"\xb6\x01\n" +
 	"\fAddTxRequest\x12\x12\n" +
 	"\x04txid\x18\x01 \x01(\fR\x04txid\x12\x10\n" +
 	"\x03fee\x18\x03 \x01(\x04R\x03fee\x12\x12\n" +
@@ -940,13 +1573,22 @@ const file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawD
 	"\x05utxos\x18\x05 \x03(\fR\x05utxos\x12\x1e\n" +
 	"\n" +
 	"txInpoints\x18\x06 \x01(\fR\n" +
-	"txInpoints\"T\n" +
+	"txInpoints\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\rR\bpriority\"T\n" +
 	"\x11AddTxBatchRequest\x12?\n" +
 	"\n" +
 	"txRequests\x18\x01 \x03(\v2\x1f.blockassembly_api.AddTxRequestR\n" +
 	"txRequests\"E\n" +
 	"\x19GetMiningCandidateRequest\x12(\n" +
-	"\x0fincludeSubtrees\x18\x01 \x01(\bR\x0fincludeSubtrees\"%\n" +
+	"\x0fincludeSubtrees\x18\x01 \x01(\bR\x0fincludeSubtrees\"B\n" +
+	"\x1dGetMiningCandidateDiffRequest\x12!\n" +
+	"\fcandidate_id\x18\x01 \x01(\fR\vcandidateId\"\xfe\x01\n" +
+	"\x1eGetMiningCandidateDiffResponse\x124\n" +
+	"\tcandidate\x18\x01 \x01(\v2\x16.model.MiningCandidateR\tcandidate\x12*\n" +
+	"\x11base_candidate_id\x18\x02 \x01(\fR\x0fbaseCandidateId\x12\x12\n" +
+	"\x04full\x18\x03 \x01(\bR\x04full\x120\n" +
+	"\x14added_subtree_hashes\x18\x04 \x03(\fR\x12addedSubtreeHashes\x124\n" +
+	"\x16removed_subtree_hashes\x18\x05 \x03(\fR\x14removedSubtreeHashes\"%\n" +
 	"\x0fRemoveTxRequest\x12\x12\n" +
 	"\x04txid\x18\x01 \x01(\fR\x04txid\"\x1f\n" +
 	"\rAddTxResponse\x12\x0e\n" +
@@ -962,10 +1604,19 @@ const file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawD
 	"\aversion\x18\x05 \x01(\rH\x01R\aversion\x88\x01\x01B\a\n" +
 	"\x05_timeB\n" +
 	"\n" +
-	"\b_version\"\x1c\n" +
+	"\b_version\"|\n" +
+	"\x1fResetBlockAssemblyScopedRequest\x12\x1f\n" +
+	"\vfrom_height\x18\x01 \x01(\rR\n" +
+	"fromHeight\x12\x1b\n" +
+	"\tto_height\x18\x02 \x01(\rR\btoHeight\x12\x1b\n" +
+	"\tfork_hash\x18\x03 \x01(\fR\bforkHash\"\x84\x01\n" +
+	" ResetBlockAssemblyScopedResponse\x12\x16\n" +
+	"\x06blocks\x18\x01 \x01(\rR\x06blocks\x12!\n" +
+	"\fmarked_mined\x18\x02 \x01(\x04R\vmarkedMined\x12%\n" +
+	"\x0emarked_unmined\x18\x03 \x01(\x04R\rmarkedUnmined\"\x1c\n" +
 	"\n" +
 	"OKResponse\x12\x0e\n" +
-	"\x02ok\x18\x01 \x01(\bR\x02ok\"\xde\x02\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\"\xa6\x03\n" +
 	"\fStateMessage\x12.\n" +
 	"\x12blockAssemblyState\x18\x01 \x01(\tR\x12blockAssemblyState\x124\n" +
 	"\x15subtreeProcessorState\x18\x02 \x01(\tR\x15subtreeProcessorState\x12\"\n" +
@@ -977,7 +1628,34 @@ const file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawD
 	"\rcurrentHeight\x18\x06 \x01(\rR\rcurrentHeight\x12 \n" +
 	"\vcurrentHash\x18\a \x01(\tR\vcurrentHash\x12&\n" +
 	"\x0eremoveMapCount\x18\b \x01(\rR\x0eremoveMapCount\x12\x1a\n" +
-	"\bsubtrees\x18\t \x03(\tR\bsubtrees\">\n" +
+	"\bsubtrees\x18\t \x03(\tR\bsubtrees\x12 \n" +
+	"\vsubtreeSize\x18\n" +
+	" \x01(\rR\vsubtreeSize\x12$\n" +
+	"\rtxArrivalRate\x18\v \x01(\x01R\rtxArrivalRate\"[\n" +
+	"\x11StateNotification\x12$\n" +
+	"\rcurrentHeight\x18\x01 \x01(\rR\rcurrentHeight\x12 \n" +
+	"\vcurrentHash\x18\x02 \x01(\tR\vcurrentHash\"\xf6\x03\n" +
+	"\vReorgStatus\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
+	"\tfrom_hash\x18\x02 \x01(\fR\bfromHash\x12\x1f\n" +
+	"\vfrom_height\x18\x03 \x01(\rR\n" +
+	"fromHeight\x12\x17\n" +
+	"\ato_hash\x18\x04 \x01(\fR\x06toHash\x12\x1b\n" +
+	"\tto_height\x18\x05 \x01(\rR\btoHeight\x12(\n" +
+	"\x10move_back_blocks\x18\x06 \x01(\rR\x0emoveBackBlocks\x12.\n" +
+	"\x13move_forward_blocks\x18\a \x01(\rR\x11moveForwardBlocks\x12\x14\n" +
+	"\x05phase\x18\b \x01(\tR\x05phase\x12\x1d\n" +
+	"\n" +
+	"full_reset\x18\t \x01(\bR\tfullReset\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12D\n" +
+	"\x10phase_started_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0ephaseStartedAt\x12=\n" +
+	"\fcompleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x14\n" +
+	"\x05error\x18\r \x01(\tR\x05error\"\x86\x01\n" +
+	"\x16GetReorgStatusResponse\x128\n" +
+	"\acurrent\x18\x01 \x01(\v2\x1e.blockassembly_api.ReorgStatusR\acurrent\x122\n" +
+	"\x04last\x18\x02 \x01(\v2\x1e.blockassembly_api.ReorgStatusR\x04last\">\n" +
 	"\x1cGetCurrentDifficultyResponse\x12\x1e\n" +
 	"\n" +
 	"difficulty\x18\x01 \x01(\x01R\n" +
@@ -993,8 +1671,13 @@ const file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawD
 	"\x05block\x18\x01 \x01(\fR\x05block\"I\n" +
 	"\x1bGetBlockAssemblyTxsResponse\x12\x18\n" +
 	"\atxCount\x18\x01 \x01(\x04R\atxCount\x12\x10\n" +
-	"\x03txs\x18\x02 \x03(\tR\x03txs2\xce\n" +
-	"\n" +
+	"\x03txs\x18\x02 \x03(\tR\x03txs\"U\n" +
+	"\x16CoinbaseOutputTemplate\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12!\n" +
+	"\fbasis_points\x18\x02 \x01(\rR\vbasisPoints\"t\n" +
+	"\x10CoinbaseTemplate\x12C\n" +
+	"\aoutputs\x18\x01 \x03(\v2).blockassembly_api.CoinbaseOutputTemplateR\aoutputs\x12\x1b\n" +
+	"\tminer_tag\x18\x02 \x01(\tR\bminerTag2\xd6\x0f\n" +
 	"\x10BlockAssemblyAPI\x12R\n" +
 	"\n" +
 	"HealthGRPC\x12\x1f.blockassembly_api.EmptyMessage\x1a!.blockassembly_api.HealthResponse\"\x00\x12L\n" +
@@ -1002,16 +1685,22 @@ const file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawD
 	"\bRemoveTx\x12\".blockassembly_api.RemoveTxRequest\x1a\x1f.blockassembly_api.EmptyMessage\"\x00\x12[\n" +
 	"\n" +
 	"AddTxBatch\x12$.blockassembly_api.AddTxBatchRequest\x1a%.blockassembly_api.AddTxBatchResponse\"\x00\x12\\\n" +
-	"\x12GetMiningCandidate\x12,.blockassembly_api.GetMiningCandidateRequest\x1a\x16.model.MiningCandidate\"\x00\x12j\n" +
+	"\x12GetMiningCandidate\x12,.blockassembly_api.GetMiningCandidateRequest\x1a\x16.model.MiningCandidate\"\x00\x12\x7f\n" +
+	"\x16GetMiningCandidateDiff\x120.blockassembly_api.GetMiningCandidateDiffRequest\x1a1.blockassembly_api.GetMiningCandidateDiffResponse\"\x00\x12j\n" +
 	"\x14GetCurrentDifficulty\x12\x1f.blockassembly_api.EmptyMessage\x1a/.blockassembly_api.GetCurrentDifficultyResponse\"\x00\x12g\n" +
 	"\x14SubmitMiningSolution\x12..blockassembly_api.SubmitMiningSolutionRequest\x1a\x1d.blockassembly_api.OKResponse\"\x00\x12X\n" +
 	"\x12ResetBlockAssembly\x12\x1f.blockassembly_api.EmptyMessage\x1a\x1f.blockassembly_api.EmptyMessage\"\x00\x12]\n" +
-	"\x17ResetBlockAssemblyFully\x12\x1f.blockassembly_api.EmptyMessage\x1a\x1f.blockassembly_api.EmptyMessage\"\x00\x12[\n" +
-	"\x15GetBlockAssemblyState\x12\x1f.blockassembly_api.EmptyMessage\x1a\x1f.blockassembly_api.StateMessage\"\x00\x12]\n" +
+	"\x17ResetBlockAssemblyFully\x12\x1f.blockassembly_api.EmptyMessage\x1a\x1f.blockassembly_api.EmptyMessage\"\x00\x12\x85\x01\n" +
+	"\x18ResetBlockAssemblyScoped\x122.blockassembly_api.ResetBlockAssemblyScopedRequest\x1a3.blockassembly_api.ResetBlockAssemblyScopedResponse\"\x00\x12[\n" +
+	"\x15GetBlockAssemblyState\x12\x1f.blockassembly_api.EmptyMessage\x1a\x1f.blockassembly_api.StateMessage\"\x00\x12[\n" +
+	"\x0eSubscribeState\x12\x1f.blockassembly_api.EmptyMessage\x1a$.blockassembly_api.StateNotification\"\x000\x01\x12^\n" +
+	"\x0eGetReorgStatus\x12\x1f.blockassembly_api.EmptyMessage\x1a).blockassembly_api.GetReorgStatusResponse\"\x00\x12]\n" +
 	"\x0eGenerateBlocks\x12(.blockassembly_api.GenerateBlocksRequest\x1a\x1f.blockassembly_api.EmptyMessage\"\x00\x12V\n" +
 	"\x12CheckBlockAssembly\x12\x1f.blockassembly_api.EmptyMessage\x1a\x1d.blockassembly_api.OKResponse\"\x00\x12~\n" +
 	"\x1eGetBlockAssemblyBlockCandidate\x12\x1f.blockassembly_api.EmptyMessage\x1a9.blockassembly_api.GetBlockAssemblyBlockCandidateResponse\"\x00\x12h\n" +
-	"\x13GetBlockAssemblyTxs\x12\x1f.blockassembly_api.EmptyMessage\x1a..blockassembly_api.GetBlockAssemblyTxsResponse\"\x00B\x16Z\x14./;blockassembly_apib\x06proto3"
+	"\x13GetBlockAssemblyTxs\x12\x1f.blockassembly_api.EmptyMessage\x1a..blockassembly_api.GetBlockAssemblyTxsResponse\"\x00\x12]\n" +
+	"\x13GetCoinbaseTemplate\x12\x1f.blockassembly_api.EmptyMessage\x1a#.blockassembly_api.CoinbaseTemplate\"\x00\x12a\n" +
+	"\x13SetCoinbaseTemplate\x12#.blockassembly_api.CoinbaseTemplate\x1a#.blockassembly_api.CoinbaseTemplate\"\x00B\x16Z\x14./;blockassembly_apib\x06proto3"
 
 var (
 	file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescOnce sync.Once
@@ -1025,7 +1714,7 @@ func file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDe
 	return file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDescData
 }
 
-var file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_services_blockassembly_blockassembly_api_blockassembly_api_proto_goTypes = []any{
 	(*EmptyMessage)(nil),                           // 0: blockassembly_api.EmptyMessage
 	(*HealthResponse)(nil),                         // 1: blockassembly_api.HealthResponse
@@ -1033,55 +1722,83 @@ var file_services_blockassembly_blockassembly_api_blockassembly_api_proto_goType
 	(*AddTxRequest)(nil),                           // 3: blockassembly_api.AddTxRequest
 	(*AddTxBatchRequest)(nil),                      // 4: blockassembly_api.AddTxBatchRequest
 	(*GetMiningCandidateRequest)(nil),              // 5: blockassembly_api.GetMiningCandidateRequest
-	(*RemoveTxRequest)(nil),                        // 6: blockassembly_api.RemoveTxRequest
-	(*AddTxResponse)(nil),                          // 7: blockassembly_api.AddTxResponse
-	(*AddTxBatchResponse)(nil),                     // 8: blockassembly_api.AddTxBatchResponse
-	(*SubmitMiningSolutionRequest)(nil),            // 9: blockassembly_api.SubmitMiningSolutionRequest
-	(*OKResponse)(nil),                             // 10: blockassembly_api.OKResponse
-	(*StateMessage)(nil),                           // 11: blockassembly_api.StateMessage
-	(*GetCurrentDifficultyResponse)(nil),           // 12: blockassembly_api.GetCurrentDifficultyResponse
-	(*GenerateBlocksRequest)(nil),                  // 13: blockassembly_api.GenerateBlocksRequest
-	(*GetBlockAssemblyBlockCandidateResponse)(nil), // 14: blockassembly_api.GetBlockAssemblyBlockCandidateResponse
-	(*GetBlockAssemblyTxsResponse)(nil),            // 15: blockassembly_api.GetBlockAssemblyTxsResponse
-	(*timestamppb.Timestamp)(nil),                  // 16: google.protobuf.Timestamp
-	(*model.MiningCandidate)(nil),                  // 17: model.MiningCandidate
+	(*GetMiningCandidateDiffRequest)(nil),          // 6: blockassembly_api.GetMiningCandidateDiffRequest
+	(*GetMiningCandidateDiffResponse)(nil),         // 7: blockassembly_api.GetMiningCandidateDiffResponse
+	(*RemoveTxRequest)(nil),                        // 8: blockassembly_api.RemoveTxRequest
+	(*AddTxResponse)(nil),                          // 9: blockassembly_api.AddTxResponse
+	(*AddTxBatchResponse)(nil),                     // 10: blockassembly_api.AddTxBatchResponse
+	(*SubmitMiningSolutionRequest)(nil),            // 11: blockassembly_api.SubmitMiningSolutionRequest
+	(*ResetBlockAssemblyScopedRequest)(nil),        // 12: blockassembly_api.ResetBlockAssemblyScopedRequest
+	(*ResetBlockAssemblyScopedResponse)(nil),       // 13: blockassembly_api.ResetBlockAssemblyScopedResponse
+	(*OKResponse)(nil),                             // 14: blockassembly_api.OKResponse
+	(*StateMessage)(nil),                           // 15: blockassembly_api.StateMessage
+	(*StateNotification)(nil),                      // 16: blockassembly_api.StateNotification
+	(*ReorgStatus)(nil),                            // 17: blockassembly_api.ReorgStatus
+	(*GetReorgStatusResponse)(nil),                 // 18: blockassembly_api.GetReorgStatusResponse
+	(*GetCurrentDifficultyResponse)(nil),           // 19: blockassembly_api.GetCurrentDifficultyResponse
+	(*GenerateBlocksRequest)(nil),                  // 20: blockassembly_api.GenerateBlocksRequest
+	(*GetBlockAssemblyBlockCandidateResponse)(nil), // 21: blockassembly_api.GetBlockAssemblyBlockCandidateResponse
+	(*GetBlockAssemblyTxsResponse)(nil),            // 22: blockassembly_api.GetBlockAssemblyTxsResponse
+	(*CoinbaseOutputTemplate)(nil),                 // 23: blockassembly_api.CoinbaseOutputTemplate
+	(*CoinbaseTemplate)(nil),                       // 24: blockassembly_api.CoinbaseTemplate
+	(*timestamppb.Timestamp)(nil),                  // 25: google.protobuf.Timestamp
+	(*model.MiningCandidate)(nil),                  // 26: model.MiningCandidate
 }
 var file_services_blockassembly_blockassembly_api_blockassembly_api_proto_depIdxs = []int32{
-	16, // 0: blockassembly_api.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
+	25, // 0: blockassembly_api.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
 	3,  // 1: blockassembly_api.AddTxBatchRequest.txRequests:type_name -> blockassembly_api.AddTxRequest
-	0,  // 2: blockassembly_api.BlockAssemblyAPI.HealthGRPC:input_type -> blockassembly_api.EmptyMessage
-	3,  // 3: blockassembly_api.BlockAssemblyAPI.AddTx:input_type -> blockassembly_api.AddTxRequest
-	6,  // 4: blockassembly_api.BlockAssemblyAPI.RemoveTx:input_type -> blockassembly_api.RemoveTxRequest
-	4,  // 5: blockassembly_api.BlockAssemblyAPI.AddTxBatch:input_type -> blockassembly_api.AddTxBatchRequest
-	5,  // 6: blockassembly_api.BlockAssemblyAPI.GetMiningCandidate:input_type -> blockassembly_api.GetMiningCandidateRequest
-	0,  // 7: blockassembly_api.BlockAssemblyAPI.GetCurrentDifficulty:input_type -> blockassembly_api.EmptyMessage
-	9,  // 8: blockassembly_api.BlockAssemblyAPI.SubmitMiningSolution:input_type -> blockassembly_api.SubmitMiningSolutionRequest
-	0,  // 9: blockassembly_api.BlockAssemblyAPI.ResetBlockAssembly:input_type -> blockassembly_api.EmptyMessage
-	0,  // 10: blockassembly_api.BlockAssemblyAPI.ResetBlockAssemblyFully:input_type -> blockassembly_api.EmptyMessage
-	0,  // 11: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyState:input_type -> blockassembly_api.EmptyMessage
-	13, // 12: blockassembly_api.BlockAssemblyAPI.GenerateBlocks:input_type -> blockassembly_api.GenerateBlocksRequest
-	0,  // 13: blockassembly_api.BlockAssemblyAPI.CheckBlockAssembly:input_type -> blockassembly_api.EmptyMessage
-	0,  // 14: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyBlockCandidate:input_type -> blockassembly_api.EmptyMessage
-	0,  // 15: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyTxs:input_type -> blockassembly_api.EmptyMessage
-	1,  // 16: blockassembly_api.BlockAssemblyAPI.HealthGRPC:output_type -> blockassembly_api.HealthResponse
-	7,  // 17: blockassembly_api.BlockAssemblyAPI.AddTx:output_type -> blockassembly_api.AddTxResponse
-	0,  // 18: blockassembly_api.BlockAssemblyAPI.RemoveTx:output_type -> blockassembly_api.EmptyMessage
-	8,  // 19: blockassembly_api.BlockAssemblyAPI.AddTxBatch:output_type -> blockassembly_api.AddTxBatchResponse
-	17, // 20: blockassembly_api.BlockAssemblyAPI.GetMiningCandidate:output_type -> model.MiningCandidate
-	12, // 21: blockassembly_api.BlockAssemblyAPI.GetCurrentDifficulty:output_type -> blockassembly_api.GetCurrentDifficultyResponse
-	10, // 22: blockassembly_api.BlockAssemblyAPI.SubmitMiningSolution:output_type -> blockassembly_api.OKResponse
-	0,  // 23: blockassembly_api.BlockAssemblyAPI.ResetBlockAssembly:output_type -> blockassembly_api.EmptyMessage
-	0,  // 24: blockassembly_api.BlockAssemblyAPI.ResetBlockAssemblyFully:output_type -> blockassembly_api.EmptyMessage
-	11, // 25: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyState:output_type -> blockassembly_api.StateMessage
-	0,  // 26: blockassembly_api.BlockAssemblyAPI.GenerateBlocks:output_type -> blockassembly_api.EmptyMessage
-	10, // 27: blockassembly_api.BlockAssemblyAPI.CheckBlockAssembly:output_type -> blockassembly_api.OKResponse
-	14, // 28: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyBlockCandidate:output_type -> blockassembly_api.GetBlockAssemblyBlockCandidateResponse
-	15, // 29: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyTxs:output_type -> blockassembly_api.GetBlockAssemblyTxsResponse
-	16, // [16:30] is the sub-list for method output_type
-	2,  // [2:16] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	26, // 2: blockassembly_api.GetMiningCandidateDiffResponse.candidate:type_name -> model.MiningCandidate
+	25, // 3: blockassembly_api.ReorgStatus.started_at:type_name -> google.protobuf.Timestamp
+	25, // 4: blockassembly_api.ReorgStatus.phase_started_at:type_name -> google.protobuf.Timestamp
+	25, // 5: blockassembly_api.ReorgStatus.completed_at:type_name -> google.protobuf.Timestamp
+	17, // 6: blockassembly_api.GetReorgStatusResponse.current:type_name -> blockassembly_api.ReorgStatus
+	17, // 7: blockassembly_api.GetReorgStatusResponse.last:type_name -> blockassembly_api.ReorgStatus
+	23, // 8: blockassembly_api.CoinbaseTemplate.outputs:type_name -> blockassembly_api.CoinbaseOutputTemplate
+	0,  // 9: blockassembly_api.BlockAssemblyAPI.HealthGRPC:input_type -> blockassembly_api.EmptyMessage
+	3,  // 10: blockassembly_api.BlockAssemblyAPI.AddTx:input_type -> blockassembly_api.AddTxRequest
+	8,  // 11: blockassembly_api.BlockAssemblyAPI.RemoveTx:input_type -> blockassembly_api.RemoveTxRequest
+	4,  // 12: blockassembly_api.BlockAssemblyAPI.AddTxBatch:input_type -> blockassembly_api.AddTxBatchRequest
+	5,  // 13: blockassembly_api.BlockAssemblyAPI.GetMiningCandidate:input_type -> blockassembly_api.GetMiningCandidateRequest
+	6,  // 14: blockassembly_api.BlockAssemblyAPI.GetMiningCandidateDiff:input_type -> blockassembly_api.GetMiningCandidateDiffRequest
+	0,  // 15: blockassembly_api.BlockAssemblyAPI.GetCurrentDifficulty:input_type -> blockassembly_api.EmptyMessage
+	11, // 16: blockassembly_api.BlockAssemblyAPI.SubmitMiningSolution:input_type -> blockassembly_api.SubmitMiningSolutionRequest
+	0,  // 17: blockassembly_api.BlockAssemblyAPI.ResetBlockAssembly:input_type -> blockassembly_api.EmptyMessage
+	0,  // 18: blockassembly_api.BlockAssemblyAPI.ResetBlockAssemblyFully:input_type -> blockassembly_api.EmptyMessage
+	12, // 19: blockassembly_api.BlockAssemblyAPI.ResetBlockAssemblyScoped:input_type -> blockassembly_api.ResetBlockAssemblyScopedRequest
+	0,  // 20: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyState:input_type -> blockassembly_api.EmptyMessage
+	0,  // 21: blockassembly_api.BlockAssemblyAPI.SubscribeState:input_type -> blockassembly_api.EmptyMessage
+	0,  // 22: blockassembly_api.BlockAssemblyAPI.GetReorgStatus:input_type -> blockassembly_api.EmptyMessage
+	20, // 23: blockassembly_api.BlockAssemblyAPI.GenerateBlocks:input_type -> blockassembly_api.GenerateBlocksRequest
+	0,  // 24: blockassembly_api.BlockAssemblyAPI.CheckBlockAssembly:input_type -> blockassembly_api.EmptyMessage
+	0,  // 25: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyBlockCandidate:input_type -> blockassembly_api.EmptyMessage
+	0,  // 26: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyTxs:input_type -> blockassembly_api.EmptyMessage
+	0,  // 27: blockassembly_api.BlockAssemblyAPI.GetCoinbaseTemplate:input_type -> blockassembly_api.EmptyMessage
+	24, // 28: blockassembly_api.BlockAssemblyAPI.SetCoinbaseTemplate:input_type -> blockassembly_api.CoinbaseTemplate
+	1,  // 29: blockassembly_api.BlockAssemblyAPI.HealthGRPC:output_type -> blockassembly_api.HealthResponse
+	9,  // 30: blockassembly_api.BlockAssemblyAPI.AddTx:output_type -> blockassembly_api.AddTxResponse
+	0,  // 31: blockassembly_api.BlockAssemblyAPI.RemoveTx:output_type -> blockassembly_api.EmptyMessage
+	10, // 32: blockassembly_api.BlockAssemblyAPI.AddTxBatch:output_type -> blockassembly_api.AddTxBatchResponse
+	26, // 33: blockassembly_api.BlockAssemblyAPI.GetMiningCandidate:output_type -> model.MiningCandidate
+	7,  // 34: blockassembly_api.BlockAssemblyAPI.GetMiningCandidateDiff:output_type -> blockassembly_api.GetMiningCandidateDiffResponse
+	19, // 35: blockassembly_api.BlockAssemblyAPI.GetCurrentDifficulty:output_type -> blockassembly_api.GetCurrentDifficultyResponse
+	14, // 36: blockassembly_api.BlockAssemblyAPI.SubmitMiningSolution:output_type -> blockassembly_api.OKResponse
+	0,  // 37: blockassembly_api.BlockAssemblyAPI.ResetBlockAssembly:output_type -> blockassembly_api.EmptyMessage
+	0,  // 38: blockassembly_api.BlockAssemblyAPI.ResetBlockAssemblyFully:output_type -> blockassembly_api.EmptyMessage
+	13, // 39: blockassembly_api.BlockAssemblyAPI.ResetBlockAssemblyScoped:output_type -> blockassembly_api.ResetBlockAssemblyScopedResponse
+	15, // 40: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyState:output_type -> blockassembly_api.StateMessage
+	16, // 41: blockassembly_api.BlockAssemblyAPI.SubscribeState:output_type -> blockassembly_api.StateNotification
+	18, // 42: blockassembly_api.BlockAssemblyAPI.GetReorgStatus:output_type -> blockassembly_api.GetReorgStatusResponse
+	0,  // 43: blockassembly_api.BlockAssemblyAPI.GenerateBlocks:output_type -> blockassembly_api.EmptyMessage
+	14, // 44: blockassembly_api.BlockAssemblyAPI.CheckBlockAssembly:output_type -> blockassembly_api.OKResponse
+	21, // 45: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyBlockCandidate:output_type -> blockassembly_api.GetBlockAssemblyBlockCandidateResponse
+	22, // 46: blockassembly_api.BlockAssemblyAPI.GetBlockAssemblyTxs:output_type -> blockassembly_api.GetBlockAssemblyTxsResponse
+	24, // 47: blockassembly_api.BlockAssemblyAPI.GetCoinbaseTemplate:output_type -> blockassembly_api.CoinbaseTemplate
+	24, // 48: blockassembly_api.BlockAssemblyAPI.SetCoinbaseTemplate:output_type -> blockassembly_api.CoinbaseTemplate
+	29, // [29:49] is the sub-list for method output_type
+	9,  // [9:29] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_services_blockassembly_blockassembly_api_blockassembly_api_proto_init() }
@@ -1089,15 +1806,15 @@ func file_services_blockassembly_blockassembly_api_blockassembly_api_proto_init(
 	if File_services_blockassembly_blockassembly_api_blockassembly_api_proto != nil {
 		return
 	}
-	file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[9].OneofWrappers = []any{}
-	file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[13].OneofWrappers = []any{}
+	file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[11].OneofWrappers = []any{}
+	file_services_blockassembly_blockassembly_api_blockassembly_api_proto_msgTypes[20].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDesc), len(file_services_blockassembly_blockassembly_api_blockassembly_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,15 +25,21 @@ const (
 	BlockAssemblyAPI_RemoveTx_FullMethodName                       = "/blockassembly_api.BlockAssemblyAPI/RemoveTx"
 	BlockAssemblyAPI_AddTxBatch_FullMethodName                     = "/blockassembly_api.BlockAssemblyAPI/AddTxBatch"
 	BlockAssemblyAPI_GetMiningCandidate_FullMethodName             = "/blockassembly_api.BlockAssemblyAPI/GetMiningCandidate"
+	BlockAssemblyAPI_GetMiningCandidateDiff_FullMethodName         = "/blockassembly_api.BlockAssemblyAPI/GetMiningCandidateDiff"
 	BlockAssemblyAPI_GetCurrentDifficulty_FullMethodName           = "/blockassembly_api.BlockAssemblyAPI/GetCurrentDifficulty"
 	BlockAssemblyAPI_SubmitMiningSolution_FullMethodName           = "/blockassembly_api.BlockAssemblyAPI/SubmitMiningSolution"
 	BlockAssemblyAPI_ResetBlockAssembly_FullMethodName             = "/blockassembly_api.BlockAssemblyAPI/ResetBlockAssembly"
 	BlockAssemblyAPI_ResetBlockAssemblyFully_FullMethodName        = "/blockassembly_api.BlockAssemblyAPI/ResetBlockAssemblyFully"
+	BlockAssemblyAPI_ResetBlockAssemblyScoped_FullMethodName       = "/blockassembly_api.BlockAssemblyAPI/ResetBlockAssemblyScoped"
 	BlockAssemblyAPI_GetBlockAssemblyState_FullMethodName          = "/blockassembly_api.BlockAssemblyAPI/GetBlockAssemblyState"
+	BlockAssemblyAPI_SubscribeState_FullMethodName                 = "/blockassembly_api.BlockAssemblyAPI/SubscribeState"
+	BlockAssemblyAPI_GetReorgStatus_FullMethodName                 = "/blockassembly_api.BlockAssemblyAPI/GetReorgStatus"
 	BlockAssemblyAPI_GenerateBlocks_FullMethodName                 = "/blockassembly_api.BlockAssemblyAPI/GenerateBlocks"
 	BlockAssemblyAPI_CheckBlockAssembly_FullMethodName             = "/blockassembly_api.BlockAssemblyAPI/CheckBlockAssembly"
 	BlockAssemblyAPI_GetBlockAssemblyBlockCandidate_FullMethodName = "/blockassembly_api.BlockAssemblyAPI/GetBlockAssemblyBlockCandidate"
 	BlockAssemblyAPI_GetBlockAssemblyTxs_FullMethodName            = "/blockassembly_api.BlockAssemblyAPI/GetBlockAssemblyTxs"
+	BlockAssemblyAPI_GetCoinbaseTemplate_FullMethodName            = "/blockassembly_api.BlockAssemblyAPI/GetCoinbaseTemplate"
+	BlockAssemblyAPI_SetCoinbaseTemplate_FullMethodName            = "/blockassembly_api.BlockAssemblyAPI/SetCoinbaseTemplate"
 )
 
 // BlockAssemblyAPIClient is the client API for BlockAssemblyAPI service.
@@ -60,6 +66,9 @@ type BlockAssemblyAPIClient interface {
 	// GetMiningCandidate retrieves a block template ready for mining.
 	// Includes all necessary components for miners to begin work.
 	GetMiningCandidate(ctx context.Context, in *GetMiningCandidateRequest, opts ...grpc.CallOption) (*model.MiningCandidate, error)
+	// GetMiningCandidateDiff retrieves a block template ready for mining with only the subtrees added and removed
+	// since a candidate the client already has, so clients polling frequently do not download all subtree hashes.
+	GetMiningCandidateDiff(ctx context.Context, in *GetMiningCandidateDiffRequest, opts ...grpc.CallOption) (*GetMiningCandidateDiffResponse, error)
 	// GetCurrentDifficulty retrieves the current network mining difficulty.
 	// Used by miners to understand the current mining requirements.
 	GetCurrentDifficulty(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*GetCurrentDifficultyResponse, error)
@@ -73,9 +82,19 @@ type BlockAssemblyAPIClient interface {
 	// This includes clearing all transactions and resetting internal structures.
 	// This will traverse the whole UTXO set and is more intensive than a standard reset.
 	ResetBlockAssemblyFully(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*EmptyMessage, error)
+	// ResetBlockAssemblyScoped repairs the unmined state of the transactions of a height range of the main chain
+	// or of a fork, and then resets the block assembly state, reloading the unmined transactions from the UTXO store.
+	// This is cheaper than a full reset when the divergence is known to be limited to a few blocks.
+	ResetBlockAssemblyScoped(ctx context.Context, in *ResetBlockAssemblyScopedRequest, opts ...grpc.CallOption) (*ResetBlockAssemblyScopedResponse, error)
 	// GetBlockAssemblyState retrieves the current state of block assembly.
 	// Provides detailed information about the assembly process status.
 	GetBlockAssemblyState(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*StateMessage, error)
+	// SubscribeState streams the chaintip of block assembly.
+	// The current chaintip is sent first, followed by a notification every time it changes.
+	SubscribeState(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StateNotification], error)
+	// GetReorgStatus retrieves the progress of the reorg being coordinated by block assembly, if any, and the outcome
+	// of the last completed reorg.
+	GetReorgStatus(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*GetReorgStatusResponse, error)
 	// GenerateBlocks creates new blocks (typically for testing purposes).
 	// Allows specification of block count and recipient address.
 	GenerateBlocks(ctx context.Context, in *GenerateBlocksRequest, opts ...grpc.CallOption) (*EmptyMessage, error)
@@ -89,6 +108,12 @@ type BlockAssemblyAPIClient interface {
 	// This provides visibility into the transactions that are candidates for inclusion in the next block.
 	// NOTE: this method is primarily for debugging purposes and may not be suitable for production use.
 	GetBlockAssemblyTxs(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*GetBlockAssemblyTxsResponse, error)
+	// GetCoinbaseTemplate retrieves the coinbase template used for the coinbase transactions created by block assembly.
+	// An empty template means the coinbase pays to the addresses of the miner wallet keys.
+	GetCoinbaseTemplate(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*CoinbaseTemplate, error)
+	// SetCoinbaseTemplate validates and sets the coinbase template at runtime, an empty template reverts to the
+	// addresses of the miner wallet keys. Protected by the admin API key when one is configured.
+	SetCoinbaseTemplate(ctx context.Context, in *CoinbaseTemplate, opts ...grpc.CallOption) (*CoinbaseTemplate, error)
 }
 
 type blockAssemblyAPIClient struct {
@@ -149,6 +174,16 @@ func (c *blockAssemblyAPIClient) GetMiningCandidate(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *blockAssemblyAPIClient) GetMiningCandidateDiff(ctx context.Context, in *GetMiningCandidateDiffRequest, opts ...grpc.CallOption) (*GetMiningCandidateDiffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMiningCandidateDiffResponse)
+	err := c.cc.Invoke(ctx, BlockAssemblyAPI_GetMiningCandidateDiff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockAssemblyAPIClient) GetCurrentDifficulty(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*GetCurrentDifficultyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetCurrentDifficultyResponse)
@@ -189,6 +224,16 @@ func (c *blockAssemblyAPIClient) ResetBlockAssemblyFully(ctx context.Context, in
 	return out, nil
 }
 
+func (c *blockAssemblyAPIClient) ResetBlockAssemblyScoped(ctx context.Context, in *ResetBlockAssemblyScopedRequest, opts ...grpc.CallOption) (*ResetBlockAssemblyScopedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetBlockAssemblyScopedResponse)
+	err := c.cc.Invoke(ctx, BlockAssemblyAPI_ResetBlockAssemblyScoped_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockAssemblyAPIClient) GetBlockAssemblyState(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*StateMessage, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StateMessage)
@@ -199,6 +244,35 @@ func (c *blockAssemblyAPIClient) GetBlockAssemblyState(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *blockAssemblyAPIClient) SubscribeState(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StateNotification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BlockAssemblyAPI_ServiceDesc.Streams[0], BlockAssemblyAPI_SubscribeState_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EmptyMessage, StateNotification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlockAssemblyAPI_SubscribeStateClient = grpc.ServerStreamingClient[StateNotification]
+
+func (c *blockAssemblyAPIClient) GetReorgStatus(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*GetReorgStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReorgStatusResponse)
+	err := c.cc.Invoke(ctx, BlockAssemblyAPI_GetReorgStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockAssemblyAPIClient) GenerateBlocks(ctx context.Context, in *GenerateBlocksRequest, opts ...grpc.CallOption) (*EmptyMessage, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(EmptyMessage)
@@ -239,6 +313,26 @@ func (c *blockAssemblyAPIClient) GetBlockAssemblyTxs(ctx context.Context, in *Em
 	return out, nil
 }
 
+func (c *blockAssemblyAPIClient) GetCoinbaseTemplate(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*CoinbaseTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoinbaseTemplate)
+	err := c.cc.Invoke(ctx, BlockAssemblyAPI_GetCoinbaseTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockAssemblyAPIClient) SetCoinbaseTemplate(ctx context.Context, in *CoinbaseTemplate, opts ...grpc.CallOption) (*CoinbaseTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoinbaseTemplate)
+	err := c.cc.Invoke(ctx, BlockAssemblyAPI_SetCoinbaseTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlockAssemblyAPIServer is the server API for BlockAssemblyAPI service.
 // All implementations must embed UnimplementedBlockAssemblyAPIServer
 // for forward compatibility.
@@ -263,6 +357,9 @@ type BlockAssemblyAPIServer interface {
 	// GetMiningCandidate retrieves a block template ready for mining.
 	// Includes all necessary components for miners to begin work.
 	GetMiningCandidate(context.Context, *GetMiningCandidateRequest) (*model.MiningCandidate, error)
+	// GetMiningCandidateDiff retrieves a block template ready for mining with only the subtrees added and removed
+	// since a candidate the client already has, so clients polling frequently do not download all subtree hashes.
+	GetMiningCandidateDiff(context.Context, *GetMiningCandidateDiffRequest) (*GetMiningCandidateDiffResponse, error)
 	// GetCurrentDifficulty retrieves the current network mining difficulty.
 	// Used by miners to understand the current mining requirements.
 	GetCurrentDifficulty(context.Context, *EmptyMessage) (*GetCurrentDifficultyResponse, error)
@@ -276,9 +373,19 @@ type BlockAssemblyAPIServer interface {
 	// This includes clearing all transactions and resetting internal structures.
 	// This will traverse the whole UTXO set and is more intensive than a standard reset.
 	ResetBlockAssemblyFully(context.Context, *EmptyMessage) (*EmptyMessage, error)
+	// ResetBlockAssemblyScoped repairs the unmined state of the transactions of a height range of the main chain
+	// or of a fork, and then resets the block assembly state, reloading the unmined transactions from the UTXO store.
+	// This is cheaper than a full reset when the divergence is known to be limited to a few blocks.
+	ResetBlockAssemblyScoped(context.Context, *ResetBlockAssemblyScopedRequest) (*ResetBlockAssemblyScopedResponse, error)
 	// GetBlockAssemblyState retrieves the current state of block assembly.
 	// Provides detailed information about the assembly process status.
 	GetBlockAssemblyState(context.Context, *EmptyMessage) (*StateMessage, error)
+	// SubscribeState streams the chaintip of block assembly.
+	// The current chaintip is sent first, followed by a notification every time it changes.
+	SubscribeState(*EmptyMessage, grpc.ServerStreamingServer[StateNotification]) error
+	// GetReorgStatus retrieves the progress of the reorg being coordinated by block assembly, if any, and the outcome
+	// of the last completed reorg.
+	GetReorgStatus(context.Context, *EmptyMessage) (*GetReorgStatusResponse, error)
 	// GenerateBlocks creates new blocks (typically for testing purposes).
 	// Allows specification of block count and recipient address.
 	GenerateBlocks(context.Context, *GenerateBlocksRequest) (*EmptyMessage, error)
@@ -292,6 +399,12 @@ type BlockAssemblyAPIServer interface {
 	// This provides visibility into the transactions that are candidates for inclusion in the next block.
 	// NOTE: this method is primarily for debugging purposes and may not be suitable for production use.
 	GetBlockAssemblyTxs(context.Context, *EmptyMessage) (*GetBlockAssemblyTxsResponse, error)
+	// GetCoinbaseTemplate retrieves the coinbase template used for the coinbase transactions created by block assembly.
+	// An empty template means the coinbase pays to the addresses of the miner wallet keys.
+	GetCoinbaseTemplate(context.Context, *EmptyMessage) (*CoinbaseTemplate, error)
+	// SetCoinbaseTemplate validates and sets the coinbase template at runtime, an empty template reverts to the
+	// addresses of the miner wallet keys. Protected by the admin API key when one is configured.
+	SetCoinbaseTemplate(context.Context, *CoinbaseTemplate) (*CoinbaseTemplate, error)
 	mustEmbedUnimplementedBlockAssemblyAPIServer()
 }
 
@@ -317,6 +430,9 @@ func (UnimplementedBlockAssemblyAPIServer) AddTxBatch(context.Context, *AddTxBat
 func (UnimplementedBlockAssemblyAPIServer) GetMiningCandidate(context.Context, *GetMiningCandidateRequest) (*model.MiningCandidate, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMiningCandidate not implemented")
 }
+func (UnimplementedBlockAssemblyAPIServer) GetMiningCandidateDiff(context.Context, *GetMiningCandidateDiffRequest) (*GetMiningCandidateDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMiningCandidateDiff not implemented")
+}
 func (UnimplementedBlockAssemblyAPIServer) GetCurrentDifficulty(context.Context, *EmptyMessage) (*GetCurrentDifficultyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentDifficulty not implemented")
 }
@@ -329,9 +445,18 @@ func (UnimplementedBlockAssemblyAPIServer) ResetBlockAssembly(context.Context, *
 func (UnimplementedBlockAssemblyAPIServer) ResetBlockAssemblyFully(context.Context, *EmptyMessage) (*EmptyMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResetBlockAssemblyFully not implemented")
 }
+func (UnimplementedBlockAssemblyAPIServer) ResetBlockAssemblyScoped(context.Context, *ResetBlockAssemblyScopedRequest) (*ResetBlockAssemblyScopedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetBlockAssemblyScoped not implemented")
+}
 func (UnimplementedBlockAssemblyAPIServer) GetBlockAssemblyState(context.Context, *EmptyMessage) (*StateMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockAssemblyState not implemented")
 }
+func (UnimplementedBlockAssemblyAPIServer) SubscribeState(*EmptyMessage, grpc.ServerStreamingServer[StateNotification]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeState not implemented")
+}
+func (UnimplementedBlockAssemblyAPIServer) GetReorgStatus(context.Context, *EmptyMessage) (*GetReorgStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReorgStatus not implemented")
+}
 func (UnimplementedBlockAssemblyAPIServer) GenerateBlocks(context.Context, *GenerateBlocksRequest) (*EmptyMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateBlocks not implemented")
 }
@@ -344,6 +469,12 @@ func (UnimplementedBlockAssemblyAPIServer) GetBlockAssemblyBlockCandidate(contex
 func (UnimplementedBlockAssemblyAPIServer) GetBlockAssemblyTxs(context.Context, *EmptyMessage) (*GetBlockAssemblyTxsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockAssemblyTxs not implemented")
 }
+func (UnimplementedBlockAssemblyAPIServer) GetCoinbaseTemplate(context.Context, *EmptyMessage) (*CoinbaseTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCoinbaseTemplate not implemented")
+}
+func (UnimplementedBlockAssemblyAPIServer) SetCoinbaseTemplate(context.Context, *CoinbaseTemplate) (*CoinbaseTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCoinbaseTemplate not implemented")
+}
 func (UnimplementedBlockAssemblyAPIServer) mustEmbedUnimplementedBlockAssemblyAPIServer() {}
 func (UnimplementedBlockAssemblyAPIServer) testEmbeddedByValue()                          {}
 
@@ -455,6 +586,24 @@ func _BlockAssemblyAPI_GetMiningCandidate_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockAssemblyAPI_GetMiningCandidateDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMiningCandidateDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockAssemblyAPIServer).GetMiningCandidateDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockAssemblyAPI_GetMiningCandidateDiff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockAssemblyAPIServer).GetMiningCandidateDiff(ctx, req.(*GetMiningCandidateDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockAssemblyAPI_GetCurrentDifficulty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EmptyMessage)
 	if err := dec(in); err != nil {
@@ -527,6 +676,24 @@ func _BlockAssemblyAPI_ResetBlockAssemblyFully_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockAssemblyAPI_ResetBlockAssemblyScoped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetBlockAssemblyScopedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockAssemblyAPIServer).ResetBlockAssemblyScoped(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockAssemblyAPI_ResetBlockAssemblyScoped_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockAssemblyAPIServer).ResetBlockAssemblyScoped(ctx, req.(*ResetBlockAssemblyScopedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockAssemblyAPI_GetBlockAssemblyState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EmptyMessage)
 	if err := dec(in); err != nil {
@@ -545,6 +712,35 @@ func _BlockAssemblyAPI_GetBlockAssemblyState_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockAssemblyAPI_SubscribeState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmptyMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockAssemblyAPIServer).SubscribeState(m, &grpc.GenericServerStream[EmptyMessage, StateNotification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlockAssemblyAPI_SubscribeStateServer = grpc.ServerStreamingServer[StateNotification]
+
+func _BlockAssemblyAPI_GetReorgStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockAssemblyAPIServer).GetReorgStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockAssemblyAPI_GetReorgStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockAssemblyAPIServer).GetReorgStatus(ctx, req.(*EmptyMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockAssemblyAPI_GenerateBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenerateBlocksRequest)
 	if err := dec(in); err != nil {
@@ -617,6 +813,42 @@ func _BlockAssemblyAPI_GetBlockAssemblyTxs_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockAssemblyAPI_GetCoinbaseTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockAssemblyAPIServer).GetCoinbaseTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockAssemblyAPI_GetCoinbaseTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockAssemblyAPIServer).GetCoinbaseTemplate(ctx, req.(*EmptyMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockAssemblyAPI_SetCoinbaseTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoinbaseTemplate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockAssemblyAPIServer).SetCoinbaseTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockAssemblyAPI_SetCoinbaseTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockAssemblyAPIServer).SetCoinbaseTemplate(ctx, req.(*CoinbaseTemplate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BlockAssemblyAPI_ServiceDesc is the grpc.ServiceDesc for BlockAssemblyAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -644,6 +876,10 @@ var BlockAssemblyAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMiningCandidate",
 			Handler:    _BlockAssemblyAPI_GetMiningCandidate_Handler,
 		},
+		{
+			MethodName: "GetMiningCandidateDiff",
+			Handler:    _BlockAssemblyAPI_GetMiningCandidateDiff_Handler,
+		},
 		{
 			MethodName: "GetCurrentDifficulty",
 			Handler:    _BlockAssemblyAPI_GetCurrentDifficulty_Handler,
@@ -660,10 +896,18 @@ var BlockAssemblyAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResetBlockAssemblyFully",
 			Handler:    _BlockAssemblyAPI_ResetBlockAssemblyFully_Handler,
 		},
+		{
+			MethodName: "ResetBlockAssemblyScoped",
+			Handler:    _BlockAssemblyAPI_ResetBlockAssemblyScoped_Handler,
+		},
 		{
 			MethodName: "GetBlockAssemblyState",
 			Handler:    _BlockAssemblyAPI_GetBlockAssemblyState_Handler,
 		},
+		{
+			MethodName: "GetReorgStatus",
+			Handler:    _BlockAssemblyAPI_GetReorgStatus_Handler,
+		},
 		{
 			MethodName: "GenerateBlocks",
 			Handler:    _BlockAssemblyAPI_GenerateBlocks_Handler,
@@ -680,7 +924,21 @@ var BlockAssemblyAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockAssemblyTxs",
 			Handler:    _BlockAssemblyAPI_GetBlockAssemblyTxs_Handler,
 		},
+		{
+			MethodName: "GetCoinbaseTemplate",
+			Handler:    _BlockAssemblyAPI_GetCoinbaseTemplate_Handler,
+		},
+		{
+			MethodName: "SetCoinbaseTemplate",
+			Handler:    _BlockAssemblyAPI_SetCoinbaseTemplate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeState",
+			Handler:       _BlockAssemblyAPI_SubscribeState_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/blockassembly/blockassembly_api/blockassembly_api.proto",
 }
@@ -0,0 +1,85 @@
+package blockassembly
+
+import (
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/blockassembly_api"
+	"github.com/bsv-blockchain/teranode/settings"
+)
+
+// setCoinbaseTemplateMethod is the full gRPC method path of SetCoinbaseTemplate, protected by the admin API key
+const setCoinbaseTemplateMethod = "/blockassembly_api.BlockAssemblyAPI/SetCoinbaseTemplate"
+
+// coinbaseTemplateFromSettings returns the validated coinbase template of the settings, or nil when no coinbase
+// outputs are configured and the coinbase pays to the addresses of the miner wallet keys
+func coinbaseTemplateFromSettings(tSettings *settings.Settings) (*model.CoinbaseTemplate, error) {
+	template, err := model.ParseCoinbaseTemplate(tSettings.BlockAssembly.CoinbaseOutputs, tSettings.BlockAssembly.CoinbaseMinerTag)
+	if err != nil {
+		return nil, err
+	}
+
+	if template == nil {
+		return nil, nil
+	}
+
+	if err = template.Validate(tSettings.ChainCfgParams); err != nil {
+		return nil, errors.NewConfigurationError("invalid blockassembly_coinbaseOutputs or blockassembly_coinbaseMinerTag setting", err)
+	}
+
+	return template, nil
+}
+
+// coinbaseTemplateFromAPI converts a coinbase template of the API, returning nil for a template without outputs
+// and miner tag
+func coinbaseTemplateFromAPI(apiTemplate *blockassembly_api.CoinbaseTemplate) *model.CoinbaseTemplate {
+	if len(apiTemplate.GetOutputs()) == 0 && apiTemplate.GetMinerTag() == "" {
+		return nil
+	}
+
+	template := &model.CoinbaseTemplate{
+		Outputs:  make([]model.CoinbaseOutputTemplate, 0, len(apiTemplate.GetOutputs())),
+		MinerTag: apiTemplate.GetMinerTag(),
+	}
+
+	for _, output := range apiTemplate.GetOutputs() {
+		template.Outputs = append(template.Outputs, model.CoinbaseOutputTemplate{
+			Address:     output.GetAddress(),
+			BasisPoints: output.GetBasisPoints(),
+		})
+	}
+
+	return template
+}
+
+// coinbaseTemplateToAPI converts a coinbase template to the API, a nil template is an empty template
+func coinbaseTemplateToAPI(template *model.CoinbaseTemplate) *blockassembly_api.CoinbaseTemplate {
+	apiTemplate := &blockassembly_api.CoinbaseTemplate{}
+
+	if template == nil {
+		return apiTemplate
+	}
+
+	apiTemplate.MinerTag = template.MinerTag
+	apiTemplate.Outputs = make([]*blockassembly_api.CoinbaseOutputTemplate, 0, len(template.Outputs))
+
+	for _, output := range template.Outputs {
+		apiTemplate.Outputs = append(apiTemplate.Outputs, &blockassembly_api.CoinbaseOutputTemplate{
+			Address:     output.Address,
+			BasisPoints: output.BasisPoints,
+		})
+	}
+
+	return apiTemplate
+}
+
+// createCoinbaseTx creates the coinbase transaction of a mining candidate from the current coinbase template, or
+// paying to the addresses of the miner wallet keys when no template is set
+func (ba *BlockAssembly) createCoinbaseTx(candidate *model.MiningCandidate) (*bt.Tx, error) {
+	template := ba.coinbaseTemplate.Load()
+	if template == nil {
+		return candidate.CreateCoinbaseTxCandidate(ba.settings)
+	}
+
+	return model.CreateCoinbaseFromTemplate(candidate.Height, candidate.CoinbaseValue, ba.settings.Coinbase.ArbitraryText, template)
+}
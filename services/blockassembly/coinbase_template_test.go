@@ -0,0 +1,139 @@
+package blockassembly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/bscript"
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/blockassembly_api"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCoinbaseAddress returns a P2PKH address of a new key for the test networks
+func testCoinbaseAddress(t *testing.T) string {
+	t.Helper()
+
+	privateKey, err := primitives.NewPrivateKey()
+	require.NoError(t, err)
+
+	address, err := bscript.NewAddressFromPublicKey(privateKey.PubKey(), false)
+	require.NoError(t, err)
+
+	return address.AddressString
+}
+
+func TestCoinbaseTemplateFromSettings(t *testing.T) {
+	address := testCoinbaseAddress(t)
+
+	t.Run("not configured", func(t *testing.T) {
+		tSettings := settings.NewSettings()
+		tSettings.BlockAssembly.CoinbaseOutputs = nil
+		tSettings.BlockAssembly.CoinbaseMinerTag = ""
+
+		template, err := coinbaseTemplateFromSettings(tSettings)
+		require.NoError(t, err)
+		assert.Nil(t, template)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		tSettings := settings.NewSettings()
+		tSettings.BlockAssembly.CoinbaseOutputs = []string{address + ":100"}
+		tSettings.BlockAssembly.CoinbaseMinerTag = "/miner/"
+
+		template, err := coinbaseTemplateFromSettings(tSettings)
+		require.NoError(t, err)
+		assert.Equal(t, &model.CoinbaseTemplate{
+			Outputs:  []model.CoinbaseOutputTemplate{{Address: address, BasisPoints: 10000}},
+			MinerTag: "/miner/",
+		}, template)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tSettings := settings.NewSettings()
+		tSettings.BlockAssembly.CoinbaseOutputs = []string{address + ":50"}
+
+		_, err := coinbaseTemplateFromSettings(tSettings)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrConfiguration))
+	})
+}
+
+func TestCoinbaseTemplateAPIConversion(t *testing.T) {
+	template := &model.CoinbaseTemplate{
+		Outputs:  []model.CoinbaseOutputTemplate{{Address: "addr1", BasisPoints: 7500}, {Address: "addr2", BasisPoints: 2500}},
+		MinerTag: "/miner/",
+	}
+
+	assert.Equal(t, template, coinbaseTemplateFromAPI(coinbaseTemplateToAPI(template)))
+
+	// an empty template of the API is no template
+	assert.Nil(t, coinbaseTemplateFromAPI(coinbaseTemplateToAPI(nil)))
+}
+
+func TestSetCoinbaseTemplate(t *testing.T) {
+	server, _ := setupServer(t)
+	ctx := context.Background()
+
+	address1 := testCoinbaseAddress(t)
+	address2 := testCoinbaseAddress(t)
+
+	candidate := &model.MiningCandidate{Height: 10, CoinbaseValue: 5_000_000_000}
+
+	resp, err := server.GetCoinbaseTemplate(ctx, &blockassembly_api.EmptyMessage{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Outputs)
+
+	t.Run("valid template", func(t *testing.T) {
+		req := &blockassembly_api.CoinbaseTemplate{
+			Outputs: []*blockassembly_api.CoinbaseOutputTemplate{
+				{Address: address1, BasisPoints: 8000},
+				{Address: address2, BasisPoints: 2000},
+			},
+			MinerTag: "/miner/",
+		}
+
+		resp, err := server.SetCoinbaseTemplate(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, req.Outputs, resp.Outputs)
+
+		resp, err = server.GetCoinbaseTemplate(ctx, &blockassembly_api.EmptyMessage{})
+		require.NoError(t, err)
+		assert.Equal(t, "/miner/", resp.MinerTag)
+
+		coinbaseTx, err := server.createCoinbaseTx(candidate)
+		require.NoError(t, err)
+		require.Len(t, coinbaseTx.Outputs, 3)
+		assert.Equal(t, uint64(4_000_000_000), coinbaseTx.Outputs[0].Satoshis)
+		assert.Equal(t, uint64(1_000_000_000), coinbaseTx.Outputs[1].Satoshis)
+		assert.True(t, coinbaseTx.Outputs[2].LockingScript.IsData())
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := server.SetCoinbaseTemplate(ctx, &blockassembly_api.CoinbaseTemplate{
+			Outputs: []*blockassembly_api.CoinbaseOutputTemplate{{Address: address1, BasisPoints: 5000}},
+		})
+		require.Error(t, err)
+
+		// the previous template stays in use
+		resp, err := server.GetCoinbaseTemplate(ctx, &blockassembly_api.EmptyMessage{})
+		require.NoError(t, err)
+		assert.Len(t, resp.Outputs, 2)
+	})
+
+	t.Run("empty template", func(t *testing.T) {
+		resp, err := server.SetCoinbaseTemplate(ctx, &blockassembly_api.CoinbaseTemplate{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Outputs)
+		assert.Nil(t, server.coinbaseTemplate.Load())
+
+		// the coinbase pays to the miner wallet keys again
+		coinbaseTx, err := server.createCoinbaseTx(candidate)
+		require.NoError(t, err)
+		assert.Len(t, coinbaseTx.Outputs, len(server.settings.BlockAssembly.MinerWalletPrivateKeys))
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/bsv-blockchain/go-bt/v2/chainhash"
 	"github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/subtreeprocessor"
 )
 
 // Data represents transaction metadata used in block assembly.
@@ -25,6 +26,9 @@ type Data struct {
 
 	// Parents is a list of parent transaction hashes
 	TxInpoints subtree.TxInpoints
+
+	// Priority is the priority lane of the transaction in the block candidate, it is not serialized
+	Priority subtreeprocessor.TxPriority
 }
 
 // NewFromBytes deserializes a byte array into a Data structure.
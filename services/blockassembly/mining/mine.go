@@ -54,6 +54,21 @@ func Mine(ctx context.Context, tSettings *settings.Settings, candidate *model.Mi
 		}
 	}
 
+	return MineWithCoinbase(ctx, candidate, coinbaseTx)
+}
+
+// MineWithCoinbase attempts to mine a block using the provided mining candidate and coinbase transaction,
+// e.g. a coinbase created from a coinbase template.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - candidate: The mining candidate containing block template information
+//   - coinbaseTx: The coinbase transaction of the block
+//
+// Returns:
+//   - *model.MiningSolution: Contains the successful mining solution if found
+//   - error: Any error encountered during mining
+func MineWithCoinbase(ctx context.Context, candidate *model.MiningCandidate, coinbaseTx *bt.Tx) (*model.MiningSolution, error) {
 	merkleRoot := util.BuildMerkleRootFromCoinbase(coinbaseTx.TxIDChainHash().CloneBytes(), candidate.MerkleProof)
 
 	previousHash, _ := chainhash.NewHash(candidate.PreviousHash)
@@ -0,0 +1,59 @@
+package blockassembly
+
+import (
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+)
+
+// miningCandidateSubtreeDiff is the change of the subtrees of a mining candidate relative to a base candidate
+type miningCandidateSubtreeDiff struct {
+	added   [][]byte // root hashes of the subtrees added after the retained subtrees of the base candidate
+	removed [][]byte // root hashes of the subtrees of the base candidate that are not in the candidate
+	full    bool     // set when the candidate is not a diff of the base candidate, added holds all subtrees
+}
+
+// diffMiningCandidateSubtrees returns the subtrees added and removed from base to current. The subtrees of current
+// must be the subtrees of base without the removed subtrees, in the same order, followed by the added subtrees.
+// Otherwise, e.g. when subtrees were reordered, the diff is full.
+func diffMiningCandidateSubtrees(base, current []*subtreepkg.Subtree) miningCandidateSubtreeDiff {
+	currentHashes := make(map[chainhash.Hash]struct{}, len(current))
+	for _, st := range current {
+		currentHashes[*st.RootHash()] = struct{}{}
+	}
+
+	diff := miningCandidateSubtreeDiff{}
+	retained := 0
+
+	for _, st := range base {
+		rootHash := st.RootHash()
+
+		if _, ok := currentHashes[*rootHash]; !ok {
+			diff.removed = append(diff.removed, rootHash.CloneBytes())
+			continue
+		}
+
+		// the retained subtrees must lead the subtrees of current in the order of base
+		if retained >= len(current) || !current[retained].RootHash().IsEqual(rootHash) {
+			return fullMiningCandidateSubtreeDiff(current)
+		}
+
+		retained++
+	}
+
+	for _, st := range current[retained:] {
+		diff.added = append(diff.added, st.RootHash().CloneBytes())
+	}
+
+	return diff
+}
+
+// fullMiningCandidateSubtreeDiff returns a full diff adding all subtrees
+func fullMiningCandidateSubtreeDiff(current []*subtreepkg.Subtree) miningCandidateSubtreeDiff {
+	diff := miningCandidateSubtreeDiff{full: true, added: make([][]byte, 0, len(current))}
+
+	for _, st := range current {
+		diff.added = append(diff.added, st.RootHash().CloneBytes())
+	}
+
+	return diff
+}
@@ -0,0 +1,135 @@
+package blockassembly
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/blockassembly_api"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/subtreeprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDiffSubtrees returns subtrees with distinct root hashes
+func testDiffSubtrees(t *testing.T, count int) []*subtreepkg.Subtree {
+	t.Helper()
+
+	subtrees := make([]*subtreepkg.Subtree, count)
+
+	for i := range subtrees {
+		st, err := subtreepkg.NewTreeByLeafCount(4)
+		require.NoError(t, err)
+		require.NoError(t, st.AddNode(chainhash.HashH([]byte(fmt.Sprintf("diff-tx-%d", i))), 1, 1))
+
+		subtrees[i] = st
+	}
+
+	return subtrees
+}
+
+func TestDiffMiningCandidateSubtrees(t *testing.T) {
+	st := testDiffSubtrees(t, 4)
+	hash := func(i int) []byte { return st[i].RootHash().CloneBytes() }
+
+	t.Run("appended subtrees", func(t *testing.T) {
+		diff := diffMiningCandidateSubtrees(st[:2], st)
+		assert.False(t, diff.full)
+		assert.Equal(t, [][]byte{hash(2), hash(3)}, diff.added)
+		assert.Empty(t, diff.removed)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		diff := diffMiningCandidateSubtrees(st, st)
+		assert.False(t, diff.full)
+		assert.Empty(t, diff.added)
+		assert.Empty(t, diff.removed)
+	})
+
+	t.Run("removed and appended subtrees", func(t *testing.T) {
+		diff := diffMiningCandidateSubtrees([]*subtreepkg.Subtree{st[0], st[1], st[2]}, []*subtreepkg.Subtree{st[0], st[2], st[3]})
+		assert.False(t, diff.full)
+		assert.Equal(t, [][]byte{hash(3)}, diff.added)
+		assert.Equal(t, [][]byte{hash(1)}, diff.removed)
+	})
+
+	t.Run("reordered subtrees", func(t *testing.T) {
+		diff := diffMiningCandidateSubtrees([]*subtreepkg.Subtree{st[0], st[1]}, []*subtreepkg.Subtree{st[1], st[0]})
+		assert.True(t, diff.full)
+		assert.Equal(t, [][]byte{hash(1), hash(0)}, diff.added)
+		assert.Empty(t, diff.removed)
+	})
+
+	t.Run("subtree inserted before a retained subtree", func(t *testing.T) {
+		diff := diffMiningCandidateSubtrees([]*subtreepkg.Subtree{st[0], st[1]}, []*subtreepkg.Subtree{st[0], st[2], st[1]})
+		assert.True(t, diff.full)
+		assert.Len(t, diff.added, 3)
+	})
+}
+
+func TestGetMiningCandidateDiff(t *testing.T) {
+	server, _ := setupServer(t)
+	require.NoError(t, server.blockAssembler.Start(context.Background()))
+
+	ctx := context.Background()
+
+	t.Run("without base candidate", func(t *testing.T) {
+		resp, err := server.GetMiningCandidateDiff(ctx, &blockassembly_api.GetMiningCandidateDiffRequest{})
+		require.NoError(t, err)
+		require.NotNil(t, resp.Candidate)
+		assert.True(t, resp.Full)
+		assert.Empty(t, resp.BaseCandidateId)
+		assert.Nil(t, resp.Candidate.SubtreeHashes)
+		assert.Len(t, resp.AddedSubtreeHashes, int(resp.Candidate.SubtreeCount))
+
+		// the candidate is stored as a job, so it can be used as the base of the next diff
+		id, err := chainhash.NewHash(resp.Candidate.Id)
+		require.NoError(t, err)
+		assert.NotNil(t, server.jobStore.Get(*id))
+	})
+
+	t.Run("with base candidate", func(t *testing.T) {
+		first, err := server.GetMiningCandidateDiff(ctx, &blockassembly_api.GetMiningCandidateDiffRequest{})
+		require.NoError(t, err)
+
+		// a base candidate on the same parent without any subtrees
+		baseID := chainhash.HashH([]byte("base-candidate"))
+		server.jobStore.Set(baseID, &subtreeprocessor.Job{
+			ID:              &baseID,
+			MiningCandidate: &model.MiningCandidate{Id: baseID[:], PreviousHash: first.Candidate.PreviousHash},
+		}, jobTTL)
+
+		resp, err := server.GetMiningCandidateDiff(ctx, &blockassembly_api.GetMiningCandidateDiffRequest{CandidateId: baseID[:]})
+		require.NoError(t, err)
+		assert.False(t, resp.Full)
+		assert.Equal(t, baseID[:], resp.BaseCandidateId)
+		assert.Len(t, resp.AddedSubtreeHashes, int(resp.Candidate.SubtreeCount))
+		assert.Empty(t, resp.RemovedSubtreeHashes)
+	})
+
+	t.Run("base candidate on another parent", func(t *testing.T) {
+		baseID := chainhash.HashH([]byte("stale-candidate"))
+		server.jobStore.Set(baseID, &subtreeprocessor.Job{
+			ID:              &baseID,
+			Subtrees:        testDiffSubtrees(t, 1),
+			MiningCandidate: &model.MiningCandidate{Id: baseID[:], PreviousHash: make([]byte, 32)},
+		}, jobTTL)
+
+		resp, err := server.GetMiningCandidateDiff(ctx, &blockassembly_api.GetMiningCandidateDiffRequest{CandidateId: baseID[:]})
+		require.NoError(t, err)
+		assert.True(t, resp.Full)
+		assert.Empty(t, resp.BaseCandidateId)
+		assert.Empty(t, resp.RemovedSubtreeHashes)
+	})
+
+	t.Run("unknown base candidate", func(t *testing.T) {
+		unknownID := chainhash.HashH([]byte("unknown-candidate"))
+
+		resp, err := server.GetMiningCandidateDiff(ctx, &blockassembly_api.GetMiningCandidateDiffRequest{CandidateId: unknownID[:]})
+		require.NoError(t, err)
+		assert.True(t, resp.Full)
+	})
+}
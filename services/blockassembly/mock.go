@@ -52,7 +52,7 @@ func (m *Mock) Health(ctx context.Context, checkLiveness bool) (int, string, err
 	return args.Int(0), args.String(1), args.Error(2)
 }
 
-func (m *Mock) Store(ctx context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints) (bool, error) {
+func (m *Mock) Store(ctx context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints, _ ...StoreOption) (bool, error) {
 	args := m.Called(ctx, hash, fee, size, txInpoints)
 
 	if args.Error(1) != nil {
@@ -82,6 +82,16 @@ func (m *Mock) GetMiningCandidate(ctx context.Context, includeSubtreeHashes ...b
 	return args.Get(0).(*model.MiningCandidate), nil
 }
 
+func (m *Mock) GetMiningCandidateDiff(ctx context.Context, candidateID []byte) (*blockassembly_api.GetMiningCandidateDiffResponse, error) {
+	args := m.Called(ctx, candidateID)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*blockassembly_api.GetMiningCandidateDiffResponse), nil
+}
+
 func (m *Mock) GetCurrentDifficulty(ctx context.Context) (float64, error) {
 	args := m.Called(ctx)
 
@@ -132,6 +142,16 @@ func (m *Mock) ResetBlockAssemblyFully(ctx context.Context) error {
 	return nil
 }
 
+func (m *Mock) ResetBlockAssemblyScoped(ctx context.Context, scope ResetScope) (ResetScopeResult, error) {
+	args := m.Called(ctx, scope)
+
+	if args.Error(1) != nil {
+		return ResetScopeResult{}, args.Error(1)
+	}
+
+	return args.Get(0).(ResetScopeResult), nil
+}
+
 func (m *Mock) GetBlockAssemblyState(ctx context.Context) (*blockassembly_api.StateMessage, error) {
 	args := m.Called(ctx)
 
@@ -142,6 +162,16 @@ func (m *Mock) GetBlockAssemblyState(ctx context.Context) (*blockassembly_api.St
 	return args.Get(0).(*blockassembly_api.StateMessage), nil
 }
 
+func (m *Mock) GetReorgStatus(ctx context.Context) (*blockassembly_api.GetReorgStatusResponse, error) {
+	args := m.Called(ctx)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*blockassembly_api.GetReorgStatusResponse), nil
+}
+
 func (m *Mock) GetBlockAssemblyBlockCandidate(ctx context.Context) (*model.Block, error) {
 	args := m.Called(ctx)
 
@@ -166,6 +196,34 @@ func (m *Mock) GetTransactionHashes(ctx context.Context) ([]string, error) {
 	return args.Get(0).([]string), nil
 }
 
+func (m *Mock) GetCoinbaseTemplate(ctx context.Context) (*model.CoinbaseTemplate, error) {
+	args := m.Called(ctx)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+
+	return args.Get(0).(*model.CoinbaseTemplate), nil
+}
+
+func (m *Mock) SetCoinbaseTemplate(ctx context.Context, template *model.CoinbaseTemplate) (*model.CoinbaseTemplate, error) {
+	args := m.Called(ctx, template)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+
+	return args.Get(0).(*model.CoinbaseTemplate), nil
+}
+
 // mockBlockAssemblyAPIClient is a mock implementation of BlockAssemblyAPIClient
 type mockBlockAssemblyAPIClient struct {
 	mock.Mock
@@ -211,6 +269,14 @@ func (m *mockBlockAssemblyAPIClient) GetMiningCandidate(ctx context.Context, in
 	return args.Get(0).(*model.MiningCandidate), args.Error(1)
 }
 
+func (m *mockBlockAssemblyAPIClient) GetMiningCandidateDiff(ctx context.Context, in *blockassembly_api.GetMiningCandidateDiffRequest, opts ...grpc.CallOption) (*blockassembly_api.GetMiningCandidateDiffResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blockassembly_api.GetMiningCandidateDiffResponse), args.Error(1)
+}
+
 func (m *mockBlockAssemblyAPIClient) GetCurrentDifficulty(ctx context.Context, in *blockassembly_api.EmptyMessage, opts ...grpc.CallOption) (*blockassembly_api.GetCurrentDifficultyResponse, error) {
 	args := m.Called(ctx, in, opts)
 	if args.Get(0) == nil {
@@ -243,6 +309,14 @@ func (m *mockBlockAssemblyAPIClient) ResetBlockAssemblyFully(ctx context.Context
 	return args.Get(0).(*blockassembly_api.EmptyMessage), args.Error(1)
 }
 
+func (m *mockBlockAssemblyAPIClient) ResetBlockAssemblyScoped(ctx context.Context, in *blockassembly_api.ResetBlockAssemblyScopedRequest, opts ...grpc.CallOption) (*blockassembly_api.ResetBlockAssemblyScopedResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blockassembly_api.ResetBlockAssemblyScopedResponse), args.Error(1)
+}
+
 func (m *mockBlockAssemblyAPIClient) GetBlockAssemblyState(ctx context.Context, in *blockassembly_api.EmptyMessage, opts ...grpc.CallOption) (*blockassembly_api.StateMessage, error) {
 	args := m.Called(ctx, in, opts)
 	if args.Get(0) == nil {
@@ -251,6 +325,22 @@ func (m *mockBlockAssemblyAPIClient) GetBlockAssemblyState(ctx context.Context,
 	return args.Get(0).(*blockassembly_api.StateMessage), args.Error(1)
 }
 
+func (m *mockBlockAssemblyAPIClient) GetReorgStatus(ctx context.Context, in *blockassembly_api.EmptyMessage, opts ...grpc.CallOption) (*blockassembly_api.GetReorgStatusResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blockassembly_api.GetReorgStatusResponse), args.Error(1)
+}
+
+func (m *mockBlockAssemblyAPIClient) SubscribeState(ctx context.Context, in *blockassembly_api.EmptyMessage, opts ...grpc.CallOption) (grpc.ServerStreamingClient[blockassembly_api.StateNotification], error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(grpc.ServerStreamingClient[blockassembly_api.StateNotification]), args.Error(1)
+}
+
 func (m *mockBlockAssemblyAPIClient) GenerateBlocks(ctx context.Context, in *blockassembly_api.GenerateBlocksRequest, opts ...grpc.CallOption) (*blockassembly_api.EmptyMessage, error) {
 	args := m.Called(ctx, in, opts)
 	if args.Get(0) == nil {
@@ -282,3 +372,19 @@ func (m *mockBlockAssemblyAPIClient) GetBlockAssemblyTxs(ctx context.Context, in
 	}
 	return args.Get(0).(*blockassembly_api.GetBlockAssemblyTxsResponse), args.Error(1)
 }
+
+func (m *mockBlockAssemblyAPIClient) GetCoinbaseTemplate(ctx context.Context, in *blockassembly_api.EmptyMessage, opts ...grpc.CallOption) (*blockassembly_api.CoinbaseTemplate, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blockassembly_api.CoinbaseTemplate), args.Error(1)
+}
+
+func (m *mockBlockAssemblyAPIClient) SetCoinbaseTemplate(ctx context.Context, in *blockassembly_api.CoinbaseTemplate, opts ...grpc.CallOption) (*blockassembly_api.CoinbaseTemplate, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blockassembly_api.CoinbaseTemplate), args.Error(1)
+}
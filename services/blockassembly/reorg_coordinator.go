@@ -0,0 +1,246 @@
+// Package blockassembly provides functionality for assembling Bitcoin blocks in Teranode.
+package blockassembly
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/blockassembly_api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ReorgPhase is a phase of a reorg coordinated by block assembly
+type ReorgPhase string
+
+const (
+	// ReorgPhaseCollectingBlocks loads the blocks to move back and forward from the blockchain
+	ReorgPhaseCollectingBlocks ReorgPhase = "collecting_blocks"
+
+	// ReorgPhaseReorging returns the transactions of the moved back blocks to block assembly, unwinding their mined
+	// state in the UTXO store, and replays the moved forward blocks
+	ReorgPhaseReorging ReorgPhase = "reorging"
+
+	// ReorgPhaseResetting resets block assembly, reloading the unmined transactions from the UTXO store, for reorgs
+	// too large or invalid to do incrementally and when the incremental reorg failed
+	ReorgPhaseResetting ReorgPhase = "resetting"
+
+	// ReorgPhaseInvalidatingCaches drops the cached mining candidate and the mining jobs with the subtrees of the old chain
+	ReorgPhaseInvalidatingCaches ReorgPhase = "invalidating_caches"
+
+	// ReorgPhaseCompleted is the phase of a reorg that ended successfully
+	ReorgPhaseCompleted ReorgPhase = "completed"
+
+	// ReorgPhaseFailed is the phase of a reorg that ended with an error
+	ReorgPhaseFailed ReorgPhase = "failed"
+)
+
+// ReorgStatus is the progress of a reorg coordinated by block assembly.
+type ReorgStatus struct {
+	// ID is the sequence number of the reorg since block assembly started
+	ID uint64
+
+	// FromHash is the chaintip of block assembly before the reorg
+	FromHash *chainhash.Hash
+
+	// FromHeight is the height of the chaintip before the reorg
+	FromHeight uint32
+
+	// ToHash is the new best block of the blockchain
+	ToHash *chainhash.Hash
+
+	// ToHeight is the height of the new best block
+	ToHeight uint32
+
+	// MoveBackBlocks is the number of blocks moved back, known once the blocks are collected
+	MoveBackBlocks int
+
+	// MoveForwardBlocks is the number of blocks moved forward, known once the blocks are collected
+	MoveForwardBlocks int
+
+	// Phase is the current phase of the reorg
+	Phase ReorgPhase
+
+	// FullReset is true when block assembly was reset instead of reorged incrementally
+	FullReset bool
+
+	// StartedAt is when the reorg started
+	StartedAt time.Time
+
+	// PhaseStartedAt is when the current phase started
+	PhaseStartedAt time.Time
+
+	// CompletedAt is when the reorg ended, zero while it is in progress
+	CompletedAt time.Time
+
+	// Error is the error of a failed reorg
+	Error string
+}
+
+// toProto converts the reorg status to its gRPC message
+func (s *ReorgStatus) toProto() *blockassembly_api.ReorgStatus {
+	if s == nil {
+		return nil
+	}
+
+	status := &blockassembly_api.ReorgStatus{
+		Id:                s.ID,
+		FromHeight:        s.FromHeight,
+		ToHeight:          s.ToHeight,
+		MoveBackBlocks:    uint32(s.MoveBackBlocks),    //nolint:gosec // bounded by the max reorg size
+		MoveForwardBlocks: uint32(s.MoveForwardBlocks), //nolint:gosec // bounded by the max reorg size
+		Phase:             string(s.Phase),
+		FullReset:         s.FullReset,
+		StartedAt:         timestamppb.New(s.StartedAt),
+		PhaseStartedAt:    timestamppb.New(s.PhaseStartedAt),
+		Error:             s.Error,
+	}
+
+	if s.FromHash != nil {
+		status.FromHash = s.FromHash.CloneBytes()
+	}
+
+	if s.ToHash != nil {
+		status.ToHash = s.ToHash.CloneBytes()
+	}
+
+	if !s.CompletedAt.IsZero() {
+		status.CompletedAt = timestamppb.New(s.CompletedAt)
+	}
+
+	return status
+}
+
+// reorgCoordinator tracks the reorgs of block assembly as single operations moving through the reorg phases, and
+// holds the caches to invalidate when the chain switched. The zero value is ready to use.
+type reorgCoordinator struct {
+	mu                sync.RWMutex
+	lastID            uint64
+	current           *ReorgStatus // the reorg in progress, nil when none is in progress
+	last              *ReorgStatus // the last completed reorg
+	cacheInvalidators []func()     // invalidate the caches holding the subtrees of the old chain
+}
+
+// begin starts tracking a reorg in the collecting blocks phase
+func (c *reorgCoordinator) begin(fromHash *chainhash.Hash, fromHeight uint32, toHash *chainhash.Hash, toHeight uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastID++
+
+	now := time.Now()
+
+	c.current = &ReorgStatus{
+		ID:             c.lastID,
+		FromHash:       fromHash,
+		FromHeight:     fromHeight,
+		ToHash:         toHash,
+		ToHeight:       toHeight,
+		Phase:          ReorgPhaseCollectingBlocks,
+		StartedAt:      now,
+		PhaseStartedAt: now,
+	}
+}
+
+// setBlocks records the number of blocks moved back and forward by the reorg in progress
+func (c *reorgCoordinator) setBlocks(moveBackBlocks, moveForwardBlocks int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current != nil {
+		c.current.MoveBackBlocks = moveBackBlocks
+		c.current.MoveForwardBlocks = moveForwardBlocks
+	}
+}
+
+// setPhase moves the reorg in progress to the phase, entering the resetting phase marks the reorg as reset
+func (c *reorgCoordinator) setPhase(phase ReorgPhase) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current == nil {
+		return
+	}
+
+	c.current.Phase = phase
+	c.current.PhaseStartedAt = time.Now()
+
+	if phase == ReorgPhaseResetting {
+		c.current.FullReset = true
+	}
+}
+
+// finish ends the reorg in progress, returning its final status. A reset of block assembly in place of the reorg is
+// not a failure.
+func (c *reorgCoordinator) finish(err error) *ReorgStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	status := c.current
+	status.PhaseStartedAt = now
+	status.CompletedAt = now
+
+	if err != nil && !errors.Is(err, errors.ErrBlockAssemblyReset) {
+		status.Phase = ReorgPhaseFailed
+		status.Error = err.Error()
+	} else {
+		status.Phase = ReorgPhaseCompleted
+	}
+
+	c.current = nil
+	c.last = status
+
+	finished := *status
+
+	return &finished
+}
+
+// status returns copies of the reorg in progress and of the last completed reorg, nil when there is none
+func (c *reorgCoordinator) status() (current *ReorgStatus, last *ReorgStatus) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.current != nil {
+		currentCopy := *c.current
+		current = &currentCopy
+	}
+
+	if c.last != nil {
+		lastCopy := *c.last
+		last = &lastCopy
+	}
+
+	return current, last
+}
+
+// addCacheInvalidator registers a function invalidating a cache that holds the subtrees of the old chain after a reorg
+func (c *reorgCoordinator) addCacheInvalidator(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cacheInvalidators = append(c.cacheInvalidators, fn)
+}
+
+// invalidateCaches invalidates the registered caches
+func (c *reorgCoordinator) invalidateCaches() {
+	c.mu.RLock()
+	invalidators := c.cacheInvalidators
+	c.mu.RUnlock()
+
+	for _, fn := range invalidators {
+		fn()
+	}
+}
+
+// ReorgStatus returns the reorg in progress, nil when no reorg is in progress, and the last completed reorg, nil when
+// there has been no reorg since block assembly started.
+func (b *BlockAssembler) ReorgStatus() (current *ReorgStatus, last *ReorgStatus) {
+	return b.reorgs.status()
+}
@@ -0,0 +1,164 @@
+package blockassembly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/subtreeprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorgCoordinator(t *testing.T) {
+	t.Run("tracks a reorg through its phases", func(t *testing.T) {
+		c := &reorgCoordinator{}
+
+		current, last := c.status()
+		assert.Nil(t, current)
+		assert.Nil(t, last)
+
+		c.begin(blockHeader4.Hash(), 4, blockHeader4Alt.Hash(), 4)
+		c.setBlocks(3, 3)
+		c.setPhase(ReorgPhaseReorging)
+
+		current, last = c.status()
+		require.NotNil(t, current)
+		assert.Nil(t, last)
+		assert.Equal(t, uint64(1), current.ID)
+		assert.Equal(t, ReorgPhaseReorging, current.Phase)
+		assert.Equal(t, 3, current.MoveBackBlocks)
+		assert.Equal(t, 3, current.MoveForwardBlocks)
+		assert.False(t, current.FullReset)
+		assert.True(t, current.CompletedAt.IsZero())
+
+		// the status is a copy
+		current.Phase = ReorgPhaseFailed
+		current, _ = c.status()
+		assert.Equal(t, ReorgPhaseReorging, current.Phase)
+
+		status := c.finish(nil)
+		assert.Equal(t, ReorgPhaseCompleted, status.Phase)
+		assert.False(t, status.CompletedAt.IsZero())
+
+		current, last = c.status()
+		assert.Nil(t, current)
+		require.NotNil(t, last)
+		assert.Equal(t, uint64(1), last.ID)
+		assert.Equal(t, ReorgPhaseCompleted, last.Phase)
+	})
+
+	t.Run("a reset is not a failure", func(t *testing.T) {
+		c := &reorgCoordinator{}
+
+		c.begin(blockHeader4.Hash(), 4, blockHeader4Alt.Hash(), 4)
+		c.setPhase(ReorgPhaseResetting)
+
+		status := c.finish(errors.NewBlockAssemblyResetError("large reorg"))
+		assert.Equal(t, ReorgPhaseCompleted, status.Phase)
+		assert.True(t, status.FullReset)
+		assert.Empty(t, status.Error)
+
+		c.begin(blockHeader4Alt.Hash(), 4, blockHeader4.Hash(), 4)
+
+		status = c.finish(errors.NewProcessingError("reset failed"))
+		assert.Equal(t, uint64(2), status.ID)
+		assert.Equal(t, ReorgPhaseFailed, status.Phase)
+		assert.Contains(t, status.Error, "reset failed")
+	})
+
+	t.Run("converts to the gRPC message", func(t *testing.T) {
+		c := &reorgCoordinator{}
+
+		c.begin(blockHeader4.Hash(), 4, blockHeader4Alt.Hash(), 5)
+
+		current, last := c.status()
+		assert.Nil(t, last.toProto())
+
+		msg := current.toProto()
+		assert.Equal(t, blockHeader4.Hash().CloneBytes(), msg.GetFromHash())
+		assert.Equal(t, blockHeader4Alt.Hash().CloneBytes(), msg.GetToHash())
+		assert.Equal(t, uint32(5), msg.GetToHeight())
+		assert.Equal(t, string(ReorgPhaseCollectingBlocks), msg.GetPhase())
+		assert.Nil(t, msg.GetCompletedAt())
+
+		msg = c.finish(nil).toProto()
+		assert.NotNil(t, msg.GetCompletedAt())
+	})
+}
+
+func TestHandleReorgCoordination(t *testing.T) {
+	initPrometheusMetrics()
+
+	t.Run("successful reorg", func(t *testing.T) {
+		items := setupBlockAssemblyTest(t)
+		require.NotNil(t, items)
+
+		for _, header := range []*model.BlockHeader{blockHeader1, blockHeader2, blockHeader3, blockHeader4, blockHeader2Alt, blockHeader3Alt, blockHeader4Alt} {
+			require.NoError(t, items.addBlock(header))
+		}
+
+		ba := items.blockAssembler
+		ba.setBestBlockHeader(blockHeader4, 4)
+
+		var invalidated int
+
+		ba.reorgs.addCacheInvalidator(func() { invalidated++ })
+
+		ba.cachedCandidate.candidate = &model.MiningCandidate{}
+
+		stp := &subtreeprocessor.MockSubtreeProcessor{}
+		stp.On("Reorg", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			current, _ := ba.ReorgStatus()
+			require.NotNil(t, current)
+			assert.Equal(t, ReorgPhaseReorging, current.Phase)
+			assert.Len(t, args.Get(0).([]*model.Block), 3)
+			assert.Len(t, args.Get(1).([]*model.Block), 3)
+		}).Return(nil)
+
+		ba.subtreeProcessor = stp
+
+		require.NoError(t, ba.handleReorg(context.Background(), blockHeader4Alt, 4))
+		stp.AssertNumberOfCalls(t, "Reorg", 1)
+
+		current, last := ba.ReorgStatus()
+		assert.Nil(t, current)
+		require.NotNil(t, last)
+		assert.Equal(t, ReorgPhaseCompleted, last.Phase)
+		assert.Equal(t, blockHeader4.Hash(), last.FromHash)
+		assert.Equal(t, blockHeader4Alt.Hash(), last.ToHash)
+		assert.Equal(t, 3, last.MoveBackBlocks)
+		assert.Equal(t, 3, last.MoveForwardBlocks)
+		assert.False(t, last.FullReset)
+
+		assert.Equal(t, 1, invalidated)
+		assert.Nil(t, ba.cachedCandidate.candidate)
+	})
+
+	t.Run("failed reorg", func(t *testing.T) {
+		items := setupBlockAssemblyTest(t)
+		require.NotNil(t, items)
+
+		require.NoError(t, items.addBlock(blockHeader1))
+
+		ba := items.blockAssembler
+		ba.setBestBlockHeader(blockHeader1, 1)
+
+		var invalidated int
+
+		ba.reorgs.addCacheInvalidator(func() { invalidated++ })
+
+		unknown := &model.BlockHeader{Version: 1, HashPrevBlock: &chainhash.Hash{1}, HashMerkleRoot: &chainhash.Hash{}}
+
+		require.Error(t, ba.handleReorg(context.Background(), unknown, 2))
+
+		_, last := ba.ReorgStatus()
+		require.NotNil(t, last)
+		assert.Equal(t, ReorgPhaseFailed, last.Phase)
+		assert.NotEmpty(t, last.Error)
+		assert.Equal(t, 1, invalidated)
+	})
+}
@@ -591,6 +591,7 @@ func TestGetBlockAssemblyStateCoverage(t *testing.T) {
 			assert.GreaterOrEqual(t, resp.TxCount, uint64(0))
 			assert.GreaterOrEqual(t, resp.SubtreeCount, uint32(0))
 			assert.NotEmpty(t, resp.BlockAssemblyState)
+			assert.NotZero(t, resp.SubtreeSize)
 		} else {
 			// Error case also provides coverage
 			assert.NotNil(t, err)
@@ -3,7 +3,7 @@
 // The subtreeprocessor implements an efficient system for organizing transactions into
 // hierarchical subtrees that can be quickly assembled into blocks. This approach enables:
 //   - Efficient transaction storage and retrieval
-//   - Dynamic adjustment of subtree sizes based on transaction volume
+//   - Dynamic adjustment of subtree sizes based on transaction volume or arrival rate
 //   - Parallelized processing of transaction groups
 //   - Optimized block candidate generation
 //   - Fast response to blockchain reorganizations
@@ -28,6 +28,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/bsv-blockchain/go-bt/v2"
 	"github.com/bsv-blockchain/go-bt/v2/chainhash"
 	safeconversion "github.com/bsv-blockchain/go-safe-conversion"
 	subtreepkg "github.com/bsv-blockchain/go-subtree"
@@ -150,6 +151,15 @@ type SubtreeProcessor struct {
 	// currentItemsPerFile specifies the maximum number of items per subtree file
 	currentItemsPerFile int
 
+	// subtreeSize mirrors currentItemsPerFile for readers outside the processing goroutine
+	subtreeSize atomic.Int64
+
+	// arrivalRate measures the transaction arrival rate when subtrees are sized to it
+	arrivalRate arrivalRateTracker
+
+	// txArrivalRate holds the float64 bits of the last measured arrival rate, for readers outside the processing goroutine
+	txArrivalRate atomic.Uint64
+
 	// blockStartTime tracks when the current block started
 	blockStartTime time.Time
 
@@ -221,6 +231,12 @@ type SubtreeProcessor struct {
 	// queue manages the transaction processing queue
 	queue *LockFreeQueue
 
+	// priorityLanes holds the enabled priority lanes, served ahead of the queue within their quota
+	priorityLanes []*priorityLane
+
+	// defaultLane accounts the transactions of the queue when priority lanes are enabled
+	defaultLane *priorityLane
+
 	// currentTxMap tracks transactions currently held in the subtree processor
 	currentTxMap *txmap.SyncedMap[chainhash.Hash, subtreepkg.TxInpoints]
 
@@ -378,8 +394,12 @@ func NewSubtreeProcessor(ctx context.Context, logger ulogger.Logger, tSettings *
 		logger:                   logger,
 		stats:                    gocore.NewStat("subtreeProcessor").NewStat("Add", false),
 		currentRunningState:      atomic.Value{},
+		arrivalRate:              arrivalRateTracker{window: tSettings.BlockAssembly.ArrivalRateWindow},
+		priorityLanes:            newPriorityLanes(tSettings),
+		defaultLane:              newPriorityLane(TxPriorityDefault, queue, 0),
 	}
 	stp.setCurrentRunningState(StateStarting)
+	stp.subtreeSize.Store(int64(initialItemsPerFile))
 
 	// need to make sure first coinbase tx is counted when we start
 	stp.setTxCountFromSubtrees()
@@ -569,7 +589,7 @@ func NewSubtreeProcessor(ctx context.Context, logger ulogger.Logger, tSettings *
 				validFromMillis := time.Now().Add(-1 * stp.settings.BlockAssembly.DoubleSpendWindow).UnixMilli()
 
 				for {
-					node, txInpoints, _, found := stp.queue.dequeue(validFromMillis)
+					node, txInpoints, lane, found := stp.dequeue(validFromMillis)
 					if !found {
 						time.Sleep(1 * time.Millisecond)
 						break
@@ -604,10 +624,14 @@ func NewSubtreeProcessor(ctx context.Context, logger ulogger.Logger, tSettings *
 					// 	}
 					// }
 
+					currentSubtree := stp.currentSubtree
+
 					if err = stp.addNode(node, &txInpoints, false); err != nil {
 						stp.logger.Errorf("[SubtreeProcessor] error adding node: %s", err.Error())
 					} else {
 						stp.txCount.Add(1)
+						stp.arrivalRate.add(1)
+						stp.addedFromLane(lane, currentSubtree, node)
 					}
 
 					nrProcessed++
@@ -616,6 +640,9 @@ func NewSubtreeProcessor(ctx context.Context, logger ulogger.Logger, tSettings *
 					}
 				}
 
+				stp.adjustSubtreeSizeToArrivalRate(time.Now())
+				stp.updatePriorityLaneMetrics()
+
 				stp.setCurrentRunningState(StateRunning)
 			}
 		}
@@ -732,36 +759,29 @@ func (stp *SubtreeProcessor) reset(blockHeader *model.BlockHeader, moveBackBlock
 
 	// optimized version for legacy sync
 	if isLegacySync {
-		coinbaseTxsAdded := sync.Map{}
+		// the coinbase UTXOs of all the blocks are created in one block transaction, rolled back when any fails
+		blockTx := utxostore.BeginBlockTransaction(stp.utxoStore)
 
 		g, gCtx := errgroup.WithContext(context.Background())
 
 		for _, block := range moveForwardBlocks {
 			g.Go(func() error {
-				if err := stp.processCoinbaseUtxos(gCtx, block); err != nil {
-					return err
-				}
-
-				coinbaseTxsAdded.Store(block.Hash().String(), block)
-
-				return nil
+				return stp.processCoinbaseUtxos(gCtx, blockTx, block)
 			})
 		}
 
 		if err := g.Wait(); err != nil {
-			coinbaseTxsAdded.Range(func(key, value interface{}) bool {
-				// remove all the coinbase transactions we added
-				block := value.(*model.Block)
-				if delErr := stp.removeCoinbaseUtxos(context.Background(), block); delErr != nil {
-					stp.logger.Errorf("[SubtreeProcessor][Reset] error deleting utxos for coinbase tx %s: %v", block.CoinbaseTx.String(), delErr)
-				}
-
-				return true
-			})
+			if rollbackErr := blockTx.Rollback(context.Background()); rollbackErr != nil {
+				stp.logger.Errorf("[SubtreeProcessor][Reset] error rolling back coinbase utxos: %v", rollbackErr)
+			}
 
 			return errors.NewProcessingError("[SubtreeProcessor][Reset] error processing coinbase utxos", err)
 		}
 
+		if err := blockTx.Commit(ctx); err != nil {
+			return errors.NewProcessingError("[SubtreeProcessor][Reset] error committing coinbase utxos", err)
+		}
+
 		stp.currentBlockHeader = blockHeader
 	} else {
 		for _, block := range moveForwardBlocks {
@@ -795,7 +815,7 @@ func (stp *SubtreeProcessor) reset(blockHeader *model.BlockHeader, moveBackBlock
 				}
 			}
 
-			if err = stp.processCoinbaseUtxos(context.Background(), block); err != nil {
+			if err = stp.processCoinbaseUtxos(context.Background(), stp.utxoStore, block); err != nil {
 				return errors.NewProcessingError("[SubtreeProcessor][Reset] error processing coinbase utxos", err)
 			}
 		}
@@ -825,11 +845,13 @@ func (stp *SubtreeProcessor) reset(blockHeader *model.BlockHeader, moveBackBlock
 
 	validUntilMillis := time.Now().UnixMilli()
 
-	for {
-		_, _, time64, found := stp.queue.dequeue(0)
-		if !found || time64 > validUntilMillis {
-			// we are done
-			break
+	for _, queue := range stp.queues() {
+		for {
+			_, _, time64, found := queue.dequeue(0)
+			if !found || time64 > validUntilMillis {
+				// we are done
+				break
+			}
 		}
 	}
 
@@ -963,7 +985,23 @@ func (stp *SubtreeProcessor) GetUtxoStore() utxostore.Store {
 // Parameters:
 //   - v: New maximum items value
 func (stp *SubtreeProcessor) SetCurrentItemsPerFile(v int) {
+	stp.setCurrentItemsPerFile(v)
+}
+
+// setCurrentItemsPerFile sets the size of new subtrees and publishes it for CurrentItemsPerFile
+func (stp *SubtreeProcessor) setCurrentItemsPerFile(v int) {
 	stp.currentItemsPerFile = v
+	stp.subtreeSize.Store(int64(v))
+	prometheusSubtreeProcessorDynamicSubtreeSize.Set(float64(v))
+}
+
+// CurrentItemsPerFile returns the effective subtree size, the maximum number of items of new subtrees.
+// It is safe to call from any goroutine.
+//
+// Returns:
+//   - int: Effective subtree size
+func (stp *SubtreeProcessor) CurrentItemsPerFile() int {
+	return int(stp.subtreeSize.Load())
 }
 
 // TxCount returns the total number of transactions processed.
@@ -979,7 +1017,7 @@ func (stp *SubtreeProcessor) TxCount() uint64 {
 // Returns:
 //   - int64: Current queue length
 func (stp *SubtreeProcessor) QueueLength() int64 {
-	return stp.queue.length()
+	return stp.queue.length() + stp.priorityLanesLength()
 }
 
 // SubtreeCount returns the total number of subtrees.
@@ -998,7 +1036,7 @@ func (stp *SubtreeProcessor) SubtreeCount() int {
 // to maintain approximately one subtree per second. The size will always be a power of 2
 // and not smaller than 1024.
 func (stp *SubtreeProcessor) adjustSubtreeSize() {
-	if !stp.settings.BlockAssembly.UseDynamicSubtreeSize {
+	if !stp.settings.BlockAssembly.UseDynamicSubtreeSize || stp.settings.BlockAssembly.ArrivalRateSubtreeSize {
 		return
 	}
 
@@ -1043,8 +1081,7 @@ func (stp *SubtreeProcessor) adjustSubtreeSize() {
 
 			if newSize != currentSize {
 				stp.logger.Debugf("[adjustSubtreeSize] setting new size from %d to %d (low utilization)\n", currentSize, newSize)
-				stp.currentItemsPerFile = newSize
-				prometheusSubtreeProcessorDynamicSubtreeSize.Set(float64(newSize))
+				stp.setCurrentItemsPerFile(newSize)
 			}
 
 			// Reset counters for next adjustment
@@ -1165,7 +1202,7 @@ func (stp *SubtreeProcessor) adjustSubtreeSize() {
 
 	if newSize != currentSize {
 		stp.logger.Debugf("[adjustSubtreeSize] setting new size from %d to %d\n", currentSize, newSize)
-		stp.currentItemsPerFile = newSize
+		stp.setCurrentItemsPerFile(newSize)
 	}
 
 	prometheusSubtreeProcessorDynamicSubtreeSize.Set(float64(newSize))
@@ -2038,7 +2075,7 @@ func (stp *SubtreeProcessor) setTxCountFromSubtrees() {
 		return
 	}
 
-	queueLenUint64, err := safeconversion.Int64ToUint64(stp.queue.length())
+	queueLenUint64, err := safeconversion.Int64ToUint64(stp.QueueLength())
 	if err != nil {
 		stp.logger.Errorf("error converting queue length: %s", err)
 		return
@@ -2636,7 +2673,7 @@ func (stp *SubtreeProcessor) moveForwardBlock(ctx context.Context, block *model.
 	}
 
 	// create the coinbase after processing all other transaction operations
-	if err = stp.processCoinbaseUtxos(ctx, block); err != nil {
+	if err = stp.processCoinbaseUtxos(ctx, stp.utxoStore, block); err != nil {
 		return nil, errors.NewProcessingError("[moveForwardBlock][%s] error processing coinbase utxos", block.String(), err)
 	}
 
@@ -2747,19 +2784,23 @@ func (stp *SubtreeProcessor) WaitForPendingBlocks(ctx context.Context) error {
 // Returns:
 //   - error: Any error encountered during processing
 func (stp *SubtreeProcessor) dequeueDuringBlockMovement(transactionMap, losingTxHashesMap txmap.TxMap, skipNotification bool) (err error) {
-	queueLength := stp.queue.length()
+	queueLength := stp.QueueLength()
 	if queueLength > 0 {
 		nrProcessed := int64(0)
 		validFromMillis := time.Now().Add(-1 * stp.settings.BlockAssembly.DoubleSpendWindow).UnixMilli()
 
 		for {
-			node, txInpoints, _, found := stp.queue.dequeue(validFromMillis)
+			node, txInpoints, lane, found := stp.dequeue(validFromMillis)
 			if !found {
 				break
 			}
 
 			if (transactionMap == nil || !transactionMap.Exists(node.Hash)) && (losingTxHashesMap == nil || !losingTxHashesMap.Exists(node.Hash)) {
-				_ = stp.addNode(node, &txInpoints, skipNotification)
+				currentSubtree := stp.currentSubtree
+
+				if addErr := stp.addNode(node, &txInpoints, skipNotification); addErr == nil {
+					stp.addedFromLane(lane, currentSubtree, node)
+				}
 			}
 
 			nrProcessed++
@@ -2772,15 +2813,22 @@ func (stp *SubtreeProcessor) dequeueDuringBlockMovement(transactionMap, losingTx
 	return nil
 }
 
+// coinbaseUtxoCreator creates the UTXOs of a coinbase transaction, either the UTXO store itself or a block
+// transaction on it
+type coinbaseUtxoCreator interface {
+	Create(ctx context.Context, tx *bt.Tx, blockHeight uint32, opts ...utxostore.CreateOption) (*meta.Data, error)
+}
+
 // processCoinbaseUtxos processes UTXOs from coinbase transactions.
 //
 // Parameters:
 //   - ctx: Context for cancellation
+//   - creator: UTXO store or block transaction the coinbase UTXOs are created in
 //   - block: Block containing the coinbase transaction
 //
 // Returns:
 //   - error: Any error encountered during processing
-func (stp *SubtreeProcessor) processCoinbaseUtxos(ctx context.Context, block *model.Block) error {
+func (stp *SubtreeProcessor) processCoinbaseUtxos(ctx context.Context, creator coinbaseUtxoCreator, block *model.Block) error {
 	startTime := time.Now()
 
 	prometheusSubtreeProcessorProcessCoinbaseTx.Inc()
@@ -2813,7 +2861,7 @@ func (stp *SubtreeProcessor) processCoinbaseUtxos(ctx context.Context, block *mo
 	stp.logger.Debugf("[SubtreeProcessor][%s] height %d storeCoinbaseTx %s blockID %d", block.Header.Hash().String(), blockHeight, block.CoinbaseTx.TxIDChainHash().String(), block.ID)
 	// we pass in the block height we are working on here, since the utxo store will recognize the tx as
 	// a coinbase and add the correct spending height, which should be + 99
-	if _, err = stp.utxoStore.Create(
+	if _, err = creator.Create(
 		ctx,
 		block.CoinbaseTx,
 		blockHeight,
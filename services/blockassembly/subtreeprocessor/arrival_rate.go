@@ -0,0 +1,164 @@
+// This file implements the sizing of subtrees to the transaction arrival rate.
+//
+// With blockassembly_arrivalRateSubtreeSize enabled, the subtree size follows the rate at which transactions are
+// dequeued, so a subtree fills in about blockassembly_subtreeTargetFillInterval. The size grows as soon as the
+// arrival rate spikes, so subtrees are not completed faster than they can be stored and announced, and shrinks as
+// the rate drops, so transactions do not wait in a mostly empty subtree that is not part of the block candidate.
+//
+// All subtrees of a block except the last must have the same size, so the size only changes while the current
+// subtree is the only subtree of the block candidate. The current subtree is then rebuilt with the new size.
+package subtreeprocessor
+
+import (
+	"math"
+	"math/bits"
+	"time"
+
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+)
+
+// arrivalRateTracker measures the transaction arrival rate. The rate rises to a higher measured rate at once and
+// decays exponentially over the window when the measured rate is lower.
+type arrivalRateTracker struct {
+	window      time.Duration
+	rate        float64
+	count       int
+	sampleStart time.Time
+}
+
+// add counts arriving transactions
+func (a *arrivalRateTracker) add(n int) {
+	a.count += n
+}
+
+// sample updates the rate when at least minInterval passed since the previous sample and returns whether it did
+func (a *arrivalRateTracker) sample(now time.Time, minInterval time.Duration) bool {
+	if a.sampleStart.IsZero() {
+		a.sampleStart = now
+		a.count = 0
+
+		return false
+	}
+
+	elapsed := now.Sub(a.sampleStart)
+	if elapsed < minInterval || elapsed <= 0 {
+		return false
+	}
+
+	measured := float64(a.count) / elapsed.Seconds()
+
+	if measured >= a.rate || a.window <= 0 {
+		a.rate = measured
+	} else {
+		alpha := 1 - math.Exp(-elapsed.Seconds()/a.window.Seconds())
+		a.rate += alpha * (measured - a.rate)
+	}
+
+	a.sampleStart = now
+	a.count = 0
+
+	return true
+}
+
+// subtreeSizeForRate returns the power of 2 subtree size that fills in the target interval at the given rate,
+// limited to the minimum and maximum subtree size
+func subtreeSizeForRate(rate float64, target time.Duration, minSize, maxSize int) int {
+	needed := uint64(math.Ceil(rate * target.Seconds()))
+
+	size := minSize
+	if needed > 1 {
+		size = 1 << bits.Len64(needed-1)
+	}
+
+	if size < minSize {
+		size = minSize
+	}
+
+	if maxSize > 0 && size > maxSize {
+		size = maxSize
+	}
+
+	return size
+}
+
+// adjustSubtreeSizeToArrivalRate samples the arrival rate and resizes the current subtree when the size for the
+// current rate differs and the current subtree is the only subtree of the block candidate. It is called from the
+// processing loop, after each batch of dequeued transactions.
+func (stp *SubtreeProcessor) adjustSubtreeSizeToArrivalRate(now time.Time) {
+	if !stp.settings.BlockAssembly.ArrivalRateSubtreeSize {
+		return
+	}
+
+	target := stp.settings.BlockAssembly.SubtreeTargetFillInterval
+	if target <= 0 {
+		target = time.Second
+	}
+
+	if !stp.arrivalRate.sample(now, target/4) {
+		return
+	}
+
+	stp.txArrivalRate.Store(math.Float64bits(stp.arrivalRate.rate))
+	prometheusSubtreeProcessorTxArrivalRate.Set(stp.arrivalRate.rate)
+
+	newSize := subtreeSizeForRate(stp.arrivalRate.rate, target, stp.settings.BlockAssembly.MinimumMerkleItemsPerSubtree,
+		stp.settings.BlockAssembly.MaximumMerkleItemsPerSubtree)
+
+	if newSize == stp.currentItemsPerFile || len(stp.chainedSubtrees) > 0 {
+		return
+	}
+
+	if stp.currentSubtree != nil {
+		if stp.currentSubtree.Length() >= newSize {
+			// the current subtree does not fit the smaller size, it is resized once a block empties it
+			return
+		}
+
+		resized, err := resizeSubtree(stp.currentSubtree, newSize)
+		if err != nil {
+			stp.logger.Errorf("[adjustSubtreeSizeToArrivalRate] error resizing current subtree to %d: %v", newSize, err)
+			return
+		}
+
+		stp.currentSubtree = resized
+	}
+
+	stp.logger.Debugf("[adjustSubtreeSizeToArrivalRate] arrival rate %.1f tx/s, setting subtree size from %d to %d",
+		stp.arrivalRate.rate, stp.currentItemsPerFile, newSize)
+
+	stp.setCurrentItemsPerFile(newSize)
+}
+
+// resizeSubtree returns a copy of the subtree with capacity for size nodes
+func resizeSubtree(st *subtreepkg.Subtree, size int) (*subtreepkg.Subtree, error) {
+	resized, err := subtreepkg.NewTreeByLeafCount(size)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, node := range st.Nodes {
+		if i == 0 && node.Hash.Equal(*subtreepkg.CoinbasePlaceholderHash) {
+			err = resized.AddCoinbaseNode()
+		} else {
+			err = resized.AddSubtreeNode(node)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, conflictingNode := range st.ConflictingNodes {
+		if err = resized.AddConflictingNode(conflictingNode); err != nil {
+			return nil, err
+		}
+	}
+
+	return resized, nil
+}
+
+// TxArrivalRate returns the smoothed transaction arrival rate in transactions per second, it is only measured when
+// subtrees are sized to the arrival rate
+func (stp *SubtreeProcessor) TxArrivalRate() float64 {
+	return math.Float64frombits(stp.txArrivalRate.Load())
+}
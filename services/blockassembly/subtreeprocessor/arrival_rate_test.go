@@ -0,0 +1,153 @@
+package subtreeprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrivalRateTracker(t *testing.T) {
+	start := time.Now()
+	tracker := arrivalRateTracker{window: 10 * time.Second}
+
+	assert.False(t, tracker.sample(start, time.Second), "the first sample starts the measurement")
+
+	tracker.add(500)
+	assert.False(t, tracker.sample(start.Add(500*time.Millisecond), time.Second), "too early")
+
+	tracker.add(500)
+	require.True(t, tracker.sample(start.Add(time.Second), time.Second))
+	assert.InDelta(t, 1000, tracker.rate, 0.001, "a spike is followed at once")
+
+	require.True(t, tracker.sample(start.Add(2*time.Second), time.Second))
+	assert.Less(t, tracker.rate, 1000.0, "a drop decays")
+	assert.Greater(t, tracker.rate, 800.0, "a drop decays over the window")
+
+	for i := 3; i < 100; i++ {
+		tracker.sample(start.Add(time.Duration(i)*time.Second), time.Second)
+	}
+
+	assert.Less(t, tracker.rate, 1.0)
+}
+
+func TestSubtreeSizeForRate(t *testing.T) {
+	tests := []struct {
+		rate     float64
+		expected int
+	}{
+		{rate: 0, expected: 4},
+		{rate: 3, expected: 4},
+		{rate: 5, expected: 8},
+		{rate: 1000, expected: 1024},
+		{rate: 1024, expected: 1024},
+		{rate: 1025, expected: 2048},
+		{rate: 1_000_000, expected: 65536},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, subtreeSizeForRate(tt.rate, time.Second, 4, 65536), "rate %f", tt.rate)
+	}
+
+	assert.Equal(t, 2048, subtreeSizeForRate(1000, 2*time.Second, 4, 65536), "longer target interval")
+}
+
+func TestSubtreeProcessor_AdjustSubtreeSizeToArrivalRate(t *testing.T) {
+	newProcessor := func(t *testing.T, size int) *SubtreeProcessor {
+		tSettings := test.CreateBaseTestSettings(t)
+		tSettings.BlockAssembly.ArrivalRateSubtreeSize = true
+		tSettings.BlockAssembly.SubtreeTargetFillInterval = time.Second
+		tSettings.BlockAssembly.MinimumMerkleItemsPerSubtree = 4
+		tSettings.BlockAssembly.MaximumMerkleItemsPerSubtree = 1024
+
+		initPrometheusMetrics()
+
+		currentSubtree, err := subtreepkg.NewTreeByLeafCount(size)
+		require.NoError(t, err)
+		require.NoError(t, currentSubtree.AddCoinbaseNode())
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, currentSubtree.AddSubtreeNode(subtreepkg.Node{Hash: chainhash.HashH([]byte{byte(i)}), Fee: 10, SizeInBytes: 100}))
+		}
+
+		stp := &SubtreeProcessor{
+			settings:       tSettings,
+			logger:         ulogger.TestLogger{},
+			currentSubtree: currentSubtree,
+			arrivalRate:    arrivalRateTracker{window: 10 * time.Second},
+		}
+		stp.setCurrentItemsPerFile(size)
+
+		return stp
+	}
+
+	// arrive feeds the processor with the given arrival rate for a second
+	arrive := func(stp *SubtreeProcessor, start time.Time, rate int) {
+		stp.adjustSubtreeSizeToArrivalRate(start)
+		stp.arrivalRate.add(rate)
+		stp.adjustSubtreeSizeToArrivalRate(start.Add(time.Second))
+	}
+
+	t.Run("grows on a spike", func(t *testing.T) {
+		stp := newProcessor(t, 8)
+
+		arrive(stp, time.Now(), 300)
+
+		assert.Equal(t, 512, stp.CurrentItemsPerFile())
+		assert.InDelta(t, 300, stp.TxArrivalRate(), 0.001)
+		assert.Equal(t, 512, stp.currentSubtree.Size(), "the current subtree is resized")
+		assert.Equal(t, 4, stp.currentSubtree.Length(), "the nodes are kept")
+		assert.True(t, stp.currentSubtree.Nodes[0].Hash.Equal(*subtreepkg.CoinbasePlaceholderHash))
+		assert.Equal(t, uint64(30), stp.currentSubtree.Fees)
+	})
+
+	t.Run("capped at the maximum size", func(t *testing.T) {
+		stp := newProcessor(t, 8)
+
+		arrive(stp, time.Now(), 100_000)
+
+		assert.Equal(t, 1024, stp.CurrentItemsPerFile())
+	})
+
+	t.Run("shrinks under low load", func(t *testing.T) {
+		stp := newProcessor(t, 1024)
+
+		arrive(stp, time.Now(), 5)
+
+		assert.Equal(t, 8, stp.CurrentItemsPerFile())
+		assert.Equal(t, 8, stp.currentSubtree.Size())
+	})
+
+	t.Run("does not shrink below the current subtree length", func(t *testing.T) {
+		stp := newProcessor(t, 1024)
+
+		arrive(stp, time.Now(), 1)
+
+		assert.Equal(t, 1024, stp.CurrentItemsPerFile(), "4 nodes do not fit a subtree of 4 with room to spare")
+	})
+
+	t.Run("size is locked while the block candidate has complete subtrees", func(t *testing.T) {
+		stp := newProcessor(t, 8)
+		stp.chainedSubtrees = []*subtreepkg.Subtree{stp.currentSubtree}
+
+		arrive(stp, time.Now(), 300)
+
+		assert.Equal(t, 8, stp.CurrentItemsPerFile())
+		assert.InDelta(t, 300, stp.TxArrivalRate(), 0.001, "the rate is still measured")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		stp := newProcessor(t, 8)
+		stp.settings.BlockAssembly.ArrivalRateSubtreeSize = false
+
+		arrive(stp, time.Now(), 300)
+
+		assert.Equal(t, 8, stp.CurrentItemsPerFile())
+		assert.Zero(t, stp.TxArrivalRate())
+	})
+}
@@ -46,6 +46,15 @@ type Interface interface {
 	//   - txInpoints: Transaction input points for dependency tracking
 	Add(node subtree.Node, txInpoints subtree.TxInpoints)
 
+	// AddWithPriority adds a transaction node to the given priority lane of the processor.
+	// Transactions of an enabled lane are placed ahead of the other transactions, within the quota of the lane.
+	//
+	// Parameters:
+	//   - node: The transaction node to add to processing
+	//   - txInpoints: Transaction input points for dependency tracking
+	//   - priority: The priority lane of the transaction
+	AddWithPriority(node subtree.Node, txInpoints subtree.TxInpoints, priority TxPriority)
+
 	// AddDirectly adds a transaction node directly to the processor without
 	// using the queue. This is typically used for block assembly startup.
 	// It allows immediate processing of transactions without waiting for
@@ -215,6 +224,20 @@ type Interface interface {
 	//   - v: Number of items per file to configure
 	SetCurrentItemsPerFile(v int)
 
+	// CurrentItemsPerFile returns the effective subtree size, the number of items of new subtrees.
+	// This reflects dynamic and arrival rate sizing of subtrees.
+	//
+	// Returns:
+	//   - int: Effective subtree size
+	CurrentItemsPerFile() int
+
+	// TxArrivalRate returns the smoothed transaction arrival rate used to size subtrees.
+	// It is only measured when subtrees are sized to the arrival rate.
+	//
+	// Returns:
+	//   - float64: Arrival rate in transactions per second
+	TxArrivalRate() float64
+
 	// TxCount returns the total number of transactions processed.
 	// This metric helps monitor processor throughput and performance.
 	//
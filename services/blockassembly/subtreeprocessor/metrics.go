@@ -30,6 +30,10 @@ var (
 	prometheusSubtreeProcessorReset                        prometheus.Histogram
 	prometheusSubtreeProcessorDynamicSubtreeSize           prometheus.Gauge
 	prometheusSubtreeProcessorCurrentState                 prometheus.Gauge
+	prometheusSubtreeProcessorTxArrivalRate                prometheus.Gauge
+	prometheusSubtreeProcessorPriorityLaneTxs              *prometheus.CounterVec
+	prometheusSubtreeProcessorPriorityLaneBytes            *prometheus.CounterVec
+	prometheusSubtreeProcessorPriorityLaneQueued           *prometheus.GaugeVec
 )
 
 var (
@@ -182,6 +186,45 @@ func _initPrometheusMetrics() {
 		},
 	)
 
+	prometheusSubtreeProcessorTxArrivalRate = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "subtreeprocessor",
+			Name:      "tx_arrival_rate",
+			Help:      "Smoothed transaction arrival rate in transactions per second, used to size subtrees",
+		},
+	)
+
+	prometheusSubtreeProcessorPriorityLaneTxs = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "subtreeprocessor",
+			Name:      "priority_lane_txs",
+			Help:      "Number of transactions added to subtrees per priority lane",
+		},
+		[]string{"lane"},
+	)
+
+	prometheusSubtreeProcessorPriorityLaneBytes = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "subtreeprocessor",
+			Name:      "priority_lane_bytes",
+			Help:      "Size in bytes of the transactions added to subtrees per priority lane",
+		},
+		[]string{"lane"},
+	)
+
+	prometheusSubtreeProcessorPriorityLaneQueued = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "subtreeprocessor",
+			Name:      "priority_lane_queued",
+			Help:      "Number of transactions waiting in each priority lane",
+		},
+		[]string{"lane"},
+	)
+
 	prometheusSubtreeProcessorCurrentState = promauto.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "teranode",
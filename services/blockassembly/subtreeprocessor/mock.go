@@ -109,6 +109,16 @@ func (m *MockSubtreeProcessor) SetCurrentItemsPerFile(v int) {
 	m.Called(v)
 }
 
+func (m *MockSubtreeProcessor) CurrentItemsPerFile() int {
+	args := m.Called()
+	return args.Int(0)
+}
+
+func (m *MockSubtreeProcessor) TxArrivalRate() float64 {
+	args := m.Called()
+	return args.Get(0).(float64)
+}
+
 func (m *MockSubtreeProcessor) TxCount() uint64 {
 	args := m.Called()
 	return args.Get(0).(uint64)
@@ -129,6 +139,11 @@ func (m *MockSubtreeProcessor) Add(node subtree.Node, txInpoints subtree.TxInpoi
 	m.Called(node, txInpoints)
 }
 
+// AddWithPriority implements Interface.AddWithPriority
+func (m *MockSubtreeProcessor) AddWithPriority(node subtree.Node, txInpoints subtree.TxInpoints, priority TxPriority) {
+	m.Called(node, txInpoints, priority)
+}
+
 func (m *MockSubtreeProcessor) AddDirectly(node subtree.Node, txInpoints subtree.TxInpoints, skipNotification bool) error {
 	args := m.Called(node, txInpoints, skipNotification)
 
@@ -0,0 +1,225 @@
+// This file implements the priority lanes of the subtree processor.
+//
+// Transactions added to a priority lane are placed ahead of the other transactions in the block candidate. Each lane
+// has a quota, the percentage of each subtree it may fill ahead of the other transactions. Once the quota of a lane is
+// used up in the current subtree, the transactions of the lane are taken in arrival order together with the other
+// transactions, so a lane never delays its transactions compared to not having the lane.
+//
+// A transaction may only be placed ahead of transactions that arrived before it when it does not spend any of them.
+// The validator therefore only assigns a lane to transactions of which all parents are mined. Children of a lane
+// transaction arrive after it, so they are always placed after it.
+package subtreeprocessor
+
+import (
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TxPriority is the priority lane of a transaction in block assembly
+type TxPriority uint32
+
+const (
+	// TxPriorityDefault is the lane of transactions without priority
+	TxPriorityDefault TxPriority = iota
+
+	// TxPriorityOperator is the lane of time-sensitive transactions tagged by the operator
+	TxPriorityOperator
+
+	// TxPriorityConsolidation is the lane of consolidation transactions
+	TxPriorityConsolidation
+)
+
+// String returns the name of the priority lane, as used in the metrics
+func (p TxPriority) String() string {
+	switch p {
+	case TxPriorityDefault:
+		return "default"
+	case TxPriorityOperator:
+		return "operator"
+	case TxPriorityConsolidation:
+		return "consolidation"
+	default:
+		return "unknown"
+	}
+}
+
+// priorityLane holds the queue of a priority lane and the use of its quota in the current subtree
+type priorityLane struct {
+	priority TxPriority
+	queue    *LockFreeQueue
+
+	// quota is the percentage of each subtree the lane may fill ahead of the other transactions
+	quota int
+
+	// subtree is the subtree used counts the transactions of, the count restarts in every new subtree
+	subtree *subtreepkg.Subtree
+	used    int
+
+	txs    prometheus.Counter
+	bytes  prometheus.Counter
+	queued prometheus.Gauge
+}
+
+func newPriorityLane(priority TxPriority, queue *LockFreeQueue, quota int) *priorityLane {
+	return &priorityLane{
+		priority: priority,
+		queue:    queue,
+		quota:    min(quota, 100),
+		txs:      prometheusSubtreeProcessorPriorityLaneTxs.WithLabelValues(priority.String()),
+		bytes:    prometheusSubtreeProcessorPriorityLaneBytes.WithLabelValues(priority.String()),
+		queued:   prometheusSubtreeProcessorPriorityLaneQueued.WithLabelValues(priority.String()),
+	}
+}
+
+// newPriorityLanes returns the lanes with a quota configured, in the order they are served
+func newPriorityLanes(tSettings *settings.Settings) []*priorityLane {
+	lanes := make([]*priorityLane, 0, 2)
+
+	if tSettings.BlockAssembly.OperatorLaneQuota > 0 {
+		lanes = append(lanes, newPriorityLane(TxPriorityOperator, NewLockFreeQueue(), tSettings.BlockAssembly.OperatorLaneQuota))
+	}
+
+	if tSettings.BlockAssembly.ConsolidationLaneQuota > 0 {
+		lanes = append(lanes, newPriorityLane(TxPriorityConsolidation, NewLockFreeQueue(), tSettings.BlockAssembly.ConsolidationLaneQuota))
+	}
+
+	return lanes
+}
+
+// hasQuota returns whether the lane may still add transactions to the subtree ahead of the other transactions
+func (l *priorityLane) hasQuota(st *subtreepkg.Subtree, itemsPerSubtree int) bool {
+	if l.subtree != st {
+		return true
+	}
+
+	return l.used < max(itemsPerSubtree*l.quota/100, 1)
+}
+
+// added counts a transaction of the lane added to the subtree
+func (l *priorityLane) added(st *subtreepkg.Subtree, node subtreepkg.Node) {
+	if l.subtree != st {
+		l.subtree = st
+		l.used = 0
+	}
+
+	l.used++
+
+	l.txs.Inc()
+	l.bytes.Add(float64(node.SizeInBytes))
+}
+
+// AddWithPriority adds a transaction node to the given priority lane of the processor. Transactions for a lane that
+// is not enabled are added to the default queue.
+//
+// Parameters:
+//   - node: Transaction node to add
+//   - txInpoints: Transaction inpoints for the node
+//   - priority: Priority lane of the transaction
+func (stp *SubtreeProcessor) AddWithPriority(node subtreepkg.Node, txInpoints subtreepkg.TxInpoints, priority TxPriority) {
+	for _, lane := range stp.priorityLanes {
+		if lane.priority == priority {
+			lane.queue.enqueue(node, txInpoints)
+			return
+		}
+	}
+
+	stp.queue.enqueue(node, txInpoints)
+}
+
+// dequeue removes and returns the next transaction to add to the current subtree, together with the lane it was taken
+// from. Lanes with quota left in the current subtree are served first, in order, otherwise the transaction that
+// arrived first in the default queue and the lanes is taken.
+func (stp *SubtreeProcessor) dequeue(validFromMillis int64) (subtreepkg.Node, subtreepkg.TxInpoints, *priorityLane, bool) {
+	if len(stp.priorityLanes) == 0 {
+		node, txInpoints, _, found := stp.queue.dequeue(validFromMillis)
+		return node, txInpoints, nil, found
+	}
+
+	var next *priorityLane
+
+	oldest, found := stp.queue.peek(validFromMillis)
+
+	for _, lane := range stp.priorityLanes {
+		added, ok := lane.queue.peek(validFromMillis)
+		if !ok {
+			continue
+		}
+
+		if lane.hasQuota(stp.currentSubtree, stp.currentItemsPerFile) {
+			next = lane
+			found = true
+
+			break
+		}
+
+		if !found || added < oldest {
+			next = lane
+			oldest = added
+			found = true
+		}
+	}
+
+	if !found {
+		return subtreepkg.Node{}, subtreepkg.TxInpoints{}, nil, false
+	}
+
+	queue := stp.queue
+	if next != nil {
+		queue = next.queue
+	}
+
+	node, txInpoints, _, found := queue.dequeue(validFromMillis)
+
+	return node, txInpoints, next, found
+}
+
+// addedFromLane accounts a transaction added to the subtree from the lane, nil for the default queue. The default
+// queue is only accounted when priority lanes are enabled, to compare the block space used by each lane.
+func (stp *SubtreeProcessor) addedFromLane(lane *priorityLane, st *subtreepkg.Subtree, node subtreepkg.Node) {
+	if len(stp.priorityLanes) == 0 {
+		return
+	}
+
+	if lane == nil {
+		lane = stp.defaultLane
+	}
+
+	lane.added(st, node)
+}
+
+// queues returns the default queue and the queues of the priority lanes
+func (stp *SubtreeProcessor) queues() []*LockFreeQueue {
+	queues := make([]*LockFreeQueue, 0, len(stp.priorityLanes)+1)
+	queues = append(queues, stp.queue)
+
+	for _, lane := range stp.priorityLanes {
+		queues = append(queues, lane.queue)
+	}
+
+	return queues
+}
+
+// priorityLanesLength returns the number of transactions waiting in the priority lanes
+func (stp *SubtreeProcessor) priorityLanesLength() int64 {
+	var length int64
+
+	for _, lane := range stp.priorityLanes {
+		length += lane.queue.length()
+	}
+
+	return length
+}
+
+// updatePriorityLaneMetrics sets the number of transactions waiting in each lane
+func (stp *SubtreeProcessor) updatePriorityLaneMetrics() {
+	if len(stp.priorityLanes) == 0 {
+		return
+	}
+
+	stp.defaultLane.queued.Set(float64(stp.queue.length()))
+
+	for _, lane := range stp.priorityLanes {
+		lane.queued.Set(float64(lane.queue.length()))
+	}
+}
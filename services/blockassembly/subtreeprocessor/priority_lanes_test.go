@@ -0,0 +1,138 @@
+package subtreeprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubtreeProcessor_PriorityLanes(t *testing.T) {
+	newProcessor := func(t *testing.T, operatorQuota, consolidationQuota int) *SubtreeProcessor {
+		tSettings := test.CreateBaseTestSettings(t)
+		tSettings.BlockAssembly.OperatorLaneQuota = operatorQuota
+		tSettings.BlockAssembly.ConsolidationLaneQuota = consolidationQuota
+
+		initPrometheusMetrics()
+
+		currentSubtree, err := subtreepkg.NewTreeByLeafCount(16)
+		require.NoError(t, err)
+
+		queue := NewLockFreeQueue()
+
+		stp := &SubtreeProcessor{
+			settings:       tSettings,
+			logger:         ulogger.TestLogger{},
+			currentSubtree: currentSubtree,
+			queue:          queue,
+			priorityLanes:  newPriorityLanes(tSettings),
+			defaultLane:    newPriorityLane(TxPriorityDefault, queue, 0),
+		}
+		stp.setCurrentItemsPerFile(10)
+
+		return stp
+	}
+
+	node := func(name string) subtreepkg.Node {
+		return subtreepkg.Node{Hash: chainhash.HashH([]byte(name)), Fee: 1, SizeInBytes: 250}
+	}
+
+	// dequeueAll dequeues all transactions into the current subtree and returns them in order
+	dequeueAll := func(t *testing.T, stp *SubtreeProcessor) []chainhash.Hash {
+		hashes := make([]chainhash.Hash, 0)
+
+		for {
+			n, _, lane, found := stp.dequeue(0)
+			if !found {
+				return hashes
+			}
+
+			stp.addedFromLane(lane, stp.currentSubtree, n)
+			require.NoError(t, stp.currentSubtree.AddSubtreeNode(n))
+
+			hashes = append(hashes, n.Hash)
+		}
+	}
+
+	t.Run("lanes go first within their quota", func(t *testing.T) {
+		stp := newProcessor(t, 0, 20)
+
+		stp.Add(node("a"), subtreepkg.TxInpoints{})
+		stp.Add(node("b"), subtreepkg.TxInpoints{})
+
+		time.Sleep(2 * time.Millisecond)
+
+		for _, name := range []string{"c", "d", "e"} {
+			stp.AddWithPriority(node(name), subtreepkg.TxInpoints{}, TxPriorityConsolidation)
+		}
+
+		assert.Equal(t, int64(5), stp.QueueLength())
+
+		// 20% of a subtree of 10 is 2 transactions ahead, the rest in arrival order
+		assert.Equal(t, []chainhash.Hash{node("c").Hash, node("d").Hash, node("a").Hash, node("b").Hash, node("e").Hash},
+			dequeueAll(t, stp))
+		assert.Equal(t, int64(0), stp.QueueLength())
+	})
+
+	t.Run("quota restarts in a new subtree", func(t *testing.T) {
+		stp := newProcessor(t, 0, 10)
+
+		stp.Add(node("a"), subtreepkg.TxInpoints{})
+
+		time.Sleep(2 * time.Millisecond)
+
+		stp.AddWithPriority(node("b"), subtreepkg.TxInpoints{}, TxPriorityConsolidation)
+		stp.AddWithPriority(node("c"), subtreepkg.TxInpoints{}, TxPriorityConsolidation)
+
+		n, _, lane, found := stp.dequeue(0)
+		require.True(t, found)
+		assert.Equal(t, node("b").Hash, n.Hash)
+		stp.addedFromLane(lane, stp.currentSubtree, n)
+
+		nextSubtree, err := subtreepkg.NewTreeByLeafCount(16)
+		require.NoError(t, err)
+
+		stp.currentSubtree = nextSubtree
+
+		n, _, _, found = stp.dequeue(0)
+		require.True(t, found)
+		assert.Equal(t, node("c").Hash, n.Hash)
+	})
+
+	t.Run("lanes are served in order", func(t *testing.T) {
+		stp := newProcessor(t, 50, 50)
+
+		stp.AddWithPriority(node("a"), subtreepkg.TxInpoints{}, TxPriorityConsolidation)
+		stp.AddWithPriority(node("b"), subtreepkg.TxInpoints{}, TxPriorityOperator)
+		stp.Add(node("c"), subtreepkg.TxInpoints{})
+
+		assert.Equal(t, []chainhash.Hash{node("b").Hash, node("a").Hash, node("c").Hash}, dequeueAll(t, stp))
+	})
+
+	t.Run("disabled lane uses the default queue", func(t *testing.T) {
+		stp := newProcessor(t, 0, 0)
+
+		stp.Add(node("a"), subtreepkg.TxInpoints{})
+		stp.AddWithPriority(node("b"), subtreepkg.TxInpoints{}, TxPriorityOperator)
+
+		assert.Equal(t, int64(2), stp.queue.length())
+		assert.Equal(t, []chainhash.Hash{node("a").Hash, node("b").Hash}, dequeueAll(t, stp))
+	})
+
+	t.Run("transactions within the double spend window are not taken", func(t *testing.T) {
+		stp := newProcessor(t, 50, 0)
+
+		stp.AddWithPriority(node("a"), subtreepkg.TxInpoints{}, TxPriorityOperator)
+
+		_, _, _, found := stp.dequeue(time.Now().Add(-time.Second).UnixMilli())
+		assert.False(t, found)
+
+		_, _, _, found = stp.dequeue(time.Now().Add(time.Second).UnixMilli())
+		assert.True(t, found)
+	})
+}
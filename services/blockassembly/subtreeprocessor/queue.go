@@ -112,6 +112,29 @@ func (q *LockFreeQueue) dequeue(validFromMillis int64) (subtree.Node, subtree.Tx
 	return next.node, next.txInpoints, next.time, true
 }
 
+// peek returns the time the next transaction was added to the queue, without removing it.
+// NOTE - This operation is not thread-safe and should only be called from the dequeueing thread.
+//
+// Parameters:
+//   - validFromMillis: Optional timestamp to filter transactions
+//
+// Returns:
+//   - int64: The time in milliseconds the next transaction was added
+//   - bool: false if the queue is empty or the next transaction is not valid yet
+func (q *LockFreeQueue) peek(validFromMillis int64) (int64, bool) {
+	next := q.head.next.Load()
+
+	if next == nil {
+		return 0, false
+	}
+
+	if validFromMillis > 0 && next.time >= validFromMillis {
+		return 0, false
+	}
+
+	return next.time, true
+}
+
 // IsEmpty checks if the queue contains any items.
 //
 // Returns:
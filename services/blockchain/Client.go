@@ -19,10 +19,12 @@ import (
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/google/uuid"
 	"github.com/ordishs/go-utils"
 	"google.golang.org/grpc"
@@ -250,6 +252,51 @@ func (c *Client) AddBlock(ctx context.Context, block *model.Block, peerID string
 	return nil
 }
 
+// StoreBlocks sends a request to add a contiguous range of blocks to the blockchain in a single operation.
+func (c *Client) StoreBlocks(ctx context.Context, blocks []*model.Block, peerID string, opts ...options.StoreBlockOption) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	storeBlockOptions := options.ProcessStoreBlockOptions(opts...)
+	if storeBlockOptions.ID != 0 {
+		return errors.NewInvalidArgumentError("custom block IDs are not supported when storing a range of blocks")
+	}
+
+	external := peerID != ""
+	req := &blockchain_api.StoreBlocksRequest{
+		Blocks:            make([]*blockchain_api.AddBlockRequest, 0, len(blocks)),
+		PeerId:            peerID,
+		OptionMinedSet:    storeBlockOptions.MinedSet,
+		OptionSubtreesSet: storeBlockOptions.SubtreesSet,
+		OptionInvalid:     storeBlockOptions.Invalid,
+	}
+
+	for _, block := range blocks {
+		blockReq := &blockchain_api.AddBlockRequest{
+			Header:           block.Header.Bytes(),
+			CoinbaseTx:       block.CoinbaseTx.Bytes(),
+			SubtreeHashes:    make([][]byte, 0, len(block.Subtrees)),
+			TransactionCount: block.TransactionCount,
+			SizeInBytes:      block.SizeInBytes,
+			External:         external,
+			PeerId:           peerID,
+		}
+
+		for _, subtreeHash := range block.Subtrees {
+			blockReq.SubtreeHashes = append(blockReq.SubtreeHashes, subtreeHash[:])
+		}
+
+		req.Blocks = append(req.Blocks, blockReq)
+	}
+
+	if _, err := c.client.StoreBlocks(ctx, req); err != nil {
+		return errors.UnwrapGRPC(err)
+	}
+
+	return nil
+}
+
 // GetBlock retrieves a block by its hash.
 func (c *Client) GetBlock(ctx context.Context, blockHash *chainhash.Hash) (*model.Block, error) {
 	resp, err := c.client.GetBlock(ctx, &blockchain_api.GetBlockRequest{
@@ -1286,6 +1333,36 @@ func (c *Client) SetState(ctx context.Context, key string, data []byte) error {
 	return nil
 }
 
+// AddAuditRecord appends an audit record of a privileged operation to the audit log of the blockchain store.
+func (c *Client) AddAuditRecord(ctx context.Context, record *audit.Record) error {
+	resp, err := c.client.AddAuditRecord(ctx, blockchain_api.NewAuditRecord(record))
+	if err != nil {
+		return errors.UnwrapGRPC(err)
+	}
+
+	record.ID = resp.Id
+
+	return nil
+}
+
+// GetAuditRecords retrieves a page of the audit records, newest first, and the total number of audit records.
+func (c *Client) GetAuditRecords(ctx context.Context, offset, limit int) ([]*audit.Record, int, error) {
+	resp, err := c.client.GetAuditRecords(ctx, &blockchain_api.GetAuditRecordsRequest{
+		Offset: uint32(offset), // nolint:gosec
+		Limit:  uint32(limit),  // nolint:gosec
+	})
+	if err != nil {
+		return nil, 0, errors.UnwrapGRPC(err)
+	}
+
+	records := make([]*audit.Record, 0, len(resp.Records))
+	for _, record := range resp.Records {
+		records = append(records, record.ToAuditRecord())
+	}
+
+	return records, int(resp.Total), nil
+}
+
 // GetBlockIsMined checks whether a specific block has been marked as mined.
 // This method queries the blockchain service to determine if a block has been
 // processed through the mining pipeline and marked as successfully mined.
@@ -1993,6 +2070,27 @@ func (c *Client) GetBestHeightAndTime(ctx context.Context) (uint32, uint32, erro
 	return resp.Height, resp.Time, nil
 }
 
+// GetCheckpoints retrieves the active hard checkpoints, sorted by height.
+func (c *Client) GetCheckpoints(ctx context.Context) ([]checkpoints.Checkpoint, error) {
+	resp, err := c.client.GetCheckpoints(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	active := make([]checkpoints.Checkpoint, 0, len(resp.Checkpoints))
+
+	for _, cp := range resp.Checkpoints {
+		hash, err := chainhash.NewHash(cp.Hash)
+		if err != nil {
+			return nil, errors.NewProcessingError("invalid checkpoint hash at height %d", cp.Height, err)
+		}
+
+		active = append(active, checkpoints.Checkpoint{Height: cp.Height, Hash: hash, Source: checkpoints.Source(cp.Source)})
+	}
+
+	return active, nil
+}
+
 // log2FloorMasks defines the masks to use when quickly calculating
 // floor(log2(x)) in a constant log2(32) = 5 steps, where x is a uint32, using
 // shifts.  They are derived from (2^(2^x) - 1) * (2^(2^x)), for x in 4..0.
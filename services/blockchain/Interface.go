@@ -12,7 +12,9 @@ import (
 	"github.com/bsv-blockchain/go-bt/v2/chainhash"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
+	"github.com/bsv-blockchain/teranode/util/audit"
 )
 
 // ClientI defines the interface for blockchain client operations.
@@ -63,6 +65,23 @@ type ClientI interface {
 	// - Error if the block addition fails, nil on success
 	AddBlock(ctx context.Context, block *model.Block, peerID string, opts ...options.StoreBlockOption) error
 
+	// StoreBlocks adds a contiguous range of blocks to the blockchain in a single operation.
+	//
+	// This method stores the blocks with multi-row inserts instead of one round trip per block,
+	// e.g. for ranges of checkpointed blocks during catchup. Each block must extend the block
+	// before it and the parent of the first block must already be stored. The range is stored
+	// atomically, and subscribers are notified of every block once the range is stored.
+	//
+	// Parameters:
+	// - ctx: Context for the operation with timeout and cancellation support
+	// - blocks: The contiguous range of blocks to be added, ordered by height
+	// - peerID: Identifier of the peer that provided the blocks (for tracking purposes)
+	// - opts: Optional parameters applied to all blocks (e.g., mined status), custom block IDs are not supported
+	//
+	// Returns:
+	// - Error if storing the range fails, in which case none of the blocks are added
+	StoreBlocks(ctx context.Context, blocks []*model.Block, peerID string, opts ...options.StoreBlockOption) error
+
 	// GetNextBlockID retrieves the next available block ID.
 	//
 	// This method fetches the next block ID that will be assigned to a new block when it is
@@ -569,6 +588,32 @@ type ClientI interface {
 	// - Error if the state storage fails, nil on success
 	SetState(ctx context.Context, key string, data []byte) error
 
+	// AddAuditRecord appends an audit record of a privileged operation.
+	//
+	// The audit log is append-only, records are persisted in the blockchain store and never
+	// updated. The ID assigned to the record by the store is set on the record.
+	//
+	// Parameters:
+	// - ctx: Context for the operation with timeout and cancellation support
+	// - record: Audit record to append
+	//
+	// Returns:
+	// - Error if the record could not be persisted, nil on success
+	AddAuditRecord(ctx context.Context, record *audit.Record) error
+
+	// GetAuditRecords retrieves a page of the audit records, newest first.
+	//
+	// Parameters:
+	// - ctx: Context for the operation with timeout and cancellation support
+	// - offset: Number of records to skip
+	// - limit: Maximum number of records to retrieve
+	//
+	// Returns:
+	// - The audit records of the page
+	// - The total number of audit records
+	// - Error if the records could not be retrieved
+	GetAuditRecords(ctx context.Context, offset, limit int) ([]*audit.Record, int, error)
+
 	// SetBlockMinedSet marks a block as mined.
 	//
 	// This method updates the blockchain database to indicate that a specific block has
@@ -670,6 +715,20 @@ type ClientI interface {
 	// - Error if the retrieval fails
 	GetBestHeightAndTime(ctx context.Context) (uint32, uint32, error)
 
+	// GetCheckpoints retrieves the active hard checkpoints.
+	//
+	// The checkpoints combine the network's chain parameters with the configured and remote
+	// checkpoints. Blocks at a checkpoint height must match its hash, and forks below a reached
+	// checkpoint are rejected.
+	//
+	// Parameters:
+	// - ctx: Context for the operation with timeout and cancellation support
+	//
+	// Returns:
+	// - The active checkpoints, sorted by height
+	// - Error if the retrieval fails
+	GetCheckpoints(ctx context.Context) ([]checkpoints.Checkpoint, error)
+
 	// CheckBlockIsInCurrentChain checks if blocks are in the current chain.
 	//
 	// This method determines whether blocks with the specified IDs are part of the
@@ -12,12 +12,14 @@ import (
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/stores/blob"
 	"github.com/bsv-blockchain/teranode/stores/blockchain"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
 	"github.com/bsv-blockchain/teranode/stores/utxo"
 	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/bsv-blockchain/teranode/util/health"
 )
 
@@ -109,7 +111,32 @@ func (c *LocalClient) AddBlock(ctx context.Context, block *model.Block, peerID s
 
 	c.logger.Infof("[Blockchain LocalClient] stored block %s (ID: %d, height: %d)", block.Hash(), ID, height)
 
-	// Send notification to all subscribers about the new block
+	c.notifyBlock(block)
+
+	return nil
+}
+
+func (c *LocalClient) StoreBlocks(ctx context.Context, blocks []*model.Block, peerID string, opts ...options.StoreBlockOption) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	IDs, err := c.store.StoreBlocks(ctx, blocks, peerID, opts...)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Infof("[Blockchain LocalClient] stored %d blocks %s to %s (IDs: %d to %d)", len(blocks), blocks[0].Hash(), blocks[len(blocks)-1].Hash(), IDs[0], IDs[len(IDs)-1])
+
+	for _, block := range blocks {
+		c.notifyBlock(block)
+	}
+
+	return nil
+}
+
+// notifyBlock sends a notification to all subscribers about a new block
+func (c *LocalClient) notifyBlock(block *model.Block) {
 	notification := &blockchain_api.Notification{
 		Type: model.NotificationType_Block,
 		Hash: block.Hash().CloneBytes(),
@@ -118,11 +145,6 @@ func (c *LocalClient) AddBlock(ctx context.Context, block *model.Block, peerID s
 	c.subscribersMu.RLock()
 	defer c.subscribersMu.RUnlock()
 
-	// Skip if no subscribers
-	if c.subscribers == nil {
-		return nil
-	}
-
 	for source, ch := range c.subscribers {
 		select {
 		case ch <- notification:
@@ -131,8 +153,6 @@ func (c *LocalClient) AddBlock(ctx context.Context, block *model.Block, peerID s
 			c.logger.Warnf("[Blockchain LocalClient] failed to send notification to subscriber %s (channel full)", source)
 		}
 	}
-
-	return nil
 }
 
 func (c *LocalClient) GetBlock(ctx context.Context, blockHash *chainhash.Hash) (*model.Block, error) {
@@ -361,6 +381,14 @@ func (c *LocalClient) SetState(ctx context.Context, key string, data []byte) err
 	return c.store.SetState(ctx, key, data)
 }
 
+func (c *LocalClient) AddAuditRecord(ctx context.Context, record *audit.Record) error {
+	return c.store.AddAuditRecord(ctx, record)
+}
+
+func (c *LocalClient) GetAuditRecords(ctx context.Context, offset, limit int) ([]*audit.Record, int, error) {
+	return c.store.GetAuditRecords(ctx, offset, limit)
+}
+
 func (c *LocalClient) GetBlockIsMined(ctx context.Context, blockHash *chainhash.Hash) (bool, error) {
 	return c.store.GetBlockIsMined(ctx, blockHash)
 }
@@ -454,6 +482,23 @@ func (c *LocalClient) LocateBlockHeaders(ctx context.Context, locator []*chainha
 	return nil, nil
 }
 
+// GetCheckpoints retrieves the active hard checkpoints, sorted by height.
+//
+// Parameters:
+//   - ctx: Context for the operation (unused in this implementation)
+//
+// Returns:
+//   - []checkpoints.Checkpoint: The active checkpoints
+//   - error: If the configured checkpoints are invalid
+func (c *LocalClient) GetCheckpoints(_ context.Context) ([]checkpoints.Checkpoint, error) {
+	registry, err := checkpoints.ForSettings(c.settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.Checkpoints(), nil
+}
+
 // GetBestHeightAndTime retrieves the height and median timestamp of the best block.
 // This method provides essential blockchain state information by returning both the
 // current blockchain height and the median timestamp calculated from recent blocks.
@@ -32,11 +32,14 @@ import (
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	"github.com/bsv-blockchain/teranode/settings"
 	blockchain_store "github.com/bsv-blockchain/teranode/stores/blockchain"
 	blockchainoptions "github.com/bsv-blockchain/teranode/stores/blockchain/options"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/blocktrace"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
 	"github.com/bsv-blockchain/teranode/util/health"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
@@ -47,7 +50,6 @@ import (
 	"github.com/ordishs/go-utils"
 	"github.com/ordishs/gocore"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -307,6 +309,7 @@ func (b *Blockchain) HealthGRPC(ctx context.Context, _ *emptypb.Empty) (*blockch
 		Ok:        status == http.StatusOK,
 		Details:   details,
 		Timestamp: timestamppb.Now(),
+		BuildInfo: buildinfo.Get(b.settings).JSON(),
 	}, errors.WrapGRPC(err)
 }
 
@@ -330,6 +333,10 @@ func (b *Blockchain) HealthGRPC(ctx context.Context, _ *emptypb.Empty) (*blockch
 // Returns:
 // - Error if initialization fails, nil on success
 func (b *Blockchain) Init(ctx context.Context) error {
+	if _, err := checkpoints.LoadFromSettings(ctx, b.logger, b.settings); err != nil {
+		return errors.NewConfigurationError("[Blockchain][Init] failed to load checkpoints", err)
+	}
+
 	b.finiteStateMachine = b.NewFiniteStateMachine()
 
 	// check if we are in local testing mode with a defined target state for the FSM
@@ -716,39 +723,80 @@ func (b *Blockchain) AddBlock(ctx context.Context, request *blockchain_api.AddBl
 	)
 	defer deferFn()
 
-	header, err := model.NewBlockHeaderFromBytes(request.Header)
+	block, err := newBlockFromAddBlockRequest(request)
 	if err != nil {
-		return nil, err
+		return nil, errors.WrapGRPC(err)
 	}
 
-	b.logger.Infof("[Blockchain][AddBlock] AddBlock called: %s", header.Hash().String())
+	b.logger.Infof("[Blockchain][AddBlock] AddBlock called: %s", block.Hash().String())
 
-	btCoinbaseTx, err := bt.NewTxFromBytes(request.CoinbaseTx)
+	// process options for storing
+	storeBlockOptions := make([]blockchainoptions.StoreBlockOption, 0, 3)
+
+	if request.OptionMinedSet {
+		storeBlockOptions = append(storeBlockOptions, blockchainoptions.WithMinedSet(request.OptionMinedSet))
+	}
+	if request.OptionSubtreesSet {
+		storeBlockOptions = append(storeBlockOptions, blockchainoptions.WithSubtreesSet(request.OptionSubtreesSet))
+	}
+	if request.OptionInvalid {
+		storeBlockOptions = append(storeBlockOptions, blockchainoptions.WithInvalid(request.OptionInvalid))
+	}
+	if request.OptionID != 0 {
+		storeBlockOptions = append(storeBlockOptions, blockchainoptions.WithID(request.OptionID))
+	}
+
+	ID, height, err := b.store.StoreBlock(ctx, block, request.PeerId, storeBlockOptions...)
 	if err != nil {
-		return nil, errors.WrapGRPC(errors.NewInvalidArgumentError("[Blockchain][AddBlock] can't create the coinbase transaction", err))
+		return nil, errors.WrapGRPC(err)
 	}
 
-	subtreeHashes := make([]*chainhash.Hash, len(request.SubtreeHashes))
-	for i, subtreeHash := range request.SubtreeHashes {
-		subtreeHashes[i], err = chainhash.NewHash(subtreeHash)
-		if err != nil {
-			return nil, errors.WrapGRPC(errors.NewInvalidArgumentError("[Blockchain][AddBlock] unable to create subtree hash", err))
-		}
+	b.logger.Infof("[AddBlock] stored block %s (ID: %d, height: %d)", block.Hash(), ID, height)
 
-		if subtreeHashes[i].Equal(chainhash.Hash{}) {
-			return nil, errors.WrapGRPC(errors.NewInvalidArgumentError("[Blockchain][AddBlock] unexpected empty subtree hash %d of %d", i, len(request.SubtreeHashes)))
-		}
+	block.Height = height
+
+	b.publishStoredBlock(ctx, block, request.OptionInvalid)
+
+	return &emptypb.Empty{}, nil
+}
+
+// StoreBlocks processes a request to add a contiguous range of blocks to the blockchain.
+//
+// The blocks are persisted in a single operation of the blockchain store, with multi-row inserts
+// instead of one round trip per block, and stored atomically: when any block of the range fails
+// to be stored, none of them are. Once stored, every block is handled as in AddBlock, in the order
+// of the range: it is published to Kafka, unless stored as invalid, and subscribers are notified.
+//
+// Parameters:
+// - ctx: Context for the operation with timeout and cancellation support
+// - request: The StoreBlocksRequest containing the blocks, ordered by height, and the options for all blocks
+//
+// Returns:
+// - Empty response on success
+// - Error if storing the range fails (wrapped for GRPC transmission)
+func (b *Blockchain) StoreBlocks(ctx context.Context, request *blockchain_api.StoreBlocksRequest) (*emptypb.Empty, error) {
+	ctx, _, deferFn := tracing.Tracer("blockchain").Start(ctx, "StoreBlocks",
+		tracing.WithParentStat(b.stats),
+		tracing.WithHistogram(prometheusBlockchainStoreBlocks),
+		tracing.WithDebugLogMessage(b.logger, "[StoreBlocks] called with %d blocks from %s", len(request.Blocks), request.PeerId),
+	)
+	defer deferFn()
+
+	if len(request.Blocks) == 0 {
+		return &emptypb.Empty{}, nil
 	}
 
-	block := &model.Block{
-		Header:           header,
-		CoinbaseTx:       btCoinbaseTx,
-		Subtrees:         subtreeHashes,
-		TransactionCount: request.TransactionCount,
-		SizeInBytes:      request.SizeInBytes,
+	blocks := make([]*model.Block, len(request.Blocks))
+
+	for i, blockRequest := range request.Blocks {
+		block, err := newBlockFromAddBlockRequest(blockRequest)
+		if err != nil {
+			return nil, errors.WrapGRPC(err)
+		}
+
+		blocks[i] = block
 	}
 
-	// process options for storing
 	storeBlockOptions := make([]blockchainoptions.StoreBlockOption, 0, 3)
 
 	if request.OptionMinedSet {
@@ -760,37 +808,88 @@ func (b *Blockchain) AddBlock(ctx context.Context, request *blockchain_api.AddBl
 	if request.OptionInvalid {
 		storeBlockOptions = append(storeBlockOptions, blockchainoptions.WithInvalid(request.OptionInvalid))
 	}
-	if request.OptionID != 0 {
-		storeBlockOptions = append(storeBlockOptions, blockchainoptions.WithID(request.OptionID))
-	}
 
-	ID, height, err := b.store.StoreBlock(ctx, block, request.PeerId, storeBlockOptions...)
+	IDs, err := b.store.StoreBlocks(ctx, blocks, request.PeerId, storeBlockOptions...)
 	if err != nil {
 		return nil, errors.WrapGRPC(err)
 	}
 
-	b.logger.Infof("[AddBlock] stored block %s (ID: %d, height: %d)", block.Hash(), ID, height)
+	// the heights of the range follow from the height of its first block
+	_, firstMeta, err := b.store.GetBlockHeader(ctx, blocks[0].Hash())
+	if err != nil {
+		return nil, errors.WrapGRPC(errors.NewProcessingError("[StoreBlocks] failed to get the height of stored block %s", blocks[0].Hash(), err))
+	}
 
-	block.Height = height
+	b.logger.Infof("[StoreBlocks] stored %d blocks %s to %s (IDs: %d to %d, heights: %d to %d)", len(blocks), blocks[0].Hash(), blocks[len(blocks)-1].Hash(),
+		IDs[0], IDs[len(IDs)-1], firstMeta.Height, firstMeta.Height+uint32(len(blocks)-1)) //nolint:gosec // the range is far smaller than the height space
+
+	for i, block := range blocks {
+		block.Height = firstMeta.Height + uint32(i) //nolint:gosec // the range is far smaller than the height space
+
+		b.publishStoredBlock(ctx, block, request.OptionInvalid)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// newBlockFromAddBlockRequest parses the block data of an AddBlockRequest: header, coinbase transaction and subtree hashes
+func newBlockFromAddBlockRequest(request *blockchain_api.AddBlockRequest) (*model.Block, error) {
+	header, err := model.NewBlockHeaderFromBytes(request.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	btCoinbaseTx, err := bt.NewTxFromBytes(request.CoinbaseTx)
+	if err != nil {
+		return nil, errors.NewInvalidArgumentError("[Blockchain][AddBlock] can't create the coinbase transaction", err)
+	}
+
+	subtreeHashes := make([]*chainhash.Hash, len(request.SubtreeHashes))
+	for i, subtreeHash := range request.SubtreeHashes {
+		subtreeHashes[i], err = chainhash.NewHash(subtreeHash)
+		if err != nil {
+			return nil, errors.NewInvalidArgumentError("[Blockchain][AddBlock] unable to create subtree hash", err)
+		}
+
+		if subtreeHashes[i].Equal(chainhash.Hash{}) {
+			return nil, errors.NewInvalidArgumentError("[Blockchain][AddBlock] unexpected empty subtree hash %d of %d", i, len(request.SubtreeHashes))
+		}
+	}
+
+	return &model.Block{
+		Header:           header,
+		CoinbaseTx:       btCoinbaseTx,
+		Subtrees:         subtreeHashes,
+		TransactionCount: request.TransactionCount,
+		SizeInBytes:      request.SizeInBytes,
+	}, nil
+}
+
+// publishStoredBlock publishes a block that was stored to Kafka, unless it was stored as invalid, and notifies the
+// subscribers about the new block
+func (b *Blockchain) publishStoredBlock(ctx context.Context, block *model.Block, invalid bool) {
+	ctx = blocktrace.ContextFor(ctx, block.Hash())
+	blocktrace.Record(ctx, b.logger, block.Hash(), blocktrace.StageStored)
 
 	b.logger.Debugf("[AddBlock] checking for Kafka producer: %v", b.blocksFinalKafkaAsyncProducer != nil)
 
 	// Only publish to Kafka if the block is valid. Invalid blocks (marked with OptionInvalid)
 	// should not be propagated to downstream consumers via the blocks_final topic.
-	if !request.OptionInvalid {
-		if err = b.sendKafkaBlockFinalNotification(block); err != nil {
+	if !invalid {
+		if err := b.sendKafkaBlockFinalNotification(block); err != nil {
 			b.logger.Errorf("[AddBlock] error sending Kafka notification for new block %s: %v", block.Hash(), err)
 		}
 	}
 
-	if _, err = b.SendNotification(ctx, &blockchain_api.Notification{
+	if _, err := b.SendNotification(ctx, &blockchain_api.Notification{
 		Type: model.NotificationType_Block,
 		Hash: block.Hash().CloneBytes(),
+		Metadata: &blockchain_api.NotificationMetadata{
+			Metadata: map[string]string{blocktrace.MetadataKey: blocktrace.IDFromContext(ctx)},
+		},
 	}); err != nil {
 		b.logger.Errorf("[AddBlock] error sending notification for new block %s: %v", block.Hash(), err)
 	}
-
-	return &emptypb.Empty{}, nil
 }
 
 func (b *Blockchain) sendKafkaBlockFinalNotification(block *model.Block) error {
@@ -811,7 +910,7 @@ func (b *Blockchain) sendKafkaBlockFinalNotification(block *model.Block) error {
 			Height:           block.Height,
 		}
 
-		value, err := proto.Marshal(message)
+		value, err := kafkamessage.Encode(message)
 		if err != nil {
 			b.logger.Errorf("[AddBlock] error creating block bytes: %v", err)
 			return err
@@ -1772,6 +1871,68 @@ func (b *Blockchain) SetState(ctx context.Context, req *blockchain_api.SetStateR
 	return &emptypb.Empty{}, nil
 }
 
+// AddAuditRecord appends an audit record of a privileged operation to the audit log of the blockchain store.
+// The audit log is append-only, the response contains the ID the store assigned to the record.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - req: The audit record to append
+//
+// Returns:
+//   - *blockchain_api.AddAuditRecordResponse: The ID of the appended record
+//   - error: Any error encountered while persisting the record
+func (b *Blockchain) AddAuditRecord(ctx context.Context, req *blockchain_api.AuditRecord) (*blockchain_api.AddAuditRecordResponse, error) {
+	ctx, _, deferFn := tracing.Tracer("blockchain").Start(ctx, "AddAuditRecord",
+		tracing.WithParentStat(b.stats),
+		tracing.WithHistogram(prometheusBlockchainAddAuditRecord),
+		tracing.WithDebugLogMessage(b.logger, "[AddAuditRecord] called with action %s by %s", req.Action, req.Actor),
+	)
+	defer deferFn()
+
+	record := req.ToAuditRecord()
+
+	if err := b.store.AddAuditRecord(ctx, record); err != nil {
+		return nil, errors.WrapGRPC(err)
+	}
+
+	return &blockchain_api.AddAuditRecordResponse{Id: record.ID}, nil
+}
+
+// GetAuditRecords retrieves a page of the audit records, newest first, together with the total number of
+// audit records, so callers can page through the audit log.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - req: The offset and limit of the page
+//
+// Returns:
+//   - *blockchain_api.GetAuditRecordsResponse: The audit records of the page and the total number of records
+//   - error: Any error encountered while retrieving the records
+func (b *Blockchain) GetAuditRecords(ctx context.Context, req *blockchain_api.GetAuditRecordsRequest) (*blockchain_api.GetAuditRecordsResponse, error) {
+	ctx, _, deferFn := tracing.Tracer("blockchain").Start(ctx, "GetAuditRecords",
+		tracing.WithParentStat(b.stats),
+		tracing.WithHistogram(prometheusBlockchainGetAuditRecords),
+		tracing.WithDebugLogMessage(b.logger, "[GetAuditRecords] called with offset %d and limit %d", req.Offset, req.Limit),
+	)
+	defer deferFn()
+
+	records, total, err := b.store.GetAuditRecords(ctx, int(req.Offset), int(req.Limit))
+	if err != nil {
+		return nil, errors.WrapGRPC(err)
+	}
+
+	resp := &blockchain_api.GetAuditRecordsResponse{
+		Records: make([]*blockchain_api.AuditRecord, 0, len(records)),
+		Total:   uint32(total), // nolint:gosec
+	}
+
+	for _, record := range records {
+		resp.Records = append(resp.Records, blockchain_api.NewAuditRecord(record))
+	}
+
+	return resp, nil
+}
+
 // GetBlockHeaderIDs retrieves block header IDs starting from a specific hash.
 // This method fetches a sequence of lightweight block header identifiers (uint32 IDs)
 // from the blockchain service, beginning with the block identified by the provided
@@ -2524,6 +2685,30 @@ func (b *Blockchain) LocateBlockHeaders(ctx context.Context, request *blockchain
 	}, nil
 }
 
+// GetCheckpoints lists the active hard checkpoints, sorted by height.
+func (b *Blockchain) GetCheckpoints(_ context.Context, _ *emptypb.Empty) (*blockchain_api.GetCheckpointsResponse, error) {
+	registry, err := checkpoints.ForSettings(b.settings)
+	if err != nil {
+		return nil, errors.WrapGRPC(err)
+	}
+
+	active := registry.Checkpoints()
+
+	resp := &blockchain_api.GetCheckpointsResponse{
+		Checkpoints: make([]*blockchain_api.Checkpoint, 0, len(active)),
+	}
+
+	for _, cp := range active {
+		resp.Checkpoints = append(resp.Checkpoints, &blockchain_api.Checkpoint{
+			Height: cp.Height,
+			Hash:   cp.Hash.CloneBytes(),
+			Source: string(cp.Source),
+		})
+	}
+
+	return resp, nil
+}
+
 // GetBestHeightAndTime retrieves the current best block height and median time.
 func (b *Blockchain) GetBestHeightAndTime(ctx context.Context, _ *emptypb.Empty) (*blockchain_api.GetBestHeightAndTimeResponse, error) {
 	blockHeader, meta, err := b.store.GetBestBlockHeader(ctx)
@@ -4,7 +4,9 @@ package blockchain_api
 import (
 	"fmt"
 
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/ordishs/go-utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Stringify returns a human-readable string representation of the notification.
@@ -12,3 +14,29 @@ import (
 func (n *Notification) Stringify() string {
 	return fmt.Sprintf("%s: %s, metadata: %s", n.Type.String(), utils.ReverseAndHexEncodeSlice(n.Hash), n.Metadata)
 }
+
+// NewAuditRecord converts an audit record to its API representation.
+func NewAuditRecord(record *audit.Record) *AuditRecord {
+	return &AuditRecord{
+		Id:        record.ID,
+		Timestamp: timestamppb.New(record.Timestamp),
+		Actor:     record.Actor,
+		Action:    record.Action,
+		Target:    record.Target,
+		Details:   record.Details,
+		Dropped:   uint32(record.Dropped), // nolint:gosec
+	}
+}
+
+// ToAuditRecord converts the API representation of an audit record back to an audit record.
+func (r *AuditRecord) ToAuditRecord() *audit.Record {
+	return &audit.Record{
+		ID:        r.Id,
+		Timestamp: r.Timestamp.AsTime(),
+		Actor:     r.Actor,
+		Action:    r.Action,
+		Target:    r.Target,
+		Details:   r.Details,
+		Dropped:   int(r.Dropped),
+	}
+}
@@ -25,6 +25,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	BlockchainAPI_HealthGRPC_FullMethodName                           = "/blockchain_api.BlockchainAPI/HealthGRPC"
 	BlockchainAPI_AddBlock_FullMethodName                             = "/blockchain_api.BlockchainAPI/AddBlock"
+	BlockchainAPI_StoreBlocks_FullMethodName                          = "/blockchain_api.BlockchainAPI/StoreBlocks"
 	BlockchainAPI_GetBlock_FullMethodName                             = "/blockchain_api.BlockchainAPI/GetBlock"
 	BlockchainAPI_GetBlocks_FullMethodName                            = "/blockchain_api.BlockchainAPI/GetBlocks"
 	BlockchainAPI_GetBlockByHeight_FullMethodName                     = "/blockchain_api.BlockchainAPI/GetBlockByHeight"
@@ -59,6 +60,8 @@ const (
 	BlockchainAPI_SendNotification_FullMethodName                     = "/blockchain_api.BlockchainAPI/SendNotification"
 	BlockchainAPI_GetState_FullMethodName                             = "/blockchain_api.BlockchainAPI/GetState"
 	BlockchainAPI_SetState_FullMethodName                             = "/blockchain_api.BlockchainAPI/SetState"
+	BlockchainAPI_AddAuditRecord_FullMethodName                       = "/blockchain_api.BlockchainAPI/AddAuditRecord"
+	BlockchainAPI_GetAuditRecords_FullMethodName                      = "/blockchain_api.BlockchainAPI/GetAuditRecords"
 	BlockchainAPI_GetBlockIsMined_FullMethodName                      = "/blockchain_api.BlockchainAPI/GetBlockIsMined"
 	BlockchainAPI_SetBlockMinedSet_FullMethodName                     = "/blockchain_api.BlockchainAPI/SetBlockMinedSet"
 	BlockchainAPI_GetBlocksMinedNotSet_FullMethodName                 = "/blockchain_api.BlockchainAPI/GetBlocksMinedNotSet"
@@ -77,6 +80,7 @@ const (
 	BlockchainAPI_GetBlockLocator_FullMethodName                      = "/blockchain_api.BlockchainAPI/GetBlockLocator"
 	BlockchainAPI_LocateBlockHeaders_FullMethodName                   = "/blockchain_api.BlockchainAPI/LocateBlockHeaders"
 	BlockchainAPI_GetBestHeightAndTime_FullMethodName                 = "/blockchain_api.BlockchainAPI/GetBestHeightAndTime"
+	BlockchainAPI_GetCheckpoints_FullMethodName                       = "/blockchain_api.BlockchainAPI/GetCheckpoints"
 )
 
 // BlockchainAPIClient is the client API for BlockchainAPI service.
@@ -90,6 +94,9 @@ type BlockchainAPIClient interface {
 	// AddBlock adds a new block to the blockchain.
 	// Called by BlockValidator to add validated blocks.
 	AddBlock(ctx context.Context, in *AddBlockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// StoreBlocks adds a contiguous range of blocks to the blockchain in a single operation.
+	// Called by BlockValidator to add ranges of checkpointed blocks during catchup.
+	StoreBlocks(ctx context.Context, in *StoreBlocksRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// GetBlock retrieves a block by its hash.
 	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
 	// GetBlocks retrieves multiple blocks starting from a specific hash.
@@ -158,6 +165,10 @@ type BlockchainAPIClient interface {
 	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*StateResponse, error)
 	// SetState stores state data with a key.
 	SetState(ctx context.Context, in *SetStateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// AddAuditRecord appends an audit record of a privileged operation.
+	AddAuditRecord(ctx context.Context, in *AuditRecord, opts ...grpc.CallOption) (*AddAuditRecordResponse, error)
+	// GetAuditRecords retrieves a page of the audit records, newest first.
+	GetAuditRecords(ctx context.Context, in *GetAuditRecordsRequest, opts ...grpc.CallOption) (*GetAuditRecordsResponse, error)
 	// GetBlockIsMined checks if a block is marked as mined.
 	GetBlockIsMined(ctx context.Context, in *GetBlockIsMinedRequest, opts ...grpc.CallOption) (*GetBlockIsMinedResponse, error)
 	// SetBlockMinedSet marks a block as mined.
@@ -194,6 +205,8 @@ type BlockchainAPIClient interface {
 	LocateBlockHeaders(ctx context.Context, in *LocateBlockHeadersRequest, opts ...grpc.CallOption) (*LocateBlockHeadersResponse, error)
 	// GetBestHeightAndTime retrieves the current best height and median time.
 	GetBestHeightAndTime(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetBestHeightAndTimeResponse, error)
+	// GetCheckpoints lists the active hard checkpoints.
+	GetCheckpoints(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetCheckpointsResponse, error)
 }
 
 type blockchainAPIClient struct {
@@ -224,6 +237,16 @@ func (c *blockchainAPIClient) AddBlock(ctx context.Context, in *AddBlockRequest,
 	return out, nil
 }
 
+func (c *blockchainAPIClient) StoreBlocks(ctx context.Context, in *StoreBlocksRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BlockchainAPI_StoreBlocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainAPIClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetBlockResponse)
@@ -573,6 +596,26 @@ func (c *blockchainAPIClient) SetState(ctx context.Context, in *SetStateRequest,
 	return out, nil
 }
 
+func (c *blockchainAPIClient) AddAuditRecord(ctx context.Context, in *AuditRecord, opts ...grpc.CallOption) (*AddAuditRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddAuditRecordResponse)
+	err := c.cc.Invoke(ctx, BlockchainAPI_AddAuditRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainAPIClient) GetAuditRecords(ctx context.Context, in *GetAuditRecordsRequest, opts ...grpc.CallOption) (*GetAuditRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAuditRecordsResponse)
+	err := c.cc.Invoke(ctx, BlockchainAPI_GetAuditRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainAPIClient) GetBlockIsMined(ctx context.Context, in *GetBlockIsMinedRequest, opts ...grpc.CallOption) (*GetBlockIsMinedResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetBlockIsMinedResponse)
@@ -753,6 +796,16 @@ func (c *blockchainAPIClient) GetBestHeightAndTime(ctx context.Context, in *empt
 	return out, nil
 }
 
+func (c *blockchainAPIClient) GetCheckpoints(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetCheckpointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCheckpointsResponse)
+	err := c.cc.Invoke(ctx, BlockchainAPI_GetCheckpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlockchainAPIServer is the server API for BlockchainAPI service.
 // All implementations must embed UnimplementedBlockchainAPIServer
 // for forward compatibility.
@@ -764,6 +817,9 @@ type BlockchainAPIServer interface {
 	// AddBlock adds a new block to the blockchain.
 	// Called by BlockValidator to add validated blocks.
 	AddBlock(context.Context, *AddBlockRequest) (*emptypb.Empty, error)
+	// StoreBlocks adds a contiguous range of blocks to the blockchain in a single operation.
+	// Called by BlockValidator to add ranges of checkpointed blocks during catchup.
+	StoreBlocks(context.Context, *StoreBlocksRequest) (*emptypb.Empty, error)
 	// GetBlock retrieves a block by its hash.
 	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
 	// GetBlocks retrieves multiple blocks starting from a specific hash.
@@ -832,6 +888,10 @@ type BlockchainAPIServer interface {
 	GetState(context.Context, *GetStateRequest) (*StateResponse, error)
 	// SetState stores state data with a key.
 	SetState(context.Context, *SetStateRequest) (*emptypb.Empty, error)
+	// AddAuditRecord appends an audit record of a privileged operation.
+	AddAuditRecord(context.Context, *AuditRecord) (*AddAuditRecordResponse, error)
+	// GetAuditRecords retrieves a page of the audit records, newest first.
+	GetAuditRecords(context.Context, *GetAuditRecordsRequest) (*GetAuditRecordsResponse, error)
 	// GetBlockIsMined checks if a block is marked as mined.
 	GetBlockIsMined(context.Context, *GetBlockIsMinedRequest) (*GetBlockIsMinedResponse, error)
 	// SetBlockMinedSet marks a block as mined.
@@ -868,6 +928,8 @@ type BlockchainAPIServer interface {
 	LocateBlockHeaders(context.Context, *LocateBlockHeadersRequest) (*LocateBlockHeadersResponse, error)
 	// GetBestHeightAndTime retrieves the current best height and median time.
 	GetBestHeightAndTime(context.Context, *emptypb.Empty) (*GetBestHeightAndTimeResponse, error)
+	// GetCheckpoints lists the active hard checkpoints.
+	GetCheckpoints(context.Context, *emptypb.Empty) (*GetCheckpointsResponse, error)
 	mustEmbedUnimplementedBlockchainAPIServer()
 }
 
@@ -884,6 +946,9 @@ func (UnimplementedBlockchainAPIServer) HealthGRPC(context.Context, *emptypb.Emp
 func (UnimplementedBlockchainAPIServer) AddBlock(context.Context, *AddBlockRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddBlock not implemented")
 }
+func (UnimplementedBlockchainAPIServer) StoreBlocks(context.Context, *StoreBlocksRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StoreBlocks not implemented")
+}
 func (UnimplementedBlockchainAPIServer) GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
 }
@@ -986,6 +1051,12 @@ func (UnimplementedBlockchainAPIServer) GetState(context.Context, *GetStateReque
 func (UnimplementedBlockchainAPIServer) SetState(context.Context, *SetStateRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetState not implemented")
 }
+func (UnimplementedBlockchainAPIServer) AddAuditRecord(context.Context, *AuditRecord) (*AddAuditRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAuditRecord not implemented")
+}
+func (UnimplementedBlockchainAPIServer) GetAuditRecords(context.Context, *GetAuditRecordsRequest) (*GetAuditRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditRecords not implemented")
+}
 func (UnimplementedBlockchainAPIServer) GetBlockIsMined(context.Context, *GetBlockIsMinedRequest) (*GetBlockIsMinedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockIsMined not implemented")
 }
@@ -1040,6 +1111,9 @@ func (UnimplementedBlockchainAPIServer) LocateBlockHeaders(context.Context, *Loc
 func (UnimplementedBlockchainAPIServer) GetBestHeightAndTime(context.Context, *emptypb.Empty) (*GetBestHeightAndTimeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBestHeightAndTime not implemented")
 }
+func (UnimplementedBlockchainAPIServer) GetCheckpoints(context.Context, *emptypb.Empty) (*GetCheckpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCheckpoints not implemented")
+}
 func (UnimplementedBlockchainAPIServer) mustEmbedUnimplementedBlockchainAPIServer() {}
 func (UnimplementedBlockchainAPIServer) testEmbeddedByValue()                       {}
 
@@ -1097,6 +1171,24 @@ func _BlockchainAPI_AddBlock_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainAPI_StoreBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainAPIServer).StoreBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainAPI_StoreBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainAPIServer).StoreBlocks(ctx, req.(*StoreBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainAPI_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetBlockRequest)
 	if err := dec(in); err != nil {
@@ -1702,6 +1794,42 @@ func _BlockchainAPI_SetState_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainAPI_AddAuditRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditRecord)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainAPIServer).AddAuditRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainAPI_AddAuditRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainAPIServer).AddAuditRecord(ctx, req.(*AuditRecord))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainAPI_GetAuditRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainAPIServer).GetAuditRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainAPI_GetAuditRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainAPIServer).GetAuditRecords(ctx, req.(*GetAuditRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainAPI_GetBlockIsMined_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetBlockIsMinedRequest)
 	if err := dec(in); err != nil {
@@ -2026,6 +2154,24 @@ func _BlockchainAPI_GetBestHeightAndTime_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainAPI_GetCheckpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainAPIServer).GetCheckpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainAPI_GetCheckpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainAPIServer).GetCheckpoints(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BlockchainAPI_ServiceDesc is the grpc.ServiceDesc for BlockchainAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2041,6 +2187,10 @@ var BlockchainAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddBlock",
 			Handler:    _BlockchainAPI_AddBlock_Handler,
 		},
+		{
+			MethodName: "StoreBlocks",
+			Handler:    _BlockchainAPI_StoreBlocks_Handler,
+		},
 		{
 			MethodName: "GetBlock",
 			Handler:    _BlockchainAPI_GetBlock_Handler,
@@ -2173,6 +2323,14 @@ var BlockchainAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetState",
 			Handler:    _BlockchainAPI_SetState_Handler,
 		},
+		{
+			MethodName: "AddAuditRecord",
+			Handler:    _BlockchainAPI_AddAuditRecord_Handler,
+		},
+		{
+			MethodName: "GetAuditRecords",
+			Handler:    _BlockchainAPI_GetAuditRecords_Handler,
+		},
 		{
 			MethodName: "GetBlockIsMined",
 			Handler:    _BlockchainAPI_GetBlockIsMined_Handler,
@@ -2245,6 +2403,10 @@ var BlockchainAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBestHeightAndTime",
 			Handler:    _BlockchainAPI_GetBestHeightAndTime_Handler,
 		},
+		{
+			MethodName: "GetCheckpoints",
+			Handler:    _BlockchainAPI_GetCheckpoints_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
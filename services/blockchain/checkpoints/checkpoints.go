@@ -0,0 +1,272 @@
+// Package checkpoints provides the registry of hard checkpoints consulted by the blockchain store and
+// block validation before accepting blocks or reorganizations.
+//
+// A checkpoint pins the block hash at a given height. Blocks at a checkpoint height must match the
+// pinned hash, and once our chain has reached a checkpoint no fork below it is accepted.
+//
+// Checkpoints are combined from three sources:
+//   - the chain parameters of the configured network
+//   - the blockchain_checkpoints setting, as a list of height:hash pairs
+//   - an optional signed remote file, configured with blockchain_checkpoints_url and
+//     blockchain_checkpoints_public_key
+package checkpoints
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/go-chaincfg"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+)
+
+// Source identifies where a checkpoint was loaded from
+type Source string
+
+const (
+	// SourceChainParams marks checkpoints compiled into the network's chain parameters
+	SourceChainParams Source = "chain_params"
+
+	// SourceSettings marks checkpoints configured with the blockchain_checkpoints setting
+	SourceSettings Source = "settings"
+
+	// SourceRemote marks checkpoints loaded from the signed remote checkpoint file
+	SourceRemote Source = "remote"
+)
+
+// Checkpoint pins the hash of the block at a given height
+type Checkpoint struct {
+	Height uint32
+	Hash   *chainhash.Hash
+	Source Source
+}
+
+// Registry holds the active checkpoints, sorted by height
+type Registry struct {
+	mu           sync.RWMutex
+	checkpoints  []Checkpoint
+	remoteLoaded bool
+}
+
+var (
+	registriesMu sync.Mutex
+	registries   = make(map[string]*Registry)
+)
+
+// NewRegistry creates a registry from the given chain parameter checkpoints
+func NewRegistry(chainCheckpoints []chaincfg.Checkpoint) (*Registry, error) {
+	r := &Registry{}
+
+	for _, cp := range chainCheckpoints {
+		if cp.Height < 0 || cp.Hash == nil {
+			continue
+		}
+
+		if err := r.Add(Checkpoint{Height: uint32(cp.Height), Hash: cp.Hash, Source: SourceChainParams}); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// NewRegistryFromSettings creates a registry from the chain parameters and the blockchain_checkpoints
+// setting. Remote checkpoints are not loaded, see LoadRemote.
+func NewRegistryFromSettings(tSettings *settings.Settings) (*Registry, error) {
+	var chainCheckpoints []chaincfg.Checkpoint
+	if tSettings.ChainCfgParams != nil {
+		chainCheckpoints = tSettings.ChainCfgParams.Checkpoints
+	}
+
+	r, err := NewRegistry(chainCheckpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range tSettings.BlockChain.Checkpoints {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+
+		cp, err := ParseCheckpoint(s)
+		if err != nil {
+			return nil, err
+		}
+
+		cp.Source = SourceSettings
+
+		if err = r.Add(cp); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// ForSettings returns the registry shared by all services of this process running with equivalent
+// settings, so that remote checkpoints loaded by one service are consulted by the others.
+func ForSettings(tSettings *settings.Settings) (*Registry, error) {
+	key := registryKey(tSettings)
+
+	registriesMu.Lock()
+	defer registriesMu.Unlock()
+
+	if r, ok := registries[key]; ok {
+		return r, nil
+	}
+
+	r, err := NewRegistryFromSettings(tSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	registries[key] = r
+
+	return r, nil
+}
+
+func registryKey(tSettings *settings.Settings) string {
+	var network string
+	if tSettings.ChainCfgParams != nil {
+		network = tSettings.ChainCfgParams.Name
+	}
+
+	return strings.Join([]string{
+		network,
+		strings.Join(tSettings.BlockChain.Checkpoints, ","),
+		tSettings.BlockChain.CheckpointsURL,
+		tSettings.BlockChain.CheckpointsPublicKey,
+	}, "|")
+}
+
+// ParseCheckpoint parses a checkpoint in height:hash format
+func ParseCheckpoint(s string) (Checkpoint, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 2 {
+		return Checkpoint{}, errors.NewConfigurationError("invalid checkpoint %q, expected height:hash", s)
+	}
+
+	height, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return Checkpoint{}, errors.NewConfigurationError("invalid checkpoint height %q", parts[0], err)
+	}
+
+	hash, err := chainhash.NewHashFromStr(parts[1])
+	if err != nil {
+		return Checkpoint{}, errors.NewConfigurationError("invalid checkpoint hash %q", parts[1], err)
+	}
+
+	return Checkpoint{Height: uint32(height), Hash: hash}, nil
+}
+
+// Add adds a checkpoint to the registry. Adding a checkpoint that is already known is a no-op, adding
+// a different hash for a height that already has a checkpoint is an error.
+func (r *Registry) Add(cp Checkpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.add(cp)
+}
+
+func (r *Registry) add(cp Checkpoint) error {
+	i := sort.Search(len(r.checkpoints), func(i int) bool {
+		return r.checkpoints[i].Height >= cp.Height
+	})
+
+	if i < len(r.checkpoints) && r.checkpoints[i].Height == cp.Height {
+		if !r.checkpoints[i].Hash.IsEqual(cp.Hash) {
+			return errors.NewConfigurationError("conflicting checkpoints at height %d: %s (%s) and %s (%s)", cp.Height,
+				r.checkpoints[i].Hash.String(), r.checkpoints[i].Source, cp.Hash.String(), cp.Source)
+		}
+
+		return nil
+	}
+
+	r.checkpoints = append(r.checkpoints, Checkpoint{})
+	copy(r.checkpoints[i+1:], r.checkpoints[i:])
+	r.checkpoints[i] = cp
+
+	return nil
+}
+
+// Checkpoints returns a copy of the active checkpoints, sorted by height
+func (r *Registry) Checkpoints() []Checkpoint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checkpoints := make([]Checkpoint, len(r.checkpoints))
+	copy(checkpoints, r.checkpoints)
+
+	return checkpoints
+}
+
+// ChainCheckpoints returns the active checkpoints in chain parameter format, sorted by height
+func (r *Registry) ChainCheckpoints() []chaincfg.Checkpoint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checkpoints := make([]chaincfg.Checkpoint, len(r.checkpoints))
+	for i, cp := range r.checkpoints {
+		checkpoints[i] = chaincfg.Checkpoint{Height: int32(cp.Height), Hash: cp.Hash} //nolint:gosec // block heights fit in int32
+	}
+
+	return checkpoints
+}
+
+// Highest returns the checkpoint with the highest height, if any
+func (r *Registry) Highest() (Checkpoint, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.checkpoints) == 0 {
+		return Checkpoint{}, false
+	}
+
+	return r.checkpoints[len(r.checkpoints)-1], true
+}
+
+// NextAbove returns the lowest checkpoint with a height strictly above the given height, if any
+func (r *Registry) NextAbove(height uint32) (Checkpoint, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := sort.Search(len(r.checkpoints), func(i int) bool {
+		return r.checkpoints[i].Height > height
+	})
+
+	if i == len(r.checkpoints) {
+		return Checkpoint{}, false
+	}
+
+	return r.checkpoints[i], true
+}
+
+// VerifyBlock checks that a block at a checkpoint height matches the checkpoint hash
+func (r *Registry) VerifyBlock(height uint32, hash *chainhash.Hash) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := sort.Search(len(r.checkpoints), func(i int) bool {
+		return r.checkpoints[i].Height >= height
+	})
+
+	if i < len(r.checkpoints) && r.checkpoints[i].Height == height && !r.checkpoints[i].Hash.IsEqual(hash) {
+		return errors.NewBlockInvalidError("block %s at height %d does not match checkpoint %s", hash.String(), height, r.checkpoints[i].Hash.String())
+	}
+
+	return nil
+}
+
+// VerifyFork checks that a fork from forkHeight does not reorganize any checkpoint our chain has already
+// reached at bestHeight.
+func (r *Registry) VerifyFork(forkHeight, bestHeight uint32) error {
+	cp, ok := r.NextAbove(forkHeight)
+	if ok && cp.Height <= bestHeight {
+		return errors.NewBlockInvalidError("fork at height %d is below checkpoint %d (%s)", forkHeight, cp.Height, cp.Hash.String())
+	}
+
+	return nil
+}
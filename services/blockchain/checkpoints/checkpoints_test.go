@@ -0,0 +1,192 @@
+package checkpoints
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/go-chaincfg"
+	bec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedFile(t *testing.T, privateKey *bec.PrivateKey, checkpoints []RemoteCheckpoint) []byte {
+	sig, err := privateKey.Sign(SigningHash(checkpoints))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(RemoteFile{Checkpoints: checkpoints, Signature: hex.EncodeToString(sig.Serialize())})
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestParseCheckpoint(t *testing.T) {
+	hash := &chainhash.Hash{1}
+
+	cp, err := ParseCheckpoint("100:" + hash.String())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(100), cp.Height)
+	assert.True(t, hash.IsEqual(cp.Hash))
+
+	for _, invalid := range []string{"100", "abc:" + hash.String(), "100:xyz", "1:2:3"} {
+		_, err = ParseCheckpoint(invalid)
+		assert.True(t, errors.Is(err, errors.ErrConfiguration), invalid)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r, err := NewRegistry([]chaincfg.Checkpoint{
+		{Height: 200, Hash: &chainhash.Hash{2}},
+		{Height: 100, Hash: &chainhash.Hash{1}},
+	})
+	require.NoError(t, err)
+
+	t.Run("sorted by height", func(t *testing.T) {
+		checkpoints := r.Checkpoints()
+		require.Len(t, checkpoints, 2)
+		assert.Equal(t, uint32(100), checkpoints[0].Height)
+		assert.Equal(t, SourceChainParams, checkpoints[0].Source)
+
+		highest, ok := r.Highest()
+		require.True(t, ok)
+		assert.Equal(t, uint32(200), highest.Height)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		require.NoError(t, r.Add(Checkpoint{Height: 100, Hash: &chainhash.Hash{1}, Source: SourceSettings}))
+		assert.Len(t, r.Checkpoints(), 2, "adding a known checkpoint is a no-op")
+
+		err := r.Add(Checkpoint{Height: 100, Hash: &chainhash.Hash{9}, Source: SourceSettings})
+		assert.True(t, errors.Is(err, errors.ErrConfiguration))
+	})
+
+	t.Run("verify block", func(t *testing.T) {
+		assert.NoError(t, r.VerifyBlock(100, &chainhash.Hash{1}))
+		assert.NoError(t, r.VerifyBlock(150, &chainhash.Hash{9}))
+
+		err := r.VerifyBlock(200, &chainhash.Hash{9})
+		assert.True(t, errors.Is(err, errors.ErrBlockInvalid))
+	})
+
+	t.Run("verify fork", func(t *testing.T) {
+		assert.NoError(t, r.VerifyFork(250, 300), "fork above all checkpoints")
+		assert.NoError(t, r.VerifyFork(150, 190), "checkpoint 200 not reached yet")
+		assert.NoError(t, r.VerifyFork(200, 300), "fork at the checkpoint block itself")
+
+		err := r.VerifyFork(150, 300)
+		assert.True(t, errors.Is(err, errors.ErrBlockInvalid))
+	})
+}
+
+func TestNewRegistryFromSettings(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.BlockChain.Checkpoints = []string{"10:" + (&chainhash.Hash{1}).String(), ""}
+
+	r, err := NewRegistryFromSettings(tSettings)
+	require.NoError(t, err)
+
+	checkpoints := r.Checkpoints()
+	require.Len(t, checkpoints, len(tSettings.ChainCfgParams.Checkpoints)+1)
+
+	tSettings.BlockChain.Checkpoints = []string{"invalid"}
+
+	_, err = NewRegistryFromSettings(tSettings)
+	assert.Error(t, err)
+}
+
+func TestForSettings(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.BlockChain.Checkpoints = []string{"12:" + (&chainhash.Hash{3}).String()}
+
+	r1, err := ForSettings(tSettings)
+	require.NoError(t, err)
+
+	r2, err := ForSettings(tSettings)
+	require.NoError(t, err)
+	assert.Same(t, r1, r2)
+
+	tSettings.BlockChain.Checkpoints = []string{"13:" + (&chainhash.Hash{3}).String()}
+
+	r3, err := ForSettings(tSettings)
+	require.NoError(t, err)
+	assert.NotSame(t, r1, r3)
+}
+
+func TestLoadRemote(t *testing.T) {
+	privateKey, err := bec.NewPrivateKey()
+	require.NoError(t, err)
+
+	publicKeyHex := hex.EncodeToString(privateKey.PubKey().Compressed())
+
+	remote := []RemoteCheckpoint{
+		{Height: 300, Hash: (&chainhash.Hash{3}).String()},
+		{Height: 100, Hash: (&chainhash.Hash{1}).String()},
+	}
+
+	serve := func(data []byte) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(data)
+		}))
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		server := serve(signedFile(t, privateKey, remote))
+		defer server.Close()
+
+		r, err := NewRegistry([]chaincfg.Checkpoint{{Height: 100, Hash: &chainhash.Hash{1}}})
+		require.NoError(t, err)
+
+		require.NoError(t, r.LoadRemote(context.Background(), server.URL, publicKeyHex))
+
+		checkpoints := r.Checkpoints()
+		require.Len(t, checkpoints, 2)
+		assert.Equal(t, SourceChainParams, checkpoints[0].Source)
+		assert.Equal(t, SourceRemote, checkpoints[1].Source)
+
+		// Loading again is a no-op
+		require.NoError(t, r.LoadRemote(context.Background(), "http://127.0.0.1:0", publicKeyHex))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		otherKey, err := bec.NewPrivateKey()
+		require.NoError(t, err)
+
+		server := serve(signedFile(t, otherKey, remote))
+		defer server.Close()
+
+		r, err := NewRegistry(nil)
+		require.NoError(t, err)
+
+		err = r.LoadRemote(context.Background(), server.URL, publicKeyHex)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "signature verification failed")
+		assert.Empty(t, r.Checkpoints())
+	})
+
+	t.Run("conflicting checkpoint", func(t *testing.T) {
+		server := serve(signedFile(t, privateKey, remote))
+		defer server.Close()
+
+		r, err := NewRegistry([]chaincfg.Checkpoint{{Height: 300, Hash: &chainhash.Hash{9}}})
+		require.NoError(t, err)
+
+		err = r.LoadRemote(context.Background(), server.URL, publicKeyHex)
+		assert.True(t, errors.Is(err, errors.ErrConfiguration))
+		assert.Len(t, r.Checkpoints(), 1, "a conflicting file is rejected as a whole")
+	})
+
+	t.Run("missing public key", func(t *testing.T) {
+		r, err := NewRegistry(nil)
+		require.NoError(t, err)
+
+		err = r.LoadRemote(context.Background(), "http://localhost", "")
+		assert.True(t, errors.Is(err, errors.ErrConfiguration))
+	})
+}
@@ -0,0 +1,156 @@
+package checkpoints
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	bec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util"
+)
+
+// RemoteCheckpoint is a checkpoint entry of the remote checkpoint file
+type RemoteCheckpoint struct {
+	Height uint32 `json:"height"`
+	Hash   string `json:"hash"`
+}
+
+// RemoteFile is the signed remote checkpoint file. The signature is a hex encoded DER signature over
+// SigningHash of the checkpoints.
+type RemoteFile struct {
+	Checkpoints []RemoteCheckpoint `json:"checkpoints"`
+	Signature   string             `json:"signature"`
+}
+
+// SigningHash returns the double sha256 hash signed by the checkpoint file publisher: one
+// "height:hash" line per checkpoint, sorted by height.
+func SigningHash(checkpoints []RemoteCheckpoint) []byte {
+	sorted := make([]RemoteCheckpoint, len(checkpoints))
+	copy(sorted, checkpoints)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Height < sorted[j].Height
+	})
+
+	var sb strings.Builder
+	for _, cp := range sorted {
+		_, _ = fmt.Fprintf(&sb, "%d:%s\n", cp.Height, strings.ToLower(cp.Hash))
+	}
+
+	return chainhash.DoubleHashB([]byte(sb.String()))
+}
+
+// ParseRemoteFile parses a remote checkpoint file and verifies its signature against the public key
+func ParseRemoteFile(data []byte, publicKey *bec.PublicKey) ([]Checkpoint, error) {
+	var file RemoteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, errors.NewProcessingError("failed to parse checkpoint file", err)
+	}
+
+	signature, err := hex.DecodeString(file.Signature)
+	if err != nil {
+		return nil, errors.NewProcessingError("invalid checkpoint file signature encoding", err)
+	}
+
+	sig, err := bec.ParseDERSignature(signature)
+	if err != nil {
+		return nil, errors.NewProcessingError("invalid checkpoint file signature", err)
+	}
+
+	if !sig.Verify(SigningHash(file.Checkpoints), publicKey) {
+		return nil, errors.NewProcessingError("checkpoint file signature verification failed")
+	}
+
+	checkpoints := make([]Checkpoint, 0, len(file.Checkpoints))
+
+	for _, cp := range file.Checkpoints {
+		hash, err := chainhash.NewHashFromStr(cp.Hash)
+		if err != nil {
+			return nil, errors.NewProcessingError("invalid checkpoint hash %q at height %d", cp.Hash, cp.Height, err)
+		}
+
+		checkpoints = append(checkpoints, Checkpoint{Height: cp.Height, Hash: hash, Source: SourceRemote})
+	}
+
+	return checkpoints, nil
+}
+
+// LoadRemote fetches the signed checkpoint file from url, verifies it against the hex encoded public key
+// and adds its checkpoints to the registry. The file is only loaded once per registry, later calls are
+// no-ops. A file with a checkpoint conflicting with one already known is rejected as a whole.
+func (r *Registry) LoadRemote(ctx context.Context, url string, publicKeyHex string) error {
+	r.mu.RLock()
+	loaded := r.remoteLoaded
+	r.mu.RUnlock()
+
+	if loaded {
+		return nil
+	}
+
+	if publicKeyHex == "" {
+		return errors.NewConfigurationError("blockchain_checkpoints_public_key is required to load checkpoints from %s", url)
+	}
+
+	publicKey, err := bec.PublicKeyFromString(publicKeyHex)
+	if err != nil {
+		return errors.NewConfigurationError("invalid blockchain_checkpoints_public_key", err)
+	}
+
+	data, err := util.DoHTTPRequest(ctx, url)
+	if err != nil {
+		return errors.NewServiceError("failed to fetch checkpoint file from %s", url, err)
+	}
+
+	remoteCheckpoints, err := ParseRemoteFile(data, publicKey)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.remoteLoaded {
+		return nil
+	}
+
+	merged := &Registry{checkpoints: append([]Checkpoint(nil), r.checkpoints...)}
+	for _, cp := range remoteCheckpoints {
+		if err = merged.add(cp); err != nil {
+			return err
+		}
+	}
+
+	r.checkpoints = merged.checkpoints
+	r.remoteLoaded = true
+
+	return nil
+}
+
+// LoadFromSettings returns the shared registry for the settings, loading the remote checkpoint file first
+// when one is configured. Failing to load the remote file is logged and the local checkpoints stay active,
+// since the node must not stop syncing because the checkpoint publisher is unreachable.
+func LoadFromSettings(ctx context.Context, logger ulogger.Logger, tSettings *settings.Settings) (*Registry, error) {
+	r, err := ForSettings(tSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	if tSettings.BlockChain.CheckpointsURL != "" {
+		if err = r.LoadRemote(ctx, tSettings.BlockChain.CheckpointsURL, tSettings.BlockChain.CheckpointsPublicKey); err != nil {
+			logger.Errorf("[Checkpoints] failed to load remote checkpoints from %s: %v", tSettings.BlockChain.CheckpointsURL, err)
+		}
+	}
+
+	if highest, ok := r.Highest(); ok {
+		logger.Infof("[Checkpoints] %d checkpoints active, highest at height %d", len(r.Checkpoints()), highest.Height)
+	}
+
+	return r, nil
+}
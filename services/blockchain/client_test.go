@@ -12,7 +12,9 @@ import (
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util/test"
 	"github.com/stretchr/testify/assert"
@@ -253,6 +255,64 @@ func TestClientAddBlock(t *testing.T) {
 	})
 }
 
+func TestClientStoreBlocks(t *testing.T) {
+	logger := ulogger.NewErrorTestLogger(t)
+	tSettings := test.CreateBaseTestSettings(t)
+
+	coinbase := bt.NewTx()
+	_ = coinbase.From("0000000000000000000000000000000000000000000000000000000000000000", 0xffffffff, "", 0)
+	_ = coinbase.AddP2PKHOutputFromAddress("mrs6FYWPcb441b4qfcEPyvLvzj64WHtwCU", 5000000000)
+
+	block := &model.Block{
+		Header: &model.BlockHeader{
+			Version:        1,
+			HashPrevBlock:  &chainhash.Hash{},
+			HashMerkleRoot: &chainhash.Hash{},
+			Timestamp:      uint32(time.Now().Unix()),
+			Bits:           model.NBit{0xff, 0xff, 0x00, 0x1d}, // mainnet genesis bits 0x1d00ffff in little endian
+			Nonce:          123,
+		},
+		CoinbaseTx:       coinbase,
+		TransactionCount: 1,
+		SizeInBytes:      1000,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c := &Client{
+			client:   &mockBlockClient{err: nil},
+			logger:   logger,
+			settings: tSettings,
+		}
+
+		err := c.StoreBlocks(context.Background(), []*model.Block{block}, "peer1")
+		require.NoError(t, err)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		c := &Client{
+			client:   &mockBlockClient{err: errors.NewProcessingError("grpc failure")},
+			logger:   logger,
+			settings: tSettings,
+		}
+
+		err := c.StoreBlocks(context.Background(), []*model.Block{block}, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "grpc failure")
+	})
+
+	t.Run("custom block ID", func(t *testing.T) {
+		c := &Client{
+			client:   &mockBlockClient{err: nil},
+			logger:   logger,
+			settings: tSettings,
+		}
+
+		err := c.StoreBlocks(context.Background(), []*model.Block{block}, "peer1", options.WithID(1))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidArgument))
+	})
+}
+
 func TestClientGetBlock(t *testing.T) {
 	logger := ulogger.NewErrorTestLogger(t)
 	tSettings := test.CreateBaseTestSettings(t)
@@ -5269,3 +5329,66 @@ func TestClient_GetBestHeightAndTime(t *testing.T) {
 		assert.Contains(t, err.Error(), "grpc connection failed")
 	})
 }
+
+// Test GetCheckpoints
+func TestClient_GetCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	logger := ulogger.NewErrorTestLogger(t)
+	tSettings := test.CreateBaseTestSettings(t)
+
+	t.Run("success", func(t *testing.T) {
+		hash := chainhash.Hash{1}
+
+		mc := &mockBlockClient{
+			responseGetCheckpoints: &blockchain_api.GetCheckpointsResponse{
+				Checkpoints: []*blockchain_api.Checkpoint{
+					{Height: 100, Hash: hash.CloneBytes(), Source: "settings"},
+				},
+			},
+		}
+		c := &Client{
+			client:   mc,
+			logger:   logger,
+			settings: tSettings,
+		}
+
+		active, err := c.GetCheckpoints(ctx)
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, uint32(100), active[0].Height)
+		assert.True(t, hash.IsEqual(active[0].Hash))
+		assert.Equal(t, checkpoints.SourceSettings, active[0].Source)
+	})
+
+	t.Run("invalid hash", func(t *testing.T) {
+		mc := &mockBlockClient{
+			responseGetCheckpoints: &blockchain_api.GetCheckpointsResponse{
+				Checkpoints: []*blockchain_api.Checkpoint{{Height: 100, Hash: []byte{1, 2, 3}}},
+			},
+		}
+		c := &Client{
+			client:   mc,
+			logger:   logger,
+			settings: tSettings,
+		}
+
+		_, err := c.GetCheckpoints(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("grpc error", func(t *testing.T) {
+		mc := &mockBlockClient{
+			err: errors.NewProcessingError("grpc connection failed"),
+		}
+		c := &Client{
+			client:   mc,
+			logger:   logger,
+			settings: tSettings,
+		}
+
+		active, err := c.GetCheckpoints(ctx)
+		require.Error(t, err)
+		assert.Nil(t, active)
+		assert.Contains(t, err.Error(), "grpc connection failed")
+	})
+}
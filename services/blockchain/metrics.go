@@ -25,6 +25,7 @@ import (
 var (
 	prometheusBlockchainHealth                               prometheus.Counter
 	prometheusBlockchainAddBlock                             prometheus.Histogram
+	prometheusBlockchainStoreBlocks                          prometheus.Histogram
 	prometheusBlockchainGetBlock                             prometheus.Histogram
 	prometheusBlockchainGetBlockStats                        prometheus.Histogram
 	prometheusBlockchainGetBlockGraphData                    prometheus.Histogram
@@ -46,6 +47,8 @@ var (
 	prometheusBlockchainSubscribe                            prometheus.Histogram
 	prometheusBlockchainGetState                             prometheus.Histogram
 	prometheusBlockchainSetState                             prometheus.Histogram
+	prometheusBlockchainAddAuditRecord                       prometheus.Histogram
+	prometheusBlockchainGetAuditRecords                      prometheus.Histogram
 	prometheusBlockchainGetBlockHeaderIDs                    prometheus.Histogram
 	prometheusBlockchainInvalidateBlock                      prometheus.Histogram
 	prometheusBlockchainRevalidateBlock                      prometheus.Histogram
@@ -94,6 +97,16 @@ func _initPrometheusMetrics() {
 		},
 	)
 
+	prometheusBlockchainStoreBlocks = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "blockchain",
+			Name:      "store_blocks",
+			Help:      "Histogram of block ranges added to the blockchain service",
+			Buckets:   util.MetricsBucketsMilliSeconds,
+		},
+	)
+
 	prometheusBlockchainGetBlock = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "teranode",
@@ -300,6 +313,26 @@ func _initPrometheusMetrics() {
 		},
 	)
 
+	prometheusBlockchainAddAuditRecord = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "blockchain",
+			Name:      "add_audit_record",
+			Help:      "Histogram of AddAuditRecord calls to the blockchain service",
+			Buckets:   util.MetricsBucketsMilliSeconds,
+		},
+	)
+
+	prometheusBlockchainGetAuditRecords = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "blockchain",
+			Name:      "get_audit_records",
+			Help:      "Histogram of GetAuditRecords calls to the blockchain service",
+			Buckets:   util.MetricsBucketsMilliSeconds,
+		},
+	)
+
 	prometheusBlockchainGetBlockHeaderIDs = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "teranode",
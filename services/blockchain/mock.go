@@ -8,8 +8,10 @@ import (
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	blockchain_store "github.com/bsv-blockchain/teranode/stores/blockchain"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -39,6 +41,13 @@ func (m *Mock) AddBlock(ctx context.Context, block *model.Block, peerID string,
 	return args.Error(0)
 }
 
+// StoreBlocks mocks the StoreBlocks method
+func (m *Mock) StoreBlocks(ctx context.Context, blocks []*model.Block, peerID string, opts ...options.StoreBlockOption) error {
+	args := m.Called(ctx, blocks, peerID, opts)
+
+	return args.Error(0)
+}
+
 // SendNotification mocks the SendNotification method
 func (m *Mock) SendNotification(ctx context.Context, notification *blockchain_api.Notification) error {
 	args := m.Called(ctx, notification)
@@ -357,6 +366,23 @@ func (m *Mock) SetState(ctx context.Context, key string, data []byte) error {
 	return args.Error(0)
 }
 
+// AddAuditRecord mocks the AddAuditRecord method
+func (m *Mock) AddAuditRecord(ctx context.Context, record *audit.Record) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+// GetAuditRecords mocks the GetAuditRecords method
+func (m *Mock) GetAuditRecords(ctx context.Context, offset, limit int) ([]*audit.Record, int, error) {
+	args := m.Called(ctx, offset, limit)
+
+	if args.Error(2) != nil {
+		return nil, 0, args.Error(2)
+	}
+
+	return args.Get(0).([]*audit.Record), args.Int(1), args.Error(2)
+}
+
 // SetBlockMinedSet mocks the SetBlockMinedSet method
 func (m *Mock) SetBlockMinedSet(ctx context.Context, blockHash *chainhash.Hash) error {
 	args := m.Called(ctx, blockHash)
@@ -403,6 +429,17 @@ func (m *Mock) GetBlocksSubtreesNotSet(ctx context.Context) ([]*model.Block, err
 	return args.Get(0).([]*model.Block), args.Error(1)
 }
 
+// GetCheckpoints mocks the GetCheckpoints method
+func (m *Mock) GetCheckpoints(ctx context.Context) ([]checkpoints.Checkpoint, error) {
+	args := m.Called(ctx)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]checkpoints.Checkpoint), args.Error(1)
+}
+
 // GetBestHeightAndTime mocks the GetBestHeightAndTime method
 func (m *Mock) GetBestHeightAndTime(ctx context.Context) (uint32, uint32, error) {
 	args := m.Called(ctx)
@@ -884,6 +921,7 @@ type mockBlockClient struct {
 	responseLocateBlockHeaders                   *blockchain_api.LocateBlockHeadersResponse
 	lastLocateBlockHeadersReq                    *blockchain_api.LocateBlockHeadersRequest
 	responseGetBestHeightAndTime                 *blockchain_api.GetBestHeightAndTimeResponse
+	responseGetCheckpoints                       *blockchain_api.GetCheckpointsResponse
 	err                                          error
 }
 
@@ -894,6 +932,13 @@ func (m *mockBlockClient) AddBlock(ctx context.Context, in *blockchain_api.AddBl
 	return &emptypb.Empty{}, nil
 }
 
+func (m *mockBlockClient) StoreBlocks(ctx context.Context, in *blockchain_api.StoreBlocksRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &emptypb.Empty{}, nil
+}
+
 func (m *mockBlockClient) GetBlock(ctx context.Context, req *blockchain_api.GetBlockRequest, opts ...grpc.CallOption) (*blockchain_api.GetBlockResponse, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -1253,6 +1298,10 @@ func (m *mockBlockClient) LocateBlockHeaders(ctx context.Context, req *blockchai
 	m.lastLocateBlockHeadersReq = req
 	return m.responseLocateBlockHeaders, m.err
 }
+func (m *mockBlockClient) GetCheckpoints(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*blockchain_api.GetCheckpointsResponse, error) {
+	return m.responseGetCheckpoints, m.err
+}
+
 func (m *mockBlockClient) GetBestHeightAndTime(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*blockchain_api.GetBestHeightAndTimeResponse, error) {
 	return m.responseGetBestHeightAndTime, m.err
 }
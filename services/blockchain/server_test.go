@@ -89,6 +89,60 @@ func Test_AddBlock(t *testing.T) {
 	assert.Equal(t, mockBlk.SizeInBytes, addedBlock.SizeInBytes)
 }
 
+// Test_StoreBlocks verifies the addition of a contiguous range of blocks.
+func Test_StoreBlocks(t *testing.T) {
+	ctx := setup(t)
+
+	block1 := mockBlock(ctx, t)
+
+	coinbase2 := block1.CoinbaseTx.Clone()
+	coinbase2.Inputs[0].UnlockingScript = bscript.NewFromBytes([]byte{0x03, byte(2), 0x00, 0x00})
+
+	header2 := *block1.Header
+	header2.HashPrevBlock = block1.Hash()
+
+	block2 := &model.Block{
+		Header:           &header2,
+		CoinbaseTx:       coinbase2,
+		TransactionCount: block1.TransactionCount,
+		Subtrees:         block1.Subtrees,
+	}
+
+	request := &blockchain_api.StoreBlocksRequest{PeerId: "test-peer"}
+
+	for _, block := range []*model.Block{block1, block2} {
+		subtreeHashes := make([][]byte, len(block.Subtrees))
+		for i, hash := range block.Subtrees {
+			subtreeHashes[i] = hash[:]
+		}
+
+		request.Blocks = append(request.Blocks, &blockchain_api.AddBlockRequest{
+			Header:           block.Header.Bytes(),
+			CoinbaseTx:       block.CoinbaseTx.Bytes(),
+			SubtreeHashes:    subtreeHashes,
+			TransactionCount: block.TransactionCount,
+			SizeInBytes:      block.SizeInBytes,
+		})
+	}
+
+	c := context.Background()
+	response, err := ctx.server.StoreBlocks(c, request)
+	require.NoError(t, err)
+	require.NotNil(t, response)
+
+	// Verify the blocks were added at consecutive heights
+	for height, block := range map[uint32]*model.Block{1: block1, 2: block2} {
+		addedBlock, err := ctx.server.GetBlockByHeight(c, &blockchain_api.GetBlockByHeightRequest{Height: height})
+		require.NoError(t, err)
+		assert.Equal(t, block.Header.Bytes(), addedBlock.Header)
+		assert.Equal(t, block.CoinbaseTx.Bytes(), addedBlock.CoinbaseTx)
+	}
+
+	// A range of blocks that are already stored is refused
+	_, err = ctx.server.StoreBlocks(c, &blockchain_api.StoreBlocksRequest{Blocks: request.Blocks[1:]})
+	require.Error(t, err)
+}
+
 // Test_GetBlock verifies the block retrieval functionality.
 func Test_GetBlock(t *testing.T) {
 	ctx := setup(t)
@@ -3216,6 +3270,24 @@ func Test_GetBlockHeadersToCommonAncestor_gRPC(t *testing.T) {
 	}
 }
 
+// Test_GetCheckpoints tests the GetCheckpoints gRPC method
+func Test_GetCheckpoints(t *testing.T) {
+	ctx := setup(t)
+
+	response, err := ctx.server.GetCheckpoints(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, response.Checkpoints, len(chaincfg.MainNetParams.Checkpoints))
+
+	first := chaincfg.MainNetParams.Checkpoints[0]
+	require.Equal(t, uint32(first.Height), response.Checkpoints[0].Height)
+	require.Equal(t, first.Hash.CloneBytes(), response.Checkpoints[0].Hash)
+	require.Equal(t, "chain_params", response.Checkpoints[0].Source)
+
+	for i := 1; i < len(response.Checkpoints); i++ {
+		require.Greater(t, response.Checkpoints[i].Height, response.Checkpoints[i-1].Height)
+	}
+}
+
 // Test_GetBestHeightAndTime tests the GetBestHeightAndTime gRPC method
 func Test_GetBestHeightAndTime(t *testing.T) {
 	ctx := setup(t)
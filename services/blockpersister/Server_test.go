@@ -18,6 +18,7 @@ import (
 	"github.com/bsv-blockchain/teranode/pkg/fileformat"
 	"github.com/bsv-blockchain/teranode/services/blockchain"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/stores/blob/memory"
 	bloboptions "github.com/bsv-blockchain/teranode/stores/blob/options"
@@ -26,6 +27,7 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/utxo/fields"
 	"github.com/bsv-blockchain/teranode/stores/utxo/meta"
 	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/bsv-blockchain/teranode/util/test"
 	"github.com/jellydator/ttlcache/v3"
 	"github.com/stretchr/testify/assert"
@@ -778,6 +780,9 @@ func (m *MockBlockchainClient) WaitUntilFSMTransitionFromIdleState(ctx context.C
 func (m *MockBlockchainClient) AddBlock(ctx context.Context, block *model.Block, peerID string, opts ...options.StoreBlockOption) error {
 	return nil
 }
+func (m *MockBlockchainClient) StoreBlocks(ctx context.Context, blocks []*model.Block, peerID string, opts ...options.StoreBlockOption) error {
+	return nil
+}
 func (m *MockBlockchainClient) GetNextBlockID(ctx context.Context) (uint64, error) { return 0, nil }
 func (m *MockBlockchainClient) SendNotification(ctx context.Context, notification *blockchain_api.Notification) error {
 	return nil
@@ -866,6 +871,12 @@ func (m *MockBlockchainClient) GetState(ctx context.Context, key string) ([]byte
 func (m *MockBlockchainClient) SetState(ctx context.Context, key string, data []byte) error {
 	return nil
 }
+func (m *MockBlockchainClient) AddAuditRecord(ctx context.Context, record *audit.Record) error {
+	return nil
+}
+func (m *MockBlockchainClient) GetAuditRecords(ctx context.Context, offset, limit int) ([]*audit.Record, int, error) {
+	return nil, 0, nil
+}
 func (m *MockBlockchainClient) SetBlockMinedSet(ctx context.Context, blockHash *chainhash.Hash) error {
 	return nil
 }
@@ -887,6 +898,9 @@ func (m *MockBlockchainClient) GetBlocksSubtreesNotSet(ctx context.Context) ([]*
 func (m *MockBlockchainClient) GetBestHeightAndTime(ctx context.Context) (uint32, uint32, error) {
 	return 0, 0, nil
 }
+func (m *MockBlockchainClient) GetCheckpoints(ctx context.Context) ([]checkpoints.Checkpoint, error) {
+	return nil, nil
+}
 func (m *MockBlockchainClient) CheckBlockIsInCurrentChain(ctx context.Context, blockIDs []uint32) (bool, error) {
 	return false, nil
 }
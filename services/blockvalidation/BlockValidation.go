@@ -43,6 +43,7 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/utxo"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/blocktrace"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
 	"github.com/bsv-blockchain/teranode/util/retry"
@@ -50,7 +51,6 @@ import (
 	"github.com/ordishs/go-utils/expiringmap"
 	"github.com/ordishs/gocore"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/protobuf/proto"
 )
 
 // ValidateBlockOptions provides optional parameters for block validation.
@@ -1020,6 +1020,9 @@ func (u *BlockValidation) ValidateBlockWithOptions(ctx context.Context, block *m
 
 	// Use helper to ensure block is validated only once
 	blockHash := block.Hash()
+
+	// the calls made to validate and store the block carry the lifecycle ID of the block
+	ctx = blocktrace.ContextFor(ctx, blockHash)
 	return u.runOncePerBlock(blockHash, opts, func(opts *ValidateBlockOptions) error {
 		var err error
 
@@ -1187,7 +1190,7 @@ func (u *BlockValidation) ValidateBlockWithOptions(ctx context.Context, block *m
 
 		// Skip difficulty validation for blocks at or below the highest checkpoint
 		// These blocks are already verified by checkpoints, so we don't need to validate difficulty
-		highestCheckpointHeight := getHighestCheckpointHeight(u.settings.ChainCfgParams.Checkpoints)
+		highestCheckpointHeight := getHighestCheckpointHeight(activeCheckpoints(u.settings))
 		skipDifficultyCheck := block.Height <= highestCheckpointHeight
 
 		if skipDifficultyCheck {
@@ -1308,6 +1311,8 @@ func (u *BlockValidation) ValidateBlockWithOptions(ctx context.Context, block *m
 					return
 				}
 
+				blocktrace.Record(decoupledCtx, u.logger, blockHash, blocktrace.StageValidated)
+
 				// Block validation succeeded - now cache it with subtrees loaded
 				u.logger.Debugf("[ValidateBlock][%s] background validation complete, caching block with subtrees", block.Hash().String())
 				u.lastValidatedBlocks.Set(*block.Hash(), block)
@@ -1367,6 +1372,8 @@ func (u *BlockValidation) ValidateBlockWithOptions(ctx context.Context, block *m
 
 			u.logger.Infof("[ValidateBlock][%s] validating block DONE", block.Hash().String())
 
+			blocktrace.Record(ctx, u.logger, blockHash, blocktrace.StageValidated)
+
 			// Cache the block only if subtrees are loaded (they should be from Valid() call)
 			if u.hasValidSubtrees(block) {
 				u.logger.Debugf("[ValidateBlock][%s] caching block with %d subtrees loaded", block.Hash().String(), len(block.SubtreeSlices))
@@ -1401,7 +1408,7 @@ func (u *BlockValidation) ValidateBlockWithOptions(ctx context.Context, block *m
 				// Use background context for critical database operation
 				// This prevents cascading cancellation from parent operations (e.g., fetch timeouts)
 				// ensuring data consistency by completing the write even if catchup is canceled
-				storeCtx, storeCancel := context.WithTimeout(context.Background(), 30*time.Second)
+				storeCtx, storeCancel := context.WithTimeout(blocktrace.ContextWithID(context.Background(), blocktrace.IDFromContext(ctx)), 30*time.Second)
 				defer storeCancel()
 
 				if err = u.blockchainClient.AddBlock(storeCtx, block, baseURL); err != nil {
@@ -1509,7 +1516,7 @@ func (u *BlockValidation) kafkaNotifyBlockInvalid(block *model.Block, reason str
 			Reason:    reason,
 		}
 
-		msgBytes, err := proto.Marshal(msg)
+		msgBytes, err := kafkamessage.Encode(msg)
 		if err != nil {
 			u.logger.Errorf("[ValidateBlock][%s] failed to marshal invalid block message: %v", block.Hash().String(), err)
 		} else {
@@ -1703,7 +1710,7 @@ func (u *BlockValidation) reValidateBlock(blockData revalidateBlockData) error {
 
 	// Skip difficulty validation for blocks at or below the highest checkpoint
 	// These blocks are already verified by checkpoints, so we don't need to validate difficulty
-	highestCheckpointHeight := getHighestCheckpointHeight(u.settings.ChainCfgParams.Checkpoints)
+	highestCheckpointHeight := getHighestCheckpointHeight(activeCheckpoints(u.settings))
 	skipDifficultyCheck := blockData.block.Height <= highestCheckpointHeight
 
 	if skipDifficultyCheck {
@@ -177,6 +177,23 @@ func (m *MockSubtreeValidationClient) CheckBlockSubtrees(ctx context.Context, bl
 	return nil
 }
 
+func (m *MockSubtreeValidationClient) ResizeWorkerPools(ctx context.Context, fetchWorkers, validateWorkers uint32) ([]subtreevalidation.WorkerPoolStatus, error) {
+	resp, err := m.server.ResizeWorkerPools(ctx, &subtreevalidation_api.ResizeWorkerPoolsRequest{
+		FetchWorkers:    fetchWorkers,
+		ValidateWorkers: validateWorkers,
+	})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	pools := make([]subtreevalidation.WorkerPoolStatus, 0, len(resp.GetPools()))
+	for _, pool := range resp.GetPools() {
+		pools = append(pools, subtreevalidation.WorkerPoolStatus{Name: pool.GetName(), Size: int(pool.GetSize()), Active: int(pool.GetActive()), Queued: int(pool.GetQueued())})
+	}
+
+	return pools, nil
+}
+
 // setup prepares a test environment with necessary components for block validation
 // testing. It initializes and configures:
 // - Transaction metadata store
@@ -63,6 +63,7 @@ func NewClient(ctx context.Context, logger ulogger.Logger, tSettings *settings.S
 	baConn, err := util.GetGRPCClient(ctx, blockValidationGrpcAddress, &util.ConnectionOptions{
 		MaxRetries:   tSettings.GRPCMaxRetries,
 		RetryBackoff: tSettings.GRPCRetryBackoff,
+		APIKey:       tSettings.GRPCAdminAPIKey,
 	}, tSettings)
 	if err != nil {
 		return nil, errors.NewServiceError("failed to init block validation service connection for '%s'", source, err)
@@ -252,6 +253,10 @@ func (s *Client) GetCatchupStatus(ctx context.Context) (*CatchupStatus, error) {
 		TotalBlocks:          int(resp.TotalBlocks),
 		BlocksFetched:        resp.BlocksFetched,
 		BlocksValidated:      resp.BlocksValidated,
+		BytesFetched:         resp.BytesFetched,
+		BlocksPerSecond:      resp.BlocksPerSecond,
+		BytesPerSecond:       resp.BytesPerSecond,
+		EtaMs:                resp.EtaMs,
 		StartTime:            resp.StartTime,
 		DurationMs:           resp.DurationMs,
 		ForkDepth:            resp.ForkDepth,
@@ -275,3 +280,48 @@ func (s *Client) GetCatchupStatus(ctx context.Context) (*CatchupStatus, error) {
 
 	return status, nil
 }
+
+// EstimateCatchup estimates the work needed to catch up to the given block without starting the catchup.
+// The estimate includes the number of blocks to fetch, the expected download size based on the
+// average size of recent blocks, and the peers that could serve the catchup with their throughput.
+//
+// Parameters:
+//   - ctx: Context for the operation, allowing for cancellation and timeouts
+//   - blockHash: Hash of the block the catchup would sync up to
+//
+// Returns:
+//   - *CatchupEstimate: Estimated catchup work and candidate peers
+//   - error: Any error encountered during the estimation
+func (s *Client) EstimateCatchup(ctx context.Context, blockHash *chainhash.Hash) (*CatchupEstimate, error) {
+	resp, err := s.apiClient.EstimateCatchup(ctx, &blockvalidation_api.EstimateCatchupRequest{
+		Hash: blockHash.CloneBytes(),
+	})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	estimate := &CatchupEstimate{
+		TargetBlockHash:   resp.TargetBlockHash,
+		TargetBlockHeight: resp.TargetBlockHeight,
+		CurrentHeight:     resp.CurrentHeight,
+		TargetExists:      resp.TargetExists,
+		BlocksToFetch:     resp.BlocksToFetch,
+		AverageBlockSize:  resp.AverageBlockSize,
+		EstimatedBytes:    resp.EstimatedBytes,
+		CandidatePeers:    make([]CatchupPeerCandidate, 0, len(resp.CandidatePeers)),
+	}
+
+	for _, candidate := range resp.CandidatePeers {
+		estimate.CandidatePeers = append(estimate.CandidatePeers, CatchupPeerCandidate{
+			PeerID:                candidate.PeerId,
+			DataHubURL:            candidate.DataHubUrl,
+			Height:                candidate.Height,
+			ReputationScore:       candidate.ReputationScore,
+			AvgResponseTimeMs:     candidate.AvgResponseTimeMs,
+			ThroughputBytesPerSec: candidate.ThroughputBytesPerSec,
+			EstimatedDurationMs:   candidate.EstimatedDurationMs,
+		})
+	}
+
+	return estimate, nil
+}
@@ -54,6 +54,14 @@ func (m *mockBlockValidationAPIClient) GetCatchupStatus(ctx context.Context, in
 	return args.Get(0).(*blockvalidation_api.CatchupStatusResponse), args.Error(1)
 }
 
+func (m *mockBlockValidationAPIClient) EstimateCatchup(ctx context.Context, in *blockvalidation_api.EstimateCatchupRequest, opts ...grpc.CallOption) (*blockvalidation_api.EstimateCatchupResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blockvalidation_api.EstimateCatchupResponse), args.Error(1)
+}
+
 func (m *mockBlockValidationAPIClient) ValidateBlock(ctx context.Context, in *blockvalidation_api.ValidateBlockRequest, opts ...grpc.CallOption) (*blockvalidation_api.ValidateBlockResponse, error) {
 	args := m.Called(ctx, in, opts)
 	if args.Get(0) == nil {
@@ -49,6 +49,9 @@ type Interface interface {
 
 	// GetCatchupStatus returns the current status of blockchain catchup operations.
 	GetCatchupStatus(ctx context.Context) (*CatchupStatus, error)
+
+	// EstimateCatchup estimates the work needed to catch up to the given block without starting the catchup.
+	EstimateCatchup(ctx context.Context, blockHash *chainhash.Hash) (*CatchupEstimate, error)
 }
 
 var _ Interface = &MockBlockValidation{}
@@ -78,3 +81,7 @@ func (mv *MockBlockValidation) RevalidateBlock(ctx context.Context, blockHash ch
 func (mv *MockBlockValidation) GetCatchupStatus(ctx context.Context) (*CatchupStatus, error) {
 	return &CatchupStatus{IsCatchingUp: false}, nil
 }
+
+func (mv *MockBlockValidation) EstimateCatchup(ctx context.Context, blockHash *chainhash.Hash) (*CatchupEstimate, error) {
+	return &CatchupEstimate{TargetBlockHash: blockHash.String(), CandidatePeers: []CatchupPeerCandidate{}}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/bsv-blockchain/go-bt/v2/chainhash"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	"github.com/stretchr/testify/mock"
 )
@@ -429,6 +430,18 @@ func (m *MockBlockchainClient) SetState(ctx context.Context, key string, data []
 	return args.Error(0)
 }
 
+// AddAuditRecord implements the blockchain.ClientI interface
+func (m *MockBlockchainClient) AddAuditRecord(ctx context.Context, record *audit.Record) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+// GetAuditRecords implements the blockchain.ClientI interface
+func (m *MockBlockchainClient) GetAuditRecords(ctx context.Context, offset, limit int) ([]*audit.Record, int, error) {
+	args := m.Called(ctx, offset, limit)
+	return nil, 0, args.Error(2)
+}
+
 // Subscribe implements the blockchain.ClientI interface
 func (m *MockBlockchainClient) Subscribe(ctx context.Context, source string) (chan interface{}, error) {
 	args := m.Called(ctx, source)
@@ -29,8 +29,10 @@ import (
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockassembly"
 	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	"github.com/bsv-blockchain/teranode/services/blockvalidation/blockvalidation_api"
 	"github.com/bsv-blockchain/teranode/services/blockvalidation/catchup"
+	"github.com/bsv-blockchain/teranode/services/p2p"
 	"github.com/bsv-blockchain/teranode/services/subtreevalidation"
 	"github.com/bsv-blockchain/teranode/services/validator"
 	"github.com/bsv-blockchain/teranode/settings"
@@ -38,7 +40,10 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/utxo"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/bandwidth"
 	"github.com/bsv-blockchain/teranode/util/blockassemblyutil"
+	"github.com/bsv-blockchain/teranode/util/blocktrace"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
 	"github.com/bsv-blockchain/teranode/util/health"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
@@ -48,7 +53,6 @@ import (
 	"github.com/ordishs/gocore"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -72,6 +76,10 @@ type processBlockFound struct {
 	// peerID is the P2P peer identifier used for peer tracking via P2P service
 	peerID string
 
+	// block is the already fetched block, e.g. an orphan released once its parent was validated,
+	// nil when the block still needs to be fetched from baseURL
+	block *model.Block
+
 	// errCh receives any errors encountered during block validation and allows
 	// synchronous waiting for validation completion
 	errCh chan error
@@ -163,6 +171,13 @@ type Server struct {
 	// catchupAlternatives tracks alternative peer sources for blocks in catchup
 	catchupAlternatives *ttlcache.Cache[chainhash.Hash, []processBlockCatchup]
 
+	// dataHubMirrors caches the DataHub mirrors advertised by each peer, which fetches from the peer fail over to
+	dataHubMirrors *ttlcache.Cache[string, []string]
+
+	// orphans holds blocks that arrived before their parent until the parent is validated;
+	// nil when the orphan pool is disabled and such blocks are handed to catchup
+	orphans *orphanPool
+
 	// stats tracks operational metrics for monitoring and troubleshooting
 	stats *gocore.Stat
 
@@ -170,6 +185,17 @@ type Server struct {
 	// cascading failures and protect against misbehaving peers
 	peerCircuitBreakers *catchup.PeerCircuitBreakers
 
+	// peerRequestLimiters caps concurrent block and subtree fetches per peer, adapting
+	// each cap to the peer's latency and error rate; nil when the limits are disabled
+	peerRequestLimiters *catchup.PeerRequestLimiters
+
+	// peerBatchSizers tunes the number of blocks requested per catchup fetch round for each peer;
+	// nil when the batch size is fixed
+	peerBatchSizers *catchup.PeerBatchSizers
+
+	// bandwidth is the shared bandwidth budget catchup block and subtree fetches are accounted to
+	bandwidth *bandwidth.Scheduler
+
 	// headerChainCache provides efficient access to block headers during catchup
 	// with proper chain validation to avoid redundant fetches during block validation
 	headerChainCache *catchup.HeaderChainCache
@@ -179,6 +205,10 @@ type Server struct {
 	// BlockValidation is running in the same process as the P2P service.
 	p2pClient P2PClientI
 
+	// peerSelector orders the peers returned by the P2P service for catchup, set by
+	// blockvalidation_catchup_peer_selector; nil keeps the order of the P2P service
+	peerSelector PeerSelector
+
 	// isCatchingUp is an atomic flag to prevent concurrent catchup operations.
 	// When true, indicates that a catchup operation is currently in progress.
 	// This flag ensures only one catchup can run at a time to prevent resource contention.
@@ -223,6 +253,12 @@ type Server struct {
 	blocksFetched   atomic.Int64
 	blocksValidated atomic.Int64
 
+	// bytesFetched counts the bytes downloaded from peers during the current catchup, and catchupRate samples
+	// the progress of the current catchup to report its rates and ETA. Both are reset at the start of each
+	// catchup operation.
+	bytesFetched atomic.Uint64
+	catchupRate  catchupRateTracker
+
 	// previousCatchupAttempt stores details about the last failed catchup attempt.
 	// This is used to display in the dashboard why we switched from one peer to another.
 	// Protected by activeCatchupCtxMu for thread-safe access.
@@ -268,6 +304,39 @@ func New(
 		MaxHalfOpenRequests: 1,
 	}
 
+	// Initialize per-peer in-flight request limits for block and subtree fetches
+	var peerRequestLimiters *catchup.PeerRequestLimiters
+	if tSettings.BlockValidation.PeerMaxInFlightRequests > 0 {
+		peerRequestLimiters = catchup.NewPeerRequestLimiters(catchup.AdaptiveLimiterConfig{
+			MinLimit:      tSettings.BlockValidation.PeerMinInFlightRequests,
+			MaxLimit:      tSettings.BlockValidation.PeerMaxInFlightRequests,
+			LatencyTarget: tSettings.BlockValidation.PeerRequestLatencyTarget,
+			QueueTimeout:  tSettings.BlockValidation.PeerRequestQueueTimeout,
+		})
+	}
+
+	// Initialize per-peer adaptive batch sizes for catchup block fetches
+	var peerBatchSizers *catchup.PeerBatchSizers
+	if tSettings.BlockValidation.FetchAdaptiveBatchSize {
+		peerBatchSizers = catchup.NewPeerBatchSizers(catchup.AdaptiveBatchSizeConfig{
+			MinBatchSize:   tSettings.BlockValidation.FetchMinBatchSize,
+			MaxBatchSize:   tSettings.BlockValidation.FetchLargeBatchSize,
+			TargetDuration: tSettings.BlockValidation.FetchBatchTargetDuration,
+			MaxBatchBytes:  int64(tSettings.BlockValidation.FetchMaxBatchBytes),
+		})
+	}
+
+	// Initialize the ordering of the catchup peers
+	peerSelector, err := newPeerSelector(tSettings.BlockValidation.CatchupPeerSelector, tSettings.BlockValidation)
+	if err != nil {
+		logger.Warnf("Invalid catchup peer selector, keeping the order of the P2P service: %v", err)
+
+		peerSelector = p2pPeerSelector{}
+	}
+
+	bandwidthScheduler := bandwidth.Default()
+	bandwidthScheduler.Configure(bandwidth.ConfigFromSettings(tSettings))
+
 	// Determine near fork threshold (default to coinbase maturity / 2)
 	nearForkThreshold := uint32(tSettings.ChainCfgParams.CoinbaseMaturity / 2)
 	if tSettings.BlockValidation.NearForkThreshold > 0 {
@@ -299,11 +368,20 @@ func New(
 		catchupCh:           make(chan processBlockCatchup, tSettings.BlockValidation.CatchupChBufferSize),
 		processBlockNotify:  ttlcache.New[chainhash.Hash, bool](),
 		catchupAlternatives: ttlcache.New[chainhash.Hash, []processBlockCatchup](ttlcache.WithTTL[chainhash.Hash, []processBlockCatchup](10 * time.Minute)),
+		dataHubMirrors:      ttlcache.New[string, []string](ttlcache.WithTTL[string, []string](dataHubMirrorsTTL)),
 		stats:               gocore.NewStat("blockvalidation"),
 		kafkaConsumerClient: kafkaConsumerClient,
 		peerCircuitBreakers: catchup.NewPeerCircuitBreakers(*cbConfig),
+		peerRequestLimiters: peerRequestLimiters,
+		peerBatchSizers:     peerBatchSizers,
+		bandwidth:           bandwidthScheduler,
 		headerChainCache:    catchup.NewHeaderChainCache(logger),
 		p2pClient:           p2pClient,
+		peerSelector:        peerSelector,
+	}
+
+	if tSettings.BlockValidation.OrphanPoolSize > 0 {
+		bVal.orphans = newOrphanPool(tSettings.BlockValidation.OrphanPoolSize, tSettings.BlockValidation.OrphanMaxAge)
 	}
 
 	return bVal
@@ -435,6 +513,7 @@ func (u *Server) HealthGRPC(ctx context.Context, _ *blockvalidation_api.EmptyMes
 		Ok:        status == http.StatusOK,
 		Details:   details,
 		Timestamp: timestamppb.Now(),
+		BuildInfo: buildinfo.Get(u.settings).JSON(),
 	}, errors.WrapGRPC(err)
 }
 
@@ -468,6 +547,10 @@ func (u *Server) GetCatchupStatus(ctx context.Context, _ *blockvalidation_api.Em
 		TotalBlocks:          int32(status.TotalBlocks),
 		BlocksFetched:        status.BlocksFetched,
 		BlocksValidated:      status.BlocksValidated,
+		BytesFetched:         status.BytesFetched,
+		BlocksPerSecond:      status.BlocksPerSecond,
+		BytesPerSecond:       status.BytesPerSecond,
+		EtaMs:                status.EtaMs,
 		StartTime:            status.StartTime,
 		DurationMs:           status.DurationMs,
 		ForkDepth:            status.ForkDepth,
@@ -504,6 +587,10 @@ func (u *Server) GetCatchupStatus(ctx context.Context, _ *blockvalidation_api.Em
 func (u *Server) Init(ctx context.Context) (err error) {
 	u.logger.Infof("[Init] Starting block validation initialization")
 
+	if _, err = checkpoints.LoadFromSettings(ctx, u.logger, u.settings); err != nil {
+		return errors.NewConfigurationError("[Init] failed to load checkpoints", err)
+	}
+
 	subtreeValidationClient, err := subtreevalidation.NewClient(ctx, u.logger, u.settings, "blockvalidation")
 	if err != nil {
 		return errors.NewServiceError("[Init] failed to create subtree validation client", err)
@@ -733,7 +820,7 @@ func (u *Server) consumerMessageHandler(ctx context.Context) func(msg *kafka.Kaf
 			msg.Topic, msg.Partition, msg.Offset)
 
 		var kafkaMsg kafkamessage.KafkaBlockTopicMessage
-		if err := proto.Unmarshal(msg.Value, &kafkaMsg); err != nil {
+		if err := kafkamessage.Decode(msg.Value, &kafkaMsg); err != nil {
 			u.logger.Errorf("Failed to unmarshal kafka message: %v", err)
 			return nil
 		}
@@ -803,6 +890,9 @@ func (u *Server) blockHandler(kafkaMsg *kafkamessage.KafkaBlockTopicMessage) err
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// continue the lifecycle of the block started when the block was announced
+	ctx = blocktrace.ContextWithID(ctx, blocktrace.Start(hash, kafkaMsg.GetTraceId()))
+
 	// Don't skip blocks from malicious peers entirely - we still want to add them to the queue
 	// in case other peers have the same block. The malicious check will be done when fetching.
 	if u.isPeerMalicious(ctx, kafkaMsg.GetPeerId()) {
@@ -898,6 +988,17 @@ func (u *Server) processBlockFoundChannel(ctx context.Context, blockFound proces
 		return nil
 	}
 
+	// reject bogus announcements before any bandwidth is spent on the block
+	if err = u.checkAnnouncedHeader(ctx, blockFound.hash, blockFound.peerID, blockFound.baseURL); err != nil {
+		u.reportPeerError(ctx, err)
+
+		if blockFound.errCh != nil {
+			blockFound.errCh <- err
+		}
+
+		return err
+	}
+
 	// Check queue depth and determine if we might need catchup mode
 	queueSize := u.blockPriorityQueue.Size()
 	shouldConsiderCatchup := u.settings.BlockValidation.UseCatchupWhenBehind && (queueSize > 10 || len(u.blockFoundCh) > 3)
@@ -922,12 +1023,20 @@ func (u *Server) processBlockFoundChannel(ctx context.Context, blockFound proces
 			return err
 		}
 
-		// If parent doesn't exist, always use catchup
+		// If parent doesn't exist, hold the block until its parent arrives or use catchup
 		if !parentExists {
 			if u.isPeerMalicious(ctx, blockFound.peerID) {
 				u.logger.Warnf("[processBlockFoundChannel][%s] peer %s is malicious, skipping catchup for block with missing parent", blockFound.hash.String(), blockFound.peerID)
 				return nil
 			}
+
+			if u.holdOrphan(ctx, block, blockFound.peerID, blockFound.baseURL) {
+				if blockFound.errCh != nil {
+					blockFound.errCh <- nil
+				}
+				return nil
+			}
+
 			u.logger.Infof("[processBlockFoundChannel] Parent block %s doesn't exist for block %s, using catchup",
 				block.Header.HashPrevBlock.String(), blockFound.hash.String())
 
@@ -1026,15 +1135,37 @@ func (u *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
 	}
 
 	u.logger.Infof("[Start] Starting Kafka consumer with handler")
-	u.kafkaConsumerClient.Start(ctx, u.consumerMessageHandler(ctx), kafka.WithLogErrorAndMoveOn())
+	consumerOptions := []kafka.ConsumerOption{
+		kafka.WithLogErrorAndMoveOn(),
+		kafka.WithRebalanceHooks(
+			func(topic string, partitions []int32) {
+				u.logger.Infof("[Start] Kafka partitions %v of topic %s assigned", partitions, topic)
+			},
+			func(topic string, partitions []int32) {
+				u.logger.Infof("[Start] Kafka partitions %v of topic %s revoked", partitions, topic)
+			},
+		),
+	}
+
+	if u.settings.BlockValidation.KafkaPauseThreshold > 0 {
+		// stop taking new blocks from Kafka while the validation queues are backed up
+		consumerOptions = append(consumerOptions, kafka.WithBackpressure(func() int {
+			return len(u.blockFoundCh) + len(u.catchupCh)
+		}, u.settings.BlockValidation.KafkaPauseThreshold, u.settings.BlockValidation.KafkaResumeThreshold))
+	}
+
+	u.kafkaConsumerClient.Start(ctx, u.consumerMessageHandler(ctx), consumerOptions...)
 
 	u.logger.Infof("[Start] Kafka consumer started successfully")
 
+	// revalidating an invalidated block is protected by the admin API key or a client certificate when configured
+	authOptions := util.NewAuthOptions(u.settings.GRPCAdminAPIKey, u.settings.GRPCAuthClientCert, blockvalidation_api.BlockValidationAPI_RevalidateBlock_FullMethodName)
+
 	// this will block
 	if err := util.StartGRPCServer(ctx, u.logger, u.settings, "blockvalidation", u.settings.BlockValidation.GRPCListenAddress, func(server *grpc.Server) {
 		blockvalidation_api.RegisterBlockValidationAPIServer(server, u)
 		closeOnce.Do(func() { close(readyCh) })
-	}, nil); err != nil {
+	}, authOptions); err != nil {
 		return err
 	}
 
@@ -1094,6 +1225,9 @@ func (u *Server) BlockFound(ctx context.Context, req *blockvalidation_api.BlockF
 			errors.NewProcessingError("[BlockFound][%s] failed to create hash from bytes", utils.ReverseAndHexEncodeSlice(req.Hash), err))
 	}
 
+	// blocks found by other services, e.g. legacy, start their lifecycle here
+	ctx = blocktrace.ContextFor(ctx, hash)
+
 	// first check if the block exists, it is very expensive to do all the checks below
 	exists, err := u.blockValidation.GetBlockExists(ctx, hash)
 	if err != nil {
@@ -1317,6 +1451,8 @@ func (u *Server) processBlockFound(ctx context.Context, hash *chainhash.Hash, pe
 	)
 	defer deferFn()
 
+	ctx = blocktrace.ContextFor(ctx, hash)
+
 	// Check if the peer is malicious before attempting to fetch
 	if u.isPeerMalicious(ctx, peerID) {
 		u.logger.Warnf("[processBlockFound][%s] peer %s is malicious, not fetching from [%s]", hash.String(), peerID, baseURL)
@@ -1342,6 +1478,8 @@ func (u *Server) processBlockFound(ctx context.Context, hash *chainhash.Hash, pe
 		if err != nil {
 			return err
 		}
+
+		blocktrace.Record(ctx, u.logger, hash, blocktrace.StageFetched)
 	}
 
 	u.checkParentProcessingComplete(ctx, block, baseURL)
@@ -1353,6 +1491,10 @@ func (u *Server) processBlockFound(ctx context.Context, hash *chainhash.Hash, pe
 	}
 
 	if !parentExists {
+		if u.holdOrphan(ctx, block, peerID, baseURL) {
+			return nil
+		}
+
 		// add to catchup channel, which will block processing any new blocks until we have caught up
 		go func() {
 			u.logger.Debugf("[processBlockFound][%s] processBlockFound add to catchup channel", hash.String())
@@ -1388,9 +1530,20 @@ func (u *Server) processBlockFound(ctx context.Context, hash *chainhash.Hash, pe
 
 	err = u.blockValidation.ValidateBlockWithOptions(ctx, block, baseURL, u.blockValidation.bloomFilterStats, opts)
 	if err != nil {
+		// only the libp2p peers are in the peer registry of the P2P service
+		if errors.Is(err, errors.ErrBlockInvalid) && baseURL != "legacy" {
+			u.reportInvalidBlock(ctx, peerID, hash.String(), p2p.InvalidDataReasonInvalid, err.Error())
+		}
+
+		// e.g. a subtree of the block that the subtree validation service attributed to the peer serving it
+		u.reportPeerError(ctx, err)
+
 		return errors.NewServiceError("failed block validation BlockFound [%s]", block.String(), err)
 	}
 
+	// validate the blocks that arrived before this block
+	u.releaseOrphans(ctx, *hash)
+
 	return nil
 }
 
@@ -1486,6 +1639,11 @@ func (u *Server) startBlockProcessingSystem(ctx context.Context) {
 		prometheusForkProcessingWorkers.Set(float64(numWorkers))
 	}
 
+	// Release, request the parents of and expire the blocks held in the orphan pool
+	if u.orphans != nil {
+		go u.maintainOrphanPool(ctx)
+	}
+
 	// Log queue statistics periodically
 	go func() {
 		ticker := time.NewTicker(1 * time.Minute)
@@ -1629,6 +1787,13 @@ func (u *Server) addBlockToPriorityQueue(ctx context.Context, blockFound process
 		return
 	}
 
+	if !parentExists && u.holdOrphan(ctx, block, blockFound.peerID, blockFound.baseURL) {
+		if blockFound.errCh != nil {
+			blockFound.errCh <- nil
+		}
+		return
+	}
+
 	if !parentExists {
 		u.logger.Infof("[addBlockToPriorityQueue] Parent block %s doesn't exist for block %s, sending to catchup", block.Header.HashPrevBlock.String(), blockFound.hash.String())
 
@@ -1690,10 +1855,24 @@ func (u *Server) addBlockToPriorityQueue(ctx context.Context, blockFound process
 		return
 	}
 
+	u.enqueueBlock(ctx, blockFound, block)
+
+	// Send success signal if someone is waiting
+	if blockFound.errCh != nil {
+		u.logger.Debugf("[addBlockToPriorityQueue] Sending success response to errCh for block %s", blockFound.hash.String())
+		blockFound.errCh <- nil
+	} else {
+		u.logger.Debugf("[addBlockToPriorityQueue] No errCh to respond to for block %s", blockFound.hash.String())
+	}
+}
+
+// enqueueBlock classifies a fetched block whose parent exists and adds it to the priority queue,
+// and to its fork when it does not extend the chain
+func (u *Server) enqueueBlock(ctx context.Context, blockFound processBlockFound, block *model.Block) {
 	// Classify the block
 	priority, err := u.blockClassifier.ClassifyBlock(ctx, block)
 	if err != nil {
-		u.logger.Warnf("[addBlockToPriorityQueue] Failed to classify block %s, using deep fork priority: %v", blockFound.hash.String(), err)
+		u.logger.Warnf("[enqueueBlock] Failed to classify block %s, using deep fork priority: %v", blockFound.hash.String(), err)
 		priority = PriorityDeepFork
 	}
 
@@ -1701,10 +1880,10 @@ func (u *Server) addBlockToPriorityQueue(ctx context.Context, blockFound process
 	if priority != PriorityChainExtending {
 		forkID, err := u.forkManager.DetermineForkID(ctx, block, u.blockchainClient)
 		if err != nil {
-			u.logger.Warnf("[addBlockToPriorityQueue] Failed to determine fork ID for block %s: %v", blockFound.hash.String(), err)
+			u.logger.Warnf("[enqueueBlock] Failed to determine fork ID for block %s: %v", blockFound.hash.String(), err)
 		} else {
 			if err := u.forkManager.AddBlockToFork(block, forkID); err != nil {
-				u.logger.Errorf("[addBlockToPriorityQueue] Failed to add block to fork %s: %v", forkID, err)
+				u.logger.Errorf("[enqueueBlock] Failed to add block to fork %s: %v", forkID, err)
 			}
 		}
 	}
@@ -1712,15 +1891,7 @@ func (u *Server) addBlockToPriorityQueue(ctx context.Context, blockFound process
 	// Add to priority queue
 	u.blockPriorityQueue.Add(blockFound, priority, block.Height)
 
-	u.logger.Infof("[addBlockToPriorityQueue] Added block %s with priority %d at height %d", blockFound.hash.String(), priority, block.Height)
-
-	// Send success signal if someone is waiting
-	if blockFound.errCh != nil {
-		u.logger.Debugf("[addBlockToPriorityQueue] Sending success response to errCh for block %s", blockFound.hash.String())
-		blockFound.errCh <- nil
-	} else {
-		u.logger.Debugf("[addBlockToPriorityQueue] No errCh to respond to for block %s", blockFound.hash.String())
-	}
+	u.logger.Infof("[enqueueBlock] Added block %s with priority %d at height %d", blockFound.hash.String(), priority, block.Height)
 }
 
 // processBlockWithPriority processes a block based on its priority
@@ -1739,8 +1910,13 @@ func (u *Server) processBlockWithPriority(ctx context.Context, blockFound proces
 		}
 	}
 
-	// Try to process with the primary source
-	err := u.processBlockFound(ctx, blockFound.hash, blockFound.peerID, blockFound.baseURL)
+	// Try to process with the primary source, without fetching a block that was already fetched
+	var useBlock []*model.Block
+	if blockFound.block != nil {
+		useBlock = append(useBlock, blockFound.block)
+	}
+
+	err := u.processBlockFound(ctx, blockFound.hash, blockFound.peerID, blockFound.baseURL, useBlock...)
 
 	// If fetch failed and it's not a validation error, try alternative sources
 	if err != nil && (errors.IsNetworkError(err) || errors.IsMaliciousResponseError(err)) {
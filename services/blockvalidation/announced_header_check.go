@@ -0,0 +1,169 @@
+// This file contains the sanity check of the header of an announced block before the block is downloaded.
+package blockvalidation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/clock"
+)
+
+const (
+	// announcedHeaderTimeout is the timeout of fetching the header of an announced block from the announcing peer
+	announcedHeaderTimeout = 5 * time.Second
+
+	// maxAnnouncedHeaderFutureTime is how far in the future the timestamp of an announced block may be
+	maxAnnouncedHeaderFutureTime = 2 * time.Hour
+)
+
+// Results of the header check of announced blocks, the other results are the reasons an announcement is rejected
+const (
+	headerCheckPass        = "pass"
+	headerCheckUnavailable = "unavailable" // the header could not be fetched or the chain could not be queried
+)
+
+// Reasons an announced block is rejected before it is downloaded
+const (
+	headerRejectMalformed           = "malformed_header"
+	headerRejectHashMismatch        = "hash_mismatch"
+	headerRejectAbovePowLimit       = "target_above_pow_limit"
+	headerRejectInsufficientPow     = "insufficient_pow"
+	headerRejectFutureTimestamp     = "future_timestamp"
+	headerRejectIncorrectDifficulty = "incorrect_difficulty"
+)
+
+// checkAnnouncedHeader fetches the 80 byte header of an announced block from the announcing peer and checks it before
+// the block is downloaded: the header must hash to the announced hash, meet its proof of work target within the proof
+// of work limit of the chain, not be timestamped more than 2 hours in the future and, when its parent is known, have
+// the difficulty required after the parent. An announcement failing the check is bogus: a block invalid error
+// attributed to the peer is returned, so no bandwidth is spent on the block and the caller adds to the ban score of
+// the peer with reportPeerError.
+//
+// A header that can not be fetched is not held against the peer, the block is then fetched and validated as usual.
+// Blocks with an unknown parent are left to catchup.
+func (u *Server) checkAnnouncedHeader(ctx context.Context, hash *chainhash.Hash, peerID, baseURL string) error {
+	if !u.settings.BlockValidation.AnnouncedHeaderCheck || baseURL == "legacy" {
+		return nil
+	}
+
+	headerBytes, err := u.fetchAnnouncedHeader(ctx, hash, baseURL)
+	if err != nil {
+		prometheusBlockValidationAnnouncedHeaderChecks.WithLabelValues(headerCheckUnavailable).Inc()
+		u.logger.Debugf("[checkAnnouncedHeader][%s] could not fetch the header from peer %s, not checked: %v", hash.String(), peerID, err)
+
+		return nil
+	}
+
+	// a peer answering with something else than a block header is not serving the announced block
+	reason := headerRejectMalformed
+
+	if header, parseErr := model.NewBlockHeaderFromBytes(headerBytes); parseErr == nil {
+		if reason, err = u.announcedHeaderRejectReason(ctx, hash, header); err != nil {
+			prometheusBlockValidationAnnouncedHeaderChecks.WithLabelValues(headerCheckUnavailable).Inc()
+			u.logger.Warnf("[checkAnnouncedHeader][%s] could not check the header from peer %s: %v", hash.String(), peerID, err)
+
+			return nil
+		}
+	}
+
+	if reason == "" {
+		prometheusBlockValidationAnnouncedHeaderChecks.WithLabelValues(headerCheckPass).Inc()
+		return nil
+	}
+
+	prometheusBlockValidationAnnouncedHeaderChecks.WithLabelValues(reason).Inc()
+	u.logger.Warnf("[checkAnnouncedHeader][%s] rejecting bogus block announcement from peer %s [%s]: %s", hash.String(), peerID, baseURL, reason)
+
+	u.reportInvalidBlock(ctx, peerID, hash.String(), announcedHeaderInvalidDataReason(reason), reason)
+
+	return errors.NewPeerAttributableError(peerID, errors.PeerSeverityMedium, 0, "[checkAnnouncedHeader][%s] block announcement rejected", hash.String(),
+		errors.NewBlockInvalidError("[checkAnnouncedHeader][%s] bogus block announcement from peer %s: %s", hash.String(), peerID, reason))
+}
+
+// announcedHeaderInvalidDataReason returns the reason a bogus announcement is reported to the peer registry with: a
+// header that can not be parsed or does not hash to the announced hash is malformed, any other header is invalid
+func announcedHeaderInvalidDataReason(reason string) p2p.InvalidDataReason {
+	if reason == headerRejectMalformed || reason == headerRejectHashMismatch {
+		return p2p.InvalidDataReasonMalformed
+	}
+
+	return p2p.InvalidDataReasonInvalid
+}
+
+// fetchAnnouncedHeader fetches the header of an announced block from the DataHub of the announcing peer, reading at
+// most one byte more than a block header whatever the peer sends
+func (u *Server) fetchAnnouncedHeader(ctx context.Context, hash *chainhash.Hash, baseURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, announcedHeaderTimeout)
+	defer cancel()
+
+	body, err := util.DoHTTPRequestBodyReader(ctx, fmt.Sprintf("%s/header/%s", baseURL, hash.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	defer body.Close()
+
+	headerBytes, err := io.ReadAll(io.LimitReader(body, int64(model.BlockHeaderSize)+1))
+	if err != nil {
+		return nil, errors.NewProcessingError("[fetchAnnouncedHeader][%s] failed to read the header", hash.String(), err)
+	}
+
+	return headerBytes, nil
+}
+
+// announcedHeaderRejectReason returns the reason the header of an announced block is rejected, or an empty reason
+// when the header passes. An error is returned when the chain could not be queried.
+func (u *Server) announcedHeaderRejectReason(ctx context.Context, hash *chainhash.Hash, header *model.BlockHeader) (string, error) {
+	if !header.Hash().IsEqual(hash) {
+		return headerRejectHashMismatch, nil
+	}
+
+	if params := u.settings.ChainCfgParams; params != nil && params.PowLimit != nil && header.Bits.CalculateTarget().Cmp(params.PowLimit) > 0 {
+		return headerRejectAbovePowLimit, nil
+	}
+
+	if valid, _, _ := header.HasMetTargetDifficulty(); !valid {
+		return headerRejectInsufficientPow, nil
+	}
+
+	if time.Unix(int64(header.Timestamp), 0).After(clock.Now().Add(maxAnnouncedHeaderFutureTime)) {
+		return headerRejectFutureTimestamp, nil
+	}
+
+	parentExists, err := u.blockchainClient.GetBlockExists(ctx, header.HashPrevBlock)
+	if err != nil {
+		return "", errors.NewServiceError("[announcedHeaderRejectReason][%s] failed to check if parent block %s exists", hash.String(), header.HashPrevBlock.String(), err)
+	}
+
+	if !parentExists {
+		return "", nil
+	}
+
+	_, parentMeta, err := u.blockchainClient.GetBlockHeader(ctx, header.HashPrevBlock)
+	if err != nil {
+		return "", errors.NewServiceError("[announcedHeaderRejectReason][%s] failed to get parent block header %s", hash.String(), header.HashPrevBlock.String(), err)
+	}
+
+	// the difficulty of blocks up to the highest checkpoint is not validated
+	if parentMeta.Height+1 <= getHighestCheckpointHeight(activeCheckpoints(u.settings)) {
+		return "", nil
+	}
+
+	expectedNBits, err := u.blockchainClient.GetNextWorkRequired(ctx, header.HashPrevBlock, int64(header.Timestamp))
+	if err != nil {
+		return "", errors.NewServiceError("[announcedHeaderRejectReason][%s] failed to get expected work required", hash.String(), err)
+	}
+
+	if expectedNBits != nil && header.Bits != *expectedNBits {
+		return headerRejectIncorrectDifficulty, nil
+	}
+
+	return "", nil
+}
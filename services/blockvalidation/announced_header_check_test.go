@@ -0,0 +1,216 @@
+package blockvalidation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/go-chaincfg"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/services/blockvalidation/testhelpers"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// banScoreP2PClient records the ban scores added to peers and the invalid blocks reported
+type banScoreP2PClient struct {
+	P2PClientI
+	banned  []string
+	invalid []string
+}
+
+func (c *banScoreP2PClient) AddBanScore(_ context.Context, peerID string, reason string) error {
+	c.banned = append(c.banned, peerID+":"+reason)
+	return nil
+}
+
+func (c *banScoreP2PClient) AddBanScorePoints(_ context.Context, peerID string, reason string, points int) error {
+	c.banned = append(c.banned, fmt.Sprintf("%s:%s:%d", peerID, reason, points))
+	return nil
+}
+
+func (c *banScoreP2PClient) RecordInvalidBlock(_ context.Context, peerID string, _ string, reason p2p.InvalidDataReason, details string) error {
+	c.invalid = append(c.invalid, peerID+":"+string(reason)+":"+details)
+	return nil
+}
+
+func TestCheckAnnouncedHeader(t *testing.T) {
+	initPrometheusMetrics()
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	nBits, err := model.NewNBitFromString("207fffff")
+	require.NoError(t, err)
+
+	otherBits, err := model.NewNBitFromString("1d00ffff")
+	require.NoError(t, err)
+
+	parentHash := &chainhash.Hash{1}
+	unknownParentHash := &chainhash.Hash{2}
+
+	newHeader := func(parent *chainhash.Hash, timestamp time.Time) *model.BlockHeader {
+		header := &model.BlockHeader{
+			Version:        1,
+			HashPrevBlock:  parent,
+			HashMerkleRoot: testhelpers.GenerateMerkleRoot(int(timestamp.Unix())),
+			Timestamp:      uint32(timestamp.Unix()),
+			Bits:           *nBits,
+		}
+
+		testhelpers.MineHeader(header)
+
+		return header
+	}
+
+	serve := func(hash *chainhash.Hash, body []byte) {
+		httpmock.RegisterResponder("GET", "http://peer/header/"+hash.String(), httpmock.NewBytesResponder(http.StatusOK, body))
+	}
+
+	blockchainClient := &blockchain.Mock{}
+	blockchainClient.On("GetBlockExists", mock.Anything, parentHash).Return(true, nil)
+	blockchainClient.On("GetBlockExists", mock.Anything, unknownParentHash).Return(false, nil)
+	blockchainClient.On("GetBlockHeader", mock.Anything, parentHash).Return(&model.BlockHeader{}, &model.BlockHeaderMeta{Height: 100}, nil)
+	blockchainClient.On("GetNextWorkRequired", mock.Anything, parentHash, mock.Anything).Return(nBits, nil)
+
+	tSettings := &settings.Settings{ChainCfgParams: &chaincfg.RegressionNetParams}
+	tSettings.BlockValidation.AnnouncedHeaderCheck = true
+
+	p2pClient := &banScoreP2PClient{}
+
+	s := &Server{
+		logger:           ulogger.TestLogger{},
+		settings:         tSettings,
+		blockchainClient: blockchainClient,
+		p2pClient:        p2pClient,
+	}
+
+	now := time.Now()
+
+	t.Run("valid header", func(t *testing.T) {
+		header := newHeader(parentHash, now)
+		serve(header.Hash(), header.Bytes())
+
+		require.NoError(t, s.checkAnnouncedHeader(context.Background(), header.Hash(), "peer1", "http://peer"))
+
+		// a block with an unknown parent is left to catchup
+		header = newHeader(unknownParentHash, now)
+		serve(header.Hash(), header.Bytes())
+
+		require.NoError(t, s.checkAnnouncedHeader(context.Background(), header.Hash(), "peer1", "http://peer"))
+		assert.Empty(t, p2pClient.banned)
+		assert.Empty(t, p2pClient.invalid)
+	})
+
+	t.Run("header not available", func(t *testing.T) {
+		hash := &chainhash.Hash{3}
+		httpmock.RegisterResponder("GET", "http://peer/header/"+hash.String(), httpmock.NewStringResponder(http.StatusNotFound, "not found"))
+
+		require.NoError(t, s.checkAnnouncedHeader(context.Background(), hash, "peer1", "http://peer"))
+		assert.Empty(t, p2pClient.banned)
+	})
+
+	reject := func(t *testing.T, hash *chainhash.Hash, reason string, invalidReason p2p.InvalidDataReason) {
+		t.Helper()
+
+		p2pClient.banned = nil
+		p2pClient.invalid = nil
+
+		err := s.checkAnnouncedHeader(context.Background(), hash, "peer2", "http://peer")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrBlockInvalid))
+		assert.Contains(t, err.Error(), reason)
+		assert.Empty(t, p2pClient.banned, "the ban score is added by the caller")
+		assert.Equal(t, []string{"peer2:" + string(invalidReason) + ":" + reason}, p2pClient.invalid)
+
+		attribution, ok := errors.GetPeerAttribution(err)
+		require.True(t, ok)
+		assert.Equal(t, "peer2", attribution.PeerID)
+		assert.Equal(t, errors.PeerSeverityMedium, attribution.Severity)
+
+		require.True(t, s.reportPeerError(context.Background(), err))
+		assert.Equal(t, []string{"peer2:invalid_block:10"}, p2pClient.banned)
+	}
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		header := newHeader(parentHash, now.Add(time.Second))
+		announced := &chainhash.Hash{4}
+		serve(announced, header.Bytes())
+
+		reject(t, announced, headerRejectHashMismatch, p2p.InvalidDataReasonMalformed)
+	})
+
+	t.Run("malformed header", func(t *testing.T) {
+		announced := &chainhash.Hash{5}
+		serve(announced, make([]byte, 4*model.BlockHeaderSize))
+
+		reject(t, announced, headerRejectMalformed, p2p.InvalidDataReasonMalformed)
+	})
+
+	t.Run("insufficient proof of work", func(t *testing.T) {
+		header := newHeader(parentHash, now.Add(2*time.Second))
+		for valid := true; valid; valid, _, _ = header.HasMetTargetDifficulty() {
+			header.Nonce++
+		}
+
+		serve(header.Hash(), header.Bytes())
+
+		reject(t, header.Hash(), headerRejectInsufficientPow, p2p.InvalidDataReasonInvalid)
+	})
+
+	t.Run("target above the proof of work limit", func(t *testing.T) {
+		aboveLimit, err := model.NewNBitFromString("2100ffff")
+		require.NoError(t, err)
+
+		header := newHeader(parentHash, now.Add(3*time.Second))
+		header.Bits = *aboveLimit
+
+		serve(header.Hash(), header.Bytes())
+
+		reject(t, header.Hash(), headerRejectAbovePowLimit, p2p.InvalidDataReasonInvalid)
+	})
+
+	t.Run("timestamp in the future", func(t *testing.T) {
+		header := newHeader(parentHash, now.Add(3*time.Hour))
+		serve(header.Hash(), header.Bytes())
+
+		reject(t, header.Hash(), headerRejectFutureTimestamp, p2p.InvalidDataReasonInvalid)
+	})
+
+	t.Run("incorrect difficulty", func(t *testing.T) {
+		s.blockchainClient = &blockchain.Mock{}
+		defer func() { s.blockchainClient = blockchainClient }()
+
+		s.blockchainClient.(*blockchain.Mock).On("GetBlockExists", mock.Anything, parentHash).Return(true, nil)
+		s.blockchainClient.(*blockchain.Mock).On("GetBlockHeader", mock.Anything, parentHash).Return(&model.BlockHeader{}, &model.BlockHeaderMeta{Height: 100}, nil)
+		s.blockchainClient.(*blockchain.Mock).On("GetNextWorkRequired", mock.Anything, parentHash, mock.Anything).Return(otherBits, nil)
+
+		header := newHeader(parentHash, now.Add(4*time.Second))
+		serve(header.Hash(), header.Bytes())
+
+		reject(t, header.Hash(), headerRejectIncorrectDifficulty, p2p.InvalidDataReasonInvalid)
+	})
+
+	t.Run("not checked", func(t *testing.T) {
+		p2pClient.banned = nil
+
+		// legacy blocks are not fetched from a DataHub
+		require.NoError(t, s.checkAnnouncedHeader(context.Background(), &chainhash.Hash{5}, "peer2", "legacy"))
+
+		tSettings.BlockValidation.AnnouncedHeaderCheck = false
+		defer func() { tSettings.BlockValidation.AnnouncedHeaderCheck = true }()
+
+		require.NoError(t, s.checkAnnouncedHeader(context.Background(), &chainhash.Hash{5}, "peer2", "http://peer"))
+		assert.Empty(t, p2pClient.banned)
+	})
+}
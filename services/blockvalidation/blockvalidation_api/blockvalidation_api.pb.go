@@ -65,6 +65,7 @@ type HealthResponse struct {
 	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
 	Details       string                 `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
 	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	BuildInfo     string                 `protobuf:"bytes,4,opt,name=build_info,json=buildInfo,proto3" json:"build_info,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -120,6 +121,13 @@ func (x *HealthResponse) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *HealthResponse) GetBuildInfo() string {
+	if x != nil {
+		return x.BuildInfo
+	}
+	return ""
+}
+
 // swagger:model BlockFoundRequest
 type BlockFoundRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -544,6 +552,10 @@ type CatchupStatusResponse struct {
 	CommonAncestorHash   string                  `protobuf:"bytes,13,opt,name=common_ancestor_hash,json=commonAncestorHash,proto3" json:"common_ancestor_hash,omitempty"`
 	CommonAncestorHeight uint32                  `protobuf:"varint,14,opt,name=common_ancestor_height,json=commonAncestorHeight,proto3" json:"common_ancestor_height,omitempty"`
 	PreviousAttempt      *PreviousCatchupAttempt `protobuf:"bytes,15,opt,name=previous_attempt,json=previousAttempt,proto3" json:"previous_attempt,omitempty"`
+	BytesFetched         uint64                  `protobuf:"varint,16,opt,name=bytes_fetched,json=bytesFetched,proto3" json:"bytes_fetched,omitempty"`
+	BlocksPerSecond      float64                 `protobuf:"fixed64,17,opt,name=blocks_per_second,json=blocksPerSecond,proto3" json:"blocks_per_second,omitempty"` // Over the last minute
+	BytesPerSecond       float64                 `protobuf:"fixed64,18,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`    // Over the last minute
+	EtaMs                int64                   `protobuf:"varint,19,opt,name=eta_ms,json=etaMs,proto3" json:"eta_ms,omitempty"`                                  // 0 when no estimate can be made yet
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -683,16 +695,285 @@ func (x *CatchupStatusResponse) GetPreviousAttempt() *PreviousCatchupAttempt {
 	return nil
 }
 
+func (x *CatchupStatusResponse) GetBytesFetched() uint64 {
+	if x != nil {
+		return x.BytesFetched
+	}
+	return 0
+}
+
+func (x *CatchupStatusResponse) GetBlocksPerSecond() float64 {
+	if x != nil {
+		return x.BlocksPerSecond
+	}
+	return 0
+}
+
+func (x *CatchupStatusResponse) GetBytesPerSecond() float64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *CatchupStatusResponse) GetEtaMs() int64 {
+	if x != nil {
+		return x.EtaMs
+	}
+	return 0
+}
+
+// swagger:model EstimateCatchupRequest
+type EstimateCatchupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          []byte                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateCatchupRequest) Reset() {
+	*x = EstimateCatchupRequest{}
+	mi := &file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateCatchupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateCatchupRequest) ProtoMessage() {}
+
+func (x *EstimateCatchupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateCatchupRequest.ProtoReflect.Descriptor instead.
+func (*EstimateCatchupRequest) Descriptor() ([]byte, []int) {
+	return file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EstimateCatchupRequest) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+// swagger:model CatchupPeerCandidate
+type CatchupPeerCandidate struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	PeerId                string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	DataHubUrl            string                 `protobuf:"bytes,2,opt,name=data_hub_url,json=dataHubUrl,proto3" json:"data_hub_url,omitempty"`
+	Height                int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	ReputationScore       float64                `protobuf:"fixed64,4,opt,name=reputation_score,json=reputationScore,proto3" json:"reputation_score,omitempty"`
+	AvgResponseTimeMs     int64                  `protobuf:"varint,5,opt,name=avg_response_time_ms,json=avgResponseTimeMs,proto3" json:"avg_response_time_ms,omitempty"`
+	ThroughputBytesPerSec uint64                 `protobuf:"varint,6,opt,name=throughput_bytes_per_sec,json=throughputBytesPerSec,proto3" json:"throughput_bytes_per_sec,omitempty"`
+	EstimatedDurationMs   int64                  `protobuf:"varint,7,opt,name=estimated_duration_ms,json=estimatedDurationMs,proto3" json:"estimated_duration_ms,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CatchupPeerCandidate) Reset() {
+	*x = CatchupPeerCandidate{}
+	mi := &file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatchupPeerCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatchupPeerCandidate) ProtoMessage() {}
+
+func (x *CatchupPeerCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatchupPeerCandidate.ProtoReflect.Descriptor instead.
+func (*CatchupPeerCandidate) Descriptor() ([]byte, []int) {
+	return file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CatchupPeerCandidate) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *CatchupPeerCandidate) GetDataHubUrl() string {
+	if x != nil {
+		return x.DataHubUrl
+	}
+	return ""
+}
+
+func (x *CatchupPeerCandidate) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *CatchupPeerCandidate) GetReputationScore() float64 {
+	if x != nil {
+		return x.ReputationScore
+	}
+	return 0
+}
+
+func (x *CatchupPeerCandidate) GetAvgResponseTimeMs() int64 {
+	if x != nil {
+		return x.AvgResponseTimeMs
+	}
+	return 0
+}
+
+func (x *CatchupPeerCandidate) GetThroughputBytesPerSec() uint64 {
+	if x != nil {
+		return x.ThroughputBytesPerSec
+	}
+	return 0
+}
+
+func (x *CatchupPeerCandidate) GetEstimatedDurationMs() int64 {
+	if x != nil {
+		return x.EstimatedDurationMs
+	}
+	return 0
+}
+
+// swagger:model EstimateCatchupResponse
+type EstimateCatchupResponse struct {
+	state             protoimpl.MessageState  `protogen:"open.v1"`
+	TargetBlockHash   string                  `protobuf:"bytes,1,opt,name=target_block_hash,json=targetBlockHash,proto3" json:"target_block_hash,omitempty"`
+	TargetBlockHeight uint32                  `protobuf:"varint,2,opt,name=target_block_height,json=targetBlockHeight,proto3" json:"target_block_height,omitempty"`
+	CurrentHeight     uint32                  `protobuf:"varint,3,opt,name=current_height,json=currentHeight,proto3" json:"current_height,omitempty"`
+	TargetExists      bool                    `protobuf:"varint,4,opt,name=target_exists,json=targetExists,proto3" json:"target_exists,omitempty"`
+	BlocksToFetch     uint64                  `protobuf:"varint,5,opt,name=blocks_to_fetch,json=blocksToFetch,proto3" json:"blocks_to_fetch,omitempty"`
+	AverageBlockSize  uint64                  `protobuf:"varint,6,opt,name=average_block_size,json=averageBlockSize,proto3" json:"average_block_size,omitempty"`
+	EstimatedBytes    uint64                  `protobuf:"varint,7,opt,name=estimated_bytes,json=estimatedBytes,proto3" json:"estimated_bytes,omitempty"`
+	CandidatePeers    []*CatchupPeerCandidate `protobuf:"bytes,8,rep,name=candidate_peers,json=candidatePeers,proto3" json:"candidate_peers,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *EstimateCatchupResponse) Reset() {
+	*x = EstimateCatchupResponse{}
+	mi := &file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateCatchupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateCatchupResponse) ProtoMessage() {}
+
+func (x *EstimateCatchupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateCatchupResponse.ProtoReflect.Descriptor instead.
+func (*EstimateCatchupResponse) Descriptor() ([]byte, []int) {
+	return file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EstimateCatchupResponse) GetTargetBlockHash() string {
+	if x != nil {
+		return x.TargetBlockHash
+	}
+	return ""
+}
+
+func (x *EstimateCatchupResponse) GetTargetBlockHeight() uint32 {
+	if x != nil {
+		return x.TargetBlockHeight
+	}
+	return 0
+}
+
+func (x *EstimateCatchupResponse) GetCurrentHeight() uint32 {
+	if x != nil {
+		return x.CurrentHeight
+	}
+	return 0
+}
+
+func (x *EstimateCatchupResponse) GetTargetExists() bool {
+	if x != nil {
+		return x.TargetExists
+	}
+	return false
+}
+
+func (x *EstimateCatchupResponse) GetBlocksToFetch() uint64 {
+	if x != nil {
+		return x.BlocksToFetch
+	}
+	return 0
+}
+
+func (x *EstimateCatchupResponse) GetAverageBlockSize() uint64 {
+	if x != nil {
+		return x.AverageBlockSize
+	}
+	return 0
+}
+
+func (x *EstimateCatchupResponse) GetEstimatedBytes() uint64 {
+	if x != nil {
+		return x.EstimatedBytes
+	}
+	return 0
+}
+
+func (x *EstimateCatchupResponse) GetCandidatePeers() []*CatchupPeerCandidate {
+	if x != nil {
+		return x.CandidatePeers
+	}
+	return nil
+}
+
 var File_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto protoreflect.FileDescriptor
 
 const file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_rawDesc = "" +
 	"\n" +
 	"Fservices/blockvalidation/blockvalidation_api/blockvalidation_api.proto\x12\x13blockvalidation_api\x1a\x1fgoogle/protobuf/timestamp.proto\"\x0e\n" +
-	"\fEmptyMessage\"t\n" +
+	"\fEmptyMessage\"\x93\x01\n" +
 	"\x0eHealthResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x18\n" +
 	"\adetails\x18\x02 \x01(\tR\adetails\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x85\x01\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"build_info\x18\x04 \x01(\tR\tbuildInfo\"\x85\x01\n" +
 	"\x11BlockFoundRequest\x12\x12\n" +
 	"\x04hash\x18\x01 \x01(\fR\x04hash\x12\x19\n" +
 	"\bbase_url\x18\x02 \x01(\tR\abaseUrl\x12(\n" +
@@ -723,7 +1004,7 @@ const file_services_blockvalidation_blockvalidation_api_blockvalidation_api_prot
 	"\fattempt_time\x18\a \x01(\x03R\vattemptTime\x12\x1f\n" +
 	"\vduration_ms\x18\b \x01(\x03R\n" +
 	"durationMs\x12)\n" +
-	"\x10blocks_validated\x18\t \x01(\x03R\x0fblocksValidated\"\x88\x05\n" +
+	"\x10blocks_validated\x18\t \x01(\x03R\x0fblocksValidated\"\x9a\x06\n" +
 	"\x15CatchupStatusResponse\x12$\n" +
 	"\x0eis_catching_up\x18\x01 \x01(\bR\fisCatchingUp\x12\x17\n" +
 	"\apeer_id\x18\x02 \x01(\tR\x06peerId\x12\x19\n" +
@@ -743,7 +1024,31 @@ const file_services_blockvalidation_blockvalidation_api_blockvalidation_api_prot
 	"fork_depth\x18\f \x01(\rR\tforkDepth\x120\n" +
 	"\x14common_ancestor_hash\x18\r \x01(\tR\x12commonAncestorHash\x124\n" +
 	"\x16common_ancestor_height\x18\x0e \x01(\rR\x14commonAncestorHeight\x12V\n" +
-	"\x10previous_attempt\x18\x0f \x01(\v2+.blockvalidation_api.PreviousCatchupAttemptR\x0fpreviousAttempt2\xda\x04\n" +
+	"\x10previous_attempt\x18\x0f \x01(\v2+.blockvalidation_api.PreviousCatchupAttemptR\x0fpreviousAttempt\x12#\n" +
+	"\rbytes_fetched\x18\x10 \x01(\x04R\fbytesFetched\x12*\n" +
+	"\x11blocks_per_second\x18\x11 \x01(\x01R\x0fblocksPerSecond\x12(\n" +
+	"\x10bytes_per_second\x18\x12 \x01(\x01R\x0ebytesPerSecond\x12\x15\n" +
+	"\x06eta_ms\x18\x13 \x01(\x03R\x05etaMs\",\n" +
+	"\x16EstimateCatchupRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\fR\x04hash\"\xb2\x02\n" +
+	"\x14CatchupPeerCandidate\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12 \n" +
+	"\fdata_hub_url\x18\x02 \x01(\tR\n" +
+	"dataHubUrl\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12)\n" +
+	"\x10reputation_score\x18\x04 \x01(\x01R\x0freputationScore\x12/\n" +
+	"\x14avg_response_time_ms\x18\x05 \x01(\x03R\x11avgResponseTimeMs\x127\n" +
+	"\x18throughput_bytes_per_sec\x18\x06 \x01(\x04R\x15throughputBytesPerSec\x122\n" +
+	"\x15estimated_duration_ms\x18\a \x01(\x03R\x13estimatedDurationMs\"\x94\x03\n" +
+	"\x17EstimateCatchupResponse\x12*\n" +
+	"\x11target_block_hash\x18\x01 \x01(\tR\x0ftargetBlockHash\x12.\n" +
+	"\x13target_block_height\x18\x02 \x01(\rR\x11targetBlockHeight\x12%\n" +
+	"\x0ecurrent_height\x18\x03 \x01(\rR\rcurrentHeight\x12#\n" +
+	"\rtarget_exists\x18\x04 \x01(\bR\ftargetExists\x12&\n" +
+	"\x0fblocks_to_fetch\x18\x05 \x01(\x04R\rblocksToFetch\x12,\n" +
+	"\x12average_block_size\x18\x06 \x01(\x04R\x10averageBlockSize\x12'\n" +
+	"\x0festimated_bytes\x18\a \x01(\x04R\x0eestimatedBytes\x12R\n" +
+	"\x0fcandidate_peers\x18\b \x03(\v2).blockvalidation_api.CatchupPeerCandidateR\x0ecandidatePeers2\xca\x05\n" +
 	"\x12BlockValidationAPI\x12V\n" +
 	"\n" +
 	"HealthGRPC\x12!.blockvalidation_api.EmptyMessage\x1a#.blockvalidation_api.HealthResponse\"\x00\x12Y\n" +
@@ -752,7 +1057,8 @@ const file_services_blockvalidation_blockvalidation_api_blockvalidation_api_prot
 	"\fProcessBlock\x12(.blockvalidation_api.ProcessBlockRequest\x1a!.blockvalidation_api.EmptyMessage\"\x00\x12h\n" +
 	"\rValidateBlock\x12).blockvalidation_api.ValidateBlockRequest\x1a*.blockvalidation_api.ValidateBlockResponse\"\x00\x12c\n" +
 	"\x0fRevalidateBlock\x12+.blockvalidation_api.RevalidateBlockRequest\x1a!.blockvalidation_api.EmptyMessage\"\x00\x12c\n" +
-	"\x10GetCatchupStatus\x12!.blockvalidation_api.EmptyMessage\x1a*.blockvalidation_api.CatchupStatusResponse\"\x00B\x18Z\x16./;blockvalidation_apib\x06proto3"
+	"\x10GetCatchupStatus\x12!.blockvalidation_api.EmptyMessage\x1a*.blockvalidation_api.CatchupStatusResponse\"\x00\x12n\n" +
+	"\x0fEstimateCatchup\x12+.blockvalidation_api.EstimateCatchupRequest\x1a,.blockvalidation_api.EstimateCatchupResponse\"\x00B\x18Z\x16./;blockvalidation_apib\x06proto3"
 
 var (
 	file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_rawDescOnce sync.Once
@@ -766,39 +1072,45 @@ func file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto
 	return file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_rawDescData
 }
 
-var file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_goTypes = []any{
-	(*EmptyMessage)(nil),           // 0: blockvalidation_api.EmptyMessage
-	(*HealthResponse)(nil),         // 1: blockvalidation_api.HealthResponse
-	(*BlockFoundRequest)(nil),      // 2: blockvalidation_api.BlockFoundRequest
-	(*ProcessBlockRequest)(nil),    // 3: blockvalidation_api.ProcessBlockRequest
-	(*ValidateBlockRequest)(nil),   // 4: blockvalidation_api.ValidateBlockRequest
-	(*ValidateBlockResponse)(nil),  // 5: blockvalidation_api.ValidateBlockResponse
-	(*RevalidateBlockRequest)(nil), // 6: blockvalidation_api.RevalidateBlockRequest
-	(*PreviousCatchupAttempt)(nil), // 7: blockvalidation_api.PreviousCatchupAttempt
-	(*CatchupStatusResponse)(nil),  // 8: blockvalidation_api.CatchupStatusResponse
-	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
+	(*EmptyMessage)(nil),            // 0: blockvalidation_api.EmptyMessage
+	(*HealthResponse)(nil),          // 1: blockvalidation_api.HealthResponse
+	(*BlockFoundRequest)(nil),       // 2: blockvalidation_api.BlockFoundRequest
+	(*ProcessBlockRequest)(nil),     // 3: blockvalidation_api.ProcessBlockRequest
+	(*ValidateBlockRequest)(nil),    // 4: blockvalidation_api.ValidateBlockRequest
+	(*ValidateBlockResponse)(nil),   // 5: blockvalidation_api.ValidateBlockResponse
+	(*RevalidateBlockRequest)(nil),  // 6: blockvalidation_api.RevalidateBlockRequest
+	(*PreviousCatchupAttempt)(nil),  // 7: blockvalidation_api.PreviousCatchupAttempt
+	(*CatchupStatusResponse)(nil),   // 8: blockvalidation_api.CatchupStatusResponse
+	(*EstimateCatchupRequest)(nil),  // 9: blockvalidation_api.EstimateCatchupRequest
+	(*CatchupPeerCandidate)(nil),    // 10: blockvalidation_api.CatchupPeerCandidate
+	(*EstimateCatchupResponse)(nil), // 11: blockvalidation_api.EstimateCatchupResponse
+	(*timestamppb.Timestamp)(nil),   // 12: google.protobuf.Timestamp
 }
 var file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_depIdxs = []int32{
-	9, // 0: blockvalidation_api.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
-	7, // 1: blockvalidation_api.CatchupStatusResponse.previous_attempt:type_name -> blockvalidation_api.PreviousCatchupAttempt
-	0, // 2: blockvalidation_api.BlockValidationAPI.HealthGRPC:input_type -> blockvalidation_api.EmptyMessage
-	2, // 3: blockvalidation_api.BlockValidationAPI.BlockFound:input_type -> blockvalidation_api.BlockFoundRequest
-	3, // 4: blockvalidation_api.BlockValidationAPI.ProcessBlock:input_type -> blockvalidation_api.ProcessBlockRequest
-	4, // 5: blockvalidation_api.BlockValidationAPI.ValidateBlock:input_type -> blockvalidation_api.ValidateBlockRequest
-	6, // 6: blockvalidation_api.BlockValidationAPI.RevalidateBlock:input_type -> blockvalidation_api.RevalidateBlockRequest
-	0, // 7: blockvalidation_api.BlockValidationAPI.GetCatchupStatus:input_type -> blockvalidation_api.EmptyMessage
-	1, // 8: blockvalidation_api.BlockValidationAPI.HealthGRPC:output_type -> blockvalidation_api.HealthResponse
-	0, // 9: blockvalidation_api.BlockValidationAPI.BlockFound:output_type -> blockvalidation_api.EmptyMessage
-	0, // 10: blockvalidation_api.BlockValidationAPI.ProcessBlock:output_type -> blockvalidation_api.EmptyMessage
-	5, // 11: blockvalidation_api.BlockValidationAPI.ValidateBlock:output_type -> blockvalidation_api.ValidateBlockResponse
-	0, // 12: blockvalidation_api.BlockValidationAPI.RevalidateBlock:output_type -> blockvalidation_api.EmptyMessage
-	8, // 13: blockvalidation_api.BlockValidationAPI.GetCatchupStatus:output_type -> blockvalidation_api.CatchupStatusResponse
-	8, // [8:14] is the sub-list for method output_type
-	2, // [2:8] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	12, // 0: blockvalidation_api.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 1: blockvalidation_api.CatchupStatusResponse.previous_attempt:type_name -> blockvalidation_api.PreviousCatchupAttempt
+	10, // 2: blockvalidation_api.EstimateCatchupResponse.candidate_peers:type_name -> blockvalidation_api.CatchupPeerCandidate
+	0,  // 3: blockvalidation_api.BlockValidationAPI.HealthGRPC:input_type -> blockvalidation_api.EmptyMessage
+	2,  // 4: blockvalidation_api.BlockValidationAPI.BlockFound:input_type -> blockvalidation_api.BlockFoundRequest
+	3,  // 5: blockvalidation_api.BlockValidationAPI.ProcessBlock:input_type -> blockvalidation_api.ProcessBlockRequest
+	4,  // 6: blockvalidation_api.BlockValidationAPI.ValidateBlock:input_type -> blockvalidation_api.ValidateBlockRequest
+	6,  // 7: blockvalidation_api.BlockValidationAPI.RevalidateBlock:input_type -> blockvalidation_api.RevalidateBlockRequest
+	0,  // 8: blockvalidation_api.BlockValidationAPI.GetCatchupStatus:input_type -> blockvalidation_api.EmptyMessage
+	9,  // 9: blockvalidation_api.BlockValidationAPI.EstimateCatchup:input_type -> blockvalidation_api.EstimateCatchupRequest
+	1,  // 10: blockvalidation_api.BlockValidationAPI.HealthGRPC:output_type -> blockvalidation_api.HealthResponse
+	0,  // 11: blockvalidation_api.BlockValidationAPI.BlockFound:output_type -> blockvalidation_api.EmptyMessage
+	0,  // 12: blockvalidation_api.BlockValidationAPI.ProcessBlock:output_type -> blockvalidation_api.EmptyMessage
+	5,  // 13: blockvalidation_api.BlockValidationAPI.ValidateBlock:output_type -> blockvalidation_api.ValidateBlockResponse
+	0,  // 14: blockvalidation_api.BlockValidationAPI.RevalidateBlock:output_type -> blockvalidation_api.EmptyMessage
+	8,  // 15: blockvalidation_api.BlockValidationAPI.GetCatchupStatus:output_type -> blockvalidation_api.CatchupStatusResponse
+	11, // 16: blockvalidation_api.BlockValidationAPI.EstimateCatchup:output_type -> blockvalidation_api.EstimateCatchupResponse
+	10, // [10:17] is the sub-list for method output_type
+	3,  // [3:10] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_init() }
@@ -812,7 +1124,7 @@ func file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_rawDesc), len(file_services_blockvalidation_blockvalidation_api_blockvalidation_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,6 +25,7 @@ const (
 	BlockValidationAPI_ValidateBlock_FullMethodName    = "/blockvalidation_api.BlockValidationAPI/ValidateBlock"
 	BlockValidationAPI_RevalidateBlock_FullMethodName  = "/blockvalidation_api.BlockValidationAPI/RevalidateBlock"
 	BlockValidationAPI_GetCatchupStatus_FullMethodName = "/blockvalidation_api.BlockValidationAPI/GetCatchupStatus"
+	BlockValidationAPI_EstimateCatchup_FullMethodName  = "/blockvalidation_api.BlockValidationAPI/EstimateCatchup"
 )
 
 // BlockValidationAPIClient is the client API for BlockValidationAPI service.
@@ -38,6 +39,8 @@ type BlockValidationAPIClient interface {
 	ValidateBlock(ctx context.Context, in *ValidateBlockRequest, opts ...grpc.CallOption) (*ValidateBlockResponse, error)
 	RevalidateBlock(ctx context.Context, in *RevalidateBlockRequest, opts ...grpc.CallOption) (*EmptyMessage, error)
 	GetCatchupStatus(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*CatchupStatusResponse, error)
+	// EstimateCatchup estimates the work needed to catch up to a block without starting the catchup.
+	EstimateCatchup(ctx context.Context, in *EstimateCatchupRequest, opts ...grpc.CallOption) (*EstimateCatchupResponse, error)
 }
 
 type blockValidationAPIClient struct {
@@ -108,6 +111,16 @@ func (c *blockValidationAPIClient) GetCatchupStatus(ctx context.Context, in *Emp
 	return out, nil
 }
 
+func (c *blockValidationAPIClient) EstimateCatchup(ctx context.Context, in *EstimateCatchupRequest, opts ...grpc.CallOption) (*EstimateCatchupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EstimateCatchupResponse)
+	err := c.cc.Invoke(ctx, BlockValidationAPI_EstimateCatchup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlockValidationAPIServer is the server API for BlockValidationAPI service.
 // All implementations must embed UnimplementedBlockValidationAPIServer
 // for forward compatibility.
@@ -119,6 +132,8 @@ type BlockValidationAPIServer interface {
 	ValidateBlock(context.Context, *ValidateBlockRequest) (*ValidateBlockResponse, error)
 	RevalidateBlock(context.Context, *RevalidateBlockRequest) (*EmptyMessage, error)
 	GetCatchupStatus(context.Context, *EmptyMessage) (*CatchupStatusResponse, error)
+	// EstimateCatchup estimates the work needed to catch up to a block without starting the catchup.
+	EstimateCatchup(context.Context, *EstimateCatchupRequest) (*EstimateCatchupResponse, error)
 	mustEmbedUnimplementedBlockValidationAPIServer()
 }
 
@@ -147,6 +162,9 @@ func (UnimplementedBlockValidationAPIServer) RevalidateBlock(context.Context, *R
 func (UnimplementedBlockValidationAPIServer) GetCatchupStatus(context.Context, *EmptyMessage) (*CatchupStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCatchupStatus not implemented")
 }
+func (UnimplementedBlockValidationAPIServer) EstimateCatchup(context.Context, *EstimateCatchupRequest) (*EstimateCatchupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateCatchup not implemented")
+}
 func (UnimplementedBlockValidationAPIServer) mustEmbedUnimplementedBlockValidationAPIServer() {}
 func (UnimplementedBlockValidationAPIServer) testEmbeddedByValue()                            {}
 
@@ -276,6 +294,24 @@ func _BlockValidationAPI_GetCatchupStatus_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockValidationAPI_EstimateCatchup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateCatchupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockValidationAPIServer).EstimateCatchup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockValidationAPI_EstimateCatchup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockValidationAPIServer).EstimateCatchup(ctx, req.(*EstimateCatchupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BlockValidationAPI_ServiceDesc is the grpc.ServiceDesc for BlockValidationAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -307,6 +343,10 @@ var BlockValidationAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCatchupStatus",
 			Handler:    _BlockValidationAPI_GetCatchupStatus_Handler,
 		},
+		{
+			MethodName: "EstimateCatchup",
+			Handler:    _BlockValidationAPI_EstimateCatchup_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/blockvalidation/blockvalidation_api/blockvalidation_api.proto",
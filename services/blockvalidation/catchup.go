@@ -14,7 +14,9 @@ import (
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/pkg/fileformat"
 	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	"github.com/bsv-blockchain/teranode/services/blockvalidation/catchup"
+	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/util/blockassemblyutil"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"golang.org/x/sync/errgroup"
@@ -35,6 +37,7 @@ type CatchupContext struct {
 	baseURL                 string
 	peerID                  string
 	startTime               time.Time
+	traceCtx                context.Context // Carries the catchup span for reports made after the catchup returned
 	commonAncestorHash      *chainhash.Hash
 	commonAncestorMeta      *model.BlockHeaderMeta
 	commonAncestorIndex     int // Index of common ancestor in peer headers
@@ -47,19 +50,32 @@ type CatchupContext struct {
 	catchupError            error  // Any error encountered during catchup
 }
 
+// reportContext returns the context for peer reports made outside of the catchup call chain. It is part of
+// the trace of the catchup, but is not canceled when the catchup ends.
+func (c *CatchupContext) reportContext() context.Context {
+	if c.traceCtx == nil {
+		return context.Background()
+	}
+
+	return c.traceCtx
+}
+
 // catchup orchestrates the complete blockchain synchronization process.
 // It follows a clear sequence of steps to safely synchronize with a peer:
 //
 // 1. Acquire catchup lock (prevent concurrent catchups)
 // 2. Fetch headers from peer
 // 3. Find and validate common ancestor
-// 4. Check coinbase maturity constraints
-// 5. Detect secret mining attempts
-// 6. Filter headers to process
-// 7. Build header chain cache
-// 8. Verify chain continuity
-// 9. Fetch and validate blocks
-// 10. Clean up resources
+// 4. Reject fabricated chains and forks below checkpoints
+// 5. Check coinbase maturity constraints
+// 6. Detect secret mining attempts
+// 7. Filter headers to process
+// 8. Build header chain cache
+// 9. Verify chain continuity
+// 10. Verify checkpoints
+// 11. Cross-check headers against other peers (when enabled)
+// 12. Fetch and validate blocks
+// 13. Clean up resources
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
@@ -104,6 +120,7 @@ func (u *Server) catchup(ctx context.Context, blockUpTo *model.Block, peerID, ba
 		baseURL:   baseURL,
 		peerID:    peerID,
 		startTime: time.Now(),
+		traceCtx:  context.WithoutCancel(ctx),
 	}
 
 	// Step 1: Acquire exclusive catchup lock
@@ -128,17 +145,22 @@ func (u *Server) catchup(ctx context.Context, blockUpTo *model.Block, peerID, ba
 		return err
 	}
 
-	// Step 4: Validate fork depth against coinbase maturity
+	// Step 4: Reject chains with fabricated difficulty or forking below a checkpoint
+	if err = u.detectMaliciousChain(ctx, catchupCtx); err != nil {
+		return err
+	}
+
+	// Step 5: Validate fork depth against coinbase maturity
 	if err = u.validateForkDepth(catchupCtx); err != nil {
 		return err
 	}
 
-	// Step 5: Check for secret mining attempts
+	// Step 6: Check for secret mining attempts
 	if err = u.checkSecretMining(ctx, catchupCtx); err != nil {
 		return err
 	}
 
-	// Step 6: Filter headers to only those we need to catchup
+	// Step 7: Filter headers to only those we need to catchup
 	if err = u.filterHeaders(ctx, catchupCtx); err != nil {
 		return err
 	}
@@ -149,34 +171,45 @@ func (u *Server) catchup(ctx context.Context, blockUpTo *model.Block, peerID, ba
 		return nil
 	}
 
-	// Step 7: Build header chain cache for validation
+	// Step 8: Build header chain cache for validation
 	if err = u.buildHeaderCache(catchupCtx); err != nil {
 		return err
 	}
 
-	// Step 8: Verify chain continuity
+	// Step 9: Verify chain continuity
 	if err = u.verifyChainContinuity(ctx, catchupCtx); err != nil {
 		return err
 	}
 
-	// Step 9: Verify checkpoints and determine if quick validation can be used
+	// Step 10: Verify checkpoints and determine if quick validation can be used
 	// This step ensures we're on the correct chain by validating checkpoint hashes
 	if err = u.verifyCheckpointsInHeaderChain(catchupCtx); err != nil {
 		u.logger.Errorf("[catchup][%s] Checkpoint verification failed: %v", blockUpTo.Hash().String(), err)
 		return err
 	}
 
-	// Step 10: Fetch and validate blocks
+	// Step 11: Cross-check headers against other high reputation peers (paranoid mode)
+	if err = u.crossCheckHeaders(ctx, catchupCtx); err != nil {
+		u.logger.Errorf("[catchup][%s] Header cross-check failed: %v", blockUpTo.Hash().String(), err)
+		return err
+	}
+
+	// Step 12: Fetch and validate blocks
 	if err = u.fetchAndValidateBlocks(ctx, catchupCtx); err != nil {
 		return err
 	}
 
-	// Step 11: Clean up resources
+	// Step 13: Clean up resources
 	u.cleanup(catchupCtx)
 
 	// Report successful catchup to P2P service
 	u.reportCatchupSuccess(ctx, catchupCtx.peerID, time.Since(catchupCtx.startTime))
 
+	// Verify the synced chain against other peers in the background, the catchup is complete
+	if u.settings.BlockValidation.CatchupIntegrityCheckPeers > 0 {
+		go u.verifyCatchupIntegrity(context.WithoutCancel(ctx), catchupCtx)
+	}
+
 	return nil
 }
 
@@ -207,6 +240,8 @@ func (u *Server) acquireCatchupLock(ctx *CatchupContext) error {
 	// Reset progress counters
 	u.blocksFetched.Store(0)
 	u.blocksValidated.Store(0)
+	u.bytesFetched.Store(0)
+	u.catchupRate.reset(time.Now())
 
 	return nil
 }
@@ -237,7 +272,11 @@ func (u *Server) releaseCatchupLock(ctx *CatchupContext, err *error) {
 		case errors.Is(*err, errors.ErrBlockInvalid) || errors.Is(*err, errors.ErrTxInvalid):
 			errorType = "validation_failure"
 			// Mark peer as malicious for validation failure
-			u.reportCatchupMalicious(context.Background(), ctx.peerID, "validation_failure")
+			u.reportCatchupMalicious(ctx.reportContext(), ctx.peerID, "validation_failure")
+		case errors.Is(*err, errors.ErrNetworkPeerThrottled):
+			// The peer served its catchup limit to us, it did nothing wrong
+			errorType = "peer_throttled"
+			isPeerError = false
 		case errors.IsNetworkError(*err):
 			errorType = "network_error"
 		case strings.Contains(errorMsg, "secret mining") || strings.Contains(errorMsg, "secretly mined"):
@@ -273,9 +312,9 @@ func (u *Server) releaseCatchupLock(ctx *CatchupContext, err *error) {
 		// Only store the error in the peer registry if it's a peer-related error
 		// Local system errors (like block assembly being behind) should not affect peer reputation
 		if isPeerError {
-			u.reportCatchupError(context.Background(), ctx.peerID, errorMsg)
+			u.reportCatchupError(ctx.reportContext(), ctx.peerID, errorMsg)
 		} else {
-			u.logger.Infof("[catchup][%s] Skipping peer error report for local system error: %s", ctx.blockUpTo.Hash().String(), errorType)
+			u.logger.Infof("[catchup][%s] Skipping peer error report for error not caused by the peer: %s", ctx.blockUpTo.Hash().String(), errorType)
 		}
 	}
 
@@ -445,6 +484,51 @@ func (u *Server) validateForkDepth(catchupCtx *CatchupContext) error {
 	return nil
 }
 
+// detectMaliciousChain rejects peer chains that fork from ours below a hard checkpoint or
+// that claim to be ahead of us with less cumulative proof of work than our chain.
+// Difficulty transitions are already checked while the headers are streamed in.
+// A peer serving such a chain is reported as malicious and banned.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - catchupCtx: Catchup context with common ancestor information
+//
+// Returns:
+//   - error: NetworkPeerMaliciousError if the peer's chain is fabricated
+func (u *Server) detectMaliciousChain(ctx context.Context, catchupCtx *CatchupContext) error {
+	u.logger.Debugf("[catchup][%s] Step 4: Checking for fabricated chains", catchupCtx.blockUpTo.Hash().String())
+
+	result := catchupCtx.headersFetchResult
+	ancestorHeight := catchupCtx.commonAncestorMeta.Height
+
+	if u.settings.ChainCfgParams != nil {
+		if err := catchup.ValidateForkPoint(ancestorHeight, result.StartHeight, activeCheckpoints(u.settings)); err != nil {
+			u.logger.Errorf("[catchup][%s] peer %s served a fork below a checkpoint: %v", catchupCtx.blockUpTo.Hash().String(), catchupCtx.peerID, err)
+			u.banMaliciousChainPeer(ctx, catchupCtx.peerID, "fork below checkpoint")
+
+			return err
+		}
+	}
+
+	// Without chain work for both tips there is nothing to compare
+	if len(catchupCtx.commonAncestorMeta.ChainWork) == 0 || len(result.StartChainWork) == 0 {
+		return nil
+	}
+
+	peerHeaders := result.Headers[catchupCtx.commonAncestorIndex+1:]
+	peerHeight := ancestorHeight + uint32(len(peerHeaders))
+	peerWork := catchup.CumulativeWork(catchupCtx.commonAncestorMeta.ChainWork, peerHeaders)
+
+	if err := catchup.ValidateCumulativeWork(peerWork, peerHeight, result.StartChainWork, result.StartHeight); err != nil {
+		u.logger.Errorf("[catchup][%s] peer %s served a chain with fabricated difficulty: %v", catchupCtx.blockUpTo.Hash().String(), catchupCtx.peerID, err)
+		u.banMaliciousChainPeer(ctx, catchupCtx.peerID, "fabricated chain work")
+
+		return err
+	}
+
+	return nil
+}
+
 // checkSecretMining detects if the peer withheld blocks (secret mining attack).
 // Delegates to checkSecretMiningFromCommonAncestor for the actual check.
 //
@@ -539,8 +623,9 @@ func (u *Server) buildHeaderCache(catchupCtx *CatchupContext) error {
 // Returns:
 //   - error: If checkpoint verification fails
 func (u *Server) verifyCheckpointsInHeaderChain(catchupCtx *CatchupContext) error {
-	// Get checkpoints from settings
-	if u.settings.ChainCfgParams == nil || len(u.settings.ChainCfgParams.Checkpoints) == 0 {
+	// Get checkpoints from the checkpoint registry
+	activeCheckpoints := activeCheckpoints(u.settings)
+	if len(activeCheckpoints) == 0 {
 		u.logger.Debugf("[catchup][%s] No checkpoints configured", catchupCtx.blockUpTo.Hash().String())
 		return nil // No checkpoints to verify
 	}
@@ -552,7 +637,7 @@ func (u *Server) verifyCheckpointsInHeaderChain(catchupCtx *CatchupContext) erro
 	}
 
 	// Get the highest checkpoint height for reference
-	highestCheckpointHeight := getHighestCheckpointHeight(u.settings.ChainCfgParams.Checkpoints)
+	highestCheckpointHeight := getHighestCheckpointHeight(activeCheckpoints)
 	catchupCtx.highestCheckpointHeight = highestCheckpointHeight
 
 	// Calculate the height range - much simpler now since headers are sequential with no gaps
@@ -568,7 +653,7 @@ func (u *Server) verifyCheckpointsInHeaderChain(catchupCtx *CatchupContext) erro
 
 	// Verify checkpoints within our header range
 	checkpointsChecked := 0
-	for _, checkpoint := range u.settings.ChainCfgParams.Checkpoints {
+	for _, checkpoint := range activeCheckpoints {
 		checkpointHeight := uint32(checkpoint.Height)
 
 		// Skip checkpoints at or below the common ancestor height
@@ -848,7 +933,9 @@ func (u *Server) restoreFSMState(ctx context.Context, catchupCtx *CatchupContext
 }
 
 // validateBlocksOnChannel processes and validates blocks received from the channel.
-// Validates blocks sequentially to maintain chain order.
+// Validates blocks sequentially to maintain chain order. Contiguous checkpointed blocks without
+// transactions besides the coinbase are collected in ranges, which are stored in a single
+// operation, see validateBlockRange.
 //
 // Parameters:
 //   - validateBlocksChan: Channel providing blocks to validate
@@ -861,8 +948,11 @@ func (u *Server) restoreFSMState(ctx context.Context, catchupCtx *CatchupContext
 func (u *Server) validateBlocksOnChannel(validateBlocksChan chan *model.Block, gCtx context.Context, catchupCtx *CatchupContext, size *atomic.Int64) error {
 	i := 0
 	blockUpTo := catchupCtx.blockUpTo
-	baseURL := catchupCtx.baseURL
-	peerID := catchupCtx.peerID
+
+	// block assembly can not move past a range before it is stored, so a range is at most as long as block
+	// assembly may run behind
+	maxRangeSize := max(u.settings.BlockValidation.MaxBlocksBehindBlockAssembly, 1)
+	blockRange := make([]*model.Block, 0, maxRangeSize)
 
 	// validate the blocks while getting them from the other node
 	// this will block until all blocks are validated
@@ -881,70 +971,183 @@ func (u *Server) validateBlocksOnChannel(validateBlocksChan chan *model.Block, g
 				u.logger.Debugf("[catchup:validateBlocksOnChannel][%s] validating block %s %d/%d", blockUpTo.Hash().String(), block.Hash().String(), i, size.Load())
 			}
 
-			// Wait for block assembly to be ready if needed
-			if err := blockassemblyutil.WaitForBlockAssemblyReady(gCtx, u.logger, u.blockAssemblyClient, block.Height, u.settings.BlockValidation.MaxBlocksBehindBlockAssembly); err != nil {
-				return errors.NewProcessingError("[catchup:validateBlocksOnChannel][%s] failed to wait for block assembly for block %s: %v", blockUpTo.Hash().String(), block.Hash().String(), err)
+			storeInRange := canStoreInBlockRange(block, catchupCtx)
+			extendsRange := storeInRange && (len(blockRange) == 0 || block.Header.HashPrevBlock.IsEqual(blockRange[len(blockRange)-1].Hash()))
+
+			// a block that does not extend the range ends it, the range is stored before the block is validated
+			if !extendsRange && len(blockRange) > 0 {
+				if err := u.validateBlockRange(gCtx, catchupCtx, blockRange, size); err != nil {
+					return err
+				}
+
+				blockRange = make([]*model.Block, 0, maxRangeSize)
 			}
 
-			// Get cached headers for validation
-			cachedHeaders, _ := u.headerChainCache.GetValidationHeaders(block.Hash())
+			if storeInRange {
+				blockRange = append(blockRange, block)
 
-			// Try quick validation if applicable
-			tryNormalValidation, err := u.tryQuickValidation(gCtx, block, catchupCtx, baseURL)
-			if err != nil {
+				if len(blockRange) >= maxRangeSize {
+					if err := u.validateBlockRange(gCtx, catchupCtx, blockRange, size); err != nil {
+						return err
+					}
+
+					blockRange = make([]*model.Block, 0, maxRangeSize)
+				}
+
+				continue
+			}
+
+			if err := u.validateCatchupBlock(gCtx, catchupCtx, block); err != nil {
 				return err
 			}
 
-			if tryNormalValidation {
-				// Standard validation path for blocks not verified by checkpoints
-				// Create validation options with cached headers
-				opts := &ValidateBlockOptions{
-					CachedHeaders:           cachedHeaders,
-					IsCatchupMode:           true,
-					DisableOptimisticMining: true,
-					PeerID:                  peerID,
-				}
+			u.recordCatchupBlockValidated(blockUpTo, size)
+		}
+	}
 
-				// Validate the block using standard validation
-				// Note: ValidateBlockWithOptions now automatically stores invalid blocks with invalid=true
-				if err := u.blockValidation.ValidateBlockWithOptions(gCtx, block, baseURL, nil, opts); err != nil {
-					u.logger.Errorf("[catchup:validateBlocksOnChannel][%s] failed to validate block %s at position %d: %v", blockUpTo.Hash().String(), block.Hash().String(), i, err)
+	if err := u.validateBlockRange(gCtx, catchupCtx, blockRange, size); err != nil {
+		return err
+	}
 
-					// ValidateBlockWithOptions already stored the block as invalid if it's a consensus violation
-					// Just log and record metrics
-					if errors.Is(err, errors.ErrBlockInvalid) || errors.Is(err, errors.ErrTxInvalid) {
-						u.logger.Warnf("[catchup:validateBlocksOnChannel][%s] block %s violates consensus rules (already stored as invalid by ValidateBlockWithOptions)", blockUpTo.Hash().String(), block.Hash().String())
+	u.logger.Infof("[catchup:validateBlocksOnChannel][%s] completed validation of %d blocks", blockUpTo.Hash().String(), i)
 
-						// Mark peer as malicious for providing invalid block
-						u.reportCatchupMalicious(gCtx, peerID, "invalid_block_validation")
-					}
+	return nil
+}
 
-					// Record metric for validation failure
-					if prometheusCatchupErrors != nil {
-						prometheusCatchupErrors.WithLabelValues(peerID, "validation_failure").Inc()
-					}
+// canStoreInBlockRange returns whether a block can be stored in a range of blocks: a block eligible for quick
+// validation without transactions besides the coinbase has no UTXOs to create or spend, it only has to be stored
+func canStoreInBlockRange(block *model.Block, catchupCtx *CatchupContext) bool {
+	return catchupCtx.useQuickValidation && block.Height <= catchupCtx.highestCheckpointHeight && len(block.Subtrees) == 0
+}
 
-					return err
+// validateBlockRange stores a contiguous range of checkpointed blocks without transactions besides the coinbase with
+// a single StoreBlocks call, instead of an AddBlock call per block. When the range can not be stored, none of its
+// blocks are, and the blocks are validated one by one.
+//
+// Parameters:
+//   - gCtx: Context for cancellation
+//   - catchupCtx: Catchup context with validation mode information
+//   - blockRange: Contiguous range of blocks, ordered by height
+//   - size: Atomic counter for remaining blocks
+//
+// Returns:
+//   - error: If validation of the blocks fails or context is cancelled
+func (u *Server) validateBlockRange(gCtx context.Context, catchupCtx *CatchupContext, blockRange []*model.Block, size *atomic.Int64) error {
+	if len(blockRange) == 0 {
+		return nil
+	}
 
-					// TODO: Consider increasing peer reputation for successful block validations. For now being cautious and only increasing on successful catchup operations.
-				}
-			}
-			// Update the remaining block count
-			remaining := size.Add(-1)
-			if remaining%100 == 0 && remaining > 0 {
-				u.logger.Infof("[catchup:validateBlocksOnChannel][%s] %d blocks remaining", blockUpTo.Hash().String(), remaining)
+	blockUpTo := catchupCtx.blockUpTo
+
+	// Wait for block assembly to be ready for the first block of the range, which it can not move past until the
+	// range is stored
+	if err := blockassemblyutil.WaitForBlockAssemblyReady(gCtx, u.logger, u.blockAssemblyClient, blockRange[0].Height, u.settings.BlockValidation.MaxBlocksBehindBlockAssembly); err != nil {
+		return errors.NewProcessingError("[catchup:validateBlocksOnChannel][%s] failed to wait for block assembly for block %s: %v", blockUpTo.Hash().String(), blockRange[0].Hash().String(), err)
+	}
+
+	if err := u.blockValidation.quickValidateBlockRange(gCtx, blockRange, catchupCtx.baseURL); err != nil {
+		u.logger.Warnf("[catchup:validateBlocksOnChannel][%s] failed to store the range of %d blocks %s to %s, validating them one by one: %v",
+			blockUpTo.Hash().String(), len(blockRange), blockRange[0].Hash().String(), blockRange[len(blockRange)-1].Hash().String(), err)
+
+		for _, block := range blockRange {
+			if err = u.validateCatchupBlock(gCtx, catchupCtx, block); err != nil {
+				return err
 			}
 
-			// Update validated counter for progress tracking
-			u.blocksValidated.Add(1)
+			u.recordCatchupBlockValidated(blockUpTo, size)
 		}
+
+		return nil
 	}
 
-	u.logger.Infof("[catchup:validateBlocksOnChannel][%s] completed validation of %d blocks", blockUpTo.Hash().String(), i)
+	for range blockRange {
+		u.recordCatchupBlockValidated(blockUpTo, size)
+	}
+
+	return nil
+}
+
+// validateCatchupBlock validates a single block of the catchup, with quick validation when the block is checkpointed
+// and with standard validation otherwise
+//
+// Parameters:
+//   - gCtx: Context for cancellation
+//   - catchupCtx: Catchup context with validation mode information
+//   - block: Block to validate
+//
+// Returns:
+//   - error: If validation fails or context is cancelled
+func (u *Server) validateCatchupBlock(gCtx context.Context, catchupCtx *CatchupContext, block *model.Block) error {
+	blockUpTo := catchupCtx.blockUpTo
+	baseURL := catchupCtx.baseURL
+	peerID := catchupCtx.peerID
+
+	// Wait for block assembly to be ready if needed
+	if err := blockassemblyutil.WaitForBlockAssemblyReady(gCtx, u.logger, u.blockAssemblyClient, block.Height, u.settings.BlockValidation.MaxBlocksBehindBlockAssembly); err != nil {
+		return errors.NewProcessingError("[catchup:validateBlocksOnChannel][%s] failed to wait for block assembly for block %s: %v", blockUpTo.Hash().String(), block.Hash().String(), err)
+	}
+
+	// Get cached headers for validation
+	cachedHeaders, _ := u.headerChainCache.GetValidationHeaders(block.Hash())
+
+	// Try quick validation if applicable
+	tryNormalValidation, err := u.tryQuickValidation(gCtx, block, catchupCtx, baseURL)
+	if err != nil {
+		return err
+	}
+
+	if !tryNormalValidation {
+		return nil
+	}
+
+	// Standard validation path for blocks not verified by checkpoints
+	// Create validation options with cached headers
+	opts := &ValidateBlockOptions{
+		CachedHeaders:           cachedHeaders,
+		IsCatchupMode:           true,
+		DisableOptimisticMining: true,
+		PeerID:                  peerID,
+	}
+
+	// Validate the block using standard validation
+	// Note: ValidateBlockWithOptions now automatically stores invalid blocks with invalid=true
+	if err = u.blockValidation.ValidateBlockWithOptions(gCtx, block, baseURL, nil, opts); err != nil {
+		u.logger.Errorf("[catchup:validateBlocksOnChannel][%s] failed to validate block %s at height %d: %v", blockUpTo.Hash().String(), block.Hash().String(), block.Height, err)
+
+		// ValidateBlockWithOptions already stored the block as invalid if it's a consensus violation
+		// Just log and record metrics
+		if errors.Is(err, errors.ErrBlockInvalid) || errors.Is(err, errors.ErrTxInvalid) {
+			u.logger.Warnf("[catchup:validateBlocksOnChannel][%s] block %s violates consensus rules (already stored as invalid by ValidateBlockWithOptions)", blockUpTo.Hash().String(), block.Hash().String())
+
+			// Mark peer as malicious for providing invalid block
+			u.reportCatchupMalicious(gCtx, peerID, "invalid_block_validation")
+		}
+
+		// Record metric for validation failure
+		if prometheusCatchupErrors != nil {
+			prometheusCatchupErrors.WithLabelValues(peerID, "validation_failure").Inc()
+		}
+
+		return err
+
+		// TODO: Consider increasing peer reputation for successful block validations. For now being cautious and only increasing on successful catchup operations.
+	}
 
 	return nil
 }
 
+// recordCatchupBlockValidated updates the remaining block count and the progress of the catchup for a validated block
+func (u *Server) recordCatchupBlockValidated(blockUpTo *model.Block, size *atomic.Int64) {
+	// Update the remaining block count
+	remaining := size.Add(-1)
+	if remaining%100 == 0 && remaining > 0 {
+		u.logger.Infof("[catchup:validateBlocksOnChannel][%s] %d blocks remaining", blockUpTo.Hash().String(), remaining)
+	}
+
+	// Update validated counter for progress tracking
+	u.catchupRate.record(time.Now(), u.blocksValidated.Add(1), u.bytesFetched.Load())
+}
+
 // tryQuickValidation attempts quick validation for checkpointed blocks
 // Returns true if normal validation should be tried, false if quick validation succeeded
 func (u *Server) tryQuickValidation(ctx context.Context, block *model.Block, catchupCtx *CatchupContext, baseURL string) (bool, error) {
@@ -984,6 +1187,22 @@ func (u *Server) tryQuickValidation(ctx context.Context, block *model.Block, cat
 	return false, nil
 }
 
+// activeCheckpoints returns the checkpoints of the checkpoint registry, which combines the chain
+// parameter checkpoints with configured and remote ones. Invalid checkpoint settings fail the
+// blockvalidation Init, so falling back to the chain parameters here only happens in tests.
+func activeCheckpoints(tSettings *settings.Settings) []chaincfg.Checkpoint {
+	registry, err := checkpoints.ForSettings(tSettings)
+	if err != nil {
+		if tSettings.ChainCfgParams == nil {
+			return nil
+		}
+
+		return tSettings.ChainCfgParams.Checkpoints
+	}
+
+	return registry.ChainCheckpoints()
+}
+
 // getHighestCheckpointHeight returns the height of the highest checkpoint
 func getHighestCheckpointHeight(checkpoints []chaincfg.Checkpoint) uint32 {
 	if len(checkpoints) == 0 {
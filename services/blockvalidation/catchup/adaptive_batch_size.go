@@ -0,0 +1,151 @@
+package catchup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// AdaptiveBatchSizeConfig holds configuration for an adaptive block fetch batch size
+type AdaptiveBatchSizeConfig struct {
+	MinBatchSize   int           // Fewest blocks requested per round
+	MaxBatchSize   int           // Most blocks requested per round, usually the limit the serving peer allows
+	TargetDuration time.Duration // Round duration the batch size is tuned towards, 0 disables duration tuning
+	MaxBatchBytes  int64         // Largest response payload the batch size is tuned towards, 0 disables payload tuning
+}
+
+// AdaptiveBatchSizer tunes the number of blocks requested from a peer per round. After every round the
+// size is moved towards the number of blocks the peer can deliver within the target duration and payload
+// size, measured on that round, changing at most by a factor of two per round. A failed round halves it.
+type AdaptiveBatchSizer struct {
+	mu     sync.Mutex
+	config AdaptiveBatchSizeConfig
+	size   int
+}
+
+// NewAdaptiveBatchSizer creates a new batch sizer. The average response time of the peer, when known, is
+// used as a starting hint: peers slower than the target duration start with a proportionally smaller batch.
+func NewAdaptiveBatchSizer(config AdaptiveBatchSizeConfig, avgResponseTime time.Duration) *AdaptiveBatchSizer {
+	if config.MinBatchSize < 1 {
+		config.MinBatchSize = 1
+	}
+
+	if config.MaxBatchSize < config.MinBatchSize {
+		config.MaxBatchSize = config.MinBatchSize
+	}
+
+	size := config.MaxBatchSize
+
+	if avgResponseTime > config.TargetDuration && config.TargetDuration > 0 {
+		size = int(float64(config.MaxBatchSize) * float64(config.TargetDuration) / float64(avgResponseTime))
+	}
+
+	abs := &AdaptiveBatchSizer{config: config}
+	abs.size = abs.clamp(size)
+
+	return abs
+}
+
+// Size returns the number of blocks to request in the next round
+func (abs *AdaptiveBatchSizer) Size() int {
+	abs.mu.Lock()
+	defer abs.mu.Unlock()
+
+	return abs.size
+}
+
+// Observe adjusts the batch size based on a completed round of the given number of blocks, its duration
+// and response payload size. Cancellations are not held against the peer, since they come from our side.
+func (abs *AdaptiveBatchSizer) Observe(blocks int, duration time.Duration, payloadBytes int, err error) {
+	abs.mu.Lock()
+	defer abs.mu.Unlock()
+
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, errors.ErrContextCanceled):
+		// Cancelled on our side, the batch size is left unchanged
+		return
+	case err != nil:
+		abs.size = abs.clamp(abs.size / 2)
+		return
+	case blocks <= 0:
+		return
+	}
+
+	desired := abs.config.MaxBatchSize
+
+	if abs.config.TargetDuration > 0 && duration > 0 {
+		perBlock := float64(duration) / float64(blocks)
+		desired = min(desired, int(float64(abs.config.TargetDuration)/perBlock))
+	}
+
+	if abs.config.MaxBatchBytes > 0 && payloadBytes > 0 {
+		perBlock := float64(payloadBytes) / float64(blocks)
+		desired = min(desired, int(float64(abs.config.MaxBatchBytes)/perBlock))
+	}
+
+	// move gradually, a single slow or large round should not collapse the batch size
+	desired = max(desired, abs.size/2)
+	desired = min(desired, abs.size*2)
+
+	abs.size = abs.clamp(desired)
+}
+
+// clamp limits a batch size to the configured bounds
+func (abs *AdaptiveBatchSizer) clamp(size int) int {
+	return min(max(size, abs.config.MinBatchSize), abs.config.MaxBatchSize)
+}
+
+// PeerBatchSizers manages adaptive batch sizers for multiple peers
+type PeerBatchSizers struct {
+	mu     sync.Mutex
+	sizers map[string]*AdaptiveBatchSizer // Key is PeerID
+	config AdaptiveBatchSizeConfig
+}
+
+// NewPeerBatchSizers creates a new peer batch sizer manager
+func NewPeerBatchSizers(config AdaptiveBatchSizeConfig) *PeerBatchSizers {
+	return &PeerBatchSizers{
+		sizers: make(map[string]*AdaptiveBatchSizer),
+		config: config,
+	}
+}
+
+// GetSizer gets or creates the batch sizer for a peer. The average response time hint is only called,
+// outside the lock, when the sizer for the peer does not exist yet.
+func (pbs *PeerBatchSizers) GetSizer(peerID string, avgResponseTime func() time.Duration) *AdaptiveBatchSizer {
+	pbs.mu.Lock()
+	sizer, exists := pbs.sizers[peerID]
+	pbs.mu.Unlock()
+
+	if exists {
+		return sizer
+	}
+
+	var hint time.Duration
+	if avgResponseTime != nil {
+		hint = avgResponseTime()
+	}
+
+	pbs.mu.Lock()
+	defer pbs.mu.Unlock()
+
+	// another fetch for the same peer may have created the sizer in the meantime
+	if sizer, exists = pbs.sizers[peerID]; exists {
+		return sizer
+	}
+
+	sizer = NewAdaptiveBatchSizer(pbs.config, hint)
+	pbs.sizers[peerID] = sizer
+
+	return sizer
+}
+
+// RemovePeer drops the batch sizer for a peer
+func (pbs *PeerBatchSizers) RemovePeer(peerID string) {
+	pbs.mu.Lock()
+	defer pbs.mu.Unlock()
+
+	delete(pbs.sizers, peerID)
+}
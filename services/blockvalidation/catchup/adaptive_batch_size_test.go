@@ -0,0 +1,116 @@
+package catchup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBatchSizeConfig = AdaptiveBatchSizeConfig{
+	MinBatchSize:   10,
+	MaxBatchSize:   100,
+	TargetDuration: 10 * time.Second,
+	MaxBatchBytes:  1000,
+}
+
+func TestNewAdaptiveBatchSizer(t *testing.T) {
+	t.Run("starts at the maximum without a hint", func(t *testing.T) {
+		assert.Equal(t, 100, NewAdaptiveBatchSizer(testBatchSizeConfig, 0).Size())
+	})
+
+	t.Run("fast peers start at the maximum", func(t *testing.T) {
+		assert.Equal(t, 100, NewAdaptiveBatchSizer(testBatchSizeConfig, time.Second).Size())
+	})
+
+	t.Run("slow peers start proportionally smaller", func(t *testing.T) {
+		assert.Equal(t, 50, NewAdaptiveBatchSizer(testBatchSizeConfig, 20*time.Second).Size())
+		assert.Equal(t, 10, NewAdaptiveBatchSizer(testBatchSizeConfig, time.Hour).Size(), "never below the minimum")
+	})
+
+	t.Run("invalid bounds", func(t *testing.T) {
+		abs := NewAdaptiveBatchSizer(AdaptiveBatchSizeConfig{MinBatchSize: 0, MaxBatchSize: 0}, 0)
+		assert.Equal(t, 1, abs.Size())
+	})
+}
+
+func TestAdaptiveBatchSizer_Observe(t *testing.T) {
+	t.Run("slow rounds shrink the batch gradually", func(t *testing.T) {
+		abs := NewAdaptiveBatchSizer(testBatchSizeConfig, 0)
+
+		// 1s per block, the target allows 10 blocks, but a round shrinks the size by at most half
+		abs.Observe(100, 100*time.Second, 100, nil)
+		assert.Equal(t, 50, abs.Size())
+
+		abs.Observe(50, 50*time.Second, 50, nil)
+		assert.Equal(t, 25, abs.Size())
+
+		abs.Observe(25, 25*time.Second, 25, nil)
+		assert.Equal(t, 12, abs.Size())
+
+		abs.Observe(12, 12*time.Second, 12, nil)
+		assert.Equal(t, 10, abs.Size(), "never below the minimum")
+	})
+
+	t.Run("fast rounds grow the batch gradually", func(t *testing.T) {
+		abs := NewAdaptiveBatchSizer(testBatchSizeConfig, time.Hour)
+		require.Equal(t, 10, abs.Size())
+
+		abs.Observe(10, 100*time.Millisecond, 10, nil)
+		assert.Equal(t, 20, abs.Size())
+
+		abs.Observe(20, 200*time.Millisecond, 20, nil)
+		assert.Equal(t, 40, abs.Size())
+
+		abs.Observe(40, 400*time.Millisecond, 40, nil)
+		assert.Equal(t, 80, abs.Size())
+
+		abs.Observe(80, 800*time.Millisecond, 80, nil)
+		assert.Equal(t, 100, abs.Size(), "never above the maximum")
+	})
+
+	t.Run("large payloads shrink the batch", func(t *testing.T) {
+		abs := NewAdaptiveBatchSizer(testBatchSizeConfig, 0)
+
+		// 15 bytes per block, 1000 bytes allow 66 blocks
+		abs.Observe(100, time.Second, 1500, nil)
+		assert.Equal(t, 66, abs.Size())
+	})
+
+	t.Run("failures halve the batch", func(t *testing.T) {
+		abs := NewAdaptiveBatchSizer(testBatchSizeConfig, 0)
+
+		abs.Observe(100, time.Second, 0, errors.NewServiceError("peer unavailable"))
+		assert.Equal(t, 50, abs.Size())
+	})
+
+	t.Run("cancellations are ignored", func(t *testing.T) {
+		abs := NewAdaptiveBatchSizer(testBatchSizeConfig, 0)
+
+		abs.Observe(100, time.Second, 0, context.Canceled)
+		assert.Equal(t, 100, abs.Size())
+	})
+}
+
+func TestPeerBatchSizers(t *testing.T) {
+	pbs := NewPeerBatchSizers(testBatchSizeConfig)
+
+	hints := 0
+	hint := func() time.Duration {
+		hints++
+		return 20 * time.Second
+	}
+
+	sizer := pbs.GetSizer("peer1", hint)
+	assert.Equal(t, 50, sizer.Size())
+	assert.Same(t, sizer, pbs.GetSizer("peer1", hint))
+	assert.Equal(t, 1, hints, "the hint is only used when the sizer is created")
+
+	assert.Equal(t, 100, pbs.GetSizer("peer2", nil).Size())
+
+	pbs.RemovePeer("peer1")
+	assert.NotSame(t, sizer, pbs.GetSizer("peer1", nil))
+}
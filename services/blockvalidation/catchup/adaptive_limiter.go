@@ -0,0 +1,169 @@
+package catchup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// AdaptiveLimiterConfig holds configuration for an adaptive in-flight request limiter
+type AdaptiveLimiterConfig struct {
+	MinLimit      int           // Lowest in-flight limit the limiter backs off to
+	MaxLimit      int           // Highest in-flight limit the limiter grows to
+	LatencyTarget time.Duration // Requests slower than this are treated as congestion
+	QueueTimeout  time.Duration // Longest a request waits for a slot, 0 waits until its context is done
+}
+
+// AdaptiveLimiter caps the number of concurrent requests to a peer and tunes the cap AIMD style:
+// every fast, successful request grows the limit by 1/limit (about one slot per full window),
+// while a failure or a request slower than the latency target halves it.
+type AdaptiveLimiter struct {
+	mu       sync.Mutex
+	config   AdaptiveLimiterConfig
+	limit    float64
+	inFlight int
+	waitCh   chan struct{} // Closed and replaced whenever a slot may have become available
+}
+
+// NewAdaptiveLimiter creates a new adaptive limiter starting halfway between its bounds
+func NewAdaptiveLimiter(config AdaptiveLimiterConfig) *AdaptiveLimiter {
+	if config.MinLimit < 1 {
+		config.MinLimit = 1
+	}
+
+	if config.MaxLimit < config.MinLimit {
+		config.MaxLimit = config.MinLimit
+	}
+
+	return &AdaptiveLimiter{
+		config: config,
+		limit:  float64(config.MinLimit+config.MaxLimit) / 2,
+		waitCh: make(chan struct{}),
+	}
+}
+
+// Acquire blocks until a request slot is available, the context is done or the request waited the queue timeout
+func (al *AdaptiveLimiter) Acquire(ctx context.Context) error {
+	var timer *time.Timer
+
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	var timeout <-chan time.Time
+
+	for {
+		al.mu.Lock()
+
+		if al.inFlight < int(al.limit) {
+			al.inFlight++
+			al.mu.Unlock()
+
+			return nil
+		}
+
+		waitCh := al.waitCh
+		al.mu.Unlock()
+
+		// the queue timeout starts when the request has to wait for the first time
+		if timer == nil && al.config.QueueTimeout > 0 {
+			timer = time.NewTimer(al.config.QueueTimeout)
+			timeout = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.NewContextCanceledError("waiting for peer request slot", ctx.Err())
+		case <-timeout:
+			return errors.NewThresholdExceededError("timed out after %v waiting for peer request slot", al.config.QueueTimeout)
+		case <-waitCh:
+		}
+	}
+}
+
+// Release frees a request slot and adjusts the limit based on the request's latency and outcome.
+// Cancellations are not held against the peer, since they come from our side.
+func (al *AdaptiveLimiter) Release(latency time.Duration, err error) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	if al.inFlight > 0 {
+		al.inFlight--
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, errors.ErrContextCanceled):
+		// Cancelled on our side, the peer's limit is left unchanged
+	case err != nil || (al.config.LatencyTarget > 0 && latency > al.config.LatencyTarget):
+		al.limit /= 2
+		if al.limit < float64(al.config.MinLimit) {
+			al.limit = float64(al.config.MinLimit)
+		}
+	default:
+		al.limit += 1 / al.limit
+		if al.limit > float64(al.config.MaxLimit) {
+			al.limit = float64(al.config.MaxLimit)
+		}
+	}
+
+	close(al.waitCh)
+	al.waitCh = make(chan struct{})
+}
+
+// Limit returns the current in-flight limit
+func (al *AdaptiveLimiter) Limit() int {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	return int(al.limit)
+}
+
+// InFlight returns the number of requests currently in flight
+func (al *AdaptiveLimiter) InFlight() int {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	return al.inFlight
+}
+
+// PeerRequestLimiters manages adaptive request limiters for multiple peers
+type PeerRequestLimiters struct {
+	mu       sync.RWMutex
+	limiters map[string]*AdaptiveLimiter // Key is PeerID
+	config   AdaptiveLimiterConfig
+}
+
+// NewPeerRequestLimiters creates a new peer request limiter manager
+func NewPeerRequestLimiters(config AdaptiveLimiterConfig) *PeerRequestLimiters {
+	return &PeerRequestLimiters{
+		limiters: make(map[string]*AdaptiveLimiter),
+		config:   config,
+	}
+}
+
+// GetLimiter gets or creates the request limiter for a peer
+func (prl *PeerRequestLimiters) GetLimiter(peerID string) *AdaptiveLimiter {
+	prl.mu.Lock()
+	defer prl.mu.Unlock()
+
+	if limiter, exists := prl.limiters[peerID]; exists {
+		return limiter
+	}
+
+	limiter := NewAdaptiveLimiter(prl.config)
+	prl.limiters[peerID] = limiter
+
+	return limiter
+}
+
+// RemovePeer drops the request limiter for a peer
+func (prl *PeerRequestLimiters) RemovePeer(peerID string) {
+	prl.mu.Lock()
+	defer prl.mu.Unlock()
+
+	delete(prl.limiters, peerID)
+}
@@ -0,0 +1,124 @@
+package catchup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveLimiter_Acquire(t *testing.T) {
+	al := NewAdaptiveLimiter(AdaptiveLimiterConfig{MinLimit: 1, MaxLimit: 4, LatencyTarget: time.Second})
+	require.Equal(t, 2, al.Limit(), "limiter starts halfway between its bounds")
+
+	ctx := context.Background()
+	require.NoError(t, al.Acquire(ctx))
+	require.NoError(t, al.Acquire(ctx))
+	assert.Equal(t, 2, al.InFlight())
+
+	// A third request waits until a slot is released
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- al.Acquire(ctx)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquire should block while the peer is at its limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	al.Release(10*time.Millisecond, nil)
+
+	select {
+	case err := <-acquired:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("acquire should succeed once a slot is released")
+	}
+
+	assert.Equal(t, 2, al.InFlight())
+}
+
+func TestAdaptiveLimiter_AcquireContextDone(t *testing.T) {
+	al := NewAdaptiveLimiter(AdaptiveLimiterConfig{MinLimit: 1, MaxLimit: 1})
+	require.NoError(t, al.Acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := al.Acquire(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrContextCanceled))
+	assert.Equal(t, 1, al.InFlight())
+}
+
+func TestAdaptiveLimiter_AcquireQueueTimeout(t *testing.T) {
+	al := NewAdaptiveLimiter(AdaptiveLimiterConfig{MinLimit: 1, MaxLimit: 1, QueueTimeout: 20 * time.Millisecond})
+	require.NoError(t, al.Acquire(context.Background()))
+
+	start := time.Now()
+
+	err := al.Acquire(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrThresholdExceeded))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	assert.Equal(t, 1, al.InFlight())
+
+	// a request that gets a slot before the queue timeout is not affected
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		al.Release(time.Millisecond, nil)
+	}()
+
+	require.NoError(t, al.Acquire(context.Background()))
+}
+
+func TestAdaptiveLimiter_AIMD(t *testing.T) {
+	al := NewAdaptiveLimiter(AdaptiveLimiterConfig{MinLimit: 2, MaxLimit: 8, LatencyTarget: time.Second})
+	require.Equal(t, 5, al.Limit())
+
+	// Fast successful requests grow the limit additively up to the maximum
+	for i := 0; i < 100; i++ {
+		al.Release(10*time.Millisecond, nil)
+	}
+
+	assert.Equal(t, 8, al.Limit())
+
+	// A failure halves the limit
+	al.Release(10*time.Millisecond, errors.NewServiceError("peer failed"))
+	assert.Equal(t, 4, al.Limit())
+
+	// A slow request also halves the limit, but never below the minimum
+	al.Release(2*time.Second, nil)
+	assert.Equal(t, 2, al.Limit())
+
+	al.Release(2*time.Second, nil)
+	assert.Equal(t, 2, al.Limit())
+
+	// Cancellations on our side leave the limit unchanged
+	al.Release(10*time.Millisecond, context.Canceled)
+	assert.Equal(t, 2, al.Limit())
+
+	// Each success adds 1/limit, so it takes a little over a window of successes to gain a slot
+	al.Release(10*time.Millisecond, nil)
+	al.Release(10*time.Millisecond, nil)
+	assert.Equal(t, 2, al.Limit())
+
+	al.Release(10*time.Millisecond, nil)
+	assert.Equal(t, 3, al.Limit())
+}
+
+func TestPeerRequestLimiters(t *testing.T) {
+	prl := NewPeerRequestLimiters(AdaptiveLimiterConfig{MinLimit: 1, MaxLimit: 4})
+
+	limiter := prl.GetLimiter("peer1")
+	assert.Same(t, limiter, prl.GetLimiter("peer1"))
+	assert.NotSame(t, limiter, prl.GetLimiter("peer2"))
+
+	prl.RemovePeer("peer1")
+	assert.NotSame(t, limiter, prl.GetLimiter("peer1"))
+}
@@ -0,0 +1,178 @@
+// Package catchup provides blockchain synchronization utilities.
+//
+// This file detects chains with fabricated difficulty or forks below hard checkpoints
+// while headers are being streamed from a peer.
+package catchup
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-chaincfg"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockchain/work"
+)
+
+const (
+	// MaxDifficultyTransitionFactor is the largest factor by which the target may change
+	// between two consecutive headers. The legacy retarget clamps adjustments to 4x and the
+	// DAA moves far less per block, so anything beyond this was not produced by consensus rules.
+	MaxDifficultyTransitionFactor = 4
+
+	// MinFabricatedChainLead is how many blocks longer than our chain a peer's chain must be,
+	// while carrying less cumulative work, before it is treated as fabricated rather than a
+	// benign race between competing tips.
+	MinFabricatedChainLead = 6
+)
+
+// ChainValidator validates difficulty across a stream of headers.
+// It keeps the previous header's target so transitions can be checked across batch boundaries.
+type ChainValidator struct {
+	params     *chaincfg.Params
+	prevTarget *big.Int
+	count      int
+}
+
+// NewChainValidator creates a validator for headers streamed on the given network.
+func NewChainValidator(params *chaincfg.Params) *ChainValidator {
+	return &ChainValidator{
+		params: params,
+	}
+}
+
+// ValidateHeaders validates a batch of headers that directly follows the previously validated batch.
+//
+// Parameters:
+//   - headers: Headers in oldest to newest order
+//
+// Returns:
+//   - error: NetworkPeerMaliciousError if a header carries fabricated difficulty
+func (v *ChainValidator) ValidateHeaders(headers []*model.BlockHeader) error {
+	for _, header := range headers {
+		if err := v.ValidateHeader(header); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateHeader validates the difficulty of a single header against the network's proof of
+// work limit and against the difficulty of the preceding header.
+//
+// Parameters:
+//   - header: Next header in the stream
+//
+// Returns:
+//   - error: NetworkPeerMaliciousError if the header carries fabricated difficulty
+func (v *ChainValidator) ValidateHeader(header *model.BlockHeader) error {
+	target := header.Bits.CalculateTarget()
+
+	if target.Sign() <= 0 {
+		return errors.NewNetworkPeerMaliciousError("block header %s has non-positive target", header.Hash().String())
+	}
+
+	if v.params != nil && v.params.PowLimit != nil && target.Cmp(v.params.PowLimit) > 0 {
+		return errors.NewNetworkPeerMaliciousError("block header %s target %064x is above the proof of work limit %064x", header.Hash().String(), target, v.params.PowLimit)
+	}
+
+	// Networks that allow minimum difficulty blocks can legitimately jump between difficulties
+	if v.prevTarget != nil && !v.skipTransitionCheck() {
+		if err := validateDifficultyTransition(header, v.prevTarget, target); err != nil {
+			return err
+		}
+	}
+
+	v.prevTarget = target
+	v.count++
+
+	return nil
+}
+
+// Count returns the number of headers validated so far.
+func (v *ChainValidator) Count() int {
+	return v.count
+}
+
+func (v *ChainValidator) skipTransitionCheck() bool {
+	return v.params != nil && (v.params.ReduceMinDifficulty || v.params.NoDifficultyAdjustment)
+}
+
+// validateDifficultyTransition checks the target change between consecutive headers
+// stays within MaxDifficultyTransitionFactor in either direction.
+func validateDifficultyTransition(header *model.BlockHeader, prevTarget, target *big.Int) error {
+	maxTarget := new(big.Int).Mul(prevTarget, big.NewInt(MaxDifficultyTransitionFactor))
+	minTarget := new(big.Int).Div(prevTarget, big.NewInt(MaxDifficultyTransitionFactor))
+
+	if target.Cmp(maxTarget) > 0 || target.Cmp(minTarget) < 0 {
+		return errors.NewNetworkPeerMaliciousError("block header %s changes difficulty by more than %dx from its parent", header.Hash().String(), MaxDifficultyTransitionFactor)
+	}
+
+	return nil
+}
+
+// ValidateForkPoint rejects chains that fork from ours below a hard checkpoint.
+// Our chain already passed every checkpoint up to our best height, so a fork starting below
+// any of them must replace a checkpointed block.
+//
+// Parameters:
+//   - forkHeight: Height of the common ancestor between our chain and the peer's
+//   - bestHeight: Height of our best block
+//   - checkpoints: Known good blocks at specific heights
+//
+// Returns:
+//   - error: NetworkPeerMaliciousError if the fork is below a checkpoint
+func ValidateForkPoint(forkHeight, bestHeight uint32, checkpoints []chaincfg.Checkpoint) error {
+	if forkHeight >= bestHeight {
+		return nil
+	}
+
+	for _, checkpoint := range checkpoints {
+		height := uint32(checkpoint.Height)
+
+		if height > forkHeight && height <= bestHeight {
+			return errors.NewNetworkPeerMaliciousError("chain forks at height %d, below checkpoint %s at height %d", forkHeight, checkpoint.Hash.String(), height)
+		}
+	}
+
+	return nil
+}
+
+// CumulativeWork returns the chain work of ancestorChainWork extended by the given headers.
+// Chain work is stored byte-reversed, as in the block header metadata.
+func CumulativeWork(ancestorChainWork []byte, headers []*model.BlockHeader) *big.Int {
+	total := new(big.Int).SetBytes(bt.ReverseBytes(ancestorChainWork))
+
+	for _, header := range headers {
+		total.Add(total, work.CalcBlockWork(binary.LittleEndian.Uint32(header.Bits.CloneBytes())))
+	}
+
+	return total
+}
+
+// ValidateCumulativeWork rejects a chain that claims to be well ahead of ours while carrying
+// less cumulative proof of work, which can only happen if its difficulty was fabricated.
+//
+// Parameters:
+//   - peerWork: Cumulative work of the peer's chain tip
+//   - peerHeight: Height of the peer's chain tip
+//   - bestChainWork: Chain work of our best block, byte-reversed
+//   - bestHeight: Height of our best block
+//
+// Returns:
+//   - error: NetworkPeerMaliciousError if the peer's chain is longer but has less work
+func ValidateCumulativeWork(peerWork *big.Int, peerHeight uint32, bestChainWork []byte, bestHeight uint32) error {
+	if len(bestChainWork) == 0 || peerHeight < bestHeight+MinFabricatedChainLead {
+		return nil
+	}
+
+	bestWork := new(big.Int).SetBytes(bt.ReverseBytes(bestChainWork))
+
+	if peerWork.Cmp(bestWork) < 0 {
+		return errors.NewNetworkPeerMaliciousError("chain at height %d has less cumulative work (%064x) than our chain at height %d (%064x)", peerHeight, peerWork, bestHeight, bestWork)
+	}
+
+	return nil
+}
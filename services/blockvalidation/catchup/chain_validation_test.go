@@ -0,0 +1,144 @@
+package catchup
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/go-chaincfg"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBitsHeader(t *testing.T, bits string) *model.BlockHeader {
+	nBits, err := model.NewNBitFromString(bits)
+	require.NoError(t, err)
+
+	return &model.BlockHeader{
+		Version:        1,
+		HashPrevBlock:  &chainhash.Hash{},
+		HashMerkleRoot: &chainhash.Hash{},
+		Bits:           *nBits,
+	}
+}
+
+func chainWorkBytes(work *big.Int) []byte {
+	return bt.ReverseBytes(work.Bytes())
+}
+
+func TestChainValidator(t *testing.T) {
+	t.Run("ValidDifficulty", func(t *testing.T) {
+		v := NewChainValidator(&chaincfg.MainNetParams)
+
+		err := v.ValidateHeaders([]*model.BlockHeader{
+			createBitsHeader(t, "1d00ffff"),
+			createBitsHeader(t, "1c7fff80"), // twice as hard
+			createBitsHeader(t, "1c3fffc0"), // twice as hard again
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, v.Count())
+	})
+
+	t.Run("TargetAbovePowLimit", func(t *testing.T) {
+		v := NewChainValidator(&chaincfg.MainNetParams)
+
+		err := v.ValidateHeader(createBitsHeader(t, "1e00ffff"))
+		require.Error(t, err)
+		assert.True(t, errors.IsMaliciousResponseError(err))
+		assert.Contains(t, err.Error(), "proof of work limit")
+	})
+
+	t.Run("DifficultyJumpAcrossBatches", func(t *testing.T) {
+		v := NewChainValidator(&chaincfg.MainNetParams)
+
+		require.NoError(t, v.ValidateHeaders([]*model.BlockHeader{createBitsHeader(t, "1c3fffc0")}))
+
+		// 1d00ffff is four times easier than 1c3fffc0, which is still allowed
+		require.NoError(t, v.ValidateHeaders([]*model.BlockHeader{createBitsHeader(t, "1d00ffff")}))
+
+		// 1c00ffff is 256 times harder than its parent
+		err := v.ValidateHeaders([]*model.BlockHeader{createBitsHeader(t, "1c00ffff")})
+		require.Error(t, err)
+		assert.True(t, errors.IsMaliciousResponseError(err))
+		assert.Contains(t, err.Error(), "changes difficulty")
+	})
+
+	t.Run("MinDifficultyNetworkSkipsTransitions", func(t *testing.T) {
+		v := NewChainValidator(&chaincfg.TestNetParams)
+
+		err := v.ValidateHeaders([]*model.BlockHeader{
+			createBitsHeader(t, "1c00ffff"),
+			createBitsHeader(t, "1d00ffff"),
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestValidateForkPoint(t *testing.T) {
+	checkpoints := []chaincfg.Checkpoint{
+		{Height: 100, Hash: &chainhash.Hash{1}},
+		{Height: 200, Hash: &chainhash.Hash{2}},
+	}
+
+	t.Run("ForkAboveCheckpoints", func(t *testing.T) {
+		assert.NoError(t, ValidateForkPoint(250, 260, checkpoints))
+	})
+
+	t.Run("ExtendingOurTip", func(t *testing.T) {
+		assert.NoError(t, ValidateForkPoint(150, 150, checkpoints))
+	})
+
+	t.Run("CheckpointAboveOurTip", func(t *testing.T) {
+		// The checkpoint at 200 has not been reached by our chain yet
+		assert.NoError(t, ValidateForkPoint(150, 180, checkpoints))
+	})
+
+	t.Run("ForkBelowCheckpoint", func(t *testing.T) {
+		err := ValidateForkPoint(150, 250, checkpoints)
+		require.Error(t, err)
+		assert.True(t, errors.IsMaliciousResponseError(err))
+		assert.Contains(t, err.Error(), "below checkpoint")
+	})
+
+	t.Run("NoCheckpoints", func(t *testing.T) {
+		assert.NoError(t, ValidateForkPoint(10, 250, nil))
+	})
+}
+
+func TestCumulativeWork(t *testing.T) {
+	ancestorWork := big.NewInt(1000)
+	headers := []*model.BlockHeader{
+		createBitsHeader(t, "207fffff"),
+		createBitsHeader(t, "207fffff"),
+	}
+
+	// Regtest difficulty is worth 2 units of work per block
+	work := CumulativeWork(chainWorkBytes(ancestorWork), headers)
+	assert.Equal(t, int64(1004), work.Int64())
+}
+
+func TestValidateCumulativeWork(t *testing.T) {
+	bestWork := chainWorkBytes(big.NewInt(1000))
+
+	t.Run("LongerChainWithMoreWork", func(t *testing.T) {
+		assert.NoError(t, ValidateCumulativeWork(big.NewInt(2000), 120, bestWork, 100))
+	})
+
+	t.Run("LongerChainWithLessWork", func(t *testing.T) {
+		err := ValidateCumulativeWork(big.NewInt(900), 120, bestWork, 100)
+		require.Error(t, err)
+		assert.True(t, errors.IsMaliciousResponseError(err))
+		assert.Contains(t, err.Error(), "less cumulative work")
+	})
+
+	t.Run("SmallLeadIsTolerated", func(t *testing.T) {
+		assert.NoError(t, ValidateCumulativeWork(big.NewInt(900), 100+MinFabricatedChainLead-1, bestWork, 100))
+	})
+
+	t.Run("UnknownChainWork", func(t *testing.T) {
+		assert.NoError(t, ValidateCumulativeWork(big.NewInt(900), 120, nil, 100))
+	})
+}
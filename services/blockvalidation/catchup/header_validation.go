@@ -8,6 +8,7 @@ import (
 	"github.com/bsv-blockchain/go-chaincfg"
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/util/clock"
 )
 
 // ValidateHeaderProofOfWork validates that a block header meets the proof of work requirement.
@@ -48,7 +49,7 @@ func ValidateHeaderMerkleRoot(header *model.BlockHeader) error {
 // More comprehensive validation requires comparing with median time past.
 func ValidateHeaderTimestamp(header *model.BlockHeader) error {
 	// Reject blocks with timestamp too far in the future (2 hours)
-	maxTime := time.Now().Add(2 * time.Hour)
+	maxTime := clock.Now().Add(2 * time.Hour)
 	headerTime := time.Unix(int64(header.Timestamp), 0)
 
 	if headerTime.After(maxTime) {
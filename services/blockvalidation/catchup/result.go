@@ -19,6 +19,7 @@ type Result struct {
 	TargetHash        *chainhash.Hash   // Hash of the target block we're catching up to
 	StartHash         *chainhash.Hash   // Hash of the block we started from
 	StartHeight       uint32            // Height of the starting block
+	StartChainWork    []byte            // Chain work of the starting block, byte-reversed
 	LastProcessedHash *chainhash.Hash   // Last successfully processed block
 	LocatorHashes     []*chainhash.Hash // Block locator hashes used
 
@@ -0,0 +1,268 @@
+// This file contains the cross-check of catchup headers against other peers.
+package blockvalidation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockvalidation/catchup"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// maxCrossCheckPeers is the maximum number of peers the catchup headers are cross-checked against
+	maxCrossCheckPeers = 2
+
+	// crossCheckDenseLocatorHashes is the number of newest catchup headers added to the cross-check locator one by one,
+	// before the locator steps back exponentially
+	crossCheckDenseLocatorHashes = 10
+)
+
+// crossCheckVerdict is the outcome of comparing the catchup headers with the chain of another peer
+type crossCheckVerdict string
+
+const (
+	crossCheckAgree        crossCheckVerdict = "agree"        // the peer has the last catchup header on its chain
+	crossCheckConflict     crossCheckVerdict = "conflict"     // the peer has another block at the height of a catchup header
+	crossCheckInconclusive crossCheckVerdict = "inconclusive" // the peer is behind, off our chain or could not be asked
+)
+
+// crossCheckResult is the verdict of a single cross-check peer
+type crossCheckResult struct {
+	peer          PeerForCatchup
+	verdict       crossCheckVerdict
+	conflictIndex int // index of the first catchup header the peer has another block for
+	err           error
+}
+
+// crossCheckHeaders verifies the catchup headers of the catchup peer against the chains of other high reputation
+// peers when the paranoid cross-check mode is enabled. When every peer that could be asked has another block at
+// the height of one of the headers, the catchup peer is reported as malicious and the catchup fails, so it is
+// retried with another peer. When the cross-check peers disagree among themselves the catchup fails without
+// blaming the catchup peer. The catchup continues when no peer could confirm or contradict the headers.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - catchupCtx: Catchup context with the headers to cross-check
+//
+// Returns:
+//   - error: If the headers conflict with the chains of the cross-check peers
+func (u *Server) crossCheckHeaders(ctx context.Context, catchupCtx *CatchupContext) error {
+	count := min(u.settings.BlockValidation.CatchupCrossCheckPeers, maxCrossCheckPeers)
+	if count <= 0 || len(catchupCtx.blockHeaders) == 0 {
+		return nil
+	}
+
+	blockHash := catchupCtx.blockUpTo.Hash().String()
+
+	u.logger.Debugf("[catchup][%s] Cross-checking %d headers against %d other peers", blockHash, len(catchupCtx.blockHeaders), count)
+
+	peers := u.selectCrossCheckPeers(ctx, catchupCtx, count)
+	if len(peers) == 0 {
+		u.logger.Warnf("[catchup][%s] no peers with a reputation of at least %.2f available to cross-check the headers of peer %s",
+			blockHash, u.settings.BlockValidation.CatchupCrossCheckMinReputation, catchupCtx.peerID)
+
+		return nil
+	}
+
+	locator := buildCrossCheckLocator(catchupCtx.commonAncestorHash, catchupCtx.blockHeaders)
+	results := make([]crossCheckResult, len(peers))
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	for i, p := range peers {
+		g.Go(func() error {
+			results[i] = u.crossCheckWithPeer(gCtx, catchupCtx, p, locator)
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	var agreed, conflicted int
+
+	for _, result := range results {
+		if prometheusCatchupCrossChecks != nil {
+			prometheusCatchupCrossChecks.WithLabelValues(string(result.verdict)).Inc()
+		}
+
+		switch result.verdict {
+		case crossCheckAgree:
+			agreed++
+		case crossCheckConflict:
+			conflicted++
+
+			u.logger.Warnf("[catchup][%s] cross-check peer %s has another block than %s at height %d of peer %s", blockHash, result.peer.ID,
+				catchupCtx.blockHeaders[result.conflictIndex].Hash().String(), catchupCtx.commonAncestorMeta.Height+uint32(result.conflictIndex)+1, catchupCtx.peerID) //nolint:gosec // index is bounded by the header count
+		default:
+			u.logger.Infof("[catchup][%s] cross-check peer %s could not confirm the headers of peer %s: %v", blockHash, result.peer.ID, catchupCtx.peerID, result.err)
+		}
+	}
+
+	if conflicted == 0 {
+		if agreed > 0 {
+			u.logger.Infof("[catchup][%s] headers of peer %s confirmed by %d of %d cross-check peers", blockHash, catchupCtx.peerID, agreed, len(results))
+		} else {
+			u.logger.Warnf("[catchup][%s] none of the %d cross-check peers could confirm the headers of peer %s, continuing", blockHash, len(results), catchupCtx.peerID)
+		}
+
+		return nil
+	}
+
+	if agreed > 0 {
+		return errors.NewProcessingError("[catchup][%s] cross-check peers disagree on the headers of peer %s: %d agree, %d conflict", blockHash, catchupCtx.peerID, agreed, conflicted)
+	}
+
+	u.recordMaliciousAttempt(catchupCtx.peerID, "cross_check_header_mismatch")
+
+	return errors.NewNetworkPeerMaliciousError("[catchup][%s] headers of peer %s conflict with the chains of %d cross-check peers", blockHash, catchupCtx.peerID, conflicted)
+}
+
+// selectCrossCheckPeers returns up to count peers, other than the catchup peer, with at least the configured
+// reputation score, highest reputation first
+func (u *Server) selectCrossCheckPeers(ctx context.Context, catchupCtx *CatchupContext, count int) []PeerForCatchup {
+	// peers behind the catchup headers can still confirm or contradict part of them
+	peers, err := u.selectBestPeersForCatchup(ctx, int32(catchupCtx.commonAncestorMeta.Height)+1) //nolint:gosec // block heights fit in an int32
+	if err != nil {
+		u.logger.Warnf("[catchup][%s] failed to get peers to cross-check headers: %v", catchupCtx.blockUpTo.Hash().String(), err)
+		return nil
+	}
+
+	selected := make([]PeerForCatchup, 0, count)
+
+	for _, p := range peers {
+		if len(selected) == count {
+			break
+		}
+
+		if p.ID == catchupCtx.peerID || p.DataHubURL == catchupCtx.baseURL || p.BlockHash == "" {
+			continue
+		}
+
+		if p.CatchupReputationScore < u.settings.BlockValidation.CatchupCrossCheckMinReputation {
+			continue
+		}
+
+		selected = append(selected, p)
+	}
+
+	return selected
+}
+
+// crossCheckWithPeer fetches the headers of a cross-check peer from the newest catchup header on its chain and
+// compares them with the catchup headers
+func (u *Server) crossCheckWithPeer(ctx context.Context, catchupCtx *CatchupContext, p PeerForCatchup, locator []*chainhash.Hash) crossCheckResult {
+	result := crossCheckResult{peer: p, verdict: crossCheckInconclusive}
+
+	chainTipHash, err := chainhash.NewHashFromStr(p.BlockHash)
+	if err != nil {
+		result.err = errors.NewInvalidArgumentError("invalid best block hash %s", p.BlockHash, err)
+		return result
+	}
+
+	timeout := time.Duration(u.settings.BlockValidation.CatchupIterationTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	requestURL := fmt.Sprintf("%s/headers_from_common_ancestor/%s?block_locator_hashes=%s&n=%d",
+		p.DataHubURL,
+		chainTipHash.String(),
+		catchup.BuildBlockLocatorString(locator),
+		maxBlockHeadersPerRequest,
+	)
+
+	headerBytes, err := catchup.FetchHeadersWithRetry(ctx, u.logger, requestURL, u.settings.BlockValidation.CatchupMaxRetries)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	if err = catchup.ValidateBlockHeaderBytes(headerBytes); err != nil {
+		result.err = err
+		return result
+	}
+
+	peerHeaders, err := catchup.ParseBlockHeaders(headerBytes)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	result.verdict, result.conflictIndex = compareCrossCheckHeaders(catchupCtx.commonAncestorHash, catchupCtx.blockHeaders, peerHeaders)
+	if result.verdict == crossCheckInconclusive {
+		result.err = errors.NewProcessingError("peer returned %d headers not covering the last catchup header", len(peerHeaders))
+	}
+
+	return result
+}
+
+// buildCrossCheckLocator returns the block locator of the catchup headers, newest first. The newest headers are
+// added one by one, older headers with exponentially growing steps, and the common ancestor is always last.
+func buildCrossCheckLocator(ancestorHash *chainhash.Hash, headers []*model.BlockHeader) []*chainhash.Hash {
+	locator := make([]*chainhash.Hash, 0, crossCheckDenseLocatorHashes+32)
+
+	step := 1
+
+	for i := len(headers) - 1; i >= 0; i -= step {
+		locator = append(locator, headers[i].Hash())
+
+		if len(locator) >= crossCheckDenseLocatorHashes {
+			step *= 2
+		}
+	}
+
+	return append(locator, ancestorHash)
+}
+
+// compareCrossCheckHeaders compares the catchup headers following the common ancestor with the headers a
+// cross-check peer returned for the locator of the catchup headers. The peer headers start at the newest locator
+// hash on the chain of the peer, followed by the blocks of the peer at the next heights.
+//
+// Returns:
+//   - crossCheckVerdict: Whether the peer confirms or contradicts the catchup headers
+//   - int: Index of the first catchup header the peer has another block for, when the verdict is a conflict
+func compareCrossCheckHeaders(ancestorHash *chainhash.Hash, headers []*model.BlockHeader, peerHeaders []*model.BlockHeader) (crossCheckVerdict, int) {
+	if len(peerHeaders) == 0 || len(headers) == 0 {
+		return crossCheckInconclusive, 0
+	}
+
+	// index of the first peer header in the catchup headers, -1 is the common ancestor
+	start := -2
+
+	first := peerHeaders[0].Hash()
+	if first.IsEqual(ancestorHash) {
+		start = -1
+	} else {
+		for i, header := range headers {
+			if header.Hash().IsEqual(first) {
+				start = i
+				break
+			}
+		}
+	}
+
+	if start == -2 {
+		// the peer does not share the common ancestor with us, which says nothing about the catchup peer
+		return crossCheckInconclusive, 0
+	}
+
+	for i := 1; i < len(peerHeaders) && start+i < len(headers); i++ {
+		if !peerHeaders[i].Hash().IsEqual(headers[start+i].Hash()) {
+			return crossCheckConflict, start + i
+		}
+	}
+
+	if start+len(peerHeaders)-1 >= len(headers)-1 {
+		return crossCheckAgree, 0
+	}
+
+	return crossCheckInconclusive, 0
+}
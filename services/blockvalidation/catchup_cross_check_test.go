@@ -0,0 +1,238 @@
+package blockvalidation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockvalidation/testhelpers"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/jarcoal/httpmock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// crossCheckP2PClient returns a fixed list of catchup peers and records the peers reported as malicious
+type crossCheckP2PClient struct {
+	P2PClientI
+	peers     []*p2p.PeerInfo
+	malicious []string
+}
+
+func (c *crossCheckP2PClient) GetPeersForCatchup(_ context.Context) ([]*p2p.PeerInfo, error) {
+	return c.peers, nil
+}
+
+func (c *crossCheckP2PClient) RecordCatchupMalicious(_ context.Context, peerID string) error {
+	c.malicious = append(c.malicious, peerID)
+	return nil
+}
+
+// crossCheckChain returns count headers following prev, the salt makes chains with the same parent differ
+func crossCheckChain(prev *chainhash.Hash, count int, salt uint32) []*model.BlockHeader {
+	headers := make([]*model.BlockHeader, count)
+	nBits, _ := model.NewNBitFromString("207fffff")
+
+	for i := range headers {
+		headers[i] = &model.BlockHeader{
+			Version:        1,
+			HashPrevBlock:  prev,
+			HashMerkleRoot: testhelpers.GenerateMerkleRoot(int(salt)*1000 + i),
+			Timestamp:      uint32(1_700_000_000 + i*600), //nolint:gosec // small test values
+			Bits:           *nBits,
+		}
+		testhelpers.MineHeader(headers[i])
+		prev = headers[i].Hash()
+	}
+
+	return headers
+}
+
+func TestBuildCrossCheckLocator(t *testing.T) {
+	ancestor := &chainhash.Hash{1}
+	headers := crossCheckChain(ancestor, 100, 0)
+
+	locator := buildCrossCheckLocator(ancestor, headers)
+
+	// the 10 newest headers one by one, then steps of 2, 4, 8, 16 and 32
+	require.Len(t, locator, 16)
+
+	for i := 0; i < crossCheckDenseLocatorHashes; i++ {
+		assert.Equal(t, headers[99-i].Hash(), locator[i])
+	}
+
+	assert.Equal(t, headers[88].Hash(), locator[10])
+	assert.Equal(t, headers[84].Hash(), locator[11])
+	assert.Equal(t, ancestor, locator[len(locator)-1])
+
+	assert.Equal(t, []*chainhash.Hash{ancestor}, buildCrossCheckLocator(ancestor, nil))
+}
+
+func TestCompareCrossCheckHeaders(t *testing.T) {
+	ancestorHeader := crossCheckChain(&chainhash.Hash{}, 1, 0)[0]
+	ancestor := ancestorHeader.Hash()
+	headers := crossCheckChain(ancestor, 20, 0)
+
+	t.Run("peer has the last header", func(t *testing.T) {
+		verdict, _ := compareCrossCheckHeaders(ancestor, headers, headers[19:])
+		assert.Equal(t, crossCheckAgree, verdict)
+	})
+
+	t.Run("peer is ahead on the same chain", func(t *testing.T) {
+		peerHeaders := append([]*model.BlockHeader{headers[15]}, headers[16:]...)
+		peerHeaders = append(peerHeaders, crossCheckChain(headers[19].Hash(), 5, 0)...)
+
+		verdict, _ := compareCrossCheckHeaders(ancestor, headers, peerHeaders)
+		assert.Equal(t, crossCheckAgree, verdict)
+	})
+
+	t.Run("peer has another block after a shared header", func(t *testing.T) {
+		peerHeaders := append([]*model.BlockHeader{headers[11], headers[12]}, crossCheckChain(headers[12].Hash(), 10, 1)...)
+
+		verdict, index := compareCrossCheckHeaders(ancestor, headers, peerHeaders)
+		assert.Equal(t, crossCheckConflict, verdict)
+		assert.Equal(t, 13, index)
+	})
+
+	t.Run("peer forks at the common ancestor", func(t *testing.T) {
+		peerHeaders := append([]*model.BlockHeader{ancestorHeader}, crossCheckChain(ancestor, 3, 1)...)
+
+		verdict, index := compareCrossCheckHeaders(ancestor, headers, peerHeaders)
+		assert.Equal(t, crossCheckConflict, verdict)
+		assert.Equal(t, 0, index)
+	})
+
+	t.Run("peer is behind", func(t *testing.T) {
+		verdict, _ := compareCrossCheckHeaders(ancestor, headers, headers[5:10])
+		assert.Equal(t, crossCheckInconclusive, verdict)
+	})
+
+	t.Run("peer is off our chain", func(t *testing.T) {
+		verdict, _ := compareCrossCheckHeaders(ancestor, headers, crossCheckChain(&chainhash.Hash{9}, 3, 0))
+		assert.Equal(t, crossCheckInconclusive, verdict)
+
+		verdict, _ = compareCrossCheckHeaders(ancestor, headers, nil)
+		assert.Equal(t, crossCheckInconclusive, verdict)
+	})
+}
+
+func TestCrossCheckHeaders(t *testing.T) {
+	ancestorHeader := crossCheckChain(&chainhash.Hash{}, 1, 0)[0]
+	ancestor := ancestorHeader.Hash()
+	headers := crossCheckChain(ancestor, 20, 0)
+	fork := crossCheckChain(headers[9].Hash(), 12, 1)
+
+	primary, err := peer.Decode("12D3KooWKd2kacFFXWtbYtkDAsTP8fhEX1TbunV9Afimr7m1E8Yg")
+	require.NoError(t, err)
+
+	witnessA, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	witnessB, err := peer.Decode("12D3KooWRvkNXHMFoT6bqfE3EnMyBpPmhsRNyNB7qrjczkmmv6dK")
+	require.NoError(t, err)
+
+	newServer := func(client *crossCheckP2PClient) *Server {
+		tSettings := &settings.Settings{}
+		tSettings.BlockValidation.CatchupCrossCheckPeers = 2
+		tSettings.BlockValidation.CatchupCrossCheckMinReputation = 60
+		tSettings.BlockValidation.CatchupMaxRetries = 1
+		tSettings.BlockValidation.CatchupIterationTimeout = 5
+
+		return &Server{logger: ulogger.TestLogger{}, settings: tSettings, p2pClient: client}
+	}
+
+	newCatchupCtx := func() *CatchupContext {
+		return &CatchupContext{
+			blockUpTo:          &model.Block{Header: headers[19]},
+			baseURL:            "http://primary",
+			peerID:             primary.String(),
+			commonAncestorHash: ancestor,
+			commonAncestorMeta: &model.BlockHeaderMeta{Height: 100},
+			blockHeaders:       headers,
+		}
+	}
+
+	witness := func(id peer.ID, url string, tip *model.BlockHeader, reputation float64) *p2p.PeerInfo {
+		return &p2p.PeerInfo{ID: id, DataHubURL: url, Height: 130, BlockHash: tip.Hash().String(), ReputationScore: reputation}
+	}
+
+	respond := func(url string, headers []*model.BlockHeader) {
+		httpmock.RegisterResponder("GET", `=~^`+url+`/headers_from_common_ancestor/`, httpmock.NewBytesResponder(200, testhelpers.HeadersToBytes(headers)))
+	}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	t.Run("disabled", func(t *testing.T) {
+		client := &crossCheckP2PClient{}
+		u := newServer(client)
+		u.settings.BlockValidation.CatchupCrossCheckPeers = 0
+
+		require.NoError(t, u.crossCheckHeaders(context.Background(), newCatchupCtx()))
+		assert.Zero(t, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("confirmed by the other peers", func(t *testing.T) {
+		httpmock.Reset()
+		respond("http://a", headers[17:])
+		respond("http://b", headers[18:])
+
+		client := &crossCheckP2PClient{peers: []*p2p.PeerInfo{
+			witness(primary, "http://primary", headers[19], 95),
+			witness(witnessA, "http://a", headers[19], 90),
+			witness(witnessB, "http://b", headers[19], 80),
+		}}
+
+		require.NoError(t, newServer(client).crossCheckHeaders(context.Background(), newCatchupCtx()))
+		assert.Equal(t, 2, httpmock.GetTotalCallCount(), "the catchup peer is not asked")
+		assert.Empty(t, client.malicious)
+	})
+
+	t.Run("conflicting headers report the catchup peer", func(t *testing.T) {
+		httpmock.Reset()
+		respond("http://a", append([]*model.BlockHeader{headers[9]}, fork...))
+		respond("http://b", append([]*model.BlockHeader{headers[9]}, fork...))
+
+		client := &crossCheckP2PClient{peers: []*p2p.PeerInfo{
+			witness(witnessA, "http://a", fork[11], 90),
+			witness(witnessB, "http://b", fork[11], 80),
+		}}
+
+		err := newServer(client).crossCheckHeaders(context.Background(), newCatchupCtx())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrNetworkPeerMalicious))
+		assert.Equal(t, []string{primary.String()}, client.malicious)
+	})
+
+	t.Run("disagreeing peers do not blame the catchup peer", func(t *testing.T) {
+		httpmock.Reset()
+		respond("http://a", headers[19:])
+		respond("http://b", append([]*model.BlockHeader{headers[9]}, fork...))
+
+		client := &crossCheckP2PClient{peers: []*p2p.PeerInfo{
+			witness(witnessA, "http://a", headers[19], 90),
+			witness(witnessB, "http://b", fork[11], 80),
+		}}
+
+		err := newServer(client).crossCheckHeaders(context.Background(), newCatchupCtx())
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, errors.ErrNetworkPeerMalicious))
+		assert.Empty(t, client.malicious)
+	})
+
+	t.Run("no peers with enough reputation", func(t *testing.T) {
+		httpmock.Reset()
+
+		client := &crossCheckP2PClient{peers: []*p2p.PeerInfo{
+			witness(witnessA, "http://a", fork[11], 50),
+		}}
+
+		require.NoError(t, newServer(client).crossCheckHeaders(context.Background(), newCatchupCtx()))
+		assert.Zero(t, httpmock.GetTotalCallCount())
+	})
+}
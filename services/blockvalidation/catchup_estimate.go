@@ -0,0 +1,259 @@
+// This file contains the catchup dry-run estimation used by operators before triggering a catchup.
+package blockvalidation
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockvalidation/blockvalidation_api"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+)
+
+// catchupEstimateBlockSampleSize is the number of recent blocks used to compute the average block size
+const catchupEstimateBlockSampleSize = 100
+
+// CatchupPeerCandidate describes a peer that could serve a catchup and how long it is expected to take.
+type CatchupPeerCandidate struct {
+	// PeerID is the P2P identifier of the peer
+	PeerID string `json:"peer_id"`
+
+	// DataHubURL is the DataHub URL blocks would be fetched from
+	DataHubURL string `json:"data_hub_url"`
+
+	// Height is the height advertised by the peer
+	Height int32 `json:"height"`
+
+	// ReputationScore is the peer's reputation in the P2P registry
+	ReputationScore float64 `json:"reputation_score"`
+
+	// AvgResponseTimeMs is the peer's average response time
+	AvgResponseTimeMs int64 `json:"avg_response_time_ms"`
+
+	// ThroughputBytesPerSec is the observed throughput, zero if the peer has no history yet
+	ThroughputBytesPerSec uint64 `json:"throughput_bytes_per_sec"`
+
+	// EstimatedDurationMs is how long fetching the estimated bytes would take, zero if unknown
+	EstimatedDurationMs int64 `json:"estimated_duration_ms"`
+}
+
+// CatchupEstimate is the result of a catchup dry-run.
+type CatchupEstimate struct {
+	// TargetBlockHash is the hash of the block the catchup would sync up to
+	TargetBlockHash string `json:"target_block_hash"`
+
+	// TargetBlockHeight is the known or advertised height of the target block
+	TargetBlockHeight uint32 `json:"target_block_height"`
+
+	// CurrentHeight is the height of our best block
+	CurrentHeight uint32 `json:"current_height"`
+
+	// TargetExists indicates the target block is already in our blockchain store
+	TargetExists bool `json:"target_exists"`
+
+	// BlocksToFetch is the number of blocks between our best block and the target
+	BlocksToFetch uint64 `json:"blocks_to_fetch"`
+
+	// AverageBlockSize is the average size in bytes of our most recent blocks
+	AverageBlockSize uint64 `json:"average_block_size"`
+
+	// EstimatedBytes is the estimated number of bytes the catchup would download
+	EstimatedBytes uint64 `json:"estimated_bytes"`
+
+	// CandidatePeers are the peers at or above the target height, in catchup preference order
+	CandidatePeers []CatchupPeerCandidate `json:"candidate_peers"`
+}
+
+// EstimateCatchup estimates the catchup work to a target block via gRPC without starting the catchup.
+//
+// Parameters:
+//   - ctx: Context for the gRPC request
+//   - req: Request containing the target block hash
+//
+// Returns:
+//   - *blockvalidation_api.EstimateCatchupResponse: Estimated catchup work and candidate peers
+//   - error: If the target block is unknown or the estimate could not be computed
+func (u *Server) EstimateCatchup(ctx context.Context, req *blockvalidation_api.EstimateCatchupRequest) (*blockvalidation_api.EstimateCatchupResponse, error) {
+	targetHash, err := chainhash.NewHash(req.Hash)
+	if err != nil {
+		return nil, errors.WrapGRPC(errors.NewInvalidArgumentError("[EstimateCatchup] invalid block hash", err))
+	}
+
+	estimate, err := u.estimateCatchupInternal(ctx, targetHash)
+	if err != nil {
+		return nil, errors.WrapGRPC(err)
+	}
+
+	resp := &blockvalidation_api.EstimateCatchupResponse{
+		TargetBlockHash:   estimate.TargetBlockHash,
+		TargetBlockHeight: estimate.TargetBlockHeight,
+		CurrentHeight:     estimate.CurrentHeight,
+		TargetExists:      estimate.TargetExists,
+		BlocksToFetch:     estimate.BlocksToFetch,
+		AverageBlockSize:  estimate.AverageBlockSize,
+		EstimatedBytes:    estimate.EstimatedBytes,
+		CandidatePeers:    make([]*blockvalidation_api.CatchupPeerCandidate, 0, len(estimate.CandidatePeers)),
+	}
+
+	for _, candidate := range estimate.CandidatePeers {
+		resp.CandidatePeers = append(resp.CandidatePeers, &blockvalidation_api.CatchupPeerCandidate{
+			PeerId:                candidate.PeerID,
+			DataHubUrl:            candidate.DataHubURL,
+			Height:                candidate.Height,
+			ReputationScore:       candidate.ReputationScore,
+			AvgResponseTimeMs:     candidate.AvgResponseTimeMs,
+			ThroughputBytesPerSec: candidate.ThroughputBytesPerSec,
+			EstimatedDurationMs:   candidate.EstimatedDurationMs,
+		})
+	}
+
+	return resp, nil
+}
+
+// estimateCatchupInternal estimates the catchup work to a target block.
+// The target height comes from our blockchain store if we already have the block, otherwise
+// from the peers advertising it as their best block.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - targetHash: Hash of the block the catchup would sync up to
+//
+// Returns:
+//   - *CatchupEstimate: Estimated catchup work and candidate peers
+//   - error: If the target block is not known locally or advertised by any peer
+func (u *Server) estimateCatchupInternal(ctx context.Context, targetHash *chainhash.Hash) (*CatchupEstimate, error) {
+	bestBlockHeader, bestBlockMeta, err := u.blockchainClient.GetBestBlockHeader(ctx)
+	if err != nil {
+		return nil, errors.NewServiceError("[EstimateCatchup] failed to get best block header", err)
+	}
+
+	estimate := &CatchupEstimate{
+		TargetBlockHash: targetHash.String(),
+		CurrentHeight:   bestBlockMeta.Height,
+		CandidatePeers:  []CatchupPeerCandidate{},
+	}
+
+	estimate.TargetExists, err = u.blockchainClient.GetBlockExists(ctx, targetHash)
+	if err != nil {
+		return nil, errors.NewServiceError("[EstimateCatchup] failed to check if block %s exists", targetHash.String(), err)
+	}
+
+	if estimate.TargetExists {
+		_, targetMeta, err := u.blockchainClient.GetBlockHeader(ctx, targetHash)
+		if err != nil {
+			return nil, errors.NewServiceError("[EstimateCatchup] failed to get block header %s", targetHash.String(), err)
+		}
+
+		estimate.TargetBlockHeight = targetMeta.Height
+	}
+
+	var peers []*p2p.PeerInfo
+
+	if u.p2pClient != nil {
+		if peers, err = u.p2pClient.GetPeersForCatchup(ctx); err != nil {
+			u.logger.Warnf("[EstimateCatchup] failed to get peers for catchup: %v", err)
+		}
+	}
+
+	if !estimate.TargetExists {
+		targetHeight, found := advertisedHeight(peers, targetHash)
+		if !found {
+			return nil, errors.NewNotFoundError("[EstimateCatchup] block %s is not known locally or advertised by any peer", targetHash.String())
+		}
+
+		estimate.TargetBlockHeight = targetHeight
+	}
+
+	if estimate.TargetBlockHeight > estimate.CurrentHeight && !estimate.TargetExists {
+		estimate.BlocksToFetch = uint64(estimate.TargetBlockHeight - estimate.CurrentHeight)
+	}
+
+	estimate.AverageBlockSize = u.averageBlockSize(ctx, bestBlockHeader.Hash())
+	estimate.EstimatedBytes = estimate.BlocksToFetch * estimate.AverageBlockSize
+	estimate.CandidatePeers = buildCatchupPeerCandidates(peers, estimate.TargetBlockHeight, estimate.EstimatedBytes)
+
+	return estimate, nil
+}
+
+// averageBlockSize returns the average size of the most recent blocks on our chain, or zero if unknown.
+func (u *Server) averageBlockSize(ctx context.Context, bestHash *chainhash.Hash) uint64 {
+	_, metas, err := u.blockchainClient.GetBlockHeaders(ctx, bestHash, catchupEstimateBlockSampleSize)
+	if err != nil {
+		u.logger.Warnf("[EstimateCatchup] failed to get recent block headers: %v", err)
+		return 0
+	}
+
+	return averageSizeInBytes(metas)
+}
+
+// averageSizeInBytes returns the average block size over the given metadata, or zero if empty.
+func averageSizeInBytes(metas []*model.BlockHeaderMeta) uint64 {
+	if len(metas) == 0 {
+		return 0
+	}
+
+	var total uint64
+	for _, meta := range metas {
+		total += meta.SizeInBytes
+	}
+
+	return total / uint64(len(metas))
+}
+
+// advertisedHeight returns the highest height at which any peer advertises the hash as its best block.
+func advertisedHeight(peers []*p2p.PeerInfo, hash *chainhash.Hash) (uint32, bool) {
+	var (
+		height uint32
+		found  bool
+	)
+
+	for _, peer := range peers {
+		if peer.BlockHash != hash.String() || peer.Height <= 0 {
+			continue
+		}
+
+		if !found || uint32(peer.Height) > height {
+			height = uint32(peer.Height)
+			found = true
+		}
+	}
+
+	return height, found
+}
+
+// buildCatchupPeerCandidates returns the peers that could serve a catchup to targetHeight, keeping
+// the order of the P2P registry. Throughput is derived from the bytes received per successful
+// interaction and the peer's average response time.
+func buildCatchupPeerCandidates(peers []*p2p.PeerInfo, targetHeight uint32, estimatedBytes uint64) []CatchupPeerCandidate {
+	candidates := make([]CatchupPeerCandidate, 0, len(peers))
+
+	for _, peer := range peers {
+		if peer.DataHubURL == "" || peer.Height < 0 || uint32(peer.Height) < targetHeight {
+			continue
+		}
+
+		candidate := CatchupPeerCandidate{
+			PeerID:            peer.ID.String(),
+			DataHubURL:        peer.DataHubURL,
+			Height:            peer.Height,
+			ReputationScore:   peer.ReputationScore,
+			AvgResponseTimeMs: peer.AvgResponseTime.Milliseconds(),
+		}
+
+		if peer.InteractionSuccesses > 0 && peer.AvgResponseTime > 0 {
+			bytesPerInteraction := float64(peer.BytesReceived) / float64(peer.InteractionSuccesses)
+			candidate.ThroughputBytesPerSec = uint64(bytesPerInteraction / peer.AvgResponseTime.Seconds())
+		}
+
+		if candidate.ThroughputBytesPerSec > 0 && estimatedBytes > 0 {
+			seconds := float64(estimatedBytes) / float64(candidate.ThroughputBytesPerSec)
+			candidate.EstimatedDurationMs = time.Duration(seconds * float64(time.Second)).Milliseconds()
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
@@ -0,0 +1,114 @@
+package blockvalidation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAverageSizeInBytes(t *testing.T) {
+	assert.Zero(t, averageSizeInBytes(nil))
+
+	metas := []*model.BlockHeaderMeta{{SizeInBytes: 100}, {SizeInBytes: 300}}
+	assert.Equal(t, uint64(200), averageSizeInBytes(metas))
+}
+
+func TestAdvertisedHeight(t *testing.T) {
+	target := &chainhash.Hash{1}
+
+	peers := []*p2p.PeerInfo{
+		{ID: peer.ID("a"), Height: 110, BlockHash: target.String()},
+		{ID: peer.ID("b"), Height: 120, BlockHash: target.String()},
+		{ID: peer.ID("c"), Height: 200, BlockHash: (&chainhash.Hash{2}).String()},
+	}
+
+	height, found := advertisedHeight(peers, target)
+	assert.True(t, found)
+	assert.Equal(t, uint32(120), height)
+
+	_, found = advertisedHeight(peers, &chainhash.Hash{3})
+	assert.False(t, found)
+}
+
+func TestBuildCatchupPeerCandidates(t *testing.T) {
+	peers := []*p2p.PeerInfo{
+		{
+			ID:                   peer.ID("fast"),
+			DataHubURL:           "http://fast",
+			Height:               150,
+			ReputationScore:      90,
+			BytesReceived:        10_000_000,
+			InteractionSuccesses: 10,
+			AvgResponseTime:      500 * time.Millisecond,
+		},
+		{ID: peer.ID("new"), DataHubURL: "http://new", Height: 150},
+		{ID: peer.ID("behind"), DataHubURL: "http://behind", Height: 90},
+		{ID: peer.ID("no-url"), Height: 150},
+	}
+
+	candidates := buildCatchupPeerCandidates(peers, 100, 4_000_000)
+	require.Len(t, candidates, 2, "peers below the target or without a DataHub URL are not candidates")
+
+	// 1MB per interaction at 500ms each
+	assert.Equal(t, peer.ID("fast").String(), candidates[0].PeerID)
+	assert.Equal(t, uint64(2_000_000), candidates[0].ThroughputBytesPerSec)
+	assert.Equal(t, int64(2000), candidates[0].EstimatedDurationMs)
+	assert.Equal(t, int64(500), candidates[0].AvgResponseTimeMs)
+
+	// Peers without history have an unknown throughput
+	assert.Zero(t, candidates[1].ThroughputBytesPerSec)
+	assert.Zero(t, candidates[1].EstimatedDurationMs)
+}
+
+func TestEstimateCatchupInternal(t *testing.T) {
+	bestHeader := &model.BlockHeader{HashPrevBlock: &chainhash.Hash{}, HashMerkleRoot: &chainhash.Hash{}}
+
+	newServer := func() (*Server, *blockchain.Mock) {
+		mockBlockchain := &blockchain.Mock{}
+		mockBlockchain.On("GetBestBlockHeader", mock.Anything).Return(bestHeader, &model.BlockHeaderMeta{Height: 100}, nil)
+		mockBlockchain.On("GetBlockHeaders", mock.Anything, mock.Anything, uint64(catchupEstimateBlockSampleSize)).
+			Return([]*model.BlockHeader{}, []*model.BlockHeaderMeta{{SizeInBytes: 1000}, {SizeInBytes: 3000}}, nil)
+
+		return &Server{logger: ulogger.TestLogger{}, blockchainClient: mockBlockchain}, mockBlockchain
+	}
+
+	t.Run("target already exists", func(t *testing.T) {
+		u, mockBlockchain := newServer()
+		target := &chainhash.Hash{1}
+
+		mockBlockchain.On("GetBlockExists", mock.Anything, target).Return(true, nil)
+		mockBlockchain.On("GetBlockHeader", mock.Anything, target).Return(bestHeader, &model.BlockHeaderMeta{Height: 95}, nil)
+
+		estimate, err := u.estimateCatchupInternal(context.Background(), target)
+		require.NoError(t, err)
+
+		assert.True(t, estimate.TargetExists)
+		assert.Equal(t, uint32(95), estimate.TargetBlockHeight)
+		assert.Equal(t, uint32(100), estimate.CurrentHeight)
+		assert.Zero(t, estimate.BlocksToFetch)
+		assert.Equal(t, uint64(2000), estimate.AverageBlockSize)
+		assert.Zero(t, estimate.EstimatedBytes)
+	})
+
+	t.Run("unknown target", func(t *testing.T) {
+		u, mockBlockchain := newServer()
+		target := &chainhash.Hash{2}
+
+		mockBlockchain.On("GetBlockExists", mock.Anything, target).Return(false, nil)
+
+		_, err := u.estimateCatchupInternal(context.Background(), target)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrNotFound))
+	})
+}
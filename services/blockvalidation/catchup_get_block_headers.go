@@ -144,6 +144,9 @@ func (u *Server) catchupGetBlockHeaders(ctx context.Context, blockUpTo *model.Bl
 	reachedTarget := false
 	stopReason := ""
 
+	// Difficulty is validated across batches so a fabricated chain is rejected while streaming
+	chainValidator := catchup.NewChainValidator(u.settings.ChainCfgParams)
+
 	// Iterate until we reach the target or chain tip
 	for iteration < maxCatchupIterations {
 		iteration++
@@ -162,12 +165,10 @@ func (u *Server) catchupGetBlockHeaders(ctx context.Context, blockUpTo *model.Bl
 		if iterationTimeout <= 0 {
 			iterationTimeout = 30 * time.Second // Default timeout
 		}
-		iterCtx, iterCancel := context.WithTimeout(ctx, iterationTimeout)
 
-		// Build request URL with current block locator
+		// Build request path with current block locator
 		blockLocatorStr := catchup.BuildBlockLocatorString(currentLocatorHashes)
-		requestURL := fmt.Sprintf("%s/headers_from_common_ancestor/%s?block_locator_hashes=%s&n=%d",
-			baseURL,
+		requestPath := fmt.Sprintf("/headers_from_common_ancestor/%s?block_locator_hashes=%s&n=%d",
 			chainTipHash.String(),
 			blockLocatorStr,
 			maxBlockHeadersPerRequest,
@@ -175,9 +176,13 @@ func (u *Server) catchupGetBlockHeaders(ctx context.Context, blockUpTo *model.Bl
 
 		u.logger.Debugf("[catchup][%s] iteration %d: requesting headers with locator starting at %s (timeout: %v)", chainTipHash.String(), iteration, currentLocatorHashes[0].String(), iterationTimeout)
 
-		// Fetch with retry using iteration context with timeout
-		blockHeadersBytes, err := catchup.FetchHeadersWithRetry(iterCtx, u.logger, requestURL, maxRetries)
-		iterCancel() // Clean up the iteration context
+		// Fetch with retry using an iteration context with timeout per DataHub URL, failing over to the mirrors of the peer
+		blockHeadersBytes, err := fetchWithFailover(ctx, u, peerID, baseURL, requestPath, func(ctx context.Context, requestURL string) ([]byte, error) {
+			iterCtx, iterCancel := context.WithTimeout(ctx, iterationTimeout)
+			defer iterCancel() // Clean up the iteration context
+
+			return catchup.FetchHeadersWithRetry(iterCtx, u.logger, requestURL, maxRetries)
+		})
 		if err != nil {
 			// Check if it's specifically a context deadline exceeded from the iteration timeout
 			// This indicates the peer is too slow to respond within our timeout
@@ -211,6 +216,26 @@ func (u *Server) catchupGetBlockHeaders(ctx context.Context, blockUpTo *model.Bl
 				), nil, errors.NewNetworkTimeoutError("peer %s timed out after %v during iteration %d", baseURL, elapsed, iteration)
 			}
 
+			// The peer throttled us for exceeding its catchup serving limit, it is healthy, so the failure is not
+			// recorded against it, another peer is used for the next catchup
+			if errors.Is(err, errors.ErrNetworkPeerThrottled) {
+				u.logger.Warnf("[catchup][%s] iteration %d: peer %s throttled the catchup: %v", chainTipHash.String(), iteration, baseURL, err)
+
+				failedIterations = append(failedIterations, catchup.IterationError{
+					Iteration:  iteration,
+					Error:      err,
+					Timestamp:  time.Now(),
+					PeerURL:    baseURL,
+					RetryCount: 0,
+					Duration:   time.Since(startTime),
+				})
+
+				return catchup.CreateCatchupResult(
+					allCatchupHeaders, blockUpTo.Hash(), startHash, startHeight, startTime, baseURL,
+					iteration, failedIterations, false, "Peer throttled catchup",
+				), nil, err
+			}
+
 			// Handle other non-timeout errors
 			iterErr := catchup.IterationError{
 				Iteration:  iteration,
@@ -319,6 +344,16 @@ func (u *Server) catchupGetBlockHeaders(ctx context.Context, blockUpTo *model.Bl
 			), nil, err
 		}
 
+		if err = chainValidator.ValidateHeaders(blockHeaders); err != nil {
+			u.logger.Errorf("[catchup][%s] iteration %d: peer %s served fabricated difficulty: %v", chainTipHash.String(), iteration, identifier, err)
+			u.banMaliciousChainPeer(ctx, identifier, "fabricated difficulty during header validation")
+
+			return catchup.CreateCatchupResult(
+				allCatchupHeaders, blockUpTo.Hash(), startHash, startHeight, startTime, baseURL,
+				iteration, failedIterations, false, "Fabricated difficulty",
+			), nil, err
+		}
+
 		// Check memory limit before appending
 		if len(allCatchupHeaders)+len(blockHeaders) > maxAccumulatedHeaders {
 			remainingCapacity := maxAccumulatedHeaders - len(allCatchupHeaders)
@@ -392,6 +427,7 @@ func (u *Server) catchupGetBlockHeaders(ctx context.Context, blockUpTo *model.Bl
 	u.logger.Infof("[catchup][%s] completed: %d headers fetched in %d iterations, reached target: %v, reason: %s", chainTipHash.String(), totalHeadersFetched, iteration, reachedTarget, stopReason)
 
 	result := catchup.CreateCatchupResultWithLocator(allCatchupHeaders, blockUpTo.Hash(), startHash, startHeight, startTime, baseURL, iteration, failedIterations, reachedTarget, stopReason, locatorHashes)
+	result.StartChainWork = bestBlockMeta.ChainWork
 
 	return result, bestBlockHeader, nil
 }
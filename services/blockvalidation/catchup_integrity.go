@@ -0,0 +1,216 @@
+// This file contains the integrity report of a completed catchup against the chains of other peers.
+package blockvalidation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockvalidation/catchup"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxIntegrityCheckPeers is the maximum number of peers asked for the blocks of a completed catchup
+const maxIntegrityCheckPeers = 5
+
+// Results of the integrity check of a sampled height of a completed catchup
+const (
+	integrityAgree        = "agree"        // at least one peer has our block at the height, none has another block
+	integrityDisputed     = "disputed"     // some peers have our block at the height, others have another block
+	integrityConflict     = "conflict"     // every peer that answered has another block at the height
+	integrityInconclusive = "inconclusive" // no peer could confirm or contradict our block at the height
+)
+
+// integrityHeightResult is the integrity check of a single sampled height of a completed catchup
+type integrityHeightResult struct {
+	height     uint32
+	hash       *chainhash.Hash
+	agreed     []string // peers with our block at the height
+	conflicted []string // peers with another block at the height
+}
+
+// result returns the integrity check result of the sampled height
+func (r *integrityHeightResult) result() string {
+	switch {
+	case len(r.conflicted) > 0 && len(r.agreed) > 0:
+		return integrityDisputed
+	case len(r.conflicted) > 0:
+		return integrityConflict
+	case len(r.agreed) > 0:
+		return integrityAgree
+	default:
+		return integrityInconclusive
+	}
+}
+
+// verifyCatchupIntegrity asks other reputable peers, after a catchup completed, for the blocks at a sample of the
+// heights just synced and reports the heights they disagree on. The headers of a catchup are only compared with
+// the chain of the catchup peer, so a catchup served entirely by colluding malicious peers would go unnoticed;
+// this check, unlike the cross-check of the headers, runs after the blocks have been validated and never fails the
+// catchup. The checked heights are counted by result in the catchup integrity metric, disagreements are logged and,
+// when every peer that answered has another block at a height, the catchup peer is reported as malicious.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - catchupCtx: Context of the completed catchup
+func (u *Server) verifyCatchupIntegrity(ctx context.Context, catchupCtx *CatchupContext) {
+	count := min(u.settings.BlockValidation.CatchupIntegrityCheckPeers, maxIntegrityCheckPeers)
+	sample := sampleIntegrityIndexes(len(catchupCtx.blockHeaders), u.settings.BlockValidation.CatchupIntegritySampleSize)
+
+	if count <= 0 || len(sample) == 0 {
+		return
+	}
+
+	blockHash := catchupCtx.blockUpTo.Hash().String()
+
+	peers := u.selectCrossCheckPeers(ctx, catchupCtx, count)
+	if len(peers) == 0 {
+		u.logger.Warnf("[catchup:integrity][%s] no peers with a reputation of at least %.2f available to verify the catchup from peer %s",
+			blockHash, u.settings.BlockValidation.CatchupCrossCheckMinReputation, catchupCtx.peerID)
+
+		return
+	}
+
+	results := make([]integrityHeightResult, len(sample))
+	verdicts := make([][]crossCheckVerdict, len(sample))
+
+	for i, index := range sample {
+		results[i] = integrityHeightResult{
+			height: catchupCtx.commonAncestorMeta.Height + uint32(index) + 1, //nolint:gosec // index is bounded by the header count
+			hash:   catchupCtx.blockHeaders[index].Hash(),
+		}
+		verdicts[i] = make([]crossCheckVerdict, len(peers))
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	for j, p := range peers {
+		g.Go(func() error {
+			for i, index := range sample {
+				verdicts[i][j] = u.integrityCheckWithPeer(gCtx, catchupCtx, p, index)
+			}
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	var disagreements int
+
+	for i := range results {
+		result := &results[i]
+
+		for j, verdict := range verdicts[i] {
+			switch verdict {
+			case crossCheckAgree:
+				result.agreed = append(result.agreed, peers[j].ID)
+			case crossCheckConflict:
+				result.conflicted = append(result.conflicted, peers[j].ID)
+			}
+		}
+
+		if prometheusCatchupIntegrityChecks != nil {
+			prometheusCatchupIntegrityChecks.WithLabelValues(result.result()).Inc()
+		}
+
+		switch result.result() {
+		case integrityDisputed:
+			disagreements++
+
+			u.logger.Warnf("[catchup:integrity][%s] peers disagree on block %s at height %d synced from peer %s: confirmed by %v, other block at %v",
+				blockHash, result.hash.String(), result.height, catchupCtx.peerID, result.agreed, result.conflicted)
+		case integrityConflict:
+			disagreements++
+
+			u.logger.Errorf("[catchup:integrity][%s] block %s at height %d synced from peer %s is not on the chain of peers %v",
+				blockHash, result.hash.String(), result.height, catchupCtx.peerID, result.conflicted)
+		}
+	}
+
+	if disagreements == 0 {
+		u.logger.Infof("[catchup:integrity][%s] no disagreements on %d sampled heights of the catchup from peer %s with %d peers", blockHash, len(results), catchupCtx.peerID, len(peers))
+		return
+	}
+
+	for i := range results {
+		if results[i].result() == integrityConflict {
+			u.recordMaliciousAttempt(catchupCtx.peerID, "integrity_check_mismatch")
+			break
+		}
+	}
+
+	u.logger.Errorf("[catchup:integrity][%s] %d of %d sampled heights of the catchup from peer %s are disputed by other peers", blockHash, disagreements, len(results), catchupCtx.peerID)
+}
+
+// integrityCheckWithPeer asks a peer whether the catchup header at index is on its chain. The peer answers with the
+// header when it is, or with the common ancestor when its chain has another block at the height of the header.
+func (u *Server) integrityCheckWithPeer(ctx context.Context, catchupCtx *CatchupContext, p PeerForCatchup, index int) crossCheckVerdict {
+	chainTipHash, err := chainhash.NewHashFromStr(p.BlockHash)
+	if err != nil {
+		return crossCheckInconclusive
+	}
+
+	hash := catchupCtx.blockHeaders[index].Hash()
+	height := catchupCtx.commonAncestorMeta.Height + uint32(index) + 1 //nolint:gosec // index is bounded by the header count
+
+	timeout := time.Duration(u.settings.BlockValidation.CatchupIterationTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	requestURL := fmt.Sprintf("%s/headers_from_common_ancestor/%s?block_locator_hashes=%s&n=1",
+		p.DataHubURL,
+		chainTipHash.String(),
+		catchup.BuildBlockLocatorString([]*chainhash.Hash{hash, catchupCtx.commonAncestorHash}),
+	)
+
+	headerBytes, err := catchup.FetchHeadersWithRetry(ctx, u.logger, requestURL, u.settings.BlockValidation.CatchupMaxRetries)
+	if err == nil {
+		err = catchup.ValidateBlockHeaderBytes(headerBytes)
+	}
+
+	var peerHeaders []*model.BlockHeader
+	if err == nil {
+		peerHeaders, err = catchup.ParseBlockHeaders(headerBytes)
+	}
+
+	if err != nil || len(peerHeaders) == 0 {
+		u.logger.Debugf("[catchup:integrity][%s] peer %s could not be asked for the block at height %d: %v", catchupCtx.blockUpTo.Hash().String(), p.ID, height, err)
+		return crossCheckInconclusive
+	}
+
+	first := peerHeaders[0].Hash()
+
+	switch {
+	case first.IsEqual(hash):
+		return crossCheckAgree
+	case first.IsEqual(catchupCtx.commonAncestorHash) && p.Height >= int32(height): //nolint:gosec // block heights fit in an int32
+		// the peer shares the common ancestor and is past the height, but the header is not on its chain
+		return crossCheckConflict
+	default:
+		// the peer is behind or does not share the common ancestor with us
+		return crossCheckInconclusive
+	}
+}
+
+// sampleIntegrityIndexes returns the indexes of up to size of count catchup headers, evenly spread and always
+// including the last header
+func sampleIntegrityIndexes(count, size int) []int {
+	size = min(size, count)
+	if size <= 0 {
+		return nil
+	}
+
+	indexes := make([]int, size)
+	for i := range indexes {
+		indexes[i] = (i+1)*count/size - 1
+	}
+
+	return indexes
+}
@@ -0,0 +1,166 @@
+package blockvalidation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockvalidation/testhelpers"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/jarcoal/httpmock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleIntegrityIndexes(t *testing.T) {
+	assert.Equal(t, []int{3, 7, 11, 15, 19}, sampleIntegrityIndexes(20, 5))
+	assert.Equal(t, []int{19}, sampleIntegrityIndexes(20, 1))
+	assert.Equal(t, []int{0, 1, 2}, sampleIntegrityIndexes(3, 5), "no more heights than synced")
+	assert.Nil(t, sampleIntegrityIndexes(20, 0))
+	assert.Nil(t, sampleIntegrityIndexes(0, 5))
+}
+
+// integrityChainResponder answers headers_from_common_ancestor requests from the chain of a peer with the first
+// locator hash on the chain
+func integrityChainResponder(chain []*model.BlockHeader) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		locator := req.URL.Query().Get("block_locator_hashes")
+
+		for ; len(locator) >= 64; locator = locator[64:] {
+			hash, err := chainhash.NewHashFromStr(locator[:64])
+			if err != nil {
+				break
+			}
+
+			for i, header := range chain {
+				if header.Hash().IsEqual(hash) {
+					return httpmock.NewBytesResponse(http.StatusOK, testhelpers.HeadersToBytes(chain[i:i+1])), nil
+				}
+			}
+		}
+
+		return httpmock.NewStringResponse(http.StatusNotFound, "not found"), nil
+	}
+}
+
+func TestVerifyCatchupIntegrity(t *testing.T) {
+	initPrometheusMetrics()
+
+	ancestorHeader := crossCheckChain(&chainhash.Hash{}, 1, 0)[0]
+	ancestor := ancestorHeader.Hash()
+	headers := crossCheckChain(ancestor, 20, 0)
+	fork := crossCheckChain(headers[9].Hash(), 12, 1)
+
+	ourChain := append([]*model.BlockHeader{ancestorHeader}, headers...)
+	forkChain := append(append([]*model.BlockHeader{ancestorHeader}, headers[:10]...), fork...)
+
+	primary, err := peer.Decode("12D3KooWKd2kacFFXWtbYtkDAsTP8fhEX1TbunV9Afimr7m1E8Yg")
+	require.NoError(t, err)
+
+	witnessA, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	witnessB, err := peer.Decode("12D3KooWRvkNXHMFoT6bqfE3EnMyBpPmhsRNyNB7qrjczkmmv6dK")
+	require.NoError(t, err)
+
+	newServer := func(client *crossCheckP2PClient) *Server {
+		tSettings := &settings.Settings{}
+		tSettings.BlockValidation.CatchupIntegrityCheckPeers = 3
+		tSettings.BlockValidation.CatchupIntegritySampleSize = 5
+		tSettings.BlockValidation.CatchupCrossCheckMinReputation = 60
+		tSettings.BlockValidation.CatchupMaxRetries = 1
+		tSettings.BlockValidation.CatchupIterationTimeout = 5
+
+		return &Server{logger: ulogger.TestLogger{}, settings: tSettings, p2pClient: client}
+	}
+
+	catchupCtx := &CatchupContext{
+		blockUpTo:          &model.Block{Header: headers[19]},
+		baseURL:            "http://primary",
+		peerID:             primary.String(),
+		commonAncestorHash: ancestor,
+		commonAncestorMeta: &model.BlockHeaderMeta{Height: 100},
+		blockHeaders:       headers,
+	}
+
+	witness := func(id peer.ID, url string, tip *model.BlockHeader) *p2p.PeerInfo {
+		return &p2p.PeerInfo{ID: id, DataHubURL: url, Height: 130, BlockHash: tip.Hash().String(), ReputationScore: 90}
+	}
+
+	respond := func(url string, chain []*model.BlockHeader) {
+		httpmock.RegisterResponder("GET", `=~^`+url+`/headers_from_common_ancestor/`, integrityChainResponder(chain))
+	}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	t.Run("disabled", func(t *testing.T) {
+		client := &crossCheckP2PClient{peers: []*p2p.PeerInfo{witness(witnessA, "http://a", headers[19])}}
+		u := newServer(client)
+		u.settings.BlockValidation.CatchupIntegrityCheckPeers = 0
+
+		u.verifyCatchupIntegrity(context.Background(), catchupCtx)
+		assert.Zero(t, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("confirmed by the other peers", func(t *testing.T) {
+		httpmock.Reset()
+		respond("http://a", ourChain)
+		respond("http://b", ourChain)
+
+		client := &crossCheckP2PClient{peers: []*p2p.PeerInfo{
+			witness(primary, "http://primary", headers[19]),
+			witness(witnessA, "http://a", headers[19]),
+			witness(witnessB, "http://b", headers[19]),
+		}}
+
+		newServer(client).verifyCatchupIntegrity(context.Background(), catchupCtx)
+		assert.Equal(t, 10, httpmock.GetTotalCallCount(), "5 heights from 2 peers, the catchup peer is not asked")
+		assert.Empty(t, client.malicious)
+	})
+
+	t.Run("chain of colluding peers reports the catchup peer", func(t *testing.T) {
+		httpmock.Reset()
+		respond("http://a", forkChain)
+		respond("http://b", forkChain)
+
+		client := &crossCheckP2PClient{peers: []*p2p.PeerInfo{
+			witness(witnessA, "http://a", fork[11]),
+			witness(witnessB, "http://b", fork[11]),
+		}}
+
+		newServer(client).verifyCatchupIntegrity(context.Background(), catchupCtx)
+		assert.Equal(t, []string{primary.String()}, client.malicious)
+	})
+
+	t.Run("disagreeing peers do not blame the catchup peer", func(t *testing.T) {
+		httpmock.Reset()
+		respond("http://a", ourChain)
+		respond("http://b", forkChain)
+
+		client := &crossCheckP2PClient{peers: []*p2p.PeerInfo{
+			witness(witnessA, "http://a", headers[19]),
+			witness(witnessB, "http://b", fork[11]),
+		}}
+
+		newServer(client).verifyCatchupIntegrity(context.Background(), catchupCtx)
+		assert.Empty(t, client.malicious)
+	})
+
+	t.Run("unreachable peers are inconclusive", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder("GET", `=~^http://a/headers_from_common_ancestor/`, httpmock.NewStringResponder(http.StatusInternalServerError, "down"))
+
+		client := &crossCheckP2PClient{peers: []*p2p.PeerInfo{witness(witnessA, "http://a", headers[19])}}
+		u := newServer(client)
+		u.settings.BlockValidation.CatchupIntegritySampleSize = 1
+
+		u.verifyCatchupIntegrity(context.Background(), catchupCtx)
+		assert.Empty(t, client.malicious)
+	})
+}
@@ -0,0 +1,262 @@
+// This file contains the merkle proof spot-check of the transactions of catchup blocks.
+package blockvalidation
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/pkg/fileformat"
+)
+
+// merkle spot-check results
+const (
+	merkleSpotCheckPass = "pass"
+	merkleSpotCheckFail = "fail"
+)
+
+// merkleSample is a sampled transaction of a block, by subtree and index in the subtree
+type merkleSample struct {
+	subtree int
+	index   int
+}
+
+// merkleSpotCheckBlock proves a random share of the transactions of a block served by a catchup peer against the
+// merkle root of the block header, before the block is validated. The transactions of the subtree data were checked
+// against the subtree nodes as they were read, so proving a node proves the transaction: a corrupted or truncated
+// subtree, or a block whose subtrees or coinbase do not add up to its merkle root, fails here instead of during full
+// validation. A failure reports the catchup peer as malicious and removes the stored subtrees of the block, so the
+// catchup is retried with another peer.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - block: Block served by the catchup peer
+//   - subtrees: Subtrees of the block served by the catchup peer, in the order of block.Subtrees
+//   - peerID: ID of the catchup peer
+//
+// Returns:
+//   - error: If a sampled transaction can not be proven against the merkle root of the block
+func (u *Server) merkleSpotCheckBlock(ctx context.Context, block *model.Block, subtrees []*subtreepkg.Subtree, peerID string) error {
+	fraction := u.settings.BlockValidation.MerkleSpotCheckFraction
+	if fraction <= 0 || len(block.Subtrees) == 0 || len(subtrees) != len(block.Subtrees) {
+		return nil
+	}
+
+	samples := sampleMerkleSpotCheck(subtrees, fraction)
+
+	txHash, index, err := proveMerkleSamples(block, subtrees, samples)
+	if err == nil {
+		recordMerkleSpotCheck(merkleSpotCheckPass)
+		u.logger.Debugf("[catchup:merkleSpotCheck][%s] %d transactions of peer %s proven against the merkle root", block.Hash().String(), len(samples), peerID)
+
+		return nil
+	}
+
+	recordMerkleSpotCheck(merkleSpotCheckFail)
+	u.recordMaliciousAttempt(peerID, "merkle_spot_check_failed")
+
+	// the subtrees are loaded from the store when the block is fetched again, from another peer
+	for _, subtreeHash := range block.Subtrees {
+		for _, fileType := range []fileformat.FileType{fileformat.FileTypeSubtreeToCheck, fileformat.FileTypeSubtreeData} {
+			if delErr := u.subtreeStore.Del(ctx, subtreeHash[:], fileType); delErr != nil && !errors.Is(delErr, errors.ErrNotFound) {
+				u.logger.Warnf("[catchup:merkleSpotCheck][%s] failed to remove %s of subtree %s: %v", block.Hash().String(), fileType, subtreeHash.String(), delErr)
+			}
+		}
+	}
+
+	if txHash == nil {
+		return errors.NewNetworkPeerMaliciousError("[catchup:merkleSpotCheck][%s] block served by peer %s can not be proven against its merkle root", block.Hash().String(), peerID, err)
+	}
+
+	return errors.NewNetworkPeerMaliciousError("[catchup:merkleSpotCheck][%s] transaction %s at index %d of subtree %s served by peer %s does not prove against the merkle root",
+		block.Hash().String(), txHash.String(), index.index, block.Subtrees[index.subtree].String(), peerID, err)
+}
+
+// sampleMerkleSpotCheck returns a random share of the transactions of the subtrees, at least one
+func sampleMerkleSpotCheck(subtrees []*subtreepkg.Subtree, fraction float64) []merkleSample {
+	total := 0
+	for _, subtree := range subtrees {
+		total += len(subtree.Nodes)
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	count := min(max(int(math.Ceil(float64(total)*fraction)), 1), total)
+
+	picked := make(map[int]struct{}, count)
+	for len(picked) < count {
+		picked[rand.Intn(total)] = struct{}{} //nolint:gosec // sampling does not need a secure source
+	}
+
+	samples := make([]merkleSample, 0, count)
+
+	for position := range picked {
+		subtree := 0
+		for position >= len(subtrees[subtree].Nodes) {
+			position -= len(subtrees[subtree].Nodes)
+			subtree++
+		}
+
+		samples = append(samples, merkleSample{subtree: subtree, index: position})
+	}
+
+	return samples
+}
+
+// proveMerkleSamples builds the merkle proof of every sampled transaction, from the subtree nodes served by the peer
+// and the subtree hashes of the block, and checks the proof against the merkle root of the block header. The coinbase
+// placeholder of the first subtree is replaced by the coinbase of the block. Returns the hash and position of the
+// first transaction that can not be proven, or an error without a hash when the block itself can not be proven.
+func proveMerkleSamples(block *model.Block, subtrees []*subtreepkg.Subtree, samples []merkleSample) (*chainhash.Hash, merkleSample, error) {
+	if block.CoinbaseTx == nil || block.Header == nil || block.Header.HashMerkleRoot == nil {
+		return nil, merkleSample{}, errors.NewProcessingError("block has no coinbase or merkle root")
+	}
+
+	bySubtree := make(map[int][]int)
+	for _, sample := range samples {
+		bySubtree[sample.subtree] = append(bySubtree[sample.subtree], sample.index)
+	}
+
+	// the first subtree is always hashed, its root with the coinbase is a leaf of the proofs of all subtrees
+	if _, ok := bySubtree[0]; !ok {
+		bySubtree[0] = nil
+	}
+
+	topLeaves := make([]chainhash.Hash, len(block.Subtrees))
+	for i, subtreeHash := range block.Subtrees {
+		topLeaves[i] = *subtreeHash
+	}
+
+	type subtreeProofs struct {
+		leaves   []chainhash.Hash
+		branches map[int][]chainhash.Hash
+		root     chainhash.Hash
+	}
+
+	proofs := make(map[int]subtreeProofs, len(bySubtree))
+
+	for subtreeIndex, indices := range bySubtree {
+		leaves := make([]chainhash.Hash, len(subtrees[subtreeIndex].Nodes))
+		for i, node := range subtrees[subtreeIndex].Nodes {
+			leaves[i] = node.Hash
+		}
+
+		if subtreeIndex == 0 && len(leaves) > 0 && leaves[0].Equal(subtreepkg.CoinbasePlaceholderHashValue) {
+			leaves[0] = *block.CoinbaseTx.TxIDChainHash()
+		}
+
+		if len(leaves) == 0 {
+			return nil, merkleSample{}, errors.NewProcessingError("subtree %d has no nodes", subtreeIndex)
+		}
+
+		root, branches := merkleBranches(leaves, indices)
+		proofs[subtreeIndex] = subtreeProofs{leaves: leaves, branches: branches, root: root}
+	}
+
+	topLeaves[0] = proofs[0].root
+
+	topIndices := make([]int, 0, len(bySubtree))
+	for subtreeIndex := range bySubtree {
+		topIndices = append(topIndices, subtreeIndex)
+	}
+
+	merkleRoot, topBranches := merkleBranches(topLeaves, topIndices)
+	if !merkleRoot.Equal(*block.Header.HashMerkleRoot) {
+		// with the first subtree replaced by its root with the coinbase, the subtrees of the block must add up to
+		// the merkle root, whatever transactions are sampled
+		return nil, merkleSample{}, errors.NewProcessingError("subtrees and coinbase of the block do not add up to merkle root %s", block.Header.HashMerkleRoot.String())
+	}
+
+	for _, sample := range samples {
+		subtree := proofs[sample.subtree]
+		txHash := subtree.leaves[sample.index]
+
+		subtreeRoot := merkleRootFromBranch(txHash, sample.index, subtree.branches[sample.index])
+		if root := merkleRootFromBranch(subtreeRoot, sample.subtree, topBranches[sample.subtree]); !root.Equal(*block.Header.HashMerkleRoot) {
+			return &txHash, sample, errors.NewProcessingError("merkle proof results in %s instead of %s", root.String(), block.Header.HashMerkleRoot.String())
+		}
+	}
+
+	return nil, merkleSample{}, nil
+}
+
+// merkleBranches returns the merkle root of the leaves and the merkle branch, the sibling hashes from the leaf to the
+// root, of each of the indices. A node without a sibling is hashed with itself, as in Bitcoin merkle trees.
+func merkleBranches(leaves []chainhash.Hash, indices []int) (chainhash.Hash, map[int][]chainhash.Hash) {
+	branches := make(map[int][]chainhash.Hash, len(indices))
+	positions := make(map[int]int, len(indices))
+
+	for _, index := range indices {
+		branches[index] = nil
+		positions[index] = index
+	}
+
+	level := leaves
+
+	for len(level) > 1 {
+		for index, position := range positions {
+			sibling := position ^ 1
+			if sibling >= len(level) {
+				sibling = position
+			}
+
+			branches[index] = append(branches[index], level[sibling])
+			positions[index] = position / 2
+		}
+
+		next := make([]chainhash.Hash, (len(level)+1)/2)
+		for i := range next {
+			left := level[2*i]
+
+			right := left
+			if 2*i+1 < len(level) {
+				right = level[2*i+1]
+			}
+
+			next[i] = hashMerklePair(left, right)
+		}
+
+		level = next
+	}
+
+	return level[0], branches
+}
+
+// merkleRootFromBranch returns the merkle root the branch of the leaf at index results in
+func merkleRootFromBranch(leaf chainhash.Hash, index int, branch []chainhash.Hash) chainhash.Hash {
+	hash := leaf
+
+	for _, sibling := range branch {
+		if index%2 == 0 {
+			hash = hashMerklePair(hash, sibling)
+		} else {
+			hash = hashMerklePair(sibling, hash)
+		}
+
+		index /= 2
+	}
+
+	return hash
+}
+
+func hashMerklePair(left, right chainhash.Hash) chainhash.Hash {
+	var pair [2 * chainhash.HashSize]byte
+
+	copy(pair[:chainhash.HashSize], left[:])
+	copy(pair[chainhash.HashSize:], right[:])
+
+	return chainhash.DoubleHashH(pair[:])
+}
+
+// recordMerkleSpotCheck counts the result of a merkle spot-checked block
+func recordMerkleSpotCheck(result string) {
+	if prometheusCatchupMerkleSpotChecks != nil {
+		prometheusCatchupMerkleSpotChecks.WithLabelValues(result).Inc()
+	}
+}
@@ -0,0 +1,234 @@
+package blockvalidation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/pkg/fileformat"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/stores/blob/memory"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// merkleSpotCheckTestBlock builds a block of subtrees with the given number of transactions, the first one including the
+// coinbase placeholder, and the merkle root the block validation computes for it
+func merkleSpotCheckTestBlock(t *testing.T, subtreeSize int, txCounts ...int) (*model.Block, []*subtreepkg.Subtree) {
+	block := &model.Block{Header: &model.BlockHeader{}, CoinbaseTx: coinbaseTx}
+
+	subtrees := make([]*subtreepkg.Subtree, len(txCounts))
+
+	for i, txCount := range txCounts {
+		subtree, err := subtreepkg.NewTreeByLeafCount(subtreeSize)
+		require.NoError(t, err)
+
+		for j := 0; j < txCount; j++ {
+			if i == 0 && j == 0 {
+				require.NoError(t, subtree.AddCoinbaseNode())
+				continue
+			}
+
+			require.NoError(t, subtree.AddNode(chainhash.Hash{byte(i), byte(j), 1}, 1, 1))
+		}
+
+		subtrees[i] = subtree
+		block.Subtrees = append(block.Subtrees, subtree.RootHash())
+	}
+
+	// the merkle root of the block validation, with the coinbase replacing the placeholder
+	roots := make([]chainhash.Hash, len(subtrees))
+	for i, subtree := range subtrees {
+		if i == 0 {
+			root, err := subtree.RootHashWithReplaceRootNode(coinbaseTx.TxIDChainHash(), 0, uint64(coinbaseTx.Size()))
+			require.NoError(t, err)
+
+			roots[i] = *root
+
+			continue
+		}
+
+		roots[i] = *subtree.RootHash()
+	}
+
+	merkleRoot := roots[0]
+
+	if len(roots) > 1 {
+		top, err := subtreepkg.NewIncompleteTreeByLeafCount(len(roots))
+		require.NoError(t, err)
+
+		for _, root := range roots {
+			require.NoError(t, top.AddNode(root, 1, 0))
+		}
+
+		merkleRoot = *top.RootHash()
+	}
+
+	block.Header.HashMerkleRoot = &merkleRoot
+	block.SubtreeSlices = subtrees
+
+	require.NoError(t, block.CheckMerkleRoot(context.Background()))
+
+	return block, subtrees
+}
+
+// allMerkleSamples samples every transaction of the subtrees
+func allMerkleSamples(subtrees []*subtreepkg.Subtree) []merkleSample {
+	return sampleMerkleSpotCheck(subtrees, 1)
+}
+
+func TestProveMerkleSamples(t *testing.T) {
+	t.Run("known block", func(t *testing.T) {
+		subtree, err := subtreepkg.NewTreeByLeafCount(4)
+		require.NoError(t, err)
+		require.NoError(t, subtree.AddCoinbaseNode())
+
+		for _, txID := range txIDs[1:] {
+			hash, err := chainhash.NewHashFromStr(txID)
+			require.NoError(t, err)
+			require.NoError(t, subtree.AddNode(*hash, 1, 1))
+		}
+
+		block := &model.Block{
+			Header:     &model.BlockHeader{HashMerkleRoot: merkleRoot},
+			CoinbaseTx: coinbaseTx,
+			Subtrees:   []*chainhash.Hash{subtree.RootHash()},
+		}
+
+		subtrees := []*subtreepkg.Subtree{subtree}
+
+		txHash, _, err := proveMerkleSamples(block, subtrees, allMerkleSamples(subtrees))
+		require.NoError(t, err)
+		assert.Nil(t, txHash)
+	})
+
+	for name, txCounts := range map[string][]int{
+		"single transaction":          {1},
+		"single subtree":              {4},
+		"odd transaction count":       {3},
+		"multiple subtrees":           {4, 4, 4, 4},
+		"odd subtree count":           {4, 4, 3},
+		"incomplete last subtree":     {4, 4, 1},
+		"incomplete second subtree":   {4, 2},
+		"large odd subtree and count": {8, 8, 8, 8, 5},
+	} {
+		t.Run(name, func(t *testing.T) {
+			block, subtrees := merkleSpotCheckTestBlock(t, 8, txCounts...)
+
+			txHash, _, err := proveMerkleSamples(block, subtrees, allMerkleSamples(subtrees))
+			require.NoError(t, err)
+			assert.Nil(t, txHash)
+		})
+	}
+
+	t.Run("corrupted transaction", func(t *testing.T) {
+		block, subtrees := merkleSpotCheckTestBlock(t, 4, 4, 4, 4)
+
+		// the subtree hash of the block is kept, the node served by the peer is changed
+		subtrees[1].Nodes[2].Hash = chainhash.Hash{9}
+
+		txHash, sample, err := proveMerkleSamples(block, subtrees, []merkleSample{{subtree: 0, index: 1}, {subtree: 1, index: 2}})
+		require.Error(t, err)
+		require.NotNil(t, txHash)
+		assert.Equal(t, chainhash.Hash{9}, *txHash)
+		assert.Equal(t, merkleSample{subtree: 1, index: 2}, sample)
+	})
+
+	t.Run("truncated subtree", func(t *testing.T) {
+		block, subtrees := merkleSpotCheckTestBlock(t, 4, 4, 4)
+
+		subtrees[1].Nodes = subtrees[1].Nodes[:3]
+
+		txHash, _, err := proveMerkleSamples(block, subtrees, []merkleSample{{subtree: 1, index: 0}})
+		require.Error(t, err)
+		assert.NotNil(t, txHash)
+	})
+
+	t.Run("coinbase does not match the merkle root", func(t *testing.T) {
+		block, subtrees := merkleSpotCheckTestBlock(t, 4, 4, 4)
+
+		block.CoinbaseTx = block.CoinbaseTx.Clone()
+		block.CoinbaseTx.LockTime++
+
+		txHash, _, err := proveMerkleSamples(block, subtrees, []merkleSample{{subtree: 1, index: 0}})
+		require.Error(t, err)
+		assert.Nil(t, txHash)
+	})
+}
+
+func TestSampleMerkleSpotCheck(t *testing.T) {
+	_, subtrees := merkleSpotCheckTestBlock(t, 8, 8, 8, 3)
+
+	seen := make(map[merkleSample]struct{})
+
+	for _, sample := range sampleMerkleSpotCheck(subtrees, 0.5) {
+		require.Less(t, sample.index, len(subtrees[sample.subtree].Nodes))
+		seen[sample] = struct{}{}
+	}
+
+	assert.Len(t, seen, 10, "half of the 19 transactions, rounded up, without duplicates")
+	assert.Len(t, sampleMerkleSpotCheck(subtrees, 0.0001), 1, "at least one transaction")
+	assert.Len(t, sampleMerkleSpotCheck(subtrees, 1), 19)
+}
+
+func TestMerkleSpotCheckBlock(t *testing.T) {
+	peerID := "12D3KooWKd2kacFFXWtbYtkDAsTP8fhEX1TbunV9Afimr7m1E8Yg"
+
+	newServer := func(fraction float64) (*Server, *spotCheckP2PClient) {
+		tSettings := &settings.Settings{}
+		tSettings.BlockValidation.MerkleSpotCheckFraction = fraction
+
+		client := &spotCheckP2PClient{}
+
+		return &Server{logger: ulogger.TestLogger{}, settings: tSettings, p2pClient: client, subtreeStore: memory.New()}, client
+	}
+
+	t.Run("valid block passes", func(t *testing.T) {
+		block, subtrees := merkleSpotCheckTestBlock(t, 4, 4, 4, 2)
+		u, client := newServer(0.5)
+
+		require.NoError(t, u.merkleSpotCheckBlock(context.Background(), block, subtrees, peerID))
+		assert.Empty(t, client.maliciousPeers)
+	})
+
+	t.Run("corrupted subtree flags the peer and removes the subtrees", func(t *testing.T) {
+		block, subtrees := merkleSpotCheckTestBlock(t, 4, 4, 4)
+		u, client := newServer(1)
+
+		for _, subtreeHash := range block.Subtrees {
+			require.NoError(t, u.subtreeStore.Set(context.Background(), subtreeHash[:], fileformat.FileTypeSubtreeToCheck, []byte{1}))
+			require.NoError(t, u.subtreeStore.Set(context.Background(), subtreeHash[:], fileformat.FileTypeSubtreeData, []byte{1}))
+		}
+
+		subtrees[1].Nodes[3].Hash = chainhash.Hash{9}
+
+		err := u.merkleSpotCheckBlock(context.Background(), block, subtrees, peerID)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrNetworkPeerMalicious))
+		assert.Equal(t, []string{peerID}, client.maliciousPeers)
+
+		for _, subtreeHash := range block.Subtrees {
+			exists, err := u.subtreeStore.Exists(context.Background(), subtreeHash[:], fileformat.FileTypeSubtreeToCheck)
+			require.NoError(t, err)
+			assert.False(t, exists)
+
+			exists, err = u.subtreeStore.Exists(context.Background(), subtreeHash[:], fileformat.FileTypeSubtreeData)
+			require.NoError(t, err)
+			assert.False(t, exists)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		block, subtrees := merkleSpotCheckTestBlock(t, 4, 4, 4)
+		u, client := newServer(0)
+
+		subtrees[1].Nodes[3].Hash = chainhash.Hash{9}
+
+		require.NoError(t, u.merkleSpotCheckBlock(context.Background(), block, subtrees, peerID))
+		assert.Empty(t, client.maliciousPeers)
+	})
+}
@@ -2,11 +2,13 @@ package blockvalidation
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 
 	"github.com/bsv-blockchain/go-bt/v2/chainhash"
 	"github.com/bsv-blockchain/go-chaincfg"
 	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/pkg/fileformat"
 	"github.com/bsv-blockchain/teranode/services/blockvalidation/testhelpers"
 	"github.com/stretchr/testify/assert"
@@ -148,6 +150,86 @@ func TestTryQuickValidation(t *testing.T) {
 	})
 }
 
+// TestValidateBlocksOnChannel_BlockRanges tests that checkpointed blocks without transactions besides the coinbase
+// are stored in contiguous ranges
+func TestValidateBlocksOnChannel_BlockRanges(t *testing.T) {
+	validate := func(t *testing.T, suite *CatchupTestSuite, blocks []*model.Block) error {
+		catchupCtx := &CatchupContext{
+			useQuickValidation:      true,
+			highestCheckpointHeight: 200,
+			blockUpTo:               blocks[len(blocks)-1],
+			baseURL:                 "http://test",
+		}
+
+		validateBlocksChan := make(chan *model.Block, len(blocks))
+		for _, block := range blocks {
+			validateBlocksChan <- block
+		}
+
+		close(validateBlocksChan)
+
+		var size atomic.Int64
+		size.Store(int64(len(blocks)))
+
+		err := suite.Server.validateBlocksOnChannel(validateBlocksChan, context.Background(), catchupCtx, &size)
+		assert.Equal(t, int64(0), size.Load(), "all blocks should be counted as validated")
+
+		return err
+	}
+
+	t.Run("should store contiguous checkpointed blocks with a single StoreBlocks call", func(t *testing.T) {
+		suite := NewCatchupTestSuite(t)
+		defer suite.Cleanup()
+
+		blocks := testhelpers.CreateTestBlocks(t, 5)
+
+		suite.MockBlockchain.On("StoreBlocks", mock.Anything, blocks, "http://test", mock.Anything).Return(nil).Once()
+
+		require.NoError(t, validate(t, suite, blocks))
+
+		suite.MockBlockchain.AssertNumberOfCalls(t, "StoreBlocks", 1)
+		suite.MockBlockchain.AssertNotCalled(t, "AddBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		for _, block := range blocks {
+			exists, err := suite.Server.blockValidation.GetBlockExists(context.Background(), block.Hash())
+			require.NoError(t, err)
+			assert.True(t, exists)
+		}
+	})
+
+	t.Run("should limit ranges to the blocks block assembly may run behind", func(t *testing.T) {
+		suite := NewCatchupTestSuite(t)
+		defer suite.Cleanup()
+
+		suite.Server.settings.BlockValidation.MaxBlocksBehindBlockAssembly = 2
+
+		blocks := testhelpers.CreateTestBlocks(t, 5)
+
+		suite.MockBlockchain.On("StoreBlocks", mock.Anything, blocks[0:2], "http://test", mock.Anything).Return(nil).Once()
+		suite.MockBlockchain.On("StoreBlocks", mock.Anything, blocks[2:4], "http://test", mock.Anything).Return(nil).Once()
+		suite.MockBlockchain.On("StoreBlocks", mock.Anything, blocks[4:5], "http://test", mock.Anything).Return(nil).Once()
+
+		require.NoError(t, validate(t, suite, blocks))
+
+		suite.MockBlockchain.AssertNumberOfCalls(t, "StoreBlocks", 3)
+	})
+
+	t.Run("should validate the blocks one by one when the range can not be stored", func(t *testing.T) {
+		suite := NewCatchupTestSuite(t)
+		defer suite.Cleanup()
+
+		blocks := testhelpers.CreateTestBlocks(t, 3)
+
+		suite.MockBlockchain.On("StoreBlocks", mock.Anything, blocks, "http://test", mock.Anything).Return(errors.NewStorageError("store failed")).Once()
+		suite.MockBlockchain.On("GetNextBlockID", mock.Anything).Return(uint64(1), nil).Times(3)
+		suite.MockBlockchain.On("AddBlock", mock.Anything, mock.Anything, "http://test", mock.Anything).Return(nil).Times(3)
+
+		require.NoError(t, validate(t, suite, blocks))
+
+		suite.MockBlockchain.AssertNumberOfCalls(t, "AddBlock", 3)
+	})
+}
+
 // TestValidateBlocksOnChannel_ErrorHandling tests the error handling changes in validateBlocksOnChannel
 func TestValidateBlocksOnChannel_ErrorHandling(t *testing.T) {
 	// This test verifies that only consensus violations (ErrBlockInvalid, ErrTxInvalid)
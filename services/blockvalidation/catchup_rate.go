@@ -0,0 +1,104 @@
+package blockvalidation
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// catchupRateWindow is the window the catchup rates and the ETA are computed over, so they follow the recent
+	// progress rather than the average since the start of the catchup
+	catchupRateWindow = time.Minute
+
+	// catchupRateSampleInterval is the minimum time between two samples of the catchup progress
+	catchupRateSampleInterval = time.Second
+)
+
+// catchupProgressSample is the progress of a catchup at a point in time
+type catchupProgressSample struct {
+	at     time.Time
+	blocks int64  // blocks validated
+	bytes  uint64 // bytes downloaded
+}
+
+// catchupRateTracker keeps samples of the progress of the active catchup over the rate window, from which the
+// blocks and bytes per second are computed. It is safe for concurrent use.
+type catchupRateTracker struct {
+	mu      sync.Mutex
+	samples []catchupProgressSample // oldest first
+}
+
+// reset starts tracking a new catchup, which has not made any progress at start
+func (r *catchupRateTracker) reset(start time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples = append(r.samples[:0], catchupProgressSample{at: start})
+}
+
+// record adds a sample of the progress, at most one per sample interval. Samples that fell out of the window are
+// dropped, except for the newest of them, so the rates always cover the whole window.
+func (r *catchupRateTracker) record(now time.Time, blocks int64, bytes uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if n := len(r.samples); n > 0 && now.Sub(r.samples[n-1].at) < catchupRateSampleInterval {
+		return
+	}
+
+	r.samples = append(r.samples, catchupProgressSample{at: now, blocks: blocks, bytes: bytes})
+
+	drop := 0
+	for drop+1 < len(r.samples) && now.Sub(r.samples[drop+1].at) >= catchupRateWindow {
+		drop++
+	}
+
+	if drop > 0 {
+		r.samples = append(r.samples[:0], r.samples[drop:]...)
+	}
+}
+
+// rates returns the blocks and bytes per second since the oldest sample in the window, given the progress now.
+// Both are 0 before any time has passed.
+func (r *catchupRateTracker) rates(now time.Time, blocks int64, bytes uint64) (blocksPerSecond float64, bytesPerSecond float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.samples) == 0 {
+		return 0, 0
+	}
+
+	oldest := r.samples[0]
+
+	elapsed := now.Sub(oldest.at).Seconds()
+	if elapsed <= 0 {
+		return 0, 0
+	}
+
+	if blocks > oldest.blocks {
+		blocksPerSecond = float64(blocks-oldest.blocks) / elapsed
+	}
+
+	if bytes > oldest.bytes {
+		bytesPerSecond = float64(bytes-oldest.bytes) / elapsed
+	}
+
+	return blocksPerSecond, bytesPerSecond
+}
+
+// catchupETA returns the estimated time until the remaining blocks are validated at blocksPerSecond, 0 when no
+// estimate can be made yet
+func catchupETA(remaining int64, blocksPerSecond float64) time.Duration {
+	if remaining <= 0 || blocksPerSecond <= 0 {
+		return 0
+	}
+
+	return time.Duration(float64(remaining) / blocksPerSecond * float64(time.Second))
+}
+
+// recordCatchupBytes adds bytes downloaded from a peer to the progress of the active catchup
+func (u *Server) recordCatchupBytes(n uint64) {
+	if u.isCatchingUp.Load() {
+		u.bytesFetched.Add(n)
+	}
+}
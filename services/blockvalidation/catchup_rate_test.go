@@ -0,0 +1,128 @@
+package blockvalidation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatchupRateTracker(t *testing.T) {
+	start := time.Now()
+
+	var r catchupRateTracker
+
+	blocksPerSecond, bytesPerSecond := r.rates(start, 0, 0)
+	assert.Zero(t, blocksPerSecond)
+	assert.Zero(t, bytesPerSecond)
+
+	r.reset(start)
+
+	blocksPerSecond, bytesPerSecond = r.rates(start, 0, 0)
+	assert.Zero(t, blocksPerSecond)
+	assert.Zero(t, bytesPerSecond)
+
+	// 10 blocks and 1000 bytes per second for the first minute
+	for i := int64(1); i <= 60; i++ {
+		r.record(start.Add(time.Duration(i)*time.Second), i*10, uint64(i*1000))
+	}
+
+	now := start.Add(time.Minute)
+
+	blocksPerSecond, bytesPerSecond = r.rates(now, 600, 60000)
+	assert.InDelta(t, 10, blocksPerSecond, 0.001)
+	assert.InDelta(t, 1000, bytesPerSecond, 0.001)
+
+	// samples closer than the sample interval are ignored
+	r.record(now.Add(time.Second/2), 1000, 60000)
+	assert.Len(t, r.samples, 61)
+
+	// the rates follow the last minute only, after a stall of 30 seconds the rates have halved
+	for i := int64(1); i <= 30; i++ {
+		r.record(now.Add(time.Duration(i)*time.Second), 600, 60000)
+	}
+
+	now = now.Add(30 * time.Second)
+
+	blocksPerSecond, bytesPerSecond = r.rates(now, 600, 60000)
+	assert.InDelta(t, 5, blocksPerSecond, 0.001)
+	assert.InDelta(t, 500, bytesPerSecond, 0.001)
+	assert.Len(t, r.samples, 61)
+
+	// a new catchup starts without progress
+	r.reset(now)
+	assert.Len(t, r.samples, 1)
+
+	blocksPerSecond, _ = r.rates(now.Add(time.Second), 0, 0)
+	assert.Zero(t, blocksPerSecond)
+}
+
+func TestCatchupETA(t *testing.T) {
+	assert.Equal(t, 10*time.Second, catchupETA(100, 10))
+	assert.Equal(t, 1500*time.Millisecond, catchupETA(3, 2))
+	assert.Zero(t, catchupETA(100, 0))
+	assert.Zero(t, catchupETA(0, 10))
+	assert.Zero(t, catchupETA(-1, 10))
+}
+
+func TestGetCatchupStatusInternal_Rates(t *testing.T) {
+	headers := make([]*model.BlockHeader, 100)
+	for i := range headers {
+		headers[i] = &model.BlockHeader{}
+	}
+
+	start := time.Now().Add(-10 * time.Second)
+
+	u := &Server{}
+	u.isCatchingUp.Store(true)
+	u.activeCatchupCtx = &CatchupContext{
+		blockUpTo: &model.Block{
+			Header: &model.BlockHeader{HashPrevBlock: &chainhash.Hash{}, HashMerkleRoot: &chainhash.Hash{}},
+			Height: 200,
+		},
+		blockHeaders: headers,
+		startTime:    start,
+	}
+
+	// the catchup has not downloaded or validated any block yet
+	u.catchupRate.reset(start)
+
+	status := u.getCatchupStatusInternal()
+	assert.Zero(t, status.BlocksPerSecond)
+	assert.Zero(t, status.BytesPerSecond)
+	assert.Zero(t, status.EtaMs)
+
+	// 20 blocks and 2000 bytes over the 10 seconds since the start
+	u.catchupRate.reset(start)
+	u.blocksValidated.Store(20)
+	u.bytesFetched.Store(2000)
+
+	status = u.getCatchupStatusInternal()
+	assert.Equal(t, uint64(2000), status.BytesFetched)
+	assert.InDelta(t, 2, status.BlocksPerSecond, 0.1)
+	assert.InDelta(t, 200, status.BytesPerSecond, 10)
+
+	// 80 blocks remain at about 2 blocks per second
+	require.Positive(t, status.EtaMs)
+	assert.InDelta(t, 40000, status.EtaMs, 2500)
+
+	summary := formatCatchupStatusSummary(status)
+	assert.Contains(t, summary, "blocks/s")
+	assert.Contains(t, summary, "ETA")
+}
+
+func TestRecordCatchupBytes(t *testing.T) {
+	u := &Server{}
+
+	// bytes downloaded outside of a catchup are not counted
+	u.recordCatchupBytes(100)
+	assert.Zero(t, u.bytesFetched.Load())
+
+	u.isCatchingUp.Store(true)
+	u.recordCatchupBytes(100)
+	u.recordCatchupBytes(50)
+	assert.Equal(t, uint64(150), u.bytesFetched.Load())
+}
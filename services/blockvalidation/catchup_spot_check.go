@@ -0,0 +1,206 @@
+// This file contains the spot-check of blocks served by new catchup peers against a trusted peer.
+package blockvalidation
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+)
+
+// spot-check results
+const (
+	spotCheckPass         = "pass"
+	spotCheckMismatch     = "mismatch"
+	spotCheckInconclusive = "inconclusive"
+)
+
+// spotChecker compares a random sample of the blocks a catchup peer with little history serves, and one subtree of
+// each of these blocks, with the data of a trusted peer. A nil spotChecker checks nothing.
+type spotChecker struct {
+	peerID  string
+	trusted PeerForCatchup
+	sample  map[int]struct{} // indices of the catchup blocks to check
+}
+
+// newSpotChecker returns the spot-checker of a catchup, or nil when spot-checks are disabled, the catchup peer has
+// enough history or no trusted peer is available
+func (u *Server) newSpotChecker(ctx context.Context, catchupCtx *CatchupContext) *spotChecker {
+	sampleSize := min(u.settings.BlockValidation.SpotCheckSampleSize, len(catchupCtx.blockHeaders))
+	if sampleSize <= 0 || u.p2pClient == nil || catchupCtx.peerID == "" {
+		return nil
+	}
+
+	blockHash := catchupCtx.blockUpTo.Hash().String()
+
+	peerInfo, err := u.p2pClient.GetPeer(ctx, catchupCtx.peerID)
+	if err != nil || peerInfo == nil {
+		u.logger.Debugf("[catchup:spotCheck][%s] peer %s not found in the peer registry, not spot-checking: %v", blockHash, catchupCtx.peerID, err)
+		return nil
+	}
+
+	if peerInfo.InteractionSuccesses >= u.settings.BlockValidation.SpotCheckNewPeerInteractions {
+		return nil
+	}
+
+	trusted, found := u.selectSpotCheckPeer(ctx, catchupCtx)
+	if !found {
+		u.logger.Warnf("[catchup:spotCheck][%s] no trusted peer with a reputation of at least %.2f available to spot-check new peer %s",
+			blockHash, u.settings.BlockValidation.SpotCheckTrustedMinReputation, catchupCtx.peerID)
+
+		return nil
+	}
+
+	sample := make(map[int]struct{}, sampleSize)
+	for _, index := range rand.Perm(len(catchupCtx.blockHeaders))[:sampleSize] { //nolint:gosec // sampling does not need a secure source
+		sample[index] = struct{}{}
+	}
+
+	u.logger.Infof("[catchup:spotCheck][%s] spot-checking %d blocks of new peer %s (%d successful interactions) against peer %s",
+		blockHash, sampleSize, catchupCtx.peerID, peerInfo.InteractionSuccesses, trusted.ID)
+
+	return &spotChecker{
+		peerID:  catchupCtx.peerID,
+		trusted: trusted,
+		sample:  sample,
+	}
+}
+
+// selectSpotCheckPeer returns the peer with the highest reputation, other than the catchup peer, that has the
+// catchup blocks, has the configured reputation and is not new itself
+func (u *Server) selectSpotCheckPeer(ctx context.Context, catchupCtx *CatchupContext) (PeerForCatchup, bool) {
+	lastHeight := catchupCtx.commonAncestorMeta.Height + uint32(len(catchupCtx.blockHeaders)) //nolint:gosec // header count is bounded
+
+	peers, err := u.selectBestPeersForCatchup(ctx, int32(lastHeight)) //nolint:gosec // block heights fit in an int32
+	if err != nil {
+		u.logger.Warnf("[catchup:spotCheck][%s] failed to get peers to spot-check blocks: %v", catchupCtx.blockUpTo.Hash().String(), err)
+		return PeerForCatchup{}, false
+	}
+
+	for _, p := range peers {
+		if p.ID == catchupCtx.peerID || p.DataHubURL == catchupCtx.baseURL {
+			continue
+		}
+
+		if p.CatchupReputationScore < u.settings.BlockValidation.SpotCheckTrustedMinReputation ||
+			p.CatchupSuccesses < u.settings.BlockValidation.SpotCheckNewPeerInteractions {
+			continue
+		}
+
+		return p, true
+	}
+
+	return PeerForCatchup{}, false
+}
+
+// sampled returns whether the catchup block at index is spot-checked
+func (c *spotChecker) sampled(index int) bool {
+	if c == nil {
+		return false
+	}
+
+	_, ok := c.sample[index]
+
+	return ok
+}
+
+// spotCheckBlock compares a block, and a random subtree of it, served by the catchup peer with the data of the
+// trusted peer. A mismatch reports the catchup peer as malicious and fails, so the catchup is retried with another
+// peer. When the data matches, the block and subtree are reported as valid, which raises the reputation of the new
+// peer without waiting for it to build up a history. When the trusted peer cannot provide the data, the check is
+// skipped.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - checker: Spot-checker of the catchup
+//   - block: Block served by the catchup peer
+//   - baseURL: URL of the catchup peer
+//
+// Returns:
+//   - error: If the data of the catchup peer does not match the data of the trusted peer
+func (u *Server) spotCheckBlock(ctx context.Context, checker *spotChecker, block *model.Block, baseURL string) error {
+	blockHash := block.Hash()
+
+	trustedBlock, err := u.fetchSingleBlock(ctx, blockHash, checker.trusted.ID, checker.trusted.DataHubURL)
+	if err != nil {
+		recordSpotCheck(spotCheckInconclusive)
+		u.logger.Infof("[catchup:spotCheck][%s] trusted peer %s could not provide the block, skipping spot-check: %v", blockHash.String(), checker.trusted.ID, err)
+
+		return nil
+	}
+
+	matches, err := sameBlockData(block, trustedBlock)
+	if err != nil {
+		return errors.NewProcessingError("[catchup:spotCheck][%s] failed to serialize block", blockHash.String(), err)
+	}
+
+	if !matches {
+		return u.spotCheckMismatch(checker, blockHash.String(), "block")
+	}
+
+	if len(block.Subtrees) > 0 {
+		subtreeHash := block.Subtrees[rand.Intn(len(block.Subtrees))] //nolint:gosec // sampling does not need a secure source
+
+		trustedSubtree, err := u.fetchSubtreeFromPeer(ctx, subtreeHash, checker.trusted.ID, checker.trusted.DataHubURL)
+		if err != nil {
+			recordSpotCheck(spotCheckInconclusive)
+			u.logger.Infof("[catchup:spotCheck][%s] trusted peer %s could not provide subtree %s, skipping spot-check: %v", blockHash.String(), checker.trusted.ID, subtreeHash.String(), err)
+
+			return nil
+		}
+
+		peerSubtree, err := u.fetchSubtreeFromPeer(ctx, subtreeHash, checker.peerID, baseURL)
+		if err != nil {
+			return errors.NewServiceError("[catchup:spotCheck][%s] failed to fetch subtree %s from peer %s", blockHash.String(), subtreeHash.String(), checker.peerID, err)
+		}
+
+		if !bytes.Equal(peerSubtree, trustedSubtree) {
+			return u.spotCheckMismatch(checker, blockHash.String(), "subtree "+subtreeHash.String())
+		}
+
+		if err = u.p2pClient.ReportValidSubtree(ctx, checker.peerID, subtreeHash.String()); err != nil {
+			u.logger.Warnf("[catchup:spotCheck][%s] failed to report valid subtree %s of peer %s: %v", blockHash.String(), subtreeHash.String(), checker.peerID, err)
+		}
+	}
+
+	if err = u.p2pClient.ReportValidBlock(ctx, checker.peerID, blockHash.String()); err != nil {
+		u.logger.Warnf("[catchup:spotCheck][%s] failed to report valid block of peer %s: %v", blockHash.String(), checker.peerID, err)
+	}
+
+	recordSpotCheck(spotCheckPass)
+	u.logger.Debugf("[catchup:spotCheck][%s] block of peer %s matches trusted peer %s", blockHash.String(), checker.peerID, checker.trusted.ID)
+
+	return nil
+}
+
+// spotCheckMismatch reports the catchup peer as malicious and returns the error failing the catchup
+func (u *Server) spotCheckMismatch(checker *spotChecker, blockHash string, what string) error {
+	recordSpotCheck(spotCheckMismatch)
+	u.recordMaliciousAttempt(checker.peerID, "spot_check_mismatch")
+
+	return errors.NewNetworkPeerMaliciousError("[catchup:spotCheck][%s] %s served by peer %s does not match trusted peer %s", blockHash, what, checker.peerID, checker.trusted.ID)
+}
+
+// recordSpotCheck counts the result of a spot-checked block
+func recordSpotCheck(result string) {
+	if prometheusCatchupSpotChecks != nil {
+		prometheusCatchupSpotChecks.WithLabelValues(result).Inc()
+	}
+}
+
+// sameBlockData returns whether two blocks have the same serialized data
+func sameBlockData(a, b *model.Block) (bool, error) {
+	aBytes, err := a.Bytes()
+	if err != nil {
+		return false, err
+	}
+
+	bBytes, err := b.Bytes()
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(aBytes, bBytes), nil
+}
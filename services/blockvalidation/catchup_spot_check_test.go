@@ -0,0 +1,218 @@
+package blockvalidation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockvalidation/testhelpers"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/jarcoal/httpmock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spotCheckP2PClient serves a fixed peer list and records the valid and malicious reports
+type spotCheckP2PClient struct {
+	P2PClientI
+	peers          []*p2p.PeerInfo
+	validBlocks    []string
+	validSubtrees  []string
+	maliciousPeers []string
+}
+
+func (c *spotCheckP2PClient) GetPeer(_ context.Context, peerID string) (*p2p.PeerInfo, error) {
+	for _, p := range c.peers {
+		if p.ID.String() == peerID {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *spotCheckP2PClient) GetPeersForCatchup(_ context.Context) ([]*p2p.PeerInfo, error) {
+	return c.peers, nil
+}
+
+func (c *spotCheckP2PClient) ReportValidBlock(_ context.Context, _ string, blockHash string) error {
+	c.validBlocks = append(c.validBlocks, blockHash)
+	return nil
+}
+
+func (c *spotCheckP2PClient) ReportValidSubtree(_ context.Context, _ string, subtreeHash string) error {
+	c.validSubtrees = append(c.validSubtrees, subtreeHash)
+	return nil
+}
+
+func (c *spotCheckP2PClient) RecordCatchupMalicious(_ context.Context, peerID string) error {
+	c.maliciousPeers = append(c.maliciousPeers, peerID)
+	return nil
+}
+
+func (c *spotCheckP2PClient) RecordDataDownloaded(_ context.Context, _ string, _ string, _ uint64, _ uint64) error {
+	return nil
+}
+
+func TestNewSpotChecker(t *testing.T) {
+	newPeer, err := peer.Decode("12D3KooWKd2kacFFXWtbYtkDAsTP8fhEX1TbunV9Afimr7m1E8Yg")
+	require.NoError(t, err)
+
+	trustedPeer, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	headers := testhelpers.CreateTestHeaders(t, 20)
+
+	catchupCtx := &CatchupContext{
+		blockUpTo:          &model.Block{Header: headers[19]},
+		baseURL:            "http://new",
+		peerID:             newPeer.String(),
+		commonAncestorMeta: &model.BlockHeaderMeta{Height: 100},
+		blockHeaders:       headers,
+	}
+
+	newServer := func(sampleSize int, peers ...*p2p.PeerInfo) *Server {
+		tSettings := &settings.Settings{}
+		tSettings.BlockValidation.SpotCheckSampleSize = sampleSize
+		tSettings.BlockValidation.SpotCheckNewPeerInteractions = 10
+		tSettings.BlockValidation.SpotCheckTrustedMinReputation = 80
+
+		return &Server{logger: ulogger.TestLogger{}, settings: tSettings, p2pClient: &spotCheckP2PClient{peers: peers}}
+	}
+
+	fresh := &p2p.PeerInfo{ID: newPeer, DataHubURL: "http://new", Height: 120, InteractionSuccesses: 2, ReputationScore: 50}
+	established := &p2p.PeerInfo{ID: newPeer, DataHubURL: "http://new", Height: 120, InteractionSuccesses: 50, ReputationScore: 50}
+	trusted := &p2p.PeerInfo{ID: trustedPeer, DataHubURL: "http://trusted", Height: 120, InteractionSuccesses: 100, ReputationScore: 90}
+	newTrusted := &p2p.PeerInfo{ID: trustedPeer, DataHubURL: "http://trusted", Height: 120, InteractionSuccesses: 1, ReputationScore: 90}
+
+	t.Run("new peer is sampled", func(t *testing.T) {
+		checker := newServer(5, fresh, trusted).newSpotChecker(context.Background(), catchupCtx)
+		require.NotNil(t, checker)
+
+		assert.Equal(t, trustedPeer.String(), checker.trusted.ID)
+		assert.Len(t, checker.sample, 5)
+
+		sampled := 0
+
+		for i := range headers {
+			if checker.sampled(i) {
+				sampled++
+			}
+		}
+
+		assert.Equal(t, 5, sampled)
+	})
+
+	t.Run("sample is limited to the catchup blocks", func(t *testing.T) {
+		checker := newServer(50, fresh, trusted).newSpotChecker(context.Background(), catchupCtx)
+		require.NotNil(t, checker)
+		assert.Len(t, checker.sample, len(headers))
+	})
+
+	t.Run("not checked", func(t *testing.T) {
+		assert.Nil(t, newServer(0, fresh, trusted).newSpotChecker(context.Background(), catchupCtx), "disabled")
+		assert.Nil(t, newServer(5, established, trusted).newSpotChecker(context.Background(), catchupCtx), "peer with history")
+		assert.Nil(t, newServer(5, fresh, newTrusted).newSpotChecker(context.Background(), catchupCtx), "no trusted peer")
+		assert.Nil(t, newServer(5, trusted).newSpotChecker(context.Background(), catchupCtx), "unknown peer")
+	})
+
+	t.Run("nil checker samples nothing", func(t *testing.T) {
+		var checker *spotChecker
+		assert.False(t, checker.sampled(0))
+	})
+}
+
+func TestSpotCheckBlock(t *testing.T) {
+	blocks := testhelpers.CreateTestBlocks(t, 2)
+	subtreeHash := chainhash.Hash{7}
+
+	block := blocks[0]
+	block.Subtrees = []*chainhash.Hash{&subtreeHash}
+
+	// same header, other coinbase
+	forged := &model.Block{Header: block.Header, CoinbaseTx: blocks[1].CoinbaseTx, Subtrees: block.Subtrees, Height: block.Height}
+
+	blockBytes, err := block.Bytes()
+	require.NoError(t, err)
+
+	subtreeBytes := subtreeHash.CloneBytes()
+
+	newPeer, trustedPeer := "12D3KooWKd2kacFFXWtbYtkDAsTP8fhEX1TbunV9Afimr7m1E8Yg", "12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ"
+
+	newServer := func() (*Server, *spotCheckP2PClient, *spotChecker) {
+		client := &spotCheckP2PClient{}
+		checker := &spotChecker{
+			peerID:  newPeer,
+			trusted: PeerForCatchup{ID: trustedPeer, DataHubURL: "http://trusted"},
+			sample:  map[int]struct{}{0: {}},
+		}
+
+		return &Server{logger: ulogger.TestLogger{}, settings: &settings.Settings{}, p2pClient: client}, client, checker
+	}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	respond := func(url string, body []byte) {
+		httpmock.RegisterResponder("GET", url, httpmock.NewBytesResponder(http.StatusOK, body))
+	}
+
+	t.Run("matching data is reported as valid", func(t *testing.T) {
+		httpmock.Reset()
+		respond("http://trusted/block/"+block.Hash().String(), blockBytes)
+		respond("http://trusted/subtree/"+subtreeHash.String(), subtreeBytes)
+		respond("http://new/subtree/"+subtreeHash.String(), subtreeBytes)
+
+		u, client, checker := newServer()
+
+		require.NoError(t, u.spotCheckBlock(context.Background(), checker, block, "http://new"))
+		assert.Equal(t, []string{block.Hash().String()}, client.validBlocks)
+		assert.Equal(t, []string{subtreeHash.String()}, client.validSubtrees)
+		assert.Empty(t, client.maliciousPeers)
+	})
+
+	t.Run("forged block flags the peer", func(t *testing.T) {
+		httpmock.Reset()
+		respond("http://trusted/block/"+block.Hash().String(), blockBytes)
+
+		u, client, checker := newServer()
+
+		err := u.spotCheckBlock(context.Background(), checker, forged, "http://new")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrNetworkPeerMalicious))
+		assert.Equal(t, []string{newPeer}, client.maliciousPeers)
+		assert.Empty(t, client.validBlocks)
+	})
+
+	t.Run("forged subtree flags the peer", func(t *testing.T) {
+		httpmock.Reset()
+		respond("http://trusted/block/"+block.Hash().String(), blockBytes)
+		respond("http://trusted/subtree/"+subtreeHash.String(), subtreeBytes)
+		respond("http://new/subtree/"+subtreeHash.String(), (&chainhash.Hash{8}).CloneBytes())
+
+		u, client, checker := newServer()
+
+		err := u.spotCheckBlock(context.Background(), checker, block, "http://new")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrNetworkPeerMalicious))
+		assert.Equal(t, []string{newPeer}, client.maliciousPeers)
+		assert.Empty(t, client.validSubtrees)
+	})
+
+	t.Run("unavailable trusted peer skips the check", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder("GET", "http://trusted/block/"+block.Hash().String(), httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+		u, client, checker := newServer()
+
+		require.NoError(t, u.spotCheckBlock(context.Background(), checker, block, "http://new"))
+		assert.Empty(t, client.validBlocks)
+		assert.Empty(t, client.maliciousPeers)
+	})
+}
@@ -68,6 +68,19 @@ type CatchupStatus struct {
 	// BlocksValidated is the number of blocks validated so far
 	BlocksValidated int64 `json:"blocks_validated,omitempty"`
 
+	// BytesFetched is the number of bytes downloaded from peers so far
+	BytesFetched uint64 `json:"bytes_fetched,omitempty"`
+
+	// BlocksPerSecond is the rate blocks were validated at over the last minute
+	BlocksPerSecond float64 `json:"blocks_per_second,omitempty"`
+
+	// BytesPerSecond is the rate bytes were downloaded at over the last minute
+	BytesPerSecond float64 `json:"bytes_per_second,omitempty"`
+
+	// EtaMs is the estimated time until the remaining blocks are validated at BlocksPerSecond,
+	// 0 until the first blocks have been validated
+	EtaMs int64 `json:"eta_ms,omitempty"`
+
 	// StartTime is when the catchup started (Unix timestamp in milliseconds)
 	StartTime int64 `json:"start_time,omitempty"`
 
@@ -128,10 +141,17 @@ func (u *Server) getCatchupStatusInternal() *CatchupStatus {
 	status.TotalBlocks = len(ctx.blockHeaders)
 	status.BlocksFetched = u.blocksFetched.Load()
 	status.BlocksValidated = u.blocksValidated.Load()
+	status.BytesFetched = u.bytesFetched.Load()
 	status.StartTime = ctx.startTime.UnixMilli()
 	status.DurationMs = time.Since(ctx.startTime).Milliseconds()
 	status.ForkDepth = ctx.forkDepth
 
+	// Sample the progress on every query as well, so the rates drop while the catchup is stalled
+	now := time.Now()
+	u.catchupRate.record(now, status.BlocksValidated, status.BytesFetched)
+	status.BlocksPerSecond, status.BytesPerSecond = u.catchupRate.rates(now, status.BlocksValidated, status.BytesFetched)
+	status.EtaMs = catchupETA(int64(status.TotalBlocks)-status.BlocksValidated, status.BlocksPerSecond).Milliseconds()
+
 	// Add common ancestor info if available
 	if ctx.commonAncestorHash != nil {
 		status.CommonAncestorHash = ctx.commonAncestorHash.String()
@@ -170,6 +190,12 @@ func formatCatchupStatusSummary(status *CatchupStatus) string {
 	if status.TotalBlocks > 0 {
 		summary += " (" + formatProgress(status.BlocksValidated, int64(status.TotalBlocks)) + ")"
 	}
+	if status.BlocksPerSecond > 0 {
+		summary += " at " + formatFloat(status.BlocksPerSecond, 1) + " blocks/s"
+	}
+	if status.EtaMs > 0 {
+		summary += ", ETA " + formatDuration(status.EtaMs)
+	}
 	summary += " [" + formatDuration(status.DurationMs) + "]"
 
 	return summary
@@ -0,0 +1,129 @@
+// This file contains the failover of fetches from the DataHub of a peer to the DataHub mirrors the peer advertises.
+package blockvalidation
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/jellydator/ttlcache/v3"
+)
+
+// dataHubMirrorsTTL is how long the DataHub mirrors of a peer are cached before they are looked up again
+const dataHubMirrorsTTL = time.Minute
+
+// Results of fetches failed over to the DataHub mirrors of a peer
+const (
+	dataHubFailoverRecovered = "recovered" // a mirror served the request
+	dataHubFailoverExhausted = "exhausted" // every mirror failed too
+)
+
+// cacheDataHubMirrorURLs caches the DataHub mirrors of a peer returned by the P2P service
+func (u *Server) cacheDataHubMirrorURLs(peerID string, mirrors []string) {
+	if u.dataHubMirrors != nil && peerID != "" {
+		u.dataHubMirrors.Set(peerID, mirrors, ttlcache.DefaultTTL)
+	}
+}
+
+// dataHubMirrorURLs returns the DataHub mirrors advertised by the peer, looked up in the peer registry of the P2P
+// service when they are not cached. A failed lookup is cached as a peer without mirrors.
+func (u *Server) dataHubMirrorURLs(ctx context.Context, peerID string) []string {
+	if u.p2pClient == nil || u.dataHubMirrors == nil || peerID == "" {
+		return nil
+	}
+
+	if item := u.dataHubMirrors.Get(peerID); item != nil {
+		return item.Value()
+	}
+
+	var mirrors []string
+
+	peerInfo, err := u.p2pClient.GetPeer(ctx, peerID)
+	if err != nil {
+		u.logger.Debugf("[dataHubMirrorURLs] failed to get peer %s, fetching without mirrors: %v", peerID, err)
+	} else if peerInfo != nil {
+		mirrors = peerInfo.DataHubMirrorURLs
+	}
+
+	u.cacheDataHubMirrorURLs(peerID, mirrors)
+
+	return mirrors
+}
+
+// dataHubURLs returns the DataHub URLs a fetch from the peer is tried against in order: the URL the block or subtree
+// was announced with, followed by the mirrors the peer advertises
+func (u *Server) dataHubURLs(ctx context.Context, peerID, baseURL string) []string {
+	mirrors := u.dataHubMirrorURLs(ctx, peerID)
+	urls := make([]string, 0, 1+len(mirrors))
+	urls = append(urls, baseURL)
+
+	for _, mirror := range mirrors {
+		if strings.TrimRight(mirror, "/") != strings.TrimRight(baseURL, "/") {
+			urls = append(urls, mirror)
+		}
+	}
+
+	return urls
+}
+
+// fetchFromDataHub requests the path, e.g. /block/<hash>, from the DataHub of the peer and returns the response body
+func (u *Server) fetchFromDataHub(ctx context.Context, peerID, baseURL, path string) ([]byte, error) {
+	return fetchWithFailover(ctx, u, peerID, baseURL, path, func(ctx context.Context, url string) ([]byte, error) {
+		return util.DoHTTPRequest(ctx, url)
+	})
+}
+
+// fetchReaderFromDataHub requests the path from the DataHub of the peer and returns a reader of the response body
+func (u *Server) fetchReaderFromDataHub(ctx context.Context, peerID, baseURL, path string) (io.ReadCloser, error) {
+	return fetchWithFailover(ctx, u, peerID, baseURL, path, func(ctx context.Context, url string) (io.ReadCloser, error) {
+		return util.DoHTTPRequestBodyReader(ctx, url)
+	})
+}
+
+// fetchWithFailover requests the path from the DataHub URL the data was announced with and, when the request fails,
+// from each DataHub mirror of the peer in turn. The fetch only fails, and counts as a failed interaction with the
+// peer, when every URL fails; the error of the announced URL is returned then.
+func fetchWithFailover[T any](ctx context.Context, u *Server, peerID, baseURL, path string, fetch func(context.Context, string) (T, error)) (T, error) {
+	urls := u.dataHubURLs(ctx, peerID, baseURL)
+
+	var (
+		zero     T
+		firstErr error
+		tried    int
+	)
+
+	for i, dataHubURL := range urls {
+		tried++
+
+		result, err := fetch(ctx, dataHubURL+path)
+		if err == nil {
+			if i > 0 {
+				prometheusDataHubFailovers.WithLabelValues(dataHubFailoverRecovered).Inc()
+				u.logger.Infof("[fetchWithFailover] fetched %s from DataHub mirror %s of peer %s", path, dataHubURL, peerID)
+			}
+
+			return result, nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+
+		// the fetch was cancelled or timed out, the mirrors would fail the same way
+		if ctx.Err() != nil {
+			break
+		}
+
+		if i < len(urls)-1 {
+			u.logger.Warnf("[fetchWithFailover] failed to fetch %s from %s of peer %s, failing over to DataHub mirror %s: %v", path, dataHubURL, peerID, urls[i+1], err)
+		}
+	}
+
+	if tried > 1 {
+		prometheusDataHubFailovers.WithLabelValues(dataHubFailoverExhausted).Inc()
+	}
+
+	return zero, firstErr
+}
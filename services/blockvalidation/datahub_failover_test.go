@@ -0,0 +1,99 @@
+package blockvalidation
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/jarcoal/httpmock"
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchFromDataHub(t *testing.T) {
+	initPrometheusMetrics()
+
+	peerID, err := peer.Decode("12D3KooWKd2kacFFXWtbYtkDAsTP8fhEX1TbunV9Afimr7m1E8Yg")
+	require.NoError(t, err)
+
+	newServer := func(mirrors ...string) *Server {
+		client := &spotCheckP2PClient{peers: []*p2p.PeerInfo{{ID: peerID, DataHubURL: "http://primary", DataHubMirrorURLs: mirrors}}}
+
+		return &Server{
+			logger:         ulogger.TestLogger{},
+			p2pClient:      client,
+			dataHubMirrors: ttlcache.New(ttlcache.WithTTL[string, []string](time.Minute)),
+		}
+	}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	t.Run("mirrors in order after the announced URL", func(t *testing.T) {
+		server := newServer("http://mirror1/", "http://primary/", "http://mirror2")
+
+		urls := server.dataHubURLs(context.Background(), peerID.String(), "http://primary")
+		assert.Equal(t, []string{"http://primary", "http://mirror1/", "http://mirror2"}, urls, "the announced URL is not tried twice")
+
+		assert.Equal(t, []string{"http://other"}, server.dataHubURLs(context.Background(), "", "http://other"), "no mirrors without a peer")
+	})
+
+	t.Run("fails over to a mirror", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder("GET", "http://primary/block/abcd", httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+		httpmock.RegisterResponder("GET", "http://mirror1/block/abcd", httpmock.NewStringResponder(http.StatusNotFound, ""))
+		httpmock.RegisterResponder("GET", "http://mirror2/block/abcd", httpmock.NewBytesResponder(http.StatusOK, []byte("block")))
+
+		server := newServer("http://mirror1", "http://mirror2")
+
+		body, err := server.fetchFromDataHub(context.Background(), peerID.String(), "http://primary", "/block/abcd")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("block"), body)
+
+		reader, err := server.fetchReaderFromDataHub(context.Background(), peerID.String(), "http://primary", "/block/abcd")
+		require.NoError(t, err)
+
+		defer reader.Close()
+
+		body, err = io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("block"), body)
+	})
+
+	t.Run("primary is not failed over", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder("GET", "http://primary/block/abcd", httpmock.NewBytesResponder(http.StatusOK, []byte("block")))
+
+		_, err := newServer("http://mirror1").fetchFromDataHub(context.Background(), peerID.String(), "http://primary", "/block/abcd")
+		require.NoError(t, err)
+		assert.Equal(t, 0, httpmock.GetCallCountInfo()["GET http://mirror1/block/abcd"])
+	})
+
+	t.Run("every URL fails", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder("GET", "http://primary/block/abcd", httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+		httpmock.RegisterResponder("GET", "http://mirror1/block/abcd", httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+
+		_, err := newServer("http://mirror1").fetchFromDataHub(context.Background(), peerID.String(), "http://primary", "/block/abcd")
+		require.Error(t, err)
+		assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET http://mirror1/block/abcd"])
+	})
+
+	t.Run("cancelled fetch is not failed over", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder("GET", "http://mirror1/block/abcd", httpmock.NewBytesResponder(http.StatusOK, []byte("block")))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := newServer("http://mirror1").fetchFromDataHub(ctx, peerID.String(), "http://primary", "/block/abcd")
+		require.Error(t, err)
+		assert.Equal(t, 0, httpmock.GetCallCountInfo()["GET http://mirror1/block/abcd"])
+	})
+}
@@ -8,14 +8,17 @@ import (
 	"fmt"
 	"io"
 	"sync/atomic"
+	"time"
 
 	"github.com/bsv-blockchain/go-bt/v2/chainhash"
 	subtreepkg "github.com/bsv-blockchain/go-subtree"
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/pkg/fileformat"
+	"github.com/bsv-blockchain/teranode/services/blockvalidation/catchup"
+	"github.com/bsv-blockchain/teranode/services/p2p"
 	"github.com/bsv-blockchain/teranode/stores/blob/options"
-	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/bandwidth"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"golang.org/x/sync/errgroup"
 )
@@ -71,7 +74,7 @@ func (u *Server) fetchBlocksConcurrently(ctx context.Context, catchupCtx *Catchu
 
 	// Configuration for high-performance pipeline
 	// All values come from settings with sensible defaults:
-	// - FetchLargeBatchSize (100): Blocks per HTTP request for efficiency
+	// - FetchLargeBatchSize (100): Blocks per HTTP request for efficiency, the upper bound for adaptive batches
 	// - FetchNumWorkers (16): Parallel workers for subtree fetching
 	// - FetchBufferSize (50): Channel buffer size - keeps workers ~100-150 blocks ahead max
 	largeBatchSize := u.settings.BlockValidation.FetchLargeBatchSize
@@ -82,6 +85,9 @@ func (u *Server) fetchBlocksConcurrently(ctx context.Context, catchupCtx *Catchu
 	workQueue := make(chan workItem, bufferSize)
 	resultQueue := make(chan resultItem, bufferSize)
 
+	// Blocks of peers with little history are spot-checked against a trusted peer, when enabled
+	checker := u.newSpotChecker(ctx, catchupCtx)
+
 	// Create local error group for better error handling and cancellation
 	g, gCtx := errgroup.WithContext(ctx)
 
@@ -101,7 +107,7 @@ func (u *Server) fetchBlocksConcurrently(ctx context.Context, catchupCtx *Catchu
 	// Start batch fetching and work distribution
 	g.Go(func() error {
 		defer close(workQueue)
-		return u.batchFetchAndDistribute(gCtx, blockHeaders, workQueue, peerID, baseURL, blockUpTo, largeBatchSize)
+		return u.batchFetchAndDistribute(gCtx, blockHeaders, workQueue, peerID, baseURL, blockUpTo, largeBatchSize, checker)
 	})
 
 	// Wait for all goroutines to complete
@@ -114,16 +120,26 @@ func (u *Server) fetchBlocksConcurrently(ctx context.Context, catchupCtx *Catchu
 }
 
 // batchFetchAndDistribute fetches blocks in large batches and immediately distributes them to workers
-func (u *Server) batchFetchAndDistribute(ctx context.Context, blockHeaders []*model.BlockHeader, workQueue chan<- workItem, peerID string, baseURL string, blockUpTo *model.Block, batchSize int) error {
+func (u *Server) batchFetchAndDistribute(ctx context.Context, blockHeaders []*model.BlockHeader, workQueue chan<- workItem, peerID string, baseURL string, blockUpTo *model.Block, batchSize int,
+	checker *spotChecker) error {
 	ctx, _, deferFn := tracing.Tracer("blockvalidation").Start(ctx, "batchFetchAndDistribute",
 		tracing.WithParentStat(u.stats),
 	)
 	defer deferFn()
 
-	u.logger.Debugf("[catchup:batchFetchAndDistribute][%s] fetching %d blocks in batches of %d", blockUpTo.Hash().String(), len(blockHeaders), batchSize)
+	sizer := u.getPeerBatchSizer(ctx, peerID)
+	if sizer != nil {
+		u.logger.Debugf("[catchup:batchFetchAndDistribute][%s] fetching %d blocks in adaptive batches starting at %d", blockUpTo.Hash().String(), len(blockHeaders), sizer.Size())
+	} else {
+		u.logger.Debugf("[catchup:batchFetchAndDistribute][%s] fetching %d blocks in batches of %d", blockUpTo.Hash().String(), len(blockHeaders), batchSize)
+	}
 
 	currentIndex := 0
-	for i := 0; i < len(blockHeaders); i += batchSize {
+	for i := 0; i < len(blockHeaders); {
+		if sizer != nil {
+			batchSize = sizer.Size()
+		}
+
 		end := i + batchSize
 		if end > len(blockHeaders) {
 			end = len(blockHeaders)
@@ -155,6 +171,17 @@ func (u *Server) batchFetchAndDistribute(ctx context.Context, blockHeaders []*mo
 			}
 		}
 
+		// Compare the sampled blocks with a trusted peer before they are processed
+		for j, block := range blocks {
+			if !checker.sampled(i + j) {
+				continue
+			}
+
+			if err = u.spotCheckBlock(ctx, checker, block, baseURL); err != nil {
+				return err
+			}
+		}
+
 		// Immediately distribute blocks to workers
 		for _, block := range blocks {
 			select {
@@ -167,6 +194,8 @@ func (u *Server) batchFetchAndDistribute(ctx context.Context, blockHeaders []*mo
 				return ctx.Err()
 			}
 		}
+
+		i = end
 	}
 
 	u.logger.Debugf("[catchup:batchFetchAndDistribute][%s] completed distribution of %d blocks", blockUpTo.Hash().String(), currentIndex)
@@ -253,6 +282,9 @@ func (u *Server) orderedDelivery(gCtx context.Context, resultQueue <-chan result
 				return errors.NewProcessingError("[catchup:orderedDelivery][%s] worker failed for block %s", blockUpTo.Hash().String(), result.block.Hash().String(), result.err)
 			}
 
+			// Update fetched counter for progress tracking
+			u.blocksFetched.Add(1)
+
 			// Store result for ordered delivery
 			results[result.index] = result
 
@@ -315,12 +347,17 @@ func (u *Server) fetchSubtreeDataForBlock(gCtx context.Context, block *model.Blo
 	}
 	g.SetLimit(subtreeConcurrency)
 
+	subtrees := make([]*subtreepkg.Subtree, len(block.Subtrees))
+
 	// Process each unique subtree concurrently
-	for _, subtreeHash := range block.Subtrees {
+	for i, subtreeHash := range block.Subtrees {
 		subtreeHashCopy := *subtreeHash // Capture for goroutine
 
 		g.Go(func() error {
-			return u.fetchAndStoreSubtreeAndSubtreeData(ctx, block, &subtreeHashCopy, peerID, baseURL)
+			subtree, err := u.fetchAndStoreSubtreeAndSubtreeData(ctx, block, &subtreeHashCopy, peerID, baseURL)
+			subtrees[i] = subtree
+
+			return err
 		})
 	}
 
@@ -329,6 +366,12 @@ func (u *Server) fetchSubtreeDataForBlock(gCtx context.Context, block *model.Blo
 		return errors.NewServiceError("[catchup:fetchSubtreeDataForBlock] Failed to fetch subtree data for block %s", block.Hash().String(), err)
 	}
 
+	if u.settings.BlockValidation.MerkleSpotCheckFraction > 0 {
+		if err := u.merkleSpotCheckBlock(gCtx, block, subtrees, peerID); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -468,6 +511,11 @@ func (u *Server) fetchAndStoreSubtreeData(ctx context.Context, block *model.Bloc
 		bufferedReader.Reset(nil)
 		bufioReaderPool.Put(bufferedReader)
 	}()
+
+	if u.settings.BlockValidation.FetchStreaming {
+		return u.streamSubtreeData(ctx, subtreeHash, subtree, bufferedReader, peerID, baseURL, dah)
+	}
+
 	subtreeDataBufferedReader := io.NopCloser(bufferedReader)
 
 	// loading the subtree data like this will validate the data as it is read
@@ -507,10 +555,49 @@ func (u *Server) fetchAndStoreSubtreeData(ctx context.Context, block *model.Bloc
 	return nil
 }
 
+// streamSubtreeData stores the subtreeData read from a peer while validating it against the subtree, transaction by
+// transaction, so the transactions of the subtree are never all held in memory. Data that fails validation is
+// removed from the store again.
+func (u *Server) streamSubtreeData(ctx context.Context, subtreeHash *chainhash.Hash, subtree *subtreepkg.Subtree,
+	reader io.Reader, peerID, baseURL string, dah uint32) error {
+	stream := newSubtreeDataStream(subtree, reader)
+
+	setErr := u.subtreeStore.SetFromReader(ctx,
+		subtreeHash[:],
+		fileformat.FileTypeSubtreeData,
+		stream,
+		options.WithAllowOverwrite(true),
+		options.WithDeleteAt(dah),
+	)
+
+	// the validation error of the data takes precedence, as it is the likely cause of a failed store
+	streamErr := stream.err
+	if setErr == nil {
+		streamErr = stream.finish()
+	} else if errors.Is(streamErr, io.EOF) {
+		streamErr = nil
+	}
+
+	if streamErr == nil && setErr == nil {
+		u.logger.Debugf("[catchup:streamSubtreeData] Subtree %s from %s streamed with %d transactions", subtreeHash.String(), baseURL, stream.txCount)
+		return nil
+	}
+
+	if err := u.subtreeStore.Del(ctx, subtreeHash[:], fileformat.FileTypeSubtreeData); err != nil && !errors.Is(err, errors.ErrNotFound) {
+		u.logger.Warnf("[catchup:streamSubtreeData] Failed to remove invalid subtreeData for %s: %v", subtreeHash.String(), err)
+	}
+
+	if streamErr != nil {
+		return errors.NewProcessingError("[catchup:streamSubtreeData] Peer %s (%s) provided invalid subtree data for %s", peerID, baseURL, subtreeHash.String(), streamErr)
+	}
+
+	return errors.NewStorageError("[catchup:streamSubtreeData] Failed to store subtreeData for %s", subtreeHash.String(), setErr)
+}
+
 // fetchAndStoreSubtreeAndSubtreeData fetches both subtree and subtreeData for a single subtree hash
-// and stores them in the subtreeStore.
+// and stores them in the subtreeStore, returning the subtree.
 func (u *Server) fetchAndStoreSubtreeAndSubtreeData(ctx context.Context, block *model.Block, subtreeHash *chainhash.Hash,
-	peerID, baseURL string) error {
+	peerID, baseURL string) (*subtreepkg.Subtree, error) {
 	ctx, _, deferFn := tracing.Tracer("blockvalidation").Start(ctx, "fetchAndStoreSubtreeAndSubtreeData",
 		tracing.WithParentStat(u.stats),
 		// tracing.WithDebugLogMessage(u.logger, "[catchup:fetchAndStoreSubtreeAndSubtreeData] fetching subtree and data for %s", subtreeHash.String()),
@@ -520,15 +607,73 @@ func (u *Server) fetchAndStoreSubtreeAndSubtreeData(ctx context.Context, block *
 	// First, fetch and store the subtree (or get it if it already exists)
 	subtree, err := u.fetchAndStoreSubtree(ctx, block, subtreeHash, peerID, baseURL)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Then, fetch and store the subtreeData (if it doesn't already exist)
 	if err = u.fetchAndStoreSubtreeData(ctx, block, subtreeHash, subtree, peerID, baseURL); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return subtree, nil
+}
+
+// getPeerBatchSizer returns the adaptive batch sizer for the peer, seeding a new sizer with the
+// average response time the peer registry has measured for the peer. Returns nil when the batch size is fixed.
+func (u *Server) getPeerBatchSizer(ctx context.Context, peerID string) *catchup.AdaptiveBatchSizer {
+	if u.peerBatchSizers == nil || peerID == "" {
+		return nil
+	}
+
+	return u.peerBatchSizers.GetSizer(peerID, func() time.Duration {
+		if u.p2pClient == nil {
+			return 0
+		}
+
+		peerInfo, err := u.p2pClient.GetPeer(ctx, peerID)
+		if err != nil || peerInfo == nil {
+			return 0
+		}
+
+		return peerInfo.AvgResponseTime
+	})
+}
+
+// acquirePeerRequestSlot waits for one of the peer's in-flight request slots, for at most the
+// blockvalidation_peer_request_queue_timeout. The time waited is recorded per peer.
+// The returned release function must be called once with the outcome of the request,
+// which is used together with the request duration to tune the peer's limit.
+func (u *Server) acquirePeerRequestSlot(ctx context.Context, peerID string) (func(error), error) {
+	if u.peerRequestLimiters == nil || peerID == "" {
+		return func(error) {}, nil
+	}
+
+	limiter := u.peerRequestLimiters.GetLimiter(peerID)
+	queuedAt := time.Now()
+
+	err := limiter.Acquire(ctx)
+
+	if prometheusPeerRequestQueueWait != nil {
+		prometheusPeerRequestQueueWait.WithLabelValues(peerID).Observe(time.Since(queuedAt).Seconds())
+	}
+
+	if err != nil {
+		if errors.Is(err, errors.ErrThresholdExceeded) {
+			u.logger.Warnf("[acquirePeerRequestSlot] timed out waiting for one of the %d in-flight request slots of peer %s", limiter.Limit(), peerID)
+
+			if prometheusPeerRequestQueueTimeouts != nil {
+				prometheusPeerRequestQueueTimeouts.WithLabelValues(peerID).Inc()
+			}
+		}
+
+		return nil, err
+	}
+
+	start := time.Now()
+
+	return func(err error) {
+		limiter.Release(time.Since(start), err)
+	}, nil
 }
 
 // fetchSubtreeFromPeer fetches subtree (for subtreeToCheck) from a peer via HTTP
@@ -543,15 +688,28 @@ func (u *Server) fetchSubtreeFromPeer(ctx context.Context, subtreeHash *chainhas
 
 	u.logger.Debugf("[catchup:fetchSubtreeFromPeer] fetching subtree from %s", url)
 
-	// Use the existing HTTP utility to fetch subtree
-	subtreeBytes, err := util.DoHTTPRequest(ctx, url)
+	release, err := u.acquirePeerRequestSlot(ctx, peerID)
+	if err != nil {
+		return nil, errors.NewServiceError("[catchup:fetchSubtreeFromPeer] failed to get request slot for peer %s", peerID, err)
+	}
+
+	// Fetch the subtree, failing over to the DataHub mirrors of the peer
+	subtreeBytes, err := u.fetchFromDataHub(ctx, peerID, baseURL, "/subtree/"+subtreeHash.String())
+	release(err)
+
 	if err != nil {
 		return nil, errors.NewServiceError("[catchup:fetchSubtreeFromPeer] failed to fetch subtree from %s", url, err)
 	}
 
+	if err = u.bandwidth.Wait(ctx, bandwidth.ClassCatchup, len(subtreeBytes)); err != nil {
+		return nil, errors.NewServiceError("[catchup:fetchSubtreeFromPeer] failed to wait for bandwidth", err)
+	}
+
+	u.recordCatchupBytes(uint64(len(subtreeBytes)))
+
 	// Track bytes downloaded from peer
 	if u.p2pClient != nil && peerID != "" {
-		if err := u.p2pClient.RecordBytesDownloaded(ctx, peerID, uint64(len(subtreeBytes))); err != nil {
+		if err := u.p2pClient.RecordDataDownloaded(ctx, peerID, p2p.PeerDataTypeSubtree, 1, uint64(len(subtreeBytes))); err != nil {
 			u.logger.Warnf("[fetchSubtreeFromPeer][%s] failed to record %d bytes downloaded from peer %s: %v", subtreeHash.String(), len(subtreeBytes), peerID, err)
 		}
 	}
@@ -598,22 +756,33 @@ func (u *Server) fetchSubtreeDataFromPeer(ctx context.Context, subtreeHash *chai
 
 	u.logger.Debugf("[catchup:fetchSubtreeDataFromPeer] fetching subtree data from %s", url)
 
-	// Use the existing HTTP utility to fetch subtree data
-	subtreeDataReader, err := util.DoHTTPRequestBodyReader(ctx, url)
+	release, err := u.acquirePeerRequestSlot(ctx, peerID)
+	if err != nil {
+		return nil, errors.NewServiceError("[catchup:fetchSubtreeDataFromPeer] failed to get request slot for peer %s", peerID, err)
+	}
+
+	// Fetch the subtree data, failing over to the DataHub mirrors of the peer
+	subtreeDataReader, err := u.fetchReaderFromDataHub(ctx, peerID, baseURL, "/subtree_data/"+subtreeHash.String())
 	if err != nil {
+		release(err)
 		return nil, errors.NewServiceError("[catchup:fetchSubtreeDataFromPeer] failed to fetch subtree data from %s", url, err)
 	}
 
 	// Wrap with counting reader to track bytes when stream is consumed
 	countingReader := &countingReadCloser{
-		reader: subtreeDataReader,
+		reader: bandwidth.NewReadCloser(ctx, u.bandwidth, bandwidth.ClassCatchup, subtreeDataReader),
 		onClose: func(bytesRead uint64) {
+			// The request slot is held until the stream has been consumed
+			release(nil)
+
+			u.recordCatchupBytes(bytesRead)
+
 			// Track bytes downloaded from peer when reader is closed (after all data consumed)
 			// Decouple the context to ensure tracking completes even if parent context is cancelled
 			if u.p2pClient != nil && peerID != "" {
 				trackCtx, _, deferFn := tracing.DecoupleTracingSpan(ctx, "blockvalidation", "recordBytesDownloaded")
 				defer deferFn()
-				if err := u.p2pClient.RecordBytesDownloaded(trackCtx, peerID, bytesRead); err != nil {
+				if err := u.p2pClient.RecordDataDownloaded(trackCtx, peerID, p2p.PeerDataTypeSubtreeData, 1, bytesRead); err != nil {
 					u.logger.Warnf("[fetchSubtreeDataFromPeer][%s] failed to record %d bytes downloaded from peer %s: %v", subtreeHash.String(), bytesRead, peerID, err)
 				}
 			}
@@ -640,14 +809,37 @@ func (u *Server) fetchBlocksBatch(ctx context.Context, hash *chainhash.Hash, n u
 	)
 	defer deferFn()
 
-	blockBytes, err := util.DoHTTPRequest(ctx, fmt.Sprintf("%s/blocks/%s?n=%d", baseURL, hash.String(), n))
+	release, err := u.acquirePeerRequestSlot(ctx, peerID)
+	if err != nil {
+		return nil, errors.NewProcessingError("[catchup:fetchBlocksBatch][%s] failed to get request slot for peer %s", hash.String(), peerID, err)
+	}
+
+	if u.settings.BlockValidation.FetchStreaming {
+		return u.streamBlocksBatch(ctx, hash, n, peerID, baseURL, release)
+	}
+
+	start := time.Now()
+
+	blockBytes, err := u.fetchFromDataHub(ctx, peerID, baseURL, fmt.Sprintf("/blocks/%s?n=%d", hash.String(), n))
+	release(err)
+
+	if sizer := u.getPeerBatchSizer(ctx, peerID); sizer != nil {
+		sizer.Observe(int(n), time.Since(start), len(blockBytes), err)
+	}
+
 	if err != nil {
 		return nil, errors.NewProcessingError("[catchup:fetchBlocksBatch][%s] failed to get blocks from peer", hash.String(), err)
 	}
 
+	if err = u.bandwidth.Wait(ctx, bandwidth.ClassCatchup, len(blockBytes)); err != nil {
+		return nil, errors.NewProcessingError("[catchup:fetchBlocksBatch][%s] failed to wait for bandwidth", hash.String(), err)
+	}
+
+	u.recordCatchupBytes(uint64(len(blockBytes)))
+
 	// Track bytes downloaded from peer
 	if u.p2pClient != nil && peerID != "" {
-		if err := u.p2pClient.RecordBytesDownloaded(ctx, peerID, uint64(len(blockBytes))); err != nil {
+		if err := u.p2pClient.RecordDataDownloaded(ctx, peerID, p2p.PeerDataTypeBlock, uint64(n), uint64(len(blockBytes))); err != nil {
 			u.logger.Warnf("[fetchBlocksBatch][%s] failed to record %d bytes downloaded from peer %s: %v", hash.String(), len(blockBytes), peerID, err)
 		}
 	}
@@ -672,6 +864,73 @@ func (u *Server) fetchBlocksBatch(ctx context.Context, hash *chainhash.Hash, n u
 	return blocks, nil
 }
 
+// streamBlocksBatch fetches a batch of blocks like fetchBlocksBatch, decoding the blocks while the response is read
+// instead of buffering the whole response first.
+// The release function of the peer request slot is called when the response has been read.
+func (u *Server) streamBlocksBatch(ctx context.Context, hash *chainhash.Hash, n uint32, peerID string, baseURL string, release func(error)) ([]*model.Block, error) {
+	start := time.Now()
+
+	body, err := u.fetchReaderFromDataHub(ctx, peerID, baseURL, fmt.Sprintf("/blocks/%s?n=%d", hash.String(), n))
+	if err != nil {
+		release(err)
+
+		if sizer := u.getPeerBatchSizer(ctx, peerID); sizer != nil {
+			sizer.Observe(int(n), time.Since(start), 0, err)
+		}
+
+		return nil, errors.NewProcessingError("[catchup:streamBlocksBatch][%s] failed to get blocks from peer", hash.String(), err)
+	}
+
+	countingReader := &countingReadCloser{reader: bandwidth.NewReadCloser(ctx, u.bandwidth, bandwidth.ClassCatchup, body)}
+
+	bufferedReader := bufioReaderPool.Get().(*bufio.Reader)
+	bufferedReader.Reset(countingReader)
+
+	blocks := make([]*model.Block, 0, n)
+
+	for {
+		var block *model.Block
+
+		block, err = model.NewBlockFromReader(bufferedReader)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				err = nil
+			} else {
+				err = errors.NewProcessingError("[catchup:streamBlocksBatch][%s] failed to create block from stream", hash.String(), err)
+			}
+
+			break
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	bufferedReader.Reset(nil)
+	bufioReaderPool.Put(bufferedReader)
+
+	_ = countingReader.Close()
+	release(err)
+
+	if sizer := u.getPeerBatchSizer(ctx, peerID); sizer != nil {
+		sizer.Observe(int(n), time.Since(start), int(countingReader.bytesRead), err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	u.recordCatchupBytes(countingReader.bytesRead)
+
+	// Track bytes downloaded from peer
+	if u.p2pClient != nil && peerID != "" {
+		if err = u.p2pClient.RecordDataDownloaded(ctx, peerID, p2p.PeerDataTypeBlock, uint64(n), countingReader.bytesRead); err != nil {
+			u.logger.Warnf("[streamBlocksBatch][%s] failed to record %d bytes downloaded from peer %s: %v", hash.String(), countingReader.bytesRead, peerID, err)
+		}
+	}
+
+	return blocks, nil
+}
+
 // fetchSingleBlock fetches a single block from a peer by its hash.
 //
 // Parameters:
@@ -689,14 +948,27 @@ func (u *Server) fetchSingleBlock(ctx context.Context, hash *chainhash.Hash, pee
 	)
 	defer deferFn()
 
-	blockBytes, err := util.DoHTTPRequest(ctx, fmt.Sprintf("%s/block/%s", baseURL, hash.String()))
+	release, err := u.acquirePeerRequestSlot(ctx, peerID)
+	if err != nil {
+		return nil, errors.NewProcessingError("[catchup:fetchSingleBlock][%s] failed to get request slot for peer %s", hash.String(), peerID, err)
+	}
+
+	blockBytes, err := u.fetchFromDataHub(ctx, peerID, baseURL, "/block/"+hash.String())
+	release(err)
+
 	if err != nil {
 		return nil, errors.NewProcessingError("[catchup:fetchSingleBlock][%s] failed to get block from peer", hash.String(), err)
 	}
 
+	if err = u.bandwidth.Wait(ctx, bandwidth.ClassCatchup, len(blockBytes)); err != nil {
+		return nil, errors.NewProcessingError("[catchup:fetchSingleBlock][%s] failed to wait for bandwidth", hash.String(), err)
+	}
+
+	u.recordCatchupBytes(uint64(len(blockBytes)))
+
 	// Track bytes downloaded from peer
 	if u.p2pClient != nil && peerID != "" {
-		if err := u.p2pClient.RecordBytesDownloaded(ctx, peerID, uint64(len(blockBytes))); err != nil {
+		if err := u.p2pClient.RecordDataDownloaded(ctx, peerID, p2p.PeerDataTypeBlock, 1, uint64(len(blockBytes))); err != nil {
 			u.logger.Warnf("[fetchSingleBlock][%s] failed to record %d bytes downloaded from peer %s: %v", hash.String(), len(blockBytes), peerID, err)
 		}
 	}
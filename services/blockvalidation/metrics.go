@@ -30,6 +30,9 @@ var (
 	prometheusBlockValidationCatchup           prometheus.Histogram
 	prometheusBlockValidationProcessBlockFound prometheus.Histogram
 
+	// announced block header check metrics
+	prometheusBlockValidationAnnouncedHeaderChecks *prometheus.CounterVec
+
 	// block validation
 	prometheusBlockValidationValidateBlock      prometheus.Histogram
 	prometheusBlockValidationReValidateBlock    prometheus.Histogram
@@ -47,11 +50,34 @@ var (
 	prometheusCatchupErrors         *prometheus.CounterVec
 	prometheusCatchupActive         prometheus.Gauge
 
+	// catchup cross-check metrics
+	prometheusCatchupCrossChecks *prometheus.CounterVec
+	prometheusCatchupSpotChecks  *prometheus.CounterVec
+
+	prometheusCatchupMerkleSpotChecks *prometheus.CounterVec
+
+	prometheusCatchupIntegrityChecks *prometheus.CounterVec
+
+	// per-peer in-flight request limit metrics
+	prometheusPeerRequestQueueWait     *prometheus.HistogramVec
+	prometheusPeerRequestQueueTimeouts *prometheus.CounterVec
+
+	// peer metrics reporting to the p2p service
+	prometheusPeerMetricsReports  *prometheus.CounterVec
+	prometheusPeerMetricsDegraded prometheus.Gauge
+
 	// priority queue metrics
 	prometheusBlockPriorityQueueSize      *prometheus.GaugeVec
 	prometheusBlockPriorityQueueAdded     *prometheus.CounterVec
 	prometheusBlockPriorityQueueProcessed *prometheus.CounterVec
 
+	// DataHub mirror failover metrics
+	prometheusDataHubFailovers *prometheus.CounterVec
+
+	// orphan pool metrics
+	prometheusOrphanPoolSize prometheus.Gauge
+	prometheusOrphanBlocks   *prometheus.CounterVec
+
 	// fork processing metrics
 	prometheusForkCount             prometheus.Gauge
 	prometheusForkProcessingWorkers prometheus.Gauge
@@ -261,6 +287,97 @@ func _initPrometheusMetrics() {
 		},
 	)
 
+	prometheusCatchupCrossChecks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "catchup_cross_checks_total",
+			Help:      "Number of catchup header cross-checks against other peers by result (agree, conflict or inconclusive)",
+		},
+		[]string{"result"},
+	)
+
+	prometheusBlockValidationAnnouncedHeaderChecks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "announced_header_checks_total",
+			Help:      "Number of headers of announced blocks checked before downloading the block by result (pass, unavailable or the reason the announcement was rejected)",
+		},
+		[]string{"result"},
+	)
+
+	prometheusCatchupSpotChecks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "catchup_spot_checks_total",
+			Help:      "Number of blocks served by new catchup peers that were compared with a trusted peer by result (pass, mismatch or inconclusive)",
+		},
+		[]string{"result"},
+	)
+
+	prometheusCatchupMerkleSpotChecks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "catchup_merkle_spot_checks_total",
+			Help:      "Number of catchup blocks of which a sample of the transactions was proven against the merkle root by result (pass or fail)",
+		},
+		[]string{"result"},
+	)
+
+	prometheusCatchupIntegrityChecks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "catchup_integrity_checks_total",
+			Help:      "Number of sampled heights of completed catchups checked against other peers by result (agree, disputed, conflict or inconclusive)",
+		},
+		[]string{"result"},
+	)
+
+	prometheusPeerRequestQueueWait = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "peer_request_queue_wait_seconds",
+			Help:      "Time block and subtree fetches waited for a free in-flight request slot of the peer",
+			Buckets:   util.MetricsBucketsSeconds,
+		},
+		[]string{"peer_id"},
+	)
+
+	prometheusPeerRequestQueueTimeouts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "peer_request_queue_timeouts_total",
+			Help:      "Number of block and subtree fetches that timed out waiting for a free in-flight request slot of the peer",
+		},
+		[]string{"peer_id"},
+	)
+
+	// Initialize peer metrics reporting metrics
+	prometheusPeerMetricsReports = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "peer_metrics_reports_total",
+			Help:      "Number of peer metrics reports to the P2P service by method and result (success, failure or fallback to local handling)",
+		},
+		[]string{"method", "result"},
+	)
+
+	prometheusPeerMetricsDegraded = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "peer_metrics_degraded",
+			Help:      "Whether peer metrics are handled locally because the last report did not reach the P2P service (1) or not (0)",
+		},
+	)
+
 	// Initialize priority queue metrics
 	prometheusBlockPriorityQueueSize = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -292,6 +409,37 @@ func _initPrometheusMetrics() {
 		[]string{"priority", "result"},
 	)
 
+	// Initialize DataHub mirror failover metrics
+	prometheusDataHubFailovers = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "datahub_failovers_total",
+			Help:      "Number of fetches from a peer failed over to its DataHub mirrors by result: recovered or exhausted",
+		},
+		[]string{"result"},
+	)
+
+	// Initialize orphan pool metrics
+	prometheusOrphanPoolSize = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "orphan_pool_size",
+			Help:      "Current number of blocks held in the orphan pool until their parent arrives",
+		},
+	)
+
+	prometheusOrphanBlocks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "blockvalidation",
+			Name:      "orphan_blocks_total",
+			Help:      "Number of blocks added to and leaving the orphan pool by event: added, released, expired or evicted",
+		},
+		[]string{"event"},
+	)
+
 	// Initialize fork processing metrics
 	prometheusForkCount = promauto.NewGauge(
 		prometheus.GaugeOpts{
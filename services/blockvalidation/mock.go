@@ -65,6 +65,15 @@ func (m *Mock) GetCatchupStatus(ctx context.Context) (*CatchupStatus, error) {
 	return args.Get(0).(*CatchupStatus), args.Error(1)
 }
 
+// EstimateCatchup performs a mock catchup estimation.
+func (m *Mock) EstimateCatchup(ctx context.Context, blockHash *chainhash.Hash) (*CatchupEstimate, error) {
+	args := m.Called(ctx, blockHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CatchupEstimate), args.Error(1)
+}
+
 // mockKafkaConsumer implements kafka.KafkaConsumerGroupI for testing
 type mockKafkaConsumer struct {
 	mock.Mock
@@ -0,0 +1,371 @@
+// This file contains the orphan pool holding blocks that arrive before their parent until the parent is validated.
+package blockvalidation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/model"
+)
+
+// orphanPoolCheckInterval is the interval at which the orphan pool is checked for arrived parents, parents to request
+// again and expired orphans
+const orphanPoolCheckInterval = 10 * time.Second
+
+// Events of blocks entering and leaving the orphan pool
+const (
+	orphanAdded    = "added"
+	orphanReleased = "released" // the parent was validated and the block is queued for validation
+	orphanExpired  = "expired"  // the parent did not arrive in time and the block is handed to catchup
+	orphanEvicted  = "evicted"  // the pool was full and the block is handed to catchup
+)
+
+// orphanBlock is a block held in the orphan pool with the peer it was received from
+type orphanBlock struct {
+	block   *model.Block
+	peerID  string
+	baseURL string
+	addedAt time.Time
+}
+
+// orphanPool holds blocks whose parent is not known yet, indexed by the hash of their parent. The pool is bounded by
+// its size, the oldest block is evicted when it is full, and by the age of its blocks.
+type orphanPool struct {
+	mu       sync.Mutex
+	maxSize  int
+	maxAge   time.Duration
+	orphans  map[chainhash.Hash]*orphanBlock
+	byParent map[chainhash.Hash][]chainhash.Hash
+	requests map[chainhash.Hash]int // number of times each missing parent was requested
+}
+
+// newOrphanPool creates an orphan pool holding up to maxSize blocks for at most maxAge
+func newOrphanPool(maxSize int, maxAge time.Duration) *orphanPool {
+	return &orphanPool{
+		maxSize:  maxSize,
+		maxAge:   maxAge,
+		orphans:  make(map[chainhash.Hash]*orphanBlock),
+		byParent: make(map[chainhash.Hash][]chainhash.Hash),
+		requests: make(map[chainhash.Hash]int),
+	}
+}
+
+// add adds the orphan to the pool, returning false when the block is already held, and the blocks evicted to make room
+func (p *orphanPool) add(orphan *orphanBlock) (bool, []*orphanBlock) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	hash := *orphan.block.Hash()
+	if _, exists := p.orphans[hash]; exists {
+		return false, nil
+	}
+
+	var evicted []*orphanBlock
+
+	for len(p.orphans) >= p.maxSize {
+		evicted = append(evicted, p.remove(p.oldest()))
+	}
+
+	parent := *orphan.block.Header.HashPrevBlock
+
+	p.orphans[hash] = orphan
+	p.byParent[parent] = append(p.byParent[parent], hash)
+
+	return true, evicted
+}
+
+// size returns the number of blocks held in the pool
+func (p *orphanPool) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.orphans)
+}
+
+// childrenOf removes and returns the blocks held for the parent
+func (p *orphanPool) childrenOf(parent chainhash.Hash) []*orphanBlock {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// remove shrinks the index of the parent in place
+	hashes := append([]chainhash.Hash(nil), p.byParent[parent]...)
+	children := make([]*orphanBlock, 0, len(hashes))
+
+	for _, hash := range hashes {
+		children = append(children, p.remove(hash))
+	}
+
+	return children
+}
+
+// expire removes and returns the blocks held longer than the maximum age
+func (p *orphanPool) expire(now time.Time) []*orphanBlock {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var expired []*orphanBlock
+
+	for hash, orphan := range p.orphans {
+		if now.Sub(orphan.addedAt) > p.maxAge {
+			expired = append(expired, p.remove(hash))
+		}
+	}
+
+	return expired
+}
+
+// missingParents returns a block held for each parent that is not held itself, the parents to request
+func (p *orphanPool) missingParents() []*orphanBlock {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	children := make([]*orphanBlock, 0, len(p.byParent))
+
+	for parent, hashes := range p.byParent {
+		if _, held := p.orphans[parent]; !held {
+			children = append(children, p.orphans[hashes[0]])
+		}
+	}
+
+	return children
+}
+
+// missingAncestor returns the first ancestor of the block that is not held, the block to request for it
+func (p *orphanPool) missingAncestor(block *model.Block) chainhash.Hash {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ancestor := *block.Header.HashPrevBlock
+
+	// a block can not be its own ancestor, the bound only guards against a malformed pool
+	for i := 0; i < len(p.orphans); i++ {
+		orphan, held := p.orphans[ancestor]
+		if !held {
+			break
+		}
+
+		ancestor = *orphan.block.Header.HashPrevBlock
+	}
+
+	return ancestor
+}
+
+// nextRequest returns how many times the parent was requested before and counts the new request
+func (p *orphanPool) nextRequest(parent chainhash.Hash) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	attempt := p.requests[parent]
+	p.requests[parent] = attempt + 1
+
+	return attempt
+}
+
+// oldest returns the hash of the block held the longest, the pool must not be empty
+func (p *orphanPool) oldest() chainhash.Hash {
+	var (
+		oldestHash chainhash.Hash
+		oldestTime time.Time
+	)
+
+	for hash, orphan := range p.orphans {
+		if oldestTime.IsZero() || orphan.addedAt.Before(oldestTime) {
+			oldestHash, oldestTime = hash, orphan.addedAt
+		}
+	}
+
+	return oldestHash
+}
+
+// remove removes the block from the pool and its parent index, the caller must hold the lock
+func (p *orphanPool) remove(hash chainhash.Hash) *orphanBlock {
+	orphan, exists := p.orphans[hash]
+	if !exists {
+		return nil
+	}
+
+	delete(p.orphans, hash)
+
+	parent := *orphan.block.Header.HashPrevBlock
+	siblings := p.byParent[parent]
+
+	for i, sibling := range siblings {
+		if sibling == hash {
+			siblings = append(siblings[:i], siblings[i+1:]...)
+			break
+		}
+	}
+
+	if len(siblings) == 0 {
+		delete(p.byParent, parent)
+		delete(p.requests, parent)
+	} else {
+		p.byParent[parent] = siblings
+	}
+
+	return orphan
+}
+
+// holdOrphan holds a block whose parent is not known in the orphan pool and requests the missing parent from the best
+// peers. It returns false when the block is not held and the caller hands it to catchup: when the pool is disabled,
+// for blocks of the legacy service, which syncs its own parents, and for blocks too far ahead of the best block to be
+// filled in one parent at a time.
+func (u *Server) holdOrphan(ctx context.Context, block *model.Block, peerID, baseURL string) bool {
+	if u.orphans == nil || baseURL == "legacy" {
+		return false
+	}
+
+	_, bestBlockMeta, err := u.blockchainClient.GetBestBlockHeader(ctx)
+	if err != nil {
+		u.logger.Warnf("[holdOrphan][%s] failed to get the best block header, not holding the block: %v", block.Hash().String(), err)
+		return false
+	}
+
+	if block.Height > bestBlockMeta.Height+uint32(max(u.settings.BlockValidation.OrphanMaxHeightGap, 0)) {
+		u.logger.Infof("[holdOrphan][%s] block at height %d is too far ahead of the best block at height %d, not holding the block", block.Hash().String(), block.Height, bestBlockMeta.Height)
+		return false
+	}
+
+	added, evicted := u.orphans.add(&orphanBlock{
+		block:   block,
+		peerID:  peerID,
+		baseURL: baseURL,
+		addedAt: time.Now(),
+	})
+
+	for _, orphan := range evicted {
+		prometheusOrphanBlocks.WithLabelValues(orphanEvicted).Inc()
+		u.orphanToCatchup(orphan)
+	}
+
+	prometheusOrphanPoolSize.Set(float64(u.orphans.size()))
+
+	if !added {
+		return true
+	}
+
+	prometheusOrphanBlocks.WithLabelValues(orphanAdded).Inc()
+	u.logger.Infof("[holdOrphan][%s] parent %s not known, holding the block from peer %s in the orphan pool", block.Hash().String(), block.Header.HashPrevBlock.String(), peerID)
+
+	u.requestOrphanParent(ctx, u.orphans.missingAncestor(block), block.Height, peerID, baseURL)
+
+	return true
+}
+
+// requestOrphanParent requests a missing parent, queueing it as a found block from one of the best peers. Every
+// request of the same parent is sent to the next peer, falling back to the peer the orphan was received from.
+func (u *Server) requestOrphanParent(ctx context.Context, parent chainhash.Hash, height uint32, peerID, baseURL string) {
+	attempt := u.orphans.nextRequest(parent)
+
+	// the parent of a block at height 1 is genesis, which is always known
+	targetHeight := int32(0)
+	if height > 1 {
+		targetHeight = int32(height - 1) //nolint:gosec // block heights fit in an int32
+	}
+
+	peers, err := u.selectBestPeersForCatchup(ctx, targetHeight)
+	if err != nil {
+		u.logger.Debugf("[requestOrphanParent][%s] failed to select peers, requesting from peer %s: %v", parent.String(), peerID, err)
+	}
+
+	if len(peers) > 0 {
+		peer := peers[attempt%len(peers)]
+		peerID, baseURL = peer.ID, peer.DataHubURL
+	}
+
+	hash := parent
+
+	select {
+	case u.blockFoundCh <- processBlockFound{hash: &hash, baseURL: baseURL, peerID: peerID}:
+		u.logger.Infof("[requestOrphanParent][%s] requested missing parent from peer %s (attempt %d)", parent.String(), peerID, attempt+1)
+	default:
+		u.logger.Warnf("[requestOrphanParent][%s] block found channel full, parent is requested again in %s", parent.String(), orphanPoolCheckInterval)
+	}
+}
+
+// releaseOrphans queues the blocks held for a validated parent for validation, each released block releases its own
+// children once it is validated
+func (u *Server) releaseOrphans(ctx context.Context, parent chainhash.Hash) {
+	if u.orphans == nil {
+		return
+	}
+
+	children := u.orphans.childrenOf(parent)
+	if len(children) == 0 {
+		return
+	}
+
+	prometheusOrphanPoolSize.Set(float64(u.orphans.size()))
+
+	for _, orphan := range children {
+		prometheusOrphanBlocks.WithLabelValues(orphanReleased).Inc()
+		u.logger.Infof("[releaseOrphans][%s] parent %s validated, queueing the orphan block for validation", orphan.block.Hash().String(), parent.String())
+
+		u.enqueueBlock(ctx, processBlockFound{
+			hash:    orphan.block.Hash(),
+			baseURL: orphan.baseURL,
+			peerID:  orphan.peerID,
+			block:   orphan.block,
+		}, orphan.block)
+	}
+}
+
+// orphanToCatchup hands an orphan whose parent did not arrive to catchup, the way blocks with an unknown parent are
+// handled without the orphan pool
+func (u *Server) orphanToCatchup(orphan *orphanBlock) {
+	select {
+	case u.catchupCh <- processBlockCatchup{
+		block:   orphan.block,
+		baseURL: orphan.baseURL,
+		peerID:  orphan.peerID,
+	}:
+		u.logger.Infof("[orphanToCatchup][%s] sent orphan block to catchup", orphan.block.Hash().String())
+	default:
+		u.logger.Warnf("[orphanToCatchup][%s] catchup channel full, dropping orphan block from peer %s", orphan.block.Hash().String(), orphan.peerID)
+	}
+}
+
+// maintainOrphanPool periodically releases the orphans of parents validated outside of the block found flow, e.g. by
+// catchup or the legacy service, requests the other missing parents again and hands expired orphans to catchup
+func (u *Server) maintainOrphanPool(ctx context.Context) {
+	ticker := time.NewTicker(orphanPoolCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			u.checkOrphanPool(ctx, time.Now())
+		}
+	}
+}
+
+func (u *Server) checkOrphanPool(ctx context.Context, now time.Time) {
+	for _, orphan := range u.orphans.expire(now) {
+		prometheusOrphanBlocks.WithLabelValues(orphanExpired).Inc()
+		u.logger.Infof("[checkOrphanPool][%s] parent %s did not arrive in time", orphan.block.Hash().String(), orphan.block.Header.HashPrevBlock.String())
+		u.orphanToCatchup(orphan)
+	}
+
+	for _, orphan := range u.orphans.missingParents() {
+		parent := *orphan.block.Header.HashPrevBlock
+
+		exists, err := u.blockValidation.GetBlockExists(ctx, &parent)
+		if err != nil {
+			u.logger.Warnf("[checkOrphanPool][%s] failed to check if the parent exists: %v", parent.String(), err)
+			continue
+		}
+
+		if exists {
+			u.releaseOrphans(ctx, parent)
+			continue
+		}
+
+		u.requestOrphanParent(ctx, parent, orphan.block.Height, orphan.peerID, orphan.baseURL)
+	}
+
+	prometheusOrphanPoolSize.Set(float64(u.orphans.size()))
+}
@@ -0,0 +1,106 @@
+package blockvalidation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orphanChild returns a block on top of the parent, distinguished from its siblings by the nonce
+func orphanChild(parent *chainhash.Hash, nonce uint32) *model.Block {
+	return &model.Block{
+		Header: &model.BlockHeader{
+			Version:        1,
+			HashPrevBlock:  parent,
+			HashMerkleRoot: &chainhash.Hash{},
+			Nonce:          nonce,
+		},
+	}
+}
+
+func TestOrphanPool_ChildrenOf(t *testing.T) {
+	pool := newOrphanPool(10, time.Minute)
+	now := time.Now()
+
+	parent := &chainhash.Hash{1}
+	child1 := orphanChild(parent, 1)
+	child2 := orphanChild(parent, 2)
+	grandchild := orphanChild(child1.Hash(), 3)
+
+	for _, block := range []*model.Block{child1, child2, grandchild} {
+		added, evicted := pool.add(&orphanBlock{block: block, peerID: "peer", addedAt: now})
+		require.True(t, added)
+		require.Empty(t, evicted)
+	}
+
+	added, _ := pool.add(&orphanBlock{block: child1, peerID: "other", addedAt: now})
+	assert.False(t, added, "a block is held once")
+	assert.Equal(t, 3, pool.size())
+
+	// only the parent of the children is missing, the grandchild waits for a held block
+	missing := pool.missingParents()
+	require.Len(t, missing, 1)
+	assert.Equal(t, *parent, *missing[0].block.Header.HashPrevBlock)
+	assert.Equal(t, *parent, pool.missingAncestor(grandchild))
+
+	children := pool.childrenOf(*parent)
+	require.Len(t, children, 2)
+	assert.ElementsMatch(t, []*chainhash.Hash{child1.Hash(), child2.Hash()}, []*chainhash.Hash{children[0].block.Hash(), children[1].block.Hash()})
+	assert.Empty(t, pool.childrenOf(*parent))
+	assert.Equal(t, 1, pool.size())
+
+	// the grandchild now waits for a block that is not held
+	missing = pool.missingParents()
+	require.Len(t, missing, 1)
+	assert.Equal(t, *child1.Hash(), *missing[0].block.Header.HashPrevBlock)
+}
+
+func TestOrphanPool_Limits(t *testing.T) {
+	pool := newOrphanPool(2, time.Minute)
+	now := time.Now()
+
+	parent := &chainhash.Hash{1}
+	oldest := orphanChild(parent, 1)
+	older := orphanChild(parent, 2)
+	newest := orphanChild(parent, 3)
+
+	pool.add(&orphanBlock{block: oldest, addedAt: now.Add(-2 * time.Second)})
+	pool.add(&orphanBlock{block: older, addedAt: now.Add(-time.Second)})
+
+	added, evicted := pool.add(&orphanBlock{block: newest, addedAt: now})
+	require.True(t, added)
+	require.Len(t, evicted, 1)
+	assert.Equal(t, oldest.Hash(), evicted[0].block.Hash(), "the oldest block is evicted when the pool is full")
+	assert.Equal(t, 2, pool.size())
+
+	assert.Empty(t, pool.expire(now.Add(30*time.Second)))
+
+	expired := pool.expire(now.Add(time.Minute - time.Second/2))
+	require.Len(t, expired, 1)
+	assert.Equal(t, older.Hash(), expired[0].block.Hash())
+
+	assert.Len(t, pool.expire(now.Add(2*time.Minute)), 1)
+	assert.Equal(t, 0, pool.size())
+	assert.Empty(t, pool.missingParents())
+}
+
+func TestOrphanPool_NextRequest(t *testing.T) {
+	pool := newOrphanPool(10, time.Minute)
+
+	parent := &chainhash.Hash{1}
+	child := orphanChild(parent, 1)
+
+	pool.add(&orphanBlock{block: child, addedAt: time.Now()})
+
+	assert.Equal(t, 0, pool.nextRequest(*parent))
+	assert.Equal(t, 1, pool.nextRequest(*parent), "requests of the same parent rotate through the peers")
+
+	// the request count is forgotten with the last child of the parent
+	pool.childrenOf(*parent)
+	pool.add(&orphanBlock{block: child, addedAt: time.Now()})
+	assert.Equal(t, 0, pool.nextRequest(*parent))
+}
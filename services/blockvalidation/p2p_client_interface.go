@@ -24,6 +24,13 @@ type P2PClientI interface {
 	// RecordCatchupMalicious records malicious behavior detected during catchup.
 	RecordCatchupMalicious(ctx context.Context, peerID string) error
 
+	// AddBanScore adds to a peer's ban score with the specified reason.
+	AddBanScore(ctx context.Context, peerID string, reason string) error
+
+	// AddBanScorePoints adds the given points to a peer's ban score with the specified reason, instead of the
+	// points of the reason, e.g. the ban-score delta of a peer-attributable error.
+	AddBanScorePoints(ctx context.Context, peerID string, reason string, points int) error
+
 	// UpdateCatchupError stores the last catchup error for a peer.
 	UpdateCatchupError(ctx context.Context, peerID string, errorMsg string) error
 
@@ -44,6 +51,9 @@ type P2PClientI interface {
 	// ReportValidSubtree reports that a subtree was successfully received and validated from a peer.
 	ReportValidSubtree(ctx context.Context, peerID string, subtreeHash string) error
 
+	// RecordInvalidBlock reports that a block received from a peer was rejected, with the reason it was rejected.
+	RecordInvalidBlock(ctx context.Context, peerID string, blockHash string, reason p2p.InvalidDataReason, details string) error
+
 	// IsPeerMalicious checks if a peer is considered malicious based on their behavior.
 	// A peer is considered malicious if they are banned or have a very low reputation score.
 	IsPeerMalicious(ctx context.Context, peerID string) (bool, string, error)
@@ -52,7 +62,7 @@ type P2PClientI interface {
 	// A peer is considered unhealthy if they have poor performance metrics or low reputation.
 	IsPeerUnhealthy(ctx context.Context, peerID string) (bool, string, float32, error)
 
-	// RecordBytesDownloaded records the number of bytes downloaded via HTTP from a peer.
-	// This is called after downloading data (blocks, subtrees, etc.) from a peer's DataHub URL.
-	RecordBytesDownloaded(ctx context.Context, peerID string, bytesDownloaded uint64) error
+	// RecordDataDownloaded records the blocks, subtrees or subtree data and the number of bytes downloaded
+	// via HTTP from a peer. This is called after downloading data from a peer's DataHub URL.
+	RecordDataDownloaded(ctx context.Context, peerID string, dataType string, items uint64, bytesDownloaded uint64) error
 }
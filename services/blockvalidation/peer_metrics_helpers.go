@@ -3,8 +3,60 @@ package blockvalidation
 import (
 	"context"
 	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+)
+
+// Methods of the peer metrics reports to the P2P service, used as metric label
+const (
+	peerMetricsMethodCatchupAttempt   = "catchup_attempt"
+	peerMetricsMethodCatchupSuccess   = "catchup_success"
+	peerMetricsMethodCatchupFailure   = "catchup_failure"
+	peerMetricsMethodCatchupError     = "catchup_error"
+	peerMetricsMethodCatchupMalicious = "catchup_malicious"
+	peerMetricsMethodBanScore         = "ban_score"
+	peerMetricsMethodInvalidBlock     = "invalid_block"
+	peerMetricsMethodIsPeerMalicious  = "is_peer_malicious"
+	peerMetricsMethodIsPeerUnhealthy  = "is_peer_unhealthy"
 )
 
+// Results of the peer metrics reports to the P2P service, used as metric label
+const (
+	peerMetricsResultSuccess  = "success"
+	peerMetricsResultFailure  = "failure"
+	peerMetricsResultFallback = "fallback"
+)
+
+// recordPeerMetricsReport records the result of a report to the P2P service. A failed report also counts
+// as a fallback, since the report is then handled locally. Any report not reaching the P2P service puts
+// the peer metrics in degraded mode until the next successful report.
+func recordPeerMetricsReport(method string, err error) {
+	if prometheusPeerMetricsReports == nil {
+		return
+	}
+
+	if err == nil {
+		prometheusPeerMetricsReports.WithLabelValues(method, peerMetricsResultSuccess).Inc()
+		prometheusPeerMetricsDegraded.Set(0)
+
+		return
+	}
+
+	prometheusPeerMetricsReports.WithLabelValues(method, peerMetricsResultFailure).Inc()
+	recordPeerMetricsFallback(method)
+}
+
+// recordPeerMetricsFallback records a report that was handled locally instead of by the P2P service
+func recordPeerMetricsFallback(method string) {
+	if prometheusPeerMetricsReports == nil {
+		return
+	}
+
+	prometheusPeerMetricsReports.WithLabelValues(method, peerMetricsResultFallback).Inc()
+	prometheusPeerMetricsDegraded.Set(1)
+}
+
 // reportCatchupAttempt reports a catchup attempt to the P2P service.
 // Falls back to local metrics if P2P client is unavailable.
 //
@@ -18,12 +70,17 @@ func (u *Server) reportCatchupAttempt(ctx context.Context, peerID string) {
 
 	// Report to P2P service if client is available
 	if u.p2pClient != nil {
-		if err := u.p2pClient.RecordCatchupAttempt(ctx, peerID); err != nil {
+		err := u.p2pClient.RecordCatchupAttempt(ctx, peerID)
+		recordPeerMetricsReport(peerMetricsMethodCatchupAttempt, err)
+
+		if err != nil {
 			u.logger.Warnf("[peer_metrics] Failed to report catchup attempt to P2P service for peer %s: %v", peerID, err)
 			// Fall through to local metrics as backup
 		} else {
 			return // Successfully reported to P2P service
 		}
+	} else {
+		recordPeerMetricsFallback(peerMetricsMethodCatchupAttempt)
 	}
 
 	// Fallback to local metrics (for backward compatibility or when P2P client unavailable)
@@ -46,12 +103,17 @@ func (u *Server) reportCatchupSuccess(ctx context.Context, peerID string, durati
 
 	// Report to P2P service if client is available
 	if u.p2pClient != nil {
-		if err := u.p2pClient.RecordCatchupSuccess(ctx, peerID, durationMs); err != nil {
+		err := u.p2pClient.RecordCatchupSuccess(ctx, peerID, durationMs)
+		recordPeerMetricsReport(peerMetricsMethodCatchupSuccess, err)
+
+		if err != nil {
 			u.logger.Warnf("[peer_metrics] Failed to report catchup success to P2P service for peer %s: %v", peerID, err)
 			// Fall through to local metrics as backup
 		} else {
 			return // Successfully reported to P2P service
 		}
+	} else {
+		recordPeerMetricsFallback(peerMetricsMethodCatchupSuccess)
 	}
 
 	// Fallback: No local metrics needed since we're using P2P service for all peer tracking
@@ -70,9 +132,14 @@ func (u *Server) reportCatchupFailure(ctx context.Context, peerID string) {
 
 	// Report to P2P service if client is available
 	if u.p2pClient != nil {
-		if err := u.p2pClient.RecordCatchupFailure(ctx, peerID); err != nil {
+		err := u.p2pClient.RecordCatchupFailure(ctx, peerID)
+		recordPeerMetricsReport(peerMetricsMethodCatchupFailure, err)
+
+		if err != nil {
 			u.logger.Warnf("[peer_metrics] Failed to report catchup failure to P2P service for peer %s: %v", peerID, err)
 		}
+	} else {
+		recordPeerMetricsFallback(peerMetricsMethodCatchupFailure)
 	}
 }
 
@@ -90,9 +157,14 @@ func (u *Server) reportCatchupError(ctx context.Context, peerID string, errorMsg
 
 	// Report to P2P service if client is available
 	if u.p2pClient != nil {
-		if err := u.p2pClient.UpdateCatchupError(ctx, peerID, errorMsg); err != nil {
+		err := u.p2pClient.UpdateCatchupError(ctx, peerID, errorMsg)
+		recordPeerMetricsReport(peerMetricsMethodCatchupError, err)
+
+		if err != nil {
 			u.logger.Warnf("[peer_metrics] Failed to update catchup error for peer %s: %v", peerID, err)
 		}
+	} else {
+		recordPeerMetricsFallback(peerMetricsMethodCatchupError)
 	}
 }
 
@@ -112,17 +184,116 @@ func (u *Server) reportCatchupMalicious(ctx context.Context, peerID string, reas
 
 	// Report to P2P service if client is available
 	if u.p2pClient != nil {
-		if err := u.p2pClient.RecordCatchupMalicious(ctx, peerID); err != nil {
+		err := u.p2pClient.RecordCatchupMalicious(ctx, peerID)
+		recordPeerMetricsReport(peerMetricsMethodCatchupMalicious, err)
+
+		if err != nil {
 			u.logger.Warnf("[peer_metrics] Failed to report malicious behavior to P2P service for peer %s: %v", peerID, err)
 			// Fall through to local metrics as backup
 		} else {
 			return // Successfully reported to P2P service
 		}
+	} else {
+		recordPeerMetricsFallback(peerMetricsMethodCatchupMalicious)
 	}
 
 	// Fallback: No local metrics needed since we're using P2P service for all peer tracking
 }
 
+// banMaliciousChainPeer reports a peer that served a fabricated chain as malicious and
+// bans it through the P2P service's ban score.
+//
+// Parameters:
+//   - ctx: Context for the gRPC calls
+//   - peerID: Peer identifier
+//   - reason: Description of the malicious chain (for logging)
+func (u *Server) banMaliciousChainPeer(ctx context.Context, peerID string, reason string) {
+	if peerID == "" {
+		return
+	}
+
+	u.reportCatchupMalicious(ctx, peerID, reason)
+
+	if u.p2pClient != nil {
+		err := u.p2pClient.AddBanScore(ctx, peerID, "malicious_chain")
+		recordPeerMetricsReport(peerMetricsMethodBanScore, err)
+
+		if err != nil {
+			u.logger.Warnf("[peer_metrics] Failed to ban peer %s for malicious chain: %v", peerID, err)
+		}
+	} else {
+		recordPeerMetricsFallback(peerMetricsMethodBanScore)
+	}
+}
+
+// reportPeerError reports a peer-attributable error to the P2P service, adding the ban-score delta of the error to the
+// ban score of the peer the error is attributed to. Call sites wrap a failure caused by a peer once with
+// errors.NewPeerAttributableError, and the error is reported here where its handling ends, so a failure is not held
+// against the peer twice. Errors that are not attributed to a peer are ignored.
+//
+// Parameters:
+//   - ctx: Context for the gRPC call
+//   - err: Error that may be attributed to a peer
+//
+// Returns:
+//   - bool: True if the error is attributed to a peer
+func (u *Server) reportPeerError(ctx context.Context, err error) bool {
+	attribution, ok := errors.GetPeerAttribution(err)
+	if !ok {
+		return false
+	}
+
+	if u.p2pClient == nil {
+		recordPeerMetricsFallback(peerMetricsMethodBanScore)
+		return true
+	}
+
+	reason := "protocol_violation"
+
+	switch {
+	case errors.Is(err, errors.ErrBlockInvalid):
+		reason = "invalid_block"
+	case errors.Is(err, errors.ErrSubtreeInvalid), errors.Is(err, errors.ErrTxInvalid):
+		reason = "invalid_subtree"
+	}
+
+	reportErr := u.p2pClient.AddBanScorePoints(ctx, attribution.PeerID, reason, attribution.BanScoreDelta)
+	recordPeerMetricsReport(peerMetricsMethodBanScore, reportErr)
+
+	if reportErr != nil {
+		u.logger.Warnf("[peer_metrics] Failed to add ban score %d (%s) to peer %s: %v", attribution.BanScoreDelta, attribution.Severity, attribution.PeerID, reportErr)
+	}
+
+	return true
+}
+
+// reportInvalidBlock reports a block received from a peer that was rejected to the P2P service, lowering the
+// reputation of the peer.
+//
+// Parameters:
+//   - ctx: Context for the gRPC call
+//   - peerID: Peer identifier
+//   - blockHash: Hash of the rejected block
+//   - reason: Reason the block was rejected
+//   - details: Description of why the block was rejected
+func (u *Server) reportInvalidBlock(ctx context.Context, peerID string, blockHash string, reason p2p.InvalidDataReason, details string) {
+	if peerID == "" {
+		return
+	}
+
+	if u.p2pClient == nil {
+		recordPeerMetricsFallback(peerMetricsMethodInvalidBlock)
+		return
+	}
+
+	err := u.p2pClient.RecordInvalidBlock(ctx, peerID, blockHash, reason, details)
+	recordPeerMetricsReport(peerMetricsMethodInvalidBlock, err)
+
+	if err != nil {
+		u.logger.Warnf("[peer_metrics] Failed to report invalid block %s to P2P service for peer %s: %v", blockHash, peerID, err)
+	}
+}
+
 // isPeerMalicious checks if a peer is marked as malicious.
 // Queries the P2P service for the peer's status.
 //
@@ -140,6 +311,8 @@ func (u *Server) isPeerMalicious(ctx context.Context, peerID string) bool {
 	// Query P2P service for peer status
 	if u.p2pClient != nil {
 		isMalicious, reason, err := u.p2pClient.IsPeerMalicious(ctx, peerID)
+		recordPeerMetricsReport(peerMetricsMethodIsPeerMalicious, err)
+
 		if err != nil {
 			u.logger.Warnf("[isPeerMalicious] Failed to check if peer %s is malicious: %v", peerID, err)
 			// On error, assume peer is not malicious to avoid false positives
@@ -151,6 +324,8 @@ func (u *Server) isPeerMalicious(ctx context.Context, peerID string) bool {
 		return isMalicious
 	}
 
+	recordPeerMetricsFallback(peerMetricsMethodIsPeerMalicious)
+
 	return false
 }
 
@@ -171,6 +346,8 @@ func (u *Server) isPeerBad(peerID string) bool {
 	if u.p2pClient != nil {
 		// Use context.Background() since the old method didn't require context
 		isUnhealthy, reason, reputationScore, err := u.p2pClient.IsPeerUnhealthy(context.Background(), peerID)
+		recordPeerMetricsReport(peerMetricsMethodIsPeerUnhealthy, err)
+
 		if err != nil {
 			u.logger.Warnf("[isPeerBad] Failed to check if peer %s is unhealthy: %v", peerID, err)
 			// On error, assume peer is not bad to avoid false positives
@@ -182,5 +359,7 @@ func (u *Server) isPeerBad(peerID string) bool {
 		return isUnhealthy
 	}
 
+	recordPeerMetricsFallback(peerMetricsMethodIsPeerUnhealthy)
+
 	return false
 }
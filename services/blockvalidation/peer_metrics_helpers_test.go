@@ -0,0 +1,113 @@
+package blockvalidation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// catchupAttemptP2PClient only implements RecordCatchupAttempt, returning the configured error
+type catchupAttemptP2PClient struct {
+	P2PClientI
+	err error
+}
+
+func (c *catchupAttemptP2PClient) RecordCatchupAttempt(_ context.Context, _ string) error {
+	return c.err
+}
+
+func metricValue(t *testing.T, metric prometheus.Metric) float64 {
+	var m dto.Metric
+	require.NoError(t, metric.Write(&m))
+
+	if m.Counter != nil {
+		return m.Counter.GetValue()
+	}
+
+	return m.Gauge.GetValue()
+}
+
+func TestPeerMetricsReportMetrics(t *testing.T) {
+	initPrometheusMetrics()
+
+	count := func(result string) float64 {
+		return metricValue(t, prometheusPeerMetricsReports.WithLabelValues(peerMetricsMethodCatchupAttempt, result))
+	}
+
+	t.Run("no p2p client", func(t *testing.T) {
+		u := &Server{logger: ulogger.TestLogger{}}
+
+		fallbacks := count(peerMetricsResultFallback)
+
+		u.reportCatchupAttempt(context.Background(), "peer1")
+
+		assert.InDelta(t, fallbacks+1, count(peerMetricsResultFallback), 0)
+		assert.InDelta(t, 1, metricValue(t, prometheusPeerMetricsDegraded), 0)
+	})
+
+	t.Run("report fails", func(t *testing.T) {
+		u := &Server{
+			logger:    ulogger.TestLogger{},
+			p2pClient: &catchupAttemptP2PClient{err: errors.NewServiceError("p2p unavailable")},
+		}
+
+		failures, fallbacks := count(peerMetricsResultFailure), count(peerMetricsResultFallback)
+
+		u.reportCatchupAttempt(context.Background(), "peer1")
+
+		assert.InDelta(t, failures+1, count(peerMetricsResultFailure), 0)
+		assert.InDelta(t, fallbacks+1, count(peerMetricsResultFallback), 0)
+		assert.InDelta(t, 1, metricValue(t, prometheusPeerMetricsDegraded), 0)
+	})
+
+	t.Run("report succeeds", func(t *testing.T) {
+		u := &Server{
+			logger:    ulogger.TestLogger{},
+			p2pClient: &catchupAttemptP2PClient{},
+		}
+
+		successes := count(peerMetricsResultSuccess)
+
+		u.reportCatchupAttempt(context.Background(), "peer1")
+
+		assert.InDelta(t, successes+1, count(peerMetricsResultSuccess), 0)
+		assert.InDelta(t, 0, metricValue(t, prometheusPeerMetricsDegraded), 0, "a successful report leaves degraded mode")
+	})
+
+	t.Run("empty peer ID", func(t *testing.T) {
+		u := &Server{logger: ulogger.TestLogger{}}
+
+		fallbacks := count(peerMetricsResultFallback)
+
+		u.reportCatchupAttempt(context.Background(), "")
+
+		assert.InDelta(t, fallbacks, count(peerMetricsResultFallback), 0, "nothing is reported without a peer")
+	})
+}
+
+func TestReportPeerError(t *testing.T) {
+	initPrometheusMetrics()
+
+	p2pClient := &banScoreP2PClient{}
+	u := &Server{logger: ulogger.TestLogger{}, p2pClient: p2pClient}
+	ctx := context.Background()
+
+	// errors not attributed to a peer are not reported
+	assert.False(t, u.reportPeerError(ctx, nil))
+	assert.False(t, u.reportPeerError(ctx, errors.NewBlockInvalidError("invalid")))
+	assert.Empty(t, p2pClient.banned)
+
+	// the reason follows the wrapped failure, the score the attribution
+	assert.True(t, u.reportPeerError(ctx, errors.NewServiceError("failed block validation",
+		errors.NewPeerAttributableError("peer1", errors.PeerSeverityHigh, 0, "invalid subtree", errors.NewTxInvalidError("invalid tx")))))
+	assert.True(t, u.reportPeerError(ctx, errors.NewPeerAttributableError("peer2", errors.PeerSeverityLow, 7, "unexpected response")))
+	assert.True(t, u.reportPeerError(ctx, errors.WrapGRPC(errors.NewPeerAttributableError("peer3", errors.PeerSeverityCritical, 0, "fake block", errors.NewBlockInvalidError("invalid")))))
+
+	assert.Equal(t, []string{"peer1:invalid_subtree:20", "peer2:protocol_violation:7", "peer3:invalid_block:100"}, p2pClient.banned)
+}
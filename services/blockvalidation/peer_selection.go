@@ -2,6 +2,9 @@ package blockvalidation
 
 import (
 	"context"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/util/tracing"
 )
 
 // PeerForCatchup represents a peer suitable for catchup operations with its metadata
@@ -9,25 +12,32 @@ type PeerForCatchup struct {
 	ID                     string
 	Storage                string
 	DataHubURL             string
+	DataHubMirrorURLs      []string // Mirrors of the DataHub, tried in order when DataHubURL fails
 	Height                 int32
 	BlockHash              string
 	CatchupReputationScore float64
 	CatchupAttempts        int64
 	CatchupSuccesses       int64
 	CatchupFailures        int64
+	AvgResponseTime        time.Duration
 }
 
-// selectBestPeersForCatchup queries the P2P service for peers suitable for catchup,
-// sorted by reputation score (highest first).
+// selectBestPeersForCatchup queries the P2P service for peers suitable for catchup, ordered by
+// the peer selector of the server. The default selector keeps the order of the P2P service: by
+// reputation score (highest first), or drawn at random weighted by reputation when
+// p2p_catchup_peer_selection is weighted_random.
 //
 // Parameters:
 //   - ctx: Context for the gRPC call
 //   - targetHeight: The height we're trying to catch up to (for filtering peers)
 //
 // Returns:
-//   - []PeerForCatchup: List of peers sorted by reputation (best first)
+//   - []PeerForCatchup: List of peers in the order they should be tried
 //   - error: If the query fails
 func (u *Server) selectBestPeersForCatchup(ctx context.Context, targetHeight int32) ([]PeerForCatchup, error) {
+	ctx, _, deferFn := tracing.Tracer("blockvalidation").Start(ctx, "selectBestPeersForCatchup")
+	defer deferFn()
+
 	// If P2P client is not available, return empty list
 	if u.p2pClient == nil {
 		u.logger.Debugf("[peer_selection] P2P client not available, using fallback peer selection")
@@ -62,19 +72,27 @@ func (u *Server) selectBestPeersForCatchup(ctx context.Context, targetHeight int
 			continue
 		}
 
+		u.cacheDataHubMirrorURLs(p.ID.String(), p.DataHubMirrorURLs)
+
 		peers = append(peers, PeerForCatchup{
 			ID:                     p.ID.String(),
 			Storage:                p.Storage,
 			DataHubURL:             p.DataHubURL,
+			DataHubMirrorURLs:      p.DataHubMirrorURLs,
 			Height:                 p.Height,
 			BlockHash:              p.BlockHash,
 			CatchupReputationScore: p.ReputationScore,
 			CatchupAttempts:        p.InteractionAttempts,
 			CatchupSuccesses:       p.InteractionSuccesses,
 			CatchupFailures:        p.InteractionFailures,
+			AvgResponseTime:        p.AvgResponseTime,
 		})
 	}
 
+	if u.peerSelector != nil {
+		peers = u.peerSelector.Select(peers)
+	}
+
 	u.logger.Infof("[peer_selection] Selected %d peers for catchup (from %d total)", len(peers), len(peerInfos))
 	for i, p := range peers {
 		successRate := float64(0)
@@ -0,0 +1,209 @@
+package blockvalidation
+
+import (
+	"math/rand/v2"
+	"sort"
+	"sync/atomic"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+)
+
+// Strategies of the catchup peer selection
+const (
+	PeerSelectorP2P            = "p2p"               // The order of the P2P service
+	PeerSelectorBestScore      = "best_score"        // By reputation, the best peer first
+	PeerSelectorWeightedRandom = "weighted_random"   // Drawn at random, weighted by reputation
+	PeerSelectorRoundRobinTopK = "round_robin_top_k" // The best K peers take turns being tried first
+	PeerSelectorLocalityAware  = "locality_aware"    // Reputable peers by response time, the fastest first
+)
+
+// localityMinReputation is the lowest reputation score of a peer ordered by response time by the locality aware
+// selector, the reputation score new peers start with
+const localityMinReputation = 50
+
+// PeerSelector orders the peers suitable for catchup, as returned by the P2P service after the peers below the
+// target height and without a DataHub URL have been filtered out. The P2P service returns full nodes before pruned
+// nodes, selectors keep the peers of each storage mode together unless noted otherwise.
+type PeerSelector interface {
+	// Select returns the peers in the order they should be tried, the slice passed in is not modified
+	Select(peers []PeerForCatchup) []PeerForCatchup
+}
+
+// newPeerSelector creates the peer selector of the strategy, configured by the block validation settings
+func newPeerSelector(strategy string, bvSettings settings.BlockValidationSettings) (PeerSelector, error) {
+	switch strategy {
+	case "", PeerSelectorP2P:
+		return p2pPeerSelector{}, nil
+	case PeerSelectorBestScore:
+		return bestScorePeerSelector{}, nil
+	case PeerSelectorWeightedRandom:
+		return &weightedRandomPeerSelector{exploration: bvSettings.CatchupPeerSelectorExploration, random: rand.Float64}, nil
+	case PeerSelectorRoundRobinTopK:
+		return &roundRobinTopKPeerSelector{k: bvSettings.CatchupPeerSelectorTopK}, nil
+	case PeerSelectorLocalityAware:
+		return localityAwarePeerSelector{minReputation: localityMinReputation}, nil
+	default:
+		return nil, errors.NewConfigurationError("unknown catchup peer selector %q", strategy)
+	}
+}
+
+// p2pPeerSelector keeps the order of the P2P service, set by p2p_catchup_peer_selection
+type p2pPeerSelector struct{}
+
+// Select returns the peers unchanged
+func (p2pPeerSelector) Select(peers []PeerForCatchup) []PeerForCatchup {
+	return peers
+}
+
+// bestScorePeerSelector orders the peers by reputation score, the highest first. Peers with the same score keep the
+// order of the P2P service.
+type bestScorePeerSelector struct{}
+
+// Select returns the peers ordered by reputation score
+func (bestScorePeerSelector) Select(peers []PeerForCatchup) []PeerForCatchup {
+	return orderWithinStorageRuns(peers, sortByScore)
+}
+
+// weightedRandomPeerSelector draws the peers one by one without replacement. A peer is drawn with a weight of
+// (1-exploration) * its share of the reputation scores of the remaining peers + exploration / the number of
+// remaining peers, so the best peers are favored while every peer is tried.
+type weightedRandomPeerSelector struct {
+	exploration float64
+	random      func() float64 // returns a number in [0, 1)
+}
+
+// Select returns the peers in the order they are drawn
+func (s *weightedRandomPeerSelector) Select(peers []PeerForCatchup) []PeerForCatchup {
+	exploration := min(max(s.exploration, 0), 1)
+
+	return orderWithinStorageRuns(peers, func(run []PeerForCatchup) {
+		for i := range run {
+			// move the drawn peer in front of the remaining peers, keeping their order
+			picked := i + drawWeightedPeer(run[i:], exploration, s.random)
+			drawn := run[picked]
+			copy(run[i+1:picked+1], run[i:picked])
+			run[i] = drawn
+		}
+	})
+}
+
+// drawWeightedPeer returns the index of the peer drawn, see weightedRandomPeerSelector
+func drawWeightedPeer(peers []PeerForCatchup, exploration float64, random func() float64) int {
+	var total float64
+	for _, p := range peers {
+		total += max(p.CatchupReputationScore, 0)
+	}
+
+	weights := make([]float64, len(peers))
+
+	var sum float64
+
+	for i, p := range peers {
+		weight := exploration / float64(len(peers))
+
+		if total > 0 {
+			weight += (1 - exploration) * max(p.CatchupReputationScore, 0) / total
+		} else {
+			weight += (1 - exploration) / float64(len(peers))
+		}
+
+		weights[i] = weight
+		sum += weight
+	}
+
+	// the last peer is picked when rounding leaves the draw above the sum of the weights
+	r := random() * sum
+
+	for i, weight := range weights {
+		if r < weight {
+			return i
+		}
+
+		r -= weight
+	}
+
+	return len(peers) - 1
+}
+
+// roundRobinTopKPeerSelector orders the peers by reputation score, and rotates the best k peers on every selection,
+// so catchups are spread across the best peers instead of always hitting the single best one. The top k are taken
+// across storage modes, the remaining peers follow in the order of their score.
+type roundRobinTopKPeerSelector struct {
+	k    int
+	next atomic.Uint64
+}
+
+// Select returns the peers ordered by reputation score, the best k rotated by one position per selection
+func (s *roundRobinTopKPeerSelector) Select(peers []PeerForCatchup) []PeerForCatchup {
+	ordered := orderWithinStorageRuns(peers, sortByScore)
+
+	k := min(s.k, len(ordered))
+	if k <= 1 {
+		return ordered
+	}
+
+	offset := int((s.next.Add(1) - 1) % uint64(k)) //nolint:gosec // k is positive
+
+	top := append(append(make([]PeerForCatchup, 0, k), ordered[offset:k]...), ordered[:offset]...)
+	copy(ordered, top)
+
+	return ordered
+}
+
+// localityAwarePeerSelector prefers the peers closest on the network, using the average response time of a peer as
+// its distance. Peers with at least minReputation are ordered by response time, the fastest first and peers without
+// response times last, followed by the less reputable peers by reputation score.
+type localityAwarePeerSelector struct {
+	minReputation float64
+}
+
+// Select returns the reputable peers ordered by response time, followed by the other peers
+func (s localityAwarePeerSelector) Select(peers []PeerForCatchup) []PeerForCatchup {
+	return orderWithinStorageRuns(peers, func(run []PeerForCatchup) {
+		sort.SliceStable(run, func(i, j int) bool {
+			iReputable := run[i].CatchupReputationScore >= s.minReputation
+			jReputable := run[j].CatchupReputationScore >= s.minReputation
+
+			if iReputable != jReputable {
+				return iReputable
+			}
+
+			if !iReputable {
+				return run[i].CatchupReputationScore > run[j].CatchupReputationScore
+			}
+
+			iMeasured, jMeasured := run[i].AvgResponseTime > 0, run[j].AvgResponseTime > 0
+			if iMeasured != jMeasured {
+				return iMeasured
+			}
+
+			return run[i].AvgResponseTime < run[j].AvgResponseTime
+		})
+	})
+}
+
+// sortByScore sorts the peers by reputation score, the highest first, keeping the order of peers with the same score
+func sortByScore(peers []PeerForCatchup) {
+	sort.SliceStable(peers, func(i, j int) bool {
+		return peers[i].CatchupReputationScore > peers[j].CatchupReputationScore
+	})
+}
+
+// orderWithinStorageRuns returns a copy of the peers in which each run of peers of the same storage mode has been
+// reordered in place by order
+func orderWithinStorageRuns(peers []PeerForCatchup, order func(run []PeerForCatchup)) []PeerForCatchup {
+	ordered := append([]PeerForCatchup(nil), peers...)
+
+	for start := 0; start < len(ordered); {
+		end := start + 1
+		for end < len(ordered) && ordered[end].Storage == ordered[start].Storage {
+			end++
+		}
+
+		order(ordered[start:end])
+		start = end
+	}
+
+	return ordered
+}
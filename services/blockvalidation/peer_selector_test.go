@@ -0,0 +1,153 @@
+package blockvalidation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func selectorTestPeers() []PeerForCatchup {
+	return []PeerForCatchup{
+		{ID: "full-a", Storage: "full", CatchupReputationScore: 60, AvgResponseTime: 300 * time.Millisecond},
+		{ID: "full-b", Storage: "full", CatchupReputationScore: 90, AvgResponseTime: 0},
+		{ID: "full-c", Storage: "full", CatchupReputationScore: 30, AvgResponseTime: 10 * time.Millisecond},
+		{ID: "full-d", Storage: "full", CatchupReputationScore: 75, AvgResponseTime: 100 * time.Millisecond},
+		{ID: "pruned-a", Storage: "pruned", CatchupReputationScore: 40},
+		{ID: "pruned-b", Storage: "pruned", CatchupReputationScore: 95},
+	}
+}
+
+func selectedPeerIDs(peers []PeerForCatchup) []string {
+	ids := make([]string, 0, len(peers))
+	for _, p := range peers {
+		ids = append(ids, p.ID)
+	}
+
+	return ids
+}
+
+func TestNewPeerSelector(t *testing.T) {
+	bvSettings := settings.BlockValidationSettings{CatchupPeerSelectorTopK: 2, CatchupPeerSelectorExploration: 0.2}
+
+	for strategy, expected := range map[string]PeerSelector{
+		"":                         p2pPeerSelector{},
+		PeerSelectorP2P:            p2pPeerSelector{},
+		PeerSelectorBestScore:      bestScorePeerSelector{},
+		PeerSelectorLocalityAware:  localityAwarePeerSelector{minReputation: localityMinReputation},
+		PeerSelectorWeightedRandom: &weightedRandomPeerSelector{},
+		PeerSelectorRoundRobinTopK: &roundRobinTopKPeerSelector{},
+	} {
+		selector, err := newPeerSelector(strategy, bvSettings)
+		require.NoError(t, err, strategy)
+		assert.IsType(t, expected, selector, strategy)
+	}
+
+	selector, err := newPeerSelector(PeerSelectorRoundRobinTopK, bvSettings)
+	require.NoError(t, err)
+	assert.Equal(t, 2, selector.(*roundRobinTopKPeerSelector).k)
+
+	_, err = newPeerSelector("fastest", bvSettings)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrConfiguration))
+}
+
+func TestPeerSelectors(t *testing.T) {
+	t.Run("p2p keeps the order", func(t *testing.T) {
+		peers := selectorTestPeers()
+		assert.Equal(t, selectedPeerIDs(peers), selectedPeerIDs(p2pPeerSelector{}.Select(peers)))
+	})
+
+	t.Run("best score", func(t *testing.T) {
+		peers := selectorTestPeers()
+
+		ordered := bestScorePeerSelector{}.Select(peers)
+
+		assert.Equal(t, []string{"full-b", "full-d", "full-a", "full-c", "pruned-b", "pruned-a"}, selectedPeerIDs(ordered))
+		assert.Equal(t, selectedPeerIDs(selectorTestPeers()), selectedPeerIDs(peers), "the input must not be modified")
+	})
+
+	t.Run("weighted random", func(t *testing.T) {
+		// drawing 0 always picks the first remaining peer, keeping the order
+		selector := &weightedRandomPeerSelector{exploration: 0.1, random: func() float64 { return 0 }}
+		assert.Equal(t, selectedPeerIDs(selectorTestPeers()), selectedPeerIDs(selector.Select(selectorTestPeers())))
+
+		// drawing just below 1 always picks the last remaining peer, reversing each storage mode
+		selector.random = func() float64 { return 0.999999 }
+		assert.Equal(t, []string{"full-d", "full-c", "full-b", "full-a", "pruned-b", "pruned-a"}, selectedPeerIDs(selector.Select(selectorTestPeers())))
+
+		// a peer without reputation is only drawn through exploration
+		peers := []PeerForCatchup{{ID: "zero", Storage: "full"}, {ID: "good", Storage: "full", CatchupReputationScore: 100}}
+		selector = &weightedRandomPeerSelector{exploration: 0, random: func() float64 { return 0 }}
+		assert.Equal(t, []string{"good", "zero"}, selectedPeerIDs(selector.Select(peers)))
+	})
+
+	t.Run("round robin top k", func(t *testing.T) {
+		selector := &roundRobinTopKPeerSelector{k: 3}
+
+		assert.Equal(t, []string{"full-b", "full-d", "full-a", "full-c", "pruned-b", "pruned-a"}, selectedPeerIDs(selector.Select(selectorTestPeers())))
+		assert.Equal(t, []string{"full-d", "full-a", "full-b", "full-c", "pruned-b", "pruned-a"}, selectedPeerIDs(selector.Select(selectorTestPeers())))
+		assert.Equal(t, []string{"full-a", "full-b", "full-d", "full-c", "pruned-b", "pruned-a"}, selectedPeerIDs(selector.Select(selectorTestPeers())))
+		assert.Equal(t, []string{"full-b", "full-d", "full-a", "full-c", "pruned-b", "pruned-a"}, selectedPeerIDs(selector.Select(selectorTestPeers())))
+
+		// k larger than the number of peers rotates all of them
+		selector = &roundRobinTopKPeerSelector{k: 10}
+		peers := selectorTestPeers()[:2]
+
+		assert.Equal(t, []string{"full-b", "full-a"}, selectedPeerIDs(selector.Select(peers)))
+		assert.Equal(t, []string{"full-a", "full-b"}, selectedPeerIDs(selector.Select(peers)))
+	})
+
+	t.Run("locality aware", func(t *testing.T) {
+		selector := localityAwarePeerSelector{minReputation: localityMinReputation}
+
+		// reputable peers by response time, unmeasured last, then the others by score
+		assert.Equal(t, []string{"full-d", "full-a", "full-b", "full-c", "pruned-b", "pruned-a"}, selectedPeerIDs(selector.Select(selectorTestPeers())))
+	})
+}
+
+// reversePeerSelector orders the peers in reverse, used to verify the selector of the server is applied
+type reversePeerSelector struct{}
+
+func (reversePeerSelector) Select(peers []PeerForCatchup) []PeerForCatchup {
+	reversed := make([]PeerForCatchup, 0, len(peers))
+	for i := len(peers) - 1; i >= 0; i-- {
+		reversed = append(reversed, peers[i])
+	}
+
+	return reversed
+}
+
+func TestSelectBestPeersForCatchup_PeerSelector(t *testing.T) {
+	first, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	second, err := peer.Decode("12D3KooWEyX7hgdXy8zUjCs9CqvMGpB5dKVFj9MX2nUBLwajdSZH")
+	require.NoError(t, err)
+
+	client := &crossCheckP2PClient{peers: []*p2p.PeerInfo{
+		{ID: first, DataHubURL: "http://first", Height: 100, ReputationScore: 80, AvgResponseTime: time.Second},
+		{ID: second, DataHubURL: "http://second", Height: 100, ReputationScore: 70},
+	}}
+
+	server := &Server{logger: ulogger.TestLogger{}, p2pClient: client}
+
+	peers, err := server.selectBestPeersForCatchup(context.Background(), 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.String(), second.String()}, selectedPeerIDs(peers))
+	assert.Equal(t, time.Second, peers[0].AvgResponseTime)
+
+	// the selector can be swapped without touching the catchup
+	server.peerSelector = reversePeerSelector{}
+
+	peers, err = server.selectBestPeersForCatchup(context.Background(), 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{second.String(), first.String()}, selectedPeerIDs(peers))
+}
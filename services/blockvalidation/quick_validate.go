@@ -51,8 +51,6 @@ func (u *BlockValidation) quickValidateBlock(ctx context.Context, block *model.B
 	)
 	defer deferFn()
 
-	// Get stable block ID - reuse existing BlockID if transactions already exist (retry scenario)
-	var id uint64
 	var err error
 	var txWrappers []txWrapper
 
@@ -62,39 +60,41 @@ func (u *BlockValidation) quickValidateBlock(ctx context.Context, block *model.B
 		if err != nil {
 			return errors.NewProcessingError("[quickValidateBlock][%s] failed to get block transactions", block.Hash().String(), err)
 		}
+	}
 
-		// Check if first transaction already exists with a BlockID (retry scenario)
-		// We only need to check the first transaction since it's always created first
-		if len(txWrappers) > 0 && txWrappers[0].tx != nil {
-			existingMeta, err := u.utxoStore.Get(ctx, txWrappers[0].tx.TxIDChainHash(), fields.BlockIDs)
-			if err == nil && existingMeta != nil && len(existingMeta.BlockIDs) > 0 {
-				// First transaction exists, reuse its BlockID for consistency
-				id = uint64(existingMeta.BlockIDs[0])
-				block.ID = existingMeta.BlockIDs[0]
-				u.logger.Debugf("[quickValidateBlock][%s] reusing existing BlockID %d from retry", block.Hash().String(), id)
-			}
-		}
+	// A failed attempt rolls back the UTXOs of the block, so a retry always starts over with a new block ID
+	id, err := u.blockchainClient.GetNextBlockID(ctx)
+	if err != nil {
+		return errors.NewProcessingError("[quickValidateBlock][%s] failed to get next block ID", block.Hash().String(), err)
 	}
 
-	// If no existing transactions found, get next block ID
-	if id == 0 {
-		id, err = u.blockchainClient.GetNextBlockID(ctx)
-		if err != nil {
-			return errors.NewProcessingError("[quickValidateBlock][%s] failed to get next block ID", block.Hash().String(), err)
+	block.ID = uint32(id) // nolint:gosec
+
+	// All creates and spends of the block are made in a block transaction, which is rolled back when the block
+	// could not be added to the blockchain, so a failed validation leaves no UTXOs of the block behind
+	blockTx := utxo.BeginBlockTransaction(u.utxoStore)
+	blockAdded := false
+
+	defer func() {
+		if blockAdded {
+			return
 		}
-		block.ID = uint32(id) // nolint:gosec
-	}
+
+		if rollbackErr := blockTx.Rollback(context.WithoutCancel(ctx)); rollbackErr != nil {
+			u.logger.Errorf("[quickValidateBlock][%s] failed to roll back the UTXOs of the block: %v", block.Hash().String(), rollbackErr)
+		}
+	}()
 
 	if len(block.Subtrees) > 0 {
 		// For checkpointed blocks, we can skip full validation and just create UTXOs
 		// This is the main optimization - we trust the checkpoint and don't need to
 		// validate every transaction's scripts and signatures
-		if err = u.createAllUTXOs(ctx, block, txWrappers); err != nil {
+		if err = u.createAllUTXOs(ctx, blockTx, block, txWrappers); err != nil {
 			return errors.NewProcessingError("[quickValidateBlock][%s] failed to create UTXOs", block.Hash().String(), err)
 		}
 
 		// validate all transactions in the block
-		if err = u.spendAllTransactions(ctx, block, txWrappers); err != nil {
+		if err = u.spendAllTransactions(ctx, blockTx, block, txWrappers); err != nil {
 			return errors.NewProcessingError("[quickValidateBlock][%s] failed to validate transactions", block.Hash().String(), err)
 		}
 	}
@@ -110,19 +110,17 @@ func (u *BlockValidation) quickValidateBlock(ctx context.Context, block *model.B
 		return errors.NewProcessingError("[quickValidateBlock][%s] failed to add block to blockchain", block.Hash().String(), err)
 	}
 
-	if len(block.Subtrees) > 0 {
-		// Extract transaction hashes for unlocking
-		txHashes := make([]chainhash.Hash, 0, len(txWrappers))
-		for _, txW := range txWrappers {
-			if txW.tx != nil {
-				txHashes = append(txHashes, *txW.tx.TxIDChainHash())
-			}
-		}
+	// the block is on the blockchain, its UTXOs are kept from here on, also when unlocking them fails
+	blockAdded = true
 
-		// Unlock all UTXOs - final commit point
-		if err = u.utxoStore.SetLocked(ctx, txHashes, false); err != nil {
-			return errors.NewProcessingError("[quickValidateBlock][%s] failed to unlock UTXOs", block.Hash().String(), err)
-		}
+	// Unlock all UTXOs - final commit point
+	if err = blockTx.Commit(ctx); err != nil {
+		return errors.NewProcessingError("[quickValidateBlock][%s] failed to unlock UTXOs", block.Hash().String(), err)
+	}
+
+	// keep the undo record of the block, block assembly reverts the UTXOs of the block with it when a reorg removes it
+	if err = u.storeBlockUndo(ctx, blockTx, block); err != nil {
+		u.logger.Warnf("[quickValidateBlock][%s] failed to store the undo record of the block: %v", block.Hash().String(), err)
 	}
 
 	// Mark block as existing in cache
@@ -133,16 +131,83 @@ func (u *BlockValidation) quickValidateBlock(ctx context.Context, block *model.B
 	return nil
 }
 
+// quickValidateBlockRange adds a contiguous range of checkpointed blocks without transactions besides the coinbase to
+// the blockchain. These blocks have no UTXOs to create or spend, so quick validation only has to store them, which is
+// done for the whole range with a single StoreBlocks call. The range is stored atomically.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - blocks: Contiguous range of blocks without subtrees, ordered by height
+//   - baseURL: URL of the peer the blocks were fetched from
+//
+// Returns:
+//   - error: If the range could not be stored, in which case none of the blocks are
+func (u *BlockValidation) quickValidateBlockRange(ctx context.Context, blocks []*model.Block, baseURL string) error {
+	ctx, _, deferFn := tracing.Tracer("blockvalidation").Start(ctx, "quickValidateBlockRange",
+		tracing.WithParentStat(u.stats),
+		tracing.WithLogMessage(u.logger, "[quickValidateBlockRange][%s] storing range of %d checkpointed blocks from height %d", blocks[0].Hash().String(), len(blocks), blocks[0].Height),
+	)
+	defer deferFn()
+
+	for _, block := range blocks {
+		if len(block.Subtrees) > 0 {
+			return errors.NewInvalidArgumentError("[quickValidateBlockRange][%s] block has transactions, it can not be stored in a range", block.Hash().String())
+		}
+	}
+
+	if err := u.blockchainClient.StoreBlocks(ctx,
+		blocks,
+		baseURL,
+		options.WithSubtreesSet(true),
+		options.WithMinedSet(true),
+	); err != nil {
+		return errors.NewProcessingError("[quickValidateBlockRange][%s] failed to add %d blocks to blockchain", blocks[0].Hash().String(), len(blocks), err)
+	}
+
+	// Mark blocks as existing in cache
+	for _, block := range blocks {
+		if err := u.SetBlockExists(block.Hash()); err != nil {
+			u.logger.Errorf("[quickValidateBlockRange][%s] failed to set block exists cache: %s", block.Hash().String(), err)
+		}
+	}
+
+	return nil
+}
+
+// storeBlockUndo stores the undo record of the committed block transaction of the block in the subtree store, kept
+// for the block height retention, like the subtrees of the block
+func (u *BlockValidation) storeBlockUndo(ctx context.Context, blockTx *utxo.BlockTransaction, block *model.Block) error {
+	undo, err := blockTx.Undo()
+	if err != nil {
+		return err
+	}
+
+	undoBytes, err := undo.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return u.subtreeStore.Set(ctx,
+		block.Hash()[:],
+		fileformat.FileTypeBlockUndo,
+		undoBytes,
+		bloboptions.WithAllowOverwrite(true),
+		bloboptions.WithDeleteAt(block.Height+u.settings.GlobalBlockHeightRetention),
+	)
+}
+
 // createAllUTXOs creates all UTXOs for transactions in the block before validation.
-// This is the first phase of quick validation for checkpointed blocks.
+// This is the first phase of quick validation for checkpointed blocks. The UTXOs are
+// created locked in the block transaction, until it is committed.
 //
 // Parameters:
 //   - ctx: Context for cancellation
+//   - blockTx: Block transaction the UTXOs are created in
 //   - block: Block containing transactions
 //
 // Returns:
 //   - error: If UTXO creation fails
-func (u *BlockValidation) createAllUTXOs(ctx context.Context, block *model.Block, txs []txWrapper) error {
+func (u *BlockValidation) createAllUTXOs(ctx context.Context, blockTx *utxo.BlockTransaction, block *model.Block, txs []txWrapper) error {
 	ctx, _, deferFn := tracing.Tracer("blockvalidation").Start(ctx, "createAllUTXOs",
 		tracing.WithParentStat(u.stats),
 		tracing.WithLogMessage(u.logger, "[createAllUTXOs][%s] creating UTXOs for %d transactions", block.Hash().String(), block.TransactionCount),
@@ -151,11 +216,11 @@ func (u *BlockValidation) createAllUTXOs(ctx context.Context, block *model.Block
 
 	// Create first transaction synchronously to establish BlockID anchor for retries
 	if len(txs) > 0 && txs[0].tx != nil {
-		if _, err := u.utxoStore.Create(ctx, txs[0].tx, block.Height, utxo.WithMinedBlockInfo(utxo.MinedBlockInfo{
+		if _, err := blockTx.Create(ctx, txs[0].tx, block.Height, utxo.WithMinedBlockInfo(utxo.MinedBlockInfo{
 			BlockID:     block.ID,
 			BlockHeight: block.Height,
 			SubtreeIdx:  txs[0].subtreeIdx,
-		}), utxo.WithLocked(true)); err != nil && !errors.Is(err, errors.ErrTxExists) {
+		})); err != nil && !errors.Is(err, errors.ErrTxExists) {
 			return errors.NewProcessingError("[createAllUTXOs][%s] failed to create first UTXO for tx %s", block.Hash().String(), txs[0].tx.TxIDChainHash().String(), err)
 		}
 	}
@@ -178,11 +243,11 @@ func (u *BlockValidation) createAllUTXOs(ctx context.Context, block *model.Block
 
 		g.Go(func() error {
 			// create the UTXO
-			if _, err := u.utxoStore.Create(gCtx, txW.tx, block.Height, utxo.WithMinedBlockInfo(utxo.MinedBlockInfo{
+			if _, err := blockTx.Create(gCtx, txW.tx, block.Height, utxo.WithMinedBlockInfo(utxo.MinedBlockInfo{
 				BlockID:     block.ID,
 				BlockHeight: block.Height,
 				SubtreeIdx:  txW.subtreeIdx,
-			}), utxo.WithLocked(true)); err != nil && !errors.Is(err, errors.ErrTxExists) {
+			})); err != nil && !errors.Is(err, errors.ErrTxExists) {
 				return errors.NewProcessingError("[createAllUTXOs][%s] failed to create UTXO for tx %s", block.Hash().String(), tx.TxIDChainHash().String(), err)
 			}
 
@@ -198,16 +263,18 @@ func (u *BlockValidation) createAllUTXOs(ctx context.Context, block *model.Block
 }
 
 // spendAllTransactions performs full validation on all transactions in the block.
-// This is the second phase of quick validation for checkpointed blocks.
+// This is the second phase of quick validation for checkpointed blocks. The spends are
+// recorded in the block transaction, to be reversed when it is rolled back.
 //
 // Parameters:
 //   - ctx: Context for cancellation
+//   - blockTx: Block transaction the spends are made in
 //   - block: Block containing transactions
 //   - txs: List of transactions to validate
 //
 // Returns:
 //   - error: If validation fails
-func (u *BlockValidation) spendAllTransactions(ctx context.Context, block *model.Block, txs []txWrapper) error {
+func (u *BlockValidation) spendAllTransactions(ctx context.Context, blockTx *utxo.BlockTransaction, block *model.Block, txs []txWrapper) error {
 	ctx, _, deferFn := tracing.Tracer("blockvalidation").Start(ctx, "spendAllTransactions",
 		tracing.WithParentStat(u.stats),
 		tracing.WithLogMessage(u.logger, "[spendAllTransactions][%s] spending %d transactions", block.Hash().String(), block.TransactionCount),
@@ -239,7 +306,7 @@ func (u *BlockValidation) spendAllTransactions(ctx context.Context, block *model
 		}
 
 		g.Go(func() error {
-			if _, err := u.utxoStore.Spend(gCtx, tx, block.Height, utxo.IgnoreFlags{IgnoreLocked: true}); err != nil {
+			if _, err := blockTx.Spend(gCtx, tx, block.Height, utxo.IgnoreFlags{IgnoreLocked: true}); err != nil {
 				return errors.NewProcessingError("[spendAllTransactions][%s] failed to spend tx %s", block.Hash().String(), tx.TxIDChainHash().String(), err)
 			}
 
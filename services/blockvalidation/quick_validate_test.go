@@ -66,49 +66,7 @@ func TestQuickValidateBlock(t *testing.T) {
 		suite.MockBlockchain.On("SetBlockSubtreesSet", mock.Anything, mock.Anything).Return(nil).Maybe()
 		suite.MockBlockchain.On("RevalidateBlock", mock.Anything, mock.Anything).Return(nil).Maybe()
 
-		// Create a transaction chain with coinbase + 2 regular transactions
-		txs := transactions.CreateTestTransactionChainWithCount(t, 4)
-		coinbaseTx := txs[0]
-		regularTxs := txs[1:] // txs[1], txs[2]
-
-		// Create block with the proper coinbase
-		block := testhelpers.CreateTestBlocks(t, 1)[0]
-		block.Height = 100
-		block.CoinbaseTx = coinbaseTx // Use the coinbase from our transaction chain
-
-		subtree, err := subtreepkg.NewIncompleteTreeByLeafCount(3)
-		require.NoError(t, err, "Should create subtree without error")
-
-		require.NoError(t, subtree.AddCoinbaseNode())
-		require.NoError(t, subtree.AddNode(*regularTxs[0].TxIDChainHash(), 1, 1))
-		require.NoError(t, subtree.AddNode(*regularTxs[1].TxIDChainHash(), 2, 2))
-
-		subtreeBytes, err := subtree.Serialize()
-		require.NoError(t, err, "Should serialize subtree without error")
-
-		err = suite.Server.subtreeStore.Set(t.Context(), subtree.RootHash()[:], fileformat.FileTypeSubtreeToCheck, subtreeBytes)
-		require.NoError(t, err, "Should store subtree without error")
-
-		subtreeData := subtreepkg.NewSubtreeData(subtree)
-		require.NoError(t, subtreeData.AddTx(coinbaseTx, 0), "Should add coinbase tx to subtree data without error")
-		require.NoError(t, subtreeData.AddTx(regularTxs[0], 1), "Should add tx 0 to subtree data without error")
-		require.NoError(t, subtreeData.AddTx(regularTxs[1], 2), "Should add tx 1 to subtree data without error")
-
-		subtreeDataBytes, err := subtreeData.Serialize()
-		require.NoError(t, err, "Should serialize subtree data without error")
-
-		err = suite.Server.subtreeStore.Set(t.Context(), subtree.RootHash()[:], fileformat.FileTypeSubtreeData, subtreeDataBytes)
-		require.NoError(t, err, "Should store subtree data without error")
-
-		block.Subtrees = []*chainhash.Hash{subtree.RootHash()}
-		block.TransactionCount = 3 // coinbase + 2 transactions
-
-		// Update the merkle root to match the subtree
-		block.Header.HashMerkleRoot, err = subtree.RootHashWithReplaceRootNode(coinbaseTx.TxIDChainHash(), 0, 0)
-		require.NoError(t, err, "Should create merkle root hash without error")
-
-		// Setup Get expectation for checking existing transactions (used for BlockID reuse on retry)
-		suite.MockUTXOStore.On("Get", mock.Anything, mock.Anything, mock.Anything).Return((*meta.Data)(nil), errors.NewNotFoundError("not found"))
+		block := createQuickValidateTestBlock(t, suite)
 
 		// Setup UTXO store expectations for all transactions (including coinbase)
 		// Use mock.Anything for the transaction since the order may vary
@@ -123,7 +81,7 @@ func TestQuickValidateBlock(t *testing.T) {
 		// Setup validator to return no errors (one for each transaction: coinbase + 2 regular)
 		suite.MockValidator.Errors = []error{nil, nil, nil}
 
-		err = suite.Server.blockValidation.quickValidateBlock(suite.Ctx, block, "test")
+		err := suite.Server.blockValidation.quickValidateBlock(suite.Ctx, block, "test")
 		assert.NoError(t, err, "Should successfully quick validate a block with transactions")
 
 		// Verify AddBlock was called with correct parameters
@@ -150,7 +108,85 @@ func TestQuickValidateBlock(t *testing.T) {
 		assert.True(t, sbo.MinedSet, "MinedSetting option should be true")
 		assert.True(t, sbo.SubtreesSet, "SubtreesSetting option should be true")
 		assert.Equal(t, uint64(1), sbo.ID, "ID option should be set to 1")
+
+		// the undo record of the block is kept for block assembly to revert the block in a reorg
+		undoBytes, err := suite.Server.subtreeStore.Get(suite.Ctx, block.Hash()[:], fileformat.FileTypeBlockUndo)
+		require.NoError(t, err)
+
+		undo, err := utxo.NewBlockUndoFromBytes(undoBytes)
+		require.NoError(t, err)
+		assert.Len(t, undo.Created, 3, "coinbase and the 2 transactions of the block")
 	})
+
+	t.Run("failed spend rolls back the block", func(t *testing.T) {
+		suite := NewCatchupTestSuite(t)
+		defer suite.Cleanup()
+
+		suite.MockBlockchain.On("GetNextBlockID", mock.Anything).Return(uint64(1), nil).Maybe()
+
+		block := createQuickValidateTestBlock(t, suite)
+
+		suite.MockUTXOStore.On("Create", mock.Anything, mock.Anything, uint32(100), mock.Anything).Return(&meta.Data{}, nil)
+		suite.MockUTXOStore.On("Spend", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*utxo.Spend{}, errors.NewTxInvalidError("double spend"))
+		suite.MockUTXOStore.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+		err := suite.Server.blockValidation.quickValidateBlock(suite.Ctx, block, "test")
+		require.Error(t, err)
+
+		// the block is not added and the created transactions are deleted again
+		suite.MockBlockchain.AssertNotCalled(t, "AddBlock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		suite.MockUTXOStore.AssertNumberOfCalls(t, "Delete", 3)
+		suite.MockUTXOStore.AssertNotCalled(t, "SetLocked", mock.Anything, mock.Anything, false)
+	})
+}
+
+// createQuickValidateTestBlock creates a block at height 100 with a coinbase and 2 transactions in 1 subtree, stored
+// in the subtree store of the suite
+func createQuickValidateTestBlock(t *testing.T, suite *CatchupTestSuite) *model.Block {
+	t.Helper()
+
+	// Create a transaction chain with coinbase + 2 regular transactions
+	txs := transactions.CreateTestTransactionChainWithCount(t, 4)
+	coinbaseTx := txs[0]
+	regularTxs := txs[1:] // txs[1], txs[2]
+
+	// Create block with the proper coinbase
+	block := testhelpers.CreateTestBlocks(t, 1)[0]
+	block.Height = 100
+	block.CoinbaseTx = coinbaseTx // Use the coinbase from our transaction chain
+
+	subtree, err := subtreepkg.NewIncompleteTreeByLeafCount(3)
+	require.NoError(t, err, "Should create subtree without error")
+
+	require.NoError(t, subtree.AddCoinbaseNode())
+	require.NoError(t, subtree.AddNode(*regularTxs[0].TxIDChainHash(), 1, 1))
+	require.NoError(t, subtree.AddNode(*regularTxs[1].TxIDChainHash(), 2, 2))
+
+	subtreeBytes, err := subtree.Serialize()
+	require.NoError(t, err, "Should serialize subtree without error")
+
+	err = suite.Server.subtreeStore.Set(t.Context(), subtree.RootHash()[:], fileformat.FileTypeSubtreeToCheck, subtreeBytes)
+	require.NoError(t, err, "Should store subtree without error")
+
+	subtreeData := subtreepkg.NewSubtreeData(subtree)
+	require.NoError(t, subtreeData.AddTx(coinbaseTx, 0), "Should add coinbase tx to subtree data without error")
+	require.NoError(t, subtreeData.AddTx(regularTxs[0], 1), "Should add tx 0 to subtree data without error")
+	require.NoError(t, subtreeData.AddTx(regularTxs[1], 2), "Should add tx 1 to subtree data without error")
+
+	subtreeDataBytes, err := subtreeData.Serialize()
+	require.NoError(t, err, "Should serialize subtree data without error")
+
+	err = suite.Server.subtreeStore.Set(t.Context(), subtree.RootHash()[:], fileformat.FileTypeSubtreeData, subtreeDataBytes)
+	require.NoError(t, err, "Should store subtree data without error")
+
+	block.Subtrees = []*chainhash.Hash{subtree.RootHash()}
+	block.TransactionCount = 3 // coinbase + 2 transactions
+
+	// Update the merkle root to match the subtree
+	block.Header.HashMerkleRoot, err = subtree.RootHashWithReplaceRootNode(coinbaseTx.TxIDChainHash(), 0, 0)
+	require.NoError(t, err, "Should create merkle root hash without error")
+
+	return block
 }
 
 // TestQuickValidationComponents tests the individual components of quick validation
@@ -173,7 +209,7 @@ func TestQuickValidationComponents(t *testing.T) {
 		}
 
 		// Execute createAllUTXOs
-		err := suite.Server.blockValidation.createAllUTXOs(suite.Ctx, block, txWrappers)
+		err := suite.Server.blockValidation.createAllUTXOs(suite.Ctx, utxo.BeginBlockTransaction(suite.MockUTXOStore), block, txWrappers)
 
 		// Verify success
 		assert.NoError(t, err, "Should successfully create all UTXOs")
@@ -194,7 +230,7 @@ func TestQuickValidationComponents(t *testing.T) {
 		suite.MockUTXOStore.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return((*meta.Data)(nil), errors.NewServiceError("database connection failed"))
 
 		// Execute createAllUTXOs
-		err := suite.Server.blockValidation.createAllUTXOs(suite.Ctx, block, txWrappers)
+		err := suite.Server.blockValidation.createAllUTXOs(suite.Ctx, utxo.BeginBlockTransaction(suite.MockUTXOStore), block, txWrappers)
 
 		// Verify error is propagated
 		assert.Error(t, err, "Should propagate UTXO creation errors")
@@ -217,7 +253,7 @@ func TestQuickValidationComponents(t *testing.T) {
 		}
 
 		// Execute createAllUTXOs
-		err := suite.Server.blockValidation.createAllUTXOs(suite.Ctx, block, txWrappers)
+		err := suite.Server.blockValidation.createAllUTXOs(suite.Ctx, utxo.BeginBlockTransaction(suite.MockUTXOStore), block, txWrappers)
 
 		// Verify success even with many transactions
 		assert.NoError(t, err, "Should handle large number of transactions with concurrency limits")
@@ -248,7 +284,7 @@ func TestValidateAllTransactions(t *testing.T) {
 		}
 
 		// Execute validateAllTransactions
-		err := suite.Server.blockValidation.spendAllTransactions(suite.Ctx, block, txWrappers)
+		err := suite.Server.blockValidation.spendAllTransactions(suite.Ctx, utxo.BeginBlockTransaction(suite.MockUTXOStore), block, txWrappers)
 
 		// Verify success
 		assert.NoError(t, err, "Should successfully validate all transactions")
@@ -273,7 +309,7 @@ func TestValidateAllTransactions(t *testing.T) {
 		}
 
 		// Execute validateAllTransactions
-		err := suite.Server.blockValidation.spendAllTransactions(suite.Ctx, block, txWrappers)
+		err := suite.Server.blockValidation.spendAllTransactions(suite.Ctx, utxo.BeginBlockTransaction(suite.MockUTXOStore), block, txWrappers)
 
 		// Verify success even with many transactions
 		assert.NoError(t, err, "Should handle large number of transactions with concurrency limits")
@@ -0,0 +1,112 @@
+// This file contains the streaming validation of subtree data fetched during catchup.
+package blockvalidation
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// subtreeDataStream passes subtree data read from a peer through, transaction by transaction, checking each
+// transaction against the subtree as it is read. Only the transaction being read is held in memory, instead of
+// all transactions of the subtree, so subtrees of multi-GB blocks are validated and stored with bounded memory.
+//
+// The data passed through is the serialized form of subtreepkg.Data: a coinbase transaction sent by the peer for
+// the coinbase placeholder is checked to be a coinbase, but not passed through.
+type subtreeDataStream struct {
+	subtree *subtreepkg.Subtree
+	reader  io.Reader
+	pending bytes.Buffer // bytes of the last validated transaction not yet read
+	index   int          // index in the subtree of the next transaction
+	txCount int          // transactions passed through
+	err     error        // error the stream ended with, io.EOF when all transactions were read and valid
+}
+
+// newSubtreeDataStream returns a stream validating the subtree data read from reader against the subtree
+func newSubtreeDataStream(subtree *subtreepkg.Subtree, reader io.Reader) *subtreeDataStream {
+	s := &subtreeDataStream{subtree: subtree, reader: reader}
+
+	if subtree == nil || len(subtree.Nodes) == 0 {
+		s.err = errors.NewProcessingError("subtree has no nodes")
+		return s
+	}
+
+	if subtree.Nodes[0].Hash.Equal(subtreepkg.CoinbasePlaceholderHashValue) {
+		s.index = 1
+	}
+
+	return s
+}
+
+// Read reads the validated subtree data
+func (s *subtreeDataStream) Read(p []byte) (int, error) {
+	for s.pending.Len() == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+
+		s.err = s.next()
+	}
+
+	return s.pending.Read(p)
+}
+
+// next reads and validates the next transaction into the pending bytes. Returns io.EOF at the end of the data,
+// when every transaction of the subtree was read.
+func (s *subtreeDataStream) next() error {
+	tx := &bt.Tx{}
+
+	// the bytes of the transaction are kept as read, to pass them through unchanged
+	if _, err := tx.ReadFrom(io.TeeReader(s.reader, &s.pending)); err != nil {
+		s.pending.Reset()
+
+		if errors.Is(err, io.EOF) {
+			if s.index != len(s.subtree.Nodes) {
+				return errors.NewProcessingError("subtree data is incomplete, %d of %d transactions received", s.index, len(s.subtree.Nodes))
+			}
+
+			return io.EOF
+		}
+
+		return errors.NewProcessingError("error reading transaction %d of subtree data", s.index, err)
+	}
+
+	if s.index == 1 && s.txCount == 0 && tx.IsCoinbase() {
+		// the coinbase of the coinbase placeholder is not part of the stored subtree data
+		s.pending.Reset()
+		s.txCount++
+
+		return nil
+	}
+
+	if s.index >= len(s.subtree.Nodes) {
+		s.pending.Reset()
+		return errors.NewProcessingError("subtree data has more than the %d transactions of the subtree", len(s.subtree.Nodes))
+	}
+
+	if !s.subtree.Nodes[s.index].Hash.Equal(*tx.TxIDChainHash()) {
+		s.pending.Reset()
+		return errors.NewProcessingError("transaction %s at index %d does not match subtree node %s", tx.TxIDChainHash().String(), s.index, s.subtree.Nodes[s.index].Hash.String())
+	}
+
+	s.index++
+	s.txCount++
+
+	return nil
+}
+
+// finish reads the rest of the data, for stores that did not consume all of it, and returns the error the
+// data failed validation with, if any
+func (s *subtreeDataStream) finish() error {
+	_, err := io.Copy(io.Discard, s)
+
+	return err
+}
+
+// Close implements io.Closer, the underlying reader is closed by the caller
+func (s *subtreeDataStream) Close() error {
+	return nil
+}
@@ -0,0 +1,165 @@
+package blockvalidation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/pkg/fileformat"
+	"github.com/bsv-blockchain/teranode/stores/blob/memory"
+	"github.com/bsv-blockchain/teranode/test/utils/transactions"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createStreamTestSubtree returns a subtree with a coinbase placeholder and the given transactions
+func createStreamTestSubtree(t *testing.T, txs []*bt.Tx) *subtreepkg.Subtree {
+	subtree, err := subtreepkg.NewIncompleteTreeByLeafCount(len(txs) + 1)
+	require.NoError(t, err)
+
+	require.NoError(t, subtree.AddCoinbaseNode())
+
+	for i, tx := range txs {
+		require.NoError(t, subtree.AddNode(*tx.TxIDChainHash(), uint64(i), uint64(i)))
+	}
+
+	return subtree
+}
+
+func serializeTxs(txs ...*bt.Tx) []byte {
+	var buf bytes.Buffer
+
+	for _, tx := range txs {
+		buf.Write(tx.SerializeBytes())
+	}
+
+	return buf.Bytes()
+}
+
+func TestSubtreeDataStream(t *testing.T) {
+	txs := transactions.CreateTestTransactionChainWithCount(t, 6)
+	subtree := createStreamTestSubtree(t, txs[1:4])
+
+	subtreeData := subtreepkg.NewSubtreeData(subtree)
+	for i := 1; i < 4; i++ {
+		require.NoError(t, subtreeData.AddTx(txs[i], i))
+	}
+
+	expected, err := subtreeData.Serialize()
+	require.NoError(t, err)
+
+	t.Run("passes the data of the subtree through", func(t *testing.T) {
+		stream := newSubtreeDataStream(subtree, bytes.NewReader(serializeTxs(txs[1:4]...)))
+
+		data, err := io.ReadAll(stream)
+		require.NoError(t, err)
+		assert.Equal(t, expected, data)
+		assert.Equal(t, 3, stream.txCount)
+		assert.NoError(t, stream.finish())
+	})
+
+	t.Run("drops the coinbase of the placeholder", func(t *testing.T) {
+		stream := newSubtreeDataStream(subtree, bytes.NewReader(serializeTxs(txs[0], txs[1], txs[2], txs[3])))
+
+		data, err := io.ReadAll(stream)
+		require.NoError(t, err)
+		assert.Equal(t, expected, data)
+	})
+
+	t.Run("reads in small chunks", func(t *testing.T) {
+		stream := newSubtreeDataStream(subtree, bytes.NewReader(serializeTxs(txs[1:4]...)))
+
+		var data []byte
+
+		buf := make([]byte, 7)
+
+		for {
+			n, err := stream.Read(buf)
+			data = append(data, buf[:n]...)
+
+			if err == io.EOF {
+				break
+			}
+
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, expected, data)
+	})
+
+	t.Run("rejects a transaction not in the subtree", func(t *testing.T) {
+		stream := newSubtreeDataStream(subtree, bytes.NewReader(serializeTxs(txs[1], txs[4], txs[3])))
+
+		_, err := io.ReadAll(stream)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match subtree node")
+	})
+
+	t.Run("rejects incomplete data", func(t *testing.T) {
+		stream := newSubtreeDataStream(subtree, bytes.NewReader(serializeTxs(txs[1], txs[2])))
+
+		_, err := io.ReadAll(stream)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "incomplete")
+	})
+
+	t.Run("rejects extra transactions", func(t *testing.T) {
+		stream := newSubtreeDataStream(subtree, bytes.NewReader(serializeTxs(txs[1], txs[2], txs[3], txs[4])))
+
+		_, err := io.ReadAll(stream)
+		require.Error(t, err)
+	})
+
+	t.Run("finish validates unread data", func(t *testing.T) {
+		stream := newSubtreeDataStream(subtree, bytes.NewReader(serializeTxs(txs[1], txs[4])))
+
+		require.Error(t, stream.finish())
+	})
+}
+
+func TestStreamSubtreeData(t *testing.T) {
+	ctx := context.Background()
+
+	txs := transactions.CreateTestTransactionChainWithCount(t, 6)
+	subtree := createStreamTestSubtree(t, txs[1:4])
+	subtreeHash := subtree.RootHash()
+
+	newServer := func() *Server {
+		return &Server{
+			logger:       ulogger.TestLogger{},
+			subtreeStore: memory.New(),
+			settings:     test.CreateBaseTestSettings(t),
+		}
+	}
+
+	t.Run("stores valid data", func(t *testing.T) {
+		server := newServer()
+
+		err := server.streamSubtreeData(ctx, subtreeHash, subtree, bytes.NewReader(serializeTxs(txs[1:4]...)), "peer", "http://peer", 100)
+		require.NoError(t, err)
+
+		stored, err := server.subtreeStore.Get(ctx, subtreeHash[:], fileformat.FileTypeSubtreeData)
+		require.NoError(t, err)
+
+		subtreeData, err := subtreepkg.NewSubtreeDataFromBytes(subtree, stored)
+		require.NoError(t, err)
+		assert.Equal(t, *txs[3].TxIDChainHash(), *subtreeData.Txs[3].TxIDChainHash())
+	})
+
+	t.Run("removes invalid data", func(t *testing.T) {
+		server := newServer()
+
+		err := server.streamSubtreeData(ctx, subtreeHash, subtree, bytes.NewReader(serializeTxs(txs[1], txs[2])), "peer", "http://peer", 100)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid subtree data")
+
+		exists, err := server.subtreeStore.Exists(ctx, subtreeHash[:], fileformat.FileTypeSubtreeData)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,159 @@
+// Package canary implements the canary mode of a node, used to soak-test new teranode releases against a network.
+//
+// A canary node validates blocks and computes all state transitions like any other node, but it does not serve
+// its DataHub to peers and does not announce blocks: the canary mode forces the listen_only P2P listen mode, and the
+// asset service refuses the requests of peers. The canary service periodically compares the chain tip of the node
+// with the tip of a reference node, running a trusted release, through the asset API of the reference node. The
+// result is exported as Prometheus metrics, and an alert is logged, and posted to a webhook when configured, when
+// the chains diverge or the node trails the reference node by more than the allowed number of blocks.
+package canary
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/asset/apiclient"
+	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/health"
+)
+
+// referenceRequestTimeout bounds the requests to the reference node
+const referenceRequestTimeout = 30 * time.Second
+
+// Server compares the chain of the node with the chain of a reference node
+type Server struct {
+	logger           ulogger.Logger
+	settings         *settings.Settings
+	blockchainClient blockchain.ClientI
+	reference        *apiclient.Client
+	alerter          *alerter
+
+	mu   sync.RWMutex
+	last *Comparison // result of the last successful comparison, nil before the first one
+}
+
+// New returns the canary service of the node, comparing its chain with the reference node configured in the
+// canary settings
+func New(logger ulogger.Logger, tSettings *settings.Settings, blockchainClient blockchain.ClientI) *Server {
+	return &Server{
+		logger:           logger,
+		settings:         tSettings,
+		blockchainClient: blockchainClient,
+		reference:        apiclient.NewClient(tSettings.Canary.ReferenceURL, &http.Client{Timeout: referenceRequestTimeout}),
+		alerter:          newAlerter(logger, tSettings),
+	}
+}
+
+// Health reports the readiness of the blockchain client, the details list the last comparison with the reference
+// node. A diverged chain does not make the service unhealthy, the node keeps running so it can be investigated.
+func (s *Server) Health(ctx context.Context, checkLiveness bool) (int, string, error) {
+	if checkLiveness {
+		return http.StatusOK, "OK", nil
+	}
+
+	checks := make([]health.Check, 0, 2)
+
+	if s.blockchainClient != nil {
+		checks = append(checks, health.Check{Name: "BlockchainClient", Check: s.blockchainClient.Health})
+	}
+
+	checks = append(checks, health.Check{Name: "Reference", Check: s.referenceHealth})
+
+	return health.CheckAll(ctx, checkLiveness, checks)
+}
+
+// referenceHealth reports the last comparison with the reference node
+func (s *Server) referenceHealth(_ context.Context, _ bool) (int, string, error) {
+	last := s.Last()
+	if last == nil {
+		return http.StatusOK, "not compared yet", nil
+	}
+
+	return http.StatusOK, string(last.Status), nil
+}
+
+// Init validates the canary settings
+func (s *Server) Init(_ context.Context) error {
+	initPrometheusMetrics()
+
+	if s.settings.Canary.ReferenceURL == "" {
+		return errors.NewConfigurationError("canary_reference_url must be set when canary_enabled is true")
+	}
+
+	if s.settings.Canary.CheckInterval <= 0 {
+		return errors.NewConfigurationError("canary_check_interval must be positive (got %s)", s.settings.Canary.CheckInterval)
+	}
+
+	return nil
+}
+
+// Start compares the chain of the node with the chain of the reference node every check interval, until ctx is done
+func (s *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
+	close(readyCh)
+
+	s.logger.Infof("[Canary] comparing the chain with reference node %s every %s", s.settings.Canary.ReferenceURL, s.settings.Canary.CheckInterval)
+
+	ticker := time.NewTicker(s.settings.Canary.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		s.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops the service, the comparisons end with the context of Start
+func (s *Server) Stop(_ context.Context) error {
+	return nil
+}
+
+// Last returns the result of the last successful comparison with the reference node, nil before the first one
+func (s *Server) Last() *Comparison {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.last
+}
+
+// check compares the chain with the reference node once, and updates the metrics and the alerts with the result
+func (s *Server) check(ctx context.Context) {
+	c, err := s.compare(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			prometheusCanaryCheckErrors.Inc()
+			s.logger.Warnf("[Canary] failed to compare the chain with the reference node: %v", err)
+		}
+
+		return
+	}
+
+	s.mu.Lock()
+	s.last = c
+	s.mu.Unlock()
+
+	observe(c)
+
+	s.alerter.update(ctx, c, s.alertFor(c))
+}
+
+// alertFor returns the alert raised by a comparison, empty when there is none
+func (s *Server) alertFor(c *Comparison) alertKind {
+	switch {
+	case c.Status == StatusDiverged:
+		return alertDiverged
+	case c.Lag > int64(s.settings.Canary.MaxLag):
+		return alertLagging
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,194 @@
+package canary
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testHeader returns a block header, headers with different nonces have different hashes
+func testHeader(nonce uint32) *model.BlockHeader {
+	return &model.BlockHeader{
+		Version:        1,
+		HashPrevBlock:  &chainhash.Hash{},
+		HashMerkleRoot: &chainhash.Hash{},
+		Nonce:          nonce,
+	}
+}
+
+// referenceNode serves the best block header and the headers of the chain of a reference node on the asset API
+type referenceNode struct {
+	chain []*model.BlockHeader // the header at index i is at height i
+}
+
+func (r *referenceNode) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	tip := len(r.chain) - 1
+
+	switch {
+	case req.URL.Path == "/api/v1/bestblockheader/json":
+		_ = json.NewEncoder(w).Encode(Tip{Hash: r.chain[tip].Hash().String(), Height: uint32(tip)})
+	case strings.HasPrefix(req.URL.Path, "/api/v1/headers/"):
+		headers := make([]Tip, 0, len(r.chain))
+		for height := tip; height >= 0; height-- {
+			headers = append(headers, Tip{Hash: r.chain[height].Hash().String(), Height: uint32(height)})
+		}
+
+		_ = json.NewEncoder(w).Encode(headers)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func newTestServer(t *testing.T, reference *referenceNode, local []*model.BlockHeader, webhookURL string) *Server {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.Canary.Enabled = true
+	tSettings.Canary.MaxLag = 2
+	tSettings.Canary.AlertWebhookURL = webhookURL
+
+	ref := httptest.NewServer(reference)
+	t.Cleanup(ref.Close)
+
+	tSettings.Canary.ReferenceURL = ref.URL + "/api/v1"
+
+	tip := len(local) - 1
+
+	blockchainClient := &blockchain.Mock{}
+	blockchainClient.On("GetBestBlockHeader", mock.Anything).
+		Return(local[tip], &model.BlockHeaderMeta{Height: uint32(tip)}, nil)
+
+	for height, header := range local {
+		blockchainClient.On("GetBlockHeadersByHeight", mock.Anything, uint32(height), uint32(height)).
+			Return([]*model.BlockHeader{header}, []*model.BlockHeaderMeta{{Height: uint32(height)}}, nil)
+	}
+
+	s := New(ulogger.TestLogger{}, tSettings, blockchainClient)
+	require.NoError(t, s.Init(context.Background()))
+
+	return s
+}
+
+func TestCompare(t *testing.T) {
+	chain := []*model.BlockHeader{testHeader(0), testHeader(1), testHeader(2), testHeader(3), testHeader(4)}
+	fork := []*model.BlockHeader{chain[0], chain[1], testHeader(102), testHeader(103)}
+
+	tests := []struct {
+		name       string
+		reference  []*model.BlockHeader
+		local      []*model.BlockHeader
+		status     Status
+		lag        int64
+		divergedAt uint32
+	}{
+		{name: "in sync", reference: chain, local: chain, status: StatusInSync},
+		{name: "ahead", reference: chain[:3], local: chain, status: StatusAhead, lag: -2},
+		{name: "behind", reference: chain, local: chain[:2], status: StatusBehind, lag: 3},
+		{name: "diverged at the same height", reference: chain[:4], local: fork, status: StatusDiverged, divergedAt: 3},
+		{name: "diverged while ahead", reference: chain[:3], local: fork, status: StatusDiverged, lag: -1, divergedAt: 2},
+		{name: "diverged while behind", reference: chain, local: fork[:3], status: StatusDiverged, lag: 2, divergedAt: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, &referenceNode{chain: tt.reference}, tt.local, "")
+
+			c, err := s.compare(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.status, c.Status)
+			assert.Equal(t, tt.lag, c.Lag)
+			assert.Equal(t, tt.divergedAt, c.DivergedAt)
+			assert.Equal(t, uint32(len(tt.local)-1), c.Local.Height)
+			assert.Equal(t, uint32(len(tt.reference)-1), c.Reference.Height)
+		})
+	}
+
+	t.Run("reference unreachable", func(t *testing.T) {
+		s := newTestServer(t, &referenceNode{chain: chain}, chain, "")
+		s.settings.Canary.ReferenceURL = "http://127.0.0.1:1/api/v1"
+		s.reference = New(ulogger.TestLogger{}, s.settings, s.blockchainClient).reference
+
+		_, err := s.compare(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func TestCheck_Alerts(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		alerts []Alert
+	)
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert Alert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		mu.Lock()
+		alerts = append(alerts, alert)
+		mu.Unlock()
+	}))
+	defer webhook.Close()
+
+	chain := []*model.BlockHeader{testHeader(0), testHeader(1), testHeader(2), testHeader(3)}
+	fork := []*model.BlockHeader{chain[0], chain[1], testHeader(102), testHeader(103)}
+
+	reference := &referenceNode{chain: chain}
+	s := newTestServer(t, reference, fork, webhook.URL)
+
+	// an ongoing divergence is reported once
+	s.check(context.Background())
+	s.check(context.Background())
+
+	require.NotNil(t, s.Last())
+	assert.Equal(t, StatusDiverged, s.Last().Status)
+
+	mu.Lock()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, alertFiring, alerts[0].Status)
+	assert.Equal(t, alertDiverged, alerts[0].Alert)
+	assert.Equal(t, uint32(3), alerts[0].Comparison.DivergedAt)
+	mu.Unlock()
+
+	// the divergence resolves once the reference node moved to the chain of the node
+	reference.chain = fork
+	s.check(context.Background())
+
+	assert.Equal(t, StatusInSync, s.Last().Status)
+
+	mu.Lock()
+	require.Len(t, alerts, 2)
+	assert.Equal(t, alertResolved, alerts[1].Status)
+	assert.Equal(t, alertDiverged, alerts[1].Alert)
+	mu.Unlock()
+}
+
+func TestAlertFor(t *testing.T) {
+	s := newTestServer(t, &referenceNode{chain: []*model.BlockHeader{testHeader(0)}}, []*model.BlockHeader{testHeader(0)}, "")
+
+	assert.Equal(t, alertDiverged, s.alertFor(&Comparison{Status: StatusDiverged}))
+	assert.Equal(t, alertLagging, s.alertFor(&Comparison{Status: StatusBehind, Lag: 3}))
+	assert.Equal(t, alertKind(""), s.alertFor(&Comparison{Status: StatusBehind, Lag: 2}))
+	assert.Equal(t, alertKind(""), s.alertFor(&Comparison{Status: StatusAhead, Lag: -5}))
+}
+
+func TestInit_RequiresReferenceURL(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.Canary.Enabled = true
+	tSettings.Canary.ReferenceURL = ""
+
+	s := New(ulogger.TestLogger{}, tSettings, &blockchain.Mock{})
+	require.Error(t, s.Init(context.Background()))
+}
@@ -0,0 +1,148 @@
+package canary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+)
+
+// alertKind is the condition an alert is raised for
+type alertKind string
+
+const (
+	alertDiverged alertKind = "diverged" // the chain of the node diverged from the chain of the reference node
+	alertLagging  alertKind = "lagging"  // the node trails the reference node by more than the allowed lag
+)
+
+const (
+	alertFiring   = "firing"
+	alertResolved = "resolved"
+
+	webhookTimeout = 10 * time.Second
+)
+
+// Alert is the JSON document posted to the alert webhook when an alert fires or resolves
+type Alert struct {
+	Status     string     `json:"status"` // firing or resolved
+	Alert      alertKind  `json:"alert"`
+	Node       string     `json:"node"`
+	Version    string     `json:"version"`
+	Message    string     `json:"message"`
+	Comparison Comparison `json:"comparison"`
+	At         time.Time  `json:"at"`
+}
+
+// alerter raises an alert when a condition starts, and resolves it when the condition ends, so an ongoing
+// divergence is reported once instead of at every comparison
+type alerter struct {
+	logger     ulogger.Logger
+	node       string
+	version    string
+	webhookURL string
+	httpClient *http.Client
+
+	active alertKind
+}
+
+func newAlerter(logger ulogger.Logger, tSettings *settings.Settings) *alerter {
+	return &alerter{
+		logger:     logger,
+		node:       tSettings.ClientName,
+		version:    tSettings.Version,
+		webhookURL: tSettings.Canary.AlertWebhookURL,
+		httpClient: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// update moves the alert to kind for comparison c, resolving the active alert and firing the new one when it
+// changed. update is called from a single goroutine.
+func (a *alerter) update(ctx context.Context, c *Comparison, kind alertKind) {
+	if kind == a.active {
+		return
+	}
+
+	if a.active != "" {
+		a.logger.Infof("[Canary] alert %s resolved: %s", a.active, describe(c))
+		a.post(ctx, a.newAlert(alertResolved, a.active, c))
+	}
+
+	a.active = kind
+
+	if kind != "" {
+		prometheusCanaryAlerts.WithLabelValues(string(kind)).Inc()
+		a.logger.Errorf("[Canary] alert %s: %s", kind, describe(c))
+		a.post(ctx, a.newAlert(alertFiring, kind, c))
+	}
+}
+
+func (a *alerter) newAlert(status string, kind alertKind, c *Comparison) Alert {
+	return Alert{
+		Status:     status,
+		Alert:      kind,
+		Node:       a.node,
+		Version:    a.version,
+		Message:    describe(c),
+		Comparison: *c,
+		At:         time.Now().UTC(),
+	}
+}
+
+// post posts the alert to the webhook, when configured
+func (a *alerter) post(ctx context.Context, alert Alert) {
+	if a.webhookURL == "" {
+		return
+	}
+
+	if err := a.send(ctx, alert); err != nil {
+		a.logger.Errorf("[Canary] failed to post alert %s (%s) to the webhook: %v", alert.Alert, alert.Status, err)
+	}
+}
+
+func (a *alerter) send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return errors.NewProcessingError("failed to encode the alert", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.NewServiceError("failed to create the webhook request", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return errors.NewServiceError("failed to post to the webhook", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.NewServiceError("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// describe returns a human readable description of a comparison
+func describe(c *Comparison) string {
+	switch c.Status {
+	case StatusDiverged:
+		return fmt.Sprintf("chain diverged from the reference node at height %d, local tip %s (%d), reference tip %s (%d)",
+			c.DivergedAt, c.Local.Hash, c.Local.Height, c.Reference.Hash, c.Reference.Height)
+	case StatusBehind:
+		return fmt.Sprintf("%d blocks behind the reference node, local tip %s (%d), reference tip %s (%d)",
+			c.Lag, c.Local.Hash, c.Local.Height, c.Reference.Hash, c.Reference.Height)
+	default:
+		return fmt.Sprintf("%s with the reference node, local tip %s (%d), reference tip %s (%d)",
+			c.Status, c.Local.Hash, c.Local.Height, c.Reference.Hash, c.Reference.Height)
+	}
+}
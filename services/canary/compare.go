@@ -0,0 +1,141 @@
+package canary
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/asset/apiclient"
+)
+
+// Status is the state of the chain of the node compared with the chain of the reference node
+type Status string
+
+const (
+	StatusInSync   Status = "in_sync"  // both nodes are at the same tip
+	StatusAhead    Status = "ahead"    // the tip of the reference node is an ancestor of the tip of the node
+	StatusBehind   Status = "behind"   // the tip of the node is an ancestor of the tip of the reference node, or too far behind to tell
+	StatusDiverged Status = "diverged" // the nodes accepted different blocks at the same height
+)
+
+// maxComparedHeaders is the number of headers the asset API returns at most, a node trailing the reference node by
+// more blocks is reported behind without checking whether its tip is on the chain of the reference node
+const maxComparedHeaders = 1000
+
+// Tip is the best block of a node
+type Tip struct {
+	Hash   string `json:"hash"`
+	Height uint32 `json:"height"`
+}
+
+// Comparison is the result of comparing the chain of the node with the chain of the reference node
+type Comparison struct {
+	Status    Status `json:"status"`
+	Local     Tip    `json:"local"`
+	Reference Tip    `json:"reference"`
+	// Lag is the number of blocks the node trails the reference node, negative when the node is ahead
+	Lag int64 `json:"lag"`
+	// DivergedAt is the height at which the blocks of the nodes differ, when diverged, the chains forked at or
+	// below this height
+	DivergedAt uint32 `json:"diverged_at,omitempty"`
+}
+
+// compare compares the best block of the node with the best block of the reference node. The chains diverged when
+// the nodes hold different blocks at the height of the lowest of both tips.
+func (s *Server) compare(ctx context.Context) (*Comparison, error) {
+	reference, err := s.referenceTip(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header, meta, err := s.blockchainClient.GetBestBlockHeader(ctx)
+	if err != nil {
+		return nil, errors.NewServiceError("failed to get the best block header", err)
+	}
+
+	c := &Comparison{
+		Local:     Tip{Hash: header.Hash().String(), Height: meta.Height},
+		Reference: *reference,
+		Lag:       int64(reference.Height) - int64(meta.Height),
+	}
+
+	switch {
+	case c.Lag <= 0:
+		// the block of the node at the height of the reference tip
+		headers, _, err := s.blockchainClient.GetBlockHeadersByHeight(ctx, reference.Height, reference.Height)
+		if err != nil {
+			return nil, errors.NewServiceError("failed to get the block header at height %d", reference.Height, err)
+		}
+
+		switch {
+		case len(headers) == 0:
+			return nil, errors.NewProcessingError("no block header at height %d", reference.Height)
+		case headers[0].Hash().String() != reference.Hash:
+			c.Status, c.DivergedAt = StatusDiverged, reference.Height
+		case c.Lag == 0:
+			c.Status = StatusInSync
+		default:
+			c.Status = StatusAhead
+		}
+	case c.Lag >= maxComparedHeaders:
+		c.Status = StatusBehind
+	default:
+		// the block of the reference node at the height of the tip of the node
+		hash, err := s.referenceHashAt(ctx, reference, meta.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		if hash == c.Local.Hash {
+			c.Status = StatusBehind
+		} else {
+			c.Status, c.DivergedAt = StatusDiverged, meta.Height
+		}
+	}
+
+	return c, nil
+}
+
+// referenceTip returns the best block of the reference node
+func (s *Server) referenceTip(ctx context.Context) (*Tip, error) {
+	b, err := s.reference.GetBestBlockHeaderJSON(ctx)
+	if err != nil {
+		return nil, errors.NewServiceError("failed to get the best block header of the reference node", err)
+	}
+
+	var tip Tip
+	if err = json.Unmarshal(b, &tip); err != nil {
+		return nil, errors.NewProcessingError("invalid best block header of the reference node", err)
+	}
+
+	if _, err = chainhash.NewHashFromStr(tip.Hash); err != nil {
+		return nil, errors.NewProcessingError("invalid best block hash %q of the reference node", tip.Hash, err)
+	}
+
+	return &tip, nil
+}
+
+// referenceHashAt returns the hash of the block of the reference node at height, an ancestor of its tip
+func (s *Server) referenceHashAt(ctx context.Context, tip *Tip, height uint32) (string, error) {
+	n := tip.Height - height + 1
+
+	headers, err := s.reference.GetBlockHeadersJSON(ctx, tip.Hash, &apiclient.GetBlockHeadersJSONParams{N: strconv.FormatUint(uint64(n), 10)})
+	if err != nil {
+		return "", errors.NewServiceError("failed to get the block headers of the reference node", err)
+	}
+
+	for _, raw := range headers {
+		var header Tip
+		if err = json.Unmarshal(raw, &header); err != nil {
+			return "", errors.NewProcessingError("invalid block header of the reference node", err)
+		}
+
+		if header.Height == height {
+			return header.Hash, nil
+		}
+	}
+
+	return "", errors.NewProcessingError("reference node returned no block header at height %d", height)
+}
@@ -0,0 +1,129 @@
+package canary
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// prometheusCanaryLocalHeight is the height of the best block of the node at the last comparison
+	prometheusCanaryLocalHeight prometheus.Gauge
+
+	// prometheusCanaryReferenceHeight is the height of the best block of the reference node at the last comparison
+	prometheusCanaryReferenceHeight prometheus.Gauge
+
+	// prometheusCanaryLag is the number of blocks the node trails the reference node, negative when it is ahead
+	prometheusCanaryLag prometheus.Gauge
+
+	// prometheusCanaryDiverged is 1 while the chain of the node diverged from the chain of the reference node
+	prometheusCanaryDiverged prometheus.Gauge
+
+	// prometheusCanaryStatus is 1 for the status of the last comparison and 0 for the other statuses.
+	// Labels: status
+	prometheusCanaryStatus *prometheus.GaugeVec
+
+	// prometheusCanaryAlerts counts the alerts raised.
+	// Labels: alert
+	prometheusCanaryAlerts *prometheus.CounterVec
+
+	// prometheusCanaryCheckErrors counts the comparisons that failed, e.g. because the reference node was unreachable
+	prometheusCanaryCheckErrors prometheus.Counter
+)
+
+var (
+	prometheusMetricsInitOnce sync.Once
+)
+
+func initPrometheusMetrics() {
+	prometheusMetricsInitOnce.Do(_initPrometheusMetrics)
+}
+
+func _initPrometheusMetrics() {
+	prometheusCanaryLocalHeight = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "canary",
+			Name:      "local_height",
+			Help:      "Height of the best block of the node at the last comparison with the reference node",
+		},
+	)
+
+	prometheusCanaryReferenceHeight = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "canary",
+			Name:      "reference_height",
+			Help:      "Height of the best block of the reference node at the last comparison",
+		},
+	)
+
+	prometheusCanaryLag = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "canary",
+			Name:      "lag_blocks",
+			Help:      "Number of blocks the node trails the reference node, negative when it is ahead",
+		},
+	)
+
+	prometheusCanaryDiverged = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "canary",
+			Name:      "diverged",
+			Help:      "1 while the chain of the node diverged from the chain of the reference node",
+		},
+	)
+
+	prometheusCanaryStatus = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "canary",
+			Name:      "status",
+			Help:      "1 for the status of the last comparison with the reference node, 0 for the other statuses",
+		},
+		[]string{"status"},
+	)
+
+	prometheusCanaryAlerts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "canary",
+			Name:      "alerts_total",
+			Help:      "Number of canary alerts raised",
+		},
+		[]string{"alert"},
+	)
+
+	prometheusCanaryCheckErrors = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "canary",
+			Name:      "check_errors_total",
+			Help:      "Number of comparisons with the reference node that failed",
+		},
+	)
+}
+
+// observe exports the result of a comparison
+func observe(c *Comparison) {
+	prometheusCanaryLocalHeight.Set(float64(c.Local.Height))
+	prometheusCanaryReferenceHeight.Set(float64(c.Reference.Height))
+	prometheusCanaryLag.Set(float64(c.Lag))
+
+	if c.Status == StatusDiverged {
+		prometheusCanaryDiverged.Set(1)
+	} else {
+		prometheusCanaryDiverged.Set(0)
+	}
+
+	for _, status := range []Status{StatusInSync, StatusAhead, StatusBehind, StatusDiverged} {
+		if status == c.Status {
+			prometheusCanaryStatus.WithLabelValues(string(status)).Set(1)
+		} else {
+			prometheusCanaryStatus.WithLabelValues(string(status)).Set(0)
+		}
+	}
+}
@@ -47,7 +47,6 @@ import (
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/ordishs/go-utils/expiringmap"
-	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -1918,7 +1917,7 @@ func (sm *SyncManager) QueueInv(inv *wire.MsgInv, peer *peerpkg.Peer) {
 		if len(invTxMsg.InvList) > 0 {
 			msg := sm.newKafkaMessageFromInv(invTxMsg, peer)
 
-			value, err := proto.Marshal(msg)
+			value, err := kafkamessage.Encode(msg)
 			if err != nil {
 				sm.logger.Errorf("failed to marshal kafka inv topic message: %v", err)
 				return
@@ -2284,7 +2283,7 @@ func (sm *SyncManager) kafkaINVListener(ctx context.Context, kafkaURL *url.URL,
 	kafka.StartKafkaListener(ctx, sm.logger, kafkaURL, groupID, true, func(msg *kafka.KafkaMessage) error {
 		var message kafkamessage.KafkaInvTopicMessage
 
-		err := proto.Unmarshal(msg.Value, &message)
+		err := kafkamessage.Decode(msg.Value, &message)
 		if err != nil {
 			sm.logger.Errorf("[kafkaINVListener] failed to unmarshal kafka inv topic message: %v", err)
 			return nil // ignore any errors, the message might be old and/or the peer is already disconnected
@@ -2321,7 +2320,7 @@ func (sm *SyncManager) kafkaBlocksFinalListener(ctx context.Context, kafkaURL *u
 		}
 
 		var blockMsg kafkamessage.KafkaBlocksFinalTopicMessage
-		if err := proto.Unmarshal(msg.Value, &blockMsg); err != nil {
+		if err := kafkamessage.Decode(msg.Value, &blockMsg); err != nil {
 			sm.logger.Errorf("[kafkaBlocksFinalListener][%s] failed to unmarshal kafka block topic message: %v", hash, err)
 			// not going to retry, if we cannot parse the message
 			return nil
@@ -2347,7 +2346,7 @@ func (sm *SyncManager) kafkaBlocksFinalListener(ctx context.Context, kafkaURL *u
 func (sm *SyncManager) kafkaTXmetaListener(ctx context.Context, kafkaURL *url.URL, groupID string) {
 	kafka.StartKafkaListener(ctx, sm.logger, kafkaURL, groupID, true, func(msg *kafka.KafkaMessage) error {
 		var kafkaMsg kafkamessage.KafkaTxMetaTopicMessage
-		if err := proto.Unmarshal(msg.Value, &kafkaMsg); err != nil {
+		if err := kafkamessage.Decode(msg.Value, &kafkaMsg); err != nil {
 			sm.logger.Errorf("Failed to unmarshal kafka message: %v", err)
 			return errors.New(errors.ERR_INVALID_ARGUMENT, "Failed to unmarshal kafka message", err)
 		}
@@ -33,7 +33,6 @@ import (
 	"github.com/bsv-blockchain/teranode/util/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/proto"
 )
 
 // nullTime is an empty time defined for convenience
@@ -300,7 +299,7 @@ func TestSyncManager_QueueInv(t *testing.T) {
 			msg := <-legacyKafkaInvCh
 
 			var value kafkamessage.KafkaInvTopicMessage
-			err := proto.Unmarshal(msg.Value, &value)
+			err := kafkamessage.Decode(msg.Value, &value)
 			require.NoError(t, err)
 
 			wireInvMsg, err := sm.newInvFromKafkaMessage(&value)
@@ -369,7 +368,7 @@ func TestSyncManager_QueueInv(t *testing.T) {
 			msg := <-legacyKafkaInvCh
 
 			var value kafkamessage.KafkaInvTopicMessage
-			err := proto.Unmarshal(msg.Value, &value)
+			err := kafkamessage.Decode(msg.Value, &value)
 			require.NoError(t, err)
 
 			wireInvMsg, err := sm.newInvFromKafkaMessage(&value)
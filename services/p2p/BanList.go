@@ -6,7 +6,7 @@ package p2p
 // restricting their access to the node.
 //
 // The ban system supports:
-// - IP-based and subnet-based banning
+// - IP-based and subnet-based banning, for IPv4 and IPv6 addresses and prefixes
 // - Time-limited bans with automatic expiration
 // - Persistent storage of ban information across restarts
 // - Event notifications for ban-related actions
@@ -16,6 +16,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/netip"
 	"strings"
 	"sync"
 	"time"
@@ -25,7 +26,9 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/blockchain"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/clock"
 	"github.com/bsv-blockchain/teranode/util/usql"
+	ma "github.com/multiformats/go-multiaddr"
 )
 
 var (
@@ -53,13 +56,20 @@ type BanInfo struct {
 // and can be used for logging, metrics collection, and coordination with
 // other network components like the P2P node.
 type BanEvent struct {
-	Action string     // Action type ("add" for new bans or "remove" for unbanning)
+	Action string     // Action type ("add" for new bans, "remove" for unbanning or "expire" for expired bans)
 	PeerID string     // Peer ID involved in the ban action (primary identifier)
 	IP     string     // IP address (for logging/backward compatibility)
 	Subnet *net.IPNet // Subnet information if the ban applies to a network range
 	Reason string     // Optional reason for the ban
 }
 
+// BanEntry is a banned IP address, subnet or peer ID with the time its ban expires. IP addresses and subnets
+// are listed in their normalized form, see NormalizeBanAddress.
+type BanEntry struct {
+	Banned  string    // IP address, subnet in CIDR notation or peer ID
+	UnbanAt time.Time // Time when the ban expires
+}
+
 // BanListI defines the interface for peer banning functionality.
 // This interface abstracts the implementation details of ban management,
 // allowing different implementations to be used (such as in-memory, database-backed,
@@ -80,8 +90,10 @@ type BanListI interface {
 	IsBanned(ipStr string) bool
 
 	// Add adds an IP or subnet to the ban list with an expiration time.
-	// For individual IPs, the ipOrSubnet parameter should be a valid IP address.
-	// For subnets, it should be in CIDR notation (e.g., "192.168.1.0/24").
+	// For individual IPs, the ipOrSubnet parameter should be a valid IPv4 or IPv6 address,
+	// optionally with a port, or a multiaddr with an ip4 or ip6 component.
+	// For subnets, it should be in CIDR notation (e.g., "192.168.1.0/24" or "2001:db8::/64").
+	// The ban is stored under the normalized form of the address, see NormalizeBanAddress.
 	//
 	// Parameters:
 	// - ctx: Context for the operation, allowing for cancellation
@@ -107,6 +119,15 @@ type BanListI interface {
 	// Returns a slice of strings representing banned IPs and subnets
 	ListBanned() []string
 
+	// ListBans returns the banned IP addresses and subnets with the time their ban expires.
+	ListBans() []BanEntry
+
+	// SweepExpired removes the bans that expired from the ban list and its storage,
+	// and notifies the subscribers with an "expire" event for each of them.
+	//
+	// Returns the IP addresses and subnets whose ban expired
+	SweepExpired(ctx context.Context) ([]string, error)
+
 	// Subscribe returns a channel to receive ban events
 	Subscribe() chan BanEvent
 
@@ -229,7 +250,7 @@ func (b *BanList) Add(ctx context.Context, ipOrSubnet string, expirationTime tim
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	subnet, err := parseAddress(ipOrSubnet)
+	ipOrSubnet, subnet, err := normalizeBanAddress(ipOrSubnet)
 	if err != nil {
 		b.logger.Errorf("error parsing ip or subnet: %v", err)
 		return err
@@ -263,7 +284,7 @@ func (b *BanList) Remove(ctx context.Context, ipOrSubnet string) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	subnet, err := parseAddress(ipOrSubnet)
+	ipOrSubnet, subnet, err := normalizeBanAddress(ipOrSubnet)
 	if err != nil {
 		b.logger.Errorf("Invalid IP address or subnet: %s", ipOrSubnet)
 		return err
@@ -286,6 +307,8 @@ func (b *BanList) Remove(ctx context.Context, ipOrSubnet string) error {
 }
 
 // IsBanned checks if a given IP address is currently banned.
+// The address is normalized first, so an address banned in one representation is banned in all of them,
+// e.g. with or without a port, as a multiaddr or as an IPv4-mapped IPv6 address.
 // Parameters:
 //   - ipStr: IP address to check
 //
@@ -296,30 +319,27 @@ func (b *BanList) IsBanned(ipStr string) bool {
 		return false
 	}
 
-	// Strip port from IP address (handles both IPv4 and IPv6)
-	host, _, err := net.SplitHostPort(ipStr)
-	if err == nil {
-		// Successfully split host and port
-		ipStr = host
+	key, _, err := normalizeBanAddress(ipStr)
+	if err != nil {
+		b.logger.Errorf("Invalid IP address passed to IsBanned: %s", ipStr)
+		return false
 	}
-	// If SplitHostPort fails, assume it's just an IP without port
 
 	// First try direct lookup in our map
 	b.mu.RLock()
-	if info, exists := b.bannedPeers[ipStr]; exists {
-		isBanned := info.ExpirationTime.After(time.Now())
+	if info, exists := b.bannedPeers[key]; exists {
+		isBanned := info.ExpirationTime.After(clock.Now())
 		b.mu.RUnlock()
 
 		return isBanned
 	}
 	b.mu.RUnlock()
 
-	// Try to parse the IP address
-	ip := net.ParseIP(ipStr)
-	if ip == nil {
-		// Not a valid IP address - no need to log this for test cases
-		b.logger.Errorf("Invalid IP address passed to IsBanned: %s", ipStr)
-		return false
+	prefix, err := netip.ParsePrefix(key)
+	if err != nil {
+		// a single address, which is checked against the banned subnets as a single address prefix
+		addr, _ := netip.ParseAddr(key)
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
 	}
 
 	// Check each subnet
@@ -333,7 +353,7 @@ func (b *BanList) IsBanned(ipStr string) bool {
 
 	for key, info := range b.bannedPeers {
 		// Mark expired bans for deletion
-		if !info.ExpirationTime.After(time.Now()) {
+		if !info.ExpirationTime.After(clock.Now()) {
 			expiredKeys = append(expiredKeys, key)
 			continue
 		}
@@ -343,8 +363,8 @@ func (b *BanList) IsBanned(ipStr string) bool {
 			continue
 		}
 
-		// Check if IP is in this subnet
-		if info.Subnet != nil && info.Subnet.Contains(ip) {
+		// Check if the IP, or the whole subnet checked, is in this subnet
+		if banned, ok := ipNetToPrefix(info.Subnet); ok && banned.Bits() <= prefix.Bits() && banned.Contains(prefix.Addr()) {
 			isBanned = true
 			break
 		}
@@ -356,7 +376,7 @@ func (b *BanList) IsBanned(ipStr string) bool {
 		b.mu.Lock()
 		for _, key := range expiredKeys {
 			// Double-check the entry is still expired (it might have been updated)
-			if info, exists := b.bannedPeers[key]; exists && !info.ExpirationTime.After(time.Now()) {
+			if info, exists := b.bannedPeers[key]; exists && !info.ExpirationTime.After(clock.Now()) {
 				delete(b.bannedPeers, key)
 			}
 		}
@@ -380,6 +400,63 @@ func (b *BanList) ListBanned() []string {
 	return banned
 }
 
+// ListBans returns all banned IP addresses and subnets with the time their ban expires
+func (b *BanList) ListBans() []BanEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	bans := make([]BanEntry, 0, len(b.bannedPeers))
+
+	for key, info := range b.bannedPeers {
+		bans = append(bans, BanEntry{Banned: key, UnbanAt: info.ExpirationTime})
+	}
+
+	return bans
+}
+
+// SweepExpired removes the expired bans from memory and from the database. IsBanned only drops
+// expired entries from memory when it comes across them, so without a sweep they stay listed and
+// stored until then.
+// Parameters:
+//   - ctx: Context for the database operations
+//
+// Returns:
+//   - []string: The IP addresses and subnets whose ban expired
+//   - error: Any error encountered removing the bans from the database
+func (b *BanList) SweepExpired(ctx context.Context) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := clock.Now()
+
+	var (
+		expired  []string
+		firstErr error
+	)
+
+	for key, info := range b.bannedPeers {
+		if info.ExpirationTime.After(now) {
+			continue
+		}
+
+		delete(b.bannedPeers, key)
+
+		expired = append(expired, key)
+
+		// Notify subscribers asynchronously after state is updated
+		event := BanEvent{Action: "expire", IP: key, Subnet: info.Subnet}
+		go func() {
+			b.notifySubscribersAsync(event)
+		}()
+
+		if err := b.removePeerFromDatabase(ctx, key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return expired, firstErr
+}
+
 // createTables initializes the database schema for persistent ban storage.
 // This method creates the necessary tables to store ban information across node restarts.
 // The bans table stores the ban key, expiration time, and subnet information for each banned entity.
@@ -448,13 +525,31 @@ func (b *BanList) removePeerFromDatabase(ctx context.Context, key string) error
 	return nil
 }
 
+// loadFromDatabase loads the stored bans into memory. Bans stored under a key that is not normalized, by
+// versions that stored the address as given, are stored again under their normalized key.
 func (b *BanList) loadFromDatabase(ctx context.Context) error {
 	rows, err := b.db.QueryContext(ctx, "SELECT key, expiration_time, subnet FROM bans")
 	if err != nil {
 		_ = b.db.Close()
 		return err
 	}
-	defer rows.Close()
+
+	renamed := make(map[string]string)
+
+	defer func() {
+		_ = rows.Close()
+
+		for oldKey, key := range renamed {
+			if err := b.removePeerFromDatabase(ctx, oldKey); err != nil {
+				b.logger.Errorf("Error removing ban %s to store it as %s: %v", oldKey, key, err)
+				continue
+			}
+
+			if err := b.savePeerToDatabase(ctx, key, b.bannedPeers[key]); err != nil {
+				b.logger.Errorf("Error storing ban %s as %s: %v", oldKey, key, err)
+			}
+		}
+	}()
 
 	for rows.Next() {
 		select {
@@ -480,9 +575,19 @@ func (b *BanList) loadFromDatabase(ctx context.Context) error {
 				continue
 			}
 
-			_, subnet, err := net.ParseCIDR(subnetStr)
+			normalizedKey, subnet, err := normalizeBanAddress(key)
 			if err != nil {
-				b.logger.Errorf("Error parsing subnet %s: %v", subnetStr, err)
+				b.logger.Errorf("Error parsing banned address %s (subnet %s): %v", key, subnetStr, err)
+				continue
+			}
+
+			if normalizedKey != key {
+				renamed[key] = normalizedKey
+				key = normalizedKey
+			}
+
+			if existing, ok := b.bannedPeers[key]; ok && existing.ExpirationTime.After(expirationTime) {
+				// the same address was stored in several representations, the latest expiration wins
 				continue
 			}
 
@@ -565,39 +670,124 @@ func (b *BanList) Clear() {
 	}
 }
 
-func parseAddress(ipOrSubnet string) (subnet *net.IPNet, err error) {
-	if strings.Contains(ipOrSubnet, "/") {
-		// It's a subnet
-		_, subnet, err = net.ParseCIDR(ipOrSubnet)
+// NormalizeBanAddress returns the normalized form of an IP address, subnet or multiaddr, which is the form
+// bans are stored, listed and matched under, so the same host or network banned in different representations
+// is the same ban:
+//   - ports, brackets and IPv6 zones are removed: "[2001:db8::1%eth0]:8333" is "2001:db8::1"
+//   - IPv6 addresses are in their canonical lower case, compressed form: "2001:DB8:0::1" is "2001:db8::1"
+//   - IPv4-mapped IPv6 addresses and prefixes are IPv4: "::ffff:192.0.2.1" is "192.0.2.1"
+//   - subnets are masked to their network address: "2001:db8::1/64" is "2001:db8::/64"
+//   - subnets of a single address are the address: "192.0.2.1/32" is "192.0.2.1"
+//   - multiaddrs are the address of their ip4 or ip6 component: "/ip6/2001:db8::1/tcp/9905" is "2001:db8::1"
+func NormalizeBanAddress(ipOrSubnet string) (string, error) {
+	key, _, err := normalizeBanAddress(ipOrSubnet)
+
+	return key, err
+}
+
+// normalizeBanAddress returns the normalized form of an IP address, subnet or multiaddr, see
+// NormalizeBanAddress, and the subnet it covers
+func normalizeBanAddress(ipOrSubnet string) (key string, subnet *net.IPNet, err error) {
+	ipOrSubnet = strings.TrimSpace(ipOrSubnet)
+
+	var prefix netip.Prefix
+
+	switch {
+	case strings.HasPrefix(ipOrSubnet, "/"):
+		// It's a multiaddr
+		addr, err := multiaddrIP(ipOrSubnet)
 		if err != nil {
-			return nil, errors.New(errors.ERR_INVALID_SUBNET, fmt.Sprintf("can't parse subnet: %s", ipOrSubnet))
+			return "", nil, err
 		}
 
-		return subnet, nil
-	} else {
-		// Strip port from IP address (handles both IPv4 and IPv6)
-		if host, _, err := net.SplitHostPort(ipOrSubnet); err == nil {
-			ipOrSubnet = host
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	case strings.Contains(ipOrSubnet, "/"):
+		// It's a subnet
+		prefix, err = netip.ParsePrefix(ipOrSubnet)
+		if err != nil {
+			return "", nil, errors.New(errors.ERR_INVALID_SUBNET, fmt.Sprintf("can't parse subnet: %s", ipOrSubnet))
 		}
-		// If SplitHostPort fails, assume it's just an IP without port
-		// It's an IP address
-		ip := net.ParseIP(ipOrSubnet)
-		if ip == nil {
-			return nil, errors.New(errors.ERR_INVALID_IP, fmt.Sprintf("can't parse IP: %s", ipOrSubnet))
+
+		if addr := prefix.Addr(); addr.Is4In6() && prefix.Bits() >= 128-32 {
+			prefix = netip.PrefixFrom(addr.Unmap(), prefix.Bits()-(128-32))
 		}
 
-		var cidr string
-		if ip.To4() != nil {
-			cidr = fmt.Sprintf("%s/32", ipOrSubnet)
-		} else {
-			cidr = fmt.Sprintf("%s/128", ipOrSubnet)
+		prefix = prefix.Masked()
+	default:
+		// It's an IP address, strip the port from it (handles both IPv4 and IPv6)
+		host := ipOrSubnet
+		if h, _, err := net.SplitHostPort(ipOrSubnet); err == nil {
+			host = h
 		}
 
-		_, subnet, err = net.ParseCIDR(cidr)
+		addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"))
 		if err != nil {
-			return nil, errors.New(errors.ERR_INVALID_IP, fmt.Sprintf("can't create subnet from IP: %s", ipOrSubnet))
+			return "", nil, errors.New(errors.ERR_INVALID_IP, fmt.Sprintf("can't parse IP: %s", ipOrSubnet))
 		}
 
-		return subnet, nil
+		addr = addr.WithZone("").Unmap()
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+
+	subnet = &net.IPNet{
+		IP:   prefix.Addr().AsSlice(),
+		Mask: net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen()),
+	}
+
+	if prefix.IsSingleIP() {
+		return prefix.Addr().String(), subnet, nil
+	}
+
+	return prefix.String(), subnet, nil
+}
+
+// multiaddrIP returns the IP address of the ip4 or ip6 component of a multiaddr
+func multiaddrIP(addr string) (netip.Addr, error) {
+	maddr, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return netip.Addr{}, errors.New(errors.ERR_INVALID_IP, fmt.Sprintf("can't parse multiaddr: %s", addr))
+	}
+
+	value, err := maddr.ValueForProtocol(ma.P_IP4)
+	if err != nil {
+		value, err = maddr.ValueForProtocol(ma.P_IP6)
+	}
+
+	if err != nil {
+		return netip.Addr{}, errors.New(errors.ERR_INVALID_IP, fmt.Sprintf("multiaddr has no IP address: %s", addr))
+	}
+
+	ip, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Addr{}, errors.New(errors.ERR_INVALID_IP, fmt.Sprintf("can't parse IP of multiaddr: %s", addr))
 	}
+
+	return ip.WithZone("").Unmap(), nil
+}
+
+// ipNetToPrefix returns the prefix of a subnet
+func ipNetToPrefix(subnet *net.IPNet) (netip.Prefix, bool) {
+	if subnet == nil {
+		return netip.Prefix{}, false
+	}
+
+	addr, ok := netip.AddrFromSlice(subnet.IP)
+	if !ok {
+		return netip.Prefix{}, false
+	}
+
+	bits, size := subnet.Mask.Size()
+	if size == 8*net.IPv6len && addr.Is4In6() && bits >= 128-32 {
+		// an IPv4 subnet stored with a 16 byte address
+		return netip.PrefixFrom(addr.Unmap(), bits-(128-32)), true
+	}
+
+	if size != addr.BitLen() {
+		addr = addr.Unmap()
+		if size != addr.BitLen() {
+			return netip.Prefix{}, false
+		}
+	}
+
+	return netip.PrefixFrom(addr, bits), true
 }
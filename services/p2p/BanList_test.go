@@ -64,6 +64,7 @@ func TestHandleSetBanAdd(t *testing.T) {
 		name     string
 		args     *bsvjson.SetBanCmd
 		isSubnet bool
+		key      string
 		expected string
 	}{
 		{
@@ -95,8 +96,19 @@ func TestHandleSetBanAdd(t *testing.T) {
 				Absolute:   &absolute,
 			},
 			isSubnet: false,
+			key:      "127.0.0.0",
 			expected: "127.0.0.0/32",
 		},
+		{
+			name: "test IPv6 subnet add ban",
+			args: &bsvjson.SetBanCmd{
+				Command:    "add",
+				IPOrSubnet: "2001:db8:1:2::/64",
+				BanTime:    &banTime,
+				Absolute:   &absolute,
+			},
+			isSubnet: true,
+		},
 	}
 	banList, eventChan, err := setupBanList(t)
 	require.NoError(t, err)
@@ -133,7 +145,12 @@ func TestHandleSetBanAdd(t *testing.T) {
 
 			t.Logf("IP or Subnet: %s\n", tt.args.IPOrSubnet)
 
-			banInfo, exists := banList.bannedPeers[tt.args.IPOrSubnet]
+			key := tt.args.IPOrSubnet
+			if tt.key != "" {
+				key = tt.key
+			}
+
+			banInfo, exists := banList.bannedPeers[key]
 			require.True(t, exists)
 
 			expectedExpiration := time.Now().Add(time.Duration(*tt.args.BanTime) * time.Second)
@@ -221,6 +238,52 @@ func TestRemoveBan(t *testing.T) {
 	}
 }
 
+func TestSweepExpired(t *testing.T) {
+	ctx := context.Background()
+
+	banList, eventChan, err := setupBanList(t)
+	require.NoError(t, err)
+
+	until := time.Now().Add(time.Hour)
+
+	require.NoError(t, banList.Add(ctx, "192.168.1.1", time.Now().Add(-time.Second)))
+	require.NoError(t, banList.Add(ctx, "10.0.0.0/24", until))
+
+	bans := banList.ListBans()
+	require.Len(t, bans, 2)
+	require.ElementsMatch(t, []string{"192.168.1.1", "10.0.0.0/24"}, []string{bans[0].Banned, bans[1].Banned})
+
+	// drain the add events
+	for i := 0; i < 2; i++ {
+		<-eventChan
+	}
+
+	expired, err := banList.SweepExpired(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"192.168.1.1"}, expired)
+
+	select {
+	case event := <-eventChan:
+		require.Equal(t, "expire", event.Action)
+		require.Equal(t, "192.168.1.1", event.IP)
+	case <-time.After(time.Second):
+		t.Fatal("no expire event received")
+	}
+
+	bans = banList.ListBans()
+	require.Len(t, bans, 1)
+	require.Equal(t, BanEntry{Banned: "10.0.0.0/24", UnbanAt: until}, bans[0])
+
+	// the expired ban is removed from the database
+	var count int
+	require.NoError(t, banList.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM bans WHERE key = $1", "192.168.1.1").Scan(&count))
+	require.Equal(t, 0, count)
+
+	expired, err = banList.SweepExpired(ctx)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+}
+
 func TestBanListChannel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -408,3 +471,168 @@ func TestIPv6Compatibility(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeBanAddress(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"192.168.1.1", "192.168.1.1"},
+		{" 192.168.1.1:8333 ", "192.168.1.1"},
+		{"192.168.1.1/32", "192.168.1.1"},
+		{"10.0.0.5/24", "10.0.0.0/24"},
+		{"::ffff:192.168.1.1", "192.168.1.1"},
+		{"[::ffff:192.168.1.1]:8333", "192.168.1.1"},
+		{"::ffff:10.0.0.0/104", "10.0.0.0/8"},
+		{"2001:DB8:0:0::1", "2001:db8::1"},
+		{"[2001:db8::1]:8333", "2001:db8::1"},
+		{"[2001:db8::1]", "2001:db8::1"},
+		{"fe80::1%eth0", "fe80::1"},
+		{"2001:db8::1/128", "2001:db8::1"},
+		{"2001:db8:1:2:3:4:5:6/64", "2001:db8:1:2::/64"},
+		{"2001:DB8:1:2::/64", "2001:db8:1:2::/64"},
+		{"/ip4/192.168.1.1/tcp/9905", "192.168.1.1"},
+		{"/ip6/2001:db8::1/tcp/9905", "2001:db8::1"},
+		{"/ip6/::ffff:192.168.1.1/udp/9905/quic-v1", "192.168.1.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			normalized, err := NormalizeBanAddress(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, normalized)
+		})
+	}
+
+	for _, invalid := range []string{"", "invalid", "10.0.0.0/33", "2001:db8::/129", "fe80::1%eth0/64", "/dns4/example.com/tcp/9905", "/ip4/not-an-ip"} {
+		t.Run("invalid "+invalid, func(t *testing.T) {
+			_, err := NormalizeBanAddress(invalid)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestIPv6PrefixBans(t *testing.T) {
+	ctx := context.Background()
+
+	banList, _, err := setupBanList(t)
+	require.NoError(t, err)
+
+	// banned as a host address of the /64, which bans the whole /64
+	require.NoError(t, banList.Add(ctx, "2001:DB8:1:2:aaaa::1/64", time.Now().Add(time.Hour)))
+	require.NoError(t, banList.Add(ctx, "::ffff:172.16.0.0/108", time.Now().Add(time.Hour)))
+
+	require.ElementsMatch(t, []string{"2001:db8:1:2::/64", "172.16.0.0/12"}, banList.ListBanned())
+
+	tests := []struct {
+		name     string
+		ip       string
+		expected bool
+	}{
+		{"address in banned /64", "2001:db8:1:2:ffff:ffff:ffff:ffff", true},
+		{"address in banned /64 with port", "[2001:db8:1:2::8]:8333", true},
+		{"address in banned /64 as multiaddr", "/ip6/2001:db8:1:2::8/tcp/9905", true},
+		{"address in banned /64 with zone", "2001:db8:1:2::8%eth0", true},
+		{"address outside banned /64", "2001:db8:1:3::1", false},
+		{"banned /64", "2001:db8:1:2::/64", true},
+		{"/80 in banned /64", "2001:db8:1:2:1::/80", true},
+		{"/48 containing banned /64", "2001:db8:1::/48", false},
+		{"IPv4 address in banned IPv4-mapped subnet", "172.16.5.5", true},
+		{"IPv4-mapped address in banned IPv4-mapped subnet", "::ffff:172.16.5.5", true},
+		{"IPv4 address outside banned subnet", "172.32.0.1", false},
+		{"IPv4 address with the bits of the banned /64", "32.1.13.184", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, banList.IsBanned(tt.ip))
+		})
+	}
+
+	// the ban is lifted through any representation of the subnet
+	require.NoError(t, banList.Remove(ctx, "2001:db8:1:2::1/64"))
+	require.False(t, banList.IsBanned("2001:db8:1:2::8"))
+}
+
+func TestSameHostBannedInDifferentRepresentations(t *testing.T) {
+	ctx := context.Background()
+
+	banList, eventChan, err := setupBanList(t)
+	require.NoError(t, err)
+
+	until := time.Now().Add(time.Hour)
+
+	require.NoError(t, banList.Add(ctx, "[2001:DB8::1]:8333", time.Now().Add(time.Minute)))
+	require.NoError(t, banList.Add(ctx, "/ip6/2001:db8:0::1/tcp/9905", until))
+	require.NoError(t, banList.Add(ctx, "/ip4/192.168.1.1/tcp/9905", until))
+
+	// a single ban per host, with the expiration of the last ban
+	bans := banList.ListBans()
+	require.ElementsMatch(t, []BanEntry{{Banned: "2001:db8::1", UnbanAt: until}, {Banned: "192.168.1.1", UnbanAt: until}}, bans)
+
+	// the events of the bans are sent concurrently, so they can arrive in any order
+	subnets := make(map[string]string)
+
+	for range 3 {
+		select {
+		case event := <-eventChan:
+			subnets[event.IP] = event.Subnet.String()
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for ban events")
+		}
+	}
+
+	require.Equal(t, map[string]string{"2001:db8::1": "2001:db8::1/128", "192.168.1.1": "192.168.1.1/32"}, subnets)
+
+	for _, ip := range []string{"2001:db8::1", "2001:0db8:0000:0000:0000:0000:0000:0001", "[2001:db8::1]:1234", "192.168.1.1:8333", "::ffff:192.168.1.1"} {
+		require.True(t, banList.IsBanned(ip), ip)
+	}
+
+	require.NoError(t, banList.Remove(ctx, "2001:0db8::0001"))
+	require.False(t, banList.IsBanned("2001:db8::1"))
+
+	var count int
+	require.NoError(t, banList.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM bans").Scan(&count))
+	require.Equal(t, 1, count)
+}
+
+func TestLoadFromDatabase_NormalizesKeys(t *testing.T) {
+	ctx := context.Background()
+
+	banList, _, err := setupBanList(t)
+	require.NoError(t, err)
+
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	// bans stored as given by earlier versions
+	for _, row := range [][2]string{
+		{"192.168.1.1:8333", "192.168.1.1/32"},
+		{"2001:DB8::1", "2001:db8::1/128"},
+		{"2001:db8:1:2::1/64", "2001:db8:1:2::/64"},
+	} {
+		_, err = banList.db.ExecContext(ctx, "INSERT INTO bans (key, expiration_time, subnet) VALUES ($1, $2, $3)", row[0], until.Format(time.RFC3339), row[1])
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, banList.loadFromDatabase(ctx))
+
+	require.ElementsMatch(t, []string{"192.168.1.1", "2001:db8::1", "2001:db8:1:2::/64"}, banList.ListBanned())
+	require.True(t, banList.IsBanned("[2001:db8::1]:8333"))
+
+	rows, err := banList.db.QueryContext(ctx, "SELECT key FROM bans")
+	require.NoError(t, err)
+
+	defer rows.Close()
+
+	var keys []string
+
+	for rows.Next() {
+		var key string
+		require.NoError(t, rows.Scan(&key))
+
+		keys = append(keys, key)
+	}
+
+	require.NoError(t, rows.Err())
+	require.ElementsMatch(t, []string{"192.168.1.1", "2001:db8::1", "2001:db8:1:2::/64"}, keys)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/util/clock"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -27,6 +28,7 @@ const (
 	ReasonSpam
 	ReasonInvalidBlock
 	ReasonCatchupFailure
+	ReasonMaliciousChain
 )
 
 func (r BanReason) String() string {
@@ -41,6 +43,8 @@ func (r BanReason) String() string {
 		return "invalid_block"
 	case ReasonCatchupFailure:
 		return "catchup_failure"
+	case ReasonMaliciousChain:
+		return "malicious_chain"
 	default:
 		return "unknown"
 	}
@@ -94,6 +98,25 @@ type PeerBanManagerI interface {
 	// AddScore adds points to a peer's ban score for a specific reason.
 	// Returns the peer's current score after adjustment and whether the peer is now banned.
 	AddScore(peerID string, reason BanReason) (score int, banned bool)
+
+	// AddScorePoints adds the given points to a peer's ban score instead of the points of the reason.
+	// Returns the peer's current score after adjustment and whether the peer is now banned.
+	AddScorePoints(peerID string, reason BanReason, points int) (score int, banned bool)
+
+	// BanPeer bans a peer until the given time regardless of its score, e.g. on request of an operator.
+	BanPeer(peerID string, until time.Time, reason string)
+
+	// ResetBanScore clears the ban score and ban status of a peer.
+	ResetBanScore(peerID string)
+
+	// ListBanned returns the IDs of the peers that are currently banned.
+	ListBanned() []string
+
+	// ListBans returns the peers that are currently banned with the time their ban expires.
+	ListBans() []BanEntry
+
+	// SweepExpiredBans lifts the bans that expired and returns the IDs of their peers.
+	SweepExpiredBans() []string
 }
 
 // PeerBanManager manages all peer scores and bans.
@@ -112,6 +135,7 @@ type PeerBanManager struct {
 	reasonPoints  map[BanReason]int    // Mapping of ban reasons to their penalty points
 	banThreshold  int                  // Score threshold that triggers a ban
 	banDuration   time.Duration        // Duration of bans when threshold is exceeded
+	probation     time.Duration        // Probation period applied after a ban expires or is lifted (0 = disabled)
 	decayInterval time.Duration        // How often scores are reduced (decay period)
 	decayAmount   int                  // How many points are removed during each decay
 	handler       BanEventHandler      // Handler for ban events to notify other components
@@ -134,6 +158,8 @@ type PeerBanManager struct {
 //
 // Returns a fully configured PeerBanManager ready for use
 func NewPeerBanManager(ctx context.Context, handler BanEventHandler, tSettings *settings.Settings, peerRegistry *PeerRegistry) *PeerBanManager {
+	initPrometheusMetrics()
+
 	m := &PeerBanManager{
 		ctx:           ctx,
 		peerBanScores: make(map[string]*BanScore),
@@ -143,9 +169,12 @@ func NewPeerBanManager(ctx context.Context, handler BanEventHandler, tSettings *
 			ReasonSpam:              50,
 			ReasonInvalidBlock:      10, // Using the same ban score value as SVNode
 			ReasonCatchupFailure:    30, // Significant penalty for infrastructure failures during sync
+			// Serving a fabricated chain bans the peer outright
+			ReasonMaliciousChain: tSettings.P2P.BanThreshold,
 		},
 		banThreshold:  tSettings.P2P.BanThreshold,
 		banDuration:   tSettings.P2P.BanDuration,
+		probation:     tSettings.P2P.ProbationDuration,
 		decayInterval: time.Minute,
 		decayAmount:   1,
 		handler:       handler,
@@ -177,7 +206,7 @@ func NewPeerBanManager(ctx context.Context, handler BanEventHandler, tSettings *
 // When called, it performs several operations:
 // - Applies time-based score decay based on elapsed time since last update
 // - Adds penalty points based on the specified reason
-// - Applies ban if score exceeds threshold
+// - Applies ban if score exceeds threshold, or immediately if the peer is on probation
 // - Records the reason in ban history
 // - Notifies ban event handler if a ban is triggered
 //
@@ -192,10 +221,31 @@ func NewPeerBanManager(ctx context.Context, handler BanEventHandler, tSettings *
 // - score: The peer's current score after adjustment
 // - banned: Whether the peer is now banned as a result of this score increase
 func (m *PeerBanManager) AddScore(peerID string, reason BanReason) (score int, banned bool) {
+	points, found := m.reasonPoints[reason]
+	if !found {
+		points = 1 // unknown reason, default to 1
+	}
+
+	return m.AddScorePoints(peerID, reason, points)
+}
+
+// AddScorePoints increments the score for a peer by the given points instead of the points of the reason,
+// e.g. the ban-score delta suggested by a peer-attributable error. Decay, banning and the ban history are
+// handled as in AddScore, the reason is recorded in the history and reported with a ban.
+//
+// Parameters:
+// - peerID: Identifier of the peer to apply score to
+// - reason: Categorized reason for the score increase
+// - points: Points to add to the score of the peer
+//
+// Returns:
+// - score: The peer's current score after adjustment
+// - banned: Whether the peer is now banned as a result of this score increase
+func (m *PeerBanManager) AddScorePoints(peerID string, reason BanReason, points int) (score int, banned bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	now := time.Now()
+	now := clock.Now()
 
 	entry, ok := m.peerBanScores[peerID]
 	if !ok {
@@ -220,19 +270,23 @@ func (m *PeerBanManager) AddScore(peerID string, reason BanReason) (score int, b
 	entry.Reasons = append(entry.Reasons, reason.String())
 
 	// Add points
-	points, found := m.reasonPoints[reason]
-	if !found {
-		points = 1 // unknown reason, default to 1
-	}
-
 	entry.Score += points
 
+	// Peers on probation have no tolerance left, any new score bans them again
+	onProbation := m.isOnProbation(peerID)
+
 	// Ban enforcement
-	if entry.Score >= m.banThreshold && !entry.Banned {
+	if (entry.Score >= m.banThreshold || onProbation) && !entry.Banned {
+		if onProbation {
+			m.endProbation(peerID)
+		}
+
 		entry.Banned = true
 		entry.BanUntil = now.Add(m.banDuration)
 		banned = true
 
+		prometheusP2PBans.WithLabelValues(reason.String()).Inc()
+
 		if m.handler != nil {
 			m.handler.OnPeerBanned(peerID, entry.BanUntil, reason.String())
 		}
@@ -248,6 +302,47 @@ func (m *PeerBanManager) AddScore(peerID string, reason BanReason) (score int, b
 	return entry.Score, entry.Banned
 }
 
+// BanPeer bans a peer until the given time regardless of its score, e.g. on request of an operator.
+// The score of the peer is raised to the ban threshold, so the ban is not lifted by a decaying score,
+// and the ban event handler is notified as for a ban caused by misbehaviour.
+func (m *PeerBanManager) BanPeer(peerID string, until time.Time, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := clock.Now()
+
+	entry, ok := m.peerBanScores[peerID]
+	if !ok {
+		entry = &BanScore{}
+		m.peerBanScores[peerID] = entry
+	}
+
+	if reason == "" {
+		reason = "manual"
+	}
+
+	entry.Score = max(entry.Score, m.banThreshold)
+	entry.Banned = true
+	entry.BanUntil = until
+	entry.LastUpdate = now
+	entry.Reasons = append(entry.Reasons, reason)
+
+	m.endProbation(peerID)
+
+	// the reason given by an operator is free text, the metric only records manual bans
+	prometheusP2PBans.WithLabelValues("manual").Inc()
+
+	if m.handler != nil {
+		m.handler.OnPeerBanned(peerID, until, reason)
+	}
+
+	if m.peerRegistry != nil {
+		if pID, err := peer.Decode(peerID); err == nil {
+			m.peerRegistry.UpdateBanStatus(pID, entry.Score, true)
+		}
+	}
+}
+
 // GetBanScore returns the current ban score and ban status for a given peer.
 func (m *PeerBanManager) GetBanScore(peerID string) (score int, banned bool, banUntil time.Time) {
 	m.mu.RLock()
@@ -262,9 +357,15 @@ func (m *PeerBanManager) GetBanScore(peerID string) (score int, banned bool, ban
 }
 
 // ResetBanScore clears the ban score and ban status for a peer.
+// A peer that was banned is put on probation.
 func (m *PeerBanManager) ResetBanScore(peerID string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+
+	if entry, ok := m.peerBanScores[peerID]; ok && entry.Banned {
+		m.startProbation(peerID)
+	}
+
 	delete(m.peerBanScores, peerID)
 
 	// Sync with peer registry
@@ -276,6 +377,7 @@ func (m *PeerBanManager) ResetBanScore(peerID string) {
 }
 
 // IsBanned returns true if the peer is currently banned, and unbans if expired.
+// A peer whose ban expired is put on probation.
 func (m *PeerBanManager) IsBanned(peerID string) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -285,23 +387,27 @@ func (m *PeerBanManager) IsBanned(peerID string) bool {
 		return false
 	}
 
-	if time.Now().After(entry.BanUntil) {
-		// Ban expired, reset
-		delete(m.peerBanScores, peerID)
-
-		// Sync with peer registry
-		if m.peerRegistry != nil {
-			if pID, err := peer.Decode(peerID); err == nil {
-				m.peerRegistry.UpdateBanStatus(pID, 0, false)
-			}
-		}
-
+	if clock.Now().After(entry.BanUntil) {
+		m.expireBan(peerID)
 		return false
 	}
 
 	return true
 }
 
+// expireBan resets a peer whose ban expired and puts it on probation. Must be called with the lock held.
+func (m *PeerBanManager) expireBan(peerID string) {
+	delete(m.peerBanScores, peerID)
+	m.startProbation(peerID)
+
+	// Sync with peer registry
+	if m.peerRegistry != nil {
+		if pID, err := peer.Decode(peerID); err == nil {
+			m.peerRegistry.UpdateBanStatus(pID, 0, false)
+		}
+	}
+}
+
 // ListBanned returns a slice of peer IDs that are currently banned.
 func (m *PeerBanManager) ListBanned() []string {
 	m.mu.RLock()
@@ -309,7 +415,7 @@ func (m *PeerBanManager) ListBanned() []string {
 
 	var banned []string
 
-	now := time.Now()
+	now := clock.Now()
 
 	for peerID, entry := range m.peerBanScores {
 		if entry.Banned && now.Before(entry.BanUntil) {
@@ -320,7 +426,47 @@ func (m *PeerBanManager) ListBanned() []string {
 	return banned
 }
 
-// CleanupBanScores removes peers with zero score and not banned.
+// ListBans returns the peers that are currently banned with the time their ban expires.
+func (m *PeerBanManager) ListBans() []BanEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var bans []BanEntry
+
+	now := clock.Now()
+
+	for peerID, entry := range m.peerBanScores {
+		if entry.Banned && now.Before(entry.BanUntil) {
+			bans = append(bans, BanEntry{Banned: peerID, UnbanAt: entry.BanUntil})
+		}
+	}
+
+	return bans
+}
+
+// SweepExpiredBans lifts the bans that expired, putting their peers on probation, and returns the
+// IDs of these peers. Without a sweep an expired ban is only lifted when IsBanned is called for the peer.
+func (m *PeerBanManager) SweepExpiredBans() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expired []string
+
+	now := clock.Now()
+
+	for peerID, entry := range m.peerBanScores {
+		if entry.Banned && now.After(entry.BanUntil) {
+			m.expireBan(peerID)
+
+			expired = append(expired, peerID)
+		}
+	}
+
+	return expired
+}
+
+// CleanupBanScores removes peers with zero score and not banned,
+// and promotes peers whose probation period has elapsed.
 func (m *PeerBanManager) CleanupBanScores() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -330,6 +476,54 @@ func (m *PeerBanManager) CleanupBanScores() {
 			delete(m.peerBanScores, peerID)
 		}
 	}
+
+	if m.peerRegistry != nil && m.probation > 0 {
+		m.peerRegistry.PromoteProbationPeers(m.probation)
+	}
+}
+
+// IsOnProbation returns true if the peer was recently unbanned and is still on probation.
+func (m *PeerBanManager) IsOnProbation(peerID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.isOnProbation(peerID)
+}
+
+// startProbation puts a peer on probation in the registry. Must be called with the lock held.
+func (m *PeerBanManager) startProbation(peerID string) {
+	if m.peerRegistry == nil || m.probation <= 0 {
+		return
+	}
+
+	if pID, err := peer.Decode(peerID); err == nil {
+		m.peerRegistry.StartProbation(pID, m.probation)
+	}
+}
+
+// endProbation clears a peer's probation in the registry. Must be called with the lock held.
+func (m *PeerBanManager) endProbation(peerID string) {
+	if m.peerRegistry == nil {
+		return
+	}
+
+	if pID, err := peer.Decode(peerID); err == nil {
+		m.peerRegistry.EndProbation(pID)
+	}
+}
+
+// isOnProbation checks the registry for a peer's probation state. Must be called with the lock held.
+func (m *PeerBanManager) isOnProbation(peerID string) bool {
+	if m.peerRegistry == nil {
+		return false
+	}
+
+	pID, err := peer.Decode(peerID)
+	if err != nil {
+		return false
+	}
+
+	return m.peerRegistry.IsOnProbation(pID)
 }
 
 // GetBanReasons returns the reasons for a peer's ban score.
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -69,6 +71,27 @@ func TestAddScore_UnknownReason(t *testing.T) {
 	assert.False(t, banned)
 }
 
+func TestAddScorePoints(t *testing.T) {
+	handler := &testBanHandler{}
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.P2P.BanThreshold = 30
+	registry := NewPeerRegistry()
+	m := NewPeerBanManager(context.Background(), handler, tSettings, registry)
+	peerID := "peer3"
+
+	// the points override the points of the reason
+	score, banned := m.AddScorePoints(peerID, ReasonInvalidBlock, 25)
+	assert.Equal(t, 25, score)
+	assert.False(t, banned)
+
+	score, banned = m.AddScorePoints(peerID, ReasonInvalidBlock, 5)
+	assert.Equal(t, 30, score)
+	assert.True(t, banned)
+	assert.Equal(t, ReasonInvalidBlock.String(), handler.lastReason)
+
+	assert.Equal(t, []string{ReasonInvalidBlock.String(), ReasonInvalidBlock.String()}, m.GetBanReasons(peerID))
+}
+
 func TestResetAndCleanupBanScore(t *testing.T) {
 	tSettings := test.CreateBaseTestSettings(t)
 	registry := NewPeerRegistry()
@@ -129,6 +152,76 @@ func TestIsBannedAndListBanned(t *testing.T) {
 	assert.Empty(t, bannedList)
 }
 
+func TestBanPeer(t *testing.T) {
+	handler := &testBanHandler{}
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.P2P.BanThreshold = 100
+	registry := NewPeerRegistry()
+	m := NewPeerBanManager(context.Background(), handler, tSettings, registry)
+
+	peerID := "peer6"
+	until := time.Now().Add(time.Hour)
+
+	m.BanPeer(peerID, until, "operator request")
+
+	assert.True(t, m.IsBanned(peerID))
+	assert.Equal(t, []string{peerID}, m.ListBanned())
+	assert.Equal(t, peerID, handler.lastPeerID)
+	assert.Equal(t, until, handler.lastUntil)
+	assert.Equal(t, "operator request", handler.lastReason)
+
+	score, banned, banUntil := m.GetBanScore(peerID)
+	assert.Equal(t, 100, score, "score is raised to the threshold")
+	assert.True(t, banned)
+	assert.Equal(t, until, banUntil)
+
+	m.ResetBanScore(peerID)
+	assert.False(t, m.IsBanned(peerID))
+
+	m.BanPeer(peerID, time.Now().Add(time.Hour), "")
+	assert.Equal(t, "manual", handler.lastReason)
+}
+
+func TestSweepExpiredBans(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.P2P.ProbationDuration = time.Hour
+	registry := NewPeerRegistry()
+	m := NewPeerBanManager(context.Background(), nil, tSettings, registry)
+
+	privKey, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+	pID, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+
+	expiredPeer := pID.String()
+	registry.AddPeer(pID, "")
+
+	until := time.Now().Add(time.Hour)
+
+	m.BanPeer(expiredPeer, time.Now().Add(time.Hour), "")
+	m.BanPeer("peer-banned", until, "")
+
+	m.mu.Lock()
+	m.peerBanScores[expiredPeer].BanUntil = time.Now().Add(-time.Second)
+	m.mu.Unlock()
+
+	assert.Equal(t, []BanEntry{{Banned: "peer-banned", UnbanAt: until}}, m.ListBans())
+
+	assert.Equal(t, []string{expiredPeer}, m.SweepExpiredBans())
+	assert.True(t, m.IsOnProbation(expiredPeer), "a peer whose ban expired is put on probation")
+
+	_, banned, _ := m.GetBanScore(expiredPeer)
+	assert.False(t, banned)
+
+	info, exists := registry.GetPeer(pID)
+	require.True(t, exists)
+	assert.False(t, info.IsBanned)
+
+	// the expired ban is only reported once
+	assert.Empty(t, m.SweepExpiredBans())
+	assert.True(t, m.IsBanned("peer-banned"))
+}
+
 func TestBanReason_String(t *testing.T) {
 	tests := []struct {
 		reason   BanReason
@@ -139,6 +232,7 @@ func TestBanReason_String(t *testing.T) {
 		{ReasonSpam, "spam"},
 		{ReasonInvalidBlock, "invalid_block"},
 		{ReasonCatchupFailure, "catchup_failure"}, // Added test for ReasonCatchupFailure
+		{ReasonMaliciousChain, "malicious_chain"},
 		{ReasonUnknown, "unknown"},
 		{BanReason(999), "unknown"}, // Unknown reason
 	}
@@ -380,3 +474,90 @@ func TestPeerBanManager_ReasonCatchupFailure(t *testing.T) {
 	reasons := m.GetBanReasons(peerID)
 	assert.Contains(t, reasons, "catchup_failure")
 }
+
+func TestPeerBanManager_ReasonMaliciousChain(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	registry := NewPeerRegistry()
+	m := NewPeerBanManager(context.Background(), nil, tSettings, registry)
+
+	// A single malicious chain report reaches the ban threshold
+	score, banned := m.AddScore("malicious-chain-peer", ReasonMaliciousChain)
+	assert.Equal(t, tSettings.P2P.BanThreshold, score)
+	assert.True(t, banned)
+}
+
+func TestPeerBanManager_Probation(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.P2P.ProbationDuration = time.Hour
+	registry := NewPeerRegistry()
+	m := NewPeerBanManager(context.Background(), nil, tSettings, registry)
+	m.banThreshold = 10
+
+	privKey, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+	pID, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+
+	peerID := pID.String()
+	registry.AddPeer(pID, "")
+
+	t.Run("ban expiry starts probation", func(t *testing.T) {
+		m.AddScore(peerID, ReasonSpam)
+		require.True(t, m.IsBanned(peerID))
+
+		m.mu.Lock()
+		m.peerBanScores[peerID].BanUntil = time.Now().Add(-time.Second)
+		m.mu.Unlock()
+
+		assert.False(t, m.IsBanned(peerID))
+		assert.True(t, m.IsOnProbation(peerID))
+
+		info, exists := registry.GetPeer(pID)
+		require.True(t, exists)
+		assert.True(t, info.IsOnProbation)
+		assert.False(t, info.ProbationUntil.IsZero())
+	})
+
+	t.Run("any score during probation bans again", func(t *testing.T) {
+		score, banned := m.AddScore(peerID, ReasonUnknown)
+		assert.Equal(t, 1, score)
+		assert.True(t, banned)
+		assert.False(t, m.IsOnProbation(peerID))
+	})
+
+	t.Run("reset of a banned peer starts probation", func(t *testing.T) {
+		m.ResetBanScore(peerID)
+		assert.False(t, m.IsBanned(peerID))
+		assert.True(t, m.IsOnProbation(peerID))
+	})
+
+	t.Run("cleanup promotes peer after probation", func(t *testing.T) {
+		registry.mu.Lock()
+		registry.peers[pID].ProbationUntil = time.Now().Add(-time.Second)
+		registry.mu.Unlock()
+
+		m.CleanupBanScores()
+		assert.False(t, m.IsOnProbation(peerID))
+	})
+}
+
+func TestPeerBanManager_ProbationDisabled(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.P2P.ProbationDuration = 0
+	registry := NewPeerRegistry()
+	m := NewPeerBanManager(context.Background(), nil, tSettings, registry)
+	m.banThreshold = 10
+
+	privKey, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+	pID, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+
+	peerID := pID.String()
+	registry.AddPeer(pID, "")
+
+	m.AddScore(peerID, ReasonSpam)
+	m.ResetBanScore(peerID)
+
+	assert.False(t, m.IsOnProbation(peerID))
+}
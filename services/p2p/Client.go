@@ -3,6 +3,7 @@ package p2p
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/bsv-blockchain/teranode/errors"
@@ -17,6 +18,7 @@ import (
 // Client implements the ClientI interface and provides P2P client functionality.
 type Client struct {
 	client p2p_api.PeerServiceClient // gRPC client for peer service communication
+	conn   *resilientConn            // Health gated connection of the client, nil for clients without their own connection
 	logger ulogger.Logger            // Logger instance for the client
 }
 
@@ -59,22 +61,44 @@ func NewClientWithAddress(ctx context.Context, logger ulogger.Logger, address st
 	}
 
 	baConn, err := util.GetGRPCClient(ctx, address, &util.ConnectionOptions{
-		MaxRetries:   tSettings.GRPCMaxRetries,
-		RetryBackoff: tSettings.GRPCRetryBackoff,
-		APIKey:       apiKey, // Add the API key to the connection options
+		MaxRetries:       tSettings.GRPCMaxRetries,
+		RetryBackoff:     tSettings.GRPCRetryBackoff,
+		APIKey:           apiKey, // Add the API key to the connection options
+		KeepaliveTime:    tSettings.P2P.ClientKeepaliveTime,
+		KeepaliveTimeout: tSettings.P2P.ClientKeepaliveTimeout,
+		BackoffBaseDelay: tSettings.P2P.ClientReconnectBaseDelay,
+		BackoffMaxDelay:  tSettings.P2P.ClientReconnectMaxDelay,
 	}, tSettings)
 	if err != nil {
 		return nil, errors.NewServiceError("failed to init p2p service connection ", err)
 	}
 
+	// the connection is made in the background and calls wait for it, instead of failing while P2P is briefly down
+	conn := newResilientConn(ctx, logger, baConn, address, tSettings.P2P.ClientCallWait)
+
 	c := &Client{
-		client: p2p_api.NewPeerServiceClient(baConn),
+		client: p2p_api.NewPeerServiceClient(conn),
+		conn:   conn,
 		logger: logger,
 	}
 
 	return c, nil
 }
 
+// IsAvailable implements the ClientI interface method to check whether the P2P service can be reached.
+// Calls made while it returns false wait a short time for the connection to come back, and then fail
+// with a service unavailable error.
+//
+// Returns:
+//   - bool: True when the connection to the P2P service is up
+func (c *Client) IsAvailable() bool {
+	if c.conn == nil {
+		return true
+	}
+
+	return c.conn.IsAvailable()
+}
+
 // GetPeers implements the ClientI interface method to retrieve connected peers.
 // Parameters:
 //   - ctx: Context for the operation
@@ -106,9 +130,25 @@ func (c *Client) GetPeers(ctx context.Context) ([]*PeerInfo, error) {
 // Returns:
 //   - Error if the gRPC call fails or the peer cannot be banned
 func (c *Client) BanPeer(ctx context.Context, addr string, until int64) error {
+	return c.BanPeerWithReason(ctx, addr, until, "")
+}
+
+// BanPeerWithReason implements the ClientI interface method to ban a peer with a reason.
+// The reason is recorded in the peer event log of the P2P service.
+//
+// Parameters:
+//   - ctx: Context for the operation, used for cancellation and timeout control
+//   - addr: Peer ID, IP address or subnet to ban
+//   - until: Unix timestamp when the ban expires
+//   - reason: Reason of the ban
+//
+// Returns:
+//   - Error if the gRPC call fails or the peer cannot be banned
+func (c *Client) BanPeerWithReason(ctx context.Context, addr string, until int64, reason string) error {
 	req := &p2p_api.BanPeerRequest{
-		Addr:  addr,
-		Until: until,
+		Addr:   addr,
+		Until:  until,
+		Reason: reason,
 	}
 
 	resp, err := c.client.BanPeer(ctx, req)
@@ -243,6 +283,35 @@ func (c *Client) AddBanScore(ctx context.Context, peerID string, reason string)
 	return nil
 }
 
+// AddBanScorePoints adds the given points to a peer's ban score with the specified reason, instead of the
+// points of the reason, e.g. the ban-score delta suggested by a peer-attributable error.
+// Parameters:
+//   - ctx: Context for the operation
+//   - peerID: Peer ID to add ban score to
+//   - reason: Reason for adding ban score
+//   - points: Points to add to the ban score, the points of the reason are used when 0
+//
+// Returns:
+//   - error: Any error encountered during the operation
+func (c *Client) AddBanScorePoints(ctx context.Context, peerID string, reason string, points int) error {
+	req := &p2p_api.AddBanScoreRequest{
+		PeerId: peerID,
+		Reason: reason,
+		Score:  int32(min(max(points, 0), math.MaxInt32)), //nolint:gosec // clamped to the int32 range
+	}
+
+	resp, err := c.client.AddBanScore(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if resp != nil && !resp.Ok {
+		return errors.NewServiceError("failed to add ban score")
+	}
+
+	return nil
+}
+
 // ConnectPeer connects to a specific peer using the provided multiaddr.
 // Parameters:
 //   - ctx: Context for the operation
@@ -525,6 +594,66 @@ func (c *Client) ReportValidBlock(ctx context.Context, peerID string, blockHash
 	return nil
 }
 
+// RecordInvalidSubtree reports that a subtree received from a peer was rejected, lowering the reputation of the peer.
+// Parameters:
+//   - ctx: Context for the operation
+//   - peerID: Peer ID that provided the subtree
+//   - subtreeHash: Hash of the rejected subtree
+//   - reason: Reason the subtree was rejected
+//   - details: Optional description of why the subtree was rejected
+//
+// Returns:
+//   - error: Any error encountered during the operation
+func (c *Client) RecordInvalidSubtree(ctx context.Context, peerID string, subtreeHash string, reason InvalidDataReason, details string) error {
+	req := &p2p_api.RecordInvalidSubtreeRequest{
+		PeerId:      peerID,
+		SubtreeHash: subtreeHash,
+		Reason:      invalidDataReasonToAPI(reason),
+		Details:     details,
+	}
+
+	resp, err := c.client.RecordInvalidSubtree(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if resp != nil && !resp.Success {
+		return errors.NewServiceError("failed to record invalid subtree: %s", resp.Message)
+	}
+
+	return nil
+}
+
+// RecordInvalidBlock reports that a block received from a peer was rejected, lowering the reputation of the peer.
+// Parameters:
+//   - ctx: Context for the operation
+//   - peerID: Peer ID that provided the block
+//   - blockHash: Hash of the rejected block
+//   - reason: Reason the block was rejected
+//   - details: Optional description of why the block was rejected
+//
+// Returns:
+//   - error: Any error encountered during the operation
+func (c *Client) RecordInvalidBlock(ctx context.Context, peerID string, blockHash string, reason InvalidDataReason, details string) error {
+	req := &p2p_api.RecordInvalidBlockRequest{
+		PeerId:    peerID,
+		BlockHash: blockHash,
+		Reason:    invalidDataReasonToAPI(reason),
+		Details:   details,
+	}
+
+	resp, err := c.client.RecordInvalidBlock(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if resp != nil && !resp.Success {
+		return errors.NewServiceError("failed to record invalid block: %s", resp.Message)
+	}
+
+	return nil
+}
+
 // IsPeerMalicious checks if a peer is considered malicious.
 //
 // Parameters:
@@ -614,6 +743,92 @@ func (c *Client) RecordBytesDownloaded(ctx context.Context, peerID string, bytes
 	return nil
 }
 
+// RecordBytesUploaded records the number of bytes served to a peer.
+// Parameters:
+//   - ctx: Context for the operation
+//   - peerID: Peer ID string the data was served to
+//   - bytesUploaded: Number of bytes uploaded in this operation
+//
+// Returns:
+//   - error: Any error encountered during the operation
+func (c *Client) RecordBytesUploaded(ctx context.Context, peerID string, bytesUploaded uint64) error {
+	req := &p2p_api.RecordBytesUploadedRequest{
+		PeerId:        peerID,
+		BytesUploaded: bytesUploaded,
+	}
+
+	resp, err := c.client.RecordBytesUploaded(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if resp != nil && !resp.Ok {
+		return errors.NewServiceError("failed to record bytes uploaded")
+	}
+
+	return nil
+}
+
+// RecordDataDownloaded records data of a known type downloaded via HTTP from a peer.
+// Parameters:
+//   - ctx: Context for the operation
+//   - peerID: Peer ID string that provided the data
+//   - dataType: Type of the data downloaded
+//   - items: Number of items of the data type downloaded
+//   - bytesDownloaded: Number of bytes downloaded in this operation
+//
+// Returns:
+//   - error: Any error encountered during the operation
+func (c *Client) RecordDataDownloaded(ctx context.Context, peerID string, dataType string, items uint64, bytesDownloaded uint64) error {
+	req := &p2p_api.RecordBytesDownloadedRequest{
+		PeerId:          peerID,
+		BytesDownloaded: bytesDownloaded,
+		DataType:        dataType,
+		Items:           items,
+	}
+
+	resp, err := c.client.RecordBytesDownloaded(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if resp != nil && !resp.Ok {
+		return errors.NewServiceError("failed to record data downloaded")
+	}
+
+	return nil
+}
+
+// RecordDataUploaded records data of a known type served to a peer.
+// Parameters:
+//   - ctx: Context for the operation
+//   - peerID: Peer ID string the data was served to
+//   - dataType: Type of the data uploaded
+//   - items: Number of items of the data type uploaded
+//   - bytesUploaded: Number of bytes uploaded in this operation
+//
+// Returns:
+//   - error: Any error encountered during the operation
+func (c *Client) RecordDataUploaded(ctx context.Context, peerID string, dataType string, items uint64, bytesUploaded uint64) error {
+	req := &p2p_api.RecordBytesUploadedRequest{
+		PeerId:        peerID,
+		BytesUploaded: bytesUploaded,
+		DataType:      dataType,
+		Items:         items,
+	}
+
+	resp, err := c.client.RecordBytesUploaded(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if resp != nil && !resp.Ok {
+		return errors.NewServiceError("failed to record data uploaded")
+	}
+
+	return nil
+}
+
 // GetPeer retrieves information about a specific peer from the P2P service.
 // Returns nil if the peer is not found in the registry.
 func (c *Client) GetPeer(ctx context.Context, peerID string) (*PeerInfo, error) {
@@ -633,6 +848,111 @@ func (c *Client) GetPeer(ctx context.Context, peerID string) (*PeerInfo, error)
 	return convertFromAPIPeerInfo(resp.Peer), nil
 }
 
+// GetNetworkOverview retrieves the aggregate network overview from the P2P service.
+func (c *Client) GetNetworkOverview(ctx context.Context) (*NetworkOverview, error) {
+	resp, err := c.client.GetNetworkOverview(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &NetworkOverview{
+		TotalPeers:         int(resp.TotalPeers),
+		ConnectedPeers:     int(resp.ConnectedPeers),
+		BestHeight:         resp.BestHeight,
+		HeightDistribution: make([]HeightCount, 0, len(resp.HeightDistribution)),
+		ChainTips:          make([]ChainTip, 0, len(resp.ChainTips)),
+		AvgResponseTime:    time.Duration(resp.AvgResponseTimeMs) * time.Millisecond,
+		PeersJoined:        int(resp.PeersJoined),
+		PeersLeft:          int(resp.PeersLeft),
+		ChurnRate:          resp.ChurnRate,
+		ChurnWindow:        time.Duration(resp.ChurnWindowSeconds) * time.Second,
+	}
+
+	for _, hc := range resp.HeightDistribution {
+		overview.HeightDistribution = append(overview.HeightDistribution, HeightCount{
+			Height:    hc.Height,
+			PeerCount: int(hc.PeerCount),
+		})
+	}
+
+	for _, tip := range resp.ChainTips {
+		overview.ChainTips = append(overview.ChainTips, ChainTip{
+			BlockHash: tip.BlockHash,
+			Height:    tip.Height,
+			PeerCount: int(tip.PeerCount),
+		})
+	}
+
+	return overview, nil
+}
+
+// GetPeerEvents retrieves peer lifecycle events from the P2P service.
+func (c *Client) GetPeerEvents(ctx context.Context, from time.Time, to time.Time, peerID string, limit int) ([]PeerEvent, error) {
+	req := &p2p_api.GetPeerEventsRequest{
+		PeerId: peerID,
+		Limit:  int32(limit), //nolint:gosec
+	}
+
+	if !from.IsZero() {
+		req.From = from.UnixMilli()
+	}
+
+	if !to.IsZero() {
+		req.To = to.UnixMilli()
+	}
+
+	resp, err := c.client.GetPeerEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]PeerEvent, 0, len(resp.Events))
+	for _, event := range resp.Events {
+		events = append(events, PeerEvent{
+			Timestamp: time.UnixMilli(event.Timestamp),
+			PeerID:    event.PeerId,
+			Type:      PeerEventType(event.Type),
+			Details:   event.Details,
+		})
+	}
+
+	return events, nil
+}
+
+// GetPeerContributions retrieves the data contribution accounting of the peers.
+// Parameters:
+//   - ctx: Context for the operation
+//   - peerID: Only return the contribution of this peer, empty for all peers
+//
+// Returns:
+//   - []PeerContribution: Contributions ordered by the bytes received from the peer, lowest first
+//   - error: Any error encountered during the operation
+func (c *Client) GetPeerContributions(ctx context.Context, peerID string) ([]PeerContribution, error) {
+	resp, err := c.client.GetPeerContributions(ctx, &p2p_api.GetPeerContributionsRequest{PeerId: peerID})
+	if err != nil {
+		return nil, err
+	}
+
+	contributions := make([]PeerContribution, 0, len(resp.Contributions))
+	for _, c := range resp.Contributions {
+		contributions = append(contributions, PeerContribution{
+			PeerID:              c.PeerId,
+			BlocksReceived:      c.BlocksReceived,
+			SubtreesReceived:    c.SubtreesReceived,
+			SubtreeDataReceived: c.SubtreeDataReceived,
+			BytesReceived:       c.BytesReceived,
+			BlocksServed:        c.BlocksServed,
+			SubtreesServed:      c.SubtreesServed,
+			SubtreeDataServed:   c.SubtreeDataServed,
+			BytesServed:         c.BytesServed,
+			FirstSeen:           time.UnixMilli(c.FirstSeen),
+			LastUpdated:         time.UnixMilli(c.LastUpdated),
+		})
+	}
+
+	return contributions, nil
+}
+
 // convertFromAPIPeerInfo converts a p2p_api peer info (either PeerInfoForCatchup or PeerRegistryInfo) to native PeerInfo
 func convertFromAPIPeerInfo(apiPeer interface{}) *PeerInfo {
 	// Handle both PeerInfoForCatchup and PeerRegistryInfo types
@@ -644,6 +964,7 @@ func convertFromAPIPeerInfo(apiPeer interface{}) *PeerInfo {
 			Height:               p.Height,
 			BlockHash:            p.BlockHash,
 			DataHubURL:           p.DataHubUrl,
+			DataHubMirrorURLs:    p.DataHubMirrorUrls,
 			ReputationScore:      p.CatchupReputationScore,
 			InteractionAttempts:  p.CatchupAttempts,
 			InteractionSuccesses: p.CatchupSuccesses,
@@ -652,35 +973,192 @@ func convertFromAPIPeerInfo(apiPeer interface{}) *PeerInfo {
 	case *p2p_api.PeerRegistryInfo:
 		peerID, _ := peer.Decode(p.Id)
 		return &PeerInfo{
-			ID:                     peerID,
-			ClientName:             p.ClientName,
-			Height:                 p.Height,
-			BlockHash:              p.BlockHash,
-			DataHubURL:             p.DataHubUrl,
-			BanScore:               int(p.BanScore),
-			IsBanned:               p.IsBanned,
-			IsConnected:            p.IsConnected,
-			ConnectedAt:            time.Unix(p.ConnectedAt, 0),
-			BytesReceived:          p.BytesReceived,
-			LastBlockTime:          time.Unix(p.LastBlockTime, 0),
-			LastMessageTime:        time.Unix(p.LastMessageTime, 0),
-			URLResponsive:          p.UrlResponsive,
-			LastURLCheck:           time.Unix(p.LastUrlCheck, 0),
-			Storage:                p.Storage,
-			InteractionAttempts:    p.InteractionAttempts,
-			InteractionSuccesses:   p.InteractionSuccesses,
-			InteractionFailures:    p.InteractionFailures,
-			LastInteractionAttempt: time.Unix(p.LastInteractionAttempt, 0),
-			LastInteractionSuccess: time.Unix(p.LastInteractionSuccess, 0),
-			LastInteractionFailure: time.Unix(p.LastInteractionFailure, 0),
-			ReputationScore:        p.ReputationScore,
-			MaliciousCount:         p.MaliciousCount,
-			AvgResponseTime:        time.Duration(p.AvgResponseTimeMs) * time.Millisecond,
-			LastCatchupError:       p.LastCatchupError,
-			LastCatchupErrorTime:   time.Unix(p.LastCatchupErrorTime, 0),
+			ID:                      peerID,
+			ClientName:              p.ClientName,
+			Height:                  p.Height,
+			BlockHash:               p.BlockHash,
+			DataHubURL:              p.DataHubUrl,
+			DataHubMirrorURLs:       p.DataHubMirrorUrls,
+			BanScore:                int(p.BanScore),
+			IsBanned:                p.IsBanned,
+			IsConnected:             p.IsConnected,
+			ConnectedAt:             time.Unix(p.ConnectedAt, 0),
+			BytesReceived:           p.BytesReceived,
+			LastBlockTime:           time.Unix(p.LastBlockTime, 0),
+			LastMessageTime:         time.Unix(p.LastMessageTime, 0),
+			URLResponsive:           p.UrlResponsive,
+			LastURLCheck:            time.Unix(p.LastUrlCheck, 0),
+			Storage:                 p.Storage,
+			InteractionAttempts:     p.InteractionAttempts,
+			InteractionSuccesses:    p.InteractionSuccesses,
+			InteractionFailures:     p.InteractionFailures,
+			LastInteractionAttempt:  time.Unix(p.LastInteractionAttempt, 0),
+			LastInteractionSuccess:  time.Unix(p.LastInteractionSuccess, 0),
+			LastInteractionFailure:  time.Unix(p.LastInteractionFailure, 0),
+			ReputationScore:         p.ReputationScore,
+			MaliciousCount:          p.MaliciousCount,
+			DoubleSpendCount:        p.DoubleSpendCount,
+			LastDoubleSpend:         time.Unix(p.LastDoubleSpend, 0),
+			AvgResponseTime:         time.Duration(p.AvgResponseTimeMs) * time.Millisecond,
+			LastCatchupError:        p.LastCatchupError,
+			LastCatchupErrorTime:    time.Unix(p.LastCatchupErrorTime, 0),
+			IsOnProbation:           p.IsOnProbation,
+			ProbationUntil:          time.Unix(p.ProbationUntil, 0),
+			BytesSent:               p.BytesSent,
+			IsThrottled:             p.IsThrottled,
+			IsHealthy:               p.IsHealthy,
+			HealthDuration:          time.Duration(p.HealthDurationMs) * time.Millisecond,
+			HealthCheckFailures:     int(p.HealthCheckFailures),
+			IsDataHubDown:           p.IsDataHubDown,
+			IsRelayOnly:             p.IsRelayOnly,
+			Transport:               p.Transport,
+			Country:                 p.Country,
+			ASN:                     p.Asn,
+			ASOrganization:          p.AsOrganization,
+			ProtocolVersion:         p.ProtocolVersion,
+			IsProtocolIncompatible:  p.IsProtocolIncompatible,
+			IsDataHubURLVerified:    p.IsDatahubUrlVerified,
+			MinerID:                 p.MinerId,
+			MinerPublicKey:          p.MinerPublicKey,
+			MinerContact:            p.MinerContact,
+			MinerIDAnnouncedAt:      time.Unix(p.MinerIdAnnouncedAt, 0),
+			InvalidBlocksReceived:   p.InvalidBlocksReceived,
+			InvalidSubtreesReceived: p.InvalidSubtreesReceived,
+			LastInvalidData:         time.Unix(p.LastInvalidData, 0),
+			LastInvalidDataReason:   InvalidDataReason(p.LastInvalidDataReason),
 		}
 	default:
 		// Return empty PeerInfo for unknown types
 		return &PeerInfo{}
 	}
 }
+
+// SignIdentity retrieves the identity document of this node, signed with its peer key over the challenge.
+// Parameters:
+//   - ctx: Context for the operation
+//   - challenge: Challenge chosen by the node verifying the identity
+//
+// Returns:
+//   - *IdentityDocument: The signed identity document
+//   - error: Any error encountered during the operation
+func (c *Client) SignIdentity(ctx context.Context, challenge string) (*IdentityDocument, error) {
+	resp, err := c.client.SignIdentity(ctx, &p2p_api.SignIdentityRequest{Challenge: challenge})
+	if err != nil {
+		return nil, err
+	}
+
+	return &IdentityDocument{
+		PeerID:     resp.PeerId,
+		DataHubURL: resp.DatahubUrl,
+		Challenge:  resp.Challenge,
+		Timestamp:  resp.Timestamp,
+		Signature:  resp.Signature,
+	}, nil
+}
+
+// SendOperatorMessage sends a direct message to the operator of another node, encrypted to the peer.
+// Parameters:
+//   - ctx: Context for the operation
+//   - peerID: Peer ID of the recipient
+//   - kind: Purpose of the message, restart, throttle, maintenance or info
+//   - text: Free text of the message
+//
+// Returns:
+//   - *OperatorMessage: The message as sent
+//   - error: Any error encountered during the operation
+func (c *Client) SendOperatorMessage(ctx context.Context, peerID string, kind OperatorMessageKind, text string) (*OperatorMessage, error) {
+	resp, err := c.client.SendOperatorMessage(ctx, &p2p_api.SendOperatorMessageRequest{
+		PeerId: peerID,
+		Kind:   string(kind),
+		Text:   text,
+	})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	msg := operatorMessageFromAPI(resp.Message)
+
+	return &msg, nil
+}
+
+// GetOperatorMessages retrieves the most recent operator messages sent and received by the P2P service.
+// Parameters:
+//   - ctx: Context for the operation
+//   - peerID: Only return the messages exchanged with this peer, empty for all peers
+//   - limit: Maximum number of messages, zero for all kept messages
+//
+// Returns:
+//   - []OperatorMessage: The messages, most recent first
+//   - error: Any error encountered during the operation
+func (c *Client) GetOperatorMessages(ctx context.Context, peerID string, limit int) ([]OperatorMessage, error) {
+	resp, err := c.client.GetOperatorMessages(ctx, &p2p_api.GetOperatorMessagesRequest{
+		PeerId: peerID,
+		Limit:  int32(limit), //nolint:gosec // the limit of an API request
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]OperatorMessage, 0, len(resp.Messages))
+	for _, m := range resp.Messages {
+		messages = append(messages, operatorMessageFromAPI(m))
+	}
+
+	return messages, nil
+}
+
+// SubscribeDoubleSpends streams the double spends detected by the validator from the P2P service.
+// Parameters:
+//   - ctx: Context for the subscription, cancelling it ends the subscription
+//
+// Returns:
+//   - <-chan DoubleSpendEvent: The double spends, closed when the subscription ends
+//   - error: Any error encountered opening the subscription
+func (c *Client) SubscribeDoubleSpends(ctx context.Context) (<-chan DoubleSpendEvent, error) {
+	stream, err := c.client.SubscribeDoubleSpends(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	ch := make(chan DoubleSpendEvent)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			apiEvent, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					c.logger.Debugf("[P2P] double spend subscription ended: %v", errors.UnwrapGRPC(err))
+				}
+
+				return
+			}
+
+			select {
+			case ch <- doubleSpendEventFromAPI(apiEvent):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// operatorMessageFromAPI converts the gRPC representation of an operator message
+func operatorMessageFromAPI(m *p2p_api.OperatorMessage) OperatorMessage {
+	if m == nil {
+		return OperatorMessage{}
+	}
+
+	return OperatorMessage{
+		ID:        m.Id,
+		PeerID:    m.PeerId,
+		Direction: m.Direction,
+		Kind:      OperatorMessageKind(m.Kind),
+		Text:      m.Text,
+		SentAt:    time.UnixMilli(m.SentAt),
+		At:        time.UnixMilli(m.At),
+	}
+}
@@ -37,10 +37,19 @@ type MockPeerServiceClient struct {
 	GetPeersForCatchupFunc      func(ctx context.Context, in *p2p_api.GetPeersForCatchupRequest, opts ...grpc.CallOption) (*p2p_api.GetPeersForCatchupResponse, error)
 	ReportValidSubtreeFunc      func(ctx context.Context, in *p2p_api.ReportValidSubtreeRequest, opts ...grpc.CallOption) (*p2p_api.ReportValidSubtreeResponse, error)
 	ReportValidBlockFunc        func(ctx context.Context, in *p2p_api.ReportValidBlockRequest, opts ...grpc.CallOption) (*p2p_api.ReportValidBlockResponse, error)
+	RecordInvalidSubtreeFunc    func(ctx context.Context, in *p2p_api.RecordInvalidSubtreeRequest, opts ...grpc.CallOption) (*p2p_api.RecordInvalidSubtreeResponse, error)
+	RecordInvalidBlockFunc      func(ctx context.Context, in *p2p_api.RecordInvalidBlockRequest, opts ...grpc.CallOption) (*p2p_api.RecordInvalidBlockResponse, error)
 	IsPeerMaliciousFunc         func(ctx context.Context, in *p2p_api.IsPeerMaliciousRequest, opts ...grpc.CallOption) (*p2p_api.IsPeerMaliciousResponse, error)
 	IsPeerUnhealthyFunc         func(ctx context.Context, in *p2p_api.IsPeerUnhealthyRequest, opts ...grpc.CallOption) (*p2p_api.IsPeerUnhealthyResponse, error)
 	GetPeerRegistryFunc         func(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*p2p_api.GetPeerRegistryResponse, error)
 	GetPeerFunc                 func(ctx context.Context, in *p2p_api.GetPeerRequest, opts ...grpc.CallOption) (*p2p_api.GetPeerResponse, error)
+	GetNetworkOverviewFunc      func(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*p2p_api.GetNetworkOverviewResponse, error)
+	GetPeerEventsFunc           func(ctx context.Context, in *p2p_api.GetPeerEventsRequest, opts ...grpc.CallOption) (*p2p_api.GetPeerEventsResponse, error)
+	GetPeerContributionsFunc    func(ctx context.Context, in *p2p_api.GetPeerContributionsRequest, opts ...grpc.CallOption) (*p2p_api.GetPeerContributionsResponse, error)
+	SignIdentityFunc            func(ctx context.Context, in *p2p_api.SignIdentityRequest, opts ...grpc.CallOption) (*p2p_api.SignIdentityResponse, error)
+	SendOperatorMessageFunc     func(ctx context.Context, in *p2p_api.SendOperatorMessageRequest, opts ...grpc.CallOption) (*p2p_api.SendOperatorMessageResponse, error)
+	GetOperatorMessagesFunc     func(ctx context.Context, in *p2p_api.GetOperatorMessagesRequest, opts ...grpc.CallOption) (*p2p_api.GetOperatorMessagesResponse, error)
+	SubscribeDoubleSpendsFunc   func(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[p2p_api.DoubleSpendEvent], error)
 }
 
 func (m *MockPeerServiceClient) GetPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*p2p_api.GetPeersResponse, error) {
@@ -169,6 +178,20 @@ func (m *MockPeerServiceClient) ReportValidBlock(ctx context.Context, in *p2p_ap
 	return &p2p_api.ReportValidBlockResponse{Success: true}, nil
 }
 
+func (m *MockPeerServiceClient) RecordInvalidSubtree(ctx context.Context, in *p2p_api.RecordInvalidSubtreeRequest, opts ...grpc.CallOption) (*p2p_api.RecordInvalidSubtreeResponse, error) {
+	if m.RecordInvalidSubtreeFunc != nil {
+		return m.RecordInvalidSubtreeFunc(ctx, in, opts...)
+	}
+	return &p2p_api.RecordInvalidSubtreeResponse{Success: true}, nil
+}
+
+func (m *MockPeerServiceClient) RecordInvalidBlock(ctx context.Context, in *p2p_api.RecordInvalidBlockRequest, opts ...grpc.CallOption) (*p2p_api.RecordInvalidBlockResponse, error) {
+	if m.RecordInvalidBlockFunc != nil {
+		return m.RecordInvalidBlockFunc(ctx, in, opts...)
+	}
+	return &p2p_api.RecordInvalidBlockResponse{Success: true}, nil
+}
+
 func (m *MockPeerServiceClient) IsPeerMalicious(ctx context.Context, in *p2p_api.IsPeerMaliciousRequest, opts ...grpc.CallOption) (*p2p_api.IsPeerMaliciousResponse, error) {
 	if m.IsPeerMaliciousFunc != nil {
 		return m.IsPeerMaliciousFunc(ctx, in, opts...)
@@ -196,6 +219,10 @@ func (m *MockPeerServiceClient) RecordBytesDownloaded(ctx context.Context, in *p
 	return &p2p_api.RecordBytesDownloadedResponse{Ok: true}, nil
 }
 
+func (m *MockPeerServiceClient) RecordBytesUploaded(ctx context.Context, in *p2p_api.RecordBytesUploadedRequest, opts ...grpc.CallOption) (*p2p_api.RecordBytesUploadedResponse, error) {
+	return &p2p_api.RecordBytesUploadedResponse{Ok: true}, nil
+}
+
 func (m *MockPeerServiceClient) GetPeer(ctx context.Context, in *p2p_api.GetPeerRequest, opts ...grpc.CallOption) (*p2p_api.GetPeerResponse, error) {
 	if m.GetPeerFunc != nil {
 		return m.GetPeerFunc(ctx, in, opts...)
@@ -205,6 +232,55 @@ func (m *MockPeerServiceClient) GetPeer(ctx context.Context, in *p2p_api.GetPeer
 	}, nil
 }
 
+func (m *MockPeerServiceClient) GetNetworkOverview(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*p2p_api.GetNetworkOverviewResponse, error) {
+	if m.GetNetworkOverviewFunc != nil {
+		return m.GetNetworkOverviewFunc(ctx, in, opts...)
+	}
+	return &p2p_api.GetNetworkOverviewResponse{}, nil
+}
+
+func (m *MockPeerServiceClient) GetPeerEvents(ctx context.Context, in *p2p_api.GetPeerEventsRequest, opts ...grpc.CallOption) (*p2p_api.GetPeerEventsResponse, error) {
+	if m.GetPeerEventsFunc != nil {
+		return m.GetPeerEventsFunc(ctx, in, opts...)
+	}
+	return &p2p_api.GetPeerEventsResponse{}, nil
+}
+
+func (m *MockPeerServiceClient) GetPeerContributions(ctx context.Context, in *p2p_api.GetPeerContributionsRequest, opts ...grpc.CallOption) (*p2p_api.GetPeerContributionsResponse, error) {
+	if m.GetPeerContributionsFunc != nil {
+		return m.GetPeerContributionsFunc(ctx, in, opts...)
+	}
+	return &p2p_api.GetPeerContributionsResponse{}, nil
+}
+
+func (m *MockPeerServiceClient) SignIdentity(ctx context.Context, in *p2p_api.SignIdentityRequest, opts ...grpc.CallOption) (*p2p_api.SignIdentityResponse, error) {
+	if m.SignIdentityFunc != nil {
+		return m.SignIdentityFunc(ctx, in, opts...)
+	}
+	return &p2p_api.SignIdentityResponse{}, nil
+}
+
+func (m *MockPeerServiceClient) SendOperatorMessage(ctx context.Context, in *p2p_api.SendOperatorMessageRequest, opts ...grpc.CallOption) (*p2p_api.SendOperatorMessageResponse, error) {
+	if m.SendOperatorMessageFunc != nil {
+		return m.SendOperatorMessageFunc(ctx, in, opts...)
+	}
+	return &p2p_api.SendOperatorMessageResponse{}, nil
+}
+
+func (m *MockPeerServiceClient) GetOperatorMessages(ctx context.Context, in *p2p_api.GetOperatorMessagesRequest, opts ...grpc.CallOption) (*p2p_api.GetOperatorMessagesResponse, error) {
+	if m.GetOperatorMessagesFunc != nil {
+		return m.GetOperatorMessagesFunc(ctx, in, opts...)
+	}
+	return &p2p_api.GetOperatorMessagesResponse{}, nil
+}
+
+func (m *MockPeerServiceClient) SubscribeDoubleSpends(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[p2p_api.DoubleSpendEvent], error) {
+	if m.SubscribeDoubleSpendsFunc != nil {
+		return m.SubscribeDoubleSpendsFunc(ctx, in, opts...)
+	}
+	return nil, nil
+}
+
 func TestSimpleClientGetPeers(t *testing.T) {
 	mockClient := &MockPeerServiceClient{
 		GetPeersFunc: func(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*p2p_api.GetPeersResponse, error) {
@@ -344,6 +420,26 @@ func TestSimpleClientAddBanScore(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSimpleClientAddBanScorePoints(t *testing.T) {
+	mockClient := &MockPeerServiceClient{
+		AddBanScoreFunc: func(ctx context.Context, in *p2p_api.AddBanScoreRequest, opts ...grpc.CallOption) (*p2p_api.AddBanScoreResponse, error) {
+			assert.Equal(t, "peer1", in.PeerId)
+			assert.Equal(t, "invalid_block", in.Reason)
+			assert.Equal(t, int32(25), in.Score)
+			return &p2p_api.AddBanScoreResponse{Ok: true}, nil
+		},
+	}
+
+	client := &Client{
+		client: mockClient,
+		logger: ulogger.New("test"),
+	}
+
+	ctx := context.Background()
+	err := client.AddBanScorePoints(ctx, "peer1", "invalid_block", 25)
+	assert.NoError(t, err)
+}
+
 func TestSimpleClientConnectPeer(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockClient := &MockPeerServiceClient{
@@ -54,6 +54,12 @@ type notificationMsg struct {
 	MinMiningTxFee      *float64 `json:"min_mining_tx_fee,omitempty"`     // Minimum mining transaction fee configured for this node (nil = unknown, 0 = no fee)
 	ConnectedPeersCount int      `json:"connected_peers_count,omitempty"` // Number of connected peers
 	Storage             string   `json:"storage,omitempty"`               // Storage mode: "full" (block persister running and caught up), "pruned" (no persister or lagging), or empty (old version)
+	DataHubSelfTestOK   *bool    `json:"datahub_self_test_ok,omitempty"`  // Whether the last self-test of our own DataHub URL passed (nil = disabled or not run yet)
+	DataHubSelfTestErr  string   `json:"datahub_self_test_err,omitempty"` // Error from the last failed DataHub self-test
+	DataHubMirrorURLs   []string `json:"datahub_mirror_urls,omitempty"`   // Mirrors of the DataHub at BaseURL
+	// Operator message fields
+	MessageKind string `json:"message_kind,omitempty"` // Kind of a received operator message
+	MessageText string `json:"message_text,omitempty"` // Text of a received operator message
 }
 
 // clientChannelMap manages a thread-safe collection of WebSocket client channels.
@@ -12,21 +12,26 @@ import (
 // This struct represents the public API contract for peer data, decoupled from
 // any transport-specific representations (like protobuf).
 type PeerInfo struct {
-	ID              peer.ID
-	ClientName      string // Human-readable name of the client software
-	Height          int32
-	BlockHash       string
-	DataHubURL      string
-	BanScore        int
-	IsBanned        bool
-	IsConnected     bool // Whether this peer is directly connected (vs gossiped)
-	ConnectedAt     time.Time
-	BytesReceived   uint64
-	LastBlockTime   time.Time
-	LastMessageTime time.Time // Last time we received any message from this peer
-	URLResponsive   bool      // Whether the DataHub URL is responsive
-	LastURLCheck    time.Time // Last time we checked URL responsiveness
-	Storage         string    // Storage mode: "full", "pruned", or empty (unknown/old version)
+	ID                peer.ID
+	ClientName        string // Human-readable name of the client software
+	Height            int32
+	BlockHash         string
+	DataHubURL        string
+	DataHubMirrorURLs []string // Mirrors of the DataHub, tried in order when DataHubURL fails
+	BanScore          int
+	IsBanned          bool
+	IsConnected       bool   // Whether this peer is directly connected (vs gossiped)
+	IsRelayOnly       bool   // Whether all live connections to this peer go through a circuit relay
+	Transport         string // Transport of the live connections to this peer: tcp, quic or relay
+	ConnectedAt       time.Time
+	BytesReceived     uint64
+	BytesSent         uint64 // Bytes served to this peer
+	IsThrottled       bool   // Whether the peer is throttled for exceeding its bandwidth quota
+	LastBlockTime     time.Time
+	LastMessageTime   time.Time // Last time we received any message from this peer
+	URLResponsive     bool      // Whether the DataHub URL is responsive
+	LastURLCheck      time.Time // Last time we checked URL responsiveness
+	Storage           string    // Storage mode: "full", "pruned", or empty (unknown/old version)
 
 	// Interaction metrics - track peer reliability across all interactions (blocks, subtrees, catchup, etc.)
 	InteractionAttempts    int64         // Total number of interactions with this peer
@@ -38,6 +43,8 @@ type PeerInfo struct {
 	ReputationScore        float64       // Reputation score (0-100) for overall reliability
 	MaliciousCount         int64         // Count of malicious behavior detections
 	AvgResponseTime        time.Duration // Average response time for all interactions
+	DoubleSpendCount       int64         // Number of double spending transactions received from this peer
+	LastDoubleSpend        time.Time     // Last time a double spending transaction was received from this peer
 
 	// Interaction type breakdown (optional tracking)
 	BlocksReceived       int64 // Number of blocks received from this peer
@@ -45,6 +52,12 @@ type PeerInfo struct {
 	TransactionsReceived int64 // Number of transactions received from this peer
 	CatchupBlocks        int64 // Number of blocks received during catchup
 
+	// Invalid data, reported by the validation services
+	InvalidBlocksReceived   int64             // Number of invalid blocks received from this peer
+	InvalidSubtreesReceived int64             // Number of invalid subtrees received from this peer
+	LastInvalidData         time.Time         // Last time an invalid block or subtree was received from this peer
+	LastInvalidDataReason   InvalidDataReason // Reason the last invalid block or subtree was rejected
+
 	// Sync attempt tracking for backoff and recovery
 	LastSyncAttempt      time.Time // When we last attempted to sync with this peer
 	SyncAttemptCount     int       // Number of sync attempts with this peer
@@ -54,6 +67,64 @@ type PeerInfo struct {
 	// Catchup error tracking
 	LastCatchupError     string    // Last error message from catchup attempt with this peer
 	LastCatchupErrorTime time.Time // When the last catchup error occurred
+
+	// Probation tracking for peers re-admitted after a ban
+	IsOnProbation      bool      // Whether the peer is on probation (restricted, not used for catchup)
+	ProbationStartedAt time.Time // When the current probation period started
+	ProbationUntil     time.Time // When the peer is due to be promoted back if it behaves
+
+	// DataHub health, probed periodically by the DataHub health checker
+	IsHealthy           bool          // Whether the last probe of the DataHub URL succeeded
+	HealthDuration      time.Duration // Latency of the last probe of the DataHub URL
+	HealthCheckFailures int           // Number of consecutive failed probes of the DataHub URL
+	IsDataHubDown       bool          // Whether the DataHub URL failed too many probes, excluding the peer from catchup
+
+	// DataHub identity, verified periodically by the DataHub identity verifier
+	IsDataHubURLVerified bool      // Whether the DataHub URL served an identity document signed by the peer key
+	DataHubURLVerifiedAt time.Time // When the DataHub URL was last verified
+
+	// Miner identification, from the signed miner ID announcements of the peer
+	MinerID            string    // Miner ID of the mining operator the peer belongs to
+	MinerPublicKey     string    // Hex encoded public key the miner ID announcement is signed with
+	MinerContact       string    // Contact of the mining operator
+	MinerIDAnnouncedAt time.Time // When the last accepted miner ID announcement was signed
+
+	// Location of the address of the live connections, from the configured GeoIP databases
+	Country        string // ISO 3166-1 alpha-2 country code
+	ASN            uint32 // Autonomous system number
+	ASOrganization string // Organization the autonomous system is registered to
+
+	// P2P wire protocol version, negotiated from the versions the peer advertises in its node status
+	ProtocolVersion        uint32 // Highest version both sides support, 0 before the first node status of the peer
+	IsProtocolIncompatible bool   // Whether the peer has no version in common, its messages are ignored
+}
+
+// NetworkOverview is an aggregate view of the network as seen by this node,
+// computed from the peer registry.
+type NetworkOverview struct {
+	TotalPeers         int
+	ConnectedPeers     int
+	BestHeight         int32         // Highest height advertised by any peer
+	HeightDistribution []HeightCount // Peer count per advertised height, highest first
+	ChainTips          []ChainTip    // Most advertised chain tips, most peers first
+	AvgResponseTime    time.Duration // Average response time over peers with measurements
+	PeersJoined        int           // Peers added to the registry within the churn window
+	PeersLeft          int           // Peers removed from the registry within the churn window
+	ChurnRate          float64       // (joined + left) / total peers over the churn window
+	ChurnWindow        time.Duration // Window the churn figures cover
+}
+
+// HeightCount is the number of peers advertising a given height
+type HeightCount struct {
+	Height    int32
+	PeerCount int
+}
+
+// ChainTip is a block hash advertised as best block by one or more peers
+type ChainTip struct {
+	BlockHash string
+	Height    int32
+	PeerCount int
 }
 
 // ClientI defines the interface for P2P client operations.
@@ -65,6 +136,14 @@ type PeerInfo struct {
 // and typically map directly to RPC endpoints. All methods accept a context for
 // cancellation and timeout control.
 type ClientI interface {
+	// IsAvailable checks whether the P2P service can currently be reached.
+	// The connection is kept up in the background and reconnected with an exponential
+	// backoff, calls made while it is down wait a short time for it to come back and
+	// then fail with a service unavailable error.
+	//
+	// Returns true when the connection to the P2P service is up.
+	IsAvailable() bool
+
 	// GetPeers retrieves a list of connected peers from the P2P network.
 	// It provides information about all active peer connections including their
 	// addresses, connection details, and network statistics.
@@ -96,6 +175,17 @@ type ClientI interface {
 	// Returns an error if the ban operation fails.
 	BanPeer(ctx context.Context, addr string, until int64) error
 
+	// BanPeerWithReason bans a peer like BanPeer, recording the reason of the ban in the peer event log.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	// - addr: Peer ID, IP address or subnet to ban
+	// - until: Unix timestamp when the ban expires
+	// - reason: Reason of the ban, e.g. provided by an operator
+	//
+	// Returns an error if the ban operation fails.
+	BanPeerWithReason(ctx context.Context, addr string, until int64, reason string) error
+
 	// UnbanPeer removes a peer from the ban list, allowing future connections.
 	// It operates on peer ID, IP address, or subnet as specified in the request.
 	//
@@ -144,6 +234,18 @@ type ClientI interface {
 	// Returns an error if the operation fails.
 	AddBanScore(ctx context.Context, peerID string, reason string) error
 
+	// AddBanScorePoints adds the given points to a peer's ban score with the specified reason, instead of the
+	// points of the reason, e.g. the ban-score delta suggested by a peer-attributable error.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	// - peerID: Peer ID to add ban score to
+	// - reason: Reason for adding ban score
+	// - points: Points to add to the ban score, the points of the reason are used when 0
+	//
+	// Returns an error if the operation fails.
+	AddBanScorePoints(ctx context.Context, peerID string, reason string, points int) error
+
 	// ConnectPeer connects to a specific peer using the provided multiaddr
 	// Returns an error if the connection fails.
 	ConnectPeer(ctx context.Context, peerAddr string) error
@@ -186,6 +288,14 @@ type ClientI interface {
 	// This increases the peer's reputation score for providing valid blocks.
 	ReportValidBlock(ctx context.Context, peerID string, blockHash string) error
 
+	// RecordInvalidSubtree reports that a subtree received from a peer was rejected.
+	// This lowers the peer's reputation score, invalid and malformed subtrees mark the peer as malicious.
+	RecordInvalidSubtree(ctx context.Context, peerID string, subtreeHash string, reason InvalidDataReason, details string) error
+
+	// RecordInvalidBlock reports that a block received from a peer was rejected.
+	// This lowers the peer's reputation score, invalid and malformed blocks mark the peer as malicious.
+	RecordInvalidBlock(ctx context.Context, peerID string, blockHash string, reason InvalidDataReason, details string) error
+
 	// IsPeerMalicious checks if a peer is considered malicious based on their behavior.
 	// A peer is considered malicious if they are banned or have a very low reputation score.
 	IsPeerMalicious(ctx context.Context, peerID string) (bool, string, error)
@@ -209,4 +319,115 @@ type ClientI interface {
 	//
 	// Returns an error if the operation fails.
 	RecordBytesDownloaded(ctx context.Context, peerID string, bytesDownloaded uint64) error
+
+	// RecordBytesUploaded records the number of bytes served to a peer.
+	// Upload usage is accounted over a rolling window and checked against the per-peer upload quota.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	// - peerID: Peer ID string the data was served to
+	// - bytesUploaded: Number of bytes uploaded in this operation
+	//
+	// Returns an error if the operation fails.
+	RecordBytesUploaded(ctx context.Context, peerID string, bytesUploaded uint64) error
+
+	// RecordDataDownloaded records data of a known type downloaded via HTTP from a peer. Besides the
+	// network usage recorded by RecordBytesDownloaded, the items are accounted in the peer's data contribution.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	// - peerID: Peer ID string that provided the data
+	// - dataType: PeerDataTypeBlock, PeerDataTypeSubtree or PeerDataTypeSubtreeData
+	// - items: Number of blocks, subtrees or subtree data files downloaded
+	// - bytesDownloaded: Number of bytes downloaded in this operation
+	//
+	// Returns an error if the operation fails.
+	RecordDataDownloaded(ctx context.Context, peerID string, dataType string, items uint64, bytesDownloaded uint64) error
+
+	// RecordDataUploaded records data of a known type served to a peer. Besides the upload usage recorded
+	// by RecordBytesUploaded, the items are accounted in the peer's data contribution.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	// - peerID: Peer ID string the data was served to
+	// - dataType: PeerDataTypeBlock, PeerDataTypeSubtree or PeerDataTypeSubtreeData
+	// - items: Number of blocks, subtrees or subtree data files served
+	// - bytesUploaded: Number of bytes uploaded in this operation
+	//
+	// Returns an error if the operation fails.
+	RecordDataUploaded(ctx context.Context, peerID string, dataType string, items uint64, bytesUploaded uint64) error
+
+	// GetNetworkOverview retrieves an aggregate view of the network computed from the peer registry,
+	// including height distribution, most advertised chain tips, average latency and peer churn.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	//
+	// Returns the network overview or an error if the operation fails.
+	GetNetworkOverview(ctx context.Context) (*NetworkOverview, error)
+
+	// GetPeerEvents retrieves peer lifecycle events (connects, disconnects, bans, reputation changes and
+	// catchup interactions) from the peer event log, oldest first.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	// - from: Start of the time range, zero for no lower bound
+	// - to: End of the time range, zero for no upper bound
+	// - peerID: Only return events of this peer, empty for all peers
+	// - limit: Maximum number of most recent events to return, 0 for all
+	//
+	// Returns the matching events or an error if the operation fails.
+	GetPeerEvents(ctx context.Context, from time.Time, to time.Time, peerID string, limit int) ([]PeerEvent, error)
+
+	// GetPeerContributions retrieves the lifetime totals of the data each peer served to us and we served
+	// to it, ordered by the bytes the peer served to us, lowest first.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	// - peerID: Only return the contribution of this peer, empty for all peers
+	//
+	// Returns the contributions or an error if the operation fails.
+	GetPeerContributions(ctx context.Context, peerID string) ([]PeerContribution, error)
+
+	// SignIdentity retrieves the identity document of this node: its peer ID and DataHub URL, signed with
+	// the peer key over a challenge, proving to other nodes that the DataHub URL belongs to this peer.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	// - challenge: Challenge chosen by the node verifying the identity
+	//
+	// Returns the signed identity document or an error if the operation fails.
+	SignIdentity(ctx context.Context, challenge string) (*IdentityDocument, error)
+
+	// SendOperatorMessage sends a direct message to the operator of another node, e.g. announcing a restart,
+	// encrypted to the peer and rate limited per peer. Requires operator messages to be enabled.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	// - peerID: Peer ID of the recipient
+	// - kind: Purpose of the message
+	// - text: Free text of the message
+	//
+	// Returns the message as sent or an error if the operation fails.
+	SendOperatorMessage(ctx context.Context, peerID string, kind OperatorMessageKind, text string) (*OperatorMessage, error)
+
+	// GetOperatorMessages retrieves the most recent operator messages sent to and received from other nodes,
+	// most recent first.
+	//
+	// Parameters:
+	// - ctx: Context for the operation
+	// - peerID: Only return the messages exchanged with this peer, empty for all peers
+	// - limit: Maximum number of messages, zero for all kept messages
+	//
+	// Returns the messages or an error if the operation fails.
+	GetOperatorMessages(ctx context.Context, peerID string, limit int) ([]OperatorMessage, error)
+
+	// SubscribeDoubleSpends streams the double spends detected by the validator.
+	//
+	// Parameters:
+	// - ctx: Context for the subscription, cancelling it ends the subscription
+	//
+	// Returns a channel receiving the double spends, closed when the subscription ends, or an error if the
+	// subscription fails.
+	SubscribeDoubleSpends(ctx context.Context) (<-chan DoubleSpendEvent, error)
 }
@@ -31,6 +31,7 @@ import (
 
 	"github.com/bsv-blockchain/go-bt/v2/chainhash"
 	p2pMessageBus "github.com/bsv-blockchain/go-p2p-message-bus"
+	bec "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockassembly"
@@ -39,6 +40,7 @@ import (
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/geoip"
 	"github.com/bsv-blockchain/teranode/util/health"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
@@ -48,7 +50,6 @@ import (
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -83,6 +84,7 @@ type peerMapEntry struct {
 type Server struct {
 	p2p_api.UnimplementedPeerServiceServer
 	P2PClient                         p2pMessageBus.P2PClient   // The P2P network client
+	privKey                           crypto.PrivKey            // Peer key, signs the identity document of our DataHub URL
 	logger                            ulogger.Logger            // Logger instance for the server
 	settings                          *settings.Settings        // Configuration settings
 	bitcoinProtocolVersion            string                    // Bitcoin protocol identifier
@@ -94,6 +96,7 @@ type Server struct {
 	rejectedTxKafkaConsumerClient     kafka.KafkaConsumerGroupI // Kafka consumer for rejected transactions
 	invalidBlocksKafkaConsumerClient  kafka.KafkaConsumerGroupI // Kafka consumer for invalid blocks
 	invalidSubtreeKafkaConsumerClient kafka.KafkaConsumerGroupI // Kafka consumer for invalid subtrees
+	doubleSpendsKafkaConsumerClient   kafka.KafkaConsumerGroupI // Kafka consumer for double spends, nil when not configured
 	subtreeKafkaProducerClient        kafka.KafkaAsyncProducerI // Kafka producer for subtrees
 	blocksKafkaProducerClient         kafka.KafkaAsyncProducerI // Kafka producer for blocks
 	banList                           BanListI                  // List of banned peers
@@ -103,23 +106,49 @@ type Server struct {
 	blockTopicName                    string
 	subtreeTopicName                  string
 	rejectedTxTopicName               string
-	invalidBlocksTopicName            string           // Kafka topic for invalid blocks
-	invalidSubtreeTopicName           string           // Kafka topic for invalid subtrees
-	nodeStatusTopicName               string           // pubsub topic for node status messages
-	topicPrefix                       string           // Chain identifier prefix for topic validation
-	blockPeerMap                      sync.Map         // Map to track which peer sent each block (hash -> peerMapEntry)
-	subtreePeerMap                    sync.Map         // Map to track which peer sent each subtree (hash -> peerMapEntry)
-	startTime                         time.Time        // Server start time for uptime calculation
-	peerRegistry                      *PeerRegistry    // Central registry for all peer information
-	peerSelector                      *PeerSelector    // Stateless peer selection logic
-	syncCoordinator                   *SyncCoordinator // Orchestrates sync operations
-	syncConnectionTimes               sync.Map         // Map to track when we first connected to each sync peer (peerID -> timestamp)
+	invalidBlocksTopicName            string                // Kafka topic for invalid blocks
+	invalidSubtreeTopicName           string                // Kafka topic for invalid subtrees
+	nodeStatusTopicName               string                // pubsub topic for node status messages
+	operatorMessageTopicName          string                // pubsub topic for direct messages between node operators
+	minerIDTopicName                  string                // pubsub topic for miner identification announcements
+	topicPrefix                       string                // Chain identifier prefix for topic validation
+	blockPeerMap                      sync.Map              // Map to track which peer sent each block (hash -> peerMapEntry)
+	subtreePeerMap                    sync.Map              // Map to track which peer sent each subtree (hash -> peerMapEntry)
+	startTime                         time.Time             // Server start time for uptime calculation
+	peerRegistry                      *PeerRegistry         // Central registry for all peer information
+	peerSelector                      *PeerSelector         // Stateless peer selection logic
+	syncCoordinator                   *SyncCoordinator      // Orchestrates sync operations
+	syncConnectionTimes               sync.Map              // Map to track when we first connected to each sync peer (peerID -> timestamp)
+	bandwidthTracker                  *PeerBandwidthTracker // Rolling per-peer download/upload accounting for quotas
+	dataHubSelfTest                   dataHubSelfTest       // Outcome of the self-test against our own advertised DataHub URL
+	peerEvents                        *PeerEventLog         // Log of peer lifecycle events for post-incident analysis
+
+	// peerContributions accounts the data served by and to each peer, persisted next to the peer registry cache
+	peerContributions *PeerContributionLedger
+
+	// messageRecorder records the received topic messages for offline replay, nil when recording is disabled
+	messageRecorder *MessageRecorder
+
+	// operatorMessenger seals and opens the direct messages between node operators, nil when disabled
+	operatorMessenger *OperatorMessenger
+
+	// minerIDKey signs the miner ID announcements of this node, nil when this node does not announce a miner ID
+	minerIDKey *bec.PrivateKey
+
+	// geoLocator looks up the country and ASN of the addresses of peers, nil when no GeoIP database is configured
+	geoLocator *geoip.Locator
+
+	// protocolVersions is the range of wire protocol versions this node supports, negotiated with the range every
+	// peer advertises in its node status
+	protocolVersions protocolVersionRange
+
+	// doubleSpends fans the double spends detected by the validator out to the SubscribeDoubleSpends streams
+	doubleSpends doubleSpendSubscribers
 
 	// Cleanup configuration
-	peerMapCleanupTicker    *time.Ticker  // Ticker for periodic cleanup of peer maps
-	peerMapMaxSize          int           // Maximum number of entries in peer maps
-	peerMapTTL              time.Duration // Time-to-live for peer map entries
-	registryCacheSaveTicker *time.Ticker  // Ticker for periodic saving of peer registry cache
+	peerMapCleanupTicker *time.Ticker  // Ticker for periodic cleanup of peer maps
+	peerMapMaxSize       int           // Maximum number of entries in peer maps
+	peerMapTTL           time.Duration // Time-to-live for peer map entries
 }
 
 // NewServer creates a new P2P server instance with the provided configuration and dependencies.
@@ -134,6 +163,7 @@ type Server struct {
 // - rejectedTxKafkaConsumerClient: Kafka consumer client for receiving rejected transaction notifications
 // - invalidBlocksKafkaConsumerClient: Kafka consumer client for receiving invalid block notifications
 // - invalidSubtreeKafkaConsumerClient: Kafka consumer client for receiving invalid subtree notifications
+// - doubleSpendsKafkaConsumerClient: Kafka consumer client for receiving double spend notifications, nil when not configured
 // - subtreeKafkaProducerClient: Kafka producer client for publishing subtree data
 // - blocksKafkaProducerClient: Kafka producer client for publishing block data
 //
@@ -163,6 +193,7 @@ func NewServer(
 	rejectedTxKafkaConsumerClient kafka.KafkaConsumerGroupI,
 	invalidBlocksKafkaConsumerClient kafka.KafkaConsumerGroupI,
 	invalidSubtreeKafkaConsumerClient kafka.KafkaConsumerGroupI,
+	doubleSpendsKafkaConsumerClient kafka.KafkaConsumerGroupI,
 	subtreeKafkaProducerClient kafka.KafkaAsyncProducerI,
 	blocksKafkaProducerClient kafka.KafkaAsyncProducerI,
 ) (*Server, error) {
@@ -208,6 +239,17 @@ func NewServer(
 		return nil, errors.NewConfigurationError("listen_mode must be either '%s' or '%s' (got '%s')", settings.ListenModeFull, settings.ListenModeListenOnly, listenMode)
 	}
 
+	peerSelection := tSettings.P2P.CatchupPeerSelection
+	if peerSelection != "" && peerSelection != CatchupPeerSelectionBest && peerSelection != CatchupPeerSelectionWeightedRandom {
+		return nil, errors.NewConfigurationError("p2p_catchup_peer_selection must be either '%s' or '%s' (got '%s')", CatchupPeerSelectionBest, CatchupPeerSelectionWeightedRandom, peerSelection)
+	}
+
+	if exploration := tSettings.P2P.CatchupPeerExploration; exploration < 0 || exploration > 1 {
+		return nil, errors.NewConfigurationError("p2p_catchup_peer_exploration must be between 0 and 1 (got %v)", exploration)
+	}
+
+	initPrometheusMetrics()
+
 	banlist, banChan, err := GetBanList(ctx, logger, tSettings)
 	if err != nil {
 		return nil, errors.NewServiceError("error getting banlist", err)
@@ -276,29 +318,27 @@ func NewServer(
 	}
 
 	// Configure advertise addresses
-	// With go-p2p v1.2.1, address advertisement is handled more intelligently:
 	// - If AdvertiseAddresses is explicitly set, those addresses are used
 	// - If SharePrivateAddresses is true, we pass listen addresses to ensure local connectivity
 	// - Otherwise, go-p2p will automatically filter private IPs and detect public addresses
-	var advertiseAddresses []string
-	if len(tSettings.P2P.AdvertiseAddresses) > 0 {
-		// Use explicitly configured advertise addresses
-		advertiseAddresses = tSettings.P2P.AdvertiseAddresses
+	// Addresses can be multiaddrs, IP addresses or DNS names and are converted to multiaddrs
+	advertiseAddresses, err := getAdvertiseAddresses(logger, tSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(tSettings.P2P.AdvertiseAddresses) > 0:
 		logger.Infof("Using configured advertise addresses: %v", advertiseAddresses)
-	} else if tSettings.P2P.SharePrivateAddresses {
-		// Share private addresses for local/test environments
-		advertiseAddresses = listenAddresses
+	case tSettings.P2P.SharePrivateAddresses:
 		logger.Infof("Sharing private addresses for local connectivity: %v", advertiseAddresses)
-	} else {
-		// Let go-p2p auto-detect and filter private addresses
-		advertiseAddresses = []string{}
+	default:
 		logger.Infof("Private address sharing disabled - go-p2p will auto-detect public addresses only")
 	}
 
 	// Construct the full Bitcoin protocol ID with version and network topic prefix
 	// This ensures we only connect to peers on the same network (e.g. mainnet/testnet)
-	// The format results in "teranode/bitcoin/<network>/<protocolIDVersion>"
-	bitcoinProtocolVersion := fmt.Sprintf("/teranode/bitcoin/%s/%s", tSettings.ChainCfgParams.Name, protocolIDVersion)
+	bitcoinProtocolVersion := BitcoinProtocolID(tSettings.ChainCfgParams.Name)
 
 	// Decode the hex-encoded private key into standard crypto library privkey
 	privDecoded, err := hex.DecodeString(privateKey)
@@ -309,6 +349,7 @@ func NewServer(
 	if err != nil {
 		return nil, errors.NewServiceError("failed to unmarshal key", err)
 	}
+
 	conf := p2pMessageBus.Config{
 		PrivateKey:         privKey,
 		Name:               tSettings.ClientName,
@@ -319,7 +360,7 @@ func NewServer(
 		ProtocolVersion:    bitcoinProtocolVersion,
 		DHTMode:            tSettings.P2P.DHTMode,
 		DHTCleanupInterval: tSettings.P2P.DHTCleanupInterval,
-		DisableNAT:         tSettings.P2P.DisableNAT,
+		DisableNAT:         natTraversalDisabled(logger, tSettings),
 		EnableMDNS:         tSettings.P2P.EnableMDNS,
 		AllowPrivateIPs:    tSettings.P2P.AllowPrivateIPs,
 	}
@@ -340,6 +381,7 @@ func NewServer(
 
 	p2pServer := &Server{
 		P2PClient:              p2pClient,
+		privKey:                privKey,
 		logger:                 logger,
 		settings:               tSettings,
 		bitcoinProtocolVersion: bitcoinProtocolVersion,
@@ -353,6 +395,7 @@ func NewServer(
 		rejectedTxKafkaConsumerClient:     rejectedTxKafkaConsumerClient,
 		invalidBlocksKafkaConsumerClient:  invalidBlocksKafkaConsumerClient,
 		invalidSubtreeKafkaConsumerClient: invalidSubtreeKafkaConsumerClient,
+		doubleSpendsKafkaConsumerClient:   doubleSpendsKafkaConsumerClient,
 		subtreeKafkaProducerClient:        subtreeKafkaProducerClient,
 		blocksKafkaProducerClient:         blocksKafkaProducerClient,
 		gCtx:                              ctx,
@@ -362,6 +405,8 @@ func NewServer(
 		invalidBlocksTopicName:            tSettings.Kafka.InvalidBlocks,
 		invalidSubtreeTopicName:           tSettings.Kafka.InvalidSubtrees,
 		nodeStatusTopicName:               fmt.Sprintf("%s-%s", topicPrefix, nodeStatusTopic),
+		operatorMessageTopicName:          fmt.Sprintf("%s-%s", topicPrefix, tSettings.P2P.OperatorMessageTopic),
+		minerIDTopicName:                  fmt.Sprintf("%s-%s", topicPrefix, tSettings.P2P.MinerIDTopic),
 		topicPrefix:                       topicPrefix,
 		startTime:                         time.Now(),
 
@@ -382,6 +427,56 @@ func NewServer(
 	// Note: peer registry must be created first so it can be passed to ban manager
 	p2pServer.peerRegistry = NewPeerRegistry()
 	p2pServer.peerSelector = NewPeerSelector(logger, tSettings)
+	p2pServer.bandwidthTracker = NewPeerBandwidthTracker(tSettings.P2P.BandwidthWindow, tSettings.P2P.DownloadQuotaBytes, tSettings.P2P.UploadQuotaBytes)
+
+	if tSettings.P2P.OperatorMessagesEnabled {
+		p2pServer.operatorMessenger, err = NewOperatorMessenger(privKey, tSettings.P2P.OperatorMessageRateLimit)
+		if err != nil {
+			return nil, errors.NewServiceError("failed to create operator messenger", err)
+		}
+	}
+
+	if tSettings.P2P.MinerIDPrivateKey != "" {
+		if p2pServer.minerIDKey, err = parseMinerIDPrivateKey(tSettings.P2P.MinerIDPrivateKey); err != nil {
+			return nil, err
+		}
+
+		if err = validateMinerID(tSettings.P2P.MinerID, tSettings.P2P.MinerContact); err != nil {
+			return nil, errors.NewConfigurationError("invalid p2p_miner_id or p2p_miner_contact", err)
+		}
+
+		if tSettings.P2P.MinerIDAnnounceInterval <= 0 {
+			return nil, errors.NewConfigurationError("p2p_miner_id_announce_interval must be positive, got %v", tSettings.P2P.MinerIDAnnounceInterval)
+		}
+	}
+
+	if p2pServer.geoLocator, err = geoip.NewLocator(tSettings.P2P.GeoIPCountryDB, tSettings.P2P.GeoIPASNDB); err != nil {
+		return nil, err
+	}
+
+	if p2pServer.protocolVersions, err = resolveProtocolVersions(tSettings); err != nil {
+		return nil, err
+	}
+
+	if tSettings.P2P.PeerEventLogSize > 0 {
+		p2pServer.peerEvents, err = NewPeerEventLog(tSettings.P2P.PeerEventLogSize, tSettings.P2P.PeerEventLogFile, int64(tSettings.P2P.PeerEventLogMaxFileBytes))
+		if err != nil {
+			return nil, errors.NewServiceError("failed to create peer event log", err)
+		}
+
+		p2pServer.peerRegistry.SetEventLog(p2pServer.peerEvents)
+	}
+
+	if tSettings.P2P.MessageRecordFile != "" {
+		p2pServer.messageRecorder, err = NewMessageRecorder(tSettings.P2P.MessageRecordFile, int64(tSettings.P2P.MessageRecordMaxBytes), tSettings.P2P.MessageRecordMaxFiles)
+		if err != nil {
+			return nil, errors.NewServiceError("failed to create message recorder", err)
+		}
+
+		logger.Infof("Recording received topic messages to %s", tSettings.P2P.MessageRecordFile)
+	}
+
+	p2pServer.peerRegistry.SetCacheCompression(tSettings.P2P.PeerCacheCompression, tSettings.P2P.PeerCacheCompressionThreshold)
 
 	// Load cached peer registry data if available
 	if err := p2pServer.peerRegistry.LoadPeerRegistryCache(tSettings.P2P.PeerCacheDir); err != nil {
@@ -391,6 +486,15 @@ func NewServer(
 		logger.Infof("Loaded peer registry cache with %d peers", p2pServer.peerRegistry.PeerCount())
 	}
 
+	// peers that went stale while the node was down are not restored
+	p2pServer.collectStalePeers()
+
+	p2pServer.peerContributions = NewPeerContributionLedger()
+	if err := p2pServer.peerContributions.Load(tSettings.P2P.PeerCacheDir); err != nil {
+		// Log error but continue - the ledger starts empty
+		logger.Warnf("Failed to load peer contribution ledger: %v", err)
+	}
+
 	// Initialize the ban manager with peer registry so it can sync ban statuses
 	p2pServer.banManager = NewPeerBanManager(ctx, &myBanEventHandler{server: p2pServer}, tSettings, p2pServer.peerRegistry)
 	p2pServer.syncCoordinator = NewSyncCoordinator(
@@ -445,6 +549,10 @@ func (s *Server) Health(ctx context.Context, checkLiveness bool) (int, string, e
 		checks = append(checks, health.Check{Name: "FSM", Check: blockchain.CheckFSM(s.blockchainClient)})
 	}
 
+	if s.dataHubSelfTestEnabled() {
+		checks = append(checks, health.Check{Name: "DataHubSelfTest", Check: s.dataHubSelfTestHealth})
+	}
+
 	return health.CheckAll(ctx, checkLiveness, checks)
 }
 
@@ -464,6 +572,14 @@ func (s *Server) Init(ctx context.Context) (err error) {
 		AssetHTTPAddressURLString = s.settings.Asset.HTTPAddress
 	}
 
+	if err = validateDataHubURL(s.logger, s.settings, AssetHTTPAddressURLString); err != nil {
+		return err
+	}
+
+	if err = validateDataHubMirrorURLs(s.settings.P2P.DataHubMirrorURLs); err != nil {
+		return err
+	}
+
 	s.AssetHTTPAddressURL = AssetHTTPAddressURLString
 
 	return nil
@@ -506,6 +622,12 @@ func (s *Server) setupHTTPServer() *echo.Echo {
 	return e
 }
 
+// BitcoinProtocolID returns the protocol ID of the Bitcoin protocol spoken between the nodes of the network,
+// in the format "/teranode/bitcoin/<network>/<protocolIDVersion>"
+func BitcoinProtocolID(network string) string {
+	return fmt.Sprintf("/teranode/bitcoin/%s/%s", network, protocolIDVersion)
+}
+
 func (s *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
 	var closeOnce sync.Once
 	defer closeOnce.Do(func() { close(readyCh) })
@@ -539,6 +661,12 @@ func (s *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
 		s.invalidSubtreeKafkaConsumerClient.Start(ctx, s.invalidSubtreeHandler(ctx), kafka.WithLogErrorAndMoveOn())
 	}
 
+	// Handler for double spends Kafka messages
+	if s.doubleSpendsKafkaConsumerClient != nil {
+		s.logger.Infof("[Start] Starting double spends Kafka consumer on topic: %s", s.settings.Kafka.DoubleSpends)
+		s.doubleSpendsKafkaConsumerClient.Start(ctx, s.doubleSpendHandler(ctx), kafka.WithLogErrorAndMoveOn())
+	}
+
 	s.subtreeKafkaProducerClient.Start(ctx, make(chan *kafka.Message, 10))
 	s.blocksKafkaProducerClient.Start(ctx, make(chan *kafka.Message, 10))
 
@@ -584,11 +712,17 @@ func (s *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
 	}()
 
 	// Subscribe to all topics
-	s.subscribeToTopic(ctx, s.blockTopicName, s.handleBlockTopic)
-	s.subscribeToTopic(ctx, s.subtreeTopicName, s.handleSubtreeTopic)
+	s.subscribeToPrioritizedTopic(ctx, s.blockTopicName, "block", s.handleBlockTopic)
+	s.subscribeToPrioritizedTopic(ctx, s.subtreeTopicName, "subtree", s.handleSubtreeTopic)
 	s.subscribeToTopic(ctx, s.nodeStatusTopicName, s.handleNodeStatusTopic)
 	s.subscribeToTopic(ctx, s.rejectedTxTopicName, s.handleRejectedTxTopic)
 
+	if s.operatorMessenger != nil {
+		s.subscribeToTopic(ctx, s.operatorMessageTopicName, s.handleOperatorMessageTopic)
+	}
+
+	s.subscribeToTopic(ctx, s.minerIDTopicName, s.handleMinerIDTopic)
+
 	// Start blockchain subscription before marking service as ready
 	// This ensures we don't miss any block notifications
 	blockchainSubscription, err := s.blockchainClient.Subscribe(ctx, "p2pServer")
@@ -612,14 +746,35 @@ func (s *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
 	// Start periodic cleanup of peer maps
 	s.startPeerMapCleanup(ctx)
 
-	// Start periodic save of peer registry cache
-	s.startPeerRegistryCacheSave(ctx)
+	// Start the maintenance jobs: saving the peer registry cache, sweeping expired bans and exporting the peer
+	// registry metrics
+	if err := s.startMaintenanceJobs(ctx); err != nil {
+		return errors.NewServiceError("failed to start maintenance jobs", err)
+	}
 
 	// Start sync coordinator (it handles all sync logic internally)
 	if s.syncCoordinator != nil {
 		s.syncCoordinator.Start(ctx)
 	}
 
+	// Start periodic self-test of our own advertised DataHub URL
+	s.startDataHubSelfTest(ctx)
+
+	// Start periodic health probing of the DataHub URLs of peers
+	s.startDataHubHealthChecker(ctx)
+
+	// Start periodic identity verification of the DataHub URLs of peers
+	s.startDataHubIdentityVerifier(ctx)
+
+	// Start periodic reconciliation of the peer registry with the live libp2p connections
+	s.startPeerRegistryReconciler(ctx)
+
+	// Start periodic retention cleanup of the peer event log and message recording files
+	s.startRetentionCleanup(ctx)
+
+	// Start periodic announcement of our miner ID, when configured
+	s.startMinerIDAnnouncer(ctx)
+
 	// Start node status publisher
 	go s.publishNodeStatus(ctx)
 
@@ -632,17 +787,12 @@ func (s *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
 		}
 	}
 
-	// Define protected methods - use the full gRPC method path
-	protectedMethods := map[string]bool{
-		"/p2p_api.PeerService/BanPeer":   true,
-		"/p2p_api.PeerService/UnbanPeer": true,
-	}
-
-	// Create auth options
-	authOptions := &util.AuthOptions{
-		APIKey:           apiKey,
-		ProtectedMethods: protectedMethods,
-	}
+	// Protect the admin methods, by their full gRPC method path
+	authOptions := util.NewAuthOptions(apiKey, s.settings.GRPCAuthClientCert,
+		"/p2p_api.PeerService/BanPeer",
+		"/p2p_api.PeerService/UnbanPeer",
+		"/p2p_api.PeerService/SendOperatorMessage",
+	)
 
 	// this will block
 	if err = util.StartGRPCServer(ctx, s.logger, s.settings, "p2p", s.settings.P2P.GRPCListenAddress, func(server *grpc.Server) {
@@ -658,12 +808,16 @@ func (s *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
 }
 
 func (s *Server) subscribeToTopic(ctx context.Context, topicName string, handler func(context.Context, []byte, string)) {
+	initPrometheusMetrics()
+
 	topicChannel := s.P2PClient.Subscribe(topicName)
 	go func() {
 		// Process messages until the topic channel is closed
 		// DO NOT check ctx.Done() here - context cancellation during operations like Kafka consumer recovery
 		// should not stop P2P message processing. The subscription ends when the topic channel closes.
 		for msg := range topicChannel {
+			prometheusP2PGossipMessages.WithLabelValues(topicName, gossipReceived).Inc()
+			s.messageRecorder.Record(topicName, msg.FromID, msg.Data)
 			handler(ctx, msg.Data, msg.FromID)
 		}
 		s.logger.Warnf("%s topic channel closed", topicName)
@@ -686,7 +840,7 @@ func (s *Server) invalidSubtreeHandler(ctx context.Context) func(msg *kafka.Kafk
 		}
 
 		var m kafkamessage.KafkaInvalidSubtreeTopicMessage
-		if err = proto.Unmarshal(msg.Value, &m); err != nil {
+		if err = kafkamessage.Decode(msg.Value, &m); err != nil {
 			s.logger.Errorf("[invalidSubtreeHandler] error unmarshalling invalidSubtreeMessage: %v", err)
 			return err
 		}
@@ -720,7 +874,7 @@ func (s *Server) invalidBlockHandler(ctx context.Context) func(msg *kafka.KafkaM
 		}
 
 		var m kafkamessage.KafkaInvalidBlockTopicMessage
-		if err := proto.Unmarshal(msg.Value, &m); err != nil {
+		if err := kafkamessage.Decode(msg.Value, &m); err != nil {
 			s.logger.Errorf("[invalidBlockHandler] error unmarshalling invalidBlocksMessage: %v", err)
 			return err
 		}
@@ -758,7 +912,7 @@ func (s *Server) rejectedTxHandler(ctx context.Context) func(msg *kafka.KafkaMes
 		}
 
 		var m kafkamessage.KafkaRejectedTxTopicMessage
-		if err := proto.Unmarshal(msg.Value, &m); err != nil {
+		if err := kafkamessage.Decode(msg.Value, &m); err != nil {
 			s.logger.Errorf("[rejectedTxHandler] error unmarshalling rejectedTxMessage: %v", err)
 			return err
 		}
@@ -796,7 +950,7 @@ func (s *Server) rejectedTxHandler(ctx context.Context) func(msg *kafka.KafkaMes
 
 		s.logger.Debugf("[rejectedTxHandler] publishing rejectedTxMessage to p2p network")
 
-		if err = s.P2PClient.Publish(ctx, s.rejectedTxTopicName, msgBytes); err != nil {
+		if err = s.publish(ctx, s.rejectedTxTopicName, msgBytes); err != nil {
 			s.logger.Errorf("[rejectedTxHandler] publish error: %v", err)
 		}
 
@@ -872,6 +1026,9 @@ func (s *Server) updateBytesReceived(from string, originatorPeerID string, messa
 		s.peerRegistry.UpdateNetworkStats(senderID, newTotal)
 	}
 
+	// Quotas are enforced against the sender only, since that is the connection the bytes were received on
+	s.recordBandwidth(senderID, BandwidthDirectionDownload, messageSize)
+
 	// Also update for the originator if different (gossiped message)
 	if originatorPeerID != "" {
 		if peerID, err := peer.Decode(originatorPeerID); err == nil && peerID != senderID {
@@ -914,6 +1071,11 @@ func (s *Server) handleNodeStatusTopic(_ context.Context, m []byte, from string)
 		// Track bytes received from this message
 		s.updateBytesReceived(from, nodeStatusMessage.PeerID, uint64(len(m)))
 
+		// Skip processing from peers without a protocol version in common (but still forward to WebSocket for monitoring)
+		if !s.negotiatePeerProtocolVersion(&nodeStatusMessage) {
+			isSelf = true
+		}
+
 		// Skip processing from unhealthy peers (but still forward to WebSocket for monitoring)
 		if s.shouldSkipUnhealthyPeer(from, "handleNodeStatusTopic") {
 			s.logger.Debugf("[handleNodeStatusTopic] Skipping peer data processing from unhealthy peer %s, but forwarding to WebSocket", from)
@@ -951,6 +1113,9 @@ func (s *Server) handleNodeStatusTopic(_ context.Context, m []byte, from string)
 		MinMiningTxFee:      nodeStatusMessage.MinMiningTxFee,
 		ConnectedPeersCount: nodeStatusMessage.ConnectedPeersCount,
 		Storage:             nodeStatusMessage.Storage,
+		DataHubSelfTestOK:   nodeStatusMessage.DataHubSelfTestOK,
+		DataHubSelfTestErr:  nodeStatusMessage.DataHubSelfTestErr,
+		DataHubMirrorURLs:   nodeStatusMessage.DataHubMirrorURLs,
 	}:
 	default:
 		s.logger.Warnf("[handleNodeStatusTopic] notification channel full, dropped node_status notification for %s", nodeStatusMessage.PeerID)
@@ -977,7 +1142,8 @@ func (s *Server) handleNodeStatusTopic(_ context.Context, m []byte, from string)
 		// a direct connection.
 		if nodeStatusMessage.BaseURL != "" {
 			s.updateDataHubURL(peerID, nodeStatusMessage.BaseURL)
-			s.logger.Debugf("[handleNodeStatusTopic] Updated DataHub URL %s for peer %s", nodeStatusMessage.BaseURL, peerID)
+			s.updateDataHubMirrorURLs(peerID, nodeStatusMessage.DataHubMirrorURLs)
+			s.logger.Debugf("[handleNodeStatusTopic] Updated DataHub URL %s with %d mirrors for peer %s", nodeStatusMessage.BaseURL, len(nodeStatusMessage.DataHubMirrorURLs), peerID)
 		}
 
 		// Update block hash if provided
@@ -1036,7 +1202,7 @@ func (s *Server) handleBlockNotification(ctx context.Context, hash *chainhash.Ha
 		return errors.NewError("blockMessage - json marshal error: %w", err)
 	}
 
-	if err = s.P2PClient.Publish(ctx, s.blockTopicName, msgBytes); err != nil {
+	if err = s.publish(ctx, s.blockTopicName, msgBytes); err != nil {
 		return errors.NewError("blockMessage - publish error: %w", err)
 	}
 
@@ -1260,6 +1426,8 @@ func (s *Server) getNodeStatusMessage(ctx context.Context) *notificationMsg {
 	s.logger.Debugf("[getNodeStatusMessage] Determined storage=%q for this node (persisterHeight=%d, bestHeight=%d, retention=%d)",
 		storage, blockPersisterHeight, height, retentionWindow)
 
+	dataHubSelfTestOK, dataHubSelfTestErr := s.dataHubSelfTestNodeStatus()
+
 	// Return the notification message
 	return &notificationMsg{
 		Timestamp:           time.Now().UTC().Format(isoFormat),
@@ -1286,6 +1454,9 @@ func (s *Server) getNodeStatusMessage(ctx context.Context) *notificationMsg {
 		MinMiningTxFee:      minMiningTxFee,
 		ConnectedPeersCount: connectedPeersCount,
 		Storage:             storage,
+		DataHubSelfTestOK:   dataHubSelfTestOK,
+		DataHubSelfTestErr:  dataHubSelfTestErr,
+		DataHubMirrorURLs:   s.settings.P2P.DataHubMirrorURLs,
 	}
 }
 
@@ -1321,6 +1492,11 @@ func (s *Server) handleNodeStatusNotification(ctx context.Context) error {
 		MinMiningTxFee:      msg.MinMiningTxFee,
 		ConnectedPeersCount: msg.ConnectedPeersCount,
 		Storage:             msg.Storage,
+		DataHubSelfTestOK:   msg.DataHubSelfTestOK,
+		DataHubSelfTestErr:  msg.DataHubSelfTestErr,
+		DataHubMirrorURLs:   msg.DataHubMirrorURLs,
+		ProtocolVersion:     s.localProtocolVersions().max,
+		MinProtocolVersion:  s.localProtocolVersions().min,
 	}
 
 	msgBytes, err := json.Marshal(nodeStatusMessage)
@@ -1331,7 +1507,7 @@ func (s *Server) handleNodeStatusNotification(ctx context.Context) error {
 	s.logger.Infof("[handleNodeStatusNotification] P2P publishing node_status to topic %s (height=%d, version=%s, storage=%q)", s.nodeStatusTopicName, nodeStatusMessage.BestHeight, nodeStatusMessage.Version, nodeStatusMessage.Storage)
 	s.logger.Debugf("[handleNodeStatusNotification] JSON payload: %s", string(msgBytes))
 
-	if err = s.P2PClient.Publish(ctx, s.nodeStatusTopicName, msgBytes); err != nil {
+	if err = s.publish(ctx, s.nodeStatusTopicName, msgBytes); err != nil {
 		return errors.NewError("nodeStatusMessage - publish error: %w", err)
 	}
 
@@ -1366,7 +1542,7 @@ func (s *Server) handleSubtreeNotification(ctx context.Context, hash *chainhash.
 		return errors.NewError("subtreeMessage - json marshal error: %w", err)
 	}
 
-	if err := s.P2PClient.Publish(ctx, s.subtreeTopicName, msgBytes); err != nil {
+	if err := s.publish(ctx, s.subtreeTopicName, msgBytes); err != nil {
 		return errors.NewError("subtreeMessage - publish error: %w", err)
 	}
 
@@ -1555,6 +1731,13 @@ func (s *Server) Stop(ctx context.Context) error {
 		}
 	}
 
+	if s.doubleSpendsKafkaConsumerClient != nil {
+		if err := s.doubleSpendsKafkaConsumerClient.Close(); err != nil {
+			s.logger.Errorf("[Stop] failed to close double spends kafka consumer gracefully: %v", err)
+			errs = append(errs, err)
+		}
+	}
+
 	if s.e != nil {
 		if err := s.e.Shutdown(ctx); err != nil {
 			s.logger.Errorf("[Stop] failed to shutdown Echo server: %v", err)
@@ -1579,6 +1762,16 @@ func (s *Server) Stop(ctx context.Context) error {
 	})
 	s.logger.Infof("[Stop] cleared peer maps")
 
+	if err := s.peerEvents.Close(); err != nil {
+		s.logger.Errorf("[Stop] failed to close peer event log: %v", err)
+		errs = append(errs, err)
+	}
+
+	if err := s.messageRecorder.Close(); err != nil {
+		s.logger.Errorf("[Stop] failed to close message recorder: %v", err)
+		errs = append(errs, err)
+	}
+
 	if len(errs) > 0 {
 		// Combine errors if multiple occurred
 		// This simple approach just returns the first error, consider a multi-error type if needed
@@ -1670,32 +1863,86 @@ func (s *Server) GetPeers(ctx context.Context, _ *emptypb.Empty) (*p2p_api.GetPe
 	return resp, nil
 }
 
-func (s *Server) BanPeer(ctx context.Context, peer *p2p_api.BanPeerRequest) (*p2p_api.BanPeerResponse, error) {
-	err := s.banList.Add(ctx, peer.Addr, time.Unix(peer.Until, 0))
+// BanPeer bans an IP address, a CIDR subnet or a peer ID until the requested time. Peer IDs are banned by
+// the ban manager, which also bans the addresses of the peer when it is connected.
+func (s *Server) BanPeer(ctx context.Context, req *p2p_api.BanPeerRequest) (*p2p_api.BanPeerResponse, error) {
+	until := time.Unix(req.Until, 0)
+
+	if isPeerID(req.Addr) {
+		if s.banManager == nil {
+			return nil, errors.NewServiceError("[BanPeer] ban manager not available to ban peer %s", req.Addr)
+		}
+
+		s.banManager.BanPeer(req.Addr, until, req.Reason)
+
+		return &p2p_api.BanPeerResponse{Ok: true}, nil
+	}
+
+	err := s.banList.Add(ctx, req.Addr, until)
 	if err != nil {
 		return nil, err
 	}
 
+	details := "banned until " + until.UTC().Format(time.RFC3339)
+	if req.Reason != "" {
+		details += ": " + req.Reason
+	}
+
+	s.peerEvents.Record(req.Addr, PeerEventBanned, details)
+
 	return &p2p_api.BanPeerResponse{Ok: true}, nil
 }
 
-func (s *Server) UnbanPeer(ctx context.Context, peer *p2p_api.UnbanPeerRequest) (*p2p_api.UnbanPeerResponse, error) {
-	err := s.banList.Remove(ctx, peer.Addr)
-	if err != nil {
+// UnbanPeer lifts the ban of an IP address, a CIDR subnet or a peer ID. Lifting the ban of a peer ID does
+// not lift the bans of the addresses that were banned along with it.
+func (s *Server) UnbanPeer(ctx context.Context, req *p2p_api.UnbanPeerRequest) (*p2p_api.UnbanPeerResponse, error) {
+	if isPeerID(req.Addr) {
+		if s.banManager == nil {
+			return nil, errors.NewServiceError("[UnbanPeer] ban manager not available to unban peer %s", req.Addr)
+		}
+
+		s.banManager.ResetBanScore(req.Addr)
+	} else if err := s.banList.Remove(ctx, req.Addr); err != nil {
 		return nil, err
 	}
 
+	s.peerEvents.Record(req.Addr, PeerEventUnbanned, "")
+
 	return &p2p_api.UnbanPeerResponse{Ok: true}, nil
 }
 
+// isPeerID returns true if addr is a peer ID rather than an IP address or subnet
+func isPeerID(addr string) bool {
+	_, err := peer.Decode(addr)
+	return err == nil
+}
+
 func (s *Server) IsBanned(ctx context.Context, peer *p2p_api.IsBannedRequest) (*p2p_api.IsBannedResponse, error) {
 	// Only check PeerID-based bans
 	// Note: The field is still called IpOrSubnet for backward compatibility, but we only accept PeerIDs
 	return &p2p_api.IsBannedResponse{IsBanned: s.banManager.IsBanned(peer.IpOrSubnet)}, nil
 }
 
+// ListBanned returns the banned IP addresses and subnets, followed by the banned peer IDs, with the
+// time their ban expires
 func (s *Server) ListBanned(ctx context.Context, _ *emptypb.Empty) (*p2p_api.ListBannedResponse, error) {
-	return &p2p_api.ListBannedResponse{Banned: s.banList.ListBanned()}, nil
+	bans := s.banList.ListBans()
+
+	if s.banManager != nil {
+		bans = append(bans, s.banManager.ListBans()...)
+	}
+
+	resp := &p2p_api.ListBannedResponse{
+		Banned: make([]string, 0, len(bans)),
+		Bans:   make([]*p2p_api.BanEntry, 0, len(bans)),
+	}
+
+	for _, ban := range bans {
+		resp.Banned = append(resp.Banned, ban.Banned)
+		resp.Bans = append(resp.Bans, &p2p_api.BanEntry{Banned: ban.Banned, UnbanAt: ban.UnbanAt.Unix()})
+	}
+
+	return resp, nil
 }
 
 func (s *Server) ClearBanned(ctx context.Context, _ *emptypb.Empty) (*p2p_api.ClearBannedResponse, error) {
@@ -1716,12 +1963,25 @@ func (s *Server) AddBanScore(ctx context.Context, req *p2p_api.AddBanScoreReques
 		reason = ReasonSpam
 	case "invalid_block":
 		reason = ReasonInvalidBlock
+	case "malicious_chain":
+		reason = ReasonMaliciousChain
 	default:
 		s.logger.Warnf("[AddBanScore] Unknown ban reason: %s", req.Reason)
 	}
 
-	score, banned := s.banManager.AddScore(req.PeerId, reason)
+	var score int
+
+	var banned bool
+
+	// a score set by the caller, e.g. the ban-score delta of a peer-attributable error, overrides the points of the reason
+	if req.Score > 0 {
+		score, banned = s.banManager.AddScorePoints(req.PeerId, reason, int(req.Score))
+	} else {
+		score, banned = s.banManager.AddScore(req.PeerId, reason)
+	}
+
 	s.logger.Infof("[AddBanScore] Added score to peer %s for reason %s. New score: %d, Banned: %t", req.PeerId, req.Reason, score, banned)
+	s.peerEvents.Record(req.PeerId, PeerEventBanScore, fmt.Sprintf("reason=%s score=%d", req.Reason, score))
 
 	// Update the sync coordinator's peer registry with the new ban status
 	if s.syncCoordinator != nil {
@@ -1749,6 +2009,8 @@ func (s *Server) RecordBytesDownloaded(ctx context.Context, req *p2p_api.RecordB
 		return &p2p_api.RecordBytesDownloadedResponse{Ok: false}, errors.NewServiceError("failed to decode peer ID", err)
 	}
 
+	s.peerContributions.RecordReceived(req.PeerId, req.DataType, req.Items, req.BytesDownloaded)
+
 	// Get current peer info from registry
 	peerInfo, exists := s.peerRegistry.GetPeer(peerID)
 	if !exists {
@@ -1766,9 +2028,46 @@ func (s *Server) RecordBytesDownloaded(ctx context.Context, req *p2p_api.RecordB
 	s.logger.Debugf("[RecordBytesDownloaded] Updated peer %s: added %d bytes, new total: %d bytes",
 		req.PeerId, req.BytesDownloaded, newTotal)
 
+	s.recordBandwidth(peerID, BandwidthDirectionDownload, req.BytesDownloaded)
+
 	return &p2p_api.RecordBytesDownloadedResponse{Ok: true}, nil
 }
 
+// RecordBytesUploaded records the number of bytes served to a peer.
+// This method is called by services that serve data to a known peer so that
+// upload usage can be accounted and checked against the per-peer upload quota.
+// Parameters:
+//   - ctx: Context for the operation
+//   - req: Request containing peer_id and bytes_uploaded
+//
+// Returns a response indicating success or an error if the peer ID cannot be decoded.
+func (s *Server) RecordBytesUploaded(ctx context.Context, req *p2p_api.RecordBytesUploadedRequest) (*p2p_api.RecordBytesUploadedResponse, error) {
+	peerID, err := peer.Decode(req.PeerId)
+	if err != nil {
+		s.logger.Errorf("[RecordBytesUploaded] failed to decode peer ID %s: %v", req.PeerId, err)
+		return &p2p_api.RecordBytesUploadedResponse{Ok: false}, errors.NewServiceError("failed to decode peer ID", err)
+	}
+
+	s.peerContributions.RecordServed(req.PeerId, req.DataType, req.Items, req.BytesUploaded)
+
+	peerInfo, exists := s.peerRegistry.GetPeer(peerID)
+	if !exists {
+		s.logger.Warnf("[RecordBytesUploaded] peer %s not found in registry", req.PeerId)
+		// Still return success - peer might not be in registry yet
+		return &p2p_api.RecordBytesUploadedResponse{Ok: true}, nil
+	}
+
+	newTotal := peerInfo.BytesSent + req.BytesUploaded
+	s.peerRegistry.UpdateUploadStats(peerID, newTotal)
+
+	s.logger.Debugf("[RecordBytesUploaded] Updated peer %s: added %d bytes, new total: %d bytes",
+		req.PeerId, req.BytesUploaded, newTotal)
+
+	s.recordBandwidth(peerID, BandwidthDirectionUpload, req.BytesUploaded)
+
+	return &p2p_api.RecordBytesUploadedResponse{Ok: true}, nil
+}
+
 // ReportInvalidBlock adds ban score to the peer that sent an invalid block.
 // This method is called by the block validation service when a block is found to be invalid.
 // Parameters:
@@ -1787,8 +2086,10 @@ func (s *Server) ReportInvalidBlock(ctx context.Context, blockHash string, reaso
 	// Add ban score to the peer
 	s.logger.Infof("[ReportInvalidBlock] adding ban score to peer %s for invalid block %s: %s", peerID, blockHash, reason)
 
-	// Record as malicious interaction for reputation tracking
-	s.peerRegistry.RecordMaliciousInteraction(peer.ID(peerID))
+	// Record the invalid block in the peer registry for reputation tracking
+	if id, decodeErr := peer.Decode(peerID); decodeErr == nil {
+		s.recordInvalidData(id, invalidDataBlock, blockHash, invalidDataReasonFromKafka(reason), reason)
+	}
 
 	// Create the request to add ban score
 	req := &p2p_api.AddBanScoreRequest{
@@ -1837,8 +2138,10 @@ func (s *Server) ReportInvalidSubtree(ctx context.Context, subtreeHash string, p
 	s.logger.Infof("[ReportInvalidSubtree] adding ban score to peer %s for invalid subtree %s: %s",
 		peerID, subtreeHash, reason)
 
-	// Record as malicious interaction for reputation tracking
-	s.peerRegistry.RecordMaliciousInteraction(peer.ID(peerID))
+	// Record the invalid subtree in the peer registry for reputation tracking
+	if id, decodeErr := peer.Decode(peerID); decodeErr == nil {
+		s.recordInvalidData(id, invalidDataSubtree, subtreeHash, invalidDataReasonFromKafka(reason), reason)
+	}
 
 	// Create the request to add ban score
 	req := &p2p_api.AddBanScoreRequest{
@@ -1867,6 +2170,7 @@ type myBanEventHandler struct {
 // OnPeerBanned is called when a peer is banned.
 func (h *myBanEventHandler) OnPeerBanned(peerID string, until time.Time, reason string) {
 	h.server.logger.Infof("Peer %s banned until %s for reason: %s", peerID, until.Format(time.RFC3339), reason)
+	h.server.peerEvents.Record(peerID, PeerEventBanned, fmt.Sprintf("banned until %s: %s", until.UTC().Format(time.RFC3339), reason))
 	// get the ip for the peer id
 	pid, err := peer.Decode(peerID)
 	if err != nil {
@@ -1924,34 +2228,61 @@ func (s *Server) GetPeerRegistry(_ context.Context, _ *emptypb.Empty) (*p2p_api.
 	peers := make([]*p2p_api.PeerRegistryInfo, 0, len(allPeers))
 	for _, p := range allPeers {
 		peers = append(peers, &p2p_api.PeerRegistryInfo{
-			Id:              p.ID.String(),
-			Height:          p.Height,
-			BlockHash:       p.BlockHash,
-			DataHubUrl:      p.DataHubURL,
-			BanScore:        int32(p.BanScore),
-			IsBanned:        p.IsBanned,
-			IsConnected:     p.IsConnected,
-			ConnectedAt:     timeToUnix(p.ConnectedAt),
-			BytesReceived:   p.BytesReceived,
-			LastBlockTime:   timeToUnix(p.LastBlockTime),
-			LastMessageTime: timeToUnix(p.LastMessageTime),
-			UrlResponsive:   p.URLResponsive,
-			LastUrlCheck:    timeToUnix(p.LastURLCheck),
+			Id:                p.ID.String(),
+			Height:            p.Height,
+			BlockHash:         p.BlockHash,
+			DataHubUrl:        p.DataHubURL,
+			DataHubMirrorUrls: p.DataHubMirrorURLs,
+			BanScore:          int32(p.BanScore),
+			IsBanned:          p.IsBanned,
+			IsConnected:       p.IsConnected,
+			ConnectedAt:       timeToUnix(p.ConnectedAt),
+			BytesReceived:     p.BytesReceived,
+			LastBlockTime:     timeToUnix(p.LastBlockTime),
+			LastMessageTime:   timeToUnix(p.LastMessageTime),
+			UrlResponsive:     p.URLResponsive,
+			LastUrlCheck:      timeToUnix(p.LastURLCheck),
 
 			// Interaction/catchup metrics
-			InteractionAttempts:    p.InteractionAttempts,
-			InteractionSuccesses:   p.InteractionSuccesses,
-			InteractionFailures:    p.InteractionFailures,
-			LastInteractionAttempt: timeToUnix(p.LastInteractionAttempt),
-			LastInteractionSuccess: timeToUnix(p.LastInteractionSuccess),
-			LastInteractionFailure: timeToUnix(p.LastInteractionFailure),
-			ReputationScore:        p.ReputationScore,
-			MaliciousCount:         p.MaliciousCount,
-			AvgResponseTimeMs:      p.AvgResponseTime.Milliseconds(),
-			Storage:                p.Storage,
-			ClientName:             p.ClientName,
-			LastCatchupError:       p.LastCatchupError,
-			LastCatchupErrorTime:   timeToUnix(p.LastCatchupErrorTime),
+			InteractionAttempts:     p.InteractionAttempts,
+			InteractionSuccesses:    p.InteractionSuccesses,
+			InteractionFailures:     p.InteractionFailures,
+			LastInteractionAttempt:  timeToUnix(p.LastInteractionAttempt),
+			LastInteractionSuccess:  timeToUnix(p.LastInteractionSuccess),
+			LastInteractionFailure:  timeToUnix(p.LastInteractionFailure),
+			ReputationScore:         p.ReputationScore,
+			MaliciousCount:          p.MaliciousCount,
+			DoubleSpendCount:        p.DoubleSpendCount,
+			LastDoubleSpend:         timeToUnix(p.LastDoubleSpend),
+			AvgResponseTimeMs:       p.AvgResponseTime.Milliseconds(),
+			Storage:                 p.Storage,
+			ClientName:              p.ClientName,
+			LastCatchupError:        p.LastCatchupError,
+			LastCatchupErrorTime:    timeToUnix(p.LastCatchupErrorTime),
+			IsOnProbation:           p.IsOnProbation,
+			ProbationUntil:          timeToUnix(p.ProbationUntil),
+			BytesSent:               p.BytesSent,
+			IsThrottled:             p.IsThrottled,
+			IsHealthy:               p.IsHealthy,
+			HealthDurationMs:        p.HealthDuration.Milliseconds(),
+			HealthCheckFailures:     int32(p.HealthCheckFailures), //nolint:gosec // consecutive failures stay far below the int32 range
+			IsDataHubDown:           p.IsDataHubDown,
+			IsRelayOnly:             p.IsRelayOnly,
+			Transport:               p.Transport,
+			Country:                 p.Country,
+			Asn:                     p.ASN,
+			AsOrganization:          p.ASOrganization,
+			ProtocolVersion:         p.ProtocolVersion,
+			IsProtocolIncompatible:  p.IsProtocolIncompatible,
+			IsDatahubUrlVerified:    p.IsDataHubURLVerified,
+			MinerId:                 p.MinerID,
+			MinerPublicKey:          p.MinerPublicKey,
+			MinerContact:            p.MinerContact,
+			MinerIdAnnouncedAt:      timeToUnix(p.MinerIDAnnouncedAt),
+			InvalidBlocksReceived:   p.InvalidBlocksReceived,
+			InvalidSubtreesReceived: p.InvalidSubtreesReceived,
+			LastInvalidData:         timeToUnix(p.LastInvalidData),
+			LastInvalidDataReason:   string(p.LastInvalidDataReason),
 		})
 	}
 
@@ -1998,34 +2329,61 @@ func (s *Server) GetPeer(_ context.Context, req *p2p_api.GetPeerRequest) (*p2p_a
 
 	// Convert to protobuf format
 	peerRegistryInfo := &p2p_api.PeerRegistryInfo{
-		Id:              peerInfo.ID.String(),
-		Height:          peerInfo.Height,
-		BlockHash:       peerInfo.BlockHash,
-		DataHubUrl:      peerInfo.DataHubURL,
-		BanScore:        int32(peerInfo.BanScore),
-		IsBanned:        peerInfo.IsBanned,
-		IsConnected:     peerInfo.IsConnected,
-		ConnectedAt:     timeToUnix(peerInfo.ConnectedAt),
-		BytesReceived:   peerInfo.BytesReceived,
-		LastBlockTime:   timeToUnix(peerInfo.LastBlockTime),
-		LastMessageTime: timeToUnix(peerInfo.LastMessageTime),
-		UrlResponsive:   peerInfo.URLResponsive,
-		LastUrlCheck:    timeToUnix(peerInfo.LastURLCheck),
+		Id:                peerInfo.ID.String(),
+		Height:            peerInfo.Height,
+		BlockHash:         peerInfo.BlockHash,
+		DataHubUrl:        peerInfo.DataHubURL,
+		DataHubMirrorUrls: peerInfo.DataHubMirrorURLs,
+		BanScore:          int32(peerInfo.BanScore),
+		IsBanned:          peerInfo.IsBanned,
+		IsConnected:       peerInfo.IsConnected,
+		ConnectedAt:       timeToUnix(peerInfo.ConnectedAt),
+		BytesReceived:     peerInfo.BytesReceived,
+		LastBlockTime:     timeToUnix(peerInfo.LastBlockTime),
+		LastMessageTime:   timeToUnix(peerInfo.LastMessageTime),
+		UrlResponsive:     peerInfo.URLResponsive,
+		LastUrlCheck:      timeToUnix(peerInfo.LastURLCheck),
 
 		// Interaction/catchup metrics
-		InteractionAttempts:    peerInfo.InteractionAttempts,
-		InteractionSuccesses:   peerInfo.InteractionSuccesses,
-		InteractionFailures:    peerInfo.InteractionFailures,
-		LastInteractionAttempt: timeToUnix(peerInfo.LastInteractionAttempt),
-		LastInteractionSuccess: timeToUnix(peerInfo.LastInteractionSuccess),
-		LastInteractionFailure: timeToUnix(peerInfo.LastInteractionFailure),
-		ReputationScore:        peerInfo.ReputationScore,
-		MaliciousCount:         peerInfo.MaliciousCount,
-		AvgResponseTimeMs:      peerInfo.AvgResponseTime.Milliseconds(),
-		Storage:                peerInfo.Storage,
-		ClientName:             peerInfo.ClientName,
-		LastCatchupError:       peerInfo.LastCatchupError,
-		LastCatchupErrorTime:   timeToUnix(peerInfo.LastCatchupErrorTime),
+		InteractionAttempts:     peerInfo.InteractionAttempts,
+		InteractionSuccesses:    peerInfo.InteractionSuccesses,
+		InteractionFailures:     peerInfo.InteractionFailures,
+		LastInteractionAttempt:  timeToUnix(peerInfo.LastInteractionAttempt),
+		LastInteractionSuccess:  timeToUnix(peerInfo.LastInteractionSuccess),
+		LastInteractionFailure:  timeToUnix(peerInfo.LastInteractionFailure),
+		ReputationScore:         peerInfo.ReputationScore,
+		MaliciousCount:          peerInfo.MaliciousCount,
+		DoubleSpendCount:        peerInfo.DoubleSpendCount,
+		LastDoubleSpend:         timeToUnix(peerInfo.LastDoubleSpend),
+		AvgResponseTimeMs:       peerInfo.AvgResponseTime.Milliseconds(),
+		Storage:                 peerInfo.Storage,
+		ClientName:              peerInfo.ClientName,
+		LastCatchupError:        peerInfo.LastCatchupError,
+		LastCatchupErrorTime:    timeToUnix(peerInfo.LastCatchupErrorTime),
+		IsOnProbation:           peerInfo.IsOnProbation,
+		ProbationUntil:          timeToUnix(peerInfo.ProbationUntil),
+		BytesSent:               peerInfo.BytesSent,
+		IsThrottled:             peerInfo.IsThrottled,
+		IsHealthy:               peerInfo.IsHealthy,
+		HealthDurationMs:        peerInfo.HealthDuration.Milliseconds(),
+		HealthCheckFailures:     int32(peerInfo.HealthCheckFailures), //nolint:gosec // consecutive failures stay far below the int32 range
+		IsDataHubDown:           peerInfo.IsDataHubDown,
+		IsRelayOnly:             peerInfo.IsRelayOnly,
+		Transport:               peerInfo.Transport,
+		Country:                 peerInfo.Country,
+		Asn:                     peerInfo.ASN,
+		AsOrganization:          peerInfo.ASOrganization,
+		ProtocolVersion:         peerInfo.ProtocolVersion,
+		IsProtocolIncompatible:  peerInfo.IsProtocolIncompatible,
+		IsDatahubUrlVerified:    peerInfo.IsDataHubURLVerified,
+		MinerId:                 peerInfo.MinerID,
+		MinerPublicKey:          peerInfo.MinerPublicKey,
+		MinerContact:            peerInfo.MinerContact,
+		MinerIdAnnouncedAt:      timeToUnix(peerInfo.MinerIDAnnouncedAt),
+		InvalidBlocksReceived:   peerInfo.InvalidBlocksReceived,
+		InvalidSubtreesReceived: peerInfo.InvalidSubtreesReceived,
+		LastInvalidData:         timeToUnix(peerInfo.LastInvalidData),
+		LastInvalidDataReason:   string(peerInfo.LastInvalidDataReason),
 	}
 
 	return &p2p_api.GetPeerResponse{
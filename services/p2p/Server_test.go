@@ -824,6 +824,35 @@ func (m *MockPeerBanManager) AddScore(peerID string, reason BanReason) (score in
 	return args.Get(0).(int), args.Get(1).(bool)
 }
 
+// AddScorePoints mocks the AddScorePoints method
+func (m *MockPeerBanManager) AddScorePoints(peerID string, reason BanReason, points int) (score int, banned bool) {
+	args := m.Called(peerID, reason, points)
+	return args.Get(0).(int), args.Get(1).(bool)
+}
+
+// BanPeer mocks the BanPeer method
+func (m *MockPeerBanManager) BanPeer(peerID string, until time.Time, reason string) {
+	m.Called(peerID, until, reason)
+}
+
+// ListBanned mocks the ListBanned method
+func (m *MockPeerBanManager) ListBanned() []string {
+	args := m.Called()
+	return args.Get(0).([]string)
+}
+
+// ListBans mocks the ListBans method
+func (m *MockPeerBanManager) ListBans() []BanEntry {
+	args := m.Called()
+	return args.Get(0).([]BanEntry)
+}
+
+// SweepExpiredBans mocks the SweepExpiredBans method
+func (m *MockPeerBanManager) SweepExpiredBans() []string {
+	args := m.Called()
+	return args.Get(0).([]string)
+}
+
 func TestContains(t *testing.T) {
 	// Generate a valid peer ID using crypto key
 	privKey, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
@@ -1601,7 +1630,7 @@ func TestNewServer_ConfigValidation(t *testing.T) {
 			tc.modify(s)
 
 			_, err := NewServer(ctx, logger, s,
-				nil, nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil, nil, nil, nil,
 			)
 
 			require.Error(t, err)
@@ -1633,7 +1662,7 @@ func TestPrivateKeyHandling(t *testing.T) {
 		// No expectations set
 
 		// Execute
-		server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil)
+		server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil, nil)
 
 		// Verify
 		require.NoError(t, err)
@@ -1654,7 +1683,7 @@ func TestPrivateKeyHandling(t *testing.T) {
 		// No blockchain client expectations - we don't use it for key storage anymore
 
 		// Execute
-		server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil)
+		server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil, nil)
 
 		// Verify
 		require.NoError(t, err)
@@ -1678,7 +1707,7 @@ func TestPrivateKeyHandling(t *testing.T) {
 		// No blockchain client expectations - we don't use it for key storage anymore
 
 		// Execute
-		_, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil)
+		_, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil, nil)
 
 		// Verify - should fail with invalid key
 		require.Error(t, err)
@@ -1700,7 +1729,7 @@ func TestPrivateKeyHandling(t *testing.T) {
 		tmpDir := t.TempDir()
 		settings.P2P.PeerCacheDir = tmpDir
 
-		server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil)
+		server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil, nil)
 		require.NoError(t, err)
 		require.NotNil(t, server)
 
@@ -1727,7 +1756,7 @@ func TestPrivateKeyHandling(t *testing.T) {
 		settings.P2P.PeerCacheDir = dir
 		settings.BlockChain.StoreURL = &url.URL{Scheme: "sqlitememory"}
 
-		server, err := NewServer(ctx, logger, settings, &blockchain.Mock{}, nil, nil, nil, nil, nil, nil)
+		server, err := NewServer(ctx, logger, settings, &blockchain.Mock{}, nil, nil, nil, nil, nil, nil, nil)
 		require.Error(t, err)
 		require.Nil(t, server)
 		require.Contains(t, err.Error(), "failed to save private key")
@@ -1816,7 +1845,7 @@ func TestServerInitHTTPPublicAddressSet(t *testing.T) {
 		Scheme: "sqlitememory",
 	}
 
-	server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil)
+	server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil, nil)
 	require.NoError(t, err)
 
 	err = server.Init(ctx)
@@ -1838,7 +1867,7 @@ func TestServerInitHTTPPublicAddressEmpty(t *testing.T) {
 		Scheme: "sqlitememory",
 	}
 
-	server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil)
+	server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil, nil)
 	require.NoError(t, err)
 
 	err = server.Init(ctx)
@@ -1859,7 +1888,7 @@ func TestServerSetupHTTPServer(t *testing.T) {
 		Scheme: "sqlitememory",
 	}
 
-	server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil)
+	server, err := NewServer(ctx, logger, settings, mockClient, nil, nil, nil, nil, nil, nil, nil)
 	require.NoError(t, err)
 
 	err = server.Init(ctx)
@@ -1951,6 +1980,10 @@ func TestServerStartFull(t *testing.T) {
 	binary.LittleEndian.PutUint32(blockPersisterHeightData, 0)
 	mockBlockchain.On("GetState", mock.Anything, "BlockPersisterHeight").Return(blockPersisterHeightData, nil).Maybe()
 
+	// Mock the persisted state of the maintenance jobs
+	mockBlockchain.On("GetState", mock.Anything, "jobs:p2p").Return([]byte(nil), nil).Maybe()
+	mockBlockchain.On("SetState", mock.Anything, "jobs:p2p", mock.Anything).Return(nil).Maybe()
+
 	mockRejectedKafka := new(MockKafkaConsumerGroup)
 	mockRejectedKafka.On("Start", mock.Anything, mock.Anything, mock.Anything).Return()
 
@@ -1983,7 +2016,7 @@ func TestServerStartFull(t *testing.T) {
 	grpcPort := getFreePort(t)
 	settings.P2P.GRPCListenAddress = fmt.Sprintf(":%d", grpcPort)
 
-	server, err := NewServer(ctx, logger, settings, mockBlockchain, nil, nil, nil, mockRejectedKafka, mockBlocksProducer, mockSubtreeProducer)
+	server, err := NewServer(ctx, logger, settings, mockBlockchain, nil, nil, nil, mockRejectedKafka, nil, mockBlocksProducer, mockSubtreeProducer)
 	require.NoError(t, err)
 
 	server.rejectedTxKafkaConsumerClient = mockRejectedKafka
@@ -2903,6 +2936,39 @@ func TestProcessInvalidBlockMessageAddBanScoreFails(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestServerAddBanScore(t *testing.T) {
+	banManager := &PeerBanManager{
+		peerBanScores: make(map[string]*BanScore),
+		reasonPoints: map[BanReason]int{
+			ReasonInvalidBlock: 10,
+		},
+		banThreshold:  100,
+		banDuration:   time.Hour,
+		decayInterval: time.Minute,
+		decayAmount:   1,
+	}
+
+	server := &Server{
+		logger:     ulogger.New("test"),
+		banManager: banManager,
+	}
+
+	// the points of the reason
+	resp, err := server.AddBanScore(context.Background(), &p2p_api.AddBanScoreRequest{PeerId: "peer1", Reason: "invalid_block"})
+	require.NoError(t, err)
+	assert.True(t, resp.Ok)
+
+	score, _, _ := banManager.GetBanScore("peer1")
+	assert.Equal(t, 10, score)
+
+	// the score of the request overrides the points of the reason
+	_, err = server.AddBanScore(context.Background(), &p2p_api.AddBanScoreRequest{PeerId: "peer1", Reason: "invalid_block", Score: 25})
+	require.NoError(t, err)
+
+	score, _, _ = banManager.GetBanScore("peer1")
+	assert.Equal(t, 35, score)
+}
+
 func TestBlockchainSubscriptionListener(t *testing.T) {
 	t.Run("context cancelled - shutdown", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -3535,18 +3601,73 @@ func TestListBannedEnhanced(t *testing.T) {
 	}
 
 	// Mock return data
+	unbanAt := time.Now().Add(time.Hour).Truncate(time.Second)
 	bannedPeers := []string{"192.168.1.100", "10.0.0.5"}
-	mockBanList.On("ListBanned").Return(bannedPeers)
+	mockBanList.On("ListBans").Return([]BanEntry{
+		{Banned: "192.168.1.100", UnbanAt: unbanAt},
+		{Banned: "10.0.0.5", UnbanAt: unbanAt.Add(time.Hour)},
+	})
 
 	resp, err := server.ListBanned(ctx, &emptypb.Empty{})
 	require.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, bannedPeers, resp.Banned)
 
+	require.Len(t, resp.Bans, 2)
+	assert.Equal(t, "192.168.1.100", resp.Bans[0].Banned)
+	assert.Equal(t, unbanAt.Unix(), resp.Bans[0].UnbanAt)
+	assert.Equal(t, "10.0.0.5", resp.Bans[1].Banned)
+	assert.Equal(t, unbanAt.Add(time.Hour).Unix(), resp.Bans[1].UnbanAt)
+
 	// Verify mock was called correctly
 	mockBanList.AssertExpectations(t)
 }
 
+func TestBanPeerByPeerID(t *testing.T) {
+	ctx := context.Background()
+
+	privKey, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+	peerID, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+
+	mockBanList := &MockBanList{}
+	mockBanManager := &MockPeerBanManager{}
+
+	server := &Server{
+		logger:     ulogger.New("test"),
+		settings:   &settings.Settings{},
+		banList:    mockBanList,
+		banManager: mockBanManager,
+	}
+
+	until := time.Now().Add(time.Hour).Unix()
+
+	mockBanManager.On("BanPeer", peerID.String(), time.Unix(until, 0), "spam").Return()
+	mockBanManager.On("ResetBanScore", peerID.String()).Return()
+	mockBanManager.On("ListBans").Return([]BanEntry{{Banned: peerID.String(), UnbanAt: time.Unix(until, 0)}})
+	mockBanList.On("ListBans").Return([]BanEntry{{Banned: "10.0.0.0/24", UnbanAt: time.Unix(until, 0)}})
+
+	banResp, err := server.BanPeer(ctx, &p2p_api.BanPeerRequest{Addr: peerID.String(), Until: until, Reason: "spam"})
+	require.NoError(t, err)
+	assert.True(t, banResp.Ok)
+
+	listResp, err := server.ListBanned(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/24", peerID.String()}, listResp.Banned)
+	require.Len(t, listResp.Bans, 2)
+	assert.Equal(t, until, listResp.Bans[1].UnbanAt)
+
+	unbanResp, err := server.UnbanPeer(ctx, &p2p_api.UnbanPeerRequest{Addr: peerID.String()})
+	require.NoError(t, err)
+	assert.True(t, unbanResp.Ok)
+
+	// peer IDs never reach the IP ban list
+	mockBanList.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+	mockBanList.AssertNotCalled(t, "Remove", mock.Anything, mock.Anything)
+	mockBanManager.AssertExpectations(t)
+}
+
 func TestClearBannedEnhanced(t *testing.T) {
 	ctx := context.Background()
 
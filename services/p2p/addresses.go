@@ -0,0 +1,276 @@
+// This file contains the conversion of the configured listen and advertise addresses to multiaddrs and the
+// validation of the advertised DataHub URL.
+package p2p
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// toMultiaddr converts a configured address to a TCP multiaddr. Addresses can be multiaddrs, IP addresses or DNS
+// names, with or without a port; the P2P port is used when the address has no port.
+func toMultiaddr(address string, port int) (string, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "", errors.NewConfigurationError("empty p2p address")
+	}
+
+	if strings.HasPrefix(address, "/") {
+		if _, err := ma.NewMultiaddr(address); err != nil {
+			return "", errors.NewConfigurationError("invalid p2p multiaddr %s", address, err)
+		}
+
+		return address, nil
+	}
+
+	host := address
+
+	if h, p, err := net.SplitHostPort(address); err == nil {
+		host = h
+
+		port, err = strconv.Atoi(p)
+		if err != nil || port <= 0 || port > 65535 {
+			return "", errors.NewConfigurationError("invalid port in p2p address %s", address)
+		}
+	}
+
+	host = strings.Trim(host, "[]")
+
+	var protocol string
+
+	if ip := net.ParseIP(host); ip != nil {
+		protocol = "ip6"
+		if ip.To4() != nil {
+			protocol = "ip4"
+		}
+	} else {
+		if !validDNSName(host) {
+			return "", errors.NewConfigurationError("invalid host in p2p address %s", address)
+		}
+
+		protocol = "dns"
+	}
+
+	maddr := "/" + protocol + "/" + host + "/tcp/" + strconv.Itoa(port)
+
+	if _, err := ma.NewMultiaddr(maddr); err != nil {
+		return "", errors.NewConfigurationError("invalid p2p address %s", address, err)
+	}
+
+	return maddr, nil
+}
+
+// validDNSName returns whether name is a syntactically valid DNS name
+func validDNSName(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" || len(name) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isUnspecifiedAddress returns whether the multiaddr binds all interfaces, such an address cannot be advertised
+func isUnspecifiedAddress(maddr string) bool {
+	parts := strings.Split(maddr, "/")
+	if len(parts) < 3 || (parts[1] != "ip4" && parts[1] != "ip6") {
+		return false
+	}
+
+	ip := net.ParseIP(parts[2])
+
+	return ip != nil && ip.IsUnspecified()
+}
+
+// getAdvertiseAddresses returns the multiaddrs this node advertises to its peers:
+//   - the configured advertise addresses, when set, which can differ from the listen addresses, e.g. the external
+//     IP addresses or DNS names of a node behind a load balancer
+//   - the listen addresses when private addresses are shared, without the addresses binding all interfaces
+//   - none otherwise, the addresses are then detected and private addresses filtered by the message bus
+func getAdvertiseAddresses(logger ulogger.Logger, tSettings *settings.Settings) ([]string, error) {
+	port := tSettings.P2P.Port
+
+	if len(tSettings.P2P.AdvertiseAddresses) > 0 {
+		addresses := make([]string, 0, len(tSettings.P2P.AdvertiseAddresses))
+
+		for _, address := range tSettings.P2P.AdvertiseAddresses {
+			maddr, err := toMultiaddr(address, port)
+			if err != nil {
+				return nil, errors.NewConfigurationError("invalid p2p_advertise_addresses", err)
+			}
+
+			if isUnspecifiedAddress(maddr) {
+				return nil, errors.NewConfigurationError("p2p_advertise_addresses contains %s, which cannot be reached by peers", address)
+			}
+
+			addresses = append(addresses, maddr)
+		}
+
+		return addresses, nil
+	}
+
+	if !tSettings.P2P.SharePrivateAddresses {
+		return []string{}, nil
+	}
+
+	addresses := make([]string, 0, len(tSettings.P2P.ListenAddresses))
+
+	for _, address := range tSettings.P2P.ListenAddresses {
+		maddr, err := toMultiaddr(address, port)
+		if err != nil {
+			return nil, errors.NewConfigurationError("invalid p2p_listen_addresses", err)
+		}
+
+		if isUnspecifiedAddress(maddr) {
+			logger.Debugf("[getAdvertiseAddresses] not sharing listen address %s, it binds all interfaces", address)
+			continue
+		}
+
+		addresses = append(addresses, maddr)
+	}
+
+	return addresses, nil
+}
+
+// validateDataHubURL checks that the DataHub URL advertised to peers is a valid http or https URL and logs a warning
+// when it does not look like it is served by the asset service of this node. A URL pointing at this host should use
+// a port the asset service listens on and end with the asset API prefix. These are only warnings, as ports mapped by
+// a container runtime or paths rewritten by a proxy cannot be detected here.
+func validateDataHubURL(logger ulogger.Logger, tSettings *settings.Settings, dataHubURL string) error {
+	if dataHubURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(dataHubURL)
+	if err != nil {
+		return errors.NewConfigurationError("invalid advertised DataHub URL %s", dataHubURL, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.NewConfigurationError("advertised DataHub URL %s must be an http or https URL with a host", dataHubURL)
+	}
+
+	for _, warning := range dataHubURLWarnings(tSettings, u) {
+		logger.Warnf("[validateDataHubURL] advertised DataHub URL %s %s", dataHubURL, warning)
+	}
+
+	return nil
+}
+
+// validateDataHubMirrorURLs checks that the DataHub mirrors advertised to peers are http or https URLs with a host,
+// no more than peers keep. Mirrors are served by other hosts, so they are not checked against the asset service.
+func validateDataHubMirrorURLs(mirrorURLs []string) error {
+	if len(mirrorURLs) > maxDataHubMirrorURLs {
+		return errors.NewConfigurationError("%d DataHub mirror URLs advertised, peers keep at most %d", len(mirrorURLs), maxDataHubMirrorURLs)
+	}
+
+	for _, mirrorURL := range mirrorURLs {
+		u, err := url.Parse(mirrorURL)
+		if err != nil {
+			return errors.NewConfigurationError("invalid DataHub mirror URL %s", mirrorURL, err)
+		}
+
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+			return errors.NewConfigurationError("DataHub mirror URL %s must be an http or https URL with a host", mirrorURL)
+		}
+	}
+
+	return nil
+}
+
+// dataHubURLWarnings returns the reasons the DataHub URL might not be served by the asset service of this node
+func dataHubURLWarnings(tSettings *settings.Settings, u *url.URL) []string {
+	var warnings []string
+
+	prefix := strings.TrimSuffix(tSettings.Asset.APIPrefix, "/")
+	if prefix != "" && !strings.HasSuffix(strings.TrimSuffix(u.Path, "/"), prefix) {
+		warnings = append(warnings, "does not end with the asset API prefix "+prefix+", peers can only use it when a proxy rewrites the path")
+	}
+
+	if !isLocalHost(u.Hostname()) {
+		return warnings
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	listenPorts := assetListenPorts(tSettings)
+	if len(listenPorts) == 0 {
+		return warnings
+	}
+
+	for _, listenPort := range listenPorts {
+		if listenPort == port || listenPort == "0" {
+			return warnings
+		}
+	}
+
+	return append(warnings, "points at this host on port "+port+", but the asset service listens on ports "+strings.Join(listenPorts, ", "))
+}
+
+// assetListenPorts returns the ports of the listen addresses of the asset service
+func assetListenPorts(tSettings *settings.Settings) []string {
+	addresses := append([]string{tSettings.Asset.HTTPListenAddress}, tSettings.Asset.HTTPListenAddresses...)
+	ports := make([]string, 0, len(addresses))
+
+	for _, address := range addresses {
+		if _, port, err := net.SplitHostPort(address); err == nil {
+			ports = append(ports, port)
+		}
+	}
+
+	return ports
+}
+
+// isLocalHost returns whether host is this machine, by name or by the address of one of its interfaces
+func isLocalHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	if ip.IsLoopback() || ip.IsUnspecified() {
+		return true
+	}
+
+	interfaceAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return false
+	}
+
+	for _, addr := range interfaceAddrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
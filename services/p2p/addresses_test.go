@@ -0,0 +1,151 @@
+package p2p
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToMultiaddr(t *testing.T) {
+	valid := map[string]string{
+		"/ip4/203.0.113.1/tcp/9905":    "/ip4/203.0.113.1/tcp/9905",
+		"/dns4/node.example.com/tcp/1": "/dns4/node.example.com/tcp/1",
+		"203.0.113.1":                  "/ip4/203.0.113.1/tcp/9905",
+		"203.0.113.1:4001":             "/ip4/203.0.113.1/tcp/4001",
+		"2001:db8::1":                  "/ip6/2001:db8::1/tcp/9905",
+		"[2001:db8::1]:4001":           "/ip6/2001:db8::1/tcp/4001",
+		"node.example.com":             "/dns/node.example.com/tcp/9905",
+		"node.example.com:4001":        "/dns/node.example.com/tcp/4001",
+		"0.0.0.0":                      "/ip4/0.0.0.0/tcp/9905",
+	}
+
+	for address, expected := range valid {
+		maddr, err := toMultiaddr(address, 9905)
+		require.NoError(t, err, address)
+		assert.Equal(t, expected, maddr, address)
+	}
+
+	for _, address := range []string{"", "/ip4/not-an-ip/tcp/1", "node.example.com:port", "node.example.com:70000", "bad_host.example.com", "-node.example.com"} {
+		_, err := toMultiaddr(address, 9905)
+		require.Error(t, err, address)
+		assert.True(t, errors.Is(err, errors.ErrConfiguration), address)
+	}
+}
+
+func TestGetAdvertiseAddresses(t *testing.T) {
+	newSettings := func() *settings.Settings {
+		tSettings := &settings.Settings{}
+		tSettings.P2P.Port = 9905
+
+		return tSettings
+	}
+
+	t.Run("advertise addresses with DNS names", func(t *testing.T) {
+		tSettings := newSettings()
+		tSettings.P2P.AdvertiseAddresses = []string{"node.example.com", "203.0.113.1:4001"}
+		tSettings.P2P.ListenAddresses = []string{"0.0.0.0"}
+
+		addresses, err := getAdvertiseAddresses(ulogger.TestLogger{}, tSettings)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/dns/node.example.com/tcp/9905", "/ip4/203.0.113.1/tcp/4001"}, addresses)
+	})
+
+	t.Run("unspecified advertise address is rejected", func(t *testing.T) {
+		tSettings := newSettings()
+		tSettings.P2P.AdvertiseAddresses = []string{"0.0.0.0"}
+
+		_, err := getAdvertiseAddresses(ulogger.TestLogger{}, tSettings)
+		require.Error(t, err)
+	})
+
+	t.Run("shared listen addresses skip unspecified addresses", func(t *testing.T) {
+		tSettings := newSettings()
+		tSettings.P2P.SharePrivateAddresses = true
+		tSettings.P2P.ListenAddresses = []string{"0.0.0.0", "10.0.0.1", "::"}
+
+		addresses, err := getAdvertiseAddresses(ulogger.TestLogger{}, tSettings)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/ip4/10.0.0.1/tcp/9905"}, addresses)
+	})
+
+	t.Run("invalid listen address", func(t *testing.T) {
+		tSettings := newSettings()
+		tSettings.P2P.SharePrivateAddresses = true
+		tSettings.P2P.ListenAddresses = []string{"not a host"}
+
+		_, err := getAdvertiseAddresses(ulogger.TestLogger{}, tSettings)
+		require.Error(t, err)
+	})
+}
+
+func TestValidateDataHubURL(t *testing.T) {
+	newSettings := func(listenAddresses ...string) *settings.Settings {
+		tSettings := &settings.Settings{}
+		tSettings.Asset.APIPrefix = "/api/v1"
+		tSettings.Asset.HTTPListenAddress = listenAddresses[0]
+		tSettings.Asset.HTTPListenAddresses = listenAddresses[1:]
+
+		return tSettings
+	}
+
+	warnings := func(tSettings *settings.Settings, dataHubURL string) []string {
+		u, err := url.Parse(dataHubURL)
+		require.NoError(t, err)
+
+		return dataHubURLWarnings(tSettings, u)
+	}
+
+	logger := ulogger.TestLogger{}
+
+	t.Run("served locally", func(t *testing.T) {
+		assert.Empty(t, warnings(newSettings(":8090"), "http://localhost:8090/api/v1"))
+		assert.Empty(t, warnings(newSettings(":8090", "127.0.0.1:9090"), "http://127.0.0.1:9090/api/v1/"))
+		assert.Empty(t, warnings(newSettings(":0"), "http://localhost:1234/api/v1"), "random listen port")
+	})
+
+	t.Run("not served locally", func(t *testing.T) {
+		assert.Len(t, warnings(newSettings(":8090"), "http://localhost:9999/api/v1"), 1, "wrong port")
+		assert.Len(t, warnings(newSettings(":8090"), "http://localhost:8090/other"), 1, "missing API prefix")
+		assert.Len(t, warnings(newSettings(":80"), "https://localhost/other"), 2, "default https port and missing API prefix")
+
+		assert.NoError(t, validateDataHubURL(logger, newSettings(":8090"), "http://localhost:9999"), "only logged")
+	})
+
+	t.Run("external hosts", func(t *testing.T) {
+		assert.Empty(t, warnings(newSettings(":8090"), "https://datahub.example.com/api/v1"))
+		assert.Len(t, warnings(newSettings(":8090"), "https://datahub.example.com/teranode"), 1, "path rewritten by a proxy")
+	})
+
+	t.Run("invalid URLs", func(t *testing.T) {
+		for _, dataHubURL := range []string{"ftp://datahub.example.com/api/v1", "http:///api/v1", "://bad"} {
+			err := validateDataHubURL(logger, newSettings(":8090"), dataHubURL)
+			require.Error(t, err, dataHubURL)
+			assert.True(t, errors.Is(err, errors.ErrConfiguration), dataHubURL)
+		}
+	})
+
+	t.Run("no advertised URL", func(t *testing.T) {
+		assert.NoError(t, validateDataHubURL(logger, newSettings(":8090"), ""))
+	})
+}
+
+func TestValidateDataHubMirrorURLs(t *testing.T) {
+	require.NoError(t, validateDataHubMirrorURLs(nil))
+	require.NoError(t, validateDataHubMirrorURLs([]string{"https://mirror1.example.com/api/v1", "http://203.0.113.1:8090/api/v1"}))
+
+	for name, mirrorURLs := range map[string][]string{
+		"too many": {"http://m1.example.com", "http://m2.example.com", "http://m3.example.com", "http://m4.example.com", "http://m5.example.com"},
+		"no http":  {"ftp://mirror.example.com"},
+		"no host":  {"http:///api/v1"},
+		"unparsed": {"://bad"},
+	} {
+		err := validateDataHubMirrorURLs(mirrorURLs)
+		require.Error(t, err, name)
+		assert.True(t, errors.Is(err, errors.ErrConfiguration), name)
+	}
+}
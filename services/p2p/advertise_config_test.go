@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestAdvertiseConfiguration verifies that advertise addresses are configured correctly
@@ -17,12 +19,9 @@ func TestAdvertiseConfiguration(t *testing.T) {
 		testSettings.P2P.AdvertiseAddresses = []string{}
 		testSettings.P2P.SharePrivateAddresses = false
 
-		listenAddresses := []string{"/ip4/192.168.1.1/tcp/9905", "/ip4/1.2.3.4/tcp/9905"}
-		advertiseAddresses := getConfiguredAdvertiseAddresses(
-			testSettings.P2P.AdvertiseAddresses,
-			testSettings.P2P.SharePrivateAddresses,
-			listenAddresses,
-		)
+		testSettings.P2P.ListenAddresses = []string{"/ip4/192.168.1.1/tcp/9905", "/ip4/1.2.3.4/tcp/9905"}
+		advertiseAddresses, err := getAdvertiseAddresses(ulogger.TestLogger{}, testSettings)
+		require.NoError(t, err)
 
 		// Should be empty - go-p2p will auto-detect public addresses only
 		assert.Empty(t, advertiseAddresses, "advertiseAddresses should be empty for go-p2p to filter private IPs")
@@ -35,15 +34,12 @@ func TestAdvertiseConfiguration(t *testing.T) {
 		testSettings.P2P.AdvertiseAddresses = []string{}
 		testSettings.P2P.SharePrivateAddresses = true
 
-		listenAddresses := []string{"/ip4/192.168.1.1/tcp/9905", "/ip4/10.0.0.1/tcp/9905"}
-		advertiseAddresses := getConfiguredAdvertiseAddresses(
-			testSettings.P2P.AdvertiseAddresses,
-			testSettings.P2P.SharePrivateAddresses,
-			listenAddresses,
-		)
+		testSettings.P2P.ListenAddresses = []string{"/ip4/192.168.1.1/tcp/9905", "/ip4/10.0.0.1/tcp/9905"}
+		advertiseAddresses, err := getAdvertiseAddresses(ulogger.TestLogger{}, testSettings)
+		require.NoError(t, err)
 
 		// Should use listen addresses for local connectivity
-		assert.Equal(t, listenAddresses, advertiseAddresses, "should use listen addresses when SharePrivateAddresses is true")
+		assert.Equal(t, testSettings.P2P.ListenAddresses, advertiseAddresses, "should use listen addresses when SharePrivateAddresses is true")
 	})
 
 	t.Run("uses_explicit_advertise_addresses_when_configured", func(t *testing.T) {
@@ -53,30 +49,11 @@ func TestAdvertiseConfiguration(t *testing.T) {
 		}
 		testSettings.P2P.SharePrivateAddresses = false // Should be ignored when explicit addresses are set
 
-		listenAddresses := []string{"/ip4/192.168.1.1/tcp/9905"}
-		advertiseAddresses := getConfiguredAdvertiseAddresses(
-			testSettings.P2P.AdvertiseAddresses,
-			testSettings.P2P.SharePrivateAddresses,
-			listenAddresses,
-		)
+		testSettings.P2P.ListenAddresses = []string{"/ip4/192.168.1.1/tcp/9905"}
+		advertiseAddresses, err := getAdvertiseAddresses(ulogger.TestLogger{}, testSettings)
+		require.NoError(t, err)
 
 		// Should use the explicitly configured addresses
 		assert.Equal(t, testSettings.P2P.AdvertiseAddresses, advertiseAddresses)
 	})
 }
-
-// Helper function that mirrors the logic in Server.go
-func getConfiguredAdvertiseAddresses(configuredAddresses []string, sharePrivateAddresses bool, listenAddresses []string) []string {
-	if len(configuredAddresses) > 0 {
-		// Use explicitly configured advertise addresses
-		return configuredAddresses
-	}
-
-	if sharePrivateAddresses {
-		// Share private addresses for local connectivity
-		return listenAddresses
-	}
-
-	// Let go-p2p auto-detect and filter private addresses
-	return []string{}
-}
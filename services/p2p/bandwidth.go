@@ -0,0 +1,237 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Bandwidth directions used for per-peer accounting
+const (
+	BandwidthDirectionDownload = "download" // Bytes received from the peer
+	BandwidthDirectionUpload   = "upload"   // Bytes sent to the peer
+)
+
+// bandwidthBucketCount is the number of buckets a rolling bandwidth window is split into
+const bandwidthBucketCount = 60
+
+// rollingCounter sums byte counts over a rolling window using fixed time buckets
+type rollingCounter struct {
+	bucketDuration time.Duration
+	counts         [bandwidthBucketCount]uint64
+	epochs         [bandwidthBucketCount]int64 // Bucket epoch each slot currently holds
+}
+
+func newRollingCounter(window time.Duration) rollingCounter {
+	bucketDuration := window / bandwidthBucketCount
+	if bucketDuration <= 0 {
+		bucketDuration = time.Second
+	}
+
+	return rollingCounter{bucketDuration: bucketDuration}
+}
+
+func (rc *rollingCounter) add(now time.Time, bytes uint64) {
+	epoch := now.UnixNano() / int64(rc.bucketDuration)
+	slot := epoch % bandwidthBucketCount
+
+	if rc.epochs[slot] != epoch {
+		rc.epochs[slot] = epoch
+		rc.counts[slot] = 0
+	}
+
+	rc.counts[slot] += bytes
+}
+
+func (rc *rollingCounter) total(now time.Time) uint64 {
+	epoch := now.UnixNano() / int64(rc.bucketDuration)
+
+	var total uint64
+
+	for slot := range rc.counts {
+		if epoch-rc.epochs[slot] < bandwidthBucketCount {
+			total += rc.counts[slot]
+		}
+	}
+
+	return total
+}
+
+// peerBandwidth holds the rolling download and upload counters for a single peer
+type peerBandwidth struct {
+	download rollingCounter
+	upload   rollingCounter
+}
+
+// PeerBandwidthTracker accounts per-peer download and upload bytes over a rolling window
+// and checks them against configured quotas. A quota of 0 means unlimited.
+// All operations are thread-safe.
+type PeerBandwidthTracker struct {
+	mu            sync.Mutex
+	window        time.Duration
+	downloadQuota uint64
+	uploadQuota   uint64
+	peers         map[peer.ID]*peerBandwidth
+}
+
+// NewPeerBandwidthTracker creates a bandwidth tracker with the given rolling window and quotas
+func NewPeerBandwidthTracker(window time.Duration, downloadQuota uint64, uploadQuota uint64) *PeerBandwidthTracker {
+	if window <= 0 {
+		window = time.Hour
+	}
+
+	return &PeerBandwidthTracker{
+		window:        window,
+		downloadQuota: downloadQuota,
+		uploadQuota:   uploadQuota,
+		peers:         make(map[peer.ID]*peerBandwidth),
+	}
+}
+
+// Record adds bytes to a peer's counter for the given direction
+// Returns the bytes used in the current window and whether the peer is now over its quota in that direction
+func (t *PeerBandwidthTracker) Record(id peer.ID, direction string, bytes uint64) (windowBytes uint64, overQuota bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+
+	pb, exists := t.peers[id]
+	if !exists {
+		pb = &peerBandwidth{
+			download: newRollingCounter(t.window),
+			upload:   newRollingCounter(t.window),
+		}
+		t.peers[id] = pb
+	}
+
+	counter, quota := &pb.download, t.downloadQuota
+	if direction == BandwidthDirectionUpload {
+		counter, quota = &pb.upload, t.uploadQuota
+	}
+
+	counter.add(now, bytes)
+	windowBytes = counter.total(now)
+
+	return windowBytes, quota > 0 && windowBytes > quota
+}
+
+// Usage returns the bytes downloaded from and uploaded to a peer in the current window
+func (t *PeerBandwidthTracker) Usage(id peer.ID) (download uint64, upload uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pb, exists := t.peers[id]
+	if !exists {
+		return 0, 0
+	}
+
+	now := time.Now()
+
+	return pb.download.total(now), pb.upload.total(now)
+}
+
+// IsOverQuota returns whether a peer currently exceeds its download or upload quota
+func (t *PeerBandwidthTracker) IsOverQuota(id peer.ID) bool {
+	download, upload := t.Usage(id)
+
+	return (t.downloadQuota > 0 && download > t.downloadQuota) || (t.uploadQuota > 0 && upload > t.uploadQuota)
+}
+
+// Remove drops all accounting for a peer
+func (t *PeerBandwidthTracker) Remove(id peer.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.peers, id)
+}
+
+// Window returns the rolling window the tracker accounts over
+func (t *PeerBandwidthTracker) Window() time.Duration {
+	return t.window
+}
+
+// Prune drops accounting for peers with no usage left in the current window
+func (t *PeerBandwidthTracker) Prune() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	pruned := 0
+
+	for id, pb := range t.peers {
+		if pb.download.total(now) == 0 && pb.upload.total(now) == 0 {
+			delete(t.peers, id)
+			pruned++
+		}
+	}
+
+	return pruned
+}
+
+// recordBandwidth accounts bytes exchanged with a peer and throttles the peer when it exceeds its quota for the
+// given direction. A throttled peer keeps its connection, the message bus client can not close it, but its messages
+// are ignored and it is not used for catchup until releaseThrottledPeers finds it back under quota.
+func (s *Server) recordBandwidth(peerID peer.ID, direction string, bytes uint64) {
+	if prometheusP2PPeerBytes != nil {
+		prometheusP2PPeerBytes.WithLabelValues(peerID.String(), direction).Add(float64(bytes))
+	}
+
+	if s.bandwidthTracker == nil || s.peerRegistry == nil {
+		return
+	}
+
+	windowBytes, overQuota := s.bandwidthTracker.Record(peerID, direction, bytes)
+	if !overQuota {
+		return
+	}
+
+	info, exists := s.peerRegistry.GetPeer(peerID)
+	if !exists || info.IsThrottled {
+		return
+	}
+
+	s.logger.Warnf("[recordBandwidth] throttling peer %s: %s quota exceeded (%d bytes in %v)", peerID, direction, windowBytes, s.bandwidthTracker.Window())
+	s.peerRegistry.UpdateThrottleStatus(peerID, true)
+
+	if prometheusP2PPeerBandwidthQuotaExceeded != nil {
+		prometheusP2PPeerBandwidthQuotaExceeded.WithLabelValues(direction).Inc()
+	}
+}
+
+// shouldSkipThrottledPeer checks if we should skip a message received from a throttled peer
+func (s *Server) shouldSkipThrottledPeer(from string, messageType string) bool {
+	if s.peerRegistry == nil {
+		return false
+	}
+
+	peerID, err := peer.Decode(from)
+	if err != nil {
+		return false
+	}
+
+	if info, exists := s.peerRegistry.GetPeer(peerID); exists && info.IsThrottled {
+		s.logger.Debugf("[%s] ignoring notification from throttled peer %s", messageType, from)
+		return true
+	}
+
+	return false
+}
+
+// releaseThrottledPeers lifts throttling from peers whose usage has dropped back under quota
+// and drops accounting for peers that no longer have any usage in the window
+func (s *Server) releaseThrottledPeers() {
+	if s.peerRegistry == nil || s.bandwidthTracker == nil {
+		return
+	}
+
+	for _, peerID := range s.peerRegistry.GetThrottledPeers() {
+		if !s.bandwidthTracker.IsOverQuota(peerID) {
+			s.logger.Infof("[releaseThrottledPeers] peer %s is back under its bandwidth quota, lifting throttle", peerID)
+			s.peerRegistry.UpdateThrottleStatus(peerID, false)
+		}
+	}
+
+	s.bandwidthTracker.Prune()
+}
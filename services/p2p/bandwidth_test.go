@@ -0,0 +1,188 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollingCounter(t *testing.T) {
+	rc := newRollingCounter(time.Minute)
+	now := time.Now()
+
+	rc.add(now, 100)
+	rc.add(now, 50)
+	assert.Equal(t, uint64(150), rc.total(now))
+
+	// Bytes still count until they fall out of the window
+	rc.add(now.Add(30*time.Second), 25)
+	assert.Equal(t, uint64(175), rc.total(now.Add(30*time.Second)))
+
+	// Only the later bucket is left once the first falls out of the window
+	assert.Equal(t, uint64(25), rc.total(now.Add(time.Minute+time.Second)))
+
+	// Reusing a slot from a previous lap resets it
+	rc.add(now.Add(2*time.Minute), 10)
+	assert.Equal(t, uint64(10), rc.total(now.Add(2*time.Minute)))
+}
+
+func TestPeerBandwidthTracker(t *testing.T) {
+	t.Run("record and usage", func(t *testing.T) {
+		tracker := NewPeerBandwidthTracker(time.Hour, 0, 0)
+		id := peer.ID("peer-1")
+
+		windowBytes, over := tracker.Record(id, BandwidthDirectionDownload, 1000)
+		assert.Equal(t, uint64(1000), windowBytes)
+		assert.False(t, over, "no quota means never over quota")
+
+		tracker.Record(id, BandwidthDirectionUpload, 300)
+
+		download, upload := tracker.Usage(id)
+		assert.Equal(t, uint64(1000), download)
+		assert.Equal(t, uint64(300), upload)
+
+		download, upload = tracker.Usage(peer.ID("unknown"))
+		assert.Zero(t, download)
+		assert.Zero(t, upload)
+	})
+
+	t.Run("quotas per direction", func(t *testing.T) {
+		tracker := NewPeerBandwidthTracker(time.Hour, 1000, 500)
+		id := peer.ID("peer-1")
+
+		_, over := tracker.Record(id, BandwidthDirectionDownload, 1000)
+		assert.False(t, over, "reaching the quota is not exceeding it")
+
+		_, over = tracker.Record(id, BandwidthDirectionUpload, 400)
+		assert.False(t, over)
+		assert.False(t, tracker.IsOverQuota(id))
+
+		_, over = tracker.Record(id, BandwidthDirectionUpload, 101)
+		assert.True(t, over)
+		assert.True(t, tracker.IsOverQuota(id))
+	})
+
+	t.Run("remove and prune", func(t *testing.T) {
+		tracker := NewPeerBandwidthTracker(time.Hour, 100, 0)
+		id := peer.ID("peer-1")
+
+		tracker.Record(id, BandwidthDirectionDownload, 200)
+		assert.Equal(t, 0, tracker.Prune(), "peers with usage in the window are kept")
+
+		tracker.Remove(id)
+		assert.False(t, tracker.IsOverQuota(id))
+
+		// A peer whose usage has fallen out of the window is pruned
+		tracker.Record(id, BandwidthDirectionDownload, 200)
+		tracker.mu.Lock()
+		for i := range tracker.peers[id].download.epochs {
+			tracker.peers[id].download.epochs[i] -= 2 * bandwidthBucketCount
+		}
+		tracker.mu.Unlock()
+
+		assert.False(t, tracker.IsOverQuota(id))
+		assert.Equal(t, 1, tracker.Prune())
+	})
+
+	t.Run("defaults window", func(t *testing.T) {
+		tracker := NewPeerBandwidthTracker(0, 0, 0)
+		assert.Equal(t, time.Hour, tracker.Window())
+	})
+}
+
+func newBandwidthTestServer(t *testing.T) *Server {
+	tSettings := test.CreateBaseTestSettings(t)
+
+	initPrometheusMetrics()
+
+	return &Server{
+		logger:           ulogger.TestLogger{},
+		settings:         tSettings,
+		peerRegistry:     NewPeerRegistry(),
+		bandwidthTracker: NewPeerBandwidthTracker(time.Hour, 1000, 1000),
+	}
+}
+
+// newTestPeerID returns the peer ID of a new Ed25519 peer key
+func newTestPeerID(t *testing.T) peer.ID {
+	t.Helper()
+
+	privKey, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+
+	id, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+
+	return id
+}
+
+func TestServer_RecordBandwidth(t *testing.T) {
+	t.Run("throttle", func(t *testing.T) {
+		s := newBandwidthTestServer(t)
+		id := newTestPeerID(t)
+		s.peerRegistry.AddPeer(id, "")
+
+		s.recordBandwidth(id, BandwidthDirectionDownload, 800)
+		assert.False(t, s.shouldSkipThrottledPeer(id.String(), "test"))
+
+		s.recordBandwidth(id, BandwidthDirectionDownload, 800)
+
+		info, exists := s.peerRegistry.GetPeer(id)
+		require.True(t, exists, "throttled peers stay in the registry")
+		assert.True(t, info.IsThrottled)
+		assert.True(t, s.shouldSkipThrottledPeer(id.String(), "test"))
+
+		s.peerRegistry.UpdateDataHubURL(id, "http://peer")
+		assert.Empty(t, s.peerRegistry.GetPeersForCatchup(), "throttled peers are not used for catchup")
+
+		// Still over quota, so the throttle stays
+		s.releaseThrottledPeers()
+		info, _ = s.peerRegistry.GetPeer(id)
+		assert.True(t, info.IsThrottled)
+
+		// Once usage drops back under quota the throttle is lifted
+		s.bandwidthTracker.Remove(id)
+		s.releaseThrottledPeers()
+		info, _ = s.peerRegistry.GetPeer(id)
+		assert.False(t, info.IsThrottled)
+	})
+
+	t.Run("upload quota", func(t *testing.T) {
+		s := newBandwidthTestServer(t)
+		client := new(MockServerP2PClient)
+		client.On("GetID").Return(peer.ID("self"))
+		s.P2PClient = client
+
+		id := newTestPeerID(t)
+		s.peerRegistry.AddPeer(id, "")
+		s.peerRegistry.UpdateConnectionState(id, true)
+
+		s.recordBandwidth(id, BandwidthDirectionUpload, 1500)
+
+		info, exists := s.peerRegistry.GetPeer(id)
+		require.True(t, exists)
+		assert.True(t, info.IsThrottled)
+		assert.True(t, info.IsConnected, "the connection of a throttled peer is kept")
+
+		// the messages of the peer are no longer processed
+		blockHash := "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+		s.notificationCh = make(chan *notificationMsg, 10)
+		s.banManager = NewPeerBanManager(context.Background(), nil, s.settings, s.peerRegistry)
+		s.handleBlockTopic(context.Background(), []byte(`{"Hash":"`+blockHash+`","Height":1,"DataHubURL":"http://example.com","PeerID":"`+id.String()+`"}`), id.String())
+
+		_, stored := s.blockPeerMap.Load(blockHash)
+		assert.False(t, stored, "the block announcement of the peer is ignored")
+
+		// once usage drops back under quota the peer is accepted again
+		s.bandwidthTracker.Remove(id)
+		s.releaseThrottledPeers()
+		assert.False(t, s.shouldSkipThrottledPeer(id.String(), "test"))
+	})
+}
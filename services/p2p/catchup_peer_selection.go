@@ -0,0 +1,91 @@
+package p2p
+
+import (
+	"math/rand/v2"
+)
+
+// Orders of the peers returned for catchup
+const (
+	CatchupPeerSelectionBest           = "best"            // By reputation, the best peer first
+	CatchupPeerSelectionWeightedRandom = "weighted_random" // Drawn at random, weighted by reputation
+)
+
+// orderPeersForCatchup reorders the peers for catchup, ordered by storage mode then reputation by
+// GetPeersForCatchup, according to the catchup peer selection mode. Peers keep their storage mode order.
+func (s *Server) orderPeersForCatchup(peers []*PeerInfo) []*PeerInfo {
+	if s.settings == nil || s.settings.P2P.CatchupPeerSelection != CatchupPeerSelectionWeightedRandom {
+		return peers
+	}
+
+	return weightedRandomOrder(peers, s.settings.P2P.CatchupPeerExploration, rand.Float64)
+}
+
+// weightedRandomOrder draws the peers one by one without replacement, within each run of peers of the same storage
+// mode. A peer is drawn with a weight of (1-exploration) * its share of the reputation scores of the remaining
+// peers + exploration / the number of remaining peers, so the best peers are favored while every peer is tried.
+// random returns a number in [0, 1).
+func weightedRandomOrder(peers []*PeerInfo, exploration float64, random func() float64) []*PeerInfo {
+	exploration = min(max(exploration, 0), 1)
+
+	ordered := make([]*PeerInfo, 0, len(peers))
+
+	for start := 0; start < len(peers); {
+		end := start + 1
+		for end < len(peers) && peers[end].Storage == peers[start].Storage {
+			end++
+		}
+
+		ordered = append(ordered, drawWeighted(peers[start:end], exploration, random)...)
+		start = end
+	}
+
+	return ordered
+}
+
+// drawWeighted returns the peers in the order they are drawn, see weightedRandomOrder
+func drawWeighted(peers []*PeerInfo, exploration float64, random func() float64) []*PeerInfo {
+	remaining := append([]*PeerInfo(nil), peers...)
+	drawn := make([]*PeerInfo, 0, len(peers))
+
+	for len(remaining) > 0 {
+		var total float64
+		for _, p := range remaining {
+			total += max(p.ReputationScore, 0)
+		}
+
+		weights := make([]float64, len(remaining))
+
+		var sum float64
+
+		for i, p := range remaining {
+			weight := exploration / float64(len(remaining))
+
+			if total > 0 {
+				weight += (1 - exploration) * max(p.ReputationScore, 0) / total
+			} else {
+				weight += (1 - exploration) / float64(len(remaining))
+			}
+
+			weights[i] = weight
+			sum += weight
+		}
+
+		// the last peer is picked when rounding leaves the draw above the sum of the weights
+		picked := len(remaining) - 1
+		r := random() * sum
+
+		for i, weight := range weights {
+			if r < weight {
+				picked = i
+				break
+			}
+
+			r -= weight
+		}
+
+		drawn = append(drawn, remaining[picked])
+		remaining = append(remaining[:picked], remaining[picked+1:]...)
+	}
+
+	return drawn
+}
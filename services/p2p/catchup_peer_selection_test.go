@@ -0,0 +1,104 @@
+package p2p
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func catchupPeers(storage string, scores ...float64) []*PeerInfo {
+	ids := GenerateTestPeerIDs(len(scores))
+	peers := make([]*PeerInfo, 0, len(scores))
+
+	for i, score := range scores {
+		peers = append(peers, &PeerInfo{ID: ids[i], Storage: storage, ReputationScore: score})
+	}
+
+	return peers
+}
+
+func TestWeightedRandomOrder(t *testing.T) {
+	t.Run("keeps the storage mode order", func(t *testing.T) {
+		peers := append(catchupPeers("full", 90, 10), catchupPeers("pruned", 80, 70)...)
+
+		rng := rand.New(rand.NewPCG(1, 2)) //nolint:gosec // deterministic draws for the test
+
+		for i := 0; i < 100; i++ {
+			ordered := weightedRandomOrder(peers, 0.1, rng.Float64)
+			require.Len(t, ordered, 4)
+
+			assert.Equal(t, "full", ordered[0].Storage)
+			assert.Equal(t, "full", ordered[1].Storage)
+			assert.Equal(t, "pruned", ordered[2].Storage)
+			assert.Equal(t, "pruned", ordered[3].Storage)
+		}
+	})
+
+	t.Run("favors the best peers while trying every peer", func(t *testing.T) {
+		peers := catchupPeers("full", 90, 60, 30)
+		first := make(map[int]int)
+
+		rng := rand.New(rand.NewPCG(3, 4)) //nolint:gosec // deterministic draws for the test
+
+		for i := 0; i < 10000; i++ {
+			ordered := weightedRandomOrder(peers, 0.1, rng.Float64)
+
+			for j, p := range peers {
+				if ordered[0] == p {
+					first[j]++
+				}
+			}
+		}
+
+		// the expected shares of the first draw are 0.9*90/180+0.1/3, 0.9*60/180+0.1/3 and 0.9*30/180+0.1/3
+		assert.InDelta(t, 0.483, float64(first[0])/10000, 0.03)
+		assert.InDelta(t, 0.333, float64(first[1])/10000, 0.03)
+		assert.InDelta(t, 0.183, float64(first[2])/10000, 0.03)
+	})
+
+	t.Run("exploration spreads peers evenly", func(t *testing.T) {
+		peers := catchupPeers("full", 100, 0)
+		first := 0
+
+		rng := rand.New(rand.NewPCG(5, 6)) //nolint:gosec // deterministic draws for the test
+
+		for i := 0; i < 10000; i++ {
+			if weightedRandomOrder(peers, 1, rng.Float64)[0] == peers[0] {
+				first++
+			}
+		}
+
+		assert.InDelta(t, 0.5, float64(first)/10000, 0.03)
+	})
+
+	t.Run("without exploration a peer without reputation comes last", func(t *testing.T) {
+		peers := catchupPeers("full", 0, 50, 40)
+
+		ordered := weightedRandomOrder(peers, 0, func() float64 { return 0.999 })
+		assert.Equal(t, peers[0], ordered[2])
+	})
+
+	t.Run("peers without reputation are drawn uniformly", func(t *testing.T) {
+		peers := catchupPeers("", 0, 0)
+
+		assert.Equal(t, peers, weightedRandomOrder(peers, 0, func() float64 { return 0 }))
+		assert.Equal(t, []*PeerInfo{peers[1], peers[0]}, weightedRandomOrder(peers, 0, func() float64 { return 0.75 }))
+	})
+}
+
+func TestOrderPeersForCatchup(t *testing.T) {
+	peers := catchupPeers("full", 90, 60, 30)
+
+	s := &Server{settings: &settings.Settings{}}
+	s.settings.P2P.CatchupPeerSelection = CatchupPeerSelectionBest
+
+	assert.Equal(t, peers, s.orderPeersForCatchup(peers), "best keeps the reputation order")
+
+	s.settings.P2P.CatchupPeerSelection = CatchupPeerSelectionWeightedRandom
+	s.settings.P2P.CatchupPeerExploration = 0.1
+
+	assert.ElementsMatch(t, peers, s.orderPeersForCatchup(peers))
+}
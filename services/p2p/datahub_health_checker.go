@@ -0,0 +1,118 @@
+package p2p
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// startDataHubHealthChecker starts the periodic health probing of the DataHub URLs of all peers in the
+// registry, so catchup never selects a peer whose DataHub is unreachable
+func (s *Server) startDataHubHealthChecker(ctx context.Context) {
+	if s.settings.P2P.DataHubHealthCheckInterval <= 0 || s.peerRegistry == nil {
+		s.logger.Infof("[startDataHubHealthChecker] DataHub health checker disabled")
+		return
+	}
+
+	initPrometheusMetrics()
+
+	interval := s.settings.P2P.DataHubHealthCheckInterval
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Infof("[startDataHubHealthChecker] stopping DataHub health checker")
+				return
+			case <-ticker.C:
+				s.runDataHubHealthChecks(ctx)
+			}
+		}
+	}()
+
+	s.logger.Infof("[startDataHubHealthChecker] started DataHub health checker with interval %v", interval)
+}
+
+// runDataHubHealthChecks probes the DataHub URL of every peer that is not banned and records the outcome in
+// the peer registry
+func (s *Server) runDataHubHealthChecks(ctx context.Context) {
+	client := &http.Client{Timeout: s.settings.P2P.DataHubHealthCheckTimeout}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(max(1, s.settings.P2P.DataHubHealthCheckConcurrency))
+
+	for _, p := range s.peerRegistry.GetAllPeers() {
+		if p.DataHubURL == "" || p.IsBanned {
+			continue
+		}
+
+		g.Go(func() error {
+			latency, err := s.probeDataHub(gCtx, client, p.DataHubURL)
+			if gCtx.Err() != nil {
+				// shutting down, the failure says nothing about the peer
+				return nil
+			}
+
+			if err != nil {
+				prometheusP2PDataHubHealthChecks.WithLabelValues("failure").Inc()
+				s.logger.Debugf("[runDataHubHealthChecks] DataHub %s of peer %s is not healthy: %v", p.DataHubURL, p.ID, err)
+			} else {
+				prometheusP2PDataHubHealthChecks.WithLabelValues("success").Inc()
+				prometheusP2PDataHubHealthCheckLatency.Observe(latency.Seconds())
+			}
+
+			if s.peerRegistry.UpdateDataHubHealth(p.ID, err == nil, latency, s.settings.P2P.DataHubHealthCheckFailureThreshold) {
+				s.logger.Warnf("[runDataHubHealthChecks] DataHub %s of peer %s is down, excluding the peer from catchup: %v", p.DataHubURL, p.ID, err)
+
+				if s.syncCoordinator != nil {
+					s.syncCoordinator.HandleDataHubDown(p.ID)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	down := 0
+
+	for _, p := range s.peerRegistry.GetAllPeers() {
+		if p.IsDataHubDown {
+			down++
+		}
+	}
+
+	prometheusP2PDataHubsDown.Set(float64(down))
+}
+
+// probeDataHub sends a HEAD request to a DataHub URL, returning the latency of the response. Any response
+// below status 500 shows the DataHub is up.
+func (s *Server) probeDataHub(ctx context.Context, client *http.Client, dataHubURL string) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, dataHubURL, nil)
+	if err != nil {
+		return 0, errors.NewInvalidArgumentError("invalid DataHub URL %s", dataHubURL, err)
+	}
+
+	start := time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, errors.NewServiceUnavailableError("DataHub %s is unreachable", dataHubURL, err)
+	}
+
+	latency := time.Since(start)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return latency, errors.NewServiceUnavailableError("DataHub %s responded with status %d", dataHubURL, resp.StatusCode)
+	}
+
+	return latency, nil
+}
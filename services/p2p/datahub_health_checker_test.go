@@ -0,0 +1,105 @@
+package p2p
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDataHubHealthChecks(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		w.WriteHeader(http.StatusNotFound) // any response below 500 shows the DataHub is up
+	}))
+	defer healthy.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachableURL := unreachable.URL
+	unreachable.Close()
+
+	tSettings := CreateTestSettings()
+	tSettings.P2P.DataHubHealthCheckTimeout = time.Second
+	tSettings.P2P.DataHubHealthCheckConcurrency = 2
+	tSettings.P2P.DataHubHealthCheckFailureThreshold = 2
+
+	s := &Server{
+		logger:       ulogger.TestLogger{},
+		settings:     tSettings,
+		peerRegistry: NewPeerRegistry(),
+	}
+
+	initPrometheusMetrics()
+
+	peers := map[string]peer.ID{
+		healthy.URL:    peer.ID("healthy"),
+		failing.URL:    peer.ID("failing"),
+		unreachableURL: peer.ID("unreachable"),
+	}
+
+	for url, id := range peers {
+		s.peerRegistry.AddPeer(id, "")
+		s.peerRegistry.UpdateDataHubURL(id, url)
+	}
+
+	s.peerRegistry.AddPeer(peer.ID("listen-only"), "")
+
+	s.runDataHubHealthChecks(context.Background())
+
+	info, _ := s.peerRegistry.GetPeer(peer.ID("failing"))
+	assert.False(t, info.IsHealthy)
+	assert.False(t, info.IsDataHubDown, "not down before the failure threshold")
+	assert.Len(t, s.peerRegistry.GetPeersForCatchup(), 3)
+
+	s.runDataHubHealthChecks(context.Background())
+
+	info, _ = s.peerRegistry.GetPeer(peer.ID("healthy"))
+	assert.True(t, info.IsHealthy)
+	assert.True(t, info.URLResponsive)
+	assert.Positive(t, info.HealthDuration)
+	assert.False(t, info.IsDataHubDown)
+
+	for _, id := range []peer.ID{"failing", "unreachable"} {
+		info, _ = s.peerRegistry.GetPeer(id)
+		assert.False(t, info.IsHealthy, id)
+		assert.False(t, info.URLResponsive, id)
+		assert.True(t, info.IsDataHubDown, id)
+		assert.Equal(t, 2, info.HealthCheckFailures, id)
+	}
+
+	catchupPeers := s.peerRegistry.GetPeersForCatchup()
+	require.Len(t, catchupPeers, 1)
+	assert.Equal(t, peer.ID("healthy"), catchupPeers[0].ID)
+	assert.Equal(t, float64(2), metricValue(t, prometheusP2PDataHubsDown))
+
+	info, _ = s.peerRegistry.GetPeer(peer.ID("listen-only"))
+	assert.True(t, info.LastURLCheck.IsZero(), "peers without a DataHub URL are not probed")
+}
+
+func TestProbeDataHub(t *testing.T) {
+	s := &Server{}
+	client := &http.Client{Timeout: time.Second}
+
+	_, err := s.probeDataHub(context.Background(), client, "://invalid")
+	require.Error(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	latency, err := s.probeDataHub(context.Background(), client, server.URL)
+	require.ErrorContains(t, err, "status 500")
+	assert.Positive(t, latency)
+}
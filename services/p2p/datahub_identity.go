@@ -0,0 +1,166 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/sync/errgroup"
+)
+
+// identityChallengeSize is the number of random bytes of the challenge sent to a DataHub
+const identityChallengeSize = 32
+
+// maxIdentityDocumentSize is the maximum size of an identity document read from a DataHub
+const maxIdentityDocumentSize = 64 * 1024
+
+// identity verification outcomes, as used in the metrics
+const (
+	identityCheckVerified    = "verified"
+	identityCheckMismatch    = "mismatch"
+	identityCheckUnavailable = "unavailable"
+)
+
+// startDataHubIdentityVerifier starts the periodic identity verification of the DataHub URLs of all peers in the
+// registry, so a peer advertising the DataHub URL of another node is never trusted with it
+func (s *Server) startDataHubIdentityVerifier(ctx context.Context) {
+	if s.settings.P2P.DataHubIdentityCheckInterval <= 0 || s.peerRegistry == nil {
+		s.logger.Infof("[startDataHubIdentityVerifier] DataHub identity verifier disabled")
+		return
+	}
+
+	initPrometheusMetrics()
+
+	interval := s.settings.P2P.DataHubIdentityCheckInterval
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Infof("[startDataHubIdentityVerifier] stopping DataHub identity verifier")
+				return
+			case <-ticker.C:
+				s.runDataHubIdentityChecks(ctx)
+			}
+		}
+	}()
+
+	s.logger.Infof("[startDataHubIdentityVerifier] started DataHub identity verifier with interval %v", interval)
+}
+
+// runDataHubIdentityChecks verifies the DataHub URL of every peer that is not banned and records the outcome in
+// the peer registry. A DataHub that can not be reached keeps its verified state, only a document that is not
+// signed by the peer for its URL revokes it.
+func (s *Server) runDataHubIdentityChecks(ctx context.Context) {
+	client := &http.Client{Timeout: s.settings.P2P.DataHubHealthCheckTimeout}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(max(1, s.settings.P2P.DataHubHealthCheckConcurrency))
+
+	for _, p := range s.peerRegistry.GetAllPeers() {
+		if p.DataHubURL == "" || p.IsBanned {
+			continue
+		}
+
+		g.Go(func() error {
+			result, err := s.verifyDataHubIdentity(gCtx, client, p.ID, p.DataHubURL)
+			if gCtx.Err() != nil {
+				// shutting down, the failure says nothing about the peer
+				return nil
+			}
+
+			prometheusP2PDataHubIdentityChecks.WithLabelValues(result).Inc()
+
+			switch result {
+			case identityCheckVerified:
+				if s.peerRegistry.SetDataHubURLVerified(p.ID, p.DataHubURL, true) {
+					s.logger.Infof("[runDataHubIdentityChecks] verified DataHub %s of peer %s", p.DataHubURL, p.ID)
+				}
+			case identityCheckMismatch:
+				s.logger.Warnf("[runDataHubIdentityChecks] DataHub %s is not served by peer %s: %v", p.DataHubURL, p.ID, err)
+				s.peerRegistry.SetDataHubURLVerified(p.ID, p.DataHubURL, false)
+			default:
+				s.logger.Debugf("[runDataHubIdentityChecks] could not verify DataHub %s of peer %s: %v", p.DataHubURL, p.ID, err)
+			}
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	verified := 0
+
+	for _, p := range s.peerRegistry.GetAllPeers() {
+		if p.IsDataHubURLVerified {
+			verified++
+		}
+	}
+
+	prometheusP2PVerifiedDataHubs.Set(float64(verified))
+}
+
+// verifyDataHubIdentity fetches the identity document from a DataHub URL for a fresh challenge and verifies it is
+// signed by the peer advertising the URL. Returns the outcome of the verification and the reason it failed.
+func (s *Server) verifyDataHubIdentity(ctx context.Context, client *http.Client, peerID peer.ID, dataHubURL string) (string, error) {
+	challengeBytes := make([]byte, identityChallengeSize)
+	if _, err := rand.Read(challengeBytes); err != nil {
+		return identityCheckUnavailable, errors.NewProcessingError("failed to generate identity challenge", err)
+	}
+
+	challenge := hex.EncodeToString(challengeBytes)
+
+	doc, err := fetchIdentityDocument(ctx, client, dataHubURL, challenge)
+	if err != nil {
+		return identityCheckUnavailable, err
+	}
+
+	if err = doc.Verify(peerID, dataHubURL, challenge); err != nil {
+		return identityCheckMismatch, err
+	}
+
+	return identityCheckVerified, nil
+}
+
+// fetchIdentityDocument requests the identity document for the challenge from the identity endpoint of a DataHub
+func fetchIdentityDocument(ctx context.Context, client *http.Client, dataHubURL, challenge string) (*IdentityDocument, error) {
+	identityURL := normalizeDataHubURL(dataHubURL) + "/identity?challenge=" + url.QueryEscape(challenge)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, identityURL, nil)
+	if err != nil {
+		return nil, errors.NewInvalidArgumentError("invalid DataHub URL %s", dataHubURL, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.NewServiceUnavailableError("DataHub %s is unreachable", dataHubURL, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.NewServiceUnavailableError("identity endpoint of DataHub %s responded with status %d", dataHubURL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxIdentityDocumentSize))
+	if err != nil {
+		return nil, errors.NewServiceUnavailableError("failed to read identity document of DataHub %s", dataHubURL, err)
+	}
+
+	var doc IdentityDocument
+	if err = json.Unmarshal(body, &doc); err != nil {
+		return nil, errors.NewServiceUnavailableError("DataHub %s served an invalid identity document", dataHubURL, err)
+	}
+
+	return &doc, nil
+}
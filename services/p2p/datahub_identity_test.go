@@ -0,0 +1,81 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDataHubIdentityChecks(t *testing.T) {
+	privKey, honestID := newTestIdentityKey(t)
+	_, impostorID := newTestIdentityKey(t)
+
+	var dataHubURL string
+
+	dataHub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/identity", r.URL.Path)
+
+		doc, err := SignIdentityDocument(privKey, dataHubURL, r.URL.Query().Get("challenge"), time.Now())
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(doc)
+	}))
+	defer dataHub.Close()
+
+	dataHubURL = dataHub.URL + "/api/v1"
+
+	oldNode := httptest.NewServer(http.NotFoundHandler())
+	defer oldNode.Close()
+
+	tSettings := CreateTestSettings()
+	tSettings.P2P.DataHubHealthCheckTimeout = time.Second
+	tSettings.P2P.DataHubHealthCheckConcurrency = 2
+
+	s := &Server{
+		logger:       ulogger.TestLogger{},
+		settings:     tSettings,
+		peerRegistry: NewPeerRegistry(),
+	}
+
+	initPrometheusMetrics()
+
+	peers := map[peer.ID]string{
+		honestID:            dataHubURL,
+		impostorID:          dataHubURL, // advertises the DataHub of the honest peer
+		peer.ID("old-node"): oldNode.URL,
+	}
+
+	for id, url := range peers {
+		s.peerRegistry.AddPeer(id, "")
+		s.peerRegistry.UpdateDataHubURL(id, url)
+	}
+
+	s.runDataHubIdentityChecks(context.Background())
+
+	info, _ := s.peerRegistry.GetPeer(honestID)
+	assert.True(t, info.IsDataHubURLVerified)
+	assert.False(t, info.DataHubURLVerifiedAt.IsZero())
+
+	info, _ = s.peerRegistry.GetPeer(impostorID)
+	assert.False(t, info.IsDataHubURLVerified, "the document is not signed by the impostor")
+
+	info, _ = s.peerRegistry.GetPeer(peer.ID("old-node"))
+	assert.False(t, info.IsDataHubURLVerified, "no identity endpoint")
+
+	// an unreachable DataHub keeps its verified state
+	dataHub.Close()
+	s.runDataHubIdentityChecks(context.Background())
+
+	info, _ = s.peerRegistry.GetPeer(honestID)
+	assert.True(t, info.IsDataHubURLVerified)
+}
@@ -0,0 +1,247 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/util"
+)
+
+// dataHubSelfTestBlockDepth is how far below the best block the self-test picks its block,
+// so the block has had time to be fully stored before we try to serve it
+const dataHubSelfTestBlockDepth = 1
+
+// DataHubSelfTestStatus is the outcome of the self-test that fetches data from this node's
+// own advertised DataHub URL through the external path
+type DataHubSelfTestStatus struct {
+	LastRun             time.Time // When the self-test last ran
+	LastSuccess         time.Time // When the self-test last succeeded
+	LastError           string    // Error from the last run, empty if it succeeded
+	ConsecutiveFailures int       // Number of consecutive failed runs
+	BlockHash           string    // Hash of the block fetched in the last run
+}
+
+// dataHubSelfTest tracks the self-test status; all operations are thread-safe
+type dataHubSelfTest struct {
+	mu     sync.RWMutex
+	status DataHubSelfTestStatus
+	ran    bool
+}
+
+func (d *dataHubSelfTest) record(blockHash string, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.ran = true
+	d.status.LastRun = time.Now()
+	d.status.BlockHash = blockHash
+
+	if err != nil {
+		d.status.LastError = err.Error()
+		d.status.ConsecutiveFailures++
+
+		return
+	}
+
+	d.status.LastError = ""
+	d.status.LastSuccess = d.status.LastRun
+	d.status.ConsecutiveFailures = 0
+}
+
+// get returns the current status and whether the self-test has run at least once
+func (d *dataHubSelfTest) get() (DataHubSelfTestStatus, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.status, d.ran
+}
+
+// dataHubSelfTestEnabled returns whether this node should self-test its advertised DataHub URL
+func (s *Server) dataHubSelfTestEnabled() bool {
+	return s.settings != nil &&
+		s.settings.P2P.DataHubSelfTestInterval > 0 &&
+		s.settings.P2P.ListenMode != settings.ListenModeListenOnly &&
+		s.AssetHTTPAddressURL != "" &&
+		s.blockchainClient != nil
+}
+
+// startDataHubSelfTest starts the periodic self-test of this node's advertised DataHub URL
+func (s *Server) startDataHubSelfTest(ctx context.Context) {
+	if !s.dataHubSelfTestEnabled() {
+		s.logger.Infof("[startDataHubSelfTest] DataHub self-test disabled")
+		return
+	}
+
+	interval := s.settings.P2P.DataHubSelfTestInterval
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Infof("[startDataHubSelfTest] stopping DataHub self-test")
+				return
+			case <-ticker.C:
+				s.runDataHubSelfTest(ctx)
+			}
+		}
+	}()
+
+	s.logger.Infof("[startDataHubSelfTest] started DataHub self-test of %s with interval %v", s.AssetHTTPAddressURL, interval)
+}
+
+// runDataHubSelfTest runs a single self-test and records the outcome
+func (s *Server) runDataHubSelfTest(ctx context.Context) {
+	blockHash, err := s.checkOwnDataHub(ctx)
+	s.dataHubSelfTest.record(blockHash, err)
+
+	if err != nil {
+		status, _ := s.dataHubSelfTest.get()
+		s.logger.Warnf("[runDataHubSelfTest] DataHub self-test of %s failed (%d consecutive): %v", s.AssetHTTPAddressURL, status.ConsecutiveFailures, err)
+
+		return
+	}
+
+	s.logger.Debugf("[runDataHubSelfTest] DataHub self-test of %s succeeded with block %s", s.AssetHTTPAddressURL, blockHash)
+}
+
+// checkOwnDataHub fetches a recent block and its first subtree from this node's advertised
+// DataHub URL and verifies that the data served matches the hashes we expect
+func (s *Server) checkOwnDataHub(ctx context.Context) (string, error) {
+	bestHeader, bestMeta, err := s.blockchainClient.GetBestBlockHeader(ctx)
+	if err != nil {
+		return "", errors.NewServiceError("failed to get best block header", err)
+	}
+
+	var expected *chainhash.Hash
+
+	if bestMeta.Height > dataHubSelfTestBlockDepth {
+		block, err := s.blockchainClient.GetBlockByHeight(ctx, bestMeta.Height-dataHubSelfTestBlockDepth)
+		if err != nil {
+			return "", errors.NewServiceError("failed to get block at height %d", bestMeta.Height-dataHubSelfTestBlockDepth, err)
+		}
+
+		expected = block.Header.Hash()
+	} else {
+		expected = bestHeader.Hash()
+	}
+
+	blockURL := fmt.Sprintf("%s/block/%s", s.AssetHTTPAddressURL, expected.String())
+
+	blockBytes, err := util.DoHTTPRequest(ctx, blockURL)
+	if err != nil {
+		return expected.String(), errors.NewServiceError("failed to fetch block from %s", blockURL, err)
+	}
+
+	block, err := model.NewBlockFromBytes(blockBytes)
+	if err != nil || block == nil {
+		return expected.String(), errors.NewProcessingError("invalid block data served by %s", blockURL, err)
+	}
+
+	if !block.Header.Hash().IsEqual(expected) {
+		return expected.String(), errors.NewProcessingError("block served by %s has hash %s", blockURL, block.Header.Hash().String())
+	}
+
+	if len(block.Subtrees) == 0 {
+		return expected.String(), nil
+	}
+
+	if err = s.checkOwnDataHubSubtree(ctx, block.Subtrees[0]); err != nil {
+		return expected.String(), err
+	}
+
+	return expected.String(), nil
+}
+
+// checkOwnDataHubSubtree fetches a subtree from this node's advertised DataHub URL and
+// verifies its root hash
+func (s *Server) checkOwnDataHubSubtree(ctx context.Context, subtreeHash *chainhash.Hash) error {
+	subtreeURL := fmt.Sprintf("%s/subtree/%s", s.AssetHTTPAddressURL, subtreeHash.String())
+
+	nodeBytes, err := util.DoHTTPRequest(ctx, subtreeURL)
+	if err != nil {
+		return errors.NewServiceError("failed to fetch subtree from %s", subtreeURL, err)
+	}
+
+	if len(nodeBytes) == 0 || len(nodeBytes)%chainhash.HashSize != 0 {
+		return errors.NewProcessingError("invalid subtree data served by %s: %d bytes", subtreeURL, len(nodeBytes))
+	}
+
+	numNodes := len(nodeBytes) / chainhash.HashSize
+
+	subtree, err := subtreepkg.NewIncompleteTreeByLeafCount(numNodes)
+	if err != nil {
+		return errors.NewProcessingError("failed to create subtree structure", err)
+	}
+
+	var nodeHash chainhash.Hash
+
+	for i := 0; i < numNodes; i++ {
+		copy(nodeHash[:], nodeBytes[i*chainhash.HashSize:(i+1)*chainhash.HashSize])
+
+		if nodeHash.Equal(subtreepkg.CoinbasePlaceholderHashValue) {
+			err = subtree.AddCoinbaseNode()
+		} else {
+			err = subtree.AddNode(nodeHash, 0, 0)
+		}
+
+		if err != nil {
+			return errors.NewProcessingError("failed to add node to subtree", err)
+		}
+	}
+
+	if !subtreeHash.Equal(*subtree.RootHash()) {
+		return errors.NewProcessingError("subtree served by %s has root hash %s", subtreeURL, subtree.RootHash().String())
+	}
+
+	return nil
+}
+
+// dataHubSelfTestHealth reports the DataHub self-test as a readiness check.
+// Readiness only fails once the configured number of consecutive runs have failed,
+// so a single transient failure does not take the node out of service.
+func (s *Server) dataHubSelfTestHealth(_ context.Context, _ bool) (int, string, error) {
+	status, ran := s.dataHubSelfTest.get()
+	if !ran {
+		return http.StatusOK, "not run yet", nil
+	}
+
+	threshold := s.settings.P2P.DataHubSelfTestFailureThreshold
+	if threshold <= 0 {
+		threshold = 1
+	}
+
+	if status.ConsecutiveFailures >= threshold {
+		return http.StatusServiceUnavailable, fmt.Sprintf("DataHub %s failed self-test %d times in a row", s.AssetHTTPAddressURL, status.ConsecutiveFailures),
+			errors.NewServiceError("%s", status.LastError)
+	}
+
+	return http.StatusOK, "OK", nil
+}
+
+// dataHubSelfTestNodeStatus returns the self-test fields for the node status message
+// ok is nil if the self-test is disabled or has not run yet
+func (s *Server) dataHubSelfTestNodeStatus() (ok *bool, lastError string) {
+	if !s.dataHubSelfTestEnabled() {
+		return nil, ""
+	}
+
+	status, ran := s.dataHubSelfTest.get()
+	if !ran {
+		return nil, ""
+	}
+
+	passed := status.LastError == ""
+
+	return &passed, status.LastError
+}
@@ -0,0 +1,154 @@
+package p2p
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	subtreepkg "github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newDataHubSelfTestFixture(t *testing.T) (*model.Block, []byte, []byte) {
+	subtree, err := subtreepkg.NewTreeByLeafCount(4)
+	require.NoError(t, err)
+	require.NoError(t, subtree.AddCoinbaseNode())
+	require.NoError(t, subtree.AddNode(chainhash.HashH([]byte("tx1")), 1, 1))
+	require.NoError(t, subtree.AddNode(chainhash.HashH([]byte("tx2")), 1, 1))
+
+	nodeBytes := make([]byte, 0, len(subtree.Nodes)*chainhash.HashSize)
+	for _, node := range subtree.Nodes {
+		nodeBytes = append(nodeBytes, node.Hash[:]...)
+	}
+
+	coinbaseTx, err := bt.NewTxFromString("01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff08044c86041b020602ffffffff0100f2052a010000004341041b0e8c2567c12536aa13357b79a073dc4444acb83c4ec7a0e2f99dd7457516c5817242da796924ca4e99947d087fedf9ce467cb9f7c6287078f801df276fdf84ac00000000")
+	require.NoError(t, err)
+
+	header := &model.BlockHeader{
+		Version:        1,
+		HashPrevBlock:  &chainhash.Hash{},
+		HashMerkleRoot: subtree.RootHash(),
+		Timestamp:      1234567890,
+		Bits:           model.NBit{0xff, 0xff, 0x00, 0x1d},
+		Nonce:          1234,
+	}
+
+	block, err := model.NewBlock(header, coinbaseTx, []*chainhash.Hash{subtree.RootHash()}, 3, 300, 99, 0)
+	require.NoError(t, err)
+
+	blockBytes, err := block.Bytes()
+	require.NoError(t, err)
+
+	return block, blockBytes, nodeBytes
+}
+
+func newDataHubSelfTestServer(t *testing.T, handler http.Handler, block *model.Block) *Server {
+	httpServer := httptest.NewServer(handler)
+	t.Cleanup(httpServer.Close)
+
+	mockBlockchain := new(blockchain.Mock)
+	mockBlockchain.On("GetBestBlockHeader", mock.Anything).Return(&model.BlockHeader{}, &model.BlockHeaderMeta{Height: 100}, nil)
+	mockBlockchain.On("GetBlockByHeight", mock.Anything, uint32(99)).Return(block, nil)
+
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.P2P.ListenMode = settings.ListenModeFull
+	tSettings.P2P.DataHubSelfTestFailureThreshold = 2
+
+	return &Server{
+		logger:              ulogger.TestLogger{},
+		settings:            tSettings,
+		blockchainClient:    mockBlockchain,
+		AssetHTTPAddressURL: httpServer.URL,
+	}
+}
+
+func TestServer_DataHubSelfTest(t *testing.T) {
+	block, blockBytes, nodeBytes := newDataHubSelfTestFixture(t)
+
+	t.Run("serving correct data passes", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/block/"+block.Header.Hash().String(), func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(blockBytes)
+		})
+		mux.HandleFunc("/subtree/"+block.Subtrees[0].String(), func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(nodeBytes)
+		})
+
+		s := newDataHubSelfTestServer(t, mux, block)
+		require.True(t, s.dataHubSelfTestEnabled())
+
+		ok, lastErr := s.dataHubSelfTestNodeStatus()
+		assert.Nil(t, ok, "no status before the first run")
+		assert.Empty(t, lastErr)
+
+		s.runDataHubSelfTest(context.Background())
+
+		status, ran := s.dataHubSelfTest.get()
+		require.True(t, ran)
+		assert.Empty(t, status.LastError)
+		assert.Equal(t, block.Header.Hash().String(), status.BlockHash)
+
+		ok, _ = s.dataHubSelfTestNodeStatus()
+		require.NotNil(t, ok)
+		assert.True(t, *ok)
+
+		code, _, err := s.dataHubSelfTestHealth(context.Background(), false)
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+	})
+
+	t.Run("corrupt subtree fails readiness after threshold", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/block/"+block.Header.Hash().String(), func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(blockBytes)
+		})
+		mux.HandleFunc("/subtree/"+block.Subtrees[0].String(), func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(nodeBytes[:2*chainhash.HashSize])
+		})
+
+		s := newDataHubSelfTestServer(t, mux, block)
+
+		s.runDataHubSelfTest(context.Background())
+
+		ok, lastErr := s.dataHubSelfTestNodeStatus()
+		require.NotNil(t, ok)
+		assert.False(t, *ok)
+		assert.Contains(t, lastErr, "root hash")
+
+		code, _, _ := s.dataHubSelfTestHealth(context.Background(), false)
+		assert.Equal(t, http.StatusOK, code, "a single failure stays under the threshold")
+
+		s.runDataHubSelfTest(context.Background())
+
+		code, _, err := s.dataHubSelfTestHealth(context.Background(), false)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Error(t, err)
+	})
+
+	t.Run("unreachable block fails", func(t *testing.T) {
+		s := newDataHubSelfTestServer(t, http.NotFoundHandler(), block)
+
+		s.runDataHubSelfTest(context.Background())
+
+		status, _ := s.dataHubSelfTest.get()
+		assert.Equal(t, 1, status.ConsecutiveFailures)
+		assert.Contains(t, status.LastError, "failed to fetch block")
+	})
+
+	t.Run("disabled in listen only mode", func(t *testing.T) {
+		s := newDataHubSelfTestServer(t, http.NotFoundHandler(), block)
+		s.settings.P2P.ListenMode = settings.ListenModeListenOnly
+
+		assert.False(t, s.dataHubSelfTestEnabled())
+	})
+}
@@ -0,0 +1,205 @@
+package p2p
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/util/kafka"
+	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// doubleSpendSubscriberBuffer is the number of double spends buffered per subscriber, double spends a subscriber
+// does not keep up with are dropped
+const doubleSpendSubscriberBuffer = 100
+
+// Sources of double spends
+const (
+	DoubleSpendSourceMempool = "mempool" // Conflicting spend of a transaction received for the mempool
+	DoubleSpendSourceBlock   = "block"   // Conflicting spend of a transaction in a subtree of a block
+)
+
+// DoubleSpendOutput is an output double spent by a transaction
+type DoubleSpendOutput struct {
+	TxHash         string // Transaction of the spent output
+	Vout           uint32 // Index of the spent output
+	SpendingTxHash string // Transaction that already spent the output
+}
+
+// DoubleSpendEvent is a double spend detected by the validator
+type DoubleSpendEvent struct {
+	TxHash      string              // Transaction double spending the outputs
+	Outputs     []DoubleSpendOutput // Outputs already spent by another transaction
+	Source      string              // DoubleSpendSourceMempool or DoubleSpendSourceBlock
+	PeerID      string              // Peer the transaction was received from, empty when unknown
+	BlockHeight uint32              // Block height the transaction was validated at
+	DetectedAt  time.Time           // When the double spend was received by the P2P service
+}
+
+// doubleSpendSubscribers fans the double spends out to the subscribed streams
+type doubleSpendSubscribers struct {
+	mu          sync.Mutex
+	subscribers map[chan DoubleSpendEvent]struct{}
+}
+
+// subscribe returns a channel receiving the double spends and the function ending the subscription
+func (d *doubleSpendSubscribers) subscribe() (<-chan DoubleSpendEvent, func()) {
+	ch := make(chan DoubleSpendEvent, doubleSpendSubscriberBuffer)
+
+	d.mu.Lock()
+	if d.subscribers == nil {
+		d.subscribers = make(map[chan DoubleSpendEvent]struct{})
+	}
+
+	d.subscribers[ch] = struct{}{}
+	d.mu.Unlock()
+
+	return ch, func() {
+		d.mu.Lock()
+		delete(d.subscribers, ch)
+		d.mu.Unlock()
+	}
+}
+
+// notify sends the double spend to all subscribers without blocking, returning the number of subscribers that did
+// not keep up and missed it
+func (d *doubleSpendSubscribers) notify(event DoubleSpendEvent) (dropped int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for ch := range d.subscribers {
+		select {
+		case ch <- event:
+		default:
+			dropped++
+		}
+	}
+
+	return dropped
+}
+
+// doubleSpendEventFromKafka converts the double spend message of the validator
+func doubleSpendEventFromKafka(m *kafkamessage.KafkaDoubleSpendTopicMessage, detectedAt time.Time) DoubleSpendEvent {
+	event := DoubleSpendEvent{
+		TxHash:      m.TxHash,
+		Outputs:     make([]DoubleSpendOutput, 0, len(m.Outputs)),
+		Source:      strings.ToLower(m.Source.String()),
+		PeerID:      m.PeerId,
+		BlockHeight: m.BlockHeight,
+		DetectedAt:  detectedAt,
+	}
+
+	for _, output := range m.Outputs {
+		event.Outputs = append(event.Outputs, DoubleSpendOutput{
+			TxHash:         output.TxHash,
+			Vout:           output.Vout,
+			SpendingTxHash: output.SpendingTxHash,
+		})
+	}
+
+	return event
+}
+
+// doubleSpendHandler handles the double spends published by the validator, recording the peer the transaction was
+// received from in the peer registry and notifying the subscribed streams
+func (s *Server) doubleSpendHandler(_ context.Context) func(msg *kafka.KafkaMessage) error {
+	return func(msg *kafka.KafkaMessage) error {
+		var m kafkamessage.KafkaDoubleSpendTopicMessage
+		if err := kafkamessage.Decode(msg.Value, &m); err != nil {
+			s.logger.Errorf("[doubleSpendHandler] error unmarshalling doubleSpendMessage: %v", err)
+			return err
+		}
+
+		event := doubleSpendEventFromKafka(&m, time.Now())
+
+		s.logger.Infof("[doubleSpendHandler] Received %s double spend of %d outputs by tx %s, peer %q", event.Source, len(event.Outputs), event.TxHash, event.PeerID)
+
+		if event.PeerID != "" {
+			// transactions of legacy peers are attributed to their address, which is not in the peer registry
+			if peerID, err := peer.Decode(event.PeerID); err == nil {
+				s.peerRegistry.RecordDoubleSpend(peerID)
+				s.peerEvents.Record(event.PeerID, PeerEventDoubleSpend, "tx="+event.TxHash+" source="+event.Source)
+			}
+		}
+
+		if dropped := s.doubleSpends.notify(event); dropped > 0 {
+			s.logger.Warnf("[doubleSpendHandler] %d double spend subscribers did not keep up, dropped double spend of tx %s", dropped, event.TxHash)
+		}
+
+		return nil
+	}
+}
+
+// SubscribeDoubleSpends streams the double spends detected by the validator to the client, until the client ends the
+// stream. Double spends the client does not keep up with are dropped.
+//
+// Parameters:
+//   - _: Empty message request (unused)
+//   - stream: Stream to send the double spends on
+//
+// Returns:
+//   - error: Any error encountered while sending a double spend
+func (s *Server) SubscribeDoubleSpends(_ *emptypb.Empty, stream p2p_api.PeerService_SubscribeDoubleSpendsServer) error {
+	ch, unsubscribe := s.doubleSpends.subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-ch:
+			if err := stream.Send(doubleSpendEventToAPI(event)); err != nil {
+				return errors.WrapGRPC(errors.NewServiceError("[SubscribeDoubleSpends] error sending double spend", err))
+			}
+		}
+	}
+}
+
+// doubleSpendEventToAPI converts a double spend to its gRPC representation
+func doubleSpendEventToAPI(event DoubleSpendEvent) *p2p_api.DoubleSpendEvent {
+	apiEvent := &p2p_api.DoubleSpendEvent{
+		TxHash:      event.TxHash,
+		Outputs:     make([]*p2p_api.DoubleSpendOutput, 0, len(event.Outputs)),
+		Source:      event.Source,
+		PeerId:      event.PeerID,
+		BlockHeight: event.BlockHeight,
+		DetectedAt:  event.DetectedAt.UnixMilli(),
+	}
+
+	for _, output := range event.Outputs {
+		apiEvent.Outputs = append(apiEvent.Outputs, &p2p_api.DoubleSpendOutput{
+			TxHash:         output.TxHash,
+			Vout:           output.Vout,
+			SpendingTxHash: output.SpendingTxHash,
+		})
+	}
+
+	return apiEvent
+}
+
+// doubleSpendEventFromAPI converts the gRPC representation of a double spend
+func doubleSpendEventFromAPI(apiEvent *p2p_api.DoubleSpendEvent) DoubleSpendEvent {
+	event := DoubleSpendEvent{
+		TxHash:      apiEvent.TxHash,
+		Outputs:     make([]DoubleSpendOutput, 0, len(apiEvent.Outputs)),
+		Source:      apiEvent.Source,
+		PeerID:      apiEvent.PeerId,
+		BlockHeight: apiEvent.BlockHeight,
+		DetectedAt:  time.UnixMilli(apiEvent.DetectedAt),
+	}
+
+	for _, output := range apiEvent.Outputs {
+		event.Outputs = append(event.Outputs, DoubleSpendOutput{
+			TxHash:         output.TxHash,
+			Vout:           output.Vout,
+			SpendingTxHash: output.SpendingTxHash,
+		})
+	}
+
+	return event
+}
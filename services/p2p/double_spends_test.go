@@ -0,0 +1,138 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/kafka"
+	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDoubleSpendSubscribers(t *testing.T) {
+	d := &doubleSpendSubscribers{}
+
+	assert.Zero(t, d.notify(DoubleSpendEvent{TxHash: "no subscribers"}))
+
+	ch1, unsubscribe1 := d.subscribe()
+	ch2, unsubscribe2 := d.subscribe()
+
+	assert.Zero(t, d.notify(DoubleSpendEvent{TxHash: "tx1"}))
+	assert.Equal(t, "tx1", (<-ch1).TxHash)
+	assert.Equal(t, "tx1", (<-ch2).TxHash)
+
+	unsubscribe2()
+
+	// the first subscriber does not read, once its buffer is full the double spends are dropped
+	for i := 0; i < doubleSpendSubscriberBuffer; i++ {
+		assert.Zero(t, d.notify(DoubleSpendEvent{TxHash: "tx2"}))
+	}
+
+	assert.Equal(t, 1, d.notify(DoubleSpendEvent{TxHash: "tx3"}))
+	assert.Empty(t, ch2)
+
+	unsubscribe1()
+	assert.Zero(t, d.notify(DoubleSpendEvent{TxHash: "tx4"}))
+}
+
+func TestDoubleSpendHandler(t *testing.T) {
+	peerID, err := peer.Decode("12D3KooWJpBNhwgvoZ15EB1JwRTRpxgM9NVaqpDtWZXfTf6CpCQd")
+	require.NoError(t, err)
+
+	newServer := func() *Server {
+		s := &Server{
+			logger:       ulogger.TestLogger{},
+			peerRegistry: NewPeerRegistry(),
+		}
+		s.peerRegistry.AddPeer(peerID, "")
+
+		return s
+	}
+
+	message := func(t *testing.T, m *kafkamessage.KafkaDoubleSpendTopicMessage) *kafka.KafkaMessage {
+		t.Helper()
+
+		value, err := proto.Marshal(m)
+		require.NoError(t, err)
+
+		return &kafka.KafkaMessage{ConsumerMessage: sarama.ConsumerMessage{Value: value}}
+	}
+
+	t.Run("records the peer and notifies the subscribers", func(t *testing.T) {
+		s := newServer()
+
+		ch, unsubscribe := s.doubleSpends.subscribe()
+		defer unsubscribe()
+
+		require.NoError(t, s.doubleSpendHandler(context.Background())(message(t, &kafkamessage.KafkaDoubleSpendTopicMessage{
+			TxHash: "tx",
+			Outputs: []*kafkamessage.KafkaDoubleSpendOutput{
+				{TxHash: "parent", Vout: 1, SpendingTxHash: "other"},
+			},
+			Source:      kafkamessage.KafkaDoubleSpendSource_BLOCK,
+			PeerId:      peerID.String(),
+			BlockHeight: 100,
+		})))
+
+		event := <-ch
+		assert.Equal(t, "tx", event.TxHash)
+		assert.Equal(t, []DoubleSpendOutput{{TxHash: "parent", Vout: 1, SpendingTxHash: "other"}}, event.Outputs)
+		assert.Equal(t, DoubleSpendSourceBlock, event.Source)
+		assert.Equal(t, peerID.String(), event.PeerID)
+		assert.Equal(t, uint32(100), event.BlockHeight)
+		assert.False(t, event.DetectedAt.IsZero())
+
+		info, ok := s.peerRegistry.GetPeer(peerID)
+		require.True(t, ok)
+		assert.Equal(t, int64(1), info.DoubleSpendCount)
+		assert.False(t, info.LastDoubleSpend.IsZero())
+		assert.Equal(t, int64(1), info.InteractionFailures)
+		assert.Zero(t, info.MaliciousCount, "a double spend is not malicious")
+	})
+
+	t.Run("unattributed double spends only notify", func(t *testing.T) {
+		s := newServer()
+
+		ch, unsubscribe := s.doubleSpends.subscribe()
+		defer unsubscribe()
+
+		for _, peerIDStr := range []string{"", "192.168.1.1:8333"} {
+			require.NoError(t, s.doubleSpendHandler(context.Background())(message(t, &kafkamessage.KafkaDoubleSpendTopicMessage{
+				TxHash: "tx",
+				PeerId: peerIDStr,
+			})))
+
+			assert.Equal(t, DoubleSpendSourceMempool, (<-ch).Source)
+		}
+
+		info, ok := s.peerRegistry.GetPeer(peerID)
+		require.True(t, ok)
+		assert.Zero(t, info.DoubleSpendCount)
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		require.Error(t, newServer().doubleSpendHandler(context.Background())(&kafka.KafkaMessage{ConsumerMessage: sarama.ConsumerMessage{Value: []byte{0xff}}}))
+	})
+}
+
+func TestDoubleSpendEventAPI(t *testing.T) {
+	event := DoubleSpendEvent{
+		TxHash: "tx",
+		Outputs: []DoubleSpendOutput{
+			{TxHash: "parent1", Vout: 0, SpendingTxHash: "other1"},
+			{TxHash: "parent2", Vout: 3, SpendingTxHash: "other2"},
+		},
+		Source:      DoubleSpendSourceMempool,
+		PeerID:      "peer",
+		BlockHeight: 42,
+		DetectedAt:  time.UnixMilli(time.Now().UnixMilli()),
+	}
+
+	assert.Equal(t, event, doubleSpendEventFromAPI(doubleSpendEventToAPI(event)))
+}
@@ -7,11 +7,18 @@ import (
 
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // RecordCatchupAttempt records that a catchup attempt was made to a peer
-func (s *Server) RecordCatchupAttempt(_ context.Context, req *p2p_api.RecordCatchupAttemptRequest) (*p2p_api.RecordCatchupAttemptResponse, error) {
+func (s *Server) RecordCatchupAttempt(ctx context.Context, req *p2p_api.RecordCatchupAttemptRequest) (*p2p_api.RecordCatchupAttemptResponse, error) {
+	_, _, deferFn := tracing.Tracer("p2p").Start(ctx, "RecordCatchupAttempt",
+		tracing.WithTag("peer_id", req.PeerId),
+	)
+	defer deferFn()
+
 	if s.peerRegistry == nil {
 		return &p2p_api.RecordCatchupAttemptResponse{Ok: false}, errors.WrapGRPC(errors.NewServiceError("peer registry not initialized"))
 	}
@@ -22,12 +29,19 @@ func (s *Server) RecordCatchupAttempt(_ context.Context, req *p2p_api.RecordCatc
 	}
 
 	s.peerRegistry.RecordCatchupAttempt(peerID)
+	prometheusP2PCatchupReports.WithLabelValues("attempt").Inc()
+	s.peerEvents.Record(req.PeerId, PeerEventCatchupAttempt, "")
 
 	return &p2p_api.RecordCatchupAttemptResponse{Ok: true}, nil
 }
 
 // RecordCatchupSuccess records a successful catchup from a peer
-func (s *Server) RecordCatchupSuccess(_ context.Context, req *p2p_api.RecordCatchupSuccessRequest) (*p2p_api.RecordCatchupSuccessResponse, error) {
+func (s *Server) RecordCatchupSuccess(ctx context.Context, req *p2p_api.RecordCatchupSuccessRequest) (*p2p_api.RecordCatchupSuccessResponse, error) {
+	_, _, deferFn := tracing.Tracer("p2p").Start(ctx, "RecordCatchupSuccess",
+		tracing.WithTag("peer_id", req.PeerId),
+	)
+	defer deferFn()
+
 	if s.peerRegistry == nil {
 		return &p2p_api.RecordCatchupSuccessResponse{Ok: false}, errors.WrapGRPC(errors.NewServiceError("peer registry not initialized"))
 	}
@@ -39,12 +53,19 @@ func (s *Server) RecordCatchupSuccess(_ context.Context, req *p2p_api.RecordCatc
 
 	duration := time.Duration(req.DurationMs) * time.Millisecond
 	s.peerRegistry.RecordCatchupSuccess(peerID, duration)
+	prometheusP2PCatchupReports.WithLabelValues("success").Inc()
+	s.peerEvents.Record(req.PeerId, PeerEventCatchupSuccess, "duration="+duration.String())
 
 	return &p2p_api.RecordCatchupSuccessResponse{Ok: true}, nil
 }
 
 // RecordCatchupFailure records a failed catchup attempt from a peer
-func (s *Server) RecordCatchupFailure(_ context.Context, req *p2p_api.RecordCatchupFailureRequest) (*p2p_api.RecordCatchupFailureResponse, error) {
+func (s *Server) RecordCatchupFailure(ctx context.Context, req *p2p_api.RecordCatchupFailureRequest) (*p2p_api.RecordCatchupFailureResponse, error) {
+	_, _, deferFn := tracing.Tracer("p2p").Start(ctx, "RecordCatchupFailure",
+		tracing.WithTag("peer_id", req.PeerId),
+	)
+	defer deferFn()
+
 	if s.peerRegistry == nil {
 		return &p2p_api.RecordCatchupFailureResponse{Ok: false}, errors.WrapGRPC(errors.NewServiceError("peer registry not initialized"))
 	}
@@ -55,12 +76,19 @@ func (s *Server) RecordCatchupFailure(_ context.Context, req *p2p_api.RecordCatc
 	}
 
 	s.peerRegistry.RecordCatchupFailure(peerID)
+	prometheusP2PCatchupReports.WithLabelValues("failure").Inc()
+	s.peerEvents.Record(req.PeerId, PeerEventCatchupFailure, "")
 
 	return &p2p_api.RecordCatchupFailureResponse{Ok: true}, nil
 }
 
 // RecordCatchupMalicious records malicious behavior detected during catchup
-func (s *Server) RecordCatchupMalicious(_ context.Context, req *p2p_api.RecordCatchupMaliciousRequest) (*p2p_api.RecordCatchupMaliciousResponse, error) {
+func (s *Server) RecordCatchupMalicious(ctx context.Context, req *p2p_api.RecordCatchupMaliciousRequest) (*p2p_api.RecordCatchupMaliciousResponse, error) {
+	_, _, deferFn := tracing.Tracer("p2p").Start(ctx, "RecordCatchupMalicious",
+		tracing.WithTag("peer_id", req.PeerId),
+	)
+	defer deferFn()
+
 	if s.peerRegistry == nil {
 		return &p2p_api.RecordCatchupMaliciousResponse{Ok: false}, errors.WrapGRPC(errors.NewServiceError("peer registry not initialized"))
 	}
@@ -71,12 +99,19 @@ func (s *Server) RecordCatchupMalicious(_ context.Context, req *p2p_api.RecordCa
 	}
 
 	s.peerRegistry.RecordCatchupMalicious(peerID)
+	prometheusP2PCatchupReports.WithLabelValues("malicious").Inc()
+	s.peerEvents.Record(req.PeerId, PeerEventCatchupMalicious, "")
 
 	return &p2p_api.RecordCatchupMaliciousResponse{Ok: true}, nil
 }
 
 // UpdateCatchupReputation updates the reputation score for a peer
-func (s *Server) UpdateCatchupReputation(_ context.Context, req *p2p_api.UpdateCatchupReputationRequest) (*p2p_api.UpdateCatchupReputationResponse, error) {
+func (s *Server) UpdateCatchupReputation(ctx context.Context, req *p2p_api.UpdateCatchupReputationRequest) (*p2p_api.UpdateCatchupReputationResponse, error) {
+	_, _, deferFn := tracing.Tracer("p2p").Start(ctx, "UpdateCatchupReputation",
+		tracing.WithTag("peer_id", req.PeerId),
+	)
+	defer deferFn()
+
 	if s.peerRegistry == nil {
 		return &p2p_api.UpdateCatchupReputationResponse{Ok: false}, errors.WrapGRPC(errors.NewServiceError("peer registry not initialized"))
 	}
@@ -92,7 +127,12 @@ func (s *Server) UpdateCatchupReputation(_ context.Context, req *p2p_api.UpdateC
 }
 
 // UpdateCatchupError updates the last catchup error for a peer
-func (s *Server) UpdateCatchupError(_ context.Context, req *p2p_api.UpdateCatchupErrorRequest) (*p2p_api.UpdateCatchupErrorResponse, error) {
+func (s *Server) UpdateCatchupError(ctx context.Context, req *p2p_api.UpdateCatchupErrorRequest) (*p2p_api.UpdateCatchupErrorResponse, error) {
+	_, _, deferFn := tracing.Tracer("p2p").Start(ctx, "UpdateCatchupError",
+		tracing.WithTag("peer_id", req.PeerId),
+	)
+	defer deferFn()
+
 	if s.peerRegistry == nil {
 		return &p2p_api.UpdateCatchupErrorResponse{Ok: false}, errors.WrapGRPC(errors.NewServiceError("peer registry not initialized"))
 	}
@@ -108,12 +148,16 @@ func (s *Server) UpdateCatchupError(_ context.Context, req *p2p_api.UpdateCatchu
 }
 
 // GetPeersForCatchup returns peers suitable for catchup operations
-func (s *Server) GetPeersForCatchup(_ context.Context, _ *p2p_api.GetPeersForCatchupRequest) (*p2p_api.GetPeersForCatchupResponse, error) {
+func (s *Server) GetPeersForCatchup(ctx context.Context, _ *p2p_api.GetPeersForCatchupRequest) (*p2p_api.GetPeersForCatchupResponse, error) {
+	_, span, deferFn := tracing.Tracer("p2p").Start(ctx, "GetPeersForCatchup")
+	defer deferFn()
+
 	if s.peerRegistry == nil {
 		return &p2p_api.GetPeersForCatchupResponse{Peers: []*p2p_api.PeerInfoForCatchup{}}, errors.WrapGRPC(errors.NewServiceError("peer registry not initialized"))
 	}
 
-	peers := s.peerRegistry.GetPeersForCatchup()
+	peers := s.orderPeersForCatchup(s.peerRegistry.GetPeersForCatchup())
+	span.SetAttributes(attribute.Int("peers", len(peers)))
 
 	// Convert to proto format
 	protoPeers := make([]*p2p_api.PeerInfoForCatchup, 0, len(peers))
@@ -127,6 +171,7 @@ func (s *Server) GetPeersForCatchup(_ context.Context, _ *p2p_api.GetPeersForCat
 			Height:                 p.Height,
 			BlockHash:              p.BlockHash,
 			DataHubUrl:             p.DataHubURL,
+			DataHubMirrorUrls:      p.DataHubMirrorURLs,
 			CatchupReputationScore: p.ReputationScore,
 			CatchupAttempts:        totalAttempts,          // Use calculated total, not InteractionAttempts
 			CatchupSuccesses:       p.InteractionSuccesses, // Number of successful interactions
@@ -223,6 +268,92 @@ func (s *Server) ReportValidBlock(_ context.Context, req *p2p_api.ReportValidBlo
 	}, nil
 }
 
+// RecordInvalidSubtree is a gRPC handler for reporting invalid subtree reception, the negative counterpart of
+// ReportValidSubtree
+func (s *Server) RecordInvalidSubtree(_ context.Context, req *p2p_api.RecordInvalidSubtreeRequest) (*p2p_api.RecordInvalidSubtreeResponse, error) {
+	if s.peerRegistry == nil {
+		return &p2p_api.RecordInvalidSubtreeResponse{
+			Success: false,
+			Message: "peer registry not initialized",
+		}, errors.WrapGRPC(errors.NewServiceError("peer registry not initialized"))
+	}
+
+	if req.PeerId == "" {
+		return &p2p_api.RecordInvalidSubtreeResponse{
+			Success: false,
+			Message: "peer ID is required",
+		}, errors.WrapGRPC(errors.NewInvalidArgumentError("peer ID is required"))
+	}
+
+	if req.SubtreeHash == "" {
+		return &p2p_api.RecordInvalidSubtreeResponse{
+			Success: false,
+			Message: "subtree hash is required",
+		}, errors.WrapGRPC(errors.NewInvalidArgumentError("subtree hash is required"))
+	}
+
+	peerID, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return &p2p_api.RecordInvalidSubtreeResponse{
+			Success: false,
+			Message: "invalid peer ID",
+		}, errors.WrapGRPC(errors.NewProcessingError("invalid peer ID: %v", err))
+	}
+
+	reason := invalidDataReasonFromAPI(req.Reason)
+
+	s.recordInvalidData(peerID, invalidDataSubtree, req.SubtreeHash, reason, req.Details)
+	s.logger.Debugf("[RecordInvalidSubtree] Recorded %s subtree %s from peer %s: %s", reason, req.SubtreeHash, req.PeerId, req.Details)
+
+	return &p2p_api.RecordInvalidSubtreeResponse{
+		Success: true,
+		Message: "invalid subtree recorded",
+	}, nil
+}
+
+// RecordInvalidBlock is a gRPC handler for reporting invalid block reception, the negative counterpart of
+// ReportValidBlock
+func (s *Server) RecordInvalidBlock(_ context.Context, req *p2p_api.RecordInvalidBlockRequest) (*p2p_api.RecordInvalidBlockResponse, error) {
+	if s.peerRegistry == nil {
+		return &p2p_api.RecordInvalidBlockResponse{
+			Success: false,
+			Message: "peer registry not initialized",
+		}, errors.WrapGRPC(errors.NewServiceError("peer registry not initialized"))
+	}
+
+	if req.PeerId == "" {
+		return &p2p_api.RecordInvalidBlockResponse{
+			Success: false,
+			Message: "peer ID is required",
+		}, errors.WrapGRPC(errors.NewInvalidArgumentError("peer ID is required"))
+	}
+
+	if req.BlockHash == "" {
+		return &p2p_api.RecordInvalidBlockResponse{
+			Success: false,
+			Message: "block hash is required",
+		}, errors.WrapGRPC(errors.NewInvalidArgumentError("block hash is required"))
+	}
+
+	peerID, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return &p2p_api.RecordInvalidBlockResponse{
+			Success: false,
+			Message: "invalid peer ID",
+		}, errors.WrapGRPC(errors.NewProcessingError("invalid peer ID: %v", err))
+	}
+
+	reason := invalidDataReasonFromAPI(req.Reason)
+
+	s.recordInvalidData(peerID, invalidDataBlock, req.BlockHash, reason, req.Details)
+	s.logger.Debugf("[RecordInvalidBlock] Recorded %s block %s from peer %s: %s", reason, req.BlockHash, req.PeerId, req.Details)
+
+	return &p2p_api.RecordInvalidBlockResponse{
+		Success: true,
+		Message: "invalid block recorded",
+	}, nil
+}
+
 // IsPeerMalicious checks if a peer is considered malicious based on their behavior
 func (s *Server) IsPeerMalicious(_ context.Context, req *p2p_api.IsPeerMaliciousRequest) (*p2p_api.IsPeerMaliciousResponse, error) {
 	if req.PeerId == "" {
@@ -0,0 +1,142 @@
+package p2p
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// identityDocumentDomain prefixes the signed payload of an identity document, so the signature can not be used
+// as a signature of any other message signed with the peer key
+const identityDocumentDomain = "teranode-datahub-identity"
+
+// maxIdentityChallengeLength is the maximum length of the challenge a node signs in its identity document
+const maxIdentityChallengeLength = 128
+
+// IdentityDocument is the proof that a DataHub URL is served by the node owning a peer ID. It is signed with the
+// peer key over a challenge chosen by the verifying node, so a peer can not copy the document of another node
+// to claim its DataHub URL.
+type IdentityDocument struct {
+	PeerID     string `json:"peer_id"`
+	DataHubURL string `json:"datahub_url"`
+	Challenge  string `json:"challenge"`
+	Timestamp  int64  `json:"timestamp"` // Unix milliseconds the document was signed at
+	Signature  []byte `json:"signature"`
+}
+
+// SignIdentityDocument returns the identity document of the node owning the private key for the DataHub URL,
+// signed over the challenge
+func SignIdentityDocument(privKey crypto.PrivKey, dataHubURL, challenge string, now time.Time) (*IdentityDocument, error) {
+	if err := validateIdentityChallenge(challenge); err != nil {
+		return nil, err
+	}
+
+	peerID, err := peer.IDFromPrivateKey(privKey)
+	if err != nil {
+		return nil, errors.NewProcessingError("failed to derive peer ID from private key", err)
+	}
+
+	doc := &IdentityDocument{
+		PeerID:     peerID.String(),
+		DataHubURL: dataHubURL,
+		Challenge:  challenge,
+		Timestamp:  now.UnixMilli(),
+	}
+
+	doc.Signature, err = privKey.Sign(doc.signingPayload())
+	if err != nil {
+		return nil, errors.NewProcessingError("failed to sign identity document", err)
+	}
+
+	return doc, nil
+}
+
+// Verify checks that the document was signed by the key of the peer, for the DataHub URL the peer advertised
+// and over the challenge sent to it
+func (d *IdentityDocument) Verify(peerID peer.ID, dataHubURL, challenge string) error {
+	if d.PeerID != peerID.String() {
+		return errors.NewProcessingError("identity document is issued by peer %s, not by %s", d.PeerID, peerID)
+	}
+
+	if d.Challenge != challenge {
+		return errors.NewProcessingError("identity document of peer %s does not answer the challenge", peerID)
+	}
+
+	if normalizeDataHubURL(d.DataHubURL) != normalizeDataHubURL(dataHubURL) {
+		return errors.NewProcessingError("identity document of peer %s is issued for DataHub %s, not for %s", peerID, d.DataHubURL, dataHubURL)
+	}
+
+	pubKey, err := peerID.ExtractPublicKey()
+	if err != nil {
+		return errors.NewProcessingError("failed to extract public key of peer %s", peerID, err)
+	}
+
+	ok, err := pubKey.Verify(d.signingPayload(), d.Signature)
+	if err != nil {
+		return errors.NewProcessingError("failed to verify identity document of peer %s", peerID, err)
+	}
+
+	if !ok {
+		return errors.NewProcessingError("identity document of peer %s has an invalid signature", peerID)
+	}
+
+	return nil
+}
+
+// signingPayload returns the bytes the signature of the document covers
+func (d *IdentityDocument) signingPayload() []byte {
+	return []byte(strings.Join([]string{
+		identityDocumentDomain,
+		d.PeerID,
+		d.DataHubURL,
+		d.Challenge,
+		strconv.FormatInt(d.Timestamp, 10),
+	}, "\n"))
+}
+
+// SignIdentity returns the identity document of our DataHub URL, signed with our peer key over the challenge of
+// the request. It is served by the asset service to nodes verifying the DataHub URL we advertise.
+func (s *Server) SignIdentity(_ context.Context, req *p2p_api.SignIdentityRequest) (*p2p_api.SignIdentityResponse, error) {
+	if s.privKey == nil || s.AssetHTTPAddressURL == "" || s.settings.P2P.ListenMode == settings.ListenModeListenOnly {
+		return nil, errors.WrapGRPC(errors.NewServiceError("no DataHub URL is advertised by this node"))
+	}
+
+	doc, err := SignIdentityDocument(s.privKey, s.AssetHTTPAddressURL, req.Challenge, time.Now())
+	if err != nil {
+		return nil, errors.WrapGRPC(err)
+	}
+
+	return &p2p_api.SignIdentityResponse{
+		PeerId:     doc.PeerID,
+		DatahubUrl: doc.DataHubURL,
+		Challenge:  doc.Challenge,
+		Timestamp:  doc.Timestamp,
+		Signature:  doc.Signature,
+	}, nil
+}
+
+// validateIdentityChallenge checks the challenge a node is asked to sign its identity document over
+func validateIdentityChallenge(challenge string) error {
+	if challenge == "" {
+		return errors.NewInvalidArgumentError("identity challenge is required")
+	}
+
+	if len(challenge) > maxIdentityChallengeLength {
+		return errors.NewInvalidArgumentError("identity challenge exceeds %d characters", maxIdentityChallengeLength)
+	}
+
+	return nil
+}
+
+// normalizeDataHubURL returns the DataHub URL without trailing slashes, as the same DataHub may be advertised
+// with and without them
+func normalizeDataHubURL(dataHubURL string) string {
+	return strings.TrimRight(dataHubURL, "/")
+}
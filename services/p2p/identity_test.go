@@ -0,0 +1,120 @@
+package p2p
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIdentityKey(t *testing.T) (crypto.PrivKey, peer.ID) {
+	t.Helper()
+
+	privKey, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+
+	peerID, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+
+	return privKey, peerID
+}
+
+func TestIdentityDocument(t *testing.T) {
+	privKey, peerID := newTestIdentityKey(t)
+	_, otherPeerID := newTestIdentityKey(t)
+
+	const dataHubURL = "https://node.example.com/api/v1"
+
+	sign := func(t *testing.T) *IdentityDocument {
+		doc, err := SignIdentityDocument(privKey, dataHubURL, "challenge", time.UnixMilli(1_700_000_000_000))
+		require.NoError(t, err)
+
+		return doc
+	}
+
+	t.Run("signed document verifies", func(t *testing.T) {
+		doc := sign(t)
+
+		assert.Equal(t, peerID.String(), doc.PeerID)
+		assert.Equal(t, int64(1_700_000_000_000), doc.Timestamp)
+		require.NoError(t, doc.Verify(peerID, dataHubURL, "challenge"))
+		require.NoError(t, doc.Verify(peerID, dataHubURL+"/", "challenge"), "trailing slash is the same DataHub")
+	})
+
+	t.Run("other peer", func(t *testing.T) {
+		require.Error(t, sign(t).Verify(otherPeerID, dataHubURL, "challenge"))
+	})
+
+	t.Run("other challenge", func(t *testing.T) {
+		require.Error(t, sign(t).Verify(peerID, dataHubURL, "other"))
+	})
+
+	t.Run("other DataHub URL", func(t *testing.T) {
+		require.Error(t, sign(t).Verify(peerID, "https://other.example.com/api/v1", "challenge"))
+	})
+
+	t.Run("tampered document", func(t *testing.T) {
+		doc := sign(t)
+		doc.Timestamp++
+		require.Error(t, doc.Verify(peerID, dataHubURL, "challenge"))
+
+		doc = sign(t)
+		doc.Signature[0] ^= 0xff
+		require.Error(t, doc.Verify(peerID, dataHubURL, "challenge"))
+	})
+
+	t.Run("document re-issued for another peer", func(t *testing.T) {
+		doc := sign(t)
+		doc.PeerID = otherPeerID.String()
+		require.Error(t, doc.Verify(otherPeerID, dataHubURL, "challenge"))
+	})
+
+	t.Run("invalid challenge", func(t *testing.T) {
+		_, err := SignIdentityDocument(privKey, dataHubURL, "", time.Now())
+		require.Error(t, err)
+
+		_, err = SignIdentityDocument(privKey, dataHubURL, strings.Repeat("a", maxIdentityChallengeLength+1), time.Now())
+		require.Error(t, err)
+	})
+}
+
+func TestServer_SignIdentity(t *testing.T) {
+	privKey, peerID := newTestIdentityKey(t)
+
+	tSettings := CreateTestSettings()
+
+	s := &Server{
+		logger:              ulogger.TestLogger{},
+		settings:            tSettings,
+		privKey:             privKey,
+		AssetHTTPAddressURL: "https://node.example.com/api/v1",
+	}
+
+	resp, err := s.SignIdentity(context.Background(), &p2p_api.SignIdentityRequest{Challenge: "challenge"})
+	require.NoError(t, err)
+
+	doc := &IdentityDocument{
+		PeerID:     resp.PeerId,
+		DataHubURL: resp.DatahubUrl,
+		Challenge:  resp.Challenge,
+		Timestamp:  resp.Timestamp,
+		Signature:  resp.Signature,
+	}
+	require.NoError(t, doc.Verify(peerID, s.AssetHTTPAddressURL, "challenge"))
+
+	_, err = s.SignIdentity(context.Background(), &p2p_api.SignIdentityRequest{})
+	require.Error(t, err)
+
+	tSettings.P2P.ListenMode = settings.ListenModeListenOnly
+
+	_, err = s.SignIdentity(context.Background(), &p2p_api.SignIdentityRequest{Challenge: "challenge"})
+	require.Error(t, err, "a listen only node advertises no DataHub URL")
+}
@@ -0,0 +1,89 @@
+package p2p
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// InvalidDataReason is the reason a block or subtree received from a peer was rejected
+type InvalidDataReason string
+
+// Reasons a block or subtree received from a peer was rejected
+const (
+	InvalidDataReasonInvalid     InvalidDataReason = "invalid"     // The data failed validation
+	InvalidDataReasonMalformed   InvalidDataReason = "malformed"   // The data could not be parsed or did not match its hash
+	InvalidDataReasonUnavailable InvalidDataReason = "unavailable" // The data was announced but the peer could not provide it
+)
+
+// Kinds of data reported invalid, used in the peer events and metrics
+const (
+	invalidDataBlock   = "block"
+	invalidDataSubtree = "subtree"
+)
+
+// IsMalicious returns whether providing data rejected for this reason is treated as malicious. A peer that could not
+// provide data it announced may only be overloaded, so only invalid and malformed data marks the peer malicious.
+func (r InvalidDataReason) IsMalicious() bool {
+	return r != InvalidDataReasonUnavailable
+}
+
+// invalidDataReasonFromAPI converts the reason of a gRPC request, treating an unspecified reason as invalid data
+func invalidDataReasonFromAPI(reason p2p_api.InvalidDataReason) InvalidDataReason {
+	switch reason {
+	case p2p_api.InvalidDataReason_INVALID_DATA_REASON_MALFORMED:
+		return InvalidDataReasonMalformed
+	case p2p_api.InvalidDataReason_INVALID_DATA_REASON_UNAVAILABLE:
+		return InvalidDataReasonUnavailable
+	default:
+		return InvalidDataReasonInvalid
+	}
+}
+
+// invalidDataReasonToAPI converts the reason to its gRPC representation
+func invalidDataReasonToAPI(reason InvalidDataReason) p2p_api.InvalidDataReason {
+	switch reason {
+	case InvalidDataReasonInvalid:
+		return p2p_api.InvalidDataReason_INVALID_DATA_REASON_INVALID
+	case InvalidDataReasonMalformed:
+		return p2p_api.InvalidDataReason_INVALID_DATA_REASON_MALFORMED
+	case InvalidDataReasonUnavailable:
+		return p2p_api.InvalidDataReason_INVALID_DATA_REASON_UNAVAILABLE
+	default:
+		return p2p_api.InvalidDataReason_INVALID_DATA_REASON_UNSPECIFIED
+	}
+}
+
+// invalidDataReasonFromKafka maps the reason of an invalid subtree or block Kafka message, e.g.
+// "peer_cannot_provide_subtree" or "contains_invalid_transaction", to the reason recorded in the peer registry
+func invalidDataReasonFromKafka(reason string) InvalidDataReason {
+	if strings.HasPrefix(reason, "peer_cannot_provide") {
+		return InvalidDataReasonUnavailable
+	}
+
+	return InvalidDataReasonInvalid
+}
+
+// recordInvalidData records an invalid block or subtree received from the peer in the peer registry, the peer
+// event log and the metrics
+func (s *Server) recordInvalidData(peerID peer.ID, kind string, hash string, reason InvalidDataReason, details string) {
+	initPrometheusMetrics()
+
+	switch kind {
+	case invalidDataBlock:
+		s.peerRegistry.RecordInvalidBlock(peerID, reason)
+	default:
+		s.peerRegistry.RecordInvalidSubtree(peerID, reason)
+	}
+
+	prometheusP2PInvalidDataReports.WithLabelValues(kind, string(reason)).Inc()
+
+	eventDetails := fmt.Sprintf("%s=%s reason=%s", kind, hash, reason)
+	if details != "" {
+		eventDetails += ": " + details
+	}
+
+	s.peerEvents.Record(peerID.String(), PeerEventInvalidData, eventDetails)
+}
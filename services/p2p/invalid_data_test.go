@@ -0,0 +1,216 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerRegistry_RecordInvalidData(t *testing.T) {
+	t.Run("invalid subtree marks the peer malicious", func(t *testing.T) {
+		pr := NewPeerRegistry()
+		peerID := newTestPeerID(t)
+		pr.AddPeer(peerID, "")
+
+		pr.RecordSubtreeReceived(peerID, time.Millisecond)
+		pr.RecordInvalidSubtree(peerID, InvalidDataReasonInvalid)
+
+		info, found := pr.GetPeer(peerID)
+		require.True(t, found)
+		assert.Equal(t, int64(1), info.SubtreesReceived)
+		assert.Equal(t, int64(1), info.InvalidSubtreesReceived)
+		assert.Zero(t, info.InvalidBlocksReceived)
+		assert.Equal(t, int64(1), info.InteractionFailures)
+		assert.Equal(t, int64(1), info.MaliciousCount)
+		assert.Equal(t, InvalidDataReasonInvalid, info.LastInvalidDataReason)
+		assert.False(t, info.LastInvalidData.IsZero())
+		assert.InDelta(t, 5.0, info.ReputationScore, 0)
+	})
+
+	t.Run("unavailable block lowers the reputation", func(t *testing.T) {
+		pr := NewPeerRegistry()
+		peerID := newTestPeerID(t)
+		pr.AddPeer(peerID, "")
+
+		for i := 0; i < 5; i++ {
+			pr.RecordBlockReceived(peerID, time.Millisecond)
+		}
+
+		before, _ := pr.GetPeer(peerID)
+
+		pr.RecordInvalidBlock(peerID, InvalidDataReasonUnavailable)
+
+		info, found := pr.GetPeer(peerID)
+		require.True(t, found)
+		assert.Equal(t, int64(1), info.InvalidBlocksReceived)
+		assert.Equal(t, int64(1), info.InteractionFailures)
+		assert.Zero(t, info.MaliciousCount)
+		assert.Equal(t, InvalidDataReasonUnavailable, info.LastInvalidDataReason)
+		assert.Less(t, info.ReputationScore, before.ReputationScore)
+		assert.Greater(t, info.ReputationScore, 5.0)
+	})
+
+	t.Run("unknown peer", func(t *testing.T) {
+		pr := NewPeerRegistry()
+		peerID := newTestPeerID(t)
+
+		pr.RecordInvalidBlock(peerID, InvalidDataReasonMalformed)
+
+		_, found := pr.GetPeer(peerID)
+		assert.False(t, found)
+	})
+}
+
+func TestInvalidDataReasons(t *testing.T) {
+	assert.True(t, InvalidDataReasonInvalid.IsMalicious())
+	assert.True(t, InvalidDataReasonMalformed.IsMalicious())
+	assert.False(t, InvalidDataReasonUnavailable.IsMalicious())
+
+	for _, reason := range []InvalidDataReason{InvalidDataReasonInvalid, InvalidDataReasonMalformed, InvalidDataReasonUnavailable} {
+		assert.Equal(t, reason, invalidDataReasonFromAPI(invalidDataReasonToAPI(reason)))
+	}
+
+	// an unspecified reason is treated as invalid data
+	assert.Equal(t, InvalidDataReasonInvalid, invalidDataReasonFromAPI(p2p_api.InvalidDataReason_INVALID_DATA_REASON_UNSPECIFIED))
+
+	assert.Equal(t, InvalidDataReasonUnavailable, invalidDataReasonFromKafka("peer_cannot_provide_subtree"))
+	assert.Equal(t, InvalidDataReasonUnavailable, invalidDataReasonFromKafka("peer_cannot_provide_transactions"))
+	assert.Equal(t, InvalidDataReasonInvalid, invalidDataReasonFromKafka("contains_invalid_transaction"))
+	assert.Equal(t, InvalidDataReasonInvalid, invalidDataReasonFromKafka(""))
+}
+
+func TestRecordInvalidSubtreeAndBlock(t *testing.T) {
+	ctx := context.Background()
+	peerID := newTestPeerID(t)
+
+	newServer := func(t *testing.T) *Server {
+		events, err := NewPeerEventLog(10, "", 0)
+		require.NoError(t, err)
+
+		s := &Server{
+			logger:       ulogger.TestLogger{},
+			peerRegistry: NewPeerRegistry(),
+			peerEvents:   events,
+		}
+
+		s.peerRegistry.AddPeer(peerID, "")
+
+		return s
+	}
+
+	t.Run("invalid subtree", func(t *testing.T) {
+		s := newServer(t)
+
+		resp, err := s.RecordInvalidSubtree(ctx, &p2p_api.RecordInvalidSubtreeRequest{
+			PeerId:      peerID.String(),
+			SubtreeHash: "subtree1",
+			Reason:      p2p_api.InvalidDataReason_INVALID_DATA_REASON_INVALID,
+			Details:     "contains invalid transaction",
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Success)
+
+		info, found := s.peerRegistry.GetPeer(peerID)
+		require.True(t, found)
+		assert.Equal(t, int64(1), info.InvalidSubtreesReceived)
+		assert.Equal(t, int64(1), info.MaliciousCount)
+
+		events := s.peerEvents.Query(time.Time{}, time.Time{}, peerID.String(), 0)
+		require.NotEmpty(t, events)
+
+		var details []string
+
+		for _, event := range events {
+			if event.Type == PeerEventInvalidData {
+				details = append(details, event.Details)
+			}
+		}
+
+		assert.Equal(t, []string{"subtree=subtree1 reason=invalid: contains invalid transaction"}, details)
+
+		// the negative counters are exposed in the peer registry
+		registry, err := s.GetPeerRegistry(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, registry.Peers, 1)
+		assert.Equal(t, int64(1), registry.Peers[0].InvalidSubtreesReceived)
+		assert.Equal(t, string(InvalidDataReasonInvalid), registry.Peers[0].LastInvalidDataReason)
+		assert.Equal(t, info.LastInvalidData.Unix(), registry.Peers[0].LastInvalidData)
+	})
+
+	t.Run("unavailable block", func(t *testing.T) {
+		s := newServer(t)
+
+		resp, err := s.RecordInvalidBlock(ctx, &p2p_api.RecordInvalidBlockRequest{
+			PeerId:    peerID.String(),
+			BlockHash: "block1",
+			Reason:    p2p_api.InvalidDataReason_INVALID_DATA_REASON_UNAVAILABLE,
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Success)
+
+		info, found := s.peerRegistry.GetPeer(peerID)
+		require.True(t, found)
+		assert.Equal(t, int64(1), info.InvalidBlocksReceived)
+		assert.Zero(t, info.MaliciousCount)
+		assert.Equal(t, InvalidDataReasonUnavailable, info.LastInvalidDataReason)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		s := newServer(t)
+
+		_, err := s.RecordInvalidSubtree(ctx, &p2p_api.RecordInvalidSubtreeRequest{SubtreeHash: "subtree1"})
+		require.Error(t, err)
+
+		_, err = s.RecordInvalidSubtree(ctx, &p2p_api.RecordInvalidSubtreeRequest{PeerId: peerID.String()})
+		require.Error(t, err)
+
+		resp, err := s.RecordInvalidBlock(ctx, &p2p_api.RecordInvalidBlockRequest{PeerId: "not-a-peer-id", BlockHash: "block1"})
+		require.Error(t, err)
+		assert.False(t, resp.Success)
+
+		_, err = (&Server{logger: ulogger.TestLogger{}}).RecordInvalidBlock(ctx, &p2p_api.RecordInvalidBlockRequest{PeerId: peerID.String(), BlockHash: "block1"})
+		require.Error(t, err)
+
+		info, _ := s.peerRegistry.GetPeer(peerID)
+		assert.Zero(t, info.InvalidSubtreesReceived)
+		assert.Zero(t, info.InvalidBlocksReceived)
+	})
+}
+
+func TestReportInvalidSubtree_RecordsInvalidData(t *testing.T) {
+	ctx := context.Background()
+	peerID := newTestPeerID(t)
+
+	tSettings := &settings.Settings{}
+	tSettings.P2P.BanThreshold = 100
+	tSettings.P2P.BanDuration = time.Hour
+
+	s := &Server{
+		logger:       ulogger.TestLogger{},
+		peerRegistry: NewPeerRegistry(),
+	}
+	s.banManager = NewPeerBanManager(ctx, &myBanEventHandler{server: s}, tSettings, nil)
+	s.peerRegistry.AddPeer(peerID, "")
+
+	s.subtreePeerMap.Store("subtree1", peerMapEntry{peerID: peerID.String(), timestamp: time.Now()})
+	require.NoError(t, s.ReportInvalidSubtree(ctx, "subtree1", "", "peer_cannot_provide_subtree"))
+
+	info, found := s.peerRegistry.GetPeer(peerID)
+	require.True(t, found)
+	assert.Equal(t, int64(1), info.InvalidSubtreesReceived)
+	assert.Equal(t, InvalidDataReasonUnavailable, info.LastInvalidDataReason)
+	assert.Zero(t, info.MaliciousCount)
+
+	s.subtreePeerMap.Store("subtree2", peerMapEntry{peerID: peerID.String(), timestamp: time.Now()})
+	require.NoError(t, s.ReportInvalidSubtree(ctx, "subtree2", "", "contains_invalid_transaction"))
+
+	info, _ = s.peerRegistry.GetPeer(peerID)
+	assert.Equal(t, int64(2), info.InvalidSubtreesReceived)
+	assert.Equal(t, int64(1), info.MaliciousCount)
+}
@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/jobs"
+)
+
+// maintenanceScheduler is the name of the scheduler of the maintenance jobs of the P2P service
+const maintenanceScheduler = "p2p"
+
+// Names of the maintenance jobs of the P2P service
+const (
+	jobRegistryCacheSave = "registry_cache_save"
+	jobBanSweep          = "ban_sweep"
+	jobRegistryMetrics   = "registry_metrics"
+	jobPeerGC            = "peer_gc"
+)
+
+// startMaintenanceJobs starts the recurring maintenance of the P2P service on a scheduler persisting the job state
+// in the blockchain store: saving the peer registry cache, removing expired bans, exporting the peer registry
+// metrics and removing stale peers from the peer registry. The jobs are listed by the /debug/jobs endpoint.
+func (s *Server) startMaintenanceJobs(ctx context.Context) error {
+	var store jobs.StateStore
+	if s.blockchainClient != nil {
+		store = s.blockchainClient
+	}
+
+	scheduler, err := jobs.NewScheduler(maintenanceScheduler, s.logger, store, s.settings.MaintenanceJobJitter)
+	if err != nil {
+		return err
+	}
+
+	// a failed save, e.g. when the disk is full, is retried sooner while the registry keeps serving from memory,
+	// and the caches are saved a final time before shutdown
+	if err = scheduler.Register(jobs.Job{
+		Name:          jobRegistryCacheSave,
+		Interval:      peerRegistryCacheSaveInterval,
+		RetryInterval: peerRegistryCacheRetryInterval,
+		RunOnStop:     true,
+		Run: func(context.Context) error {
+			if !s.savePeerRegistryCaches() {
+				return errors.NewProcessingError("failed to save the peer registry caches")
+			}
+
+			return nil
+		},
+	}); err != nil {
+		return err
+	}
+
+	if interval := s.settings.P2P.BanSweepInterval; interval > 0 {
+		if err = scheduler.Register(jobs.Job{Name: jobBanSweep, Interval: interval, Run: s.sweepExpiredBans}); err != nil {
+			return err
+		}
+	} else {
+		s.logger.Infof("[startMaintenanceJobs] ban sweep disabled, expired bans are removed when checked")
+	}
+
+	if interval := s.settings.P2P.RegistryMetricsInterval; interval > 0 {
+		if err = scheduler.Register(jobs.Job{
+			Name:     jobRegistryMetrics,
+			Interval: interval,
+			Run: func(context.Context) error {
+				s.updateRegistryMetrics()
+				return nil
+			},
+		}); err != nil {
+			return err
+		}
+	} else {
+		s.logger.Infof("[startMaintenanceJobs] peer registry metrics disabled")
+	}
+
+	if interval := s.settings.P2P.PeerGCInterval; interval > 0 && s.settings.P2P.PeerGCMaxAge > 0 {
+		if err = scheduler.Register(jobs.Job{
+			Name:     jobPeerGC,
+			Interval: interval,
+			Run: func(context.Context) error {
+				s.collectStalePeers()
+				return nil
+			},
+		}); err != nil {
+			return err
+		}
+	} else {
+		s.logger.Infof("[startMaintenanceJobs] peer registry garbage collection disabled")
+	}
+
+	scheduler.Start(ctx)
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/jobs"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// maintenanceJobNames returns the names of the running maintenance jobs of the P2P service
+func maintenanceJobNames() []string {
+	var names []string
+
+	for _, status := range jobs.Statuses() {
+		if status.Scheduler == maintenanceScheduler {
+			names = append(names, status.Name)
+		}
+	}
+
+	return names
+}
+
+func TestStartMaintenanceJobs(t *testing.T) {
+	t.Run("all jobs", func(t *testing.T) {
+		s := &Server{
+			settings: &settings.Settings{P2P: settings.P2PSettings{
+				PeerCacheDir:            t.TempDir(),
+				BanSweepInterval:        time.Minute,
+				RegistryMetricsInterval: 15 * time.Second,
+			}},
+			logger:       ulogger.TestLogger{},
+			peerRegistry: NewPeerRegistry(),
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		require.NoError(t, s.startMaintenanceJobs(ctx))
+		assert.Equal(t, []string{jobBanSweep, jobRegistryCacheSave, jobRegistryMetrics}, maintenanceJobNames())
+
+		cancel()
+		require.Eventually(t, func() bool { return len(maintenanceJobNames()) == 0 }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("disabled jobs and save on shutdown", func(t *testing.T) {
+		cacheDir := t.TempDir()
+
+		peerID, err := peer.Decode(testPeer1)
+		require.NoError(t, err)
+
+		registry := NewPeerRegistry()
+		registry.AddPeer(peerID, "")
+		registry.RecordInteractionAttempt(peerID)
+
+		s := &Server{
+			settings:     &settings.Settings{P2P: settings.P2PSettings{PeerCacheDir: cacheDir}},
+			logger:       ulogger.TestLogger{},
+			peerRegistry: registry,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		require.NoError(t, s.startMaintenanceJobs(ctx))
+		assert.Equal(t, []string{jobRegistryCacheSave}, maintenanceJobNames())
+
+		cancel()
+		require.Eventually(t, func() bool { return len(maintenanceJobNames()) == 0 }, time.Second, 5*time.Millisecond)
+
+		// the cache was saved before the jobs stopped
+		loaded := NewPeerRegistry()
+		require.NoError(t, loaded.LoadPeerRegistryCache(cacheDir))
+		assert.Equal(t, 1, loaded.PeerCount())
+	})
+
+	t.Run("invalid jitter", func(t *testing.T) {
+		s := &Server{
+			settings: &settings.Settings{MaintenanceJobJitter: 2},
+			logger:   ulogger.TestLogger{},
+		}
+
+		assert.Error(t, s.startMaintenanceJobs(context.Background()))
+	})
+}
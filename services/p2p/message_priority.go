@@ -0,0 +1,194 @@
+package p2p
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// messagePriority is the priority tier of a received topic message, derived from the reputation of the
+// peer that relayed it
+type messagePriority int
+
+const (
+	priorityHigh messagePriority = iota
+	priorityNormal
+	priorityLow
+	priorityTiers // number of priority tiers
+)
+
+func (p messagePriority) String() string {
+	switch p {
+	case priorityHigh:
+		return "high"
+	case priorityNormal:
+		return "normal"
+	case priorityLow:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
+
+// queuedMessage is a topic message waiting in a priority queue
+type queuedMessage struct {
+	data []byte
+	from string
+}
+
+// priorityQueue buffers the messages of a topic in a bounded queue per priority tier. The messages of a
+// higher tier are always processed before those of a lower tier, so when the handler can not keep up with
+// the announcements, those of high-reputation peers are processed first. A message whose tier queue is
+// full is dropped, the reader of the topic never blocks.
+type priorityQueue struct {
+	topic  string // metric label of the topic
+	tiers  [priorityTiers]chan queuedMessage
+	closed chan struct{}
+}
+
+// newPriorityQueue creates a priority queue holding at most size messages per tier
+func newPriorityQueue(topic string, size int) *priorityQueue {
+	initPrometheusMetrics()
+
+	q := &priorityQueue{
+		topic:  topic,
+		closed: make(chan struct{}),
+	}
+
+	for i := range q.tiers {
+		q.tiers[i] = make(chan queuedMessage, size)
+	}
+
+	return q
+}
+
+// push queues a message in its tier, returning false when the message was dropped because the tier is full
+func (q *priorityQueue) push(msg queuedMessage, priority messagePriority) bool {
+	select {
+	case q.tiers[priority] <- msg:
+		q.updateDepth(priority)
+		return true
+	default:
+		prometheusP2PPriorityQueueDropped.WithLabelValues(q.topic, priority.String()).Inc()
+		return false
+	}
+}
+
+// close signals that no more messages are pushed, pop returns the messages still queued before reporting
+// the queue as done
+func (q *priorityQueue) close() {
+	close(q.closed)
+}
+
+// pop returns the first message of the highest non-empty tier, waiting for a message when all tiers are
+// empty. It returns false once the queue is closed and empty.
+func (q *priorityQueue) pop() (queuedMessage, bool) {
+	if msg, priority, ok := q.tryPop(); ok {
+		q.updateDepth(priority)
+		return msg, true
+	}
+
+	// all tiers are empty, the first message to arrive is processed regardless of its tier
+	select {
+	case msg := <-q.tiers[priorityHigh]:
+		q.updateDepth(priorityHigh)
+		return msg, true
+	case msg := <-q.tiers[priorityNormal]:
+		q.updateDepth(priorityNormal)
+		return msg, true
+	case msg := <-q.tiers[priorityLow]:
+		q.updateDepth(priorityLow)
+		return msg, true
+	case <-q.closed:
+		if msg, priority, ok := q.tryPop(); ok {
+			q.updateDepth(priority)
+			return msg, true
+		}
+
+		return queuedMessage{}, false
+	}
+}
+
+// tryPop returns the first message of the highest non-empty tier without waiting
+func (q *priorityQueue) tryPop() (queuedMessage, messagePriority, bool) {
+	for priority := priorityHigh; priority < priorityTiers; priority++ {
+		select {
+		case msg := <-q.tiers[priority]:
+			return msg, priority, true
+		default:
+		}
+	}
+
+	return queuedMessage{}, 0, false
+}
+
+func (q *priorityQueue) updateDepth(priority messagePriority) {
+	prometheusP2PPriorityQueueDepth.WithLabelValues(q.topic, priority.String()).Set(float64(len(q.tiers[priority])))
+}
+
+// messagePriority returns the priority tier of the messages relayed by a peer, based on its reputation.
+// Peers that are not in the registry are normal priority.
+func (s *Server) messagePriority(from string) messagePriority {
+	if s.peerRegistry == nil {
+		return priorityNormal
+	}
+
+	peerID, err := peer.Decode(from)
+	if err != nil {
+		return priorityNormal
+	}
+
+	info, exists := s.peerRegistry.GetPeer(peerID)
+	if !exists {
+		return priorityNormal
+	}
+
+	switch {
+	case info.ReputationScore >= s.settings.P2P.PriorityHighReputation:
+		return priorityHigh
+	case info.ReputationScore < s.settings.P2P.PriorityLowReputation:
+		return priorityLow
+	default:
+		return priorityNormal
+	}
+}
+
+// subscribeToPrioritizedTopic subscribes to a topic like subscribeToTopic, but queues the received messages
+// by the reputation of the relaying peer, processing those of high-reputation peers first when the handler
+// falls behind. Without a priority queue size the messages are processed in arrival order.
+func (s *Server) subscribeToPrioritizedTopic(ctx context.Context, topicName string, label string, handler func(context.Context, []byte, string)) {
+	if s.settings.P2P.PriorityQueueSize <= 0 {
+		s.subscribeToTopic(ctx, topicName, handler)
+		return
+	}
+
+	topicChannel := s.P2PClient.Subscribe(topicName)
+	queue := newPriorityQueue(label, s.settings.P2P.PriorityQueueSize)
+
+	go func() {
+		// as in subscribeToTopic, the subscription ends when the topic channel closes, not on ctx.Done()
+		for msg := range topicChannel {
+			prometheusP2PGossipMessages.WithLabelValues(topicName, gossipReceived).Inc()
+			s.messageRecorder.Record(topicName, msg.FromID, msg.Data)
+
+			priority := s.messagePriority(msg.FromID)
+			if !queue.push(queuedMessage{data: msg.Data, from: msg.FromID}, priority) {
+				s.logger.Debugf("[subscribeToPrioritizedTopic] %s queue for %s priority messages is full, dropped message from %s", topicName, priority, msg.FromID)
+			}
+		}
+
+		queue.close()
+		s.logger.Warnf("%s topic channel closed", topicName)
+	}()
+
+	go func() {
+		for {
+			msg, ok := queue.pop()
+			if !ok {
+				return
+			}
+
+			handler(ctx, msg.data, msg.from)
+		}
+	}()
+}
@@ -0,0 +1,189 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	p2pMessageBus "github.com/bsv-blockchain/go-p2p-message-bus"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metricValue returns the current value of a gauge or counter
+func metricValue(t *testing.T, metric prometheus.Metric) float64 {
+	t.Helper()
+
+	var m dto.Metric
+	require.NoError(t, metric.Write(&m))
+
+	if m.Gauge != nil {
+		return m.Gauge.GetValue()
+	}
+
+	return m.Counter.GetValue()
+}
+
+func TestPriorityQueue(t *testing.T) {
+	t.Run("higher tiers are processed first", func(t *testing.T) {
+		q := newPriorityQueue("test-order", 10)
+
+		require.True(t, q.push(queuedMessage{from: "low1"}, priorityLow))
+		require.True(t, q.push(queuedMessage{from: "normal1"}, priorityNormal))
+		require.True(t, q.push(queuedMessage{from: "high1"}, priorityHigh))
+		require.True(t, q.push(queuedMessage{from: "low2"}, priorityLow))
+		require.True(t, q.push(queuedMessage{from: "high2"}, priorityHigh))
+
+		assert.Equal(t, float64(2), metricValue(t, prometheusP2PPriorityQueueDepth.WithLabelValues("test-order", "high")))
+
+		q.close()
+
+		var order []string
+
+		for {
+			msg, ok := q.pop()
+			if !ok {
+				break
+			}
+
+			order = append(order, msg.from)
+		}
+
+		assert.Equal(t, []string{"high1", "high2", "normal1", "low1", "low2"}, order)
+		assert.Equal(t, float64(0), metricValue(t, prometheusP2PPriorityQueueDepth.WithLabelValues("test-order", "low")))
+	})
+
+	t.Run("full tier drops its messages only", func(t *testing.T) {
+		q := newPriorityQueue("test-drop", 2)
+
+		require.True(t, q.push(queuedMessage{from: "low1"}, priorityLow))
+		require.True(t, q.push(queuedMessage{from: "low2"}, priorityLow))
+		assert.False(t, q.push(queuedMessage{from: "low3"}, priorityLow))
+		assert.True(t, q.push(queuedMessage{from: "high1"}, priorityHigh))
+
+		assert.Equal(t, float64(1), metricValue(t, prometheusP2PPriorityQueueDropped.WithLabelValues("test-drop", "low")))
+		assert.Equal(t, float64(0), metricValue(t, prometheusP2PPriorityQueueDropped.WithLabelValues("test-drop", "high")))
+	})
+
+	t.Run("pop waits for a message", func(t *testing.T) {
+		q := newPriorityQueue("test-wait", 2)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			q.push(queuedMessage{from: "normal1"}, priorityNormal)
+		}()
+
+		msg, ok := q.pop()
+		require.True(t, ok)
+		assert.Equal(t, "normal1", msg.from)
+
+		q.close()
+
+		_, ok = q.pop()
+		assert.False(t, ok)
+	})
+}
+
+func TestServerMessagePriority(t *testing.T) {
+	high, err := peer.Decode("12D3KooWKd2kacFFXWtbYtkDAsTP8fhEX1TbunV9Afimr7m1E8Yg")
+	require.NoError(t, err)
+
+	low, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	tSettings := CreateTestSettings()
+	tSettings.P2P.PriorityHighReputation = 75
+	tSettings.P2P.PriorityLowReputation = 40
+
+	s := &Server{settings: tSettings, peerRegistry: NewPeerRegistry()}
+
+	s.peerRegistry.AddPeer(high, "")
+	s.peerRegistry.UpdateReputation(high, 90)
+	s.peerRegistry.AddPeer(low, "")
+	s.peerRegistry.UpdateReputation(low, 10)
+
+	assert.Equal(t, priorityHigh, s.messagePriority(high.String()))
+	assert.Equal(t, priorityLow, s.messagePriority(low.String()))
+	assert.Equal(t, priorityNormal, s.messagePriority("12D3KooWRvkNXHMFoT6bqfE3EnMyBpPmhsRNyNB7qrjczkmmv6dK"), "unknown peer")
+	assert.Equal(t, priorityNormal, s.messagePriority("not-a-peer-id"))
+}
+
+func TestSubscribeToPrioritizedTopic(t *testing.T) {
+	high, err := peer.Decode("12D3KooWKd2kacFFXWtbYtkDAsTP8fhEX1TbunV9Afimr7m1E8Yg")
+	require.NoError(t, err)
+
+	low, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	tSettings := CreateTestSettings()
+	tSettings.P2P.PriorityQueueSize = 10
+	tSettings.P2P.PriorityHighReputation = 75
+	tSettings.P2P.PriorityLowReputation = 40
+
+	topicChannel := make(chan p2pMessageBus.Message, 10)
+	client := &MockServerP2PClient{peerID: high}
+	client.On("Subscribe", "block").Return((<-chan p2pMessageBus.Message)(topicChannel))
+
+	s := &Server{
+		P2PClient:    client,
+		logger:       ulogger.TestLogger{},
+		settings:     tSettings,
+		peerRegistry: NewPeerRegistry(),
+	}
+
+	s.peerRegistry.AddPeer(high, "")
+	s.peerRegistry.UpdateReputation(high, 90)
+	s.peerRegistry.AddPeer(low, "")
+	s.peerRegistry.UpdateReputation(low, 10)
+
+	var (
+		mu        sync.Mutex
+		processed []string
+		first     = make(chan struct{})
+		release   = make(chan struct{})
+	)
+
+	s.subscribeToPrioritizedTopic(context.Background(), "block", "block", func(_ context.Context, data []byte, _ string) {
+		mu.Lock()
+		processed = append(processed, string(data))
+		mu.Unlock()
+
+		if string(data) == "first" {
+			close(first)
+			<-release
+		}
+	})
+
+	// block the handler on the first message, so the following messages queue up
+	topicChannel <- p2pMessageBus.Message{FromID: low.String(), Data: []byte("first")}
+	<-first
+
+	topicChannel <- p2pMessageBus.Message{FromID: low.String(), Data: []byte("low")}
+	topicChannel <- p2pMessageBus.Message{FromID: high.String(), Data: []byte("high")}
+
+	require.Eventually(t, func() bool {
+		return len(topicChannel) == 0 &&
+			metricValue(t, prometheusP2PPriorityQueueDepth.WithLabelValues("block", "high")) == 1 &&
+			metricValue(t, prometheusP2PPriorityQueueDepth.WithLabelValues("block", "low")) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	close(release)
+	close(topicChannel)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(processed) == 3
+	}, time.Second, 5*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, []string{"first", "high", "low"}, processed)
+}
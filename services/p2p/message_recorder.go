@@ -0,0 +1,231 @@
+package p2p
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// maxRecordedMessageSize is the largest record line read back from a message recording
+const maxRecordedMessageSize = 16 * 1024 * 1024
+
+// RecordedMessage is a topic message received from the network, as written by the MessageRecorder
+type RecordedMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Topic     string    `json:"topic"`
+	From      string    `json:"from"` // peer ID of the peer that relayed the message to us
+	Data      []byte    `json:"data"`
+}
+
+// MessageRecorder appends the topic messages received from the network to a rolling file, so incidents
+// caused by bad announcements can be reproduced offline with a MessageReplayer. When the file exceeds the
+// maximum size it is rotated to <file>.1, shifting older files up to <file>.<maxFiles-1>, the oldest file
+// is removed. A nil MessageRecorder discards all messages.
+type MessageRecorder struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	maxFiles int
+	file     *os.File
+	size     int64
+	now      func() time.Time
+}
+
+// NewMessageRecorder creates a recorder appending to the file at path. The file is rotated once it exceeds
+// maxBytes, keeping at most maxFiles files including the current one. A maxBytes of 0 or lower disables
+// rotation.
+func NewMessageRecorder(path string, maxBytes int64, maxFiles int) (*MessageRecorder, error) {
+	if path == "" {
+		return nil, errors.NewConfigurationError("message recorder file is required")
+	}
+
+	if maxFiles < 1 {
+		maxFiles = 1
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, errors.NewStorageError("failed to create message recorder directory for %s", path, err)
+	}
+
+	r := &MessageRecorder{
+		path:     path,
+		maxBytes: maxBytes,
+		maxFiles: maxFiles,
+		now:      time.Now,
+	}
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Record appends a received topic message to the recording. Recording is best effort, a message that can
+// not be written is dropped without affecting its processing.
+func (r *MessageRecorder) Record(topic string, from string, data []byte) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return
+	}
+
+	line, err := json.Marshal(RecordedMessage{
+		Timestamp: r.now(),
+		Topic:     topic,
+		From:      from,
+		Data:      data,
+	})
+	if err != nil {
+		return
+	}
+
+	line = append(line, '\n')
+
+	if r.maxBytes > 0 && r.size > 0 && r.size+int64(len(line)) > r.maxBytes {
+		if err = r.rotate(); err != nil {
+			return
+		}
+	}
+
+	// written unbuffered, so the messages leading up to a crash are part of the recording
+	n, _ := r.file.Write(line)
+	r.size += int64(n)
+}
+
+// Files returns the files of the recording, oldest first
+func (r *MessageRecorder) Files() []string {
+	if r == nil {
+		return nil
+	}
+
+	return RecordedMessageFiles(r.path, r.maxFiles)
+}
+
+// Close closes the recording file
+func (r *MessageRecorder) Close() error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+
+	if err != nil {
+		return errors.NewStorageError("failed to close message recorder file", err)
+	}
+
+	return nil
+}
+
+// open opens the current recording file for appending, must be called with the lock held
+func (r *MessageRecorder) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.NewStorageError("failed to open message recorder file %s", r.path, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return errors.NewStorageError("failed to stat message recorder file %s", r.path, err)
+	}
+
+	r.file = f
+	r.size = info.Size()
+
+	return nil
+}
+
+// rotate shifts the recording files and starts a new current file, must be called with the lock held
+func (r *MessageRecorder) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return errors.NewStorageError("failed to close message recorder file %s", r.path, err)
+	}
+
+	r.file = nil
+
+	if r.maxFiles == 1 {
+		if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+			return errors.NewStorageError("failed to remove message recorder file %s", r.path, err)
+		}
+
+		return r.open()
+	}
+
+	for i := r.maxFiles - 1; i >= 1; i-- {
+		from := rotatedFileName(r.path, i-1)
+		if err := os.Rename(from, rotatedFileName(r.path, i)); err != nil && !os.IsNotExist(err) {
+			return errors.NewStorageError("failed to rotate message recorder file %s", from, err)
+		}
+	}
+
+	return r.open()
+}
+
+// rotatedFileName returns the name of the nth rotated file of a recording, 0 is the current file
+func rotatedFileName(path string, n int) string {
+	if n == 0 {
+		return path
+	}
+
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+// RecordedMessageFiles returns the existing files of the recording at path, oldest first, looking at up to
+// maxFiles files including the current one
+func RecordedMessageFiles(path string, maxFiles int) []string {
+	files := make([]string, 0, maxFiles)
+
+	for i := maxFiles - 1; i >= 0; i-- {
+		name := rotatedFileName(path, i)
+		if _, err := os.Stat(name); err == nil {
+			files = append(files, name)
+		}
+	}
+
+	return files
+}
+
+// ReadRecordedMessages calls fn for every message of a recording, in the order they were received, and
+// stops at the first error returned by fn. A partially written last line, left by a crash, is skipped.
+func ReadRecordedMessages(r io.Reader, fn func(message RecordedMessage) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordedMessageSize)
+
+	for scanner.Scan() {
+		var message RecordedMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			continue
+		}
+
+		if err := fn(message); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return errors.NewProcessingError("failed to read recorded messages", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package p2p
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readRecording(t *testing.T, file string) []RecordedMessage {
+	t.Helper()
+
+	f, err := os.Open(file)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	var messages []RecordedMessage
+
+	require.NoError(t, ReadRecordedMessages(f, func(message RecordedMessage) error {
+		messages = append(messages, message)
+		return nil
+	}))
+
+	return messages
+}
+
+func TestMessageRecorder_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recordings", "p2p_messages.ndjson")
+
+	r, err := NewMessageRecorder(path, 0, 3)
+	require.NoError(t, err)
+
+	now := time.UnixMilli(1_700_000_000_000).UTC()
+	r.now = func() time.Time { return now }
+
+	r.Record("mainnet-block", "peer1", []byte(`{"Hash":"00ab"}`))
+	r.Record("mainnet-subtree", "peer2", []byte{0x00, 0xff})
+	require.NoError(t, r.Close())
+
+	// closed recorders drop messages
+	r.Record("mainnet-block", "peer1", []byte(`{}`))
+
+	messages := readRecording(t, path)
+	require.Len(t, messages, 2)
+	assert.Equal(t, RecordedMessage{Timestamp: now, Topic: "mainnet-block", From: "peer1", Data: []byte(`{"Hash":"00ab"}`)}, messages[0])
+	assert.Equal(t, []byte{0x00, 0xff}, messages[1].Data)
+
+	// a recorder appends to an existing recording
+	r, err = NewMessageRecorder(path, 0, 3)
+	require.NoError(t, err)
+	r.Record("mainnet-block", "peer3", nil)
+	require.NoError(t, r.Close())
+
+	assert.Len(t, readRecording(t, path), 3)
+}
+
+func TestMessageRecorder_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "p2p_messages.ndjson")
+
+	r, err := NewMessageRecorder(path, 200, 3)
+	require.NoError(t, err)
+
+	defer r.Close()
+
+	payload := []byte(strings.Repeat("x", 50))
+
+	for i := 0; i < 20; i++ {
+		r.Record("mainnet-block", "peer1", payload)
+	}
+
+	files := r.Files()
+	require.Equal(t, []string{path + ".2", path + ".1", path}, files)
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "only maxFiles files are kept")
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(200))
+		assert.NotEmpty(t, readRecording(t, file))
+	}
+}
+
+func TestReadRecordedMessages_PartialLine(t *testing.T) {
+	recording := `{"timestamp":"2024-01-01T00:00:00Z","topic":"mainnet-block","from":"peer1","data":"e30="}
+{"timestamp":"2024-01-01T00:00:01Z","topic":"mainnet-bl`
+
+	var messages []RecordedMessage
+
+	require.NoError(t, ReadRecordedMessages(strings.NewReader(recording), func(message RecordedMessage) error {
+		messages = append(messages, message)
+		return nil
+	}))
+
+	require.Len(t, messages, 1)
+	assert.Equal(t, []byte("{}"), messages[0].Data)
+}
+
+func TestMessageRecorder_Nil(t *testing.T) {
+	var r *MessageRecorder
+
+	r.Record("mainnet-block", "peer1", nil)
+	assert.Empty(t, r.Files())
+	assert.NoError(t, r.Close())
+
+	_, err := NewMessageRecorder("", 0, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package p2p
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// topicHandler is the handler of the messages received on a topic
+type topicHandler func(ctx context.Context, data []byte, from string)
+
+// MessageReplayer feeds recorded topic messages back through the topic handlers of a server, so incidents
+// recorded by a MessageRecorder can be reproduced offline, e.g. in a test harness with mocked clients.
+type MessageReplayer struct {
+	handlers map[string]topicHandler
+	speed    float64
+	sleep    func(ctx context.Context, d time.Duration) error
+}
+
+// ReplayResult summarizes a replay
+type ReplayResult struct {
+	Replayed int // messages passed to a topic handler
+	Skipped  int // messages of topics without a handler
+}
+
+// NewMessageReplayer creates a replayer dispatching the recorded messages to the topic handlers of s.
+// With a speed of 0 or lower the messages are replayed as fast as possible, otherwise the recorded time
+// between messages is kept, divided by speed, e.g. 2 replays twice as fast as recorded.
+func (s *Server) NewMessageReplayer(speed float64) *MessageReplayer {
+	return &MessageReplayer{
+		handlers: map[string]topicHandler{
+			s.blockTopicName:      s.handleBlockTopic,
+			s.subtreeTopicName:    s.handleSubtreeTopic,
+			s.nodeStatusTopicName: s.handleNodeStatusTopic,
+			s.rejectedTxTopicName: s.handleRejectedTxTopic,
+		},
+		speed: speed,
+		sleep: sleepContext,
+	}
+}
+
+// Replay passes the recorded messages of the files, read in order, to the topic handlers. A handler that
+// panics stops the replay with an error identifying the message, so the message causing a crash can be
+// isolated.
+func (r *MessageReplayer) Replay(ctx context.Context, files ...string) (ReplayResult, error) {
+	var (
+		result   ReplayResult
+		previous time.Time
+	)
+
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return result, errors.NewStorageError("failed to open message recording %s", file, err)
+		}
+
+		err = ReadRecordedMessages(f, func(message RecordedMessage) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			handler, ok := r.handlers[message.Topic]
+			if !ok {
+				result.Skipped++
+				return nil
+			}
+
+			if r.speed > 0 && !previous.IsZero() && message.Timestamp.After(previous) {
+				if err := r.sleep(ctx, time.Duration(float64(message.Timestamp.Sub(previous))/r.speed)); err != nil {
+					return err
+				}
+			}
+
+			previous = message.Timestamp
+
+			if err := replayMessage(ctx, handler, message); err != nil {
+				return errors.NewProcessingError("replay of message %d of %s failed", result.Replayed+result.Skipped+1, file, err)
+			}
+
+			result.Replayed++
+
+			return nil
+		})
+
+		_ = f.Close()
+
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// replayMessage passes a single message to its handler, turning a panic of the handler into an error
+func replayMessage(ctx context.Context, handler topicHandler, message RecordedMessage) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errors.NewProcessingError("handler of topic %s panicked on message from %s received at %s: %v",
+				message.Topic, message.From, message.Timestamp.Format(time.RFC3339Nano), recovered)
+		}
+	}()
+
+	handler(ctx, message.Data, message.From)
+
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newReplayHarness creates a server with mocked network and ban manager whose topic handlers accept
+// replayed messages of the topics of the given prefix, e.g. mainnet
+func newReplayHarness(t *testing.T, topicPrefix string) *Server {
+	t.Helper()
+
+	selfPeerID, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	banManager := new(MockPeerBanManager)
+	banManager.On("IsBanned", mock.Anything).Return(false)
+
+	return &Server{
+		P2PClient:           &MockServerP2PClient{peerID: selfPeerID},
+		logger:              ulogger.TestLogger{},
+		settings:            CreateTestSettings(),
+		banManager:          banManager,
+		peerRegistry:        NewPeerRegistry(),
+		notificationCh:      make(chan *notificationMsg, 1_000_000),
+		gCtx:                context.Background(),
+		blockTopicName:      topicPrefix + "-block",
+		subtreeTopicName:    topicPrefix + "-subtree",
+		nodeStatusTopicName: topicPrefix + "-node_status",
+		rejectedTxTopicName: topicPrefix + "-rejected_tx",
+	}
+}
+
+func writeRecording(t *testing.T, messages ...RecordedMessage) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "p2p_messages.ndjson")
+
+	r, err := NewMessageRecorder(path, 0, 1)
+	require.NoError(t, err)
+
+	for _, message := range messages {
+		r.now = func() time.Time { return message.Timestamp }
+		r.Record(message.Topic, message.From, message.Data)
+	}
+
+	require.NoError(t, r.Close())
+
+	return path
+}
+
+func TestMessageReplayer_Replay(t *testing.T) {
+	senderPeerID := "12D3KooWEyX7hgdXy8zUjCs9CqvMGpB5dKVFj9MX2nUBLwajdSZH"
+	originatorPeerID := "12D3KooWQYVQJfrw4RZnNHgRxGFLXoXswE5wuoUBgWpeJYeGDjvA"
+	start := time.UnixMilli(1_700_000_000_000)
+
+	blockMessage := func(height int) []byte {
+		return []byte(fmt.Sprintf(`{"Hash":"000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f","Height":%d,"DataHubURL":"http://example.com","PeerID":"%s"}`, height, originatorPeerID))
+	}
+
+	path := writeRecording(t,
+		RecordedMessage{Timestamp: start, Topic: "mainnet-block", From: senderPeerID, Data: blockMessage(1)},
+		RecordedMessage{Timestamp: start.Add(time.Second), Topic: "testnet-block", From: senderPeerID, Data: blockMessage(2)},
+		RecordedMessage{Timestamp: start.Add(3 * time.Second), Topic: "mainnet-block", From: senderPeerID, Data: blockMessage(3)},
+	)
+
+	t.Run("feeds the messages through the handlers", func(t *testing.T) {
+		s := newReplayHarness(t, "mainnet")
+
+		var slept []time.Duration
+
+		replayer := s.NewMessageReplayer(2)
+		replayer.sleep = func(_ context.Context, d time.Duration) error {
+			slept = append(slept, d)
+			return nil
+		}
+
+		result, err := replayer.Replay(context.Background(), path)
+		require.NoError(t, err)
+		assert.Equal(t, ReplayResult{Replayed: 2, Skipped: 1}, result)
+
+		// the recorded gap of 3 seconds is replayed twice as fast
+		assert.Equal(t, []time.Duration{1500 * time.Millisecond}, slept)
+
+		require.Len(t, s.notificationCh, 2)
+		assert.Equal(t, uint32(1), (<-s.notificationCh).Height)
+		assert.Equal(t, uint32(3), (<-s.notificationCh).Height)
+
+		originator, err := peer.Decode(originatorPeerID)
+		require.NoError(t, err)
+
+		info, exists := s.peerRegistry.GetPeer(originator)
+		require.True(t, exists)
+		assert.Equal(t, int32(3), info.Height)
+	})
+
+	t.Run("reports the message a handler panics on", func(t *testing.T) {
+		s := newReplayHarness(t, "mainnet")
+
+		replayer := s.NewMessageReplayer(0)
+		replayer.handlers["mainnet-block"] = func(_ context.Context, data []byte, _ string) {
+			if string(data) == string(blockMessage(3)) {
+				panic("bad announcement")
+			}
+		}
+
+		result, err := replayer.Replay(context.Background(), path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "message 3")
+		assert.Contains(t, err.Error(), "bad announcement")
+		assert.Equal(t, 1, result.Replayed)
+	})
+
+	t.Run("missing recording", func(t *testing.T) {
+		_, err := newReplayHarness(t, "mainnet").NewMessageReplayer(0).Replay(context.Background(), filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+}
+
+// TestReplayRecordedMessages replays a recording made by a node with p2p_message_record_file set through the
+// topic handlers, to reproduce an incident offline:
+//
+//	P2P_REPLAY_FILE=/path/to/p2p_messages.ndjson P2P_REPLAY_TOPIC_PREFIX=mainnet go test -v -run TestReplayRecordedMessages ./services/p2p/
+//
+// Rotated files of the recording are replayed first, oldest first.
+func TestReplayRecordedMessages(t *testing.T) {
+	path := os.Getenv("P2P_REPLAY_FILE")
+	if path == "" {
+		t.Skip("P2P_REPLAY_FILE not set")
+	}
+
+	topicPrefix := os.Getenv("P2P_REPLAY_TOPIC_PREFIX")
+	if topicPrefix == "" {
+		topicPrefix = "mainnet"
+	}
+
+	s := newReplayHarness(t, topicPrefix)
+	s.logger = ulogger.New("p2p-replay", ulogger.WithLevel("DEBUG"))
+
+	files := RecordedMessageFiles(path, 100)
+	require.NotEmpty(t, files, "no recording found at %s", path)
+
+	result, err := s.NewMessageReplayer(0).Replay(context.Background(), files...)
+	t.Logf("replayed %d messages, skipped %d messages of other topics", result.Replayed, result.Skipped)
+	require.NoError(t, err)
+}
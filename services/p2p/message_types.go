@@ -6,6 +6,7 @@ type NodeStatusMessage struct {
 	ClientName          string   `json:"client_name"` // Name of this node client
 	Type                string   `json:"type"`
 	BaseURL             string   `json:"base_url"`
+	DataHubMirrorURLs   []string `json:"datahub_mirror_urls,omitempty"` // Mirrors of the DataHub at BaseURL, tried in order when BaseURL fails
 	Version             string   `json:"version"`
 	CommitHash          string   `json:"commit_hash"`
 	BestBlockHash       string   `json:"best_block_hash"`
@@ -25,6 +26,10 @@ type NodeStatusMessage struct {
 	MinMiningTxFee      *float64 `json:"min_mining_tx_fee,omitempty"`     // Minimum mining transaction fee configured for this node (nil = unknown, 0 = no fee)
 	ConnectedPeersCount int      `json:"connected_peers_count,omitempty"` // Number of connected peers
 	Storage             string   `json:"storage,omitempty"`               // Storage mode: "full" (block persister running and caught up), "pruned" (no persister or lagging), or empty (old version)
+	DataHubSelfTestOK   *bool    `json:"datahub_self_test_ok,omitempty"`  // Whether the last self-test of our own DataHub URL passed (nil = disabled or not run yet)
+	DataHubSelfTestErr  string   `json:"datahub_self_test_err,omitempty"` // Error from the last failed DataHub self-test
+	ProtocolVersion     uint32   `json:"protocol_version,omitempty"`      // Highest P2P wire protocol version supported (0 = old version, baseline only)
+	MinProtocolVersion  uint32   `json:"min_protocol_version,omitempty"`  // Lowest P2P wire protocol version supported
 }
 
 // BlockMessage announces the availability of a new block to the P2P network.
@@ -0,0 +1,308 @@
+package p2p
+
+import (
+	"sync"
+
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// bandwidth accounting metrics
+	prometheusP2PPeerBytes                  *prometheus.CounterVec
+	prometheusP2PPeerBandwidthQuotaExceeded *prometheus.CounterVec
+
+	// gossip message prioritization metrics
+	prometheusP2PPriorityQueueDepth   *prometheus.GaugeVec
+	prometheusP2PPriorityQueueDropped *prometheus.CounterVec
+
+	// DataHub health checker metrics
+	prometheusP2PDataHubHealthChecks       *prometheus.CounterVec
+	prometheusP2PDataHubHealthCheckLatency prometheus.Histogram
+	prometheusP2PDataHubsDown              prometheus.Gauge
+
+	// peer registry reconciliation metrics
+	prometheusP2PPeerRegistryDrift *prometheus.CounterVec
+	prometheusP2PRelayOnlyPeers    prometheus.Gauge
+	prometheusP2PPeerTransports    *prometheus.GaugeVec
+
+	// peer registry garbage collection metrics
+	prometheusP2PPeersCollected prometheus.Counter
+
+	// DataHub identity verification metrics
+	prometheusP2PDataHubIdentityChecks *prometheus.CounterVec
+	prometheusP2PVerifiedDataHubs      prometheus.Gauge
+
+	// peer registry lock contention metrics
+	prometheusP2PPeerRegistryLockWait  *prometheus.HistogramVec
+	prometheusP2PPeerRegistrySnapshots prometheus.Counter
+
+	// peer registry and gossip stats
+	prometheusP2PPeers          *prometheus.GaugeVec
+	prometheusP2PPeerReputation *prometheus.GaugeVec
+	prometheusP2PCatchupReports *prometheus.CounterVec
+	prometheusP2PGossipMessages *prometheus.CounterVec
+	prometheusP2PBans           *prometheus.CounterVec
+	prometheusP2PActiveBans     *prometheus.GaugeVec
+
+	// operator message metrics
+	prometheusP2POperatorMessages *prometheus.CounterVec
+
+	// miner ID announcement metrics
+	prometheusP2PMinerIDAnnouncements *prometheus.CounterVec
+
+	// invalid data reported by the validation services
+	prometheusP2PInvalidDataReports *prometheus.CounterVec
+)
+
+var (
+	prometheusMetricsInitOnce sync.Once
+)
+
+// initPrometheusMetrics initializes all the Prometheus metrics for the p2p service.
+// This function uses sync.Once to ensure metrics are only initialized once,
+// regardless of how many times it's called, preventing duplicate metric registration errors.
+func initPrometheusMetrics() {
+	prometheusMetricsInitOnce.Do(_initPrometheusMetrics)
+}
+
+// _initPrometheusMetrics is the internal implementation that registers all Prometheus metrics
+// used by the p2p service.
+func _initPrometheusMetrics() {
+	prometheusP2PPeerBytes = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "peer_bytes_total",
+			Help:      "Total bytes exchanged with each peer, by direction",
+		},
+		[]string{"peer_id", "direction"},
+	)
+
+	prometheusP2PPeerBandwidthQuotaExceeded = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "peer_bandwidth_quota_exceeded_total",
+			Help:      "Number of times a peer exceeded its bandwidth quota and was throttled, by direction",
+		},
+		[]string{"direction"},
+	)
+
+	prometheusP2PPriorityQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "priority_queue_depth",
+			Help:      "Number of queued topic messages waiting to be processed, by topic and priority tier",
+		},
+		[]string{"topic", "priority"},
+	)
+
+	prometheusP2PPriorityQueueDropped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "priority_queue_dropped_total",
+			Help:      "Number of topic messages dropped because the queue of their priority tier was full, by topic and priority tier",
+		},
+		[]string{"topic", "priority"},
+	)
+
+	prometheusP2PDataHubHealthChecks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "datahub_health_checks_total",
+			Help:      "Number of probes of the DataHub URLs of peers, by result",
+		},
+		[]string{"result"},
+	)
+
+	prometheusP2PDataHubHealthCheckLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "datahub_health_check_latency",
+			Help:      "Histogram of the latency of successful probes of the DataHub URLs of peers",
+			Buckets:   util.MetricsBucketsMilliSeconds,
+		},
+	)
+
+	prometheusP2PDataHubsDown = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "datahubs_down",
+			Help:      "Number of peers excluded from catchup because their DataHub URL failed too many probes",
+		},
+	)
+
+	prometheusP2PPeerRegistryDrift = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "peer_registry_drift_total",
+			Help:      "Number of peers whose connection state in the peer registry differed from the live libp2p connections, by drift",
+		},
+		[]string{"drift"},
+	)
+
+	prometheusP2PRelayOnlyPeers = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "relay_only_peers",
+			Help:      "Number of connected peers only reachable through a circuit relay",
+		},
+	)
+
+	prometheusP2PPeerTransports = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "peer_transports",
+			Help:      "Number of connected peers by the transport of their live connections",
+		},
+		[]string{"transport"},
+	)
+
+	prometheusP2PPeersCollected = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "peer_registry_collected_total",
+			Help:      "Number of stale peers removed from the peer registry",
+		},
+	)
+
+	prometheusP2PDataHubIdentityChecks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "datahub_identity_checks_total",
+			Help:      "Number of identity verifications of the DataHub URLs of peers, by result",
+		},
+		[]string{"result"},
+	)
+
+	prometheusP2PVerifiedDataHubs = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "verified_datahubs",
+			Help:      "Number of peers whose DataHub URL is verified to be served by the peer",
+		},
+	)
+
+	prometheusP2PPeerRegistryLockWait = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "peer_registry_lock_wait",
+			Help:      "Histogram of the time spent waiting for the peer registry lock in seconds, by read or write mode",
+			Buckets:   util.MetricsBucketsMicroSeconds,
+		},
+		[]string{"mode"},
+	)
+
+	prometheusP2PPeerRegistrySnapshots = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "peer_registry_snapshots_total",
+			Help:      "Number of snapshots of the peer registry built for readers after the registry was modified",
+		},
+	)
+
+	prometheusP2PPeers = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "peers",
+			Help:      "Number of peers in the peer registry, by state. Peers are either connected or disconnected, and can be in any of the other states",
+		},
+		[]string{"state"},
+	)
+
+	prometheusP2PPeerReputation = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "peer_reputation",
+			Help:      "Number of peers in the peer registry, by reputation score bucket",
+		},
+		[]string{"bucket"},
+	)
+
+	prometheusP2PCatchupReports = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "catchup_reports_total",
+			Help:      "Number of catchup attempts, successes, failures and malicious peers reported to the peer registry",
+		},
+		[]string{"type"},
+	)
+
+	prometheusP2PGossipMessages = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "gossip_messages_total",
+			Help:      "Number of gossip messages received and published, by topic and direction",
+		},
+		[]string{"topic", "direction"},
+	)
+
+	prometheusP2PBans = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "bans_total",
+			Help:      "Number of peers banned, by ban reason",
+		},
+		[]string{"reason"},
+	)
+
+	prometheusP2PActiveBans = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "active_bans",
+			Help:      "Number of bans in effect, of peer IDs and of IP addresses and subnets",
+		},
+		[]string{"type"},
+	)
+
+	prometheusP2POperatorMessages = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "operator_messages_total",
+			Help:      "Number of direct operator messages sent, received and rejected",
+		},
+		[]string{"direction"},
+	)
+
+	prometheusP2PMinerIDAnnouncements = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "miner_id_announcements_total",
+			Help:      "Number of miner ID announcements received from peers, accepted and rejected",
+		},
+		[]string{"result"},
+	)
+
+	prometheusP2PInvalidDataReports = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "p2p",
+			Name:      "invalid_data_reports_total",
+			Help:      "Number of invalid blocks and subtrees attributed to peers, by type and reason",
+		},
+		[]string{"type", "reason"},
+	)
+}
@@ -0,0 +1,249 @@
+package p2p
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	bec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// minerIDAnnouncementDomain prefixes the signed payload of a miner ID announcement, so the signature can not be
+// used as a signature of any other message signed with the miner ID key
+const minerIDAnnouncementDomain = "teranode-miner-id"
+
+const (
+	// maxMinerIDLength is the maximum length in bytes of an announced miner ID
+	maxMinerIDLength = 64
+
+	// maxMinerContactLength is the maximum length in bytes of an announced miner contact
+	maxMinerContactLength = 256
+
+	// minerIDAnnouncementMaxAge is how old a received miner ID announcement may be, older announcements and
+	// announcements signed further in the future are refused
+	minerIDAnnouncementMaxAge = time.Hour
+)
+
+// Results of received miner ID announcements
+const (
+	minerIDAccepted = "accepted"
+	minerIDRejected = "rejected"
+)
+
+// MinerIDAnnouncement announces the mining operator a peer belongs to. It is published by the peer on the miner
+// ID topic, which authenticates the peer, and signed with the miner ID key over the peer ID, so a miner ID can only
+// be claimed for a peer by the holder of its key.
+type MinerIDAnnouncement struct {
+	PeerID    string `json:"peer_id"`
+	MinerID   string `json:"miner_id"`
+	PublicKey string `json:"public_key"` // Hex encoded compressed secp256k1 public key of the miner ID key
+	Contact   string `json:"contact,omitempty"`
+	Timestamp int64  `json:"timestamp"` // Unix milliseconds the announcement was signed at
+	Signature []byte `json:"signature"` // DER encoded signature by the miner ID key
+}
+
+// SignMinerIDAnnouncement returns the announcement of the miner ID and contact for the peer, signed with the
+// miner ID key
+func SignMinerIDAnnouncement(privKey *bec.PrivateKey, peerID, minerID, contact string, now time.Time) (*MinerIDAnnouncement, error) {
+	if err := validateMinerID(minerID, contact); err != nil {
+		return nil, err
+	}
+
+	announcement := &MinerIDAnnouncement{
+		PeerID:    peerID,
+		MinerID:   minerID,
+		PublicKey: hex.EncodeToString(privKey.PubKey().Compressed()),
+		Contact:   contact,
+		Timestamp: now.UnixMilli(),
+	}
+
+	sig, err := privKey.Sign(announcement.signingHash())
+	if err != nil {
+		return nil, errors.NewProcessingError("failed to sign miner ID announcement", err)
+	}
+
+	announcement.Signature = sig.Serialize()
+
+	return announcement, nil
+}
+
+// Verify checks that the announcement was published by the peer, signed with the key of its public key and
+// recently enough
+func (a *MinerIDAnnouncement) Verify(peerID string, now time.Time) error {
+	if a.PeerID != peerID {
+		return errors.NewInvalidArgumentError("miner ID announcement for peer %s was published by %s", a.PeerID, peerID)
+	}
+
+	if err := validateMinerID(a.MinerID, a.Contact); err != nil {
+		return err
+	}
+
+	signedAt := time.UnixMilli(a.Timestamp)
+	if age := now.Sub(signedAt); age > minerIDAnnouncementMaxAge || age < -minerIDAnnouncementMaxAge {
+		return errors.NewInvalidArgumentError("miner ID announcement of peer %s was signed at %s, outside of %v", peerID, signedAt.UTC().Format(time.RFC3339), minerIDAnnouncementMaxAge)
+	}
+
+	pubKey, err := bec.PublicKeyFromString(a.PublicKey)
+	if err != nil {
+		return errors.NewInvalidArgumentError("invalid miner ID public key of peer %s", peerID, err)
+	}
+
+	sig, err := bec.ParseDERSignature(a.Signature)
+	if err != nil {
+		return errors.NewInvalidArgumentError("invalid miner ID announcement signature of peer %s", peerID, err)
+	}
+
+	if !sig.Verify(a.signingHash(), pubKey) {
+		return errors.NewInvalidArgumentError("miner ID announcement of peer %s has an invalid signature", peerID)
+	}
+
+	return nil
+}
+
+// signingHash returns the double sha256 hash of the fields the signature of the announcement covers
+func (a *MinerIDAnnouncement) signingHash() []byte {
+	return chainhash.DoubleHashB([]byte(strings.Join([]string{
+		minerIDAnnouncementDomain,
+		a.PeerID,
+		a.MinerID,
+		a.PublicKey,
+		a.Contact,
+		strconv.FormatInt(a.Timestamp, 10),
+	}, "\n")))
+}
+
+// validateMinerID checks the miner ID and contact of an announcement, which are shown to node operators
+func validateMinerID(minerID, contact string) error {
+	if minerID == "" {
+		return errors.NewInvalidArgumentError("miner ID is required")
+	}
+
+	if len(minerID) > maxMinerIDLength {
+		return errors.NewInvalidArgumentError("miner ID exceeds %d bytes", maxMinerIDLength)
+	}
+
+	if len(contact) > maxMinerContactLength {
+		return errors.NewInvalidArgumentError("miner contact exceeds %d bytes", maxMinerContactLength)
+	}
+
+	for _, s := range []string{minerID, contact} {
+		if !utf8.ValidString(s) || strings.IndexFunc(s, unicode.IsControl) >= 0 {
+			return errors.NewInvalidArgumentError("miner ID and contact must be valid UTF-8 without control characters")
+		}
+	}
+
+	return nil
+}
+
+// parseMinerIDPrivateKey parses a WIF or hex encoded miner ID private key
+func parseMinerIDPrivateKey(key string) (*bec.PrivateKey, error) {
+	if privKey, err := bec.PrivateKeyFromWif(key); err == nil {
+		return privKey, nil
+	}
+
+	privKey, err := bec.PrivateKeyFromHex(key)
+	if err != nil {
+		return nil, errors.NewConfigurationError("miner ID private key is neither a WIF nor a hex encoded key", err)
+	}
+
+	return privKey, nil
+}
+
+// startMinerIDAnnouncer announces the miner ID of this node now and every MinerIDAnnounceInterval, when a miner
+// ID key is configured
+func (s *Server) startMinerIDAnnouncer(ctx context.Context) {
+	if s.minerIDKey == nil {
+		return
+	}
+
+	interval := s.settings.P2P.MinerIDAnnounceInterval
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.announceMinerID(ctx); err != nil {
+				s.logger.Errorf("[startMinerIDAnnouncer] error announcing miner ID: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				s.logger.Infof("[startMinerIDAnnouncer] stopping miner ID announcer")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	s.logger.Infof("[startMinerIDAnnouncer] announcing miner ID %s every %v", s.settings.P2P.MinerID, interval)
+}
+
+// announceMinerID publishes the signed miner ID announcement of this node on the miner ID topic
+func (s *Server) announceMinerID(ctx context.Context) error {
+	announcement, err := SignMinerIDAnnouncement(s.minerIDKey, s.P2PClient.GetID(), s.settings.P2P.MinerID, s.settings.P2P.MinerContact, time.Now())
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(announcement)
+	if err != nil {
+		return errors.NewProcessingError("failed to encode miner ID announcement", err)
+	}
+
+	if err = s.publish(ctx, s.minerIDTopicName, data); err != nil {
+		return errors.NewServiceError("failed to publish miner ID announcement", err)
+	}
+
+	return nil
+}
+
+// handleMinerIDTopic records the miner identification announced by a peer in the peer registry. Announcements
+// that are not published by the announced peer, are not signed by the announced key or are stale are refused.
+func (s *Server) handleMinerIDTopic(_ context.Context, m []byte, from string) {
+	if s.peerRegistry == nil || from == s.P2PClient.GetID() {
+		return
+	}
+
+	initPrometheusMetrics()
+
+	var announcement MinerIDAnnouncement
+
+	err := json.Unmarshal(m, &announcement)
+	if err != nil {
+		err = errors.NewInvalidArgumentError("invalid miner ID announcement", err)
+	} else {
+		err = announcement.Verify(from, time.Now())
+	}
+
+	if err != nil {
+		prometheusP2PMinerIDAnnouncements.WithLabelValues(minerIDRejected).Inc()
+		s.logger.Warnf("[handleMinerIDTopic] refused miner ID announcement from %s: %v", from, err)
+
+		return
+	}
+
+	peerID, err := peer.Decode(from)
+	if err != nil {
+		s.logger.Errorf("[handleMinerIDTopic] failed to decode peer ID %s: %v", from, err)
+		return
+	}
+
+	prometheusP2PMinerIDAnnouncements.WithLabelValues(minerIDAccepted).Inc()
+
+	s.addPeer(peerID, "")
+
+	if s.peerRegistry.UpdateMinerID(peerID, announcement.MinerID, announcement.PublicKey, announcement.Contact, time.UnixMilli(announcement.Timestamp)) {
+		s.logger.Infof("[handleMinerIDTopic] peer %s belongs to miner %s (%s)", from, announcement.MinerID, announcement.PublicKey)
+
+		s.peerEvents.Record(from, PeerEventMinerIDAnnounced, announcement.MinerID+" "+announcement.PublicKey)
+	}
+}
@@ -0,0 +1,204 @@
+package p2p
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	bec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// testMinerPublicKey returns the public key of the miner ID key as announced
+func testMinerPublicKey(privKey *bec.PrivateKey) string {
+	return hex.EncodeToString(privKey.PubKey().Compressed())
+}
+
+func TestMinerIDAnnouncement_SignVerify(t *testing.T) {
+	now := time.Now()
+	peerID := newTestPeerID(t).String()
+
+	minerKey, err := bec.NewPrivateKey()
+	require.NoError(t, err)
+
+	otherKey, err := bec.NewPrivateKey()
+	require.NoError(t, err)
+
+	announcement, err := SignMinerIDAnnouncement(minerKey, peerID, "example-pool", "ops@example.com", now)
+	require.NoError(t, err)
+
+	// the announcement survives the gossip encoding
+	data, err := json.Marshal(announcement)
+	require.NoError(t, err)
+
+	var received MinerIDAnnouncement
+	require.NoError(t, json.Unmarshal(data, &received))
+	require.NoError(t, received.Verify(peerID, now))
+
+	tests := []struct {
+		name   string
+		modify func(a *MinerIDAnnouncement)
+		from   string
+		now    time.Time
+	}{
+		{"published by another peer", func(_ *MinerIDAnnouncement) {}, newTestPeerID(t).String(), now},
+		{"changed miner ID", func(a *MinerIDAnnouncement) { a.MinerID = "other-pool" }, peerID, now},
+		{"changed contact", func(a *MinerIDAnnouncement) { a.Contact = "evil@example.com" }, peerID, now},
+		{"changed public key", func(a *MinerIDAnnouncement) { a.PublicKey = testMinerPublicKey(otherKey) }, peerID, now},
+		{"invalid public key", func(a *MinerIDAnnouncement) { a.PublicKey = "00" }, peerID, now},
+		{"invalid signature", func(a *MinerIDAnnouncement) { a.Signature = []byte{0x30, 0x01} }, peerID, now},
+		{"stale", func(_ *MinerIDAnnouncement) {}, peerID, now.Add(minerIDAnnouncementMaxAge + time.Minute)},
+		{"signed in the future", func(_ *MinerIDAnnouncement) {}, peerID, now.Add(-minerIDAnnouncementMaxAge - time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := *announcement
+			tt.modify(&a)
+
+			err := a.Verify(tt.from, tt.now)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, errors.ErrInvalidArgument))
+		})
+	}
+}
+
+func TestValidateMinerID(t *testing.T) {
+	require.NoError(t, validateMinerID("example-pool", ""))
+	require.NoError(t, validateMinerID("Exämple Pool", "https://example.com/contact"))
+
+	require.Error(t, validateMinerID("", "ops@example.com"))
+	require.Error(t, validateMinerID(strings.Repeat("a", maxMinerIDLength+1), ""))
+	require.Error(t, validateMinerID("example-pool", strings.Repeat("a", maxMinerContactLength+1)))
+	require.Error(t, validateMinerID("example\npool", ""))
+	require.Error(t, validateMinerID("example-pool", "ops@example.com\x00"))
+	require.Error(t, validateMinerID("\xff", ""))
+}
+
+func TestParseMinerIDPrivateKey(t *testing.T) {
+	privKey, err := bec.NewPrivateKey()
+	require.NoError(t, err)
+
+	fromWIF, err := parseMinerIDPrivateKey(privKey.Wif())
+	require.NoError(t, err)
+	assert.Equal(t, privKey.Hex(), fromWIF.Hex())
+
+	fromHex, err := parseMinerIDPrivateKey(privKey.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, privKey.Hex(), fromHex.Hex())
+
+	_, err = parseMinerIDPrivateKey("not a key")
+	require.True(t, errors.Is(err, errors.ErrConfiguration))
+}
+
+func TestServerMinerIDAnnouncements(t *testing.T) {
+	initPrometheusMetrics()
+
+	minerKey, err := bec.NewPrivateKey()
+	require.NoError(t, err)
+
+	minerPeerID := newTestPeerID(t)
+	receiverPeerID := newTestPeerID(t)
+
+	tSettings := &settings.Settings{}
+	tSettings.P2P.MinerID = "example-pool"
+	tSettings.P2P.MinerContact = "ops@example.com"
+
+	minerClient := new(MockServerP2PClient)
+	minerClient.On("GetID").Return(minerPeerID).Maybe()
+
+	miner := &Server{
+		P2PClient:        minerClient,
+		logger:           ulogger.TestLogger{},
+		settings:         tSettings,
+		minerIDKey:       minerKey,
+		minerIDTopicName: "teranode-miner_id",
+	}
+
+	var published []byte
+
+	minerClient.On("Publish", mock.Anything, "teranode-miner_id", mock.Anything).Run(func(args mock.Arguments) {
+		published = args.Get(2).([]byte)
+	}).Return(nil)
+
+	require.NoError(t, miner.announceMinerID(context.Background()))
+	require.NotEmpty(t, published)
+
+	newReceiver := func(t *testing.T) *Server {
+		events, err := NewPeerEventLog(10, "", 0)
+		require.NoError(t, err)
+
+		client := new(MockServerP2PClient)
+		client.On("GetID").Return(receiverPeerID).Maybe()
+
+		return &Server{
+			P2PClient:    client,
+			logger:       ulogger.TestLogger{},
+			peerRegistry: NewPeerRegistry(),
+			peerEvents:   events,
+		}
+	}
+
+	t.Run("announcement is recorded in the peer registry", func(t *testing.T) {
+		receiver := newReceiver(t)
+
+		acceptedBefore := metricValue(t, prometheusP2PMinerIDAnnouncements.WithLabelValues(minerIDAccepted))
+
+		receiver.handleMinerIDTopic(context.Background(), published, minerPeerID.String())
+
+		info, found := receiver.peerRegistry.GetPeer(minerPeerID)
+		require.True(t, found)
+		assert.Equal(t, "example-pool", info.MinerID)
+		assert.Equal(t, testMinerPublicKey(minerKey), info.MinerPublicKey)
+		assert.Equal(t, "ops@example.com", info.MinerContact)
+		assert.False(t, info.MinerIDAnnouncedAt.IsZero())
+
+		events := receiver.peerEvents.Query(time.Time{}, time.Time{}, minerPeerID.String(), 0)
+		require.Len(t, events, 1)
+		assert.Equal(t, PeerEventMinerIDAnnounced, events[0].Type)
+
+		resp, err := receiver.GetPeerRegistry(context.Background(), &emptypb.Empty{})
+		require.NoError(t, err)
+		require.Len(t, resp.Peers, 1)
+		assert.Equal(t, "example-pool", resp.Peers[0].MinerId)
+		assert.Equal(t, info.MinerPublicKey, resp.Peers[0].MinerPublicKey)
+		assert.Equal(t, "ops@example.com", resp.Peers[0].MinerContact)
+		assert.Equal(t, info.MinerIDAnnouncedAt.Unix(), resp.Peers[0].MinerIdAnnouncedAt)
+
+		assert.InDelta(t, acceptedBefore+1, metricValue(t, prometheusP2PMinerIDAnnouncements.WithLabelValues(minerIDAccepted)), 0)
+
+		// a replayed announcement does not change the registry or record another event
+		receiver.handleMinerIDTopic(context.Background(), published, minerPeerID.String())
+		assert.Len(t, receiver.peerEvents.Query(time.Time{}, time.Time{}, minerPeerID.String(), 0), 1)
+	})
+
+	t.Run("announcement republished by another peer is refused", func(t *testing.T) {
+		receiver := newReceiver(t)
+		other := newTestPeerID(t)
+
+		rejectedBefore := metricValue(t, prometheusP2PMinerIDAnnouncements.WithLabelValues(minerIDRejected))
+
+		receiver.handleMinerIDTopic(context.Background(), published, other.String())
+		receiver.handleMinerIDTopic(context.Background(), []byte("not json"), other.String())
+
+		assert.Equal(t, 0, receiver.peerRegistry.PeerCount())
+		assert.InDelta(t, rejectedBefore+2, metricValue(t, prometheusP2PMinerIDAnnouncements.WithLabelValues(minerIDRejected)), 0)
+	})
+
+	t.Run("own announcement is ignored", func(t *testing.T) {
+		receiver := newReceiver(t)
+		receiver.P2PClient = minerClient
+
+		receiver.handleMinerIDTopic(context.Background(), published, minerPeerID.String())
+		assert.Equal(t, 0, receiver.peerRegistry.PeerCount())
+	})
+}
@@ -110,6 +110,16 @@ func (m *MockBanList) ListBanned() []string {
 	return args.Get(0).([]string)
 }
 
+func (m *MockBanList) ListBans() []BanEntry {
+	args := m.Called()
+	return args.Get(0).([]BanEntry)
+}
+
+func (m *MockBanList) SweepExpired(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *MockBanList) Subscribe() chan BanEvent {
 	args := m.Called()
 	return args.Get(0).(chan BanEvent)
@@ -0,0 +1,27 @@
+package p2p
+
+import (
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+)
+
+// natTraversalDisabled returns the NAT traversal switch of the message bus, which enables the AutoNAT service, port
+// mapping and hole punching together. Circuit relay is always enabled by the message bus, through the configured
+// relay peers or the bootstrap peers. The message bus config has no switch for AutoNAT, hole punching or relay alone,
+// so they can not be enabled or disabled one by one.
+func natTraversalDisabled(logger ulogger.Logger, tSettings *settings.Settings) bool {
+	p2pSettings := tSettings.P2P
+
+	if p2pSettings.DisableNAT {
+		logger.Infof("NAT traversal disabled, AutoNAT, port mapping and hole punching are off, circuit relay stays enabled")
+		return true
+	}
+
+	if len(p2pSettings.RelayPeers) > 0 {
+		logger.Infof("NAT traversal enabled: AutoNAT, port mapping, hole punching and circuit relay through %d relay peers", len(p2pSettings.RelayPeers))
+	} else {
+		logger.Infof("NAT traversal enabled: AutoNAT, port mapping, hole punching and circuit relay through the bootstrap peers")
+	}
+
+	return false
+}
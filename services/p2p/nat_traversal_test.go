@@ -0,0 +1,31 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNATTraversalDisabled(t *testing.T) {
+	tests := []struct {
+		name             string
+		disableNAT       bool
+		relayPeers       []string
+		expectedDisabled bool
+	}{
+		{name: "enabled", expectedDisabled: false},
+		{name: "enabled with relay peers", relayPeers: []string{"/ip4/127.0.0.1/tcp/9905/p2p/12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ"}, expectedDisabled: false},
+		{name: "disabled", disableNAT: true, expectedDisabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tSettings := CreateTestSettings()
+			tSettings.P2P.DisableNAT = tt.disableNAT
+			tSettings.P2P.RelayPeers = tt.relayPeers
+
+			assert.Equal(t, tt.expectedDisabled, natTraversalDisabled(ulogger.TestLogger{}, tSettings))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	networkOverviewChurnWindow = time.Hour // Window used for the churn figures in the network overview
+	networkOverviewMaxTips     = 10        // Maximum number of chain tips returned in the network overview
+)
+
+// GetNetworkOverview returns an aggregate view of the network computed from the peer registry
+func (s *Server) GetNetworkOverview(_ context.Context, _ *emptypb.Empty) (*p2p_api.GetNetworkOverviewResponse, error) {
+	if s.peerRegistry == nil {
+		return nil, errors.WrapGRPC(errors.NewServiceError("peer registry not initialized"))
+	}
+
+	joined, left := s.peerRegistry.GetChurn(networkOverviewChurnWindow)
+	overview := buildNetworkOverview(s.peerRegistry.GetAllPeers(), joined, left, networkOverviewChurnWindow)
+
+	resp := &p2p_api.GetNetworkOverviewResponse{
+		TotalPeers:         int32(overview.TotalPeers),     //nolint:gosec
+		ConnectedPeers:     int32(overview.ConnectedPeers), //nolint:gosec
+		BestHeight:         overview.BestHeight,
+		HeightDistribution: make([]*p2p_api.HeightCount, 0, len(overview.HeightDistribution)),
+		ChainTips:          make([]*p2p_api.ChainTip, 0, len(overview.ChainTips)),
+		AvgResponseTimeMs:  overview.AvgResponseTime.Milliseconds(),
+		PeersJoined:        int32(overview.PeersJoined), //nolint:gosec
+		PeersLeft:          int32(overview.PeersLeft),   //nolint:gosec
+		ChurnRate:          overview.ChurnRate,
+		ChurnWindowSeconds: int64(overview.ChurnWindow.Seconds()),
+	}
+
+	for _, hc := range overview.HeightDistribution {
+		resp.HeightDistribution = append(resp.HeightDistribution, &p2p_api.HeightCount{
+			Height:    hc.Height,
+			PeerCount: int32(hc.PeerCount), //nolint:gosec
+		})
+	}
+
+	for _, tip := range overview.ChainTips {
+		resp.ChainTips = append(resp.ChainTips, &p2p_api.ChainTip{
+			BlockHash: tip.BlockHash,
+			Height:    tip.Height,
+			PeerCount: int32(tip.PeerCount), //nolint:gosec
+		})
+	}
+
+	return resp, nil
+}
+
+// buildNetworkOverview aggregates registry peers and churn counts into a NetworkOverview
+func buildNetworkOverview(peers []*PeerInfo, joined, left int, churnWindow time.Duration) *NetworkOverview {
+	overview := &NetworkOverview{
+		TotalPeers:  len(peers),
+		PeersJoined: joined,
+		PeersLeft:   left,
+		ChurnWindow: churnWindow,
+	}
+
+	heights := make(map[int32]int)
+	tips := make(map[string]*ChainTip)
+
+	var (
+		totalResponseTime time.Duration
+		measuredPeers     int64
+	)
+
+	for _, p := range peers {
+		if p.IsConnected {
+			overview.ConnectedPeers++
+		}
+
+		if p.AvgResponseTime > 0 {
+			totalResponseTime += p.AvgResponseTime
+			measuredPeers++
+		}
+
+		// Peers that have not reported a height yet are not part of the chain view
+		if p.Height <= 0 {
+			continue
+		}
+
+		heights[p.Height]++
+
+		if p.Height > overview.BestHeight {
+			overview.BestHeight = p.Height
+		}
+
+		if p.BlockHash == "" {
+			continue
+		}
+
+		tip, exists := tips[p.BlockHash]
+		if !exists {
+			tip = &ChainTip{BlockHash: p.BlockHash, Height: p.Height}
+			tips[p.BlockHash] = tip
+		}
+
+		tip.PeerCount++
+	}
+
+	if measuredPeers > 0 {
+		overview.AvgResponseTime = totalResponseTime / time.Duration(measuredPeers)
+	}
+
+	if overview.TotalPeers > 0 {
+		overview.ChurnRate = float64(joined+left) / float64(overview.TotalPeers)
+	}
+
+	overview.HeightDistribution = make([]HeightCount, 0, len(heights))
+	for height, count := range heights {
+		overview.HeightDistribution = append(overview.HeightDistribution, HeightCount{Height: height, PeerCount: count})
+	}
+
+	sort.Slice(overview.HeightDistribution, func(i, j int) bool {
+		return overview.HeightDistribution[i].Height > overview.HeightDistribution[j].Height
+	})
+
+	overview.ChainTips = make([]ChainTip, 0, len(tips))
+	for _, tip := range tips {
+		overview.ChainTips = append(overview.ChainTips, *tip)
+	}
+
+	// Most advertised tips first, higher tips first on equal peer count
+	sort.Slice(overview.ChainTips, func(i, j int) bool {
+		a, b := overview.ChainTips[i], overview.ChainTips[j]
+		if a.PeerCount != b.PeerCount {
+			return a.PeerCount > b.PeerCount
+		}
+
+		if a.Height != b.Height {
+			return a.Height > b.Height
+		}
+
+		return a.BlockHash < b.BlockHash
+	})
+
+	if len(overview.ChainTips) > networkOverviewMaxTips {
+		overview.ChainTips = overview.ChainTips[:networkOverviewMaxTips]
+	}
+
+	return overview
+}
@@ -0,0 +1,103 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestBuildNetworkOverview(t *testing.T) {
+	ids := GenerateTestPeerIDs(5)
+
+	peers := []*PeerInfo{
+		{ID: ids[0], Height: 100, BlockHash: "tip-a", IsConnected: true, AvgResponseTime: 100 * time.Millisecond},
+		{ID: ids[1], Height: 100, BlockHash: "tip-a", IsConnected: true, AvgResponseTime: 300 * time.Millisecond},
+		{ID: ids[2], Height: 101, BlockHash: "tip-b", IsConnected: false},
+		{ID: ids[3], Height: 99, BlockHash: "tip-c", IsConnected: true},
+		{ID: ids[4]}, // No height reported yet
+	}
+
+	overview := buildNetworkOverview(peers, 3, 1, time.Hour)
+
+	assert.Equal(t, 5, overview.TotalPeers)
+	assert.Equal(t, 3, overview.ConnectedPeers)
+	assert.Equal(t, int32(101), overview.BestHeight)
+	assert.Equal(t, 200*time.Millisecond, overview.AvgResponseTime)
+	assert.Equal(t, 3, overview.PeersJoined)
+	assert.Equal(t, 1, overview.PeersLeft)
+	assert.InDelta(t, 0.8, overview.ChurnRate, 0.0001)
+	assert.Equal(t, time.Hour, overview.ChurnWindow)
+
+	assert.Equal(t, []HeightCount{
+		{Height: 101, PeerCount: 1},
+		{Height: 100, PeerCount: 2},
+		{Height: 99, PeerCount: 1},
+	}, overview.HeightDistribution)
+
+	require.Len(t, overview.ChainTips, 3)
+	assert.Equal(t, ChainTip{BlockHash: "tip-a", Height: 100, PeerCount: 2}, overview.ChainTips[0])
+	assert.Equal(t, "tip-b", overview.ChainTips[1].BlockHash)
+	assert.Equal(t, "tip-c", overview.ChainTips[2].BlockHash)
+}
+
+func TestBuildNetworkOverview_Empty(t *testing.T) {
+	overview := buildNetworkOverview(nil, 0, 0, time.Hour)
+
+	assert.Equal(t, 0, overview.TotalPeers)
+	assert.Zero(t, overview.ChurnRate)
+	assert.Empty(t, overview.HeightDistribution)
+	assert.Empty(t, overview.ChainTips)
+}
+
+func TestBuildNetworkOverview_LimitsChainTips(t *testing.T) {
+	ids := GenerateTestPeerIDs(networkOverviewMaxTips + 5)
+
+	peers := make([]*PeerInfo, 0, len(ids))
+	for i, id := range ids {
+		peers = append(peers, &PeerInfo{ID: id, Height: int32(100 + i), BlockHash: string(id)}) //nolint:gosec
+	}
+
+	overview := buildNetworkOverview(peers, 0, 0, time.Hour)
+
+	require.Len(t, overview.ChainTips, networkOverviewMaxTips)
+	assert.Equal(t, int32(100+len(ids)-1), overview.ChainTips[0].Height, "Highest tip should be first on equal peer count") //nolint:gosec
+}
+
+func TestServer_GetNetworkOverview(t *testing.T) {
+	registry := NewPeerRegistry()
+	ids := GenerateTestPeerIDs(3)
+
+	for _, id := range ids {
+		registry.AddPeer(id, "")
+		registry.UpdateHeight(id, 200, "tip")
+	}
+
+	registry.RemovePeer(ids[2])
+
+	s := &Server{
+		logger:       ulogger.New("test"),
+		peerRegistry: registry,
+	}
+
+	resp, err := s.GetNetworkOverview(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), resp.TotalPeers)
+	assert.Equal(t, int32(3), resp.PeersJoined)
+	assert.Equal(t, int32(1), resp.PeersLeft)
+	assert.Equal(t, int64(time.Hour.Seconds()), resp.ChurnWindowSeconds)
+	require.Len(t, resp.ChainTips, 1)
+	assert.Equal(t, int32(2), resp.ChainTips[0].PeerCount)
+}
+
+func TestServer_GetNetworkOverview_NoRegistry(t *testing.T) {
+	s := &Server{logger: ulogger.New("test")}
+
+	_, err := s.GetNetworkOverview(context.Background(), &emptypb.Empty{})
+	require.Error(t, err)
+}
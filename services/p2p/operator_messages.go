@@ -0,0 +1,543 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"filippo.io/edwards25519"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// OperatorMessageKind is the purpose of a direct message between node operators
+type OperatorMessageKind string
+
+// Operator message kinds
+const (
+	OperatorMessageRestart     OperatorMessageKind = "restart"     // The sender is about to restart its node
+	OperatorMessageThrottle    OperatorMessageKind = "throttle"    // The sender asks the recipient to send it less traffic
+	OperatorMessageMaintenance OperatorMessageKind = "maintenance" // The sender announces maintenance of its node
+	OperatorMessageInfo        OperatorMessageKind = "info"        // Any other message
+)
+
+// Directions of operator messages
+const (
+	OperatorMessageReceived = "received"
+	OperatorMessageSent     = "sent"
+
+	// operatorMessageRejected is the direction of the metric of the refused messages
+	operatorMessageRejected = "rejected"
+)
+
+const (
+	// maxOperatorMessageText is the maximum length in bytes of the text of an operator message
+	maxOperatorMessageText = 1024
+
+	// operatorMessageMaxAge is how old a received operator message may be, older messages and messages sent
+	// further in the future are refused, and message IDs are remembered this long to refuse replayed messages
+	operatorMessageMaxAge = 10 * time.Minute
+
+	// operatorMessageRateWindow is the window of the per-peer rate limit of operator messages
+	operatorMessageRateWindow = time.Minute
+
+	// operatorMessageInboxSize is the number of sent and received operator messages kept for the API
+	operatorMessageInboxSize = 100
+
+	// operatorMessageNotification is the type of the websocket notification of a received operator message
+	operatorMessageNotification = "operator_message"
+)
+
+// OperatorMessage is a direct message sent to or received from the operator of another node
+type OperatorMessage struct {
+	ID        string              // Random ID of the message, used to refuse replays
+	PeerID    string              // The other peer, the sender of a received message or the recipient of a sent one
+	Direction string              // OperatorMessageReceived or OperatorMessageSent
+	Kind      OperatorMessageKind // Purpose of the message
+	Text      string              // Free text of the operator
+	SentAt    time.Time           // When the message was sent, as claimed by the sender
+	At        time.Time           // When the message was received or sent by this node
+}
+
+// operatorEnvelope is an operator message on the operator message topic. The payload is encrypted with NaCl box
+// from the X25519 form of the Ed25519 peer key of the sender to that of the recipient, so only the recipient can
+// read it and only the holder of the sender key could have written it.
+type operatorEnvelope struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// operatorPayload is the encrypted content of an operator envelope
+type operatorPayload struct {
+	ID     string              `json:"id"`
+	To     string              `json:"to"`
+	Kind   OperatorMessageKind `json:"kind"`
+	Text   string              `json:"text"`
+	SentAt int64               `json:"sent_at"` // Unix timestamp in milliseconds
+}
+
+// OperatorMessenger seals and opens the direct messages between node operators, exchanged on a gossip topic all
+// nodes with operator messages enabled subscribe to. Messages to and from each peer are rate limited, replayed
+// and stale messages are refused, and the most recent messages are kept for the API.
+type OperatorMessenger struct {
+	mu        sync.Mutex
+	privKey   crypto.PrivKey
+	selfID    peer.ID
+	rateLimit int
+	sent      map[string][]time.Time // send times per recipient within the rate window
+	received  map[string][]time.Time // receive times per sender within the rate window
+	seen      map[string]time.Time   // IDs of received messages, with the time they expire
+	inbox     []OperatorMessage      // ring buffer, next is the position of the oldest message once the buffer is full
+	next      int
+	full      bool
+	now       func() time.Time
+}
+
+// NewOperatorMessenger creates the operator messenger of the node owning the Ed25519 private key, accepting at most
+// rateLimit messages per minute from and to each peer
+func NewOperatorMessenger(privKey crypto.PrivKey, rateLimit int) (*OperatorMessenger, error) {
+	if privKey == nil || privKey.Type() != crypto.Ed25519 {
+		return nil, errors.NewConfigurationError("operator messages require an Ed25519 peer key")
+	}
+
+	if rateLimit <= 0 {
+		return nil, errors.NewConfigurationError("operator message rate limit must be positive, got %d", rateLimit)
+	}
+
+	selfID, err := peer.IDFromPrivateKey(privKey)
+	if err != nil {
+		return nil, errors.NewConfigurationError("failed to derive peer ID of the peer key", err)
+	}
+
+	return &OperatorMessenger{
+		privKey:   privKey,
+		selfID:    selfID,
+		rateLimit: rateLimit,
+		sent:      make(map[string][]time.Time),
+		received:  make(map[string][]time.Time),
+		seen:      make(map[string]time.Time),
+		inbox:     make([]OperatorMessage, 0, operatorMessageInboxSize),
+		now:       time.Now,
+	}, nil
+}
+
+// Seal returns the envelope of a message to the peer, counted against the rate limit of messages to the peer
+func (m *OperatorMessenger) Seal(to string, kind OperatorMessageKind, text string) ([]byte, *OperatorMessage, error) {
+	if err := validateOperatorMessage(kind, text); err != nil {
+		return nil, nil, err
+	}
+
+	toID, err := peer.Decode(to)
+	if err != nil {
+		return nil, nil, errors.NewInvalidArgumentError("invalid peer ID %q", to, err)
+	}
+
+	if toID == m.selfID {
+		return nil, nil, errors.NewInvalidArgumentError("operator messages can not be sent to ourselves")
+	}
+
+	peerKey, err := operatorBoxPublicKey(toID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ownKey, err := operatorBoxPrivateKey(m.privKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var id [16]byte
+	if _, err = rand.Read(id[:]); err != nil {
+		return nil, nil, errors.NewProcessingError("failed to generate operator message ID", err)
+	}
+
+	var nonce [24]byte
+	if _, err = rand.Read(nonce[:]); err != nil {
+		return nil, nil, errors.NewProcessingError("failed to generate operator message nonce", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	if !m.allow(m.sent, toID.String(), now) {
+		return nil, nil, errors.NewThresholdExceededError("more than %d operator messages per minute to peer %s", m.rateLimit, toID)
+	}
+
+	payload, err := json.Marshal(operatorPayload{
+		ID:     hex.EncodeToString(id[:]),
+		To:     toID.String(),
+		Kind:   kind,
+		Text:   text,
+		SentAt: now.UnixMilli(),
+	})
+	if err != nil {
+		return nil, nil, errors.NewProcessingError("failed to encode operator message", err)
+	}
+
+	data, err := json.Marshal(operatorEnvelope{
+		From:       m.selfID.String(),
+		To:         toID.String(),
+		Nonce:      nonce[:],
+		Ciphertext: box.Seal(nil, payload, &nonce, peerKey, ownKey),
+	})
+	if err != nil {
+		return nil, nil, errors.NewProcessingError("failed to encode operator message", err)
+	}
+
+	msg := OperatorMessage{
+		ID:        hex.EncodeToString(id[:]),
+		PeerID:    toID.String(),
+		Direction: OperatorMessageSent,
+		Kind:      kind,
+		Text:      text,
+		SentAt:    now,
+		At:        now,
+	}
+
+	m.add(msg)
+
+	return data, &msg, nil
+}
+
+// Open returns the message of the envelope received from the peer fromID, the sender authenticated by the gossip
+// layer. The message is nil without an error when the envelope is addressed to another peer. Envelopes whose
+// sender does not match fromID, that can not be decrypted, are stale or replayed, or exceed the rate limit of the
+// sender are refused with an error.
+func (m *OperatorMessenger) Open(data []byte, fromID string) (*OperatorMessage, error) {
+	var envelope operatorEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, errors.NewInvalidArgumentError("invalid operator message", err)
+	}
+
+	if envelope.To != m.selfID.String() {
+		return nil, nil
+	}
+
+	if envelope.From != fromID {
+		return nil, errors.NewInvalidArgumentError("operator message claims sender %s but was published by %s", envelope.From, fromID)
+	}
+
+	senderID, err := peer.Decode(fromID)
+	if err != nil {
+		return nil, errors.NewInvalidArgumentError("invalid sender peer ID %q", fromID, err)
+	}
+
+	if len(envelope.Nonce) != 24 {
+		return nil, errors.NewInvalidArgumentError("invalid operator message nonce from %s", fromID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	// the rate limit is applied before decrypting, so a flooding peer can not make us spend cycles on it
+	if !m.allow(m.received, fromID, now) {
+		return nil, errors.NewThresholdExceededError("more than %d operator messages per minute from peer %s", m.rateLimit, fromID)
+	}
+
+	peerKey, err := operatorBoxPublicKey(senderID)
+	if err != nil {
+		return nil, err
+	}
+
+	ownKey, err := operatorBoxPrivateKey(m.privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [24]byte
+	copy(nonce[:], envelope.Nonce)
+
+	plaintext, ok := box.Open(nil, envelope.Ciphertext, &nonce, peerKey, ownKey)
+	if !ok {
+		return nil, errors.NewInvalidArgumentError("operator message from %s can not be decrypted", fromID)
+	}
+
+	var payload operatorPayload
+	if err = json.Unmarshal(plaintext, &payload); err != nil {
+		return nil, errors.NewInvalidArgumentError("invalid operator message payload from %s", fromID, err)
+	}
+
+	// the recipient inside the encrypted payload binds the message to us, it can not be forwarded to another peer
+	if payload.To != m.selfID.String() {
+		return nil, errors.NewInvalidArgumentError("operator message from %s is addressed to %s", fromID, payload.To)
+	}
+
+	if err = validateOperatorMessage(payload.Kind, payload.Text); err != nil {
+		return nil, err
+	}
+
+	sentAt := time.UnixMilli(payload.SentAt)
+	if age := now.Sub(sentAt); age > operatorMessageMaxAge || age < -operatorMessageMaxAge {
+		return nil, errors.NewInvalidArgumentError("operator message from %s was sent at %s, outside of %v", fromID, sentAt.UTC().Format(time.RFC3339), operatorMessageMaxAge)
+	}
+
+	for id, expires := range m.seen {
+		if now.After(expires) {
+			delete(m.seen, id)
+		}
+	}
+
+	if _, replayed := m.seen[fromID+"/"+payload.ID]; replayed {
+		return nil, errors.NewInvalidArgumentError("operator message %s from %s was already received", payload.ID, fromID)
+	}
+
+	m.seen[fromID+"/"+payload.ID] = now.Add(2 * operatorMessageMaxAge)
+
+	msg := OperatorMessage{
+		ID:        payload.ID,
+		PeerID:    fromID,
+		Direction: OperatorMessageReceived,
+		Kind:      payload.Kind,
+		Text:      payload.Text,
+		SentAt:    sentAt,
+		At:        now,
+	}
+
+	m.add(msg)
+
+	return &msg, nil
+}
+
+// Messages returns the most recent sent and received messages, newest first. An empty peerID matches all peers,
+// when limit is positive at most limit messages are returned.
+func (m *OperatorMessenger) Messages(peerID string, limit int) []OperatorMessage {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages := make([]OperatorMessage, 0, len(m.inbox))
+
+	for i := len(m.inbox) - 1; i >= 0; i-- {
+		msg := m.inbox[(m.next+i)%len(m.inbox)]
+		if !m.full {
+			msg = m.inbox[i]
+		}
+
+		if peerID != "" && msg.PeerID != peerID {
+			continue
+		}
+
+		messages = append(messages, msg)
+
+		if limit > 0 && len(messages) == limit {
+			break
+		}
+	}
+
+	return messages
+}
+
+// allow returns whether another message to or from the peer is within the rate limit, and counts it when it is.
+// The caller holds the lock.
+func (m *OperatorMessenger) allow(windows map[string][]time.Time, peerID string, now time.Time) bool {
+	// drop the windows of the peers without recent messages, so the maps do not grow with every peer ever seen
+	for id, times := range windows {
+		if len(times) > 0 && now.Sub(times[len(times)-1]) >= operatorMessageRateWindow {
+			delete(windows, id)
+		}
+	}
+
+	times := windows[peerID]
+
+	recent := times[:0]
+	for _, t := range times {
+		if now.Sub(t) < operatorMessageRateWindow {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) >= m.rateLimit {
+		windows[peerID] = recent
+		return false
+	}
+
+	windows[peerID] = append(recent, now)
+
+	return true
+}
+
+// add appends the message to the ring buffer of recent messages, the caller holds the lock
+func (m *OperatorMessenger) add(msg OperatorMessage) {
+	if !m.full {
+		m.inbox = append(m.inbox, msg)
+		m.full = len(m.inbox) == cap(m.inbox)
+
+		return
+	}
+
+	m.inbox[m.next] = msg
+	m.next = (m.next + 1) % len(m.inbox)
+}
+
+// validateOperatorMessage checks the kind and the text of an operator message
+func validateOperatorMessage(kind OperatorMessageKind, text string) error {
+	switch kind {
+	case OperatorMessageRestart, OperatorMessageThrottle, OperatorMessageMaintenance, OperatorMessageInfo:
+	default:
+		return errors.NewInvalidArgumentError("invalid operator message kind %q, expected restart, throttle, maintenance or info", kind)
+	}
+
+	if len(text) > maxOperatorMessageText {
+		return errors.NewInvalidArgumentError("operator message text exceeds %d bytes", maxOperatorMessageText)
+	}
+
+	if !utf8.ValidString(text) {
+		return errors.NewInvalidArgumentError("operator message text is not valid UTF-8")
+	}
+
+	return nil
+}
+
+// operatorBoxPublicKey returns the X25519 public key of the Ed25519 key embedded in the peer ID
+func operatorBoxPublicKey(id peer.ID) (*[32]byte, error) {
+	pubKey, err := id.ExtractPublicKey()
+	if err != nil {
+		return nil, errors.NewInvalidArgumentError("failed to extract public key of peer %s", id, err)
+	}
+
+	if pubKey.Type() != crypto.Ed25519 {
+		return nil, errors.NewInvalidArgumentError("peer %s does not have an Ed25519 key", id)
+	}
+
+	raw, err := pubKey.Raw()
+	if err != nil {
+		return nil, errors.NewInvalidArgumentError("failed to read public key of peer %s", id, err)
+	}
+
+	point, err := new(edwards25519.Point).SetBytes(raw)
+	if err != nil {
+		return nil, errors.NewInvalidArgumentError("invalid public key of peer %s", id, err)
+	}
+
+	var key [32]byte
+	copy(key[:], point.BytesMontgomery())
+
+	return &key, nil
+}
+
+// operatorBoxPrivateKey returns the X25519 private key of the Ed25519 private key, the clamped lower half of the
+// SHA-512 hash of its seed as defined by RFC 8032
+func operatorBoxPrivateKey(privKey crypto.PrivKey) (*[32]byte, error) {
+	raw, err := privKey.Raw()
+	if err != nil || len(raw) < 32 {
+		return nil, errors.NewProcessingError("failed to read the peer key", err)
+	}
+
+	digest := sha512.Sum512(raw[:32])
+	digest[0] &= 248
+	digest[31] &= 127
+	digest[31] |= 64
+
+	var key [32]byte
+	copy(key[:], digest[:32])
+
+	return &key, nil
+}
+
+// handleOperatorMessageTopic opens the operator messages addressed to us, recording them in the peer event log
+// and notifying the websocket clients
+func (s *Server) handleOperatorMessageTopic(_ context.Context, m []byte, from string) {
+	if s.operatorMessenger == nil || from == s.P2PClient.GetID() {
+		return
+	}
+
+	initPrometheusMetrics()
+
+	msg, err := s.operatorMessenger.Open(m, from)
+	if err != nil {
+		prometheusP2POperatorMessages.WithLabelValues(operatorMessageRejected).Inc()
+		s.logger.Warnf("[handleOperatorMessageTopic] refused operator message from %s: %v", from, err)
+
+		return
+	}
+
+	if msg == nil {
+		return
+	}
+
+	prometheusP2POperatorMessages.WithLabelValues(OperatorMessageReceived).Inc()
+	s.logger.Infof("[handleOperatorMessageTopic] operator message from %s: %s %q", from, msg.Kind, msg.Text)
+
+	s.peerEvents.Record(from, PeerEventOperatorMessageReceived, string(msg.Kind)+": "+msg.Text)
+
+	select {
+	case s.notificationCh <- &notificationMsg{
+		Timestamp:   msg.At.UTC().Format(isoFormat),
+		Type:        operatorMessageNotification,
+		PeerID:      from,
+		MessageKind: string(msg.Kind),
+		MessageText: msg.Text,
+	}:
+	default:
+		s.logger.Warnf("[handleOperatorMessageTopic] notification channel full, dropped operator message notification")
+	}
+}
+
+// SendOperatorMessage encrypts a message to the operator of the peer of the request and publishes it on the
+// operator message topic
+func (s *Server) SendOperatorMessage(ctx context.Context, req *p2p_api.SendOperatorMessageRequest) (*p2p_api.SendOperatorMessageResponse, error) {
+	if s.operatorMessenger == nil {
+		return nil, errors.WrapGRPC(errors.NewServiceUnavailableError("operator messages are disabled, set p2p_operator_messages_enabled"))
+	}
+
+	data, msg, err := s.operatorMessenger.Seal(req.PeerId, OperatorMessageKind(req.Kind), req.Text)
+	if err != nil {
+		return nil, errors.WrapGRPC(err)
+	}
+
+	if err = s.publish(ctx, s.operatorMessageTopicName, data); err != nil {
+		return nil, errors.WrapGRPC(errors.NewServiceError("failed to publish operator message", err))
+	}
+
+	prometheusP2POperatorMessages.WithLabelValues(OperatorMessageSent).Inc()
+	s.logger.Infof("[SendOperatorMessage] operator message to %s: %s %q", msg.PeerID, msg.Kind, msg.Text)
+
+	s.peerEvents.Record(msg.PeerID, PeerEventOperatorMessageSent, string(msg.Kind)+": "+msg.Text)
+
+	return &p2p_api.SendOperatorMessageResponse{Message: operatorMessageToAPI(*msg)}, nil
+}
+
+// GetOperatorMessages returns the most recent operator messages sent and received, newest first
+func (s *Server) GetOperatorMessages(_ context.Context, req *p2p_api.GetOperatorMessagesRequest) (*p2p_api.GetOperatorMessagesResponse, error) {
+	messages := s.operatorMessenger.Messages(req.PeerId, int(req.Limit))
+
+	resp := &p2p_api.GetOperatorMessagesResponse{
+		Messages: make([]*p2p_api.OperatorMessage, 0, len(messages)),
+	}
+
+	for _, msg := range messages {
+		resp.Messages = append(resp.Messages, operatorMessageToAPI(msg))
+	}
+
+	return resp, nil
+}
+
+// operatorMessageToAPI converts an operator message to its gRPC representation
+func operatorMessageToAPI(msg OperatorMessage) *p2p_api.OperatorMessage {
+	return &p2p_api.OperatorMessage{
+		Id:        msg.ID,
+		PeerId:    msg.PeerID,
+		Direction: msg.Direction,
+		Kind:      string(msg.Kind),
+		Text:      msg.Text,
+		SentAt:    msg.SentAt.UnixMilli(),
+		At:        msg.At.UnixMilli(),
+	}
+}
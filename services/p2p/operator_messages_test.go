@@ -0,0 +1,349 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestOperatorMessenger creates an operator messenger with a new peer key and a fixed clock
+func newTestOperatorMessenger(t *testing.T, rateLimit int, now time.Time) *OperatorMessenger {
+	t.Helper()
+
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	m, err := NewOperatorMessenger(privKey, rateLimit)
+	require.NoError(t, err)
+
+	m.now = func() time.Time { return now }
+
+	return m
+}
+
+func TestNewOperatorMessenger(t *testing.T) {
+	_, err := NewOperatorMessenger(nil, 6)
+	require.Error(t, err)
+
+	privKey, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+
+	_, err = NewOperatorMessenger(privKey, 6)
+	require.Error(t, err, "only Ed25519 keys can be converted for encryption")
+
+	privKey, _, err = crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	_, err = NewOperatorMessenger(privKey, 0)
+	require.Error(t, err)
+}
+
+func TestOperatorMessenger_SealOpen(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	alice := newTestOperatorMessenger(t, 6, now)
+	bob := newTestOperatorMessenger(t, 6, now)
+
+	data, sent, err := alice.Seal(bob.selfID.String(), OperatorMessageRestart, "restarting for an upgrade")
+	require.NoError(t, err)
+	assert.Equal(t, OperatorMessageSent, sent.Direction)
+	assert.Equal(t, bob.selfID.String(), sent.PeerID)
+
+	// the text is encrypted on the wire
+	assert.NotContains(t, string(data), "upgrade")
+
+	received, err := bob.Open(data, alice.selfID.String())
+	require.NoError(t, err)
+	require.NotNil(t, received)
+	assert.Equal(t, sent.ID, received.ID)
+	assert.Equal(t, alice.selfID.String(), received.PeerID)
+	assert.Equal(t, OperatorMessageReceived, received.Direction)
+	assert.Equal(t, OperatorMessageRestart, received.Kind)
+	assert.Equal(t, "restarting for an upgrade", received.Text)
+	assert.Equal(t, now.UnixMilli(), received.SentAt.UnixMilli())
+
+	assert.Equal(t, []OperatorMessage{*sent}, alice.Messages("", 0))
+	assert.Equal(t, []OperatorMessage{*received}, bob.Messages(alice.selfID.String(), 0))
+	assert.Empty(t, bob.Messages(bob.selfID.String(), 0))
+}
+
+func TestOperatorMessenger_Seal(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	alice := newTestOperatorMessenger(t, 6, now)
+	bob := newTestOperatorMessenger(t, 6, now)
+
+	_, _, err := alice.Seal(bob.selfID.String(), "reboot", "")
+	require.True(t, errors.Is(err, errors.ErrInvalidArgument), "unknown kind")
+
+	_, _, err = alice.Seal(bob.selfID.String(), OperatorMessageInfo, strings.Repeat("x", maxOperatorMessageText+1))
+	require.True(t, errors.Is(err, errors.ErrInvalidArgument), "text too long")
+
+	_, _, err = alice.Seal("not-a-peer-id", OperatorMessageInfo, "")
+	require.True(t, errors.Is(err, errors.ErrInvalidArgument), "invalid peer ID")
+
+	_, _, err = alice.Seal(alice.selfID.String(), OperatorMessageInfo, "")
+	require.True(t, errors.Is(err, errors.ErrInvalidArgument), "message to ourselves")
+
+	secp, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+
+	secpID, err := peer.IDFromPrivateKey(secp)
+	require.NoError(t, err)
+
+	_, _, err = alice.Seal(secpID.String(), OperatorMessageInfo, "")
+	require.True(t, errors.Is(err, errors.ErrInvalidArgument), "peer without an Ed25519 key")
+}
+
+func TestOperatorMessenger_Open(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	alice := newTestOperatorMessenger(t, 6, now)
+	bob := newTestOperatorMessenger(t, 6, now)
+	carol := newTestOperatorMessenger(t, 6, now)
+
+	data, _, err := alice.Seal(bob.selfID.String(), OperatorMessageThrottle, "please throttle")
+	require.NoError(t, err)
+
+	t.Run("addressed to another peer", func(t *testing.T) {
+		msg, err := carol.Open(data, alice.selfID.String())
+		require.NoError(t, err)
+		assert.Nil(t, msg)
+	})
+
+	t.Run("spoofed sender", func(t *testing.T) {
+		// the envelope was published by carol, claiming to be from alice
+		_, err := bob.Open(data, carol.selfID.String())
+		require.Error(t, err)
+	})
+
+	t.Run("forged sender", func(t *testing.T) {
+		// carol rewrites the sender of her own message to alice, it can not be decrypted with the key of alice
+		forged, _, err := carol.Seal(bob.selfID.String(), OperatorMessageInfo, "hi")
+		require.NoError(t, err)
+
+		var envelope operatorEnvelope
+		require.NoError(t, json.Unmarshal(forged, &envelope))
+
+		envelope.From = alice.selfID.String()
+
+		forged, err = json.Marshal(envelope)
+		require.NoError(t, err)
+
+		_, err = bob.Open(forged, alice.selfID.String())
+		require.Error(t, err)
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		_, err := bob.Open(data, alice.selfID.String())
+		require.NoError(t, err)
+
+		_, err = bob.Open(data, alice.selfID.String())
+		require.Error(t, err)
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		stale, _, err := alice.Seal(bob.selfID.String(), OperatorMessageInfo, "old news")
+		require.NoError(t, err)
+
+		bob.now = func() time.Time { return now.Add(operatorMessageMaxAge + time.Second) }
+		defer func() { bob.now = func() time.Time { return now } }()
+
+		_, err = bob.Open(stale, alice.selfID.String())
+		require.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := bob.Open([]byte("not json"), alice.selfID.String())
+		require.Error(t, err)
+	})
+}
+
+func TestOperatorMessenger_RateLimit(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	alice := newTestOperatorMessenger(t, 2, now)
+	bob := newTestOperatorMessenger(t, 2, now)
+	carol := newTestOperatorMessenger(t, 2, now)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := alice.Seal(bob.selfID.String(), OperatorMessageInfo, "hi")
+		require.NoError(t, err)
+	}
+
+	_, _, err := alice.Seal(bob.selfID.String(), OperatorMessageInfo, "hi")
+	require.True(t, errors.Is(err, errors.ErrThresholdExceeded))
+
+	// the limit is per peer
+	_, _, err = alice.Seal(carol.selfID.String(), OperatorMessageInfo, "hi")
+	require.NoError(t, err)
+
+	// the window slides
+	alice.now = func() time.Time { return now.Add(operatorMessageRateWindow) }
+
+	_, _, err = alice.Seal(bob.selfID.String(), OperatorMessageInfo, "hi")
+	require.NoError(t, err)
+
+	// received messages are limited per sender, the receiver does not trust the sender to limit itself
+	carol.rateLimit = 10
+
+	var envelopes [][]byte
+
+	for i := 0; i < 3; i++ {
+		data, _, err := carol.Seal(bob.selfID.String(), OperatorMessageInfo, "hi")
+		require.NoError(t, err)
+
+		envelopes = append(envelopes, data)
+	}
+
+	for _, data := range envelopes[:2] {
+		_, err = bob.Open(data, carol.selfID.String())
+		require.NoError(t, err)
+	}
+
+	_, err = bob.Open(envelopes[2], carol.selfID.String())
+	require.True(t, errors.Is(err, errors.ErrThresholdExceeded))
+}
+
+func TestOperatorMessenger_Messages(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	alice := newTestOperatorMessenger(t, operatorMessageInboxSize*2, now)
+	bob := newTestOperatorMessenger(t, operatorMessageInboxSize*2, now)
+
+	var last *OperatorMessage
+
+	for i := 0; i < operatorMessageInboxSize+5; i++ {
+		var err error
+
+		_, last, err = alice.Seal(bob.selfID.String(), OperatorMessageInfo, "hi")
+		require.NoError(t, err)
+	}
+
+	messages := alice.Messages("", 0)
+	require.Len(t, messages, operatorMessageInboxSize, "the oldest messages are dropped")
+	assert.Equal(t, last.ID, messages[0].ID, "newest first")
+
+	assert.Len(t, alice.Messages("", 3), 3)
+
+	var nilMessenger *OperatorMessenger
+	assert.Empty(t, nilMessenger.Messages("", 0))
+}
+
+func TestServerOperatorMessages(t *testing.T) {
+	initPrometheusMetrics()
+
+	now := time.Now()
+
+	alice := newTestOperatorMessenger(t, 6, now)
+	bob := newTestOperatorMessenger(t, 6, now)
+
+	newServer := func(t *testing.T, messenger *OperatorMessenger) (*Server, *MockServerP2PClient) {
+		events, err := NewPeerEventLog(10, "", 0)
+		require.NoError(t, err)
+
+		client := new(MockServerP2PClient)
+		client.On("GetID").Return(messenger.selfID).Maybe()
+
+		return &Server{
+			P2PClient:                client,
+			logger:                   ulogger.TestLogger{},
+			notificationCh:           make(chan *notificationMsg, 10),
+			peerEvents:               events,
+			operatorMessenger:        messenger,
+			operatorMessageTopicName: "teranode-operator_message",
+		}, client
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		s := &Server{}
+
+		_, err := s.SendOperatorMessage(context.Background(), &p2p_api.SendOperatorMessageRequest{PeerId: bob.selfID.String(), Kind: "info"})
+		require.Error(t, err)
+
+		resp, err := s.GetOperatorMessages(context.Background(), &p2p_api.GetOperatorMessagesRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Messages)
+	})
+
+	t.Run("send and receive", func(t *testing.T) {
+		sender, senderClient := newServer(t, alice)
+		receiver, _ := newServer(t, bob)
+
+		var published []byte
+
+		senderClient.On("Publish", mock.Anything, "teranode-operator_message", mock.Anything).Run(func(args mock.Arguments) {
+			published = args.Get(2).([]byte)
+		}).Return(nil)
+
+		sentBefore := metricValue(t, prometheusP2POperatorMessages.WithLabelValues(OperatorMessageSent))
+		receivedBefore := metricValue(t, prometheusP2POperatorMessages.WithLabelValues(OperatorMessageReceived))
+
+		resp, err := sender.SendOperatorMessage(context.Background(), &p2p_api.SendOperatorMessageRequest{
+			PeerId: bob.selfID.String(),
+			Kind:   string(OperatorMessageMaintenance),
+			Text:   "disk replacement at 14:00 UTC",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, OperatorMessageSent, resp.Message.Direction)
+		require.NotEmpty(t, published)
+
+		sentEvents := sender.peerEvents.Query(time.Time{}, time.Time{}, bob.selfID.String(), 0)
+		require.Len(t, sentEvents, 1)
+		assert.Equal(t, PeerEventOperatorMessageSent, sentEvents[0].Type)
+
+		receiver.handleOperatorMessageTopic(context.Background(), published, alice.selfID.String())
+
+		receivedEvents := receiver.peerEvents.Query(time.Time{}, time.Time{}, alice.selfID.String(), 0)
+		require.Len(t, receivedEvents, 1)
+		assert.Equal(t, PeerEventOperatorMessageReceived, receivedEvents[0].Type)
+		assert.Equal(t, "maintenance: disk replacement at 14:00 UTC", receivedEvents[0].Details)
+
+		require.Len(t, receiver.notificationCh, 1)
+		notification := <-receiver.notificationCh
+		assert.Equal(t, operatorMessageNotification, notification.Type)
+		assert.Equal(t, alice.selfID.String(), notification.PeerID)
+		assert.Equal(t, "maintenance", notification.MessageKind)
+		assert.Equal(t, "disk replacement at 14:00 UTC", notification.MessageText)
+
+		messages, err := receiver.GetOperatorMessages(context.Background(), &p2p_api.GetOperatorMessagesRequest{PeerId: alice.selfID.String()})
+		require.NoError(t, err)
+		require.Len(t, messages.Messages, 1)
+		assert.Equal(t, resp.Message.Id, messages.Messages[0].Id)
+
+		assert.InDelta(t, sentBefore+1, metricValue(t, prometheusP2POperatorMessages.WithLabelValues(OperatorMessageSent)), 0)
+		assert.InDelta(t, receivedBefore+1, metricValue(t, prometheusP2POperatorMessages.WithLabelValues(OperatorMessageReceived)), 0)
+
+		// a replayed message is refused without an event or notification
+		receiver.handleOperatorMessageTopic(context.Background(), published, alice.selfID.String())
+		assert.Len(t, receiver.peerEvents.Query(time.Time{}, time.Time{}, alice.selfID.String(), 0), 1)
+		assert.Empty(t, receiver.notificationCh)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		limited := newTestOperatorMessenger(t, 1, now)
+
+		sender, senderClient := newServer(t, limited)
+		senderClient.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		req := &p2p_api.SendOperatorMessageRequest{PeerId: bob.selfID.String(), Kind: string(OperatorMessageInfo)}
+
+		_, err := sender.SendOperatorMessage(context.Background(), req)
+		require.NoError(t, err)
+
+		_, err = sender.SendOperatorMessage(context.Background(), req)
+		require.True(t, errors.Is(errors.UnwrapGRPC(err), errors.ErrThresholdExceeded))
+	})
+}
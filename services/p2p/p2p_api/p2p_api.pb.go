@@ -22,6 +22,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Reason a peer provided invalid data
+type InvalidDataReason int32
+
+const (
+	InvalidDataReason_INVALID_DATA_REASON_UNSPECIFIED InvalidDataReason = 0
+	InvalidDataReason_INVALID_DATA_REASON_INVALID     InvalidDataReason = 1 // The data failed validation
+	InvalidDataReason_INVALID_DATA_REASON_MALFORMED   InvalidDataReason = 2 // The data could not be parsed or did not match its hash
+	InvalidDataReason_INVALID_DATA_REASON_UNAVAILABLE InvalidDataReason = 3 // The data was announced but the peer could not provide it
+)
+
+// Enum value maps for InvalidDataReason.
+var (
+	InvalidDataReason_name = map[int32]string{
+		0: "INVALID_DATA_REASON_UNSPECIFIED",
+		1: "INVALID_DATA_REASON_INVALID",
+		2: "INVALID_DATA_REASON_MALFORMED",
+		3: "INVALID_DATA_REASON_UNAVAILABLE",
+	}
+	InvalidDataReason_value = map[string]int32{
+		"INVALID_DATA_REASON_UNSPECIFIED": 0,
+		"INVALID_DATA_REASON_INVALID":     1,
+		"INVALID_DATA_REASON_MALFORMED":   2,
+		"INVALID_DATA_REASON_UNAVAILABLE": 3,
+	}
+)
+
+func (x InvalidDataReason) Enum() *InvalidDataReason {
+	p := new(InvalidDataReason)
+	*p = x
+	return p
+}
+
+func (x InvalidDataReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InvalidDataReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_services_p2p_p2p_api_p2p_api_proto_enumTypes[0].Descriptor()
+}
+
+func (InvalidDataReason) Type() protoreflect.EnumType {
+	return &file_services_p2p_p2p_api_p2p_api_proto_enumTypes[0]
+}
+
+func (x InvalidDataReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InvalidDataReason.Descriptor instead.
+func (InvalidDataReason) EnumDescriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{0}
+}
+
 type Peer struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -320,8 +373,9 @@ func (x *GetPeersResponse) GetPeers() []*Peer {
 
 type BanPeerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"` // IP address, CIDR subnet or peer ID
 	Until         int64                  `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // optional reason of the ban, recorded in the peer event log
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -370,6 +424,13 @@ func (x *BanPeerRequest) GetUntil() int64 {
 	return 0
 }
 
+func (x *BanPeerRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type BanPeerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
@@ -593,6 +654,7 @@ func (x *IsBannedResponse) GetIsBanned() bool {
 type ListBannedResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Banned        []string               `protobuf:"bytes,1,rep,name=banned,proto3" json:"banned,omitempty"`
+	Bans          []*BanEntry            `protobuf:"bytes,2,rep,name=bans,proto3" json:"bans,omitempty"` // the banned entries with the time their ban expires
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -634,6 +696,65 @@ func (x *ListBannedResponse) GetBanned() []string {
 	return nil
 }
 
+func (x *ListBannedResponse) GetBans() []*BanEntry {
+	if x != nil {
+		return x.Bans
+	}
+	return nil
+}
+
+type BanEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Banned        string                 `protobuf:"bytes,1,opt,name=banned,proto3" json:"banned,omitempty"`                   // IP address, CIDR subnet or peer ID
+	UnbanAt       int64                  `protobuf:"varint,2,opt,name=unban_at,json=unbanAt,proto3" json:"unban_at,omitempty"` // unix time in seconds at which the ban expires
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanEntry) Reset() {
+	*x = BanEntry{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanEntry) ProtoMessage() {}
+
+func (x *BanEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanEntry.ProtoReflect.Descriptor instead.
+func (*BanEntry) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BanEntry) GetBanned() string {
+	if x != nil {
+		return x.Banned
+	}
+	return ""
+}
+
+func (x *BanEntry) GetUnbanAt() int64 {
+	if x != nil {
+		return x.UnbanAt
+	}
+	return 0
+}
+
 type ClearBannedResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
@@ -643,7 +764,7 @@ type ClearBannedResponse struct {
 
 func (x *ClearBannedResponse) Reset() {
 	*x = ClearBannedResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[9]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -655,7 +776,7 @@ func (x *ClearBannedResponse) String() string {
 func (*ClearBannedResponse) ProtoMessage() {}
 
 func (x *ClearBannedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[9]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -668,7 +789,7 @@ func (x *ClearBannedResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearBannedResponse.ProtoReflect.Descriptor instead.
 func (*ClearBannedResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{9}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ClearBannedResponse) GetOk() bool {
@@ -682,13 +803,14 @@ type AddBanScoreRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
 	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Score         int32                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"` // points added to the ban score instead of the points of the reason, when greater than 0
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AddBanScoreRequest) Reset() {
 	*x = AddBanScoreRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[10]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -700,7 +822,7 @@ func (x *AddBanScoreRequest) String() string {
 func (*AddBanScoreRequest) ProtoMessage() {}
 
 func (x *AddBanScoreRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[10]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -713,7 +835,7 @@ func (x *AddBanScoreRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddBanScoreRequest.ProtoReflect.Descriptor instead.
 func (*AddBanScoreRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{10}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *AddBanScoreRequest) GetPeerId() string {
@@ -730,6 +852,13 @@ func (x *AddBanScoreRequest) GetReason() string {
 	return ""
 }
 
+func (x *AddBanScoreRequest) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
 type AddBanScoreResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
@@ -739,7 +868,7 @@ type AddBanScoreResponse struct {
 
 func (x *AddBanScoreResponse) Reset() {
 	*x = AddBanScoreResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[11]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -751,7 +880,7 @@ func (x *AddBanScoreResponse) String() string {
 func (*AddBanScoreResponse) ProtoMessage() {}
 
 func (x *AddBanScoreResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[11]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,7 +893,7 @@ func (x *AddBanScoreResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddBanScoreResponse.ProtoReflect.Descriptor instead.
 func (*AddBanScoreResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{11}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *AddBanScoreResponse) GetOk() bool {
@@ -783,7 +912,7 @@ type ConnectPeerRequest struct {
 
 func (x *ConnectPeerRequest) Reset() {
 	*x = ConnectPeerRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[12]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -795,7 +924,7 @@ func (x *ConnectPeerRequest) String() string {
 func (*ConnectPeerRequest) ProtoMessage() {}
 
 func (x *ConnectPeerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[12]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -808,7 +937,7 @@ func (x *ConnectPeerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectPeerRequest.ProtoReflect.Descriptor instead.
 func (*ConnectPeerRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{12}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ConnectPeerRequest) GetPeerAddress() string {
@@ -828,7 +957,7 @@ type ConnectPeerResponse struct {
 
 func (x *ConnectPeerResponse) Reset() {
 	*x = ConnectPeerResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[13]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -840,7 +969,7 @@ func (x *ConnectPeerResponse) String() string {
 func (*ConnectPeerResponse) ProtoMessage() {}
 
 func (x *ConnectPeerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[13]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -853,7 +982,7 @@ func (x *ConnectPeerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectPeerResponse.ProtoReflect.Descriptor instead.
 func (*ConnectPeerResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{13}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ConnectPeerResponse) GetSuccess() bool {
@@ -879,7 +1008,7 @@ type DisconnectPeerRequest struct {
 
 func (x *DisconnectPeerRequest) Reset() {
 	*x = DisconnectPeerRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[14]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -891,7 +1020,7 @@ func (x *DisconnectPeerRequest) String() string {
 func (*DisconnectPeerRequest) ProtoMessage() {}
 
 func (x *DisconnectPeerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[14]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -904,7 +1033,7 @@ func (x *DisconnectPeerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisconnectPeerRequest.ProtoReflect.Descriptor instead.
 func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{14}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DisconnectPeerRequest) GetPeerId() string {
@@ -924,7 +1053,7 @@ type DisconnectPeerResponse struct {
 
 func (x *DisconnectPeerResponse) Reset() {
 	*x = DisconnectPeerResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[15]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -936,7 +1065,7 @@ func (x *DisconnectPeerResponse) String() string {
 func (*DisconnectPeerResponse) ProtoMessage() {}
 
 func (x *DisconnectPeerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[15]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -949,7 +1078,7 @@ func (x *DisconnectPeerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisconnectPeerResponse.ProtoReflect.Descriptor instead.
 func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{15}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DisconnectPeerResponse) GetSuccess() bool {
@@ -976,7 +1105,7 @@ type RecordCatchupAttemptRequest struct {
 
 func (x *RecordCatchupAttemptRequest) Reset() {
 	*x = RecordCatchupAttemptRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[16]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -988,7 +1117,7 @@ func (x *RecordCatchupAttemptRequest) String() string {
 func (*RecordCatchupAttemptRequest) ProtoMessage() {}
 
 func (x *RecordCatchupAttemptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[16]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1001,7 +1130,7 @@ func (x *RecordCatchupAttemptRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordCatchupAttemptRequest.ProtoReflect.Descriptor instead.
 func (*RecordCatchupAttemptRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{16}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RecordCatchupAttemptRequest) GetPeerId() string {
@@ -1020,7 +1149,7 @@ type RecordCatchupAttemptResponse struct {
 
 func (x *RecordCatchupAttemptResponse) Reset() {
 	*x = RecordCatchupAttemptResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[17]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1032,7 +1161,7 @@ func (x *RecordCatchupAttemptResponse) String() string {
 func (*RecordCatchupAttemptResponse) ProtoMessage() {}
 
 func (x *RecordCatchupAttemptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[17]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1045,7 +1174,7 @@ func (x *RecordCatchupAttemptResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordCatchupAttemptResponse.ProtoReflect.Descriptor instead.
 func (*RecordCatchupAttemptResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{17}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *RecordCatchupAttemptResponse) GetOk() bool {
@@ -1065,7 +1194,7 @@ type RecordCatchupSuccessRequest struct {
 
 func (x *RecordCatchupSuccessRequest) Reset() {
 	*x = RecordCatchupSuccessRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[18]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1077,7 +1206,7 @@ func (x *RecordCatchupSuccessRequest) String() string {
 func (*RecordCatchupSuccessRequest) ProtoMessage() {}
 
 func (x *RecordCatchupSuccessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[18]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1090,7 +1219,7 @@ func (x *RecordCatchupSuccessRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordCatchupSuccessRequest.ProtoReflect.Descriptor instead.
 func (*RecordCatchupSuccessRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{18}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *RecordCatchupSuccessRequest) GetPeerId() string {
@@ -1116,7 +1245,7 @@ type RecordCatchupSuccessResponse struct {
 
 func (x *RecordCatchupSuccessResponse) Reset() {
 	*x = RecordCatchupSuccessResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[19]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1128,7 +1257,7 @@ func (x *RecordCatchupSuccessResponse) String() string {
 func (*RecordCatchupSuccessResponse) ProtoMessage() {}
 
 func (x *RecordCatchupSuccessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[19]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1141,7 +1270,7 @@ func (x *RecordCatchupSuccessResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordCatchupSuccessResponse.ProtoReflect.Descriptor instead.
 func (*RecordCatchupSuccessResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{19}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RecordCatchupSuccessResponse) GetOk() bool {
@@ -1160,7 +1289,7 @@ type RecordCatchupFailureRequest struct {
 
 func (x *RecordCatchupFailureRequest) Reset() {
 	*x = RecordCatchupFailureRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[20]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1172,7 +1301,7 @@ func (x *RecordCatchupFailureRequest) String() string {
 func (*RecordCatchupFailureRequest) ProtoMessage() {}
 
 func (x *RecordCatchupFailureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[20]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1185,7 +1314,7 @@ func (x *RecordCatchupFailureRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordCatchupFailureRequest.ProtoReflect.Descriptor instead.
 func (*RecordCatchupFailureRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{20}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *RecordCatchupFailureRequest) GetPeerId() string {
@@ -1204,7 +1333,7 @@ type RecordCatchupFailureResponse struct {
 
 func (x *RecordCatchupFailureResponse) Reset() {
 	*x = RecordCatchupFailureResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[21]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1216,7 +1345,7 @@ func (x *RecordCatchupFailureResponse) String() string {
 func (*RecordCatchupFailureResponse) ProtoMessage() {}
 
 func (x *RecordCatchupFailureResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[21]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1229,7 +1358,7 @@ func (x *RecordCatchupFailureResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordCatchupFailureResponse.ProtoReflect.Descriptor instead.
 func (*RecordCatchupFailureResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{21}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *RecordCatchupFailureResponse) GetOk() bool {
@@ -1248,7 +1377,7 @@ type RecordCatchupMaliciousRequest struct {
 
 func (x *RecordCatchupMaliciousRequest) Reset() {
 	*x = RecordCatchupMaliciousRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[22]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1260,7 +1389,7 @@ func (x *RecordCatchupMaliciousRequest) String() string {
 func (*RecordCatchupMaliciousRequest) ProtoMessage() {}
 
 func (x *RecordCatchupMaliciousRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[22]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1273,7 +1402,7 @@ func (x *RecordCatchupMaliciousRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordCatchupMaliciousRequest.ProtoReflect.Descriptor instead.
 func (*RecordCatchupMaliciousRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{22}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *RecordCatchupMaliciousRequest) GetPeerId() string {
@@ -1292,7 +1421,7 @@ type RecordCatchupMaliciousResponse struct {
 
 func (x *RecordCatchupMaliciousResponse) Reset() {
 	*x = RecordCatchupMaliciousResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[23]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1304,7 +1433,7 @@ func (x *RecordCatchupMaliciousResponse) String() string {
 func (*RecordCatchupMaliciousResponse) ProtoMessage() {}
 
 func (x *RecordCatchupMaliciousResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[23]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1317,7 +1446,7 @@ func (x *RecordCatchupMaliciousResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordCatchupMaliciousResponse.ProtoReflect.Descriptor instead.
 func (*RecordCatchupMaliciousResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{23}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *RecordCatchupMaliciousResponse) GetOk() bool {
@@ -1337,7 +1466,7 @@ type UpdateCatchupReputationRequest struct {
 
 func (x *UpdateCatchupReputationRequest) Reset() {
 	*x = UpdateCatchupReputationRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[24]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1349,7 +1478,7 @@ func (x *UpdateCatchupReputationRequest) String() string {
 func (*UpdateCatchupReputationRequest) ProtoMessage() {}
 
 func (x *UpdateCatchupReputationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[24]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1362,7 +1491,7 @@ func (x *UpdateCatchupReputationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCatchupReputationRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCatchupReputationRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{24}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *UpdateCatchupReputationRequest) GetPeerId() string {
@@ -1388,7 +1517,7 @@ type UpdateCatchupReputationResponse struct {
 
 func (x *UpdateCatchupReputationResponse) Reset() {
 	*x = UpdateCatchupReputationResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[25]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1400,7 +1529,7 @@ func (x *UpdateCatchupReputationResponse) String() string {
 func (*UpdateCatchupReputationResponse) ProtoMessage() {}
 
 func (x *UpdateCatchupReputationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[25]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1413,7 +1542,7 @@ func (x *UpdateCatchupReputationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCatchupReputationResponse.ProtoReflect.Descriptor instead.
 func (*UpdateCatchupReputationResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{25}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *UpdateCatchupReputationResponse) GetOk() bool {
@@ -1433,7 +1562,7 @@ type UpdateCatchupErrorRequest struct {
 
 func (x *UpdateCatchupErrorRequest) Reset() {
 	*x = UpdateCatchupErrorRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[26]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1445,7 +1574,7 @@ func (x *UpdateCatchupErrorRequest) String() string {
 func (*UpdateCatchupErrorRequest) ProtoMessage() {}
 
 func (x *UpdateCatchupErrorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[26]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1458,7 +1587,7 @@ func (x *UpdateCatchupErrorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCatchupErrorRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCatchupErrorRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{26}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *UpdateCatchupErrorRequest) GetPeerId() string {
@@ -1484,7 +1613,7 @@ type UpdateCatchupErrorResponse struct {
 
 func (x *UpdateCatchupErrorResponse) Reset() {
 	*x = UpdateCatchupErrorResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[27]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1496,7 +1625,7 @@ func (x *UpdateCatchupErrorResponse) String() string {
 func (*UpdateCatchupErrorResponse) ProtoMessage() {}
 
 func (x *UpdateCatchupErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[27]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1509,7 +1638,7 @@ func (x *UpdateCatchupErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCatchupErrorResponse.ProtoReflect.Descriptor instead.
 func (*UpdateCatchupErrorResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{27}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *UpdateCatchupErrorResponse) GetOk() bool {
@@ -1527,7 +1656,7 @@ type GetPeersForCatchupRequest struct {
 
 func (x *GetPeersForCatchupRequest) Reset() {
 	*x = GetPeersForCatchupRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[28]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1539,7 +1668,7 @@ func (x *GetPeersForCatchupRequest) String() string {
 func (*GetPeersForCatchupRequest) ProtoMessage() {}
 
 func (x *GetPeersForCatchupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[28]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1552,7 +1681,7 @@ func (x *GetPeersForCatchupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPeersForCatchupRequest.ProtoReflect.Descriptor instead.
 func (*GetPeersForCatchupRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{28}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{29}
 }
 
 type PeerInfoForCatchup struct {
@@ -1565,13 +1694,14 @@ type PeerInfoForCatchup struct {
 	CatchupAttempts        int64                  `protobuf:"varint,6,opt,name=catchup_attempts,json=catchupAttempts,proto3" json:"catchup_attempts,omitempty"`
 	CatchupSuccesses       int64                  `protobuf:"varint,7,opt,name=catchup_successes,json=catchupSuccesses,proto3" json:"catchup_successes,omitempty"`
 	CatchupFailures        int64                  `protobuf:"varint,8,opt,name=catchup_failures,json=catchupFailures,proto3" json:"catchup_failures,omitempty"`
+	DataHubMirrorUrls      []string               `protobuf:"bytes,9,rep,name=data_hub_mirror_urls,json=dataHubMirrorUrls,proto3" json:"data_hub_mirror_urls,omitempty"` // Mirrors of the DataHub, tried in order when the DataHub URL fails
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
 func (x *PeerInfoForCatchup) Reset() {
 	*x = PeerInfoForCatchup{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[29]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1583,7 +1713,7 @@ func (x *PeerInfoForCatchup) String() string {
 func (*PeerInfoForCatchup) ProtoMessage() {}
 
 func (x *PeerInfoForCatchup) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[29]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1596,7 +1726,7 @@ func (x *PeerInfoForCatchup) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerInfoForCatchup.ProtoReflect.Descriptor instead.
 func (*PeerInfoForCatchup) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{29}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *PeerInfoForCatchup) GetId() string {
@@ -1655,6 +1785,13 @@ func (x *PeerInfoForCatchup) GetCatchupFailures() int64 {
 	return 0
 }
 
+func (x *PeerInfoForCatchup) GetDataHubMirrorUrls() []string {
+	if x != nil {
+		return x.DataHubMirrorUrls
+	}
+	return nil
+}
+
 type GetPeersForCatchupResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Peers         []*PeerInfoForCatchup  `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
@@ -1664,7 +1801,7 @@ type GetPeersForCatchupResponse struct {
 
 func (x *GetPeersForCatchupResponse) Reset() {
 	*x = GetPeersForCatchupResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[30]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1676,7 +1813,7 @@ func (x *GetPeersForCatchupResponse) String() string {
 func (*GetPeersForCatchupResponse) ProtoMessage() {}
 
 func (x *GetPeersForCatchupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[30]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1689,7 +1826,7 @@ func (x *GetPeersForCatchupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPeersForCatchupResponse.ProtoReflect.Descriptor instead.
 func (*GetPeersForCatchupResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{30}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *GetPeersForCatchupResponse) GetPeers() []*PeerInfoForCatchup {
@@ -1710,7 +1847,7 @@ type ReportValidSubtreeRequest struct {
 
 func (x *ReportValidSubtreeRequest) Reset() {
 	*x = ReportValidSubtreeRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[31]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1722,7 +1859,7 @@ func (x *ReportValidSubtreeRequest) String() string {
 func (*ReportValidSubtreeRequest) ProtoMessage() {}
 
 func (x *ReportValidSubtreeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[31]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1735,7 +1872,7 @@ func (x *ReportValidSubtreeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportValidSubtreeRequest.ProtoReflect.Descriptor instead.
 func (*ReportValidSubtreeRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{31}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ReportValidSubtreeRequest) GetPeerId() string {
@@ -1762,7 +1899,7 @@ type ReportValidSubtreeResponse struct {
 
 func (x *ReportValidSubtreeResponse) Reset() {
 	*x = ReportValidSubtreeResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[32]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1774,7 +1911,7 @@ func (x *ReportValidSubtreeResponse) String() string {
 func (*ReportValidSubtreeResponse) ProtoMessage() {}
 
 func (x *ReportValidSubtreeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[32]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1787,7 +1924,7 @@ func (x *ReportValidSubtreeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportValidSubtreeResponse.ProtoReflect.Descriptor instead.
 func (*ReportValidSubtreeResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{32}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ReportValidSubtreeResponse) GetSuccess() bool {
@@ -1815,7 +1952,7 @@ type ReportValidBlockRequest struct {
 
 func (x *ReportValidBlockRequest) Reset() {
 	*x = ReportValidBlockRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[33]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1827,7 +1964,7 @@ func (x *ReportValidBlockRequest) String() string {
 func (*ReportValidBlockRequest) ProtoMessage() {}
 
 func (x *ReportValidBlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[33]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1840,7 +1977,7 @@ func (x *ReportValidBlockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportValidBlockRequest.ProtoReflect.Descriptor instead.
 func (*ReportValidBlockRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{33}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *ReportValidBlockRequest) GetPeerId() string {
@@ -1867,7 +2004,7 @@ type ReportValidBlockResponse struct {
 
 func (x *ReportValidBlockResponse) Reset() {
 	*x = ReportValidBlockResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[34]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1879,7 +2016,7 @@ func (x *ReportValidBlockResponse) String() string {
 func (*ReportValidBlockResponse) ProtoMessage() {}
 
 func (x *ReportValidBlockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[34]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1892,7 +2029,7 @@ func (x *ReportValidBlockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportValidBlockResponse.ProtoReflect.Descriptor instead.
 func (*ReportValidBlockResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{34}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ReportValidBlockResponse) GetSuccess() bool {
@@ -1909,29 +2046,32 @@ func (x *ReportValidBlockResponse) GetMessage() string {
 	return ""
 }
 
-// Messages for peer status checking
-type IsPeerMaliciousRequest struct {
+// Report invalid subtree reception
+type RecordInvalidSubtreeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"` // Peer ID that provided the subtree
+	SubtreeHash   string                 `protobuf:"bytes,2,opt,name=subtree_hash,json=subtreeHash,proto3" json:"subtree_hash,omitempty"`
+	Reason        InvalidDataReason      `protobuf:"varint,3,opt,name=reason,proto3,enum=p2p_api.InvalidDataReason" json:"reason,omitempty"`
+	Details       string                 `protobuf:"bytes,4,opt,name=details,proto3" json:"details,omitempty"` // Optional description of why the subtree is invalid
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IsPeerMaliciousRequest) Reset() {
-	*x = IsPeerMaliciousRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[35]
+func (x *RecordInvalidSubtreeRequest) Reset() {
+	*x = RecordInvalidSubtreeRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IsPeerMaliciousRequest) String() string {
+func (x *RecordInvalidSubtreeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IsPeerMaliciousRequest) ProtoMessage() {}
+func (*RecordInvalidSubtreeRequest) ProtoMessage() {}
 
-func (x *IsPeerMaliciousRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[35]
+func (x *RecordInvalidSubtreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1942,9 +2082,248 @@ func (x *IsPeerMaliciousRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IsPeerMaliciousRequest.ProtoReflect.Descriptor instead.
-func (*IsPeerMaliciousRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use RecordInvalidSubtreeRequest.ProtoReflect.Descriptor instead.
+func (*RecordInvalidSubtreeRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RecordInvalidSubtreeRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *RecordInvalidSubtreeRequest) GetSubtreeHash() string {
+	if x != nil {
+		return x.SubtreeHash
+	}
+	return ""
+}
+
+func (x *RecordInvalidSubtreeRequest) GetReason() InvalidDataReason {
+	if x != nil {
+		return x.Reason
+	}
+	return InvalidDataReason_INVALID_DATA_REASON_UNSPECIFIED
+}
+
+func (x *RecordInvalidSubtreeRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+type RecordInvalidSubtreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordInvalidSubtreeResponse) Reset() {
+	*x = RecordInvalidSubtreeResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordInvalidSubtreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordInvalidSubtreeResponse) ProtoMessage() {}
+
+func (x *RecordInvalidSubtreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordInvalidSubtreeResponse.ProtoReflect.Descriptor instead.
+func (*RecordInvalidSubtreeResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RecordInvalidSubtreeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RecordInvalidSubtreeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Report invalid block reception
+type RecordInvalidBlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"` // Peer ID that provided the block
+	BlockHash     string                 `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Reason        InvalidDataReason      `protobuf:"varint,3,opt,name=reason,proto3,enum=p2p_api.InvalidDataReason" json:"reason,omitempty"`
+	Details       string                 `protobuf:"bytes,4,opt,name=details,proto3" json:"details,omitempty"` // Optional description of why the block is invalid
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordInvalidBlockRequest) Reset() {
+	*x = RecordInvalidBlockRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordInvalidBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordInvalidBlockRequest) ProtoMessage() {}
+
+func (x *RecordInvalidBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordInvalidBlockRequest.ProtoReflect.Descriptor instead.
+func (*RecordInvalidBlockRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RecordInvalidBlockRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *RecordInvalidBlockRequest) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *RecordInvalidBlockRequest) GetReason() InvalidDataReason {
+	if x != nil {
+		return x.Reason
+	}
+	return InvalidDataReason_INVALID_DATA_REASON_UNSPECIFIED
+}
+
+func (x *RecordInvalidBlockRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+type RecordInvalidBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordInvalidBlockResponse) Reset() {
+	*x = RecordInvalidBlockResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordInvalidBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordInvalidBlockResponse) ProtoMessage() {}
+
+func (x *RecordInvalidBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordInvalidBlockResponse.ProtoReflect.Descriptor instead.
+func (*RecordInvalidBlockResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RecordInvalidBlockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RecordInvalidBlockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Messages for peer status checking
+type IsPeerMaliciousRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsPeerMaliciousRequest) Reset() {
+	*x = IsPeerMaliciousRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsPeerMaliciousRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsPeerMaliciousRequest) ProtoMessage() {}
+
+func (x *IsPeerMaliciousRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsPeerMaliciousRequest.ProtoReflect.Descriptor instead.
+func (*IsPeerMaliciousRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *IsPeerMaliciousRequest) GetPeerId() string {
@@ -1964,7 +2343,7 @@ type IsPeerMaliciousResponse struct {
 
 func (x *IsPeerMaliciousResponse) Reset() {
 	*x = IsPeerMaliciousResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[36]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1976,7 +2355,7 @@ func (x *IsPeerMaliciousResponse) String() string {
 func (*IsPeerMaliciousResponse) ProtoMessage() {}
 
 func (x *IsPeerMaliciousResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[36]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1989,7 +2368,7 @@ func (x *IsPeerMaliciousResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsPeerMaliciousResponse.ProtoReflect.Descriptor instead.
 func (*IsPeerMaliciousResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{36}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *IsPeerMaliciousResponse) GetIsMalicious() bool {
@@ -2015,7 +2394,7 @@ type IsPeerUnhealthyRequest struct {
 
 func (x *IsPeerUnhealthyRequest) Reset() {
 	*x = IsPeerUnhealthyRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[37]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2027,7 +2406,7 @@ func (x *IsPeerUnhealthyRequest) String() string {
 func (*IsPeerUnhealthyRequest) ProtoMessage() {}
 
 func (x *IsPeerUnhealthyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[37]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2040,7 +2419,7 @@ func (x *IsPeerUnhealthyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsPeerUnhealthyRequest.ProtoReflect.Descriptor instead.
 func (*IsPeerUnhealthyRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{37}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *IsPeerUnhealthyRequest) GetPeerId() string {
@@ -2061,7 +2440,7 @@ type IsPeerUnhealthyResponse struct {
 
 func (x *IsPeerUnhealthyResponse) Reset() {
 	*x = IsPeerUnhealthyResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[38]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2073,7 +2452,7 @@ func (x *IsPeerUnhealthyResponse) String() string {
 func (*IsPeerUnhealthyResponse) ProtoMessage() {}
 
 func (x *IsPeerUnhealthyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[38]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2086,7 +2465,7 @@ func (x *IsPeerUnhealthyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsPeerUnhealthyResponse.ProtoReflect.Descriptor instead.
 func (*IsPeerUnhealthyResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{38}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *IsPeerUnhealthyResponse) GetIsUnhealthy() bool {
@@ -2127,26 +2506,53 @@ type PeerRegistryInfo struct {
 	UrlResponsive   bool                   `protobuf:"varint,12,opt,name=url_responsive,json=urlResponsive,proto3" json:"url_responsive,omitempty"`
 	LastUrlCheck    int64                  `protobuf:"varint,13,opt,name=last_url_check,json=lastUrlCheck,proto3" json:"last_url_check,omitempty"` // Unix timestamp
 	// Interaction/catchup metrics
-	InteractionAttempts    int64   `protobuf:"varint,14,opt,name=interaction_attempts,json=interactionAttempts,proto3" json:"interaction_attempts,omitempty"`
-	InteractionSuccesses   int64   `protobuf:"varint,15,opt,name=interaction_successes,json=interactionSuccesses,proto3" json:"interaction_successes,omitempty"`
-	InteractionFailures    int64   `protobuf:"varint,16,opt,name=interaction_failures,json=interactionFailures,proto3" json:"interaction_failures,omitempty"`
-	LastInteractionAttempt int64   `protobuf:"varint,17,opt,name=last_interaction_attempt,json=lastInteractionAttempt,proto3" json:"last_interaction_attempt,omitempty"` // Unix timestamp
-	LastInteractionSuccess int64   `protobuf:"varint,18,opt,name=last_interaction_success,json=lastInteractionSuccess,proto3" json:"last_interaction_success,omitempty"` // Unix timestamp
-	LastInteractionFailure int64   `protobuf:"varint,19,opt,name=last_interaction_failure,json=lastInteractionFailure,proto3" json:"last_interaction_failure,omitempty"` // Unix timestamp
-	ReputationScore        float64 `protobuf:"fixed64,20,opt,name=reputation_score,json=reputationScore,proto3" json:"reputation_score,omitempty"`
-	MaliciousCount         int64   `protobuf:"varint,21,opt,name=malicious_count,json=maliciousCount,proto3" json:"malicious_count,omitempty"`
-	AvgResponseTimeMs      int64   `protobuf:"varint,22,opt,name=avg_response_time_ms,json=avgResponseTimeMs,proto3" json:"avg_response_time_ms,omitempty"`
-	Storage                string  `protobuf:"bytes,23,opt,name=storage,proto3" json:"storage,omitempty"`
-	ClientName             string  `protobuf:"bytes,24,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`                                    // Human-readable name of the client
-	LastCatchupError       string  `protobuf:"bytes,25,opt,name=last_catchup_error,json=lastCatchupError,proto3" json:"last_catchup_error,omitempty"`                // Last error message from catchup attempt
-	LastCatchupErrorTime   int64   `protobuf:"varint,26,opt,name=last_catchup_error_time,json=lastCatchupErrorTime,proto3" json:"last_catchup_error_time,omitempty"` // Unix timestamp of last catchup error
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	InteractionAttempts     int64    `protobuf:"varint,14,opt,name=interaction_attempts,json=interactionAttempts,proto3" json:"interaction_attempts,omitempty"`
+	InteractionSuccesses    int64    `protobuf:"varint,15,opt,name=interaction_successes,json=interactionSuccesses,proto3" json:"interaction_successes,omitempty"`
+	InteractionFailures     int64    `protobuf:"varint,16,opt,name=interaction_failures,json=interactionFailures,proto3" json:"interaction_failures,omitempty"`
+	LastInteractionAttempt  int64    `protobuf:"varint,17,opt,name=last_interaction_attempt,json=lastInteractionAttempt,proto3" json:"last_interaction_attempt,omitempty"` // Unix timestamp
+	LastInteractionSuccess  int64    `protobuf:"varint,18,opt,name=last_interaction_success,json=lastInteractionSuccess,proto3" json:"last_interaction_success,omitempty"` // Unix timestamp
+	LastInteractionFailure  int64    `protobuf:"varint,19,opt,name=last_interaction_failure,json=lastInteractionFailure,proto3" json:"last_interaction_failure,omitempty"` // Unix timestamp
+	ReputationScore         float64  `protobuf:"fixed64,20,opt,name=reputation_score,json=reputationScore,proto3" json:"reputation_score,omitempty"`
+	MaliciousCount          int64    `protobuf:"varint,21,opt,name=malicious_count,json=maliciousCount,proto3" json:"malicious_count,omitempty"`
+	AvgResponseTimeMs       int64    `protobuf:"varint,22,opt,name=avg_response_time_ms,json=avgResponseTimeMs,proto3" json:"avg_response_time_ms,omitempty"`
+	Storage                 string   `protobuf:"bytes,23,opt,name=storage,proto3" json:"storage,omitempty"`
+	ClientName              string   `protobuf:"bytes,24,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`                                           // Human-readable name of the client
+	LastCatchupError        string   `protobuf:"bytes,25,opt,name=last_catchup_error,json=lastCatchupError,proto3" json:"last_catchup_error,omitempty"`                       // Last error message from catchup attempt
+	LastCatchupErrorTime    int64    `protobuf:"varint,26,opt,name=last_catchup_error_time,json=lastCatchupErrorTime,proto3" json:"last_catchup_error_time,omitempty"`        // Unix timestamp of last catchup error
+	IsOnProbation           bool     `protobuf:"varint,27,opt,name=is_on_probation,json=isOnProbation,proto3" json:"is_on_probation,omitempty"`                               // Whether the peer is on probation after a ban
+	ProbationUntil          int64    `protobuf:"varint,28,opt,name=probation_until,json=probationUntil,proto3" json:"probation_until,omitempty"`                              // Unix timestamp when probation ends
+	BytesSent               uint64   `protobuf:"varint,29,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`                                             // Bytes served to this peer
+	IsThrottled             bool     `protobuf:"varint,30,opt,name=is_throttled,json=isThrottled,proto3" json:"is_throttled,omitempty"`                                       // Whether the peer is throttled for exceeding its bandwidth quota
+	IsHealthy               bool     `protobuf:"varint,31,opt,name=is_healthy,json=isHealthy,proto3" json:"is_healthy,omitempty"`                                             // Whether the last probe of the DataHub URL succeeded
+	HealthDurationMs        int64    `protobuf:"varint,32,opt,name=health_duration_ms,json=healthDurationMs,proto3" json:"health_duration_ms,omitempty"`                      // Latency of the last probe of the DataHub URL
+	HealthCheckFailures     int32    `protobuf:"varint,33,opt,name=health_check_failures,json=healthCheckFailures,proto3" json:"health_check_failures,omitempty"`             // Consecutive failed probes of the DataHub URL
+	IsDataHubDown           bool     `protobuf:"varint,34,opt,name=is_data_hub_down,json=isDataHubDown,proto3" json:"is_data_hub_down,omitempty"`                             // Whether the DataHub URL failed too many probes
+	IsRelayOnly             bool     `protobuf:"varint,35,opt,name=is_relay_only,json=isRelayOnly,proto3" json:"is_relay_only,omitempty"`                                     // Whether the peer is only reachable through a circuit relay
+	IsDatahubUrlVerified    bool     `protobuf:"varint,36,opt,name=is_datahub_url_verified,json=isDatahubUrlVerified,proto3" json:"is_datahub_url_verified,omitempty"`        // Whether the DataHub URL served an identity document signed by the peer
+	DoubleSpendCount        int64    `protobuf:"varint,37,opt,name=double_spend_count,json=doubleSpendCount,proto3" json:"double_spend_count,omitempty"`                      // Number of double spending transactions received from this peer
+	LastDoubleSpend         int64    `protobuf:"varint,38,opt,name=last_double_spend,json=lastDoubleSpend,proto3" json:"last_double_spend,omitempty"`                         // Unix timestamp of the last double spending transaction received from this peer
+	MinerId                 string   `protobuf:"bytes,39,opt,name=miner_id,json=minerId,proto3" json:"miner_id,omitempty"`                                                    // Miner ID of the mining operator the peer belongs to, from its signed announcements
+	MinerPublicKey          string   `protobuf:"bytes,40,opt,name=miner_public_key,json=minerPublicKey,proto3" json:"miner_public_key,omitempty"`                             // Hex encoded public key the miner ID announcement is signed with
+	MinerContact            string   `protobuf:"bytes,41,opt,name=miner_contact,json=minerContact,proto3" json:"miner_contact,omitempty"`                                     // Contact of the mining operator
+	MinerIdAnnouncedAt      int64    `protobuf:"varint,42,opt,name=miner_id_announced_at,json=minerIdAnnouncedAt,proto3" json:"miner_id_announced_at,omitempty"`              // Unix timestamp the last accepted miner ID announcement was signed at
+	InvalidBlocksReceived   int64    `protobuf:"varint,43,opt,name=invalid_blocks_received,json=invalidBlocksReceived,proto3" json:"invalid_blocks_received,omitempty"`       // Number of invalid blocks received from this peer
+	InvalidSubtreesReceived int64    `protobuf:"varint,44,opt,name=invalid_subtrees_received,json=invalidSubtreesReceived,proto3" json:"invalid_subtrees_received,omitempty"` // Number of invalid subtrees received from this peer
+	LastInvalidData         int64    `protobuf:"varint,45,opt,name=last_invalid_data,json=lastInvalidData,proto3" json:"last_invalid_data,omitempty"`                         // Unix timestamp of the last invalid block or subtree received from this peer
+	LastInvalidDataReason   string   `protobuf:"bytes,46,opt,name=last_invalid_data_reason,json=lastInvalidDataReason,proto3" json:"last_invalid_data_reason,omitempty"`      // Reason the last invalid block or subtree was rejected
+	Transport               string   `protobuf:"bytes,47,opt,name=transport,proto3" json:"transport,omitempty"`                                                               // Transport of the live connections to the peer: tcp, quic or relay
+	Country                 string   `protobuf:"bytes,48,opt,name=country,proto3" json:"country,omitempty"`                                                                   // ISO country code of the address of the peer, from the GeoIP country database
+	Asn                     uint32   `protobuf:"varint,49,opt,name=asn,proto3" json:"asn,omitempty"`                                                                          // Autonomous system number of the address of the peer, from the GeoIP ASN database
+	AsOrganization          string   `protobuf:"bytes,50,opt,name=as_organization,json=asOrganization,proto3" json:"as_organization,omitempty"`                               // Organization the autonomous system of the peer is registered to
+	ProtocolVersion         uint32   `protobuf:"varint,51,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`                           // Wire protocol version negotiated with the peer, 0 before its first node status
+	IsProtocolIncompatible  bool     `protobuf:"varint,52,opt,name=is_protocol_incompatible,json=isProtocolIncompatible,proto3" json:"is_protocol_incompatible,omitempty"`    // Whether the peer has no wire protocol version in common with this node
+	DataHubMirrorUrls       []string `protobuf:"bytes,54,rep,name=data_hub_mirror_urls,json=dataHubMirrorUrls,proto3" json:"data_hub_mirror_urls,omitempty"`                  // Mirrors of the DataHub advertised by the peer, tried in order when the DataHub URL fails
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *PeerRegistryInfo) Reset() {
 	*x = PeerRegistryInfo{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[39]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2158,7 +2564,7 @@ func (x *PeerRegistryInfo) String() string {
 func (*PeerRegistryInfo) ProtoMessage() {}
 
 func (x *PeerRegistryInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[39]
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2171,7 +2577,7 @@ func (x *PeerRegistryInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerRegistryInfo.ProtoReflect.Descriptor instead.
 func (*PeerRegistryInfo) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{39}
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *PeerRegistryInfo) GetId() string {
@@ -2188,196 +2594,1648 @@ func (x *PeerRegistryInfo) GetHeight() int32 {
 	return 0
 }
 
-func (x *PeerRegistryInfo) GetBlockHash() string {
+func (x *PeerRegistryInfo) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetDataHubUrl() string {
+	if x != nil {
+		return x.DataHubUrl
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetBanScore() int32 {
+	if x != nil {
+		return x.BanScore
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetIsBanned() bool {
+	if x != nil {
+		return x.IsBanned
+	}
+	return false
+}
+
+func (x *PeerRegistryInfo) GetIsConnected() bool {
+	if x != nil {
+		return x.IsConnected
+	}
+	return false
+}
+
+func (x *PeerRegistryInfo) GetConnectedAt() int64 {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetLastBlockTime() int64 {
+	if x != nil {
+		return x.LastBlockTime
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetLastMessageTime() int64 {
+	if x != nil {
+		return x.LastMessageTime
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetUrlResponsive() bool {
+	if x != nil {
+		return x.UrlResponsive
+	}
+	return false
+}
+
+func (x *PeerRegistryInfo) GetLastUrlCheck() int64 {
+	if x != nil {
+		return x.LastUrlCheck
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetInteractionAttempts() int64 {
+	if x != nil {
+		return x.InteractionAttempts
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetInteractionSuccesses() int64 {
+	if x != nil {
+		return x.InteractionSuccesses
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetInteractionFailures() int64 {
+	if x != nil {
+		return x.InteractionFailures
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetLastInteractionAttempt() int64 {
+	if x != nil {
+		return x.LastInteractionAttempt
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetLastInteractionSuccess() int64 {
+	if x != nil {
+		return x.LastInteractionSuccess
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetLastInteractionFailure() int64 {
+	if x != nil {
+		return x.LastInteractionFailure
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetReputationScore() float64 {
+	if x != nil {
+		return x.ReputationScore
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetMaliciousCount() int64 {
+	if x != nil {
+		return x.MaliciousCount
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetAvgResponseTimeMs() int64 {
+	if x != nil {
+		return x.AvgResponseTimeMs
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetStorage() string {
+	if x != nil {
+		return x.Storage
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetLastCatchupError() string {
+	if x != nil {
+		return x.LastCatchupError
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetLastCatchupErrorTime() int64 {
+	if x != nil {
+		return x.LastCatchupErrorTime
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetIsOnProbation() bool {
+	if x != nil {
+		return x.IsOnProbation
+	}
+	return false
+}
+
+func (x *PeerRegistryInfo) GetProbationUntil() int64 {
+	if x != nil {
+		return x.ProbationUntil
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetIsThrottled() bool {
+	if x != nil {
+		return x.IsThrottled
+	}
+	return false
+}
+
+func (x *PeerRegistryInfo) GetIsHealthy() bool {
+	if x != nil {
+		return x.IsHealthy
+	}
+	return false
+}
+
+func (x *PeerRegistryInfo) GetHealthDurationMs() int64 {
+	if x != nil {
+		return x.HealthDurationMs
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetHealthCheckFailures() int32 {
+	if x != nil {
+		return x.HealthCheckFailures
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetIsDataHubDown() bool {
+	if x != nil {
+		return x.IsDataHubDown
+	}
+	return false
+}
+
+func (x *PeerRegistryInfo) GetIsRelayOnly() bool {
+	if x != nil {
+		return x.IsRelayOnly
+	}
+	return false
+}
+
+func (x *PeerRegistryInfo) GetIsDatahubUrlVerified() bool {
+	if x != nil {
+		return x.IsDatahubUrlVerified
+	}
+	return false
+}
+
+func (x *PeerRegistryInfo) GetDoubleSpendCount() int64 {
+	if x != nil {
+		return x.DoubleSpendCount
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetLastDoubleSpend() int64 {
+	if x != nil {
+		return x.LastDoubleSpend
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetMinerId() string {
+	if x != nil {
+		return x.MinerId
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetMinerPublicKey() string {
+	if x != nil {
+		return x.MinerPublicKey
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetMinerContact() string {
+	if x != nil {
+		return x.MinerContact
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetMinerIdAnnouncedAt() int64 {
+	if x != nil {
+		return x.MinerIdAnnouncedAt
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetInvalidBlocksReceived() int64 {
+	if x != nil {
+		return x.InvalidBlocksReceived
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetInvalidSubtreesReceived() int64 {
+	if x != nil {
+		return x.InvalidSubtreesReceived
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetLastInvalidData() int64 {
+	if x != nil {
+		return x.LastInvalidData
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetLastInvalidDataReason() string {
+	if x != nil {
+		return x.LastInvalidDataReason
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetTransport() string {
+	if x != nil {
+		return x.Transport
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetAsn() uint32 {
+	if x != nil {
+		return x.Asn
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetAsOrganization() string {
+	if x != nil {
+		return x.AsOrganization
+	}
+	return ""
+}
+
+func (x *PeerRegistryInfo) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *PeerRegistryInfo) GetIsProtocolIncompatible() bool {
+	if x != nil {
+		return x.IsProtocolIncompatible
+	}
+	return false
+}
+
+func (x *PeerRegistryInfo) GetDataHubMirrorUrls() []string {
+	if x != nil {
+		return x.DataHubMirrorUrls
+	}
+	return nil
+}
+
+type GetPeerRegistryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Peers         []*PeerRegistryInfo    `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerRegistryResponse) Reset() {
+	*x = GetPeerRegistryResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerRegistryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerRegistryResponse) ProtoMessage() {}
+
+func (x *GetPeerRegistryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerRegistryResponse.ProtoReflect.Descriptor instead.
+func (*GetPeerRegistryResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetPeerRegistryResponse) GetPeers() []*PeerRegistryInfo {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+// Record bytes downloaded via HTTP from a peer
+type RecordBytesDownloadedRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PeerId          string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`                             // Peer ID that provided the data
+	BytesDownloaded uint64                 `protobuf:"varint,2,opt,name=bytes_downloaded,json=bytesDownloaded,proto3" json:"bytes_downloaded,omitempty"` // Number of bytes downloaded
+	DataType        string                 `protobuf:"bytes,3,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`                       // block, subtree or subtree_data, empty when unknown
+	Items           uint64                 `protobuf:"varint,4,opt,name=items,proto3" json:"items,omitempty"`                                            // Number of items of the data type downloaded
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RecordBytesDownloadedRequest) Reset() {
+	*x = RecordBytesDownloadedRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordBytesDownloadedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordBytesDownloadedRequest) ProtoMessage() {}
+
+func (x *RecordBytesDownloadedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordBytesDownloadedRequest.ProtoReflect.Descriptor instead.
+func (*RecordBytesDownloadedRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RecordBytesDownloadedRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *RecordBytesDownloadedRequest) GetBytesDownloaded() uint64 {
+	if x != nil {
+		return x.BytesDownloaded
+	}
+	return 0
+}
+
+func (x *RecordBytesDownloadedRequest) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *RecordBytesDownloadedRequest) GetItems() uint64 {
+	if x != nil {
+		return x.Items
+	}
+	return 0
+}
+
+type RecordBytesDownloadedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordBytesDownloadedResponse) Reset() {
+	*x = RecordBytesDownloadedResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordBytesDownloadedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordBytesDownloadedResponse) ProtoMessage() {}
+
+func (x *RecordBytesDownloadedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordBytesDownloadedResponse.ProtoReflect.Descriptor instead.
+func (*RecordBytesDownloadedResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RecordBytesDownloadedResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+// Record bytes served to a peer
+type RecordBytesUploadedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`                       // Peer ID the data was served to
+	BytesUploaded uint64                 `protobuf:"varint,2,opt,name=bytes_uploaded,json=bytesUploaded,proto3" json:"bytes_uploaded,omitempty"` // Number of bytes uploaded
+	DataType      string                 `protobuf:"bytes,3,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`                 // block, subtree or subtree_data, empty when unknown
+	Items         uint64                 `protobuf:"varint,4,opt,name=items,proto3" json:"items,omitempty"`                                      // Number of items of the data type uploaded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordBytesUploadedRequest) Reset() {
+	*x = RecordBytesUploadedRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordBytesUploadedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordBytesUploadedRequest) ProtoMessage() {}
+
+func (x *RecordBytesUploadedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordBytesUploadedRequest.ProtoReflect.Descriptor instead.
+func (*RecordBytesUploadedRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RecordBytesUploadedRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *RecordBytesUploadedRequest) GetBytesUploaded() uint64 {
+	if x != nil {
+		return x.BytesUploaded
+	}
+	return 0
+}
+
+func (x *RecordBytesUploadedRequest) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *RecordBytesUploadedRequest) GetItems() uint64 {
+	if x != nil {
+		return x.Items
+	}
+	return 0
+}
+
+type RecordBytesUploadedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordBytesUploadedResponse) Reset() {
+	*x = RecordBytesUploadedResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordBytesUploadedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordBytesUploadedResponse) ProtoMessage() {}
+
+func (x *RecordBytesUploadedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordBytesUploadedResponse.ProtoReflect.Descriptor instead.
+func (*RecordBytesUploadedResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RecordBytesUploadedResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+type GetPeerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerRequest) Reset() {
+	*x = GetPeerRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerRequest) ProtoMessage() {}
+
+func (x *GetPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerRequest.ProtoReflect.Descriptor instead.
+func (*GetPeerRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetPeerRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+type GetPeerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Peer          *PeerRegistryInfo      `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"` // Will be null/empty if peer not found
+	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerResponse) Reset() {
+	*x = GetPeerResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerResponse) ProtoMessage() {}
+
+func (x *GetPeerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerResponse.ProtoReflect.Descriptor instead.
+func (*GetPeerResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetPeerResponse) GetPeer() *PeerRegistryInfo {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *GetPeerResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+// Aggregate view of the network built from the peer registry
+type HeightCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        int32                  `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	PeerCount     int32                  `protobuf:"varint,2,opt,name=peer_count,json=peerCount,proto3" json:"peer_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeightCount) Reset() {
+	*x = HeightCount{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeightCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeightCount) ProtoMessage() {}
+
+func (x *HeightCount) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeightCount.ProtoReflect.Descriptor instead.
+func (*HeightCount) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *HeightCount) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *HeightCount) GetPeerCount() int32 {
+	if x != nil {
+		return x.PeerCount
+	}
+	return 0
+}
+
+type ChainTip struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockHash     string                 `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Height        int32                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	PeerCount     int32                  `protobuf:"varint,3,opt,name=peer_count,json=peerCount,proto3" json:"peer_count,omitempty"` // Number of peers advertising this tip
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChainTip) Reset() {
+	*x = ChainTip{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChainTip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainTip) ProtoMessage() {}
+
+func (x *ChainTip) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainTip.ProtoReflect.Descriptor instead.
+func (*ChainTip) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ChainTip) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *ChainTip) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ChainTip) GetPeerCount() int32 {
+	if x != nil {
+		return x.PeerCount
+	}
+	return 0
+}
+
+type GetNetworkOverviewResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TotalPeers         int32                  `protobuf:"varint,1,opt,name=total_peers,json=totalPeers,proto3" json:"total_peers,omitempty"`
+	ConnectedPeers     int32                  `protobuf:"varint,2,opt,name=connected_peers,json=connectedPeers,proto3" json:"connected_peers,omitempty"`
+	BestHeight         int32                  `protobuf:"varint,3,opt,name=best_height,json=bestHeight,proto3" json:"best_height,omitempty"`                          // Highest height advertised by any peer
+	HeightDistribution []*HeightCount         `protobuf:"bytes,4,rep,name=height_distribution,json=heightDistribution,proto3" json:"height_distribution,omitempty"`   // Peer count per advertised height, highest first
+	ChainTips          []*ChainTip            `protobuf:"bytes,5,rep,name=chain_tips,json=chainTips,proto3" json:"chain_tips,omitempty"`                              // Most advertised chain tips, most peers first
+	AvgResponseTimeMs  int64                  `protobuf:"varint,6,opt,name=avg_response_time_ms,json=avgResponseTimeMs,proto3" json:"avg_response_time_ms,omitempty"` // Average response time over peers with measurements
+	PeersJoined        int32                  `protobuf:"varint,7,opt,name=peers_joined,json=peersJoined,proto3" json:"peers_joined,omitempty"`                       // Peers added to the registry within the churn window
+	PeersLeft          int32                  `protobuf:"varint,8,opt,name=peers_left,json=peersLeft,proto3" json:"peers_left,omitempty"`                             // Peers removed from the registry within the churn window
+	ChurnRate          float64                `protobuf:"fixed64,9,opt,name=churn_rate,json=churnRate,proto3" json:"churn_rate,omitempty"`                            // (joined + left) / total_peers over the churn window
+	ChurnWindowSeconds int64                  `protobuf:"varint,10,opt,name=churn_window_seconds,json=churnWindowSeconds,proto3" json:"churn_window_seconds,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetNetworkOverviewResponse) Reset() {
+	*x = GetNetworkOverviewResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNetworkOverviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetworkOverviewResponse) ProtoMessage() {}
+
+func (x *GetNetworkOverviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetworkOverviewResponse.ProtoReflect.Descriptor instead.
+func (*GetNetworkOverviewResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetNetworkOverviewResponse) GetTotalPeers() int32 {
+	if x != nil {
+		return x.TotalPeers
+	}
+	return 0
+}
+
+func (x *GetNetworkOverviewResponse) GetConnectedPeers() int32 {
+	if x != nil {
+		return x.ConnectedPeers
+	}
+	return 0
+}
+
+func (x *GetNetworkOverviewResponse) GetBestHeight() int32 {
+	if x != nil {
+		return x.BestHeight
+	}
+	return 0
+}
+
+func (x *GetNetworkOverviewResponse) GetHeightDistribution() []*HeightCount {
+	if x != nil {
+		return x.HeightDistribution
+	}
+	return nil
+}
+
+func (x *GetNetworkOverviewResponse) GetChainTips() []*ChainTip {
+	if x != nil {
+		return x.ChainTips
+	}
+	return nil
+}
+
+func (x *GetNetworkOverviewResponse) GetAvgResponseTimeMs() int64 {
+	if x != nil {
+		return x.AvgResponseTimeMs
+	}
+	return 0
+}
+
+func (x *GetNetworkOverviewResponse) GetPeersJoined() int32 {
+	if x != nil {
+		return x.PeersJoined
+	}
+	return 0
+}
+
+func (x *GetNetworkOverviewResponse) GetPeersLeft() int32 {
+	if x != nil {
+		return x.PeersLeft
+	}
+	return 0
+}
+
+func (x *GetNetworkOverviewResponse) GetChurnRate() float64 {
+	if x != nil {
+		return x.ChurnRate
+	}
+	return 0
+}
+
+func (x *GetNetworkOverviewResponse) GetChurnWindowSeconds() int64 {
+	if x != nil {
+		return x.ChurnWindowSeconds
+	}
+	return 0
+}
+
+// Peer lifecycle event log
+type PeerEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp in milliseconds
+	PeerId        string                 `protobuf:"bytes,2,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // connected, disconnected, ban_score, banned, unbanned, reputation_changed or catchup_*
+	Details       string                 `protobuf:"bytes,4,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerEvent) Reset() {
+	*x = PeerEvent{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerEvent) ProtoMessage() {}
+
+func (x *PeerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerEvent.ProtoReflect.Descriptor instead.
+func (*PeerEvent) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *PeerEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *PeerEvent) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *PeerEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PeerEvent) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+type GetPeerEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          int64                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`                  // Unix timestamp in milliseconds, 0 for no lower bound
+	To            int64                  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`                      // Unix timestamp in milliseconds, 0 for no upper bound
+	PeerId        string                 `protobuf:"bytes,3,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"` // Only return events of this peer, empty for all peers
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                // Maximum number of most recent events to return, 0 for all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerEventsRequest) Reset() {
+	*x = GetPeerEventsRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerEventsRequest) ProtoMessage() {}
+
+func (x *GetPeerEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetPeerEventsRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetPeerEventsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetPeerEventsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetPeerEventsRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *GetPeerEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetPeerEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*PeerEvent           `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // Matching events, oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerEventsResponse) Reset() {
+	*x = GetPeerEventsResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerEventsResponse) ProtoMessage() {}
+
+func (x *GetPeerEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetPeerEventsResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetPeerEventsResponse) GetEvents() []*PeerEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Data contribution accounting of a peer, lifetime totals
+type PeerContribution struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PeerId              string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	BlocksReceived      uint64                 `protobuf:"varint,2,opt,name=blocks_received,json=blocksReceived,proto3" json:"blocks_received,omitempty"` // Data the peer served to us
+	SubtreesReceived    uint64                 `protobuf:"varint,3,opt,name=subtrees_received,json=subtreesReceived,proto3" json:"subtrees_received,omitempty"`
+	SubtreeDataReceived uint64                 `protobuf:"varint,4,opt,name=subtree_data_received,json=subtreeDataReceived,proto3" json:"subtree_data_received,omitempty"`
+	BytesReceived       uint64                 `protobuf:"varint,5,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	BlocksServed        uint64                 `protobuf:"varint,6,opt,name=blocks_served,json=blocksServed,proto3" json:"blocks_served,omitempty"` // Data we served to the peer
+	SubtreesServed      uint64                 `protobuf:"varint,7,opt,name=subtrees_served,json=subtreesServed,proto3" json:"subtrees_served,omitempty"`
+	SubtreeDataServed   uint64                 `protobuf:"varint,8,opt,name=subtree_data_served,json=subtreeDataServed,proto3" json:"subtree_data_served,omitempty"`
+	BytesServed         uint64                 `protobuf:"varint,9,opt,name=bytes_served,json=bytesServed,proto3" json:"bytes_served,omitempty"`
+	FirstSeen           int64                  `protobuf:"varint,10,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`       // Unix timestamp in milliseconds
+	LastUpdated         int64                  `protobuf:"varint,11,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"` // Unix timestamp in milliseconds
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PeerContribution) Reset() {
+	*x = PeerContribution{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerContribution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerContribution) ProtoMessage() {}
+
+func (x *PeerContribution) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerContribution.ProtoReflect.Descriptor instead.
+func (*PeerContribution) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *PeerContribution) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *PeerContribution) GetBlocksReceived() uint64 {
+	if x != nil {
+		return x.BlocksReceived
+	}
+	return 0
+}
+
+func (x *PeerContribution) GetSubtreesReceived() uint64 {
+	if x != nil {
+		return x.SubtreesReceived
+	}
+	return 0
+}
+
+func (x *PeerContribution) GetSubtreeDataReceived() uint64 {
+	if x != nil {
+		return x.SubtreeDataReceived
+	}
+	return 0
+}
+
+func (x *PeerContribution) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *PeerContribution) GetBlocksServed() uint64 {
+	if x != nil {
+		return x.BlocksServed
+	}
+	return 0
+}
+
+func (x *PeerContribution) GetSubtreesServed() uint64 {
+	if x != nil {
+		return x.SubtreesServed
+	}
+	return 0
+}
+
+func (x *PeerContribution) GetSubtreeDataServed() uint64 {
+	if x != nil {
+		return x.SubtreeDataServed
+	}
+	return 0
+}
+
+func (x *PeerContribution) GetBytesServed() uint64 {
+	if x != nil {
+		return x.BytesServed
+	}
+	return 0
+}
+
+func (x *PeerContribution) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *PeerContribution) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+type GetPeerContributionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"` // Optional, all peers when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerContributionsRequest) Reset() {
+	*x = GetPeerContributionsRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerContributionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerContributionsRequest) ProtoMessage() {}
+
+func (x *GetPeerContributionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerContributionsRequest.ProtoReflect.Descriptor instead.
+func (*GetPeerContributionsRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetPeerContributionsRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+type GetPeerContributionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contributions []*PeerContribution    `protobuf:"bytes,1,rep,name=contributions,proto3" json:"contributions,omitempty"` // Ordered by bytes received, lowest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerContributionsResponse) Reset() {
+	*x = GetPeerContributionsResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerContributionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerContributionsResponse) ProtoMessage() {}
+
+func (x *GetPeerContributionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerContributionsResponse.ProtoReflect.Descriptor instead.
+func (*GetPeerContributionsResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetPeerContributionsResponse) GetContributions() []*PeerContribution {
+	if x != nil {
+		return x.Contributions
+	}
+	return nil
+}
+
+// Identity document proving that a DataHub URL is served by the node of a peer ID
+type SignIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Challenge     string                 `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"` // Random challenge of the verifying node, included in the signed document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignIdentityRequest) Reset() {
+	*x = SignIdentityRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignIdentityRequest) ProtoMessage() {}
+
+func (x *SignIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignIdentityRequest.ProtoReflect.Descriptor instead.
+func (*SignIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SignIdentityRequest) GetChallenge() string {
+	if x != nil {
+		return x.Challenge
+	}
+	return ""
+}
+
+type SignIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	DatahubUrl    string                 `protobuf:"bytes,2,opt,name=datahub_url,json=datahubUrl,proto3" json:"datahub_url,omitempty"` // DataHub URL this node advertises
+	Challenge     string                 `protobuf:"bytes,3,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp in milliseconds
+	Signature     []byte                 `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`  // Signature by the peer key over the other fields
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignIdentityResponse) Reset() {
+	*x = SignIdentityResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignIdentityResponse) ProtoMessage() {}
+
+func (x *SignIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignIdentityResponse.ProtoReflect.Descriptor instead.
+func (*SignIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SignIdentityResponse) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *SignIdentityResponse) GetDatahubUrl() string {
 	if x != nil {
-		return x.BlockHash
+		return x.DatahubUrl
 	}
 	return ""
 }
 
-func (x *PeerRegistryInfo) GetDataHubUrl() string {
+func (x *SignIdentityResponse) GetChallenge() string {
 	if x != nil {
-		return x.DataHubUrl
+		return x.Challenge
 	}
 	return ""
 }
 
-func (x *PeerRegistryInfo) GetBanScore() int32 {
+func (x *SignIdentityResponse) GetTimestamp() int64 {
 	if x != nil {
-		return x.BanScore
+		return x.Timestamp
 	}
 	return 0
 }
 
-func (x *PeerRegistryInfo) GetIsBanned() bool {
+func (x *SignIdentityResponse) GetSignature() []byte {
 	if x != nil {
-		return x.IsBanned
+		return x.Signature
 	}
-	return false
+	return nil
 }
 
-func (x *PeerRegistryInfo) GetIsConnected() bool {
-	if x != nil {
-		return x.IsConnected
-	}
-	return false
+// Direct message sent to or received from the operator of another node
+type OperatorMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PeerId        string                 `protobuf:"bytes,2,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"` // Sender of a received message, recipient of a sent message
+	Direction     string                 `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`         // "sent" or "received"
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`                   // "restart", "throttle", "maintenance" or "info"
+	Text          string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	SentAt        int64                  `protobuf:"varint,6,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"` // Unix timestamp in milliseconds, as claimed by the sender
+	At            int64                  `protobuf:"varint,7,opt,name=at,proto3" json:"at,omitempty"`                       // Unix timestamp in milliseconds when this node sent or received the message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PeerRegistryInfo) GetConnectedAt() int64 {
-	if x != nil {
-		return x.ConnectedAt
-	}
-	return 0
+func (x *OperatorMessage) Reset() {
+	*x = OperatorMessage{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *PeerRegistryInfo) GetBytesReceived() uint64 {
-	if x != nil {
-		return x.BytesReceived
-	}
-	return 0
+func (x *OperatorMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *PeerRegistryInfo) GetLastBlockTime() int64 {
+func (*OperatorMessage) ProtoMessage() {}
+
+func (x *OperatorMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[63]
 	if x != nil {
-		return x.LastBlockTime
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *PeerRegistryInfo) GetLastMessageTime() int64 {
-	if x != nil {
-		return x.LastMessageTime
-	}
-	return 0
+// Deprecated: Use OperatorMessage.ProtoReflect.Descriptor instead.
+func (*OperatorMessage) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *PeerRegistryInfo) GetUrlResponsive() bool {
+func (x *OperatorMessage) GetId() string {
 	if x != nil {
-		return x.UrlResponsive
+		return x.Id
 	}
-	return false
+	return ""
 }
 
-func (x *PeerRegistryInfo) GetLastUrlCheck() int64 {
+func (x *OperatorMessage) GetPeerId() string {
 	if x != nil {
-		return x.LastUrlCheck
+		return x.PeerId
 	}
-	return 0
+	return ""
 }
 
-func (x *PeerRegistryInfo) GetInteractionAttempts() int64 {
+func (x *OperatorMessage) GetDirection() string {
 	if x != nil {
-		return x.InteractionAttempts
+		return x.Direction
 	}
-	return 0
+	return ""
 }
 
-func (x *PeerRegistryInfo) GetInteractionSuccesses() int64 {
+func (x *OperatorMessage) GetKind() string {
 	if x != nil {
-		return x.InteractionSuccesses
+		return x.Kind
 	}
-	return 0
+	return ""
 }
 
-func (x *PeerRegistryInfo) GetInteractionFailures() int64 {
+func (x *OperatorMessage) GetText() string {
 	if x != nil {
-		return x.InteractionFailures
+		return x.Text
 	}
-	return 0
+	return ""
 }
 
-func (x *PeerRegistryInfo) GetLastInteractionAttempt() int64 {
+func (x *OperatorMessage) GetSentAt() int64 {
 	if x != nil {
-		return x.LastInteractionAttempt
+		return x.SentAt
 	}
 	return 0
 }
 
-func (x *PeerRegistryInfo) GetLastInteractionSuccess() int64 {
+func (x *OperatorMessage) GetAt() int64 {
 	if x != nil {
-		return x.LastInteractionSuccess
+		return x.At
 	}
 	return 0
 }
 
-func (x *PeerRegistryInfo) GetLastInteractionFailure() int64 {
-	if x != nil {
-		return x.LastInteractionFailure
-	}
-	return 0
+type SendOperatorMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PeerRegistryInfo) GetReputationScore() float64 {
-	if x != nil {
-		return x.ReputationScore
-	}
-	return 0
+func (x *SendOperatorMessageRequest) Reset() {
+	*x = SendOperatorMessageRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *PeerRegistryInfo) GetMaliciousCount() int64 {
-	if x != nil {
-		return x.MaliciousCount
-	}
-	return 0
+func (x *SendOperatorMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *PeerRegistryInfo) GetAvgResponseTimeMs() int64 {
+func (*SendOperatorMessageRequest) ProtoMessage() {}
+
+func (x *SendOperatorMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[64]
 	if x != nil {
-		return x.AvgResponseTimeMs
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *PeerRegistryInfo) GetStorage() string {
-	if x != nil {
-		return x.Storage
-	}
-	return ""
+// Deprecated: Use SendOperatorMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendOperatorMessageRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *PeerRegistryInfo) GetClientName() string {
+func (x *SendOperatorMessageRequest) GetPeerId() string {
 	if x != nil {
-		return x.ClientName
+		return x.PeerId
 	}
 	return ""
 }
 
-func (x *PeerRegistryInfo) GetLastCatchupError() string {
+func (x *SendOperatorMessageRequest) GetKind() string {
 	if x != nil {
-		return x.LastCatchupError
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *PeerRegistryInfo) GetLastCatchupErrorTime() int64 {
+func (x *SendOperatorMessageRequest) GetText() string {
 	if x != nil {
-		return x.LastCatchupErrorTime
+		return x.Text
 	}
-	return 0
+	return ""
 }
 
-type GetPeerRegistryResponse struct {
+type SendOperatorMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Peers         []*PeerRegistryInfo    `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	Message       *OperatorMessage       `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPeerRegistryResponse) Reset() {
-	*x = GetPeerRegistryResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[40]
+func (x *SendOperatorMessageResponse) Reset() {
+	*x = SendOperatorMessageResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPeerRegistryResponse) String() string {
+func (x *SendOperatorMessageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPeerRegistryResponse) ProtoMessage() {}
+func (*SendOperatorMessageResponse) ProtoMessage() {}
 
-func (x *GetPeerRegistryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[40]
+func (x *SendOperatorMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2388,42 +4246,41 @@ func (x *GetPeerRegistryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPeerRegistryResponse.ProtoReflect.Descriptor instead.
-func (*GetPeerRegistryResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use SendOperatorMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendOperatorMessageResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *GetPeerRegistryResponse) GetPeers() []*PeerRegistryInfo {
+func (x *SendOperatorMessageResponse) GetMessage() *OperatorMessage {
 	if x != nil {
-		return x.Peers
+		return x.Message
 	}
 	return nil
 }
 
-// Record bytes downloaded via HTTP from a peer
-type RecordBytesDownloadedRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	PeerId          string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`                             // Peer ID that provided the data
-	BytesDownloaded uint64                 `protobuf:"varint,2,opt,name=bytes_downloaded,json=bytesDownloaded,proto3" json:"bytes_downloaded,omitempty"` // Number of bytes downloaded
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type GetOperatorMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"` // Optional, all peers when empty
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                // Optional, all kept messages when zero
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordBytesDownloadedRequest) Reset() {
-	*x = RecordBytesDownloadedRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[41]
+func (x *GetOperatorMessagesRequest) Reset() {
+	*x = GetOperatorMessagesRequest{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordBytesDownloadedRequest) String() string {
+func (x *GetOperatorMessagesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordBytesDownloadedRequest) ProtoMessage() {}
+func (*GetOperatorMessagesRequest) ProtoMessage() {}
 
-func (x *RecordBytesDownloadedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[41]
+func (x *GetOperatorMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2434,47 +4291,47 @@ func (x *RecordBytesDownloadedRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordBytesDownloadedRequest.ProtoReflect.Descriptor instead.
-func (*RecordBytesDownloadedRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use GetOperatorMessagesRequest.ProtoReflect.Descriptor instead.
+func (*GetOperatorMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *RecordBytesDownloadedRequest) GetPeerId() string {
+func (x *GetOperatorMessagesRequest) GetPeerId() string {
 	if x != nil {
 		return x.PeerId
 	}
 	return ""
 }
 
-func (x *RecordBytesDownloadedRequest) GetBytesDownloaded() uint64 {
+func (x *GetOperatorMessagesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.BytesDownloaded
+		return x.Limit
 	}
 	return 0
 }
 
-type RecordBytesDownloadedResponse struct {
+type GetOperatorMessagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Messages      []*OperatorMessage     `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"` // Most recent messages first
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordBytesDownloadedResponse) Reset() {
-	*x = RecordBytesDownloadedResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[42]
+func (x *GetOperatorMessagesResponse) Reset() {
+	*x = GetOperatorMessagesResponse{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordBytesDownloadedResponse) String() string {
+func (x *GetOperatorMessagesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordBytesDownloadedResponse) ProtoMessage() {}
+func (*GetOperatorMessagesResponse) ProtoMessage() {}
 
-func (x *RecordBytesDownloadedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[42]
+func (x *GetOperatorMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2485,40 +4342,43 @@ func (x *RecordBytesDownloadedResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordBytesDownloadedResponse.ProtoReflect.Descriptor instead.
-func (*RecordBytesDownloadedResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use GetOperatorMessagesResponse.ProtoReflect.Descriptor instead.
+func (*GetOperatorMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *RecordBytesDownloadedResponse) GetOk() bool {
+func (x *GetOperatorMessagesResponse) GetMessages() []*OperatorMessage {
 	if x != nil {
-		return x.Ok
+		return x.Messages
 	}
-	return false
+	return nil
 }
 
-type GetPeerRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PeerId        string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Output of a double spend, already spent by another transaction
+type DoubleSpendOutput struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TxHash         string                 `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`                           // Transaction of the spent output
+	Vout           uint32                 `protobuf:"varint,2,opt,name=vout,proto3" json:"vout,omitempty"`                                            // Index of the spent output
+	SpendingTxHash string                 `protobuf:"bytes,3,opt,name=spending_tx_hash,json=spendingTxHash,proto3" json:"spending_tx_hash,omitempty"` // Transaction that already spent the output
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *GetPeerRequest) Reset() {
-	*x = GetPeerRequest{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[43]
+func (x *DoubleSpendOutput) Reset() {
+	*x = DoubleSpendOutput{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPeerRequest) String() string {
+func (x *DoubleSpendOutput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPeerRequest) ProtoMessage() {}
+func (*DoubleSpendOutput) ProtoMessage() {}
 
-func (x *GetPeerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[43]
+func (x *DoubleSpendOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2529,41 +4389,60 @@ func (x *GetPeerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPeerRequest.ProtoReflect.Descriptor instead.
-func (*GetPeerRequest) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use DoubleSpendOutput.ProtoReflect.Descriptor instead.
+func (*DoubleSpendOutput) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *GetPeerRequest) GetPeerId() string {
+func (x *DoubleSpendOutput) GetTxHash() string {
 	if x != nil {
-		return x.PeerId
+		return x.TxHash
 	}
 	return ""
 }
 
-type GetPeerResponse struct {
+func (x *DoubleSpendOutput) GetVout() uint32 {
+	if x != nil {
+		return x.Vout
+	}
+	return 0
+}
+
+func (x *DoubleSpendOutput) GetSpendingTxHash() string {
+	if x != nil {
+		return x.SpendingTxHash
+	}
+	return ""
+}
+
+// Double spend detected by the validator
+type DoubleSpendEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Peer          *PeerRegistryInfo      `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"` // Will be null/empty if peer not found
-	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	TxHash        string                 `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"` // Transaction double spending the outputs
+	Outputs       []*DoubleSpendOutput   `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`                               // mempool or block
+	PeerId        string                 `protobuf:"bytes,4,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`                 // Peer the transaction was received from, empty when unknown
+	BlockHeight   uint32                 `protobuf:"varint,5,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"` // Block height the transaction was validated at
+	DetectedAt    int64                  `protobuf:"varint,6,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`    // Unix timestamp in milliseconds the double spend was received by the P2P service
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPeerResponse) Reset() {
-	*x = GetPeerResponse{}
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[44]
+func (x *DoubleSpendEvent) Reset() {
+	*x = DoubleSpendEvent{}
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPeerResponse) String() string {
+func (x *DoubleSpendEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPeerResponse) ProtoMessage() {}
+func (*DoubleSpendEvent) ProtoMessage() {}
 
-func (x *GetPeerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[44]
+func (x *DoubleSpendEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_services_p2p_p2p_api_p2p_api_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2574,23 +4453,51 @@ func (x *GetPeerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPeerResponse.ProtoReflect.Descriptor instead.
-func (*GetPeerResponse) Descriptor() ([]byte, []int) {
-	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use DoubleSpendEvent.ProtoReflect.Descriptor instead.
+func (*DoubleSpendEvent) Descriptor() ([]byte, []int) {
+	return file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *GetPeerResponse) GetPeer() *PeerRegistryInfo {
+func (x *DoubleSpendEvent) GetTxHash() string {
 	if x != nil {
-		return x.Peer
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *DoubleSpendEvent) GetOutputs() []*DoubleSpendOutput {
+	if x != nil {
+		return x.Outputs
 	}
 	return nil
 }
 
-func (x *GetPeerResponse) GetFound() bool {
+func (x *DoubleSpendEvent) GetSource() string {
 	if x != nil {
-		return x.Found
+		return x.Source
 	}
-	return false
+	return ""
+}
+
+func (x *DoubleSpendEvent) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *DoubleSpendEvent) GetBlockHeight() uint32 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *DoubleSpendEvent) GetDetectedAt() int64 {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return 0
 }
 
 var File_services_p2p_p2p_api_p2p_api_proto protoreflect.FileDescriptor
@@ -2632,10 +4539,11 @@ const file_services_p2p_p2p_api_p2p_api_proto_rawDesc = "" +
 	"\vwhitelisted\x18\x1a \x01(\bR\vwhitelisted\x12\x1c\n" +
 	"\tfeeFilter\x18\x1b \x01(\x03R\tfeeFilter\"7\n" +
 	"\x10GetPeersResponse\x12#\n" +
-	"\x05peers\x18\x01 \x03(\v2\r.p2p_api.PeerR\x05peers\":\n" +
+	"\x05peers\x18\x01 \x03(\v2\r.p2p_api.PeerR\x05peers\"R\n" +
 	"\x0eBanPeerRequest\x12\x12\n" +
 	"\x04addr\x18\x01 \x01(\tR\x04addr\x12\x14\n" +
-	"\x05until\x18\x02 \x01(\x03R\x05until\"!\n" +
+	"\x05until\x18\x02 \x01(\x03R\x05until\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"!\n" +
 	"\x0fBanPeerResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\"&\n" +
 	"\x10UnbanPeerRequest\x12\x12\n" +
@@ -2647,14 +4555,19 @@ const file_services_p2p_p2p_api_p2p_api_proto_rawDesc = "" +
 	"ipOrSubnet\x18\x01 \x01(\tR\n" +
 	"ipOrSubnet\".\n" +
 	"\x10IsBannedResponse\x12\x1a\n" +
-	"\bisBanned\x18\x01 \x01(\bR\bisBanned\",\n" +
+	"\bisBanned\x18\x01 \x01(\bR\bisBanned\"S\n" +
 	"\x12ListBannedResponse\x12\x16\n" +
-	"\x06banned\x18\x01 \x03(\tR\x06banned\"%\n" +
+	"\x06banned\x18\x01 \x03(\tR\x06banned\x12%\n" +
+	"\x04bans\x18\x02 \x03(\v2\x11.p2p_api.BanEntryR\x04bans\"=\n" +
+	"\bBanEntry\x12\x16\n" +
+	"\x06banned\x18\x01 \x01(\tR\x06banned\x12\x19\n" +
+	"\bunban_at\x18\x02 \x01(\x03R\aunbanAt\"%\n" +
 	"\x13ClearBannedResponse\x12\x0e\n" +
-	"\x02ok\x18\x01 \x01(\bR\x02ok\"E\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\"[\n" +
 	"\x12AddBanScoreRequest\x12\x17\n" +
 	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12\x16\n" +
-	"\x06reason\x18\x02 \x01(\tR\x06reason\"%\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\"%\n" +
 	"\x13AddBanScoreResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\"7\n" +
 	"\x12ConnectPeerRequest\x12!\n" +
@@ -2695,7 +4608,7 @@ const file_services_p2p_p2p_api_p2p_api_proto_rawDesc = "" +
 	"\terror_msg\x18\x02 \x01(\tR\berrorMsg\",\n" +
 	"\x1aUpdateCatchupErrorResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\"\x1b\n" +
-	"\x19GetPeersForCatchupRequest\"\xba\x02\n" +
+	"\x19GetPeersForCatchupRequest\"\xeb\x02\n" +
 	"\x12PeerInfoForCatchup\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x1d\n" +
@@ -2706,7 +4619,8 @@ const file_services_p2p_p2p_api_p2p_api_proto_rawDesc = "" +
 	"\x18catchup_reputation_score\x18\x05 \x01(\x01R\x16catchupReputationScore\x12)\n" +
 	"\x10catchup_attempts\x18\x06 \x01(\x03R\x0fcatchupAttempts\x12+\n" +
 	"\x11catchup_successes\x18\a \x01(\x03R\x10catchupSuccesses\x12)\n" +
-	"\x10catchup_failures\x18\b \x01(\x03R\x0fcatchupFailures\"O\n" +
+	"\x10catchup_failures\x18\b \x01(\x03R\x0fcatchupFailures\x12/\n" +
+	"\x14data_hub_mirror_urls\x18\t \x03(\tR\x11dataHubMirrorUrls\"O\n" +
 	"\x1aGetPeersForCatchupResponse\x121\n" +
 	"\x05peers\x18\x01 \x03(\v2\x1b.p2p_api.PeerInfoForCatchupR\x05peers\"W\n" +
 	"\x19ReportValidSubtreeRequest\x12\x17\n" +
@@ -2721,6 +4635,23 @@ const file_services_p2p_p2p_api_p2p_api_proto_rawDesc = "" +
 	"block_hash\x18\x02 \x01(\tR\tblockHash\"N\n" +
 	"\x18ReportValidBlockResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xa7\x01\n" +
+	"\x1bRecordInvalidSubtreeRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12!\n" +
+	"\fsubtree_hash\x18\x02 \x01(\tR\vsubtreeHash\x122\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x1a.p2p_api.InvalidDataReasonR\x06reason\x12\x18\n" +
+	"\adetails\x18\x04 \x01(\tR\adetails\"R\n" +
+	"\x1cRecordInvalidSubtreeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xa1\x01\n" +
+	"\x19RecordInvalidBlockRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x02 \x01(\tR\tblockHash\x122\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x1a.p2p_api.InvalidDataReasonR\x06reason\x12\x18\n" +
+	"\adetails\x18\x04 \x01(\tR\adetails\"P\n" +
+	"\x1aRecordInvalidBlockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"1\n" +
 	"\x16IsPeerMaliciousRequest\x12\x17\n" +
 	"\apeer_id\x18\x01 \x01(\tR\x06peerId\"T\n" +
@@ -2732,7 +4663,7 @@ const file_services_p2p_p2p_api_p2p_api_proto_rawDesc = "" +
 	"\x17IsPeerUnhealthyResponse\x12!\n" +
 	"\fis_unhealthy\x18\x01 \x01(\bR\visUnhealthy\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\x12)\n" +
-	"\x10reputation_score\x18\x03 \x01(\x02R\x0freputationScore\"\xb1\b\n" +
+	"\x10reputation_score\x18\x03 \x01(\x02R\x0freputationScore\"\xa2\x11\n" +
 	"\x10PeerRegistryInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x1d\n" +
@@ -2763,19 +4694,159 @@ const file_services_p2p_p2p_api_p2p_api_proto_rawDesc = "" +
 	"\vclient_name\x18\x18 \x01(\tR\n" +
 	"clientName\x12,\n" +
 	"\x12last_catchup_error\x18\x19 \x01(\tR\x10lastCatchupError\x125\n" +
-	"\x17last_catchup_error_time\x18\x1a \x01(\x03R\x14lastCatchupErrorTime\"J\n" +
+	"\x17last_catchup_error_time\x18\x1a \x01(\x03R\x14lastCatchupErrorTime\x12&\n" +
+	"\x0fis_on_probation\x18\x1b \x01(\bR\risOnProbation\x12'\n" +
+	"\x0fprobation_until\x18\x1c \x01(\x03R\x0eprobationUntil\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x1d \x01(\x04R\tbytesSent\x12!\n" +
+	"\fis_throttled\x18\x1e \x01(\bR\visThrottled\x12\x1d\n" +
+	"\n" +
+	"is_healthy\x18\x1f \x01(\bR\tisHealthy\x12,\n" +
+	"\x12health_duration_ms\x18  \x01(\x03R\x10healthDurationMs\x122\n" +
+	"\x15health_check_failures\x18! \x01(\x05R\x13healthCheckFailures\x12'\n" +
+	"\x10is_data_hub_down\x18\" \x01(\bR\risDataHubDown\x12\"\n" +
+	"\ris_relay_only\x18# \x01(\bR\visRelayOnly\x125\n" +
+	"\x17is_datahub_url_verified\x18$ \x01(\bR\x14isDatahubUrlVerified\x12,\n" +
+	"\x12double_spend_count\x18% \x01(\x03R\x10doubleSpendCount\x12*\n" +
+	"\x11last_double_spend\x18& \x01(\x03R\x0flastDoubleSpend\x12\x19\n" +
+	"\bminer_id\x18' \x01(\tR\aminerId\x12(\n" +
+	"\x10miner_public_key\x18( \x01(\tR\x0eminerPublicKey\x12#\n" +
+	"\rminer_contact\x18) \x01(\tR\fminerContact\x121\n" +
+	"\x15miner_id_announced_at\x18* \x01(\x03R\x12minerIdAnnouncedAt\x126\n" +
+	"\x17invalid_blocks_received\x18+ \x01(\x03R\x15invalidBlocksReceived\x12:\n" +
+	"\x19invalid_subtrees_received\x18, \x01(\x03R\x17invalidSubtreesReceived\x12*\n" +
+	"\x11last_invalid_data\x18- \x01(\x03R\x0flastInvalidData\x127\n" +
+	"\x18last_invalid_data_reason\x18. \x01(\tR\x15lastInvalidDataReason\x12\x1c\n" +
+	"\ttransport\x18/ \x01(\tR\ttransport\x12\x18\n" +
+	"\acountry\x180 \x01(\tR\acountry\x12\x10\n" +
+	"\x03asn\x181 \x01(\rR\x03asn\x12'\n" +
+	"\x0fas_organization\x182 \x01(\tR\x0easOrganization\x12)\n" +
+	"\x10protocol_version\x183 \x01(\rR\x0fprotocolVersion\x128\n" +
+	"\x18is_protocol_incompatible\x184 \x01(\bR\x16isProtocolIncompatible\x12/\n" +
+	"\x14data_hub_mirror_urls\x186 \x03(\tR\x11dataHubMirrorUrls\"J\n" +
 	"\x17GetPeerRegistryResponse\x12/\n" +
-	"\x05peers\x18\x01 \x03(\v2\x19.p2p_api.PeerRegistryInfoR\x05peers\"b\n" +
+	"\x05peers\x18\x01 \x03(\v2\x19.p2p_api.PeerRegistryInfoR\x05peers\"\x95\x01\n" +
 	"\x1cRecordBytesDownloadedRequest\x12\x17\n" +
 	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12)\n" +
-	"\x10bytes_downloaded\x18\x02 \x01(\x04R\x0fbytesDownloaded\"/\n" +
+	"\x10bytes_downloaded\x18\x02 \x01(\x04R\x0fbytesDownloaded\x12\x1b\n" +
+	"\tdata_type\x18\x03 \x01(\tR\bdataType\x12\x14\n" +
+	"\x05items\x18\x04 \x01(\x04R\x05items\"/\n" +
 	"\x1dRecordBytesDownloadedResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\"\x8f\x01\n" +
+	"\x1aRecordBytesUploadedRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12%\n" +
+	"\x0ebytes_uploaded\x18\x02 \x01(\x04R\rbytesUploaded\x12\x1b\n" +
+	"\tdata_type\x18\x03 \x01(\tR\bdataType\x12\x14\n" +
+	"\x05items\x18\x04 \x01(\x04R\x05items\"-\n" +
+	"\x1bRecordBytesUploadedResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\")\n" +
 	"\x0eGetPeerRequest\x12\x17\n" +
 	"\apeer_id\x18\x01 \x01(\tR\x06peerId\"V\n" +
 	"\x0fGetPeerResponse\x12-\n" +
 	"\x04peer\x18\x01 \x01(\v2\x19.p2p_api.PeerRegistryInfoR\x04peer\x12\x14\n" +
-	"\x05found\x18\x02 \x01(\bR\x05found2\xc9\x0f\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\"D\n" +
+	"\vHeightCount\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x05R\x06height\x12\x1d\n" +
+	"\n" +
+	"peer_count\x18\x02 \x01(\x05R\tpeerCount\"`\n" +
+	"\bChainTip\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x01 \x01(\tR\tblockHash\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x1d\n" +
+	"\n" +
+	"peer_count\x18\x03 \x01(\x05R\tpeerCount\"\xc4\x03\n" +
+	"\x1aGetNetworkOverviewResponse\x12\x1f\n" +
+	"\vtotal_peers\x18\x01 \x01(\x05R\n" +
+	"totalPeers\x12'\n" +
+	"\x0fconnected_peers\x18\x02 \x01(\x05R\x0econnectedPeers\x12\x1f\n" +
+	"\vbest_height\x18\x03 \x01(\x05R\n" +
+	"bestHeight\x12E\n" +
+	"\x13height_distribution\x18\x04 \x03(\v2\x14.p2p_api.HeightCountR\x12heightDistribution\x120\n" +
+	"\n" +
+	"chain_tips\x18\x05 \x03(\v2\x11.p2p_api.ChainTipR\tchainTips\x12/\n" +
+	"\x14avg_response_time_ms\x18\x06 \x01(\x03R\x11avgResponseTimeMs\x12!\n" +
+	"\fpeers_joined\x18\a \x01(\x05R\vpeersJoined\x12\x1d\n" +
+	"\n" +
+	"peers_left\x18\b \x01(\x05R\tpeersLeft\x12\x1d\n" +
+	"\n" +
+	"churn_rate\x18\t \x01(\x01R\tchurnRate\x120\n" +
+	"\x14churn_window_seconds\x18\n" +
+	" \x01(\x03R\x12churnWindowSeconds\"p\n" +
+	"\tPeerEvent\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x17\n" +
+	"\apeer_id\x18\x02 \x01(\tR\x06peerId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
+	"\adetails\x18\x04 \x01(\tR\adetails\"i\n" +
+	"\x14GetPeerEventsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x03R\x02to\x12\x17\n" +
+	"\apeer_id\x18\x03 \x01(\tR\x06peerId\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"C\n" +
+	"\x15GetPeerEventsResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.p2p_api.PeerEventR\x06events\"\xbf\x03\n" +
+	"\x10PeerContribution\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12'\n" +
+	"\x0fblocks_received\x18\x02 \x01(\x04R\x0eblocksReceived\x12+\n" +
+	"\x11subtrees_received\x18\x03 \x01(\x04R\x10subtreesReceived\x122\n" +
+	"\x15subtree_data_received\x18\x04 \x01(\x04R\x13subtreeDataReceived\x12%\n" +
+	"\x0ebytes_received\x18\x05 \x01(\x04R\rbytesReceived\x12#\n" +
+	"\rblocks_served\x18\x06 \x01(\x04R\fblocksServed\x12'\n" +
+	"\x0fsubtrees_served\x18\a \x01(\x04R\x0esubtreesServed\x12.\n" +
+	"\x13subtree_data_served\x18\b \x01(\x04R\x11subtreeDataServed\x12!\n" +
+	"\fbytes_served\x18\t \x01(\x04R\vbytesServed\x12\x1d\n" +
+	"\n" +
+	"first_seen\x18\n" +
+	" \x01(\x03R\tfirstSeen\x12!\n" +
+	"\flast_updated\x18\v \x01(\x03R\vlastUpdated\"6\n" +
+	"\x1bGetPeerContributionsRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\"_\n" +
+	"\x1cGetPeerContributionsResponse\x12?\n" +
+	"\rcontributions\x18\x01 \x03(\v2\x19.p2p_api.PeerContributionR\rcontributions\"3\n" +
+	"\x13SignIdentityRequest\x12\x1c\n" +
+	"\tchallenge\x18\x01 \x01(\tR\tchallenge\"\xaa\x01\n" +
+	"\x14SignIdentityResponse\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12\x1f\n" +
+	"\vdatahub_url\x18\x02 \x01(\tR\n" +
+	"datahubUrl\x12\x1c\n" +
+	"\tchallenge\x18\x03 \x01(\tR\tchallenge\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x1c\n" +
+	"\tsignature\x18\x05 \x01(\fR\tsignature\"\xa9\x01\n" +
+	"\x0fOperatorMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\apeer_id\x18\x02 \x01(\tR\x06peerId\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12\x17\n" +
+	"\asent_at\x18\x06 \x01(\x03R\x06sentAt\x12\x0e\n" +
+	"\x02at\x18\a \x01(\x03R\x02at\"]\n" +
+	"\x1aSendOperatorMessageRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"Q\n" +
+	"\x1bSendOperatorMessageResponse\x122\n" +
+	"\amessage\x18\x01 \x01(\v2\x18.p2p_api.OperatorMessageR\amessage\"K\n" +
+	"\x1aGetOperatorMessagesRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"S\n" +
+	"\x1bGetOperatorMessagesResponse\x124\n" +
+	"\bmessages\x18\x01 \x03(\v2\x18.p2p_api.OperatorMessageR\bmessages\"j\n" +
+	"\x11DoubleSpendOutput\x12\x17\n" +
+	"\atx_hash\x18\x01 \x01(\tR\x06txHash\x12\x12\n" +
+	"\x04vout\x18\x02 \x01(\rR\x04vout\x12(\n" +
+	"\x10spending_tx_hash\x18\x03 \x01(\tR\x0espendingTxHash\"\xd6\x01\n" +
+	"\x10DoubleSpendEvent\x12\x17\n" +
+	"\atx_hash\x18\x01 \x01(\tR\x06txHash\x124\n" +
+	"\aoutputs\x18\x02 \x03(\v2\x1a.p2p_api.DoubleSpendOutputR\aoutputs\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x17\n" +
+	"\apeer_id\x18\x04 \x01(\tR\x06peerId\x12!\n" +
+	"\fblock_height\x18\x05 \x01(\rR\vblockHeight\x12\x1f\n" +
+	"\vdetected_at\x18\x06 \x01(\x03R\n" +
+	"detectedAt*\xa1\x01\n" +
+	"\x11InvalidDataReason\x12#\n" +
+	"\x1fINVALID_DATA_REASON_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bINVALID_DATA_REASON_INVALID\x10\x01\x12!\n" +
+	"\x1dINVALID_DATA_REASON_MALFORMED\x10\x02\x12#\n" +
+	"\x1fINVALID_DATA_REASON_UNAVAILABLE\x10\x032\xea\x16\n" +
 	"\vPeerService\x12?\n" +
 	"\bGetPeers\x12\x16.google.protobuf.Empty\x1a\x19.p2p_api.GetPeersResponse\"\x00\x12>\n" +
 	"\aBanPeer\x12\x17.p2p_api.BanPeerRequest\x1a\x18.p2p_api.BanPeerResponse\"\x00\x12D\n" +
@@ -2795,12 +4866,22 @@ const file_services_p2p_p2p_api_p2p_api_proto_rawDesc = "" +
 	"\x12UpdateCatchupError\x12\".p2p_api.UpdateCatchupErrorRequest\x1a#.p2p_api.UpdateCatchupErrorResponse\"\x00\x12_\n" +
 	"\x12GetPeersForCatchup\x12\".p2p_api.GetPeersForCatchupRequest\x1a#.p2p_api.GetPeersForCatchupResponse\"\x00\x12_\n" +
 	"\x12ReportValidSubtree\x12\".p2p_api.ReportValidSubtreeRequest\x1a#.p2p_api.ReportValidSubtreeResponse\"\x00\x12Y\n" +
-	"\x10ReportValidBlock\x12 .p2p_api.ReportValidBlockRequest\x1a!.p2p_api.ReportValidBlockResponse\"\x00\x12V\n" +
+	"\x10ReportValidBlock\x12 .p2p_api.ReportValidBlockRequest\x1a!.p2p_api.ReportValidBlockResponse\"\x00\x12e\n" +
+	"\x14RecordInvalidSubtree\x12$.p2p_api.RecordInvalidSubtreeRequest\x1a%.p2p_api.RecordInvalidSubtreeResponse\"\x00\x12_\n" +
+	"\x12RecordInvalidBlock\x12\".p2p_api.RecordInvalidBlockRequest\x1a#.p2p_api.RecordInvalidBlockResponse\"\x00\x12V\n" +
 	"\x0fIsPeerMalicious\x12\x1f.p2p_api.IsPeerMaliciousRequest\x1a .p2p_api.IsPeerMaliciousResponse\"\x00\x12V\n" +
 	"\x0fIsPeerUnhealthy\x12\x1f.p2p_api.IsPeerUnhealthyRequest\x1a .p2p_api.IsPeerUnhealthyResponse\"\x00\x12M\n" +
 	"\x0fGetPeerRegistry\x12\x16.google.protobuf.Empty\x1a .p2p_api.GetPeerRegistryResponse\"\x00\x12h\n" +
-	"\x15RecordBytesDownloaded\x12%.p2p_api.RecordBytesDownloadedRequest\x1a&.p2p_api.RecordBytesDownloadedResponse\"\x00\x12>\n" +
-	"\aGetPeer\x12\x17.p2p_api.GetPeerRequest\x1a\x18.p2p_api.GetPeerResponse\"\x00B\fZ\n" +
+	"\x15RecordBytesDownloaded\x12%.p2p_api.RecordBytesDownloadedRequest\x1a&.p2p_api.RecordBytesDownloadedResponse\"\x00\x12b\n" +
+	"\x13RecordBytesUploaded\x12#.p2p_api.RecordBytesUploadedRequest\x1a$.p2p_api.RecordBytesUploadedResponse\"\x00\x12>\n" +
+	"\aGetPeer\x12\x17.p2p_api.GetPeerRequest\x1a\x18.p2p_api.GetPeerResponse\"\x00\x12S\n" +
+	"\x12GetNetworkOverview\x12\x16.google.protobuf.Empty\x1a#.p2p_api.GetNetworkOverviewResponse\"\x00\x12P\n" +
+	"\rGetPeerEvents\x12\x1d.p2p_api.GetPeerEventsRequest\x1a\x1e.p2p_api.GetPeerEventsResponse\"\x00\x12e\n" +
+	"\x14GetPeerContributions\x12$.p2p_api.GetPeerContributionsRequest\x1a%.p2p_api.GetPeerContributionsResponse\"\x00\x12M\n" +
+	"\fSignIdentity\x12\x1c.p2p_api.SignIdentityRequest\x1a\x1d.p2p_api.SignIdentityResponse\"\x00\x12b\n" +
+	"\x13SendOperatorMessage\x12#.p2p_api.SendOperatorMessageRequest\x1a$.p2p_api.SendOperatorMessageResponse\"\x00\x12b\n" +
+	"\x13GetOperatorMessages\x12#.p2p_api.GetOperatorMessagesRequest\x1a$.p2p_api.GetOperatorMessagesResponse\"\x00\x12N\n" +
+	"\x15SubscribeDoubleSpends\x12\x16.google.protobuf.Empty\x1a\x19.p2p_api.DoubleSpendEvent\"\x000\x01B\fZ\n" +
 	"./;p2p_apib\x06proto3"
 
 var (
@@ -2815,111 +4896,168 @@ func file_services_p2p_p2p_api_p2p_api_proto_rawDescGZIP() []byte {
 	return file_services_p2p_p2p_api_p2p_api_proto_rawDescData
 }
 
-var file_services_p2p_p2p_api_p2p_api_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
+var file_services_p2p_p2p_api_p2p_api_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_services_p2p_p2p_api_p2p_api_proto_msgTypes = make([]protoimpl.MessageInfo, 70)
 var file_services_p2p_p2p_api_p2p_api_proto_goTypes = []any{
-	(*Peer)(nil),                            // 0: p2p_api.Peer
-	(*GetPeersResponse)(nil),                // 1: p2p_api.GetPeersResponse
-	(*BanPeerRequest)(nil),                  // 2: p2p_api.BanPeerRequest
-	(*BanPeerResponse)(nil),                 // 3: p2p_api.BanPeerResponse
-	(*UnbanPeerRequest)(nil),                // 4: p2p_api.UnbanPeerRequest
-	(*UnbanPeerResponse)(nil),               // 5: p2p_api.UnbanPeerResponse
-	(*IsBannedRequest)(nil),                 // 6: p2p_api.IsBannedRequest
-	(*IsBannedResponse)(nil),                // 7: p2p_api.IsBannedResponse
-	(*ListBannedResponse)(nil),              // 8: p2p_api.ListBannedResponse
-	(*ClearBannedResponse)(nil),             // 9: p2p_api.ClearBannedResponse
-	(*AddBanScoreRequest)(nil),              // 10: p2p_api.AddBanScoreRequest
-	(*AddBanScoreResponse)(nil),             // 11: p2p_api.AddBanScoreResponse
-	(*ConnectPeerRequest)(nil),              // 12: p2p_api.ConnectPeerRequest
-	(*ConnectPeerResponse)(nil),             // 13: p2p_api.ConnectPeerResponse
-	(*DisconnectPeerRequest)(nil),           // 14: p2p_api.DisconnectPeerRequest
-	(*DisconnectPeerResponse)(nil),          // 15: p2p_api.DisconnectPeerResponse
-	(*RecordCatchupAttemptRequest)(nil),     // 16: p2p_api.RecordCatchupAttemptRequest
-	(*RecordCatchupAttemptResponse)(nil),    // 17: p2p_api.RecordCatchupAttemptResponse
-	(*RecordCatchupSuccessRequest)(nil),     // 18: p2p_api.RecordCatchupSuccessRequest
-	(*RecordCatchupSuccessResponse)(nil),    // 19: p2p_api.RecordCatchupSuccessResponse
-	(*RecordCatchupFailureRequest)(nil),     // 20: p2p_api.RecordCatchupFailureRequest
-	(*RecordCatchupFailureResponse)(nil),    // 21: p2p_api.RecordCatchupFailureResponse
-	(*RecordCatchupMaliciousRequest)(nil),   // 22: p2p_api.RecordCatchupMaliciousRequest
-	(*RecordCatchupMaliciousResponse)(nil),  // 23: p2p_api.RecordCatchupMaliciousResponse
-	(*UpdateCatchupReputationRequest)(nil),  // 24: p2p_api.UpdateCatchupReputationRequest
-	(*UpdateCatchupReputationResponse)(nil), // 25: p2p_api.UpdateCatchupReputationResponse
-	(*UpdateCatchupErrorRequest)(nil),       // 26: p2p_api.UpdateCatchupErrorRequest
-	(*UpdateCatchupErrorResponse)(nil),      // 27: p2p_api.UpdateCatchupErrorResponse
-	(*GetPeersForCatchupRequest)(nil),       // 28: p2p_api.GetPeersForCatchupRequest
-	(*PeerInfoForCatchup)(nil),              // 29: p2p_api.PeerInfoForCatchup
-	(*GetPeersForCatchupResponse)(nil),      // 30: p2p_api.GetPeersForCatchupResponse
-	(*ReportValidSubtreeRequest)(nil),       // 31: p2p_api.ReportValidSubtreeRequest
-	(*ReportValidSubtreeResponse)(nil),      // 32: p2p_api.ReportValidSubtreeResponse
-	(*ReportValidBlockRequest)(nil),         // 33: p2p_api.ReportValidBlockRequest
-	(*ReportValidBlockResponse)(nil),        // 34: p2p_api.ReportValidBlockResponse
-	(*IsPeerMaliciousRequest)(nil),          // 35: p2p_api.IsPeerMaliciousRequest
-	(*IsPeerMaliciousResponse)(nil),         // 36: p2p_api.IsPeerMaliciousResponse
-	(*IsPeerUnhealthyRequest)(nil),          // 37: p2p_api.IsPeerUnhealthyRequest
-	(*IsPeerUnhealthyResponse)(nil),         // 38: p2p_api.IsPeerUnhealthyResponse
-	(*PeerRegistryInfo)(nil),                // 39: p2p_api.PeerRegistryInfo
-	(*GetPeerRegistryResponse)(nil),         // 40: p2p_api.GetPeerRegistryResponse
-	(*RecordBytesDownloadedRequest)(nil),    // 41: p2p_api.RecordBytesDownloadedRequest
-	(*RecordBytesDownloadedResponse)(nil),   // 42: p2p_api.RecordBytesDownloadedResponse
-	(*GetPeerRequest)(nil),                  // 43: p2p_api.GetPeerRequest
-	(*GetPeerResponse)(nil),                 // 44: p2p_api.GetPeerResponse
-	(*emptypb.Empty)(nil),                   // 45: google.protobuf.Empty
+	(InvalidDataReason)(0),                  // 0: p2p_api.InvalidDataReason
+	(*Peer)(nil),                            // 1: p2p_api.Peer
+	(*GetPeersResponse)(nil),                // 2: p2p_api.GetPeersResponse
+	(*BanPeerRequest)(nil),                  // 3: p2p_api.BanPeerRequest
+	(*BanPeerResponse)(nil),                 // 4: p2p_api.BanPeerResponse
+	(*UnbanPeerRequest)(nil),                // 5: p2p_api.UnbanPeerRequest
+	(*UnbanPeerResponse)(nil),               // 6: p2p_api.UnbanPeerResponse
+	(*IsBannedRequest)(nil),                 // 7: p2p_api.IsBannedRequest
+	(*IsBannedResponse)(nil),                // 8: p2p_api.IsBannedResponse
+	(*ListBannedResponse)(nil),              // 9: p2p_api.ListBannedResponse
+	(*BanEntry)(nil),                        // 10: p2p_api.BanEntry
+	(*ClearBannedResponse)(nil),             // 11: p2p_api.ClearBannedResponse
+	(*AddBanScoreRequest)(nil),              // 12: p2p_api.AddBanScoreRequest
+	(*AddBanScoreResponse)(nil),             // 13: p2p_api.AddBanScoreResponse
+	(*ConnectPeerRequest)(nil),              // 14: p2p_api.ConnectPeerRequest
+	(*ConnectPeerResponse)(nil),             // 15: p2p_api.ConnectPeerResponse
+	(*DisconnectPeerRequest)(nil),           // 16: p2p_api.DisconnectPeerRequest
+	(*DisconnectPeerResponse)(nil),          // 17: p2p_api.DisconnectPeerResponse
+	(*RecordCatchupAttemptRequest)(nil),     // 18: p2p_api.RecordCatchupAttemptRequest
+	(*RecordCatchupAttemptResponse)(nil),    // 19: p2p_api.RecordCatchupAttemptResponse
+	(*RecordCatchupSuccessRequest)(nil),     // 20: p2p_api.RecordCatchupSuccessRequest
+	(*RecordCatchupSuccessResponse)(nil),    // 21: p2p_api.RecordCatchupSuccessResponse
+	(*RecordCatchupFailureRequest)(nil),     // 22: p2p_api.RecordCatchupFailureRequest
+	(*RecordCatchupFailureResponse)(nil),    // 23: p2p_api.RecordCatchupFailureResponse
+	(*RecordCatchupMaliciousRequest)(nil),   // 24: p2p_api.RecordCatchupMaliciousRequest
+	(*RecordCatchupMaliciousResponse)(nil),  // 25: p2p_api.RecordCatchupMaliciousResponse
+	(*UpdateCatchupReputationRequest)(nil),  // 26: p2p_api.UpdateCatchupReputationRequest
+	(*UpdateCatchupReputationResponse)(nil), // 27: p2p_api.UpdateCatchupReputationResponse
+	(*UpdateCatchupErrorRequest)(nil),       // 28: p2p_api.UpdateCatchupErrorRequest
+	(*UpdateCatchupErrorResponse)(nil),      // 29: p2p_api.UpdateCatchupErrorResponse
+	(*GetPeersForCatchupRequest)(nil),       // 30: p2p_api.GetPeersForCatchupRequest
+	(*PeerInfoForCatchup)(nil),              // 31: p2p_api.PeerInfoForCatchup
+	(*GetPeersForCatchupResponse)(nil),      // 32: p2p_api.GetPeersForCatchupResponse
+	(*ReportValidSubtreeRequest)(nil),       // 33: p2p_api.ReportValidSubtreeRequest
+	(*ReportValidSubtreeResponse)(nil),      // 34: p2p_api.ReportValidSubtreeResponse
+	(*ReportValidBlockRequest)(nil),         // 35: p2p_api.ReportValidBlockRequest
+	(*ReportValidBlockResponse)(nil),        // 36: p2p_api.ReportValidBlockResponse
+	(*RecordInvalidSubtreeRequest)(nil),     // 37: p2p_api.RecordInvalidSubtreeRequest
+	(*RecordInvalidSubtreeResponse)(nil),    // 38: p2p_api.RecordInvalidSubtreeResponse
+	(*RecordInvalidBlockRequest)(nil),       // 39: p2p_api.RecordInvalidBlockRequest
+	(*RecordInvalidBlockResponse)(nil),      // 40: p2p_api.RecordInvalidBlockResponse
+	(*IsPeerMaliciousRequest)(nil),          // 41: p2p_api.IsPeerMaliciousRequest
+	(*IsPeerMaliciousResponse)(nil),         // 42: p2p_api.IsPeerMaliciousResponse
+	(*IsPeerUnhealthyRequest)(nil),          // 43: p2p_api.IsPeerUnhealthyRequest
+	(*IsPeerUnhealthyResponse)(nil),         // 44: p2p_api.IsPeerUnhealthyResponse
+	(*PeerRegistryInfo)(nil),                // 45: p2p_api.PeerRegistryInfo
+	(*GetPeerRegistryResponse)(nil),         // 46: p2p_api.GetPeerRegistryResponse
+	(*RecordBytesDownloadedRequest)(nil),    // 47: p2p_api.RecordBytesDownloadedRequest
+	(*RecordBytesDownloadedResponse)(nil),   // 48: p2p_api.RecordBytesDownloadedResponse
+	(*RecordBytesUploadedRequest)(nil),      // 49: p2p_api.RecordBytesUploadedRequest
+	(*RecordBytesUploadedResponse)(nil),     // 50: p2p_api.RecordBytesUploadedResponse
+	(*GetPeerRequest)(nil),                  // 51: p2p_api.GetPeerRequest
+	(*GetPeerResponse)(nil),                 // 52: p2p_api.GetPeerResponse
+	(*HeightCount)(nil),                     // 53: p2p_api.HeightCount
+	(*ChainTip)(nil),                        // 54: p2p_api.ChainTip
+	(*GetNetworkOverviewResponse)(nil),      // 55: p2p_api.GetNetworkOverviewResponse
+	(*PeerEvent)(nil),                       // 56: p2p_api.PeerEvent
+	(*GetPeerEventsRequest)(nil),            // 57: p2p_api.GetPeerEventsRequest
+	(*GetPeerEventsResponse)(nil),           // 58: p2p_api.GetPeerEventsResponse
+	(*PeerContribution)(nil),                // 59: p2p_api.PeerContribution
+	(*GetPeerContributionsRequest)(nil),     // 60: p2p_api.GetPeerContributionsRequest
+	(*GetPeerContributionsResponse)(nil),    // 61: p2p_api.GetPeerContributionsResponse
+	(*SignIdentityRequest)(nil),             // 62: p2p_api.SignIdentityRequest
+	(*SignIdentityResponse)(nil),            // 63: p2p_api.SignIdentityResponse
+	(*OperatorMessage)(nil),                 // 64: p2p_api.OperatorMessage
+	(*SendOperatorMessageRequest)(nil),      // 65: p2p_api.SendOperatorMessageRequest
+	(*SendOperatorMessageResponse)(nil),     // 66: p2p_api.SendOperatorMessageResponse
+	(*GetOperatorMessagesRequest)(nil),      // 67: p2p_api.GetOperatorMessagesRequest
+	(*GetOperatorMessagesResponse)(nil),     // 68: p2p_api.GetOperatorMessagesResponse
+	(*DoubleSpendOutput)(nil),               // 69: p2p_api.DoubleSpendOutput
+	(*DoubleSpendEvent)(nil),                // 70: p2p_api.DoubleSpendEvent
+	(*emptypb.Empty)(nil),                   // 71: google.protobuf.Empty
 }
 var file_services_p2p_p2p_api_p2p_api_proto_depIdxs = []int32{
-	0,  // 0: p2p_api.GetPeersResponse.peers:type_name -> p2p_api.Peer
-	29, // 1: p2p_api.GetPeersForCatchupResponse.peers:type_name -> p2p_api.PeerInfoForCatchup
-	39, // 2: p2p_api.GetPeerRegistryResponse.peers:type_name -> p2p_api.PeerRegistryInfo
-	39, // 3: p2p_api.GetPeerResponse.peer:type_name -> p2p_api.PeerRegistryInfo
-	45, // 4: p2p_api.PeerService.GetPeers:input_type -> google.protobuf.Empty
-	2,  // 5: p2p_api.PeerService.BanPeer:input_type -> p2p_api.BanPeerRequest
-	4,  // 6: p2p_api.PeerService.UnbanPeer:input_type -> p2p_api.UnbanPeerRequest
-	6,  // 7: p2p_api.PeerService.IsBanned:input_type -> p2p_api.IsBannedRequest
-	45, // 8: p2p_api.PeerService.ListBanned:input_type -> google.protobuf.Empty
-	45, // 9: p2p_api.PeerService.ClearBanned:input_type -> google.protobuf.Empty
-	10, // 10: p2p_api.PeerService.AddBanScore:input_type -> p2p_api.AddBanScoreRequest
-	12, // 11: p2p_api.PeerService.ConnectPeer:input_type -> p2p_api.ConnectPeerRequest
-	14, // 12: p2p_api.PeerService.DisconnectPeer:input_type -> p2p_api.DisconnectPeerRequest
-	16, // 13: p2p_api.PeerService.RecordCatchupAttempt:input_type -> p2p_api.RecordCatchupAttemptRequest
-	18, // 14: p2p_api.PeerService.RecordCatchupSuccess:input_type -> p2p_api.RecordCatchupSuccessRequest
-	20, // 15: p2p_api.PeerService.RecordCatchupFailure:input_type -> p2p_api.RecordCatchupFailureRequest
-	22, // 16: p2p_api.PeerService.RecordCatchupMalicious:input_type -> p2p_api.RecordCatchupMaliciousRequest
-	24, // 17: p2p_api.PeerService.UpdateCatchupReputation:input_type -> p2p_api.UpdateCatchupReputationRequest
-	26, // 18: p2p_api.PeerService.UpdateCatchupError:input_type -> p2p_api.UpdateCatchupErrorRequest
-	28, // 19: p2p_api.PeerService.GetPeersForCatchup:input_type -> p2p_api.GetPeersForCatchupRequest
-	31, // 20: p2p_api.PeerService.ReportValidSubtree:input_type -> p2p_api.ReportValidSubtreeRequest
-	33, // 21: p2p_api.PeerService.ReportValidBlock:input_type -> p2p_api.ReportValidBlockRequest
-	35, // 22: p2p_api.PeerService.IsPeerMalicious:input_type -> p2p_api.IsPeerMaliciousRequest
-	37, // 23: p2p_api.PeerService.IsPeerUnhealthy:input_type -> p2p_api.IsPeerUnhealthyRequest
-	45, // 24: p2p_api.PeerService.GetPeerRegistry:input_type -> google.protobuf.Empty
-	41, // 25: p2p_api.PeerService.RecordBytesDownloaded:input_type -> p2p_api.RecordBytesDownloadedRequest
-	43, // 26: p2p_api.PeerService.GetPeer:input_type -> p2p_api.GetPeerRequest
-	1,  // 27: p2p_api.PeerService.GetPeers:output_type -> p2p_api.GetPeersResponse
-	3,  // 28: p2p_api.PeerService.BanPeer:output_type -> p2p_api.BanPeerResponse
-	5,  // 29: p2p_api.PeerService.UnbanPeer:output_type -> p2p_api.UnbanPeerResponse
-	7,  // 30: p2p_api.PeerService.IsBanned:output_type -> p2p_api.IsBannedResponse
-	8,  // 31: p2p_api.PeerService.ListBanned:output_type -> p2p_api.ListBannedResponse
-	9,  // 32: p2p_api.PeerService.ClearBanned:output_type -> p2p_api.ClearBannedResponse
-	11, // 33: p2p_api.PeerService.AddBanScore:output_type -> p2p_api.AddBanScoreResponse
-	13, // 34: p2p_api.PeerService.ConnectPeer:output_type -> p2p_api.ConnectPeerResponse
-	15, // 35: p2p_api.PeerService.DisconnectPeer:output_type -> p2p_api.DisconnectPeerResponse
-	17, // 36: p2p_api.PeerService.RecordCatchupAttempt:output_type -> p2p_api.RecordCatchupAttemptResponse
-	19, // 37: p2p_api.PeerService.RecordCatchupSuccess:output_type -> p2p_api.RecordCatchupSuccessResponse
-	21, // 38: p2p_api.PeerService.RecordCatchupFailure:output_type -> p2p_api.RecordCatchupFailureResponse
-	23, // 39: p2p_api.PeerService.RecordCatchupMalicious:output_type -> p2p_api.RecordCatchupMaliciousResponse
-	25, // 40: p2p_api.PeerService.UpdateCatchupReputation:output_type -> p2p_api.UpdateCatchupReputationResponse
-	27, // 41: p2p_api.PeerService.UpdateCatchupError:output_type -> p2p_api.UpdateCatchupErrorResponse
-	30, // 42: p2p_api.PeerService.GetPeersForCatchup:output_type -> p2p_api.GetPeersForCatchupResponse
-	32, // 43: p2p_api.PeerService.ReportValidSubtree:output_type -> p2p_api.ReportValidSubtreeResponse
-	34, // 44: p2p_api.PeerService.ReportValidBlock:output_type -> p2p_api.ReportValidBlockResponse
-	36, // 45: p2p_api.PeerService.IsPeerMalicious:output_type -> p2p_api.IsPeerMaliciousResponse
-	38, // 46: p2p_api.PeerService.IsPeerUnhealthy:output_type -> p2p_api.IsPeerUnhealthyResponse
-	40, // 47: p2p_api.PeerService.GetPeerRegistry:output_type -> p2p_api.GetPeerRegistryResponse
-	42, // 48: p2p_api.PeerService.RecordBytesDownloaded:output_type -> p2p_api.RecordBytesDownloadedResponse
-	44, // 49: p2p_api.PeerService.GetPeer:output_type -> p2p_api.GetPeerResponse
-	27, // [27:50] is the sub-list for method output_type
-	4,  // [4:27] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	1,  // 0: p2p_api.GetPeersResponse.peers:type_name -> p2p_api.Peer
+	10, // 1: p2p_api.ListBannedResponse.bans:type_name -> p2p_api.BanEntry
+	31, // 2: p2p_api.GetPeersForCatchupResponse.peers:type_name -> p2p_api.PeerInfoForCatchup
+	0,  // 3: p2p_api.RecordInvalidSubtreeRequest.reason:type_name -> p2p_api.InvalidDataReason
+	0,  // 4: p2p_api.RecordInvalidBlockRequest.reason:type_name -> p2p_api.InvalidDataReason
+	45, // 5: p2p_api.GetPeerRegistryResponse.peers:type_name -> p2p_api.PeerRegistryInfo
+	45, // 6: p2p_api.GetPeerResponse.peer:type_name -> p2p_api.PeerRegistryInfo
+	53, // 7: p2p_api.GetNetworkOverviewResponse.height_distribution:type_name -> p2p_api.HeightCount
+	54, // 8: p2p_api.GetNetworkOverviewResponse.chain_tips:type_name -> p2p_api.ChainTip
+	56, // 9: p2p_api.GetPeerEventsResponse.events:type_name -> p2p_api.PeerEvent
+	59, // 10: p2p_api.GetPeerContributionsResponse.contributions:type_name -> p2p_api.PeerContribution
+	64, // 11: p2p_api.SendOperatorMessageResponse.message:type_name -> p2p_api.OperatorMessage
+	64, // 12: p2p_api.GetOperatorMessagesResponse.messages:type_name -> p2p_api.OperatorMessage
+	69, // 13: p2p_api.DoubleSpendEvent.outputs:type_name -> p2p_api.DoubleSpendOutput
+	71, // 14: p2p_api.PeerService.GetPeers:input_type -> google.protobuf.Empty
+	3,  // 15: p2p_api.PeerService.BanPeer:input_type -> p2p_api.BanPeerRequest
+	5,  // 16: p2p_api.PeerService.UnbanPeer:input_type -> p2p_api.UnbanPeerRequest
+	7,  // 17: p2p_api.PeerService.IsBanned:input_type -> p2p_api.IsBannedRequest
+	71, // 18: p2p_api.PeerService.ListBanned:input_type -> google.protobuf.Empty
+	71, // 19: p2p_api.PeerService.ClearBanned:input_type -> google.protobuf.Empty
+	12, // 20: p2p_api.PeerService.AddBanScore:input_type -> p2p_api.AddBanScoreRequest
+	14, // 21: p2p_api.PeerService.ConnectPeer:input_type -> p2p_api.ConnectPeerRequest
+	16, // 22: p2p_api.PeerService.DisconnectPeer:input_type -> p2p_api.DisconnectPeerRequest
+	18, // 23: p2p_api.PeerService.RecordCatchupAttempt:input_type -> p2p_api.RecordCatchupAttemptRequest
+	20, // 24: p2p_api.PeerService.RecordCatchupSuccess:input_type -> p2p_api.RecordCatchupSuccessRequest
+	22, // 25: p2p_api.PeerService.RecordCatchupFailure:input_type -> p2p_api.RecordCatchupFailureRequest
+	24, // 26: p2p_api.PeerService.RecordCatchupMalicious:input_type -> p2p_api.RecordCatchupMaliciousRequest
+	26, // 27: p2p_api.PeerService.UpdateCatchupReputation:input_type -> p2p_api.UpdateCatchupReputationRequest
+	28, // 28: p2p_api.PeerService.UpdateCatchupError:input_type -> p2p_api.UpdateCatchupErrorRequest
+	30, // 29: p2p_api.PeerService.GetPeersForCatchup:input_type -> p2p_api.GetPeersForCatchupRequest
+	33, // 30: p2p_api.PeerService.ReportValidSubtree:input_type -> p2p_api.ReportValidSubtreeRequest
+	35, // 31: p2p_api.PeerService.ReportValidBlock:input_type -> p2p_api.ReportValidBlockRequest
+	37, // 32: p2p_api.PeerService.RecordInvalidSubtree:input_type -> p2p_api.RecordInvalidSubtreeRequest
+	39, // 33: p2p_api.PeerService.RecordInvalidBlock:input_type -> p2p_api.RecordInvalidBlockRequest
+	41, // 34: p2p_api.PeerService.IsPeerMalicious:input_type -> p2p_api.IsPeerMaliciousRequest
+	43, // 35: p2p_api.PeerService.IsPeerUnhealthy:input_type -> p2p_api.IsPeerUnhealthyRequest
+	71, // 36: p2p_api.PeerService.GetPeerRegistry:input_type -> google.protobuf.Empty
+	47, // 37: p2p_api.PeerService.RecordBytesDownloaded:input_type -> p2p_api.RecordBytesDownloadedRequest
+	49, // 38: p2p_api.PeerService.RecordBytesUploaded:input_type -> p2p_api.RecordBytesUploadedRequest
+	51, // 39: p2p_api.PeerService.GetPeer:input_type -> p2p_api.GetPeerRequest
+	71, // 40: p2p_api.PeerService.GetNetworkOverview:input_type -> google.protobuf.Empty
+	57, // 41: p2p_api.PeerService.GetPeerEvents:input_type -> p2p_api.GetPeerEventsRequest
+	60, // 42: p2p_api.PeerService.GetPeerContributions:input_type -> p2p_api.GetPeerContributionsRequest
+	62, // 43: p2p_api.PeerService.SignIdentity:input_type -> p2p_api.SignIdentityRequest
+	65, // 44: p2p_api.PeerService.SendOperatorMessage:input_type -> p2p_api.SendOperatorMessageRequest
+	67, // 45: p2p_api.PeerService.GetOperatorMessages:input_type -> p2p_api.GetOperatorMessagesRequest
+	71, // 46: p2p_api.PeerService.SubscribeDoubleSpends:input_type -> google.protobuf.Empty
+	2,  // 47: p2p_api.PeerService.GetPeers:output_type -> p2p_api.GetPeersResponse
+	4,  // 48: p2p_api.PeerService.BanPeer:output_type -> p2p_api.BanPeerResponse
+	6,  // 49: p2p_api.PeerService.UnbanPeer:output_type -> p2p_api.UnbanPeerResponse
+	8,  // 50: p2p_api.PeerService.IsBanned:output_type -> p2p_api.IsBannedResponse
+	9,  // 51: p2p_api.PeerService.ListBanned:output_type -> p2p_api.ListBannedResponse
+	11, // 52: p2p_api.PeerService.ClearBanned:output_type -> p2p_api.ClearBannedResponse
+	13, // 53: p2p_api.PeerService.AddBanScore:output_type -> p2p_api.AddBanScoreResponse
+	15, // 54: p2p_api.PeerService.ConnectPeer:output_type -> p2p_api.ConnectPeerResponse
+	17, // 55: p2p_api.PeerService.DisconnectPeer:output_type -> p2p_api.DisconnectPeerResponse
+	19, // 56: p2p_api.PeerService.RecordCatchupAttempt:output_type -> p2p_api.RecordCatchupAttemptResponse
+	21, // 57: p2p_api.PeerService.RecordCatchupSuccess:output_type -> p2p_api.RecordCatchupSuccessResponse
+	23, // 58: p2p_api.PeerService.RecordCatchupFailure:output_type -> p2p_api.RecordCatchupFailureResponse
+	25, // 59: p2p_api.PeerService.RecordCatchupMalicious:output_type -> p2p_api.RecordCatchupMaliciousResponse
+	27, // 60: p2p_api.PeerService.UpdateCatchupReputation:output_type -> p2p_api.UpdateCatchupReputationResponse
+	29, // 61: p2p_api.PeerService.UpdateCatchupError:output_type -> p2p_api.UpdateCatchupErrorResponse
+	32, // 62: p2p_api.PeerService.GetPeersForCatchup:output_type -> p2p_api.GetPeersForCatchupResponse
+	34, // 63: p2p_api.PeerService.ReportValidSubtree:output_type -> p2p_api.ReportValidSubtreeResponse
+	36, // 64: p2p_api.PeerService.ReportValidBlock:output_type -> p2p_api.ReportValidBlockResponse
+	38, // 65: p2p_api.PeerService.RecordInvalidSubtree:output_type -> p2p_api.RecordInvalidSubtreeResponse
+	40, // 66: p2p_api.PeerService.RecordInvalidBlock:output_type -> p2p_api.RecordInvalidBlockResponse
+	42, // 67: p2p_api.PeerService.IsPeerMalicious:output_type -> p2p_api.IsPeerMaliciousResponse
+	44, // 68: p2p_api.PeerService.IsPeerUnhealthy:output_type -> p2p_api.IsPeerUnhealthyResponse
+	46, // 69: p2p_api.PeerService.GetPeerRegistry:output_type -> p2p_api.GetPeerRegistryResponse
+	48, // 70: p2p_api.PeerService.RecordBytesDownloaded:output_type -> p2p_api.RecordBytesDownloadedResponse
+	50, // 71: p2p_api.PeerService.RecordBytesUploaded:output_type -> p2p_api.RecordBytesUploadedResponse
+	52, // 72: p2p_api.PeerService.GetPeer:output_type -> p2p_api.GetPeerResponse
+	55, // 73: p2p_api.PeerService.GetNetworkOverview:output_type -> p2p_api.GetNetworkOverviewResponse
+	58, // 74: p2p_api.PeerService.GetPeerEvents:output_type -> p2p_api.GetPeerEventsResponse
+	61, // 75: p2p_api.PeerService.GetPeerContributions:output_type -> p2p_api.GetPeerContributionsResponse
+	63, // 76: p2p_api.PeerService.SignIdentity:output_type -> p2p_api.SignIdentityResponse
+	66, // 77: p2p_api.PeerService.SendOperatorMessage:output_type -> p2p_api.SendOperatorMessageResponse
+	68, // 78: p2p_api.PeerService.GetOperatorMessages:output_type -> p2p_api.GetOperatorMessagesResponse
+	70, // 79: p2p_api.PeerService.SubscribeDoubleSpends:output_type -> p2p_api.DoubleSpendEvent
+	47, // [47:80] is the sub-list for method output_type
+	14, // [14:47] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_services_p2p_p2p_api_p2p_api_proto_init() }
@@ -2932,13 +5070,14 @@ func file_services_p2p_p2p_api_p2p_api_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_p2p_p2p_api_p2p_api_proto_rawDesc), len(file_services_p2p_p2p_api_p2p_api_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   45,
+			NumEnums:      1,
+			NumMessages:   70,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_services_p2p_p2p_api_p2p_api_proto_goTypes,
 		DependencyIndexes: file_services_p2p_p2p_api_p2p_api_proto_depIdxs,
+		EnumInfos:         file_services_p2p_p2p_api_p2p_api_proto_enumTypes,
 		MessageInfos:      file_services_p2p_p2p_api_p2p_api_proto_msgTypes,
 	}.Build()
 	File_services_p2p_p2p_api_p2p_api_proto = out.File
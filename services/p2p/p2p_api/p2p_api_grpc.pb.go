@@ -38,11 +38,21 @@ const (
 	PeerService_GetPeersForCatchup_FullMethodName      = "/p2p_api.PeerService/GetPeersForCatchup"
 	PeerService_ReportValidSubtree_FullMethodName      = "/p2p_api.PeerService/ReportValidSubtree"
 	PeerService_ReportValidBlock_FullMethodName        = "/p2p_api.PeerService/ReportValidBlock"
+	PeerService_RecordInvalidSubtree_FullMethodName    = "/p2p_api.PeerService/RecordInvalidSubtree"
+	PeerService_RecordInvalidBlock_FullMethodName      = "/p2p_api.PeerService/RecordInvalidBlock"
 	PeerService_IsPeerMalicious_FullMethodName         = "/p2p_api.PeerService/IsPeerMalicious"
 	PeerService_IsPeerUnhealthy_FullMethodName         = "/p2p_api.PeerService/IsPeerUnhealthy"
 	PeerService_GetPeerRegistry_FullMethodName         = "/p2p_api.PeerService/GetPeerRegistry"
 	PeerService_RecordBytesDownloaded_FullMethodName   = "/p2p_api.PeerService/RecordBytesDownloaded"
+	PeerService_RecordBytesUploaded_FullMethodName     = "/p2p_api.PeerService/RecordBytesUploaded"
 	PeerService_GetPeer_FullMethodName                 = "/p2p_api.PeerService/GetPeer"
+	PeerService_GetNetworkOverview_FullMethodName      = "/p2p_api.PeerService/GetNetworkOverview"
+	PeerService_GetPeerEvents_FullMethodName           = "/p2p_api.PeerService/GetPeerEvents"
+	PeerService_GetPeerContributions_FullMethodName    = "/p2p_api.PeerService/GetPeerContributions"
+	PeerService_SignIdentity_FullMethodName            = "/p2p_api.PeerService/SignIdentity"
+	PeerService_SendOperatorMessage_FullMethodName     = "/p2p_api.PeerService/SendOperatorMessage"
+	PeerService_GetOperatorMessages_FullMethodName     = "/p2p_api.PeerService/GetOperatorMessages"
+	PeerService_SubscribeDoubleSpends_FullMethodName   = "/p2p_api.PeerService/SubscribeDoubleSpends"
 )
 
 // PeerServiceClient is the client API for PeerService service.
@@ -71,6 +81,8 @@ type PeerServiceClient interface {
 	// Subtree and block validation reporting
 	ReportValidSubtree(ctx context.Context, in *ReportValidSubtreeRequest, opts ...grpc.CallOption) (*ReportValidSubtreeResponse, error)
 	ReportValidBlock(ctx context.Context, in *ReportValidBlockRequest, opts ...grpc.CallOption) (*ReportValidBlockResponse, error)
+	RecordInvalidSubtree(ctx context.Context, in *RecordInvalidSubtreeRequest, opts ...grpc.CallOption) (*RecordInvalidSubtreeResponse, error)
+	RecordInvalidBlock(ctx context.Context, in *RecordInvalidBlockRequest, opts ...grpc.CallOption) (*RecordInvalidBlockResponse, error)
 	// Peer status checking
 	IsPeerMalicious(ctx context.Context, in *IsPeerMaliciousRequest, opts ...grpc.CallOption) (*IsPeerMaliciousResponse, error)
 	IsPeerUnhealthy(ctx context.Context, in *IsPeerUnhealthyRequest, opts ...grpc.CallOption) (*IsPeerUnhealthyResponse, error)
@@ -78,8 +90,21 @@ type PeerServiceClient interface {
 	GetPeerRegistry(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetPeerRegistryResponse, error)
 	// Record bytes downloaded via HTTP from a peer
 	RecordBytesDownloaded(ctx context.Context, in *RecordBytesDownloadedRequest, opts ...grpc.CallOption) (*RecordBytesDownloadedResponse, error)
+	RecordBytesUploaded(ctx context.Context, in *RecordBytesUploadedRequest, opts ...grpc.CallOption) (*RecordBytesUploadedResponse, error)
 	// Get single peer information by peer ID
 	GetPeer(ctx context.Context, in *GetPeerRequest, opts ...grpc.CallOption) (*GetPeerResponse, error)
+	// Get aggregate network overview computed from the peer registry
+	GetNetworkOverview(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNetworkOverviewResponse, error)
+	// Get peer lifecycle events filtered by time range and peer ID
+	GetPeerEvents(ctx context.Context, in *GetPeerEventsRequest, opts ...grpc.CallOption) (*GetPeerEventsResponse, error)
+	GetPeerContributions(ctx context.Context, in *GetPeerContributionsRequest, opts ...grpc.CallOption) (*GetPeerContributionsResponse, error)
+	// Sign an identity document for the DataHub URL of this node, served to verifying peers by the asset service
+	SignIdentity(ctx context.Context, in *SignIdentityRequest, opts ...grpc.CallOption) (*SignIdentityResponse, error)
+	// Direct messages between node operators, encrypted to the recipient peer
+	SendOperatorMessage(ctx context.Context, in *SendOperatorMessageRequest, opts ...grpc.CallOption) (*SendOperatorMessageResponse, error)
+	GetOperatorMessages(ctx context.Context, in *GetOperatorMessagesRequest, opts ...grpc.CallOption) (*GetOperatorMessagesResponse, error)
+	// Stream the double spends detected by the validator, until the client ends the stream
+	SubscribeDoubleSpends(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DoubleSpendEvent], error)
 }
 
 type peerServiceClient struct {
@@ -270,6 +295,26 @@ func (c *peerServiceClient) ReportValidBlock(ctx context.Context, in *ReportVali
 	return out, nil
 }
 
+func (c *peerServiceClient) RecordInvalidSubtree(ctx context.Context, in *RecordInvalidSubtreeRequest, opts ...grpc.CallOption) (*RecordInvalidSubtreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordInvalidSubtreeResponse)
+	err := c.cc.Invoke(ctx, PeerService_RecordInvalidSubtree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) RecordInvalidBlock(ctx context.Context, in *RecordInvalidBlockRequest, opts ...grpc.CallOption) (*RecordInvalidBlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordInvalidBlockResponse)
+	err := c.cc.Invoke(ctx, PeerService_RecordInvalidBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *peerServiceClient) IsPeerMalicious(ctx context.Context, in *IsPeerMaliciousRequest, opts ...grpc.CallOption) (*IsPeerMaliciousResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(IsPeerMaliciousResponse)
@@ -310,6 +355,16 @@ func (c *peerServiceClient) RecordBytesDownloaded(ctx context.Context, in *Recor
 	return out, nil
 }
 
+func (c *peerServiceClient) RecordBytesUploaded(ctx context.Context, in *RecordBytesUploadedRequest, opts ...grpc.CallOption) (*RecordBytesUploadedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordBytesUploadedResponse)
+	err := c.cc.Invoke(ctx, PeerService_RecordBytesUploaded_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *peerServiceClient) GetPeer(ctx context.Context, in *GetPeerRequest, opts ...grpc.CallOption) (*GetPeerResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetPeerResponse)
@@ -320,6 +375,85 @@ func (c *peerServiceClient) GetPeer(ctx context.Context, in *GetPeerRequest, opt
 	return out, nil
 }
 
+func (c *peerServiceClient) GetNetworkOverview(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetNetworkOverviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNetworkOverviewResponse)
+	err := c.cc.Invoke(ctx, PeerService_GetNetworkOverview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) GetPeerEvents(ctx context.Context, in *GetPeerEventsRequest, opts ...grpc.CallOption) (*GetPeerEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPeerEventsResponse)
+	err := c.cc.Invoke(ctx, PeerService_GetPeerEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) GetPeerContributions(ctx context.Context, in *GetPeerContributionsRequest, opts ...grpc.CallOption) (*GetPeerContributionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPeerContributionsResponse)
+	err := c.cc.Invoke(ctx, PeerService_GetPeerContributions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) SignIdentity(ctx context.Context, in *SignIdentityRequest, opts ...grpc.CallOption) (*SignIdentityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignIdentityResponse)
+	err := c.cc.Invoke(ctx, PeerService_SignIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) SendOperatorMessage(ctx context.Context, in *SendOperatorMessageRequest, opts ...grpc.CallOption) (*SendOperatorMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendOperatorMessageResponse)
+	err := c.cc.Invoke(ctx, PeerService_SendOperatorMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) GetOperatorMessages(ctx context.Context, in *GetOperatorMessagesRequest, opts ...grpc.CallOption) (*GetOperatorMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOperatorMessagesResponse)
+	err := c.cc.Invoke(ctx, PeerService_GetOperatorMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *peerServiceClient) SubscribeDoubleSpends(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DoubleSpendEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PeerService_ServiceDesc.Streams[0], PeerService_SubscribeDoubleSpends_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[emptypb.Empty, DoubleSpendEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PeerService_SubscribeDoubleSpendsClient = grpc.ServerStreamingClient[DoubleSpendEvent]
+
 // PeerServiceServer is the server API for PeerService service.
 // All implementations must embed UnimplementedPeerServiceServer
 // for forward compatibility.
@@ -346,6 +480,8 @@ type PeerServiceServer interface {
 	// Subtree and block validation reporting
 	ReportValidSubtree(context.Context, *ReportValidSubtreeRequest) (*ReportValidSubtreeResponse, error)
 	ReportValidBlock(context.Context, *ReportValidBlockRequest) (*ReportValidBlockResponse, error)
+	RecordInvalidSubtree(context.Context, *RecordInvalidSubtreeRequest) (*RecordInvalidSubtreeResponse, error)
+	RecordInvalidBlock(context.Context, *RecordInvalidBlockRequest) (*RecordInvalidBlockResponse, error)
 	// Peer status checking
 	IsPeerMalicious(context.Context, *IsPeerMaliciousRequest) (*IsPeerMaliciousResponse, error)
 	IsPeerUnhealthy(context.Context, *IsPeerUnhealthyRequest) (*IsPeerUnhealthyResponse, error)
@@ -353,8 +489,21 @@ type PeerServiceServer interface {
 	GetPeerRegistry(context.Context, *emptypb.Empty) (*GetPeerRegistryResponse, error)
 	// Record bytes downloaded via HTTP from a peer
 	RecordBytesDownloaded(context.Context, *RecordBytesDownloadedRequest) (*RecordBytesDownloadedResponse, error)
+	RecordBytesUploaded(context.Context, *RecordBytesUploadedRequest) (*RecordBytesUploadedResponse, error)
 	// Get single peer information by peer ID
 	GetPeer(context.Context, *GetPeerRequest) (*GetPeerResponse, error)
+	// Get aggregate network overview computed from the peer registry
+	GetNetworkOverview(context.Context, *emptypb.Empty) (*GetNetworkOverviewResponse, error)
+	// Get peer lifecycle events filtered by time range and peer ID
+	GetPeerEvents(context.Context, *GetPeerEventsRequest) (*GetPeerEventsResponse, error)
+	GetPeerContributions(context.Context, *GetPeerContributionsRequest) (*GetPeerContributionsResponse, error)
+	// Sign an identity document for the DataHub URL of this node, served to verifying peers by the asset service
+	SignIdentity(context.Context, *SignIdentityRequest) (*SignIdentityResponse, error)
+	// Direct messages between node operators, encrypted to the recipient peer
+	SendOperatorMessage(context.Context, *SendOperatorMessageRequest) (*SendOperatorMessageResponse, error)
+	GetOperatorMessages(context.Context, *GetOperatorMessagesRequest) (*GetOperatorMessagesResponse, error)
+	// Stream the double spends detected by the validator, until the client ends the stream
+	SubscribeDoubleSpends(*emptypb.Empty, grpc.ServerStreamingServer[DoubleSpendEvent]) error
 	mustEmbedUnimplementedPeerServiceServer()
 }
 
@@ -419,6 +568,12 @@ func (UnimplementedPeerServiceServer) ReportValidSubtree(context.Context, *Repor
 func (UnimplementedPeerServiceServer) ReportValidBlock(context.Context, *ReportValidBlockRequest) (*ReportValidBlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReportValidBlock not implemented")
 }
+func (UnimplementedPeerServiceServer) RecordInvalidSubtree(context.Context, *RecordInvalidSubtreeRequest) (*RecordInvalidSubtreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordInvalidSubtree not implemented")
+}
+func (UnimplementedPeerServiceServer) RecordInvalidBlock(context.Context, *RecordInvalidBlockRequest) (*RecordInvalidBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordInvalidBlock not implemented")
+}
 func (UnimplementedPeerServiceServer) IsPeerMalicious(context.Context, *IsPeerMaliciousRequest) (*IsPeerMaliciousResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsPeerMalicious not implemented")
 }
@@ -431,9 +586,33 @@ func (UnimplementedPeerServiceServer) GetPeerRegistry(context.Context, *emptypb.
 func (UnimplementedPeerServiceServer) RecordBytesDownloaded(context.Context, *RecordBytesDownloadedRequest) (*RecordBytesDownloadedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RecordBytesDownloaded not implemented")
 }
+func (UnimplementedPeerServiceServer) RecordBytesUploaded(context.Context, *RecordBytesUploadedRequest) (*RecordBytesUploadedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordBytesUploaded not implemented")
+}
 func (UnimplementedPeerServiceServer) GetPeer(context.Context, *GetPeerRequest) (*GetPeerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPeer not implemented")
 }
+func (UnimplementedPeerServiceServer) GetNetworkOverview(context.Context, *emptypb.Empty) (*GetNetworkOverviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkOverview not implemented")
+}
+func (UnimplementedPeerServiceServer) GetPeerEvents(context.Context, *GetPeerEventsRequest) (*GetPeerEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerEvents not implemented")
+}
+func (UnimplementedPeerServiceServer) GetPeerContributions(context.Context, *GetPeerContributionsRequest) (*GetPeerContributionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerContributions not implemented")
+}
+func (UnimplementedPeerServiceServer) SignIdentity(context.Context, *SignIdentityRequest) (*SignIdentityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignIdentity not implemented")
+}
+func (UnimplementedPeerServiceServer) SendOperatorMessage(context.Context, *SendOperatorMessageRequest) (*SendOperatorMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendOperatorMessage not implemented")
+}
+func (UnimplementedPeerServiceServer) GetOperatorMessages(context.Context, *GetOperatorMessagesRequest) (*GetOperatorMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperatorMessages not implemented")
+}
+func (UnimplementedPeerServiceServer) SubscribeDoubleSpends(*emptypb.Empty, grpc.ServerStreamingServer[DoubleSpendEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeDoubleSpends not implemented")
+}
 func (UnimplementedPeerServiceServer) mustEmbedUnimplementedPeerServiceServer() {}
 func (UnimplementedPeerServiceServer) testEmbeddedByValue()                     {}
 
@@ -779,6 +958,42 @@ func _PeerService_ReportValidBlock_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PeerService_RecordInvalidSubtree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordInvalidSubtreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).RecordInvalidSubtree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerService_RecordInvalidSubtree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).RecordInvalidSubtree(ctx, req.(*RecordInvalidSubtreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerService_RecordInvalidBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordInvalidBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).RecordInvalidBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerService_RecordInvalidBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).RecordInvalidBlock(ctx, req.(*RecordInvalidBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PeerService_IsPeerMalicious_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IsPeerMaliciousRequest)
 	if err := dec(in); err != nil {
@@ -851,6 +1066,24 @@ func _PeerService_RecordBytesDownloaded_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PeerService_RecordBytesUploaded_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordBytesUploadedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).RecordBytesUploaded(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerService_RecordBytesUploaded_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).RecordBytesUploaded(ctx, req.(*RecordBytesUploadedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PeerService_GetPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPeerRequest)
 	if err := dec(in); err != nil {
@@ -869,6 +1102,125 @@ func _PeerService_GetPeer_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PeerService_GetNetworkOverview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).GetNetworkOverview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerService_GetNetworkOverview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).GetNetworkOverview(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerService_GetPeerEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).GetPeerEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerService_GetPeerEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).GetPeerEvents(ctx, req.(*GetPeerEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerService_GetPeerContributions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerContributionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).GetPeerContributions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerService_GetPeerContributions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).GetPeerContributions(ctx, req.(*GetPeerContributionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerService_SignIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).SignIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerService_SignIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).SignIdentity(ctx, req.(*SignIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerService_SendOperatorMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendOperatorMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).SendOperatorMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerService_SendOperatorMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).SendOperatorMessage(ctx, req.(*SendOperatorMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerService_GetOperatorMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperatorMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerServiceServer).GetOperatorMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerService_GetOperatorMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerServiceServer).GetOperatorMessages(ctx, req.(*GetOperatorMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeerService_SubscribeDoubleSpends_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PeerServiceServer).SubscribeDoubleSpends(m, &grpc.GenericServerStream[emptypb.Empty, DoubleSpendEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PeerService_SubscribeDoubleSpendsServer = grpc.ServerStreamingServer[DoubleSpendEvent]
+
 // PeerService_ServiceDesc is the grpc.ServiceDesc for PeerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -948,6 +1300,14 @@ var PeerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReportValidBlock",
 			Handler:    _PeerService_ReportValidBlock_Handler,
 		},
+		{
+			MethodName: "RecordInvalidSubtree",
+			Handler:    _PeerService_RecordInvalidSubtree_Handler,
+		},
+		{
+			MethodName: "RecordInvalidBlock",
+			Handler:    _PeerService_RecordInvalidBlock_Handler,
+		},
 		{
 			MethodName: "IsPeerMalicious",
 			Handler:    _PeerService_IsPeerMalicious_Handler,
@@ -964,11 +1324,45 @@ var PeerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RecordBytesDownloaded",
 			Handler:    _PeerService_RecordBytesDownloaded_Handler,
 		},
+		{
+			MethodName: "RecordBytesUploaded",
+			Handler:    _PeerService_RecordBytesUploaded_Handler,
+		},
 		{
 			MethodName: "GetPeer",
 			Handler:    _PeerService_GetPeer_Handler,
 		},
+		{
+			MethodName: "GetNetworkOverview",
+			Handler:    _PeerService_GetNetworkOverview_Handler,
+		},
+		{
+			MethodName: "GetPeerEvents",
+			Handler:    _PeerService_GetPeerEvents_Handler,
+		},
+		{
+			MethodName: "GetPeerContributions",
+			Handler:    _PeerService_GetPeerContributions_Handler,
+		},
+		{
+			MethodName: "SignIdentity",
+			Handler:    _PeerService_SignIdentity_Handler,
+		},
+		{
+			MethodName: "SendOperatorMessage",
+			Handler:    _PeerService_SendOperatorMessage_Handler,
+		},
+		{
+			MethodName: "GetOperatorMessages",
+			Handler:    _PeerService_GetOperatorMessages_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeDoubleSpends",
+			Handler:       _PeerService_SubscribeDoubleSpends_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/p2p/p2p_api/p2p_api.proto",
 }
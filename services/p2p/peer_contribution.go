@@ -0,0 +1,298 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+)
+
+// Data types accounted in the peer contribution ledger
+const (
+	PeerDataTypeBlock       = "block"
+	PeerDataTypeSubtree     = "subtree"
+	PeerDataTypeSubtreeData = "subtree_data"
+)
+
+// PeerContributionCacheVersion is the current version of the contribution ledger file format
+const PeerContributionCacheVersion = "1.0"
+
+// PeerContribution holds the lifetime totals of the data a peer served to us and the data we served to it
+type PeerContribution struct {
+	PeerID string `json:"peer_id"`
+
+	// Data the peer served to us
+	BlocksReceived      uint64 `json:"blocks_received"`
+	SubtreesReceived    uint64 `json:"subtrees_received"`
+	SubtreeDataReceived uint64 `json:"subtree_data_received"`
+	BytesReceived       uint64 `json:"bytes_received"`
+
+	// Data we served to the peer
+	BlocksServed      uint64 `json:"blocks_served"`
+	SubtreesServed    uint64 `json:"subtrees_served"`
+	SubtreeDataServed uint64 `json:"subtree_data_served"`
+	BytesServed       uint64 `json:"bytes_served"`
+
+	FirstSeen   time.Time `json:"first_seen"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+// peerContributionCache is the persistent format of the contribution ledger
+type peerContributionCache struct {
+	Version     string                       `json:"version"`
+	LastUpdated time.Time                    `json:"last_updated"`
+	Peers       map[string]*PeerContribution `json:"peers"`
+}
+
+// PeerContributionLedger accounts per peer the blocks, subtrees and bytes the peer served to us and we served
+// to it, so operators can identify peers that only take data from the network. Unlike the peer registry the
+// ledger keeps the totals of peers that disconnected, and it is persisted next to the peer registry cache.
+// A nil PeerContributionLedger discards all records.
+type PeerContributionLedger struct {
+	mu    sync.RWMutex
+	peers map[string]*PeerContribution
+	now   func() time.Time
+}
+
+// NewPeerContributionLedger creates an empty contribution ledger
+func NewPeerContributionLedger() *PeerContributionLedger {
+	return &PeerContributionLedger{
+		peers: make(map[string]*PeerContribution),
+		now:   time.Now,
+	}
+}
+
+// RecordReceived accounts data of the given type the peer served to us. Items is the number of blocks,
+// subtrees or subtree data files in the transfer, data of an unknown type is only accounted in bytes.
+func (l *PeerContributionLedger) RecordReceived(peerID string, dataType string, items uint64, bytes uint64) {
+	if l == nil || peerID == "" {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c := l.getOrCreate(peerID)
+	c.BytesReceived += bytes
+
+	switch dataType {
+	case PeerDataTypeBlock:
+		c.BlocksReceived += items
+	case PeerDataTypeSubtree:
+		c.SubtreesReceived += items
+	case PeerDataTypeSubtreeData:
+		c.SubtreeDataReceived += items
+	}
+}
+
+// RecordServed accounts data of the given type we served to the peer
+func (l *PeerContributionLedger) RecordServed(peerID string, dataType string, items uint64, bytes uint64) {
+	if l == nil || peerID == "" {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c := l.getOrCreate(peerID)
+	c.BytesServed += bytes
+
+	switch dataType {
+	case PeerDataTypeBlock:
+		c.BlocksServed += items
+	case PeerDataTypeSubtree:
+		c.SubtreesServed += items
+	case PeerDataTypeSubtreeData:
+		c.SubtreeDataServed += items
+	}
+}
+
+// Get returns a copy of the contribution of a peer
+func (l *PeerContributionLedger) Get(peerID string) (PeerContribution, bool) {
+	if l == nil {
+		return PeerContribution{}, false
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	c, exists := l.peers[peerID]
+	if !exists {
+		return PeerContribution{}, false
+	}
+
+	return *c, true
+}
+
+// List returns a copy of the contributions of all peers, ordered by the bytes the peer served to us, lowest
+// first, so the peers contributing the least are listed at the top
+func (l *PeerContributionLedger) List() []PeerContribution {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.RLock()
+	result := make([]PeerContribution, 0, len(l.peers))
+
+	for _, c := range l.peers {
+		result = append(result, *c)
+	}
+	l.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].BytesReceived != result[j].BytesReceived {
+			return result[i].BytesReceived < result[j].BytesReceived
+		}
+
+		return result[i].PeerID < result[j].PeerID
+	})
+
+	return result
+}
+
+// Save writes the ledger to the teranode_peer_contributions.json file in the cache directory
+func (l *PeerContributionLedger) Save(cacheDir string) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.RLock()
+	cache := &peerContributionCache{
+		Version:     PeerContributionCacheVersion,
+		LastUpdated: l.now(),
+		Peers:       l.peers,
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	l.mu.RUnlock()
+
+	if err != nil {
+		return errors.NewProcessingError("failed to marshal peer contribution ledger", err)
+	}
+
+	// Write to temporary file first, then rename for atomicity
+	cacheFile := getPeerContributionFilePath(cacheDir)
+	tempFile := fmt.Sprintf("%s.tmp.%d", cacheFile, time.Now().UnixNano())
+
+	if err = writePeerCacheFile(tempFile, data); err != nil {
+		_ = os.Remove(tempFile)
+		return errors.NewStorageError("failed to write peer contribution ledger", err)
+	}
+
+	if err = os.Rename(tempFile, cacheFile); err != nil {
+		_ = os.Remove(tempFile)
+		return errors.NewStorageError("failed to finalize peer contribution ledger", err)
+	}
+
+	return nil
+}
+
+// Load reads the ledger from the cache directory, a missing file is not an error
+func (l *PeerContributionLedger) Load(cacheDir string) error {
+	if l == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(getPeerContributionFilePath(cacheDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return errors.NewStorageError("failed to read peer contribution ledger", err)
+	}
+
+	var cache peerContributionCache
+	if err = json.Unmarshal(data, &cache); err != nil {
+		return errors.NewProcessingError("failed to unmarshal peer contribution ledger", err)
+	}
+
+	if cache.Version != PeerContributionCacheVersion {
+		return errors.NewProcessingError("peer contribution ledger version mismatch: expected %s, got %s", PeerContributionCacheVersion, cache.Version)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for peerID, c := range cache.Peers {
+		if c == nil {
+			continue
+		}
+
+		c.PeerID = peerID
+		l.peers[peerID] = c
+	}
+
+	return nil
+}
+
+// getOrCreate returns the contribution of a peer, creating it when needed, must be called with the lock held
+func (l *PeerContributionLedger) getOrCreate(peerID string) *PeerContribution {
+	now := l.now()
+
+	c, exists := l.peers[peerID]
+	if !exists {
+		c = &PeerContribution{
+			PeerID:    peerID,
+			FirstSeen: now,
+		}
+		l.peers[peerID] = c
+	}
+
+	c.LastUpdated = now
+
+	return c
+}
+
+// getPeerContributionFilePath constructs the full path to the teranode_peer_contributions.json file
+func getPeerContributionFilePath(configuredDir string) string {
+	dir := configuredDir
+	if dir == "" {
+		dir = "."
+	}
+
+	return filepath.Join(dir, "teranode_peer_contributions.json")
+}
+
+// GetPeerContributions returns the data contribution accounting of a single peer, or of all peers when the
+// request does not name a peer. A peer without any recorded data returns no contributions.
+func (s *Server) GetPeerContributions(_ context.Context, req *p2p_api.GetPeerContributionsRequest) (*p2p_api.GetPeerContributionsResponse, error) {
+	var contributions []PeerContribution
+
+	if req.PeerId != "" {
+		if c, exists := s.peerContributions.Get(req.PeerId); exists {
+			contributions = []PeerContribution{c}
+		}
+	} else {
+		contributions = s.peerContributions.List()
+	}
+
+	resp := &p2p_api.GetPeerContributionsResponse{
+		Contributions: make([]*p2p_api.PeerContribution, 0, len(contributions)),
+	}
+
+	for _, c := range contributions {
+		resp.Contributions = append(resp.Contributions, &p2p_api.PeerContribution{
+			PeerId:              c.PeerID,
+			BlocksReceived:      c.BlocksReceived,
+			SubtreesReceived:    c.SubtreesReceived,
+			SubtreeDataReceived: c.SubtreeDataReceived,
+			BytesReceived:       c.BytesReceived,
+			BlocksServed:        c.BlocksServed,
+			SubtreesServed:      c.SubtreesServed,
+			SubtreeDataServed:   c.SubtreeDataServed,
+			BytesServed:         c.BytesServed,
+			FirstSeen:           c.FirstSeen.UnixMilli(),
+			LastUpdated:         c.LastUpdated.UnixMilli(),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,135 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerContributionLedger_Record(t *testing.T) {
+	l := NewPeerContributionLedger()
+
+	now := time.UnixMilli(1_700_000_000_000)
+	l.now = func() time.Time { return now }
+
+	l.RecordReceived("peer1", PeerDataTypeBlock, 10, 1000)
+	l.RecordReceived("peer1", PeerDataTypeSubtree, 1, 200)
+	l.RecordReceived("peer1", PeerDataTypeSubtreeData, 1, 5000)
+	l.RecordReceived("peer1", "", 0, 50)
+
+	now = now.Add(time.Minute)
+	l.RecordServed("peer1", PeerDataTypeBlock, 2, 300)
+	l.RecordServed("peer2", PeerDataTypeSubtree, 3, 600)
+
+	c, exists := l.Get("peer1")
+	require.True(t, exists)
+	assert.Equal(t, PeerContribution{
+		PeerID:              "peer1",
+		BlocksReceived:      10,
+		SubtreesReceived:    1,
+		SubtreeDataReceived: 1,
+		BytesReceived:       6250,
+		BlocksServed:        2,
+		BytesServed:         300,
+		FirstSeen:           time.UnixMilli(1_700_000_000_000),
+		LastUpdated:         now,
+	}, c)
+
+	// peer2 only took data from us, so it is listed first
+	list := l.List()
+	require.Len(t, list, 2)
+	assert.Equal(t, "peer2", list[0].PeerID)
+	assert.Equal(t, uint64(3), list[0].SubtreesServed)
+	assert.Zero(t, list[0].BytesReceived)
+
+	_, exists = l.Get("peer3")
+	assert.False(t, exists)
+}
+
+func TestPeerContributionLedger_Persistence(t *testing.T) {
+	dir := t.TempDir()
+
+	l := NewPeerContributionLedger()
+	l.RecordReceived("peer1", PeerDataTypeBlock, 5, 500)
+	l.RecordServed("peer2", PeerDataTypeSubtreeData, 1, 100)
+	require.NoError(t, l.Save(dir))
+
+	loaded := NewPeerContributionLedger()
+	require.NoError(t, loaded.Load(dir))
+
+	c, exists := loaded.Get("peer1")
+	require.True(t, exists)
+	assert.Equal(t, uint64(5), c.BlocksReceived)
+	assert.Equal(t, uint64(500), c.BytesReceived)
+
+	c, exists = loaded.Get("peer2")
+	require.True(t, exists)
+	assert.Equal(t, uint64(1), c.SubtreeDataServed)
+
+	// a missing ledger file starts an empty ledger
+	empty := NewPeerContributionLedger()
+	require.NoError(t, empty.Load(t.TempDir()))
+	assert.Empty(t, empty.List())
+}
+
+func TestPeerContributionLedger_Nil(t *testing.T) {
+	var l *PeerContributionLedger
+
+	l.RecordReceived("peer1", PeerDataTypeBlock, 1, 1)
+	l.RecordServed("peer1", PeerDataTypeBlock, 1, 1)
+	assert.Empty(t, l.List())
+	assert.NoError(t, l.Save(t.TempDir()))
+	assert.NoError(t, l.Load(t.TempDir()))
+}
+
+func TestServer_PeerContributions(t *testing.T) {
+	s := &Server{
+		logger:            ulogger.New("test"),
+		peerRegistry:      NewPeerRegistry(),
+		bandwidthTracker:  NewPeerBandwidthTracker(time.Hour, 0, 0),
+		peerContributions: NewPeerContributionLedger(),
+	}
+
+	ctx := context.Background()
+
+	_, err := s.RecordBytesDownloaded(ctx, &p2p_api.RecordBytesDownloadedRequest{
+		PeerId:          peerIDStr,
+		BytesDownloaded: 1000,
+		DataType:        PeerDataTypeBlock,
+		Items:           4,
+	})
+	require.NoError(t, err)
+
+	_, err = s.RecordBytesUploaded(ctx, &p2p_api.RecordBytesUploadedRequest{
+		PeerId:        peerIDStr,
+		BytesUploaded: 200,
+		DataType:      PeerDataTypeSubtree,
+		Items:         1,
+	})
+	require.NoError(t, err)
+
+	// an invalid peer ID is not accounted
+	_, err = s.RecordBytesUploaded(ctx, &p2p_api.RecordBytesUploadedRequest{PeerId: "invalid", BytesUploaded: 200})
+	require.Error(t, err)
+
+	resp, err := s.GetPeerContributions(ctx, &p2p_api.GetPeerContributionsRequest{PeerId: peerIDStr})
+	require.NoError(t, err)
+	require.Len(t, resp.Contributions, 1)
+	assert.Equal(t, uint64(4), resp.Contributions[0].BlocksReceived)
+	assert.Equal(t, uint64(1000), resp.Contributions[0].BytesReceived)
+	assert.Equal(t, uint64(1), resp.Contributions[0].SubtreesServed)
+	assert.Equal(t, uint64(200), resp.Contributions[0].BytesServed)
+
+	resp, err = s.GetPeerContributions(ctx, &p2p_api.GetPeerContributionsRequest{PeerId: "unknown"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Contributions)
+
+	resp, err = s.GetPeerContributions(ctx, &p2p_api.GetPeerContributionsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Contributions, 1)
+}
@@ -0,0 +1,399 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+)
+
+// PeerEventType is the type of a peer lifecycle event
+type PeerEventType string
+
+// Peer lifecycle event types
+const (
+	PeerEventConnected         PeerEventType = "connected"
+	PeerEventDisconnected      PeerEventType = "disconnected"
+	PeerEventBanScore          PeerEventType = "ban_score"
+	PeerEventBanned            PeerEventType = "banned"
+	PeerEventUnbanned          PeerEventType = "unbanned"
+	PeerEventBanExpired        PeerEventType = "ban_expired"
+	PeerEventReputationChanged PeerEventType = "reputation_changed"
+	PeerEventCatchupAttempt    PeerEventType = "catchup_attempt"
+	PeerEventCatchupSuccess    PeerEventType = "catchup_success"
+	PeerEventCatchupFailure    PeerEventType = "catchup_failure"
+	PeerEventCatchupMalicious  PeerEventType = "catchup_malicious"
+	PeerEventDataHubDown       PeerEventType = "datahub_down"
+	PeerEventDataHubRestored   PeerEventType = "datahub_restored"
+
+	PeerEventDataHubVerified         PeerEventType = "datahub_verified"
+	PeerEventDataHubIdentityMismatch PeerEventType = "datahub_identity_mismatch"
+
+	PeerEventOperatorMessageSent     PeerEventType = "operator_message_sent"
+	PeerEventOperatorMessageReceived PeerEventType = "operator_message_received"
+
+	PeerEventDoubleSpend PeerEventType = "double_spend"
+	PeerEventInvalidData PeerEventType = "invalid_data"
+
+	PeerEventMinerIDAnnounced PeerEventType = "miner_id_announced"
+
+	PeerEventProtocolIncompatible PeerEventType = "protocol_incompatible"
+	PeerEventProtocolCompatible   PeerEventType = "protocol_compatible"
+)
+
+// peerEventReputationMinDelta is the minimum change of a reputation score that is recorded as an event,
+// so the small adjustments after every interaction do not flood the event log
+const peerEventReputationMinDelta = 1.0
+
+// peerEventLogRotatedLayout is the time layout of the suffix of rotated peer event log files, which sorts the
+// rotated files by name in the order they were rotated
+const peerEventLogRotatedLayout = "20060102T150405.000000000"
+
+// PeerEvent is a single entry of the peer event log
+type PeerEvent struct {
+	Timestamp time.Time     `json:"timestamp"`
+	PeerID    string        `json:"peer_id"`
+	Type      PeerEventType `json:"type"`
+	Details   string        `json:"details,omitempty"`
+}
+
+// PeerEventLog is an append-only log of peer lifecycle events for post-incident analysis. The most recent
+// events are kept in memory in a ring buffer. When a file is configured, every event is also appended to
+// the file as a JSON line, and the most recent events of a previous run are loaded from it on startup.
+// Once the file exceeds the maximum size it is rotated to <file>.<time of rotation>, the rotated files are
+// removed by the retention cleanup of the P2P service. A nil PeerEventLog discards all events.
+type PeerEventLog struct {
+	mu           sync.RWMutex
+	events       []PeerEvent // ring buffer, next is the position of the oldest event once the buffer is full
+	next         int
+	full         bool
+	path         string
+	maxFileBytes int64
+	file         *os.File
+	size         int64
+	now          func() time.Time
+}
+
+// NewPeerEventLog creates an event log keeping maxEvents events in memory. When filePath is not empty the
+// events are also appended to that file, which is rotated once it exceeds maxFileBytes. A maxFileBytes of 0
+// or lower disables rotation.
+func NewPeerEventLog(maxEvents int, filePath string, maxFileBytes int64) (*PeerEventLog, error) {
+	if maxEvents <= 0 {
+		return nil, errors.NewConfigurationError("peer event log size must be positive, got %d", maxEvents)
+	}
+
+	l := &PeerEventLog{
+		events:       make([]PeerEvent, 0, maxEvents),
+		path:         filePath,
+		maxFileBytes: maxFileBytes,
+		now:          time.Now,
+	}
+
+	if filePath == "" {
+		return l, nil
+	}
+
+	for _, file := range append(l.rotatedFiles(), filePath) {
+		if err := l.load(file); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return nil, errors.NewStorageError("failed to create peer event log directory for %s", filePath, err)
+	}
+
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Record appends an event for the peer to the log
+func (l *PeerEventLog) Record(peerID string, eventType PeerEventType, details string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	event := PeerEvent{
+		Timestamp: l.now(),
+		PeerID:    peerID,
+		Type:      eventType,
+		Details:   details,
+	}
+
+	l.add(event)
+
+	if l.file != nil {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+
+		data = append(data, '\n')
+
+		if l.maxFileBytes > 0 && l.size > 0 && l.size+int64(len(data)) > l.maxFileBytes {
+			if err = l.rotate(); err != nil {
+				return
+			}
+		}
+
+		// best effort, the in-memory log stays complete if the file can not be written
+		n, _ := l.file.Write(data)
+		l.size += int64(n)
+	}
+}
+
+// Query returns the events within [from, to], oldest first. A zero from or to leaves that side of the time
+// range open, an empty peerID matches all peers. When limit is positive only the most recent limit matching
+// events are returned.
+func (l *PeerEventLog) Query(from time.Time, to time.Time, peerID string, limit int) []PeerEvent {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	result := make([]PeerEvent, 0)
+
+	for _, event := range l.ordered() {
+		if !from.IsZero() && event.Timestamp.Before(from) {
+			continue
+		}
+
+		if !to.IsZero() && event.Timestamp.After(to) {
+			continue
+		}
+
+		if peerID != "" && event.PeerID != peerID {
+			continue
+		}
+
+		result = append(result, event)
+	}
+
+	if limit > 0 && len(result) > limit {
+		result = result[len(result)-limit:]
+	}
+
+	return result
+}
+
+// Files returns the files of the event log, the rotated files oldest first followed by the current file
+func (l *PeerEventLog) Files() []string {
+	if l == nil || l.path == "" {
+		return nil
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return append(l.rotatedFiles(), l.path)
+}
+
+// File returns the file events are currently appended to, empty when the events are only kept in memory
+func (l *PeerEventLog) File() string {
+	if l == nil {
+		return ""
+	}
+
+	return l.path
+}
+
+// Len returns the number of events kept in memory
+func (l *PeerEventLog) Len() int {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return len(l.events)
+}
+
+// Close closes the event log file
+func (l *PeerEventLog) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+
+	if err != nil {
+		return errors.NewStorageError("failed to close peer event log file", err)
+	}
+
+	return nil
+}
+
+// open opens the event log file for appending, must be called with the lock held
+func (l *PeerEventLog) open() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.NewStorageError("failed to open peer event log file %s", l.path, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return errors.NewStorageError("failed to stat peer event log file %s", l.path, err)
+	}
+
+	l.file = f
+	l.size = info.Size()
+
+	return nil
+}
+
+// rotate moves the event log file aside and starts a new file, must be called with the lock held
+func (l *PeerEventLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return errors.NewStorageError("failed to close peer event log file %s", l.path, err)
+	}
+
+	l.file = nil
+
+	rotated := l.path + "." + l.now().UTC().Format(peerEventLogRotatedLayout)
+	if err := os.Rename(l.path, rotated); err != nil && !os.IsNotExist(err) {
+		return errors.NewStorageError("failed to rotate peer event log file %s", l.path, err)
+	}
+
+	return l.open()
+}
+
+// rotatedFiles returns the rotated files of the event log, oldest first
+func (l *PeerEventLog) rotatedFiles() []string {
+	matches, err := filepath.Glob(l.path + ".*")
+	if err != nil {
+		return nil
+	}
+
+	files := make([]string, 0, len(matches))
+
+	for _, match := range matches {
+		if _, err := time.Parse(peerEventLogRotatedLayout, strings.TrimPrefix(match, l.path+".")); err == nil {
+			files = append(files, match)
+		}
+	}
+
+	sort.Strings(files)
+
+	return files
+}
+
+// add adds an event to the ring buffer, must be called with the lock held
+func (l *PeerEventLog) add(event PeerEvent) {
+	if !l.full {
+		l.events = append(l.events, event)
+
+		if len(l.events) == cap(l.events) {
+			l.full = true
+		}
+
+		return
+	}
+
+	l.events[l.next] = event
+	l.next = (l.next + 1) % len(l.events)
+}
+
+// ordered returns the events in memory oldest first, must be called with the lock held
+func (l *PeerEventLog) ordered() []PeerEvent {
+	if !l.full {
+		return l.events
+	}
+
+	ordered := make([]PeerEvent, 0, len(l.events))
+	ordered = append(ordered, l.events[l.next:]...)
+	ordered = append(ordered, l.events[:l.next]...)
+
+	return ordered
+}
+
+// load reads the events of a previous run from the file, keeping the most recent ones in memory
+func (l *PeerEventLog) load(filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return errors.NewStorageError("failed to open peer event log file %s", filePath, err)
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		var event PeerEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			// skip lines that were only partially written when the previous run stopped
+			continue
+		}
+
+		l.add(event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return errors.NewStorageError("failed to read peer event log file %s", filePath, err)
+	}
+
+	return nil
+}
+
+// GetPeerEvents returns the peer lifecycle events matching the time range and peer ID of the request
+func (s *Server) GetPeerEvents(_ context.Context, req *p2p_api.GetPeerEventsRequest) (*p2p_api.GetPeerEventsResponse, error) {
+	if s.peerEvents == nil {
+		return nil, errors.WrapGRPC(errors.NewServiceError("peer event log not enabled"))
+	}
+
+	var from, to time.Time
+
+	if req.From > 0 {
+		from = time.UnixMilli(req.From)
+	}
+
+	if req.To > 0 {
+		to = time.UnixMilli(req.To)
+	}
+
+	events := s.peerEvents.Query(from, to, req.PeerId, int(req.Limit))
+
+	resp := &p2p_api.GetPeerEventsResponse{
+		Events: make([]*p2p_api.PeerEvent, 0, len(events)),
+	}
+
+	for _, event := range events {
+		resp.Events = append(resp.Events, &p2p_api.PeerEvent{
+			Timestamp: event.Timestamp.UnixMilli(),
+			PeerId:    event.PeerID,
+			Type:      string(event.Type),
+			Details:   event.Details,
+		})
+	}
+
+	return resp, nil
+}
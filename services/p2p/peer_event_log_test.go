@@ -0,0 +1,211 @@
+package p2p
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPeerEventLog creates an in-memory event log with a controllable clock
+func newTestPeerEventLog(t *testing.T, maxEvents int, filePath string) (*PeerEventLog, *time.Time) {
+	l, err := NewPeerEventLog(maxEvents, filePath, 0)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+
+	now := time.UnixMilli(1_700_000_000_000)
+	l.now = func() time.Time { return now }
+
+	return l, &now
+}
+
+func TestPeerEventLog_RingBuffer(t *testing.T) {
+	l, now := newTestPeerEventLog(t, 3, "")
+
+	for i, eventType := range []PeerEventType{PeerEventConnected, PeerEventCatchupAttempt, PeerEventCatchupSuccess, PeerEventDisconnected} {
+		*now = now.Add(time.Duration(i) * time.Second)
+		l.Record("peer1", eventType, "")
+	}
+
+	events := l.Query(time.Time{}, time.Time{}, "", 0)
+	require.Len(t, events, 3, "the oldest event is evicted")
+	assert.Equal(t, PeerEventCatchupAttempt, events[0].Type)
+	assert.Equal(t, PeerEventDisconnected, events[2].Type)
+}
+
+func TestPeerEventLog_Query(t *testing.T) {
+	l, now := newTestPeerEventLog(t, 100, "")
+	start := *now
+
+	l.Record("peer1", PeerEventConnected, "")
+	*now = start.Add(time.Minute)
+	l.Record("peer2", PeerEventConnected, "")
+	*now = start.Add(2 * time.Minute)
+	l.Record("peer1", PeerEventBanScore, "reason=spam score=10")
+	*now = start.Add(3 * time.Minute)
+	l.Record("peer1", PeerEventDisconnected, "")
+
+	t.Run("peer filter", func(t *testing.T) {
+		events := l.Query(time.Time{}, time.Time{}, "peer1", 0)
+		require.Len(t, events, 3)
+		assert.Equal(t, "reason=spam score=10", events[1].Details)
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		events := l.Query(start.Add(time.Minute), start.Add(2*time.Minute), "", 0)
+		require.Len(t, events, 2)
+		assert.Equal(t, "peer2", events[0].PeerID)
+		assert.Equal(t, PeerEventBanScore, events[1].Type)
+	})
+
+	t.Run("limit returns the most recent events", func(t *testing.T) {
+		events := l.Query(time.Time{}, time.Time{}, "", 2)
+		require.Len(t, events, 2)
+		assert.Equal(t, PeerEventBanScore, events[0].Type)
+		assert.Equal(t, PeerEventDisconnected, events[1].Type)
+	})
+}
+
+func TestPeerEventLog_FileBacked(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "events", "peer_events.log")
+
+	l, err := NewPeerEventLog(10, filePath, 0)
+	require.NoError(t, err)
+
+	l.Record("peer1", PeerEventConnected, "client")
+	l.Record("peer1", PeerEventBanned, "banned until tomorrow")
+	require.NoError(t, l.Close())
+
+	// a new run picks up the events of the previous run, keeping only the most recent ones
+	reloaded, err := NewPeerEventLog(1, filePath, 0)
+	require.NoError(t, err)
+
+	defer func() {
+		_ = reloaded.Close()
+	}()
+
+	events := reloaded.Query(time.Time{}, time.Time{}, "", 0)
+	require.Len(t, events, 1)
+	assert.Equal(t, PeerEventBanned, events[0].Type)
+	assert.Equal(t, "banned until tomorrow", events[0].Details)
+}
+
+func TestPeerEventLog_Rotation(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "peer_events.log")
+
+	// every event exceeds the maximum file size, so every event after the first rotates the file
+	l, err := NewPeerEventLog(10, filePath, 1)
+	require.NoError(t, err)
+
+	now := time.UnixMilli(1_700_000_000_000)
+	l.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	l.Record("peer1", PeerEventConnected, "")
+	l.Record("peer1", PeerEventCatchupAttempt, "")
+	l.Record("peer1", PeerEventCatchupSuccess, "")
+	require.NoError(t, l.Close())
+
+	files := l.Files()
+	require.Len(t, files, 3)
+	assert.Equal(t, filePath, files[2], "the current file is listed last")
+
+	// a new run loads the events of the rotated files as well, in order
+	reloaded, err := NewPeerEventLog(10, filePath, 1)
+	require.NoError(t, err)
+
+	defer func() {
+		_ = reloaded.Close()
+	}()
+
+	events := reloaded.Query(time.Time{}, time.Time{}, "", 0)
+	require.Len(t, events, 3)
+	assert.Equal(t, PeerEventConnected, events[0].Type)
+	assert.Equal(t, PeerEventCatchupSuccess, events[2].Type)
+}
+
+func TestPeerEventLog_Nil(t *testing.T) {
+	var l *PeerEventLog
+
+	l.Record("peer1", PeerEventConnected, "")
+	assert.Empty(t, l.Query(time.Time{}, time.Time{}, "", 0))
+	assert.Zero(t, l.Len())
+	assert.NoError(t, l.Close())
+}
+
+func TestNewPeerEventLog_InvalidSize(t *testing.T) {
+	_, err := NewPeerEventLog(0, "", 0)
+	require.Error(t, err)
+}
+
+func TestPeerRegistry_RecordsReputationChanges(t *testing.T) {
+	l, _ := newTestPeerEventLog(t, 100, "")
+
+	registry := NewPeerRegistry()
+	registry.SetEventLog(l)
+
+	peerID := peer.ID("test-peer-1")
+	registry.AddPeer(peerID, "")
+
+	registry.UpdateReputation(peerID, 50.5)
+	assert.Zero(t, l.Len(), "changes below the minimum delta are not recorded")
+
+	registry.RecordMaliciousInteraction(peerID)
+
+	events := l.Query(time.Time{}, time.Time{}, peerID.String(), 0)
+	require.Len(t, events, 1)
+	assert.Equal(t, PeerEventReputationChanged, events[0].Type)
+	assert.Equal(t, "50.50 -> 5.00", events[0].Details)
+}
+
+func TestServer_GetPeerEvents(t *testing.T) {
+	l, now := newTestPeerEventLog(t, 100, "")
+
+	s := &Server{
+		logger:       ulogger.New("test"),
+		peerRegistry: NewPeerRegistry(),
+		peerEvents:   l,
+	}
+
+	peerID, err := peer.Decode(peerIDStr)
+	require.NoError(t, err)
+
+	s.addConnectedPeer(peerID, "client")
+	s.addConnectedPeer(peerID, "client") // already connected, not recorded again
+
+	_, err = s.RecordCatchupAttempt(context.Background(), &p2p_api.RecordCatchupAttemptRequest{PeerId: peerID.String()})
+	require.NoError(t, err)
+
+	*now = now.Add(time.Minute)
+	s.removePeer(peerID)
+
+	resp, err := s.GetPeerEvents(context.Background(), &p2p_api.GetPeerEventsRequest{PeerId: peerID.String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 3)
+	assert.Equal(t, string(PeerEventConnected), resp.Events[0].Type)
+	assert.Equal(t, string(PeerEventCatchupAttempt), resp.Events[1].Type)
+	assert.Equal(t, string(PeerEventDisconnected), resp.Events[2].Type)
+
+	resp, err = s.GetPeerEvents(context.Background(), &p2p_api.GetPeerEventsRequest{From: now.UnixMilli()})
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 1)
+	assert.Equal(t, now.UnixMilli(), resp.Events[0].Timestamp)
+}
+
+func TestServer_GetPeerEvents_Disabled(t *testing.T) {
+	s := &Server{logger: ulogger.New("test")}
+
+	_, err := s.GetPeerEvents(context.Background(), &p2p_api.GetPeerEventsRequest{})
+	require.Error(t, err)
+}
@@ -0,0 +1,32 @@
+package p2p
+
+import (
+	"github.com/bsv-blockchain/teranode/util/geoip"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// locatePeers looks up the location of the live connection addresses of the peers, skipping circuit relay
+// addresses, which carry the address of the relay. Peers without a located address are not in the returned map.
+func locatePeers(locator *geoip.Locator, addrs map[peer.ID][]string) map[peer.ID]geoip.Location {
+	locations := make(map[peer.ID]geoip.Location, len(addrs))
+
+	for id, peerAddrs := range addrs {
+		for _, addr := range peerAddrs {
+			if addrTransport(addr) == transportRelay {
+				continue
+			}
+
+			ip, err := multiaddrIP(addr)
+			if err != nil {
+				continue
+			}
+
+			if location := locator.Locate(ip); !location.IsZero() {
+				locations[id] = location
+				break
+			}
+		}
+	}
+
+	return locations
+}
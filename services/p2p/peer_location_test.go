@@ -0,0 +1,96 @@
+package p2p
+
+import (
+	"testing"
+
+	p2pMessageBus "github.com/bsv-blockchain/go-p2p-message-bus"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/geoip"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testGeoLocator returns a locator of an IPv4 MaxMind DB locating 0.0.0.0/1 in NL and AS64500
+func testGeoLocator(t *testing.T) *geoip.Locator {
+	t.Helper()
+
+	db := []byte{
+		// search tree of one node: the left record points to the data at offset 0, the right record is empty
+		0x00, 0x00, 0x11, 0x00, 0x00, 0x01,
+	}
+	db = append(db, make([]byte, 16)...)
+
+	// {"country": {"iso_code": "NL"}, "autonomous_system_number": 64500}
+	db = append(db, 0xe2, 0x47)
+	db = append(db, "country"...)
+	db = append(db, 0xe1, 0x48)
+	db = append(db, "iso_code"...)
+	db = append(db, 0x42, 'N', 'L', 0x58)
+	db = append(db, "autonomous_system_number"...)
+	db = append(db, 0xc2, 0xfb, 0xf4)
+
+	// metadata {"node_count": 1, "record_size": 24, "ip_version": 4}
+	db = append(db, "\xAB\xCD\xEFMaxMind.com"...)
+	db = append(db, 0xe3, 0x4a)
+	db = append(db, "node_count"...)
+	db = append(db, 0xc1, 0x01, 0x4b)
+	db = append(db, "record_size"...)
+	db = append(db, 0xa1, 0x18, 0x4a)
+	db = append(db, "ip_version"...)
+	db = append(db, 0xa1, 0x04)
+
+	r, err := geoip.New(db)
+	require.NoError(t, err)
+
+	return geoip.NewLocatorFromReaders(r)
+}
+
+func TestLocatePeers(t *testing.T) {
+	located := peer.ID("located-peer")
+	relayed := peer.ID("relayed-peer")
+	unknown := peer.ID("unknown-peer")
+
+	locations := locatePeers(testGeoLocator(t), map[peer.ID][]string{
+		// the first address that is located is used
+		located: {"/dns4/peer.example.com/tcp/9905", "/ip4/198.51.100.1/tcp/9905", "/ip4/10.1.2.3/udp/9905/quic-v1"},
+		// the address of a relayed connection is the address of the relay
+		relayed: {"/ip4/10.1.2.3/tcp/9905/p2p/12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ/p2p-circuit"},
+		unknown: {"/ip4/198.51.100.2/tcp/9905"},
+	})
+
+	assert.Equal(t, map[peer.ID]geoip.Location{located: {Country: "NL", ASN: 64500}}, locations)
+}
+
+func TestReconcilePeerRegistry_Locations(t *testing.T) {
+	peerID, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	client := &reconcilerTestP2PClient{
+		peers: []p2pMessageBus.PeerInfo{{ID: peerID.String(), Addrs: []string{"/ip4/10.1.2.3/tcp/9905"}}},
+	}
+
+	s := &Server{
+		logger:       ulogger.TestLogger{},
+		settings:     CreateTestSettings(),
+		peerRegistry: NewPeerRegistry(),
+		P2PClient:    client,
+		geoLocator:   testGeoLocator(t),
+	}
+
+	initPrometheusMetrics()
+
+	s.peerRegistry.AddPeer(peerID, "")
+	s.reconcilePeerRegistry()
+
+	info, _ := s.peerRegistry.GetPeer(peerID)
+	assert.Equal(t, "NL", info.Country)
+	assert.Equal(t, uint32(64500), info.ASN)
+
+	// the last known location is kept after the peer disconnected
+	client.peers = nil
+	s.reconcilePeerRegistry()
+
+	info, _ = s.peerRegistry.GetPeer(peerID)
+	assert.Equal(t, "NL", info.Country)
+}
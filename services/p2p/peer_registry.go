@@ -1,29 +1,95 @@
 package p2p
 
 import (
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/bsv-blockchain/teranode/util/geoip"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+const (
+	churnBucketDuration = time.Minute // Granularity of the peer churn history
+	churnHistoryLength  = time.Hour   // How far back the peer churn history goes
+
+	maxDataHubMirrorURLs = 4 // Maximum number of DataHub mirrors kept per peer
+)
+
+// churnBucket counts peers joining and leaving the registry within one bucket period
+type churnBucket struct {
+	start  time.Time
+	joined int
+	left   int
+}
+
 // PeerRegistry maintains peer information
 // This is a pure data store with no business logic
 type PeerRegistry struct {
 	mu    sync.RWMutex
 	peers map[peer.ID]*PeerInfo
+
+	// version is incremented on every modification of the peers, snap is the snapshot of the peers readers share
+	// until the next modification and published is the version of the last snapshot built. owned holds the
+	// version an entry was created or copied at, entries owned after the published version are modified in place.
+	version   atomic.Uint64
+	published atomic.Uint64
+	snap      atomic.Pointer[registrySnapshot]
+	owned     map[peer.ID]uint64
+
+	// pendingProbation holds probation expiry for peers that are not currently in the registry
+	// (e.g. removed when they were banned), applied when the peer is added again
+	pendingProbation map[peer.ID]time.Time
+
+	// churn holds join/leave counts in time buckets, oldest first
+	churn []churnBucket
+
+	// events records reputation changes in the peer event log, nil when no event log is used
+	events *PeerEventLog
+
+	// cacheCompression is the gzip compression mode of the cache file, see SetCacheCompression
+	cacheCompression          string
+	cacheCompressionThreshold int
 }
 
 // NewPeerRegistry creates a new peer registry
 func NewPeerRegistry() *PeerRegistry {
+	initPrometheusMetrics()
+
 	return &PeerRegistry{
-		peers: make(map[peer.ID]*PeerInfo),
+		peers:            make(map[peer.ID]*PeerInfo),
+		owned:            make(map[peer.ID]uint64),
+		pendingProbation: make(map[peer.ID]time.Time),
 	}
 }
 
+// SetEventLog sets the event log reputation changes of peers are recorded in
+func (pr *PeerRegistry) SetEventLog(events *PeerEventLog) {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	pr.events = events
+}
+
+// SetCacheCompression sets the gzip compression of the cache file, one of the PeerCacheCompression modes.
+// In auto mode the cache is compressed when its JSON is larger than threshold bytes. The cache is not compressed
+// when no compression is set.
+func (pr *PeerRegistry) SetCacheCompression(mode string, threshold int) {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	pr.cacheCompression = mode
+	pr.cacheCompressionThreshold = threshold
+}
+
 // AddPeer adds or updates a peer
 func (pr *PeerRegistry) AddPeer(id peer.ID, clientName string) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
 	if _, exists := pr.peers[id]; !exists {
@@ -35,23 +101,44 @@ func (pr *PeerRegistry) AddPeer(id peer.ID, clientName string) {
 			LastMessageTime: now,  // Initialize to connection time
 			ReputationScore: 50.0, // Start with neutral reputation
 		}
+		pr.owned[id] = pr.version.Add(1)
+
+		pr.recordChurn(now, true)
+
+		// Re-admit previously banned peers on probation
+		if until, pending := pr.pendingProbation[id]; pending {
+			delete(pr.pendingProbation, id)
+
+			if now.Before(until) {
+				info := pr.peers[id] // created above, not shared with a snapshot
+				info.IsOnProbation = true
+				info.ProbationStartedAt = now
+				info.ProbationUntil = until
+			}
+		}
 	} else if clientName != "" {
 		// Update client name if provided for existing peer
-		pr.peers[id].ClientName = clientName
+		info, _ := pr.mutablePeer(id)
+		info.ClientName = clientName
 	}
 }
 
 // RemovePeer removes a peer
 func (pr *PeerRegistry) RemovePeer(id peer.ID) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	delete(pr.peers, id)
+	if _, exists := pr.peers[id]; exists {
+		delete(pr.peers, id)
+		delete(pr.owned, id)
+		pr.version.Add(1)
+		pr.recordChurn(time.Now(), false)
+	}
 }
 
 // GetPeer returns peer info
 func (pr *PeerRegistry) GetPeer(id peer.ID) (*PeerInfo, bool) {
-	pr.mu.RLock()
+	pr.rlock()
 	defer pr.mu.RUnlock()
 
 	info, exists := pr.peers[id]
@@ -66,23 +153,15 @@ func (pr *PeerRegistry) GetPeer(id peer.ID) (*PeerInfo, bool) {
 
 // GetAllPeers returns all peer information
 func (pr *PeerRegistry) GetAllPeers() []*PeerInfo {
-	pr.mu.RLock()
-	defer pr.mu.RUnlock()
-
-	result := make([]*PeerInfo, 0, len(pr.peers))
-	for _, info := range pr.peers {
-		copy := *info
-		result = append(result, &copy)
-	}
-	return result
+	return copyPeers(pr.snapshot(), nil)
 }
 
 // UpdateHeight updates a peer's height
 func (pr *PeerRegistry) UpdateHeight(id peer.ID, height int32, blockHash string) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.Height = height
 		info.BlockHash = blockHash
 	}
@@ -90,30 +169,123 @@ func (pr *PeerRegistry) UpdateHeight(id peer.ID, height int32, blockHash string)
 
 // UpdateBlockHash updates only the peer's block hash
 func (pr *PeerRegistry) UpdateBlockHash(id peer.ID, blockHash string) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.BlockHash = blockHash
 	}
 }
 
-// UpdateDataHubURL updates a peer's DataHub URL
+// UpdateDataHubURL updates a peer's DataHub URL. A changed URL is no longer verified, until the peer proves
+// again that it serves the URL.
 func (pr *PeerRegistry) UpdateDataHubURL(id peer.ID, url string) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
+		if info.DataHubURL != url {
+			info.IsDataHubURLVerified = false
+			info.DataHubURLVerifiedAt = time.Time{}
+		}
+
 		info.DataHubURL = url
 	}
 }
 
+// UpdateDataHubMirrorURLs replaces the DataHub mirrors of a peer, keeping the valid http and https URLs up to
+// maxDataHubMirrorURLs, so a peer can not make the node fail over to an unbounded list of URLs
+func (pr *PeerRegistry) UpdateDataHubMirrorURLs(id peer.ID, urls []string) {
+	mirrors := make([]string, 0, min(len(urls), maxDataHubMirrorURLs))
+
+	for _, mirror := range urls {
+		if len(mirrors) == maxDataHubMirrorURLs {
+			break
+		}
+
+		if u, err := url.Parse(mirror); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+			mirrors = append(mirrors, mirror)
+		}
+	}
+
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	// node status messages repeat the mirrors, an unchanged list leaves the published snapshot intact
+	if info, exists := pr.peers[id]; !exists || slices.Equal(info.DataHubMirrorURLs, mirrors) {
+		return
+	}
+
+	if info, exists := pr.mutablePeer(id); exists {
+		// replaced instead of updated in place, copies handed out by GetPeer share the slice
+		info.DataHubMirrorURLs = mirrors
+	}
+}
+
+// SetDataHubURLVerified records the outcome of the identity verification of a peer's DataHub URL. The outcome
+// is only recorded while the peer still advertises the verified URL. Returns whether the verified state of the
+// peer changed.
+func (pr *PeerRegistry) SetDataHubURLVerified(id peer.ID, url string, verified bool) bool {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	info, exists := pr.peers[id]
+	if !exists || info.DataHubURL != url {
+		return false
+	}
+
+	info = pr.ownPeer(id, info)
+
+	if verified {
+		info.DataHubURLVerifiedAt = time.Now()
+	}
+
+	if info.IsDataHubURLVerified == verified {
+		return false
+	}
+
+	info.IsDataHubURLVerified = verified
+
+	if pr.events != nil {
+		if verified {
+			pr.events.Record(id.String(), PeerEventDataHubVerified, url)
+		} else {
+			pr.events.Record(id.String(), PeerEventDataHubIdentityMismatch, url)
+		}
+	}
+
+	return true
+}
+
+// UpdateMinerID records the miner identification a peer announced at announcedAt. Announcements older than the
+// last one recorded for the peer are ignored, so a replayed announcement can not revert a change. Returns whether
+// the miner identification of the peer changed.
+func (pr *PeerRegistry) UpdateMinerID(id peer.ID, minerID, publicKey, contact string, announcedAt time.Time) bool {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	info, exists := pr.peers[id]
+	if !exists || !announcedAt.After(info.MinerIDAnnouncedAt) {
+		return false
+	}
+
+	changed := info.MinerID != minerID || info.MinerPublicKey != publicKey || info.MinerContact != contact
+
+	info = pr.ownPeer(id, info)
+	info.MinerID = minerID
+	info.MinerPublicKey = publicKey
+	info.MinerContact = contact
+	info.MinerIDAnnouncedAt = announcedAt
+
+	return changed
+}
+
 // UpdateBanStatus updates a peer's ban status
 func (pr *PeerRegistry) UpdateBanStatus(id peer.ID, score int, banned bool) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.BanScore = score
 		info.IsBanned = banned
 	}
@@ -121,49 +293,147 @@ func (pr *PeerRegistry) UpdateBanStatus(id peer.ID, score int, banned bool) {
 
 // UpdateNetworkStats updates network statistics for a peer
 func (pr *PeerRegistry) UpdateNetworkStats(id peer.ID, bytesReceived uint64) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.BytesReceived = bytesReceived
 		info.LastBlockTime = time.Now()
 	}
 }
 
+// UpdateUploadStats updates the number of bytes sent to a peer
+func (pr *PeerRegistry) UpdateUploadStats(id peer.ID, bytesSent uint64) {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	if info, exists := pr.mutablePeer(id); exists {
+		info.BytesSent = bytesSent
+	}
+}
+
+// UpdateThrottleStatus updates whether a peer is throttled for exceeding its bandwidth quota
+func (pr *PeerRegistry) UpdateThrottleStatus(id peer.ID, throttled bool) {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	if info, exists := pr.mutablePeer(id); exists {
+		info.IsThrottled = throttled
+	}
+}
+
+// GetThrottledPeers returns the IDs of all peers currently throttled
+func (pr *PeerRegistry) GetThrottledPeers() []peer.ID {
+	result := make([]peer.ID, 0)
+	for _, info := range pr.snapshot() {
+		if info.IsThrottled {
+			result = append(result, info.ID)
+		}
+	}
+
+	return result
+}
+
 // UpdateURLResponsiveness updates whether a peer's DataHub URL is responsive
 func (pr *PeerRegistry) UpdateURLResponsiveness(id peer.ID, responsive bool) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.URLResponsive = responsive
 		info.LastURLCheck = time.Now()
 	}
 }
 
+// UpdateDataHubHealth records the outcome of a health probe of a peer's DataHub URL. After failureThreshold
+// consecutive failed probes the DataHub is considered down and the peer is excluded from catchup, until a
+// probe succeeds again. Returns whether this probe marked the DataHub as down.
+func (pr *PeerRegistry) UpdateDataHubHealth(id peer.ID, healthy bool, latency time.Duration, failureThreshold int) bool {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	info, exists := pr.mutablePeer(id)
+	if !exists {
+		return false
+	}
+
+	info.URLResponsive = healthy
+	info.LastURLCheck = time.Now()
+	info.IsHealthy = healthy
+	info.HealthDuration = latency
+
+	if healthy {
+		info.HealthCheckFailures = 0
+
+		if info.IsDataHubDown {
+			info.IsDataHubDown = false
+
+			if pr.events != nil {
+				pr.events.Record(id.String(), PeerEventDataHubRestored, info.DataHubURL)
+			}
+		}
+
+		return false
+	}
+
+	info.HealthCheckFailures++
+
+	if info.IsDataHubDown || info.HealthCheckFailures < failureThreshold {
+		return false
+	}
+
+	info.IsDataHubDown = true
+
+	if pr.events != nil {
+		pr.events.Record(id.String(), PeerEventDataHubDown, fmt.Sprintf("%s failed %d consecutive probes", info.DataHubURL, info.HealthCheckFailures))
+	}
+
+	return true
+}
+
 // UpdateLastMessageTime updates the last time we received a message from a peer
 func (pr *PeerRegistry) UpdateLastMessageTime(id peer.ID) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.LastMessageTime = time.Now()
 	}
 }
 
 // UpdateStorage updates a peer's node mode (full/pruned)
 func (pr *PeerRegistry) UpdateStorage(id peer.ID, mode string) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.Storage = mode
 	}
 }
 
+// UpdateProtocolVersion records the wire protocol version negotiated with a peer, or that the peer has no version
+// in common with this node. Returns whether the compatibility of the peer changed.
+func (pr *PeerRegistry) UpdateProtocolVersion(id peer.ID, version uint32, compatible bool) bool {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	info, exists := pr.peers[id]
+	if !exists || (info.ProtocolVersion == version && info.IsProtocolIncompatible == !compatible) {
+		return false
+	}
+
+	changed := info.IsProtocolIncompatible == compatible
+
+	info = pr.ownPeer(id, info)
+	info.ProtocolVersion = version
+	info.IsProtocolIncompatible = !compatible
+
+	return changed
+}
+
 // PeerCount returns the number of peers
 func (pr *PeerRegistry) PeerCount() int {
-	pr.mu.RLock()
+	pr.rlock()
 	defer pr.mu.RUnlock()
 
 	return len(pr.peers)
@@ -171,35 +441,125 @@ func (pr *PeerRegistry) PeerCount() int {
 
 // UpdateConnectionState updates whether a peer is directly connected
 func (pr *PeerRegistry) UpdateConnectionState(id peer.ID, connected bool) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.IsConnected = connected
 	}
 }
 
 // GetConnectedPeers returns only directly connected peers
 func (pr *PeerRegistry) GetConnectedPeers() []*PeerInfo {
-	pr.mu.RLock()
-	defer pr.mu.RUnlock()
+	return copyPeers(pr.snapshot(), func(info *PeerInfo) bool {
+		return info.IsConnected
+	})
+}
+
+// ReconcileConnections sets the connection state of all peers in the registry to the peers the host has live
+// connections to. Peers in the set that are not in the registry are ignored, they are added when they send a
+// message. A peer that is marked connected again gets a new ConnectedAt.
+// Returns the peers marked connected and the peers marked disconnected
+func (pr *PeerRegistry) ReconcileConnections(connected map[peer.ID]struct{}) (markedConnected []peer.ID, markedDisconnected []peer.ID) {
+	pr.lock()
+	defer pr.mu.Unlock()
 
-	result := make([]*PeerInfo, 0, len(pr.peers))
-	for _, info := range pr.peers {
-		if info.IsConnected {
-			copy := *info
-			result = append(result, &copy)
+	now := time.Now()
+
+	for id, info := range pr.peers {
+		_, isConnected := connected[id]
+
+		switch {
+		case isConnected && !info.IsConnected:
+			info = pr.ownPeer(id, info)
+			info.IsConnected = true
+			info.ConnectedAt = now
+			markedConnected = append(markedConnected, id)
+		case !isConnected && info.IsConnected:
+			info = pr.ownPeer(id, info)
+			info.IsConnected = false
+			markedDisconnected = append(markedDisconnected, id)
 		}
 	}
-	return result
+
+	return markedConnected, markedDisconnected
+}
+
+// UpdateRelayOnly marks the peers in the set as only reachable through a circuit relay, all other peers in the
+// registry as directly reachable. Peers in the set that are not in the registry are ignored.
+// Returns the number of peers in the registry marked relay only
+func (pr *PeerRegistry) UpdateRelayOnly(relayOnly map[peer.ID]struct{}) int {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	count := 0
+
+	for id, info := range pr.peers {
+		_, isRelayOnly := relayOnly[id]
+
+		if info.IsRelayOnly != isRelayOnly {
+			info = pr.ownPeer(id, info)
+			info.IsRelayOnly = isRelayOnly
+		}
+
+		if isRelayOnly {
+			count++
+		}
+	}
+
+	return count
+}
+
+// UpdateTransports sets the transport of the live connections of the peers in the map, all other peers in the
+// registry get no transport. Peers in the map that are not in the registry are ignored.
+// Returns the number of peers in the registry by transport
+func (pr *PeerRegistry) UpdateTransports(transports map[peer.ID]string) map[string]int {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	counts := make(map[string]int)
+
+	for id, info := range pr.peers {
+		transport := transports[id]
+
+		if info.Transport != transport {
+			info = pr.ownPeer(id, info)
+			info.Transport = transport
+		}
+
+		if transport != "" {
+			counts[transport]++
+		}
+	}
+
+	return counts
+}
+
+// UpdateLocations sets the country and ASN of the peers in the map. Peers that are not in the map keep their last
+// known location, peers in the map that are not in the registry are ignored.
+func (pr *PeerRegistry) UpdateLocations(locations map[peer.ID]geoip.Location) {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	for id, location := range locations {
+		info, exists := pr.peers[id]
+		if !exists || (info.Country == location.Country && info.ASN == location.ASN && info.ASOrganization == location.ASOrganization) {
+			continue
+		}
+
+		info = pr.ownPeer(id, info)
+		info.Country = location.Country
+		info.ASN = location.ASN
+		info.ASOrganization = location.ASOrganization
+	}
 }
 
 // RecordInteractionAttempt records that an interaction attempt was made to a peer
 func (pr *PeerRegistry) RecordInteractionAttempt(id peer.ID) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.InteractionAttempts++
 		info.LastInteractionAttempt = time.Now()
 	}
@@ -215,10 +575,10 @@ func (pr *PeerRegistry) RecordCatchupAttempt(id peer.ID) {
 // Updates success count and calculates running average response time
 // Automatically recalculates reputation score based on success/failure ratio
 func (pr *PeerRegistry) RecordInteractionSuccess(id peer.ID, duration time.Duration) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.InteractionSuccesses++
 		info.LastInteractionSuccess = time.Now()
 
@@ -242,9 +602,9 @@ func (pr *PeerRegistry) RecordInteractionSuccess(id peer.ID, duration time.Durat
 func (pr *PeerRegistry) RecordCatchupSuccess(id peer.ID, duration time.Duration) {
 	pr.RecordInteractionSuccess(id, duration)
 	// Also increment CatchupBlocks for backward compatibility
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.CatchupBlocks++
 	}
 }
@@ -252,10 +612,10 @@ func (pr *PeerRegistry) RecordCatchupSuccess(id peer.ID, duration time.Duration)
 // RecordInteractionFailure records a failed interaction attempt from a peer
 // Automatically recalculates reputation score based on success/failure ratio
 func (pr *PeerRegistry) RecordInteractionFailure(id peer.ID) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.InteractionFailures++
 		info.LastInteractionFailure = time.Now()
 
@@ -284,10 +644,10 @@ func (pr *PeerRegistry) RecordCatchupFailure(id peer.ID) {
 
 // UpdateCatchupError stores the last catchup error for a peer
 func (pr *PeerRegistry) UpdateCatchupError(id peer.ID, errorMsg string) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.LastCatchupError = errorMsg
 		info.LastCatchupErrorTime = time.Now()
 	}
@@ -296,10 +656,12 @@ func (pr *PeerRegistry) UpdateCatchupError(id peer.ID, errorMsg string) {
 // RecordMaliciousInteraction records malicious behavior detected during any interaction
 // Significantly reduces reputation score for malicious activity
 func (pr *PeerRegistry) RecordMaliciousInteraction(id peer.ID) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
+		defer pr.recordReputationChange(info, info.ReputationScore)
+
 		info.MaliciousCount++
 		info.InteractionFailures++ // Also count as a failed interaction
 		info.LastInteractionFailure = time.Now()
@@ -313,6 +675,25 @@ func (pr *PeerRegistry) RecordMaliciousInteraction(id peer.ID) {
 	}
 }
 
+// RecordDoubleSpend records a double spending transaction received from the peer. A double spend counts as a failed
+// interaction, lowering the reputation of the peer, but is not treated as malicious, since the peer may only have
+// relayed the transaction or seen the other spend later.
+func (pr *PeerRegistry) RecordDoubleSpend(id peer.ID) {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	if info, exists := pr.mutablePeer(id); exists {
+		now := time.Now()
+
+		info.DoubleSpendCount++
+		info.LastDoubleSpend = now
+		info.InteractionFailures++
+		info.LastInteractionFailure = now
+
+		pr.calculateAndUpdateReputation(info)
+	}
+}
+
 // RecordCatchupMalicious is deprecated - use RecordMaliciousInteraction instead
 // Maintained for backward compatibility
 func (pr *PeerRegistry) RecordCatchupMalicious(id peer.ID) {
@@ -322,17 +703,21 @@ func (pr *PeerRegistry) RecordCatchupMalicious(id peer.ID) {
 // UpdateReputation updates the reputation score for a peer
 // Score should be between 0 and 100
 func (pr *PeerRegistry) UpdateReputation(id peer.ID, score float64) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		// Clamp score to valid range
 		if score < 0 {
 			score = 0
 		} else if score > 100 {
 			score = 100
 		}
+
+		previous := info.ReputationScore
 		info.ReputationScore = score
+
+		pr.recordReputationChange(info, previous)
 	}
 }
 
@@ -361,6 +746,8 @@ func (pr *PeerRegistry) calculateAndUpdateReputation(info *PeerInfo) {
 		recencyWindow    = 1 * time.Hour
 	)
 
+	defer pr.recordReputationChange(info, info.ReputationScore)
+
 	// If peer has been marked malicious, keep reputation very low
 	if info.MaliciousCount > 0 {
 		// Malicious peers get minimal reputation
@@ -407,12 +794,22 @@ func (pr *PeerRegistry) calculateAndUpdateReputation(info *PeerInfo) {
 	info.ReputationScore = score
 }
 
+// recordReputationChange records a change of the reputation score of the peer in the event log
+// This method should be called with the lock already held
+func (pr *PeerRegistry) recordReputationChange(info *PeerInfo, previous float64) {
+	if pr.events == nil || math.Abs(info.ReputationScore-previous) < peerEventReputationMinDelta {
+		return
+	}
+
+	pr.events.Record(info.ID.String(), PeerEventReputationChanged, fmt.Sprintf("%.2f -> %.2f", previous, info.ReputationScore))
+}
+
 // RecordBlockReceived records when a block is successfully received from a peer
 func (pr *PeerRegistry) RecordBlockReceived(id peer.ID, duration time.Duration) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.BlocksReceived++
 		// Also record as a successful interaction
 		info.InteractionSuccesses++
@@ -433,10 +830,10 @@ func (pr *PeerRegistry) RecordBlockReceived(id peer.ID, duration time.Duration)
 
 // RecordSubtreeReceived records when a subtree is successfully received from a peer
 func (pr *PeerRegistry) RecordSubtreeReceived(id peer.ID, duration time.Duration) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.SubtreesReceived++
 		// Also record as a successful interaction
 		info.InteractionSuccesses++
@@ -455,12 +852,52 @@ func (pr *PeerRegistry) RecordSubtreeReceived(id peer.ID, duration time.Duration
 	}
 }
 
+// RecordInvalidBlock records an invalid block received from a peer, the negative counterpart of RecordBlockReceived
+func (pr *PeerRegistry) RecordInvalidBlock(id peer.ID, reason InvalidDataReason) {
+	pr.recordInvalidData(id, reason, func(info *PeerInfo) { info.InvalidBlocksReceived++ })
+}
+
+// RecordInvalidSubtree records an invalid subtree received from a peer, the negative counterpart of
+// RecordSubtreeReceived
+func (pr *PeerRegistry) RecordInvalidSubtree(id peer.ID, reason InvalidDataReason) {
+	pr.recordInvalidData(id, reason, func(info *PeerInfo) { info.InvalidSubtreesReceived++ })
+}
+
+// recordInvalidData records invalid data received from a peer as a failed interaction. Invalid or malformed data is
+// treated like a malicious interaction, dropping the reputation to a very low score, data the peer could not provide
+// only lowers the reputation by the recalculation for the failure.
+func (pr *PeerRegistry) recordInvalidData(id peer.ID, reason InvalidDataReason, count func(info *PeerInfo)) {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	if info, exists := pr.mutablePeer(id); exists {
+		defer pr.recordReputationChange(info, info.ReputationScore)
+
+		now := time.Now()
+
+		count(info)
+		info.LastInvalidData = now
+		info.LastInvalidDataReason = reason
+		info.InteractionFailures++
+		info.LastInteractionFailure = now
+
+		if reason.IsMalicious() {
+			info.MaliciousCount++
+			info.ReputationScore = 5.0 // Same as RecordMaliciousInteraction
+
+			return
+		}
+
+		pr.calculateAndUpdateReputation(info)
+	}
+}
+
 // RecordTransactionReceived records when a transaction is successfully received from a peer
 func (pr *PeerRegistry) RecordTransactionReceived(id peer.ID) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.TransactionsReceived++
 		// For transactions, we don't track response time as they're broadcast
 		// but we still count them as successful interactions
@@ -474,43 +911,36 @@ func (pr *PeerRegistry) RecordTransactionReceived(id peer.ID) {
 // GetPeersByReputation returns peers sorted by reputation score
 // Filters for peers that are not banned
 func (pr *PeerRegistry) GetPeersByReputation() []*PeerInfo {
-	pr.mu.RLock()
-	defer pr.mu.RUnlock()
-
-	result := make([]*PeerInfo, 0, len(pr.peers))
-	for _, info := range pr.peers {
-		// Only include peers that are not banned
-		if !info.IsBanned {
-			copy := *info
-			result = append(result, &copy)
-		}
-	}
+	// Only include peers that are not banned
+	result := copyPeers(pr.snapshot(), func(info *PeerInfo) bool {
+		return !info.IsBanned
+	})
 
 	// Sort by reputation score (highest first)
 	// Secondary sort by last success time (most recent first)
-	for i := 0; i < len(result); i++ {
-		for j := i + 1; j < len(result); j++ {
-			// Compare reputation scores
-			if result[i].ReputationScore < result[j].ReputationScore {
-				result[i], result[j] = result[j], result[i]
-			} else if result[i].ReputationScore == result[j].ReputationScore {
-				// If same reputation, prefer more recently successful peer
-				if result[i].LastInteractionSuccess.Before(result[j].LastInteractionSuccess) {
-					result[i], result[j] = result[j], result[i]
-				}
-			}
-		}
-	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return betterReputation(result[i], result[j])
+	})
 
 	return result
 }
 
+// betterReputation returns whether peer a ranks before peer b by reputation, preferring the more recently
+// successful peer on equal reputation
+func betterReputation(a, b *PeerInfo) bool {
+	if a.ReputationScore != b.ReputationScore {
+		return a.ReputationScore > b.ReputationScore
+	}
+
+	return a.LastInteractionSuccess.After(b.LastInteractionSuccess)
+}
+
 // RecordSyncAttempt records that we attempted to sync with a peer
 func (pr *PeerRegistry) RecordSyncAttempt(id peer.ID) {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
-	if info, exists := pr.peers[id]; exists {
+	if info, exists := pr.mutablePeer(id); exists {
 		info.LastSyncAttempt = time.Now()
 		info.SyncAttemptCount++
 	}
@@ -519,12 +949,12 @@ func (pr *PeerRegistry) RecordSyncAttempt(id peer.ID) {
 // ReconsiderBadPeers resets reputation for peers that have been bad for a while
 // Returns the number of peers that had their reputation recovered
 func (pr *PeerRegistry) ReconsiderBadPeers(cooldownPeriod time.Duration) int {
-	pr.mu.Lock()
+	pr.lock()
 	defer pr.mu.Unlock()
 
 	peersRecovered := 0
 
-	for _, info := range pr.peers {
+	for id, info := range pr.peers {
 		// Only consider peers with very low reputation
 		if info.ReputationScore >= 20 {
 			continue
@@ -550,6 +980,7 @@ func (pr *PeerRegistry) ReconsiderBadPeers(cooldownPeriod time.Duration) int {
 		}
 
 		// Reset reputation to a low but eligible value
+		info = pr.ownPeer(id, info)
 		oldReputation := info.ReputationScore
 		info.ReputationScore = 30 // Below neutral (50) but above threshold (20)
 		info.MaliciousCount = 0   // Clear malicious count for fresh start
@@ -570,46 +1001,173 @@ func (pr *PeerRegistry) ReconsiderBadPeers(cooldownPeriod time.Duration) int {
 // Filters for peers with DataHub URLs, sorted by reputation
 // This is a specialized version of GetPeersByReputation for catchup operations
 func (pr *PeerRegistry) GetPeersForCatchup() []*PeerInfo {
-	pr.mu.RLock()
+	// Only include peers with reachable DataHub URLs that are not banned, on probation, throttled or protocol incompatible
+	result := copyPeers(pr.snapshot(), func(info *PeerInfo) bool {
+		return info.DataHubURL != "" && !info.IsDataHubDown && !info.IsBanned && !info.IsOnProbation && !info.IsThrottled &&
+			!info.IsProtocolIncompatible
+	})
+
+	// Sort by storage mode preference: full > pruned > unknown
+	// Secondary sort by reputation score (highest first)
+	// Tertiary sort by last success time (most recent first)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Storage != result[j].Storage {
+			return storagePreference[result[i].Storage] > storagePreference[result[j].Storage]
+		}
+
+		return betterReputation(result[i], result[j])
+	})
+
+	return result
+}
+
+// storagePreference orders the storage modes of peers for catchup
+var storagePreference = map[string]int{
+	"full":   3,
+	"pruned": 2,
+	"":       1, // Unknown/old version
+}
+
+// StartProbation puts a peer on probation for the given duration
+// If the peer is not in the registry, probation is applied when it is added again
+func (pr *PeerRegistry) StartProbation(id peer.ID, duration time.Duration) {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	now := time.Now()
+	until := now.Add(duration)
+
+	info, exists := pr.mutablePeer(id)
+	if !exists {
+		pr.pendingProbation[id] = until
+		return
+	}
+
+	info.IsOnProbation = true
+	info.ProbationStartedAt = now
+	info.ProbationUntil = until
+}
+
+// EndProbation clears a peer's probation state
+func (pr *PeerRegistry) EndProbation(id peer.ID) {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	delete(pr.pendingProbation, id)
+
+	if info, exists := pr.mutablePeer(id); exists {
+		info.IsOnProbation = false
+		info.ProbationStartedAt = time.Time{}
+		info.ProbationUntil = time.Time{}
+	}
+}
+
+// IsOnProbation returns whether a peer is currently on probation
+func (pr *PeerRegistry) IsOnProbation(id peer.ID) bool {
+	pr.rlock()
 	defer pr.mu.RUnlock()
 
-	result := make([]*PeerInfo, 0, len(pr.peers))
-	for _, info := range pr.peers {
-		// Only include peers with DataHub URLs that are not banned
-		if info.DataHubURL != "" && !info.IsBanned {
-			copy := *info
-			result = append(result, &copy)
+	if info, exists := pr.peers[id]; exists {
+		return info.IsOnProbation
+	}
+
+	until, pending := pr.pendingProbation[id]
+
+	return pending && time.Now().Before(until)
+}
+
+// PromoteProbationPeers promotes peers whose probation period has elapsed
+// Peers that failed an interaction during probation have their probation restarted for the given duration
+// Returns the IDs of the peers that were promoted
+func (pr *PeerRegistry) PromoteProbationPeers(extension time.Duration) []peer.ID {
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	now := time.Now()
+
+	var promoted []peer.ID
+
+	for id, info := range pr.peers {
+		if !info.IsOnProbation || now.Before(info.ProbationUntil) {
+			continue
+		}
+
+		info = pr.ownPeer(id, info)
+
+		// Misbehaved during probation, keep it restricted for another period
+		if info.LastInteractionFailure.After(info.ProbationStartedAt) {
+			info.ProbationStartedAt = now
+			info.ProbationUntil = now.Add(extension)
+
+			continue
 		}
+
+		info.IsOnProbation = false
+		info.ProbationStartedAt = time.Time{}
+		info.ProbationUntil = time.Time{}
+
+		promoted = append(promoted, id)
 	}
 
-	// Sort by storage mode preference: full > pruned > unknown
-	// Secondary sort by reputation score (highest first)
-	// Tertiary sort by last success time (most recent first)
-	for i := 0; i < len(result); i++ {
-		for j := i + 1; j < len(result); j++ {
-			if result[i].Storage != result[j].Storage {
-				// Define storage preference order
-				storagePreference := map[string]int{
-					"full":   3,
-					"pruned": 2,
-					"":       1, // Unknown/old version
-				}
-				if storagePreference[result[i].Storage] < storagePreference[result[j].Storage] {
-					result[i], result[j] = result[j], result[i]
-				}
-				continue
-			}
-			// Compare reputation scores
-			if result[i].ReputationScore < result[j].ReputationScore {
-				result[i], result[j] = result[j], result[i]
-			} else if result[i].ReputationScore == result[j].ReputationScore {
-				// If same reputation, prefer more recently successful peer
-				if result[i].LastInteractionSuccess.Before(result[j].LastInteractionSuccess) {
-					result[i], result[j] = result[j], result[i]
-				}
-			}
+	// Drop pending probations for peers that never came back
+	for id, until := range pr.pendingProbation {
+		if now.After(until) {
+			delete(pr.pendingProbation, id)
 		}
 	}
 
-	return result
+	return promoted
+}
+
+// recordChurn records a peer joining or leaving the registry
+// Must be called with the lock held
+func (pr *PeerRegistry) recordChurn(now time.Time, joined bool) {
+	start := now.Truncate(churnBucketDuration)
+
+	if len(pr.churn) == 0 || pr.churn[len(pr.churn)-1].start.Before(start) {
+		pr.churn = append(pr.churn, churnBucket{start: start})
+	}
+
+	bucket := &pr.churn[len(pr.churn)-1]
+	if joined {
+		bucket.joined++
+	} else {
+		bucket.left++
+	}
+
+	// Drop buckets that fell out of the history window
+	cutoff := now.Add(-churnHistoryLength)
+
+	drop := 0
+	for drop < len(pr.churn) && pr.churn[drop].start.Add(churnBucketDuration).Before(cutoff) {
+		drop++
+	}
+
+	if drop > 0 {
+		pr.churn = append(pr.churn[:0], pr.churn[drop:]...)
+	}
+}
+
+// GetChurn returns the number of peers that joined and left the registry within the given window
+// The window is capped at the churn history length and rounded to whole buckets
+func (pr *PeerRegistry) GetChurn(window time.Duration) (joined int, left int) {
+	pr.rlock()
+	defer pr.mu.RUnlock()
+
+	if window > churnHistoryLength {
+		window = churnHistoryLength
+	}
+
+	cutoff := time.Now().Add(-window).Truncate(churnBucketDuration)
+
+	for _, bucket := range pr.churn {
+		if bucket.start.Before(cutoff) {
+			continue
+		}
+
+		joined += bucket.joined
+		left += bucket.left
+	}
+
+	return joined, left
 }
@@ -1,6 +1,9 @@
 package p2p
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,12 +12,28 @@ import (
 	"time"
 
 	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/faultinject"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 // PeerRegistryCacheVersion is the current version of the cache format
 const PeerRegistryCacheVersion = "1.0"
 
+// Gzip compression modes of the peer registry cache file
+const (
+	PeerCacheCompressionAuto   = "auto"   // Compressed when the JSON is larger than the compression threshold
+	PeerCacheCompressionAlways = "always" // Always compressed
+	PeerCacheCompressionNever  = "never"  // Never compressed
+)
+
+const (
+	// compressedPeerCacheSuffix is appended to the name of a gzip compressed cache file
+	compressedPeerCacheSuffix = ".gz"
+
+	// maxPeerCacheFileSize is the largest cache JSON that is loaded, guarding against corrupt or hostile files
+	maxPeerCacheFileSize = 256 * 1024 * 1024
+)
+
 // PeerRegistryCache represents the persistent cache structure for peer registry data
 type PeerRegistryCache struct {
 	Version     string                        `json:"version"`
@@ -34,6 +53,8 @@ type CachedPeerMetrics struct {
 	ReputationScore        float64   `json:"reputation_score"`
 	MaliciousCount         int64     `json:"malicious_count"`
 	AvgResponseMS          int64     `json:"avg_response_ms"` // Duration in milliseconds
+	DoubleSpendCount       int64     `json:"double_spend_count,omitempty"`
+	LastDoubleSpend        time.Time `json:"last_double_spend,omitempty"`
 
 	// Interaction type breakdown
 	BlocksReceived       int64 `json:"blocks_received,omitempty"`
@@ -41,12 +62,22 @@ type CachedPeerMetrics struct {
 	TransactionsReceived int64 `json:"transactions_received,omitempty"`
 	CatchupBlocks        int64 `json:"catchup_blocks,omitempty"`
 
+	// Invalid data reported by the validation services
+	InvalidBlocksReceived   int64             `json:"invalid_blocks_received,omitempty"`
+	InvalidSubtreesReceived int64             `json:"invalid_subtrees_received,omitempty"`
+	LastInvalidData         time.Time         `json:"last_invalid_data,omitempty"`
+	LastInvalidDataReason   InvalidDataReason `json:"last_invalid_data_reason,omitempty"`
+
 	// Additional peer info worth persisting
-	Height     int32  `json:"height,omitempty"`
-	BlockHash  string `json:"block_hash,omitempty"`
-	DataHubURL string `json:"data_hub_url,omitempty"`
-	ClientName string `json:"client_name,omitempty"`
-	Storage    string `json:"storage,omitempty"`
+	Height            int32    `json:"height,omitempty"`
+	BlockHash         string   `json:"block_hash,omitempty"`
+	DataHubURL        string   `json:"data_hub_url,omitempty"`
+	DataHubMirrorURLs []string `json:"data_hub_mirror_urls,omitempty"`
+	ClientName        string   `json:"client_name,omitempty"`
+	Storage           string   `json:"storage,omitempty"`
+
+	// Last time a message of the peer was received, used to collect stale peers after a restart
+	LastSeen time.Time `json:"last_seen,omitempty"`
 
 	// Legacy fields for backward compatibility (can read old cache files)
 	CatchupAttempts        int64     `json:"catchup_attempts,omitempty"`
@@ -87,27 +118,36 @@ func (pr *PeerRegistry) SavePeerRegistryCache(cacheDir string) error {
 	for id, info := range pr.peers {
 		// Only cache peers with meaningful metrics
 		if info.InteractionAttempts > 0 || info.DataHubURL != "" || info.Height > 0 ||
-			info.BlocksReceived > 0 || info.SubtreesReceived > 0 || info.TransactionsReceived > 0 {
+			info.BlocksReceived > 0 || info.SubtreesReceived > 0 || info.TransactionsReceived > 0 ||
+			info.InvalidBlocksReceived > 0 || info.InvalidSubtreesReceived > 0 {
 			// Store peer ID as string
 			cache.Peers[id.String()] = &CachedPeerMetrics{
-				InteractionAttempts:    info.InteractionAttempts,
-				InteractionSuccesses:   info.InteractionSuccesses,
-				InteractionFailures:    info.InteractionFailures,
-				LastInteractionAttempt: info.LastInteractionAttempt,
-				LastInteractionSuccess: info.LastInteractionSuccess,
-				LastInteractionFailure: info.LastInteractionFailure,
-				ReputationScore:        info.ReputationScore,
-				MaliciousCount:         info.MaliciousCount,
-				AvgResponseMS:          info.AvgResponseTime.Milliseconds(),
-				BlocksReceived:         info.BlocksReceived,
-				SubtreesReceived:       info.SubtreesReceived,
-				TransactionsReceived:   info.TransactionsReceived,
-				CatchupBlocks:          info.CatchupBlocks,
-				Height:                 info.Height,
-				BlockHash:              info.BlockHash,
-				DataHubURL:             info.DataHubURL,
-				ClientName:             info.ClientName,
-				Storage:                info.Storage,
+				InteractionAttempts:     info.InteractionAttempts,
+				InteractionSuccesses:    info.InteractionSuccesses,
+				InteractionFailures:     info.InteractionFailures,
+				LastInteractionAttempt:  info.LastInteractionAttempt,
+				LastInteractionSuccess:  info.LastInteractionSuccess,
+				LastInteractionFailure:  info.LastInteractionFailure,
+				ReputationScore:         info.ReputationScore,
+				MaliciousCount:          info.MaliciousCount,
+				AvgResponseMS:           info.AvgResponseTime.Milliseconds(),
+				DoubleSpendCount:        info.DoubleSpendCount,
+				LastDoubleSpend:         info.LastDoubleSpend,
+				BlocksReceived:          info.BlocksReceived,
+				SubtreesReceived:        info.SubtreesReceived,
+				TransactionsReceived:    info.TransactionsReceived,
+				CatchupBlocks:           info.CatchupBlocks,
+				InvalidBlocksReceived:   info.InvalidBlocksReceived,
+				InvalidSubtreesReceived: info.InvalidSubtreesReceived,
+				LastInvalidData:         info.LastInvalidData,
+				LastInvalidDataReason:   info.LastInvalidDataReason,
+				Height:                  info.Height,
+				BlockHash:               info.BlockHash,
+				DataHubURL:              info.DataHubURL,
+				DataHubMirrorURLs:       info.DataHubMirrorURLs,
+				ClientName:              info.ClientName,
+				Storage:                 info.Storage,
+				LastSeen:                info.LastMessageTime,
 			}
 		}
 	}
@@ -118,13 +158,26 @@ func (pr *PeerRegistry) SavePeerRegistryCache(cacheDir string) error {
 		return errors.NewProcessingError("failed to marshal peer registry cache: %v", err)
 	}
 
-	// Write to temporary file first, then rename for atomicity
+	// The cache is written in one format, the file of the other format is removed once it is written
 	cacheFile := getPeerRegistryCacheFilePath(cacheDir)
+	staleFile := cacheFile + compressedPeerCacheSuffix
+
+	if compressPeerCache(pr.cacheCompression, pr.cacheCompressionThreshold, len(data)) {
+		if data, err = gzipPeerCache(data); err != nil {
+			return errors.NewProcessingError("failed to compress peer registry cache", err)
+		}
+
+		cacheFile, staleFile = staleFile, cacheFile
+	}
+
+	// Write to temporary file first, then rename for atomicity
 	// Use unique temp file name to avoid concurrent write conflicts
 	tempFile := fmt.Sprintf("%s.tmp.%d", cacheFile, time.Now().UnixNano())
 
-	if err := os.WriteFile(tempFile, data, 0600); err != nil {
-		return errors.NewProcessingError("failed to write peer registry cache: %v", err)
+	if err := writePeerCacheFile(tempFile, data); err != nil {
+		// Clean up the partially written temp file, the previous cache file is kept
+		_ = os.Remove(tempFile)
+		return errors.NewStorageError("failed to write peer registry cache", err)
 	}
 
 	// Atomic rename
@@ -134,28 +187,119 @@ func (pr *PeerRegistry) SavePeerRegistryCache(cacheDir string) error {
 		return errors.NewProcessingError("failed to finalize peer registry cache: %v", err)
 	}
 
+	// A stale file that could not be removed is harmless, the most recently written file is loaded
+	_ = os.Remove(staleFile)
+
 	return nil
 }
 
-// LoadPeerRegistryCache loads the peer registry data from the cache file
-func (pr *PeerRegistry) LoadPeerRegistryCache(cacheDir string) error {
-	cacheFile := getPeerRegistryCacheFilePath(cacheDir)
+// compressPeerCache returns whether a cache of size bytes of JSON is gzip compressed in the compression mode
+func compressPeerCache(mode string, threshold int, size int) bool {
+	switch mode {
+	case PeerCacheCompressionAlways:
+		return true
+	case PeerCacheCompressionAuto:
+		return size > threshold
+	default:
+		return false
+	}
+}
 
-	// Check if file exists
-	if _, err := os.Stat(cacheFile); os.IsNotExist(err) {
-		// No cache file, not an error
-		return nil
+// gzipPeerCache returns the gzip compressed cache data
+func gzipPeerCache(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, err
 	}
 
-	file, err := os.Open(cacheFile)
+	return buf.Bytes(), nil
+}
+
+// readPeerCacheFile returns the JSON data of the cache file, or of its gzip compressed variant when that was written
+// last. Compressed data is detected by its gzip header, found is false when neither file exists.
+func readPeerCacheFile(cacheFile string) (data []byte, found bool, err error) {
+	var (
+		file    string
+		modTime time.Time
+	)
+
+	for _, candidate := range []string{cacheFile, cacheFile + compressedPeerCacheSuffix} {
+		info, statErr := os.Stat(candidate)
+		if statErr != nil {
+			continue
+		}
+
+		if file == "" || info.ModTime().After(modTime) {
+			file, modTime = candidate, info.ModTime()
+		}
+	}
+
+	if file == "" {
+		return nil, false, nil
+	}
+
+	f, err := os.Open(file)
 	if err != nil {
-		return errors.NewProcessingError("failed to open peer registry cache: %v", err)
+		return nil, true, errors.NewProcessingError("failed to open peer registry cache", err)
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+
+	var reader io.Reader = br
+
+	if header, _ := br.Peek(2); bytes.Equal(header, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, true, errors.NewProcessingError("failed to decompress peer registry cache", err)
+		}
+		defer gz.Close()
+
+		reader = gz
+	}
+
+	data, err = io.ReadAll(io.LimitReader(reader, maxPeerCacheFileSize+1))
+	if err != nil {
+		return nil, true, errors.NewProcessingError("failed to read peer registry cache", err)
+	}
+
+	if len(data) > maxPeerCacheFileSize {
+		return nil, true, errors.NewProcessingError("peer registry cache is larger than %d bytes", maxPeerCacheFileSize)
+	}
+
+	return data, true, nil
+}
+
+// writePeerCacheFile writes the data of a file in the peer cache directory. A fault injected at faultinject.PeerRegistryCacheWrite
+// fails the write after half of the data is written, the way a write fails when the disk fills up.
+func writePeerCacheFile(file string, data []byte) error {
+	if err := faultinject.Check(faultinject.PeerRegistryCacheWrite); err != nil {
+		_ = os.WriteFile(file, data[:len(data)/2], 0600)
+		return err
 	}
-	defer file.Close()
 
-	data, err := io.ReadAll(file)
+	return os.WriteFile(file, data, 0600)
+}
+
+// LoadPeerRegistryCache loads the peer registry data from the cache file
+func (pr *PeerRegistry) LoadPeerRegistryCache(cacheDir string) error {
+	cacheFile := getPeerRegistryCacheFilePath(cacheDir)
+
+	data, found, err := readPeerCacheFile(cacheFile)
 	if err != nil {
-		return errors.NewProcessingError("failed to read peer registry cache: %v", err)
+		return err
+	}
+
+	if !found {
+		// No cache file, not an error
+		return nil
 	}
 
 	var cache PeerRegistryCache
@@ -188,19 +332,21 @@ func (pr *PeerRegistry) LoadPeerRegistryCache(cacheDir string) error {
 		if !exists {
 			// Create new peer entry with cached data
 			info = &PeerInfo{
-				ID:              peerID,
-				Height:          metrics.Height,
-				BlockHash:       metrics.BlockHash,
-				DataHubURL:      metrics.DataHubURL,
-				Storage:         metrics.Storage,
-				ReputationScore: 50.0, // Start with neutral reputation
+				ID:                peerID,
+				Height:            metrics.Height,
+				BlockHash:         metrics.BlockHash,
+				DataHubURL:        metrics.DataHubURL,
+				DataHubMirrorURLs: metrics.DataHubMirrorURLs,
+				Storage:           metrics.Storage,
+				ReputationScore:   50.0, // Start with neutral reputation
 			}
 			pr.peers[peerID] = info
 		}
 
-		// Restore interaction metrics (prefer new fields, fall back to legacy)
+		// Restore interaction metrics (prefer new fields, fall back to legacy). Invalid data reported by the
+		// validation services is a failure without an attempt.
 		switch {
-		case metrics.InteractionAttempts > 0:
+		case metrics.InteractionAttempts > 0 || metrics.InteractionFailures > 0:
 			info.InteractionAttempts = metrics.InteractionAttempts
 			info.InteractionSuccesses = metrics.InteractionSuccesses
 			info.InteractionFailures = metrics.InteractionFailures
@@ -230,6 +376,14 @@ func (pr *PeerRegistry) LoadPeerRegistryCache(cacheDir string) error {
 			}
 		}
 
+		info.DoubleSpendCount = metrics.DoubleSpendCount
+		info.LastDoubleSpend = metrics.LastDoubleSpend
+
+		info.InvalidBlocksReceived = metrics.InvalidBlocksReceived
+		info.InvalidSubtreesReceived = metrics.InvalidSubtreesReceived
+		info.LastInvalidData = metrics.LastInvalidData
+		info.LastInvalidDataReason = metrics.LastInvalidDataReason
+
 		// Restore interaction type breakdown
 		info.BlocksReceived = metrics.BlocksReceived
 		info.SubtreesReceived = metrics.SubtreesReceived
@@ -242,11 +396,21 @@ func (pr *PeerRegistry) LoadPeerRegistryCache(cacheDir string) error {
 		// Update DataHubURL and height if not already set
 		if info.DataHubURL == "" && metrics.DataHubURL != "" {
 			info.DataHubURL = metrics.DataHubURL
+			info.DataHubMirrorURLs = metrics.DataHubMirrorURLs
 		}
 		if info.Height == 0 && metrics.Height > 0 {
 			info.Height = metrics.Height
 			info.BlockHash = metrics.BlockHash
 		}
+
+		// Restore the last seen time of peers not seen since the cache was written
+		if metrics.LastSeen.After(info.LastMessageTime) {
+			info.LastMessageTime = metrics.LastSeen
+		}
+		if info.LastMessageTime.IsZero() {
+			// cache files written before the last seen time was cached, the peer was known when it was written
+			info.LastMessageTime = cache.LastUpdated
+		}
 	}
 
 	return nil
@@ -3,9 +3,14 @@ package p2p
 import (
 	"os"
 	"path/filepath"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/faultinject"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -371,6 +376,32 @@ func TestPeerRegistryCache_ReputationMetricsPersistence(t *testing.T) {
 	assert.Equal(t, 5.0, info3.ReputationScore, "Malicious peer should have very low reputation")
 }
 
+func TestPeerRegistryCache_InvalidDataPersistence(t *testing.T) {
+	tempDir := t.TempDir()
+
+	pr := NewPeerRegistry()
+
+	peerID, _ := peer.Decode(testPeer1)
+
+	// a peer that only sent invalid data is still cached
+	pr.AddPeer(peerID, "")
+	pr.RecordInvalidSubtree(peerID, InvalidDataReasonInvalid)
+	pr.RecordInvalidBlock(peerID, InvalidDataReasonUnavailable)
+
+	require.NoError(t, pr.SavePeerRegistryCache(tempDir))
+
+	pr2 := NewPeerRegistry()
+	require.NoError(t, pr2.LoadPeerRegistryCache(tempDir))
+
+	info, exists := pr2.GetPeer(peerID)
+	require.True(t, exists)
+	assert.Equal(t, int64(1), info.InvalidSubtreesReceived)
+	assert.Equal(t, int64(1), info.InvalidBlocksReceived)
+	assert.Equal(t, InvalidDataReasonUnavailable, info.LastInvalidDataReason)
+	assert.False(t, info.LastInvalidData.IsZero())
+	assert.Equal(t, int64(1), info.MaliciousCount)
+}
+
 func TestPeerRegistryCache_BackwardCompatibility_LegacyFields(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -532,3 +563,189 @@ func TestPeerRegistryCache_InvalidPeerID(t *testing.T) {
 	// The "invalid" peer ID should not be stored
 	assert.Equal(t, 0, pr.PeerCount())
 }
+
+func TestPeerRegistryCache_WriteFailure(t *testing.T) {
+	t.Cleanup(faultinject.Reset)
+
+	tempDir := t.TempDir()
+	cacheFile := filepath.Join(tempDir, "teranode_peer_registry.json")
+
+	peerID, _ := peer.Decode(testPeer1)
+
+	pr := NewPeerRegistry()
+	pr.AddPeer(peerID, "")
+	pr.RecordInteractionAttempt(peerID)
+	require.NoError(t, pr.SavePeerRegistryCache(tempDir))
+
+	previous, err := os.ReadFile(cacheFile)
+	require.NoError(t, err)
+
+	faultinject.Set(faultinject.PeerRegistryCacheWrite, syscall.ENOSPC)
+
+	pr.RecordInteractionAttempt(peerID)
+
+	err = pr.SavePeerRegistryCache(tempDir)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrStorageError))
+	assert.ErrorIs(t, err, syscall.ENOSPC)
+
+	// The previous cache file is kept and the partially written temp file is removed
+	current, err := os.ReadFile(cacheFile)
+	require.NoError(t, err)
+	assert.Equal(t, previous, current)
+
+	tempFiles, err := filepath.Glob(cacheFile + ".tmp.*")
+	require.NoError(t, err)
+	assert.Empty(t, tempFiles)
+
+	s := &Server{
+		settings:     &settings.Settings{P2P: settings.P2PSettings{PeerCacheDir: tempDir}},
+		logger:       ulogger.TestLogger{},
+		peerRegistry: pr,
+	}
+	assert.False(t, s.savePeerRegistryCaches())
+
+	faultinject.Clear(faultinject.PeerRegistryCacheWrite)
+	assert.True(t, s.savePeerRegistryCaches())
+
+	pr2 := NewPeerRegistry()
+	require.NoError(t, pr2.LoadPeerRegistryCache(tempDir))
+
+	info, exists := pr2.GetPeer(peerID)
+	require.True(t, exists)
+	assert.Equal(t, int64(2), info.InteractionAttempts)
+}
+
+func TestPeerRegistryCache_Compression(t *testing.T) {
+	peerID, _ := peer.Decode(testPeer1)
+
+	newRegistry := func() *PeerRegistry {
+		pr := NewPeerRegistry()
+		pr.AddPeer(peerID, "")
+		pr.UpdateDataHubURL(peerID, "http://peer1.example.com:8090")
+		pr.RecordInteractionAttempt(peerID)
+		pr.RecordInteractionSuccess(peerID, 100*time.Millisecond)
+
+		return pr
+	}
+
+	assertLoaded := func(t *testing.T, tempDir string) {
+		t.Helper()
+
+		pr := NewPeerRegistry()
+		require.NoError(t, pr.LoadPeerRegistryCache(tempDir))
+
+		info, exists := pr.GetPeer(peerID)
+		require.True(t, exists)
+		assert.Equal(t, "http://peer1.example.com:8090", info.DataHubURL)
+		assert.Equal(t, int64(1), info.InteractionSuccesses)
+	}
+
+	t.Run("always", func(t *testing.T) {
+		tempDir := t.TempDir()
+		cacheFile := filepath.Join(tempDir, "teranode_peer_registry.json")
+
+		pr := newRegistry()
+		pr.SetCacheCompression(PeerCacheCompressionAlways, 0)
+		require.NoError(t, pr.SavePeerRegistryCache(tempDir))
+
+		data, err := os.ReadFile(cacheFile + ".gz")
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x1f, 0x8b}, data[:2])
+		assert.NoFileExists(t, cacheFile)
+
+		assertLoaded(t, tempDir)
+	})
+
+	t.Run("auto switches to compressed storage above the threshold", func(t *testing.T) {
+		tempDir := t.TempDir()
+		cacheFile := filepath.Join(tempDir, "teranode_peer_registry.json")
+
+		pr := newRegistry()
+		pr.SetCacheCompression(PeerCacheCompressionAuto, 1<<20)
+		require.NoError(t, pr.SavePeerRegistryCache(tempDir))
+
+		assert.FileExists(t, cacheFile)
+		assert.NoFileExists(t, cacheFile+".gz")
+
+		pr.SetCacheCompression(PeerCacheCompressionAuto, 10)
+		require.NoError(t, pr.SavePeerRegistryCache(tempDir))
+
+		assert.FileExists(t, cacheFile+".gz")
+		assert.NoFileExists(t, cacheFile, "the uncompressed cache is removed")
+
+		assertLoaded(t, tempDir)
+	})
+
+	t.Run("never", func(t *testing.T) {
+		tempDir := t.TempDir()
+		cacheFile := filepath.Join(tempDir, "teranode_peer_registry.json")
+
+		pr := newRegistry()
+		pr.SetCacheCompression(PeerCacheCompressionAlways, 0)
+		require.NoError(t, pr.SavePeerRegistryCache(tempDir))
+
+		pr.SetCacheCompression(PeerCacheCompressionNever, 0)
+		require.NoError(t, pr.SavePeerRegistryCache(tempDir))
+
+		data, err := os.ReadFile(cacheFile)
+		require.NoError(t, err)
+		assert.Equal(t, byte('{'), data[0])
+		assert.NoFileExists(t, cacheFile+".gz")
+
+		assertLoaded(t, tempDir)
+	})
+
+	t.Run("the most recently written file is loaded", func(t *testing.T) {
+		tempDir := t.TempDir()
+		cacheFile := filepath.Join(tempDir, "teranode_peer_registry.json")
+
+		pr := newRegistry()
+		pr.SetCacheCompression(PeerCacheCompressionAlways, 0)
+		require.NoError(t, pr.SavePeerRegistryCache(tempDir))
+
+		// a stale uncompressed cache without the peer, written before the compressed one
+		require.NoError(t, os.WriteFile(cacheFile, []byte(`{"version": "1.0", "peers": {}}`), 0600))
+
+		stale := time.Now().Add(-time.Hour)
+		require.NoError(t, os.Chtimes(cacheFile, stale, stale))
+
+		assertLoaded(t, tempDir)
+	})
+
+	t.Run("a gzip cache file is detected by its content", func(t *testing.T) {
+		tempDir := t.TempDir()
+
+		pr := newRegistry()
+		pr.SetCacheCompression(PeerCacheCompressionAlways, 0)
+		require.NoError(t, pr.SavePeerRegistryCache(tempDir))
+
+		cacheFile := filepath.Join(tempDir, "teranode_peer_registry.json")
+		require.NoError(t, os.Rename(cacheFile+".gz", cacheFile))
+
+		assertLoaded(t, tempDir)
+	})
+}
+
+func TestPeerRegistryCache_LastSeen(t *testing.T) {
+	tempDir := t.TempDir()
+
+	peerID1, _ := peer.Decode(testPeer1)
+
+	pr := NewPeerRegistry()
+	pr.AddPeer(peerID1, "")
+	pr.UpdateDataHubURL(peerID1, "http://peer1")
+	pr.UpdateLastMessageTime(peerID1)
+
+	saved, _ := pr.GetPeer(peerID1)
+	require.False(t, saved.LastMessageTime.IsZero())
+
+	require.NoError(t, pr.SavePeerRegistryCache(tempDir))
+
+	restored := NewPeerRegistry()
+	require.NoError(t, restored.LoadPeerRegistryCache(tempDir))
+
+	info, exists := restored.GetPeer(peerID1)
+	require.True(t, exists)
+	assert.True(t, saved.LastMessageTime.Equal(info.LastMessageTime))
+}
@@ -0,0 +1,78 @@
+package p2p
+
+import (
+	"math"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// baselineReputation is the reputation peers start with, before any interaction
+	baselineReputation = 50.0
+
+	// baselineReputationTolerance is how far the reputation of a peer may be from the baseline for the peer to
+	// count as having no reputation worth keeping
+	baselineReputationTolerance = 1.0
+)
+
+// CollectStalePeers removes the peers not seen for maxAge whose reputation is at the baseline, e.g. peers gossiped
+// about or connected once months ago. Connected, banned and probation peers are kept, as are peers with a good or
+// bad reputation, so bad peers are not forgiven by being forgotten. Returns the removed peers.
+func (pr *PeerRegistry) CollectStalePeers(maxAge time.Duration, now time.Time) []peer.ID {
+	if maxAge <= 0 {
+		return nil
+	}
+
+	pr.lock()
+	defer pr.mu.Unlock()
+
+	cutoff := now.Add(-maxAge)
+
+	var collected []peer.ID
+
+	for id, info := range pr.peers {
+		if info.IsConnected || info.IsBanned || info.IsOnProbation {
+			continue
+		}
+
+		if info.LastMessageTime.After(cutoff) {
+			continue
+		}
+
+		if math.Abs(info.ReputationScore-baselineReputation) > baselineReputationTolerance {
+			continue
+		}
+
+		delete(pr.peers, id)
+		delete(pr.owned, id)
+
+		collected = append(collected, id)
+	}
+
+	if len(collected) > 0 {
+		pr.version.Add(1)
+	}
+
+	return collected
+}
+
+// collectStalePeers removes the stale peers from the peer registry, see PeerRegistry.CollectStalePeers. The peers
+// are dropped from the cache file the next time it is saved.
+func (s *Server) collectStalePeers() int {
+	maxAge := s.settings.P2P.PeerGCMaxAge
+	if maxAge <= 0 || s.peerRegistry == nil {
+		return 0
+	}
+
+	collected := s.peerRegistry.CollectStalePeers(maxAge, time.Now())
+	if len(collected) == 0 {
+		return 0
+	}
+
+	prometheusP2PPeersCollected.Add(float64(len(collected)))
+
+	s.logger.Infof("[collectStalePeers] removed %d peers not seen for %s with a baseline reputation from the peer registry", len(collected), maxAge)
+
+	return len(collected)
+}
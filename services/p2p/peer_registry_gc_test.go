@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerRegistry_CollectStalePeers(t *testing.T) {
+	now := time.Now()
+	maxAge := 30 * 24 * time.Hour
+
+	pr := NewPeerRegistry()
+
+	addPeer := func(id peer.ID, lastSeen time.Time, update func(info *PeerInfo)) {
+		pr.AddPeer(id, "")
+
+		info := pr.peers[id]
+		info.LastMessageTime = lastSeen
+
+		if update != nil {
+			update(info)
+		}
+	}
+
+	stale := now.Add(-maxAge - time.Hour)
+
+	addPeer("stale", stale, nil)
+	addPeer("stale-near-baseline", stale, func(info *PeerInfo) { info.ReputationScore = 50.5 })
+	addPeer("recent", now.Add(-time.Hour), nil)
+	addPeer("good", stale, func(info *PeerInfo) { info.ReputationScore = 80 })
+	addPeer("bad", stale, func(info *PeerInfo) { info.ReputationScore = 5 })
+	addPeer("connected", stale, func(info *PeerInfo) { info.IsConnected = true })
+	addPeer("banned", stale, func(info *PeerInfo) { info.IsBanned = true })
+	addPeer("probation", stale, func(info *PeerInfo) { info.IsOnProbation = true })
+
+	collected := pr.CollectStalePeers(maxAge, now)
+	assert.ElementsMatch(t, []peer.ID{"stale", "stale-near-baseline"}, collected)
+
+	for _, id := range []peer.ID{"recent", "good", "bad", "connected", "banned", "probation"} {
+		_, exists := pr.GetPeer(id)
+		assert.True(t, exists, "peer %s is kept", id)
+	}
+
+	_, exists := pr.GetPeer("stale")
+	assert.False(t, exists)
+
+	assert.Empty(t, pr.CollectStalePeers(maxAge, now), "nothing left to collect")
+	assert.Empty(t, pr.CollectStalePeers(0, now.Add(365*24*time.Hour)), "collection disabled")
+}
+
+func TestCollectStalePeers_OnCacheLoad(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	stalePeerID, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	recentPeerID, err := peer.Decode("12D3KooWEyX7hgdXy8zUjCs9CqvMGpB5dKVFj9MX2nUBLwajdSZH")
+	require.NoError(t, err)
+
+	saved := NewPeerRegistry()
+	saved.AddPeer(stalePeerID, "")
+	saved.AddPeer(recentPeerID, "")
+	saved.UpdateDataHubURL(stalePeerID, "http://stale.example.com")
+	saved.UpdateDataHubURL(recentPeerID, "http://recent.example.com")
+	saved.peers[stalePeerID].LastMessageTime = time.Now().Add(-60 * 24 * time.Hour)
+	require.NoError(t, saved.SavePeerRegistryCache(cacheDir))
+
+	tSettings := CreateTestSettings()
+	tSettings.P2P.PeerGCMaxAge = 30 * 24 * time.Hour
+
+	s := &Server{
+		logger:       ulogger.TestLogger{},
+		settings:     tSettings,
+		peerRegistry: NewPeerRegistry(),
+	}
+	require.NoError(t, s.peerRegistry.LoadPeerRegistryCache(cacheDir))
+
+	assert.Equal(t, 1, s.collectStalePeers())
+
+	_, exists := s.peerRegistry.GetPeer(stalePeerID)
+	assert.False(t, exists)
+
+	_, exists = s.peerRegistry.GetPeer(recentPeerID)
+	assert.True(t, exists)
+}
@@ -0,0 +1,120 @@
+package p2p
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	driftStaleConnected  = "stale_connected"  // marked connected in the registry without a live connection
+	driftMissedConnected = "missed_connected" // marked disconnected in the registry with a live connection
+)
+
+// startPeerRegistryReconciler starts the periodic reconciliation of the connection state of the peers in the
+// registry with the live connections of the libp2p host, so a peer that is no longer connected is never used as
+// a connected peer
+func (s *Server) startPeerRegistryReconciler(ctx context.Context) {
+	if s.settings.P2P.PeerRegistryReconcileInterval <= 0 || s.peerRegistry == nil || s.P2PClient == nil {
+		s.logger.Infof("[startPeerRegistryReconciler] peer registry reconciliation disabled")
+		return
+	}
+
+	initPrometheusMetrics()
+
+	interval := s.settings.P2P.PeerRegistryReconcileInterval
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Infof("[startPeerRegistryReconciler] stopping peer registry reconciliation")
+				return
+			case <-ticker.C:
+				s.reconcilePeerRegistry()
+			}
+		}
+	}()
+
+	s.logger.Infof("[startPeerRegistryReconciler] started peer registry reconciliation with interval %v", interval)
+}
+
+// reconcilePeerRegistry corrects the connection state of the peers in the registry that drifted from the live
+// connections of the libp2p host
+func (s *Server) reconcilePeerRegistry() {
+	connected := make(map[peer.ID]struct{})
+	relayOnly := make(map[peer.ID]struct{})
+	transports := make(map[peer.ID]string)
+	addrs := make(map[peer.ID][]string)
+
+	for _, p := range s.P2PClient.GetPeers() {
+		// peers on our topics without any connection are known through gossip only
+		if len(p.Addrs) == 0 {
+			continue
+		}
+
+		id, err := peer.Decode(p.ID)
+		if err != nil {
+			s.logger.Debugf("[reconcilePeerRegistry] failed to decode peer ID %s: %v", p.ID, err)
+			continue
+		}
+
+		connected[id] = struct{}{}
+		addrs[id] = p.Addrs
+		transports[id] = connectionTransport(p.Addrs)
+
+		if isRelayOnly(p.Addrs) {
+			relayOnly[id] = struct{}{}
+		}
+	}
+
+	markedConnected, markedDisconnected := s.peerRegistry.ReconcileConnections(connected)
+
+	if s.geoLocator != nil {
+		s.peerRegistry.UpdateLocations(locatePeers(s.geoLocator, addrs))
+	}
+
+	prometheusP2PRelayOnlyPeers.Set(float64(s.peerRegistry.UpdateRelayOnly(relayOnly)))
+
+	transportCounts := s.peerRegistry.UpdateTransports(transports)
+	for _, transport := range peerTransports {
+		prometheusP2PPeerTransports.WithLabelValues(transport).Set(float64(transportCounts[transport]))
+	}
+
+	for _, id := range markedConnected {
+		s.peerEvents.Record(id.String(), PeerEventConnected, "reconciled with live connections")
+	}
+
+	for _, id := range markedDisconnected {
+		s.peerEvents.Record(id.String(), PeerEventDisconnected, "reconciled with live connections")
+	}
+
+	prometheusP2PPeerRegistryDrift.WithLabelValues(driftMissedConnected).Add(float64(len(markedConnected)))
+	prometheusP2PPeerRegistryDrift.WithLabelValues(driftStaleConnected).Add(float64(len(markedDisconnected)))
+
+	if len(markedConnected) > 0 || len(markedDisconnected) > 0 {
+		s.logger.Warnf("[reconcilePeerRegistry] corrected peer registry drift: %d peers marked connected, %d peers marked disconnected",
+			len(markedConnected), len(markedDisconnected))
+	}
+}
+
+// isRelayOnly returns whether all connection addresses of a peer are circuit relay addresses, so the peer can not
+// be reached directly, e.g. because it is behind a NAT that hole punching could not get through
+func isRelayOnly(addrs []string) bool {
+	if len(addrs) == 0 {
+		return false
+	}
+
+	for _, addr := range addrs {
+		if !strings.Contains(addr, "/p2p-circuit") {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,110 @@
+package p2p
+
+import (
+	"testing"
+
+	p2pMessageBus "github.com/bsv-blockchain/go-p2p-message-bus"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reconcilerTestP2PClient is a P2P client returning a fixed list of peers
+type reconcilerTestP2PClient struct {
+	MockServerP2PClient
+	peers []p2pMessageBus.PeerInfo
+}
+
+func (m *reconcilerTestP2PClient) GetPeers() []p2pMessageBus.PeerInfo {
+	return m.peers
+}
+
+func TestReconcilePeerRegistry(t *testing.T) {
+	livePeerID, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	phantomPeerID, err := peer.Decode("12D3KooWEyX7hgdXy8zUjCs9CqvMGpB5dKVFj9MX2nUBLwajdSZH")
+	require.NoError(t, err)
+
+	s := &Server{
+		logger:       ulogger.TestLogger{},
+		settings:     CreateTestSettings(),
+		peerRegistry: NewPeerRegistry(),
+		P2PClient: &reconcilerTestP2PClient{
+			peers: []p2pMessageBus.PeerInfo{
+				{ID: livePeerID.String(), Addrs: []string{"/ip4/127.0.0.1/tcp/9905"}},
+				{ID: phantomPeerID.String()}, // on our topics, but without a connection
+				{ID: "invalid-peer-id", Addrs: []string{"/ip4/127.0.0.1/tcp/9906"}},
+			},
+		},
+	}
+
+	initPrometheusMetrics()
+
+	s.peerRegistry.AddPeer(livePeerID, "")
+	s.peerRegistry.AddPeer(phantomPeerID, "")
+	s.peerRegistry.UpdateConnectionState(phantomPeerID, true)
+
+	s.reconcilePeerRegistry()
+
+	info, _ := s.peerRegistry.GetPeer(livePeerID)
+	assert.True(t, info.IsConnected)
+
+	info, _ = s.peerRegistry.GetPeer(phantomPeerID)
+	assert.False(t, info.IsConnected)
+
+	connected := s.peerRegistry.GetConnectedPeers()
+	require.Len(t, connected, 1)
+	assert.Equal(t, livePeerID, connected[0].ID)
+}
+
+func TestReconcilePeerRegistry_RelayOnly(t *testing.T) {
+	directPeerID, err := peer.Decode("12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ")
+	require.NoError(t, err)
+
+	relayedPeerID, err := peer.Decode("12D3KooWEyX7hgdXy8zUjCs9CqvMGpB5dKVFj9MX2nUBLwajdSZH")
+	require.NoError(t, err)
+
+	relayAddr := "/ip4/203.0.113.1/tcp/9905/p2p/12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ/p2p-circuit"
+
+	client := &reconcilerTestP2PClient{
+		peers: []p2pMessageBus.PeerInfo{
+			// a relayed connection next to a direct connection, e.g. after a successful hole punch
+			{ID: directPeerID.String(), Addrs: []string{relayAddr, "/ip4/198.51.100.1/tcp/9905"}},
+			{ID: relayedPeerID.String(), Addrs: []string{relayAddr}},
+		},
+	}
+
+	s := &Server{
+		logger:       ulogger.TestLogger{},
+		settings:     CreateTestSettings(),
+		peerRegistry: NewPeerRegistry(),
+		P2PClient:    client,
+	}
+
+	initPrometheusMetrics()
+
+	s.peerRegistry.AddPeer(directPeerID, "")
+	s.peerRegistry.AddPeer(relayedPeerID, "")
+
+	s.reconcilePeerRegistry()
+
+	info, _ := s.peerRegistry.GetPeer(directPeerID)
+	assert.False(t, info.IsRelayOnly)
+	assert.Equal(t, transportTCP, info.Transport)
+
+	info, _ = s.peerRegistry.GetPeer(relayedPeerID)
+	assert.True(t, info.IsConnected)
+	assert.True(t, info.IsRelayOnly)
+	assert.Equal(t, transportRelay, info.Transport)
+
+	// the peer is reachable directly once hole punching succeeded
+	client.peers[1].Addrs = []string{"/ip4/198.51.100.2/udp/9905/quic-v1"}
+
+	s.reconcilePeerRegistry()
+
+	info, _ = s.peerRegistry.GetPeer(relayedPeerID)
+	assert.False(t, info.IsRelayOnly)
+	assert.Equal(t, transportQUIC, info.Transport)
+}
@@ -0,0 +1,103 @@
+package p2p
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// registrySnapshot is an immutable view of the peers in the registry at a registry version. The entries are
+// shared with the registry, which copies an entry before modifying it once it is part of a published snapshot.
+type registrySnapshot struct {
+	version uint64
+	peers   []*PeerInfo
+}
+
+// lock acquires the write lock of the registry, recording how long the caller waited for it
+func (pr *PeerRegistry) lock() {
+	start := time.Now()
+	pr.mu.Lock()
+	prometheusP2PPeerRegistryLockWait.WithLabelValues("write").Observe(time.Since(start).Seconds())
+}
+
+// rlock acquires the read lock of the registry, recording how long the caller waited for it
+func (pr *PeerRegistry) rlock() {
+	start := time.Now()
+	pr.mu.RLock()
+	prometheusP2PPeerRegistryLockWait.WithLabelValues("read").Observe(time.Since(start).Seconds())
+}
+
+// mutablePeer returns the entry of a peer for modification, and whether the peer is in the registry.
+// Must be called with the write lock held.
+func (pr *PeerRegistry) mutablePeer(id peer.ID) (*PeerInfo, bool) {
+	info, exists := pr.peers[id]
+	if !exists {
+		return nil, false
+	}
+
+	return pr.ownPeer(id, info), true
+}
+
+// ownPeer returns the entry of a peer for modification: the entry itself when no published snapshot shares it,
+// otherwise a copy replacing the entry in the registry. Invalidates the published snapshot.
+// Must be called with the write lock held.
+func (pr *PeerRegistry) ownPeer(id peer.ID, info *PeerInfo) *PeerInfo {
+	version := pr.version.Add(1)
+
+	// entries created or copied after the last published snapshot are not shared with it
+	if pr.owned[id] > pr.published.Load() {
+		return info
+	}
+
+	clone := *info
+	pr.peers[id] = &clone
+	pr.owned[id] = version
+
+	return &clone
+}
+
+// snapshot returns the peers in the registry. Readers share the snapshot until the registry is modified, so
+// reading the registry only takes the read lock, for copying the entry pointers, after a modification.
+// The returned entries must not be modified.
+func (pr *PeerRegistry) snapshot() []*PeerInfo {
+	if snap := pr.snap.Load(); snap != nil && snap.version == pr.version.Load() {
+		return snap.peers
+	}
+
+	pr.rlock()
+
+	snap := &registrySnapshot{
+		version: pr.version.Load(),
+		peers:   make([]*PeerInfo, 0, len(pr.peers)),
+	}
+
+	for _, info := range pr.peers {
+		snap.peers = append(snap.peers, info)
+	}
+
+	// published while holding the read lock, so writers see it before modifying any entry
+	pr.published.Store(snap.version)
+	pr.mu.RUnlock()
+
+	pr.snap.Store(snap)
+
+	prometheusP2PPeerRegistrySnapshots.Inc()
+
+	return snap.peers
+}
+
+// copyPeers returns copies of the entries matching the filter, all entries when the filter is nil
+func copyPeers(peers []*PeerInfo, filter func(info *PeerInfo) bool) []*PeerInfo {
+	result := make([]*PeerInfo, 0, len(peers))
+
+	for _, info := range peers {
+		if filter != nil && !filter(info) {
+			continue
+		}
+
+		copy := *info
+		result = append(result, &copy)
+	}
+
+	return result
+}
@@ -0,0 +1,203 @@
+package p2p
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerRegistrySnapshot_SharedUntilModified(t *testing.T) {
+	pr := NewPeerRegistry()
+	pr.AddPeer(peer.ID("peer-a"), "")
+
+	first := pr.snapshot()
+	require.Len(t, first, 1)
+
+	// no modification, readers share the snapshot
+	assert.Same(t, &first[0], &pr.snapshot()[0])
+
+	pr.UpdateHeight(peer.ID("peer-a"), 100, "hash")
+
+	second := pr.snapshot()
+	require.Len(t, second, 1)
+
+	assert.NotSame(t, &first[0], &second[0])
+	assert.Equal(t, int32(100), second[0].Height)
+}
+
+func TestPeerRegistrySnapshot_CopyOnWrite(t *testing.T) {
+	pr := NewPeerRegistry()
+	id := peer.ID("peer-a")
+	pr.AddPeer(id, "")
+	pr.UpdateHeight(id, 100, "hash-100")
+
+	snap := pr.snapshot()
+	require.Len(t, snap, 1)
+
+	pr.UpdateHeight(id, 101, "hash-101")
+	pr.RecordInteractionSuccess(id, time.Millisecond)
+
+	// the published snapshot is never modified by writers
+	assert.Equal(t, int32(100), snap[0].Height)
+	assert.Equal(t, int64(0), snap[0].InteractionSuccesses)
+
+	info, exists := pr.GetPeer(id)
+	require.True(t, exists)
+	assert.Equal(t, int32(101), info.Height)
+	assert.Equal(t, int64(1), info.InteractionSuccesses)
+}
+
+func TestPeerRegistrySnapshot_ModifiesUnsharedEntriesInPlace(t *testing.T) {
+	pr := NewPeerRegistry()
+	id := peer.ID("peer-a")
+	pr.AddPeer(id, "")
+
+	entry := pr.peers[id]
+
+	pr.UpdateHeight(id, 100, "hash")
+	pr.UpdateLastMessageTime(id)
+
+	assert.Same(t, entry, pr.peers[id], "an entry not shared with a snapshot is not copied")
+
+	_ = pr.snapshot()
+	pr.UpdateHeight(id, 101, "hash")
+
+	copied := pr.peers[id]
+	assert.NotSame(t, entry, copied, "an entry shared with a snapshot is copied")
+
+	pr.UpdateHeight(id, 102, "hash")
+	assert.Same(t, copied, pr.peers[id], "the copy is owned by the registry until the next snapshot")
+}
+
+func TestPeerRegistrySnapshot_RemovedPeers(t *testing.T) {
+	pr := NewPeerRegistry()
+	pr.AddPeer(peer.ID("peer-a"), "")
+	pr.AddPeer(peer.ID("peer-b"), "")
+
+	require.Len(t, pr.GetAllPeers(), 2)
+
+	pr.RemovePeer(peer.ID("peer-a"))
+
+	peers := pr.GetAllPeers()
+	require.Len(t, peers, 1)
+	assert.Equal(t, peer.ID("peer-b"), peers[0].ID)
+}
+
+func TestPeerRegistrySnapshot_ReturnedPeersAreCopies(t *testing.T) {
+	pr := NewPeerRegistry()
+	id := peer.ID("peer-a")
+	pr.AddPeer(id, "")
+	pr.UpdateDataHubURL(id, "http://peer-a")
+
+	peers := pr.GetPeersForCatchup()
+	require.Len(t, peers, 1)
+
+	peers[0].ReputationScore = 0
+	peers[0].DataHubURL = ""
+
+	again := pr.GetPeersForCatchup()
+	require.Len(t, again, 1)
+	assert.Equal(t, 50.0, again[0].ReputationScore)
+	assert.Equal(t, "http://peer-a", again[0].DataHubURL)
+}
+
+func TestPeerRegistrySnapshot_ConcurrentReadersAndWriters(t *testing.T) {
+	pr := NewPeerRegistry()
+
+	ids := make([]peer.ID, 50)
+	for i := range ids {
+		ids[i] = peer.ID(fmt.Sprintf("peer-%d", i))
+		pr.AddPeer(ids[i], "")
+		pr.UpdateDataHubURL(ids[i], fmt.Sprintf("http://peer-%d", i))
+	}
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < 500; i++ {
+				id := ids[(i+w)%len(ids)]
+
+				switch i % 4 {
+				case 0:
+					pr.RecordInteractionSuccess(id, time.Millisecond)
+				case 1:
+					pr.RecordInteractionFailure(id)
+				case 2:
+					pr.UpdateHeight(id, int32(i), "hash")
+				default:
+					pr.UpdateConnectionState(id, i%8 == 3)
+				}
+			}
+		}(w)
+	}
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 500; i++ {
+				assert.Len(t, pr.GetAllPeers(), len(ids))
+
+				for _, info := range pr.GetPeersForCatchup() {
+					assert.NotEmpty(t, info.DataHubURL)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	var attempts int64
+	for _, info := range pr.GetAllPeers() {
+		attempts += info.InteractionSuccesses + info.InteractionFailures
+	}
+
+	assert.Equal(t, int64(1000), attempts)
+}
+
+// BenchmarkPeerRegistry_CatchupReadsUnderChurn measures GetPeersForCatchup on a large registry while writers
+// record catchup outcomes, as many block validation clients do
+func BenchmarkPeerRegistry_CatchupReadsUnderChurn(b *testing.B) {
+	pr := NewPeerRegistry()
+
+	ids := make([]peer.ID, 2000)
+	for i := range ids {
+		ids[i] = peer.ID(fmt.Sprintf("peer-%d", i))
+		pr.AddPeer(ids[i], "")
+		pr.UpdateDataHubURL(ids[i], fmt.Sprintf("http://peer-%d", i))
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+				pr.RecordInteractionSuccess(ids[i%len(ids)], time.Millisecond)
+			}
+		}
+	}()
+
+	b.ResetTimer()
+
+	b.RunParallel(func(p *testing.PB) {
+		for p.Next() {
+			_ = pr.GetPeersForCatchup()
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bsv-blockchain/teranode/util/geoip"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,6 +134,38 @@ func TestPeerRegistry_UpdateDataHubURL(t *testing.T) {
 	assert.Equal(t, "http://datahub.test", info.DataHubURL)
 }
 
+func TestPeerRegistry_UpdateDataHubMirrorURLs(t *testing.T) {
+	pr := NewPeerRegistry()
+	peerID := peer.ID("test-peer-1")
+
+	pr.AddPeer(peerID, "")
+	pr.UpdateDataHubMirrorURLs(peerID, []string{
+		"https://mirror1.test",
+		"ftp://mirror.test",
+		"http://mirror2.test/api/v1",
+		"not a url",
+		"http://mirror3.test",
+		"http://mirror4.test",
+		"http://mirror5.test",
+	})
+
+	info, exists := pr.GetPeer(peerID)
+	require.True(t, exists)
+	assert.Equal(t, []string{"https://mirror1.test", "http://mirror2.test/api/v1", "http://mirror3.test", "http://mirror4.test"}, info.DataHubMirrorURLs, "only valid URLs up to the maximum are kept")
+
+	// an unchanged list leaves the published snapshot intact
+	version := pr.version.Load()
+	pr.UpdateDataHubMirrorURLs(peerID, []string{"https://mirror1.test", "http://mirror2.test/api/v1", "http://mirror3.test", "http://mirror4.test"})
+	assert.Equal(t, version, pr.version.Load())
+
+	pr.UpdateDataHubMirrorURLs(peerID, nil)
+	info, _ = pr.GetPeer(peerID)
+	assert.Empty(t, info.DataHubMirrorURLs)
+
+	// Update non-existent peer should not panic
+	pr.UpdateDataHubMirrorURLs(peer.ID("non-existent"), []string{"http://mirror.test"})
+}
+
 func TestPeerRegistry_UpdateHealth(t *testing.T) {
 	pr := NewPeerRegistry()
 	peerID := peer.ID("test-peer-1")
@@ -202,6 +235,52 @@ func TestPeerRegistry_UpdateURLResponsiveness(t *testing.T) {
 	assert.NotZero(t, info.LastURLCheck)
 }
 
+func TestPeerRegistry_UpdateDataHubHealth(t *testing.T) {
+	pr := NewPeerRegistry()
+	events, err := NewPeerEventLog(10, "", 0)
+	require.NoError(t, err)
+	pr.SetEventLog(events)
+
+	peerID := peer.ID("test-peer-1")
+	pr.AddPeer(peerID, "")
+	pr.UpdateDataHubURL(peerID, "http://hub.test")
+
+	assert.False(t, pr.UpdateDataHubHealth(peerID, true, 20*time.Millisecond, 2))
+
+	info, _ := pr.GetPeer(peerID)
+	assert.True(t, info.IsHealthy)
+	assert.True(t, info.URLResponsive)
+	assert.Equal(t, 20*time.Millisecond, info.HealthDuration)
+
+	// the DataHub is down after the second consecutive failure
+	assert.False(t, pr.UpdateDataHubHealth(peerID, false, 0, 2))
+	assert.Len(t, pr.GetPeersForCatchup(), 1)
+	assert.True(t, pr.UpdateDataHubHealth(peerID, false, 0, 2))
+	assert.False(t, pr.UpdateDataHubHealth(peerID, false, 0, 2), "only reported once")
+
+	info, _ = pr.GetPeer(peerID)
+	assert.False(t, info.IsHealthy)
+	assert.True(t, info.IsDataHubDown)
+	assert.Equal(t, 3, info.HealthCheckFailures)
+	assert.Empty(t, pr.GetPeersForCatchup(), "peers with a DataHub that is down are not used for catchup")
+
+	// a successful probe restores the peer
+	assert.False(t, pr.UpdateDataHubHealth(peerID, true, 10*time.Millisecond, 2))
+
+	info, _ = pr.GetPeer(peerID)
+	assert.False(t, info.IsDataHubDown)
+	assert.Zero(t, info.HealthCheckFailures)
+	assert.Len(t, pr.GetPeersForCatchup(), 1)
+
+	recorded := events.Query(time.Time{}, time.Time{}, peerID.String(), 0)
+	require.Len(t, recorded, 2)
+	assert.Equal(t, PeerEventDataHubDown, recorded[0].Type)
+	assert.Equal(t, PeerEventDataHubRestored, recorded[1].Type)
+
+	// unknown peers are ignored
+	assert.False(t, pr.UpdateDataHubHealth(peer.ID("unknown"), false, 0, 1))
+}
+
 func TestPeerRegistry_PeerCount(t *testing.T) {
 	pr := NewPeerRegistry()
 
@@ -587,3 +666,303 @@ func TestPeerRegistry_CatchupMetrics_ConcurrentAccess(t *testing.T) {
 	assert.Equal(t, int64(30), info.InteractionFailures)
 	assert.NotZero(t, info.AvgResponseTime)
 }
+
+func TestPeerRegistry_Probation(t *testing.T) {
+	pr := NewPeerRegistry()
+	ids := GenerateTestPeerIDs(3)
+
+	for i, id := range ids {
+		pr.AddPeer(id, "")
+		pr.UpdateDataHubURL(id, "http://peer"+string(rune('0'+i))+".test")
+	}
+
+	// Peer 0: on probation, excluded from catchup
+	pr.StartProbation(ids[0], time.Hour)
+	assert.True(t, pr.IsOnProbation(ids[0]))
+
+	peers := pr.GetPeersForCatchup()
+	require.Len(t, peers, 2)
+
+	for _, p := range peers {
+		assert.NotEqual(t, ids[0], p.ID, "Peer on probation should be excluded from catchup")
+	}
+
+	// Peer 1: probation elapsed with clean behaviour, promoted
+	pr.StartProbation(ids[1], -time.Second)
+
+	// Peer 2: probation elapsed but failed during probation, extended
+	pr.StartProbation(ids[2], time.Hour)
+	pr.RecordInteractionFailure(ids[2])
+	pr.mu.Lock()
+	pr.peers[ids[2]].ProbationUntil = time.Now().Add(-time.Second)
+	pr.mu.Unlock()
+
+	promoted := pr.PromoteProbationPeers(time.Hour)
+	assert.Equal(t, []peer.ID{ids[1]}, promoted)
+	assert.True(t, pr.IsOnProbation(ids[0]))
+	assert.False(t, pr.IsOnProbation(ids[1]))
+	assert.True(t, pr.IsOnProbation(ids[2]))
+
+	info, _ := pr.GetPeer(ids[2])
+	assert.True(t, info.ProbationUntil.After(time.Now()), "Probation should be extended after a failure")
+
+	pr.EndProbation(ids[0])
+	assert.False(t, pr.IsOnProbation(ids[0]))
+}
+
+func TestPeerRegistry_ProbationAppliedOnReAdd(t *testing.T) {
+	pr := NewPeerRegistry()
+	peerID := peer.ID("test-peer-1")
+
+	// Banned peers are removed from the registry, probation must survive until they return
+	pr.StartProbation(peerID, time.Hour)
+	assert.True(t, pr.IsOnProbation(peerID))
+
+	pr.AddPeer(peerID, "")
+
+	info, exists := pr.GetPeer(peerID)
+	require.True(t, exists)
+	assert.True(t, info.IsOnProbation)
+	assert.False(t, info.ProbationStartedAt.IsZero())
+}
+
+func TestPeerRegistry_GetChurn(t *testing.T) {
+	pr := NewPeerRegistry()
+	ids := GenerateTestPeerIDs(3)
+
+	for _, id := range ids {
+		pr.AddPeer(id, "")
+	}
+
+	// Re-adding an existing peer is not a join
+	pr.AddPeer(ids[0], "client")
+
+	pr.RemovePeer(ids[1])
+
+	// Removing an unknown peer is not a leave
+	pr.RemovePeer(peer.ID("unknown-peer"))
+
+	joined, left := pr.GetChurn(time.Hour)
+	assert.Equal(t, 3, joined)
+	assert.Equal(t, 1, left)
+
+	// Buckets older than the history window are dropped on the next change
+	pr.mu.Lock()
+	for i := range pr.churn {
+		pr.churn[i].start = pr.churn[i].start.Add(-2 * churnHistoryLength)
+	}
+	pr.mu.Unlock()
+
+	pr.RemovePeer(ids[2])
+
+	joined, left = pr.GetChurn(time.Hour)
+	assert.Equal(t, 0, joined)
+	assert.Equal(t, 1, left)
+}
+
+func TestPeerRegistry_UpdateRelayOnly(t *testing.T) {
+	pr := NewPeerRegistry()
+
+	relayed := peer.ID("relayed-peer")
+	direct := peer.ID("direct-peer")
+
+	pr.AddPeer(relayed, "")
+	pr.AddPeer(direct, "")
+
+	count := pr.UpdateRelayOnly(map[peer.ID]struct{}{relayed: {}, peer.ID("unknown-peer"): {}})
+	assert.Equal(t, 1, count)
+
+	info, _ := pr.GetPeer(relayed)
+	assert.True(t, info.IsRelayOnly)
+
+	info, _ = pr.GetPeer(direct)
+	assert.False(t, info.IsRelayOnly)
+
+	assert.Zero(t, pr.UpdateRelayOnly(map[peer.ID]struct{}{}))
+
+	info, _ = pr.GetPeer(relayed)
+	assert.False(t, info.IsRelayOnly)
+}
+
+func TestPeerRegistry_UpdateTransports(t *testing.T) {
+	pr := NewPeerRegistry()
+
+	quicPeer := peer.ID("quic-peer")
+	tcpPeer := peer.ID("tcp-peer")
+	gossipedPeer := peer.ID("gossiped-peer")
+
+	pr.AddPeer(quicPeer, "")
+	pr.AddPeer(tcpPeer, "")
+	pr.AddPeer(gossipedPeer, "")
+
+	counts := pr.UpdateTransports(map[peer.ID]string{
+		quicPeer:                transportQUIC,
+		tcpPeer:                 transportTCP,
+		peer.ID("unknown-peer"): transportTCP,
+	})
+	assert.Equal(t, map[string]int{transportQUIC: 1, transportTCP: 1}, counts)
+
+	info, _ := pr.GetPeer(quicPeer)
+	assert.Equal(t, transportQUIC, info.Transport)
+
+	info, _ = pr.GetPeer(gossipedPeer)
+	assert.Empty(t, info.Transport)
+
+	assert.Empty(t, pr.UpdateTransports(map[peer.ID]string{}))
+
+	info, _ = pr.GetPeer(quicPeer)
+	assert.Empty(t, info.Transport)
+}
+
+func TestPeerRegistry_UpdateLocations(t *testing.T) {
+	pr := NewPeerRegistry()
+
+	located := peer.ID("located-peer")
+	other := peer.ID("other-peer")
+
+	pr.AddPeer(located, "")
+	pr.AddPeer(other, "")
+
+	location := geoip.Location{Country: "NL", ASN: 64500, ASOrganization: "Example Hosting"}
+	pr.UpdateLocations(map[peer.ID]geoip.Location{located: location, peer.ID("unknown-peer"): location})
+
+	info, _ := pr.GetPeer(located)
+	assert.Equal(t, "NL", info.Country)
+	assert.Equal(t, uint32(64500), info.ASN)
+	assert.Equal(t, "Example Hosting", info.ASOrganization)
+
+	info, _ = pr.GetPeer(other)
+	assert.Empty(t, info.Country)
+
+	_, exists := pr.GetPeer(peer.ID("unknown-peer"))
+	assert.False(t, exists)
+}
+
+func TestPeerRegistry_ReconcileConnections(t *testing.T) {
+	pr := NewPeerRegistry()
+
+	stale := peer.ID("stale-peer")
+	missed := peer.ID("missed-peer")
+	connected := peer.ID("connected-peer")
+	gossiped := peer.ID("gossiped-peer")
+
+	for _, id := range []peer.ID{stale, missed, connected, gossiped} {
+		pr.AddPeer(id, "")
+	}
+
+	pr.UpdateConnectionState(stale, true)
+	pr.UpdateConnectionState(connected, true)
+
+	before, _ := pr.GetPeer(missed)
+
+	markedConnected, markedDisconnected := pr.ReconcileConnections(map[peer.ID]struct{}{
+		missed:                  {},
+		connected:               {},
+		peer.ID("unknown-peer"): {},
+	})
+
+	assert.Equal(t, []peer.ID{missed}, markedConnected)
+	assert.Equal(t, []peer.ID{stale}, markedDisconnected)
+
+	info, _ := pr.GetPeer(stale)
+	assert.False(t, info.IsConnected)
+
+	info, _ = pr.GetPeer(missed)
+	assert.True(t, info.IsConnected)
+	assert.False(t, info.ConnectedAt.Before(before.ConnectedAt))
+
+	info, _ = pr.GetPeer(connected)
+	assert.True(t, info.IsConnected)
+
+	info, _ = pr.GetPeer(gossiped)
+	assert.False(t, info.IsConnected)
+
+	// peers with a live connection are only added to the registry when they send a message
+	_, exists := pr.GetPeer(peer.ID("unknown-peer"))
+	assert.False(t, exists)
+
+	// nothing to correct on the next run
+	markedConnected, markedDisconnected = pr.ReconcileConnections(map[peer.ID]struct{}{missed: {}, connected: {}})
+	assert.Empty(t, markedConnected)
+	assert.Empty(t, markedDisconnected)
+}
+
+func TestPeerRegistry_SetDataHubURLVerified(t *testing.T) {
+	pr := NewPeerRegistry()
+	id := peer.ID("peer")
+
+	pr.AddPeer(id, "")
+	pr.UpdateDataHubURL(id, "http://datahub")
+
+	assert.False(t, pr.SetDataHubURLVerified(id, "http://other", true), "verified URL is no longer advertised")
+	assert.True(t, pr.SetDataHubURLVerified(id, "http://datahub", true))
+	assert.False(t, pr.SetDataHubURLVerified(id, "http://datahub", true), "already verified")
+
+	info, _ := pr.GetPeer(id)
+	assert.True(t, info.IsDataHubURLVerified)
+
+	pr.UpdateDataHubURL(id, "http://datahub")
+
+	info, _ = pr.GetPeer(id)
+	assert.True(t, info.IsDataHubURLVerified, "same URL stays verified")
+
+	pr.UpdateDataHubURL(id, "http://moved")
+
+	info, _ = pr.GetPeer(id)
+	assert.False(t, info.IsDataHubURLVerified, "changed URL must be verified again")
+	assert.True(t, info.DataHubURLVerifiedAt.IsZero())
+}
+
+func TestPeerRegistry_UpdateMinerID(t *testing.T) {
+	pr := NewPeerRegistry()
+	id := peer.ID("peer")
+	now := time.Now()
+
+	assert.False(t, pr.UpdateMinerID(id, "pool", "02aa", "", now), "unknown peer")
+
+	pr.AddPeer(id, "")
+
+	assert.True(t, pr.UpdateMinerID(id, "pool", "02aa", "ops@pool", now))
+	assert.False(t, pr.UpdateMinerID(id, "pool", "02aa", "ops@pool", now.Add(time.Minute)), "same miner identification")
+	assert.False(t, pr.UpdateMinerID(id, "other", "02bb", "", now), "older announcement")
+
+	info, _ := pr.GetPeer(id)
+	assert.Equal(t, "pool", info.MinerID)
+	assert.Equal(t, "02aa", info.MinerPublicKey)
+	assert.Equal(t, "ops@pool", info.MinerContact)
+	assert.Equal(t, now.Add(time.Minute), info.MinerIDAnnouncedAt)
+
+	assert.True(t, pr.UpdateMinerID(id, "other", "02bb", "", now.Add(2*time.Minute)))
+
+	info, _ = pr.GetPeer(id)
+	assert.Equal(t, "other", info.MinerID)
+	assert.Empty(t, info.MinerContact)
+}
+
+func TestPeerRegistry_UpdateProtocolVersion(t *testing.T) {
+	pr := NewPeerRegistry()
+	id := peer.ID("peer")
+
+	assert.False(t, pr.UpdateProtocolVersion(id, 1, true), "unknown peer")
+
+	pr.AddPeer(id, "")
+
+	assert.False(t, pr.UpdateProtocolVersion(id, 1, true), "compatible peer")
+
+	info, _ := pr.GetPeer(id)
+	assert.Equal(t, uint32(1), info.ProtocolVersion)
+	assert.False(t, info.IsProtocolIncompatible)
+
+	assert.True(t, pr.UpdateProtocolVersion(id, 0, false))
+	assert.False(t, pr.UpdateProtocolVersion(id, 0, false), "still incompatible")
+
+	info, _ = pr.GetPeer(id)
+	assert.Zero(t, info.ProtocolVersion)
+	assert.True(t, info.IsProtocolIncompatible)
+
+	assert.True(t, pr.UpdateProtocolVersion(id, 2, true))
+
+	info, _ = pr.GetPeer(id)
+	assert.Equal(t, uint32(2), info.ProtocolVersion)
+	assert.False(t, info.IsProtocolIncompatible)
+}
@@ -185,6 +185,24 @@ func (ps *PeerSelector) isEligible(p *PeerInfo, criteria SelectionCriteria) bool
 		return false
 	}
 
+	// Peers on probation after a ban are not trusted for sync yet
+	if p.IsOnProbation {
+		ps.logger.Debugf("[PeerSelector] Peer %s is on probation until %s", p.ID, p.ProbationUntil.Format(time.RFC3339))
+		return false
+	}
+
+	// Peers throttled for exceeding their bandwidth quota are not used for sync
+	if p.IsThrottled {
+		ps.logger.Debugf("[PeerSelector] Peer %s is throttled for exceeding its bandwidth quota", p.ID)
+		return false
+	}
+
+	// Peers without a wire protocol version in common can not be synced from
+	if p.IsProtocolIncompatible {
+		ps.logger.Debugf("[PeerSelector] Peer %s has no protocol version in common", p.ID)
+		return false
+	}
+
 	// Check DataHub URL requirement - this protects against listen-only nodes
 	if p.DataHubURL == "" {
 		ps.logger.Debugf("[PeerSelector] Peer %s has no DataHub URL (listen-only node)", p.ID)
@@ -197,6 +215,18 @@ func (ps *PeerSelector) isEligible(p *PeerInfo, criteria SelectionCriteria) bool
 		return false
 	}
 
+	// Peers whose DataHub failed too many health probes are not used for sync
+	if p.IsDataHubDown {
+		ps.logger.Debugf("[PeerSelector] Peer %s DataHub is down after %d failed health checks", p.ID, p.HealthCheckFailures)
+		return false
+	}
+
+	// Peers that did not prove they serve their DataHub URL are only used when verification is not required
+	if ps.settings != nil && ps.settings.P2P.RequireVerifiedDataHubURL && !p.IsDataHubURLVerified {
+		ps.logger.Debugf("[PeerSelector] Peer %s DataHub URL %s is not verified", p.ID, p.DataHubURL)
+		return false
+	}
+
 	// Check valid height
 	if p.Height <= 0 {
 		ps.logger.Debugf("[PeerSelector] Peer %s has invalid height %d", p.ID, p.Height)
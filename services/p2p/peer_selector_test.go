@@ -311,6 +311,24 @@ func TestPeerSelector_SelectSyncPeer_RequireResponsiveURL(t *testing.T) {
 	assert.Equal(t, peer.ID("B"), selected, "Should only select peer with responsive URL")
 }
 
+func TestPeerSelector_SelectSyncPeer_ExcludeDataHubDown(t *testing.T) {
+	logger := ulogger.New("test")
+	ps := NewPeerSelector(logger, nil)
+
+	peers := []*PeerInfo{
+		CreateTestPeerInfo(peer.ID("A"), 130, true, false, "http://hub1.com"),
+		CreateTestPeerInfo(peer.ID("B"), 120, true, false, "http://hub2.com"),
+	}
+	peers[0].IsDataHubDown = true
+	peers[0].HealthCheckFailures = 3
+
+	selected := ps.SelectSyncPeer(peers, SelectionCriteria{
+		LocalHeight: 100,
+	})
+
+	assert.Equal(t, peer.ID("B"), selected, "Should not select peer whose DataHub is down")
+}
+
 func TestPeerSelector_SelectSyncPeer_ForcedPeer(t *testing.T) {
 	logger := ulogger.New("test")
 	ps := NewPeerSelector(logger, nil)
@@ -597,3 +615,20 @@ func TestPeerSelector_DeterministicSelectionAmongEqualPeers(t *testing.T) {
 	})
 	assert.Equal(t, peer.ID("B"), selected, "Should select next peer when previous was the first")
 }
+
+func TestPeerSelector_RequireVerifiedDataHubURL(t *testing.T) {
+	tSettings := CreateTestSettings()
+	tSettings.P2P.RequireVerifiedDataHubURL = true
+
+	ps := NewPeerSelector(ulogger.TestLogger{}, tSettings)
+
+	peers := []*PeerInfo{
+		{ID: peer.ID("unverified"), Height: 100, BlockHash: "hash", DataHubURL: "http://unverified", URLResponsive: true, ReputationScore: 80, Storage: "full"},
+		{ID: peer.ID("verified"), Height: 100, BlockHash: "hash", DataHubURL: "http://verified", URLResponsive: true, ReputationScore: 80, Storage: "full", IsDataHubURLVerified: true},
+	}
+
+	assert.Equal(t, peer.ID("verified"), ps.SelectSyncPeer(peers, SelectionCriteria{LocalHeight: 50}))
+
+	tSettings.P2P.RequireVerifiedDataHubURL = false
+	assert.Equal(t, peer.ID("unverified"), ps.SelectSyncPeer(peers[:1], SelectionCriteria{LocalHeight: 50}))
+}
@@ -0,0 +1,157 @@
+package p2p
+
+import (
+	"fmt"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Versions of the wire protocol of the P2P messages. Peers advertise the range of versions they support in their
+// node_status messages and the highest version both sides support is used with the peer. A change of the wire
+// format of a message bumps protocolVersionLatest; nodes keep the previous format while it is within the range of
+// the peer.
+const (
+	// protocolVersionBaseline is the version of peers that do not advertise a protocol version, nodes released
+	// before the negotiation was added
+	protocolVersionBaseline uint32 = 1
+
+	// protocolVersionLatest is the highest version this build supports
+	protocolVersionLatest uint32 = 1
+)
+
+// protocolVersionRange is a range of supported wire protocol versions
+type protocolVersionRange struct {
+	min uint32
+	max uint32
+}
+
+// String returns the range as min-max, or the version when the range has a single version
+func (r protocolVersionRange) String() string {
+	if r.min == r.max {
+		return fmt.Sprintf("%d", r.min)
+	}
+
+	return fmt.Sprintf("%d-%d", r.min, r.max)
+}
+
+// resolveProtocolVersions returns the range of wire protocol versions of the settings, with an unset minimum
+// defaulting to protocolVersionBaseline and an unset maximum to protocolVersionLatest
+func resolveProtocolVersions(tSettings *settings.Settings) (protocolVersionRange, error) {
+	r := protocolVersionRange{min: tSettings.P2P.MinProtocolVersion, max: tSettings.P2P.MaxProtocolVersion}
+
+	if r.min == 0 {
+		r.min = protocolVersionBaseline
+	}
+
+	if r.max == 0 {
+		r.max = protocolVersionLatest
+	}
+
+	if r.max > protocolVersionLatest {
+		return r, errors.NewConfigurationError("p2p_max_protocol_version %d is higher than the latest protocol version %d", r.max, protocolVersionLatest)
+	}
+
+	if r.min > r.max {
+		return r, errors.NewConfigurationError("p2p_min_protocol_version %d is higher than p2p_max_protocol_version %d", r.min, r.max)
+	}
+
+	return r, nil
+}
+
+// remoteProtocolVersions returns the range of versions a peer advertised in a node_status message. Peers that do
+// not advertise a version support the baseline version and peers that only advertise a maximum support that
+// version alone.
+func remoteProtocolVersions(msg *NodeStatusMessage) protocolVersionRange {
+	r := protocolVersionRange{min: msg.MinProtocolVersion, max: msg.ProtocolVersion}
+
+	if r.max == 0 {
+		r.max = protocolVersionBaseline
+	}
+
+	if r.min == 0 || r.min > r.max {
+		r.min = r.max
+	}
+
+	return r
+}
+
+// negotiateProtocolVersion returns the highest version in both ranges, and false when the ranges do not overlap
+func negotiateProtocolVersion(local, remote protocolVersionRange) (uint32, bool) {
+	version := min(local.max, remote.max)
+
+	if version < max(local.min, remote.min) {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// localProtocolVersions returns the range of wire protocol versions this node supports, the full range of this
+// build when the server was created without the settings being resolved
+func (s *Server) localProtocolVersions() protocolVersionRange {
+	if s.protocolVersions.max == 0 {
+		return protocolVersionRange{min: protocolVersionBaseline, max: protocolVersionLatest}
+	}
+
+	return s.protocolVersions
+}
+
+// negotiatePeerProtocolVersion negotiates the wire protocol version with the originator of a node_status message
+// and records it in the peer registry. Returns false when the peer has no version in common with this node, its
+// messages are then ignored and it is excluded from syncing until it advertises a compatible range.
+func (s *Server) negotiatePeerProtocolVersion(msg *NodeStatusMessage) bool {
+	local := s.localProtocolVersions()
+	remote := remoteProtocolVersions(msg)
+
+	version, compatible := negotiateProtocolVersion(local, remote)
+
+	if s.peerRegistry == nil || msg.PeerID == "" {
+		return compatible
+	}
+
+	peerID, err := peer.Decode(msg.PeerID)
+	if err != nil {
+		s.logger.Debugf("[negotiatePeerProtocolVersion] failed to decode peer ID %s: %v", msg.PeerID, err)
+		return compatible
+	}
+
+	if !s.peerRegistry.UpdateProtocolVersion(peerID, version, compatible) {
+		return compatible
+	}
+
+	details := fmt.Sprintf("remote=%s local=%s", remote, local)
+
+	if compatible {
+		s.logger.Infof("[negotiatePeerProtocolVersion] peer %s is compatible again, using protocol version %d (%s)", msg.PeerID, version, details)
+		s.peerEvents.Record(msg.PeerID, PeerEventProtocolCompatible, fmt.Sprintf("version=%d %s", version, details))
+	} else {
+		s.logger.Warnf("[negotiatePeerProtocolVersion] ignoring peer %s, no protocol version in common (%s)", msg.PeerID, details)
+		s.peerEvents.Record(msg.PeerID, PeerEventProtocolIncompatible, details)
+	}
+
+	return compatible
+}
+
+// shouldSkipIncompatiblePeer checks if we should skip a message from a peer, or relayed from an originator, that has
+// no protocol version in common with this node
+func (s *Server) shouldSkipIncompatiblePeer(from string, originatorPeerID string, messageType string) bool {
+	if s.peerRegistry == nil {
+		return false
+	}
+
+	for _, id := range []string{from, originatorPeerID} {
+		peerID, err := peer.Decode(id)
+		if err != nil {
+			continue
+		}
+
+		if info, exists := s.peerRegistry.GetPeer(peerID); exists && info.IsProtocolIncompatible {
+			s.logger.Debugf("[%s] ignoring notification from protocol incompatible peer %s", messageType, id)
+			return true
+		}
+	}
+
+	return false
+}
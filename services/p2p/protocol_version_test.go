@@ -0,0 +1,189 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveProtocolVersions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		tSettings := settings.NewSettings()
+		tSettings.P2P.MinProtocolVersion = 0
+		tSettings.P2P.MaxProtocolVersion = 0
+
+		r, err := resolveProtocolVersions(tSettings)
+		require.NoError(t, err)
+		assert.Equal(t, protocolVersionRange{min: protocolVersionBaseline, max: protocolVersionLatest}, r)
+	})
+
+	t.Run("above latest", func(t *testing.T) {
+		tSettings := settings.NewSettings()
+		tSettings.P2P.MaxProtocolVersion = protocolVersionLatest + 1
+
+		_, err := resolveProtocolVersions(tSettings)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrConfiguration))
+	})
+
+	t.Run("minimum above maximum", func(t *testing.T) {
+		tSettings := settings.NewSettings()
+		tSettings.P2P.MinProtocolVersion = protocolVersionLatest + 1
+		tSettings.P2P.MaxProtocolVersion = protocolVersionLatest
+
+		_, err := resolveProtocolVersions(tSettings)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrConfiguration))
+	})
+}
+
+func TestRemoteProtocolVersions(t *testing.T) {
+	tests := map[string]struct {
+		msg      NodeStatusMessage
+		expected protocolVersionRange
+	}{
+		"not advertised":     {NodeStatusMessage{}, protocolVersionRange{min: protocolVersionBaseline, max: protocolVersionBaseline}},
+		"maximum only":       {NodeStatusMessage{ProtocolVersion: 3}, protocolVersionRange{min: 3, max: 3}},
+		"range":              {NodeStatusMessage{ProtocolVersion: 3, MinProtocolVersion: 2}, protocolVersionRange{min: 2, max: 3}},
+		"minimum above max":  {NodeStatusMessage{ProtocolVersion: 2, MinProtocolVersion: 5}, protocolVersionRange{min: 2, max: 2}},
+		"minimum only (old)": {NodeStatusMessage{MinProtocolVersion: 2}, protocolVersionRange{min: 1, max: 1}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, remoteProtocolVersions(&tt.msg))
+		})
+	}
+}
+
+func TestNegotiateProtocolVersion(t *testing.T) {
+	tests := map[string]struct {
+		local, remote protocolVersionRange
+		version       uint32
+		compatible    bool
+	}{
+		"same":             {protocolVersionRange{1, 1}, protocolVersionRange{1, 1}, 1, true},
+		"remote newer":     {protocolVersionRange{1, 2}, protocolVersionRange{1, 3}, 2, true},
+		"remote older":     {protocolVersionRange{2, 3}, protocolVersionRange{1, 2}, 2, true},
+		"remote too new":   {protocolVersionRange{1, 1}, protocolVersionRange{2, 3}, 0, false},
+		"remote too old":   {protocolVersionRange{2, 3}, protocolVersionRange{1, 1}, 0, false},
+		"overlapping edge": {protocolVersionRange{1, 3}, protocolVersionRange{3, 5}, 3, true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, compatible := negotiateProtocolVersion(tt.local, tt.remote)
+			assert.Equal(t, tt.version, version)
+			assert.Equal(t, tt.compatible, compatible)
+		})
+	}
+}
+
+func TestHandleNodeStatusTopic_ProtocolVersion(t *testing.T) {
+	ctx := context.Background()
+
+	selfPeerID, err := peer.Decode("12D3KooWJpBNhwgvoZ15EB1JwRTRpxgM9NVaqpDtWZXfTf6CpCQd")
+	require.NoError(t, err)
+
+	remotePeerIDStr := "12D3KooWBv1jXjEN3zMZ7cJzQa4LZQZKGeNp8xYZAtNAd5DEbR9n"
+	remotePeerID, err := peer.Decode(remotePeerIDStr)
+	require.NoError(t, err)
+
+	mockP2P := new(MockServerP2PClient)
+	mockP2P.On("GetID").Return(selfPeerID)
+
+	s := &Server{
+		logger:           ulogger.TestLogger{},
+		P2PClient:        mockP2P,
+		notificationCh:   make(chan *notificationMsg, 10),
+		peerRegistry:     NewPeerRegistry(),
+		protocolVersions: protocolVersionRange{min: 1, max: 1},
+	}
+
+	nodeStatus := func(height, minVersion, maxVersion uint32) []byte {
+		return fmt.Appendf(nil, `{"peer_id": %q, "best_height": %d, "best_block_hash": "hash", "min_protocol_version": %d, "protocol_version": %d}`,
+			remotePeerIDStr, height, minVersion, maxVersion)
+	}
+
+	// a peer that does not advertise a version uses the baseline version
+	s.handleNodeStatusTopic(ctx, nodeStatus(100, 0, 0), remotePeerIDStr)
+
+	info, ok := s.peerRegistry.GetPeer(remotePeerID)
+	require.True(t, ok)
+	assert.Equal(t, uint32(1), info.ProtocolVersion)
+	assert.False(t, info.IsProtocolIncompatible)
+	assert.Equal(t, int32(100), info.Height)
+
+	// a peer that dropped the versions of this node is ignored, but still forwarded to the WebSocket clients
+	s.handleNodeStatusTopic(ctx, nodeStatus(200, 2, 3), remotePeerIDStr)
+
+	info, _ = s.peerRegistry.GetPeer(remotePeerID)
+	assert.True(t, info.IsProtocolIncompatible)
+	assert.Equal(t, int32(100), info.Height)
+	assert.Len(t, s.notificationCh, 2)
+
+	// and used again once it advertises a compatible range
+	s.handleNodeStatusTopic(ctx, nodeStatus(300, 1, 3), remotePeerIDStr)
+
+	info, _ = s.peerRegistry.GetPeer(remotePeerID)
+	assert.False(t, info.IsProtocolIncompatible)
+	assert.Equal(t, uint32(1), info.ProtocolVersion)
+	assert.Equal(t, int32(300), info.Height)
+}
+
+func TestHandleBlockTopic_ProtocolIncompatiblePeer(t *testing.T) {
+	ctx := context.Background()
+
+	selfPeerID, err := peer.Decode("12D3KooWJpBNhwgvoZ15EB1JwRTRpxgM9NVaqpDtWZXfTf6CpCQd")
+	require.NoError(t, err)
+
+	remotePeerIDStr := "12D3KooWBv1jXjEN3zMZ7cJzQa4LZQZKGeNp8xYZAtNAd5DEbR9n"
+	remotePeerID, err := peer.Decode(remotePeerIDStr)
+	require.NoError(t, err)
+
+	mockP2P := new(MockServerP2PClient)
+	mockP2P.On("GetID").Return(selfPeerID)
+
+	tSettings := test.CreateBaseTestSettings(t)
+
+	s := &Server{
+		logger:           ulogger.TestLogger{},
+		settings:         tSettings,
+		P2PClient:        mockP2P,
+		notificationCh:   make(chan *notificationMsg, 10),
+		peerRegistry:     NewPeerRegistry(),
+		protocolVersions: protocolVersionRange{min: 1, max: 1},
+	}
+	s.banManager = NewPeerBanManager(ctx, nil, tSettings, s.peerRegistry)
+
+	s.peerRegistry.AddPeer(remotePeerID, "")
+	s.peerRegistry.UpdateProtocolVersion(remotePeerID, 0, false)
+
+	blockMessage := func(hash string) []byte {
+		return fmt.Appendf(nil, `{"Hash": %q, "Height": 1, "DataHubURL": "http://example.com", "PeerID": %q}`, hash, remotePeerIDStr)
+	}
+
+	// the block announcement of an incompatible peer is ignored
+	ignoredHash := "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	s.handleBlockTopic(ctx, blockMessage(ignoredHash), remotePeerIDStr)
+
+	_, stored := s.blockPeerMap.Load(ignoredHash)
+	assert.False(t, stored, "the block announcement of the peer is ignored")
+
+	// and processed again once the peer is compatible
+	s.peerRegistry.UpdateProtocolVersion(remotePeerID, 1, true)
+
+	acceptedHash := "00000000839a8e6886ab5951d76f411475428afc90947ee320161bbf18eb6048"
+	s.handleBlockTopic(ctx, blockMessage(acceptedHash), remotePeerIDStr)
+
+	_, stored = s.blockPeerMap.Load(acceptedHash)
+	assert.True(t, stored)
+}
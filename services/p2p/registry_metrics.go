@@ -0,0 +1,119 @@
+package p2p
+
+import (
+	"context"
+)
+
+// Directions of the gossip message metric
+const (
+	gossipReceived  = "received"
+	gossipPublished = "published"
+)
+
+// States of the peer count metric
+const (
+	peerStateConnected    = "connected"
+	peerStateDisconnected = "disconnected"
+	peerStateBanned       = "banned"
+	peerStateThrottled    = "throttled"
+	peerStateProbation    = "probation"
+	peerStateDataHubDown  = "datahub_down"
+)
+
+// reputationBuckets are the buckets of the reputation score metric, a score falls in the first bucket whose
+// upper bound it is below, the last bucket includes its upper bound
+var reputationBuckets = []struct {
+	label string
+	upper float64
+}{
+	{"0-20", 20},
+	{"20-40", 40},
+	{"40-60", 60},
+	{"60-80", 80},
+	{"80-100", 100},
+}
+
+// publish publishes a message on a gossip topic, counting the published messages per topic
+func (s *Server) publish(ctx context.Context, topicName string, msgBytes []byte) error {
+	initPrometheusMetrics()
+
+	if err := s.P2PClient.Publish(ctx, topicName, msgBytes); err != nil {
+		return err
+	}
+
+	prometheusP2PGossipMessages.WithLabelValues(topicName, gossipPublished).Inc()
+
+	return nil
+}
+
+// updateRegistryMetrics exports the peer counts by state, the reputation distribution of the peers and the number
+// of active bans
+func (s *Server) updateRegistryMetrics() {
+	initPrometheusMetrics()
+
+	if s.peerRegistry != nil {
+		states := map[string]int{
+			peerStateConnected:    0,
+			peerStateDisconnected: 0,
+			peerStateBanned:       0,
+			peerStateThrottled:    0,
+			peerStateProbation:    0,
+			peerStateDataHubDown:  0,
+		}
+
+		reputation := make([]int, len(reputationBuckets))
+
+		for _, p := range s.peerRegistry.GetAllPeers() {
+			if p.IsConnected {
+				states[peerStateConnected]++
+			} else {
+				states[peerStateDisconnected]++
+			}
+
+			if p.IsBanned {
+				states[peerStateBanned]++
+			}
+
+			if p.IsThrottled {
+				states[peerStateThrottled]++
+			}
+
+			if p.IsOnProbation {
+				states[peerStateProbation]++
+			}
+
+			if p.IsDataHubDown {
+				states[peerStateDataHubDown]++
+			}
+
+			reputation[reputationBucket(p.ReputationScore)]++
+		}
+
+		for state, count := range states {
+			prometheusP2PPeers.WithLabelValues(state).Set(float64(count))
+		}
+
+		for i, bucket := range reputationBuckets {
+			prometheusP2PPeerReputation.WithLabelValues(bucket.label).Set(float64(reputation[i]))
+		}
+	}
+
+	if s.banManager != nil {
+		prometheusP2PActiveBans.WithLabelValues("peer").Set(float64(len(s.banManager.ListBanned())))
+	}
+
+	if s.banList != nil {
+		prometheusP2PActiveBans.WithLabelValues("address").Set(float64(len(s.banList.ListBanned())))
+	}
+}
+
+// reputationBucket returns the index of the reputation bucket of the score
+func reputationBucket(score float64) int {
+	for i, bucket := range reputationBuckets[:len(reputationBuckets)-1] {
+		if score < bucket.upper {
+			return i
+		}
+	}
+
+	return len(reputationBuckets) - 1
+}
@@ -0,0 +1,105 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReputationBucket(t *testing.T) {
+	assert.Equal(t, 0, reputationBucket(0))
+	assert.Equal(t, 0, reputationBucket(19.9))
+	assert.Equal(t, 1, reputationBucket(20))
+	assert.Equal(t, 2, reputationBucket(50))
+	assert.Equal(t, 4, reputationBucket(80))
+	assert.Equal(t, 4, reputationBucket(100))
+}
+
+func TestUpdateRegistryMetrics(t *testing.T) {
+	peerIDs := make([]peer.ID, 0, 2)
+
+	for _, s := range []string{testPeer1, testPeer2} {
+		id, err := peer.Decode(s)
+		require.NoError(t, err)
+
+		peerIDs = append(peerIDs, id)
+	}
+
+	registry := NewPeerRegistry()
+
+	registry.AddPeer(peerIDs[0], "")
+	registry.UpdateConnectionState(peerIDs[0], true)
+	registry.UpdateThrottleStatus(peerIDs[0], true)
+	registry.UpdateReputation(peerIDs[0], 90)
+
+	registry.AddPeer(peerIDs[1], "")
+	registry.UpdateBanStatus(peerIDs[1], 100, true)
+	registry.UpdateReputation(peerIDs[1], 10)
+
+	banManager := &PeerBanManager{peerBanScores: map[string]*BanScore{
+		"banned":  {Banned: true, BanUntil: time.Now().Add(time.Hour)},
+		"expired": {Banned: true, BanUntil: time.Now().Add(-time.Hour)},
+	}}
+
+	s := &Server{
+		settings:     &settings.Settings{},
+		logger:       ulogger.TestLogger{},
+		peerRegistry: registry,
+		banManager:   banManager,
+	}
+
+	s.updateRegistryMetrics()
+
+	assert.Equal(t, float64(1), metricValue(t, prometheusP2PPeers.WithLabelValues(peerStateConnected)))
+	assert.Equal(t, float64(1), metricValue(t, prometheusP2PPeers.WithLabelValues(peerStateDisconnected)))
+	assert.Equal(t, float64(1), metricValue(t, prometheusP2PPeers.WithLabelValues(peerStateBanned)))
+	assert.Equal(t, float64(1), metricValue(t, prometheusP2PPeers.WithLabelValues(peerStateThrottled)))
+	assert.Equal(t, float64(0), metricValue(t, prometheusP2PPeers.WithLabelValues(peerStateProbation)))
+
+	assert.Equal(t, float64(1), metricValue(t, prometheusP2PPeerReputation.WithLabelValues("0-20")))
+	assert.Equal(t, float64(0), metricValue(t, prometheusP2PPeerReputation.WithLabelValues("40-60")))
+	assert.Equal(t, float64(1), metricValue(t, prometheusP2PPeerReputation.WithLabelValues("80-100")))
+
+	assert.Equal(t, float64(1), metricValue(t, prometheusP2PActiveBans.WithLabelValues("peer")))
+
+	// the gauges follow the registry
+	registry.RemovePeer(peerIDs[1])
+	s.updateRegistryMetrics()
+
+	assert.Equal(t, float64(0), metricValue(t, prometheusP2PPeers.WithLabelValues(peerStateDisconnected)))
+	assert.Equal(t, float64(0), metricValue(t, prometheusP2PPeerReputation.WithLabelValues("0-20")))
+}
+
+func TestPublishCountsGossipMessages(t *testing.T) {
+	client := &MockServerP2PClient{}
+	client.On("Publish", mock.Anything, "metrics-topic", mock.Anything).Return(nil).Once()
+	client.On("Publish", mock.Anything, "metrics-topic", mock.Anything).Return(errors.NewServiceError("publish failed")).Once()
+
+	s := &Server{P2PClient: client}
+
+	require.NoError(t, s.publish(context.Background(), "metrics-topic", []byte("msg")))
+	require.Error(t, s.publish(context.Background(), "metrics-topic", []byte("msg")))
+
+	// only the message that was published is counted
+	assert.Equal(t, float64(1), metricValue(t, prometheusP2PGossipMessages.WithLabelValues("metrics-topic", gossipPublished)))
+}
+
+func TestCatchupReportsAreCounted(t *testing.T) {
+	s := &Server{peerRegistry: NewPeerRegistry()}
+
+	before := metricValue(t, prometheusP2PCatchupReports.WithLabelValues("failure"))
+
+	_, err := s.RecordCatchupFailure(context.Background(), &p2p_api.RecordCatchupFailureRequest{PeerId: testPeer1})
+	require.NoError(t, err)
+
+	assert.Equal(t, before+1, metricValue(t, prometheusP2PCatchupReports.WithLabelValues("failure")))
+}
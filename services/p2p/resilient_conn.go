@@ -0,0 +1,138 @@
+package p2p
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// resilientConn wraps the gRPC connection to the P2P service. The connection is kept up in the background and
+// reconnected with an exponential backoff when it fails. Calls are gated on the health of the connection: a call
+// made while the connection is down waits up to callWait for it to come back, once the P2P service is unavailable
+// for longer than that calls fail straight away with a service unavailable error, instead of each call retrying
+// against a P2P service that is away.
+type resilientConn struct {
+	conn      *grpc.ClientConn
+	address   string
+	callWait  time.Duration
+	logger    ulogger.Logger
+	downSince atomic.Int64 // unix nano time the connection failed, 0 while it did not fail
+}
+
+// newResilientConn wraps conn and, until ctx is done, connects it in the background and keeps it connected
+func newResilientConn(ctx context.Context, logger ulogger.Logger, conn *grpc.ClientConn, address string, callWait time.Duration) *resilientConn {
+	c := &resilientConn{
+		conn:     conn,
+		address:  address,
+		callWait: callWait,
+		logger:   logger,
+	}
+
+	go c.watch(ctx)
+
+	return c
+}
+
+// Invoke implements grpc.ClientConnInterface, gating the unary call on the health of the connection
+func (c *resilientConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	if err := c.ready(ctx); err != nil {
+		return err
+	}
+
+	return c.conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+// NewStream implements grpc.ClientConnInterface, gating the stream on the health of the connection
+func (c *resilientConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if err := c.ready(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.conn.NewStream(ctx, desc, method, opts...)
+}
+
+// IsAvailable returns whether the connection to the P2P service is up
+func (c *resilientConn) IsAvailable() bool {
+	return c.conn.GetState() == connectivity.Ready
+}
+
+// ready waits for the connection to be up. Returns a service unavailable error when the connection is closed, did
+// not come up within callWait, or has been down for longer than callWait already.
+func (c *resilientConn) ready(ctx context.Context) error {
+	state := c.conn.GetState()
+	if state == connectivity.Ready {
+		return nil
+	}
+
+	if state == connectivity.Shutdown {
+		return errors.NewServiceUnavailableError("[P2P Client] connection to the P2P service at %s is closed", c.address)
+	}
+
+	// an idle connection is not reconnected by gRPC until asked to
+	c.conn.Connect()
+
+	wait := c.callWait
+
+	if down := c.downSince.Load(); down != 0 {
+		since := time.Unix(0, down)
+		if wait -= time.Since(since); wait <= 0 {
+			return errors.NewServiceUnavailableError("[P2P Client] P2P service at %s unavailable since %s", c.address, since.Format(time.RFC3339))
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	for state != connectivity.Ready {
+		if !c.conn.WaitForStateChange(waitCtx, state) {
+			if ctx.Err() != nil {
+				return errors.NewContextCanceledError("[P2P Client] waiting for the P2P service at %s", c.address, ctx.Err())
+			}
+
+			return errors.NewServiceUnavailableError("[P2P Client] P2P service at %s not available within %v", c.address, c.callWait)
+		}
+
+		state = c.conn.GetState()
+		if state == connectivity.Shutdown {
+			return errors.NewServiceUnavailableError("[P2P Client] connection to the P2P service at %s is closed", c.address)
+		}
+	}
+
+	c.downSince.Store(0)
+
+	return nil
+}
+
+// watch follows the state of the connection until ctx is done, recording since when it is down and reconnecting it
+// when gRPC let it go idle
+func (c *resilientConn) watch(ctx context.Context) {
+	state := c.conn.GetState()
+
+	for {
+		switch state {
+		case connectivity.Ready:
+			if down := c.downSince.Swap(0); down != 0 {
+				c.logger.Infof("[P2P Client] reconnected to the P2P service at %s after %v", c.address, time.Since(time.Unix(0, down)).Round(time.Millisecond))
+			}
+		case connectivity.TransientFailure:
+			if c.downSince.CompareAndSwap(0, time.Now().UnixNano()) {
+				c.logger.Warnf("[P2P Client] connection to the P2P service at %s failed, reconnecting", c.address)
+			}
+		case connectivity.Idle:
+			c.conn.Connect()
+		case connectivity.Shutdown:
+			return
+		}
+
+		if !c.conn.WaitForStateChange(ctx, state) {
+			return
+		}
+
+		state = c.conn.GetState()
+	}
+}
@@ -0,0 +1,101 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// resilientTestPeerService answers GetPeers with an empty peer list
+type resilientTestPeerService struct {
+	p2p_api.UnimplementedPeerServiceServer
+}
+
+func (s *resilientTestPeerService) GetPeers(context.Context, *emptypb.Empty) (*p2p_api.GetPeersResponse, error) {
+	return &p2p_api.GetPeersResponse{}, nil
+}
+
+func startResilientTestServer(t *testing.T, address string) *grpc.Server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", address)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	p2p_api.RegisterPeerServiceServer(server, &resilientTestPeerService{})
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	return server
+}
+
+func TestClient_ReconnectsToP2PService(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	server := startResilientTestServer(t, address)
+
+	tSettings := CreateTestSettings()
+	tSettings.GRPCMaxRetries = 1
+	tSettings.P2P.ClientKeepaliveTime = time.Second
+	tSettings.P2P.ClientKeepaliveTimeout = time.Second
+	tSettings.P2P.ClientReconnectBaseDelay = 10 * time.Millisecond
+	tSettings.P2P.ClientReconnectMaxDelay = 50 * time.Millisecond
+	tSettings.P2P.ClientCallWait = 500 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := NewClientWithAddress(ctx, ulogger.TestLogger{}, address, tSettings)
+	require.NoError(t, err)
+
+	// the connection is made in the background
+	require.Eventually(t, client.IsAvailable, 5*time.Second, 10*time.Millisecond)
+
+	_, err = client.GetPeers(ctx)
+	require.NoError(t, err)
+
+	server.Stop()
+
+	require.Eventually(t, func() bool { return !client.IsAvailable() }, 5*time.Second, 10*time.Millisecond)
+
+	// calls wait for the connection to come back, then fail as unavailable
+	_, err = client.GetPeers(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrServiceUnavailable), err)
+
+	// down for longer than the call wait, calls fail straight away
+	start := time.Now()
+	_, err = client.GetPeers(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrServiceUnavailable), err)
+	assert.Less(t, time.Since(start), tSettings.P2P.ClientCallWait)
+
+	server = startResilientTestServer(t, address)
+	defer server.Stop()
+
+	require.Eventually(t, client.IsAvailable, 5*time.Second, 10*time.Millisecond)
+
+	_, err = client.GetPeers(ctx)
+	require.NoError(t, err)
+}
+
+func TestClient_IsAvailableWithoutConnection(t *testing.T) {
+	client := &Client{client: &MockPeerServiceClient{}, logger: ulogger.TestLogger{}}
+
+	assert.True(t, client.IsAvailable())
+}
@@ -0,0 +1,58 @@
+package p2p
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/teranode/util/retention"
+)
+
+const (
+	retentionClassPeerEvents     = "peer_events"
+	retentionClassMessageRecords = "message_records"
+)
+
+// retentionClasses returns the artifact classes the P2P service keeps on disk, with their retention limits
+func (s *Server) retentionClasses() []retention.Class {
+	classes := make([]retention.Class, 0, 2)
+
+	if s.peerEvents != nil && s.peerEvents.File() != "" {
+		events := s.peerEvents
+
+		classes = append(classes, retention.Class{
+			Name:     retentionClassPeerEvents,
+			MaxAge:   s.settings.P2P.PeerEventLogMaxAge,
+			MaxBytes: int64(s.settings.P2P.PeerEventLogMaxBytes),
+			Files: func() ([]string, error) {
+				return events.Files(), nil
+			},
+			InUse: func(path string) bool {
+				return path == events.File()
+			},
+		})
+	}
+
+	if s.messageRecorder != nil {
+		recorder := s.messageRecorder
+		current := s.settings.P2P.MessageRecordFile
+
+		classes = append(classes, retention.Class{
+			Name:     retentionClassMessageRecords,
+			MaxAge:   s.settings.P2P.MessageRecordMaxAge,
+			MaxBytes: int64(s.settings.P2P.MessageRecordMaxBytes) * int64(max(s.settings.P2P.MessageRecordMaxFiles, 1)),
+			Files: func() ([]string, error) {
+				return recorder.Files(), nil
+			},
+			InUse: func(path string) bool {
+				return path == current
+			},
+		})
+	}
+
+	return classes
+}
+
+// startRetentionCleanup starts the periodic removal of the peer event log and message recording files that exceed
+// their retention period or disk space cap
+func (s *Server) startRetentionCleanup(ctx context.Context) {
+	retention.NewCleaner(s.logger, s.settings.P2P.RetentionInterval, s.retentionClasses()...).Start(ctx)
+}
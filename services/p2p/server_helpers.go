@@ -16,14 +16,14 @@ import (
 	"github.com/bsv-blockchain/teranode/services/blockchain"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
 	"github.com/bsv-blockchain/teranode/services/p2p/p2p_api"
+	"github.com/bsv-blockchain/teranode/util/blocktrace"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
 	"github.com/libp2p/go-libp2p/core/peer"
 	ma "github.com/multiformats/go-multiaddr"
-	"google.golang.org/protobuf/proto"
 )
 
-func (s *Server) handleBlockTopic(_ context.Context, m []byte, from string) {
+func (s *Server) handleBlockTopic(ctx context.Context, m []byte, from string) {
 	var (
 		blockMessage BlockMessage
 		hash         *chainhash.Hash
@@ -76,6 +76,16 @@ func (s *Server) handleBlockTopic(_ context.Context, m []byte, from string) {
 		return
 	}
 
+	// Skip notifications from peers throttled for exceeding their bandwidth quota
+	if s.shouldSkipThrottledPeer(from, "handleBlockTopic") {
+		return
+	}
+
+	// Skip notifications from peers with no protocol version in common
+	if s.shouldSkipIncompatiblePeer(from, blockMessage.PeerID, "handleBlockTopic") {
+		return
+	}
+
 	// Skip notifications from unhealthy peers
 	if s.shouldSkipUnhealthyPeer(blockMessage.PeerID, "handleBlockTopic") {
 		return
@@ -88,6 +98,8 @@ func (s *Server) handleBlockTopic(_ context.Context, m []byte, from string) {
 		return
 	}
 
+	traceID := blocktrace.Announce(ctx, s.logger, hash)
+
 	// Store the peer ID that sent this block
 	s.storePeerMapEntry(&s.blockPeerMap, blockMessage.Hash, from, now)
 	s.logger.Debugf("[handleBlockTopic] storing peer %s for block %s", from, blockMessage.Hash)
@@ -118,14 +130,15 @@ func (s *Server) handleBlockTopic(_ context.Context, m []byte, from string) {
 	// send block to kafka, if configured
 	if s.blocksKafkaProducerClient != nil {
 		msg := &kafkamessage.KafkaBlockTopicMessage{
-			Hash:   hash.String(),
-			URL:    blockMessage.DataHubURL,
-			PeerId: blockMessage.PeerID,
+			Hash:    hash.String(),
+			URL:     blockMessage.DataHubURL,
+			PeerId:  blockMessage.PeerID,
+			TraceId: traceID,
 		}
 
 		s.logger.Debugf("[handleBlockTopic] Sending block %s to Kafka", hash.String())
 
-		value, err := proto.Marshal(msg)
+		value, err := kafkamessage.Encode(msg)
 		if err != nil {
 			s.logger.Errorf("[handleBlockTopic] error marshaling KafkaBlockTopicMessage: %v", err)
 			return
@@ -198,6 +211,16 @@ func (s *Server) handleSubtreeTopic(_ context.Context, m []byte, from string) {
 		return
 	}
 
+	// Skip notifications from peers throttled for exceeding their bandwidth quota
+	if s.shouldSkipThrottledPeer(from, "handleSubtreeTopic") {
+		return
+	}
+
+	// Skip notifications from peers with no protocol version in common
+	if s.shouldSkipIncompatiblePeer(from, subtreeMessage.PeerID, "handleSubtreeTopic") {
+		return
+	}
+
 	// Skip notifications from unhealthy peers
 	if s.shouldSkipUnhealthyPeer(from, "handleSubtreeTopic") {
 		return
@@ -220,7 +243,7 @@ func (s *Server) handleSubtreeTopic(_ context.Context, m []byte, from string) {
 			PeerId: subtreeMessage.PeerID,
 		}
 
-		value, err := proto.Marshal(msg)
+		value, err := kafkamessage.Encode(msg)
 		if err != nil {
 			s.logger.Errorf("[handleSubtreeTopic] error marshaling KafkaSubtreeTopicMessage: %v", err)
 			return
@@ -319,6 +342,16 @@ func (s *Server) handleRejectedTxTopic(_ context.Context, m []byte, from string)
 		return
 	}
 
+	// Skip notifications from peers throttled for exceeding their bandwidth quota
+	if s.shouldSkipThrottledPeer(from, "handleRejectedTxTopic") {
+		return
+	}
+
+	// Skip notifications from peers with no protocol version in common
+	if s.shouldSkipIncompatiblePeer(from, rejectedTxMessage.PeerID, "handleRejectedTxTopic") {
+		return
+	}
+
 	// Skip notifications from unhealthy peers
 	if s.shouldSkipUnhealthyPeer(from, "handleRejectedTxTopic") {
 		return
@@ -518,6 +551,10 @@ func (s *Server) addPeer(peerID peer.ID, clientName string) {
 // addConnectedPeer adds a peer and marks it as directly connected
 func (s *Server) addConnectedPeer(peerID peer.ID, clientName string) {
 	if s.peerRegistry != nil {
+		if info, exists := s.peerRegistry.GetPeer(peerID); !exists || !info.IsConnected {
+			s.peerEvents.Record(peerID.String(), PeerEventConnected, clientName)
+		}
+
 		s.peerRegistry.AddPeer(peerID, clientName)
 		s.peerRegistry.UpdateConnectionState(peerID, true)
 	}
@@ -525,10 +562,18 @@ func (s *Server) addConnectedPeer(peerID peer.ID, clientName string) {
 
 func (s *Server) removePeer(peerID peer.ID) {
 	if s.peerRegistry != nil {
+		if _, exists := s.peerRegistry.GetPeer(peerID); exists {
+			s.peerEvents.Record(peerID.String(), PeerEventDisconnected, "")
+		}
+
 		// Mark as disconnected before removing
 		s.peerRegistry.UpdateConnectionState(peerID, false)
 		s.peerRegistry.RemovePeer(peerID)
 	}
+	if prometheusP2PPeerBytes != nil {
+		prometheusP2PPeerBytes.DeleteLabelValues(peerID.String(), BandwidthDirectionDownload)
+		prometheusP2PPeerBytes.DeleteLabelValues(peerID.String(), BandwidthDirectionUpload)
+	}
 	if s.syncCoordinator != nil {
 		s.syncCoordinator.HandlePeerDisconnected(peerID)
 	}
@@ -562,6 +607,13 @@ func (s *Server) updateDataHubURL(peerID peer.ID, url string) {
 	}
 }
 
+// updateDataHubMirrorURLs updates the DataHub mirrors of a peer in the registry
+func (s *Server) updateDataHubMirrorURLs(peerID peer.ID, urls []string) {
+	if s.peerRegistry != nil {
+		s.peerRegistry.UpdateDataHubMirrorURLs(peerID, urls)
+	}
+}
+
 // updateStorage updates peer storage mode in the registry
 func (s *Server) updateStorage(peerID peer.ID, mode string) {
 	if s.peerRegistry != nil && mode != "" {
@@ -573,7 +625,7 @@ func (s *Server) processInvalidBlockMessage(message *kafka.KafkaMessage) error {
 	ctx := context.Background()
 
 	var invalidBlockMsg kafkamessage.KafkaInvalidBlockTopicMessage
-	if err := proto.Unmarshal(message.Value, &invalidBlockMsg); err != nil {
+	if err := kafkamessage.Decode(message.Value, &invalidBlockMsg); err != nil {
 		s.logger.Errorf("failed to unmarshal invalid block message: %v", err)
 		return err
 	}
@@ -893,6 +945,7 @@ func (s *Server) startPeerMapCleanup(ctx context.Context) {
 				return
 			case <-s.peerMapCleanupTicker.C:
 				s.cleanupPeerMaps()
+				s.releaseThrottledPeers()
 			}
 		}
 	}()
@@ -900,41 +953,60 @@ func (s *Server) startPeerMapCleanup(ctx context.Context) {
 	s.logger.Infof("[startPeerMapCleanup] started peer map cleanup with interval %v", cleanupInterval)
 }
 
-// startPeerRegistryCacheSave starts periodic saving of peer registry cache
-func (s *Server) startPeerRegistryCacheSave(ctx context.Context) {
-	// Save every 5 minutes
-	saveInterval := 5 * time.Minute
+// Intervals of the periodic saving of the peer registry cache
+const (
+	peerRegistryCacheSaveInterval  = 5 * time.Minute
+	peerRegistryCacheRetryInterval = 30 * time.Second // after a failed save, e.g. when the disk is full
+)
 
-	s.registryCacheSaveTicker = time.NewTicker(saveInterval)
+// savePeerRegistryCaches saves the peer registry cache and the peer contribution ledger, and returns whether both
+// were saved. Failures are logged, the in-memory data is kept.
+func (s *Server) savePeerRegistryCaches() bool {
+	saved := true
 
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				// Save one final time before shutdown
-				if s.peerRegistry != nil {
-					if err := s.peerRegistry.SavePeerRegistryCache(s.settings.P2P.PeerCacheDir); err != nil {
-						s.logger.Errorf("[startPeerRegistryCacheSave] failed to save peer registry cache on shutdown: %v", err)
-					} else {
-						s.logger.Infof("[startPeerRegistryCacheSave] saved peer registry cache on shutdown")
-					}
-				}
-				s.logger.Infof("[startPeerRegistryCacheSave] stopping peer registry cache save")
-				return
-			case <-s.registryCacheSaveTicker.C:
-				if s.peerRegistry != nil {
-					if err := s.peerRegistry.SavePeerRegistryCache(s.settings.P2P.PeerCacheDir); err != nil {
-						s.logger.Errorf("[startPeerRegistryCacheSave] failed to save peer registry cache: %v", err)
-					} else {
-						peerCount := s.peerRegistry.PeerCount()
-						s.logger.Debugf("[startPeerRegistryCacheSave] saved peer registry cache with %d peers", peerCount)
-					}
-				}
-			}
+	if s.peerRegistry != nil {
+		if err := s.peerRegistry.SavePeerRegistryCache(s.settings.P2P.PeerCacheDir); err != nil {
+			s.logger.Errorf("[savePeerRegistryCaches] failed to save peer registry cache: %v", err)
+			saved = false
+		} else {
+			peerCount := s.peerRegistry.PeerCount()
+			s.logger.Debugf("[savePeerRegistryCaches] saved peer registry cache with %d peers", peerCount)
 		}
-	}()
+	}
+
+	if err := s.peerContributions.Save(s.settings.P2P.PeerCacheDir); err != nil {
+		s.logger.Errorf("[savePeerRegistryCaches] failed to save peer contribution ledger: %v", err)
+		saved = false
+	}
+
+	return saved
+}
+
+// sweepExpiredBans removes the expired bans and records a ban expired event for each of them. Returns the error of
+// removing the expired bans from the ban list, the bans of the ban manager are removed regardless.
+func (s *Server) sweepExpiredBans(ctx context.Context) error {
+	var sweepErr error
+
+	if s.banList != nil {
+		expired, err := s.banList.SweepExpired(ctx)
+		if err != nil {
+			sweepErr = errors.NewProcessingError("failed to remove expired bans", err)
+		}
+
+		for _, key := range expired {
+			s.logger.Infof("[sweepExpiredBans] ban of %s expired", key)
+			s.peerEvents.Record(key, PeerEventBanExpired, "")
+		}
+	}
+
+	if s.banManager != nil {
+		for _, peerID := range s.banManager.SweepExpiredBans() {
+			s.logger.Infof("[sweepExpiredBans] ban of peer %s expired", peerID)
+			s.peerEvents.Record(peerID, PeerEventBanExpired, "")
+		}
+	}
 
-	s.logger.Infof("[startPeerRegistryCacheSave] started peer registry cache save with interval %v", saveInterval)
+	return sweepErr
 }
 
 func (s *Server) listenForBanEvents(ctx context.Context) {
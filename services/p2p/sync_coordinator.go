@@ -15,7 +15,6 @@ import (
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
 	"github.com/libp2p/go-libp2p/core/peer"
-	"google.golang.org/protobuf/proto"
 )
 
 // SyncCoordinator orchestrates sync operations
@@ -600,6 +599,19 @@ func (sc *SyncCoordinator) UpdateBanStatus(peerID peer.ID) {
 	}
 }
 
+// HandleDataHubDown switches to another sync peer when the DataHub of the current sync peer is down
+func (sc *SyncCoordinator) HandleDataHubDown(peerID peer.ID) {
+	sc.mu.RLock()
+	isSyncPeer := sc.currentSyncPeer == peerID
+	sc.mu.RUnlock()
+
+	if isSyncPeer {
+		sc.logger.Warnf("[SyncCoordinator] DataHub of sync peer %s is down", peerID)
+		sc.ClearSyncPeer()
+		_ = sc.TriggerSync()
+	}
+}
+
 // checkURLResponsiveness checks if a peer's DataHub URL is responsive with a short timeout
 func (sc *SyncCoordinator) checkURLResponsiveness(url string) bool {
 	if url == "" {
@@ -778,7 +790,7 @@ func (sc *SyncCoordinator) sendSyncTriggerToKafka(syncPeer peer.ID, bestHash str
 		PeerId: syncPeer.String(),
 	}
 
-	value, err := proto.Marshal(msg)
+	value, err := kafkamessage.Encode(msg)
 	if err != nil {
 		sc.logger.Errorf("[sendSyncTriggerToKafka] error marshaling sync peer's best block: %v", err)
 		return
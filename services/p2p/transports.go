@@ -0,0 +1,48 @@
+package p2p
+
+import "strings"
+
+// Transports of the connections to peers, as tracked in the peer registry. The message bus only listens on TCP and
+// takes no transport options, QUIC connections are only made when dialing peers that advertise QUIC addresses.
+const (
+	transportTCP   = "tcp"
+	transportQUIC  = "quic"
+	transportRelay = "relay"
+)
+
+// peerTransports are the transports counted in the teranode_p2p_peer_transports metric
+var peerTransports = []string{transportTCP, transportQUIC, transportRelay}
+
+// addrTransport returns the transport of a multiaddr: relay for circuit relay addresses, quic for QUIC addresses and
+// tcp for all other addresses, e.g. TCP or DNS addresses
+func addrTransport(addr string) string {
+	switch {
+	case strings.Contains(addr, "/p2p-circuit"):
+		return transportRelay
+	case strings.Contains(addr, "/quic"):
+		return transportQUIC
+	default:
+		return transportTCP
+	}
+}
+
+// connectionTransport returns the transport of the live connections of a peer, preferring direct connections over
+// relayed connections and QUIC over TCP, or an empty string when the peer has no connection addresses
+func connectionTransport(addrs []string) string {
+	transport := ""
+
+	for _, addr := range addrs {
+		switch addrTransport(addr) {
+		case transportQUIC:
+			return transportQUIC
+		case transportTCP:
+			transport = transportTCP
+		case transportRelay:
+			if transport == "" {
+				transport = transportRelay
+			}
+		}
+	}
+
+	return transport
+}
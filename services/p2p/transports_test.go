@@ -0,0 +1,20 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionTransport(t *testing.T) {
+	tcpAddr := "/ip4/198.51.100.1/tcp/9905"
+	quicAddr := "/ip4/198.51.100.1/udp/9905/quic-v1"
+	relayAddr := "/ip4/203.0.113.1/tcp/9905/p2p/12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ/p2p-circuit"
+
+	assert.Equal(t, "", connectionTransport(nil))
+	assert.Equal(t, transportTCP, connectionTransport([]string{tcpAddr}))
+	assert.Equal(t, transportQUIC, connectionTransport([]string{"/ip6/2001:db8::1/udp/9905/quic"}))
+	assert.Equal(t, transportRelay, connectionTransport([]string{relayAddr}))
+	assert.Equal(t, transportTCP, connectionTransport([]string{relayAddr, tcpAddr}))
+	assert.Equal(t, transportQUIC, connectionTransport([]string{tcpAddr, relayAddr, quicAddr}))
+}
@@ -29,9 +29,11 @@ package propagation
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/bsv-blockchain/go-batcher"
@@ -517,6 +519,234 @@ func (c *Client) ProcessTransactionBatch(ctx context.Context, batch []*batchItem
 	return c.handleBatchError(batch, unwrappedErr, "[ProcessTransactionBatch] Failed to process transaction batch")
 }
 
+// maxBusyRetries is the number of times ProcessTransactionStream sends the transactions reported busy by the
+// propagation service again, before giving up on them
+const maxBusyRetries = 10
+
+// ProcessTransactionStream sends many transactions to the propagation service in a single call and returns the
+// result of every transaction. The transactions are sent on a bidirectional gRPC stream, or to the /txs/batch
+// endpoint when the client is configured to always use HTTP, and are processed concurrently by the service.
+//
+// The backpressure signaled by the service is honored: the transactions reported busy because too many transactions
+// were in flight are sent again after the retry delay of the service, up to maxBusyRetries times. Transactions that
+// are still busy afterwards keep the busy error of the service, which is a service unavailable error.
+//
+// Parameters:
+//   - ctx: Context for transaction processing, supports cancellation and timeouts
+//   - txs: Bitcoin transactions to process
+//
+// Returns:
+//   - []error: The result of each transaction, in the order of txs, nil when the transaction was accepted
+//   - error: Error if the transactions could not be sent to the service
+func (c *Client) ProcessTransactionStream(ctx context.Context, txs []*bt.Tx) ([]error, error) {
+	ctx, _, endSpan := tracing.Tracer("PropagationClient").Start(ctx, "ProcessTransactionStream", tracing.WithTag("txs", strconv.Itoa(len(txs))))
+	defer endSpan()
+
+	results := make([]error, len(txs))
+
+	pending := make([]int, len(txs))
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for attempt := 0; len(pending) > 0; attempt++ {
+		var (
+			busy       []int
+			retryAfter time.Duration
+			err        error
+		)
+
+		if c.settings.Propagation.AlwaysUseHTTP {
+			busy, retryAfter, err = c.streamTransactionsViaHTTP(ctx, txs, pending, results)
+		} else {
+			busy, retryAfter, err = c.streamTransactions(ctx, txs, pending, results)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(busy) == 0 || attempt >= maxBusyRetries {
+			break
+		}
+
+		c.logger.Debugf("[ProcessTransactionStream] %d transactions busy, retrying after %s", len(busy), retryAfter)
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.NewContextCanceledError("[ProcessTransactionStream] context done waiting to retry %d busy transactions", len(busy), ctx.Err())
+		case <-time.After(retryAfter):
+		}
+
+		pending = busy
+	}
+
+	return results, nil
+}
+
+// streamTransactions sends the pending transactions on a gRPC transaction stream and stores the acknowledgements in
+// results.
+//
+// Returns:
+//   - []int: The pending transactions reported busy by the service
+//   - time.Duration: The longest retry delay of the busy transactions
+//   - error: Error if the stream failed
+func (c *Client) streamTransactions(ctx context.Context, txs []*bt.Tx, pending []int, results []error) ([]int, time.Duration, error) {
+	// cancelling the stream unblocks the sender when the stream fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.ProcessTransactionStream(ctx)
+	if err != nil {
+		return nil, 0, errors.NewServiceError("[ProcessTransactionStream] failed to open transaction stream", errors.UnwrapGRPC(err))
+	}
+
+	sendErrCh := make(chan error, 1)
+
+	go func() {
+		prop := otel.GetTextMapPropagator()
+
+		for _, idx := range pending {
+			traceContext := make(map[string]string)
+			prop.Inject(ctx, propagation.MapCarrier(traceContext))
+
+			if err := stream.Send(&propagation_api.ProcessTransactionStreamRequest{
+				Id:           uint64(idx), // nolint:gosec
+				Tx:           txs[idx].SerializeBytes(),
+				TraceContext: traceContext,
+			}); err != nil {
+				sendErrCh <- err
+				return
+			}
+		}
+
+		sendErrCh <- stream.CloseSend()
+	}()
+
+	var (
+		busy       []int
+		retryAfter time.Duration
+	)
+
+	for range pending {
+		ack, err := stream.Recv()
+		if err != nil {
+			return nil, 0, errors.NewServiceError("[ProcessTransactionStream] failed to receive transaction acknowledgement", errors.UnwrapGRPC(err))
+		}
+
+		if ack.Id >= uint64(len(txs)) {
+			return nil, 0, errors.NewServiceError("[ProcessTransactionStream] received acknowledgement for unknown transaction %d", ack.Id)
+		}
+
+		idx := int(ack.Id) // nolint:gosec
+
+		if ack.Error.IsNil() { // don't do err != nil, proto can't return nil TError
+			results[idx] = nil
+		} else {
+			results[idx] = ack.Error
+		}
+
+		if ack.Busy {
+			busy = append(busy, idx)
+			retryAfter = max(retryAfter, time.Duration(ack.RetryAfterMs)*time.Millisecond)
+		}
+	}
+
+	if err = <-sendErrCh; err != nil {
+		return nil, 0, errors.NewServiceError("[ProcessTransactionStream] failed to send transactions", errors.UnwrapGRPC(err))
+	}
+
+	return busy, retryAfter, nil
+}
+
+// streamTransactionsViaHTTP sends the pending transactions to the /txs/batch endpoint and stores the results in
+// results. A 429 response reports all pending transactions busy.
+//
+// Returns:
+//   - []int: The pending transactions reported busy by the service
+//   - time.Duration: The longest retry delay of the busy transactions
+//   - error: Error if the request failed
+func (c *Client) streamTransactionsViaHTTP(ctx context.Context, txs []*bt.Tx, pending []int, results []error) ([]int, time.Duration, error) {
+	client := &http.Client{
+		Timeout: 60 * time.Second, // Longer timeout for batch
+	}
+
+	var body bytes.Buffer
+	for _, idx := range pending {
+		body.Write(txs[idx].SerializeBytes())
+	}
+
+	endpoint, err := url.Parse("/txs/batch")
+	if err != nil {
+		return nil, 0, errors.NewServiceError("[ProcessTransactionStream] failed to parse endpoint /txs/batch", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.propagationHTTPAddr.ResolveReference(endpoint).String(), &body)
+	if err != nil {
+		return nil, 0, errors.NewServiceError("[ProcessTransactionStream] failed to create HTTP request", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, errors.NewServiceError("[ProcessTransactionStream] failed to send HTTP request", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := time.Second
+
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+
+		for _, idx := range pending {
+			results[idx] = errors.NewServiceUnavailableError("[ProcessTransactionStream] propagation service busy")
+		}
+
+		return pending, retryAfter, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		httpErr := errors.NewServiceError("HTTP status %d: %s", resp.StatusCode, string(respBody))
+
+		return nil, 0, errors.NewServiceError("[ProcessTransactionStream] propagation /txs/batch endpoint returned error", httpErr)
+	}
+
+	var (
+		busy       []int
+		retryAfter time.Duration
+		decoder    = json.NewDecoder(resp.Body)
+	)
+
+	for range pending {
+		var result batchTxResponse
+		if err = decoder.Decode(&result); err != nil {
+			return nil, 0, errors.NewServiceError("[ProcessTransactionStream] failed to read transaction result", err)
+		}
+
+		// the index of the result is the position of the transaction in the request
+		if result.Index < 0 || result.Index >= len(pending) {
+			return nil, 0, errors.NewServiceError("[ProcessTransactionStream] received result for unknown transaction %d", result.Index)
+		}
+
+		idx := pending[result.Index]
+
+		switch result.Status {
+		case batchTxAccepted:
+			results[idx] = nil
+		case batchTxBusy:
+			results[idx] = errors.NewServiceUnavailableError("[ProcessTransactionStream] %s", result.Error)
+			busy = append(busy, idx)
+			retryAfter = max(retryAfter, time.Duration(result.RetryAfterMs)*time.Millisecond)
+		default:
+			results[idx] = errors.NewTxError("[ProcessTransactionStream][%s] %s", result.TxID, result.Error)
+		}
+	}
+
+	return busy, retryAfter, nil
+}
+
 // getClientConn establishes a gRPC connection to the propagation service.
 // This method handles the critical task of establishing a reliable connection
 // to the propagation service with proper configuration:
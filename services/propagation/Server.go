@@ -41,6 +41,7 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/blob"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
 	"github.com/bsv-blockchain/teranode/util/health"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
@@ -54,7 +55,6 @@ import (
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -121,6 +121,7 @@ type PropagationServer struct {
 	validatorKafkaProducerClient kafka.KafkaAsyncProducerI
 	httpServer                   *echo.Echo
 	validatorHTTPAddr            *url.URL
+	ingest                       *ingestLimiter // bounds the transactions in flight over all endpoints
 }
 
 // New creates a new PropagationServer instance with the specified dependencies.
@@ -148,6 +149,7 @@ func New(logger ulogger.Logger, tSettings *settings.Settings, txStore blob.Store
 		blockchainClient:             blockchainClient,
 		validatorKafkaProducerClient: validatorKafkaProducerClient,
 		validatorHTTPAddr:            tSettings.Validator.HTTPAddress,
+		ingest:                       newIngestLimiter(tSettings.Propagation.MaxInFlightTransactions, tSettings.Propagation.BackpressureRetryAfter),
 	}
 }
 
@@ -258,6 +260,7 @@ func (ps *PropagationServer) HealthGRPC(ctx context.Context, _ *propagation_api.
 		Ok:        status == http.StatusOK,
 		Details:   details,
 		Timestamp: timestamppb.Now(),
+		BuildInfo: buildinfo.Get(ps.settings).JSON(),
 	}, errors.WrapGRPC(err)
 }
 
@@ -621,6 +624,8 @@ func (ps *PropagationServer) handleMultipleTx(_ context.Context) echo.HandlerFun
 // 3. Configures transaction processing endpoints:
 //   - POST /tx for single transaction processing
 //   - POST /txs for batch transaction processing
+//   - POST /txs/batch for batch transaction processing with per transaction results
+//   - POST /package for validating dependent transactions as a package
 //   - GET /health for service health checks
 //
 // 4. Sets up listener configuration with appropriate address binding
@@ -653,6 +658,8 @@ func (ps *PropagationServer) startHTTPServer(ctx context.Context, httpAddresses
 	// Register route handlers
 	ps.httpServer.POST("/tx", ps.handleSingleTx(ctx))
 	ps.httpServer.POST("/txs", ps.handleMultipleTx(ctx))
+	ps.httpServer.POST("/txs/batch", ps.handleBatchTx(ctx))
+	ps.httpServer.POST("/package", ps.handlePackage(ctx))
 
 	// add a health endpoint that simply returns "OK"
 	ps.httpServer.GET("/health", func(c echo.Context) error {
@@ -767,6 +774,7 @@ func (ps *PropagationServer) ProcessTransaction(ctx context.Context, req *propag
 // 3. Processes each transaction independently while preserving the original order in results
 // 4. Aggregates errors for each transaction while allowing the batch to complete even with partial failures
 // 5. Collects and maps individual transaction errors to their respective positions in the response
+// 6. Rejects the transactions above the in flight limit of the service with a busy error, without processing them
 //
 // This concurrent processing approach significantly improves throughput for batch submission
 // while maintaining proper error isolation between transactions.
@@ -799,7 +807,16 @@ func (ps *PropagationServer) ProcessTransactionBatch(ctx context.Context, req *p
 		idx := idx
 		tx := item.Tx
 
+		if !ps.ingest.tryAcquire() {
+			response.Errors[idx] = errors.Wrap(ps.ingest.busyError())
+			response.RetryAfterMs = ps.ingest.retryAfterMs()
+
+			continue
+		}
+
 		g.Go(func() error {
+			defer ps.ingest.release()
+
 			var txCtx context.Context
 
 			if len(item.TraceContext) > 0 {
@@ -833,6 +850,8 @@ func (ps *PropagationServer) ProcessTransactionBatch(ctx context.Context, req *p
 		return nil, errors.WrapGRPC(err)
 	}
 
+	response.InFlight, response.MaxInFlight = ps.ingest.stats()
+
 	return response, nil
 }
 
@@ -1064,7 +1083,7 @@ func (ps *PropagationServer) validateTransactionViaKafka(btTx *bt.Tx) error {
 		},
 	}
 
-	value, err := proto.Marshal(msg)
+	value, err := kafkamessage.Encode(msg)
 	if err != nil {
 		return errors.NewProcessingError("[ProcessTransaction][%s] error marshaling KafkaTxValidationTopicMessage", btTx.TxID(), err, err)
 	}
@@ -0,0 +1,312 @@
+package propagation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/propagation/propagation_api"
+	"github.com/bsv-blockchain/teranode/util/tracing"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Statuses of the transactions returned by the /txs/batch endpoint
+const (
+	batchTxAccepted = "accepted"
+	batchTxRejected = "rejected"
+	batchTxBusy     = "busy"
+)
+
+// ingestLimiter bounds the number of transactions processed by the propagation service at the same time, over all
+// endpoints. Transactions received when the limit is reached are not processed but reported as busy, with the delay
+// after which they can be sent again, so high-volume senders slow down instead of queueing up in the service.
+//
+// A nil limiter does not limit the transactions.
+type ingestLimiter struct {
+	inFlight   atomic.Int64
+	max        int64
+	retryAfter time.Duration
+}
+
+// newIngestLimiter creates a limiter for max transactions in flight, 0 or less disables the limit
+func newIngestLimiter(max int, retryAfter time.Duration) *ingestLimiter {
+	return &ingestLimiter{
+		max:        int64(max),
+		retryAfter: retryAfter,
+	}
+}
+
+// tryAcquire reserves a slot for a transaction, returns false when too many transactions are in flight.
+// Every successful tryAcquire must be followed by a release once the transaction has been processed.
+func (l *ingestLimiter) tryAcquire() bool {
+	if l == nil {
+		return true
+	}
+
+	if l.inFlight.Add(1) > l.max && l.max > 0 {
+		l.inFlight.Add(-1)
+		return false
+	}
+
+	return true
+}
+
+// release frees the slot of a processed transaction
+func (l *ingestLimiter) release() {
+	if l != nil {
+		l.inFlight.Add(-1)
+	}
+}
+
+// busy returns whether no transaction can be accepted at the moment
+func (l *ingestLimiter) busy() bool {
+	return l != nil && l.max > 0 && l.inFlight.Load() >= l.max
+}
+
+// stats returns the number of transactions in flight and the limit, for the acknowledgements sent to the senders
+func (l *ingestLimiter) stats() (inFlight uint32, maxInFlight uint32) {
+	if l == nil {
+		return 0, 0
+	}
+
+	return clampUint32(l.inFlight.Load()), clampUint32(l.max)
+}
+
+// retryAfterMs returns the delay after which busy transactions can be sent again, in milliseconds
+func (l *ingestLimiter) retryAfterMs() uint32 {
+	if l == nil {
+		return 0
+	}
+
+	return clampUint32(l.retryAfter.Milliseconds())
+}
+
+// busyError returns the error of a transaction rejected because too many transactions are in flight
+func (l *ingestLimiter) busyError() *errors.Error {
+	prometheusBusyTransactions.Inc()
+
+	return errors.NewServiceUnavailableError("[Propagation] too many transactions in flight, retry after %dms", l.retryAfterMs())
+}
+
+func clampUint32(v int64) uint32 {
+	if v <= 0 {
+		return 0
+	}
+
+	if v > math.MaxUint32 {
+		return math.MaxUint32
+	}
+
+	return uint32(v)
+}
+
+// ProcessTransactionStream processes the transactions received on a bidirectional stream. Every transaction is
+// acknowledged on the stream as soon as it has been processed, in the order the transactions complete, with the id
+// chosen by the sender. The transactions of the stream are processed concurrently, bounded by the in flight limit of
+// the service; the transactions received above the limit are acknowledged straight away as busy, with the delay
+// after which they can be sent again.
+//
+// The stream ends when the sender closes its side of the stream, once all received transactions are acknowledged.
+//
+// Parameters:
+//   - stream: The bidirectional transaction stream
+//
+// Returns:
+//   - error: Error if the stream failed, nil when the sender closed the stream
+func (ps *PropagationServer) ProcessTransactionStream(stream propagation_api.PropagationAPI_ProcessTransactionStreamServer) error {
+	ctx, _, endSpan := tracing.Tracer("propagation").Start(stream.Context(), "ProcessTransactionStream",
+		tracing.WithParentStat(ps.stats),
+	)
+	defer endSpan()
+
+	var (
+		sendMu sync.Mutex
+		wg     sync.WaitGroup
+	)
+
+	// gRPC streams do not support concurrent sends
+	send := func(ack *propagation_api.ProcessTransactionStreamResponse) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+
+		ack.InFlight, ack.MaxInFlight = ps.ingest.stats()
+
+		return stream.Send(ack)
+	}
+
+	// all acknowledgements must have been sent before returning, the stream can no longer be used afterwards
+	defer wg.Wait()
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		if !ps.ingest.tryAcquire() {
+			if err = send(&propagation_api.ProcessTransactionStreamResponse{
+				Id:           req.Id,
+				Error:        errors.Wrap(ps.ingest.busyError()),
+				Busy:         true,
+				RetryAfterMs: ps.ingest.retryAfterMs(),
+			}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			txCtx := ctx
+			if len(req.TraceContext) > 0 {
+				txCtx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(req.TraceContext))
+			}
+
+			err := ps.processTransaction(txCtx, &propagation_api.ProcessTransactionRequest{Tx: req.Tx})
+
+			ps.ingest.release()
+
+			ack := &propagation_api.ProcessTransactionStreamResponse{
+				Id: req.Id,
+			}
+
+			if err != nil {
+				ps.logger.Debugf("[ProcessTransactionStream] failed to process transaction %d: %v", req.Id, err)
+				ack.Error = errors.Wrap(err)
+			}
+
+			if err = send(ack); err != nil {
+				ps.logger.Debugf("[ProcessTransactionStream] failed to acknowledge transaction %d: %v", req.Id, err)
+			}
+		}()
+	}
+}
+
+// batchTxResponse is the result of a single transaction returned by the /txs/batch endpoint
+type batchTxResponse struct {
+	Index        int    `json:"index"`
+	TxID         string `json:"txid"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+	RetryAfterMs uint32 `json:"retry_after_ms,omitempty"`
+}
+
+// handleBatchTx handles a batch of transactions on the /txs/batch endpoint.
+// The request body holds the transactions back to back, like the /txs endpoint. Unlike the /txs endpoint the result
+// of every transaction is returned, streamed as newline delimited JSON as soon as the transaction has been
+// processed, in the order the transactions complete. The index of a result is the position of the transaction in
+// the request.
+//
+// Backpressure is signaled to the sender in two ways: the whole request is rejected with status 429 and a
+// Retry-After header when no transaction can be accepted, and the transactions received above the in flight limit
+// of the service get the busy status, with the delay after which they can be sent again.
+//
+// Parameters:
+//   - _: Unused context parameter (context is obtained from the HTTP request)
+//
+// Returns:
+//   - echo.HandlerFunc: HTTP handler function for the Echo web framework
+func (ps *PropagationServer) handleBatchTx(_ context.Context) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, _, deferFn := tracing.Tracer("propagation").Start(c.Request().Context(), "handleBatchTx",
+			tracing.WithParentStat(ps.stats),
+			tracing.WithHistogram(prometheusProcessedHandleBatchTx),
+		)
+		defer deferFn()
+
+		if ps.ingest.busy() {
+			prometheusBusyTransactions.Inc()
+
+			retryAfterSeconds := (int64(ps.ingest.retryAfterMs()) + 999) / 1000
+			c.Response().Header().Set(echo.HeaderRetryAfter, strconv.FormatInt(retryAfterSeconds, 10))
+
+			return c.String(http.StatusTooManyRequests, "Too many transactions in flight")
+		}
+
+		txs, err := readTransactions(c.Request().Body)
+		if err != nil {
+			prometheusInvalidTransactions.Inc()
+			return c.String(http.StatusBadRequest, "Invalid request body: "+err.Error())
+		}
+
+		results := make(chan batchTxResponse, len(txs))
+		wg := sync.WaitGroup{}
+
+		for idx, tx := range txs {
+			if !ps.ingest.tryAcquire() {
+				results <- batchTxResponse{
+					Index:        idx,
+					TxID:         tx.TxID(),
+					Status:       batchTxBusy,
+					Error:        ps.ingest.busyError().Error(),
+					RetryAfterMs: ps.ingest.retryAfterMs(),
+				}
+
+				continue
+			}
+
+			wg.Add(1)
+
+			go func(idx int, tx *bt.Tx) {
+				defer wg.Done()
+
+				err := ps.processTransactionInternal(ctx, tx)
+
+				ps.ingest.release()
+
+				result := batchTxResponse{
+					Index:  idx,
+					TxID:   tx.TxID(),
+					Status: batchTxAccepted,
+				}
+
+				if err != nil {
+					result.Status = batchTxRejected
+					result.Error = err.Error()
+				}
+
+				results <- result
+			}(idx, tx)
+		}
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		c.Response().Header().Set(echo.HeaderContentType, "application/x-ndjson")
+		c.Response().WriteHeader(http.StatusOK)
+
+		encoder := json.NewEncoder(c.Response())
+
+		for result := range results {
+			if err = encoder.Encode(result); err != nil {
+				// the sender went away, the remaining transactions are still processed
+				ps.logger.Debugf("[handleBatchTx] failed to write the result of transaction %d: %v", result.Index, err)
+				continue
+			}
+
+			c.Response().Flush()
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,382 @@
+package propagation
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/propagation/propagation_api"
+	"github.com/bsv-blockchain/teranode/services/validator"
+	"github.com/bsv-blockchain/teranode/stores/blob/null"
+	"github.com/bsv-blockchain/teranode/stores/utxo/meta"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// blockingValidator is a mock validator blocking every validation until unblock is closed
+type blockingValidator struct {
+	validator.MockValidatorClient
+	unblock chan struct{}
+}
+
+// Validate implements a mock validator.Validate method
+func (m *blockingValidator) Validate(ctx context.Context, tx *bt.Tx, _ uint32, _ ...validator.Option) (*meta.Data, error) {
+	select {
+	case <-m.unblock:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return &meta.Data{Tx: tx}, nil
+}
+
+func newIngestTestServer(t *testing.T, validatorClient validator.Interface, limiter *ingestLimiter) *PropagationServer {
+	initPrometheusMetrics()
+
+	txStore, err := null.New(ulogger.TestLogger{})
+	require.NoError(t, err)
+
+	return &PropagationServer{
+		logger:    ulogger.TestLogger{},
+		settings:  test.CreateBaseTestSettings(t),
+		txStore:   txStore,
+		validator: validatorClient,
+		ingest:    limiter,
+	}
+}
+
+func unblockedValidator() *blockingValidator {
+	v := &blockingValidator{unblock: make(chan struct{})}
+	close(v.unblock)
+
+	return v
+}
+
+func TestIngestLimiter(t *testing.T) {
+	limiter := newIngestLimiter(2, 250*time.Millisecond)
+
+	assert.True(t, limiter.tryAcquire())
+	assert.False(t, limiter.busy())
+	assert.True(t, limiter.tryAcquire())
+	assert.True(t, limiter.busy())
+	assert.False(t, limiter.tryAcquire())
+
+	inFlight, maxInFlight := limiter.stats()
+	assert.Equal(t, uint32(2), inFlight)
+	assert.Equal(t, uint32(2), maxInFlight)
+	assert.Equal(t, uint32(250), limiter.retryAfterMs())
+
+	limiter.release()
+	assert.True(t, limiter.tryAcquire())
+
+	t.Run("unlimited", func(t *testing.T) {
+		limiter := newIngestLimiter(0, time.Second)
+
+		for i := 0; i < 100; i++ {
+			assert.True(t, limiter.tryAcquire())
+		}
+
+		assert.False(t, limiter.busy())
+
+		inFlight, maxInFlight := limiter.stats()
+		assert.Equal(t, uint32(100), inFlight)
+		assert.Zero(t, maxInFlight)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var limiter *ingestLimiter
+
+		assert.True(t, limiter.tryAcquire())
+		assert.False(t, limiter.busy())
+		limiter.release()
+	})
+}
+
+func TestProcessTransactionBatch_Busy(t *testing.T) {
+	tx := createRobustTestTx(t)
+
+	limiter := newIngestLimiter(1, 250*time.Millisecond)
+	require.True(t, limiter.tryAcquire())
+
+	ps := newIngestTestServer(t, unblockedValidator(), limiter)
+
+	response, err := ps.ProcessTransactionBatch(context.Background(), &propagation_api.ProcessTransactionBatchRequest{
+		Items: []*propagation_api.BatchTransactionItem{{Tx: tx.ExtendedBytes()}, {Tx: tx.ExtendedBytes()}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, response.Errors, 2)
+
+	for _, tErr := range response.Errors {
+		assert.Equal(t, errors.ERR_SERVICE_UNAVAILABLE, tErr.Code)
+	}
+
+	assert.Equal(t, uint32(250), response.RetryAfterMs)
+	assert.Equal(t, uint32(1), response.InFlight)
+	assert.Equal(t, uint32(1), response.MaxInFlight)
+
+	limiter.release()
+
+	response, err = ps.ProcessTransactionBatch(context.Background(), &propagation_api.ProcessTransactionBatchRequest{
+		Items: []*propagation_api.BatchTransactionItem{{Tx: tx.ExtendedBytes()}},
+	})
+	require.NoError(t, err)
+
+	assert.Nil(t, response.Errors[0])
+	assert.Zero(t, response.RetryAfterMs)
+	assert.Zero(t, response.InFlight)
+}
+
+func TestHandleBatchTx(t *testing.T) {
+	tx1 := createRobustTestTx(t)
+	tx2 := createRobustTestTx(t)
+
+	var body bytes.Buffer
+
+	body.Write(tx1.ExtendedBytes())
+	body.Write(tx2.ExtendedBytes())
+
+	startServer := func(t *testing.T, ps *PropagationServer) string {
+		e := echo.New()
+		e.POST("/txs/batch", ps.handleBatchTx(context.Background()))
+
+		server := httptest.NewServer(e)
+		t.Cleanup(server.Close)
+
+		return server.URL + "/txs/batch"
+	}
+
+	readResult := func(t *testing.T, reader *bufio.Reader) batchTxResponse {
+		t.Helper()
+
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+
+		var result batchTxResponse
+		require.NoError(t, json.Unmarshal(line, &result))
+
+		return result
+	}
+
+	t.Run("returns the result of every transaction", func(t *testing.T) {
+		mockValidator := &MockValidatorForTxTest{}
+		ps := newIngestTestServer(t, mockValidator, newIngestLimiter(10, time.Second))
+
+		resp, err := http.Post(startServer(t, ps), echo.MIMEOctetStream, bytes.NewReader(body.Bytes()))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get(echo.HeaderContentType))
+
+		reader := bufio.NewReader(resp.Body)
+
+		indexes := map[int]bool{}
+
+		for i := 0; i < 2; i++ {
+			result := readResult(t, reader)
+			assert.Equal(t, batchTxAccepted, result.Status)
+			assert.Equal(t, tx1.TxID(), result.TxID)
+			assert.Empty(t, result.Error)
+
+			indexes[result.Index] = true
+		}
+
+		assert.Equal(t, map[int]bool{0: true, 1: true}, indexes)
+	})
+
+	t.Run("rejected transaction", func(t *testing.T) {
+		mockValidator := NewMockValidatorForTxTest(errors.NewTxInvalidError("invalid transaction"))
+		ps := newIngestTestServer(t, mockValidator, nil)
+
+		resp, err := http.Post(startServer(t, ps), echo.MIMEOctetStream, bytes.NewReader(tx1.ExtendedBytes()))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		result := readResult(t, bufio.NewReader(resp.Body))
+		assert.Equal(t, batchTxRejected, result.Status)
+		assert.Contains(t, result.Error, "invalid transaction")
+	})
+
+	t.Run("transactions above the in flight limit are busy", func(t *testing.T) {
+		blocking := &blockingValidator{unblock: make(chan struct{})}
+		ps := newIngestTestServer(t, blocking, newIngestLimiter(1, 250*time.Millisecond))
+
+		resp, err := http.Post(startServer(t, ps), echo.MIMEOctetStream, bytes.NewReader(body.Bytes()))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		reader := bufio.NewReader(resp.Body)
+
+		// the second transaction is acknowledged while the first one is still being processed
+		result := readResult(t, reader)
+		assert.Equal(t, 1, result.Index)
+		assert.Equal(t, batchTxBusy, result.Status)
+		assert.Equal(t, uint32(250), result.RetryAfterMs)
+
+		close(blocking.unblock)
+
+		result = readResult(t, reader)
+		assert.Equal(t, 0, result.Index)
+		assert.Equal(t, batchTxAccepted, result.Status)
+	})
+
+	t.Run("rejects the request when busy", func(t *testing.T) {
+		limiter := newIngestLimiter(1, 1500*time.Millisecond)
+		require.True(t, limiter.tryAcquire())
+
+		ps := newIngestTestServer(t, unblockedValidator(), limiter)
+
+		resp, err := http.Post(startServer(t, ps), echo.MIMEOctetStream, bytes.NewReader(body.Bytes()))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get(echo.HeaderRetryAfter))
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		ps := newIngestTestServer(t, unblockedValidator(), nil)
+
+		resp, err := http.Post(startServer(t, ps), echo.MIMEOctetStream, bytes.NewReader([]byte{0x01, 0x02}))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestProcessTransactionStream(t *testing.T) {
+	tx1 := createRobustTestTx(t)
+	tx2 := createRobustTestTx(t)
+
+	newClient := func(t *testing.T, ps *PropagationServer) *Client {
+		listener := bufconn.Listen(1024 * 1024)
+
+		grpcServer := grpc.NewServer()
+		propagation_api.RegisterPropagationAPIServer(grpcServer, ps)
+
+		go func() {
+			_ = grpcServer.Serve(listener)
+		}()
+
+		t.Cleanup(grpcServer.Stop)
+
+		conn, err := grpc.NewClient("passthrough:///bufconn",
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = conn.Close()
+		})
+
+		return &Client{
+			client:   propagation_api.NewPropagationAPIClient(conn),
+			conn:     conn,
+			logger:   ulogger.TestLogger{},
+			settings: test.CreateBaseTestSettings(t),
+		}
+	}
+
+	t.Run("acknowledges every transaction", func(t *testing.T) {
+		mockValidator := &MockValidatorForTxTest{}
+		client := newClient(t, newIngestTestServer(t, mockValidator, newIngestLimiter(10, time.Second)))
+
+		results, err := client.ProcessTransactionStream(context.Background(), []*bt.Tx{tx1, tx2})
+		require.NoError(t, err)
+
+		assert.Equal(t, []error{nil, nil}, results)
+		assert.True(t, mockValidator.WasValidateCalled())
+	})
+
+	t.Run("rejected transaction", func(t *testing.T) {
+		mockValidator := NewMockValidatorForTxTest(errors.NewTxInvalidError("invalid transaction"))
+		client := newClient(t, newIngestTestServer(t, mockValidator, nil))
+
+		results, err := client.ProcessTransactionStream(context.Background(), []*bt.Tx{tx1})
+		require.NoError(t, err)
+
+		require.Len(t, results, 1)
+		require.Error(t, results[0])
+		assert.Contains(t, results[0].Error(), "invalid transaction")
+	})
+
+	t.Run("sends the busy transactions again", func(t *testing.T) {
+		limiter := newIngestLimiter(1, 10*time.Millisecond)
+		require.True(t, limiter.tryAcquire())
+
+		client := newClient(t, newIngestTestServer(t, unblockedValidator(), limiter))
+
+		// free the slot after the first attempt has been rejected
+		time.AfterFunc(50*time.Millisecond, limiter.release)
+
+		results, err := client.ProcessTransactionStream(context.Background(), []*bt.Tx{tx1, tx2})
+		require.NoError(t, err)
+
+		assert.Equal(t, []error{nil, nil}, results)
+	})
+
+	t.Run("gives up on transactions that stay busy", func(t *testing.T) {
+		limiter := newIngestLimiter(1, time.Millisecond)
+		require.True(t, limiter.tryAcquire())
+
+		client := newClient(t, newIngestTestServer(t, unblockedValidator(), limiter))
+
+		results, err := client.ProcessTransactionStream(context.Background(), []*bt.Tx{tx1})
+		require.NoError(t, err)
+
+		require.Len(t, results, 1)
+		assert.ErrorIs(t, results[0], errors.ErrServiceUnavailable)
+	})
+
+	t.Run("via HTTP", func(t *testing.T) {
+		ps := newIngestTestServer(t, &MockValidatorForTxTest{}, newIngestLimiter(10, time.Second))
+
+		e := echo.New()
+		e.POST("/txs/batch", ps.handleBatchTx(context.Background()))
+
+		server := httptest.NewServer(e)
+		defer server.Close()
+
+		httpAddr, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		tSettings := test.CreateBaseTestSettings(t)
+		tSettings.Propagation.AlwaysUseHTTP = true
+
+		client := &Client{
+			logger:              ulogger.TestLogger{},
+			settings:            tSettings,
+			propagationHTTPAddr: httpAddr,
+		}
+
+		results, err := client.ProcessTransactionStream(context.Background(), []*bt.Tx{tx1, tx2})
+		require.NoError(t, err)
+
+		assert.Equal(t, []error{nil, nil}, results)
+	})
+}
@@ -41,8 +41,11 @@ var (
 	prometheusProcessedTransactionBatch prometheus.Histogram
 	prometheusProcessedHandleSingleTx   prometheus.Histogram
 	prometheusProcessedHandleMultipleTx prometheus.Histogram
+	prometheusProcessedHandlePackage    prometheus.Histogram
+	prometheusProcessedHandleBatchTx    prometheus.Histogram
 	prometheusTransactionSize           prometheus.Histogram
 	prometheusInvalidTransactions       prometheus.Counter
+	prometheusBusyTransactions          prometheus.Counter
 )
 
 // Synchronization primitive for ensuring metrics are initialized exactly once.
@@ -64,6 +67,7 @@ func initPrometheusMetrics() {
 // - Transaction processing latency histograms (single, batch, HTTP single, HTTP multiple)
 // - Transaction size histogram for monitoring data volume
 // - Invalid transaction counter for monitoring error rates
+// - Busy transaction counter for monitoring backpressure
 //
 // Each metric is properly namespaced under 'teranode' and the 'propagation' subsystem
 // with appropriate bucket definitions based on the expected value distributions.
@@ -113,6 +117,24 @@ func _initPrometheusMetrics() {
 			Buckets:   util.MetricsBucketsMilliSeconds,
 		},
 	)
+	prometheusProcessedHandlePackage = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "propagation",
+			Name:      "handle_package",
+			Help:      "Histogram of transaction package processing by the propagation service using HTTP",
+			Buckets:   util.MetricsBucketsMilliSeconds,
+		},
+	)
+	prometheusProcessedHandleBatchTx = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "propagation",
+			Name:      "handle_batch_tx",
+			Help:      "Histogram of transaction batch processing with per transaction results by the propagation service using HTTP",
+			Buckets:   util.MetricsBucketsMilliSeconds,
+		},
+	)
 	prometheusTransactionSize = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "teranode",
@@ -130,4 +152,12 @@ func _initPrometheusMetrics() {
 			Help:      "Number of transactions found invalid by the propagation service",
 		},
 	)
+	prometheusBusyTransactions = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "propagation",
+			Name:      "busy_transactions",
+			Help:      "Number of transactions rejected by the propagation service because too many transactions were in flight",
+		},
+	)
 }
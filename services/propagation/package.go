@@ -0,0 +1,193 @@
+package propagation
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/validator"
+	"github.com/bsv-blockchain/teranode/util/tracing"
+	"github.com/labstack/echo/v4"
+)
+
+// packageTxResponse is the result of a single transaction of a package returned by the /package endpoint
+type packageTxResponse struct {
+	TxID   string `json:"txid"`
+	Status string `json:"status"`
+	Fee    uint64 `json:"fee"`
+	Size   uint64 `json:"size"`
+	Error  string `json:"error,omitempty"`
+}
+
+// packageResponse is the result of a package returned by the /package endpoint
+type packageResponse struct {
+	Accepted     bool                `json:"accepted"`
+	Fee          uint64              `json:"fee"`
+	Size         uint64              `json:"size"`
+	Error        string              `json:"error,omitempty"`
+	Transactions []packageTxResponse `json:"transactions"`
+}
+
+// handlePackage handles a package of dependent transactions on the /package endpoint.
+// The request body holds the transactions of the package back to back, in any order, like the /txs endpoint. The
+// transactions are validated together and admitted all or none, the fees are checked over the whole package so a
+// child can pay the fee of its parent.
+//
+// The response is a JSON document with the result of the package and of each transaction, in the order of the
+// request. The status is 200 when the package was admitted, 400 when it is invalid and 500 when it could not be
+// processed.
+//
+// Parameters:
+//   - _: Unused context parameter (context is obtained from the HTTP request)
+//
+// Returns:
+//   - echo.HandlerFunc: HTTP handler function for the Echo web framework
+func (ps *PropagationServer) handlePackage(_ context.Context) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, _, deferFn := tracing.Tracer("propagation").Start(c.Request().Context(), "handlePackage",
+			tracing.WithParentStat(ps.stats),
+			tracing.WithHistogram(prometheusProcessedHandlePackage),
+		)
+		defer deferFn()
+
+		txs, err := readTransactions(c.Request().Body)
+		if err != nil {
+			prometheusInvalidTransactions.Inc()
+			return c.String(http.StatusBadRequest, "Invalid request body: "+err.Error())
+		}
+
+		result, err := ps.processPackage(ctx, txs)
+
+		response := packageResponse{
+			Transactions: make([]packageTxResponse, 0, len(txs)),
+		}
+
+		if result != nil {
+			response.Accepted = result.Accepted && err == nil
+			response.Fee = result.Fee
+			response.Size = result.Size
+
+			for _, txResult := range result.Transactions {
+				txResponse := packageTxResponse{
+					TxID:   txResult.TxID.String(),
+					Status: txResult.Status.String(),
+					Fee:    txResult.Fee,
+					Size:   txResult.Size,
+				}
+
+				if txResult.Err != nil {
+					txResponse.Error = txResult.Err.Error()
+				}
+
+				response.Transactions = append(response.Transactions, txResponse)
+			}
+		}
+
+		if err != nil {
+			response.Error = err.Error()
+
+			if errors.Is(err, errors.ErrServiceError) || errors.Is(err, errors.ErrStorageError) {
+				return c.JSON(http.StatusInternalServerError, response)
+			}
+
+			return c.JSON(http.StatusBadRequest, response)
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// readTransactions reads the transactions sent back to back in a request body, up to maxTransactionsPerRequest
+// transactions and maxDataPerRequest bytes
+func readTransactions(body io.Reader) (txs []*bt.Tx, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.NewProcessingError("transaction parsing panic: %v", r)
+		}
+	}()
+
+	totalBytesRead := int64(0)
+
+	for {
+		tx := &bt.Tx{}
+
+		bytesRead, err := tx.ReadFrom(body)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, errors.NewTxInvalidError("failed to read transaction %d", len(txs), err)
+		}
+
+		totalBytesRead += bytesRead
+
+		if len(txs) >= maxTransactionsPerRequest {
+			return nil, errors.NewTxInvalidError("too many transactions")
+		}
+
+		if totalBytesRead > maxDataPerRequest {
+			return nil, errors.NewTxInvalidError("too much data")
+		}
+
+		txs = append(txs, tx)
+	}
+
+	if len(txs) == 0 {
+		return nil, errors.NewTxInvalidError("no transactions")
+	}
+
+	return txs, nil
+}
+
+// processPackage stores the transactions of a package and validates them together with the validator. Packages are
+// always validated synchronously, also when transactions are sent to the validator through Kafka, as the result of
+// the whole package is needed before any of its transactions can be admitted.
+//
+// Parameters:
+//   - ctx: Context for package processing with tracing information
+//   - txs: The transactions of the package, in any order
+//
+// Returns:
+//   - *validator.PackageResult: The result of the package and of each transaction, nil when the package did not
+//     reach the validator
+//   - error: The reason the package was rejected, nil when all transactions were admitted
+func (ps *PropagationServer) processPackage(ctx context.Context, txs []*bt.Tx) (*validator.PackageResult, error) {
+	ctx, _, endSpan := tracing.Tracer("propagation").Start(ctx, "processPackage",
+		tracing.WithParentStat(ps.stats),
+	)
+	defer endSpan()
+
+	for _, btTx := range txs {
+		// Do not allow propagation of coinbase transactions
+		if btTx.IsCoinbase() {
+			prometheusInvalidTransactions.Inc()
+			return nil, errors.NewTxInvalidError("[ProcessPackage][%s] received coinbase transaction", btTx.TxID())
+		}
+
+		if err := ps.txSanityChecks(btTx); err != nil {
+			return nil, err
+		}
+	}
+
+	// we should store all transactions, if this fails we should not validate the package
+	for _, btTx := range txs {
+		if err := ps.storeTransaction(ctx, btTx); err != nil {
+			return nil, errors.NewStorageError("[ProcessPackage][%s] failed to save transaction", btTx.TxIDChainHash(), err)
+		}
+	}
+
+	result, err := ps.validator.ValidatePackage(ctx, txs, 0)
+	if err != nil {
+		prometheusInvalidTransactions.Inc()
+		return result, errors.NewProcessingError("[ProcessPackage] failed to validate package of %d transactions", len(txs), err)
+	}
+
+	for _, btTx := range txs {
+		prometheusTransactionSize.Observe(float64(btTx.Size()))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package propagation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/validator"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPackageValidator is a mock validator returning the result of validatePackageFunc for packages
+type mockPackageValidator struct {
+	validator.MockValidatorClient
+	validatePackageFunc func(txs []*bt.Tx) (*validator.PackageResult, error)
+	packageTxs          []*bt.Tx
+}
+
+// ValidatePackage implements a mock validator.ValidatePackage method
+func (m *mockPackageValidator) ValidatePackage(_ context.Context, txs []*bt.Tx, _ uint32, _ ...validator.Option) (*validator.PackageResult, error) {
+	m.packageTxs = txs
+
+	return m.validatePackageFunc(txs)
+}
+
+func packageResultFor(txs []*bt.Tx, status validator.PackageTxStatus) *validator.PackageResult {
+	result := &validator.PackageResult{
+		Accepted: status == validator.PackageTxAccepted,
+	}
+
+	for _, tx := range txs {
+		result.Transactions = append(result.Transactions, &validator.PackageTxResult{
+			TxID:   *tx.TxIDChainHash(),
+			Status: status,
+			Size:   uint64(tx.Size()), // nolint:gosec
+		})
+
+		result.Size += uint64(tx.Size()) // nolint:gosec
+	}
+
+	return result
+}
+
+func TestHandlePackage(t *testing.T) {
+	tx1 := createRobustTestTx(t)
+	tx2 := createRobustTestTx(t)
+
+	postPackage := func(t *testing.T, mockValidator *mockPackageValidator, body []byte) (int, packageResponse) {
+		mockStore := &MockTxStore{}
+
+		ps := &PropagationServer{
+			logger:    ulogger.TestLogger{},
+			validator: mockValidator,
+			txStore:   mockStore,
+		}
+
+		e := echo.New()
+		e.POST("/package", ps.handlePackage(context.Background()))
+
+		server := httptest.NewServer(e)
+		defer server.Close()
+
+		resp, err := http.Post(server.URL+"/package", echo.MIMEOctetStream, bytes.NewReader(body))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		var response packageResponse
+
+		if strings.HasPrefix(resp.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		}
+
+		return resp.StatusCode, response
+	}
+
+	var body bytes.Buffer
+
+	body.Write(tx1.ExtendedBytes())
+	body.Write(tx2.ExtendedBytes())
+
+	t.Run("accepted", func(t *testing.T) {
+		mockValidator := &mockPackageValidator{
+			validatePackageFunc: func(txs []*bt.Tx) (*validator.PackageResult, error) {
+				return packageResultFor(txs, validator.PackageTxAccepted), nil
+			},
+		}
+
+		status, response := postPackage(t, mockValidator, body.Bytes())
+		assert.Equal(t, http.StatusOK, status)
+
+		require.Len(t, mockValidator.packageTxs, 2)
+
+		assert.True(t, response.Accepted)
+		require.Len(t, response.Transactions, 2)
+		assert.Equal(t, tx1.TxID(), response.Transactions[0].TxID)
+		assert.Equal(t, "accepted", response.Transactions[0].Status)
+		assert.Empty(t, response.Error)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		mockValidator := &mockPackageValidator{
+			validatePackageFunc: func(txs []*bt.Tx) (*validator.PackageResult, error) {
+				return packageResultFor(txs, validator.PackageTxNotAdmitted), errors.NewTxInvalidError("package fee too low")
+			},
+		}
+
+		status, response := postPackage(t, mockValidator, body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		assert.False(t, response.Accepted)
+		require.Len(t, response.Transactions, 2)
+		assert.Equal(t, "not_admitted", response.Transactions[1].Status)
+		assert.Contains(t, response.Error, "package fee too low")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockValidator := &mockPackageValidator{
+			validatePackageFunc: func(txs []*bt.Tx) (*validator.PackageResult, error) {
+				return nil, errors.NewServiceError("validator unavailable")
+			},
+		}
+
+		status, response := postPackage(t, mockValidator, body.Bytes())
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.False(t, response.Accepted)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		mockValidator := &mockPackageValidator{}
+
+		status, _ := postPackage(t, mockValidator, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Nil(t, mockValidator.packageTxs)
+	})
+}
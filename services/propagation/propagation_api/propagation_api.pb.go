@@ -71,7 +71,9 @@ type HealthResponse struct {
 	// details provides additional information about the health status
 	Details string `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
 	// timestamp indicates when the health check was performed
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// build_info is the JSON build metadata of the node, as returned by the /api/v1/version endpoint
+	BuildInfo     string `protobuf:"bytes,4,opt,name=build_info,json=buildInfo,proto3" json:"build_info,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -127,6 +129,13 @@ func (x *HealthResponse) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *HealthResponse) GetBuildInfo() string {
+	if x != nil {
+		return x.BuildInfo
+	}
+	return ""
+}
+
 // GetRequest represents a request to retrieve a transaction by its ID.
 // swagger:model GetRequest
 type GetRequest struct {
@@ -376,7 +385,14 @@ type ProcessTransactionBatchResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// error contains error messages for each transaction in the batch
 	// empty string indicates success for that transaction
-	Errors        []*errors.TError `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	Errors []*errors.TError `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	// in_flight is the number of transactions being processed by the service when the batch was completed
+	InFlight uint32 `protobuf:"varint,2,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+	// max_in_flight is the maximum number of transactions the service processes at the same time, 0 when unlimited
+	MaxInFlight uint32 `protobuf:"varint,3,opt,name=max_in_flight,json=maxInFlight,proto3" json:"max_in_flight,omitempty"`
+	// retry_after_ms is the delay in milliseconds after which the busy transactions of the batch can be sent again,
+	// 0 when no transaction of the batch was busy
+	RetryAfterMs  uint32 `protobuf:"varint,4,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -418,16 +434,197 @@ func (x *ProcessTransactionBatchResponse) GetErrors() []*errors.TError {
 	return nil
 }
 
+func (x *ProcessTransactionBatchResponse) GetInFlight() uint32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *ProcessTransactionBatchResponse) GetMaxInFlight() uint32 {
+	if x != nil {
+		return x.MaxInFlight
+	}
+	return 0
+}
+
+func (x *ProcessTransactionBatchResponse) GetRetryAfterMs() uint32 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
+// ProcessTransactionStreamRequest represents a single transaction sent on a transaction stream.
+// swagger:model ProcessTransactionStreamRequest
+type ProcessTransactionStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is chosen by the client to match the acknowledgement to the transaction
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// tx contains the raw transaction bytes to process
+	Tx []byte `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+	// trace_context contains the serialized OpenTelemetry trace context as key-value pairs
+	TraceContext  map[string]string `protobuf:"bytes,3,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessTransactionStreamRequest) Reset() {
+	*x = ProcessTransactionStreamRequest{}
+	mi := &file_services_propagation_propagation_api_propagation_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessTransactionStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessTransactionStreamRequest) ProtoMessage() {}
+
+func (x *ProcessTransactionStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_propagation_propagation_api_propagation_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessTransactionStreamRequest.ProtoReflect.Descriptor instead.
+func (*ProcessTransactionStreamRequest) Descriptor() ([]byte, []int) {
+	return file_services_propagation_propagation_api_propagation_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProcessTransactionStreamRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProcessTransactionStreamRequest) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *ProcessTransactionStreamRequest) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
+// ProcessTransactionStreamResponse acknowledges a single transaction of a transaction stream.
+// Acknowledgements are sent in the order the transactions complete, not in the order they were sent.
+// swagger:model ProcessTransactionStreamResponse
+type ProcessTransactionStreamResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the id of the acknowledged transaction
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// error contains the reason the transaction was rejected, empty when the transaction was accepted
+	Error *errors.TError `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// busy indicates the transaction was not processed because too many transactions are in flight
+	Busy bool `protobuf:"varint,3,opt,name=busy,proto3" json:"busy,omitempty"`
+	// retry_after_ms is the delay in milliseconds after which a busy transaction can be sent again
+	RetryAfterMs uint32 `protobuf:"varint,4,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"`
+	// in_flight is the number of transactions being processed by the service
+	InFlight uint32 `protobuf:"varint,5,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+	// max_in_flight is the maximum number of transactions the service processes at the same time, 0 when unlimited
+	MaxInFlight   uint32 `protobuf:"varint,6,opt,name=max_in_flight,json=maxInFlight,proto3" json:"max_in_flight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessTransactionStreamResponse) Reset() {
+	*x = ProcessTransactionStreamResponse{}
+	mi := &file_services_propagation_propagation_api_propagation_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessTransactionStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessTransactionStreamResponse) ProtoMessage() {}
+
+func (x *ProcessTransactionStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_propagation_propagation_api_propagation_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessTransactionStreamResponse.ProtoReflect.Descriptor instead.
+func (*ProcessTransactionStreamResponse) Descriptor() ([]byte, []int) {
+	return file_services_propagation_propagation_api_propagation_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProcessTransactionStreamResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProcessTransactionStreamResponse) GetError() *errors.TError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *ProcessTransactionStreamResponse) GetBusy() bool {
+	if x != nil {
+		return x.Busy
+	}
+	return false
+}
+
+func (x *ProcessTransactionStreamResponse) GetRetryAfterMs() uint32 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
+func (x *ProcessTransactionStreamResponse) GetInFlight() uint32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *ProcessTransactionStreamResponse) GetMaxInFlight() uint32 {
+	if x != nil {
+		return x.MaxInFlight
+	}
+	return 0
+}
+
 var File_services_propagation_propagation_api_propagation_api_proto protoreflect.FileDescriptor
 
 const file_services_propagation_propagation_api_propagation_api_proto_rawDesc = "" +
 	"\n" +
 	":services/propagation/propagation_api/propagation_api.proto\x12\x0fpropagation_api\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x12errors/error.proto\"\x0e\n" +
-	"\fEmptyMessage\"t\n" +
+	"\fEmptyMessage\"\x93\x01\n" +
 	"\x0eHealthResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x18\n" +
 	"\adetails\x18\x02 \x01(\tR\adetails\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\" \n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"build_info\x18\x04 \x01(\tR\tbuildInfo\" \n" +
 	"\n" +
 	"GetRequest\x12\x12\n" +
 	"\x04txid\x18\x01 \x01(\fR\x04txid\"\x1d\n" +
@@ -442,14 +639,32 @@ const file_services_propagation_propagation_api_propagation_api_proto_rawDesc =
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"]\n" +
 	"\x1eProcessTransactionBatchRequest\x12;\n" +
-	"\x05items\x18\x01 \x03(\v2%.propagation_api.BatchTransactionItemR\x05items\"I\n" +
+	"\x05items\x18\x01 \x03(\v2%.propagation_api.BatchTransactionItemR\x05items\"\xb0\x01\n" +
 	"\x1fProcessTransactionBatchResponse\x12&\n" +
-	"\x06errors\x18\x01 \x03(\v2\x0e.errors.TErrorR\x06errors2\xc3\x02\n" +
+	"\x06errors\x18\x01 \x03(\v2\x0e.errors.TErrorR\x06errors\x12\x1b\n" +
+	"\tin_flight\x18\x02 \x01(\rR\binFlight\x12\"\n" +
+	"\rmax_in_flight\x18\x03 \x01(\rR\vmaxInFlight\x12$\n" +
+	"\x0eretry_after_ms\x18\x04 \x01(\rR\fretryAfterMs\"\xeb\x01\n" +
+	"\x1fProcessTransactionStreamRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x0e\n" +
+	"\x02tx\x18\x02 \x01(\fR\x02tx\x12g\n" +
+	"\rtrace_context\x18\x03 \x03(\v2B.propagation_api.ProcessTransactionStreamRequest.TraceContextEntryR\ftraceContext\x1a?\n" +
+	"\x11TraceContextEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd3\x01\n" +
+	" ProcessTransactionStreamResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12$\n" +
+	"\x05error\x18\x02 \x01(\v2\x0e.errors.TErrorR\x05error\x12\x12\n" +
+	"\x04busy\x18\x03 \x01(\bR\x04busy\x12$\n" +
+	"\x0eretry_after_ms\x18\x04 \x01(\rR\fretryAfterMs\x12\x1b\n" +
+	"\tin_flight\x18\x05 \x01(\rR\binFlight\x12\"\n" +
+	"\rmax_in_flight\x18\x06 \x01(\rR\vmaxInFlight2\xcb\x03\n" +
 	"\x0ePropagationAPI\x12N\n" +
 	"\n" +
 	"HealthGRPC\x12\x1d.propagation_api.EmptyMessage\x1a\x1f.propagation_api.HealthResponse\"\x00\x12a\n" +
 	"\x12ProcessTransaction\x12*.propagation_api.ProcessTransactionRequest\x1a\x1d.propagation_api.EmptyMessage\"\x00\x12~\n" +
-	"\x17ProcessTransactionBatch\x12/.propagation_api.ProcessTransactionBatchRequest\x1a0.propagation_api.ProcessTransactionBatchResponse\"\x00B\x14Z\x12./;propagation_apib\x06proto3"
+	"\x17ProcessTransactionBatch\x12/.propagation_api.ProcessTransactionBatchRequest\x1a0.propagation_api.ProcessTransactionBatchResponse\"\x00\x12\x85\x01\n" +
+	"\x18ProcessTransactionStream\x120.propagation_api.ProcessTransactionStreamRequest\x1a1.propagation_api.ProcessTransactionStreamResponse\"\x00(\x010\x01B\x14Z\x12./;propagation_apib\x06proto3"
 
 var (
 	file_services_propagation_propagation_api_propagation_api_proto_rawDescOnce sync.Once
@@ -463,36 +678,43 @@ func file_services_propagation_propagation_api_propagation_api_proto_rawDescGZIP
 	return file_services_propagation_propagation_api_propagation_api_proto_rawDescData
 }
 
-var file_services_propagation_propagation_api_propagation_api_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_services_propagation_propagation_api_propagation_api_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_services_propagation_propagation_api_propagation_api_proto_goTypes = []any{
-	(*EmptyMessage)(nil),                    // 0: propagation_api.EmptyMessage
-	(*HealthResponse)(nil),                  // 1: propagation_api.HealthResponse
-	(*GetRequest)(nil),                      // 2: propagation_api.GetRequest
-	(*GetResponse)(nil),                     // 3: propagation_api.GetResponse
-	(*ProcessTransactionRequest)(nil),       // 4: propagation_api.ProcessTransactionRequest
-	(*BatchTransactionItem)(nil),            // 5: propagation_api.BatchTransactionItem
-	(*ProcessTransactionBatchRequest)(nil),  // 6: propagation_api.ProcessTransactionBatchRequest
-	(*ProcessTransactionBatchResponse)(nil), // 7: propagation_api.ProcessTransactionBatchResponse
-	nil,                                     // 8: propagation_api.BatchTransactionItem.TraceContextEntry
-	(*timestamppb.Timestamp)(nil),           // 9: google.protobuf.Timestamp
-	(*errors.TError)(nil),                   // 10: errors.TError
+	(*EmptyMessage)(nil),                     // 0: propagation_api.EmptyMessage
+	(*HealthResponse)(nil),                   // 1: propagation_api.HealthResponse
+	(*GetRequest)(nil),                       // 2: propagation_api.GetRequest
+	(*GetResponse)(nil),                      // 3: propagation_api.GetResponse
+	(*ProcessTransactionRequest)(nil),        // 4: propagation_api.ProcessTransactionRequest
+	(*BatchTransactionItem)(nil),             // 5: propagation_api.BatchTransactionItem
+	(*ProcessTransactionBatchRequest)(nil),   // 6: propagation_api.ProcessTransactionBatchRequest
+	(*ProcessTransactionBatchResponse)(nil),  // 7: propagation_api.ProcessTransactionBatchResponse
+	(*ProcessTransactionStreamRequest)(nil),  // 8: propagation_api.ProcessTransactionStreamRequest
+	(*ProcessTransactionStreamResponse)(nil), // 9: propagation_api.ProcessTransactionStreamResponse
+	nil,                                      // 10: propagation_api.BatchTransactionItem.TraceContextEntry
+	nil,                                      // 11: propagation_api.ProcessTransactionStreamRequest.TraceContextEntry
+	(*timestamppb.Timestamp)(nil),            // 12: google.protobuf.Timestamp
+	(*errors.TError)(nil),                    // 13: errors.TError
 }
 var file_services_propagation_propagation_api_propagation_api_proto_depIdxs = []int32{
-	9,  // 0: propagation_api.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
-	8,  // 1: propagation_api.BatchTransactionItem.trace_context:type_name -> propagation_api.BatchTransactionItem.TraceContextEntry
+	12, // 0: propagation_api.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
+	10, // 1: propagation_api.BatchTransactionItem.trace_context:type_name -> propagation_api.BatchTransactionItem.TraceContextEntry
 	5,  // 2: propagation_api.ProcessTransactionBatchRequest.items:type_name -> propagation_api.BatchTransactionItem
-	10, // 3: propagation_api.ProcessTransactionBatchResponse.errors:type_name -> errors.TError
-	0,  // 4: propagation_api.PropagationAPI.HealthGRPC:input_type -> propagation_api.EmptyMessage
-	4,  // 5: propagation_api.PropagationAPI.ProcessTransaction:input_type -> propagation_api.ProcessTransactionRequest
-	6,  // 6: propagation_api.PropagationAPI.ProcessTransactionBatch:input_type -> propagation_api.ProcessTransactionBatchRequest
-	1,  // 7: propagation_api.PropagationAPI.HealthGRPC:output_type -> propagation_api.HealthResponse
-	0,  // 8: propagation_api.PropagationAPI.ProcessTransaction:output_type -> propagation_api.EmptyMessage
-	7,  // 9: propagation_api.PropagationAPI.ProcessTransactionBatch:output_type -> propagation_api.ProcessTransactionBatchResponse
-	7,  // [7:10] is the sub-list for method output_type
-	4,  // [4:7] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	13, // 3: propagation_api.ProcessTransactionBatchResponse.errors:type_name -> errors.TError
+	11, // 4: propagation_api.ProcessTransactionStreamRequest.trace_context:type_name -> propagation_api.ProcessTransactionStreamRequest.TraceContextEntry
+	13, // 5: propagation_api.ProcessTransactionStreamResponse.error:type_name -> errors.TError
+	0,  // 6: propagation_api.PropagationAPI.HealthGRPC:input_type -> propagation_api.EmptyMessage
+	4,  // 7: propagation_api.PropagationAPI.ProcessTransaction:input_type -> propagation_api.ProcessTransactionRequest
+	6,  // 8: propagation_api.PropagationAPI.ProcessTransactionBatch:input_type -> propagation_api.ProcessTransactionBatchRequest
+	8,  // 9: propagation_api.PropagationAPI.ProcessTransactionStream:input_type -> propagation_api.ProcessTransactionStreamRequest
+	1,  // 10: propagation_api.PropagationAPI.HealthGRPC:output_type -> propagation_api.HealthResponse
+	0,  // 11: propagation_api.PropagationAPI.ProcessTransaction:output_type -> propagation_api.EmptyMessage
+	7,  // 12: propagation_api.PropagationAPI.ProcessTransactionBatch:output_type -> propagation_api.ProcessTransactionBatchResponse
+	9,  // 13: propagation_api.PropagationAPI.ProcessTransactionStream:output_type -> propagation_api.ProcessTransactionStreamResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_services_propagation_propagation_api_propagation_api_proto_init() }
@@ -506,7 +728,7 @@ func file_services_propagation_propagation_api_propagation_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_propagation_propagation_api_propagation_api_proto_rawDesc), len(file_services_propagation_propagation_api_propagation_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
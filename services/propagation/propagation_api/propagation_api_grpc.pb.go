@@ -19,9 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PropagationAPI_HealthGRPC_FullMethodName              = "/propagation_api.PropagationAPI/HealthGRPC"
-	PropagationAPI_ProcessTransaction_FullMethodName      = "/propagation_api.PropagationAPI/ProcessTransaction"
-	PropagationAPI_ProcessTransactionBatch_FullMethodName = "/propagation_api.PropagationAPI/ProcessTransactionBatch"
+	PropagationAPI_HealthGRPC_FullMethodName               = "/propagation_api.PropagationAPI/HealthGRPC"
+	PropagationAPI_ProcessTransaction_FullMethodName       = "/propagation_api.PropagationAPI/ProcessTransaction"
+	PropagationAPI_ProcessTransactionBatch_FullMethodName  = "/propagation_api.PropagationAPI/ProcessTransactionBatch"
+	PropagationAPI_ProcessTransactionStream_FullMethodName = "/propagation_api.PropagationAPI/ProcessTransactionStream"
 )
 
 // PropagationAPIClient is the client API for PropagationAPI service.
@@ -43,6 +44,10 @@ type PropagationAPIClient interface {
 	// This is more efficient than processing transactions individually when dealing
 	// with large numbers of transactions.
 	ProcessTransactionBatch(ctx context.Context, in *ProcessTransactionBatchRequest, opts ...grpc.CallOption) (*ProcessTransactionBatchResponse, error)
+	// ProcessTransactionStream processes a stream of transactions, acknowledging each transaction as soon as it
+	// has been processed. Transactions that cannot be accepted because too many transactions are in flight are
+	// acknowledged as busy, and should be sent again after the retry delay of the acknowledgement.
+	ProcessTransactionStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ProcessTransactionStreamRequest, ProcessTransactionStreamResponse], error)
 }
 
 type propagationAPIClient struct {
@@ -83,6 +88,19 @@ func (c *propagationAPIClient) ProcessTransactionBatch(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *propagationAPIClient) ProcessTransactionStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ProcessTransactionStreamRequest, ProcessTransactionStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PropagationAPI_ServiceDesc.Streams[0], PropagationAPI_ProcessTransactionStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ProcessTransactionStreamRequest, ProcessTransactionStreamResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PropagationAPI_ProcessTransactionStreamClient = grpc.BidiStreamingClient[ProcessTransactionStreamRequest, ProcessTransactionStreamResponse]
+
 // PropagationAPIServer is the server API for PropagationAPI service.
 // All implementations must embed UnimplementedPropagationAPIServer
 // for forward compatibility.
@@ -102,6 +120,10 @@ type PropagationAPIServer interface {
 	// This is more efficient than processing transactions individually when dealing
 	// with large numbers of transactions.
 	ProcessTransactionBatch(context.Context, *ProcessTransactionBatchRequest) (*ProcessTransactionBatchResponse, error)
+	// ProcessTransactionStream processes a stream of transactions, acknowledging each transaction as soon as it
+	// has been processed. Transactions that cannot be accepted because too many transactions are in flight are
+	// acknowledged as busy, and should be sent again after the retry delay of the acknowledgement.
+	ProcessTransactionStream(grpc.BidiStreamingServer[ProcessTransactionStreamRequest, ProcessTransactionStreamResponse]) error
 	mustEmbedUnimplementedPropagationAPIServer()
 }
 
@@ -121,6 +143,9 @@ func (UnimplementedPropagationAPIServer) ProcessTransaction(context.Context, *Pr
 func (UnimplementedPropagationAPIServer) ProcessTransactionBatch(context.Context, *ProcessTransactionBatchRequest) (*ProcessTransactionBatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProcessTransactionBatch not implemented")
 }
+func (UnimplementedPropagationAPIServer) ProcessTransactionStream(grpc.BidiStreamingServer[ProcessTransactionStreamRequest, ProcessTransactionStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ProcessTransactionStream not implemented")
+}
 func (UnimplementedPropagationAPIServer) mustEmbedUnimplementedPropagationAPIServer() {}
 func (UnimplementedPropagationAPIServer) testEmbeddedByValue()                        {}
 
@@ -196,6 +221,13 @@ func _PropagationAPI_ProcessTransactionBatch_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PropagationAPI_ProcessTransactionStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PropagationAPIServer).ProcessTransactionStream(&grpc.GenericServerStream[ProcessTransactionStreamRequest, ProcessTransactionStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PropagationAPI_ProcessTransactionStreamServer = grpc.BidiStreamingServer[ProcessTransactionStreamRequest, ProcessTransactionStreamResponse]
+
 // PropagationAPI_ServiceDesc is the grpc.ServiceDesc for PropagationAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -216,6 +248,13 @@ var PropagationAPI_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _PropagationAPI_ProcessTransactionBatch_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ProcessTransactionStream",
+			Handler:       _PropagationAPI_ProcessTransactionStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "services/propagation/propagation_api/propagation_api.proto",
 }
@@ -72,6 +72,7 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/utxo"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/bsv-blockchain/teranode/util/health"
 	"github.com/ordishs/gocore"
 	"go.opentelemetry.io/otel"
@@ -661,6 +662,10 @@ type RPCServer struct {
 	// Used for retrieving block information, chain state, and blockchain operations
 	blockchainClient blockchain.ClientI
 
+	// auditLog records the privileged operations performed through the RPC interface, e.g. bans and block
+	// invalidations, nil when there is no blockchain client
+	auditLog *audit.Logger
+
 	// blockValidationClient provides access to block validation services
 	// Used for validating blocks and triggering revalidation of invalid blocks
 	blockValidationClient blockvalidation.Interface
@@ -935,6 +940,19 @@ func (s *RPCServer) checkAuth(r *http.Request, require bool) (bool, bool, error)
 	return false, false, errors.NewServiceError("auth failure")
 }
 
+// auditActor returns the actor of the audit records of an authenticated request, the basic auth user at the
+// address of the client
+func auditActor(r *http.Request) string {
+	user, _, _ := r.BasicAuth()
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return user + "@" + host
+}
+
 // parsedRPCCmd represents a JSON-RPC request object that has been parsed into
 // a known concrete command along with any error that might have happened while
 // parsing it.
@@ -1319,6 +1337,9 @@ func (s *RPCServer) Start(ctx context.Context, readyCh chan<- struct{}) error {
 			return
 		}
 
+		// the audit records of the privileged commands of the request name the authenticated user
+		r = r.WithContext(audit.WithActor(r.Context(), auditActor(r)))
+
 		// Read and respond to the request.
 		s.jsonRPCRead(w, r, isAdmin)
 	})
@@ -1415,6 +1436,10 @@ func NewServer(logger ulogger.Logger, tSettings *settings.Settings, blockchainCl
 		validatorClient:        validatorClient,
 	}
 
+	if blockchainClient != nil {
+		rpc.auditLog = audit.NewLogger(logger, blockchainClient, tSettings.AuditLogRateLimit)
+	}
+
 	rpcUser := tSettings.RPC.RPCUser
 	if rpcUser == "" {
 		logger.Warnf("rpc_user not set in config")
@@ -55,6 +55,8 @@ import (
 	"github.com/bsv-blockchain/teranode/services/p2p"
 	"github.com/bsv-blockchain/teranode/services/rpc/bsvjson"
 	"github.com/bsv-blockchain/teranode/stores/utxo"
+	"github.com/bsv-blockchain/teranode/util/audit"
+	"github.com/bsv-blockchain/teranode/util/clock"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/ordishs/go-utils"
 	cache "github.com/patrickmn/go-cache"
@@ -1762,6 +1764,8 @@ func handleInvalidateBlock(ctx context.Context, s *RPCServer, cmd interface{}, _
 		return nil, err
 	}
 
+	s.auditLog.Record(ctx, audit.ActorFromContext(ctx), audit.ActionInvalidateBlock, ch.String(), "")
+
 	return nil, nil
 }
 
@@ -1850,6 +1854,8 @@ func handleReconsiderBlock(ctx context.Context, s *RPCServer, cmd interface{}, _
 
 	s.logger.Infof("[handleReconsiderBlock] block %s successfully reconsidered and validated", ch)
 
+	s.auditLog.Record(ctx, audit.ActorFromContext(ctx), audit.ActionRevalidateBlock, ch.String(), "")
+
 	return nil, nil
 }
 
@@ -2134,6 +2140,8 @@ func handleClearBanned(ctx context.Context, s *RPCServer, cmd interface{}, _ <-c
 		}
 	}
 
+	s.auditLog.Record(ctx, audit.ActorFromContext(ctx), audit.ActionClearBans, "", "")
+
 	return true, nil
 }
 
@@ -2202,12 +2210,12 @@ func handleSetBan(ctx context.Context, s *RPCServer, cmd interface{}, _ <-chan s
 		if c.Absolute != nil && *c.Absolute {
 			expirationTime = time.Unix(*c.BanTime, 0)
 		} else {
-			expirationTime = time.Now().Add(time.Duration(*c.BanTime) * time.Second)
+			expirationTime = clock.Now().Add(time.Duration(*c.BanTime) * time.Second)
 		}
 
 		// If BanTime is 0, use a default ban time (e.g., 24 hours)
 		if *c.BanTime == 0 {
-			expirationTime = time.Now().Add(24 * time.Hour)
+			expirationTime = clock.Now().Add(24 * time.Hour)
 		}
 
 		expirationTimeInt64 := expirationTime.Unix()
@@ -2252,6 +2260,8 @@ func handleSetBan(ctx context.Context, s *RPCServer, cmd interface{}, _ <-chan s
 				s.logger.Debugf("Added ban for %s until %v", c.IPOrSubnet, expirationTime)
 			}
 		}
+
+		s.auditLog.Record(ctx, audit.ActorFromContext(ctx), audit.ActionBan, c.IPOrSubnet, "until="+expirationTime.UTC().Format(time.RFC3339))
 	case "remove":
 		var success bool
 
@@ -2289,6 +2299,8 @@ func handleSetBan(ctx context.Context, s *RPCServer, cmd interface{}, _ <-chan s
 				s.logger.Debugf("Removed ban for %s", c.IPOrSubnet)
 			}
 		}
+
+		s.auditLog.Record(ctx, audit.ActorFromContext(ctx), audit.ActionUnban, c.IPOrSubnet, "")
 	default:
 		return nil, &bsvjson.RPCError{
 			Code:    bsvjson.ErrRPCInvalidParameter,
@@ -2607,9 +2619,9 @@ func isIPOrSubnet(ipOrSubnet string) bool {
 		return err == nil
 	}
 
-	if strings.Contains(ipOrSubnet, ":") {
-		// remove port
-		ipOrSubnet = strings.Split(ipOrSubnet, ":")[0]
+	if i := strings.LastIndex(ipOrSubnet, ":"); i > strings.Index(ipOrSubnet, "/") {
+		// remove port, IPv6 subnets contain colons before the prefix length
+		ipOrSubnet = ipOrSubnet[:i]
 	}
 
 	_, _, err := net.ParseCIDR(ipOrSubnet)
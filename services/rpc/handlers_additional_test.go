@@ -19,9 +19,11 @@ import (
 	"github.com/bsv-blockchain/go-wire"
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockassembly"
 	"github.com/bsv-blockchain/teranode/services/blockassembly/blockassembly_api"
 	"github.com/bsv-blockchain/teranode/services/blockchain"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	"github.com/bsv-blockchain/teranode/services/blockvalidation"
 	"github.com/bsv-blockchain/teranode/services/legacy/bsvutil"
 	"github.com/bsv-blockchain/teranode/services/legacy/peer_api"
@@ -29,6 +31,7 @@ import (
 	"github.com/bsv-blockchain/teranode/services/rpc/bsvjson"
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/bsv-blockchain/teranode/util/test/mocklogger"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
@@ -1140,7 +1143,9 @@ func TestIPOrSubnetValidationExtended(t *testing.T) {
 		{"127.0.0.1", true, "valid IPv4"},
 		{"192.168.1.0/24", true, "valid IPv4 subnet"},
 		{"::1", true, "valid IPv6 localhost"},
-		{"2001:db8::/32", false, "IPv6 subnet - function has bug with port removal"},
+		{"2001:db8::/32", true, "valid IPv6 subnet"},
+		{"2001:db8::/64:8333", true, "IPv6 subnet with port"},
+		{"2001:db8::/129", false, "invalid IPv6 subnet - mask too large"},
 		{"256.256.256.256", false, "invalid IPv4 - out of range"},
 		{"192.168.1.0/33", false, "invalid IPv4 subnet - mask too large"},
 		{"not.an.ip", false, "completely invalid"},
@@ -4424,6 +4429,9 @@ func (m *mockBlockchainClient) GetBlockHeader(ctx context.Context, blockHash *ch
 func (m *mockBlockchainClient) AddBlock(ctx context.Context, block *model.Block, peerID string, opts ...options.StoreBlockOption) error {
 	return nil
 }
+func (m *mockBlockchainClient) StoreBlocks(ctx context.Context, blocks []*model.Block, peerID string, opts ...options.StoreBlockOption) error {
+	return nil
+}
 func (m *mockBlockchainClient) SendNotification(ctx context.Context, notification *blockchain_api.Notification) error {
 	return nil
 }
@@ -4520,6 +4528,12 @@ func (m *mockBlockchainClient) GetState(ctx context.Context, key string) ([]byte
 func (m *mockBlockchainClient) SetState(ctx context.Context, key string, data []byte) error {
 	return nil
 }
+func (m *mockBlockchainClient) AddAuditRecord(ctx context.Context, record *audit.Record) error {
+	return nil
+}
+func (m *mockBlockchainClient) GetAuditRecords(ctx context.Context, offset, limit int) ([]*audit.Record, int, error) {
+	return nil, 0, nil
+}
 func (m *mockBlockchainClient) SetBlockMinedSet(ctx context.Context, blockHash *chainhash.Hash) error {
 	return nil
 }
@@ -4544,6 +4558,9 @@ func (m *mockBlockchainClient) GetBestHeightAndTime(ctx context.Context) (uint32
 	}
 	return 0, 0, nil
 }
+func (m *mockBlockchainClient) GetCheckpoints(ctx context.Context) ([]checkpoints.Checkpoint, error) {
+	return nil, nil
+}
 func (m *mockBlockchainClient) CheckBlockIsInCurrentChain(ctx context.Context, blockIDs []uint32) (bool, error) {
 	if m.checkBlockIsInCurrentChainFunc != nil {
 		return m.checkBlockIsInCurrentChainFunc(ctx, blockIDs)
@@ -4621,6 +4638,10 @@ func (m *mockBlockValidationClient) GetCatchupStatus(ctx context.Context) (*bloc
 	return &blockvalidation.CatchupStatus{IsCatchingUp: false}, nil
 }
 
+func (m *mockBlockValidationClient) EstimateCatchup(ctx context.Context, blockHash *chainhash.Hash) (*blockvalidation.CatchupEstimate, error) {
+	return &blockvalidation.CatchupEstimate{}, nil
+}
+
 func (m *mockBlockchainClient) IsFullyReady(ctx context.Context) (bool, error) { return false, nil }
 func (m *mockBlockchainClient) Run(ctx context.Context, source string) error   { return nil }
 func (m *mockBlockchainClient) CatchUpBlocks(ctx context.Context) error        { return nil }
@@ -5266,6 +5287,10 @@ func (m *mockP2PClient) BanPeer(ctx context.Context, addr string, until int64) e
 	return nil
 }
 
+func (m *mockP2PClient) BanPeerWithReason(ctx context.Context, addr string, until int64, _ string) error {
+	return m.BanPeer(ctx, addr, until)
+}
+
 func (m *mockP2PClient) UnbanPeer(ctx context.Context, addr string) error {
 	if m.unbanPeerFunc != nil {
 		return m.unbanPeerFunc(ctx, addr)
@@ -5301,6 +5326,10 @@ func (m *mockP2PClient) AddBanScore(ctx context.Context, peerID string, reason s
 	return nil
 }
 
+func (m *mockP2PClient) AddBanScorePoints(ctx context.Context, peerID string, reason string, points int) error {
+	return nil
+}
+
 func (m *mockP2PClient) ConnectPeer(ctx context.Context, peerAddr string) error {
 	return nil
 }
@@ -5341,10 +5370,22 @@ func (m *mockP2PClient) ReportValidBlock(ctx context.Context, peerID string, blo
 	return nil
 }
 
+func (m *mockP2PClient) RecordInvalidSubtree(ctx context.Context, peerID string, subtreeHash string, reason p2p.InvalidDataReason, details string) error {
+	return nil
+}
+
+func (m *mockP2PClient) RecordInvalidBlock(ctx context.Context, peerID string, blockHash string, reason p2p.InvalidDataReason, details string) error {
+	return nil
+}
+
 func (m *mockP2PClient) RecordBytesDownloaded(ctx context.Context, peerID string, bytesDownloaded uint64) error {
 	return nil
 }
 
+func (m *mockP2PClient) RecordBytesUploaded(ctx context.Context, peerID string, bytesUploaded uint64) error {
+	return nil
+}
+
 func (m *mockP2PClient) GetPeerRegistry(ctx context.Context) ([]*p2p.PeerInfo, error) {
 	if m.getPeerRegistryFunc != nil {
 		return m.getPeerRegistryFunc(ctx)
@@ -5352,6 +5393,46 @@ func (m *mockP2PClient) GetPeerRegistry(ctx context.Context) ([]*p2p.PeerInfo, e
 	return []*p2p.PeerInfo{}, nil
 }
 
+func (m *mockP2PClient) GetNetworkOverview(ctx context.Context) (*p2p.NetworkOverview, error) {
+	return &p2p.NetworkOverview{}, nil
+}
+
+func (m *mockP2PClient) GetPeerEvents(ctx context.Context, from time.Time, to time.Time, peerID string, limit int) ([]p2p.PeerEvent, error) {
+	return []p2p.PeerEvent{}, nil
+}
+
+func (m *mockP2PClient) RecordDataDownloaded(ctx context.Context, peerID string, dataType string, items uint64, bytesDownloaded uint64) error {
+	return nil
+}
+
+func (m *mockP2PClient) RecordDataUploaded(ctx context.Context, peerID string, dataType string, items uint64, bytesUploaded uint64) error {
+	return nil
+}
+
+func (m *mockP2PClient) GetPeerContributions(ctx context.Context, peerID string) ([]p2p.PeerContribution, error) {
+	return []p2p.PeerContribution{}, nil
+}
+
+func (m *mockP2PClient) IsAvailable() bool {
+	return true
+}
+
+func (m *mockP2PClient) SignIdentity(ctx context.Context, challenge string) (*p2p.IdentityDocument, error) {
+	return &p2p.IdentityDocument{}, nil
+}
+
+func (m *mockP2PClient) SendOperatorMessage(ctx context.Context, peerID string, kind p2p.OperatorMessageKind, text string) (*p2p.OperatorMessage, error) {
+	return &p2p.OperatorMessage{}, nil
+}
+
+func (m *mockP2PClient) GetOperatorMessages(ctx context.Context, peerID string, limit int) ([]p2p.OperatorMessage, error) {
+	return []p2p.OperatorMessage{}, nil
+}
+
+func (m *mockP2PClient) SubscribeDoubleSpends(ctx context.Context) (<-chan p2p.DoubleSpendEvent, error) {
+	return nil, nil
+}
+
 // TestHandleSubmitMiningSolutionComprehensive tests the complete handleSubmitMiningSolution functionality
 func TestHandleSubmitMiningSolutionComprehensive(t *testing.T) {
 	logger := mocklogger.NewTestLogger()
@@ -5663,7 +5744,7 @@ func (m *mockBlockAssemblyClient) Health(ctx context.Context, checkLiveness bool
 	}
 	return 200, "OK", nil
 }
-func (m *mockBlockAssemblyClient) Store(ctx context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints) (bool, error) {
+func (m *mockBlockAssemblyClient) Store(ctx context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints, opts ...blockassembly.StoreOption) (bool, error) {
 	return true, nil
 }
 func (m *mockBlockAssemblyClient) RemoveTx(ctx context.Context, hash *chainhash.Hash) error {
@@ -5675,6 +5756,10 @@ func (m *mockBlockAssemblyClient) GetMiningCandidate(ctx context.Context, includ
 	}
 	return nil, nil
 }
+
+func (m *mockBlockAssemblyClient) GetMiningCandidateDiff(ctx context.Context, candidateID []byte) (*blockassembly_api.GetMiningCandidateDiffResponse, error) {
+	return nil, nil
+}
 func (m *mockBlockAssemblyClient) GetCurrentDifficulty(ctx context.Context) (float64, error) {
 	if m.getCurrentDifficultyFunc != nil {
 		return m.getCurrentDifficultyFunc(ctx)
@@ -5695,9 +5780,16 @@ func (m *mockBlockAssemblyClient) ResetBlockAssemblyFully(ctx context.Context) e
 	return nil
 }
 
+func (m *mockBlockAssemblyClient) ResetBlockAssemblyScoped(ctx context.Context, scope blockassembly.ResetScope) (blockassembly.ResetScopeResult, error) {
+	return blockassembly.ResetScopeResult{}, nil
+}
+
 func (m *mockBlockAssemblyClient) GetBlockAssemblyState(ctx context.Context) (*blockassembly_api.StateMessage, error) {
 	return nil, nil
 }
+func (m *mockBlockAssemblyClient) GetReorgStatus(ctx context.Context) (*blockassembly_api.GetReorgStatusResponse, error) {
+	return nil, nil
+}
 func (m *mockBlockAssemblyClient) GetBlockAssemblyBlockCandidate(ctx context.Context) (*model.Block, error) {
 	return nil, nil
 }
@@ -5708,6 +5800,14 @@ func (m *mockBlockAssemblyClient) GetTransactionHashes(ctx context.Context) ([]s
 	return nil, nil
 }
 
+func (m *mockBlockAssemblyClient) GetCoinbaseTemplate(ctx context.Context) (*model.CoinbaseTemplate, error) {
+	return nil, nil
+}
+
+func (m *mockBlockAssemblyClient) SetCoinbaseTemplate(ctx context.Context, template *model.CoinbaseTemplate) (*model.CoinbaseTemplate, error) {
+	return template, nil
+}
+
 // TestHandleGetMiningInfoComprehensive tests the complete handleGetMiningInfo functionality
 func TestHandleGetMiningInfoComprehensive(t *testing.T) {
 	logger := mocklogger.NewTestLogger()
@@ -153,3 +153,28 @@ func (s *Client) CheckBlockSubtrees(ctx context.Context, block *model.Block, pee
 
 	return nil
 }
+
+// ResizeWorkerPools changes the number of workers of the pools validating the subtrees of a block, 0 leaves a pool
+// unchanged. Returns the state of the pools after the resize.
+func (s *Client) ResizeWorkerPools(ctx context.Context, fetchWorkers, validateWorkers uint32) ([]WorkerPoolStatus, error) {
+	resp, err := s.apiClient.ResizeWorkerPools(ctx, &subtreevalidation_api.ResizeWorkerPoolsRequest{
+		FetchWorkers:    fetchWorkers,
+		ValidateWorkers: validateWorkers,
+	})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	pools := make([]WorkerPoolStatus, 0, len(resp.GetPools()))
+
+	for _, pool := range resp.GetPools() {
+		pools = append(pools, WorkerPoolStatus{
+			Name:   pool.GetName(),
+			Size:   int(pool.GetSize()),
+			Active: int(pool.GetActive()),
+			Queued: int(pool.GetQueued()),
+		})
+	}
+
+	return pools, nil
+}
@@ -98,6 +98,19 @@ type Interface interface {
 	// Returns:
 	//   - error: Any error encountered during validation, nil if successful
 	CheckBlockSubtrees(ctx context.Context, block *model.Block, peerID, baseURL string) error
+
+	// ResizeWorkerPools changes the number of workers of the pools validating the subtrees of a block without a
+	// restart, so operators can tune the CPU usage of block validation.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and tracing
+	//   - fetchWorkers: Number of subtrees of a block fetched concurrently, 0 leaves the pool unchanged
+	//   - validateWorkers: Number of subtrees of a block validated concurrently, 0 leaves the pool unchanged
+	//
+	// Returns:
+	//   - []WorkerPoolStatus: Size, busy workers and waiting subtrees of every pool after the resize
+	//   - error: Any error encountered while resizing the pools
+	ResizeWorkerPools(ctx context.Context, fetchWorkers, validateWorkers uint32) ([]WorkerPoolStatus, error)
 }
 
 var _ Interface = &MockSubtreeValidation{}
@@ -128,3 +141,12 @@ func (mv *MockSubtreeValidation) CheckBlockSubtrees(ctx context.Context, block *
 	args := mv.Called(ctx, block, peerID, baseURL)
 	return args.Error(0)
 }
+
+func (mv *MockSubtreeValidation) ResizeWorkerPools(ctx context.Context, fetchWorkers, validateWorkers uint32) ([]WorkerPoolStatus, error) {
+	args := mv.Called(ctx, fetchWorkers, validateWorkers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]WorkerPoolStatus), args.Error(1)
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/utxo"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/bandwidth"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
 	"github.com/bsv-blockchain/teranode/util/health"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
@@ -34,7 +36,6 @@ import (
 	"github.com/ordishs/gocore"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -134,6 +135,15 @@ type Server struct {
 	// p2pClient interfaces with the P2P service
 	// Used to report successful subtree fetches to improve peer reputation
 	p2pClient P2PClientI
+
+	// bandwidth is the shared bandwidth budget subtree fetches are accounted to
+	bandwidth *bandwidth.Scheduler
+
+	// fetchPool and validatePool bound the subtrees of a block fetched and validated concurrently by
+	// CheckBlockSubtrees, they can be resized at runtime through ResizeWorkerPools, see workerPools
+	workerPoolsOnce sync.Once
+	fetchPool       *workerPool
+	validatePool    *workerPool
 }
 
 var (
@@ -197,8 +207,11 @@ func New(
 		txmetaConsumerClient:              txmetaConsumerClient,
 		invalidSubtreeDeDuplicateMap:      expiringmap.New[string, struct{}](time.Minute * 1),
 		p2pClient:                         p2pClient,
+		bandwidth:                         bandwidth.Default(),
 	}
 
+	u.bandwidth.Configure(bandwidth.ConfigFromSettings(tSettings))
+
 	var err error
 
 	// Initialize orphanage
@@ -452,6 +465,7 @@ func (u *Server) HealthGRPC(ctx context.Context, _ *subtreevalidation_api.EmptyM
 		Ok:        status == http.StatusOK,
 		Details:   details,
 		Timestamp: timestamppb.Now(),
+		BuildInfo: buildinfo.Get(u.settings).JSON(),
 	}, errors.WrapGRPC(err)
 }
 
@@ -518,7 +532,8 @@ func (u *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
 	}
 
 	// start kafka consumers
-	u.subtreeConsumerClient.Start(ctx, u.subtreeMessageHandler(ctx), kafka.WithLogErrorAndMoveOn())
+	u.subtreeConsumerClient.Start(ctx, u.subtreeMessageHandler(ctx), kafka.WithLogErrorAndMoveOn(),
+		kafka.WithWorkers(u.settings.SubtreeValidation.KafkaWorkers))
 	u.txmetaConsumerClient.Start(ctx, u.txmetaMessageHandler(ctx), kafka.WithLogErrorAndMoveOn())
 
 	// this will block
@@ -943,7 +958,7 @@ func (u *Server) publishInvalidSubtree(ctx context.Context, subtreeHash, peerURL
 		Reason:      reason,
 	}
 
-	msgBytes, err := proto.Marshal(msg)
+	msgBytes, err := kafkamessage.Encode(msg)
 	if err != nil {
 		u.logger.Errorf("[publishInvalidSubtree] failed to marshal invalid subtree message: %v", err)
 		return
@@ -25,7 +25,6 @@ import (
 	"github.com/ordishs/gocore"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/proto"
 )
 
 // setupMemoryKafkaConsumer creates a memory Kafka consumer for testing
@@ -611,7 +610,7 @@ func TestPublishInvalidSubtree(t *testing.T) {
 
 		// Verify the message content
 		var msg kafkamessage.KafkaInvalidSubtreeTopicMessage
-		err := proto.Unmarshal(publishedMsg.Value, &msg)
+		err := kafkamessage.Decode(publishedMsg.Value, &msg)
 		require.NoError(t, err)
 		require.Equal(t, "hash123", msg.SubtreeHash)
 		require.Equal(t, "peer456", msg.PeerUrl)
@@ -51,12 +51,14 @@ import (
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/pkg/fileformat"
 	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/services/p2p"
 	"github.com/bsv-blockchain/teranode/services/validator"
 	"github.com/bsv-blockchain/teranode/stores/blob/options"
 	"github.com/bsv-blockchain/teranode/stores/txmetacache"
 	"github.com/bsv-blockchain/teranode/stores/utxo"
 	"github.com/bsv-blockchain/teranode/stores/utxo/meta"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/bandwidth"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/ordishs/gocore"
 	"golang.org/x/sync/errgroup"
@@ -214,12 +216,24 @@ func (u *Server) DelTxMetaCacheMulti(ctx context.Context, hash *chainhash.Hash)
 //   - []*bt.Tx: Slice of retrieved transactions
 //   - error: Any error encountered during retrieval
 func (u *Server) getMissingTransactionsBatch(ctx context.Context, subtreeHash chainhash.Hash, txHashes []utxo.UnresolvedMetaData, baseURL string) ([]*bt.Tx, error) {
+	missingTxs, invalidReason, err := u.fetchMissingTransactionsBatch(ctx, subtreeHash, txHashes, baseURL)
+	if invalidReason != "" {
+		u.publishInvalidSubtree(ctx, subtreeHash.String(), baseURL, invalidReason)
+	}
+
+	return missingTxs, err
+}
+
+// fetchMissingTransactionsBatch retrieves a batch of transactions from the network, like getMissingTransactionsBatch,
+// without reporting the subtree as invalid. When the peer fails to provide the transactions, the reason to report
+// the subtree of the peer as invalid is returned with the error.
+func (u *Server) fetchMissingTransactionsBatch(ctx context.Context, subtreeHash chainhash.Hash, txHashes []utxo.UnresolvedMetaData, baseURL string) ([]*bt.Tx, string, error) {
 	// Validate that baseURL is a proper HTTP/HTTPS URL and not a peer ID
 	parsedURL, err := url.Parse(baseURL)
 	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
 		u.logger.Errorf("[getMissingTransactionsBatch][%s] Invalid baseURL '%s' - must be valid http/https URL, not peer ID",
 			subtreeHash.String(), baseURL)
-		return nil, errors.NewExternalError("[getMissingTransactionsBatch][%s] invalid baseURL - not a valid http/https URL", subtreeHash.String())
+		return nil, "", errors.NewExternalError("[getMissingTransactionsBatch][%s] invalid baseURL - not a valid http/https URL", subtreeHash.String())
 	}
 
 	log := false
@@ -247,10 +261,10 @@ func (u *Server) getMissingTransactionsBatch(ctx context.Context, subtreeHash ch
 	body, err := util.DoHTTPRequestBodyReader(ctx, url, txIDBytes)
 	if err != nil {
 		// Peer cannot provide requested transactions - report as invalid subtree
-		u.publishInvalidSubtree(ctx, subtreeHash.String(), baseURL, "peer_cannot_provide_transactions")
-		return nil, errors.NewExternalError("[getMissingTransactionsBatch][%s] failed to do http request", subtreeHash.String(), err)
+		return nil, "peer_cannot_provide_transactions", errors.NewExternalError("[getMissingTransactionsBatch][%s] failed to do http request", subtreeHash.String(), err)
 	}
 
+	body = bandwidth.NewReadCloser(ctx, u.bandwidth, bandwidth.ClassSubtree, body)
 	defer body.Close()
 
 	// read the body into transactions using go-bt
@@ -265,9 +279,8 @@ func (u *Server) getMissingTransactionsBatch(ctx context.Context, subtreeHash ch
 				break
 			}
 			// Malformed transaction data from peer - report as invalid subtree
-			u.publishInvalidSubtree(ctx, subtreeHash.String(), baseURL, "malformed_transaction_data")
 			// Not recoverable, returning processing error
-			return nil, errors.NewProcessingError("[getMissingTransactionsBatch][%s] failed to read transaction from body", subtreeHash.String(), err)
+			return nil, "malformed_transaction_data", errors.NewProcessingError("[getMissingTransactionsBatch][%s] failed to read transaction from body", subtreeHash.String(), err)
 		}
 
 		missingTxs = append(missingTxs, tx)
@@ -275,11 +288,10 @@ func (u *Server) getMissingTransactionsBatch(ctx context.Context, subtreeHash ch
 
 	if len(missingTxs) != len(txHashes) {
 		// Peer sent wrong number of transactions - report as invalid subtree
-		u.publishInvalidSubtree(ctx, subtreeHash.String(), baseURL, "transaction_count_mismatch")
-		return nil, errors.NewProcessingError("[getMissingTransactionsBatch][%s] missing tx count mismatch: missing=%d, txHashes=%d", subtreeHash.String(), len(missingTxs), len(txHashes))
+		return nil, "transaction_count_mismatch", errors.NewProcessingError("[getMissingTransactionsBatch][%s] missing tx count mismatch: missing=%d, txHashes=%d", subtreeHash.String(), len(missingTxs), len(txHashes))
 	}
 
-	return missingTxs, nil
+	return missingTxs, "", nil
 }
 
 // readTxFromReader reads and validates a single transaction from an io.ReadCloser.
@@ -554,6 +566,13 @@ func (u *Server) ValidateSubtreeInternal(ctx context.Context, v ValidateSubtree,
 		endSpan(err)
 	}()
 
+	if v.PeerID != "" {
+		// attribute the transactions of the subtree to the peer, to report the peer with their double spends,
+		// copying the options since the callers share them between subtrees
+		peerOptions := make([]validator.Option, 0, len(validationOptions)+1)
+		validationOptions = append(append(peerOptions, validationOptions...), validator.WithPeerID(v.PeerID))
+	}
+
 	start := gocore.CurrentTime()
 
 	// Get the subtree hashes if they were passed in
@@ -697,6 +716,7 @@ func (u *Server) ValidateSubtreeInternal(ctx context.Context, v ValidateSubtree,
 				missingTxHashesCompacted,
 				txHashes,
 				v.BaseURL,
+				v.PeerID,
 				txMetaSlice,
 				blockHeight,
 				blockIds,
@@ -766,7 +786,15 @@ func (u *Server) ValidateSubtreeInternal(ctx context.Context, v ValidateSubtree,
 	// does the merkle tree give the correct root?
 	merkleRoot := subtree.RootHash()
 	if !merkleRoot.IsEqual(&v.SubtreeHash) {
-		return nil, errors.NewSubtreeInvalidError("subtree root hash does not match", err)
+		err = errors.NewSubtreeInvalidError("subtree root hash does not match", err)
+
+		// the transaction hashes of the subtree were received from the peer
+		if v.PeerID != "" {
+			err = errors.NewPeerAttributableError(v.PeerID, errors.PeerSeverityMedium, 0, "[ValidateSubtreeInternal][%s] subtree from peer %s does not match its hash",
+				v.SubtreeHash.String(), v.PeerID, err)
+		}
+
+		return nil, err
 	}
 
 	//
@@ -888,6 +916,8 @@ func (u *Server) getSubtreeTxHashes(spanCtx context.Context, stat *gocore.Stat,
 
 		return nil, errors.NewExternalError("[getSubtreeTxHashes][%s] failed to do http request on host %s", subtreeHash.String(), baseURL, err)
 	}
+
+	body = bandwidth.NewReadCloser(spanCtx, u.bandwidth, bandwidth.ClassSubtree, body)
 	defer body.Close()
 
 	stat.NewStat("2. http fetch subtree").AddTime(start)
@@ -966,6 +996,7 @@ func (u *Server) getSubtreeTxHashes(spanCtx context.Context, stat *gocore.Stat,
 //   - missingTxHashes: List of transaction hashes that need to be retrieved and validated
 //   - allTxs: Complete list of all transaction hashes in the subtree
 //   - baseURL: Source URL for retrieving missing transactions
+//   - peerID: ID of the peer the subtree was received from, invalid transactions are attributed to it
 //   - txMetaSlice: Pre-allocated slice to store transaction metadata results
 //   - blockHeight: Height of the block containing the subtree
 //   - blockIds: Map of block IDs to check if transactions are already mined
@@ -974,7 +1005,7 @@ func (u *Server) getSubtreeTxHashes(spanCtx context.Context, stat *gocore.Stat,
 // Returns:
 //   - error: Any error encountered during retrieval or validation
 func (u *Server) processMissingTransactions(ctx context.Context, subtreeHash chainhash.Hash, subtree *subtreepkg.Subtree,
-	missingTxHashes []utxo.UnresolvedMetaData, allTxs []chainhash.Hash, baseURL string, txMetaSlice []*meta.Data, blockHeight uint32,
+	missingTxHashes []utxo.UnresolvedMetaData, allTxs []chainhash.Hash, baseURL string, peerID string, txMetaSlice []*meta.Data, blockHeight uint32,
 	blockIds map[uint32]bool, validationOptions ...validator.Option) (err error) {
 	ctx, _, deferFn := tracing.Tracer("subtreevalidation").Start(ctx, "SubtreeValidation:processMissingTransactions",
 		tracing.WithDebugLogMessage(u.logger, "[processMissingTransactions][%s] processing %d missing txs", subtreeHash.String(), len(missingTxHashes)),
@@ -1065,10 +1096,14 @@ func (u *Server) processMissingTransactions(ctx context.Context, subtreeHash cha
 						// Report invalid subtree - contains truly invalid transaction
 						u.publishInvalidSubtree(gCtx, subtreeHash.String(), baseURL, "contains_invalid_transaction")
 
-						// return the error, so that the caller can handle it
-						if errors.Is(err, errors.ErrTxInvalid) {
-							return err
+						// the peer that sent the subtree is held responsible for the invalid transaction
+						if peerID != "" {
+							err = errors.NewPeerAttributableError(peerID, errors.PeerSeverityHigh, 0, "[validateSubtree][%s] subtree from peer %s contains invalid transaction %s",
+								subtreeHash.String(), peerID, tx.TxIDChainHash().String(), err)
 						}
+
+						// return the error, so that the caller can handle it
+						return err
 					} else {
 						// If the error is not a policy error, we log it as a processing error
 						u.logger.Errorf("[validateSubtree][%s] failed to bless missing transaction: %s: %v", subtreeHash.String(), tx.TxIDChainHash().String(), err)
@@ -1176,6 +1211,8 @@ func (u *Server) getSubtreeMissingTxs(ctx context.Context, subtreeHash chainhash
 				u.publishInvalidSubtree(ctx, subtreeHash.String(), baseURL, "peer_cannot_provide_subtree_data")
 				u.logger.Errorf("[validateSubtree][%s] failed to get subtree data from %s: %v", subtreeHash.String(), url, subtreeDataErr)
 			} else {
+				body = bandwidth.NewReadCloser(ctx, u.bandwidth, bandwidth.ClassSubtree, body)
+
 				// Build subtree structure from allTxs for deserialization
 				// We cannot use the empty 'subtree' parameter as it has no nodes yet
 				subtreeForData, buildErr := subtreepkg.NewIncompleteTreeByLeafCount(len(allTxs))
@@ -1576,6 +1613,9 @@ func (u *Server) getMissingTransactionsFromFile(ctx context.Context, subtreeHash
 	return missingTxs, nil
 }
 
+// getMissingTransactionsFromPeer retrieves the missing transactions from the peer at baseURL, in parallel batches.
+// The batches the peer fails to provide are requested from other high-reputation peers in the peer registry
+// before the subtree is reported as invalid.
 func (u *Server) getMissingTransactionsFromPeer(ctx context.Context, subtreeHash chainhash.Hash, missingTxHashes []utxo.UnresolvedMetaData,
 	baseURL string) (missingTxs []missingTx, err error) {
 	// transactions have to be returned in the same order as they were requested
@@ -1587,6 +1627,11 @@ func (u *Server) getMissingTransactionsFromPeer(ctx context.Context, subtreeHash
 	g, gCtx := errgroup.WithContext(ctx)
 	util.SafeSetLimit(g, getMissingTransactionsConcurrency) // keep 32 cores free for other tasks
 
+	// the other peers are only looked up when the announcing peer fails to provide a batch
+	alternatePeers := sync.OnceValue(func() []*p2p.PeerInfo {
+		return u.selectAlternatePeers(ctx, subtreeHash, baseURL)
+	})
+
 	// get the transactions in batches of 500
 	batchSize := u.settings.SubtreeValidation.MissingTransactionsBatchSize
 
@@ -1594,7 +1639,7 @@ func (u *Server) getMissingTransactionsFromPeer(ctx context.Context, subtreeHash
 		missingTxHashesBatch := missingTxHashes[i:subtreepkg.Min(i+batchSize, len(missingTxHashes))]
 
 		g.Go(func() error {
-			missingTxsBatch, err := u.getMissingTransactionsBatch(gCtx, subtreeHash, missingTxHashesBatch, baseURL)
+			missingTxsBatch, err := u.getMissingTransactionsBatchWithFallback(gCtx, subtreeHash, missingTxHashesBatch, baseURL, alternatePeers)
 			if err != nil {
 				return errors.NewProcessingError("[getMissingTransactionsFromPeer][%s] failed to get missing transactions batch", subtreeHash.String(), err)
 			}
@@ -0,0 +1,134 @@
+package subtreevalidation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/stores/utxo"
+)
+
+// selectAlternatePeers returns the peers, other than the announcing peer at baseURL, the missing transactions of a
+// subtree can be requested from when the announcing peer cannot provide them. These are the peers of the registry
+// with at least the configured reputation score, in the order of the registry, up to the configured number of peers.
+func (u *Server) selectAlternatePeers(ctx context.Context, subtreeHash chainhash.Hash, baseURL string) []*p2p.PeerInfo {
+	count := u.settings.SubtreeValidation.MissingTransactionsAlternatePeers
+	if u.p2pClient == nil || count <= 0 {
+		return nil
+	}
+
+	peers, err := u.p2pClient.GetPeersForCatchup(ctx)
+	if err != nil {
+		u.logger.Warnf("[selectAlternatePeers][%s] failed to get peers from the peer registry: %v", subtreeHash.String(), err)
+		return nil
+	}
+
+	selected := make([]*p2p.PeerInfo, 0, count)
+
+	for _, p := range peers {
+		if len(selected) == count {
+			break
+		}
+
+		if p.DataHubURL == "" || strings.TrimSuffix(p.DataHubURL, "/") == strings.TrimSuffix(baseURL, "/") {
+			continue
+		}
+
+		if _, blacklisted := u.settings.SubtreeValidation.BlacklistedBaseURLs[p.DataHubURL]; blacklisted {
+			continue
+		}
+
+		if p.ReputationScore < u.settings.SubtreeValidation.AlternatePeerMinReputation {
+			continue
+		}
+
+		selected = append(selected, p)
+	}
+
+	return selected
+}
+
+// getMissingTransactionsBatchWithFallback retrieves a batch of transactions from the announcing peer at baseURL,
+// falling back to the alternate peers, one after the other, when the announcing peer cannot provide the batch.
+// The subtree is only reported as invalid when none of the peers provided the batch.
+//
+// Parameters:
+//   - ctx: Context for cancellation and tracing
+//   - subtreeHash: Hash of the subtree containing the transactions
+//   - txHashes: Slice of transaction hashes to retrieve
+//   - baseURL: URL of the peer that announced the subtree
+//   - alternatePeers: Returns the peers to fall back to, only called when the announcing peer fails
+//
+// Returns:
+//   - []*bt.Tx: Slice of retrieved transactions, not necessarily in the order of the hashes
+//   - error: The error of the announcing peer when no peer provided the batch
+func (u *Server) getMissingTransactionsBatchWithFallback(ctx context.Context, subtreeHash chainhash.Hash, txHashes []utxo.UnresolvedMetaData,
+	baseURL string, alternatePeers func() []*p2p.PeerInfo) ([]*bt.Tx, error) {
+	missingTxs, invalidReason, err := u.fetchMissingTransactionsBatch(ctx, subtreeHash, txHashes, baseURL)
+	if err == nil {
+		return missingTxs, nil
+	}
+
+	if ctx.Err() == nil {
+		for _, p := range alternatePeers() {
+			alternateTxs, _, alternateErr := u.fetchMissingTransactionsBatch(ctx, subtreeHash, txHashes, p.DataHubURL)
+			if alternateErr == nil && !matchesTxHashes(alternateTxs, txHashes) {
+				alternateErr = errors.NewProcessingError("[getMissingTransactionsBatchWithFallback][%s] peer %s returned other transactions than requested", subtreeHash.String(), p.ID)
+			}
+
+			if alternateErr != nil {
+				prometheusSubtreeValidationAlternatePeerBatches.WithLabelValues("failed").Inc()
+
+				u.logger.Debugf("[getMissingTransactionsBatchWithFallback][%s] alternate peer %s failed to provide %d transactions: %v", subtreeHash.String(), p.ID, len(txHashes), alternateErr)
+
+				if ctx.Err() != nil {
+					break
+				}
+
+				continue
+			}
+
+			prometheusSubtreeValidationAlternatePeerBatches.WithLabelValues("provided").Inc()
+
+			u.logger.Infof("[getMissingTransactionsBatchWithFallback][%s] got %d transactions %s could not provide from alternate peer %s", subtreeHash.String(), len(txHashes), baseURL, p.ID)
+
+			return alternateTxs, nil
+		}
+	}
+
+	if invalidReason != "" {
+		u.publishInvalidSubtree(ctx, subtreeHash.String(), baseURL, invalidReason)
+	}
+
+	return nil, err
+}
+
+// matchesTxHashes returns whether the transactions are the transactions of the hashes, in any order
+func matchesTxHashes(txs []*bt.Tx, txHashes []utxo.UnresolvedMetaData) bool {
+	if len(txs) != len(txHashes) {
+		return false
+	}
+
+	requested := make(map[chainhash.Hash]struct{}, len(txHashes))
+	for _, txHash := range txHashes {
+		requested[txHash.Hash] = struct{}{}
+	}
+
+	for _, tx := range txs {
+		if tx == nil {
+			return false
+		}
+
+		if _, ok := requested[*tx.TxIDChainHash()]; !ok {
+			return false
+		}
+
+		// every transaction must only be returned once
+		delete(requested, *tx.TxIDChainHash())
+	}
+
+	return true
+}
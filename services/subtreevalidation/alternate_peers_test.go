@@ -0,0 +1,231 @@
+package subtreevalidation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/stores/blob/memory"
+	"github.com/bsv-blockchain/teranode/stores/utxo"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/jarcoal/httpmock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/ordishs/go-utils/expiringmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPeerRegistryClient returns a fixed list of peers from the peer registry and records the ban scores added to peers
+type mockPeerRegistryClient struct {
+	peers  []*p2p.PeerInfo
+	calls  int
+	banned []string
+}
+
+func (m *mockPeerRegistryClient) ReportValidSubtree(_ context.Context, _ string, _ string) error {
+	return nil
+}
+
+func (m *mockPeerRegistryClient) AddBanScorePoints(_ context.Context, peerID string, reason string, points int) error {
+	m.banned = append(m.banned, fmt.Sprintf("%s:%s:%d", peerID, reason, points))
+	return nil
+}
+
+func (m *mockPeerRegistryClient) RecordDataDownloaded(_ context.Context, _ string, _ string, _ uint64, _ uint64) error {
+	return nil
+}
+
+func (m *mockPeerRegistryClient) GetPeersForCatchup(_ context.Context) ([]*p2p.PeerInfo, error) {
+	m.calls++
+	return m.peers, nil
+}
+
+func TestSelectAlternatePeers(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.SubtreeValidation.MissingTransactionsAlternatePeers = 2
+	tSettings.SubtreeValidation.AlternatePeerMinReputation = 60
+	tSettings.SubtreeValidation.BlacklistedBaseURLs = map[string]struct{}{"http://blacklisted.com": {}}
+
+	p2pClient := &mockPeerRegistryClient{
+		peers: []*p2p.PeerInfo{
+			{ID: peer.ID("announcer"), DataHubURL: testPeerURL + "/", ReputationScore: 90},
+			{ID: peer.ID("no-url"), ReputationScore: 90},
+			{ID: peer.ID("blacklisted"), DataHubURL: "http://blacklisted.com", ReputationScore: 90},
+			{ID: peer.ID("low-reputation"), DataHubURL: "http://low-reputation.com", ReputationScore: 59},
+			{ID: peer.ID("peer-1"), DataHubURL: "http://peer-1.com", ReputationScore: 80},
+			{ID: peer.ID("peer-2"), DataHubURL: "http://peer-2.com", ReputationScore: 60},
+			{ID: peer.ID("peer-3"), DataHubURL: "http://peer-3.com", ReputationScore: 95},
+		},
+	}
+
+	server := &Server{
+		logger:    ulogger.TestLogger{},
+		settings:  tSettings,
+		p2pClient: p2pClient,
+	}
+
+	selected := server.selectAlternatePeers(context.Background(), chainhash.Hash{}, testPeerURL)
+	require.Len(t, selected, 2)
+	assert.Equal(t, peer.ID("peer-1"), selected[0].ID)
+	assert.Equal(t, peer.ID("peer-2"), selected[1].ID)
+
+	t.Run("disabled", func(t *testing.T) {
+		tSettings.SubtreeValidation.MissingTransactionsAlternatePeers = 0
+		defer func() {
+			tSettings.SubtreeValidation.MissingTransactionsAlternatePeers = 2
+		}()
+
+		p2pClient.calls = 0
+
+		assert.Empty(t, server.selectAlternatePeers(context.Background(), chainhash.Hash{}, testPeerURL))
+		assert.Equal(t, 0, p2pClient.calls)
+	})
+
+	t.Run("without p2p client", func(t *testing.T) {
+		server := &Server{
+			logger:   ulogger.TestLogger{},
+			settings: tSettings,
+		}
+
+		assert.Empty(t, server.selectAlternatePeers(context.Background(), chainhash.Hash{}, testPeerURL))
+	})
+}
+
+func TestGetMissingTransactionsFromPeer_AlternatePeers(t *testing.T) {
+	InitPrometheusMetrics()
+
+	tx, err := bt.NewTxFromString("010000000000000000ef0152a9231baa4e4b05dc30c8fbb7787bab5f460d4d33b039c39dd8cc006f3363e4020000006b483045022100ce3605307dd1633d3c14de4a0cf0df1439f392994e561b648897c4e540baa9ad02207af74878a7575a95c9599e9cdc7e6d73308608ee59abcd90af3ea1a5c0cca41541210275f8390df62d1e951920b623b8ef9c2a67c4d2574d408e422fb334dd1f3ee5b6ffffffff706b9600000000001976a914a32f7eaae3afd5f73a2d6009b93f91aa11d16eef88ac05404b4c00000000001976a914aabb8c2f08567e2d29e3a64f1f833eee85aaf74d88ac80841e00000000001976a914a4aff400bef2fa074169453e703c611c6b9df51588ac204e0000000000001976a9144669d92d46393c38594b2f07587f01b3e5289f6088ac204e0000000000001976a914a461497034343a91683e86b568c8945fb73aca0288ac99fe2a00000000001976a914de7850e419719258077abd37d4fcccdb0a659b9388ac00000000")
+	require.NoError(t, err)
+
+	otherTx, err := bt.NewTxFromString(model.CoinbaseHex)
+	require.NoError(t, err)
+
+	subtreeHash := chainhash.HashH([]byte("test-subtree"))
+	missingTxHashes := []utxo.UnresolvedMetaData{
+		{Hash: *tx.TxIDChainHash(), Idx: 3},
+	}
+
+	txsURL := func(baseURL string) string {
+		return fmt.Sprintf("%s/subtree/%s/txs", baseURL, subtreeHash.String())
+	}
+
+	newServer := func(t *testing.T, peers ...*p2p.PeerInfo) (*Server, *mockKafkaProducer) {
+		tSettings := test.CreateBaseTestSettings(t)
+		tSettings.SubtreeValidation.MissingTransactionsAlternatePeers = 3
+		tSettings.SubtreeValidation.AlternatePeerMinReputation = 60
+
+		kafkaProducer := &mockKafkaProducer{}
+
+		return &Server{
+			logger:                       ulogger.TestLogger{},
+			settings:                     tSettings,
+			subtreeStore:                 memory.New(),
+			invalidSubtreeKafkaProducer:  kafkaProducer,
+			invalidSubtreeDeDuplicateMap: expiringmap.New[string, struct{}](time.Minute * 1),
+			p2pClient:                    &mockPeerRegistryClient{peers: peers},
+		}, kafkaProducer
+	}
+
+	t.Run("announcing peer provides the transactions", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		server, kafkaProducer := newServer(t)
+		p2pClient := server.p2pClient.(*mockPeerRegistryClient)
+
+		httpmock.RegisterResponder("POST", txsURL(testPeerURL), httpmock.NewBytesResponder(http.StatusOK, tx.ExtendedBytes()))
+
+		missingTxs, err := server.getMissingTransactionsFromPeer(context.Background(), subtreeHash, missingTxHashes, testPeerURL)
+		require.NoError(t, err)
+		require.Len(t, missingTxs, 1)
+
+		// the peer registry is only queried when the announcing peer fails
+		assert.Equal(t, 0, p2pClient.calls)
+		assert.Empty(t, kafkaProducer.messages)
+	})
+
+	t.Run("alternate peer provides the transactions", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		server, kafkaProducer := newServer(t,
+			&p2p.PeerInfo{ID: peer.ID("unreachable"), DataHubURL: "http://unreachable.com", ReputationScore: 90},
+			&p2p.PeerInfo{ID: peer.ID("wrong-txs"), DataHubURL: "http://wrong-txs.com", ReputationScore: 90},
+			&p2p.PeerInfo{ID: peer.ID("good"), DataHubURL: "http://good.com", ReputationScore: 70},
+		)
+
+		httpmock.RegisterResponder("POST", txsURL(testPeerURL), httpmock.NewStringResponder(http.StatusNotFound, "not found"))
+		httpmock.RegisterResponder("POST", txsURL("http://unreachable.com"), httpmock.NewStringResponder(http.StatusInternalServerError, "error"))
+		httpmock.RegisterResponder("POST", txsURL("http://wrong-txs.com"), httpmock.NewBytesResponder(http.StatusOK, otherTx.Bytes()))
+		httpmock.RegisterResponder("POST", txsURL("http://good.com"), httpmock.NewBytesResponder(http.StatusOK, tx.ExtendedBytes()))
+
+		missingTxs, err := server.getMissingTransactionsFromPeer(context.Background(), subtreeHash, missingTxHashes, testPeerURL)
+		require.NoError(t, err)
+		require.Len(t, missingTxs, 1)
+		assert.Equal(t, tx.TxIDChainHash(), missingTxs[0].tx.TxIDChainHash())
+		assert.Equal(t, 3, missingTxs[0].idx)
+
+		assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST "+txsURL("http://good.com")])
+
+		// the subtree is not reported as invalid and the alternate peers are not blamed
+		assert.Empty(t, kafkaProducer.messages)
+	})
+
+	t.Run("no peer provides the transactions", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		server, kafkaProducer := newServer(t,
+			&p2p.PeerInfo{ID: peer.ID("unreachable"), DataHubURL: "http://unreachable.com", ReputationScore: 90},
+			&p2p.PeerInfo{ID: peer.ID("low-reputation"), DataHubURL: "http://low-reputation.com", ReputationScore: 10},
+		)
+
+		httpmock.RegisterResponder("POST", txsURL(testPeerURL), httpmock.NewStringResponder(http.StatusNotFound, "not found"))
+		httpmock.RegisterResponder("POST", txsURL("http://unreachable.com"), httpmock.NewStringResponder(http.StatusInternalServerError, "error"))
+		httpmock.RegisterResponder("POST", txsURL("http://low-reputation.com"), httpmock.NewBytesResponder(http.StatusOK, tx.ExtendedBytes()))
+
+		_, err := server.getMissingTransactionsFromPeer(context.Background(), subtreeHash, missingTxHashes, testPeerURL)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrExternal))
+
+		assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST "+txsURL("http://unreachable.com")])
+		assert.Equal(t, 0, httpmock.GetCallCountInfo()["POST "+txsURL("http://low-reputation.com")])
+
+		// the subtree of the announcing peer is reported as invalid
+		require.Len(t, kafkaProducer.messages, 1)
+
+		var msg kafkamessage.KafkaInvalidSubtreeTopicMessage
+		require.NoError(t, kafkamessage.Decode(kafkaProducer.messages[0].Value, &msg))
+
+		assert.Equal(t, subtreeHash.String(), msg.SubtreeHash)
+		assert.Equal(t, testPeerURL, msg.PeerUrl)
+		assert.Equal(t, "peer_cannot_provide_transactions", msg.Reason)
+	})
+}
+
+func TestMatchesTxHashes(t *testing.T) {
+	tx1, err := bt.NewTxFromString(model.CoinbaseHex)
+	require.NoError(t, err)
+
+	tx2 := tx1.Clone()
+	tx2.LockTime = 1
+
+	txHashes := []utxo.UnresolvedMetaData{
+		{Hash: *tx1.TxIDChainHash()},
+		{Hash: *tx2.TxIDChainHash()},
+	}
+
+	assert.True(t, matchesTxHashes([]*bt.Tx{tx2, tx1}, txHashes))
+	assert.False(t, matchesTxHashes([]*bt.Tx{tx1}, txHashes))
+	assert.False(t, matchesTxHashes([]*bt.Tx{tx1, tx1}, txHashes))
+	assert.False(t, matchesTxHashes([]*bt.Tx{tx1, nil}, txHashes))
+}
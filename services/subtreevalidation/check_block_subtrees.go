@@ -20,6 +20,8 @@ import (
 	"github.com/bsv-blockchain/teranode/services/validator"
 	"github.com/bsv-blockchain/teranode/stores/blob/options"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/bandwidth"
+	"github.com/bsv-blockchain/teranode/util/blocktrace"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"golang.org/x/sync/errgroup"
 )
@@ -168,6 +170,8 @@ func (u *Server) CheckBlockSubtrees(ctx context.Context, request *subtreevalidat
 	}
 
 	if len(missingSubtrees) == 0 {
+		blocktrace.Record(ctx, u.logger, block.Hash(), blocktrace.StageSubtreesValidated)
+
 		return &subtreevalidation_api.CheckBlockSubtreesResponse{
 			Blessed: true,
 		}, nil
@@ -179,9 +183,10 @@ func (u *Server) CheckBlockSubtrees(ctx context.Context, request *subtreevalidat
 		allTransactions = make([]*bt.Tx, 0, block.TransactionCount)
 	)
 
-	// get all the subtrees that are missing from the peer in parallel
+	// get all the subtrees that are missing from the peer in parallel, bounded by the fetch worker pool
+	fetchPool, validatePool := u.workerPools()
+
 	g, gCtx := errgroup.WithContext(ctx)
-	util.SafeSetLimit(g, u.settings.SubtreeValidation.CheckBlockSubtreesConcurrency)
 
 	dah := u.utxoStore.GetBlockHeight() + u.settings.GetSubtreeValidationBlockHeightRetention()
 
@@ -192,6 +197,11 @@ func (u *Server) CheckBlockSubtrees(ctx context.Context, request *subtreevalidat
 		subtreeTxs[subtreeIdx] = make([]*bt.Tx, 0, 1024) // Pre-allocate space for transactions in this subtree
 
 		g.Go(func() (err error) {
+			if err = fetchPool.acquire(gCtx); err != nil {
+				return err
+			}
+			defer fetchPool.release()
+
 			subtreeToCheckExists, err := u.subtreeStore.Exists(gCtx, subtreeHash[:], fileformat.FileTypeSubtreeToCheck)
 			if err != nil {
 				return errors.NewProcessingError("[CheckBlockSubtrees][%s] failed to check if subtree exists in store", subtreeHash.String(), err)
@@ -228,9 +238,13 @@ func (u *Server) CheckBlockSubtrees(ctx context.Context, request *subtreevalidat
 					return errors.NewServiceError("[CheckBlockSubtrees][%s] failed to get subtree from %s", subtreeHash.String(), url, err)
 				}
 
+				if err = u.bandwidth.Wait(gCtx, bandwidth.ClassSubtree, len(subtreeNodeBytes)); err != nil {
+					return errors.NewServiceError("[CheckBlockSubtrees][%s] failed to wait for bandwidth", subtreeHash.String(), err)
+				}
+
 				// Track bytes downloaded from peer
 				if u.p2pClient != nil && peerID != "" {
-					if err := u.p2pClient.RecordBytesDownloaded(gCtx, peerID, uint64(len(subtreeNodeBytes))); err != nil {
+					if err := u.p2pClient.RecordDataDownloaded(gCtx, peerID, peerDataTypeSubtree, 1, uint64(len(subtreeNodeBytes))); err != nil {
 						u.logger.Warnf("[CheckBlockSubtrees][%s] failed to record %d bytes downloaded from peer %s: %v", subtreeHash.String(), len(subtreeNodeBytes), peerID, err)
 					}
 				}
@@ -288,7 +302,7 @@ func (u *Server) CheckBlockSubtrees(ctx context.Context, request *subtreevalidat
 				// Wrap with counting reader to track bytes downloaded
 				var bytesRead uint64
 				countingBody := &countingReadCloser{
-					reader:    body,
+					reader:    bandwidth.NewReadCloser(gCtx, u.bandwidth, bandwidth.ClassSubtree, body),
 					bytesRead: &bytesRead,
 				}
 
@@ -301,7 +315,7 @@ func (u *Server) CheckBlockSubtrees(ctx context.Context, request *subtreevalidat
 				if u.p2pClient != nil && peerID != "" {
 					trackCtx, _, deferFn := tracing.DecoupleTracingSpan(gCtx, "subtreevalidation", "recordBytesDownloaded")
 					defer deferFn()
-					if err := u.p2pClient.RecordBytesDownloaded(trackCtx, peerID, bytesRead); err != nil {
+					if err := u.p2pClient.RecordDataDownloaded(trackCtx, peerID, peerDataTypeSubtreeData, 1, bytesRead); err != nil {
 						u.logger.Warnf("[CheckBlockSubtrees][%s] failed to record %d bytes downloaded from peer %s: %v", subtreeHash.String(), bytesRead, peerID, err)
 					}
 				}
@@ -357,7 +371,6 @@ func (u *Server) CheckBlockSubtrees(ctx context.Context, request *subtreevalidat
 		}
 
 		g, gCtx = errgroup.WithContext(ctx)
-		util.SafeSetLimit(g, u.settings.SubtreeValidation.CheckBlockSubtreesConcurrency)
 
 		var revalidateSubtreesMutex sync.Mutex
 		revalidateSubtrees := make([]chainhash.Hash, 0, len(missingSubtrees))
@@ -367,6 +380,11 @@ func (u *Server) CheckBlockSubtrees(ctx context.Context, request *subtreevalidat
 			subtreeHash := subtreeHash
 
 			g.Go(func() (err error) {
+				if err = validatePool.acquire(gCtx); err != nil {
+					return err
+				}
+				defer validatePool.release()
+
 				// This line is only reached when the base URL is not "legacy"
 				v := ValidateSubtree{
 					SubtreeHash:   subtreeHash,
@@ -440,6 +458,8 @@ func (u *Server) CheckBlockSubtrees(ctx context.Context, request *subtreevalidat
 
 	u.processOrphans(ctx, *block.Header.Hash(), block.Height, blockIds)
 
+	blocktrace.Record(ctx, u.logger, block.Hash(), blocktrace.StageSubtreesValidated)
+
 	return &subtreevalidation_api.CheckBlockSubtreesResponse{
 		Blessed: true,
 	}, nil
@@ -27,7 +27,6 @@ import (
 	"github.com/ordishs/gocore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/proto"
 )
 
 const testPeerURL = "http://test-peer.com"
@@ -99,7 +98,7 @@ func TestInvalidSubtreeReporting_MalformedTransactionData(t *testing.T) {
 
 	// decode and verify the message
 	var msg kafkamessage.KafkaInvalidSubtreeTopicMessage
-	err = proto.Unmarshal(kafkaProducer.messages[0].Value, &msg)
+	err = kafkamessage.Decode(kafkaProducer.messages[0].Value, &msg)
 	require.NoError(t, err)
 
 	assert.Equal(t, subtreeHash.String(), msg.SubtreeHash)
@@ -161,7 +160,7 @@ func TestInvalidSubtreeReporting_TransactionCountMismatch(t *testing.T) {
 
 	// decode and verify the message
 	var msg kafkamessage.KafkaInvalidSubtreeTopicMessage
-	err = proto.Unmarshal(kafkaProducer.messages[0].Value, &msg)
+	err = kafkamessage.Decode(kafkaProducer.messages[0].Value, &msg)
 	require.NoError(t, err)
 
 	assert.Equal(t, subtreeHash.String(), msg.SubtreeHash)
@@ -210,7 +209,7 @@ func TestInvalidSubtreeReporting_PeerCannotProvideData(t *testing.T) {
 	require.Len(t, kafkaProducer.messages, 1)
 
 	var msg kafkamessage.KafkaInvalidSubtreeTopicMessage
-	err = proto.Unmarshal(kafkaProducer.messages[0].Value, &msg)
+	err = kafkamessage.Decode(kafkaProducer.messages[0].Value, &msg)
 	require.NoError(t, err)
 
 	assert.Equal(t, subtreeHash.String(), msg.SubtreeHash)
@@ -236,7 +235,7 @@ func TestInvalidSubtreeReporting_PeerCannotProvideData(t *testing.T) {
 	// verify invalid subtree message was published
 	require.Len(t, kafkaProducer.messages, 1)
 
-	err = proto.Unmarshal(kafkaProducer.messages[0].Value, &msg)
+	err = kafkamessage.Decode(kafkaProducer.messages[0].Value, &msg)
 	require.NoError(t, err)
 
 	assert.Equal(t, subtreeHash.String(), msg.SubtreeHash)
@@ -306,7 +305,7 @@ func TestInvalidSubtreeReporting_HTTPErrorResponse(t *testing.T) {
 	require.Len(t, kafkaProducer.messages, 1)
 
 	var msg kafkamessage.KafkaInvalidSubtreeTopicMessage
-	err = proto.Unmarshal(kafkaProducer.messages[0].Value, &msg)
+	err = kafkamessage.Decode(kafkaProducer.messages[0].Value, &msg)
 	require.NoError(t, err)
 
 	assert.Equal(t, subtreeHash.String(), msg.SubtreeHash)
@@ -359,7 +358,7 @@ func TestPublishInvalidSubtree_DirectCall(t *testing.T) {
 
 	// decode and verify the message
 	var msg kafkamessage.KafkaInvalidSubtreeTopicMessage
-	err := proto.Unmarshal(kafkaProducer.messages[0].Value, &msg)
+	err := kafkamessage.Decode(kafkaProducer.messages[0].Value, &msg)
 	require.NoError(t, err)
 
 	assert.Equal(t, subtreeHash, msg.SubtreeHash)
@@ -83,6 +83,17 @@ var (
 	// which is critical for detecting when pauses exceed expected durations and may indicate
 	// issues with block validation or lock release mechanisms.
 	prometheusSubtreeValidationPauseDuration prometheus.Histogram
+
+	// prometheusSubtreeValidationAlternatePeerBatches counts the batches of missing transactions requested from
+	// alternate peers, because the announcing peer could not provide them, by whether the alternate peer provided them.
+	prometheusSubtreeValidationAlternatePeerBatches *prometheus.CounterVec
+
+	// prometheusSubtreeValidationWorkerPoolSize, prometheusSubtreeValidationWorkerPoolActive and
+	// prometheusSubtreeValidationWorkerPoolQueueDepth track the size, the busy workers and the waiting tasks of the
+	// worker pools validating the subtrees of a block, by pool.
+	prometheusSubtreeValidationWorkerPoolSize       *prometheus.GaugeVec
+	prometheusSubtreeValidationWorkerPoolActive     *prometheus.GaugeVec
+	prometheusSubtreeValidationWorkerPoolQueueDepth *prometheus.GaugeVec
 )
 
 var (
@@ -201,4 +212,44 @@ func _initPrometheusMetrics() {
 			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12), // 0.1s to ~6.8 minutes
 		},
 	)
+
+	prometheusSubtreeValidationAlternatePeerBatches = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "subtreevalidation",
+			Name:      "alternate_peer_batches",
+			Help:      "Number of batches of missing transactions requested from alternate peers, by result",
+		},
+		[]string{"result"},
+	)
+
+	prometheusSubtreeValidationWorkerPoolSize = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "subtreevalidation",
+			Name:      "worker_pool_size",
+			Help:      "Number of workers of the pools validating the subtrees of a block, by pool",
+		},
+		[]string{"pool"},
+	)
+
+	prometheusSubtreeValidationWorkerPoolActive = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "subtreevalidation",
+			Name:      "worker_pool_active",
+			Help:      "Number of busy workers of the pools validating the subtrees of a block, by pool",
+		},
+		[]string{"pool"},
+	)
+
+	prometheusSubtreeValidationWorkerPoolQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "subtreevalidation",
+			Name:      "worker_pool_queue_depth",
+			Help:      "Number of subtrees waiting for a worker of the pools validating the subtrees of a block, by pool",
+		},
+		[]string{"pool"},
+	)
 }
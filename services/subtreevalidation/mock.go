@@ -39,3 +39,12 @@ func (m *MockSubtreeValidationAPIClient) CheckBlockSubtrees(ctx context.Context,
 	}
 	return args.Get(0).(*subtreevalidation_api.CheckBlockSubtreesResponse), args.Error(1)
 }
+
+// ResizeWorkerPools mocks the ResizeWorkerPools method of SubtreeValidationAPIClient
+func (m *MockSubtreeValidationAPIClient) ResizeWorkerPools(ctx context.Context, in *subtreevalidation_api.ResizeWorkerPoolsRequest, opts ...grpc.CallOption) (*subtreevalidation_api.WorkerPoolsResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subtreevalidation_api.WorkerPoolsResponse), args.Error(1)
+}
@@ -2,18 +2,35 @@ package subtreevalidation
 
 import (
 	"context"
+
+	"github.com/bsv-blockchain/teranode/services/p2p"
 )
 
 // P2PClientI defines the interface for P2P client operations needed by SubtreeValidation.
 // This interface is a subset of p2p.ClientI, containing only the methods
-// that SubtreeValidation needs for reporting peer metrics to the peer registry.
+// that SubtreeValidation needs for reporting peer metrics and peer-attributable errors to the peer registry
+// and for finding peers to fetch missing transactions from.
 //
 // This interface exists to avoid circular dependencies between subtreevalidation and p2p packages.
 type P2PClientI interface {
 	// ReportValidSubtree reports that a subtree was successfully fetched and validated from a peer.
 	ReportValidSubtree(ctx context.Context, peerID string, subtreeHash string) error
 
-	// RecordBytesDownloaded records the number of bytes downloaded via HTTP from a peer.
-	// This is called after downloading data (subtrees, etc.) from a peer's DataHub URL.
-	RecordBytesDownloaded(ctx context.Context, peerID string, bytesDownloaded uint64) error
+	// AddBanScorePoints adds the given points to a peer's ban score with the specified reason, instead of the
+	// points of the reason, e.g. the ban-score delta of a peer-attributable error.
+	AddBanScorePoints(ctx context.Context, peerID string, reason string, points int) error
+
+	// RecordDataDownloaded records the subtrees or subtree data and the number of bytes downloaded via HTTP
+	// from a peer. This is called after downloading data from a peer's DataHub URL.
+	RecordDataDownloaded(ctx context.Context, peerID string, dataType string, items uint64, bytesDownloaded uint64) error
+
+	// GetPeersForCatchup returns the peers with a reachable DataHub URL that are not banned, on probation or throttled.
+	// Returns a slice of PeerInfo sorted by storage mode and reputation (highest first).
+	GetPeersForCatchup(ctx context.Context) ([]*p2p.PeerInfo, error)
 }
+
+// Data types reported to RecordDataDownloaded, these match p2p.PeerDataTypeSubtree and p2p.PeerDataTypeSubtreeData
+const (
+	peerDataTypeSubtree     = "subtree"
+	peerDataTypeSubtreeData = "subtree_data"
+)
@@ -0,0 +1,30 @@
+package subtreevalidation
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// reportPeerError reports a peer-attributable error of a subtree validation to the P2P service, adding the ban-score
+// delta of the error to the ban score of the peer the error is attributed to. It is called where the handling of the
+// error ends: errors of subtrees validated for a block are returned to the block validation service, which reports
+// them instead. Errors that are not attributed to a peer are ignored.
+//
+// Returns true if the error is attributed to a peer.
+func (u *Server) reportPeerError(ctx context.Context, err error) bool {
+	attribution, ok := errors.GetPeerAttribution(err)
+	if !ok {
+		return false
+	}
+
+	if u.p2pClient == nil {
+		return true
+	}
+
+	if reportErr := u.p2pClient.AddBanScorePoints(ctx, attribution.PeerID, "invalid_subtree", attribution.BanScoreDelta); reportErr != nil {
+		u.logger.Warnf("[reportPeerError] failed to add ban score %d (%s) to peer %s: %v", attribution.BanScoreDelta, attribution.Severity, attribution.PeerID, reportErr)
+	}
+
+	return true
+}
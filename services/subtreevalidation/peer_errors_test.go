@@ -0,0 +1,28 @@
+package subtreevalidation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportPeerError(t *testing.T) {
+	p2pClient := &mockPeerRegistryClient{}
+	server := &Server{logger: ulogger.TestLogger{}, p2pClient: p2pClient}
+	ctx := context.Background()
+
+	assert.False(t, server.reportPeerError(ctx, errors.NewTxInvalidError("invalid tx")))
+	assert.Empty(t, p2pClient.banned)
+
+	err := errors.NewProcessingError("failed to validate subtree",
+		errors.NewPeerAttributableError("peer1", errors.PeerSeverityHigh, 0, "subtree contains invalid transaction", errors.NewTxInvalidError("invalid tx")))
+
+	assert.True(t, server.reportPeerError(ctx, err))
+	assert.Equal(t, []string{"peer1:invalid_subtree:20"}, p2pClient.banned)
+
+	// without a P2P client the error is still attributed, but not reported
+	assert.True(t, (&Server{logger: ulogger.TestLogger{}}).reportPeerError(ctx, err))
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/bsv-blockchain/teranode/services/blockchain"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
-	"google.golang.org/protobuf/proto"
 )
 
 // subtreeMessageHandler returns a Kafka message handler for subtree validation.
@@ -25,6 +24,10 @@ import (
 //
 // Note: Pause/resume is now handled by pausing the Kafka consumer itself (via PauseAll/ResumeAll)
 // rather than blocking in this handler. This prevents session timeouts and improves resource usage.
+//
+// The handler is called concurrently for different partitions and, with subtreevalidation_kafka_workers
+// above 1, for messages of the same partition with different keys. Concurrent messages for the same
+// subtree are serialized by the subtree quorum lock.
 func (u *Server) subtreeMessageHandler(ctx context.Context) func(msg *kafka.KafkaMessage) error {
 	return func(msg *kafka.KafkaMessage) error {
 		// Check if context is already cancelled
@@ -103,7 +106,7 @@ func (u *Server) subtreesHandler(msg *kafka.KafkaMessage) error {
 			subtree  *subtreepkg.Subtree
 		)
 
-		if err := proto.Unmarshal(msg.Value, &kafkaMsg); err != nil {
+		if err := kafkamessage.Decode(msg.Value, &kafkaMsg); err != nil {
 			u.logger.Errorf("Failed to unmarshal kafka message: %v", err)
 			return err
 		}
@@ -151,6 +154,7 @@ func (u *Server) subtreesHandler(msg *kafka.KafkaMessage) error {
 		// validate the subtree as if it is for the next block height
 		// this is because subtrees are always validated ahead of time before they are needed for a block
 		if subtree, err = u.ValidateSubtreeInternal(ctx, v, bestBlockHeaderMeta.Height+1, *blockIDsMap); err != nil {
+			u.reportPeerError(ctx, err)
 			return err
 		}
 
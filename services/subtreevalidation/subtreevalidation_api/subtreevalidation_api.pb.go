@@ -67,7 +67,9 @@ type HealthResponse struct {
 	// details provides additional context about the service health status
 	Details string `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
 	// timestamp records when the health check was performed
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// build_info holds the JSON build metadata of the node, as returned by the /api/v1/version endpoint
+	BuildInfo     string `protobuf:"bytes,4,opt,name=build_info,json=buildInfo,proto3" json:"build_info,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -123,6 +125,13 @@ func (x *HealthResponse) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *HealthResponse) GetBuildInfo() string {
+	if x != nil {
+		return x.BuildInfo
+	}
+	return ""
+}
+
 // CheckSubtreeFromBlockRequest defines the input parameters for subtree validation.
 type CheckSubtreeFromBlockRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -361,16 +370,192 @@ func (x *CheckBlockSubtreesResponse) GetBlessed() bool {
 	return false
 }
 
+// ResizeWorkerPoolsRequest defines the new sizes of the worker pools validating the subtrees of a block.
+type ResizeWorkerPoolsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// fetch_workers is the number of subtrees of a block fetched concurrently, 0 leaves the pool unchanged
+	FetchWorkers uint32 `protobuf:"varint,1,opt,name=fetch_workers,json=fetchWorkers,proto3" json:"fetch_workers,omitempty"`
+	// validate_workers is the number of subtrees of a block validated concurrently, 0 leaves the pool unchanged
+	ValidateWorkers uint32 `protobuf:"varint,2,opt,name=validate_workers,json=validateWorkers,proto3" json:"validate_workers,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ResizeWorkerPoolsRequest) Reset() {
+	*x = ResizeWorkerPoolsRequest{}
+	mi := &file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResizeWorkerPoolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResizeWorkerPoolsRequest) ProtoMessage() {}
+
+func (x *ResizeWorkerPoolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResizeWorkerPoolsRequest.ProtoReflect.Descriptor instead.
+func (*ResizeWorkerPoolsRequest) Descriptor() ([]byte, []int) {
+	return file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ResizeWorkerPoolsRequest) GetFetchWorkers() uint32 {
+	if x != nil {
+		return x.FetchWorkers
+	}
+	return 0
+}
+
+func (x *ResizeWorkerPoolsRequest) GetValidateWorkers() uint32 {
+	if x != nil {
+		return x.ValidateWorkers
+	}
+	return 0
+}
+
+// WorkerPoolStatus describes the state of a worker pool validating the subtrees of a block.
+type WorkerPoolStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name identifies the pool, fetch or validate
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// size is the number of workers of the pool
+	Size uint32 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// active is the number of busy workers
+	Active uint32 `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	// queued is the number of subtrees waiting for a worker
+	Queued        uint32 `protobuf:"varint,4,opt,name=queued,proto3" json:"queued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerPoolStatus) Reset() {
+	*x = WorkerPoolStatus{}
+	mi := &file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerPoolStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerPoolStatus) ProtoMessage() {}
+
+func (x *WorkerPoolStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerPoolStatus.ProtoReflect.Descriptor instead.
+func (*WorkerPoolStatus) Descriptor() ([]byte, []int) {
+	return file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WorkerPoolStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkerPoolStatus) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *WorkerPoolStatus) GetActive() uint32 {
+	if x != nil {
+		return x.Active
+	}
+	return 0
+}
+
+func (x *WorkerPoolStatus) GetQueued() uint32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+// WorkerPoolsResponse contains the state of the worker pools validating the subtrees of a block.
+type WorkerPoolsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// pools holds the state of every pool
+	Pools         []*WorkerPoolStatus `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerPoolsResponse) Reset() {
+	*x = WorkerPoolsResponse{}
+	mi := &file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerPoolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerPoolsResponse) ProtoMessage() {}
+
+func (x *WorkerPoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerPoolsResponse.ProtoReflect.Descriptor instead.
+func (*WorkerPoolsResponse) Descriptor() ([]byte, []int) {
+	return file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WorkerPoolsResponse) GetPools() []*WorkerPoolStatus {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
 var File_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto protoreflect.FileDescriptor
 
 const file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_rawDesc = "" +
 	"\n" +
 	"Lservices/subtreevalidation/subtreevalidation_api/subtreevalidation_api.proto\x12\x15subtreevalidation_api\x1a\x1fgoogle/protobuf/timestamp.proto\"\x0e\n" +
-	"\fEmptyMessage\"t\n" +
+	"\fEmptyMessage\"\x93\x01\n" +
 	"\x0eHealthResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x18\n" +
 	"\adetails\x18\x02 \x01(\tR\adetails\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xbf\x01\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"build_info\x18\x04 \x01(\tR\tbuildInfo\"\xbf\x01\n" +
 	"\x1cCheckSubtreeFromBlockRequest\x12\x12\n" +
 	"\x04hash\x18\x01 \x01(\fR\x04hash\x12\x19\n" +
 	"\bbase_url\x18\x02 \x01(\tR\abaseUrl\x12!\n" +
@@ -385,12 +570,23 @@ const file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_ap
 	"\bbase_url\x18\x02 \x01(\tR\abaseUrl\x12\x17\n" +
 	"\apeer_id\x18\x03 \x01(\tR\x06peerId\"6\n" +
 	"\x1aCheckBlockSubtreesResponse\x12\x18\n" +
-	"\ablessed\x18\x01 \x01(\bR\ablessed2\xf6\x02\n" +
+	"\ablessed\x18\x01 \x01(\bR\ablessed\"j\n" +
+	"\x18ResizeWorkerPoolsRequest\x12#\n" +
+	"\rfetch_workers\x18\x01 \x01(\rR\ffetchWorkers\x12)\n" +
+	"\x10validate_workers\x18\x02 \x01(\rR\x0fvalidateWorkers\"j\n" +
+	"\x10WorkerPoolStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\rR\x04size\x12\x16\n" +
+	"\x06active\x18\x03 \x01(\rR\x06active\x12\x16\n" +
+	"\x06queued\x18\x04 \x01(\rR\x06queued\"T\n" +
+	"\x13WorkerPoolsResponse\x12=\n" +
+	"\x05pools\x18\x01 \x03(\v2'.subtreevalidation_api.WorkerPoolStatusR\x05pools2\xea\x03\n" +
 	"\x14SubtreeValidationAPI\x12Z\n" +
 	"\n" +
 	"HealthGRPC\x12#.subtreevalidation_api.EmptyMessage\x1a%.subtreevalidation_api.HealthResponse\"\x00\x12\x84\x01\n" +
 	"\x15CheckSubtreeFromBlock\x123.subtreevalidation_api.CheckSubtreeFromBlockRequest\x1a4.subtreevalidation_api.CheckSubtreeFromBlockResponse\"\x00\x12{\n" +
-	"\x12CheckBlockSubtrees\x120.subtreevalidation_api.CheckBlockSubtreesRequest\x1a1.subtreevalidation_api.CheckBlockSubtreesResponse\"\x00B\x1aZ\x18./;subtreevalidation_apib\x06proto3"
+	"\x12CheckBlockSubtrees\x120.subtreevalidation_api.CheckBlockSubtreesRequest\x1a1.subtreevalidation_api.CheckBlockSubtreesResponse\"\x00\x12r\n" +
+	"\x11ResizeWorkerPools\x12/.subtreevalidation_api.ResizeWorkerPoolsRequest\x1a*.subtreevalidation_api.WorkerPoolsResponse\"\x00B\x1aZ\x18./;subtreevalidation_apib\x06proto3"
 
 var (
 	file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_rawDescOnce sync.Once
@@ -404,7 +600,7 @@ func file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api
 	return file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_rawDescData
 }
 
-var file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_goTypes = []any{
 	(*EmptyMessage)(nil),                  // 0: subtreevalidation_api.EmptyMessage
 	(*HealthResponse)(nil),                // 1: subtreevalidation_api.HealthResponse
@@ -412,21 +608,27 @@ var file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_
 	(*CheckSubtreeFromBlockResponse)(nil), // 3: subtreevalidation_api.CheckSubtreeFromBlockResponse
 	(*CheckBlockSubtreesRequest)(nil),     // 4: subtreevalidation_api.CheckBlockSubtreesRequest
 	(*CheckBlockSubtreesResponse)(nil),    // 5: subtreevalidation_api.CheckBlockSubtreesResponse
-	(*timestamppb.Timestamp)(nil),         // 6: google.protobuf.Timestamp
+	(*ResizeWorkerPoolsRequest)(nil),      // 6: subtreevalidation_api.ResizeWorkerPoolsRequest
+	(*WorkerPoolStatus)(nil),              // 7: subtreevalidation_api.WorkerPoolStatus
+	(*WorkerPoolsResponse)(nil),           // 8: subtreevalidation_api.WorkerPoolsResponse
+	(*timestamppb.Timestamp)(nil),         // 9: google.protobuf.Timestamp
 }
 var file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_depIdxs = []int32{
-	6, // 0: subtreevalidation_api.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
-	0, // 1: subtreevalidation_api.SubtreeValidationAPI.HealthGRPC:input_type -> subtreevalidation_api.EmptyMessage
-	2, // 2: subtreevalidation_api.SubtreeValidationAPI.CheckSubtreeFromBlock:input_type -> subtreevalidation_api.CheckSubtreeFromBlockRequest
-	4, // 3: subtreevalidation_api.SubtreeValidationAPI.CheckBlockSubtrees:input_type -> subtreevalidation_api.CheckBlockSubtreesRequest
-	1, // 4: subtreevalidation_api.SubtreeValidationAPI.HealthGRPC:output_type -> subtreevalidation_api.HealthResponse
-	3, // 5: subtreevalidation_api.SubtreeValidationAPI.CheckSubtreeFromBlock:output_type -> subtreevalidation_api.CheckSubtreeFromBlockResponse
-	5, // 6: subtreevalidation_api.SubtreeValidationAPI.CheckBlockSubtrees:output_type -> subtreevalidation_api.CheckBlockSubtreesResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	9, // 0: subtreevalidation_api.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
+	7, // 1: subtreevalidation_api.WorkerPoolsResponse.pools:type_name -> subtreevalidation_api.WorkerPoolStatus
+	0, // 2: subtreevalidation_api.SubtreeValidationAPI.HealthGRPC:input_type -> subtreevalidation_api.EmptyMessage
+	2, // 3: subtreevalidation_api.SubtreeValidationAPI.CheckSubtreeFromBlock:input_type -> subtreevalidation_api.CheckSubtreeFromBlockRequest
+	4, // 4: subtreevalidation_api.SubtreeValidationAPI.CheckBlockSubtrees:input_type -> subtreevalidation_api.CheckBlockSubtreesRequest
+	6, // 5: subtreevalidation_api.SubtreeValidationAPI.ResizeWorkerPools:input_type -> subtreevalidation_api.ResizeWorkerPoolsRequest
+	1, // 6: subtreevalidation_api.SubtreeValidationAPI.HealthGRPC:output_type -> subtreevalidation_api.HealthResponse
+	3, // 7: subtreevalidation_api.SubtreeValidationAPI.CheckSubtreeFromBlock:output_type -> subtreevalidation_api.CheckSubtreeFromBlockResponse
+	5, // 8: subtreevalidation_api.SubtreeValidationAPI.CheckBlockSubtrees:output_type -> subtreevalidation_api.CheckBlockSubtreesResponse
+	8, // 9: subtreevalidation_api.SubtreeValidationAPI.ResizeWorkerPools:output_type -> subtreevalidation_api.WorkerPoolsResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_init() }
@@ -440,7 +642,7 @@ func file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_rawDesc), len(file_services_subtreevalidation_subtreevalidation_api_subtreevalidation_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,7 @@ const (
 	SubtreeValidationAPI_HealthGRPC_FullMethodName            = "/subtreevalidation_api.SubtreeValidationAPI/HealthGRPC"
 	SubtreeValidationAPI_CheckSubtreeFromBlock_FullMethodName = "/subtreevalidation_api.SubtreeValidationAPI/CheckSubtreeFromBlock"
 	SubtreeValidationAPI_CheckBlockSubtrees_FullMethodName    = "/subtreevalidation_api.SubtreeValidationAPI/CheckBlockSubtrees"
+	SubtreeValidationAPI_ResizeWorkerPools_FullMethodName     = "/subtreevalidation_api.SubtreeValidationAPI/ResizeWorkerPools"
 )
 
 // SubtreeValidationAPIClient is the client API for SubtreeValidationAPI service.
@@ -39,6 +40,9 @@ type SubtreeValidationAPIClient interface {
 	// returning a response indicating the subtree's validity status.
 	CheckSubtreeFromBlock(ctx context.Context, in *CheckSubtreeFromBlockRequest, opts ...grpc.CallOption) (*CheckSubtreeFromBlockResponse, error)
 	CheckBlockSubtrees(ctx context.Context, in *CheckBlockSubtreesRequest, opts ...grpc.CallOption) (*CheckBlockSubtreesResponse, error)
+	// ResizeWorkerPools changes the number of workers of the pools validating the subtrees of a block, without a restart.
+	// A size of 0 leaves the pool unchanged, so an empty request returns the current state of the pools.
+	ResizeWorkerPools(ctx context.Context, in *ResizeWorkerPoolsRequest, opts ...grpc.CallOption) (*WorkerPoolsResponse, error)
 }
 
 type subtreeValidationAPIClient struct {
@@ -79,6 +83,16 @@ func (c *subtreeValidationAPIClient) CheckBlockSubtrees(ctx context.Context, in
 	return out, nil
 }
 
+func (c *subtreeValidationAPIClient) ResizeWorkerPools(ctx context.Context, in *ResizeWorkerPoolsRequest, opts ...grpc.CallOption) (*WorkerPoolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerPoolsResponse)
+	err := c.cc.Invoke(ctx, SubtreeValidationAPI_ResizeWorkerPools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SubtreeValidationAPIServer is the server API for SubtreeValidationAPI service.
 // All implementations must embed UnimplementedSubtreeValidationAPIServer
 // for forward compatibility.
@@ -94,6 +108,9 @@ type SubtreeValidationAPIServer interface {
 	// returning a response indicating the subtree's validity status.
 	CheckSubtreeFromBlock(context.Context, *CheckSubtreeFromBlockRequest) (*CheckSubtreeFromBlockResponse, error)
 	CheckBlockSubtrees(context.Context, *CheckBlockSubtreesRequest) (*CheckBlockSubtreesResponse, error)
+	// ResizeWorkerPools changes the number of workers of the pools validating the subtrees of a block, without a restart.
+	// A size of 0 leaves the pool unchanged, so an empty request returns the current state of the pools.
+	ResizeWorkerPools(context.Context, *ResizeWorkerPoolsRequest) (*WorkerPoolsResponse, error)
 	mustEmbedUnimplementedSubtreeValidationAPIServer()
 }
 
@@ -113,6 +130,9 @@ func (UnimplementedSubtreeValidationAPIServer) CheckSubtreeFromBlock(context.Con
 func (UnimplementedSubtreeValidationAPIServer) CheckBlockSubtrees(context.Context, *CheckBlockSubtreesRequest) (*CheckBlockSubtreesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckBlockSubtrees not implemented")
 }
+func (UnimplementedSubtreeValidationAPIServer) ResizeWorkerPools(context.Context, *ResizeWorkerPoolsRequest) (*WorkerPoolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResizeWorkerPools not implemented")
+}
 func (UnimplementedSubtreeValidationAPIServer) mustEmbedUnimplementedSubtreeValidationAPIServer() {}
 func (UnimplementedSubtreeValidationAPIServer) testEmbeddedByValue()                              {}
 
@@ -188,6 +208,24 @@ func _SubtreeValidationAPI_CheckBlockSubtrees_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SubtreeValidationAPI_ResizeWorkerPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeWorkerPoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubtreeValidationAPIServer).ResizeWorkerPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubtreeValidationAPI_ResizeWorkerPools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubtreeValidationAPIServer).ResizeWorkerPools(ctx, req.(*ResizeWorkerPoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SubtreeValidationAPI_ServiceDesc is the grpc.ServiceDesc for SubtreeValidationAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -207,6 +245,10 @@ var SubtreeValidationAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckBlockSubtrees",
 			Handler:    _SubtreeValidationAPI_CheckBlockSubtrees_Handler,
 		},
+		{
+			MethodName: "ResizeWorkerPools",
+			Handler:    _SubtreeValidationAPI_ResizeWorkerPools_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/subtreevalidation/subtreevalidation_api/subtreevalidation_api.proto",
@@ -11,7 +11,6 @@ import (
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
-	"google.golang.org/protobuf/proto"
 )
 
 // txmetaMessageHandler returns a Kafka message handler for transaction metadata operations.
@@ -35,7 +34,7 @@ func (u *Server) txmetaHandler(ctx context.Context, msg *kafka.KafkaMessage) err
 	startTime := time.Now()
 
 	var m kafkamessage.KafkaTxMetaTopicMessage
-	if err := proto.Unmarshal(msg.ConsumerMessage.Value, &m); err != nil {
+	if err := kafkamessage.Decode(msg.ConsumerMessage.Value, &m); err != nil {
 		return err
 	}
 
@@ -0,0 +1,196 @@
+package subtreevalidation
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/subtreevalidation/subtreevalidation_api"
+)
+
+// Names of the worker pools validating the subtrees of a block in CheckBlockSubtrees
+const (
+	// WorkerPoolFetch fetches the subtrees and subtree data missing from the block and parses their transactions
+	WorkerPoolFetch = "fetch"
+
+	// WorkerPoolValidate validates the subtrees of the block, after their transactions were validated
+	WorkerPoolValidate = "validate"
+)
+
+// WorkerPoolStatus is the state of a worker pool validating the subtrees of a block
+type WorkerPoolStatus struct {
+	Name   string // Name of the pool, WorkerPoolFetch or WorkerPoolValidate
+	Size   int    // Number of workers of the pool
+	Active int    // Number of busy workers
+	Queued int    // Number of subtrees waiting for a worker
+}
+
+// workerPool bounds the number of subtrees of a block processed concurrently by a stage of CheckBlockSubtrees.
+// Unlike the limit of an errgroup, the size of a worker pool can be changed while subtrees are being processed: a
+// larger pool starts waiting subtrees right away, a smaller pool lets the busy workers finish and starts new subtrees
+// once the number of busy workers dropped below the new size.
+type workerPool struct {
+	name   string
+	mu     sync.Mutex
+	size   int
+	active int
+	queued int
+	waitCh chan struct{} // Closed and replaced whenever a worker may have become available
+}
+
+// newWorkerPool creates a worker pool of the given size, at least 1
+func newWorkerPool(name string, size int) *workerPool {
+	InitPrometheusMetrics()
+
+	p := &workerPool{
+		name:   name,
+		size:   max(1, size),
+		waitCh: make(chan struct{}),
+	}
+
+	p.updateMetrics()
+
+	return p
+}
+
+// acquire blocks until a worker of the pool is available or the context is done
+func (p *workerPool) acquire(ctx context.Context) error {
+	queued := false
+
+	defer func() {
+		if queued {
+			p.mu.Lock()
+			p.queued--
+			p.updateMetrics()
+			p.mu.Unlock()
+		}
+	}()
+
+	for {
+		p.mu.Lock()
+
+		if p.active < p.size {
+			p.active++
+			p.updateMetrics()
+			p.mu.Unlock()
+
+			return nil
+		}
+
+		if !queued {
+			queued = true
+			p.queued++
+			p.updateMetrics()
+		}
+
+		waitCh := p.waitCh
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return errors.NewContextCanceledError("waiting for a %s worker", p.name, ctx.Err())
+		case <-waitCh:
+		}
+	}
+}
+
+// release returns a worker to the pool
+func (p *workerPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.active > 0 {
+		p.active--
+	}
+
+	p.notify()
+}
+
+// resize changes the number of workers of the pool, at least 1
+func (p *workerPool) resize(size int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.size = max(1, size)
+
+	p.notify()
+}
+
+// status returns the size, busy workers and waiting subtrees of the pool
+func (p *workerPool) status() WorkerPoolStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return WorkerPoolStatus{
+		Name:   p.name,
+		Size:   p.size,
+		Active: p.active,
+		Queued: p.queued,
+	}
+}
+
+// notify wakes the waiting subtrees and updates the metrics, must be called with the lock held
+func (p *workerPool) notify() {
+	p.updateMetrics()
+
+	close(p.waitCh)
+	p.waitCh = make(chan struct{})
+}
+
+// updateMetrics exports the state of the pool, must be called with the lock held
+func (p *workerPool) updateMetrics() {
+	prometheusSubtreeValidationWorkerPoolSize.WithLabelValues(p.name).Set(float64(p.size))
+	prometheusSubtreeValidationWorkerPoolActive.WithLabelValues(p.name).Set(float64(p.active))
+	prometheusSubtreeValidationWorkerPoolQueueDepth.WithLabelValues(p.name).Set(float64(p.queued))
+}
+
+// workerPools returns the fetch and validate worker pools of CheckBlockSubtrees, created with the configured sizes
+// on first use
+func (u *Server) workerPools() (fetch, validate *workerPool) {
+	u.workerPoolsOnce.Do(func() {
+		u.fetchPool = newWorkerPool(WorkerPoolFetch, u.settings.SubtreeValidation.CheckBlockSubtreesConcurrency)
+		u.validatePool = newWorkerPool(WorkerPoolValidate, u.settings.SubtreeValidation.CheckBlockSubtreesValidateConcurrency)
+	})
+
+	return u.fetchPool, u.validatePool
+}
+
+// ResizeWorkerPools changes the number of workers of the fetch and validate pools of CheckBlockSubtrees via gRPC,
+// so the CPU usage of block validation can be tuned without a restart. A size of 0 leaves the pool unchanged. The
+// new sizes apply to the blocks being validated right away, they are not persisted and the configured sizes apply
+// again after a restart.
+func (u *Server) ResizeWorkerPools(_ context.Context, request *subtreevalidation_api.ResizeWorkerPoolsRequest) (*subtreevalidation_api.WorkerPoolsResponse, error) {
+	fetch, validate := u.workerPools()
+
+	for _, resize := range []struct {
+		pool *workerPool
+		size uint32
+	}{
+		{fetch, request.GetFetchWorkers()},
+		{validate, request.GetValidateWorkers()},
+	} {
+		if resize.size == 0 {
+			continue
+		}
+
+		previous := resize.pool.status().Size
+		resize.pool.resize(int(resize.size))
+
+		u.logger.Infof("[ResizeWorkerPools] resized %s worker pool from %d to %d workers", resize.pool.name, previous, resize.size)
+	}
+
+	response := &subtreevalidation_api.WorkerPoolsResponse{}
+
+	for _, pool := range []*workerPool{fetch, validate} {
+		status := pool.status()
+
+		response.Pools = append(response.Pools, &subtreevalidation_api.WorkerPoolStatus{
+			Name:   status.Name,
+			Size:   uint32(status.Size),   //nolint:gosec // set from a uint32
+			Active: uint32(status.Active), //nolint:gosec // at most the subtrees of a block
+			Queued: uint32(status.Queued), //nolint:gosec // at most the subtrees of a block
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,136 @@
+package subtreevalidation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/subtreevalidation/subtreevalidation_api"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("size is at least 1", func(t *testing.T) {
+		p := newWorkerPool("test", 0)
+		assert.Equal(t, 1, p.status().Size)
+
+		p.resize(-5)
+		assert.Equal(t, 1, p.status().Size)
+	})
+
+	t.Run("queues beyond size", func(t *testing.T) {
+		p := newWorkerPool("test", 1)
+
+		require.NoError(t, p.acquire(context.Background()))
+
+		acquired := make(chan error, 1)
+
+		go func() {
+			acquired <- p.acquire(context.Background())
+		}()
+
+		require.Eventually(t, func() bool {
+			return p.status().Queued == 1
+		}, time.Second, time.Millisecond)
+
+		assert.Equal(t, WorkerPoolStatus{Name: "test", Size: 1, Active: 1, Queued: 1}, p.status())
+
+		p.release()
+
+		require.NoError(t, <-acquired)
+		assert.Equal(t, WorkerPoolStatus{Name: "test", Size: 1, Active: 1, Queued: 0}, p.status())
+	})
+
+	t.Run("growing starts queued tasks", func(t *testing.T) {
+		p := newWorkerPool("test", 1)
+
+		require.NoError(t, p.acquire(context.Background()))
+
+		acquired := make(chan error, 1)
+
+		go func() {
+			acquired <- p.acquire(context.Background())
+		}()
+
+		require.Eventually(t, func() bool {
+			return p.status().Queued == 1
+		}, time.Second, time.Millisecond)
+
+		p.resize(2)
+
+		require.NoError(t, <-acquired)
+		assert.Equal(t, WorkerPoolStatus{Name: "test", Size: 2, Active: 2, Queued: 0}, p.status())
+	})
+
+	t.Run("shrinking lets busy workers finish", func(t *testing.T) {
+		p := newWorkerPool("test", 2)
+
+		require.NoError(t, p.acquire(context.Background()))
+		require.NoError(t, p.acquire(context.Background()))
+
+		p.resize(1)
+		assert.Equal(t, 2, p.status().Active)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		// one worker released leaves the pool at its new size, no worker is available yet
+		p.release()
+
+		err := p.acquire(ctx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrContextCanceled))
+
+		p.release()
+		require.NoError(t, p.acquire(context.Background()))
+	})
+
+	t.Run("cancelled while queued", func(t *testing.T) {
+		p := newWorkerPool("test", 1)
+
+		require.NoError(t, p.acquire(context.Background()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.Error(t, p.acquire(ctx))
+		assert.Equal(t, 0, p.status().Queued)
+	})
+}
+
+func TestResizeWorkerPools(t *testing.T) {
+	tSettings := &settings.Settings{SubtreeValidation: settings.SubtreeValidationSettings{
+		CheckBlockSubtreesConcurrency:         8,
+		CheckBlockSubtreesValidateConcurrency: 4,
+	}}
+
+	server := &Server{logger: ulogger.TestLogger{}, settings: tSettings}
+
+	t.Run("empty request returns the configured sizes", func(t *testing.T) {
+		resp, err := server.ResizeWorkerPools(context.Background(), &subtreevalidation_api.ResizeWorkerPoolsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Pools, 2)
+
+		assert.Equal(t, WorkerPoolFetch, resp.Pools[0].Name)
+		assert.Equal(t, uint32(8), resp.Pools[0].Size)
+		assert.Equal(t, WorkerPoolValidate, resp.Pools[1].Name)
+		assert.Equal(t, uint32(4), resp.Pools[1].Size)
+	})
+
+	t.Run("resizes a single pool", func(t *testing.T) {
+		resp, err := server.ResizeWorkerPools(context.Background(), &subtreevalidation_api.ResizeWorkerPoolsRequest{
+			ValidateWorkers: 16,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, uint32(8), resp.Pools[0].Size)
+		assert.Equal(t, uint32(16), resp.Pools[1].Size)
+
+		_, validate := server.workerPools()
+		assert.Equal(t, 16, validate.status().Size)
+	})
+}
@@ -33,7 +33,9 @@ import (
 
 	"github.com/bsv-blockchain/go-batcher"
 	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
 	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/subtreeprocessor"
 	"github.com/bsv-blockchain/teranode/services/validator/validator_api"
 	"github.com/bsv-blockchain/teranode/settings"
 	utxometa "github.com/bsv-blockchain/teranode/stores/utxo/meta"
@@ -192,6 +194,101 @@ func (c *Client) Validate(ctx context.Context, tx *bt.Tx, blockHeight uint32, op
 	return c.ValidateWithOptions(ctx, tx, blockHeight, validationOptions)
 }
 
+// ValidatePackage validates a package of dependent transactions together through the validator service, all
+// transactions of the package are admitted or none of them are. Only the block assembly and policy check options
+// are sent to the validator service, the UTXOs of a package are always created.
+func (c *Client) ValidatePackage(ctx context.Context, txs []*bt.Tx, blockHeight uint32, opts ...Option) (*PackageResult, error) {
+	validationOptions := ProcessOptions(opts...)
+
+	if validationOptions.SkipUtxoCreation {
+		return nil, errors.NewInvalidArgumentError("[ValidatePackage] transaction packages must create their utxos")
+	}
+
+	transactions := make([][]byte, 0, len(txs))
+	for _, tx := range txs {
+		transactions = append(transactions, tx.SerializeBytes())
+	}
+
+	response, err := c.client.ValidatePackage(ctx, &validator_api.ValidatePackageRequest{
+		Transactions:         transactions,
+		BlockHeight:          blockHeight,
+		AddTxToBlockAssembly: &validationOptions.AddTXToBlockAssembly,
+		SkipPolicyChecks:     &validationOptions.SkipPolicyChecks,
+	})
+	if err != nil {
+		return nil, errors.UnwrapGRPC(err)
+	}
+
+	return packageResultFromResponse(response)
+}
+
+// packageResultFromResponse converts the gRPC response of a package validation to the result and the reason the
+// package was rejected
+func packageResultFromResponse(response *validator_api.ValidatePackageResponse) (*PackageResult, error) {
+	result := &PackageResult{
+		Accepted:     response.GetAccepted(),
+		Fee:          response.GetFee(),
+		Size:         response.GetSize(),
+		Transactions: make([]*PackageTxResult, 0, len(response.GetTransactions())),
+	}
+
+	for _, txResult := range response.GetTransactions() {
+		txID, err := chainhash.NewHash(txResult.GetTxid())
+		if err != nil {
+			return nil, errors.NewProcessingError("invalid transaction id in package response", err)
+		}
+
+		packageTxResult := &PackageTxResult{
+			TxID:   *txID,
+			Status: PackageTxStatus(txResult.GetStatus()),
+			Fee:    txResult.GetFee(),
+			Size:   txResult.GetSize(),
+		}
+
+		if !txResult.GetError().IsNil() { // don't do err != nil, proto can't return nil TError
+			packageTxResult.Err = txResult.GetError()
+		}
+
+		result.Transactions = append(result.Transactions, packageTxResult)
+	}
+
+	if !response.GetError().IsNil() {
+		return result, response.GetError()
+	}
+
+	return result, nil
+}
+
+// ValidatePolicy tests the transaction against the transaction acceptance policy of the validator service, without
+// validating or storing it. It returns whether the transaction passes all enabled policy rules, and the result of each
+// rule, including the disabled rules.
+func (c *Client) ValidatePolicy(ctx context.Context, tx *bt.Tx, blockHeight uint32) (bool, []PolicyRuleResult, error) {
+	response, err := c.client.ValidatePolicy(ctx, &validator_api.ValidatePolicyRequest{
+		TransactionData: tx.SerializeBytes(),
+		BlockHeight:     blockHeight,
+	})
+	if err != nil {
+		return false, nil, errors.UnwrapGRPC(err)
+	}
+
+	results := make([]PolicyRuleResult, 0, len(response.GetRules()))
+
+	for _, rule := range response.GetRules() {
+		result := PolicyRuleResult{
+			Rule:    PolicyRule(rule.GetRule()),
+			Enabled: rule.GetEnabled(),
+		}
+
+		if !rule.GetPassed() {
+			result.Err = errors.NewTxPolicyError("%s", rule.GetReason())
+		}
+
+		results = append(results, result)
+	}
+
+	return response.GetPassed(), results, nil
+}
+
 type validateBatchResponse struct {
 	metaData []byte
 	err      error
@@ -207,6 +304,8 @@ func (c *Client) ValidateWithOptions(ctx context.Context, tx *bt.Tx, blockHeight
 			AddTxToBlockAssembly: &validationOptions.AddTXToBlockAssembly,
 			SkipPolicyChecks:     &validationOptions.SkipPolicyChecks,
 			CreateConflicting:    &validationOptions.CreateConflicting,
+			TxPriority:           (*uint32)(&validationOptions.TxPriority),
+			PeerId:               &validationOptions.PeerID,
 		})
 		if err != nil {
 			c.logger.Errorf("[ValidateWithOptions] failed to validate non-batched transaction: %v", err)
@@ -233,6 +332,8 @@ func (c *Client) ValidateWithOptions(ctx context.Context, tx *bt.Tx, blockHeight
 			AddTxToBlockAssembly: &validationOptions.AddTXToBlockAssembly,
 			SkipPolicyChecks:     &validationOptions.SkipPolicyChecks,
 			CreateConflicting:    &validationOptions.CreateConflicting,
+			TxPriority:           (*uint32)(&validationOptions.TxPriority),
+			PeerId:               &validationOptions.PeerID,
 		},
 		done: doneCh,
 	})
@@ -340,6 +441,8 @@ func (c *Client) handleBatchHTTPFallback(ctx context.Context, batch []*batchItem
 			AddTXToBlockAssembly: *txReq.AddTxToBlockAssembly,
 			SkipPolicyChecks:     *txReq.SkipPolicyChecks,
 			CreateConflicting:    *txReq.CreateConflicting,
+			TxPriority:           subtreeprocessor.TxPriority(txReq.GetTxPriority()),
+			PeerID:               txReq.GetPeerId(),
 		}
 
 		// Try HTTP fallback for this individual transaction
@@ -409,6 +512,14 @@ func (c *Client) validateTransactionViaHTTP(ctx context.Context, tx *bt.Tx, bloc
 		queryParams.Add("createConflicting", "true")
 	}
 
+	if validationOptions.TxPriority != subtreeprocessor.TxPriorityDefault {
+		queryParams.Add("txPriority", fmt.Sprintf("%d", validationOptions.TxPriority))
+	}
+
+	if validationOptions.PeerID != "" {
+		queryParams.Add("peerId", validationOptions.PeerID)
+	}
+
 	if blockHeight > 0 {
 		queryParams.Add("blockHeight", fmt.Sprintf("%d", blockHeight))
 	}
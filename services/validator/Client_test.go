@@ -27,6 +27,8 @@ type MockValidatorAPIClient struct {
 	healthGRPCFunc         func(ctx context.Context, in *validator_api.EmptyMessage) (*validator_api.HealthResponse, error)
 	getBlockHeightFunc     func(ctx context.Context, in *validator_api.EmptyMessage) (*validator_api.GetBlockHeightResponse, error)
 	getMedianBlockTimeFunc func(ctx context.Context, in *validator_api.EmptyMessage) (*validator_api.GetMedianBlockTimeResponse, error)
+	validatePackageFunc    func(ctx context.Context, in *validator_api.ValidatePackageRequest) (*validator_api.ValidatePackageResponse, error)
+	validatePolicyFunc     func(ctx context.Context, in *validator_api.ValidatePolicyRequest) (*validator_api.ValidatePolicyResponse, error)
 }
 
 func (m *MockValidatorAPIClient) ValidateTransaction(ctx context.Context, in *validator_api.ValidateTransactionRequest, opts ...grpc.CallOption) (*validator_api.ValidateTransactionResponse, error) {
@@ -69,6 +71,22 @@ func (m *MockValidatorAPIClient) GetMedianBlockTime(ctx context.Context, in *val
 	return nil, errors.NewProcessingError("not implemented")
 }
 
+func (m *MockValidatorAPIClient) ValidatePackage(ctx context.Context, in *validator_api.ValidatePackageRequest, opts ...grpc.CallOption) (*validator_api.ValidatePackageResponse, error) {
+	if m.validatePackageFunc != nil {
+		return m.validatePackageFunc(ctx, in)
+	}
+
+	return nil, errors.NewProcessingError("not implemented")
+}
+
+func (m *MockValidatorAPIClient) ValidatePolicy(ctx context.Context, in *validator_api.ValidatePolicyRequest, opts ...grpc.CallOption) (*validator_api.ValidatePolicyResponse, error) {
+	if m.validatePolicyFunc != nil {
+		return m.validatePolicyFunc(ctx, in)
+	}
+
+	return nil, errors.NewProcessingError("not implemented")
+}
+
 func setupTestClient(t *testing.T, mockClient *MockValidatorAPIClient) (*Client, *httptest.Server) {
 	// Create an HTTP test server for HTTP fallback testing
 	mockHTTPServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -88,6 +88,21 @@ type Interface interface {
 	//   - error: Validation errors if transaction violates consensus rules or policy constraints
 	ValidateWithOptions(ctx context.Context, tx *bt.Tx, blockHeight uint32, validationOptions *Options) (*meta.Data, error)
 
+	// ValidatePackage validates a package of dependent transactions together and admits all of them or none of
+	// them. The fees of the new transactions of the package are checked over the whole package, so a child can pay
+	// the fee of its parent.
+	//
+	// Parameters:
+	//   - ctx: Context for the validation operation, supports cancellation and timeouts
+	//   - txs: The transactions of the package, in any order
+	//   - blockHeight: Current block height for validation context, 0 for the next block
+	//   - opts: Optional validation settings that modify validation behavior (e.g., policy rules)
+	//
+	// Returns:
+	//   - *PackageResult: The result of the package and of each of its transactions
+	//   - error: The reason the package was rejected, nil when all transactions were admitted
+	ValidatePackage(ctx context.Context, txs []*bt.Tx, blockHeight uint32, opts ...Option) (*PackageResult, error)
+
 	// GetBlockHeight returns the current block height known to the validator service.
 	// This height is used for validation context and consensus rule application, and should
 	// reflect the latest confirmed block in the blockchain.
@@ -164,6 +179,32 @@ func (mv *MockValidator) ValidateWithOptions(ctx context.Context, tx *bt.Tx, blo
 	return util.TxMetaDataFromTx(tx)
 }
 
+// ValidatePackage implements mock transaction package validation
+// Always accepts all transactions without performing any actual validation
+// Parameters:
+//   - ctx: Context for validation (unused in mock)
+//   - txs: Transactions of the package
+//   - blockHeight: Block height for validation context (unused in mock)
+//   - opts: Optional validation settings (unused in mock)
+//
+// Returns:
+//   - *PackageResult: All transactions accepted
+//   - error: Always returns nil
+func (mv *MockValidator) ValidatePackage(ctx context.Context, txs []*bt.Tx, blockHeight uint32, opts ...Option) (*PackageResult, error) {
+	result := &PackageResult{Accepted: true}
+
+	for _, tx := range txs {
+		result.Transactions = append(result.Transactions, &PackageTxResult{
+			TxID:   *tx.TxIDChainHash(),
+			Status: PackageTxAccepted,
+			Size:   uint64(tx.Size()), // nolint:gosec
+		})
+		result.Size += uint64(tx.Size()) // nolint:gosec
+	}
+
+	return result, nil
+}
+
 // GetBlockHeight implements mock block height retrieval
 // Always returns 0 without actually checking any block height
 // Returns:
@@ -113,6 +113,51 @@ func (m *MockValidatorClient) ValidateWithOptions(ctx context.Context, tx *bt.Tx
 	return m.UtxoStore.Create(context.Background(), tx, 0)
 }
 
+// ValidatePackage performs mock transaction package validation with error injection support.
+// If errors are queued, the package is rejected with the first error, which is removed from the queue.
+// Otherwise, creates UTXO entries for all transactions using the configured UTXO store.
+func (m *MockValidatorClient) ValidatePackage(ctx context.Context, txs []*bt.Tx, blockHeight uint32, opts ...Option) (*PackageResult, error) {
+	result := &PackageResult{}
+
+	for _, tx := range txs {
+		result.Transactions = append(result.Transactions, &PackageTxResult{
+			TxID:   *tx.TxIDChainHash(),
+			Status: PackageTxNotAdmitted,
+			Size:   uint64(tx.Size()), // nolint:gosec
+		})
+	}
+
+	m.ErrorsMu.Lock()
+	defer m.ErrorsMu.Unlock()
+
+	if len(m.Errors) > 0 {
+		// return error and pop of stack
+		err := m.Errors[0]
+		m.Errors = m.Errors[1:]
+
+		return result, err
+	}
+
+	for i, tx := range txs {
+		txMeta, err := m.UtxoStore.Create(context.Background(), tx, 0)
+		if err != nil {
+			result.Transactions[i].Status = PackageTxRejected
+			result.Transactions[i].Err = err
+
+			return result, err
+		}
+
+		result.Transactions[i].Status = PackageTxAccepted
+		result.Transactions[i].Fee = txMeta.Fee
+		result.Fee += txMeta.Fee
+		result.Size += result.Transactions[i].Size
+	}
+
+	result.Accepted = true
+
+	return result, nil
+}
+
 // TriggerBatcher implements the batcher trigger interface for testing.
 // This is a no-op in the mock implementation as no actual batching occurs.
 func (m *MockValidatorClient) TriggerBatcher() {}
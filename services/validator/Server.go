@@ -36,12 +36,14 @@ import (
 	"github.com/bsv-blockchain/go-bt/v2"
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/services/blockassembly"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/subtreeprocessor"
 	"github.com/bsv-blockchain/teranode/services/blockchain"
 	"github.com/bsv-blockchain/teranode/services/validator/validator_api"
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/stores/utxo"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
 	"github.com/bsv-blockchain/teranode/util/health"
 	"github.com/bsv-blockchain/teranode/util/kafka"
 	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
@@ -54,7 +56,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/proto"
 )
 
 // Server implements the validator gRPC service and manages validation operations.
@@ -132,6 +133,10 @@ type Server struct {
 	// synchronous validation path for clients. This server is used to process HTTP
 	// requests and return validation results.
 	httpServer *echo.Echo
+
+	// policy evaluates the transaction acceptance policy of the node for the ValidatePolicy endpoint,
+	// allowing operators to test a transaction against the current policy without submitting it.
+	policy *PolicyEngine
 }
 
 // NewServer creates and initializes a new validator server instance with the specified components.
@@ -167,6 +172,7 @@ func NewServer(logger ulogger.Logger, tSettings *settings.Settings, utxoStore ut
 		txMetaKafkaProducerClient:     txMetaKafkaProducerClient,
 		rejectedTxKafkaProducerClient: rejectedTxKafkaProducerClient,
 		blockAssemblyClient:           blockAssemblyClient,
+		policy:                        NewPolicyEngine(logger, tSettings),
 	}
 }
 
@@ -270,8 +276,9 @@ func (v *Server) HealthGRPC(ctx context.Context, _ *validator_api.EmptyMessage)
 	status, details, err := v.Health(ctx, false)
 
 	return &validator_api.HealthResponse{
-		Ok:      status == http.StatusOK,
-		Details: details,
+		Ok:        status == http.StatusOK,
+		Details:   details,
+		BuildInfo: buildinfo.Get(v.settings).JSON(),
 	}, errors.WrapGRPC(err)
 }
 
@@ -332,7 +339,7 @@ func (v *Server) Start(ctx context.Context, readyCh chan<- struct{}) error {
 
 	kafkaMessageHandler := func(msg *kafka.KafkaMessage) error {
 		var kafkaMsg kafkamessage.KafkaTxValidationTopicMessage
-		if err := proto.Unmarshal(msg.Value, &kafkaMsg); err != nil {
+		if err := kafkamessage.Decode(msg.Value, &kafkaMsg); err != nil {
 			v.logger.Errorf("Failed to unmarshal kafka message: %v", err)
 
 			return err
@@ -483,6 +490,14 @@ func (v *Server) validateTransaction(ctx context.Context, req *validator_api.Val
 		validationOptions.CreateConflicting = *req.CreateConflicting
 	}
 
+	if req.TxPriority != nil {
+		validationOptions.TxPriority = subtreeprocessor.TxPriority(*req.TxPriority)
+	}
+
+	if req.PeerId != nil {
+		validationOptions.PeerID = *req.PeerId
+	}
+
 	txMetaData, err := v.validator.ValidateWithOptions(ctx, tx, req.BlockHeight, validationOptions)
 	if err != nil {
 		prometheusInvalidTransactions.Inc()
@@ -565,6 +580,152 @@ func (v *Server) ValidateTransactionBatch(ctx context.Context, req *validator_ap
 	}, nil
 }
 
+// ValidatePackage implements the gRPC endpoint for validating a package of dependent transactions together.
+// All transactions of the package are admitted, or none of them are. The fees of the new transactions are
+// checked over the whole package, so a child can pay the fee of its parent.
+//
+// Like batch validation, the rejection of the package is returned in the response, together with the result of
+// each transaction, in the order of the request.
+//
+// Parameters:
+//   - ctx: Context for the validation operation, used for cancellation and tracing
+//   - req: ValidatePackageRequest containing the transactions of the package and the validation options
+//
+// Returns:
+//   - *validator_api.ValidatePackageResponse: Result of the package and of each transaction
+//   - error: Invalid argument error if a transaction cannot be parsed
+func (v *Server) ValidatePackage(ctx context.Context, req *validator_api.ValidatePackageRequest) (*validator_api.ValidatePackageResponse, error) {
+	ctx, _, deferFn := tracing.Tracer("validator").Start(ctx, "ValidatePackage",
+		tracing.WithParentStat(v.stats),
+		tracing.WithDebugLogMessage(v.logger, "[ValidatePackage] called for %d transactions", len(req.GetTransactions())),
+	)
+	defer deferFn()
+
+	txs := make([]*bt.Tx, 0, len(req.GetTransactions()))
+
+	for idx, transactionData := range req.GetTransactions() {
+		tx, err := bt.NewTxFromBytes(transactionData)
+		if err != nil {
+			prometheusInvalidTransactions.Inc()
+
+			return nil, errors.WrapGRPC(errors.NewInvalidArgumentError("error reading transaction %d of the package", idx, err))
+		}
+
+		txs = append(txs, tx)
+	}
+
+	opts := make([]Option, 0, 2)
+
+	if req.AddTxToBlockAssembly != nil {
+		opts = append(opts, WithAddTXToBlockAssembly(*req.AddTxToBlockAssembly))
+	}
+
+	if req.SkipPolicyChecks != nil {
+		opts = append(opts, WithSkipPolicyChecks(*req.SkipPolicyChecks))
+	}
+
+	result, err := v.validator.ValidatePackage(ctx, txs, req.GetBlockHeight(), opts...)
+	if err != nil {
+		prometheusInvalidTransactions.Inc()
+	}
+
+	return packageResultToResponse(result, err), nil
+}
+
+// packageResultToResponse converts the result of a package validation to the gRPC response
+func packageResultToResponse(result *PackageResult, err error) *validator_api.ValidatePackageResponse {
+	response := &validator_api.ValidatePackageResponse{
+		Error: errors.Wrap(err),
+	}
+
+	if result == nil {
+		return response
+	}
+
+	response.Accepted = result.Accepted && err == nil
+	response.Fee = result.Fee
+	response.Size = result.Size
+	response.Transactions = make([]*validator_api.PackageTransactionResult, 0, len(result.Transactions))
+
+	for _, txResult := range result.Transactions {
+		response.Transactions = append(response.Transactions, &validator_api.PackageTransactionResult{
+			Txid:   txResult.TxID.CloneBytes(),
+			Status: validator_api.PackageTransactionStatus(txResult.Status), // nolint:gosec
+			Fee:    txResult.Fee,
+			Size:   txResult.Size,
+			Error:  errors.Wrap(txResult.Err),
+		})
+	}
+
+	return response
+}
+
+// ValidatePolicy implements the gRPC endpoint for testing a transaction against the transaction acceptance policy
+// of the node. Every policy rule is evaluated, including the disabled rules, and the result of each rule is returned.
+// The transaction passes when it passes all enabled rules.
+//
+// The transaction is not validated: its scripts are not verified, its inputs are not spent and it is not stored.
+// Transactions not in extended format are extended from the UTXO store, to evaluate the fee rule.
+//
+// Parameters:
+//   - ctx: Context for the operation, used for tracing and cancellation
+//   - req: ValidatePolicyRequest containing the raw transaction and the block height, 0 for the next block
+//
+// Returns:
+//   - *validator_api.ValidatePolicyResponse: Result of the transaction and of each policy rule
+//   - error: Invalid argument error if the transaction cannot be parsed, or an error extending the transaction
+func (v *Server) ValidatePolicy(ctx context.Context, req *validator_api.ValidatePolicyRequest) (*validator_api.ValidatePolicyResponse, error) {
+	ctx, _, deferFn := tracing.Tracer("validator").Start(ctx, "ValidatePolicy",
+		tracing.WithParentStat(v.stats),
+		tracing.WithDebugLogMessage(v.logger, "[ValidatePolicy] called"),
+	)
+	defer deferFn()
+
+	tx, err := bt.NewTxFromBytes(req.GetTransactionData())
+	if err != nil {
+		return nil, errors.WrapGRPC(errors.NewInvalidArgumentError("error reading transaction data", err))
+	}
+
+	if !tx.IsExtended() && !tx.IsCoinbase() {
+		if err = v.utxoStore.PreviousOutputsDecorate(ctx, tx); err != nil {
+			return nil, errors.WrapGRPC(errors.NewProcessingError("[ValidatePolicy] error extending transaction %s", tx.TxIDChainHash(), err))
+		}
+
+		tx.SetExtended(true)
+	}
+
+	blockHeight := req.GetBlockHeight()
+	if blockHeight == 0 {
+		blockHeight = v.validator.GetBlockHeight() + 1
+	}
+
+	response := &validator_api.ValidatePolicyResponse{
+		Passed: true,
+	}
+
+	// without the utxo heights, consolidation transactions are recognised on their inputs and outputs, not on the
+	// confirmations of their inputs
+	for _, result := range v.policy.Evaluate(tx, blockHeight, nil) {
+		ruleResult := &validator_api.PolicyRuleResult{
+			Rule:    string(result.Rule),
+			Enabled: result.Enabled,
+			Passed:  result.Err == nil,
+		}
+
+		if result.Err != nil {
+			ruleResult.Reason = result.Err.Error()
+
+			if result.Enabled {
+				response.Passed = false
+			}
+		}
+
+		response.Rules = append(response.Rules, ruleResult)
+	}
+
+	return response, nil
+}
+
 // GetBlockHeight implements the gRPC endpoint for retrieving the current blockchain height.
 // This method provides a critical service for clients needing to know the current chain state,
 // which is essential for transaction validation, block template generation, and determining
@@ -685,6 +846,15 @@ func extractValidationParams(c echo.Context) (uint32, *Options) {
 		options.CreateConflicting = boolVal
 	}
 
+	if txPriorityStr := c.QueryParam("txPriority"); txPriorityStr != "" {
+		priority, err := strconv.ParseUint(txPriorityStr, 10, 32)
+		if err == nil {
+			options.TxPriority = subtreeprocessor.TxPriority(priority)
+		}
+	}
+
+	options.PeerID = c.QueryParam("peerId")
+
 	return blockHeight, options
 }
 
@@ -728,6 +898,8 @@ func (v *Server) handleSingleTx(ctx context.Context) echo.HandlerFunc {
 			AddTxToBlockAssembly: &options.AddTXToBlockAssembly,
 			SkipPolicyChecks:     &options.SkipPolicyChecks,
 			CreateConflicting:    &options.CreateConflicting,
+			TxPriority:           (*uint32)(&options.TxPriority),
+			PeerId:               &options.PeerID,
 		}
 
 		// Process the transaction and return appropriate response
@@ -796,6 +968,8 @@ func (v *Server) handleMultipleTx(ctx context.Context) echo.HandlerFunc {
 				AddTxToBlockAssembly: &options.AddTXToBlockAssembly,
 				SkipPolicyChecks:     &options.SkipPolicyChecks,
 				CreateConflicting:    &options.CreateConflicting,
+				TxPriority:           (*uint32)(&options.TxPriority),
+				PeerId:               &options.PeerID,
 			}
 
 			response, err := v.validateTransaction(ctx, req)
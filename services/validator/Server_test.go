@@ -454,6 +454,25 @@ func (m *TestMockValidator) ValidateWithOptions(ctx context.Context, tx *bt.Tx,
 	return &meta.Data{}, nil
 }
 
+func (m *TestMockValidator) ValidatePackage(ctx context.Context, txs []*bt.Tx, blockHeight uint32, opts ...Option) (*PackageResult, error) {
+	result := &PackageResult{Accepted: true}
+
+	for _, tx := range txs {
+		if m.validateTxFunc != nil {
+			if _, err := m.validateTxFunc(ctx, tx); err != nil {
+				result.Accepted = false
+				result.Transactions = append(result.Transactions, &PackageTxResult{TxID: *tx.TxIDChainHash(), Status: PackageTxRejected, Err: err})
+
+				return result, err
+			}
+		}
+
+		result.Transactions = append(result.Transactions, &PackageTxResult{TxID: *tx.TxIDChainHash(), Status: PackageTxAccepted})
+	}
+
+	return result, nil
+}
+
 func (m *TestMockValidator) GetBlockHeight() uint32 {
 	return 101
 }
@@ -98,6 +98,7 @@ type TxValidator struct {
 	logger      ulogger.Logger
 	settings    *settings.Settings
 	interpreter TxScriptInterpreter
+	policy      *PolicyEngine
 	options     *TxValidatorOptions
 }
 
@@ -160,6 +161,7 @@ func NewTxValidator(logger ulogger.Logger, tSettings *settings.Settings, opts ..
 		logger:      logger,
 		settings:    tSettings,
 		interpreter: txScriptInterpreter,
+		policy:      NewPolicyEngine(logger, tSettings),
 		options:     options,
 	}
 }
@@ -236,7 +238,7 @@ func (tv *TxValidator) ValidateTransaction(tx *bt.Tx, blockHeight uint32, utxoHe
 
 	// 10) Reject if the sum of input values is less than sum of output values
 	// 11) Reject if transaction fee would be too low (minRelayTxFee) to get into an empty block.
-	if !validationOptions.SkipPolicyChecks {
+	if !validationOptions.SkipPolicyChecks && !validationOptions.skipFeeCheck {
 		if err := tv.checkFees(tx, blockHeight, utxoHeights); err != nil {
 			return err
 		}
@@ -319,27 +321,28 @@ func isStandardInputScript(script *bscript.Script, blockHeight uint32, uahfHeigh
 func (tv *TxValidator) checkOutputs(tx *bt.Tx, blockHeight uint32, validationOptions *Options) error {
 	total := uint64(0)
 
-	// Note: We use > instead of >= to exclude the Genesis activation block itself
-	// because transactions in block 620538 were created before Genesis rules existed
-	isGenesisActivated := blockHeight > tv.settings.ChainCfgParams.GenesisActivationHeight
+	policy := tv.policyEngine()
+
+	// P2SH and dust checks are policy rules, not consensus rules - they only apply to mempool/relay after genesis
+	// activation, unless the rules are disabled
+	checkPolicy := !validationOptions.SkipPolicyChecks && policy.isGenesisActivated(blockHeight)
+	checkP2SH := checkPolicy && policy.Enabled(PolicyRuleP2SHOutputs)
+	checkDust := checkPolicy && policy.Enabled(PolicyRuleDust)
 
 	for index, output := range tx.Outputs {
-		// Check P2SH output after genesis activation
-		if !validationOptions.SkipPolicyChecks && isGenesisActivated && output.LockingScript.IsP2SH() {
-			// See https://github.com/bitcoin-sv/teranode/issues/4333
-			return errors.NewTxInvalidError("transaction output %d is p2sh after genesis activation", index)
+		if checkP2SH {
+			if err := policy.checkP2SHOutput(index, output); err != nil {
+				return err
+			}
 		}
 
 		if output.Satoshis > MaxSatoshis {
 			return errors.NewTxInvalidError("transaction output %d satoshis is invalid", index)
 		}
 
-		// Check dust limit after genesis activation
-		// Dust checks are policy rules, not consensus rules - they only apply to mempool/relay
-		if !validationOptions.SkipPolicyChecks && isGenesisActivated {
-			// Only enforce dust limit for spendable outputs when RequireStandard is true
-			if tv.settings.ChainCfgParams.RequireStandard && output.Satoshis < DustLimit && !isUnspendableOutput(output.LockingScript) {
-				return errors.NewTxInvalidError("zero-satoshi outputs require 'OP_FALSE OP_RETURN' prefix")
+		if checkDust {
+			if err := policy.checkDustOutput(index, output); err != nil {
+				return err
 			}
 		}
 
@@ -413,211 +416,48 @@ func (tv *TxValidator) checkInputs(tx *bt.Tx, blockHeight uint32) error {
 	return nil
 }
 
-// checkTxSize validates that the transaction size complies with policy limits.
+// checkTxSize validates that the transaction size complies with policy limits, unless the max tx size policy rule
+// is disabled.
 func (tv *TxValidator) checkTxSize(txSize int) error {
-	maxTxSizePolicy := tv.settings.Policy.GetMaxTxSizePolicy()
-	if maxTxSizePolicy == 0 {
-		// no policy found for tx size, use max block size
-		maxTxSizePolicy = MaxBlockSize
-	}
+	policy := tv.policyEngine()
 
-	if txSize > maxTxSizePolicy {
-		return errors.NewTxInvalidError("transaction size in bytes is greater than max tx size policy %d", maxTxSizePolicy)
+	if !policy.Enabled(PolicyRuleMaxTxSize) {
+		return nil
 	}
 
-	return nil
+	return policy.checkTxSize(txSize)
 }
 
-// checkFees validates transaction fees according to policy requirements.
+// checkFees validates transaction fees according to policy requirements, unless the min fee policy rule is disabled.
 func (tv *TxValidator) checkFees(tx *bt.Tx, blockHeight uint32, utxoHeights []uint32) error {
-	// Check for consolidation transaction with proper UTXO height verification
-	isConsolidation := tv.isConsolidationTx(tx, utxoHeights, blockHeight)
-	if isConsolidation {
-		return nil // We return nil here to say there was no issue with the fees
-	}
-
-	inputSats := tx.TotalInputSatoshis()
-	outputSats := tx.TotalOutputSatoshis()
-
-	if inputSats < outputSats {
-		return errors.NewTxInvalidError("transaction input satoshis is less than output satoshis: %d < %d", inputSats, outputSats)
-	}
-
-	minFeeRateBSVPerKB := tv.settings.Policy.GetMinMiningTxFee() // BSV per kilobyte
+	policy := tv.policyEngine()
 
-	if minFeeRateBSVPerKB == 0 {
-		return nil // no fee policy found, skip fee check
+	if !policy.Enabled(PolicyRuleMinFee) {
+		return nil
 	}
 
-	actualFeePaid := inputSats - outputSats
-
-	// Convert BSV/kB to satoshis/byte
-	// 1 BSV = 1e8 satoshis
-	// 1 kB = 1000 bytes
-	// So BSV/kB * 1e8 / 1000 = satoshis/byte
-	satoshisPerByte := minFeeRateBSVPerKB * 1e8 / 1000
-
-	// Calculate minimum relay fee based on transaction size
-	txSize := tx.Size()
-	minRequiredFee := uint64(satoshisPerByte * float64(txSize))
-
-	// Ensure minimum 1 satoshi for non-zero sized transactions (matching Bitcoin SV)
-	if minRequiredFee == 0 && txSize > 0 && minFeeRateBSVPerKB > 0 {
-		minRequiredFee = 1
-	}
-
-	if actualFeePaid < minRequiredFee {
-		return errors.NewTxInvalidError("transaction fee is too low: %d < %d required", actualFeePaid, minRequiredFee)
-	}
-
-	return nil
+	return policy.checkFees(tx, blockHeight, utxoHeights)
 }
 
-// isDustReturnTx checks if a transaction is a dust return transaction.
-// A dust return transaction has a single output with 0 satoshis and an unspendable script
-// (OP_FALSE OP_RETURN pattern). These transactions are used to clean up dust UTXOs.
-//
-// Parameters:
-//   - tx: The transaction to check
-//
-// Returns:
-//   - bool: true if the transaction is a dust return transaction, false otherwise
+// isDustReturnTx checks if a transaction is a dust return transaction, see PolicyEngine.isDustReturnTx.
 func (tv *TxValidator) isDustReturnTx(tx *bt.Tx) bool {
-	if tx == nil {
-		return false
-	}
-
-	// Must have exactly one output
-	if len(tx.Outputs) != 1 {
-		return false
-	}
-
-	output := tx.Outputs[0]
-
-	// Output must have 0 satoshis
-	if output.Satoshis != 0 {
-		return false
-	}
-
-	// Output script must be unspendable (OP_FALSE OP_RETURN)
-	return isUnspendableOutput(output.LockingScript)
+	return tv.policyEngine().isDustReturnTx(tx)
 }
 
-// isConsolidationTx checks if a transaction qualifies as a consolidation transaction
-// following Bitcoin SV rules.
-//
-// Parameters:
-//   - tx: The transaction to check
-//   - utxoHeights: Block heights of the UTXOs being spent (nil for fee checks only)
-//   - currentHeight: Current block height (ignored if utxoHeights is nil)
-//
-// Returns:
-//   - bool: true if the transaction qualifies as a consolidation transaction
+// isConsolidationTx checks if a transaction qualifies as a consolidation transaction, see
+// PolicyEngine.isConsolidationTx.
 func (tv *TxValidator) isConsolidationTx(tx *bt.Tx, utxoHeights []uint32, currentHeight uint32) bool {
-	if tx == nil {
-		return false
-	}
-
-	// Coinbase transactions cannot be consolidation transactions
-	if tx.IsCoinbase() {
-		return false
-	}
-
-	// Get policy settings
-	minConsolidationFactor := tv.settings.Policy.GetMinConsolidationFactor()
-	if minConsolidationFactor <= 0 {
-		return false
-	}
-
-	numInputs := len(tx.Inputs)
-	numOutputs := len(tx.Outputs)
-
-	// Check if it's a dust return transaction (special case)
-	isDustReturn := tv.isDustReturnTx(tx)
-
-	// Rule 1: Input/Output Ratio
-	// The number of inputs must be >= minConsolidationFactor × number of outputs
-	if !isDustReturn && numInputs < minConsolidationFactor*numOutputs {
-		return false
-	}
-
-	// Rule 2: Script Size Comparison (Bitcoin SV rule)
-	// Sum of input scriptPubKey sizes >= minConsolidationFactor × sum of output scriptPubKey sizes
-	if !isDustReturn {
-		// Check if transaction is extended (has PreviousTxScript for all inputs)
-		for _, input := range tx.Inputs {
-			if input.PreviousTxScript == nil {
-				return false
-			}
-		}
-
-		// Calculate total size of scriptPubKeys from UTXOs being spent
-		totalInputScriptPubKeySize := 0
-		for _, input := range tx.Inputs {
-			totalInputScriptPubKeySize += len(*input.PreviousTxScript)
-		}
-
-		// Calculate total size of output scriptPubKeys
-		totalOutputScriptPubKeySize := 0
-		for _, output := range tx.Outputs {
-			if output.LockingScript != nil {
-				totalOutputScriptPubKeySize += len(*output.LockingScript)
-			}
-		}
-
-		// Check the script size ratio
-		if totalInputScriptPubKeySize < minConsolidationFactor*totalOutputScriptPubKeySize {
-			return false
-		}
-	}
-
-	// If no UTXO heights provided, we're done (fee exemption check)
-	if utxoHeights == nil {
-		return true
-	}
-
-	// FULL VALIDATION - Only performed when UTXO heights are provided
-
-	// Get configuration settings
-	minConf := tv.settings.Policy.GetMinConfConsolidationInput()
-	maxInputScriptSize := tv.settings.Policy.GetMaxConsolidationInputScriptSize()
-	acceptNonStdInputs := tv.settings.Policy.GetAcceptNonStdConsolidationInput()
-
-	// Dust return transactions don't require confirmations
-	if isDustReturn {
-		minConf = 0
-	}
-
-	// Check each input
-	for i, input := range tx.Inputs {
-		// Rule 3: Input Maturity
-		// All inputs must have at least minConfConsolidationInput confirmations
-		if minConf > 0 && i < len(utxoHeights) {
-			inputHeight := utxoHeights[i]
-			confirmations := int(currentHeight - inputHeight)
-			if confirmations < minConf {
-				return false
-			}
-		}
-
-		// Rule 4: Input Script Size Limit
-		// Each input's scriptSig must be <= maxConsolidationInputScriptSize bytes
-		if maxInputScriptSize > 0 && input.UnlockingScript != nil {
-			scriptSize := len(*input.UnlockingScript)
-			if scriptSize > maxInputScriptSize {
-				return false
-			}
-		}
+	return tv.policyEngine().isConsolidationTx(tx, utxoHeights, currentHeight)
+}
 
-		// Rule 5: Standard Script Rule
-		// If acceptNonStdConsolidationInput = 0, all inputs must use standard scripts
-		if !acceptNonStdInputs && !isStandardInputScript(input.UnlockingScript, currentHeight, tv.settings.ChainCfgParams.UahfForkHeight) {
-			return false
-		}
+// policyEngine returns the policy engine of the validator, validators not created with NewTxValidator use a policy
+// engine of their settings
+func (tv *TxValidator) policyEngine() *PolicyEngine {
+	if tv.policy != nil {
+		return tv.policy
 	}
 
-	// Transaction qualifies as a consolidation transaction
-	return true
+	return &PolicyEngine{settings: tv.settings}
 }
 
 // sigOpsCheck validates that the transaction's signature operations count complies with policy limits.
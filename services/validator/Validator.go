@@ -19,6 +19,7 @@ import (
 	"github.com/bsv-blockchain/go-subtree"
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/services/blockassembly"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/subtreeprocessor"
 	"github.com/bsv-blockchain/teranode/services/blockchain"
 	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
 	"github.com/bsv-blockchain/teranode/settings"
@@ -33,7 +34,6 @@ import (
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/ordishs/gocore"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/protobuf/proto"
 )
 
 // Constants defining key validation parameters and limits for Bitcoin SV consensus rules.
@@ -62,10 +62,10 @@ const (
 	// operations allowed in a transaction after the Genesis upgrade (UINT32_MAX).
 	MaxTxSigopsCountPolicyAfterGenesis = ^uint32(0)
 
-	// DustLimit defines the minimum output value in satoshis (1 satoshi)
+	// DustLimit defines the default minimum output value in satoshis (1 satoshi)
 	// Outputs with less than this value are considered dust unless they are
 	// not spendable (OP_FALSE OP_RETURN).  This applies to outputs after the
-	// Genesis upgrade.
+	// Genesis upgrade. The dustlimit policy setting overrides it.
 	DustLimit = uint64(1)
 )
 
@@ -123,6 +123,9 @@ type Validator struct {
 
 	// rejectedTxKafkaProducerClient publishes rejected transaction events
 	rejectedTxKafkaProducerClient kafka.KafkaAsyncProducerI
+
+	// doubleSpendKafkaProducerClient publishes double spend events, nil when no double spends topic is configured
+	doubleSpendKafkaProducerClient kafka.KafkaAsyncProducerI
 }
 
 // New creates a new Validator instance with the provided configuration.
@@ -165,6 +168,16 @@ func New(ctx context.Context, logger ulogger.Logger, tSettings *settings.Setting
 		v.rejectedTxKafkaProducerClient.Start(ctx, make(chan *kafka.Message, 10_000))
 	}
 
+	if tSettings.Kafka.DoubleSpendsConfig != nil {
+		doubleSpendKafkaProducer, err := initialiseDoubleSpendKafkaProducer(ctx, logger, tSettings)
+		if err != nil {
+			logger.Errorf("Failed to create Kafka producer for double spends: %v", err)
+		} else {
+			v.doubleSpendKafkaProducerClient = doubleSpendKafkaProducer
+			v.doubleSpendKafkaProducerClient.Start(ctx, make(chan *kafka.Message, 10_000))
+		}
+	}
+
 	return v, nil
 }
 
@@ -281,53 +294,63 @@ func (v *Validator) Validate(ctx context.Context, tx *bt.Tx, blockHeight uint32,
 //   - error: Detailed validation error if validation fails, nil on success
 func (v *Validator) ValidateWithOptions(ctx context.Context, tx *bt.Tx, blockHeight uint32, validationOptions *Options) (txMetaData *meta.Data, err error) {
 	if txMetaData, err = v.validateInternal(ctx, tx, blockHeight, validationOptions); err != nil {
-		if v.rejectedTxKafkaProducerClient != nil { // tests may not set this
-			// TODO which errors should we be sending here?
-			if !errors.Is(err, errors.ErrStorageError) && !errors.Is(err, errors.ErrServiceError) && !errors.Is(err, errors.ErrTxMissingParent) {
-				if v.blockchainClient != nil {
-					var (
-						state *blockchain.FSMStateType
-						err1  error
-					)
-
-					if state, err1 = v.blockchainClient.GetFSMCurrentState(ctx); err1 != nil {
-						v.logger.Errorf("[ValidateWithOptions] failed to publish rejected tx - error getting blockchain FSM state: %v", err1)
-
-						return
-					}
-
-					if *state == blockchain_api.FSMStateType_CATCHINGBLOCKS || *state == blockchain_api.FSMStateType_LEGACYSYNCING {
-						// ignore notifications while syncing or catching up
-						return
-					}
-				}
+		if publishErr := v.publishRejectedTx(ctx, tx, err); publishErr != nil {
+			return nil, publishErr
+		}
+	}
 
-				startKafka := time.Now()
+	return txMetaData, err
+}
 
-				txID := tx.TxIDChainHash().String()
+// publishRejectedTx reports a rejected transaction to the rejected transaction Kafka topic, unless the rejection was
+// caused by a storage or service error or a missing parent, or the node is syncing.
+func (v *Validator) publishRejectedTx(ctx context.Context, tx *bt.Tx, rejectErr error) error {
+	if v.rejectedTxKafkaProducerClient == nil { // tests may not set this
+		return nil
+	}
 
-				m := &kafkamessage.KafkaRejectedTxTopicMessage{
-					TxHash: txID,
-					Reason: err.Error(),
-					PeerId: "", // Empty peer_id indicates internal rejection
-				}
+	// TODO which errors should we be sending here?
+	if errors.Is(rejectErr, errors.ErrStorageError) || errors.Is(rejectErr, errors.ErrServiceError) || errors.Is(rejectErr, errors.ErrTxMissingParent) {
+		return nil
+	}
 
-				value, err := proto.Marshal(m)
-				if err != nil {
-					return nil, err
-				}
+	if v.blockchainClient != nil {
+		state, err := v.blockchainClient.GetFSMCurrentState(ctx)
+		if err != nil {
+			v.logger.Errorf("[ValidateWithOptions] failed to publish rejected tx - error getting blockchain FSM state: %v", err)
 
-				v.rejectedTxKafkaProducerClient.Publish(&kafka.Message{
-					Key:   []byte(txID),
-					Value: value,
-				})
+			return nil
+		}
 
-				prometheusValidatorSendToP2PKafka.Observe(float64(time.Since(startKafka).Microseconds()) / 1_000_000)
-			}
+		if *state == blockchain_api.FSMStateType_CATCHINGBLOCKS || *state == blockchain_api.FSMStateType_LEGACYSYNCING {
+			// ignore notifications while syncing or catching up
+			return nil
 		}
 	}
 
-	return txMetaData, err
+	startKafka := time.Now()
+
+	txID := tx.TxIDChainHash().String()
+
+	m := &kafkamessage.KafkaRejectedTxTopicMessage{
+		TxHash: txID,
+		Reason: rejectErr.Error(),
+		PeerId: "", // Empty peer_id indicates internal rejection
+	}
+
+	value, err := kafkamessage.Encode(m)
+	if err != nil {
+		return err
+	}
+
+	v.rejectedTxKafkaProducerClient.Publish(&kafka.Message{
+		Key:   []byte(txID),
+		Value: value,
+	})
+
+	prometheusValidatorSendToP2PKafka.Observe(float64(time.Since(startKafka).Microseconds()) / 1_000_000)
+
+	return nil
 }
 
 // validateInternal performs the core validation logic for a transaction.
@@ -516,6 +539,8 @@ func (v *Validator) validateInternal(ctx context.Context, tx *bt.Tx, blockHeight
 				}
 			}
 
+			v.publishDoubleSpend(decoupledCtx, tx, blockHeight, spentUtxos, validationOptions)
+
 			if saveAsConflicting {
 				if txMetaData, utxoMapErr = v.CreateInUtxoStore(decoupledCtx, tx, blockHeight, true, false); utxoMapErr != nil {
 					if errors.Is(utxoMapErr, errors.ErrTxExists) {
@@ -609,6 +634,7 @@ func (v *Validator) validateInternal(ctx context.Context, tx *bt.Tx, blockHeight
 			Fee:           txMetaData.Fee,
 			Size:          uint64(tx.Size()), // nolint:gosec
 			TxInpoints:    txInpoints,
+			Priority:      v.txPriority(tx, blockHeight, blockState.Height, utxoHeights, validationOptions),
 		}, spentUtxos); err != nil {
 			err = errors.NewProcessingError("[Validate][%s] error sending tx to block assembler", txID, err)
 			span.RecordError(err)
@@ -808,7 +834,7 @@ func (v *Validator) sendTxMetaToKafka(data *meta.Data, txHash *chainhash.Hash) e
 		v.logger.Warnf("stored tx meta maybe too big for txmeta cache, size: %d, parent hash count: %d", len(metaBytes), len(data.TxInpoints.ParentTxHashes))
 	}
 
-	value, err := proto.Marshal(&kafkamessage.KafkaTxMetaTopicMessage{
+	value, err := kafkamessage.Encode(&kafkamessage.KafkaTxMetaTopicMessage{
 		TxHash:  txHash.String(),
 		Action:  kafkamessage.KafkaTxMetaActionType_ADD,
 		Content: metaBytes,
@@ -866,7 +892,8 @@ func (v *Validator) sendToBlockAssembler(ctx context.Context, bData *blockassemb
 		v.logger.Debugf("[Validator] sending tx %s to block assembler", bData.TxIDChainHash.String())
 	}
 
-	if _, err := v.blockAssembler.Store(ctx, &bData.TxIDChainHash, bData.Fee, bData.Size, bData.TxInpoints); err != nil {
+	if _, err := v.blockAssembler.Store(ctx, &bData.TxIDChainHash, bData.Fee, bData.Size, bData.TxInpoints,
+		blockassembly.WithTxPriority(bData.Priority)); err != nil {
 		e := errors.NewServiceError("error calling blockAssembler Store()", err)
 		span.RecordError(e)
 
@@ -876,6 +903,32 @@ func (v *Validator) sendToBlockAssembler(ctx context.Context, bData *blockassemb
 	return nil
 }
 
+// txPriority returns the priority lane of the transaction in block assembly. Transactions tagged by the operator get
+// the requested lane and consolidation transactions the consolidation lane, when it is enabled. A transaction in a lane
+// can be placed ahead of transactions that arrived before it, so it only gets a lane when all its parents are mined
+// before the tip, parents that are not mined are reported at the tip height.
+func (v *Validator) txPriority(tx *bt.Tx, blockHeight, tipHeight uint32, utxoHeights []uint32, validationOptions *Options) subtreeprocessor.TxPriority {
+	priority := validationOptions.TxPriority
+
+	if priority == subtreeprocessor.TxPriorityDefault && v.settings.BlockAssembly.ConsolidationLaneQuota > 0 {
+		if tv, ok := v.txValidator.(*TxValidator); ok && tv.isConsolidationTx(tx, utxoHeights, blockHeight) {
+			priority = subtreeprocessor.TxPriorityConsolidation
+		}
+	}
+
+	if priority == subtreeprocessor.TxPriorityDefault || len(utxoHeights) != len(tx.Inputs) {
+		return subtreeprocessor.TxPriorityDefault
+	}
+
+	for _, height := range utxoHeights {
+		if height >= tipHeight {
+			return subtreeprocessor.TxPriorityDefault
+		}
+	}
+
+	return priority
+}
+
 // reverseSpends reverses previously spent UTXOs in case of validation failure.
 // Attempts up to 3 retries with exponential backoff.
 // Returns error if UTXO reversal fails.
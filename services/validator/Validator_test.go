@@ -61,7 +61,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/proto"
 )
 
 func BenchmarkValidator(b *testing.B) {
@@ -238,7 +237,7 @@ func TestValidate_BlockAssemblyAndTxMetaChannels(t *testing.T) {
 
 	// unmarshal the kafka message
 	var kafkaMsg kafkamessage.KafkaTxMetaTopicMessage
-	err = proto.Unmarshal(msg.Value, &kafkaMsg)
+	err = kafkamessage.Decode(msg.Value, &kafkaMsg)
 	require.NoError(t, err)
 
 	assert.Equal(t, tx.TxID(), kafkaMsg.TxHash)
@@ -678,7 +677,7 @@ type MockBlockAssemblyStore struct {
 	removedTxs  []chainhash.Hash
 }
 
-func (s *MockBlockAssemblyStore) Store(_ context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints) (bool, error) {
+func (s *MockBlockAssemblyStore) Store(_ context.Context, hash *chainhash.Hash, fee, size uint64, txInpoints subtree.TxInpoints, _ ...blockassembly.StoreOption) (bool, error) {
 	if s.returnError != nil {
 		return false, s.returnError
 	}
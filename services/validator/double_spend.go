@@ -0,0 +1,111 @@
+// Package validator implements Bitcoin SV transaction validation functionality.
+//
+// This file implements the double spend notifications, publishing the conflicting spends detected when spending the
+// inputs of a transaction to the double spends Kafka topic.
+package validator
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/stores/utxo"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/kafka"
+	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
+)
+
+// initialiseDoubleSpendKafkaProducer creates a Kafka producer for double spend events
+func initialiseDoubleSpendKafkaProducer(ctx context.Context, logger ulogger.Logger, tSettings *settings.Settings) (*kafka.KafkaAsyncProducer, error) {
+	logger.Infof("Initializing Kafka producer for double spends topic: %s", tSettings.Kafka.DoubleSpends)
+
+	doubleSpendKafkaProducer, err := kafka.NewKafkaAsyncProducerFromURL(ctx, logger, tSettings.Kafka.DoubleSpendsConfig, &tSettings.Kafka)
+	if err != nil {
+		return nil, err
+	}
+
+	return doubleSpendKafkaProducer, nil
+}
+
+// doubleSpendOutputs returns the outputs of the failed spends that were already spent by, or conflict with, another
+// transaction, nil when none of the spends is a double spend
+func doubleSpendOutputs(spends []*utxo.Spend) []*kafkamessage.KafkaDoubleSpendOutput {
+	var outputs []*kafkamessage.KafkaDoubleSpendOutput
+
+	for _, spend := range spends {
+		if spend == nil || spend.TxID == nil || spend.ConflictingTxID == nil {
+			continue
+		}
+
+		if !errors.Is(spend.Err, errors.ErrSpent) && !errors.Is(spend.Err, errors.ErrTxConflicting) {
+			continue
+		}
+
+		outputs = append(outputs, &kafkamessage.KafkaDoubleSpendOutput{
+			TxHash:         spend.TxID.String(),
+			Vout:           spend.Vout,
+			SpendingTxHash: spend.ConflictingTxID.String(),
+		})
+	}
+
+	return outputs
+}
+
+// publishDoubleSpend reports the double spends of the transaction to the double spends Kafka topic, unless the node
+// is syncing. Transactions validated for a block, with conflicting transactions allowed, are reported as block double
+// spends, all others as mempool double spends.
+func (v *Validator) publishDoubleSpend(ctx context.Context, tx *bt.Tx, blockHeight uint32, spends []*utxo.Spend, validationOptions *Options) {
+	if v.doubleSpendKafkaProducerClient == nil {
+		return
+	}
+
+	outputs := doubleSpendOutputs(spends)
+	if len(outputs) == 0 {
+		return
+	}
+
+	if v.blockchainClient != nil {
+		state, err := v.blockchainClient.GetFSMCurrentState(ctx)
+		if err != nil {
+			v.logger.Errorf("[publishDoubleSpend] failed to publish double spend - error getting blockchain FSM state: %v", err)
+
+			return
+		}
+
+		if *state == blockchain_api.FSMStateType_CATCHINGBLOCKS || *state == blockchain_api.FSMStateType_LEGACYSYNCING {
+			// ignore notifications while syncing or catching up
+			return
+		}
+	}
+
+	source := kafkamessage.KafkaDoubleSpendSource_MEMPOOL
+	if validationOptions.CreateConflicting {
+		source = kafkamessage.KafkaDoubleSpendSource_BLOCK
+	}
+
+	txID := tx.TxIDChainHash().String()
+
+	value, err := kafkamessage.Encode(&kafkamessage.KafkaDoubleSpendTopicMessage{
+		TxHash:      txID,
+		Outputs:     outputs,
+		Source:      source,
+		PeerId:      validationOptions.PeerID,
+		BlockHeight: blockHeight,
+	})
+	if err != nil {
+		v.logger.Errorf("[publishDoubleSpend][%s] failed to marshal double spend message: %v", txID, err)
+
+		return
+	}
+
+	v.logger.Infof("[publishDoubleSpend][%s] publishing %s double spend of %d outputs, peer %q", txID, source.String(), len(outputs), validationOptions.PeerID)
+
+	v.doubleSpendKafkaProducerClient.Publish(&kafka.Message{
+		Key:   []byte(txID),
+		Value: value,
+	})
+
+	prometheusValidatorDoubleSpends.WithLabelValues(source.String()).Inc()
+}
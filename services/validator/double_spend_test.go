@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/stores/utxo"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/kafka"
+	kafkamessage "github.com/bsv-blockchain/teranode/util/kafka/kafka_message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoubleSpendOutputs(t *testing.T) {
+	parent := chainhash.Hash{1}
+	spending := chainhash.Hash{2}
+
+	outputs := doubleSpendOutputs([]*utxo.Spend{
+		{TxID: &parent, Vout: 0},
+		{TxID: &parent, Vout: 1, ConflictingTxID: &spending, Err: errors.ErrSpent},
+		{TxID: &parent, Vout: 2, ConflictingTxID: &spending, Err: errors.NewTxConflictingError("conflicting")},
+		{TxID: &parent, Vout: 3, Err: errors.ErrSpent},
+		{TxID: &parent, Vout: 4, ConflictingTxID: &spending, Err: errors.NewTxNotFoundError("not found")},
+		nil,
+	})
+
+	require.Len(t, outputs, 2)
+	assert.Equal(t, parent.String(), outputs[0].TxHash)
+	assert.Equal(t, uint32(1), outputs[0].Vout)
+	assert.Equal(t, spending.String(), outputs[0].SpendingTxHash)
+	assert.Equal(t, uint32(2), outputs[1].Vout)
+
+	assert.Nil(t, doubleSpendOutputs([]*utxo.Spend{{TxID: &parent, Vout: 0}}))
+}
+
+func TestPublishDoubleSpend(t *testing.T) {
+	parent := chainhash.Hash{1}
+	spending := chainhash.Hash{2}
+
+	tx := bt.NewTx()
+
+	spends := []*utxo.Spend{
+		{TxID: &parent, Vout: 1, ConflictingTxID: &spending, Err: errors.ErrSpent},
+	}
+
+	initPrometheusMetrics()
+
+	t.Run("publishes the double spend", func(t *testing.T) {
+		producer := kafka.NewKafkaAsyncProducerMock()
+		v := &Validator{logger: ulogger.TestLogger{}, doubleSpendKafkaProducerClient: producer}
+
+		v.publishDoubleSpend(context.Background(), tx, 100, spends, ProcessOptions(WithCreateConflicting(true), WithPeerID("peer")))
+
+		require.Len(t, producer.PublishChannel(), 1)
+
+		msg := <-producer.PublishChannel()
+		assert.Equal(t, []byte(tx.TxID()), msg.Key)
+
+		var m kafkamessage.KafkaDoubleSpendTopicMessage
+		require.NoError(t, kafkamessage.Decode(msg.Value, &m))
+		assert.Equal(t, tx.TxID(), m.TxHash)
+		assert.Equal(t, kafkamessage.KafkaDoubleSpendSource_BLOCK, m.Source)
+		assert.Equal(t, "peer", m.PeerId)
+		assert.Equal(t, uint32(100), m.BlockHeight)
+		require.Len(t, m.Outputs, 1)
+		assert.Equal(t, spending.String(), m.Outputs[0].SpendingTxHash)
+	})
+
+	t.Run("mempool double spend", func(t *testing.T) {
+		producer := kafka.NewKafkaAsyncProducerMock()
+		v := &Validator{logger: ulogger.TestLogger{}, doubleSpendKafkaProducerClient: producer}
+
+		v.publishDoubleSpend(context.Background(), tx, 100, spends, ProcessOptions())
+
+		msg := <-producer.PublishChannel()
+
+		var m kafkamessage.KafkaDoubleSpendTopicMessage
+		require.NoError(t, kafkamessage.Decode(msg.Value, &m))
+		assert.Equal(t, kafkamessage.KafkaDoubleSpendSource_MEMPOOL, m.Source)
+		assert.Empty(t, m.PeerId)
+	})
+
+	t.Run("no double spends", func(t *testing.T) {
+		producer := kafka.NewKafkaAsyncProducerMock()
+		v := &Validator{logger: ulogger.TestLogger{}, doubleSpendKafkaProducerClient: producer}
+
+		v.publishDoubleSpend(context.Background(), tx, 100, []*utxo.Spend{{TxID: &parent, Vout: 0}}, ProcessOptions())
+		assert.Empty(t, producer.PublishChannel())
+	})
+
+	t.Run("no producer", func(t *testing.T) {
+		v := &Validator{logger: ulogger.TestLogger{}}
+
+		v.publishDoubleSpend(context.Background(), tx, 100, spends, ProcessOptions())
+	})
+}
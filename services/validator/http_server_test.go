@@ -63,6 +63,10 @@ func (m *MockValidator) ValidateWithOptions(ctx context.Context, tx *bt.Tx, bloc
 	return m.Validate(ctx, tx, blockHeight)
 }
 
+func (m *MockValidator) ValidatePackage(ctx context.Context, txs []*bt.Tx, blockHeight uint32, opts ...validator.Option) (*validator.PackageResult, error) {
+	return &validator.PackageResult{Accepted: true}, nil
+}
+
 func (m *MockValidator) GetBlockHeight() uint32 {
 	return 100
 }
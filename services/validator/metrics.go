@@ -108,6 +108,15 @@ var (
 	// This histogram tracks database operations for storing and updating transaction metadata,
 	// including validation status, processing timestamps, and related transaction information. Units: seconds.
 	prometheusValidatorSetTxMeta prometheus.Histogram
+
+	// prometheusValidatePackage measures the time spent validating and admitting transaction packages. Units: seconds.
+	prometheusValidatePackage prometheus.Histogram
+
+	// prometheusValidatePackageResult counts the validated transaction packages by result, accepted or rejected
+	prometheusValidatePackageResult *prometheus.CounterVec
+
+	// prometheusValidatorDoubleSpends counts the double spends published to Kafka by source, mempool or block
+	prometheusValidatorDoubleSpends *prometheus.CounterVec
 )
 
 // Synchronization primitives
@@ -297,4 +306,36 @@ func _initPrometheusMetrics() {
 			Buckets:   util.MetricsBucketsMilliSeconds,
 		},
 	)
+
+	// Transaction package validation histogram
+	prometheusValidatePackage = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "validator",
+			Name:      "package_validate",
+			Help:      "Histogram of transaction package validation",
+			Buckets:   util.MetricsBucketsMilliSeconds,
+		},
+	)
+
+	// Transaction package results counter
+	prometheusValidatePackageResult = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "validator",
+			Name:      "package_result",
+			Help:      "Number of validated transaction packages by result",
+		},
+		[]string{"result"},
+	)
+
+	prometheusValidatorDoubleSpends = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "validator",
+			Name:      "double_spends",
+			Help:      "Number of double spends published to Kafka by source",
+		},
+		[]string{"source"},
+	)
 }
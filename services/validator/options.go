@@ -7,6 +7,8 @@ validation operations.
 */
 package validator
 
+import "github.com/bsv-blockchain/teranode/services/blockassembly/subtreeprocessor"
+
 // Options defines the configuration options for validation operations
 type Options struct {
 	// SkipUtxoCreation determines whether UTXO creation should be skipped
@@ -30,6 +32,18 @@ type Options struct {
 
 	// IgnoreLocked determines whether to ignore transactions marked as locked when spending
 	IgnoreLocked bool
+
+	// TxPriority is the priority lane requested for the transaction in block assembly, operators use it to tag
+	// time-sensitive transactions. The lane is only used when all parents of the transaction are mined.
+	TxPriority subtreeprocessor.TxPriority
+
+	// PeerID is the ID of the peer the transaction was received from, if known. It is reported with the double
+	// spends of the transaction, so the peer can be held accountable.
+	PeerID string
+
+	// skipFeeCheck skips the fee check of a single transaction, the fees of a transaction package are checked
+	// over the whole package
+	skipFeeCheck bool
 }
 
 // Option defines a function type for setting options
@@ -139,6 +153,30 @@ func WithIgnoreLocked(ignoreLocked bool) Option {
 	}
 }
 
+// WithTxPriority creates an option to request a priority lane for the transaction in block assembly
+// Parameters:
+//   - priority: The priority lane of the transaction
+//
+// Returns:
+//   - Option: Function that sets the txPriority option
+func WithTxPriority(priority subtreeprocessor.TxPriority) Option {
+	return func(o *Options) {
+		o.TxPriority = priority
+	}
+}
+
+// WithPeerID creates an option to attribute the transaction to the peer it was received from
+// Parameters:
+//   - peerID: The ID of the peer, empty when unknown
+//
+// Returns:
+//   - Option: Function that sets the peerID option
+func WithPeerID(peerID string) Option {
+	return func(o *Options) {
+		o.PeerID = peerID
+	}
+}
+
 // TxValidatorOptions defines configuration options specific to transaction validation
 type TxValidatorOptions struct {
 	skipPolicyChecks bool
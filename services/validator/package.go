@@ -0,0 +1,597 @@
+// This file implements the validation of transaction packages.
+//
+// A transaction package is a set of dependent transactions, e.g. a parent and a child spending one of its outputs,
+// submitted together because the parent has not been admitted on its own yet. The package is validated as a whole:
+// instead of the fee of each transaction, the fees of all new transactions of the package together must pay the
+// minimum mining fee for their combined size, so a child can pay for its parent.
+//
+// The transactions of a package are admitted all or none. Every transaction is validated before any UTXO is spent,
+// and when spending, storing or sending one of the transactions to block assembly fails, the spends, stored
+// transactions and block assembly additions of the package done so far are reversed.
+package validator
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/go-subtree"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/blockassembly"
+	"github.com/bsv-blockchain/teranode/stores/utxo"
+	"github.com/bsv-blockchain/teranode/stores/utxo/meta"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/tracing"
+)
+
+// PackageTxStatus is the result of a single transaction of a transaction package
+type PackageTxStatus int
+
+const (
+	// PackageTxNotAdmitted is the status of a transaction that was not admitted, because another transaction of the
+	// package or the package as a whole was rejected
+	PackageTxNotAdmitted PackageTxStatus = iota
+
+	// PackageTxAccepted is the status of a transaction that was validated and admitted
+	PackageTxAccepted
+
+	// PackageTxAlreadyKnown is the status of a transaction that was already validated before the package was
+	// submitted, it is not validated again and its fee does not count towards the package fee
+	PackageTxAlreadyKnown
+
+	// PackageTxRejected is the status of a transaction that is invalid, which rejects the whole package
+	PackageTxRejected
+)
+
+// String returns the name of the status
+func (s PackageTxStatus) String() string {
+	switch s {
+	case PackageTxNotAdmitted:
+		return "not_admitted"
+	case PackageTxAccepted:
+		return "accepted"
+	case PackageTxAlreadyKnown:
+		return "already_known"
+	case PackageTxRejected:
+		return "rejected"
+	default:
+		return "unknown(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// PackageTxResult is the result of a single transaction of a transaction package
+type PackageTxResult struct {
+	// TxID is the id of the transaction
+	TxID chainhash.Hash
+
+	// Status is the result of the transaction
+	Status PackageTxStatus
+
+	// Fee is the fee paid by the transaction in satoshis, set once the transaction has been validated
+	Fee uint64
+
+	// Size is the size of the transaction in bytes
+	Size uint64
+
+	// Err is the reason the transaction was rejected
+	Err error
+}
+
+// PackageResult is the result of a transaction package
+type PackageResult struct {
+	// Accepted is set when all transactions of the package were admitted
+	Accepted bool
+
+	// Fee is the combined fee of the new transactions of the package in satoshis
+	Fee uint64
+
+	// Size is the combined size of the new transactions of the package in bytes
+	Size uint64
+
+	// Transactions holds the result of each transaction, in the order the transactions were submitted
+	Transactions []*PackageTxResult
+}
+
+// packageOutpoint is an output spent by a transaction of a package
+type packageOutpoint struct {
+	hash chainhash.Hash
+	vout uint32
+}
+
+// packageTx is a transaction of a package being validated
+type packageTx struct {
+	tx          *bt.Tx
+	result      *PackageTxResult
+	utxoHeights []uint32
+
+	// spendsPackageTx is set when the transaction spends an output of another new transaction of the package
+	spendsPackageTx bool
+
+	spends  []*utxo.Spend
+	created bool
+	txMeta  *meta.Data
+}
+
+// ValidatePackage validates a package of dependent transactions together and admits all of them or none of them.
+//
+// The transactions may be submitted in any order, they are validated parents first. All transactions of the package
+// must be connected by spending each other's outputs, and no two transactions may spend the same output. The combined
+// fee of the new transactions of the package must pay the minimum mining fee for their combined size, so a child can
+// pay the fee of its parent. Transactions already in the UTXO store are reported as already known and not validated
+// again.
+//
+// Parameters:
+//   - ctx: Context for the validation operation, used for tracing and cancellation
+//   - txs: The transactions of the package
+//   - blockHeight: Block height to validate against, 0 for the next block
+//   - opts: Validation options, the UTXOs of a package are always created and never created as conflicting
+//
+// Returns:
+//   - *PackageResult: The result of the package and of each transaction, also when the package was rejected
+//   - error: The reason the package was rejected, nil when all transactions were admitted
+func (v *Validator) ValidatePackage(ctx context.Context, txs []*bt.Tx, blockHeight uint32, opts ...Option) (result *PackageResult, err error) {
+	ctx, span, deferFn := tracing.Tracer("validator").Start(ctx, "ValidatePackage",
+		tracing.WithParentStat(v.stats),
+		tracing.WithHistogram(prometheusValidatePackage),
+		tracing.WithTag("transactions", strconv.Itoa(len(txs))),
+	)
+
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			prometheusValidatePackageResult.WithLabelValues("rejected").Inc()
+		} else {
+			prometheusValidatePackageResult.WithLabelValues("accepted").Inc()
+		}
+
+		deferFn(err)
+	}()
+
+	validationOptions := ProcessOptions(opts...)
+
+	result = &PackageResult{
+		Transactions: make([]*PackageTxResult, len(txs)),
+	}
+
+	pkg := make([]*packageTx, len(txs))
+
+	for i, tx := range txs {
+		tx.SetTxHash(tx.TxIDChainHash())
+
+		pkg[i] = &packageTx{
+			tx: tx,
+			result: &PackageTxResult{
+				TxID:   *tx.TxIDChainHash(),
+				Status: PackageTxNotAdmitted,
+				Size:   uint64(tx.Size()), // nolint:gosec
+			},
+		}
+
+		result.Transactions[i] = pkg[i].result
+	}
+
+	if validationOptions.SkipUtxoCreation {
+		return result, errors.NewInvalidArgumentError("[ValidatePackage] transaction packages must create their utxos")
+	}
+
+	order, err := v.orderPackage(txs)
+	if err != nil {
+		return result, err
+	}
+
+	blockState := v.GetBlockState()
+
+	if blockHeight == 0 {
+		blockHeight = blockState.Height + 1
+	}
+
+	// validate all transactions before spending anything, parents first, so children can be extended with the outputs
+	// of their parents in the package
+	newTxs := make(map[chainhash.Hash]*packageTx, len(txs))
+	ordered := make([]*packageTx, 0, len(txs))
+
+	var rejectErr error
+
+	for _, idx := range order {
+		pt := pkg[idx]
+
+		known, err := v.isKnownPackageTx(ctx, pt)
+		if err != nil {
+			return result, err
+		}
+
+		if known {
+			continue
+		}
+
+		newTxs[pt.result.TxID] = pt
+		ordered = append(ordered, pt)
+
+		if err = v.validatePackageTx(ctx, pt, newTxs, blockHeight, blockState, validationOptions); err != nil {
+			pt.result.Status = PackageTxRejected
+			pt.result.Err = err
+
+			if publishErr := v.publishRejectedTx(ctx, pt.tx, err); publishErr != nil {
+				v.logger.Errorf("[ValidatePackage][%s] failed to publish rejected tx: %v", pt.result.TxID, publishErr)
+			}
+
+			if rejectErr == nil {
+				rejectErr = errors.NewTxInvalidError("[ValidatePackage] package rejected, transaction %s is invalid", pt.result.TxID, err)
+			}
+
+			continue
+		}
+
+		result.Fee += pt.result.Fee
+		result.Size += pt.result.Size
+	}
+
+	if rejectErr != nil {
+		return result, rejectErr
+	}
+
+	if !validationOptions.SkipPolicyChecks && !v.settings.Policy.IsPolicyRuleDisabled(string(PolicyRuleMinFee)) {
+		if minRequiredFee := minRequiredTxFee(v.settings, int(result.Size)); result.Fee < minRequiredFee { // nolint:gosec
+			return result, errors.NewTxInvalidError("[ValidatePackage] package fee is too low: %d < %d required for %d bytes",
+				result.Fee, minRequiredFee, result.Size)
+		}
+	}
+
+	if err = v.admitPackage(ctx, ordered, blockHeight, validationOptions); err != nil {
+		return result, err
+	}
+
+	result.Accepted = true
+
+	return result, nil
+}
+
+// orderPackage checks the structure of the package and returns the indexes of the transactions, parents before
+// their children and otherwise in the order of submission
+func (v *Validator) orderPackage(txs []*bt.Tx) ([]int, error) {
+	if len(txs) == 0 {
+		return nil, errors.NewTxInvalidError("[ValidatePackage] package has no transactions")
+	}
+
+	if maxTransactions := v.settings.Validator.MaxPackageTransactions; maxTransactions > 0 && len(txs) > maxTransactions {
+		return nil, errors.NewTxInvalidError("[ValidatePackage] package has %d transactions, the maximum is %d", len(txs), maxTransactions)
+	}
+
+	indexes := make(map[chainhash.Hash]int, len(txs))
+	packageSize := 0
+
+	for i, tx := range txs {
+		if tx.IsCoinbase() {
+			return nil, errors.NewTxInvalidError("[ValidatePackage][%s] coinbase transactions are not supported", tx.TxIDChainHash())
+		}
+
+		if _, ok := indexes[*tx.TxIDChainHash()]; ok {
+			return nil, errors.NewTxInvalidError("[ValidatePackage][%s] transaction is included twice", tx.TxIDChainHash())
+		}
+
+		indexes[*tx.TxIDChainHash()] = i
+		packageSize += tx.Size()
+	}
+
+	if maxSize := v.settings.Validator.MaxPackageSize; maxSize > 0 && packageSize > maxSize {
+		return nil, errors.NewTxInvalidError("[ValidatePackage] package size %d bytes exceeds the maximum of %d bytes", packageSize, maxSize)
+	}
+
+	var (
+		spentBy  = make(map[packageOutpoint]int)
+		children = make([][]int, len(txs))
+		parents  = make([]int, len(txs))
+		group    = make([]int, len(txs))
+	)
+
+	for i := range group {
+		group[i] = i
+	}
+
+	// root returns the connected group of a transaction
+	root := func(i int) int {
+		for group[i] != i {
+			group[i] = group[group[i]]
+			i = group[i]
+		}
+
+		return i
+	}
+
+	for i, tx := range txs {
+		seenParents := make(map[int]struct{})
+
+		for _, input := range tx.Inputs {
+			key := packageOutpoint{hash: *input.PreviousTxIDChainHash(), vout: input.PreviousTxOutIndex}
+
+			if other, ok := spentBy[key]; ok {
+				return nil, errors.NewTxInvalidError("[ValidatePackage] transactions %s and %s spend the same output %s:%d",
+					txs[other].TxIDChainHash(), tx.TxIDChainHash(), input.PreviousTxIDChainHash(), input.PreviousTxOutIndex)
+			}
+
+			spentBy[key] = i
+
+			parent, ok := indexes[*input.PreviousTxIDChainHash()]
+			if !ok {
+				continue
+			}
+
+			if _, ok = seenParents[parent]; ok {
+				continue
+			}
+
+			seenParents[parent] = struct{}{}
+			children[parent] = append(children[parent], i)
+			parents[i]++
+
+			group[root(i)] = root(parent)
+		}
+	}
+
+	for i := range txs {
+		if root(i) != root(0) {
+			return nil, errors.NewTxInvalidError("[ValidatePackage][%s] transaction does not spend or fund any other transaction of the package",
+				txs[i].TxIDChainHash())
+		}
+	}
+
+	order := make([]int, 0, len(txs))
+	done := make([]bool, len(txs))
+
+	// repeatedly take the first transaction in submission order of which all parents have been taken, the packages
+	// are small enough for this to be cheaper than keeping a sorted queue
+	for len(order) < len(txs) {
+		next := -1
+
+		for i := range txs {
+			if !done[i] && parents[i] == 0 {
+				next = i
+				break
+			}
+		}
+
+		if next == -1 {
+			return nil, errors.NewTxInvalidError("[ValidatePackage] package transactions spend each other in a cycle")
+		}
+
+		done[next] = true
+		order = append(order, next)
+
+		for _, child := range children[next] {
+			parents[child]--
+		}
+	}
+
+	return order, nil
+}
+
+// isKnownPackageTx checks whether the transaction of the package is already in the UTXO store
+func (v *Validator) isKnownPackageTx(ctx context.Context, pt *packageTx) (bool, error) {
+	txMeta, err := v.utxoStore.GetMeta(ctx, &pt.result.TxID)
+	if err != nil {
+		if errors.Is(err, errors.ErrTxNotFound) {
+			return false, nil
+		}
+
+		return false, errors.NewProcessingError("[ValidatePackage][%s] error checking whether transaction exists", pt.result.TxID, err)
+	}
+
+	pt.result.Status = PackageTxAlreadyKnown
+	pt.result.Fee = txMeta.Fee
+
+	return true, nil
+}
+
+// validatePackageTx validates a new transaction of the package, without checking its fee or spending its inputs. The
+// inputs spending outputs of earlier transactions of the package are extended with the outputs of those transactions.
+func (v *Validator) validatePackageTx(ctx context.Context, pt *packageTx, newTxs map[chainhash.Hash]*packageTx, blockHeight uint32,
+	blockState utxo.BlockState, validationOptions *Options) error {
+	tx := pt.tx
+	txID := pt.result.TxID.String()
+
+	// We do not check IsFinal for transactions before BIP113 change (block height 419328)
+	if blockHeight > v.settings.ChainCfgParams.CSVHeight {
+		if blockState.MedianTime == 0 {
+			return errors.NewProcessingError("utxo store not ready, block height: %d, median block time: %d", blockHeight, blockState.MedianTime)
+		}
+
+		if err := util.IsTransactionFinal(tx, blockHeight, blockState.MedianTime); err != nil {
+			return errors.NewUtxoNonFinalError("[ValidatePackage][%s] transaction is not final", txID, err)
+		}
+	}
+
+	extend := !tx.IsExtended()
+	externalParents := make(map[chainhash.Hash][]int)
+	pt.utxoHeights = make([]uint32, len(tx.Inputs))
+
+	for idx, input := range tx.Inputs {
+		parentTxHash := *input.PreviousTxIDChainHash()
+
+		parent, ok := newTxs[parentTxHash]
+		if !ok {
+			externalParents[parentTxHash] = append(externalParents[parentTxHash], idx)
+			continue
+		}
+
+		if int(input.PreviousTxOutIndex) >= len(parent.tx.Outputs) {
+			return errors.NewTxInvalidError("[ValidatePackage][%s] input %d spends output %d of %s, which does not exist",
+				txID, idx, input.PreviousTxOutIndex, parentTxHash)
+		}
+
+		// always use the outputs of the parent in the package, they are not in the utxo store yet
+		output := parent.tx.Outputs[input.PreviousTxOutIndex]
+		input.PreviousTxSatoshis = output.Satoshis
+		input.PreviousTxScript = output.LockingScript
+
+		// the parent is unmined, like in getUtxoBlockHeightAndExtendForParentTx
+		pt.utxoHeights[idx] = blockState.Height
+		pt.spendsPackageTx = true
+	}
+
+	for parentTxHash, idxs := range externalParents {
+		if err := v.getUtxoBlockHeightAndExtendForParentTx(ctx, parentTxHash, idxs, pt.utxoHeights, tx, extend); err != nil {
+			if errors.Is(err, errors.ErrTxNotFound) {
+				return errors.NewTxMissingParentError("[ValidatePackage][%s] error getting parent transaction %s", txID, parentTxHash, err)
+			}
+
+			return errors.NewProcessingError("[ValidatePackage][%s] error getting parent transaction %s", txID, parentTxHash, err)
+		}
+
+		if pt.spendsPackageTx && !validationOptions.IgnoreLocked {
+			// the inputs are spent ignoring the lock of the parents in the package, so the lock of the other parents
+			// is checked here
+			parentMeta, err := v.utxoStore.GetMeta(ctx, &parentTxHash)
+			if err != nil {
+				return errors.NewProcessingError("[ValidatePackage][%s] error getting parent transaction %s", txID, parentTxHash, err)
+			}
+
+			if parentMeta.Locked {
+				return errors.NewTxLockedError("[ValidatePackage][%s] parent transaction %s is not spendable", txID, parentTxHash)
+			}
+		}
+	}
+
+	tx.SetExtended(true)
+
+	txOptions := *validationOptions
+	txOptions.skipFeeCheck = true
+
+	if err := v.validateTransaction(ctx, tx, blockHeight, pt.utxoHeights, &txOptions); err != nil {
+		return errors.NewProcessingError("[ValidatePackage][%s] error validating transaction", txID, err)
+	}
+
+	inputSats := tx.TotalInputSatoshis()
+	outputSats := tx.TotalOutputSatoshis()
+
+	if inputSats < outputSats {
+		return errors.NewTxInvalidError("[ValidatePackage][%s] transaction input satoshis is less than output satoshis: %d < %d",
+			txID, inputSats, outputSats)
+	}
+
+	if err := v.validateTransactionScripts(ctx, tx, blockHeight, pt.utxoHeights, validationOptions); err != nil {
+		return errors.NewProcessingError("[ValidatePackage][%s] error validating transaction scripts", txID, err)
+	}
+
+	pt.result.Fee = inputSats - outputSats
+
+	return nil
+}
+
+// admitPackage spends the inputs of the validated transactions, stores them and sends them to block assembly.
+// Everything done so far is reversed when any of the steps fails for any of the transactions.
+func (v *Validator) admitPackage(ctx context.Context, ordered []*packageTx, blockHeight uint32, validationOptions *Options) (err error) {
+	// decouple the tracing context to not cancel the context while the package is being admitted or reversed
+	decoupledCtx, _, deferFn := tracing.DecoupleTracingSpan(ctx, "validator", "admitPackage")
+	defer deferFn()
+
+	addToBlockAssembly := !v.settings.BlockAssembly.Disabled && validationOptions.AddTXToBlockAssembly && v.blockAssembler != nil
+
+	for i, pt := range ordered {
+		// the parents in the package are locked until the package has been added to block assembly
+		ignoreLocked := validationOptions.IgnoreLocked || pt.spendsPackageTx
+
+		if pt.spends, err = v.spendUtxos(decoupledCtx, pt.tx, blockHeight, ignoreLocked); err != nil {
+			pt.spends = nil
+
+			return v.rejectPackage(decoupledCtx, ordered[:i], pt, errors.NewProcessingError("[ValidatePackage][%s] error spending utxos", pt.result.TxID, err))
+		}
+
+		if pt.txMeta, err = v.CreateInUtxoStore(decoupledCtx, pt.tx, blockHeight, false, addToBlockAssembly); err != nil {
+			return v.rejectPackage(decoupledCtx, ordered[:i+1], pt, errors.NewProcessingError("[ValidatePackage][%s] error registering tx in utxo store", pt.result.TxID, err))
+		}
+
+		pt.created = true
+	}
+
+	if addToBlockAssembly {
+		for i, pt := range ordered {
+			txInpoints := pt.txMeta.TxInpoints
+
+			if txInpoints.ParentTxHashes == nil {
+				if txInpoints, err = subtree.NewTxInpointsFromTx(pt.tx); err != nil {
+					v.removePackageFromBlockAssembly(decoupledCtx, ordered[:i])
+
+					return v.rejectPackage(decoupledCtx, ordered, pt, errors.NewProcessingError("[ValidatePackage][%s] error getting tx inpoints", pt.result.TxID, err))
+				}
+			}
+
+			if err = v.sendToBlockAssembler(decoupledCtx, &blockassembly.Data{
+				TxIDChainHash: pt.result.TxID,
+				Fee:           pt.result.Fee,
+				Size:          pt.result.Size,
+				TxInpoints:    txInpoints,
+			}, pt.spends); err != nil {
+				v.removePackageFromBlockAssembly(decoupledCtx, ordered[:i])
+
+				return v.rejectPackage(decoupledCtx, ordered, pt, errors.NewProcessingError("[ValidatePackage][%s] error sending tx to block assembler", pt.result.TxID, err))
+			}
+		}
+	}
+
+	lockedTxs := make([]chainhash.Hash, 0, len(ordered))
+
+	for _, pt := range ordered {
+		pt.result.Status = PackageTxAccepted
+
+		// send the txMetaData over to the subtree validation kafka topic
+		if v.txmetaKafkaProducerClient != nil {
+			if err = v.sendTxMetaToKafka(pt.txMeta, &pt.result.TxID); err != nil {
+				v.logger.Errorf("[ValidatePackage][%s] error sending tx meta to kafka: %v", pt.result.TxID, err)
+			}
+		}
+
+		if pt.txMeta.Locked {
+			lockedTxs = append(lockedTxs, pt.result.TxID)
+			pt.txMeta.Locked = false
+		}
+	}
+
+	if len(lockedTxs) > 0 {
+		// this is not a fatal error, since the transactions will be marked as spendable on the next block they are mined into
+		if err = v.utxoStore.SetLocked(decoupledCtx, lockedTxs, false); err != nil {
+			v.logger.Errorf("[ValidatePackage] error marking %d package transactions as spendable: %v", len(lockedTxs), err)
+		}
+	}
+
+	return nil
+}
+
+// rejectPackage reverses the spends and stored transactions of the package, children first, and marks the failed
+// transaction as rejected
+func (v *Validator) rejectPackage(ctx context.Context, done []*packageTx, failed *packageTx, err error) error {
+	for i := len(done) - 1; i >= 0; i-- {
+		pt := done[i]
+
+		if len(pt.spends) > 0 {
+			if reverseErr := v.reverseSpends(ctx, pt.spends); reverseErr != nil {
+				v.logger.Errorf("[ValidatePackage][%s] error reversing utxo spends: %v", pt.result.TxID, reverseErr)
+			}
+
+			pt.spends = nil
+		}
+
+		if pt.created {
+			if deleteErr := v.utxoStore.Delete(ctx, &pt.result.TxID); deleteErr != nil {
+				v.logger.Errorf("[ValidatePackage][%s] error deleting transaction from utxo store: %v", pt.result.TxID, deleteErr)
+			}
+
+			pt.created = false
+		}
+	}
+
+	failed.result.Status = PackageTxRejected
+	failed.result.Err = err
+
+	return errors.NewProcessingError("[ValidatePackage] package rejected, transaction %s could not be admitted", failed.result.TxID, err)
+}
+
+// removePackageFromBlockAssembly removes the transactions of the package already sent to block assembly, children
+// first
+func (v *Validator) removePackageFromBlockAssembly(ctx context.Context, added []*packageTx) {
+	for i := len(added) - 1; i >= 0; i-- {
+		if err := v.blockAssembler.RemoveTx(ctx, &added[i].result.TxID); err != nil {
+			v.logger.Errorf("[ValidatePackage][%s] error removing transaction from block assembly: %v", added[i].result.TxID, err)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package validator
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	bec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/stores/utxo"
+	"github.com/bsv-blockchain/teranode/stores/utxo/sql"
+	"github.com/bsv-blockchain/teranode/test/utils/transactions"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/bsv-blockchain/teranode/util/tracing"
+	"github.com/ordishs/gocore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packageTest holds a validator with a mined coinbase transaction of which the first output can be spent by key
+type packageTest struct {
+	v              *Validator
+	utxoStore      utxo.Store
+	blockAssembler *MockBlockAssemblyStore
+	key            *bec.PrivateKey
+	coinbase       *bt.Tx
+}
+
+func newPackageTest(t *testing.T) *packageTest {
+	tracing.SetupMockTracer()
+	initPrometheusMetrics()
+
+	ctx := context.Background()
+
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.Policy.MinMiningTxFee = 0.00001 // 1 satoshi per byte
+
+	utxoStoreURL, err := url.Parse("sqlitememory:///" + t.Name())
+	require.NoError(t, err)
+
+	utxoStore, err := sql.New(ctx, ulogger.TestLogger{}, tSettings, utxoStoreURL)
+	require.NoError(t, err)
+
+	require.NoError(t, utxoStore.SetBlockHeight(1000))
+	require.NoError(t, utxoStore.SetMedianBlockTime(uint32(time.Now().Unix()))) // nolint:gosec
+
+	key, err := bec.NewPrivateKey()
+	require.NoError(t, err)
+
+	coinbase := transactions.Create(t,
+		transactions.WithCoinbaseData(1, "/Test miner/"),
+		transactions.WithP2PKHOutputs(1, 100_000, key.PubKey()),
+	)
+
+	_, err = utxoStore.Create(ctx, coinbase, 1, utxo.WithMinedBlockInfo(utxo.MinedBlockInfo{BlockID: 1, BlockHeight: 1}))
+	require.NoError(t, err)
+
+	blockAssembler := &MockBlockAssemblyStore{}
+
+	return &packageTest{
+		v: &Validator{
+			logger:         ulogger.TestLogger{},
+			settings:       tSettings,
+			txValidator:    NewTxValidator(ulogger.TestLogger{}, tSettings),
+			utxoStore:      utxoStore,
+			blockAssembler: blockAssembler,
+			stats:          gocore.NewStat("validator"),
+		},
+		utxoStore:      utxoStore,
+		blockAssembler: blockAssembler,
+		key:            key,
+		coinbase:       coinbase,
+	}
+}
+
+// spend returns a transaction spending the first output of parent, paying the given fee
+func (p *packageTest) spend(t *testing.T, parent *bt.Tx, fee uint64) *bt.Tx {
+	return transactions.Create(t,
+		transactions.WithPrivateKey(p.key),
+		transactions.WithInput(parent, 0),
+		transactions.WithP2PKHOutputs(1, parent.Outputs[0].Satoshis-fee),
+	)
+}
+
+func (p *packageTest) exists(t *testing.T, tx *bt.Tx) bool {
+	_, err := p.utxoStore.GetMeta(context.Background(), tx.TxIDChainHash())
+	if errors.Is(err, errors.ErrTxNotFound) {
+		return false
+	}
+
+	require.NoError(t, err)
+
+	return true
+}
+
+func TestValidatePackage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("child pays for parent", func(t *testing.T) {
+		p := newPackageTest(t)
+
+		parent := p.spend(t, p.coinbase, 0)
+		child := p.spend(t, parent, 1000)
+
+		_, err := p.v.Validate(ctx, parent, 0)
+		require.Error(t, err, "the parent does not pay a fee on its own")
+		assert.False(t, p.exists(t, parent))
+
+		// children may be submitted before their parents
+		result, err := p.v.ValidatePackage(ctx, []*bt.Tx{child, parent}, 0)
+		require.NoError(t, err)
+
+		assert.True(t, result.Accepted)
+		assert.Equal(t, uint64(1000), result.Fee)
+		assert.Equal(t, uint64(parent.Size()+child.Size()), result.Size)
+
+		require.Len(t, result.Transactions, 2)
+		assert.Equal(t, *child.TxIDChainHash(), result.Transactions[0].TxID)
+		assert.Equal(t, PackageTxAccepted, result.Transactions[0].Status)
+		assert.Equal(t, uint64(1000), result.Transactions[0].Fee)
+		assert.Equal(t, *parent.TxIDChainHash(), result.Transactions[1].TxID)
+		assert.Equal(t, PackageTxAccepted, result.Transactions[1].Status)
+		assert.Equal(t, uint64(0), result.Transactions[1].Fee)
+
+		// the parent is sent to block assembly before the child
+		require.Len(t, p.blockAssembler.storedTxs, 2)
+		assert.Equal(t, *parent.TxIDChainHash(), *p.blockAssembler.storedTxs[0].txHash)
+		assert.Equal(t, *child.TxIDChainHash(), *p.blockAssembler.storedTxs[1].txHash)
+
+		for _, tx := range []*bt.Tx{parent, child} {
+			txMeta, err := p.utxoStore.GetMeta(ctx, tx.TxIDChainHash())
+			require.NoError(t, err)
+			assert.False(t, txMeta.Locked, "the package transactions are spendable once admitted")
+		}
+	})
+
+	t.Run("package fee too low", func(t *testing.T) {
+		p := newPackageTest(t)
+
+		parent := p.spend(t, p.coinbase, 0)
+		child := p.spend(t, parent, 100)
+
+		result, err := p.v.ValidatePackage(ctx, []*bt.Tx{parent, child}, 0)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrTxInvalid))
+
+		assert.False(t, result.Accepted)
+		assert.Equal(t, PackageTxNotAdmitted, result.Transactions[0].Status)
+		assert.Equal(t, PackageTxNotAdmitted, result.Transactions[1].Status)
+		assert.False(t, p.exists(t, parent))
+		assert.False(t, p.exists(t, child))
+		assert.Empty(t, p.blockAssembler.storedTxs)
+	})
+
+	t.Run("invalid transaction rejects the package", func(t *testing.T) {
+		p := newPackageTest(t)
+
+		parent := p.spend(t, p.coinbase, 0)
+		child := p.spend(t, parent, 1000)
+
+		// invalidate the signature of the child
+		child.Outputs[0].Satoshis--
+
+		result, err := p.v.ValidatePackage(ctx, []*bt.Tx{parent, child}, 0)
+		require.Error(t, err)
+
+		assert.Equal(t, PackageTxNotAdmitted, result.Transactions[0].Status)
+		assert.Equal(t, PackageTxRejected, result.Transactions[1].Status)
+		assert.Error(t, result.Transactions[1].Err)
+		assert.False(t, p.exists(t, parent))
+		assert.Empty(t, p.blockAssembler.storedTxs)
+	})
+
+	t.Run("block assembly failure reverses the package", func(t *testing.T) {
+		p := newPackageTest(t)
+
+		parent := p.spend(t, p.coinbase, 0)
+		child := p.spend(t, parent, 1000)
+
+		p.blockAssembler.returnError = errors.NewServiceError("block assembly unavailable")
+
+		result, err := p.v.ValidatePackage(ctx, []*bt.Tx{parent, child}, 0)
+		require.Error(t, err)
+
+		assert.Equal(t, PackageTxRejected, result.Transactions[0].Status)
+		assert.Equal(t, PackageTxNotAdmitted, result.Transactions[1].Status)
+		assert.False(t, p.exists(t, parent))
+		assert.False(t, p.exists(t, child))
+
+		// the spends were reversed, so the package can be submitted again
+		p.blockAssembler.returnError = nil
+
+		result, err = p.v.ValidatePackage(ctx, []*bt.Tx{parent, child}, 0)
+		require.NoError(t, err)
+		assert.True(t, result.Accepted)
+	})
+
+	t.Run("already known parent", func(t *testing.T) {
+		p := newPackageTest(t)
+
+		parent := p.spend(t, p.coinbase, 500)
+		child := p.spend(t, parent, 500)
+
+		_, err := p.v.Validate(ctx, parent, 0)
+		require.NoError(t, err)
+
+		result, err := p.v.ValidatePackage(ctx, []*bt.Tx{parent, child}, 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, PackageTxAlreadyKnown, result.Transactions[0].Status)
+		assert.Equal(t, PackageTxAccepted, result.Transactions[1].Status)
+		assert.Equal(t, uint64(500), result.Fee, "the fee of the known parent does not count")
+		assert.True(t, p.exists(t, child))
+	})
+
+	t.Run("double spend of an output in the store", func(t *testing.T) {
+		p := newPackageTest(t)
+
+		_, err := p.v.Validate(ctx, p.spend(t, p.coinbase, 1000), 0)
+		require.NoError(t, err)
+
+		parent := p.spend(t, p.coinbase, 0)
+		child := p.spend(t, parent, 2000)
+
+		result, err := p.v.ValidatePackage(ctx, []*bt.Tx{parent, child}, 0)
+		require.Error(t, err)
+
+		assert.Equal(t, PackageTxRejected, result.Transactions[0].Status)
+		assert.False(t, p.exists(t, parent))
+		assert.False(t, p.exists(t, child))
+	})
+
+	t.Run("transactions must create their utxos", func(t *testing.T) {
+		p := newPackageTest(t)
+
+		parent := p.spend(t, p.coinbase, 1000)
+
+		_, err := p.v.ValidatePackage(ctx, []*bt.Tx{parent}, 0, WithSkipUtxoCreation(true))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidArgument))
+	})
+}
+
+func TestValidator_OrderPackage(t *testing.T) {
+	p := newPackageTest(t)
+
+	parent := p.spend(t, p.coinbase, 0)
+	child := p.spend(t, parent, 0)
+	grandchild := p.spend(t, child, 0)
+
+	t.Run("parents first", func(t *testing.T) {
+		order, err := p.v.orderPackage([]*bt.Tx{grandchild, child, parent})
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 1, 0}, order)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := p.v.orderPackage(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("duplicate transaction", func(t *testing.T) {
+		_, err := p.v.orderPackage([]*bt.Tx{parent, child, parent})
+		require.Error(t, err)
+	})
+
+	t.Run("same output spent twice", func(t *testing.T) {
+		_, err := p.v.orderPackage([]*bt.Tx{parent, p.spend(t, p.coinbase, 10)})
+		require.Error(t, err)
+	})
+
+	t.Run("unrelated transactions", func(t *testing.T) {
+		_, err := p.v.orderPackage([]*bt.Tx{parent, grandchild})
+		require.Error(t, err, "the grandchild does not spend the parent without the child")
+	})
+
+	t.Run("too many transactions", func(t *testing.T) {
+		p.v.settings.Validator.MaxPackageTransactions = 2
+		defer func() { p.v.settings.Validator.MaxPackageTransactions = 25 }()
+
+		_, err := p.v.orderPackage([]*bt.Tx{parent, child, grandchild})
+		require.Error(t, err)
+	})
+
+	t.Run("coinbase", func(t *testing.T) {
+		_, err := p.v.orderPackage([]*bt.Tx{p.coinbase, parent})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,398 @@
+// Package validator implements Bitcoin SV transaction validation functionality.
+//
+// This file contains the policy engine, which evaluates the transaction acceptance policy of the node. Policy rules
+// are not consensus rules: they are local to the node, only apply to the transactions received for the mempool and
+// are skipped for transactions validated with the SkipPolicyChecks option, e.g. the transactions of a block.
+//
+// The policy is loaded from the policy settings (maxtxsizepolicy, dustlimit, minminingtxfee, ...) and each rule can be
+// disabled by adding its name to the disabledpolicyrules setting. The script policy limits are enforced by the script
+// interpreter and are not rules of the policy engine.
+package validator
+
+import (
+	"slices"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+)
+
+// PolicyRule is the name of a rule of the transaction acceptance policy
+type PolicyRule string
+
+const (
+	// PolicyRuleMaxTxSize rejects transactions larger than maxtxsizepolicy
+	PolicyRuleMaxTxSize PolicyRule = "maxtxsize"
+
+	// PolicyRuleP2SHOutputs rejects the non-standard P2SH outputs after the genesis activation
+	PolicyRuleP2SHOutputs PolicyRule = "p2shoutputs"
+
+	// PolicyRuleDust rejects spendable outputs below the dust limit after the genesis activation, on networks
+	// requiring standard transactions
+	PolicyRuleDust PolicyRule = "dust"
+
+	// PolicyRuleMinFee rejects transactions paying less than the minminingtxfee fee rate, consolidation transactions
+	// are exempt
+	PolicyRuleMinFee PolicyRule = "minfee"
+)
+
+// PolicyRules are the rules of the policy engine, in the order they are evaluated
+var PolicyRules = []PolicyRule{PolicyRuleMaxTxSize, PolicyRuleP2SHOutputs, PolicyRuleDust, PolicyRuleMinFee}
+
+// PolicyRuleResult is the result of evaluating a policy rule against a transaction
+type PolicyRuleResult struct {
+	// Rule is the evaluated rule
+	Rule PolicyRule
+
+	// Enabled is false when the rule is disabled, the transaction is evaluated but the rule is not enforced
+	Enabled bool
+
+	// Err is the reason the transaction fails the rule, nil when it passes
+	Err error
+}
+
+// PolicyEngine evaluates the transaction acceptance policy loaded from the policy settings
+type PolicyEngine struct {
+	settings *settings.Settings
+}
+
+// NewPolicyEngine creates the policy engine of the policy settings, warning about disabled rules that are not known
+func NewPolicyEngine(logger ulogger.Logger, tSettings *settings.Settings) *PolicyEngine {
+	for _, rule := range tSettings.Policy.GetDisabledPolicyRules() {
+		if !slices.Contains(PolicyRules, PolicyRule(rule)) {
+			logger.Warnf("[PolicyEngine] unknown policy rule %q in disabledpolicyrules, the policy rules are %v", rule, PolicyRules)
+		}
+	}
+
+	return &PolicyEngine{
+		settings: tSettings,
+	}
+}
+
+// Enabled returns true when the rule is enforced, false when it is disabled in the disabledpolicyrules setting
+func (p *PolicyEngine) Enabled(rule PolicyRule) bool {
+	return !p.settings.Policy.IsPolicyRuleDisabled(string(rule))
+}
+
+// Check returns the reason the transaction fails the rule, nil when the transaction passes or the rule is disabled.
+// The fee rule needs an extended transaction.
+func (p *PolicyEngine) Check(rule PolicyRule, tx *bt.Tx, blockHeight uint32, utxoHeights []uint32) error {
+	if !p.Enabled(rule) {
+		return nil
+	}
+
+	return p.evaluate(rule, tx, blockHeight, utxoHeights)
+}
+
+// Evaluate evaluates all rules against the transaction, including the disabled rules, and returns the result of
+// each rule in the order of PolicyRules. The fee rule needs an extended transaction.
+func (p *PolicyEngine) Evaluate(tx *bt.Tx, blockHeight uint32, utxoHeights []uint32) []PolicyRuleResult {
+	results := make([]PolicyRuleResult, 0, len(PolicyRules))
+
+	for _, rule := range PolicyRules {
+		results = append(results, PolicyRuleResult{
+			Rule:    rule,
+			Enabled: p.Enabled(rule),
+			Err:     p.evaluate(rule, tx, blockHeight, utxoHeights),
+		})
+	}
+
+	return results
+}
+
+// evaluate evaluates a single rule against the transaction, whether the rule is enabled or not
+func (p *PolicyEngine) evaluate(rule PolicyRule, tx *bt.Tx, blockHeight uint32, utxoHeights []uint32) error {
+	switch rule {
+	case PolicyRuleMaxTxSize:
+		return p.checkTxSize(tx.Size())
+	case PolicyRuleP2SHOutputs:
+		if !p.isGenesisActivated(blockHeight) {
+			return nil
+		}
+
+		for index, output := range tx.Outputs {
+			if err := p.checkP2SHOutput(index, output); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case PolicyRuleDust:
+		if !p.isGenesisActivated(blockHeight) {
+			return nil
+		}
+
+		for index, output := range tx.Outputs {
+			if err := p.checkDustOutput(index, output); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case PolicyRuleMinFee:
+		return p.checkFees(tx, blockHeight, utxoHeights)
+	default:
+		return errors.NewInvalidArgumentError("unknown policy rule %s", rule)
+	}
+}
+
+// isGenesisActivated returns true when the output policy rules apply at the block height.
+// Note: We use > instead of >= to exclude the Genesis activation block itself
+// because transactions in block 620538 were created before Genesis rules existed
+func (p *PolicyEngine) isGenesisActivated(blockHeight uint32) bool {
+	return blockHeight > p.settings.ChainCfgParams.GenesisActivationHeight
+}
+
+// checkTxSize validates that the transaction size complies with the max tx size policy.
+func (p *PolicyEngine) checkTxSize(txSize int) error {
+	maxTxSizePolicy := p.settings.Policy.GetMaxTxSizePolicy()
+	if maxTxSizePolicy == 0 {
+		// no policy found for tx size, use max block size
+		maxTxSizePolicy = MaxBlockSize
+	}
+
+	if txSize > maxTxSizePolicy {
+		return errors.NewTxInvalidError("transaction size in bytes is greater than max tx size policy %d", maxTxSizePolicy)
+	}
+
+	return nil
+}
+
+// checkP2SHOutput validates that the output is not a P2SH output, which is non-standard after the genesis activation
+func (p *PolicyEngine) checkP2SHOutput(index int, output *bt.Output) error {
+	if output.LockingScript.IsP2SH() {
+		// See https://github.com/bitcoin-sv/teranode/issues/4333
+		return errors.NewTxInvalidError("transaction output %d is p2sh after genesis activation", index)
+	}
+
+	return nil
+}
+
+// checkDustOutput validates that a spendable output is not below the dust limit, when the network requires standard
+// transactions. Unspendable 'OP_FALSE OP_RETURN' outputs are allowed 0 satoshis.
+func (p *PolicyEngine) checkDustOutput(index int, output *bt.Output) error {
+	if !p.settings.ChainCfgParams.RequireStandard || isUnspendableOutput(output.LockingScript) {
+		return nil
+	}
+
+	dustLimit := p.dustLimit()
+
+	if output.Satoshis < dustLimit {
+		if output.Satoshis == 0 {
+			return errors.NewTxInvalidError("zero-satoshi outputs require 'OP_FALSE OP_RETURN' prefix")
+		}
+
+		return errors.NewTxInvalidError("transaction output %d of %d satoshis is below the dust limit of %d satoshis", index, output.Satoshis, dustLimit)
+	}
+
+	return nil
+}
+
+// dustLimit returns the dust limit of the policy, DustLimit when the policy does not set it
+func (p *PolicyEngine) dustLimit() uint64 {
+	if dustLimit := p.settings.Policy.GetDustLimit(); dustLimit > 0 {
+		return dustLimit
+	}
+
+	return DustLimit
+}
+
+// checkFees validates transaction fees according to policy requirements.
+func (p *PolicyEngine) checkFees(tx *bt.Tx, blockHeight uint32, utxoHeights []uint32) error {
+	// Check for consolidation transaction with proper UTXO height verification
+	isConsolidation := p.isConsolidationTx(tx, utxoHeights, blockHeight)
+	if isConsolidation {
+		return nil // We return nil here to say there was no issue with the fees
+	}
+
+	inputSats := tx.TotalInputSatoshis()
+	outputSats := tx.TotalOutputSatoshis()
+
+	if inputSats < outputSats {
+		return errors.NewTxInvalidError("transaction input satoshis is less than output satoshis: %d < %d", inputSats, outputSats)
+	}
+
+	actualFeePaid := inputSats - outputSats
+
+	// Calculate minimum relay fee based on transaction size
+	minRequiredFee := minRequiredTxFee(p.settings, tx.Size())
+
+	if actualFeePaid < minRequiredFee {
+		return errors.NewTxInvalidError("transaction fee is too low: %d < %d required", actualFeePaid, minRequiredFee)
+	}
+
+	return nil
+}
+
+// minRequiredTxFee returns the minimum fee in satoshis for the given number of bytes at the minimum mining fee rate,
+// or 0 when no fee policy is set
+func minRequiredTxFee(tSettings *settings.Settings, size int) uint64 {
+	minFeeRateBSVPerKB := tSettings.Policy.GetMinMiningTxFee() // BSV per kilobyte
+
+	if minFeeRateBSVPerKB == 0 {
+		return 0 // no fee policy found, skip fee check
+	}
+
+	// Convert BSV/kB to satoshis/byte
+	// 1 BSV = 1e8 satoshis
+	// 1 kB = 1000 bytes
+	// So BSV/kB * 1e8 / 1000 = satoshis/byte
+	satoshisPerByte := minFeeRateBSVPerKB * 1e8 / 1000
+
+	minRequiredFee := uint64(satoshisPerByte * float64(size))
+
+	// Ensure minimum 1 satoshi for non-zero sized transactions (matching Bitcoin SV)
+	if minRequiredFee == 0 && size > 0 {
+		minRequiredFee = 1
+	}
+
+	return minRequiredFee
+}
+
+// isDustReturnTx checks if a transaction is a dust return transaction.
+// A dust return transaction has a single output with 0 satoshis and an unspendable script
+// (OP_FALSE OP_RETURN pattern). These transactions are used to clean up dust UTXOs.
+//
+// Parameters:
+//   - tx: The transaction to check
+//
+// Returns:
+//   - bool: true if the transaction is a dust return transaction, false otherwise
+func (p *PolicyEngine) isDustReturnTx(tx *bt.Tx) bool {
+	if tx == nil {
+		return false
+	}
+
+	// Must have exactly one output
+	if len(tx.Outputs) != 1 {
+		return false
+	}
+
+	output := tx.Outputs[0]
+
+	// Output must have 0 satoshis
+	if output.Satoshis != 0 {
+		return false
+	}
+
+	// Output script must be unspendable (OP_FALSE OP_RETURN)
+	return isUnspendableOutput(output.LockingScript)
+}
+
+// isConsolidationTx checks if a transaction qualifies as a consolidation transaction
+// following Bitcoin SV rules.
+//
+// Parameters:
+//   - tx: The transaction to check
+//   - utxoHeights: Block heights of the UTXOs being spent (nil for fee checks only)
+//   - currentHeight: Current block height (ignored if utxoHeights is nil)
+//
+// Returns:
+//   - bool: true if the transaction qualifies as a consolidation transaction
+func (p *PolicyEngine) isConsolidationTx(tx *bt.Tx, utxoHeights []uint32, currentHeight uint32) bool {
+	if tx == nil {
+		return false
+	}
+
+	// Coinbase transactions cannot be consolidation transactions
+	if tx.IsCoinbase() {
+		return false
+	}
+
+	// Get policy settings
+	minConsolidationFactor := p.settings.Policy.GetMinConsolidationFactor()
+	if minConsolidationFactor <= 0 {
+		return false
+	}
+
+	numInputs := len(tx.Inputs)
+	numOutputs := len(tx.Outputs)
+
+	// Check if it's a dust return transaction (special case)
+	isDustReturn := p.isDustReturnTx(tx)
+
+	// Rule 1: Input/Output Ratio
+	// The number of inputs must be >= minConsolidationFactor × number of outputs
+	if !isDustReturn && numInputs < minConsolidationFactor*numOutputs {
+		return false
+	}
+
+	// Rule 2: Script Size Comparison (Bitcoin SV rule)
+	// Sum of input scriptPubKey sizes >= minConsolidationFactor × sum of output scriptPubKey sizes
+	if !isDustReturn {
+		// Check if transaction is extended (has PreviousTxScript for all inputs)
+		for _, input := range tx.Inputs {
+			if input.PreviousTxScript == nil {
+				return false
+			}
+		}
+
+		// Calculate total size of scriptPubKeys from UTXOs being spent
+		totalInputScriptPubKeySize := 0
+		for _, input := range tx.Inputs {
+			totalInputScriptPubKeySize += len(*input.PreviousTxScript)
+		}
+
+		// Calculate total size of output scriptPubKeys
+		totalOutputScriptPubKeySize := 0
+		for _, output := range tx.Outputs {
+			if output.LockingScript != nil {
+				totalOutputScriptPubKeySize += len(*output.LockingScript)
+			}
+		}
+
+		// Check the script size ratio
+		if totalInputScriptPubKeySize < minConsolidationFactor*totalOutputScriptPubKeySize {
+			return false
+		}
+	}
+
+	// If no UTXO heights provided, we're done (fee exemption check)
+	if utxoHeights == nil {
+		return true
+	}
+
+	// FULL VALIDATION - Only performed when UTXO heights are provided
+
+	// Get configuration settings
+	minConf := p.settings.Policy.GetMinConfConsolidationInput()
+	maxInputScriptSize := p.settings.Policy.GetMaxConsolidationInputScriptSize()
+	acceptNonStdInputs := p.settings.Policy.GetAcceptNonStdConsolidationInput()
+
+	// Dust return transactions don't require confirmations
+	if isDustReturn {
+		minConf = 0
+	}
+
+	// Check each input
+	for i, input := range tx.Inputs {
+		// Rule 3: Input Maturity
+		// All inputs must have at least minConfConsolidationInput confirmations
+		if minConf > 0 && i < len(utxoHeights) {
+			inputHeight := utxoHeights[i]
+			confirmations := int(currentHeight - inputHeight)
+			if confirmations < minConf {
+				return false
+			}
+		}
+
+		// Rule 4: Input Script Size Limit
+		// Each input's scriptSig must be <= maxConsolidationInputScriptSize bytes
+		if maxInputScriptSize > 0 && input.UnlockingScript != nil {
+			scriptSize := len(*input.UnlockingScript)
+			if scriptSize > maxInputScriptSize {
+				return false
+			}
+		}
+
+		// Rule 5: Standard Script Rule
+		// If acceptNonStdConsolidationInput = 0, all inputs must use standard scripts
+		if !acceptNonStdInputs && !isStandardInputScript(input.UnlockingScript, currentHeight, p.settings.ChainCfgParams.UahfForkHeight) {
+			return false
+		}
+	}
+
+	// Transaction qualifies as a consolidation transaction
+	return true
+}
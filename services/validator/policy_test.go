@@ -0,0 +1,274 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/bscript"
+	bec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/services/validator/validator_api"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/stores/utxo"
+	"github.com/bsv-blockchain/teranode/test/utils/transactions"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newPolicyTestSettings(t *testing.T) *settings.Settings {
+	tSettings := test.CreateBaseTestSettings(t)
+
+	// copy the chain params, the tests require standard transactions
+	params := *tSettings.ChainCfgParams
+	params.RequireStandard = true
+	tSettings.ChainCfgParams = &params
+
+	tSettings.Policy.MinMiningTxFee = 0.00000500
+	tSettings.Policy.MinConsolidationFactor = 0
+
+	return tSettings
+}
+
+// policyTestTxs are transactions failing the policy rules, spending the output of the parent
+type policyTestTxs struct {
+	parent *bt.Tx
+	valid  *bt.Tx // pays a fee of 1000 satoshis
+	dust   *bt.Tx // pays a fee of 1000 satoshis, but has a zero-satoshi spendable output
+	lowFee *bt.Tx // pays a fee of 1 satoshi
+	p2sh   *bt.Tx // pays a fee of 1000 satoshis to a p2sh output
+}
+
+func createPolicyTestTxs(t *testing.T) policyTestTxs {
+	privKey, err := bec.NewPrivateKey()
+	require.NoError(t, err)
+
+	parentTx := transactions.Create(t,
+		transactions.WithCoinbaseData(100, "/Test miner/"),
+		transactions.WithP2PKHOutputs(1, 100000, privKey.PubKey()),
+	)
+
+	p2shScript, err := bscript.NewFromHexString("a914" + "0102030405060708090a0b0c0d0e0f1011121314" + "87")
+	require.NoError(t, err)
+
+	return policyTestTxs{
+		parent: parentTx,
+		valid: transactions.Create(t,
+			transactions.WithPrivateKey(privKey),
+			transactions.WithInput(parentTx, 0, privKey),
+			transactions.WithP2PKHOutputs(1, 99000, privKey.PubKey()),
+		),
+		dust: transactions.Create(t,
+			transactions.WithPrivateKey(privKey),
+			transactions.WithInput(parentTx, 0, privKey),
+			transactions.WithP2PKHOutputs(1, 0, privKey.PubKey()),
+			transactions.WithP2PKHOutputs(1, 99000, privKey.PubKey()),
+		),
+		lowFee: transactions.Create(t,
+			transactions.WithPrivateKey(privKey),
+			transactions.WithInput(parentTx, 0, privKey),
+			transactions.WithP2PKHOutputs(1, 99999, privKey.PubKey()),
+		),
+		p2sh: transactions.Create(t,
+			transactions.WithPrivateKey(privKey),
+			transactions.WithInput(parentTx, 0, privKey),
+			transactions.WithOutput(99000, p2shScript),
+		),
+	}
+}
+
+func TestPolicyEngine(t *testing.T) {
+	txs := createPolicyTestTxs(t)
+
+	t.Run("enforces the enabled rules", func(t *testing.T) {
+		tSettings := newPolicyTestSettings(t)
+		policy := NewPolicyEngine(ulogger.TestLogger{}, tSettings)
+
+		afterGenesis := tSettings.ChainCfgParams.GenesisActivationHeight + 1
+
+		err := policy.Check(PolicyRuleDust, txs.dust, afterGenesis, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "zero-satoshi outputs require 'OP_FALSE OP_RETURN' prefix")
+
+		// the dust rule does not apply before genesis activation
+		assert.NoError(t, policy.Check(PolicyRuleDust, txs.dust, tSettings.ChainCfgParams.GenesisActivationHeight, nil))
+
+		err = policy.Check(PolicyRuleMinFee, txs.lowFee, afterGenesis, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "transaction fee is too low")
+
+		err = policy.Check(PolicyRuleP2SHOutputs, txs.p2sh, afterGenesis, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is p2sh after genesis activation")
+
+		for _, rule := range PolicyRules {
+			assert.True(t, policy.Enabled(rule))
+			assert.NoError(t, policy.Check(rule, txs.valid, afterGenesis, nil), rule)
+		}
+	})
+
+	t.Run("max tx size", func(t *testing.T) {
+		tSettings := newPolicyTestSettings(t)
+		tSettings.Policy.MaxTxSizePolicy = 10
+
+		policy := NewPolicyEngine(ulogger.TestLogger{}, tSettings)
+
+		err := policy.Check(PolicyRuleMaxTxSize, txs.lowFee, 1, nil)
+		assert.ErrorIs(t, err, errors.New(errors.ERR_TX_INVALID, "transaction size in bytes is greater than max tx size policy 10"))
+	})
+
+	t.Run("dust limit", func(t *testing.T) {
+		tSettings := newPolicyTestSettings(t)
+		tSettings.Policy.DustLimit = 100000
+
+		policy := NewPolicyEngine(ulogger.TestLogger{}, tSettings)
+
+		err := policy.Check(PolicyRuleDust, txs.lowFee, tSettings.ChainCfgParams.GenesisActivationHeight+1, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "transaction output 0 of 99999 satoshis is below the dust limit of 100000 satoshis")
+	})
+
+	t.Run("disabled rules are evaluated but not enforced", func(t *testing.T) {
+		tSettings := newPolicyTestSettings(t)
+		tSettings.Policy.DisabledPolicyRules = []string{string(PolicyRuleDust), string(PolicyRuleMinFee), "unknown"}
+
+		policy := NewPolicyEngine(ulogger.TestLogger{}, tSettings)
+
+		afterGenesis := tSettings.ChainCfgParams.GenesisActivationHeight + 1
+
+		assert.False(t, policy.Enabled(PolicyRuleDust))
+		assert.False(t, policy.Enabled(PolicyRuleMinFee))
+		assert.True(t, policy.Enabled(PolicyRuleMaxTxSize))
+
+		assert.NoError(t, policy.Check(PolicyRuleDust, txs.dust, afterGenesis, nil))
+		assert.NoError(t, policy.Check(PolicyRuleMinFee, txs.lowFee, afterGenesis, nil))
+
+		results := policy.Evaluate(txs.dust, afterGenesis, nil)
+		require.Len(t, results, len(PolicyRules))
+
+		for i, result := range results {
+			assert.Equal(t, PolicyRules[i], result.Rule)
+
+			if result.Rule == PolicyRuleDust {
+				assert.False(t, result.Enabled)
+				assert.Error(t, result.Err)
+			} else {
+				assert.NoError(t, result.Err, result.Rule)
+			}
+		}
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		policy := NewPolicyEngine(ulogger.TestLogger{}, newPolicyTestSettings(t))
+
+		assert.Error(t, policy.Check(PolicyRule("unknown"), txs.valid, 1, nil))
+	})
+
+	t.Run("tx validator skips the disabled rules", func(t *testing.T) {
+		tSettings := newPolicyTestSettings(t)
+		afterGenesis := tSettings.ChainCfgParams.GenesisActivationHeight + 1
+
+		tv := &TxValidator{settings: tSettings}
+
+		require.Error(t, tv.checkOutputs(txs.dust, afterGenesis, &Options{}))
+		require.Error(t, tv.checkOutputs(txs.p2sh, afterGenesis, &Options{}))
+		require.Error(t, tv.checkFees(txs.lowFee, afterGenesis, nil))
+
+		tSettings.Policy.DisabledPolicyRules = []string{string(PolicyRuleDust), string(PolicyRuleP2SHOutputs), string(PolicyRuleMinFee)}
+
+		assert.NoError(t, tv.checkOutputs(txs.dust, afterGenesis, &Options{}))
+		assert.NoError(t, tv.checkOutputs(txs.p2sh, afterGenesis, &Options{}))
+		assert.NoError(t, tv.checkFees(txs.lowFee, afterGenesis, nil))
+	})
+}
+
+func TestServer_ValidatePolicy(t *testing.T) {
+	txs := createPolicyTestTxs(t)
+
+	tSettings := newPolicyTestSettings(t)
+	tSettings.Policy.DisabledPolicyRules = []string{string(PolicyRuleDust)}
+
+	afterGenesis := tSettings.ChainCfgParams.GenesisActivationHeight + 1
+
+	utxoStore := &utxo.MockUtxostore{}
+
+	server := NewServer(ulogger.TestLogger{}, tSettings, utxoStore, &blockchain.Mock{}, nil, nil, nil, nil)
+	server.validator = &TestMockValidator{}
+
+	validatePolicy := func(t *testing.T, transactionData []byte, blockHeight uint32) *validator_api.ValidatePolicyResponse {
+		t.Helper()
+
+		response, err := server.ValidatePolicy(context.Background(), &validator_api.ValidatePolicyRequest{
+			TransactionData: transactionData,
+			BlockHeight:     blockHeight,
+		})
+		require.NoError(t, err)
+		require.Len(t, response.GetRules(), len(PolicyRules))
+
+		return response
+	}
+
+	t.Run("passes the policy", func(t *testing.T) {
+		response := validatePolicy(t, txs.valid.ExtendedBytes(), afterGenesis)
+		assert.True(t, response.GetPassed())
+
+		for i, rule := range response.GetRules() {
+			assert.Equal(t, string(PolicyRules[i]), rule.GetRule())
+			assert.True(t, rule.GetPassed(), rule.GetRule())
+			assert.Empty(t, rule.GetReason())
+		}
+	})
+
+	t.Run("fails an enabled rule", func(t *testing.T) {
+		response := validatePolicy(t, txs.lowFee.ExtendedBytes(), afterGenesis)
+		assert.False(t, response.GetPassed())
+
+		for _, rule := range response.GetRules() {
+			if rule.GetRule() == string(PolicyRuleMinFee) {
+				assert.True(t, rule.GetEnabled())
+				assert.False(t, rule.GetPassed())
+				assert.Contains(t, rule.GetReason(), "transaction fee is too low")
+			} else {
+				assert.True(t, rule.GetPassed(), rule.GetRule())
+			}
+		}
+	})
+
+	t.Run("a disabled rule does not fail the policy", func(t *testing.T) {
+		response := validatePolicy(t, txs.dust.ExtendedBytes(), afterGenesis)
+		assert.True(t, response.GetPassed())
+
+		for _, rule := range response.GetRules() {
+			if rule.GetRule() == string(PolicyRuleDust) {
+				assert.False(t, rule.GetEnabled())
+				assert.False(t, rule.GetPassed())
+				assert.NotEmpty(t, rule.GetReason())
+			}
+		}
+	})
+
+	t.Run("extends the transaction", func(t *testing.T) {
+		utxoStore.On("PreviousOutputsDecorate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			tx := args.Get(1).(*bt.Tx)
+			tx.Inputs[0].PreviousTxSatoshis = txs.parent.Outputs[0].Satoshis
+			tx.Inputs[0].PreviousTxScript = txs.parent.Outputs[0].LockingScript
+		}).Return(nil).Once()
+
+		// the block height of the validator is used for the next block
+		response := validatePolicy(t, txs.lowFee.Bytes(), 0)
+		assert.False(t, response.GetPassed())
+
+		utxoStore.AssertExpectations(t)
+	})
+
+	t.Run("invalid transaction", func(t *testing.T) {
+		_, err := server.ValidatePolicy(context.Background(), &validator_api.ValidatePolicyRequest{
+			TransactionData: []byte{0x01, 0x02},
+		})
+		require.Error(t, err)
+	})
+}
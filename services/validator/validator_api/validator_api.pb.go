@@ -28,6 +28,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// PackageTransactionStatus is the result of a single transaction of a package
+type PackageTransactionStatus int32
+
+const (
+	PackageTransactionStatus_NOT_ADMITTED  PackageTransactionStatus = 0 // Not admitted, because another transaction or the package was rejected
+	PackageTransactionStatus_ACCEPTED      PackageTransactionStatus = 1 // Validated and admitted
+	PackageTransactionStatus_ALREADY_KNOWN PackageTransactionStatus = 2 // Already validated before the package was submitted
+	PackageTransactionStatus_REJECTED      PackageTransactionStatus = 3 // Invalid, which rejects the whole package
+)
+
+// Enum value maps for PackageTransactionStatus.
+var (
+	PackageTransactionStatus_name = map[int32]string{
+		0: "NOT_ADMITTED",
+		1: "ACCEPTED",
+		2: "ALREADY_KNOWN",
+		3: "REJECTED",
+	}
+	PackageTransactionStatus_value = map[string]int32{
+		"NOT_ADMITTED":  0,
+		"ACCEPTED":      1,
+		"ALREADY_KNOWN": 2,
+		"REJECTED":      3,
+	}
+)
+
+func (x PackageTransactionStatus) Enum() *PackageTransactionStatus {
+	p := new(PackageTransactionStatus)
+	*p = x
+	return p
+}
+
+func (x PackageTransactionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PackageTransactionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_services_validator_validator_api_validator_api_proto_enumTypes[0].Descriptor()
+}
+
+func (PackageTransactionStatus) Type() protoreflect.EnumType {
+	return &file_services_validator_validator_api_validator_api_proto_enumTypes[0]
+}
+
+func (x PackageTransactionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PackageTransactionStatus.Descriptor instead.
+func (PackageTransactionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{0}
+}
+
 // EmptyMessage represents an empty request message
 // Used for endpoints that don't require input parameters
 // swagger:model EmptyMessage
@@ -71,9 +124,10 @@ func (*EmptyMessage) Descriptor() ([]byte, []int) {
 // swagger:model HealthResponse
 type HealthResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`              // Overall health status
-	Details       string                 `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`     // Detailed health information
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Timestamp of health check
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`                               // Overall health status
+	Details       string                 `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`                      // Detailed health information
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                  // Timestamp of health check
+	BuildInfo     string                 `protobuf:"bytes,4,opt,name=build_info,json=buildInfo,proto3" json:"build_info,omitempty"` // JSON build metadata of the node, as returned by /api/v1/version
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -129,6 +183,13 @@ func (x *HealthResponse) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *HealthResponse) GetBuildInfo() string {
+	if x != nil {
+		return x.BuildInfo
+	}
+	return ""
+}
+
 // ValidateTransactionRequest contains data for transaction validation
 // swagger:model ValidateTransactionRequest
 type ValidateTransactionRequest struct {
@@ -136,10 +197,12 @@ type ValidateTransactionRequest struct {
 	TransactionData []byte                 `protobuf:"bytes,1,opt,name=transaction_data,json=transactionData,proto3" json:"transaction_data,omitempty"` // Raw transaction data to validate
 	BlockHeight     uint32                 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`            // Block height for validation context
 	// validation options
-	SkipUtxoCreation     *bool `protobuf:"varint,3,opt,name=skip_utxo_creation,json=skipUtxoCreation,proto3,oneof" json:"skip_utxo_creation,omitempty"`                 // Skip UTXO creation for validation
-	AddTxToBlockAssembly *bool `protobuf:"varint,4,opt,name=add_tx_to_block_assembly,json=addTxToBlockAssembly,proto3,oneof" json:"add_tx_to_block_assembly,omitempty"` // Add transaction to block assembly
-	SkipPolicyChecks     *bool `protobuf:"varint,5,opt,name=skip_policy_checks,json=skipPolicyChecks,proto3,oneof" json:"skip_policy_checks,omitempty"`                 // Skip policy checks
-	CreateConflicting    *bool `protobuf:"varint,6,opt,name=create_conflicting,json=createConflicting,proto3,oneof" json:"create_conflicting,omitempty"`                // Create conflicting transaction
+	SkipUtxoCreation     *bool   `protobuf:"varint,3,opt,name=skip_utxo_creation,json=skipUtxoCreation,proto3,oneof" json:"skip_utxo_creation,omitempty"`                 // Skip UTXO creation for validation
+	AddTxToBlockAssembly *bool   `protobuf:"varint,4,opt,name=add_tx_to_block_assembly,json=addTxToBlockAssembly,proto3,oneof" json:"add_tx_to_block_assembly,omitempty"` // Add transaction to block assembly
+	SkipPolicyChecks     *bool   `protobuf:"varint,5,opt,name=skip_policy_checks,json=skipPolicyChecks,proto3,oneof" json:"skip_policy_checks,omitempty"`                 // Skip policy checks
+	CreateConflicting    *bool   `protobuf:"varint,6,opt,name=create_conflicting,json=createConflicting,proto3,oneof" json:"create_conflicting,omitempty"`                // Create conflicting transaction
+	TxPriority           *uint32 `protobuf:"varint,7,opt,name=tx_priority,json=txPriority,proto3,oneof" json:"tx_priority,omitempty"`                                     // Priority lane of the transaction in block assembly
+	PeerId               *string `protobuf:"bytes,8,opt,name=peer_id,json=peerId,proto3,oneof" json:"peer_id,omitempty"`                                                  // Peer the transaction was received from, reported with double spends
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -216,6 +279,20 @@ func (x *ValidateTransactionRequest) GetCreateConflicting() bool {
 	return false
 }
 
+func (x *ValidateTransactionRequest) GetTxPriority() uint32 {
+	if x != nil && x.TxPriority != nil {
+		return *x.TxPriority
+	}
+	return 0
+}
+
+func (x *ValidateTransactionRequest) GetPeerId() string {
+	if x != nil && x.PeerId != nil {
+		return *x.PeerId
+	}
+	return ""
+}
+
 // ValidateTransactionResponse provides transaction validation results
 // swagger:model ValidateTransactionResponse
 type ValidateTransactionResponse struct {
@@ -394,6 +471,411 @@ func (x *ValidateTransactionBatchResponse) GetMetadata() [][]byte {
 	return nil
 }
 
+// ValidatePackageRequest contains a package of dependent transactions to validate together
+// swagger:model ValidatePackageRequest
+type ValidatePackageRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Transactions [][]byte               `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`                   // Raw transactions of the package, in any order
+	BlockHeight  uint32                 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"` // Block height for validation context
+	// validation options
+	AddTxToBlockAssembly *bool `protobuf:"varint,3,opt,name=add_tx_to_block_assembly,json=addTxToBlockAssembly,proto3,oneof" json:"add_tx_to_block_assembly,omitempty"` // Add the transactions to block assembly
+	SkipPolicyChecks     *bool `protobuf:"varint,4,opt,name=skip_policy_checks,json=skipPolicyChecks,proto3,oneof" json:"skip_policy_checks,omitempty"`                 // Skip policy checks
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ValidatePackageRequest) Reset() {
+	*x = ValidatePackageRequest{}
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidatePackageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatePackageRequest) ProtoMessage() {}
+
+func (x *ValidatePackageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatePackageRequest.ProtoReflect.Descriptor instead.
+func (*ValidatePackageRequest) Descriptor() ([]byte, []int) {
+	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ValidatePackageRequest) GetTransactions() [][]byte {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ValidatePackageRequest) GetBlockHeight() uint32 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *ValidatePackageRequest) GetAddTxToBlockAssembly() bool {
+	if x != nil && x.AddTxToBlockAssembly != nil {
+		return *x.AddTxToBlockAssembly
+	}
+	return false
+}
+
+func (x *ValidatePackageRequest) GetSkipPolicyChecks() bool {
+	if x != nil && x.SkipPolicyChecks != nil {
+		return *x.SkipPolicyChecks
+	}
+	return false
+}
+
+// PackageTransactionResult provides the result of a single transaction of a package
+// swagger:model PackageTransactionResult
+type PackageTransactionResult struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Txid          []byte                   `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`                                                  // Transaction ID
+	Status        PackageTransactionStatus `protobuf:"varint,2,opt,name=status,proto3,enum=validator_api.PackageTransactionStatus" json:"status,omitempty"` // Result of the transaction
+	Fee           uint64                   `protobuf:"varint,3,opt,name=fee,proto3" json:"fee,omitempty"`                                                   // Fee paid by the transaction in satoshis
+	Size          uint64                   `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`                                                 // Size of the transaction in bytes
+	Error         *errors.TError           `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                                                // Reason the transaction was rejected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageTransactionResult) Reset() {
+	*x = PackageTransactionResult{}
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageTransactionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageTransactionResult) ProtoMessage() {}
+
+func (x *PackageTransactionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageTransactionResult.ProtoReflect.Descriptor instead.
+func (*PackageTransactionResult) Descriptor() ([]byte, []int) {
+	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PackageTransactionResult) GetTxid() []byte {
+	if x != nil {
+		return x.Txid
+	}
+	return nil
+}
+
+func (x *PackageTransactionResult) GetStatus() PackageTransactionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PackageTransactionStatus_NOT_ADMITTED
+}
+
+func (x *PackageTransactionResult) GetFee() uint64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *PackageTransactionResult) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PackageTransactionResult) GetError() *errors.TError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// ValidatePackageResponse provides the result of a package validation
+// swagger:model ValidatePackageResponse
+type ValidatePackageResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Accepted      bool                        `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`        // All transactions of the package were admitted
+	Fee           uint64                      `protobuf:"varint,2,opt,name=fee,proto3" json:"fee,omitempty"`                  // Combined fee of the new transactions in satoshis
+	Size          uint64                      `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`                // Combined size of the new transactions in bytes
+	Transactions  []*PackageTransactionResult `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"` // Result of each transaction, in the order of the request
+	Error         *errors.TError              `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`               // Reason the package was rejected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidatePackageResponse) Reset() {
+	*x = ValidatePackageResponse{}
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidatePackageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatePackageResponse) ProtoMessage() {}
+
+func (x *ValidatePackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatePackageResponse.ProtoReflect.Descriptor instead.
+func (*ValidatePackageResponse) Descriptor() ([]byte, []int) {
+	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ValidatePackageResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *ValidatePackageResponse) GetFee() uint64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *ValidatePackageResponse) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ValidatePackageResponse) GetTransactions() []*PackageTransactionResult {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ValidatePackageResponse) GetError() *errors.TError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// ValidatePolicyRequest contains a transaction to test against the transaction acceptance policy
+// swagger:model ValidatePolicyRequest
+type ValidatePolicyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionData []byte                 `protobuf:"bytes,1,opt,name=transaction_data,json=transactionData,proto3" json:"transaction_data,omitempty"` // Raw transaction data, extended or not
+	BlockHeight     uint32                 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`            // Block height for validation context, 0 for the next block
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ValidatePolicyRequest) Reset() {
+	*x = ValidatePolicyRequest{}
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidatePolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatePolicyRequest) ProtoMessage() {}
+
+func (x *ValidatePolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatePolicyRequest.ProtoReflect.Descriptor instead.
+func (*ValidatePolicyRequest) Descriptor() ([]byte, []int) {
+	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidatePolicyRequest) GetTransactionData() []byte {
+	if x != nil {
+		return x.TransactionData
+	}
+	return nil
+}
+
+func (x *ValidatePolicyRequest) GetBlockHeight() uint32 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+// PolicyRuleResult provides the result of a single policy rule
+// swagger:model PolicyRuleResult
+type PolicyRuleResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`        // Name of the policy rule
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"` // The rule is enforced, disabled rules are evaluated but not enforced
+	Passed        bool                   `protobuf:"varint,3,opt,name=passed,proto3" json:"passed,omitempty"`   // The transaction passes the rule
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`    // Reason the transaction fails the rule
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyRuleResult) Reset() {
+	*x = PolicyRuleResult{}
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyRuleResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyRuleResult) ProtoMessage() {}
+
+func (x *PolicyRuleResult) ProtoReflect() protoreflect.Message {
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyRuleResult.ProtoReflect.Descriptor instead.
+func (*PolicyRuleResult) Descriptor() ([]byte, []int) {
+	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PolicyRuleResult) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *PolicyRuleResult) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *PolicyRuleResult) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *PolicyRuleResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ValidatePolicyResponse provides the result of testing a transaction against the policy
+// swagger:model ValidatePolicyResponse
+type ValidatePolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passed        bool                   `protobuf:"varint,1,opt,name=passed,proto3" json:"passed,omitempty"` // The transaction passes all enabled policy rules
+	Rules         []*PolicyRuleResult    `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`    // Result of each policy rule, in the order they are evaluated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidatePolicyResponse) Reset() {
+	*x = ValidatePolicyResponse{}
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidatePolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatePolicyResponse) ProtoMessage() {}
+
+func (x *ValidatePolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatePolicyResponse.ProtoReflect.Descriptor instead.
+func (*ValidatePolicyResponse) Descriptor() ([]byte, []int) {
+	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidatePolicyResponse) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *ValidatePolicyResponse) GetRules() []*PolicyRuleResult {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
 // GetBlockHeightResponse provides the current block height
 // swagger:model GetBlockHeightResponse
 type GetBlockHeightResponse struct {
@@ -405,7 +887,7 @@ type GetBlockHeightResponse struct {
 
 func (x *GetBlockHeightResponse) Reset() {
 	*x = GetBlockHeightResponse{}
-	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[6]
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -417,7 +899,7 @@ func (x *GetBlockHeightResponse) String() string {
 func (*GetBlockHeightResponse) ProtoMessage() {}
 
 func (x *GetBlockHeightResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[6]
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -430,7 +912,7 @@ func (x *GetBlockHeightResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockHeightResponse.ProtoReflect.Descriptor instead.
 func (*GetBlockHeightResponse) Descriptor() ([]byte, []int) {
-	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{6}
+	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetBlockHeightResponse) GetHeight() uint32 {
@@ -451,7 +933,7 @@ type GetMedianBlockTimeResponse struct {
 
 func (x *GetMedianBlockTimeResponse) Reset() {
 	*x = GetMedianBlockTimeResponse{}
-	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[7]
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -463,7 +945,7 @@ func (x *GetMedianBlockTimeResponse) String() string {
 func (*GetMedianBlockTimeResponse) ProtoMessage() {}
 
 func (x *GetMedianBlockTimeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[7]
+	mi := &file_services_validator_validator_api_validator_api_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -476,7 +958,7 @@ func (x *GetMedianBlockTimeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMedianBlockTimeResponse.ProtoReflect.Descriptor instead.
 func (*GetMedianBlockTimeResponse) Descriptor() ([]byte, []int) {
-	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{7}
+	return file_services_validator_validator_api_validator_api_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetMedianBlockTimeResponse) GetMedianTime() uint32 {
@@ -491,22 +973,30 @@ var File_services_validator_validator_api_validator_api_proto protoreflect.FileD
 const file_services_validator_validator_api_validator_api_proto_rawDesc = "" +
 	"\n" +
 	"4services/validator/validator_api/validator_api.proto\x12\rvalidator_api\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x12errors/error.proto\"\x0e\n" +
-	"\fEmptyMessage\"t\n" +
+	"\fEmptyMessage\"\x93\x01\n" +
 	"\x0eHealthResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x18\n" +
 	"\adetails\x18\x02 \x01(\tR\adetails\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xa3\x03\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"build_info\x18\x04 \x01(\tR\tbuildInfo\"\x83\x04\n" +
 	"\x1aValidateTransactionRequest\x12)\n" +
 	"\x10transaction_data\x18\x01 \x01(\fR\x0ftransactionData\x12!\n" +
 	"\fblock_height\x18\x02 \x01(\rR\vblockHeight\x121\n" +
 	"\x12skip_utxo_creation\x18\x03 \x01(\bH\x00R\x10skipUtxoCreation\x88\x01\x01\x12;\n" +
 	"\x18add_tx_to_block_assembly\x18\x04 \x01(\bH\x01R\x14addTxToBlockAssembly\x88\x01\x01\x121\n" +
 	"\x12skip_policy_checks\x18\x05 \x01(\bH\x02R\x10skipPolicyChecks\x88\x01\x01\x122\n" +
-	"\x12create_conflicting\x18\x06 \x01(\bH\x03R\x11createConflicting\x88\x01\x01B\x15\n" +
+	"\x12create_conflicting\x18\x06 \x01(\bH\x03R\x11createConflicting\x88\x01\x01\x12$\n" +
+	"\vtx_priority\x18\a \x01(\rH\x04R\n" +
+	"txPriority\x88\x01\x01\x12\x1c\n" +
+	"\apeer_id\x18\b \x01(\tH\x05R\x06peerId\x88\x01\x01B\x15\n" +
 	"\x13_skip_utxo_creationB\x1b\n" +
 	"\x19_add_tx_to_block_assemblyB\x15\n" +
 	"\x13_skip_policy_checksB\x15\n" +
-	"\x13_create_conflicting\"{\n" +
+	"\x13_create_conflictingB\x0e\n" +
+	"\f_tx_priorityB\n" +
+	"\n" +
+	"\b_peer_id\"{\n" +
 	"\x1bValidateTransactionResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x12\n" +
 	"\x04txid\x18\x02 \x01(\fR\x04txid\x12\x16\n" +
@@ -517,17 +1007,54 @@ const file_services_validator_validator_api_validator_api_proto_rawDesc = "" +
 	" ValidateTransactionBatchResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12&\n" +
 	"\x06errors\x18\x02 \x03(\v2\x0e.errors.TErrorR\x06errors\x12\x1a\n" +
-	"\bmetadata\x18\x03 \x03(\fR\bmetadata\"0\n" +
+	"\bmetadata\x18\x03 \x03(\fR\bmetadata\"\x83\x02\n" +
+	"\x16ValidatePackageRequest\x12\"\n" +
+	"\ftransactions\x18\x01 \x03(\fR\ftransactions\x12!\n" +
+	"\fblock_height\x18\x02 \x01(\rR\vblockHeight\x12;\n" +
+	"\x18add_tx_to_block_assembly\x18\x03 \x01(\bH\x00R\x14addTxToBlockAssembly\x88\x01\x01\x121\n" +
+	"\x12skip_policy_checks\x18\x04 \x01(\bH\x01R\x10skipPolicyChecks\x88\x01\x01B\x1b\n" +
+	"\x19_add_tx_to_block_assemblyB\x15\n" +
+	"\x13_skip_policy_checks\"\xbb\x01\n" +
+	"\x18PackageTransactionResult\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\fR\x04txid\x12?\n" +
+	"\x06status\x18\x02 \x01(\x0e2'.validator_api.PackageTransactionStatusR\x06status\x12\x10\n" +
+	"\x03fee\x18\x03 \x01(\x04R\x03fee\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x04R\x04size\x12$\n" +
+	"\x05error\x18\x05 \x01(\v2\x0e.errors.TErrorR\x05error\"\xce\x01\n" +
+	"\x17ValidatePackageResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x10\n" +
+	"\x03fee\x18\x02 \x01(\x04R\x03fee\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x04R\x04size\x12K\n" +
+	"\ftransactions\x18\x04 \x03(\v2'.validator_api.PackageTransactionResultR\ftransactions\x12$\n" +
+	"\x05error\x18\x05 \x01(\v2\x0e.errors.TErrorR\x05error\"e\n" +
+	"\x15ValidatePolicyRequest\x12)\n" +
+	"\x10transaction_data\x18\x01 \x01(\fR\x0ftransactionData\x12!\n" +
+	"\fblock_height\x18\x02 \x01(\rR\vblockHeight\"p\n" +
+	"\x10PolicyRuleResult\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06passed\x18\x03 \x01(\bR\x06passed\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"g\n" +
+	"\x16ValidatePolicyResponse\x12\x16\n" +
+	"\x06passed\x18\x01 \x01(\bR\x06passed\x125\n" +
+	"\x05rules\x18\x02 \x03(\v2\x1f.validator_api.PolicyRuleResultR\x05rules\"0\n" +
 	"\x16GetBlockHeightResponse\x12\x16\n" +
 	"\x06height\x18\x01 \x01(\rR\x06height\"=\n" +
 	"\x1aGetMedianBlockTimeResponse\x12\x1f\n" +
 	"\vmedian_time\x18\x01 \x01(\rR\n" +
-	"medianTime2\x81\x04\n" +
+	"medianTime*[\n" +
+	"\x18PackageTransactionStatus\x12\x10\n" +
+	"\fNOT_ADMITTED\x10\x00\x12\f\n" +
+	"\bACCEPTED\x10\x01\x12\x11\n" +
+	"\rALREADY_KNOWN\x10\x02\x12\f\n" +
+	"\bREJECTED\x10\x032\xc6\x05\n" +
 	"\fValidatorAPI\x12J\n" +
 	"\n" +
 	"HealthGRPC\x12\x1b.validator_api.EmptyMessage\x1a\x1d.validator_api.HealthResponse\"\x00\x12n\n" +
 	"\x13ValidateTransaction\x12).validator_api.ValidateTransactionRequest\x1a*.validator_api.ValidateTransactionResponse\"\x00\x12}\n" +
-	"\x18ValidateTransactionBatch\x12..validator_api.ValidateTransactionBatchRequest\x1a/.validator_api.ValidateTransactionBatchResponse\"\x00\x12V\n" +
+	"\x18ValidateTransactionBatch\x12..validator_api.ValidateTransactionBatchRequest\x1a/.validator_api.ValidateTransactionBatchResponse\"\x00\x12b\n" +
+	"\x0fValidatePackage\x12%.validator_api.ValidatePackageRequest\x1a&.validator_api.ValidatePackageResponse\"\x00\x12_\n" +
+	"\x0eValidatePolicy\x12$.validator_api.ValidatePolicyRequest\x1a%.validator_api.ValidatePolicyResponse\"\x00\x12V\n" +
 	"\x0eGetBlockHeight\x12\x1b.validator_api.EmptyMessage\x1a%.validator_api.GetBlockHeightResponse\"\x00\x12^\n" +
 	"\x12GetMedianBlockTime\x12\x1b.validator_api.EmptyMessage\x1a).validator_api.GetMedianBlockTimeResponse\"\x00B\x12Z\x10./;validator_apib\x06proto3"
 
@@ -543,38 +1070,55 @@ func file_services_validator_validator_api_validator_api_proto_rawDescGZIP() []b
 	return file_services_validator_validator_api_validator_api_proto_rawDescData
 }
 
-var file_services_validator_validator_api_validator_api_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_services_validator_validator_api_validator_api_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_services_validator_validator_api_validator_api_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_services_validator_validator_api_validator_api_proto_goTypes = []any{
-	(*EmptyMessage)(nil),                     // 0: validator_api.EmptyMessage
-	(*HealthResponse)(nil),                   // 1: validator_api.HealthResponse
-	(*ValidateTransactionRequest)(nil),       // 2: validator_api.ValidateTransactionRequest
-	(*ValidateTransactionResponse)(nil),      // 3: validator_api.ValidateTransactionResponse
-	(*ValidateTransactionBatchRequest)(nil),  // 4: validator_api.ValidateTransactionBatchRequest
-	(*ValidateTransactionBatchResponse)(nil), // 5: validator_api.ValidateTransactionBatchResponse
-	(*GetBlockHeightResponse)(nil),           // 6: validator_api.GetBlockHeightResponse
-	(*GetMedianBlockTimeResponse)(nil),       // 7: validator_api.GetMedianBlockTimeResponse
-	(*timestamppb.Timestamp)(nil),            // 8: google.protobuf.Timestamp
-	(*errors.TError)(nil),                    // 9: errors.TError
+	(PackageTransactionStatus)(0),            // 0: validator_api.PackageTransactionStatus
+	(*EmptyMessage)(nil),                     // 1: validator_api.EmptyMessage
+	(*HealthResponse)(nil),                   // 2: validator_api.HealthResponse
+	(*ValidateTransactionRequest)(nil),       // 3: validator_api.ValidateTransactionRequest
+	(*ValidateTransactionResponse)(nil),      // 4: validator_api.ValidateTransactionResponse
+	(*ValidateTransactionBatchRequest)(nil),  // 5: validator_api.ValidateTransactionBatchRequest
+	(*ValidateTransactionBatchResponse)(nil), // 6: validator_api.ValidateTransactionBatchResponse
+	(*ValidatePackageRequest)(nil),           // 7: validator_api.ValidatePackageRequest
+	(*PackageTransactionResult)(nil),         // 8: validator_api.PackageTransactionResult
+	(*ValidatePackageResponse)(nil),          // 9: validator_api.ValidatePackageResponse
+	(*ValidatePolicyRequest)(nil),            // 10: validator_api.ValidatePolicyRequest
+	(*PolicyRuleResult)(nil),                 // 11: validator_api.PolicyRuleResult
+	(*ValidatePolicyResponse)(nil),           // 12: validator_api.ValidatePolicyResponse
+	(*GetBlockHeightResponse)(nil),           // 13: validator_api.GetBlockHeightResponse
+	(*GetMedianBlockTimeResponse)(nil),       // 14: validator_api.GetMedianBlockTimeResponse
+	(*timestamppb.Timestamp)(nil),            // 15: google.protobuf.Timestamp
+	(*errors.TError)(nil),                    // 16: errors.TError
 }
 var file_services_validator_validator_api_validator_api_proto_depIdxs = []int32{
-	8, // 0: validator_api.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
-	2, // 1: validator_api.ValidateTransactionBatchRequest.transactions:type_name -> validator_api.ValidateTransactionRequest
-	9, // 2: validator_api.ValidateTransactionBatchResponse.errors:type_name -> errors.TError
-	0, // 3: validator_api.ValidatorAPI.HealthGRPC:input_type -> validator_api.EmptyMessage
-	2, // 4: validator_api.ValidatorAPI.ValidateTransaction:input_type -> validator_api.ValidateTransactionRequest
-	4, // 5: validator_api.ValidatorAPI.ValidateTransactionBatch:input_type -> validator_api.ValidateTransactionBatchRequest
-	0, // 6: validator_api.ValidatorAPI.GetBlockHeight:input_type -> validator_api.EmptyMessage
-	0, // 7: validator_api.ValidatorAPI.GetMedianBlockTime:input_type -> validator_api.EmptyMessage
-	1, // 8: validator_api.ValidatorAPI.HealthGRPC:output_type -> validator_api.HealthResponse
-	3, // 9: validator_api.ValidatorAPI.ValidateTransaction:output_type -> validator_api.ValidateTransactionResponse
-	5, // 10: validator_api.ValidatorAPI.ValidateTransactionBatch:output_type -> validator_api.ValidateTransactionBatchResponse
-	6, // 11: validator_api.ValidatorAPI.GetBlockHeight:output_type -> validator_api.GetBlockHeightResponse
-	7, // 12: validator_api.ValidatorAPI.GetMedianBlockTime:output_type -> validator_api.GetMedianBlockTimeResponse
-	8, // [8:13] is the sub-list for method output_type
-	3, // [3:8] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	15, // 0: validator_api.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 1: validator_api.ValidateTransactionBatchRequest.transactions:type_name -> validator_api.ValidateTransactionRequest
+	16, // 2: validator_api.ValidateTransactionBatchResponse.errors:type_name -> errors.TError
+	0,  // 3: validator_api.PackageTransactionResult.status:type_name -> validator_api.PackageTransactionStatus
+	16, // 4: validator_api.PackageTransactionResult.error:type_name -> errors.TError
+	8,  // 5: validator_api.ValidatePackageResponse.transactions:type_name -> validator_api.PackageTransactionResult
+	16, // 6: validator_api.ValidatePackageResponse.error:type_name -> errors.TError
+	11, // 7: validator_api.ValidatePolicyResponse.rules:type_name -> validator_api.PolicyRuleResult
+	1,  // 8: validator_api.ValidatorAPI.HealthGRPC:input_type -> validator_api.EmptyMessage
+	3,  // 9: validator_api.ValidatorAPI.ValidateTransaction:input_type -> validator_api.ValidateTransactionRequest
+	5,  // 10: validator_api.ValidatorAPI.ValidateTransactionBatch:input_type -> validator_api.ValidateTransactionBatchRequest
+	7,  // 11: validator_api.ValidatorAPI.ValidatePackage:input_type -> validator_api.ValidatePackageRequest
+	10, // 12: validator_api.ValidatorAPI.ValidatePolicy:input_type -> validator_api.ValidatePolicyRequest
+	1,  // 13: validator_api.ValidatorAPI.GetBlockHeight:input_type -> validator_api.EmptyMessage
+	1,  // 14: validator_api.ValidatorAPI.GetMedianBlockTime:input_type -> validator_api.EmptyMessage
+	2,  // 15: validator_api.ValidatorAPI.HealthGRPC:output_type -> validator_api.HealthResponse
+	4,  // 16: validator_api.ValidatorAPI.ValidateTransaction:output_type -> validator_api.ValidateTransactionResponse
+	6,  // 17: validator_api.ValidatorAPI.ValidateTransactionBatch:output_type -> validator_api.ValidateTransactionBatchResponse
+	9,  // 18: validator_api.ValidatorAPI.ValidatePackage:output_type -> validator_api.ValidatePackageResponse
+	12, // 19: validator_api.ValidatorAPI.ValidatePolicy:output_type -> validator_api.ValidatePolicyResponse
+	13, // 20: validator_api.ValidatorAPI.GetBlockHeight:output_type -> validator_api.GetBlockHeightResponse
+	14, // 21: validator_api.ValidatorAPI.GetMedianBlockTime:output_type -> validator_api.GetMedianBlockTimeResponse
+	15, // [15:22] is the sub-list for method output_type
+	8,  // [8:15] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_services_validator_validator_api_validator_api_proto_init() }
@@ -583,18 +1127,20 @@ func file_services_validator_validator_api_validator_api_proto_init() {
 		return
 	}
 	file_services_validator_validator_api_validator_api_proto_msgTypes[2].OneofWrappers = []any{}
+	file_services_validator_validator_api_validator_api_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_validator_validator_api_validator_api_proto_rawDesc), len(file_services_validator_validator_api_validator_api_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_services_validator_validator_api_validator_api_proto_goTypes,
 		DependencyIndexes: file_services_validator_validator_api_validator_api_proto_depIdxs,
+		EnumInfos:         file_services_validator_validator_api_validator_api_proto_enumTypes,
 		MessageInfos:      file_services_validator_validator_api_validator_api_proto_msgTypes,
 	}.Build()
 	File_services_validator_validator_api_validator_api_proto = out.File
@@ -27,6 +27,8 @@ const (
 	ValidatorAPI_HealthGRPC_FullMethodName               = "/validator_api.ValidatorAPI/HealthGRPC"
 	ValidatorAPI_ValidateTransaction_FullMethodName      = "/validator_api.ValidatorAPI/ValidateTransaction"
 	ValidatorAPI_ValidateTransactionBatch_FullMethodName = "/validator_api.ValidatorAPI/ValidateTransactionBatch"
+	ValidatorAPI_ValidatePackage_FullMethodName          = "/validator_api.ValidatorAPI/ValidatePackage"
+	ValidatorAPI_ValidatePolicy_FullMethodName           = "/validator_api.ValidatorAPI/ValidatePolicy"
 	ValidatorAPI_GetBlockHeight_FullMethodName           = "/validator_api.ValidatorAPI/GetBlockHeight"
 	ValidatorAPI_GetMedianBlockTime_FullMethodName       = "/validator_api.ValidatorAPI/GetMedianBlockTime"
 )
@@ -46,6 +48,12 @@ type ValidatorAPIClient interface {
 	// ValidateTransactionBatch validates multiple transactions in a single request
 	// Provides efficient batch processing of transactions
 	ValidateTransactionBatch(ctx context.Context, in *ValidateTransactionBatchRequest, opts ...grpc.CallOption) (*ValidateTransactionBatchResponse, error)
+	// ValidatePackage validates a package of dependent transactions together
+	// Admits all transactions of the package or none of them, the fees are checked over the whole package
+	ValidatePackage(ctx context.Context, in *ValidatePackageRequest, opts ...grpc.CallOption) (*ValidatePackageResponse, error)
+	// ValidatePolicy tests a transaction against the transaction acceptance policy of the node
+	// Evaluates every policy rule, including the disabled rules, without validating the scripts or spending the UTXOs
+	ValidatePolicy(ctx context.Context, in *ValidatePolicyRequest, opts ...grpc.CallOption) (*ValidatePolicyResponse, error)
 	// GetBlockHeight retrieves the current block height
 	// Used for validation context and protocol upgrade determination
 	GetBlockHeight(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*GetBlockHeightResponse, error)
@@ -92,6 +100,26 @@ func (c *validatorAPIClient) ValidateTransactionBatch(ctx context.Context, in *V
 	return out, nil
 }
 
+func (c *validatorAPIClient) ValidatePackage(ctx context.Context, in *ValidatePackageRequest, opts ...grpc.CallOption) (*ValidatePackageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidatePackageResponse)
+	err := c.cc.Invoke(ctx, ValidatorAPI_ValidatePackage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *validatorAPIClient) ValidatePolicy(ctx context.Context, in *ValidatePolicyRequest, opts ...grpc.CallOption) (*ValidatePolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidatePolicyResponse)
+	err := c.cc.Invoke(ctx, ValidatorAPI_ValidatePolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *validatorAPIClient) GetBlockHeight(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*GetBlockHeightResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetBlockHeightResponse)
@@ -127,6 +155,12 @@ type ValidatorAPIServer interface {
 	// ValidateTransactionBatch validates multiple transactions in a single request
 	// Provides efficient batch processing of transactions
 	ValidateTransactionBatch(context.Context, *ValidateTransactionBatchRequest) (*ValidateTransactionBatchResponse, error)
+	// ValidatePackage validates a package of dependent transactions together
+	// Admits all transactions of the package or none of them, the fees are checked over the whole package
+	ValidatePackage(context.Context, *ValidatePackageRequest) (*ValidatePackageResponse, error)
+	// ValidatePolicy tests a transaction against the transaction acceptance policy of the node
+	// Evaluates every policy rule, including the disabled rules, without validating the scripts or spending the UTXOs
+	ValidatePolicy(context.Context, *ValidatePolicyRequest) (*ValidatePolicyResponse, error)
 	// GetBlockHeight retrieves the current block height
 	// Used for validation context and protocol upgrade determination
 	GetBlockHeight(context.Context, *EmptyMessage) (*GetBlockHeightResponse, error)
@@ -152,6 +186,12 @@ func (UnimplementedValidatorAPIServer) ValidateTransaction(context.Context, *Val
 func (UnimplementedValidatorAPIServer) ValidateTransactionBatch(context.Context, *ValidateTransactionBatchRequest) (*ValidateTransactionBatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateTransactionBatch not implemented")
 }
+func (UnimplementedValidatorAPIServer) ValidatePackage(context.Context, *ValidatePackageRequest) (*ValidatePackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatePackage not implemented")
+}
+func (UnimplementedValidatorAPIServer) ValidatePolicy(context.Context, *ValidatePolicyRequest) (*ValidatePolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatePolicy not implemented")
+}
 func (UnimplementedValidatorAPIServer) GetBlockHeight(context.Context, *EmptyMessage) (*GetBlockHeightResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockHeight not implemented")
 }
@@ -233,6 +273,42 @@ func _ValidatorAPI_ValidateTransactionBatch_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ValidatorAPI_ValidatePackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatePackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValidatorAPIServer).ValidatePackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ValidatorAPI_ValidatePackage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValidatorAPIServer).ValidatePackage(ctx, req.(*ValidatePackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ValidatorAPI_ValidatePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValidatorAPIServer).ValidatePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ValidatorAPI_ValidatePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValidatorAPIServer).ValidatePolicy(ctx, req.(*ValidatePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ValidatorAPI_GetBlockHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EmptyMessage)
 	if err := dec(in); err != nil {
@@ -288,6 +364,14 @@ var ValidatorAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ValidateTransactionBatch",
 			Handler:    _ValidatorAPI_ValidateTransactionBatch_Handler,
 		},
+		{
+			MethodName: "ValidatePackage",
+			Handler:    _ValidatorAPI_ValidatePackage_Handler,
+		},
+		{
+			MethodName: "ValidatePolicy",
+			Handler:    _ValidatorAPI_ValidatePolicy_Handler,
+		},
 		{
 			MethodName: "GetBlockHeight",
 			Handler:    _ValidatorAPI_GetBlockHeight_Handler,
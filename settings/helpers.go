@@ -9,13 +9,13 @@ import (
 )
 
 func getString(key, defaultValue string, alternativeContext ...string) string {
-	value, _ := gocore.Config(alternativeContext...).Get(key, defaultValue)
+	value, _ := gocore.Config(alternativeContext...).Get(key, profileDefault(key, defaultValue, parseString, alternativeContext...))
 
 	return value
 }
 
 func getMultiString(key, sep string, defaultValues []string, alternativeContext ...string) []string {
-	value, _ := gocore.Config(alternativeContext...).GetMulti(key, sep, defaultValues)
+	value, _ := gocore.Config(alternativeContext...).GetMulti(key, sep, profileDefault(key, defaultValues, parseMulti(sep), alternativeContext...))
 
 	return value
 }
@@ -32,47 +32,47 @@ func getMultiStringMap(key, sep string, defaultValues []string, alternativeConte
 }
 
 func getInt(key string, defaultValue int, alternativeContext ...string) int {
-	value, _ := gocore.Config(alternativeContext...).GetInt(key, defaultValue)
+	value, _ := gocore.Config(alternativeContext...).GetInt(key, profileDefault(key, defaultValue, strconv.Atoi, alternativeContext...))
 
 	return value
 }
 
 func getInt32(key string, defaultValue int32, alternativeContext ...string) int32 {
-	value, _ := gocore.Config(alternativeContext...).GetInt32(key, defaultValue)
+	value, _ := gocore.Config(alternativeContext...).GetInt32(key, profileDefault(key, defaultValue, parseInt32, alternativeContext...))
 
 	return value
 }
 
 func getUint32(key string, defaultValue uint32, alternativeContext ...string) uint32 {
-	value, _ := gocore.Config(alternativeContext...).GetUint32(key, defaultValue)
+	value, _ := gocore.Config(alternativeContext...).GetUint32(key, profileDefault(key, defaultValue, parseUint32, alternativeContext...))
 
 	return value
 }
 
 func getUint64(key string, defaultValue uint64, alternativeContext ...string) uint64 {
-	value, _ := gocore.Config(alternativeContext...).GetUint64(key, defaultValue)
+	value, _ := gocore.Config(alternativeContext...).GetUint64(key, profileDefault(key, defaultValue, parseUint64, alternativeContext...))
 
 	return value
 }
 
 func getURL(key string, defaultValue string, alternativeContext ...string) *url.URL {
-	value, _, _ := gocore.Config(alternativeContext...).GetURL(key, defaultValue)
+	value, _, _ := gocore.Config(alternativeContext...).GetURL(key, profileDefault(key, defaultValue, parseString, alternativeContext...))
 
 	return value
 }
 
 func getBool(key string, defaultValue bool, alternativeContext ...string) bool {
-	return gocore.Config(alternativeContext...).GetBool(key, defaultValue)
+	return gocore.Config(alternativeContext...).GetBool(key, profileDefault(key, defaultValue, strconv.ParseBool, alternativeContext...))
 }
 
 func getFloat64(key string, defaultValue float64, alternativeContext ...string) float64 {
-	value, _ := gocore.Config(alternativeContext...).GetFloat64(key, defaultValue)
+	value, _ := gocore.Config(alternativeContext...).GetFloat64(key, profileDefault(key, defaultValue, parseFloat64, alternativeContext...))
 
 	return value
 }
 
 func getDuration(key string, defaultValue time.Duration, alternativeContext ...string) time.Duration {
-	d, err, _ := gocore.Config(alternativeContext...).GetDuration(key, defaultValue)
+	d, err, _ := gocore.Config(alternativeContext...).GetDuration(key, profileDefault(key, defaultValue, time.ParseDuration, alternativeContext...))
 	if err != nil {
 		panic(err)
 	}
@@ -19,6 +19,7 @@ type Settings struct {
 	Context                      string
 	IsAllInOneMode               bool // Runtime-computed: true if daemon is running multiple services in a single process
 	ServiceName                  string
+	Profile                      string // Built-in settings profile providing the defaults, see ProfileNames
 	TracingEnabled               bool
 	TracingSampleRate            float64
 	TracingCollectorURL          *url.URL
@@ -29,6 +30,7 @@ type Settings struct {
 	ServerKeyFile                string
 	Logger                       string
 	LogLevel                     string
+	LogLevelComponents           []string // Log levels of individual logger components, as component=LEVEL entries
 	PrettyLogs                   bool
 	JSONLogging                  bool
 	ProfilerAddr                 string
@@ -42,9 +44,14 @@ type Settings struct {
 	GRPCResolver                 string
 	GRPCMaxRetries               int
 	GRPCRetryBackoff             time.Duration
+	GRPCKeepaliveMinTime         time.Duration
 	SecurityLevelGRPC            int
 	UsePrometheusGRPCMetrics     bool
 	GRPCAdminAPIKey              string
+	GRPCAuthClientCert           bool     // Protected gRPC methods accept a verified client certificate instead of the API key
+	GRPCRateLimits               []string // Requests per second of gRPC methods or services, as /package.Service[/Method]=rate entries
+	MaintenanceJobJitter         float64  // Fraction of the interval of a maintenance job its runs are moved by at random
+	AuditLogRateLimit            int      // Audit records persisted per actor per minute, 0 for no limit
 	ChainCfgParams               *chaincfg.Params
 	Policy                       *PolicySettings
 	Kafka                        KafkaSettings
@@ -67,6 +74,8 @@ type Settings struct {
 	RPC                          RPCSettings
 	Faucet                       FaucetSettings
 	Dashboard                    DashboardSettings
+	Bandwidth                    BandwidthSettings
+	Canary                       CanarySettings
 	GlobalBlockHeightRetention   uint32
 }
 
@@ -99,10 +108,28 @@ type DashboardSettings struct {
 	WebSocketPath  string // WebSocket path (e.g., "/connection/websocket")
 }
 
+type BandwidthSettings struct {
+	Limit         uint64  // total bandwidth budget in bytes per second shared by catchup, subtree and DataHub traffic, 0 is unlimited
+	CatchupWeight float64 // relative share of catchup block and subtree fetches
+	SubtreeWeight float64 // relative share of subtree fetches for block propagation
+	DataHubWeight float64 // relative share of DataHub serving responses
+}
+
+// CanarySettings configure the canary mode, in which a node validates blocks and computes all state transitions
+// without serving its DataHub to peers or announcing blocks, and compares its chain with a reference node
+type CanarySettings struct {
+	Enabled         bool          // run the node as canary, forces the listen_only P2P listen mode
+	ReferenceURL    string        // asset API base URL of the reference node, e.g. https://reference:8090/api/v1
+	CheckInterval   time.Duration // interval at which the chain tip is compared with the reference node
+	MaxLag          uint32        // number of blocks the node may trail the reference node before an alert is raised
+	AlertWebhookURL string        // URL the alerts are posted to as JSON, alerts are only logged when empty
+}
+
 type KafkaSettings struct {
 	Blocks                string
 	BlocksFinal           string
 	BlocksValidate        string
+	DoubleSpends          string
 	Hosts                 string
 	InvalidBlocks         string
 	InvalidSubtrees       string
@@ -123,6 +150,7 @@ type KafkaSettings struct {
 	InvalidSubtreesConfig *url.URL
 	SubtreesConfig        *url.URL
 	BlocksConfig          *url.URL
+	DoubleSpendsConfig    *url.URL
 	// TLS settings
 	EnableTLS     bool
 	TLSSkipVerify bool
@@ -131,6 +159,13 @@ type KafkaSettings struct {
 	TLSKeyFile    string
 	// Debug logging
 	EnableDebugLogging bool
+	// Producer delivery guarantees
+	ProducerIdempotent  bool
+	SpoolDir            string
+	SpoolMaxMessages    int
+	SpoolReplayInterval time.Duration
+	// Highest version Kafka messages are produced at, 0 produces messages without envelope for nodes before versioning
+	MessageVersion uint32
 }
 
 type AerospikeSettings struct {
@@ -164,9 +199,27 @@ type AssetSettings struct {
 	HTTPAddress             string
 	HTTPPublicAddress       string
 	HTTPListenAddress       string
+	HTTPListenAddresses     []string // additional addresses the HTTP server listens on, e.g. an internal interface
 	HTTPPort                int
 	SignHTTPResponses       bool
 	EchoDebug               bool
+	// HTTP server connection handling
+	HTTPMaxConnections  int           // Maximum concurrent HTTP connections, 0 is unlimited
+	HTTPIdleTimeout     time.Duration // Time a keep-alive connection may stay idle before it is closed
+	HTTPShutdownTimeout time.Duration // Time in-flight requests are given to complete on shutdown
+	// Catchup serving limits: the blocks and the bytes of blocks, subtrees and subtree data served to a single peer,
+	// identified by its peer ID header, per hour. A limit of 0 is unlimited. Peers with a reputation of at least
+	// CatchupServeTrustedReputation get CatchupServeTrustedMultiplier times the limits. Pinned peers, the P2P static
+	// peers and the CatchupServePinnedPeers, are not limited.
+	CatchupServeMaxBytesPerHour   uint64
+	CatchupServeMaxBlocksPerHour  uint64
+	CatchupServeTrustedReputation float64
+	CatchupServeTrustedMultiplier float64
+	CatchupServePinnedPeers       []string
+	// Response cache of the peer registry, block header and catchup status endpoints: responses are cached for
+	// ResponseCacheTTL, 0 disables the cache, up to ResponseCacheMaxEntries request URIs
+	ResponseCacheTTL        time.Duration
+	ResponseCacheMaxEntries int
 }
 
 type BlockSettings struct {
@@ -210,6 +263,17 @@ type BlockChainSettings struct {
 	FSMStateChangeDelay   time.Duration // used by tests to delay the state change and have time to capture the state
 	StoreDBTimeoutMillis  int
 	InitializeNodeInState string
+	Checkpoints           []string // additional hard checkpoints in height:hash format
+	CheckpointsURL        string   // optional URL of a signed remote checkpoint file
+	CheckpointsPublicKey  string   // hex encoded public key the remote checkpoint file is signed with
+
+	// read replicas
+	StoreReadReplicas               []string      // URLs of read replicas of the store, read-only lookups are routed to
+	StoreReplicaHealthCheckInterval time.Duration // how often read replicas are checked, unhealthy replicas are skipped until they recover
+
+	// prepared statements and slow queries
+	StorePreparedStatements bool          // prepare hot-path lookups once and reuse the statements
+	StoreSlowQueryThreshold time.Duration // lookups slower than this are logged, 0 disables the log
 }
 
 type BlockAssemblySettings struct {
@@ -246,6 +310,16 @@ type BlockAssemblySettings struct {
 	// GetMiningCandidate timeouts
 	GetMiningCandidateSendTimeout     time.Duration // Timeout when sending request on internal channel (default: 1s)
 	GetMiningCandidateResponseTimeout time.Duration // Timeout waiting for mining candidate response (default: 10s)
+	// Arrival rate subtree sizing
+	ArrivalRateSubtreeSize    bool          // Size subtrees to the transaction arrival rate, replaces UseDynamicSubtreeSize
+	SubtreeTargetFillInterval time.Duration // Time a subtree should take to fill at the current arrival rate (default: 1s)
+	ArrivalRateWindow         time.Duration // Time over which a drop of the arrival rate is smoothed (default: 10s)
+	// Priority lanes
+	OperatorLaneQuota      int // Percentage of each subtree reserved ahead of other transactions for operator tagged transactions, 0 disables the lane
+	ConsolidationLaneQuota int // Percentage of each subtree reserved ahead of other transactions for consolidation transactions, 0 disables the lane
+	// Coinbase template, replaces the addresses of the miner wallet keys when outputs are configured
+	CoinbaseOutputs  []string // Payout addresses of the coinbase as address:percentage entries, the percentages must add up to 100
+	CoinbaseMinerTag string   // Optional miner tag written to an OP_RETURN output of the coinbase
 }
 
 type BlockValidationSettings struct {
@@ -254,6 +328,8 @@ type BlockValidationSettings struct {
 	GRPCAddress                               string
 	GRPCListenAddress                         string
 	KafkaWorkers                              int
+	KafkaPauseThreshold                       int // pause the blocks consumer when the block queues exceed this size, 0 disables
+	KafkaResumeThreshold                      int // resume the blocks consumer once the block queues drop to this size
 	LocalSetTxMinedConcurrency                int
 	MaxPreviousBlockHeadersToCheck            uint64
 	MissingTransactionsBatchSize              int
@@ -291,15 +367,48 @@ type BlockValidationSettings struct {
 	CatchupIterationTimeout      int // Timeout in seconds for each catchup iteration
 	CatchupOperationTimeout      int // Timeout in seconds for the entire catchup operation
 	CatchupMaxAccumulatedHeaders int // Maximum headers to accumulate during catchup (default: 100000)
+	// Ordering of the peers tried for catchup
+	CatchupPeerSelector            string  // Strategy: p2p, best_score, weighted_random, round_robin_top_k or locality_aware (default: p2p)
+	CatchupPeerSelectorTopK        int     // Number of best peers taking turns in round_robin_top_k (default: 3)
+	CatchupPeerSelectorExploration float64 // Share (0-1) of the weight spread evenly across the peers in weighted_random (default: 0.1)
+	// Catchup cross-check of the headers of the catchup peer against other peers
+	CatchupCrossCheckPeers         int     // Number of other peers the catchup headers are cross-checked against, 0 disables (max 2)
+	CatchupCrossCheckMinReputation float64 // Lowest reputation score of a peer used to cross-check the headers (default: 60)
+	// Spot-check of the blocks and subtrees served by catchup peers with little history against a trusted peer
+	SpotCheckSampleSize           int     // Number of blocks of a catchup re-fetched from a trusted peer, 0 disables
+	SpotCheckNewPeerInteractions  int64   // Peers with fewer successful interactions than this are spot-checked (default: 10)
+	SpotCheckTrustedMinReputation float64 // Lowest reputation score of the trusted peer the data is compared with (default: 80)
+	// Merkle proof spot-check of the transactions of catchup blocks before the blocks are validated
+	MerkleSpotCheckFraction float64 // Share (0-1) of the transactions of each catchup block proven against its merkle root, 0 disables
+	// Integrity report of a completed catchup against the chains of other peers
+	CatchupIntegrityCheckPeers int // Number of other peers asked for the blocks of a completed catchup, 0 disables (max 5)
+	CatchupIntegritySampleSize int // Number of synced heights the other peers are asked for (default: 5)
+	// Sanity check of the header of an announced block before the block is downloaded
+	AnnouncedHeaderCheck bool // Reject announced blocks with a bogus header and add to the ban score of the peer (default: true)
+	// Orphan pool of blocks arriving before their parent
+	OrphanPoolSize     int           // Blocks held until their parent arrives, the oldest is evicted to catchup when full, 0 disables the pool
+	OrphanMaxAge       time.Duration // Longest a block is held for its parent before it is handed to catchup (default: 2m)
+	OrphanMaxHeightGap int           // Blocks further ahead of the best block are handed to catchup instead of held (default: 6)
 	// Circuit breaker configuration
 	CircuitBreakerFailureThreshold int // Number of consecutive failures before opening circuit
 	CircuitBreakerSuccessThreshold int // Number of consecutive successes before closing circuit
 	CircuitBreakerTimeoutSeconds   int // Timeout in seconds before transitioning from open to half-open
+	// Per-peer in-flight request limits for block and subtree fetches
+	PeerMinInFlightRequests  int           // Lowest in-flight limit a peer is backed off to
+	PeerMaxInFlightRequests  int           // Highest in-flight limit a peer can grow to, 0 disables the limits
+	PeerRequestLatencyTarget time.Duration // Fetches slower than this shrink the peer's in-flight limit
+	PeerRequestQueueTimeout  time.Duration // Longest a fetch waits for a free in-flight slot of the peer, 0 waits until the fetch is cancelled
 	// Block fetching configuration
 	FetchLargeBatchSize     int // Large batches for maximum HTTP efficiency (default: 100, peer limit)
 	FetchNumWorkers         int // Number of worker goroutines for parallel processing (default: 16)
 	FetchBufferSize         int // Buffer size for channels (default: 50)
 	SubtreeFetchConcurrency int // Concurrent subtree fetches per block (default: 8)
+	// Adaptive block fetch batch size, FetchLargeBatchSize is the largest batch requested
+	FetchAdaptiveBatchSize   bool          // Tune the blocks requested per round to each peer's response time and payload size
+	FetchMinBatchSize        int           // Fewest blocks requested per round when adapting (default: 10)
+	FetchBatchTargetDuration time.Duration // Round duration the batch size is tuned towards (default: 10s)
+	FetchMaxBatchBytes       int           // Largest response payload the batch size is tuned towards (default: 64MB)
+	FetchStreaming           bool          // Decode block batches and validate subtree data while it is read, instead of buffering whole responses
 	// Transaction extension timeout
 	ExtendTransactionTimeout time.Duration // Timeout for extending transactions (default: 120s)
 	// Concurrency limits
@@ -326,6 +435,10 @@ type ValidatorSettings struct {
 	HTTPRateLimit             int
 	KafkaMaxMessageBytes      int // Maximum Kafka message size in bytes for transaction validation
 	UseLocalValidator         bool
+
+	// transaction packages
+	MaxPackageTransactions int
+	MaxPackageSize         int
 }
 
 type RegionSettings struct {
@@ -377,6 +490,13 @@ type UtxoStoreSettings struct {
 	CleanupDeleteBatcherSize                 int // Batch size for record deletions during cleanup
 	CleanupDeleteBatcherDurationMillis       int // Batch duration for record deletions during cleanup (ms)
 	CleanupMaxConcurrentOperations           int // Maximum concurrent operations during cleanup (0 = use connection queue size)
+	// Pruner-specific settings
+	PruneSpentAfterBlocks uint32        // Prune fully spent transactions this many blocks after their last spend (0 = disabled)
+	PruneInterval         time.Duration // Interval between pruning runs
+	PruneBatchSize        int           // Transactions selected per pruning query
+	PruneRateLimit        int           // Maximum transactions pruned per second (0 = unlimited)
+	PruneDryRun           bool          // Only count and log the transactions that would be pruned
+	PruneArchiveStore     *url.URL      // Blob store pruned transactions are archived to before deletion (nil = no archive)
 }
 
 type P2PSettings struct {
@@ -408,9 +528,131 @@ type P2PSettings struct {
 	// Peer persistence (from go-p2p improvements)
 	PeerCacheDir string // Directory for peer cache file (empty = binary directory)
 
+	PeerCacheCompression          string // Gzip compression of the peer registry cache: auto, always or never
+	PeerCacheCompressionThreshold int    // Size in bytes above which the peer registry cache is compressed in auto mode
+
 	BanThreshold int
 	BanDuration  time.Duration
 
+	// BanSweepInterval is how often expired bans are removed from the ban list and the ban manager
+	BanSweepInterval time.Duration
+
+	// RegistryMetricsInterval is how often the peer registry and ban gauges are exported to Prometheus
+	RegistryMetricsInterval time.Duration
+
+	// PeerGCMaxAge is how long a peer with a baseline reputation is kept in the peer registry and its cache file
+	// after it was last seen, 0 keeps all peers. PeerGCInterval is how often the stale peers are removed.
+	PeerGCMaxAge   time.Duration
+	PeerGCInterval time.Duration
+
+	// ProbationDuration is how long a peer stays on probation after its ban expires or is lifted.
+	// Peers on probation are not used for catchup and are re-banned on any new ban score.
+	// Set to 0 to disable probation.
+	ProbationDuration time.Duration
+
+	// CatchupPeerSelection orders the peers returned for catchup: "best" orders them by reputation, "weighted_random"
+	// draws them at random weighted by reputation, spreading catchup traffic across good peers.
+	// CatchupPeerExploration (0-1) is the share of the weight spread evenly across the peers in weighted_random mode.
+	CatchupPeerSelection   string
+	CatchupPeerExploration float64
+
+	// Per-peer bandwidth quotas, accounted over a rolling BandwidthWindow.
+	// A quota of 0 means unlimited. A peer over quota is throttled: its messages are ignored until usage drops.
+	BandwidthWindow    time.Duration
+	DownloadQuotaBytes uint64
+	UploadQuotaBytes   uint64
+
+	// DataHub self-test: periodically fetch a recent block and subtree from our own advertised
+	// DataHub URL. Readiness fails after DataHubSelfTestFailureThreshold consecutive failures.
+	// Set DataHubSelfTestInterval to 0 to disable.
+	DataHubSelfTestInterval         time.Duration
+	DataHubSelfTestFailureThreshold int
+
+	// DataHubMirrorURLs are advertised to peers next to the DataHub URL, which stays the primary URL. Peers fail
+	// over to the mirrors, in order, when a fetch from the primary URL fails.
+	DataHubMirrorURLs []string
+
+	// Peer lifecycle event log, queryable through the GetPeerEvents RPC. PeerEventLogSize is the number of
+	// events kept in memory. When PeerEventLogFile is set, events are also appended to that file and the
+	// most recent events are loaded from it on startup.
+	PeerEventLogSize int
+	PeerEventLogFile string
+
+	// Recording of the topic messages received from the network, for offline replay of incidents. Messages
+	// are appended to MessageRecordFile when set, which is rotated after MessageRecordMaxBytes, keeping
+	// MessageRecordMaxFiles files.
+	MessageRecordFile     string
+	MessageRecordMaxBytes int
+	MessageRecordMaxFiles int
+
+	// Prioritization of block and subtree announcements by the reputation of the relaying peer. Every
+	// priority tier queues at most PriorityQueueSize messages, 0 processes the messages in arrival order.
+	// Peers with a reputation of at least PriorityHighReputation are high priority, peers below
+	// PriorityLowReputation are low priority, all other and unknown peers are normal priority.
+	PriorityQueueSize      int
+	PriorityHighReputation float64
+	PriorityLowReputation  float64
+
+	// Direct messages between cooperating node operators, e.g. announcing a restart. When
+	// OperatorMessagesEnabled is set the node subscribes to OperatorMessageTopic, the messages are encrypted
+	// to the recipient peer, and at most OperatorMessageRateLimit messages per minute are sent to and
+	// accepted from each peer.
+	OperatorMessagesEnabled  bool
+	OperatorMessageTopic     string
+	OperatorMessageRateLimit int
+
+	// Miner identification of the node, announced on MinerIDTopic so the nodes of the network can show which
+	// mining operator a peer belongs to. When MinerIDPrivateKey is set, a WIF or hex encoded secp256k1 key, the
+	// node announces MinerID, the public key of MinerIDPrivateKey and MinerContact, signed with the key, every
+	// MinerIDAnnounceInterval. The announcements of other peers are accepted whether or not this is set.
+	MinerIDTopic            string
+	MinerID                 string
+	MinerIDPrivateKey       string
+	MinerContact            string
+	MinerIDAnnounceInterval time.Duration
+
+	// DataHub health checker: every DataHubHealthCheckInterval the DataHub URL of every peer is probed with a
+	// HEAD request, at most DataHubHealthCheckConcurrency at a time. A peer whose DataHub fails
+	// DataHubHealthCheckFailureThreshold consecutive probes is excluded from catchup until a probe succeeds.
+	// Set DataHubHealthCheckInterval to 0 to disable.
+	DataHubHealthCheckInterval         time.Duration
+	DataHubHealthCheckTimeout          time.Duration
+	DataHubHealthCheckConcurrency      int
+	DataHubHealthCheckFailureThreshold int
+
+	// DataHub identity verifier: every DataHubIdentityCheckInterval the node asks the DataHub URL of every peer
+	// for an identity document signed by the peer key, so a peer can not claim the DataHub of another node.
+	// The checks share the timeout and concurrency of the DataHub health checker. When
+	// RequireVerifiedDataHubURL is set, peers whose DataHub URL is not verified are not used for catchup.
+	// Set DataHubIdentityCheckInterval to 0 to disable.
+	DataHubIdentityCheckInterval time.Duration
+	RequireVerifiedDataHubURL    bool
+
+	// Every PeerRegistryReconcileInterval the connection state of the peers in the registry is compared with the
+	// live connections of the libp2p host and corrected where it drifted. Set to 0 to disable.
+	PeerRegistryReconcileInterval time.Duration
+
+	// The P2P gRPC client pings the P2P service after ClientKeepaliveTime without activity and drops the
+	// connection when the ping is not answered within ClientKeepaliveTimeout. A failed connection is
+	// reconnected with an exponential backoff from ClientReconnectBaseDelay up to ClientReconnectMaxDelay.
+	// Calls wait up to ClientCallWait for the connection to come back, once the P2P service is unavailable
+	// for longer they fail straight away.
+	ClientKeepaliveTime      time.Duration
+	ClientKeepaliveTimeout   time.Duration
+	ClientReconnectBaseDelay time.Duration
+	ClientReconnectMaxDelay  time.Duration
+	ClientCallWait           time.Duration
+
+	// Retention of the artifacts kept on disk, enforced every RetentionInterval, set to 0 to disable. The peer
+	// event log file is rotated after PeerEventLogMaxFileBytes, rotated files are removed after
+	// PeerEventLogMaxAge and, oldest first, while all event log files use more than PeerEventLogMaxBytes.
+	// Message recording files are removed after MessageRecordMaxAge.
+	RetentionInterval        time.Duration
+	PeerEventLogMaxFileBytes int
+	PeerEventLogMaxBytes     int
+	PeerEventLogMaxAge       time.Duration
+	MessageRecordMaxAge      time.Duration
+
 	// Sync manager configuration
 	ForceSyncPeer string // Force sync from specific peer ID, overrides automatic selection
 
@@ -429,10 +671,22 @@ type P2PSettings struct {
 	DHTCleanupInterval time.Duration // Interval for DHT provider record cleanup (default: 24h, only applies to server mode)
 
 	// DisableNAT disables NAT traversal features (UPnP/NAT-PMP port mapping, NAT service, hole punching).
+	// The message bus switches them together, circuit relay stays enabled.
 	// Set to true in test environments where NAT traversal is not needed.
 	// Default: false (NAT features enabled)
 	DisableNAT bool
 
+	// GeoIPCountryDB and GeoIPASNDB are the paths of local MaxMind DB files, e.g. GeoLite2-Country.mmdb and
+	// GeoLite2-ASN.mmdb, the country and ASN of the addresses of peers are looked up in. Empty disables the lookup.
+	GeoIPCountryDB string
+	GeoIPASNDB     string
+
+	// MinProtocolVersion and MaxProtocolVersion are the range of P2P wire protocol versions advertised to and
+	// accepted from peers, 0 uses the baseline and the latest version of the build. Peers without a common
+	// version are marked incompatible and their messages are ignored.
+	MinProtocolVersion uint32
+	MaxProtocolVersion uint32
+
 	// EnableMDNS enables multicast DNS peer discovery on the local network.
 	// IMPORTANT: Only enable on isolated local networks. On shared hosting (e.g., Hetzner, AWS)
 	// without VLANs, mDNS broadcasts appear as network scanning and may result in abuse reports.
@@ -492,14 +746,21 @@ type SubtreeValidationSettings struct {
 	SpendBatcherSize                          int
 	MissingTransactionsBatchSize              int
 	PercentageMissingGetFullData              float64
+	// Fallback for the missing transactions the announcing peer of a subtree cannot provide
+	MissingTransactionsAlternatePeers int     // Number of other peers asked for the missing transactions, 0 disables the fallback (default: 3)
+	AlternatePeerMinReputation        float64 // Lowest reputation score of a peer asked for the missing transactions (default: 60)
 	// BlacklistedBaseURLs is a set of base URLs that are not allowed for subtree validation
 	BlacklistedBaseURLs            map[string]struct{}
 	BlockHeightRetentionAdjustment int32 // Adjustment to GlobalBlockHeightRetention (can be positive or negative)
 	OrphanageTimeout               time.Duration
 	OrphanageMaxSize               int // Maximum number of transactions that can be stored in the orphanage
 	// Concurrency limits
-	CheckBlockSubtreesConcurrency int           // Concurrency limit for CheckBlockSubtrees operations (default: 32)
-	PauseTimeout                  time.Duration // Maximum duration for subtree processing pauses during block validation (default: 5 minutes)
+	CheckBlockSubtreesConcurrency         int           // Subtrees of a block fetched concurrently by CheckBlockSubtrees (default: 32)
+	CheckBlockSubtreesValidateConcurrency int           // Subtrees of a block validated concurrently by CheckBlockSubtrees (default: 32)
+	PauseTimeout                          time.Duration // Maximum duration for subtree processing pauses during block validation (default: 5 minutes)
+	// Subtree Kafka consumer
+	KafkaWorkers        int    // Workers processing the subtree messages of each Kafka partition, 1 processes a partition sequentially (default: 1)
+	KafkaCommitStrategy string // When offsets are committed: "auto" commits on the Kafka auto commit interval, "processed" only commits processed messages (default: auto)
 }
 
 type LegacySettings struct {
@@ -536,6 +797,11 @@ type PropagationSettings struct {
 	SendBatchTimeout     int
 	GRPCAddresses        []string
 	GRPCListenAddress    string
+	// MaxInFlightTransactions is the maximum number of transactions processed at the same time, transactions
+	// received above it are rejected as busy to signal backpressure to the senders, 0 disables the limit
+	MaxInFlightTransactions int
+	// BackpressureRetryAfter is the delay after which the senders of busy transactions are asked to retry
+	BackpressureRetryAfter time.Duration
 }
 
 type RPCSettings struct {
@@ -1,32 +1,34 @@
 package settings
 
 type PolicySettings struct {
-	ExcessiveBlockSize              int     `json:"excessiveblocksize"`
-	BlockMaxSize                    int     `json:"blockmaxsize"`
-	MaxTxSizePolicy                 int     `json:"maxtxsizepolicy"`
-	MaxOrphanTxSize                 int     `json:"maxorphantxsize"`
-	DataCarrierSize                 int64   `json:"datacarriersize"`
-	MaxScriptSizePolicy             int     `json:"maxscriptsizepolicy"`
-	MaxOpsPerScriptPolicy           int64   `json:"maxopsperscriptpolicy"`
-	MaxScriptNumLengthPolicy        int     `json:"maxscriptnumlengthpolicy"`
-	MaxPubKeysPerMultisigPolicy     int64   `json:"maxpubkeyspermultisigpolicy"`
-	MaxTxSigopsCountsPolicy         int64   `json:"maxtxsigopscountspolicy"`
-	MaxStackMemoryUsagePolicy       int     `json:"maxstackmemoryusagepolicy"`
-	MaxStackMemoryUsageConsensus    int     `json:"maxstackmemoryusageconsensus"`
-	LimitAncestorCount              int     `json:"limitancestorcount"`
-	LimitCPFPGroupMembersCount      int     `json:"limitcpfpgroupmemberscount"`
-	AcceptNonStdOutputs             bool    `json:"acceptnonstdoutputs"`
-	DataCarrier                     bool    `json:"datacarrier"`
-	MinMiningTxFee                  float64 `json:"minminingtxfee"`
-	MaxStdTxValidationDuration      int     `json:"maxstdtxvalidationduration"`
-	MaxNonStdTxValidationDuration   int     `json:"maxnonstdtxvalidationduration"`
-	MaxTxChainValidationBudget      int     `json:"maxtxchainvalidationbudget"`
-	ValidationClockCPU              bool    `json:"validationclockcpu"`
-	MinConsolidationFactor          int     `json:"minconsolidationfactor"`
-	MaxConsolidationInputScriptSize int     `json:"maxconsolidationinputscriptsize"`
-	MinConfConsolidationInput       int     `json:"minconfconsolidationinput"`
-	MinConsolidationInputMaturity   int     `json:"minconsolidationinputmaturity"`
-	AcceptNonStdConsolidationInput  bool    `json:"acceptnonstdconsolidationinput"`
+	ExcessiveBlockSize              int      `json:"excessiveblocksize"`
+	BlockMaxSize                    int      `json:"blockmaxsize"`
+	MaxTxSizePolicy                 int      `json:"maxtxsizepolicy"`
+	MaxOrphanTxSize                 int      `json:"maxorphantxsize"`
+	DataCarrierSize                 int64    `json:"datacarriersize"`
+	MaxScriptSizePolicy             int      `json:"maxscriptsizepolicy"`
+	MaxOpsPerScriptPolicy           int64    `json:"maxopsperscriptpolicy"`
+	MaxScriptNumLengthPolicy        int      `json:"maxscriptnumlengthpolicy"`
+	MaxPubKeysPerMultisigPolicy     int64    `json:"maxpubkeyspermultisigpolicy"`
+	MaxTxSigopsCountsPolicy         int64    `json:"maxtxsigopscountspolicy"`
+	MaxStackMemoryUsagePolicy       int      `json:"maxstackmemoryusagepolicy"`
+	MaxStackMemoryUsageConsensus    int      `json:"maxstackmemoryusageconsensus"`
+	LimitAncestorCount              int      `json:"limitancestorcount"`
+	LimitCPFPGroupMembersCount      int      `json:"limitcpfpgroupmemberscount"`
+	AcceptNonStdOutputs             bool     `json:"acceptnonstdoutputs"`
+	DataCarrier                     bool     `json:"datacarrier"`
+	MinMiningTxFee                  float64  `json:"minminingtxfee"`
+	MaxStdTxValidationDuration      int      `json:"maxstdtxvalidationduration"`
+	MaxNonStdTxValidationDuration   int      `json:"maxnonstdtxvalidationduration"`
+	MaxTxChainValidationBudget      int      `json:"maxtxchainvalidationbudget"`
+	ValidationClockCPU              bool     `json:"validationclockcpu"`
+	MinConsolidationFactor          int      `json:"minconsolidationfactor"`
+	MaxConsolidationInputScriptSize int      `json:"maxconsolidationinputscriptsize"`
+	MinConfConsolidationInput       int      `json:"minconfconsolidationinput"`
+	MinConsolidationInputMaturity   int      `json:"minconsolidationinputmaturity"`
+	AcceptNonStdConsolidationInput  bool     `json:"acceptnonstdconsolidationinput"`
+	DustLimit                       uint64   `json:"dustlimit"`
+	DisabledPolicyRules             []string `json:"disabledpolicyrules"`
 }
 
 func NewPolicySettings() *PolicySettings {
@@ -139,6 +141,14 @@ func (ps *PolicySettings) SetAcceptNonStdConsolidationInput(accept bool) {
 	ps.AcceptNonStdConsolidationInput = accept
 }
 
+func (ps *PolicySettings) SetDustLimit(limit uint64) {
+	ps.DustLimit = limit
+}
+
+func (ps *PolicySettings) SetDisabledPolicyRules(rules []string) {
+	ps.DisabledPolicyRules = rules
+}
+
 func (ps *PolicySettings) GetExcessiveBlockSize() int {
 	return ps.ExcessiveBlockSize
 }
@@ -242,3 +252,22 @@ func (ps *PolicySettings) GetMinConsolidationInputMaturity() int {
 func (ps *PolicySettings) GetAcceptNonStdConsolidationInput() bool {
 	return ps.AcceptNonStdConsolidationInput
 }
+
+func (ps *PolicySettings) GetDustLimit() uint64 {
+	return ps.DustLimit
+}
+
+func (ps *PolicySettings) GetDisabledPolicyRules() []string {
+	return ps.DisabledPolicyRules
+}
+
+// IsPolicyRuleDisabled returns true when the transaction acceptance policy rule is disabled
+func (ps *PolicySettings) IsPolicyRuleDisabled(rule string) bool {
+	for _, disabled := range ps.DisabledPolicyRules {
+		if disabled == rule {
+			return true
+		}
+	}
+
+	return false
+}
@@ -40,6 +40,8 @@ func TestNewPolicySettings(t *testing.T) {
 		assert.Equal(t, 0, ps.MinConfConsolidationInput)
 		assert.Equal(t, 0, ps.MinConsolidationInputMaturity)
 		assert.Equal(t, false, ps.AcceptNonStdConsolidationInput)
+		assert.Equal(t, uint64(0), ps.DustLimit)
+		assert.Empty(t, ps.DisabledPolicyRules)
 	})
 }
 
@@ -379,6 +381,27 @@ func TestPolicySettings_ConsolidationSettings(t *testing.T) {
 	})
 }
 
+func TestPolicySettings_PolicyRuleSettings(t *testing.T) {
+	ps := NewPolicySettings()
+
+	t.Run("SetAndGetDustLimit", func(t *testing.T) {
+		testValue := uint64(546)
+		ps.SetDustLimit(testValue)
+		assert.Equal(t, testValue, ps.GetDustLimit())
+	})
+
+	t.Run("SetAndGetDisabledPolicyRules", func(t *testing.T) {
+		assert.False(t, ps.IsPolicyRuleDisabled("dust"))
+
+		ps.SetDisabledPolicyRules([]string{"dust", "minfee"})
+		assert.Equal(t, []string{"dust", "minfee"}, ps.GetDisabledPolicyRules())
+
+		assert.True(t, ps.IsPolicyRuleDisabled("dust"))
+		assert.True(t, ps.IsPolicyRuleDisabled("minfee"))
+		assert.False(t, ps.IsPolicyRuleDisabled("maxtxsize"))
+	})
+}
+
 func TestPolicySettings_FieldIndependence(t *testing.T) {
 	ps := NewPolicySettings()
 
@@ -0,0 +1,216 @@
+package settings
+
+import (
+	"errors" //nolint:depguard // refactor needed to use the internal errors package
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ordishs/gocore"
+)
+
+// settings profiles, selected with the settings_profile setting
+const (
+	ProfileDev      = "dev"      // a developer machine, small caches and little concurrency
+	ProfileSmall    = "small"    // a node on a single modest server
+	ProfileLarge    = "large"    // a node with plenty of memory and cores handling high transaction volumes
+	ProfileArchival = "archival" // a large node that keeps the spent transaction history
+)
+
+// settingsProfiles holds the defaults of the built-in profiles. A profile only replaces the built-in default of
+// a key, a key that is set in the configuration or environment always overrides the value of the profile.
+var settingsProfiles = map[string]map[string]string{
+	ProfileDev: {
+		// cache sizes
+		"txMetaCacheMaxMB":     "64",
+		"blockMinedCacheMaxMB": "32",
+
+		// concurrency limits
+		"filestore_read_concurrency":                                  "64",
+		"filestore_write_concurrency":                                 "32",
+		"blockpersister_concurrency":                                  "2",
+		"blockvalidation_catchupConcurrency":                          "2",
+		"blockvalidation_fetch_num_workers":                           "4",
+		"blockvalidation_subtree_fetch_concurrency":                   "2",
+		"blockvalidation_get_block_transactions_concurrency":          "16",
+		"subtreevalidation_check_block_subtrees_concurrency":          "8",
+		"subtreevalidation_check_block_subtrees_validate_concurrency": "8",
+		"blockassembly_moveBackBlockConcurrency":                      "32",
+		"blockassembly_processRemainderTxHashesConcurrency":           "32",
+
+		// batch sizes
+		"blockvalidation_fetch_large_batch_size":         "20",
+		"subtreevalidation_missingTransactionsBatchSize": "1024",
+		"utxostore_setDAHBatcherSize":                    "64",
+		"utxostore_incrementBatcherSize":                 "64",
+		"utxostore_cleanupDeleteBatcherSize":             "64",
+
+		// retention
+		"global_blockHeightRetention": "10",
+		"utxostore_disableDAHCleaner": "false",
+	},
+	ProfileSmall: {
+		"txMetaCacheMaxMB":     "512",
+		"blockMinedCacheMaxMB": "128",
+
+		"filestore_read_concurrency":                                  "256",
+		"filestore_write_concurrency":                                 "128",
+		"blockpersister_concurrency":                                  "4",
+		"blockvalidation_catchupConcurrency":                          "4",
+		"blockvalidation_fetch_num_workers":                           "8",
+		"blockvalidation_subtree_fetch_concurrency":                   "4",
+		"blockvalidation_get_block_transactions_concurrency":          "32",
+		"subtreevalidation_check_block_subtrees_concurrency":          "16",
+		"subtreevalidation_check_block_subtrees_validate_concurrency": "16",
+		"blockassembly_moveBackBlockConcurrency":                      "128",
+		"blockassembly_processRemainderTxHashesConcurrency":           "128",
+
+		"blockvalidation_fetch_large_batch_size":         "50",
+		"subtreevalidation_missingTransactionsBatchSize": "8192",
+		"utxostore_setDAHBatcherSize":                    "128",
+		"utxostore_incrementBatcherSize":                 "128",
+		"utxostore_cleanupDeleteBatcherSize":             "128",
+
+		"global_blockHeightRetention": "288",
+		"utxostore_disableDAHCleaner": "false",
+	},
+	ProfileLarge: {
+		"txMetaCacheMaxMB":     "8192",
+		"blockMinedCacheMaxMB": "1024",
+
+		"filestore_read_concurrency":                                  "1024",
+		"filestore_write_concurrency":                                 "512",
+		"blockpersister_concurrency":                                  "16",
+		"blockvalidation_catchupConcurrency":                          "16",
+		"blockvalidation_fetch_num_workers":                           "32",
+		"blockvalidation_subtree_fetch_concurrency":                   "16",
+		"blockvalidation_get_block_transactions_concurrency":          "128",
+		"subtreevalidation_check_block_subtrees_concurrency":          "64",
+		"subtreevalidation_check_block_subtrees_validate_concurrency": "64",
+		"blockassembly_moveBackBlockConcurrency":                      "1024",
+		"blockassembly_processRemainderTxHashesConcurrency":           "1024",
+
+		"blockvalidation_fetch_large_batch_size":         "200",
+		"subtreevalidation_missingTransactionsBatchSize": "32768",
+		"utxostore_setDAHBatcherSize":                    "1024",
+		"utxostore_incrementBatcherSize":                 "1024",
+		"utxostore_cleanupDeleteBatcherSize":             "1024",
+
+		"global_blockHeightRetention": "288",
+		"utxostore_disableDAHCleaner": "false",
+	},
+	ProfileArchival: {
+		"txMetaCacheMaxMB":     "8192",
+		"blockMinedCacheMaxMB": "1024",
+
+		"filestore_read_concurrency":                                  "1024",
+		"filestore_write_concurrency":                                 "512",
+		"blockpersister_concurrency":                                  "16",
+		"blockvalidation_catchupConcurrency":                          "16",
+		"blockvalidation_fetch_num_workers":                           "32",
+		"blockvalidation_subtree_fetch_concurrency":                   "16",
+		"blockvalidation_get_block_transactions_concurrency":          "128",
+		"subtreevalidation_check_block_subtrees_concurrency":          "64",
+		"subtreevalidation_check_block_subtrees_validate_concurrency": "64",
+		"blockassembly_moveBackBlockConcurrency":                      "1024",
+		"blockassembly_processRemainderTxHashesConcurrency":           "1024",
+
+		"blockvalidation_fetch_large_batch_size":         "200",
+		"subtreevalidation_missingTransactionsBatchSize": "32768",
+		"utxostore_setDAHBatcherSize":                    "1024",
+		"utxostore_incrementBatcherSize":                 "1024",
+		"utxostore_cleanupDeleteBatcherSize":             "1024",
+
+		// about a year of blocks, spent UTXOs are never deleted
+		"global_blockHeightRetention": "52560",
+		"utxostore_disableDAHCleaner": "true",
+	},
+}
+
+// ProfileNames returns the names of the built-in settings profiles
+func ProfileNames() []string {
+	names := make([]string, 0, len(settingsProfiles))
+	for name := range settingsProfiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// validateProfile returns an error when a profile is set that is not one of the built-in profiles
+func validateProfile(profile string) error {
+	if profile == "" {
+		return nil
+	}
+
+	if _, ok := settingsProfiles[profile]; !ok {
+		return errors.New("unknown settings_profile " + profile + ", expected one of " + strings.Join(ProfileNames(), ", "))
+	}
+
+	return nil
+}
+
+// profileValue returns the value of a key in the selected settings profile
+func profileValue(key string, alternativeContext ...string) (string, bool) {
+	profile, _ := gocore.Config(alternativeContext...).Get("settings_profile", "")
+	if profile == "" {
+		return "", false
+	}
+
+	value, ok := settingsProfiles[profile][key]
+
+	return value, ok
+}
+
+// profileDefault returns the value of a key in the selected settings profile, or defaultValue when the profile
+// does not set the key
+func profileDefault[T any](key string, defaultValue T, parse func(string) (T, error), alternativeContext ...string) T {
+	value, ok := profileValue(key, alternativeContext...)
+	if !ok {
+		return defaultValue
+	}
+
+	parsed, err := parse(value)
+	if err != nil {
+		return defaultValue
+	}
+
+	return parsed
+}
+
+func parseString(value string) (string, error) {
+	return value, nil
+}
+
+func parseInt32(value string) (int32, error) {
+	v, err := strconv.ParseInt(value, 10, 32)
+
+	return int32(v), err //nolint:gosec // parsed with a bit size of 32
+}
+
+func parseUint32(value string) (uint32, error) {
+	v, err := strconv.ParseUint(value, 10, 32)
+
+	return uint32(v), err //nolint:gosec // parsed with a bit size of 32
+}
+
+func parseUint64(value string) (uint64, error) {
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func parseFloat64(value string) (float64, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+func parseMulti(sep string) func(string) ([]string, error) {
+	return func(value string) ([]string, error) {
+		values := strings.Split(value, sep)
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+
+		return values, nil
+	}
+}
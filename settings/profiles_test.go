@@ -0,0 +1,87 @@
+package settings
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ordishs/gocore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileNames(t *testing.T) {
+	assert.Equal(t, []string{ProfileArchival, ProfileDev, ProfileLarge, ProfileSmall}, ProfileNames())
+}
+
+func TestValidateProfile(t *testing.T) {
+	require.NoError(t, validateProfile(""))
+	require.NoError(t, validateProfile(ProfileLarge))
+	require.ErrorContains(t, validateProfile("huge"), "expected one of archival, dev, large, small")
+}
+
+func TestSettingsProfile(t *testing.T) {
+	t.Run("profile replaces the defaults", func(t *testing.T) {
+		gocore.Config().Set("settings_profile", ProfileArchival)
+		defer gocore.Config().Unset("settings_profile")
+
+		tSettings := NewSettings()
+
+		assert.Equal(t, ProfileArchival, tSettings.Profile)
+		assert.Equal(t, 8192, tSettings.SubtreeValidation.TxMetaCacheMaxMB)
+		assert.Equal(t, 1024, tSettings.Block.MinedCacheMaxMB)
+		assert.Equal(t, 1024, tSettings.Block.FileStoreReadConcurrency)
+		assert.Equal(t, 32, tSettings.BlockValidation.FetchNumWorkers)
+		assert.Equal(t, uint32(52560), tSettings.GlobalBlockHeightRetention)
+		assert.Equal(t, uint32(52560), tSettings.UtxoStore.BlockHeightRetention, "derived defaults follow the profile")
+		assert.True(t, tSettings.UtxoStore.DisableDAHCleaner)
+	})
+
+	t.Run("configured keys override the profile", func(t *testing.T) {
+		gocore.Config().Set("settings_profile", ProfileDev)
+		defer gocore.Config().Unset("settings_profile")
+
+		gocore.Config().Set("txMetaCacheMaxMB", "100")
+		defer gocore.Config().Unset("txMetaCacheMaxMB")
+
+		tSettings := NewSettings()
+
+		assert.Equal(t, 100, tSettings.SubtreeValidation.TxMetaCacheMaxMB)
+		assert.Equal(t, 32, tSettings.Block.MinedCacheMaxMB)
+	})
+
+	t.Run("without a profile the built-in defaults apply", func(t *testing.T) {
+		tSettings := NewSettings()
+
+		assert.Empty(t, tSettings.Profile)
+		assert.Equal(t, 768, tSettings.Block.FileStoreReadConcurrency)
+		assert.Equal(t, 16, tSettings.BlockValidation.FetchNumWorkers)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		gocore.Config().Set("settings_profile", "huge")
+		defer gocore.Config().Unset("settings_profile")
+
+		assert.Panics(t, func() { NewSettings() })
+	})
+}
+
+// TestSettingsProfileKeys checks that every profile sets the same keys, that the keys are read by NewSettings
+// and that the shipped settings.conf does not set them, which would hide the value of the profile
+func TestSettingsProfileKeys(t *testing.T) {
+	source, err := os.ReadFile("settings.go")
+	require.NoError(t, err)
+
+	reference := settingsProfiles[ProfileSmall]
+
+	for name, profile := range settingsProfiles {
+		assert.Len(t, profile, len(reference), name)
+
+		for key := range profile {
+			assert.Contains(t, reference, key, "%s sets a key the small profile does not set", name)
+			assert.Contains(t, string(source), `"`+key+`"`, "%s sets key %s that is not read", name, key)
+
+			_, found := gocore.Config().Get(key)
+			assert.False(t, found, "key %s of the profiles is set in the configuration", key)
+		}
+	}
+}
@@ -14,6 +14,11 @@ func NewSettings(alternativeContext ...string) *Settings {
 		settingsContext = alternativeContext[0]
 	}
 
+	profile := getString("settings_profile", "", alternativeContext...)
+	if err := validateProfile(profile); err != nil {
+		panic(err)
+	}
+
 	params, err := chaincfg.GetChainParams(getString("network", "mainnet", alternativeContext...))
 	if err != nil {
 		panic(err)
@@ -33,10 +38,19 @@ func NewSettings(alternativeContext ...string) *Settings {
 
 	blacklistMap := getMultiStringMap("subtreevalidation_blacklisted_baseurls", "|", []string{}, alternativeContext...)
 
+	// a canary node validates blocks but never announces them, nor advertises its DataHub
+	canaryEnabled := getBool("canary_enabled", false, alternativeContext...)
+
+	listenMode := getString("listen_mode", ListenModeFull, alternativeContext...)
+	if canaryEnabled {
+		listenMode = ListenModeListenOnly
+	}
+
 	return &Settings{
 		Commit:                       gocore.GetCommit(),
 		Version:                      gocore.GetVersion(),
 		Context:                      settingsContext,
+		Profile:                      profile,
 		ServiceName:                  getString("SERVICE_NAME", "teranode", alternativeContext...),
 		TracingEnabled:               getBool("tracing_enabled", false, alternativeContext...),
 		TracingSampleRate:            getFloat64("tracing_SampleRate", 0.01, alternativeContext...),
@@ -48,6 +62,7 @@ func NewSettings(alternativeContext ...string) *Settings {
 		ServerKeyFile:                getString("server_keyFile", "", alternativeContext...),
 		Logger:                       getString("logger", "", alternativeContext...),
 		LogLevel:                     getString("logLevel", "INFO", alternativeContext...),
+		LogLevelComponents:           getMultiString("logLevelComponents", "|", []string{}, alternativeContext...),
 		PrettyLogs:                   getBool("prettyLogs", true, alternativeContext...),
 		JSONLogging:                  getBool("jsonLogging", false, alternativeContext...),
 		ProfilerAddr:                 getString("profilerAddr", "", alternativeContext...),
@@ -61,9 +76,14 @@ func NewSettings(alternativeContext ...string) *Settings {
 		GRPCResolver:                 getString("grpc_resolver", "", alternativeContext...),
 		GRPCMaxRetries:               getInt("grpc_max_retries", 40, alternativeContext...),
 		GRPCRetryBackoff:             getDuration("grpc_retry_backoff", 250*time.Millisecond, alternativeContext...),
+		GRPCKeepaliveMinTime:         getDuration("grpc_keepalive_min_time", 10*time.Second, alternativeContext...),
 		SecurityLevelGRPC:            getInt("security_level_grpc", 0, alternativeContext...),
 		UsePrometheusGRPCMetrics:     getBool("use_prometheus_grpc_metrics", true, alternativeContext...),
 		GRPCAdminAPIKey:              getString("grpc_admin_api_key", "", alternativeContext...),
+		GRPCAuthClientCert:           getBool("grpc_auth_client_cert", false, alternativeContext...),
+		GRPCRateLimits:               getMultiString("grpc_rate_limits", "|", []string{}, alternativeContext...),
+		MaintenanceJobJitter:         getFloat64("maintenance_job_jitter", 0.1, alternativeContext...),
+		AuditLogRateLimit:            getInt("audit_log_rate_limit", 60, alternativeContext...),
 		GlobalBlockHeightRetention:   globalBlockHeightRetention,
 
 		ChainCfgParams: params,
@@ -97,10 +117,13 @@ func NewSettings(alternativeContext ...string) *Settings {
 			MinConfConsolidationInput:       getInt("minconfconsolidationinput", 6, alternativeContext...),
 			MinConsolidationInputMaturity:   getInt("minconsolidationinputmaturity", 6, alternativeContext...),
 			AcceptNonStdConsolidationInput:  getBool("acceptnonstdconsolidationinput", false, alternativeContext...),
+			DustLimit:                       getUint64("dustlimit", 1, alternativeContext...),
+			DisabledPolicyRules:             getMultiString("disabledpolicyrules", "|", []string{}, alternativeContext...),
 		},
 		Kafka: KafkaSettings{
 			Blocks:                getString("KAFKA_BLOCKS", "blocks", alternativeContext...),
 			BlocksFinal:           getString("KAFKA_BLOCKS_FINAL", "blocks-final", alternativeContext...),
+			DoubleSpends:          getString("KAFKA_DOUBLE_SPENDS", "double-spends", alternativeContext...),
 			Hosts:                 getString("KAFKA_HOSTS", "localhost:9092", alternativeContext...),
 			InvalidBlocks:         getString("KAFKA_INVALID_BLOCKS", "invalid-blocks", alternativeContext...),
 			InvalidSubtrees:       getString("KAFKA_INVALID_SUBTREES", "invalid-subtrees", alternativeContext...),
@@ -121,6 +144,7 @@ func NewSettings(alternativeContext ...string) *Settings {
 			InvalidSubtreesConfig: getURL("kafka_invalidSubtreesConfig", "", alternativeContext...),
 			SubtreesConfig:        getURL("kafka_subtreesConfig", "", alternativeContext...),
 			BlocksConfig:          getURL("kafka_blocksConfig", "", alternativeContext...),
+			DoubleSpendsConfig:    getURL("kafka_doubleSpendsConfig", "", alternativeContext...),
 			// TLS settings
 			EnableTLS:     getBool("KAFKA_ENABLE_TLS", false, alternativeContext...),
 			TLSSkipVerify: getBool("KAFKA_TLS_SKIP_VERIFY", false, alternativeContext...),
@@ -129,6 +153,12 @@ func NewSettings(alternativeContext ...string) *Settings {
 			TLSKeyFile:    getString("KAFKA_TLS_KEY_FILE", "", alternativeContext...),
 			// Debug logging
 			EnableDebugLogging: getBool("kafka_enable_debug_logging", false, alternativeContext...),
+			// Producer delivery guarantees
+			ProducerIdempotent:  getBool("kafka_producer_idempotent", false, alternativeContext...),
+			SpoolDir:            getString("kafka_spool_dir", "data/kafka_spool", alternativeContext...),
+			SpoolMaxMessages:    getInt("kafka_spool_max_messages", 10_000, alternativeContext...),
+			SpoolReplayInterval: getDuration("kafka_spool_replay_interval", 10*time.Second, alternativeContext...),
+			MessageVersion:      getUint32("kafka_message_version", 1, alternativeContext...),
 		},
 		Aerospike: AerospikeSettings{
 			Debug:                  getBool("aerospike_debug", false, alternativeContext...),
@@ -159,9 +189,22 @@ func NewSettings(alternativeContext ...string) *Settings {
 			HTTPAddress:             getString("asset_httpAddress", "http://localhost:8090/api/v1", alternativeContext...),
 			HTTPPublicAddress:       getString("asset_httpPublicAddress", "", alternativeContext...),
 			HTTPListenAddress:       getString("asset_httpListenAddress", ":8090", alternativeContext...),
+			HTTPListenAddresses:     getMultiString("asset_httpListenAddresses", "|", []string{}, alternativeContext...),
 			HTTPPort:                getPort("ASSET_HTTP_PORT", 8090, alternativeContext...),
 			SignHTTPResponses:       getBool("asset_sign_http_responses", false, alternativeContext...),
 			EchoDebug:               getBool("ECHO_DEBUG", false, alternativeContext...),
+			HTTPMaxConnections:      getInt("asset_http_max_connections", 0, alternativeContext...),
+			HTTPIdleTimeout:         getDuration("asset_http_idle_timeout", 2*time.Minute, alternativeContext...),
+			HTTPShutdownTimeout:     getDuration("asset_http_shutdown_timeout", 30*time.Second, alternativeContext...),
+
+			CatchupServeMaxBytesPerHour:   getUint64("asset_catchup_serve_max_bytes_per_hour", 0, alternativeContext...),
+			CatchupServeMaxBlocksPerHour:  getUint64("asset_catchup_serve_max_blocks_per_hour", 0, alternativeContext...),
+			CatchupServeTrustedReputation: getFloat64("asset_catchup_serve_trusted_reputation", 80, alternativeContext...),
+			CatchupServeTrustedMultiplier: getFloat64("asset_catchup_serve_trusted_multiplier", 4, alternativeContext...),
+			CatchupServePinnedPeers:       getMultiString("asset_catchup_serve_pinned_peers", "|", []string{}, alternativeContext...),
+
+			ResponseCacheTTL:        getDuration("asset_response_cache_ttl", time.Second, alternativeContext...),
+			ResponseCacheMaxEntries: getInt("asset_response_cache_max_entries", 1000, alternativeContext...),
 		},
 		Block: BlockSettings{
 			MinedCacheMaxMB:                       getInt("blockMinedCacheMaxMB", 256, alternativeContext...),
@@ -226,6 +269,16 @@ func NewSettings(alternativeContext ...string) *Settings {
 			// getMiningCandidate timeout settings
 			GetMiningCandidateSendTimeout:     getDuration("blockassembly_getMiningCandidate_send_timeout", 1*time.Second, alternativeContext...),
 			GetMiningCandidateResponseTimeout: getDuration("blockassembly_getMiningCandidate_response_timeout", 10*time.Second, alternativeContext...),
+			// arrival rate subtree sizing settings
+			ArrivalRateSubtreeSize:    getBool("blockassembly_arrivalRateSubtreeSize", false, alternativeContext...),
+			SubtreeTargetFillInterval: getDuration("blockassembly_subtreeTargetFillInterval", 1*time.Second, alternativeContext...),
+			ArrivalRateWindow:         getDuration("blockassembly_arrivalRateWindow", 10*time.Second, alternativeContext...),
+			// priority lane settings
+			OperatorLaneQuota:      getInt("blockassembly_operatorLaneQuota", 0, alternativeContext...),
+			ConsolidationLaneQuota: getInt("blockassembly_consolidationLaneQuota", 0, alternativeContext...),
+			// coinbase template settings
+			CoinbaseOutputs:  getMultiString("blockassembly_coinbaseOutputs", "|", []string{}, alternativeContext...),
+			CoinbaseMinerTag: getString("blockassembly_coinbaseMinerTag", "", alternativeContext...),
 		},
 		BlockChain: BlockChainSettings{
 			GRPCAddress:           getString("blockchain_grpcAddress", "localhost:8087", alternativeContext...),
@@ -238,6 +291,15 @@ func NewSettings(alternativeContext ...string) *Settings {
 			FSMStateChangeDelay:   getDuration("fsm_state_change_delay", 0, alternativeContext...),
 			StoreDBTimeoutMillis:  getInt("blockchain_store_dbTimeoutMillis", 5000, alternativeContext...),
 			InitializeNodeInState: getString("blockchain_initializeNodeInState", "", alternativeContext...),
+			Checkpoints:           getMultiString("blockchain_checkpoints", "|", []string{}, alternativeContext...),
+			CheckpointsURL:        getString("blockchain_checkpoints_url", "", alternativeContext...),
+			CheckpointsPublicKey:  getString("blockchain_checkpoints_public_key", "", alternativeContext...),
+
+			StoreReadReplicas:               getMultiString("blockchain_store_read_replicas", "|", []string{}, alternativeContext...),
+			StoreReplicaHealthCheckInterval: getDuration("blockchain_store_replica_health_check_interval", 10*time.Second, alternativeContext...),
+
+			StorePreparedStatements: getBool("blockchain_store_prepared_statements", true, alternativeContext...),
+			StoreSlowQueryThreshold: getDuration("blockchain_store_slow_query_threshold", 0, alternativeContext...),
 		},
 		BlockValidation: BlockValidationSettings{
 			MaxRetries:                                getInt("blockV	alidationMaxRetries", 3, alternativeContext...),
@@ -245,6 +307,8 @@ func NewSettings(alternativeContext ...string) *Settings {
 			GRPCAddress:                               getString("blockvalidation_grpcAddress", "localhost:8088", alternativeContext...),
 			GRPCListenAddress:                         getString("blockvalidation_grpcListenAddress", ":8088", alternativeContext...),
 			KafkaWorkers:                              getInt("blockvalidation_kafkaWorkers", 0, alternativeContext...),
+			KafkaPauseThreshold:                       getInt("blockvalidation_kafka_pause_threshold", 0, alternativeContext...),
+			KafkaResumeThreshold:                      getInt("blockvalidation_kafka_resume_threshold", 0, alternativeContext...),
 			LocalSetTxMinedConcurrency:                getInt("blockvalidation_localSetTxMinedConcurrency", 8, alternativeContext...),
 			MaxPreviousBlockHeadersToCheck:            getUint64("blockvalidation_maxPreviousBlockHeadersToCheck", 100, alternativeContext...),
 			MissingTransactionsBatchSize:              getInt("blockvalidation_missingTransactionsBatchSize", 5000, alternativeContext...),
@@ -282,12 +346,42 @@ func NewSettings(alternativeContext ...string) *Settings {
 			CatchupIterationTimeout:      getInt("blockvalidation_catchup_iteration_timeout", 30, alternativeContext...),
 			CatchupOperationTimeout:      getInt("blockvalidation_catchup_operation_timeout", 300, alternativeContext...),
 			CatchupMaxAccumulatedHeaders: getInt("blockvalidation_max_accumulated_headers", 100000, alternativeContext...),
+			// Catchup peer selection configuration
+			CatchupPeerSelector:            getString("blockvalidation_catchup_peer_selector", "p2p", alternativeContext...),
+			CatchupPeerSelectorTopK:        getInt("blockvalidation_catchup_peer_selector_top_k", 3, alternativeContext...),
+			CatchupPeerSelectorExploration: getFloat64("blockvalidation_catchup_peer_selector_exploration", 0.1, alternativeContext...),
+			// Catchup cross-check configuration
+			CatchupCrossCheckPeers:         getInt("blockvalidation_catchup_cross_check_peers", 0, alternativeContext...),
+			CatchupCrossCheckMinReputation: getFloat64("blockvalidation_catchup_cross_check_min_reputation", 60, alternativeContext...),
+			// Catchup spot-check configuration
+			SpotCheckSampleSize:           getInt("blockvalidation_spot_check_sample_size", 0, alternativeContext...),
+			SpotCheckNewPeerInteractions:  int64(getInt("blockvalidation_spot_check_new_peer_interactions", 10, alternativeContext...)),
+			SpotCheckTrustedMinReputation: getFloat64("blockvalidation_spot_check_trusted_min_reputation", 80, alternativeContext...),
+			MerkleSpotCheckFraction:       getFloat64("blockvalidation_merkle_spot_check_fraction", 0, alternativeContext...),
+			CatchupIntegrityCheckPeers:    getInt("blockvalidation_catchup_integrity_check_peers", 0, alternativeContext...),
+			CatchupIntegritySampleSize:    getInt("blockvalidation_catchup_integrity_sample_size", 5, alternativeContext...),
+			// Announced block header check configuration
+			AnnouncedHeaderCheck: getBool("blockvalidation_announced_header_check", true, alternativeContext...),
+			// Orphan pool configuration
+			OrphanPoolSize:     getInt("blockvalidation_orphan_pool_size", 100, alternativeContext...),
+			OrphanMaxAge:       getDuration("blockvalidation_orphan_max_age", 2*time.Minute, alternativeContext...),
+			OrphanMaxHeightGap: getInt("blockvalidation_orphan_max_height_gap", 6, alternativeContext...),
 			// Catchup circuit breaker configuration
 			CircuitBreakerFailureThreshold: getInt("blockvalidation_circuit_breaker_failure_threshold", 5, alternativeContext...),
 			CircuitBreakerSuccessThreshold: getInt("blockvalidation_circuit_breaker_success_threshold", 2, alternativeContext...),
 			CircuitBreakerTimeoutSeconds:   getInt("blockvalidation_circuit_breaker_timeout_seconds", 30, alternativeContext...),
+			// Per-peer in-flight request limits
+			PeerMinInFlightRequests:  getInt("blockvalidation_peer_min_in_flight_requests", 1, alternativeContext...),
+			PeerMaxInFlightRequests:  getInt("blockvalidation_peer_max_in_flight_requests", 16, alternativeContext...),
+			PeerRequestLatencyTarget: getDuration("blockvalidation_peer_request_latency_target", 30*time.Second, alternativeContext...),
+			PeerRequestQueueTimeout:  getDuration("blockvalidation_peer_request_queue_timeout", time.Minute, alternativeContext...),
 			// Block fetching configuration
 			FetchLargeBatchSize:             getInt("blockvalidation_fetch_large_batch_size", 100, alternativeContext...),
+			FetchAdaptiveBatchSize:          getBool("blockvalidation_fetch_adaptive_batch_size", true, alternativeContext...),
+			FetchMinBatchSize:               getInt("blockvalidation_fetch_min_batch_size", 10, alternativeContext...),
+			FetchBatchTargetDuration:        getDuration("blockvalidation_fetch_batch_target_duration", 10*time.Second, alternativeContext...),
+			FetchMaxBatchBytes:              getInt("blockvalidation_fetch_max_batch_bytes", 64*1024*1024, alternativeContext...),
+			FetchStreaming:                  getBool("blockvalidation_fetch_streaming", false, alternativeContext...),
 			FetchNumWorkers:                 getInt("blockvalidation_fetch_num_workers", 16, alternativeContext...),
 			FetchBufferSize:                 getInt("blockvalidation_fetch_buffer_size", 50, alternativeContext...),
 			SubtreeFetchConcurrency:         getInt("blockvalidation_subtree_fetch_concurrency", 8, alternativeContext...),
@@ -314,6 +408,9 @@ func NewSettings(alternativeContext ...string) *Settings {
 			HTTPRateLimit:             getInt("validator_httpRateLimit", 1024, alternativeContext...),
 			KafkaMaxMessageBytes:      getInt("validator_kafka_maxMessageBytes", 1024*1024, alternativeContext...), // Default 1MB
 			UseLocalValidator:         getBool("useLocalValidator", false, alternativeContext...),
+			// transaction packages
+			MaxPackageTransactions: getInt("validator_maxPackageTransactions", 25, alternativeContext...),
+			MaxPackageSize:         getInt("validator_maxPackageSize", 10*1024*1024, alternativeContext...),
 		},
 		Region: RegionSettings{
 			Name: getString("regionName", "defaultRegionName", alternativeContext...),
@@ -362,6 +459,13 @@ func NewSettings(alternativeContext ...string) *Settings {
 			CleanupDeleteBatcherSize:                 getInt("utxostore_cleanupDeleteBatcherSize", 256, alternativeContext...),
 			CleanupDeleteBatcherDurationMillis:       getInt("utxostore_cleanupDeleteBatcherDurationMillis", 10, alternativeContext...),
 			CleanupMaxConcurrentOperations:           getInt("utxostore_cleanupMaxConcurrentOperations", 0, alternativeContext...),
+			// Pruner-specific settings, pruning is disabled by default
+			PruneSpentAfterBlocks: getUint32("utxostore_pruneSpentAfterBlocks", 0, alternativeContext...),
+			PruneInterval:         getDuration("utxostore_pruneInterval", 10*time.Minute, alternativeContext...),
+			PruneBatchSize:        getInt("utxostore_pruneBatchSize", 1000, alternativeContext...),
+			PruneRateLimit:        getInt("utxostore_pruneRateLimit", 1000, alternativeContext...),
+			PruneDryRun:           getBool("utxostore_pruneDryRun", false, alternativeContext...),
+			PruneArchiveStore:     getURL("utxostore_pruneArchiveStore", "", alternativeContext...),
 		},
 		P2P: P2PSettings{
 			BlockTopic:         getString("p2p_block_topic", "", alternativeContext...),
@@ -374,7 +478,7 @@ func NewSettings(alternativeContext ...string) *Settings {
 			ListenAddresses:    getMultiString("p2p_listen_addresses", "|", []string{}, alternativeContext...),
 			AdvertiseAddresses: getMultiString("p2p_advertise_addresses", "|", []string{}, alternativeContext...), // This is used to announce the node to the network on a different address than the listen address
 			Port:               getInt("p2p_port", 9906, alternativeContext...),                                   // This is the port that go-p2p-message-bus will listen on but only used when the AdvertiseAddresses are specified
-			ListenMode:         getString("listen_mode", ListenModeFull, alternativeContext...),
+			ListenMode:         listenMode,
 			PeerID:             getString("p2p_peer_id", "", alternativeContext...),
 			PrivateKey:         getString("p2p_private_key", "", alternativeContext...),
 			RejectedTxTopic:    getString("p2p_rejected_tx_topic", "", alternativeContext...),
@@ -382,8 +486,75 @@ func NewSettings(alternativeContext ...string) *Settings {
 			RelayPeers:         getMultiString("p2p_relay_peers", "|", []string{}, alternativeContext...),
 			// Peer persistence
 			PeerCacheDir: getString("p2p_peer_cache_dir", "", alternativeContext...), // Empty = binary directory
-			BanThreshold: getInt("p2p_ban_threshold", 100, alternativeContext...),
-			BanDuration:  getDuration("p2p_ban_duration", 24*time.Hour),
+			// Gzip compression of the peer registry cache, in auto mode above the threshold in bytes
+			PeerCacheCompression:          getString("p2p_peer_cache_compression", "auto", alternativeContext...),
+			PeerCacheCompressionThreshold: getInt("p2p_peer_cache_compression_threshold", 1048576, alternativeContext...),
+			BanThreshold:                  getInt("p2p_ban_threshold", 100, alternativeContext...),
+			BanDuration:                   getDuration("p2p_ban_duration", 24*time.Hour),
+			// Sweep of expired bans
+			BanSweepInterval: getDuration("p2p_ban_sweep_interval", time.Minute, alternativeContext...),
+			// Export of the peer registry and ban gauges
+			RegistryMetricsInterval: getDuration("p2p_registry_metrics_interval", 15*time.Second, alternativeContext...),
+			// Garbage collection of stale peers of the peer registry
+			PeerGCMaxAge:   getDuration("p2p_peer_gc_max_age", 30*24*time.Hour, alternativeContext...),
+			PeerGCInterval: getDuration("p2p_peer_gc_interval", time.Hour, alternativeContext...),
+			// Probation period applied to peers after their ban expires or is lifted
+			ProbationDuration: getDuration("p2p_probation_duration", time.Hour, alternativeContext...),
+			// Order of the peers returned for catchup
+			CatchupPeerSelection:   getString("p2p_catchup_peer_selection", "best", alternativeContext...),
+			CatchupPeerExploration: getFloat64("p2p_catchup_peer_exploration", 0.1, alternativeContext...),
+			// Per-peer bandwidth accounting and quotas
+			BandwidthWindow:    getDuration("p2p_bandwidth_window", time.Hour, alternativeContext...),
+			DownloadQuotaBytes: getUint64("p2p_download_quota_bytes", 0, alternativeContext...),
+			UploadQuotaBytes:   getUint64("p2p_upload_quota_bytes", 0, alternativeContext...),
+			// Self-test of our own advertised DataHub URL
+			DataHubSelfTestInterval:         getDuration("p2p_datahub_self_test_interval", 5*time.Minute, alternativeContext...),
+			DataHubSelfTestFailureThreshold: getInt("p2p_datahub_self_test_failure_threshold", 3, alternativeContext...),
+			// Mirrors of our own DataHub advertised to peers
+			DataHubMirrorURLs: getMultiString("p2p_datahub_mirror_urls", "|", []string{}, alternativeContext...),
+			// Peer lifecycle event log
+			PeerEventLogSize: getInt("p2p_peer_event_log_size", 10000, alternativeContext...),
+			PeerEventLogFile: getString("p2p_peer_event_log_file", "", alternativeContext...),
+			// Topic message recording for offline replay
+			MessageRecordFile:     getString("p2p_message_record_file", "", alternativeContext...),
+			MessageRecordMaxBytes: getInt("p2p_message_record_max_bytes", 100*1024*1024, alternativeContext...),
+			MessageRecordMaxFiles: getInt("p2p_message_record_max_files", 5, alternativeContext...),
+			// Reputation-aware prioritization of block and subtree announcements
+			PriorityQueueSize:      getInt("p2p_priority_queue_size", 1000, alternativeContext...),
+			PriorityHighReputation: getFloat64("p2p_priority_high_reputation", 75, alternativeContext...),
+			PriorityLowReputation:  getFloat64("p2p_priority_low_reputation", 40, alternativeContext...),
+			// Direct messages between node operators
+			OperatorMessagesEnabled:  getBool("p2p_operator_messages_enabled", false, alternativeContext...),
+			OperatorMessageTopic:     getString("p2p_operator_message_topic", "operator_message", alternativeContext...),
+			OperatorMessageRateLimit: getInt("p2p_operator_message_rate_limit", 6, alternativeContext...),
+			// Miner identification announcements
+			MinerIDTopic:            getString("p2p_miner_id_topic", "miner_id", alternativeContext...),
+			MinerID:                 getString("p2p_miner_id", "", alternativeContext...),
+			MinerIDPrivateKey:       getString("p2p_miner_id_private_key", "", alternativeContext...),
+			MinerContact:            getString("p2p_miner_contact", "", alternativeContext...),
+			MinerIDAnnounceInterval: getDuration("p2p_miner_id_announce_interval", 10*time.Minute, alternativeContext...),
+			// Health probing of the DataHub URLs of peers
+			DataHubHealthCheckInterval:         getDuration("p2p_datahub_health_check_interval", time.Minute, alternativeContext...),
+			DataHubHealthCheckTimeout:          getDuration("p2p_datahub_health_check_timeout", 5*time.Second, alternativeContext...),
+			DataHubHealthCheckConcurrency:      getInt("p2p_datahub_health_check_concurrency", 8, alternativeContext...),
+			DataHubHealthCheckFailureThreshold: getInt("p2p_datahub_health_check_failure_threshold", 3, alternativeContext...),
+			// Identity verification of the DataHub URLs of peers
+			DataHubIdentityCheckInterval: getDuration("p2p_datahub_identity_check_interval", 10*time.Minute, alternativeContext...),
+			RequireVerifiedDataHubURL:    getBool("p2p_require_verified_datahub_url", false, alternativeContext...),
+			// Reconciliation of the peer registry with the live libp2p connections
+			PeerRegistryReconcileInterval: getDuration("p2p_peer_registry_reconcile_interval", time.Minute, alternativeContext...),
+			// Keepalive and reconnect of the P2P gRPC client
+			ClientKeepaliveTime:      getDuration("p2p_client_keepalive_time", 30*time.Second, alternativeContext...),
+			ClientKeepaliveTimeout:   getDuration("p2p_client_keepalive_timeout", 10*time.Second, alternativeContext...),
+			ClientReconnectBaseDelay: getDuration("p2p_client_reconnect_base_delay", time.Second, alternativeContext...),
+			ClientReconnectMaxDelay:  getDuration("p2p_client_reconnect_max_delay", 30*time.Second, alternativeContext...),
+			ClientCallWait:           getDuration("p2p_client_call_wait", 5*time.Second, alternativeContext...),
+			// Retention of the artifacts kept on disk
+			RetentionInterval:        getDuration("p2p_retention_interval", 10*time.Minute, alternativeContext...),
+			PeerEventLogMaxFileBytes: getInt("p2p_peer_event_log_max_file_bytes", 16*1024*1024, alternativeContext...),
+			PeerEventLogMaxBytes:     getInt("p2p_peer_event_log_max_bytes", 256*1024*1024, alternativeContext...),
+			PeerEventLogMaxAge:       getDuration("p2p_peer_event_log_max_age", 30*24*time.Hour, alternativeContext...),
+			MessageRecordMaxAge:      getDuration("p2p_message_record_max_age", 7*24*time.Hour, alternativeContext...),
 			// Sync manager configuration
 			ForceSyncPeer:         getString("p2p_force_sync_peer", "", alternativeContext...),
 			NodeStatusTopic:       getString("p2p_node_status_topic", "", alternativeContext...),
@@ -398,6 +569,12 @@ func NewSettings(alternativeContext ...string) *Settings {
 			// Full/pruned node selection configuration
 			AllowPrunedNodeFallback: getBool("p2p_allow_pruned_node_fallback", true, alternativeContext...),
 			DisableNAT:              getBool("p2p_disable_nat", false, alternativeContext...),
+			// GeoIP enrichment
+			GeoIPCountryDB: getString("p2p_geoip_country_db", "", alternativeContext...),
+			GeoIPASNDB:     getString("p2p_geoip_asn_db", "", alternativeContext...),
+			// Protocol version negotiation
+			MinProtocolVersion: getUint32("p2p_min_protocol_version", 0, alternativeContext...),
+			MaxProtocolVersion: getUint32("p2p_max_protocol_version", 0, alternativeContext...),
 		},
 		Coinbase: CoinbaseSettings{
 			DB:                    getString("coinbaseDB", "", alternativeContext...),
@@ -433,18 +610,23 @@ func NewSettings(alternativeContext ...string) *Settings {
 			SubtreeBlockHeightRetention:               getUint32("subtreevalidation_subtreeBlockHeightRetention", globalBlockHeightRetention),
 			SubtreeDAHConcurrency:                     getInt("subtreevalidation_subtreeDAHConcurrency", 8, alternativeContext...),
 			TxMetaCacheEnabled:                        getBool("subtreevalidation_txMetaCacheEnabled", true, alternativeContext...),
-			TxMetaCacheMaxMB:                          getInt("txMetaCacheMaxMB", 256, alternativeContext...),
+			TxMetaCacheMaxMB:                          getInt("txMetaCacheMaxMB", 1024, alternativeContext...),
 			TxChanBufferSize:                          getInt("subtreevalidation_txChanBufferSize", 0, alternativeContext...),
 			BatchMissingTransactions:                  getBool("subtreevalidation_batch_missing_transactions", true, alternativeContext...),
 			SpendBatcherSize:                          getInt("subtreevalidation_spendBatcherSize", 1024, alternativeContext...),
 			MissingTransactionsBatchSize:              getInt("subtreevalidation_missingTransactionsBatchSize", 16_384, alternativeContext...),
 			PercentageMissingGetFullData:              getFloat64("subtreevalidation_percentageMissingGetFullData", 20, alternativeContext...),
+			MissingTransactionsAlternatePeers:         getInt("subtreevalidation_missingTransactionsAlternatePeers", 3, alternativeContext...),
+			AlternatePeerMinReputation:                getFloat64("subtreevalidation_alternatePeerMinReputation", 60, alternativeContext...),
 			BlacklistedBaseURLs:                       blacklistMap,
 			BlockHeightRetentionAdjustment:            getInt32("subtreevalidation_blockHeightRetentionAdjustment", 0, alternativeContext...),
 			OrphanageTimeout:                          getDuration("subtreevalidation_orphanageTimeout", 15*time.Minute, alternativeContext...),
 			OrphanageMaxSize:                          getInt("subtreevalidation_orphanageMaxSize", 100_000, alternativeContext...),
 			CheckBlockSubtreesConcurrency:             getInt("subtreevalidation_check_block_subtrees_concurrency", 32, alternativeContext...),
+			CheckBlockSubtreesValidateConcurrency:     getInt("subtreevalidation_check_block_subtrees_validate_concurrency", 32, alternativeContext...),
 			PauseTimeout:                              getDuration("subtreevalidation_pauseTimeout", 5*time.Minute, alternativeContext...),
+			KafkaWorkers:                              getInt("subtreevalidation_kafka_workers", 1, alternativeContext...),
+			KafkaCommitStrategy:                       getString("subtreevalidation_kafka_commit_strategy", "auto", alternativeContext...),
 		},
 		Legacy: LegacySettings{
 			WorkingDir:                       getString("legacy_workingDir", "../../data", alternativeContext...),
@@ -468,17 +650,19 @@ func NewSettings(alternativeContext ...string) *Settings {
 			PeerProcessingTimeout:            getDuration("legacy_peerProcessingTimeout", 3*time.Minute, alternativeContext...), // processing a block will be the largest message to process
 		},
 		Propagation: PropagationSettings{
-			IPv6Addresses:        getString("ipv6_addresses", "", alternativeContext...),
-			IPv6Interface:        getString("ipv6_interface", "", alternativeContext...),
-			GRPCMaxConnectionAge: getDuration("propagation_grpcMaxConnectionAge", 90*time.Second, alternativeContext...),
-			HTTPListenAddress:    getString("propagation_httpListenAddress", "", alternativeContext...),
-			HTTPAddresses:        getMultiString("propagation_httpAddresses", "|", []string{}, alternativeContext...),
-			HTTPRateLimit:        getInt("propagation_httpRateLimit", 1024, alternativeContext...),
-			AlwaysUseHTTP:        getBool("propagation_alwaysUseHTTP", false, alternativeContext...),
-			SendBatchSize:        getInt("propagation_sendBatchSize", 100, alternativeContext...),
-			SendBatchTimeout:     getInt("propagation_sendBatchTimeout", 5, alternativeContext...),
-			GRPCAddresses:        getMultiString("propagation_grpcAddresses", "|", []string{}, alternativeContext...),
-			GRPCListenAddress:    getString("propagation_grpcListenAddress", "", alternativeContext...),
+			IPv6Addresses:           getString("ipv6_addresses", "", alternativeContext...),
+			IPv6Interface:           getString("ipv6_interface", "", alternativeContext...),
+			GRPCMaxConnectionAge:    getDuration("propagation_grpcMaxConnectionAge", 90*time.Second, alternativeContext...),
+			HTTPListenAddress:       getString("propagation_httpListenAddress", "", alternativeContext...),
+			HTTPAddresses:           getMultiString("propagation_httpAddresses", "|", []string{}, alternativeContext...),
+			HTTPRateLimit:           getInt("propagation_httpRateLimit", 1024, alternativeContext...),
+			AlwaysUseHTTP:           getBool("propagation_alwaysUseHTTP", false, alternativeContext...),
+			SendBatchSize:           getInt("propagation_sendBatchSize", 100, alternativeContext...),
+			SendBatchTimeout:        getInt("propagation_sendBatchTimeout", 5, alternativeContext...),
+			GRPCAddresses:           getMultiString("propagation_grpcAddresses", "|", []string{}, alternativeContext...),
+			GRPCListenAddress:       getString("propagation_grpcListenAddress", "", alternativeContext...),
+			MaxInFlightTransactions: getInt("propagation_maxInFlightTransactions", 10000, alternativeContext...),
+			BackpressureRetryAfter:  getDuration("propagation_backpressureRetryAfter", 500*time.Millisecond, alternativeContext...),
 		},
 		RPC: RPCSettings{
 			RPCUser:           getString("rpc_user", "", alternativeContext...),
@@ -501,6 +685,19 @@ func NewSettings(alternativeContext ...string) *Settings {
 			WebSocketPort:  getString("dashboard_websocketPort", "8090", alternativeContext...),
 			WebSocketPath:  getString("dashboard_websocketPath", "/connection/websocket", alternativeContext...),
 		},
+		Bandwidth: BandwidthSettings{
+			Limit:         getUint64("bandwidth_limit", 0, alternativeContext...),
+			CatchupWeight: getFloat64("bandwidth_weight_catchup", 1, alternativeContext...),
+			SubtreeWeight: getFloat64("bandwidth_weight_subtree", 4, alternativeContext...),
+			DataHubWeight: getFloat64("bandwidth_weight_datahub", 2, alternativeContext...),
+		},
+		Canary: CanarySettings{
+			Enabled:         canaryEnabled,
+			ReferenceURL:    getString("canary_reference_url", "", alternativeContext...),
+			CheckInterval:   getDuration("canary_check_interval", 30*time.Second, alternativeContext...),
+			MaxLag:          getUint32("canary_max_lag", 3, alternativeContext...),
+			AlertWebhookURL: getString("canary_alert_webhook_url", "", alternativeContext...),
+		},
 	}
 }
 
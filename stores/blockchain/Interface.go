@@ -37,6 +37,7 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/blob/file"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/bsv-blockchain/teranode/util/usql"
 )
 
@@ -185,6 +186,17 @@ type Store interface {
 	// Returns: Block ID, height, and any error encountered
 	StoreBlock(ctx context.Context, block *model.Block, peerID string, opts ...options.StoreBlockOption) (ID uint64, height uint32, err error)
 
+	// StoreBlocks stores a contiguous range of blocks in a single operation, e.g. the headers of the chain
+	// imported by a fresh node. Each block must extend the block before it and the parent of the first block
+	// must already be stored. The range is stored atomically.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - blocks: Blocks to store, ordered by height
+	//   - peerID: ID of the peer that provided the blocks
+	//   - opts: Optional store block options, applied to all blocks
+	// Returns: Block IDs in the order of the blocks, and any error encountered
+	StoreBlocks(ctx context.Context, blocks []*model.Block, peerID string, opts ...options.StoreBlockOption) (IDs []uint64, err error)
+
 	// GetBestBlockHeader retrieves the header of the best block in the chain.
 	// Parameters:
 	//   - ctx: Context for the operation
@@ -292,6 +304,22 @@ type Store interface {
 	//   - data: State data to store
 	// Returns: Any error encountered
 	SetState(ctx context.Context, key string, data []byte) error
+
+	// AddAuditRecord appends an audit record of a privileged operation, assigning the ID of the record.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - record: Audit record to append
+	// Returns: Any error encountered
+	AddAuditRecord(ctx context.Context, record *audit.Record) error
+
+	// GetAuditRecords retrieves a page of the audit records, newest first.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - offset: Number of records to skip
+	//   - limit: Maximum number of records to retrieve
+	// Returns: The audit records, the total number of audit records and any error encountered
+	GetAuditRecords(ctx context.Context, offset, limit int) ([]*audit.Record, int, error)
+
 	GetBlockIsMined(ctx context.Context, blockHash *chainhash.Hash) (bool, error)
 
 	// SetBlockMinedSet marks a block as mined.
@@ -24,6 +24,7 @@ import (
 	"github.com/bsv-blockchain/teranode/stores/blob/file"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
 	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/audit"
 	"github.com/bsv-blockchain/teranode/util/usql"
 )
 
@@ -411,6 +412,35 @@ func (m *MockStore) StoreBlock(ctx context.Context, block *model.Block, peerID s
 	return uint64(block.Height), block.Height, nil
 }
 
+// StoreBlocks stores a range of blocks in the in-memory maps.
+// This implements the blockchain.Store.StoreBlocks interface method.
+//
+// The method stores each block with StoreBlock, the contiguity of the range is not checked.
+//
+// Parameters:
+//   - ctx: Context for the operation (unused in this implementation)
+//   - blocks: The block objects to store
+//   - peerID: ID of the peer that provided the blocks (unused in this implementation)
+//   - opts: Optional store block options (unused in this implementation)
+//
+// Returns:
+//   - []uint64: Block IDs (uses block heights as IDs in this implementation)
+//   - error: Always nil in this implementation
+func (m *MockStore) StoreBlocks(ctx context.Context, blocks []*model.Block, peerID string, opts ...options.StoreBlockOption) ([]uint64, error) {
+	ids := make([]uint64, 0, len(blocks))
+
+	for _, block := range blocks {
+		id, _, err := m.StoreBlock(ctx, block, peerID, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 // GetBestBlockHeader retrieves the header of the block at the tip of the best chain.
 // This implements the blockchain.Store.GetBestBlockHeader interface method.
 //
@@ -565,6 +595,14 @@ func (m *MockStore) SetState(ctx context.Context, key string, data []byte) error
 	panic(implementMe)
 }
 
+func (m *MockStore) AddAuditRecord(ctx context.Context, record *audit.Record) error {
+	panic(implementMe)
+}
+
+func (m *MockStore) GetAuditRecords(ctx context.Context, offset, limit int) ([]*audit.Record, int, error) {
+	panic(implementMe)
+}
+
 func (m *MockStore) GetBlockIsMined(ctx context.Context, blockHash *chainhash.Hash) (bool, error) {
 	panic("implement me")
 }
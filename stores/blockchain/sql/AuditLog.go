@@ -0,0 +1,114 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/audit"
+	"github.com/bsv-blockchain/teranode/util/tracing"
+)
+
+// AddAuditRecord appends an audit record of a privileged operation to the audit_log table.
+// The audit log is append-only, records are never updated or deleted by the store. The timestamp of the record is
+// stored as unix milliseconds, which both SQL engines store and compare the same way.
+//
+// Parameters:
+//   - ctx: Context for the database operation, allows for cancellation and timeouts
+//   - record: The audit record to append, its ID is set to the ID assigned by the database
+//
+// Returns:
+//   - error: Any error encountered while appending the record
+func (s *SQL) AddAuditRecord(ctx context.Context, record *audit.Record) error {
+	ctx, _, deferFn := tracing.Tracer("blockchain").Start(ctx, "sql:AddAuditRecord")
+	defer deferFn()
+
+	q := `
+		INSERT INTO audit_log (recorded_at, actor, action, target, details, dropped)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	if err := s.db.QueryRowContext(ctx, q,
+		record.Timestamp.UnixMilli(),
+		record.Actor,
+		record.Action,
+		record.Target,
+		record.Details,
+		record.Dropped,
+	).Scan(&record.ID); err != nil {
+		return errors.NewStorageError("failed to insert audit record", err)
+	}
+
+	return nil
+}
+
+// GetAuditRecords retrieves a page of the audit records, newest first.
+//
+// Parameters:
+//   - ctx: Context for the database operation, allows for cancellation and timeouts
+//   - offset: The number of records to skip
+//   - limit: The maximum number of records to return
+//
+// Returns:
+//   - []*audit.Record: The audit records of the page
+//   - int: The total number of audit records
+//   - error: Any error encountered during retrieval
+func (s *SQL) GetAuditRecords(ctx context.Context, offset, limit int) ([]*audit.Record, int, error) {
+	ctx, _, deferFn := tracing.Tracer("blockchain").Start(ctx, "sql:GetAuditRecords")
+	defer deferFn()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var total int
+
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_log`).Scan(&total); err != nil {
+		return nil, 0, errors.NewStorageError("failed to count audit records", err)
+	}
+
+	q := `
+		SELECT id, recorded_at, actor, action, target, details, dropped
+		FROM audit_log
+		ORDER BY id DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, limit, offset)
+	if err != nil {
+		return nil, 0, errors.NewStorageError("failed to get audit records", err)
+	}
+
+	defer rows.Close()
+
+	records := make([]*audit.Record, 0, limit)
+
+	for rows.Next() {
+		var (
+			record     audit.Record
+			recordedAt int64
+		)
+
+		if err = rows.Scan(
+			&record.ID,
+			&recordedAt,
+			&record.Actor,
+			&record.Action,
+			&record.Target,
+			&record.Details,
+			&record.Dropped,
+		); err != nil {
+			return nil, 0, errors.NewStorageError("failed to scan audit record", err)
+		}
+
+		record.Timestamp = time.UnixMilli(recordedAt)
+
+		records = append(records, &record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, errors.NewStorageError("failed to read audit records", err)
+	}
+
+	return records, total, nil
+}
@@ -0,0 +1,65 @@
+package sql
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/audit"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLAuditLog(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+
+	storeURL, err := url.Parse("sqlitememory:///")
+	require.NoError(t, err)
+
+	s, err := New(ulogger.TestLogger{}, storeURL, tSettings)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+
+	records, total, err := s.GetAuditRecords(context.Background(), 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.Equal(t, 0, total)
+
+	recordedAt := time.UnixMilli(time.Now().UnixMilli())
+
+	for _, target := range []string{"peer1", "peer2", "peer3"} {
+		record := &audit.Record{
+			Timestamp: recordedAt,
+			Actor:     "admin@127.0.0.1",
+			Action:    audit.ActionBan,
+			Target:    target,
+			Details:   "until=1700000000",
+		}
+
+		require.NoError(t, s.AddAuditRecord(context.Background(), record))
+		assert.NotZero(t, record.ID)
+	}
+
+	records, total, err = s.GetAuditRecords(context.Background(), 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "peer3", records[0].Target, "newest record first")
+	assert.Equal(t, "peer2", records[1].Target)
+	assert.Equal(t, "admin@127.0.0.1", records[0].Actor)
+	assert.Equal(t, audit.ActionBan, records[0].Action)
+	assert.True(t, recordedAt.Equal(records[0].Timestamp))
+
+	records, total, err = s.GetAuditRecords(context.Background(), 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, records, 1)
+	assert.Equal(t, "peer1", records[0].Target)
+}
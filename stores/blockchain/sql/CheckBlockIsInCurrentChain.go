@@ -131,7 +131,7 @@ func (s *SQL) CheckBlockIsInCurrentChain(ctx context.Context, blockIDs []uint32)
 	// Execute the query
 	var result bool
 
-	err = s.db.QueryRowContext(ctx, q, args...).Scan(&result)
+	err = s.queryRowContext(ctx, q, args...).Scan(&result)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
@@ -147,7 +147,7 @@ func (s *SQL) GetBestBlockHeader(ctx context.Context) (*model.BlockHeader, *mode
 		err            error
 	)
 
-	if err = s.db.QueryRowContext(ctx, q).Scan(
+	if err = s.queryRowContext(ctx, q).Scan(
 		&blockHeaderMeta.ID,
 		&blockHeader.Version,
 		&blockHeader.Timestamp,
@@ -139,7 +139,7 @@ func (s *SQL) GetBlock(ctx context.Context, blockHash *chainhash.Hash) (*model.B
 		err              error
 	)
 
-	if err = s.db.QueryRowContext(ctx, q, blockHash[:]).Scan(
+	if err = s.readQueryRowContext(ctx, q, blockHash[:]).Scan(
 		&block.ID,
 		&block.Header.Version,
 		&block.Header.Timestamp,
@@ -142,7 +142,7 @@ func (s *SQL) GetBlockByHeight(ctx context.Context, height uint32) (*model.Block
 		)
 	`
 
-	rows, err := s.db.QueryContext(ctx, q, height)
+	rows, err := s.queryContext(ctx, q, height)
 	if err != nil {
 		return nil, errors.NewStorageError("failed to get block by height", err)
 	}
@@ -88,7 +88,7 @@ func (s *SQL) GetBlockExists(ctx context.Context, blockHash *chainhash.Hash) (bo
 	`
 
 	var height uint32
-	if err := s.db.QueryRowContext(ctx, q, blockHash[:]).Scan(
+	if err := s.readQueryRowContext(ctx, q, blockHash[:]).Scan(
 		&height,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
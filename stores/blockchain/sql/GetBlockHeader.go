@@ -150,7 +150,7 @@ func (s *SQL) GetBlockHeader(ctx context.Context, blockHash *chainhash.Hash) (*m
 		err            error
 	)
 
-	if err = s.db.QueryRowContext(ctx, q, blockHash[:]).Scan(
+	if err = s.readQueryRowContext(ctx, q, blockHash[:]).Scan(
 		&blockHeaderMeta.ID,
 		&blockHeader.Version,
 		&blockHeader.Timestamp,
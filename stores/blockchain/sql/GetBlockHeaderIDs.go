@@ -129,7 +129,7 @@ func (s *SQL) GetBlockHeaderIDs(ctx context.Context, blockHashFrom *chainhash.Ha
 		SELECT id FROM ChainBlocks
 		LIMIT $2
 	`
-	rows, err := s.db.QueryContext(ctx, q, blockHashFrom[:], numberOfHeaders)
+	rows, err := s.queryContext(ctx, q, blockHashFrom[:], numberOfHeaders)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
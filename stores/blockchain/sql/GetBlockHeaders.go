@@ -132,7 +132,7 @@ func (s *SQL) GetBlockHeaders(ctx context.Context, blockHashFrom *chainhash.Hash
 		ORDER BY height DESC
 	`
 
-	rows, err := s.db.QueryContext(ctx, q, blockHashFrom[:], numberOfHeaders)
+	rows, err := s.queryContext(ctx, q, blockHashFrom[:], numberOfHeaders)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return []*model.BlockHeader{}, []*model.BlockHeaderMeta{}, nil
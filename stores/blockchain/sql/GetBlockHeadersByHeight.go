@@ -130,7 +130,7 @@ func (s *SQL) GetBlockHeadersByHeight(ctx context.Context, startHeight, endHeigh
 			)
 		)
 	`
-	rows, err := s.db.QueryContext(ctx, q, startHeight, endHeight)
+	rows, err := s.queryContext(ctx, q, startHeight, endHeight)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return blockHeaders, blockMetas, nil
@@ -118,7 +118,7 @@ func (s *SQL) GetBlockHeadersFromHeight(ctx context.Context, height, limit uint3
 		ORDER BY height DESC
 	`
 
-	rows, err := s.db.QueryContext(ctx, q, height, height+limit)
+	rows, err := s.queryContext(ctx, q, height, height+limit)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return blockHeaders, blockMetas, nil
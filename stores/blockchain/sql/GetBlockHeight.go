@@ -66,7 +66,7 @@ func (s *SQL) GetBlockHeight(ctx context.Context, blockHash *chainhash.Hash) (ui
 
 	var height uint32
 
-	if err := s.db.QueryRowContext(ctx, q, blockHash[:]).Scan(
+	if err := s.queryRowContext(ctx, q, blockHash[:]).Scan(
 		&height,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
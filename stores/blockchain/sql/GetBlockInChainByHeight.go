@@ -119,7 +119,7 @@ func (s *SQL) GetBlockInChainByHeightHash(ctx context.Context, height uint32, st
 		nBits            []byte
 	)
 
-	if err = s.db.QueryRowContext(ctx, q, height, startHash.CloneBytes()).Scan(
+	if err = s.queryRowContext(ctx, q, height, startHash.CloneBytes()).Scan(
 		&block.ID,
 		&block.Header.Version,
 		&block.Header.Timestamp,
@@ -72,7 +72,7 @@ func (s *SQL) GetBlockIsMined(ctx context.Context, blockHash *chainhash.Hash) (b
 
 	var isMined bool
 
-	err := s.db.QueryRowContext(ctx, q, blockHash[:]).Scan(&isMined)
+	err := s.queryRowContext(ctx, q, blockHash[:]).Scan(&isMined)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, errors.NewBlockNotFoundError("[GetBlockIsMined][%s] block not found", blockHash.String())
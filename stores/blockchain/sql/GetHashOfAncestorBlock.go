@@ -103,7 +103,7 @@ func (s *SQL) GetHashOfAncestorBlock(ctx context.Context, hash *chainhash.Hash,
 		depth DESC
 	LIMIT 1`
 
-	if err := s.db.QueryRowContext(ctx, q, hash[:], depth).Scan(
+	if err := s.queryRowContext(ctx, q, hash[:], depth).Scan(
 		&pastHash,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -97,7 +97,7 @@ func TestSQLGetHashOfAncestorBlockShort(t *testing.T) {
 	require.Equal(t, expectedHash, ancestorHash)
 }
 
-func generateBlocks(t *testing.T, numberOfBlocks int) []*model.Block {
+func generateBlocks(t testing.TB, numberOfBlocks int) []*model.Block {
 	var generateBlocks []*model.Block
 
 	hashPrevBlock, err := chainhash.NewHashFromStr("0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206")
@@ -98,7 +98,7 @@ func (s *SQL) GetHeader(ctx context.Context, blockHash *chainhash.Hash) (*model.
 		nBits          []byte
 	)
 
-	if err := s.db.QueryRowContext(ctx, q, blockHash[:]).Scan(
+	if err := s.readQueryRowContext(ctx, q, blockHash[:]).Scan(
 		&blockHeader.Version,
 		&blockHeader.Timestamp,
 		&blockHeader.Nonce,
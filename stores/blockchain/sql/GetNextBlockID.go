@@ -30,7 +30,7 @@ func (s *SQL) getNextBlockIdFromPostgres(ctx context.Context) (uint64, error) {
 	`
 	var nextID uint64
 
-	if err := s.db.QueryRowContext(ctx, q).Scan(&nextID); err != nil {
+	if err := s.queryRowContext(ctx, q).Scan(&nextID); err != nil {
 		return 0, err
 	}
 
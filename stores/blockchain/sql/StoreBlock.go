@@ -19,6 +19,7 @@ import (
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/services/blockchain/work"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
+	"github.com/bsv-blockchain/teranode/util/faultinject"
 	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/lib/pq"
 	"modernc.org/sqlite"
@@ -102,6 +103,10 @@ func (s *SQL) StoreBlock(ctx context.Context, block *model.Block, peerID string,
 		opt(&storeBlockOptions)
 	}
 
+	if err := faultinject.Check(faultinject.BlockchainStoreWrite); err != nil {
+		return 0, 0, errors.NewStorageError("failed to store block %s", block.Hash(), err)
+	}
+
 	newBlockID, height, _, _, err := s.storeBlock(ctx, block, peerID, storeBlockOptions)
 	if err != nil {
 		return 0, height, err
@@ -455,20 +460,27 @@ RETURNING id
 //   - error: A domain-specific error with appropriate context, typically wrapped as
 //     a BlockAlreadyExistsError for duplicates or a more general StorageError for other issues
 func (*SQL) parseSQLError(err error, block *model.Block) error {
+	if isUniqueConstraintError(err) {
+		return errors.NewBlockExistsError("block already exists in the database: %s", block.Hash().String(), err)
+	}
+
+	// otherwise, return the generic error
+	return errors.NewStorageError("failed to store block", err)
+}
+
+// isUniqueConstraintError returns whether the error is a constraint violation of PostgreSQL or SQLite, which on
+// inserting blocks means a block with the same hash is already stored
+func isUniqueConstraintError(err error) bool {
 	// check whether this is a postgres exists constraint error
 	var pqErr *pq.Error
 	if errors.As(err, &pqErr) && pqErr.Code == "23505" { // Duplicate constraint violation
-		return errors.NewBlockExistsError("block already exists in the database: %s", block.Hash().String(), err)
+		return true
 	}
 
 	// check whether this is a sqlite exists constraint error
 	var sqliteErr *sqlite.Error
-	if errors.As(err, &sqliteErr) && (sqliteErr.Code()&0xff) == SQLITE_CONSTRAINT {
-		return errors.NewBlockExistsError("block already exists in the database: %s", block.Hash().String(), err)
-	}
 
-	// otherwise, return the generic error
-	return errors.NewStorageError("failed to store block", err)
+	return errors.As(err, &sqliteErr) && (sqliteErr.Code()&0xff) == SQLITE_CONSTRAINT
 }
 
 // getPreviousBlockData determines if this is a genesis block and retrieves information
@@ -556,11 +568,55 @@ func (s *SQL) getPreviousBlockData(
 		if err := s.validateCoinbaseHeight(block, height); err != nil {
 			return false, 0, 0, nil, false, err
 		}
+
+		if err := s.validateCheckpoints(ctx, block, height); err != nil {
+			return false, 0, 0, nil, false, err
+		}
 	}
 
 	return genesis, height, previousBlockID, previousChainWork, previousBlockInvalid, nil
 }
 
+// validateCheckpoints verifies a block against the hard checkpoints before it is stored.
+//
+// A block at a checkpoint height must match the checkpoint hash. A block below a checkpoint that is
+// already in the store can only be part of a fork below that checkpoint, since the main chain block at
+// its height is already stored, and is rejected as well.
+//
+// Parameters:
+//   - ctx: Context for the database operation
+//   - block: The block being stored
+//   - height: Height the block would be stored at
+//
+// Returns:
+//   - error: BlockInvalidError if the block conflicts with a checkpoint, StorageError if the
+//     checkpoint lookup fails
+func (s *SQL) validateCheckpoints(ctx context.Context, block *model.Block, height uint32) error {
+	if s.checkpoints == nil {
+		return nil
+	}
+
+	if err := s.checkpoints.VerifyBlock(height, block.Hash()); err != nil {
+		return err
+	}
+
+	cp, ok := s.checkpoints.NextAbove(height)
+	if !ok {
+		return nil
+	}
+
+	reached, err := s.GetBlockExists(ctx, cp.Hash)
+	if err != nil {
+		return errors.NewStorageError("failed to check checkpoint block %s", cp.Hash.String(), err)
+	}
+
+	if reached {
+		return errors.NewBlockInvalidError("block %s at height %d forks below checkpoint %d (%s)", block.Hash().String(), height, cp.Height, cp.Hash.String())
+	}
+
+	return nil
+}
+
 // calculateAndPrepareChainWork computes the cumulative proof-of-work for a new block.
 // It converts the previous chain work bytes to a hash, calculates the new cumulative
 // chain work by adding the block's work, and returns the result in byte format.
@@ -9,6 +9,7 @@ import (
 
 	"github.com/bsv-blockchain/go-bt/v2"
 	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
 	"github.com/bsv-blockchain/teranode/ulogger"
@@ -236,6 +237,57 @@ func TestStoreBlock_InvalidBlock(t *testing.T) {
 	assert.Equal(t, uint32(1), height)
 }
 
+func TestStoreBlock_CheckpointMismatch(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.BlockChain.Checkpoints = []string{"2:" + block2.Hash().String()}
+
+	storeURL, err := url.Parse("sqlitememory:///")
+	require.NoError(t, err)
+
+	s, err := New(ulogger.TestLogger{}, storeURL, tSettings)
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, _, err = s.StoreBlock(context.Background(), block1, "test-peer")
+	require.NoError(t, err)
+
+	// blockAlternative2 is at the checkpoint height with a different hash
+	_, _, err = s.StoreBlock(context.Background(), blockAlternative2, "test-peer")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrBlockInvalid))
+
+	_, height, err := s.StoreBlock(context.Background(), block2, "test-peer")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), height)
+}
+
+func TestStoreBlock_ForkBelowCheckpoint(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.BlockChain.Checkpoints = []string{"3:" + block3.Hash().String()}
+
+	storeURL, err := url.Parse("sqlitememory:///")
+	require.NoError(t, err)
+
+	s, err := New(ulogger.TestLogger{}, storeURL, tSettings)
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, _, err = s.StoreBlock(context.Background(), block1, "test-peer")
+	require.NoError(t, err)
+
+	// Forks below a checkpoint are accepted as long as the checkpoint has not been reached
+	_, _, err = s.StoreBlock(context.Background(), block2, "test-peer")
+	require.NoError(t, err)
+
+	_, _, err = s.StoreBlock(context.Background(), block3, "test-peer")
+	require.NoError(t, err)
+
+	_, _, err = s.StoreBlock(context.Background(), blockAlternative2, "test-peer")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrBlockInvalid))
+	assert.Contains(t, err.Error(), "forks below checkpoint")
+}
+
 func TestGetPreviousBlockInfo_FromCache(t *testing.T) {
 	tSettings := test.CreateBaseTestSettings(t)
 	storeURL, err := url.Parse("sqlitememory:///")
@@ -0,0 +1,340 @@
+// Package sql implements the blockchain.Store interface using SQL database backends.
+// It provides concrete SQL-based implementations for all blockchain operations
+// defined in the interface, with support for different SQL engines.
+//
+// This file implements the StoreBlocks method, which persists a contiguous range of
+// blocks with multi-row inserts instead of one insert per block. It is used when many
+// blocks or headers are stored at once, e.g. when a fresh node imports the headers of
+// the chain, where the round trip per block dominates the time spent storing them.
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/faultinject"
+	"github.com/bsv-blockchain/teranode/util/tracing"
+)
+
+// Maximum number of blocks inserted by a single statement. With 20 columns per block PostgreSQL stays well below its
+// limit of 65535 bind parameters. The SQLite driver binds the parameters of a statement in quadratic time, which
+// makes statements of more than a few dozen blocks slower than inserting the blocks one by one.
+const (
+	storeBlocksBatchSizePostgres = 500
+	storeBlocksBatchSizeSqlite   = 32
+)
+
+// storeBlocksColumns are the columns of the blocks table set by StoreBlocks, in the order of the bind parameters
+const storeBlocksColumns = `id
+	,parent_id
+	,version
+	,hash
+	,previous_hash
+	,merkle_root
+	,block_time
+	,n_bits
+	,nonce
+	,height
+	,chain_work
+	,tx_count
+	,size_in_bytes
+	,subtree_count
+	,subtrees
+	,peer_id
+	,coinbase_tx
+	,invalid
+	,mined_set
+	,subtrees_set`
+
+// storeBlocksColumnCount is the number of columns in storeBlocksColumns
+const storeBlocksColumnCount = 20
+
+// StoreBlocks persists a contiguous range of blocks to the database in a single transaction.
+// This implements the blockchain.Store.StoreBlocks interface method.
+//
+// Each block must extend the block before it, and the parent of the first block must already
+// be stored. The blocks are validated as in StoreBlock (BIP34 coinbase height, checkpoints),
+// their heights and cumulative chain work are calculated in memory, and their IDs are
+// allocated up front, so the parent ID of every block is known before it is inserted. The
+// blocks are then inserted with multi-row INSERT statements of up to storeBlocksBatchSizePostgres
+// or storeBlocksBatchSizeSqlite blocks each, instead of one round trip per block.
+//
+// The range is stored atomically: when any block fails to be stored, none of the blocks are.
+// Blocks inherit the invalid status of the parent of the range, as in StoreBlock.
+//
+// Parameters:
+//   - ctx: Context for the database operation, allowing for cancellation and timeouts
+//   - blocks: The contiguous range of blocks to store, ordered by height
+//   - peerID: Identifier of the peer that provided the blocks
+//   - opts: Optional parameters applied to all blocks, such as marking them as mined.
+//     Custom block IDs are not supported for a range
+//
+// Returns:
+//   - []uint64: The database IDs assigned to the blocks, in the order of the blocks
+//   - error: Any error encountered during the operation, specifically:
+//   - InvalidArgumentError for a range that is not contiguous, starts with the genesis block or sets a custom ID
+//   - BlockInvalidError or ProcessingError for blocks that violate consensus rules
+//   - BlockAlreadyExistsError when any of the blocks is already stored
+//   - StorageError for database failures
+func (s *SQL) StoreBlocks(ctx context.Context, blocks []*model.Block, peerID string, opts ...options.StoreBlockOption) ([]uint64, error) {
+	ctx, _, deferFn := tracing.Tracer("sql").Start(ctx, "sql:StoreBlocks")
+	defer deferFn()
+
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	storeBlockOptions := options.ProcessStoreBlockOptions(opts...)
+	if storeBlockOptions.ID != 0 {
+		return nil, errors.NewInvalidArgumentError("custom block IDs are not supported when storing a range of blocks")
+	}
+
+	if err := faultinject.Check(faultinject.BlockchainStoreWrite); err != nil {
+		return nil, errors.NewStorageError("failed to store %d blocks from %s", len(blocks), blocks[0].Hash(), err)
+	}
+
+	args, err := s.prepareStoreBlocks(ctx, blocks, peerID, storeBlockOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.NewStorageError("failed to begin transaction to store %d blocks", len(blocks), err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	ids, err := s.allocateBlockIDs(ctx, tx, len(blocks))
+	if err != nil {
+		return nil, errors.NewStorageError("failed to allocate IDs for %d blocks", len(blocks), err)
+	}
+
+	// set the IDs of the blocks and the parent IDs of all but the first block, which is the stored parent
+	for i := range args {
+		args[i][0] = ids[i]
+
+		if i > 0 {
+			args[i][1] = ids[i-1]
+		}
+	}
+
+	batchSize := storeBlocksBatchSizeSqlite
+	if s.engine == util.Postgres {
+		batchSize = storeBlocksBatchSizePostgres
+	}
+
+	for start := 0; start < len(args); start += batchSize {
+		end := min(start+batchSize, len(args))
+
+		if _, err = tx.ExecContext(ctx, s.storeBlocksQuery(end-start), flattenStoreBlocksArgs(args[start:end])...); err != nil {
+			err = s.parseStoreBlocksSQLError(err, blocks[start], blocks[end-1])
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.NewStorageError("failed to commit %d blocks", len(blocks), err)
+	}
+
+	// Reset response cache to invalidate cached block headers and best block
+	s.ResetResponseCache()
+
+	return ids, nil
+}
+
+// prepareStoreBlocks validates the range of blocks and returns the bind parameters of every block, with the ID and
+// parent ID left to be set once the IDs are allocated, except for the parent ID of the first block.
+func (s *SQL) prepareStoreBlocks(ctx context.Context, blocks []*model.Block, peerID string, storeBlockOptions *options.StoreBlockOptions) ([][]interface{}, error) {
+	genesisTxID := s.chainParams.GenesisBlock.Transactions[0].TxHash().String()
+
+	for i, block := range blocks {
+		if block.CoinbaseTx != nil && block.CoinbaseTx.TxID() == genesisTxID {
+			return nil, errors.NewInvalidArgumentError("genesis block cannot be stored in a range of blocks")
+		}
+
+		if i > 0 && !block.Header.HashPrevBlock.IsEqual(blocks[i-1].Hash()) {
+			return nil, errors.NewInvalidArgumentError("block %s at index %d does not extend block %s", block.Hash(), i, blocks[i-1].Hash())
+		}
+	}
+
+	parentID, chainWork, parentHeight, parentInvalid, err := s.getPreviousBlockInfo(ctx, *blocks[0].Header.HashPrevBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	storeAsInvalid := parentInvalid || storeBlockOptions.Invalid
+	args := make([][]interface{}, len(blocks))
+
+	for i, block := range blocks {
+		height := parentHeight + 1 + uint32(i) //nolint:gosec // the range is far smaller than the height space
+
+		if err = s.validateCoinbaseHeight(block, height); err != nil {
+			return nil, err
+		}
+
+		if err = s.validateCheckpoints(ctx, block, height); err != nil {
+			return nil, err
+		}
+
+		if chainWork, err = calculateAndPrepareChainWork(chainWork, block); err != nil {
+			return nil, err
+		}
+
+		var subtreeBytes []byte
+
+		if subtreeBytes, err = block.SubTreeBytes(); err != nil {
+			return nil, errors.NewStorageError("failed to get subtree bytes", err)
+		}
+
+		var coinbaseBytes []byte
+		if block.CoinbaseTx != nil {
+			coinbaseBytes = block.CoinbaseTx.Bytes()
+		}
+
+		args[i] = []interface{}{
+			nil, // id, allocated in the transaction
+			parentID,
+			block.Header.Version,
+			block.Hash().CloneBytes(),
+			block.Header.HashPrevBlock.CloneBytes(),
+			block.Header.HashMerkleRoot.CloneBytes(),
+			block.Header.Timestamp,
+			block.Header.Bits.CloneBytes(),
+			block.Header.Nonce,
+			height,
+			chainWork,
+			block.TransactionCount,
+			block.SizeInBytes,
+			len(block.Subtrees),
+			subtreeBytes,
+			peerID,
+			coinbaseBytes,
+			storeAsInvalid,
+			storeBlockOptions.MinedSet,
+			storeBlockOptions.SubtreesSet,
+		}
+	}
+
+	return args, nil
+}
+
+// allocateBlockIDs reserves n consecutive block IDs from the ID sequence of the blocks table.
+// On SQLite the sequence is advanced in the transaction, so the IDs are released again when
+// the transaction is rolled back. On PostgreSQL the IDs are taken from the serial sequence,
+// which leaves a gap when the transaction is rolled back, as a failed StoreBlock does.
+func (s *SQL) allocateBlockIDs(ctx context.Context, tx *sql.Tx, n int) ([]uint64, error) {
+	ids := make([]uint64, 0, n)
+
+	if s.engine == util.Postgres {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT nextval(pg_get_serial_sequence('blocks', 'id'))
+			FROM generate_series(1, $1)
+		`, n)
+		if err != nil {
+			return nil, err
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var id uint64
+			if err = rows.Scan(&id); err != nil {
+				return nil, err
+			}
+
+			ids = append(ids, id)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+
+		if len(ids) != n {
+			return nil, errors.NewStorageError("allocated %d block IDs, expected %d", len(ids), n)
+		}
+
+		return ids, nil
+	}
+
+	var last uint64
+	if err := tx.QueryRowContext(ctx, `
+		UPDATE sqlite_sequence
+		SET seq = seq + $1
+		WHERE name = 'blocks'
+		RETURNING seq
+	`, n).Scan(&last); err != nil {
+		return nil, err
+	}
+
+	for id := last - uint64(n) + 1; id <= last; id++ { //nolint:gosec // n is positive
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// storeBlocksQuery returns the multi-row INSERT statement for n blocks, with anonymous parameters on SQLite, which
+// the driver binds by position instead of looking up numbered parameters like $1 by name.
+func (s *SQL) storeBlocksQuery(n int) string {
+	var q strings.Builder
+
+	q.WriteString("INSERT INTO blocks (\n\t")
+	q.WriteString(storeBlocksColumns)
+	q.WriteString("\n) VALUES ")
+
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			q.WriteString(", ")
+		}
+
+		q.WriteString("(")
+
+		for c := 0; c < storeBlocksColumnCount; c++ {
+			if c > 0 {
+				q.WriteString(", ")
+			}
+
+			if s.engine == util.Postgres {
+				fmt.Fprintf(&q, "$%d", i*storeBlocksColumnCount+c+1)
+			} else {
+				q.WriteString("?")
+			}
+		}
+
+		q.WriteString(")")
+	}
+
+	return q.String()
+}
+
+// flattenStoreBlocksArgs returns the bind parameters of the blocks in a single slice, in the order of storeBlocksQuery
+func flattenStoreBlocksArgs(args [][]interface{}) []interface{} {
+	flat := make([]interface{}, 0, len(args)*storeBlocksColumnCount)
+
+	for _, blockArgs := range args {
+		flat = append(flat, blockArgs...)
+	}
+
+	return flat
+}
+
+// parseStoreBlocksSQLError translates the error of inserting the blocks first to last, as parseSQLError does for a
+// single block
+func (s *SQL) parseStoreBlocksSQLError(err error, first, last *model.Block) error {
+	if isUniqueConstraintError(err) {
+		return errors.NewBlockExistsError("one of the blocks %s to %s already exists in the database", first.Hash().String(), last.Hash().String(), err)
+	}
+
+	return errors.NewStorageError("failed to store blocks %s to %s", first.Hash().String(), last.Hash().String(), err)
+}
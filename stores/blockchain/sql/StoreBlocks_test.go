@@ -0,0 +1,213 @@
+package sql
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStoreBlocksTestStore(t testing.TB) *SQL {
+	tSettings := test.CreateBaseTestSettings(t)
+
+	storeURL, err := url.Parse("sqlitememory:///")
+	require.NoError(t, err)
+
+	s, err := New(ulogger.TestLogger{}, storeURL, tSettings)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestStoreBlocks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores a range like StoreBlock", func(t *testing.T) {
+		// more blocks than fit in a single statement
+		blocks := generateBlocks(t, storeBlocksBatchSizeSqlite*2+10)
+
+		s := newStoreBlocksTestStore(t)
+		defer s.Close()
+
+		ids, err := s.StoreBlocks(ctx, blocks, "test-peer", options.WithMinedSet(true))
+		require.NoError(t, err)
+		require.Len(t, ids, len(blocks))
+
+		for i := 1; i < len(ids); i++ {
+			assert.Greater(t, ids[i], ids[i-1])
+		}
+
+		// the same blocks stored one by one
+		expected := newStoreBlocksTestStore(t)
+		defer expected.Close()
+
+		for _, block := range blocks {
+			_, _, err = expected.StoreBlock(ctx, block, "test-peer", options.WithMinedSet(true))
+			require.NoError(t, err)
+		}
+
+		for i, block := range blocks {
+			_, meta, err := s.GetBlockHeader(ctx, block.Hash())
+			require.NoError(t, err)
+
+			_, expectedMeta, err := expected.GetBlockHeader(ctx, block.Hash())
+			require.NoError(t, err)
+
+			assert.Equal(t, ids[i], uint64(meta.ID))
+			assert.Equal(t, uint32(i+1), meta.Height) //nolint:gosec // test index
+			assert.Equal(t, expectedMeta.ChainWork, meta.ChainWork)
+			assert.True(t, meta.MinedSet)
+			assert.False(t, meta.SubtreesSet)
+		}
+
+		best, bestMeta, err := s.GetBestBlockHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, blocks[len(blocks)-1].Hash(), best.Hash())
+		assert.Equal(t, uint32(len(blocks)), bestMeta.Height) //nolint:gosec // test length
+
+		// the parent of a block in the range is the block before it
+		ancestor, err := s.GetHashOfAncestorBlock(ctx, blocks[len(blocks)-1].Hash(), len(blocks)-1)
+		require.NoError(t, err)
+		assert.Equal(t, blocks[0].Hash(), ancestor)
+	})
+
+	t.Run("continues the ID sequence", func(t *testing.T) {
+		blocks := generateBlocks(t, 4)
+
+		s := newStoreBlocksTestStore(t)
+		defer s.Close()
+
+		id1, _, err := s.StoreBlock(ctx, blocks[0], "test-peer")
+		require.NoError(t, err)
+
+		ids, err := s.StoreBlocks(ctx, blocks[1:3], "test-peer")
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{id1 + 1, id1 + 2}, ids)
+
+		id4, height, err := s.StoreBlock(ctx, blocks[3], "test-peer")
+		require.NoError(t, err)
+		assert.Equal(t, id1+3, id4)
+		assert.Equal(t, uint32(4), height)
+	})
+
+	t.Run("range with a stored block is not stored", func(t *testing.T) {
+		blocks := generateBlocks(t, 3)
+
+		s := newStoreBlocksTestStore(t)
+		defer s.Close()
+
+		id1, _, err := s.StoreBlock(ctx, blocks[0], "test-peer")
+		require.NoError(t, err)
+
+		id2, _, err := s.StoreBlock(ctx, blocks[1], "test-peer")
+		require.NoError(t, err)
+
+		_, err = s.StoreBlocks(ctx, []*model.Block{blocks[1], blocks[2]}, "test-peer")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrBlockExists))
+
+		exists, err := s.GetBlockExists(ctx, blocks[2].Hash())
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		// the IDs allocated for the failed range are released
+		ids, err := s.StoreBlocks(ctx, blocks[2:], "test-peer")
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{id2 + 1}, ids)
+		assert.Equal(t, id1+1, id2)
+	})
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		blocks := generateBlocks(t, 3)
+
+		s := newStoreBlocksTestStore(t)
+		defer s.Close()
+
+		ids, err := s.StoreBlocks(ctx, nil, "test-peer")
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+
+		_, err = s.StoreBlocks(ctx, []*model.Block{blocks[0], blocks[2]}, "test-peer")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidArgument))
+
+		_, err = s.StoreBlocks(ctx, blocks[1:], "test-peer")
+		require.Error(t, err, "the parent of the range is not stored")
+
+		_, err = s.StoreBlocks(ctx, blocks, "test-peer", options.WithID(100))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidArgument))
+
+		for _, block := range blocks {
+			exists, err := s.GetBlockExists(ctx, block.Hash())
+			require.NoError(t, err)
+			assert.False(t, exists)
+		}
+	})
+
+	t.Run("invalid parent", func(t *testing.T) {
+		blocks := generateBlocks(t, 3)
+
+		s := newStoreBlocksTestStore(t)
+		defer s.Close()
+
+		_, _, err := s.StoreBlock(ctx, blocks[0], "test-peer", options.WithInvalid(true))
+		require.NoError(t, err)
+
+		_, err = s.StoreBlocks(ctx, blocks[1:], "test-peer")
+		require.NoError(t, err)
+
+		for _, block := range blocks[1:] {
+			_, meta, err := s.GetBlockHeader(ctx, block.Hash())
+			require.NoError(t, err)
+			assert.True(t, meta.Invalid)
+		}
+	})
+}
+
+// BenchmarkStoreBlocks compares storing a range of 2000 headers one by one with storing them with StoreBlocks
+func BenchmarkStoreBlocks(b *testing.B) {
+	ctx := context.Background()
+	blocks := generateBlocks(b, 2000)
+
+	b.Run("StoreBlock", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			s := newStoreBlocksTestStore(b)
+			b.StartTimer()
+
+			for _, block := range blocks {
+				if _, _, err := s.StoreBlock(ctx, block, "test-peer", options.WithMinedSet(true), options.WithSubtreesSet(true)); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.StopTimer()
+			_ = s.Close()
+			b.StartTimer()
+		}
+	})
+
+	b.Run("StoreBlocks", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			s := newStoreBlocksTestStore(b)
+			b.StartTimer()
+
+			if _, err := s.StoreBlocks(ctx, blocks, "test-peer", options.WithMinedSet(true), options.WithSubtreesSet(true)); err != nil {
+				b.Fatal(err)
+			}
+
+			b.StopTimer()
+			_ = s.Close()
+			b.StartTimer()
+		}
+	})
+}
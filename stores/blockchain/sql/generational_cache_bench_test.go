@@ -0,0 +1,165 @@
+package sql
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+)
+
+// benchmarkCacheKeys returns a key space of the given size, mirroring the hashed operation keys of the store
+func benchmarkCacheKeys(n int) []chainhash.Hash {
+	keys := make([]chainhash.Hash, n)
+
+	for i := range keys {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], uint64(i)) //nolint:gosec // i is never negative
+		keys[i] = chainhash.HashH(buf[:])
+	}
+
+	return keys
+}
+
+// benchmarkKeySequence returns a sequence of key indexes following a Zipf distribution, so a small set of
+// hot keys, like the chain tip, is requested far more often than the rest, as seen by the store
+func benchmarkKeySequence(keys int) []uint32 {
+	zipf := rand.NewZipf(rand.New(rand.NewSource(1)), 1.1, 1, uint64(keys-1)) //nolint:gosec // deterministic benchmark input
+
+	sequence := make([]uint32, 1<<16)
+	for i := range sequence {
+		sequence[i] = uint32(zipf.Uint64()) //nolint:gosec // bounded by the number of keys
+	}
+
+	return sequence
+}
+
+// readThrough performs the Begin→Get→Set pattern of the store queries and reports whether it was a cache hit
+func readThrough(gc *GenerationalCache, key chainhash.Hash) bool {
+	op := gc.Begin(key)
+	if item := op.Get(); item != nil {
+		return true
+	}
+
+	op.Set(key, time.Minute)
+
+	return false
+}
+
+// BenchmarkGenerationalCache measures the cache under concurrent read, write and invalidation load.
+// The invalidate_every sub-benchmarks issue one DeleteAll per N operations, comparable to blocks being
+// added while the cache is being read, and report the resulting hit ratio.
+func BenchmarkGenerationalCache(b *testing.B) {
+	keys := benchmarkCacheKeys(1024)
+	sequence := benchmarkKeySequence(len(keys))
+	mask := uint64(len(sequence) - 1)
+
+	b.Run("Get/hit", func(b *testing.B) {
+		gc := NewGenerationalCache()
+		defer gc.Stop()
+
+		for _, key := range keys {
+			gc.Begin(key).Set(key, time.Hour)
+		}
+
+		var counter atomic.Uint64
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = gc.Begin(keys[sequence[counter.Add(1)&mask]]).Get()
+			}
+		})
+	})
+
+	b.Run("Get/miss", func(b *testing.B) {
+		gc := NewGenerationalCache()
+		defer gc.Stop()
+
+		var counter atomic.Uint64
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = gc.Begin(keys[sequence[counter.Add(1)&mask]]).Get()
+			}
+		})
+	})
+
+	b.Run("Set", func(b *testing.B) {
+		gc := NewGenerationalCache()
+		defer gc.Stop()
+
+		var counter atomic.Uint64
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				key := keys[sequence[counter.Add(1)&mask]]
+				gc.Begin(key).Set(key, time.Hour)
+			}
+		})
+	})
+
+	b.Run("DeleteAll", func(b *testing.B) {
+		gc := NewGenerationalCache()
+		defer gc.Stop()
+
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+
+			for _, key := range keys {
+				gc.Begin(key).Set(key, time.Hour)
+			}
+
+			b.StartTimer()
+
+			gc.DeleteAll()
+		}
+	})
+
+	for _, invalidateEvery := range []uint64{0, 10_000, 1_000, 100} {
+		name := "ReadThrough/no_invalidation"
+		if invalidateEvery > 0 {
+			name = fmt.Sprintf("ReadThrough/invalidate_every_%d", invalidateEvery)
+		}
+
+		b.Run(name, func(b *testing.B) {
+			gc := NewGenerationalCache()
+			defer gc.Stop()
+
+			var counter, hits atomic.Uint64
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					n := counter.Add(1)
+
+					if invalidateEvery > 0 && n%invalidateEvery == 0 {
+						gc.DeleteAll()
+						continue
+					}
+
+					if readThrough(gc, keys[sequence[n&mask]]) {
+						hits.Add(1)
+					}
+				}
+			})
+
+			b.ReportMetric(float64(hits.Load())/float64(b.N), "hits/op")
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package sql
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// prometheusBlockchainSQLPreparedStatements is the number of prepared statements held by the blockchain stores
+	prometheusBlockchainSQLPreparedStatements prometheus.Gauge
+
+	// prometheusBlockchainSQLSlowQueries counts the lookups slower than the slow query threshold
+	prometheusBlockchainSQLSlowQueries prometheus.Counter
+
+	// only init the metrics once
+	prometheusMetricsInitOnce sync.Once
+)
+
+func initPrometheusMetrics() {
+	prometheusMetricsInitOnce.Do(_initPrometheusMetrics)
+}
+
+func _initPrometheusMetrics() {
+	prometheusBlockchainSQLPreparedStatements = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "blockchain_sql",
+			Name:      "prepared_statements",
+			Help:      "Number of prepared statements held by the blockchain store",
+		},
+	)
+
+	prometheusBlockchainSQLSlowQueries = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "blockchain_sql",
+			Name:      "slow_queries_total",
+			Help:      "Number of blockchain store lookups slower than the slow query threshold",
+		},
+	)
+}
@@ -0,0 +1,258 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/usql"
+	"github.com/bsv-blockchain/teranode/util/usql/migrate"
+)
+
+const (
+	// migrationsTable records the schema migrations applied to the blockchain database
+	migrationsTable = "schema_migrations"
+
+	// migrationInitialSchema creates the tables of the blockchain store, it is the only migration applied in seeder
+	// mode, the others are applied at the next start without seeder mode
+	migrationInitialSchema = 1
+)
+
+// The migrations of the blockchain store. Applied migrations must never be changed, their checksums are verified at
+// every start: add a new migration for every schema change instead, with the statements rolling it back.
+//
+// The initial schema uses IF NOT EXISTS, and brings the legacy columns and indexes up to date, so databases created
+// before the schema was managed by migrations are adopted by applying it.
+var postgresMigrations = []migrate.Migration{
+	{
+		Version: migrationInitialSchema,
+		Name:    "initial_schema",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS state (
+			  key            VARCHAR(32) PRIMARY KEY
+			 ,data           BYTEA NOT NULL
+			 ,inserted_at    TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+			 ,updated_at     TIMESTAMPTZ NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS audit_log (
+			  id             BIGSERIAL PRIMARY KEY
+			 ,recorded_at    BIGINT NOT NULL
+			 ,actor          TEXT NOT NULL
+			 ,action         TEXT NOT NULL
+			 ,target         TEXT NOT NULL
+			 ,details        TEXT NOT NULL
+			 ,dropped        INTEGER NOT NULL DEFAULT 0
+			)`,
+			`CREATE TABLE IF NOT EXISTS blocks (
+			  id             BIGSERIAL PRIMARY KEY
+			 ,parent_id      BIGSERIAL REFERENCES blocks(id)
+			 ,version        INTEGER NOT NULL
+			 ,hash           BYTEA NOT NULL
+			 ,previous_hash  BYTEA NOT NULL
+			 ,merkle_root    BYTEA NOT NULL
+			 ,block_time     BIGINT NOT NULL
+			 ,n_bits         BYTEA NOT NULL
+			 ,nonce          BIGINT NOT NULL
+			 ,height         BIGINT NOT NULL
+			 ,chain_work     BYTEA NOT NULL
+			 ,tx_count       BIGINT NOT NULL
+			 ,size_in_bytes  BIGINT NOT NULL
+			 ,subtree_count  BIGINT NOT NULL
+			 ,subtrees       BYTEA NOT NULL
+			 ,coinbase_tx    BYTEA NOT NULL
+			 ,invalid        BOOLEAN NOT NULL DEFAULT FALSE
+			 ,mined_set      BOOLEAN NOT NULL DEFAULT FALSE
+			 ,subtrees_set   BOOLEAN NOT NULL DEFAULT FALSE
+			 ,peer_id        TEXT NOT NULL
+			 ,inserted_at    TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+			 ,processed_at   TIMESTAMPTZ NULL
+			)`,
+			`ALTER TABLE blocks ALTER COLUMN peer_id TYPE TEXT`,
+			`ALTER TABLE blocks ADD COLUMN IF NOT EXISTS processed_at TIMESTAMPTZ NULL`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS ux_blocks_hash ON blocks (hash)`,
+			`DROP INDEX IF EXISTS pux_blocks_height`,
+			`CREATE OR REPLACE FUNCTION reverse_bytes_iter(bytes bytea, length int, midpoint int, index int)
+			RETURNS bytea AS
+			$$
+			  SELECT CASE WHEN index >= midpoint THEN bytes ELSE
+				reverse_bytes_iter(
+				  set_byte(
+					set_byte(bytes, index, get_byte(bytes, length-index)),
+					length-index, get_byte(bytes, index)
+				  ),
+				  length, midpoint, index + 1
+				)
+			  END;
+			$$ LANGUAGE SQL IMMUTABLE`,
+			`CREATE OR REPLACE FUNCTION reverse_bytes(bytes bytea) RETURNS bytea AS
+			'SELECT reverse_bytes_iter(bytes, octet_length(bytes)-1, octet_length(bytes)/2, 0)'
+			LANGUAGE SQL IMMUTABLE`,
+		},
+		Down: []string{
+			`DROP FUNCTION IF EXISTS reverse_bytes(bytea)`,
+			`DROP FUNCTION IF EXISTS reverse_bytes_iter(bytea, int, int, int)`,
+			`DROP TABLE IF EXISTS blocks`,
+			`DROP TABLE IF EXISTS audit_log`,
+			`DROP TABLE IF EXISTS state`,
+		},
+	},
+	{
+		Version: 2,
+		Name:    "blocks_indexes",
+		Up: []string{
+			`CREATE INDEX IF NOT EXISTS idx_chain_work_id ON blocks (chain_work DESC, id ASC)`,
+			`CREATE INDEX IF NOT EXISTS idx_chain_work_peer_id ON blocks (chain_work DESC, peer_id ASC, id ASC)`,
+			`CREATE INDEX IF NOT EXISTS idx_mined_set ON blocks (mined_set) WHERE mined_set = false`,
+			`CREATE INDEX IF NOT EXISTS idx_subtrees_set ON blocks (subtrees_set) WHERE subtrees_set = false`,
+			`CREATE INDEX IF NOT EXISTS idx_height ON blocks (height)`,
+			`CREATE INDEX IF NOT EXISTS idx_parent_id ON blocks (parent_id)`,
+		},
+		Down: []string{
+			`DROP INDEX IF EXISTS idx_parent_id`,
+			`DROP INDEX IF EXISTS idx_height`,
+			`DROP INDEX IF EXISTS idx_subtrees_set`,
+			`DROP INDEX IF EXISTS idx_mined_set`,
+			`DROP INDEX IF EXISTS idx_chain_work_peer_id`,
+			`DROP INDEX IF EXISTS idx_chain_work_id`,
+		},
+	},
+}
+
+var sqliteMigrations = []migrate.Migration{
+	{
+		Version: migrationInitialSchema,
+		Name:    "initial_schema",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS state (
+			  key            VARCHAR(32) PRIMARY KEY
+			 ,data           BLOB NOT NULL
+			 ,inserted_at    TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+			 ,updated_at     TEXT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS audit_log (
+			  id             INTEGER PRIMARY KEY AUTOINCREMENT
+			 ,recorded_at    BIGINT NOT NULL
+			 ,actor          TEXT NOT NULL
+			 ,action         TEXT NOT NULL
+			 ,target         TEXT NOT NULL
+			 ,details        TEXT NOT NULL
+			 ,dropped        INTEGER NOT NULL DEFAULT 0
+			)`,
+			`CREATE TABLE IF NOT EXISTS blocks (
+			  id             INTEGER PRIMARY KEY AUTOINCREMENT
+			 ,parent_id      INTEGER REFERENCES blocks(id)
+			 ,version        INTEGER NOT NULL
+			 ,hash           BLOB NOT NULL
+			 ,previous_hash  BLOB NOT NULL
+			 ,merkle_root    BLOB NOT NULL
+			 ,block_time     BIGINT NOT NULL
+			 ,n_bits         BLOB NOT NULL
+			 ,nonce          BIGINT NOT NULL
+			 ,height         BIGINT NOT NULL
+			 ,chain_work     BLOB NOT NULL
+			 ,tx_count       BIGINT NOT NULL
+			 ,size_in_bytes  BIGINT NOT NULL
+			 ,subtree_count  BIGINT NOT NULL
+			 ,subtrees       BLOB NOT NULL
+			 ,coinbase_tx    BLOB NOT NULL
+			 ,invalid        BOOLEAN NOT NULL DEFAULT FALSE
+			 ,mined_set      BOOLEAN NOT NULL DEFAULT FALSE
+			 ,subtrees_set   BOOLEAN NOT NULL DEFAULT FALSE
+			 ,peer_id        TEXT NOT NULL
+			 ,inserted_at    TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+			 ,processed_at   TEXT NULL
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS ux_blocks_hash ON blocks (hash)`,
+			`DROP INDEX IF EXISTS pux_blocks_height`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS blocks`,
+			`DROP TABLE IF EXISTS audit_log`,
+			`DROP TABLE IF EXISTS state`,
+		},
+	},
+	{
+		Version: 2,
+		Name:    "blocks_indexes",
+		Up: []string{
+			`CREATE INDEX IF NOT EXISTS idx_chain_work_id ON blocks (chain_work DESC, id ASC)`,
+			`CREATE INDEX IF NOT EXISTS idx_chain_work_peer_id ON blocks (chain_work DESC, peer_id ASC, id ASC)`,
+			`CREATE INDEX IF NOT EXISTS idx_mined_set ON blocks (mined_set) WHERE mined_set = false`,
+			`CREATE INDEX IF NOT EXISTS idx_subtrees_set ON blocks (subtrees_set) WHERE subtrees_set = false`,
+		},
+		Down: []string{
+			`DROP INDEX IF EXISTS idx_subtrees_set`,
+			`DROP INDEX IF EXISTS idx_mined_set`,
+			`DROP INDEX IF EXISTS idx_chain_work_peer_id`,
+			`DROP INDEX IF EXISTS idx_chain_work_id`,
+		},
+	},
+}
+
+// NewMigrator returns the schema migrator of the blockchain database
+func NewMigrator(logger ulogger.Logger, db *usql.DB, engine util.SQLEngine) (*migrate.Migrator, error) {
+	switch engine {
+	case util.Postgres:
+		return migrate.New(logger, db, engine, migrationsTable, postgresMigrations)
+	case util.Sqlite, util.SqliteMemory:
+		return migrate.New(logger, db, engine, migrationsTable, sqliteMigrations)
+	default:
+		return nil, errors.NewStorageError("unknown database engine: %s", engine)
+	}
+}
+
+// migrateSchema applies the schema migrations not applied to the database yet. In seeder mode only the initial
+// schema is applied, creating the indexes during a bulk import slows it down.
+func migrateSchema(ctx context.Context, logger ulogger.Logger, db *usql.DB, engine util.SQLEngine, seeder bool) error {
+	migrator, err := NewMigrator(logger, db, engine)
+	if err != nil {
+		return err
+	}
+
+	version, _, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version == 0 && engine != util.Postgres {
+		if err = addLegacySqliteColumns(db); err != nil {
+			return err
+		}
+	}
+
+	if seeder {
+		if version >= migrationInitialSchema {
+			return nil
+		}
+
+		return migrator.MigrateTo(ctx, migrationInitialSchema)
+	}
+
+	return migrator.Up(ctx)
+}
+
+// addLegacySqliteColumns adds the columns added to the blocks table before the schema was managed by migrations to a
+// legacy sqlite database, sqlite has no ADD COLUMN IF NOT EXISTS
+func addLegacySqliteColumns(db *usql.DB) error {
+	var table string
+
+	err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'blocks'`).Scan(&table)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return errors.NewStorageError("could not check for the blocks table", err)
+	}
+
+	err = db.QueryRow(`SELECT name FROM pragma_table_info('blocks') WHERE name = 'processed_at'`).Scan(new(string))
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err = db.Exec(`ALTER TABLE blocks ADD COLUMN processed_at TEXT NULL`); err != nil {
+			return errors.NewStorageError("could not add processed_at column to blocks table", err)
+		}
+	} else if err != nil {
+		return errors.NewStorageError("could not check for processed_at column in blocks table", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package sql
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/bsv-blockchain/teranode/util/usql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sqliteObjectExists(t *testing.T, db *usql.DB, kind, name string) bool {
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = $1 AND name = $2`, kind, name).Scan(&count))
+
+	return count > 0
+}
+
+func schemaVersion(t *testing.T, db *usql.DB) uint32 {
+	migrator, err := NewMigrator(ulogger.TestLogger{}, db, util.Sqlite)
+	require.NoError(t, err)
+
+	version, dirty, err := migrator.Version(context.Background())
+	require.NoError(t, err)
+	require.False(t, dirty)
+
+	return version
+}
+
+func TestMigrateSchema(t *testing.T) {
+	t.Run("new database", func(t *testing.T) {
+		storeURL, err := url.Parse("sqlitememory:///")
+		require.NoError(t, err)
+
+		s, err := New(ulogger.TestLogger{}, storeURL, test.CreateBaseTestSettings(t))
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		assert.Equal(t, uint32(len(sqliteMigrations)), schemaVersion(t, s.GetDB()))
+		assert.True(t, sqliteObjectExists(t, s.GetDB(), "index", "idx_chain_work_id"))
+	})
+
+	t.Run("seeder mode applies the initial schema only", func(t *testing.T) {
+		tSettings := test.CreateBaseTestSettings(t)
+		tSettings.DataFolder = t.TempDir()
+
+		storeURL, err := url.Parse("sqlite:///blockchain?seeder=true")
+		require.NoError(t, err)
+
+		s, err := New(ulogger.TestLogger{}, storeURL, tSettings)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint32(migrationInitialSchema), schemaVersion(t, s.GetDB()))
+		assert.False(t, sqliteObjectExists(t, s.GetDB(), "index", "idx_chain_work_id"))
+		require.NoError(t, s.Close())
+
+		storeURL, err = url.Parse("sqlite:///blockchain")
+		require.NoError(t, err)
+
+		s, err = New(ulogger.TestLogger{}, storeURL, tSettings)
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		assert.Equal(t, uint32(len(sqliteMigrations)), schemaVersion(t, s.GetDB()))
+		assert.True(t, sqliteObjectExists(t, s.GetDB(), "index", "idx_chain_work_id"))
+	})
+
+	t.Run("legacy database is adopted", func(t *testing.T) {
+		tSettings := test.CreateBaseTestSettings(t)
+		tSettings.DataFolder = t.TempDir()
+
+		storeURL, err := url.Parse("sqlite:///blockchain")
+		require.NoError(t, err)
+
+		db, err := util.InitSQLDB(ulogger.TestLogger{}, storeURL, tSettings)
+		require.NoError(t, err)
+
+		// the schema created before the migrations, without the processed_at column
+		for _, statement := range []string{
+			`CREATE TABLE state (key VARCHAR(32) PRIMARY KEY, data BLOB NOT NULL, inserted_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP, updated_at TEXT NULL)`,
+			`CREATE TABLE blocks (id INTEGER PRIMARY KEY AUTOINCREMENT, parent_id INTEGER REFERENCES blocks(id), version INTEGER NOT NULL,
+				hash BLOB NOT NULL, previous_hash BLOB NOT NULL, merkle_root BLOB NOT NULL, block_time BIGINT NOT NULL, n_bits BLOB NOT NULL,
+				nonce BIGINT NOT NULL, height BIGINT NOT NULL, chain_work BLOB NOT NULL, tx_count BIGINT NOT NULL, size_in_bytes BIGINT NOT NULL,
+				subtree_count BIGINT NOT NULL, subtrees BLOB NOT NULL, coinbase_tx BLOB NOT NULL, invalid BOOLEAN NOT NULL DEFAULT FALSE,
+				mined_set BOOLEAN NOT NULL DEFAULT FALSE, subtrees_set BOOLEAN NOT NULL DEFAULT FALSE, peer_id TEXT NOT NULL,
+				inserted_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP)`,
+			`CREATE UNIQUE INDEX ux_blocks_hash ON blocks (hash)`,
+			`CREATE INDEX idx_chain_work_id ON blocks (chain_work DESC, id ASC)`,
+		} {
+			_, err = db.Exec(statement)
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, db.Close())
+
+		s, err := New(ulogger.TestLogger{}, storeURL, tSettings)
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		assert.Equal(t, uint32(len(sqliteMigrations)), schemaVersion(t, s.GetDB()))
+		assert.True(t, sqliteObjectExists(t, s.GetDB(), "table", "audit_log"))
+		assert.True(t, sqliteObjectExists(t, s.GetDB(), "index", "idx_mined_set"))
+
+		var column string
+		require.NoError(t, s.GetDB().QueryRow(`SELECT name FROM pragma_table_info('blocks') WHERE name = 'processed_at'`).Scan(&column))
+	})
+
+	t.Run("rolled back and migrated again", func(t *testing.T) {
+		tSettings := test.CreateBaseTestSettings(t)
+		tSettings.DataFolder = t.TempDir()
+
+		storeURL, err := url.Parse("sqlite:///blockchain")
+		require.NoError(t, err)
+
+		s, err := New(ulogger.TestLogger{}, storeURL, tSettings)
+		require.NoError(t, err)
+
+		migrator, err := NewMigrator(ulogger.TestLogger{}, s.GetDB(), util.Sqlite)
+		require.NoError(t, err)
+		require.NoError(t, migrator.MigrateTo(context.Background(), migrationInitialSchema))
+
+		assert.Equal(t, uint32(migrationInitialSchema), schemaVersion(t, s.GetDB()))
+		assert.False(t, sqliteObjectExists(t, s.GetDB(), "index", "idx_chain_work_id"))
+		require.NoError(t, s.Close())
+
+		s, err = New(ulogger.TestLogger{}, storeURL, tSettings)
+		require.NoError(t, err)
+
+		defer s.Close()
+
+		assert.Equal(t, uint32(len(sqliteMigrations)), schemaVersion(t, s.GetDB()))
+		assert.True(t, sqliteObjectExists(t, s.GetDB(), "index", "idx_chain_work_id"))
+	})
+}
+
+func TestMigrations(t *testing.T) {
+	require.Len(t, sqliteMigrations, len(postgresMigrations), "the engines have the same schema versions")
+
+	for i, migration := range postgresMigrations {
+		assert.Equal(t, migration.Version, sqliteMigrations[i].Version)
+		assert.Equal(t, migration.Name, sqliteMigrations[i].Name)
+		assert.NotEmpty(t, migration.Down, migration.Name)
+		assert.NotEmpty(t, sqliteMigrations[i].Down, migration.Name)
+	}
+}
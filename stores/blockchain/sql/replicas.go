@@ -0,0 +1,211 @@
+// This file implements the routing of read-only lookups to read replicas of the blockchain store.
+//
+// Lookups of a single block or header by hash are sent to a healthy read replica, round-robin, while all writes and
+// all other queries stay on the primary. As replicas lag behind the primary, a lookup that finds nothing on a replica
+// is repeated on the primary, so a block that was just stored is always found. A replica that fails a query is
+// skipped until the periodic health check can reach it again, and lookups fall back to the primary while no replica
+// is healthy.
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/usql"
+)
+
+// replica is a read replica of the blockchain store
+type replica struct {
+	name       string // host and database, without credentials
+	db         *usql.DB
+	statements *statements
+	healthy    atomic.Bool
+}
+
+// readReplicas holds the read replicas of the blockchain store and checks their health
+type readReplicas struct {
+	logger   ulogger.Logger
+	replicas []*replica
+	next     atomic.Uint64
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// newReadReplicas connects to the read replicas and starts checking their health. It returns nil when no replicas
+// are configured.
+func newReadReplicas(logger ulogger.Logger, engine util.SQLEngine, tSettings *settings.Settings) (*readReplicas, error) {
+	if len(tSettings.BlockChain.StoreReadReplicas) == 0 {
+		return nil, nil
+	}
+
+	if engine != util.Postgres {
+		return nil, errors.NewConfigurationError("read replicas are only supported for postgres blockchain stores, not %s", engine)
+	}
+
+	r := &readReplicas{logger: logger}
+
+	for _, replicaURL := range tSettings.BlockChain.StoreReadReplicas {
+		u, err := url.Parse(replicaURL)
+		if err != nil {
+			r.closeReplicas()
+			return nil, errors.NewConfigurationError("invalid blockchain store read replica URL", err)
+		}
+
+		if util.SQLEngine(u.Scheme) != util.Postgres {
+			r.closeReplicas()
+			return nil, errors.NewConfigurationError("blockchain store read replica %s%s must be a postgres URL", u.Host, u.Path)
+		}
+
+		db, err := util.InitPostgresDB(logger, u, tSettings)
+		if err != nil {
+			r.closeReplicas()
+			return nil, errors.NewStorageError("failed to init blockchain store read replica %s%s", u.Host, u.Path, err)
+		}
+
+		r.replicas = append(r.replicas, &replica{name: u.Host + u.Path, db: db, statements: newStatements(logger, db, tSettings)})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	// replicas only receive lookups after the first successful health check
+	r.checkHealth(ctx)
+
+	interval := tSettings.BlockChain.StoreReplicaHealthCheckInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.checkHealth(ctx)
+			}
+		}
+	}()
+
+	logger.Infof("[readReplicas] routing block and header lookups to %d read replicas", len(r.replicas))
+
+	return r, nil
+}
+
+// checkHealth pings every replica and updates whether it receives lookups
+func (r *readReplicas) checkHealth(ctx context.Context) {
+	for _, rep := range r.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err := rep.db.PingContext(pingCtx)
+
+		cancel()
+
+		if err != nil {
+			r.markUnhealthy(rep, err)
+			continue
+		}
+
+		if !rep.healthy.Swap(true) {
+			r.logger.Infof("[readReplicas] read replica %s is healthy, routing lookups to it", rep.name)
+		}
+	}
+}
+
+// markUnhealthy stops routing lookups to a replica until the health check can reach it again
+func (r *readReplicas) markUnhealthy(rep *replica, err error) {
+	if rep.healthy.Swap(false) {
+		r.logger.Warnf("[readReplicas] read replica %s is unhealthy, routing lookups to the primary: %v", rep.name, err)
+	}
+}
+
+// pick returns the next healthy replica, or nil when no replica is healthy
+func (r *readReplicas) pick() *replica {
+	if r == nil {
+		return nil
+	}
+
+	count := uint64(len(r.replicas))
+	start := r.next.Add(1)
+
+	for i := uint64(0); i < count; i++ {
+		if rep := r.replicas[(start+i)%count]; rep.healthy.Load() {
+			return rep
+		}
+	}
+
+	return nil
+}
+
+// close stops the health checks and closes the replica connections
+func (r *readReplicas) close() error {
+	if r == nil {
+		return nil
+	}
+
+	r.cancel()
+	r.wg.Wait()
+
+	return r.closeReplicas()
+}
+
+func (r *readReplicas) closeReplicas() error {
+	var firstErr error
+
+	for _, rep := range r.replicas {
+		if err := rep.statements.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		if err := rep.db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// readRow is the single row result of a read-only lookup that is scanned from a read replica when one is healthy
+type readRow struct {
+	s     *SQL
+	ctx   context.Context
+	query string
+	args  []interface{}
+}
+
+// readQueryRowContext runs a read-only lookup of a single row on a healthy read replica, or on the primary when no
+// replicas are configured or healthy. Only use it for lookups that tolerate the replication lag, the row is
+// looked up on the primary when the replica does not have it.
+func (s *SQL) readQueryRowContext(ctx context.Context, query string, args ...interface{}) *readRow {
+	return &readRow{s: s, ctx: ctx, query: query, args: args}
+}
+
+// Scan copies the columns of the row into dest, like sql.Row.Scan
+func (r *readRow) Scan(dest ...interface{}) error {
+	if rep := r.s.replicas.pick(); rep != nil {
+		err := rep.statements.queryRowContext(r.ctx, r.query, r.args...).Scan(dest...)
+		if err == nil {
+			return nil
+		}
+
+		if !errors.Is(err, sql.ErrNoRows) && r.ctx.Err() == nil {
+			r.s.replicas.markUnhealthy(rep, err)
+		}
+	}
+
+	return r.s.queryRowContext(r.ctx, r.query, r.args...).Scan(dest...)
+}
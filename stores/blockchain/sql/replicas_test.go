@@ -0,0 +1,122 @@
+package sql
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReplicas(t *testing.T) {
+	tSettings := test.CreateBaseTestSettings(t)
+
+	newStore := func(t *testing.T) *SQL {
+		storeURL, err := url.Parse("sqlitememory:///")
+		require.NoError(t, err)
+
+		s, err := New(ulogger.TestLogger{}, storeURL, tSettings)
+		require.NoError(t, err)
+
+		return s
+	}
+
+	// withReplica routes the lookups of the primary to the database of the replica store
+	withReplica := func(primary, replicaStore *SQL) *replica {
+		rep := &replica{name: "replica", db: replicaStore.db, statements: replicaStore.statements}
+		rep.healthy.Store(true)
+
+		primary.replicas = &readReplicas{logger: ulogger.TestLogger{}, replicas: []*replica{rep}}
+
+		return rep
+	}
+
+	t.Run("lookups use a healthy replica", func(t *testing.T) {
+		primary, replicaStore := newStore(t), newStore(t)
+
+		_, _, err := replicaStore.StoreBlock(context.Background(), block1, "")
+		require.NoError(t, err)
+
+		rep := withReplica(primary, replicaStore)
+
+		exists, err := primary.GetBlockExists(context.Background(), block1.Hash())
+		require.NoError(t, err)
+		assert.True(t, exists, "block only exists on the replica")
+
+		rep.healthy.Store(false)
+
+		_, err = primary.GetHeader(context.Background(), block1.Hash())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrBlockNotFound), "unhealthy replica is skipped")
+	})
+
+	t.Run("rows missing on the replica are looked up on the primary", func(t *testing.T) {
+		primary, replicaStore := newStore(t), newStore(t)
+
+		_, _, err := primary.StoreBlock(context.Background(), block1, "")
+		require.NoError(t, err)
+
+		rep := withReplica(primary, replicaStore)
+
+		header, _, err := primary.GetBlockHeader(context.Background(), block1.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, block1.Header.Hash(), header.Hash())
+		assert.True(t, rep.healthy.Load(), "a replica lagging behind is not unhealthy")
+	})
+
+	t.Run("failing replica is marked unhealthy", func(t *testing.T) {
+		primary, replicaStore := newStore(t), newStore(t)
+
+		rep := withReplica(primary, replicaStore)
+		require.NoError(t, replicaStore.db.Close())
+
+		block, _, err := primary.GetBlock(context.Background(), tSettings.ChainCfgParams.GenesisHash)
+		require.NoError(t, err)
+		assert.Equal(t, tSettings.ChainCfgParams.GenesisHash, block.Hash())
+		assert.False(t, rep.healthy.Load())
+
+		primary.replicas.checkHealth(context.Background())
+		assert.False(t, rep.healthy.Load(), "closed replica stays unhealthy")
+	})
+
+	t.Run("pick skips unhealthy replicas", func(t *testing.T) {
+		a, b := &replica{name: "a"}, &replica{name: "b"}
+		b.healthy.Store(true)
+
+		r := &readReplicas{replicas: []*replica{a, b}}
+		assert.Same(t, b, r.pick())
+		assert.Same(t, b, r.pick())
+
+		b.healthy.Store(false)
+		assert.Nil(t, r.pick())
+
+		var none *readReplicas
+		assert.Nil(t, none.pick())
+		assert.NoError(t, none.close())
+	})
+
+	t.Run("configuration", func(t *testing.T) {
+		replicaSettings := test.CreateBaseTestSettings(t)
+
+		r, err := newReadReplicas(ulogger.TestLogger{}, util.Postgres, replicaSettings)
+		require.NoError(t, err)
+		assert.Nil(t, r, "no replicas configured")
+
+		replicaSettings.BlockChain.StoreReadReplicas = []string{"postgres://replica:5432/teranode"}
+
+		_, err = newReadReplicas(ulogger.TestLogger{}, util.Sqlite, replicaSettings)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrConfiguration))
+
+		replicaSettings.BlockChain.StoreReadReplicas = []string{"sqlite:///replica"}
+
+		_, err = newReadReplicas(ulogger.TestLogger{}, util.Postgres, replicaSettings)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrConfiguration))
+	})
+}
@@ -9,7 +9,7 @@
 // - Support for chain reorganization
 // - Block validation status tracking
 // - Chain state management
-// - Database schema creation and migration
+// - Versioned, checksummed schema migrations with rollback
 // - Performance optimizations for bulk imports
 //
 // The SQL store can be configured with different caching strategies and
@@ -32,6 +32,7 @@ import (
 	"github.com/bsv-blockchain/go-chaincfg"
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockchain/checkpoints"
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/stores/blockchain/options"
 	"github.com/bsv-blockchain/teranode/ulogger"
@@ -59,12 +60,18 @@ type SQL struct {
 	cacheTTL time.Duration
 	// chainParams contains the blockchain network parameters (mainnet, testnet, etc.)
 	chainParams *chaincfg.Params
+	// checkpoints holds the hard checkpoints blocks are verified against before being stored
+	checkpoints *checkpoints.Registry
+	// replicas holds the read replicas block and header lookups are routed to, nil when none are configured
+	replicas *readReplicas
+	// statements holds the prepared statements of the hot-path lookups on the primary
+	statements *statements
 }
 
 // New creates and initializes a new SQL blockchain store instance.
 //
 // This constructor function establishes a database connection based on the provided URL,
-// initializes the appropriate schema for the selected SQL engine, and configures caching
+// applies the schema migrations of the selected SQL engine, and configures caching
 // and performance settings. For PostgreSQL, it applies optimizations based on whether
 // the store is being used for bulk imports (seeder mode).
 //
@@ -84,21 +91,24 @@ func New(logger ulogger.Logger, storeURL *url.URL, tSettings *settings.Settings)
 		return nil, errors.NewStorageError("failed to init sql db", err)
 	}
 
+	const trueStr = "true"
+
+	// The 'seeder' query parameter is used to optimize bulk imports by bypassing index creation.
+	// Creating indexes during data insertion can significantly slow down the process, so only the
+	// initial schema migration is applied when 'seeder=true' is specified in the query parameters.
+	seeder := storeURL.Query().Get("seeder") == trueStr
+
 	switch util.SQLEngine(storeURL.Scheme) {
 	case util.Postgres:
-		const trueStr = "true"
-
 		// offOrOn := "on"
 		trueOrFalse := trueStr
 
-		// The 'seeder' query parameter is used to optimize bulk imports by bypassing index creation.
-		// Creating indexes during data insertion can significantly slow down the process, so we skip
-		// index creation when 'seeder=true' is specified in the query parameters.
-		if err = createPostgresSchema(db, storeURL.Query().Get("seeder") != trueStr); err != nil {
-			return nil, errors.NewStorageError("failed to create postgres schema", err)
+		if err = migrateSchema(context.Background(), logger, db, util.Postgres, seeder); err != nil {
+			_ = db.Close()
+			return nil, errors.NewStorageError("failed to migrate postgres schema", err)
 		}
 
-		if storeURL.Query().Get("seeder") == trueStr {
+		if seeder {
 			// offOrOn = "off"
 			trueOrFalse = "false"
 
@@ -131,14 +141,25 @@ func New(logger ulogger.Logger, storeURL *url.URL, tSettings *settings.Settings)
 		}
 
 	case util.Sqlite, util.SqliteMemory:
-		if err = createSqliteSchema(db); err != nil {
-			return nil, errors.NewStorageError("failed to create sqlite schema", err)
+		if err = migrateSchema(context.Background(), logger, db, util.SQLEngine(storeURL.Scheme), seeder); err != nil {
+			_ = db.Close()
+			return nil, errors.NewStorageError("failed to migrate sqlite schema", err)
 		}
 
 	default:
 		return nil, errors.NewStorageError("unknown database engine: %s", storeURL.Scheme)
 	}
 
+	checkpointRegistry, err := checkpoints.ForSettings(tSettings)
+	if err != nil {
+		return nil, errors.NewStorageError("failed to load checkpoints", err)
+	}
+
+	replicas, err := newReadReplicas(logger, util.SQLEngine(storeURL.Scheme), tSettings)
+	if err != nil {
+		return nil, err
+	}
+
 	s := &SQL{
 		db:            db,
 		engine:        util.SQLEngine(storeURL.Scheme),
@@ -146,10 +167,14 @@ func New(logger ulogger.Logger, storeURL *url.URL, tSettings *settings.Settings)
 		responseCache: NewGenerationalCache(),
 		cacheTTL:      2 * time.Minute,
 		chainParams:   tSettings.ChainCfgParams,
+		checkpoints:   checkpointRegistry,
+		replicas:      replicas,
+		statements:    newStatements(logger, db, tSettings),
 	}
 
 	err = s.insertGenesisTransaction(logger)
 	if err != nil {
+		_ = replicas.close()
 		return nil, errors.NewStorageError("failed to insert genesis transaction", err)
 	}
 
@@ -175,230 +200,17 @@ func (s *SQL) GetDBEngine() util.SQLEngine {
 }
 
 func (s *SQL) Close() error {
-	return s.db.Close()
-}
-
-func createPostgresSchema(db *usql.DB, withIndexes bool) error {
-	if _, err := db.Exec(`
-      CREATE TABLE IF NOT EXISTS state (
-	    key            VARCHAR(32) PRIMARY KEY
-	    ,data          BYTEA NOT NULL
-        ,inserted_at   TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
-        ,updated_at    TIMESTAMPTZ NULL
-	  );
-	`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create state table", err)
+	if err := s.replicas.close(); err != nil {
+		s.logger.Warnf("failed to close read replicas: %v", err)
 	}
 
-	if _, err := db.Exec(`
-      CREATE TABLE IF NOT EXISTS blocks (
-	    id              BIGSERIAL PRIMARY KEY
-		,parent_id	    BIGSERIAL REFERENCES blocks(id)
-        ,version        INTEGER NOT NULL
-	    ,hash           BYTEA NOT NULL
-	    ,previous_hash  BYTEA NOT NULL
-	    ,merkle_root    BYTEA NOT NULL
-        ,block_time     BIGINT NOT NULL
-        ,n_bits         BYTEA NOT NULL
-        ,nonce          BIGINT NOT NULL
-	    ,height         BIGINT NOT NULL
-        ,chain_work     BYTEA NOT NULL
-		,tx_count       BIGINT NOT NULL
-		,size_in_bytes  BIGINT NOT NULL
-		,subtree_count  BIGINT NOT NULL
-        ,subtrees       BYTEA NOT NULL
-        ,coinbase_tx    BYTEA NOT NULL
-		,invalid	    BOOLEAN NOT NULL DEFAULT FALSE
-        ,mined_set 	    BOOLEAN NOT NULL DEFAULT FALSE
-        ,subtrees_set   BOOLEAN NOT NULL DEFAULT FALSE
-    	,peer_id	    TEXT NOT NULL
-    	,inserted_at    TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
-		,processed_at   TIMESTAMPTZ NULL
-	  );
-	`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create blocks table", err)
-	}
-
-	// change the blocks table peer_id column to TEXT, if it is not already
-	_, _ = db.Exec(`ALTER TABLE blocks ALTER COLUMN peer_id TYPE TEXT;`)
-
-	// add the processed_at column to the blocks table if it does not exist
-	err := db.QueryRow("SELECT column_name FROM information_schema.columns WHERE table_name='blocks' AND column_name='processed_at'").Scan(new(string))
-	if err != nil {
-		if err == sql.ErrNoRows {
-			_, err := db.Exec(`ALTER TABLE blocks ADD COLUMN processed_at TIMESTAMPTZ NULL;`)
-			if err != nil {
-				_ = db.Close()
-				return errors.NewStorageError("could not add processed_at column to blocks table", err)
-			}
-		} else {
-			return errors.NewStorageError("could not check for processed_at column in blocks table", err)
+	if s.statements != nil {
+		if err := s.statements.close(); err != nil {
+			s.logger.Warnf("failed to close prepared statements: %v", err)
 		}
 	}
 
-	if _, err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS ux_blocks_hash ON blocks (hash);`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create ux_blocks_hash index", err)
-	}
-
-	if withIndexes {
-		if _, err := db.Exec(`DROP INDEX IF EXISTS pux_blocks_height;`); err != nil {
-			_ = db.Close()
-			return errors.NewStorageError("could not drop pux_blocks_height index", err)
-		}
-
-		if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_chain_work_id ON blocks (chain_work DESC, id ASC);`); err != nil {
-			_ = db.Close()
-			return errors.NewStorageError("could not create idx_chain_work_id index", err)
-		}
-
-		if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_chain_work_peer_id ON blocks (chain_work DESC, peer_id ASC, id ASC);`); err != nil {
-			_ = db.Close()
-			return errors.NewStorageError("could not create idx_chain_work_peer_id index", err)
-		}
-
-		if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_mined_set ON blocks (mined_set) WHERE mined_set = false;`); err != nil {
-			_ = db.Close()
-			return errors.NewStorageError("could not create idx_mined_set index", err)
-		}
-
-		if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_subtrees_set ON blocks (subtrees_set) WHERE subtrees_set = false;`); err != nil {
-			_ = db.Close()
-			return errors.NewStorageError("could not create idx_subtrees_set index", err)
-		}
-
-		if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_height ON blocks (height);`); err != nil {
-			_ = db.Close()
-			return errors.NewStorageError("could not create idx_height index", err)
-		}
-
-		if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_parent_id ON blocks (parent_id);`); err != nil {
-			_ = db.Close()
-			return errors.NewStorageError("could not create idx_parent_id index", err)
-		}
-	}
-
-	if _, err := db.Exec(`
-		CREATE OR REPLACE FUNCTION reverse_bytes_iter(bytes bytea, length int, midpoint int, index int)
-		RETURNS bytea AS
-		$$
-		  SELECT CASE WHEN index >= midpoint THEN bytes ELSE
-			reverse_bytes_iter(
-			  set_byte(
-				set_byte(bytes, index, get_byte(bytes, length-index)),
-				length-index, get_byte(bytes, index)
-			  ),
-			  length, midpoint, index + 1
-			)
-		  END;
-		$$ LANGUAGE SQL IMMUTABLE;
-   `); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create block_transactions_map table", err)
-	}
-
-	if _, err := db.Exec(`
-		CREATE OR REPLACE FUNCTION reverse_bytes(bytes bytea) RETURNS bytea AS
-		'SELECT reverse_bytes_iter(bytes, octet_length(bytes)-1, octet_length(bytes)/2, 0)'
-		LANGUAGE SQL IMMUTABLE;
-	`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create block_transactions_map table", err)
-	}
-
-	return nil
-}
-
-func createSqliteSchema(db *usql.DB) error {
-	if _, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS state (
-		 key            VARCHAR(32) PRIMARY KEY
-	    ,data           BLOB NOT NULL
-        ,inserted_at    TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
-        ,updated_at     TEXT NULL
-	  );
-	`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create blocks table", err)
-	}
-
-	if _, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS blocks (
-		 id           INTEGER PRIMARY KEY AUTOINCREMENT
-		,parent_id	  INTEGER REFERENCES blocks(id)
-        ,version        INTEGER NOT NULL
-	    ,hash           BLOB NOT NULL
-	    ,previous_hash  BLOB NOT NULL
-	    ,merkle_root    BLOB NOT NULL
-        ,block_time		BIGINT NOT NULL
-        ,n_bits         BLOB NOT NULL
-        ,nonce          BIGINT NOT NULL
-	    ,height         BIGINT NOT NULL
-        ,chain_work     BLOB NOT NULL
-		,tx_count       BIGINT NOT NULL
-		,size_in_bytes  BIGINT NOT NULL
-		,subtree_count  BIGINT NOT NULL
-		,subtrees       BLOB NOT NULL
-        ,coinbase_tx    BLOB NOT NULL
-		,invalid	    BOOLEAN NOT NULL DEFAULT FALSE
-	    ,mined_set 	    BOOLEAN NOT NULL DEFAULT FALSE
-        ,subtrees_set   BOOLEAN NOT NULL DEFAULT FALSE
-     	,peer_id	    TEXT NOT NULL
-        ,inserted_at    TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
-		,processed_at   TEXT NULL
-	  );
-	`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create blocks table", err)
-	}
-
-	// add the processed_at column to the blocks table if it does not exist
-	err := db.QueryRow("SELECT name FROM pragma_table_info('blocks') WHERE name='processed_at'").Scan(new(string))
-	if err != nil {
-		if err == sql.ErrNoRows {
-			_, err := db.Exec(`ALTER TABLE blocks ADD COLUMN processed_at TEXT NULL;`)
-			if err != nil {
-				_ = db.Close()
-				return errors.NewStorageError("could not add processed_at column to blocks table", err)
-			}
-		} else {
-			return errors.NewStorageError("could not check for processed_at column in blocks table", err)
-		}
-	}
-
-	if _, err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS ux_blocks_hash ON blocks (hash);`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create ux_blocks_hash index", err)
-	}
-
-	if _, err := db.Exec(`DROP INDEX IF EXISTS pux_blocks_height;`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not drop pux_blocks_height index", err)
-	}
-
-	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_chain_work_id ON blocks (chain_work DESC, id ASC);`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create idx_chain_work_id index", err)
-	}
-
-	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_chain_work_peer_id ON blocks (chain_work DESC, peer_id ASC, id ASC);`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create idx_chain_work_peer_id index", err)
-	}
-
-	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_mined_set ON blocks (mined_set) WHERE mined_set = false;`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create idx_mined_set index", err)
-	}
-
-	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_subtrees_set ON blocks (subtrees_set) WHERE subtrees_set = false;`); err != nil {
-		_ = db.Close()
-		return errors.NewStorageError("could not create idx_subtrees_set index", err)
-	}
-
-	return nil
+	return s.db.Close()
 }
 
 func (s *SQL) insertGenesisTransaction(logger ulogger.Logger) error {
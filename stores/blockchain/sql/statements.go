@@ -0,0 +1,174 @@
+// This file implements the prepared statement cache and the slow query log of the blockchain store.
+//
+// The hot-path lookups of the store are prepared once per query and the prepared statements are reused, so the
+// database does not parse and plan these queries on every call. database/sql prepares a statement again on every
+// connection of the pool it is used on, so the statements follow the pool as connections are opened and closed, and
+// the statements are closed with the store. Postgres also caches the query plan of a prepared statement once it has
+// been executed a few times.
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/usql"
+	"github.com/ordishs/gocore"
+)
+
+// maxPreparedStatements limits the number of prepared statements of a database, queries built with a variable number
+// of arguments would otherwise grow the cache without bounds
+const maxPreparedStatements = 256
+
+// statementStat adds the timings of prepared statements to the same stats as the queries run through usql
+var statementStat = gocore.NewStat("SQL")
+
+// statements holds the prepared statements of a database, keyed by query
+type statements struct {
+	db                 *usql.DB
+	logger             ulogger.Logger
+	prepare            bool
+	slowQueryThreshold time.Duration
+
+	mu     sync.RWMutex
+	stmts  map[string]*sql.Stmt
+	closed bool
+}
+
+// newStatements returns the prepared statement cache of a database
+func newStatements(logger ulogger.Logger, db *usql.DB, tSettings *settings.Settings) *statements {
+	initPrometheusMetrics()
+
+	return &statements{
+		db:                 db,
+		logger:             logger,
+		prepare:            tSettings.BlockChain.StorePreparedStatements,
+		slowQueryThreshold: tSettings.BlockChain.StoreSlowQueryThreshold,
+		stmts:              make(map[string]*sql.Stmt),
+	}
+}
+
+// get returns the prepared statement of the query, preparing it on first use. It returns nil when prepared
+// statements are disabled, the cache is full or the query could not be prepared, the query is then run unprepared.
+func (st *statements) get(ctx context.Context, query string) *sql.Stmt {
+	if !st.prepare {
+		return nil
+	}
+
+	st.mu.RLock()
+	stmt, ok := st.stmts[query]
+	st.mu.RUnlock()
+
+	if ok {
+		return stmt
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if stmt, ok = st.stmts[query]; ok {
+		return stmt
+	}
+
+	if st.closed || len(st.stmts) >= maxPreparedStatements {
+		return nil
+	}
+
+	stmt, err := st.db.PrepareContext(ctx, query)
+	if err != nil {
+		st.logger.Debugf("[statements] failed to prepare query, running it unprepared: %v", err)
+		return nil
+	}
+
+	st.stmts[query] = stmt
+
+	prometheusBlockchainSQLPreparedStatements.Inc()
+
+	return stmt
+}
+
+// queryRowContext runs a query that returns at most one row, like sql.DB.QueryRowContext
+func (st *statements) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	defer st.observe(query, start)
+
+	if stmt := st.get(ctx, query); stmt != nil {
+		return stmt.QueryRowContext(ctx, args...)
+	}
+
+	return st.db.DB.QueryRowContext(ctx, query, args...)
+}
+
+// queryContext runs a query that returns rows, like sql.DB.QueryContext
+func (st *statements) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	defer st.observe(query, start)
+
+	if stmt := st.get(ctx, query); stmt != nil {
+		return stmt.QueryContext(ctx, args...)
+	}
+
+	return st.db.DB.QueryContext(ctx, query, args...)
+}
+
+// observe records the timing of a query and logs it when it is slower than the slow query threshold
+func (st *statements) observe(query string, start time.Time) {
+	statementStat.NewStat(query).AddTime(start)
+
+	if st.slowQueryThreshold <= 0 {
+		return
+	}
+
+	if duration := time.Since(start); duration >= st.slowQueryThreshold {
+		prometheusBlockchainSQLSlowQueries.Inc()
+		st.logger.Warnf("[statements] slow query took %s (threshold %s): %s", duration, st.slowQueryThreshold, compactQuery(query))
+	}
+}
+
+// close closes the prepared statements, queries are run unprepared afterwards
+func (st *statements) close() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	var firstErr error
+
+	for query, stmt := range st.stmts {
+		if err := stmt.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		delete(st.stmts, query)
+		prometheusBlockchainSQLPreparedStatements.Dec()
+	}
+
+	st.closed = true
+
+	return firstErr
+}
+
+// compactQuery returns the query on a single line, for logging
+func compactQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// queryRowContext runs a query that returns at most one row on the primary, using a prepared statement
+func (s *SQL) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if s.statements == nil {
+		return s.db.QueryRowContext(ctx, query, args...)
+	}
+
+	return s.statements.queryRowContext(ctx, query, args...)
+}
+
+// queryContext runs a query that returns rows on the primary, using a prepared statement
+func (s *SQL) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if s.statements == nil {
+		return s.db.QueryContext(ctx, query, args...)
+	}
+
+	return s.statements.queryContext(ctx, query, args...)
+}
@@ -0,0 +1,98 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowQueries returns the number of slow queries counted
+func slowQueries(t *testing.T) float64 {
+	var m dto.Metric
+	require.NoError(t, prometheusBlockchainSQLSlowQueries.Write(&m))
+
+	return m.GetCounter().GetValue()
+}
+
+func TestStatements(t *testing.T) {
+	newStore := func(t *testing.T, configure func(tSettings *settings.Settings)) *SQL {
+		tSettings := test.CreateBaseTestSettings(t)
+		configure(tSettings)
+
+		storeURL, err := url.Parse("sqlitememory:///")
+		require.NoError(t, err)
+
+		s, err := New(ulogger.TestLogger{}, storeURL, tSettings)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = s.Close() })
+
+		return s
+	}
+
+	t.Run("lookups are prepared once", func(t *testing.T) {
+		s := newStore(t, func(tSettings *settings.Settings) {})
+
+		for i := 0; i < 3; i++ {
+			_, err := s.GetBlockHeight(context.Background(), s.chainParams.GenesisHash)
+			require.NoError(t, err)
+		}
+
+		assert.Len(t, s.statements.stmts, 1)
+	})
+
+	t.Run("disabled prepared statements", func(t *testing.T) {
+		s := newStore(t, func(tSettings *settings.Settings) {
+			tSettings.BlockChain.StorePreparedStatements = false
+		})
+
+		_, _, err := s.GetBestBlockHeader(context.Background())
+		require.NoError(t, err)
+
+		assert.Empty(t, s.statements.stmts)
+	})
+
+	t.Run("queries are unprepared when the cache is full", func(t *testing.T) {
+		s := newStore(t, func(tSettings *settings.Settings) {})
+
+		for i := 0; i < maxPreparedStatements+10; i++ {
+			var value int
+			require.NoError(t, s.queryRowContext(context.Background(), fmt.Sprintf("SELECT %d", i)).Scan(&value))
+		}
+
+		assert.Len(t, s.statements.stmts, maxPreparedStatements)
+	})
+
+	t.Run("closed statements fall back to unprepared queries", func(t *testing.T) {
+		s := newStore(t, func(tSettings *settings.Settings) {})
+
+		require.NoError(t, s.statements.close())
+
+		var one int
+		require.NoError(t, s.queryRowContext(context.Background(), "SELECT 1").Scan(&one))
+		assert.Equal(t, 1, one)
+		assert.Empty(t, s.statements.stmts)
+	})
+
+	t.Run("slow queries are counted", func(t *testing.T) {
+		s := newStore(t, func(tSettings *settings.Settings) {
+			tSettings.BlockChain.StoreSlowQueryThreshold = time.Nanosecond
+		})
+
+		before := slowQueries(t)
+
+		var one int
+		require.NoError(t, s.queryRowContext(context.Background(), "SELECT 1").Scan(&one))
+
+		assert.Equal(t, before+1, slowQueries(t))
+	})
+}
@@ -0,0 +1,130 @@
+package sql
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+// benchmarkChainLength is the number of blocks stored for the hot query benchmarks
+const benchmarkChainLength = 1_000
+
+// setupBenchmarkStore creates an in-memory store holding a chain of benchmarkChainLength blocks
+func setupBenchmarkStore(b *testing.B) (*SQL, []*model.Block) {
+	b.Helper()
+
+	storeURL, err := url.Parse("sqlitememory:///")
+	require.NoError(b, err)
+
+	s, err := New(ulogger.TestLogger{}, storeURL, test.CreateBaseTestSettings(b))
+	require.NoError(b, err)
+
+	b.Cleanup(func() {
+		s.responseCache.Stop()
+		_ = s.Close()
+	})
+
+	blocks := generateBlocks(b, benchmarkChainLength)
+
+	for _, block := range blocks {
+		_, _, err = s.StoreBlock(context.Background(), block, "")
+		require.NoError(b, err)
+	}
+
+	return s, blocks
+}
+
+// BenchmarkStoreHotQueries measures the most frequently called store queries with a warm response cache
+// ("cached") and with the cache reset before every query ("uncached"), so the numbers show both the
+// database cost of each query and the saving of the cache. The uncached numbers include the cost of
+// the reset, which is negligible compared to the query.
+func BenchmarkStoreHotQueries(b *testing.B) {
+	s, blocks := setupBenchmarkStore(b)
+	ctx := context.Background()
+
+	tip := blocks[len(blocks)-1]
+	middle := blocks[len(blocks)/2]
+
+	queries := []struct {
+		name  string
+		query func() error
+	}{
+		{"GetBestBlockHeader", func() error {
+			_, _, err := s.GetBestBlockHeader(ctx)
+			return err
+		}},
+		{"GetBlockHeader", func() error {
+			_, _, err := s.GetBlockHeader(ctx, middle.Hash())
+			return err
+		}},
+		{"GetBlockExists", func() error {
+			_, err := s.GetBlockExists(ctx, middle.Hash())
+			return err
+		}},
+		{"GetBlockHeight", func() error {
+			_, err := s.GetBlockHeight(ctx, middle.Hash())
+			return err
+		}},
+		{"GetBlockHeaders_100", func() error {
+			_, _, err := s.GetBlockHeaders(ctx, tip.Hash(), 100)
+			return err
+		}},
+		{"GetBlockByHeight", func() error {
+			_, err := s.GetBlockByHeight(ctx, uint32(len(blocks)/2)) //nolint:gosec // the chain length is small
+			return err
+		}},
+		{"GetChainTips", func() error {
+			_, err := s.GetChainTips(ctx)
+			return err
+		}},
+	}
+
+	for _, q := range queries {
+		b.Run(q.name+"/cached", func(b *testing.B) {
+			require.NoError(b, q.query())
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if err := q.query(); err != nil {
+					b.Fatalf("%s failed: %v", q.name, err)
+				}
+			}
+		})
+
+		b.Run(q.name+"/uncached", func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				s.ResetResponseCache()
+
+				if err := q.query(); err != nil {
+					b.Fatalf("%s failed: %v", q.name, err)
+				}
+			}
+		})
+
+		b.Run(q.name+"/cached_parallel", func(b *testing.B) {
+			require.NoError(b, q.query())
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := q.query(); err != nil {
+						b.Errorf("%s failed: %v", q.name, err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
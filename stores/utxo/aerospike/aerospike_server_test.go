@@ -1548,6 +1548,22 @@ func TestSmokeTests(t *testing.T) {
 	})
 }
 
+func TestConformance(t *testing.T) {
+	logger := ulogger.NewErrorTestLogger(t)
+	tSettings := test.CreateBaseTestSettings(t)
+
+	_, store, _, deferFn := initAerospike(t, tSettings, logger)
+
+	t.Cleanup(func() {
+		deferFn()
+	})
+
+	// the cases create transactions of their own, so they share the store of the container
+	tests.Conformance(t, func(t *testing.T) utxo.Store {
+		return store
+	})
+}
+
 func TestCreateZeroSat(t *testing.T) {
 	logger := ulogger.NewErrorTestLogger(t)
 	tSettings := test.CreateBaseTestSettings(t)
@@ -0,0 +1,278 @@
+// Package utxo provides UTXO (Unspent Transaction Output) management for the Bitcoin SV Teranode implementation.
+//
+// This file implements the two-phase block transaction, committing or rolling back all the creates and spends made
+// for a block together, and the undo record reverting them when a reorg removes the block.
+package utxo
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/stores/utxo/meta"
+)
+
+// blockTransactionState is the state of a block transaction
+type blockTransactionState int
+
+const (
+	blockTransactionOpen blockTransactionState = iota
+	blockTransactionCommitted
+	blockTransactionRolledBack
+)
+
+// BlockTransaction groups the creates and spends made for a block in the UTXO store, so that they are committed or
+// rolled back together when the validation of the block fails midway.
+//
+// The store only writes single records atomically, so the block transaction is made in two phases:
+//
+//   - 1: Create stores the transactions locked, so their outputs cannot be spent by transactions outside the block,
+//     and Spend records the spends made. Spends of the block ignore the locked flag to spend the outputs of
+//     transactions created earlier in the same block.
+//   - 2: Commit unlocks the created transactions, making the outputs spendable. Rollback instead unspends the
+//     recorded spends and deletes the created transactions.
+//
+// Transactions that were already in the store when the block transaction created them are not owned by the block:
+// they are not unlocked on commit, nor deleted on rollback, nor are their spends reversed. A block transaction is safe for concurrent use.
+type BlockTransaction struct {
+	store       Store
+	mu          sync.Mutex
+	state       blockTransactionState
+	created     []chainhash.Hash            // transactions created by the block transaction, unlocked on commit, deleted on rollback
+	existing    map[chainhash.Hash]struct{} // transactions that were already in the store
+	spends      map[chainhash.Hash][]*Spend // spends per spending transaction, reversed on rollback
+	spendsOrder []chainhash.Hash            // spending transactions in the order they were spent
+}
+
+// BeginBlockTransaction starts a block transaction on the store
+func BeginBlockTransaction(store Store) *BlockTransaction {
+	return &BlockTransaction{
+		store:    store,
+		existing: make(map[chainhash.Hash]struct{}),
+		spends:   make(map[chainhash.Hash][]*Spend),
+	}
+}
+
+// Create stores the transaction locked as part of the block transaction. A transaction that already exists returns
+// the tx exists error of the store, like Create of the store, and is left as it is on commit and rollback.
+func (t *BlockTransaction) Create(ctx context.Context, tx *bt.Tx, blockHeight uint32, opts ...CreateOption) (*meta.Data, error) {
+	if err := t.checkOpen(); err != nil {
+		return nil, err
+	}
+
+	lockedOpts := make([]CreateOption, 0, len(opts)+1)
+	lockedOpts = append(lockedOpts, opts...)
+
+	txMeta, err := t.store.Create(ctx, tx, blockHeight, append(lockedOpts, WithLocked(true))...)
+	if err != nil && !errors.Is(err, errors.ErrTxExists) {
+		return nil, err
+	}
+
+	txHash := *tx.TxIDChainHash()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		t.existing[txHash] = struct{}{}
+	} else {
+		t.created = append(t.created, txHash)
+	}
+
+	return txMeta, err
+}
+
+// Spend spends the inputs of the transaction as part of the block transaction. Spends that fail are reversed by the
+// store itself, only the successful spends are recorded.
+func (t *BlockTransaction) Spend(ctx context.Context, tx *bt.Tx, blockHeight uint32, ignoreFlags ...IgnoreFlags) ([]*Spend, error) {
+	if err := t.checkOpen(); err != nil {
+		return nil, err
+	}
+
+	spends, err := t.store.Spend(ctx, tx, blockHeight, ignoreFlags...)
+	if err != nil {
+		return spends, err
+	}
+
+	txHash := *tx.TxIDChainHash()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.spends[txHash]; !ok {
+		t.spendsOrder = append(t.spendsOrder, txHash)
+	}
+
+	t.spends[txHash] = append(t.spends[txHash], spends...)
+
+	return spends, nil
+}
+
+// Commit ends the block transaction by unlocking the transactions it created, making their outputs spendable
+func (t *BlockTransaction) Commit(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != blockTransactionOpen {
+		return errors.NewStateError("[BlockTransaction] cannot commit a block transaction that already ended")
+	}
+
+	if len(t.created) > 0 {
+		if err := t.store.SetLocked(ctx, t.created, false); err != nil {
+			// the block transaction stays open, the commit can be retried or the block transaction rolled back
+			return errors.NewStorageError("[BlockTransaction] failed to unlock %d transactions", len(t.created), err)
+		}
+	}
+
+	t.state = blockTransactionCommitted
+
+	return nil
+}
+
+// Rollback ends the block transaction by unspending the recorded spends, newest first, and deleting the created
+// transactions. Rollback after Commit does nothing, so it can be deferred to roll back a block transaction that did
+// not commit. Every spend and create is undone, even when some fail; the first error is returned.
+func (t *BlockTransaction) Rollback(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != blockTransactionOpen {
+		return nil
+	}
+
+	t.state = blockTransactionRolledBack
+
+	var firstErr error
+
+	for i := len(t.spendsOrder) - 1; i >= 0; i-- {
+		txHash := t.spendsOrder[i]
+
+		if _, ok := t.existing[txHash]; ok {
+			continue
+		}
+
+		if err := t.store.Unspend(ctx, t.spends[txHash]); err != nil && firstErr == nil {
+			firstErr = errors.NewStorageError("[BlockTransaction] failed to unspend the spends of tx %s", txHash.String(), err)
+		}
+	}
+
+	for i := len(t.created) - 1; i >= 0; i-- {
+		if err := t.store.Delete(ctx, &t.created[i]); err != nil && !errors.Is(err, errors.ErrTxNotFound) && firstErr == nil {
+			firstErr = errors.NewStorageError("[BlockTransaction] failed to delete tx %s", t.created[i].String(), err)
+		}
+	}
+
+	return firstErr
+}
+
+// Undo returns the undo record of a committed block transaction: the transactions it created and the spends they
+// made. Transactions that already existed are left out, they are not owned by the block.
+func (t *BlockTransaction) Undo() (*BlockUndo, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != blockTransactionCommitted {
+		return nil, errors.NewStateError("[BlockTransaction] only a committed block transaction has an undo record")
+	}
+
+	undo := &BlockUndo{
+		Created: append([]chainhash.Hash(nil), t.created...),
+		Spends:  make([]BlockUndoSpends, 0, len(t.spendsOrder)),
+	}
+
+	for _, txHash := range t.spendsOrder {
+		if _, ok := t.existing[txHash]; ok {
+			continue
+		}
+
+		undo.Spends = append(undo.Spends, BlockUndoSpends{TxID: txHash, Spends: t.spends[txHash]})
+	}
+
+	return undo, nil
+}
+
+// checkOpen returns an error when the block transaction already ended
+func (t *BlockTransaction) checkOpen() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != blockTransactionOpen {
+		return errors.NewStateError("[BlockTransaction] block transaction already ended")
+	}
+
+	return nil
+}
+
+// BlockUndo is the record of the creates and spends a committed block transaction made for a block. It is stored with
+// the block, so the UTXO changes of the block can be reverted when a reorg removes it.
+type BlockUndo struct {
+	Created []chainhash.Hash  `json:"created"` // transactions created for the block, in the order they were created
+	Spends  []BlockUndoSpends `json:"spends"`  // spends made for the block, in the order they were made
+}
+
+// BlockUndoSpends are the spends made by a transaction of the block
+type BlockUndoSpends struct {
+	TxID   chainhash.Hash `json:"txId"`
+	Spends []*Spend       `json:"spends"`
+}
+
+// NewBlockUndoFromBytes decodes an undo record encoded with Bytes
+func NewBlockUndoFromBytes(b []byte) (*BlockUndo, error) {
+	undo := &BlockUndo{}
+	if err := json.Unmarshal(b, undo); err != nil {
+		return nil, errors.NewProcessingError("[BlockUndo] failed to decode undo record", err)
+	}
+
+	return undo, nil
+}
+
+// Bytes encodes the undo record
+func (u *BlockUndo) Bytes() ([]byte, error) {
+	b, err := json.Marshal(u)
+	if err != nil {
+		return nil, errors.NewProcessingError("[BlockUndo] failed to encode undo record", err)
+	}
+
+	return b, nil
+}
+
+// Revert reverts the UTXO changes of the block: the spends made by the created transactions are unspent, newest first,
+// and the created transactions are deleted. Transactions for which keep returns true, like transactions also mined in
+// the blocks replacing the block, are kept with their spends. Every transaction is reverted, even when some fail; the
+// number of reverted transactions and the first error are returned.
+func (u *BlockUndo) Revert(ctx context.Context, store Store, keep func(txHash chainhash.Hash) bool) (int, error) {
+	reverted := make(map[chainhash.Hash]struct{}, len(u.Created))
+
+	for _, txHash := range u.Created {
+		if keep == nil || !keep(txHash) {
+			reverted[txHash] = struct{}{}
+		}
+	}
+
+	var firstErr error
+
+	for i := len(u.Spends) - 1; i >= 0; i-- {
+		if _, ok := reverted[u.Spends[i].TxID]; !ok {
+			continue
+		}
+
+		if err := store.Unspend(ctx, u.Spends[i].Spends); err != nil && firstErr == nil {
+			firstErr = errors.NewStorageError("[BlockUndo] failed to unspend the spends of tx %s", u.Spends[i].TxID.String(), err)
+		}
+	}
+
+	for i := len(u.Created) - 1; i >= 0; i-- {
+		if _, ok := reverted[u.Created[i]]; !ok {
+			continue
+		}
+
+		if err := store.Delete(ctx, &u.Created[i]); err != nil && !errors.Is(err, errors.ErrTxNotFound) && firstErr == nil {
+			firstErr = errors.NewStorageError("[BlockUndo] failed to delete tx %s", u.Created[i].String(), err)
+		}
+	}
+
+	return len(reverted), firstErr
+}
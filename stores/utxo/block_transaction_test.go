@@ -0,0 +1,202 @@
+package utxo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/bscript"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/stores/utxo/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newBlockTransactionTestTx returns a transaction spending output vout of the parent
+func newBlockTransactionTestTx(parentTxHash chainhash.Hash, vout uint32) *bt.Tx {
+	tx := bt.NewTx()
+
+	input := &bt.Input{PreviousTxOutIndex: vout}
+	_ = input.PreviousTxIDAdd(&parentTxHash)
+	tx.Inputs = append(tx.Inputs, input)
+	tx.Outputs = append(tx.Outputs, &bt.Output{Satoshis: 1000, LockingScript: &bscript.Script{}})
+
+	return tx
+}
+
+func TestBlockTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	parentTx := newBlockTransactionTestTx(createTestHash("parent-parent"), 0)
+	childTx := newBlockTransactionTestTx(*parentTx.TxIDChainHash(), 0)
+	existingTx := newBlockTransactionTestTx(createTestHash("existing-parent"), 1)
+
+	parentSpends := []*Spend{{TxID: parentTx.Inputs[0].PreviousTxIDChainHash(), Vout: 0}}
+	childSpends := []*Spend{{TxID: parentTx.TxIDChainHash(), Vout: 0}}
+	existingSpends := []*Spend{{TxID: existingTx.Inputs[0].PreviousTxIDChainHash(), Vout: 1}}
+
+	// lockedCreate matches creates with the locked option
+	lockedCreate := mock.MatchedBy(func(opts []CreateOption) bool {
+		options := &CreateOptions{}
+		for _, opt := range opts {
+			opt(options)
+		}
+
+		return options.Locked
+	})
+
+	newStore := func() *MockUtxostore {
+		store := &MockUtxostore{}
+		store.On("Create", mock.Anything, parentTx, uint32(100), lockedCreate).Return(&meta.Data{}, nil)
+		store.On("Create", mock.Anything, childTx, uint32(100), lockedCreate).Return(&meta.Data{}, nil)
+		store.On("Create", mock.Anything, existingTx, uint32(100), lockedCreate).Return((*meta.Data)(nil), errors.NewTxExistsError("exists"))
+		store.On("Spend", mock.Anything, parentTx, uint32(100), mock.Anything).Return(parentSpends, nil)
+		store.On("Spend", mock.Anything, childTx, uint32(100), mock.Anything).Return(childSpends, nil)
+		store.On("Spend", mock.Anything, existingTx, uint32(100), mock.Anything).Return(existingSpends, nil)
+
+		return store
+	}
+
+	run := func(t *testing.T, blockTx *BlockTransaction) {
+		t.Helper()
+
+		for _, tx := range []*bt.Tx{parentTx, childTx} {
+			_, err := blockTx.Create(ctx, tx, 100)
+			require.NoError(t, err)
+		}
+
+		_, err := blockTx.Create(ctx, existingTx, 100)
+		require.True(t, errors.Is(err, errors.ErrTxExists))
+
+		for _, tx := range []*bt.Tx{parentTx, childTx, existingTx} {
+			_, err = blockTx.Spend(ctx, tx, 100, IgnoreFlags{IgnoreLocked: true})
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("commit unlocks the created transactions", func(t *testing.T) {
+		store := newStore()
+
+		// the transaction that already existed is not owned by the block and stays as it is
+		store.On("SetLocked", mock.Anything, []chainhash.Hash{*parentTx.TxIDChainHash(), *childTx.TxIDChainHash()}, false).Return(nil)
+
+		blockTx := BeginBlockTransaction(store)
+		run(t, blockTx)
+
+		require.NoError(t, blockTx.Commit(ctx))
+
+		// rolling back a committed block transaction does nothing
+		require.NoError(t, blockTx.Rollback(ctx))
+		store.AssertNotCalled(t, "Unspend", mock.Anything, mock.Anything, mock.Anything)
+		store.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+
+		_, err := blockTx.Create(ctx, parentTx, 100)
+		assert.True(t, errors.Is(err, errors.ErrStateError))
+		assert.True(t, errors.Is(blockTx.Commit(ctx), errors.ErrStateError))
+	})
+
+	t.Run("rollback reverses the spends and deletes the created transactions", func(t *testing.T) {
+		store := newStore()
+
+		var unspent [][]*Spend
+
+		store.On("Unspend", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			unspent = append(unspent, args.Get(1).([]*Spend))
+		}).Return(nil)
+
+		var deleted []chainhash.Hash
+
+		store.On("Delete", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			deleted = append(deleted, *args.Get(1).(*chainhash.Hash))
+		}).Return(nil)
+
+		blockTx := BeginBlockTransaction(store)
+		run(t, blockTx)
+
+		require.NoError(t, blockTx.Rollback(ctx))
+
+		// newest first, the transaction that already existed is left alone
+		assert.Equal(t, [][]*Spend{childSpends, parentSpends}, unspent)
+		assert.Equal(t, []chainhash.Hash{*childTx.TxIDChainHash(), *parentTx.TxIDChainHash()}, deleted)
+		store.AssertNotCalled(t, "SetLocked", mock.Anything, mock.Anything, mock.Anything)
+
+		_, err := blockTx.Spend(ctx, parentTx, 100)
+		assert.True(t, errors.Is(err, errors.ErrStateError))
+	})
+
+	t.Run("rollback continues after a failure", func(t *testing.T) {
+		store := newStore()
+		store.On("Unspend", mock.Anything, childSpends, mock.Anything).Return(errors.NewStorageError("unavailable"))
+		store.On("Unspend", mock.Anything, parentSpends, mock.Anything).Return(nil)
+		store.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+		blockTx := BeginBlockTransaction(store)
+		run(t, blockTx)
+
+		err := blockTx.Rollback(ctx)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrStorageError))
+		store.AssertNumberOfCalls(t, "Unspend", 2)
+		store.AssertNumberOfCalls(t, "Delete", 2)
+	})
+
+	t.Run("failed commit stays open", func(t *testing.T) {
+		store := newStore()
+		store.On("SetLocked", mock.Anything, mock.Anything, false).Return(errors.NewStorageError("unavailable")).Once()
+		store.On("SetLocked", mock.Anything, mock.Anything, false).Return(nil).Once()
+
+		blockTx := BeginBlockTransaction(store)
+		run(t, blockTx)
+
+		require.Error(t, blockTx.Commit(ctx))
+		require.NoError(t, blockTx.Commit(ctx))
+	})
+
+	t.Run("undo record reverts the committed block", func(t *testing.T) {
+		store := newStore()
+		store.On("SetLocked", mock.Anything, mock.Anything, false).Return(nil)
+
+		blockTx := BeginBlockTransaction(store)
+		run(t, blockTx)
+
+		_, err := blockTx.Undo()
+		assert.True(t, errors.Is(err, errors.ErrStateError), "no undo record before the commit")
+
+		require.NoError(t, blockTx.Commit(ctx))
+
+		undo, err := blockTx.Undo()
+		require.NoError(t, err)
+
+		undoBytes, err := undo.Bytes()
+		require.NoError(t, err)
+
+		undo, err = NewBlockUndoFromBytes(undoBytes)
+		require.NoError(t, err)
+		assert.Equal(t, []chainhash.Hash{*parentTx.TxIDChainHash(), *childTx.TxIDChainHash()}, undo.Created)
+
+		var unspent [][]*Spend
+
+		store.On("Unspend", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			unspent = append(unspent, args.Get(1).([]*Spend))
+		}).Return(nil)
+
+		var deleted []chainhash.Hash
+
+		store.On("Delete", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			deleted = append(deleted, *args.Get(1).(*chainhash.Hash))
+		}).Return(nil)
+
+		// the parent is also mined in the block replacing the block, it is kept with its spends
+		reverted, err := undo.Revert(ctx, store, func(txHash chainhash.Hash) bool {
+			return txHash.IsEqual(parentTx.TxIDChainHash())
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, reverted)
+
+		require.Len(t, unspent, 1)
+		assert.Equal(t, *childSpends[0].TxID, *unspent[0][0].TxID)
+		assert.Equal(t, []chainhash.Hash{*childTx.TxIDChainHash()}, deleted)
+	})
+}
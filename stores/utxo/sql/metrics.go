@@ -38,6 +38,9 @@
 //   - teranode_sql_utxo_reset: Number of UTXO reset operations
 //   - teranode_sql_utxo_delete: Number of UTXO delete operations
 //   - teranode_sql_utxo_errors: Number of errors by function and type
+//   - teranode_sql_utxo_pruned: Number of fully spent transactions pruned, by mode
+//   - teranode_sql_utxo_pruned_bytes: Transaction bytes reclaimed by pruning, by mode
+//   - teranode_sql_utxo_prune_duration_seconds: Duration of the pruning runs
 package sql
 
 import (
@@ -57,6 +60,10 @@ var (
 	prometheusSQLUtxoGetCounterConflicting prometheus.Histogram
 	prometheusSQLUtxoGetConflicting        prometheus.Histogram
 
+	prometheusSQLUtxoPruned        *prometheus.CounterVec
+	prometheusSQLUtxoPrunedBytes   *prometheus.CounterVec
+	prometheusSQLUtxoPruneDuration prometheus.Histogram
+
 	// only init the metrics once
 	prometheusMetricsInitOnce sync.Once
 )
@@ -128,4 +135,38 @@ func _initPrometheusMetrics() {
 			Help:      "Histogram of utxo get conflicting calls done to sql",
 		},
 	)
+
+	prometheusSQLUtxoPruned = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "sql",
+			Name:      "utxo_pruned",
+			Help:      "Number of fully spent transactions pruned from the utxo store",
+		},
+		[]string{
+			"mode", // deleted, archived or dry_run
+		},
+	)
+
+	prometheusSQLUtxoPrunedBytes = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "sql",
+			Name:      "utxo_pruned_bytes",
+			Help:      "Transaction bytes reclaimed by pruning the utxo store",
+		},
+		[]string{
+			"mode", // deleted, archived or dry_run
+		},
+	)
+
+	prometheusSQLUtxoPruneDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "sql",
+			Name:      "utxo_prune_duration_seconds",
+			Help:      "Duration of the utxo store pruning runs",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+		},
+	)
 }
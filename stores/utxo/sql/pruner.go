@@ -0,0 +1,320 @@
+// This file implements the background pruner of the SQL UTXO store.
+//
+// The pruner deletes transactions of which all outputs have been spent by transactions mined more than
+// utxostore_pruneSpentAfterBlocks blocks ago. Pruned transactions can be archived to a blob store before they are
+// deleted, and a dry run only counts and logs the transactions that would be pruned. Deletions are rate limited, so
+// pruning a large backlog does not starve the foreground spends and lookups of database connections.
+//
+// Transactions that are unmined, conflicting, locked, frozen, preserved or have frozen outputs are never pruned.
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/pkg/fileformat"
+	"github.com/bsv-blockchain/teranode/stores/blob"
+	"github.com/bsv-blockchain/teranode/stores/utxo/fields"
+	"github.com/bsv-blockchain/teranode/util/jobs"
+	"golang.org/x/time/rate"
+)
+
+// pruneMode labels the pruning metrics
+const (
+	pruneModeDeleted  = "deleted"
+	pruneModeArchived = "archived"
+	pruneModeDryRun   = "dry_run"
+)
+
+// pruneScheduler and pruneJob name the maintenance job of the pruner, as listed by the /debug/jobs endpoint
+const (
+	pruneScheduler = "utxostore_sql"
+	pruneJob       = "prune"
+)
+
+// PruneResult summarizes a pruning run
+type PruneResult struct {
+	// CutoffHeight is the height up to which the spending transactions must have been mined
+	CutoffHeight uint32
+
+	// Pruned is the number of transactions pruned, or that would have been pruned in a dry run
+	Pruned int
+
+	// ReclaimedBytes is the size of the pruned transactions
+	ReclaimedBytes uint64
+
+	// DryRun is set when the transactions were only counted
+	DryRun bool
+}
+
+// pruneCandidate is a fully spent transaction selected for pruning
+type pruneCandidate struct {
+	id   int64
+	hash chainhash.Hash
+	size uint64
+}
+
+// pruner deletes fully spent transactions beyond the configured number of blocks
+type pruner struct {
+	store   *Store
+	archive blob.Store
+	limiter *rate.Limiter
+}
+
+// newPruner returns the pruner of the store, or nil when pruning is disabled
+func newPruner(s *Store) (*pruner, error) {
+	if s.settings.UtxoStore.PruneSpentAfterBlocks == 0 {
+		return nil, nil
+	}
+
+	p := &pruner{
+		store:   s,
+		limiter: rate.NewLimiter(rate.Inf, 0),
+	}
+
+	if limit := s.settings.UtxoStore.PruneRateLimit; limit > 0 {
+		p.limiter = rate.NewLimiter(rate.Limit(limit), limit)
+	}
+
+	if archiveURL := s.settings.UtxoStore.PruneArchiveStore; archiveURL != nil {
+		archive, err := blob.NewStore(s.logger, archiveURL)
+		if err != nil {
+			return nil, errors.NewConfigurationError("failed to create utxostore_pruneArchiveStore %s", archiveURL, err)
+		}
+
+		p.archive = archive
+	}
+
+	return p, nil
+}
+
+// pruneAfterBlocks returns the number of blocks after which spent transactions are pruned, at least the block height
+// retention, as the block persister and catchup rely on the transactions within the retention
+func (p *pruner) pruneAfterBlocks() uint32 {
+	blocks := p.store.settings.UtxoStore.PruneSpentAfterBlocks
+
+	if retention := p.store.settings.GetUtxoStoreBlockHeightRetention(); blocks < retention {
+		return retention
+	}
+
+	return blocks
+}
+
+// start runs the pruner every utxostore_pruneInterval as a maintenance job, of which the last and next run are
+// persisted in the store, until the context is cancelled
+func (p *pruner) start(ctx context.Context) error {
+	interval := p.store.settings.UtxoStore.PruneInterval
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+
+	stateStore, err := jobs.NewSQLStateStore(p.store.db)
+	if err != nil {
+		return err
+	}
+
+	scheduler, err := jobs.NewScheduler(pruneScheduler, p.store.logger, stateStore, p.store.settings.MaintenanceJobJitter)
+	if err != nil {
+		return err
+	}
+
+	if err = scheduler.Register(jobs.Job{
+		Name:     pruneJob,
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			if _, err := p.prune(ctx); err != nil {
+				prometheusUtxoErrors.WithLabelValues("Prune", "Failed Prune").Inc()
+				return err
+			}
+
+			return nil
+		},
+	}); err != nil {
+		return err
+	}
+
+	p.store.logger.Infof("[UtxoPruner] pruning transactions spent more than %d blocks ago every %s (dry run: %t, archive: %t)",
+		p.pruneAfterBlocks(), interval, p.store.settings.UtxoStore.PruneDryRun, p.archive != nil)
+
+	scheduler.Start(ctx)
+
+	return nil
+}
+
+// prune prunes the transactions of which all outputs were spent at or below the cutoff height
+func (p *pruner) prune(ctx context.Context) (PruneResult, error) {
+	start := time.Now()
+	defer func() {
+		prometheusSQLUtxoPruneDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	result := PruneResult{DryRun: p.store.settings.UtxoStore.PruneDryRun}
+
+	blockHeight := p.store.blockHeight.Load()
+	pruneAfter := p.pruneAfterBlocks()
+
+	if blockHeight <= pruneAfter {
+		return result, nil
+	}
+
+	result.CutoffHeight = blockHeight - pruneAfter
+
+	mode := pruneModeDeleted
+
+	switch {
+	case result.DryRun:
+		mode = pruneModeDryRun
+	case p.archive != nil:
+		mode = pruneModeArchived
+	}
+
+	batchSize := p.store.settings.UtxoStore.PruneBatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	var lastID int64
+
+	for {
+		candidates, err := p.selectCandidates(ctx, lastID, blockHeight, result.CutoffHeight, batchSize)
+		if err != nil {
+			return result, err
+		}
+
+		for _, candidate := range candidates {
+			if err = p.limiter.Wait(ctx); err != nil {
+				return result, err
+			}
+
+			if !result.DryRun {
+				if err = p.pruneTransaction(ctx, candidate); err != nil {
+					return result, err
+				}
+			}
+
+			result.Pruned++
+			result.ReclaimedBytes += candidate.size
+
+			prometheusSQLUtxoPruned.WithLabelValues(mode).Inc()
+			prometheusSQLUtxoPrunedBytes.WithLabelValues(mode).Add(float64(candidate.size))
+
+			lastID = candidate.id
+		}
+
+		if len(candidates) < batchSize {
+			break
+		}
+	}
+
+	if result.Pruned > 0 {
+		if result.DryRun {
+			p.store.logger.Infof("[UtxoPruner] dry run: %d transactions (%d bytes) spent at or below height %d would be pruned in %s",
+				result.Pruned, result.ReclaimedBytes, result.CutoffHeight, time.Since(start))
+		} else {
+			p.store.logger.Infof("[UtxoPruner] pruned %d transactions (%d bytes) spent at or below height %d in %s",
+				result.Pruned, result.ReclaimedBytes, result.CutoffHeight, time.Since(start))
+		}
+	}
+
+	return result, nil
+}
+
+// selectCandidates returns up to limit fully spent transactions after lastID, of which every spending transaction was
+// mined at or below the cutoff height. Outputs of which the spending transaction is no longer in the store cannot be
+// dated and keep their transaction.
+func (p *pruner) selectCandidates(ctx context.Context, lastID int64, blockHeight, cutoffHeight uint32, limit int) ([]pruneCandidate, error) {
+	ctx, cancelTimeout := context.WithTimeout(ctx, p.store.settings.UtxoStore.DBTimeout)
+	defer cancelTimeout()
+
+	q := `
+		SELECT t.id, t.hash, t.size_in_bytes
+		FROM transactions t
+		WHERE t.id > $1
+		  AND t.frozen = false
+		  AND t.conflicting = false
+		  AND t.locked = false
+		  AND t.unmined_since IS NULL
+		  AND (t.preserve_until IS NULL OR t.preserve_until < $2)
+		  AND EXISTS (SELECT 1 FROM outputs o WHERE o.transaction_id = t.id)
+		  AND NOT EXISTS (
+			SELECT 1 FROM outputs o
+			WHERE o.transaction_id = t.id
+			  AND (o.spending_data IS NULL OR o.frozen = true)
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM outputs o
+			LEFT JOIN transactions st ON st.hash = substr(o.spending_data, 1, 32)
+			LEFT JOIN block_ids b ON b.transaction_id = st.id
+			WHERE o.transaction_id = t.id
+			GROUP BY o.idx
+			HAVING MAX(b.block_height) IS NULL OR MAX(b.block_height) > $3
+		  )
+		ORDER BY t.id
+		LIMIT $4
+	`
+
+	rows, err := p.store.db.QueryContext(ctx, q, lastID, blockHeight, cutoffHeight, limit)
+	if err != nil {
+		return nil, errors.NewStorageError("[UtxoPruner] failed to select transactions to prune", err)
+	}
+	defer rows.Close()
+
+	candidates := make([]pruneCandidate, 0, limit)
+
+	for rows.Next() {
+		var (
+			candidate pruneCandidate
+			hashBytes []byte
+		)
+
+		if err = rows.Scan(&candidate.id, &hashBytes, &candidate.size); err != nil {
+			return nil, errors.NewStorageError("[UtxoPruner] failed to scan transaction to prune", err)
+		}
+
+		copy(candidate.hash[:], hashBytes)
+		candidates = append(candidates, candidate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.NewStorageError("[UtxoPruner] error iterating transactions to prune", err)
+	}
+
+	return candidates, nil
+}
+
+// pruneTransaction archives the transaction when an archive store is configured and deletes it, the inputs,
+// outputs, block ids and conflicting children are deleted with it
+func (p *pruner) pruneTransaction(ctx context.Context, candidate pruneCandidate) error {
+	if p.archive != nil {
+		data, err := p.store.get(ctx, &candidate.hash, []fields.FieldName{fields.Tx})
+		if err != nil {
+			return errors.NewStorageError("[UtxoPruner] failed to read transaction %s to archive", candidate.hash, err)
+		}
+
+		if err = p.archive.Set(ctx, candidate.hash[:], fileformat.FileTypeTx, data.Tx.ExtendedBytes()); err != nil && !errors.Is(err, errors.ErrBlobAlreadyExists) {
+			return errors.NewStorageError("[UtxoPruner] failed to archive transaction %s", candidate.hash, err)
+		}
+	}
+
+	ctx, cancelTimeout := context.WithTimeout(ctx, p.store.settings.UtxoStore.DBTimeout)
+	defer cancelTimeout()
+
+	if _, err := p.store.db.ExecContext(ctx, "DELETE FROM transactions WHERE id = $1", candidate.id); err != nil {
+		return errors.NewStorageError("[UtxoPruner] failed to delete transaction %s", candidate.hash, err)
+	}
+
+	return nil
+}
+
+// Prune runs the pruner once, pruning the transactions of which all outputs were spent by transactions mined more
+// than utxostore_pruneSpentAfterBlocks blocks below the current block height. It returns an error when pruning is
+// disabled.
+func (s *Store) Prune(ctx context.Context) (PruneResult, error) {
+	if s.pruner == nil {
+		return PruneResult{}, errors.NewConfigurationError("utxo pruning is disabled, set utxostore_pruneSpentAfterBlocks")
+	}
+
+	return s.pruner.prune(ctx)
+}
@@ -0,0 +1,212 @@
+package sql
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/bscript"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/pkg/fileformat"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/stores/utxo"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prunerTestTx returns a transaction spending the first output of parent, or a made up previous output when parent
+// is nil
+func prunerTestTx(t *testing.T, parent *bt.Tx, satoshis uint64) *bt.Tx {
+	lockingScript, err := bscript.NewFromHexString("76a914296b03a4dd56b3b0fe5706c845f2edff22e84d7388ac")
+	require.NoError(t, err)
+
+	tx := bt.NewTx()
+
+	input := &bt.Input{
+		PreviousTxOutIndex: 0,
+		PreviousTxSatoshis: satoshis + 1000,
+		PreviousTxScript:   lockingScript,
+		UnlockingScript:    bscript.NewFromBytes([]byte{0x51}),
+		SequenceNumber:     0xffffffff,
+	}
+
+	previousTxID := chainhash.HashH([]byte(t.Name()))
+	if parent != nil {
+		previousTxID = *parent.TxIDChainHash()
+		input.PreviousTxSatoshis = parent.Outputs[0].Satoshis
+	}
+
+	require.NoError(t, input.PreviousTxIDAdd(&previousTxID))
+
+	tx.Inputs = append(tx.Inputs, input)
+	tx.Outputs = append(tx.Outputs, &bt.Output{Satoshis: satoshis, LockingScript: lockingScript})
+
+	return tx
+}
+
+func TestStore_Prune(t *testing.T) {
+	ctx := context.Background()
+
+	// newStore returns a store with a parent transaction mined at height 1, fully spent by a child transaction mined
+	// at height 5
+	newStore := func(t *testing.T, configure func(tSettings *settings.Settings)) (*Store, *bt.Tx, *bt.Tx) {
+		tSettings := test.CreateBaseTestSettings(t)
+		tSettings.GlobalBlockHeightRetention = 5
+		tSettings.UtxoStore.PruneSpentAfterBlocks = 10
+		tSettings.UtxoStore.PruneRateLimit = 0
+
+		configure(tSettings)
+
+		storeURL, err := url.Parse("sqlitememory:///" + t.Name())
+		require.NoError(t, err)
+
+		store, err := New(ctx, ulogger.TestLogger{}, tSettings, storeURL)
+		require.NoError(t, err)
+
+		parent := prunerTestTx(t, nil, 5000)
+		child := prunerTestTx(t, parent, 4000)
+
+		_, err = store.Create(ctx, parent, 1, utxo.WithMinedBlockInfo(utxo.MinedBlockInfo{BlockID: 1, BlockHeight: 1}))
+		require.NoError(t, err)
+
+		_, err = store.Create(ctx, child, 5, utxo.WithMinedBlockInfo(utxo.MinedBlockInfo{BlockID: 5, BlockHeight: 5}))
+		require.NoError(t, err)
+
+		_, err = store.Spend(ctx, child, 5)
+		require.NoError(t, err)
+
+		return store, parent, child
+	}
+
+	exists := func(t *testing.T, store *Store, tx *bt.Tx) bool {
+		_, err := store.Get(ctx, tx.TxIDChainHash())
+		if errors.Is(err, errors.ErrTxNotFound) {
+			return false
+		}
+
+		require.NoError(t, err)
+
+		return true
+	}
+
+	t.Run("fully spent transactions are pruned", func(t *testing.T) {
+		store, parent, child := newStore(t, func(tSettings *settings.Settings) {})
+
+		require.NoError(t, store.SetBlockHeight(15))
+
+		result, err := store.Prune(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint32(5), result.CutoffHeight)
+		assert.Equal(t, 1, result.Pruned)
+		assert.Equal(t, uint64(parent.Size()), result.ReclaimedBytes)
+		assert.False(t, exists(t, store, parent))
+		assert.True(t, exists(t, store, child), "the child has unspent outputs")
+	})
+
+	t.Run("recent spends are kept", func(t *testing.T) {
+		store, parent, _ := newStore(t, func(tSettings *settings.Settings) {})
+
+		require.NoError(t, store.SetBlockHeight(14))
+
+		result, err := store.Prune(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, result.Pruned)
+		assert.True(t, exists(t, store, parent))
+	})
+
+	t.Run("retention is respected", func(t *testing.T) {
+		store, parent, _ := newStore(t, func(tSettings *settings.Settings) {
+			tSettings.GlobalBlockHeightRetention = 20
+		})
+
+		require.NoError(t, store.SetBlockHeight(15))
+
+		result, err := store.Prune(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, result.Pruned)
+		assert.True(t, exists(t, store, parent))
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		store, parent, _ := newStore(t, func(tSettings *settings.Settings) {
+			tSettings.UtxoStore.PruneDryRun = true
+		})
+
+		require.NoError(t, store.SetBlockHeight(15))
+
+		result, err := store.Prune(ctx)
+		require.NoError(t, err)
+
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 1, result.Pruned)
+		assert.True(t, exists(t, store, parent), "a dry run does not delete")
+	})
+
+	t.Run("frozen transactions are kept", func(t *testing.T) {
+		store, parent, _ := newStore(t, func(tSettings *settings.Settings) {})
+
+		_, err := store.db.Exec("UPDATE transactions SET frozen = true WHERE hash = $1", parent.TxIDChainHash()[:])
+		require.NoError(t, err)
+
+		require.NoError(t, store.SetBlockHeight(15))
+
+		result, err := store.Prune(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, result.Pruned)
+	})
+
+	t.Run("unmined spends are kept", func(t *testing.T) {
+		store, parent, child := newStore(t, func(tSettings *settings.Settings) {})
+
+		_, err := store.SetMinedMulti(ctx, []*chainhash.Hash{child.TxIDChainHash()}, utxo.MinedBlockInfo{BlockID: 5, UnsetMined: true})
+		require.NoError(t, err)
+
+		require.NoError(t, store.SetBlockHeight(15))
+
+		result, err := store.Prune(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, result.Pruned)
+		assert.True(t, exists(t, store, parent))
+	})
+
+	t.Run("pruned transactions are archived", func(t *testing.T) {
+		archiveURL, err := url.Parse("memory://")
+		require.NoError(t, err)
+
+		store, parent, _ := newStore(t, func(tSettings *settings.Settings) {
+			tSettings.UtxoStore.PruneArchiveStore = archiveURL
+		})
+
+		require.NoError(t, store.SetBlockHeight(15))
+
+		result, err := store.Prune(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Pruned)
+
+		archived, err := store.pruner.archive.Get(ctx, parent.TxIDChainHash()[:], fileformat.FileTypeTx)
+		require.NoError(t, err)
+
+		archivedTx, err := bt.NewTxFromBytes(archived)
+		require.NoError(t, err)
+		assert.Equal(t, parent.TxID(), archivedTx.TxID())
+		assert.False(t, exists(t, store, parent))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		store, _, _ := newStore(t, func(tSettings *settings.Settings) {
+			tSettings.UtxoStore.PruneSpentAfterBlocks = 0
+		})
+
+		_, err := store.Prune(ctx)
+		require.Error(t, err)
+	})
+}
@@ -9,6 +9,7 @@
 //   - Input/output tracking
 //   - Block height and median time tracking
 //   - Optional UTXO expiration with automatic cleanup
+//   - Optional pruning of fully spent transactions, see pruner.go
 //   - Prometheus metrics integration
 //   - Support for the alert system (freeze/unfreeze/reassign UTXOs)
 //
@@ -80,6 +81,7 @@ type Store struct {
 	engine          string
 	blockHeight     atomic.Uint32
 	medianBlockTime atomic.Uint32
+	pruner          *pruner
 }
 
 // New creates a new SQL-based UTXO store.
@@ -132,6 +134,16 @@ func New(ctx context.Context, logger ulogger.Logger, tSettings *settings.Setting
 		medianBlockTime: atomic.Uint32{},
 	}
 
+	if s.pruner, err = newPruner(s); err != nil {
+		return nil, err
+	}
+
+	if s.pruner != nil {
+		if err = s.pruner.start(ctx); err != nil {
+			return nil, errors.NewStorageError("failed to start the utxo pruner", err)
+		}
+	}
+
 	return s, nil
 }
 
@@ -650,6 +650,15 @@ func Test_SmokeTests(t *testing.T) {
 	})
 }
 
+func TestConformance(t *testing.T) {
+	ctx := context.Background()
+
+	tests.Conformance(t, func(t *testing.T) utxo.Store {
+		db, _ := setup(ctx, t)
+		return db
+	})
+}
+
 func TestSetTTL(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -0,0 +1,496 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/bscript"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	bec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/teranode/errors"
+	utxostore "github.com/bsv-blockchain/teranode/stores/utxo"
+	"github.com/bsv-blockchain/teranode/stores/utxo/spend"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conformanceConcurrency is the number of goroutines of the concurrency cases of the conformance suite
+const conformanceConcurrency = 16
+
+// StoreFactory returns the store a case of the conformance suite runs against. The cases create transactions of
+// their own, so a factory may return the same store for every case.
+type StoreFactory func(t *testing.T) utxostore.Store
+
+// Conformance runs the conformance suite of the UTXO store interface against the stores of the factory. Every
+// backend runs the same suite, so a new backend, or a change of an existing one, is verified against the same
+// invariants of spend ordering, freezing, reassignment and concurrent access.
+func Conformance(t *testing.T, newStore StoreFactory) {
+	t.Run("spend ordering", func(t *testing.T) {
+		t.Run("outputs in any order", func(t *testing.T) { conformanceSpendAnyOrder(t, newStore(t)) })
+		t.Run("double spend reports the first spender", func(t *testing.T) { conformanceDoubleSpend(t, newStore(t)) })
+		t.Run("respend by the same transaction", func(t *testing.T) { conformanceRespend(t, newStore(t)) })
+		t.Run("failed spend spends no outputs", func(t *testing.T) { conformanceFailedSpend(t, newStore(t)) })
+		t.Run("unspend before a new spender", func(t *testing.T) { conformanceUnspend(t, newStore(t)) })
+		t.Run("unknown output", func(t *testing.T) { conformanceUnknownOutput(t, newStore(t)) })
+	})
+
+	t.Run("freeze", func(t *testing.T) {
+		t.Run("frozen output is not spendable", func(t *testing.T) { conformanceFreeze(t, newStore(t)) })
+		t.Run("spent output can not be frozen", func(t *testing.T) { conformanceFreezeSpent(t, newStore(t)) })
+		t.Run("frozen twice", func(t *testing.T) { conformanceFreezeTwice(t, newStore(t)) })
+		t.Run("unfreeze of an output that is not frozen", func(t *testing.T) { conformanceUnfreezeNotFrozen(t, newStore(t)) })
+	})
+
+	t.Run("reassign", func(t *testing.T) {
+		t.Run("output that is not frozen", func(t *testing.T) { conformanceReAssignNotFrozen(t, newStore(t)) })
+		t.Run("spendable after the reassignment delay", func(t *testing.T) { conformanceReAssign(t, newStore(t)) })
+	})
+
+	t.Run("concurrency", func(t *testing.T) {
+		t.Run("conflicting spends", func(t *testing.T) { conformanceConcurrentDoubleSpend(t, newStore(t)) })
+		t.Run("spends of distinct outputs", func(t *testing.T) { conformanceConcurrentSpends(t, newStore(t)) })
+	})
+}
+
+// newLockingScript returns a P2PKH locking script of a new key, so every transaction of the suite is unique
+func newLockingScript(t *testing.T) *bscript.Script {
+	t.Helper()
+
+	privateKey, err := bec.NewPrivateKey()
+	require.NoError(t, err)
+
+	lockingScript, err := bscript.NewP2PKHFromPubKeyBytes(privateKey.PubKey().Compressed())
+	require.NoError(t, err)
+
+	return lockingScript
+}
+
+// createParent creates a transaction with outputs outputs in the store
+func createParent(t *testing.T, db utxostore.Store, outputs int) *bt.Tx {
+	t.Helper()
+
+	tx := bt.NewTx()
+
+	require.NoError(t, tx.FromUTXOs(&bt.UTXO{
+		TxIDHash:      Tx.TxIDChainHash(),
+		Vout:          0,
+		LockingScript: Tx.Inputs[0].PreviousTxScript,
+		Satoshis:      Tx.Inputs[0].PreviousTxSatoshis,
+	}))
+
+	tx.Inputs[0].UnlockingScript = &bscript.Script{}
+
+	lockingScript := newLockingScript(t)
+
+	for i := 0; i < outputs; i++ {
+		tx.AddOutput(&bt.Output{Satoshis: 1_000 + uint64(i), LockingScript: lockingScript}) //nolint:gosec // i is never negative
+	}
+
+	_, err := db.Create(context.Background(), tx, db.GetBlockHeight())
+	require.NoError(t, err)
+
+	return tx
+}
+
+// newSpendingTx returns a new transaction spending the outputs of the parent
+func newSpendingTx(t *testing.T, parent *bt.Tx, vouts ...uint32) *bt.Tx {
+	t.Helper()
+
+	tx := bt.NewTx()
+
+	var satoshis uint64
+
+	for _, vout := range vouts {
+		require.NoError(t, tx.FromUTXOs(&bt.UTXO{
+			TxIDHash:      parent.TxIDChainHash(),
+			Vout:          vout,
+			LockingScript: parent.Outputs[vout].LockingScript,
+			Satoshis:      parent.Outputs[vout].Satoshis,
+		}))
+
+		satoshis += parent.Outputs[vout].Satoshis
+	}
+
+	for _, input := range tx.Inputs {
+		input.UnlockingScript = &bscript.Script{}
+	}
+
+	tx.AddOutput(&bt.Output{Satoshis: satoshis, LockingScript: newLockingScript(t)})
+
+	return tx
+}
+
+// outputSpend returns the spend of an output of the parent by the spending transaction, nil for no spender
+func outputSpend(t *testing.T, parent *bt.Tx, vout uint32, spendingTxID *chainhash.Hash) *utxostore.Spend {
+	t.Helper()
+
+	utxoHash, err := util.UTXOHashFromOutput(parent.TxIDChainHash(), parent.Outputs[vout], vout)
+	require.NoError(t, err)
+
+	s := &utxostore.Spend{
+		TxID:     parent.TxIDChainHash(),
+		Vout:     vout,
+		UTXOHash: utxoHash,
+	}
+
+	if spendingTxID != nil {
+		s.SpendingData = spend.NewSpendingData(spendingTxID, 0)
+	}
+
+	return s
+}
+
+// requireStatus requires the output of the parent to have the status, and to be spent by the spending transaction
+// when it is spent
+func requireStatus(t *testing.T, db utxostore.Store, parent *bt.Tx, vout uint32, status utxostore.Status, spendingTx *bt.Tx) {
+	t.Helper()
+
+	resp, err := db.GetSpend(context.Background(), outputSpend(t, parent, vout, nil))
+	require.NoError(t, err)
+	require.Equal(t, int(status), resp.Status, "status of output %d", vout)
+
+	if spendingTx != nil {
+		require.NotNil(t, resp.SpendingData)
+		require.Equal(t, spendingTx.TxIDChainHash().String(), resp.SpendingData.TxID.String())
+	}
+}
+
+// requireSpent requires the spend of the transaction to fail with a spent error reporting the spender
+func requireSpent(t *testing.T, spends []*utxostore.Spend, err error, spender *bt.Tx) {
+	t.Helper()
+
+	require.ErrorIs(t, err, errors.ErrUtxoError)
+	require.Len(t, spends, 1)
+	require.ErrorIs(t, spends[0].Err, errors.ErrSpent)
+	require.NotNil(t, spends[0].ConflictingTxID)
+	require.Equal(t, spender.TxIDChainHash().String(), spends[0].ConflictingTxID.String())
+}
+
+func conformanceSpendAnyOrder(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	parent := createParent(t, db, 3)
+
+	spenders := make(map[uint32]*bt.Tx)
+
+	for _, vout := range []uint32{2, 0, 1} {
+		spenders[vout] = newSpendingTx(t, parent, vout)
+
+		_, err := db.Spend(ctx, spenders[vout], db.GetBlockHeight()+1)
+		require.NoError(t, err)
+	}
+
+	for vout, spender := range spenders {
+		requireStatus(t, db, parent, vout, utxostore.Status_SPENT, spender)
+	}
+}
+
+func conformanceDoubleSpend(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	parent := createParent(t, db, 1)
+
+	first := newSpendingTx(t, parent, 0)
+	second := newSpendingTx(t, parent, 0)
+
+	_, err := db.Spend(ctx, first, db.GetBlockHeight()+1)
+	require.NoError(t, err)
+
+	spends, err := db.Spend(ctx, second, db.GetBlockHeight()+1)
+	requireSpent(t, spends, err, first)
+
+	requireStatus(t, db, parent, 0, utxostore.Status_SPENT, first)
+}
+
+func conformanceRespend(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	parent := createParent(t, db, 1)
+
+	spender := newSpendingTx(t, parent, 0)
+
+	_, err := db.Spend(ctx, spender, db.GetBlockHeight()+1)
+	require.NoError(t, err)
+
+	// spending the output again with the same spending data is not a double spend
+	_, err = db.Spend(ctx, spender, db.GetBlockHeight()+1)
+	require.NoError(t, err)
+
+	requireStatus(t, db, parent, 0, utxostore.Status_SPENT, spender)
+}
+
+func conformanceFailedSpend(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	parent := createParent(t, db, 2)
+
+	first := newSpendingTx(t, parent, 1)
+
+	_, err := db.Spend(ctx, first, db.GetBlockHeight()+1)
+	require.NoError(t, err)
+
+	spends, err := db.Spend(ctx, newSpendingTx(t, parent, 0, 1), db.GetBlockHeight()+1)
+	require.ErrorIs(t, err, errors.ErrUtxoError)
+	require.Len(t, spends, 2)
+	require.ErrorIs(t, spends[1].Err, errors.ErrSpent)
+
+	// the spend of the unspent output is not kept when another output of the transaction could not be spent
+	requireStatus(t, db, parent, 0, utxostore.Status_OK, nil)
+	requireStatus(t, db, parent, 1, utxostore.Status_SPENT, first)
+}
+
+func conformanceUnspend(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	parent := createParent(t, db, 1)
+
+	first := newSpendingTx(t, parent, 0)
+	second := newSpendingTx(t, parent, 0)
+
+	_, err := db.Spend(ctx, first, db.GetBlockHeight()+1)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Unspend(ctx, []*utxostore.Spend{outputSpend(t, parent, 0, first.TxIDChainHash())}))
+	requireStatus(t, db, parent, 0, utxostore.Status_OK, nil)
+
+	_, err = db.Spend(ctx, second, db.GetBlockHeight()+1)
+	require.NoError(t, err)
+
+	requireStatus(t, db, parent, 0, utxostore.Status_SPENT, second)
+
+	// the first spender is now the double spend
+	spends, err := db.Spend(ctx, first, db.GetBlockHeight()+1)
+	requireSpent(t, spends, err, second)
+}
+
+func conformanceUnknownOutput(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+
+	// the parent is not created in the store
+	parent := bt.NewTx()
+	parent.AddOutput(&bt.Output{Satoshis: 1_000, LockingScript: newLockingScript(t)})
+
+	spends, err := db.Spend(ctx, newSpendingTx(t, parent, 0), db.GetBlockHeight()+1)
+	require.Error(t, err)
+
+	for _, s := range spends {
+		require.Error(t, s.Err)
+	}
+}
+
+func conformanceFreeze(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	tSettings := test.CreateBaseTestSettings(t)
+	parent := createParent(t, db, 2)
+
+	require.NoError(t, db.FreezeUTXOs(ctx, []*utxostore.Spend{outputSpend(t, parent, 0, nil)}, tSettings))
+	requireStatus(t, db, parent, 0, utxostore.Status_FROZEN, nil)
+
+	spender := newSpendingTx(t, parent, 0)
+
+	spends, err := db.Spend(ctx, spender, db.GetBlockHeight()+1)
+	require.ErrorIs(t, err, errors.ErrUtxoError)
+	require.ErrorIs(t, spends[0].Err, errors.ErrFrozen)
+
+	// freezing an output does not freeze the other outputs of the transaction
+	sibling := newSpendingTx(t, parent, 1)
+
+	_, err = db.Spend(ctx, sibling, db.GetBlockHeight()+1)
+	require.NoError(t, err)
+
+	require.NoError(t, db.UnFreezeUTXOs(ctx, []*utxostore.Spend{outputSpend(t, parent, 0, nil)}, tSettings))
+	requireStatus(t, db, parent, 0, utxostore.Status_OK, nil)
+
+	_, err = db.Spend(ctx, spender, db.GetBlockHeight()+1)
+	require.NoError(t, err)
+
+	requireStatus(t, db, parent, 0, utxostore.Status_SPENT, spender)
+	requireStatus(t, db, parent, 1, utxostore.Status_SPENT, sibling)
+}
+
+func conformanceFreezeSpent(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	tSettings := test.CreateBaseTestSettings(t)
+	parent := createParent(t, db, 1)
+
+	spender := newSpendingTx(t, parent, 0)
+
+	_, err := db.Spend(ctx, spender, db.GetBlockHeight()+1)
+	require.NoError(t, err)
+
+	require.Error(t, db.FreezeUTXOs(ctx, []*utxostore.Spend{outputSpend(t, parent, 0, nil)}, tSettings))
+	requireStatus(t, db, parent, 0, utxostore.Status_SPENT, spender)
+}
+
+func conformanceFreezeTwice(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	tSettings := test.CreateBaseTestSettings(t)
+	parent := createParent(t, db, 1)
+
+	require.NoError(t, db.FreezeUTXOs(ctx, []*utxostore.Spend{outputSpend(t, parent, 0, nil)}, tSettings))
+	require.Error(t, db.FreezeUTXOs(ctx, []*utxostore.Spend{outputSpend(t, parent, 0, nil)}, tSettings))
+	requireStatus(t, db, parent, 0, utxostore.Status_FROZEN, nil)
+}
+
+func conformanceUnfreezeNotFrozen(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	tSettings := test.CreateBaseTestSettings(t)
+	parent := createParent(t, db, 1)
+
+	require.Error(t, db.UnFreezeUTXOs(ctx, []*utxostore.Spend{outputSpend(t, parent, 0, nil)}, tSettings))
+	requireStatus(t, db, parent, 0, utxostore.Status_OK, nil)
+}
+
+// reAssignedOutput returns the spend of the output the first output of the parent is reassigned to, spent by a new
+// transaction, and that transaction
+func reAssignedOutput(t *testing.T, parent *bt.Tx) (*utxostore.Spend, *bt.Tx) {
+	t.Helper()
+
+	newOutput := &bt.Output{Satoshis: parent.Outputs[0].Satoshis, LockingScript: newLockingScript(t)}
+
+	spender := bt.NewTx()
+
+	require.NoError(t, spender.FromUTXOs(&bt.UTXO{
+		TxIDHash:      parent.TxIDChainHash(),
+		Vout:          0,
+		LockingScript: newOutput.LockingScript,
+		Satoshis:      newOutput.Satoshis,
+	}))
+
+	spender.Inputs[0].UnlockingScript = &bscript.Script{}
+	spender.AddOutput(&bt.Output{Satoshis: newOutput.Satoshis, LockingScript: newLockingScript(t)})
+
+	utxoHash, err := util.UTXOHashFromOutput(parent.TxIDChainHash(), newOutput, 0)
+	require.NoError(t, err)
+
+	return &utxostore.Spend{
+		TxID:         parent.TxIDChainHash(),
+		Vout:         0,
+		UTXOHash:     utxoHash,
+		SpendingData: spend.NewSpendingData(spender.TxIDChainHash(), 0),
+	}, spender
+}
+
+func conformanceReAssignNotFrozen(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	tSettings := test.CreateBaseTestSettings(t)
+	parent := createParent(t, db, 1)
+
+	newUtxo, _ := reAssignedOutput(t, parent)
+
+	require.Error(t, db.ReAssignUTXO(ctx, outputSpend(t, parent, 0, nil), newUtxo, tSettings))
+	requireStatus(t, db, parent, 0, utxostore.Status_OK, nil)
+}
+
+func conformanceReAssign(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	tSettings := test.CreateBaseTestSettings(t)
+	parent := createParent(t, db, 1)
+
+	newUtxo, spender := reAssignedOutput(t, parent)
+
+	require.NoError(t, db.FreezeUTXOs(ctx, []*utxostore.Spend{outputSpend(t, parent, 0, nil)}, tSettings))
+	require.NoError(t, db.ReAssignUTXO(ctx, outputSpend(t, parent, 0, nil), newUtxo, tSettings))
+
+	// the output is only known by its new hash
+	_, err := db.GetSpend(ctx, outputSpend(t, parent, 0, nil))
+	require.Error(t, err)
+
+	resp, err := db.GetSpend(ctx, newUtxo)
+	require.NoError(t, err)
+	require.Equal(t, int(utxostore.Status_IMMATURE), resp.Status)
+
+	// the original spender no longer matches the output
+	_, err = db.Spend(ctx, newSpendingTx(t, parent, 0), db.GetBlockHeight()+1)
+	require.Error(t, err)
+
+	// and the new output is not spendable before the reassignment delay passed
+	_, err = db.Spend(ctx, spender, db.GetBlockHeight()+1)
+	require.Error(t, err)
+
+	require.NoError(t, db.SetBlockHeight(db.GetBlockHeight()+utxostore.ReAssignedUtxoSpendableAfterBlocks))
+
+	_, err = db.Spend(ctx, spender, db.GetBlockHeight()+1)
+	require.NoError(t, err)
+
+	resp, err = db.GetSpend(ctx, newUtxo)
+	require.NoError(t, err)
+	require.Equal(t, int(utxostore.Status_SPENT), resp.Status)
+}
+
+func conformanceConcurrentDoubleSpend(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	parent := createParent(t, db, 1)
+
+	spenders := make([]*bt.Tx, conformanceConcurrency)
+	for i := range spenders {
+		spenders[i] = newSpendingTx(t, parent, 0)
+	}
+
+	results := make([]error, len(spenders))
+	conflicts := make([][]*utxostore.Spend, len(spenders))
+
+	var wg sync.WaitGroup
+
+	for i, spender := range spenders {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			conflicts[i], results[i] = db.Spend(ctx, spender, db.GetBlockHeight()+1)
+		}()
+	}
+
+	wg.Wait()
+
+	// exactly one spender wins, all others see it as the spender of the output
+	var winner *bt.Tx
+
+	for i, err := range results {
+		if err == nil {
+			require.Nil(t, winner, "output spent by more than one transaction")
+			winner = spenders[i]
+		}
+	}
+
+	require.NotNil(t, winner, "output not spent by any transaction")
+
+	for i, err := range results {
+		if spenders[i] != winner {
+			requireSpent(t, conflicts[i], err, winner)
+		}
+	}
+
+	requireStatus(t, db, parent, 0, utxostore.Status_SPENT, winner)
+}
+
+func conformanceConcurrentSpends(t *testing.T, db utxostore.Store) {
+	ctx := context.Background()
+	parent := createParent(t, db, conformanceConcurrency)
+
+	spenders := make([]*bt.Tx, conformanceConcurrency)
+	for i := range spenders {
+		spenders[i] = newSpendingTx(t, parent, uint32(i)) //nolint:gosec // i is below conformanceConcurrency
+	}
+
+	results := make([]error, len(spenders))
+
+	var wg sync.WaitGroup
+
+	for i, spender := range spenders {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, results[i] = db.Spend(ctx, spender, db.GetBlockHeight()+1)
+		}()
+	}
+
+	wg.Wait()
+
+	for i, err := range results {
+		assert.NoError(t, err, "spend of output %d", i)
+		requireStatus(t, db, parent, uint32(i), utxostore.Status_SPENT, spenders[i]) //nolint:gosec // i is below conformanceConcurrency
+	}
+}
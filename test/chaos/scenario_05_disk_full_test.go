@@ -0,0 +1,245 @@
+package chaos
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2"
+	"github.com/bsv-blockchain/go-bt/v2/bscript"
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/go-chaincfg"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/services/blockchain/blockchain_api"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/stores/blockchain/sql"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/faultinject"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TestScenario05_DiskFull tests how the system handles a full disk on its write paths
+// This injects ENOSPC with the faultinject package, no docker services are needed
+//
+// Test Scenario:
+// 1. Save the peer registry cache while the disk is full
+// 2. Verify the previous cache file is kept and no temp files are left behind
+// 3. Verify the peer registry keeps serving and recording from memory
+// 4. Store blocks in the blockchain while the disk is full
+// 5. Verify the blockchain keeps serving reads and health checks
+// 6. Free the disk and verify the writes are retried successfully
+//
+// Expected Behavior:
+// - Writes fail with a storage error wrapping ENOSPC, nothing panics
+// - Previously persisted data is not corrupted
+// - Reads keep being served from memory and the store
+// - Writes succeed again once the disk has space
+func TestScenario05_DiskFull(t *testing.T) {
+	// Skip if running in short mode
+	if testing.Short() {
+		t.Skip("Skipping chaos test in short mode")
+	}
+
+	t.Cleanup(faultinject.Reset)
+
+	t.Run("Peer_Registry_Cache", func(t *testing.T) {
+		testDiskFullPeerRegistryCache(t)
+	})
+
+	t.Run("Blockchain_Store", func(t *testing.T) {
+		testDiskFullBlockchainStore(t)
+	})
+}
+
+func testDiskFullPeerRegistryCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	cacheFile := filepath.Join(cacheDir, "teranode_peer_registry.json")
+
+	peerIDs := make([]peer.ID, 0, 2)
+
+	for _, s := range []string{"12D3KooWL1NF6fdTJ9cucEuwvuX8V8KtpJZZnUE4umdLBuK15eUZ", "12D3KooWEyX7hgdXy8zUjCs9CqvMGpB5dKVFj9MX2nUBLwajdSZH"} {
+		id, err := peer.Decode(s)
+		require.NoError(t, err)
+
+		peerIDs = append(peerIDs, id)
+	}
+
+	registry := p2p.NewPeerRegistry()
+	registry.AddPeer(peerIDs[0], "peer-0")
+	registry.RecordInteractionAttempt(peerIDs[0])
+	registry.RecordInteractionSuccess(peerIDs[0], 50*time.Millisecond)
+
+	var baseline []byte
+
+	t.Run("Baseline", func(t *testing.T) {
+		require.NoError(t, registry.SavePeerRegistryCache(cacheDir))
+
+		var err error
+		baseline, err = os.ReadFile(cacheFile)
+		require.NoError(t, err)
+		t.Logf("Baseline cache file written with %d bytes", len(baseline))
+	})
+
+	t.Run("Inject_Disk_Full", func(t *testing.T) {
+		faultinject.Set(faultinject.PeerRegistryCacheWrite, syscall.ENOSPC)
+		t.Logf("Injected ENOSPC on peer registry cache writes")
+	})
+
+	t.Run("Behavior_Under_Failure", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			err := registry.SavePeerRegistryCache(cacheDir)
+			require.Error(t, err, "the save must fail while the disk is full")
+			assert.True(t, errors.Is(err, errors.ErrStorageError), "expected a storage error, got %v", err)
+			assert.True(t, errors.Is(err, syscall.ENOSPC), "expected the error to wrap ENOSPC, got %v", err)
+		}
+
+		current, err := os.ReadFile(cacheFile)
+		require.NoError(t, err)
+		assert.Equal(t, baseline, current, "the previous cache file must be kept")
+
+		tempFiles, err := filepath.Glob(cacheFile + ".tmp.*")
+		require.NoError(t, err)
+		assert.Empty(t, tempFiles, "partially written temp files must be removed")
+
+		// the registry keeps serving and recording from memory
+		registry.AddPeer(peerIDs[1], "peer-1")
+		registry.RecordInteractionAttempt(peerIDs[1])
+		registry.RecordInteractionSuccess(peerIDs[1], 20*time.Millisecond)
+
+		info, exists := registry.GetPeer(peerIDs[0])
+		require.True(t, exists)
+		assert.Equal(t, int64(1), info.InteractionSuccesses)
+		assert.Equal(t, 2, registry.PeerCount())
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		faultinject.Clear(faultinject.PeerRegistryCacheWrite)
+
+		require.NoError(t, registry.SavePeerRegistryCache(cacheDir))
+
+		restored := p2p.NewPeerRegistry()
+		require.NoError(t, restored.LoadPeerRegistryCache(cacheDir))
+
+		for _, id := range peerIDs {
+			info, exists := restored.GetPeer(id)
+			require.True(t, exists, "peer %s recorded during the failure must be persisted after recovery", id)
+			assert.Equal(t, int64(1), info.InteractionSuccesses)
+		}
+	})
+}
+
+func testDiskFullBlockchainStore(t *testing.T) {
+	ctx := context.Background()
+	logger := ulogger.TestLogger{}
+
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.ChainCfgParams = &chaincfg.MainNetParams
+	tSettings.BlockChain.GRPCListenAddress = ""
+	tSettings.BlockChain.HTTPListenAddress = ""
+
+	storeURL, err := url.Parse("sqlitememory:///")
+	require.NoError(t, err)
+
+	store, err := sql.New(logger, storeURL, tSettings)
+	require.NoError(t, err)
+
+	server, err := blockchain.New(ctx, logger, tSettings, store, nil)
+	require.NoError(t, err)
+	require.NoError(t, server.Init(ctx))
+
+	request := diskFullAddBlockRequest(t, tSettings.ChainCfgParams.GenesisHash)
+
+	t.Run("Baseline", func(t *testing.T) {
+		best, err := server.GetBestBlockHeader(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(0), best.Height)
+	})
+
+	t.Run("Inject_Disk_Full", func(t *testing.T) {
+		faultinject.Set(faultinject.BlockchainStoreWrite, syscall.ENOSPC)
+		t.Logf("Injected ENOSPC on blockchain store writes")
+	})
+
+	t.Run("Behavior_Under_Failure", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := server.AddBlock(ctx, request)
+			require.Error(t, err, "storing the block must fail while the disk is full")
+			assert.True(t, errors.Is(err, errors.ErrStorageError), "expected a storage error, got %v", err)
+		}
+
+		// reads and health checks keep being served
+		best, err := server.GetBestBlockHeader(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(0), best.Height, "the failed block must not be stored")
+
+		status, _, err := server.Health(ctx, true)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		// the disk is freed after two more failed attempts, the retries succeed afterwards
+		faultinject.SetTimes(faultinject.BlockchainStoreWrite, syscall.ENOSPC, 2)
+
+		const maxRetries = 5
+
+		attempts := 0
+
+		for attempts < maxRetries {
+			attempts++
+
+			if _, err := server.AddBlock(ctx, request); err == nil {
+				break
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		assert.Equal(t, 3, attempts, "the block must be stored on the first attempt after the disk is freed")
+
+		best, err := server.GetBestBlockHeader(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), best.Height)
+	})
+}
+
+// diskFullAddBlockRequest creates the request to add a block on top of the parent
+func diskFullAddBlockRequest(t *testing.T, parent *chainhash.Hash) *blockchain_api.AddBlockRequest {
+	t.Helper()
+
+	coinbase := bt.NewTx()
+	require.NoError(t, coinbase.From("0000000000000000000000000000000000000000000000000000000000000000", 0xffffffff, "", 0))
+	coinbase.Inputs[0].UnlockingScript = bscript.NewFromBytes([]byte{0x03, 0x01, 0x00, 0x00})
+	coinbase.Inputs[0].SequenceNumber = 0xffffffff
+	require.NoError(t, coinbase.AddP2PKHOutputFromAddress("mrs6FYWPcb441b4qfcEPyvLvzj64WHtwCU", 5000000000))
+
+	merkleRoot := chainhash.DoubleHashH([]byte("disk full"))
+
+	header := &model.BlockHeader{
+		Version:        1,
+		HashPrevBlock:  parent,
+		HashMerkleRoot: &merkleRoot,
+		Timestamp:      uint32(time.Now().Unix()), // nolint:gosec
+		Bits:           model.NBit{0xff, 0xff, 0x00, 0x1d},
+		Nonce:          0,
+	}
+
+	return &blockchain_api.AddBlockRequest{
+		Header:           header.Bytes(),
+		CoinbaseTx:       coinbase.Bytes(),
+		TransactionCount: 1,
+		SizeInBytes:      uint64(len(header.Bytes()) + len(coinbase.Bytes())), // nolint:gosec
+		PeerId:           "chaos-peer",
+	}
+}
@@ -0,0 +1,300 @@
+package chaos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockvalidation/catchup"
+	"github.com/bsv-blockchain/teranode/services/p2p"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/clock"
+	"github.com/bsv-blockchain/teranode/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clockSkew is the skew of the node clock simulated in both directions
+const clockSkew = 2 * time.Hour
+
+// TestScenario06_ClockSkew tests how the system handles a node clock that is ahead of or behind the network
+// This skews the node clock with the clock package, no docker services are needed
+//
+// Test Scenario:
+// 1. Validate block timestamps with the node clock 2 hours ahead and 2 hours behind
+// 2. Ban peers and IP addresses, then move the node clock past and before the ban expiry
+// 3. Open the catchup circuit breaker and start catchup timeouts while the node clock is skewed
+// 4. Reset the clock and verify the rules follow the system clock again
+//
+// Expected Behavior:
+// - Block timestamps are accepted up to 2 hours past the node clock, so a node behind rejects blocks the network
+//   accepts and a node ahead accepts blocks the network rejects
+// - Bans expire by the node clock, a node ahead lifts bans early and a node behind keeps them longer
+// - Catchup timeouts measure elapsed time and are not shortened or extended by the skew
+func TestScenario06_ClockSkew(t *testing.T) {
+	// Skip if running in short mode
+	if testing.Short() {
+		t.Skip("Skipping chaos test in short mode")
+	}
+
+	t.Cleanup(clock.Reset)
+
+	t.Run("Block_Timestamps", func(t *testing.T) {
+		testClockSkewBlockTimestamps(t)
+	})
+
+	t.Run("Peer_Ban_Expiry", func(t *testing.T) {
+		testClockSkewPeerBanExpiry(t)
+	})
+
+	t.Run("IP_Ban_Expiry", func(t *testing.T) {
+		testClockSkewIPBanExpiry(t)
+	})
+
+	t.Run("Catchup_Timeouts", func(t *testing.T) {
+		testClockSkewCatchupTimeouts(t)
+	})
+}
+
+func testClockSkewBlockTimestamps(t *testing.T) {
+	t.Cleanup(clock.Reset)
+
+	ctx := context.Background()
+	logger := ulogger.TestLogger{}
+	tSettings := test.CreateBaseTestSettings(t)
+
+	// timestamps of the blocks relative to the system clock, i.e. the clock of the rest of the network
+	tests := []struct {
+		name     string
+		skew     time.Duration
+		offset   time.Duration
+		accepted bool
+	}{
+		{name: "no skew, block mined now", skew: 0, offset: 0, accepted: true},
+		{name: "no skew, block 1h in the future", skew: 0, offset: time.Hour, accepted: true},
+		{name: "no skew, block 3h in the future", skew: 0, offset: 3 * time.Hour, accepted: false},
+		{name: "behind, block mined a minute ago", skew: -clockSkew, offset: -time.Minute, accepted: true},
+		{name: "behind, block 1h in the future", skew: -clockSkew, offset: time.Hour, accepted: false},
+		{name: "ahead, block 3h in the future", skew: clockSkew, offset: 3 * time.Hour, accepted: true},
+		{name: "ahead, block 5h in the future", skew: clockSkew, offset: 5 * time.Hour, accepted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := clockSkewBlock(t, time.Now().Add(tt.offset))
+
+			clock.SetSkew(tt.skew)
+			defer clock.Reset()
+
+			// the catchup header check
+			err := catchup.ValidateHeaderTimestamp(block.Header)
+			if tt.accepted {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+
+			// the full block validation, the block has no coinbase so it is always invalid, only the reason differs
+			_, err = block.Valid(ctx, logger, nil, nil, nil, nil, nil, nil, nil, tSettings)
+			require.Error(t, err)
+
+			rejectedTimestamp := strings.Contains(err.Error(), "more than two hours in the future")
+			assert.Equal(t, !tt.accepted, rejectedTimestamp, "unexpected block validation error: %v", err)
+		})
+	}
+}
+
+func testClockSkewPeerBanExpiry(t *testing.T) {
+	t.Cleanup(clock.Reset)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tSettings := &settings.Settings{}
+	tSettings.P2P.BanThreshold = 100
+	tSettings.P2P.BanDuration = time.Hour
+
+	banManager := p2p.NewPeerBanManager(ctx, &clockSkewBanHandler{}, tSettings, nil)
+
+	t.Run("Baseline", func(t *testing.T) {
+		banManager.BanPeer("peer-a", time.Now().Add(time.Hour), "chaos test")
+		banManager.BanPeer("peer-b", time.Now().Add(time.Hour), "chaos test")
+
+		assert.True(t, banManager.IsBanned("peer-a"))
+		assert.True(t, banManager.IsBanned("peer-b"))
+	})
+
+	t.Run("Clock_Behind", func(t *testing.T) {
+		clock.SetSkew(-clockSkew)
+
+		assert.True(t, banManager.IsBanned("peer-a"))
+		assert.Empty(t, banManager.SweepExpiredBans())
+		assert.Len(t, banManager.ListBanned(), 2)
+	})
+
+	t.Run("Clock_Ahead", func(t *testing.T) {
+		clock.SetSkew(clockSkew)
+		t.Logf("Node clock is %s ahead, the 1h bans have expired", clockSkew)
+
+		assert.False(t, banManager.IsBanned("peer-a"))
+		assert.Equal(t, []string{"peer-b"}, banManager.SweepExpiredBans())
+		assert.Empty(t, banManager.ListBanned())
+	})
+
+	t.Run("Ban_Issued_While_Behind", func(t *testing.T) {
+		clock.SetSkew(-clockSkew)
+
+		_, banned := banManager.AddScore("peer-c", p2p.ReasonMaliciousChain)
+		require.True(t, banned)
+		assert.True(t, banManager.IsBanned("peer-c"))
+
+		// the ban ran until 1h after the skewed clock, which is already over once the clock is corrected
+		clock.Reset()
+
+		assert.False(t, banManager.IsBanned("peer-c"))
+	})
+}
+
+func testClockSkewIPBanExpiry(t *testing.T) {
+	t.Cleanup(clock.Reset)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tSettings := test.CreateBaseTestSettings(t)
+	tSettings.BlockChain.StoreURL, _ = url.Parse("sqlitememory://")
+
+	banList, eventChan, err := p2p.GetBanList(ctx, ulogger.TestLogger{}, tSettings)
+	require.NoError(t, err)
+
+	defer banList.Unsubscribe(eventChan)
+
+	const address = "192.168.66.1"
+
+	require.NoError(t, banList.Add(ctx, address, clock.Now().Add(time.Hour)))
+	require.True(t, banList.IsBanned(address))
+
+	t.Run("Clock_Behind", func(t *testing.T) {
+		clock.SetSkew(-clockSkew)
+
+		assert.True(t, banList.IsBanned(address))
+
+		expired, err := banList.SweepExpired(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, expired)
+	})
+
+	t.Run("Clock_Ahead", func(t *testing.T) {
+		clock.SetSkew(clockSkew)
+
+		assert.False(t, banList.IsBanned(address))
+
+		expired, err := banList.SweepExpired(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{address}, expired)
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		clock.Reset()
+
+		require.NoError(t, banList.Add(ctx, address, clock.Now().Add(time.Hour)))
+		assert.True(t, banList.IsBanned(address))
+		require.NoError(t, banList.Remove(ctx, address))
+	})
+}
+
+func testClockSkewCatchupTimeouts(t *testing.T) {
+	t.Cleanup(clock.Reset)
+
+	const timeout = 200 * time.Millisecond
+
+	newOpenBreaker := func(t *testing.T) *catchup.CircuitBreaker {
+		breaker := catchup.NewCircuitBreaker(catchup.CircuitBreakerConfig{
+			FailureThreshold:    1,
+			SuccessThreshold:    1,
+			Timeout:             timeout,
+			MaxHalfOpenRequests: 1,
+		})
+
+		breaker.RecordFailure()
+		require.Equal(t, catchup.StateOpen, breaker.GetState())
+
+		return breaker
+	}
+
+	t.Run("Clock_Ahead_Does_Not_Shorten", func(t *testing.T) {
+		breaker := newOpenBreaker(t)
+
+		clock.SetSkew(clockSkew)
+		defer clock.Reset()
+
+		assert.False(t, breaker.CanCall(), "the skew must not let the open circuit time out early")
+
+		time.Sleep(timeout + 50*time.Millisecond)
+		assert.True(t, breaker.CanCall())
+		assert.Equal(t, catchup.StateHalfOpen, breaker.GetState())
+	})
+
+	t.Run("Clock_Behind_Does_Not_Extend", func(t *testing.T) {
+		breaker := newOpenBreaker(t)
+
+		clock.SetSkew(-clockSkew)
+		defer clock.Reset()
+
+		time.Sleep(timeout + 50*time.Millisecond)
+		assert.True(t, breaker.CanCall(), "the skew must not keep the circuit open past its timeout")
+	})
+
+	t.Run("Operation_Timeout", func(t *testing.T) {
+		// the catchup iteration and operation timeouts are context deadlines
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		start := time.Now()
+
+		clock.SetSkew(clockSkew)
+		defer clock.Reset()
+
+		assert.NoError(t, ctx.Err(), "the skew must not expire the deadline")
+
+		<-ctx.Done()
+
+		elapsed := time.Since(start)
+		assert.GreaterOrEqual(t, elapsed, timeout-10*time.Millisecond)
+		assert.Less(t, elapsed, 5*time.Second)
+	})
+}
+
+// clockSkewBlock returns a block with a header meeting the regtest proof of work at the given timestamp
+func clockSkewBlock(t *testing.T, timestamp time.Time) *model.Block {
+	nBits, err := model.NewNBitFromString("207fffff")
+	require.NoError(t, err)
+
+	header := &model.BlockHeader{
+		Version:        1,
+		HashPrevBlock:  &chainhash.Hash{},
+		HashMerkleRoot: &chainhash.Hash{},
+		Timestamp:      uint32(timestamp.Unix()), //nolint:gosec // test timestamps fit in uint32
+		Bits:           *nBits,
+	}
+
+	for {
+		if valid, _, _ := header.HasMetTargetDifficulty(); valid {
+			break
+		}
+
+		header.Nonce++
+	}
+
+	return &model.Block{Header: header}
+}
+
+// clockSkewBanHandler ignores the ban events of the ban manager
+type clockSkewBanHandler struct{}
+
+func (h *clockSkewBanHandler) OnPeerBanned(string, time.Time, string) {}
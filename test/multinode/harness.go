@@ -0,0 +1,209 @@
+// Package multinode provides a test harness running several in-process all-in-one Teranode nodes, used to
+// test scenarios that need more than one node, like network partitions and chain reorganisations.
+package multinode
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/daemon"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/services/blockassembly/blockassembly_api"
+	"github.com/bsv-blockchain/teranode/services/blockchain"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/stores/utxo/fields"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pollInterval is the interval at which the harness polls the nodes while waiting for a condition
+const pollInterval = 250 * time.Millisecond
+
+// Cluster is a set of in-process nodes. The nodes start without any connections between them, so every node
+// is its own partition until the partitions are connected with Connect.
+type Cluster struct {
+	Nodes []*daemon.TestDaemon
+}
+
+// NewCluster starts numberOfNodes nodes with the docker.host.teranodeN.daemon settings contexts. The optional
+// settingsOverride is applied to the settings of every node. The nodes are stopped when the test finishes.
+func NewCluster(t *testing.T, numberOfNodes int, settingsOverride func(*settings.Settings)) *Cluster {
+	c := &Cluster{
+		Nodes: make([]*daemon.TestDaemon, 0, numberOfNodes),
+	}
+
+	for i := 1; i <= numberOfNodes; i++ {
+		node := daemon.NewTestDaemon(t, daemon.TestOptions{
+			EnableRPC:       true,
+			EnableP2P:       true,
+			EnableValidator: true,
+			// EnableFullLogging: true,
+			SettingsContext: fmt.Sprintf("docker.host.teranode%d.daemon", i),
+			SettingsOverrideFunc: func(s *settings.Settings) {
+				// competing chains must never be treated as secret mining
+				s.BlockValidation.SecretMiningThreshold = 9999
+
+				if settingsOverride != nil {
+					settingsOverride(s)
+				}
+			},
+			FSMState: blockchain.FSMStateRUNNING,
+		})
+
+		c.Nodes = append(c.Nodes, node)
+	}
+
+	t.Cleanup(func() {
+		for _, node := range c.Nodes {
+			node.Stop(t, true)
+		}
+	})
+
+	return c
+}
+
+// Node returns the node with the given 1-based index, matching the number of its settings context
+func (c *Cluster) Node(i int) *daemon.TestDaemon {
+	return c.Nodes[i-1]
+}
+
+// Connect connects every node to every other node in the list, merging them into a single partition
+func (c *Cluster) Connect(t *testing.T, nodes ...*daemon.TestDaemon) {
+	for i, node := range nodes {
+		for _, peer := range nodes[i+1:] {
+			node.ConnectToPeer(t, peer)
+		}
+	}
+}
+
+// Heal connects every node of partition a to every node of partition b, merging the two partitions
+func (c *Cluster) Heal(t *testing.T, a []*daemon.TestDaemon, b []*daemon.TestDaemon) {
+	for _, nodeA := range a {
+		for _, nodeB := range b {
+			nodeA.ConnectToPeer(t, nodeB)
+		}
+	}
+}
+
+// Disconnect disconnects the nodes of partition a from the nodes of partition b, in both directions
+func (c *Cluster) Disconnect(t *testing.T, a []*daemon.TestDaemon, b []*daemon.TestDaemon) {
+	for _, nodeA := range a {
+		for _, nodeB := range b {
+			nodeA.DisconnectFromPeer(t, nodeB)
+			nodeB.DisconnectFromPeer(t, nodeA)
+		}
+	}
+}
+
+// Mine mines count blocks on the node and waits until the node has processed them. It returns the new tip.
+func (c *Cluster) Mine(t *testing.T, node *daemon.TestDaemon, count uint32, timeout time.Duration) *model.Block {
+	_, meta, err := node.BlockchainClient.GetBestBlockHeader(node.Ctx)
+	require.NoError(t, err)
+
+	err = node.BlockAssemblyClient.GenerateBlocks(node.Ctx, &blockassembly_api.GenerateBlocksRequest{Count: int32(count)}) //nolint:gosec // test block counts are small
+	require.NoError(t, err)
+
+	c.WaitForHeight(t, node, meta.Height+count, timeout)
+
+	block, err := node.BlockchainClient.GetBlockByHeight(node.Ctx, meta.Height+count)
+	require.NoError(t, err)
+
+	return block
+}
+
+// WaitForHeight waits until the best block of the node is at least at the given height
+func (c *Cluster) WaitForHeight(t *testing.T, node *daemon.TestDaemon, height uint32, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		_, meta, err := node.BlockchainClient.GetBestBlockHeader(node.Ctx)
+		if err == nil && meta.Height >= height {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			require.FailNowf(t, "timeout waiting for block height", "node %s did not reach height %d", node.Settings.ClientName, height)
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
+
+// WaitForConvergence waits until all nodes have the expected block as their best block, and block assembly
+// on every node is building on top of it.
+func (c *Cluster) WaitForConvergence(t *testing.T, expected *model.Block, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+
+	for _, node := range c.Nodes {
+		for {
+			ok, state := c.isConverged(node, expected.Hash())
+			if ok {
+				break
+			}
+
+			if time.Now().After(deadline) {
+				require.FailNowf(t, "nodes did not converge", "node %s did not converge on block %s at height %d: %s",
+					node.Settings.ClientName, expected.Hash(), expected.Height, state)
+			}
+
+			time.Sleep(pollInterval)
+		}
+	}
+}
+
+// isConverged reports whether the best block and the block assembly tip of the node are the expected
+// block, together with a description of the current state of the node
+func (c *Cluster) isConverged(node *daemon.TestDaemon, expected *chainhash.Hash) (bool, string) {
+	header, meta, err := node.BlockchainClient.GetBestBlockHeader(node.Ctx)
+	if err != nil {
+		return false, fmt.Sprintf("failed to get best block header: %v", err)
+	}
+
+	if !header.Hash().IsEqual(expected) {
+		return false, fmt.Sprintf("best block is %s at height %d", header.Hash(), meta.Height)
+	}
+
+	state, err := node.BlockAssemblyClient.GetBlockAssemblyState(node.Ctx)
+	if err != nil {
+		return false, fmt.Sprintf("failed to get block assembly state: %v", err)
+	}
+
+	if state.CurrentHash != expected.String() {
+		return false, fmt.Sprintf("block assembly is building on %s at height %d", state.CurrentHash, state.CurrentHeight)
+	}
+
+	return true, ""
+}
+
+// VerifyMainChain verifies that every node has the blocks on its main chain and that the coinbase outputs
+// of the blocks are in the UTXO store of every node, marked as mined on the longest chain
+func (c *Cluster) VerifyMainChain(t *testing.T, blocks ...*model.Block) {
+	for _, node := range c.Nodes {
+		for _, block := range blocks {
+			assert.True(t, isInCurrentChain(t, node, block), "block %s should be on the main chain of %s", block.Hash(), node.Settings.ClientName)
+
+			coinbase, err := node.UtxoStore.Get(node.Ctx, block.CoinbaseTx.TxIDChainHash(), fields.UnminedSince)
+			require.NoError(t, err, "coinbase of block %s should be in the utxo store of %s", block.Hash(), node.Settings.ClientName)
+			assert.Zero(t, coinbase.UnminedSince, "coinbase of block %s should be on the longest chain of %s", block.Hash(), node.Settings.ClientName)
+		}
+	}
+}
+
+// VerifyNotMainChain verifies that blocks of an abandoned chain are not on the main chain of the node
+func (c *Cluster) VerifyNotMainChain(t *testing.T, node *daemon.TestDaemon, blocks ...*model.Block) {
+	for _, block := range blocks {
+		assert.False(t, isInCurrentChain(t, node, block), "block %s should not be on the main chain of %s", block.Hash(), node.Settings.ClientName)
+	}
+}
+
+func isInCurrentChain(t *testing.T, node *daemon.TestDaemon, block *model.Block) bool {
+	_, meta, err := node.BlockchainClient.GetBlockHeader(node.Ctx, block.Hash())
+	require.NoError(t, err, "block %s should be known to %s", block.Hash(), node.Settings.ClientName)
+
+	inChain, err := node.BlockchainClient.CheckBlockIsInCurrentChain(node.Ctx, []uint32{meta.ID})
+	require.NoError(t, err)
+
+	return inChain
+}
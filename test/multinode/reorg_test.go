@@ -0,0 +1,92 @@
+package multinode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/daemon"
+	"github.com/bsv-blockchain/teranode/model"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	blockWait       = 30 * time.Second
+	convergenceWait = 2 * time.Minute
+)
+
+func TestPartitionReorg(t *testing.T) {
+	t.Run("majority partition has the heaviest chain", func(t *testing.T) {
+		testPartitionReorg(t, 3, 5)
+	})
+
+	t.Run("minority partition has the heaviest chain", func(t *testing.T) {
+		testPartitionReorg(t, 6, 4)
+	})
+}
+
+// testPartitionReorg splits 3 nodes into the partitions {node1} and {node2, node3}, mines competing chains
+// of the given lengths on both partitions, heals the partition and verifies all nodes converge on the
+// heaviest chain.
+func testPartitionReorg(t *testing.T, minorityBlocks uint32, majorityBlocks uint32) {
+	cluster := NewCluster(t, 3, func(s *settings.Settings) {
+		// Create a copy to avoid race conditions
+		if s.ChainCfgParams != nil {
+			chainParams := *s.ChainCfgParams
+			chainParams.CoinbaseMaturity = 2
+			s.ChainCfgParams = &chainParams
+		}
+	})
+
+	node1, node2, node3 := cluster.Node(1), cluster.Node(2), cluster.Node(3)
+
+	// the nodes start unconnected, join node2 and node3 into the majority partition
+	cluster.Connect(t, node2, node3)
+
+	minorityTip := cluster.Mine(t, node1, minorityBlocks, blockWait)
+	majorityTip := cluster.Mine(t, node2, majorityBlocks, blockWait)
+
+	cluster.WaitForHeight(t, node3, majorityBlocks, blockWait)
+
+	minorityChain := getChain(t, node1, minorityTip)
+	majorityChain := getChain(t, node2, majorityTip)
+
+	// heal the partition
+	cluster.Heal(t, []*daemon.TestDaemon{node1}, []*daemon.TestDaemon{node2, node3})
+
+	// all blocks have the same difficulty, so the longest chain is the heaviest
+	winningTip, winningChain, losingChain := majorityTip, majorityChain, minorityChain
+	losingNodes := []*daemon.TestDaemon{node1}
+
+	if minorityBlocks > majorityBlocks {
+		winningTip, winningChain, losingChain = minorityTip, minorityChain, majorityChain
+		losingNodes = []*daemon.TestDaemon{node2, node3}
+	}
+
+	cluster.WaitForConvergence(t, winningTip, convergenceWait)
+	cluster.VerifyMainChain(t, winningChain...)
+
+	for _, node := range losingNodes {
+		cluster.VerifyNotMainChain(t, node, losingChain...)
+	}
+
+	// the converged nodes keep extending the same chain
+	newTip := cluster.Mine(t, node3, 1, blockWait)
+
+	cluster.WaitForConvergence(t, newTip, convergenceWait)
+	cluster.VerifyMainChain(t, newTip)
+}
+
+// getChain returns the blocks from height 1 up to and including the tip, read from the node that mined them
+func getChain(t *testing.T, node *daemon.TestDaemon, tip *model.Block) []*model.Block {
+	chain := make([]*model.Block, 0, tip.Height)
+
+	for height := uint32(1); height <= tip.Height; height++ {
+		block, err := node.BlockchainClient.GetBlockByHeight(node.Ctx, height)
+		require.NoError(t, err)
+
+		chain = append(chain, block)
+	}
+
+	return chain
+}
@@ -361,11 +361,11 @@ func (h *AuthHandler) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
-// PostAuthMiddleware is a middleware that requires authentication for all POST requests
+// PostAuthMiddleware is a middleware that requires authentication for all POST and DELETE requests
 func (h *AuthHandler) PostAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		// Only apply authentication to POST requests
-		if c.Request().Method == http.MethodPost {
+		// Only apply authentication to requests changing state
+		if method := c.Request().Method; method == http.MethodPost || method == http.MethodDelete {
 			path := c.Request().URL.Path
 
 			// Skip auth check for login and logout endpoints
@@ -377,7 +377,7 @@ func (h *AuthHandler) PostAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc
 				// Return 401 Unauthorized for API requests
 				return c.JSON(http.StatusUnauthorized, map[string]interface{}{
 					"success": false,
-					"error":   "Authentication required for POST endpoints",
+					"error":   "Authentication required for " + c.Request().Method + " endpoints",
 				})
 			}
 		}
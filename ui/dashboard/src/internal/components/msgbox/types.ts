@@ -55,6 +55,8 @@ export interface BlockAssemblyDetails {
   currentHash?: string             // Hash of the chaintip
   removeMapCount?: number          // Number of transactions in the remove map
   subtrees?: string[]              // Hashes of the current subtrees
+  subtreeSize?: number             // Effective size of new subtrees
+  txArrivalRate?: number           // Smoothed transaction arrival rate in transactions per second
 }
 
 export interface NodeStatusMessage extends P2PMessageBase {
@@ -28,7 +28,14 @@ func InitLogger(progname string, tSettings *settings.Settings) Logger {
 		logOptions = append(logOptions, WithLoggerType(useLogger))
 	}
 
-	logger := New(progname, logOptions...)
+	// the process wide registry controls the level of the loggers of each component at runtime
+	logLevelsErr := ComponentLevels().Configure(logLevel, tSettings.LogLevelComponents)
+
+	logger := ComponentLevels().Logger(progname, New(progname, logOptions...))
+
+	if logLevelsErr != nil {
+		logger.Warnf("[ulogger] %v", logLevelsErr)
+	}
 
 	return logger
 }
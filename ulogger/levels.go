@@ -0,0 +1,279 @@
+package ulogger
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// ComponentLevel describes the log level of a logger component
+type ComponentLevel struct {
+	Component  string `json:"component"`
+	Level      string `json:"level"`
+	Overridden bool   `json:"overridden"` // the level was set for this component and does not follow the default
+}
+
+// componentLevel is the log level shared by all loggers of a component
+type componentLevel struct {
+	level      atomic.Int32
+	overridden atomic.Bool
+}
+
+// LevelRegistry controls the log levels of logger components, e.g. the services of a node, at runtime.
+// Every component follows the default level until a level is set for it, so a single subsystem can be
+// debugged without changing the output of the others.
+type LevelRegistry struct {
+	mu           sync.RWMutex
+	defaultLevel int
+	components   map[string]*componentLevel
+	recent       recentErrors
+}
+
+var componentLevels = NewLevelRegistry("INFO")
+
+// ComponentLevels returns the process wide log level registry
+func ComponentLevels() *LevelRegistry {
+	return componentLevels
+}
+
+// NewLevelRegistry creates a log level registry with the given default level, an invalid level defaults to INFO
+func NewLevelRegistry(defaultLevel string) *LevelRegistry {
+	level, ok := ParseLogLevel(defaultLevel)
+	if !ok {
+		level = LogLevelInfo
+	}
+
+	return &LevelRegistry{
+		defaultLevel: level,
+		components:   make(map[string]*componentLevel),
+	}
+}
+
+// Configure sets the default level and the levels of individual components, given as component=LEVEL
+// entries, e.g. bval=DEBUG. Invalid entries are skipped and reported in the returned error.
+func (r *LevelRegistry) Configure(defaultLevel string, componentLevels []string) error {
+	var errs []error
+
+	if err := r.SetLevel("", defaultLevel); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, entry := range componentLevels {
+		component, level, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || component == "" {
+			errs = append(errs, errors.NewInvalidArgumentError("invalid component log level %s, expected component=LEVEL", entry))
+			continue
+		}
+
+		if err := r.SetLevel(component, level); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseLogLevel parses a log level name, case-insensitive, into its numeric level
+func ParseLogLevel(level string) (int, bool) {
+	switch strings.ToUpper(level) {
+	case "DEBUG":
+		return LogLevelDebug, true
+	case "INFO":
+		return LogLevelInfo, true
+	case "WARN", "WARNING":
+		return LogLevelWarning, true
+	case "ERROR":
+		return LogLevelError, true
+	case "FATAL":
+		return LogLevelFatal, true
+	default:
+		return LogLevelInfo, false
+	}
+}
+
+// Logger wraps the logger of a component, the level of the returned logger is controlled by the registry
+func (r *LevelRegistry) Logger(component string, base Logger) Logger {
+	return &componentLogger{
+		// the registry filters the messages, the wrapped logger accepts all levels and skips the wrapper frame
+		Logger:    base.Duplicate(WithLevel("DEBUG"), WithSkipFrameIncrement(1)),
+		base:      base,
+		component: component,
+		level:     r.getOrCreate(component),
+		registry:  r,
+	}
+}
+
+// SetLevel sets the log level of a component. A level set for a component that has no logger yet is
+// applied once its logger is created. An empty component sets the default level of all components whose
+// level has not been set.
+func (r *LevelRegistry) SetLevel(component string, level string) error {
+	parsed, ok := ParseLogLevel(level)
+	if !ok {
+		return errors.NewInvalidArgumentError("invalid log level %s, expected DEBUG, INFO, WARN, ERROR or FATAL", level)
+	}
+
+	if component == "" {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.defaultLevel = parsed
+
+		for _, c := range r.components {
+			if !c.overridden.Load() {
+				c.level.Store(int32(parsed)) //nolint:gosec // log levels are small
+			}
+		}
+
+		return nil
+	}
+
+	c := r.getOrCreate(component)
+	c.overridden.Store(true)
+	c.level.Store(int32(parsed)) //nolint:gosec // log levels are small
+
+	return nil
+}
+
+// ResetLevel makes a component follow the default level again
+func (r *LevelRegistry) ResetLevel(component string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, exists := r.components[component]
+	if !exists {
+		return errors.NewNotFoundError("unknown log component %s", component)
+	}
+
+	c.overridden.Store(false)
+	c.level.Store(int32(r.defaultLevel)) //nolint:gosec // log levels are small
+
+	return nil
+}
+
+// DefaultLevel returns the level of components whose level has not been set
+func (r *LevelRegistry) DefaultLevel() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return LogLevelString(r.defaultLevel)
+}
+
+// Levels returns the log level of every component, ordered by component name
+func (r *LevelRegistry) Levels() []ComponentLevel {
+	r.mu.RLock()
+	levels := make([]ComponentLevel, 0, len(r.components))
+
+	for name, c := range r.components {
+		levels = append(levels, ComponentLevel{
+			Component:  name,
+			Level:      LogLevelString(int(c.level.Load())),
+			Overridden: c.overridden.Load(),
+		})
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i].Component < levels[j].Component
+	})
+
+	return levels
+}
+
+func (r *LevelRegistry) getOrCreate(component string) *componentLevel {
+	r.mu.RLock()
+	c, exists := r.components[component]
+	r.mu.RUnlock()
+
+	if exists {
+		return c
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if c, exists = r.components[component]; exists {
+		return c
+	}
+
+	c = &componentLevel{}
+	c.level.Store(int32(r.defaultLevel)) //nolint:gosec // log levels are small
+	r.components[component] = c
+
+	return c
+}
+
+// componentLogger is a logger whose level is controlled by a LevelRegistry
+type componentLogger struct {
+	Logger
+	base      Logger
+	component string
+	level     *componentLevel
+	registry  *LevelRegistry
+}
+
+func (l *componentLogger) enabled(level int) bool {
+	return int(l.level.level.Load()) <= level
+}
+
+func (l *componentLogger) LogLevel() int {
+	return int(l.level.level.Load())
+}
+
+// SetLogLevel sets the level of the component of the logger, affecting all loggers of the component
+func (l *componentLogger) SetLogLevel(level string) {
+	if err := l.registry.SetLevel(l.component, level); err != nil {
+		l.Logger.Warnf("[ulogger] %v", err)
+	}
+}
+
+func (l *componentLogger) Debugf(format string, args ...interface{}) {
+	if l.enabled(LogLevelDebug) {
+		l.Logger.Debugf(format, args...)
+	}
+}
+
+func (l *componentLogger) Infof(format string, args ...interface{}) {
+	if l.enabled(LogLevelInfo) {
+		l.Logger.Infof(format, args...)
+	}
+}
+
+func (l *componentLogger) Warnf(format string, args ...interface{}) {
+	if l.enabled(LogLevelWarning) {
+		l.Logger.Warnf(format, args...)
+	}
+}
+
+func (l *componentLogger) Errorf(format string, args ...interface{}) {
+	l.registry.recent.record(l.component, LogLevelError, format, args...)
+
+	if l.enabled(LogLevelError) {
+		l.Logger.Errorf(format, args...)
+	}
+}
+
+// Fatalf is always logged
+func (l *componentLogger) Fatalf(format string, args ...interface{}) {
+	l.registry.recent.record(l.component, LogLevelFatal, format, args...)
+
+	l.Logger.Fatalf(format, args...)
+}
+
+// New creates the logger of another component, controlled by the same registry
+func (l *componentLogger) New(service string, options ...Option) Logger {
+	return l.registry.Logger(service, l.base.New(service, options...))
+}
+
+// Duplicate duplicates the logger, the duplicate stays part of the component
+func (l *componentLogger) Duplicate(options ...Option) Logger {
+	return &componentLogger{
+		Logger:    l.Logger.Duplicate(options...),
+		base:      l.base,
+		component: l.component,
+		level:     l.level,
+		registry:  l.registry,
+	}
+}
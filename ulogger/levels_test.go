@@ -0,0 +1,138 @@
+package ulogger
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLogger records the levels of the messages it logs
+type recordingLogger struct {
+	TestLogger
+	mu       *sync.Mutex
+	messages *[]string
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{mu: &sync.Mutex{}, messages: &[]string{}}
+}
+
+func (l *recordingLogger) record(level string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	*l.messages = append(*l.messages, level)
+}
+
+func (l *recordingLogger) recorded() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), *l.messages...)
+}
+
+func (l *recordingLogger) Debugf(string, ...interface{}) { l.record("DEBUG") }
+func (l *recordingLogger) Infof(string, ...interface{})  { l.record("INFO") }
+func (l *recordingLogger) Warnf(string, ...interface{})  { l.record("WARN") }
+func (l *recordingLogger) Errorf(string, ...interface{}) { l.record("ERROR") }
+
+func (l *recordingLogger) New(string, ...Option) Logger { return l }
+func (l *recordingLogger) Duplicate(...Option) Logger   { return l }
+func logAll(l Logger) {
+	l.Debugf("debug")
+	l.Infof("info")
+	l.Warnf("warn")
+	l.Errorf("error")
+}
+
+func TestLevelRegistry(t *testing.T) {
+	t.Run("components follow the default level", func(t *testing.T) {
+		r := NewLevelRegistry("INFO")
+
+		bval := newRecordingLogger()
+		p2p := newRecordingLogger()
+
+		bvalLogger := r.Logger("bval", bval)
+		p2pLogger := r.Logger("p2p", p2p)
+
+		logAll(bvalLogger)
+		assert.Equal(t, []string{"INFO", "WARN", "ERROR"}, bval.recorded())
+
+		require.NoError(t, r.SetLevel("", "WARN"))
+		logAll(p2pLogger)
+		assert.Equal(t, []string{"WARN", "ERROR"}, p2p.recorded())
+		assert.Equal(t, "WARN", r.DefaultLevel())
+	})
+
+	t.Run("a component level does not affect other components", func(t *testing.T) {
+		r := NewLevelRegistry("INFO")
+
+		bval := newRecordingLogger()
+		p2p := newRecordingLogger()
+
+		bvalLogger := r.Logger("bval", bval)
+		p2pLogger := r.Logger("p2p", p2p)
+
+		require.NoError(t, r.SetLevel("bval", "debug"))
+
+		logAll(bvalLogger)
+		logAll(p2pLogger)
+
+		assert.Equal(t, []string{"DEBUG", "INFO", "WARN", "ERROR"}, bval.recorded())
+		assert.Equal(t, []string{"INFO", "WARN", "ERROR"}, p2p.recorded())
+		assert.Equal(t, LogLevelDebug, bvalLogger.LogLevel())
+
+		// a changed default level does not affect a component with a level of its own
+		require.NoError(t, r.SetLevel("", "ERROR"))
+		assert.Equal(t, []ComponentLevel{
+			{Component: "bval", Level: "DEBUG", Overridden: true},
+			{Component: "p2p", Level: "ERROR", Overridden: false},
+		}, r.Levels())
+
+		require.NoError(t, r.ResetLevel("bval"))
+		assert.Equal(t, LogLevelError, bvalLogger.LogLevel())
+	})
+
+	t.Run("loggers of a component share the level", func(t *testing.T) {
+		r := NewLevelRegistry("INFO")
+
+		first := r.Logger("bval", newRecordingLogger())
+		second := r.Logger("bval", newRecordingLogger()).Duplicate()
+
+		first.SetLogLevel("DEBUG")
+		assert.Equal(t, LogLevelDebug, second.LogLevel())
+
+		child := first.New("bval-child")
+		assert.Equal(t, LogLevelInfo, child.LogLevel(), "loggers created from a component logger are components of their own")
+	})
+
+	t.Run("levels set before the logger exists", func(t *testing.T) {
+		r := NewLevelRegistry("INFO")
+
+		require.NoError(t, r.SetLevel("rpc", "DEBUG"))
+		assert.Equal(t, LogLevelDebug, r.Logger("rpc", newRecordingLogger()).LogLevel())
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		r := NewLevelRegistry("INFO")
+
+		assert.True(t, errors.Is(r.SetLevel("bval", "LOUD"), errors.ErrInvalidArgument))
+		assert.True(t, errors.Is(r.ResetLevel("unknown"), errors.ErrNotFound))
+	})
+
+	t.Run("configure", func(t *testing.T) {
+		r := NewLevelRegistry("INFO")
+
+		err := r.Configure("WARN", []string{"bval=DEBUG", " p2p=error", "invalid", "rpc=LOUD"})
+		require.Error(t, err)
+
+		assert.Equal(t, "WARN", r.DefaultLevel())
+		assert.Equal(t, []ComponentLevel{
+			{Component: "bval", Level: "DEBUG", Overridden: true},
+			{Component: "p2p", Level: "ERROR", Overridden: true},
+		}, r.Levels())
+	})
+}
@@ -0,0 +1,75 @@
+package ulogger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// recentErrorsSize is the number of errors a LevelRegistry keeps
+	recentErrorsSize = 200
+
+	// maxRecentErrorLength is the length a recent error message is truncated to
+	maxRecentErrorLength = 2048
+)
+
+// RecentError is an error or fatal message logged by a logger component
+type RecentError struct {
+	Time      time.Time `json:"time"`
+	Component string    `json:"component"`
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+}
+
+// recentErrors is a ring buffer of the last errors logged by the components of a registry
+type recentErrors struct {
+	mu     sync.Mutex
+	errors []RecentError
+	next   int
+}
+
+func (r *recentErrors) record(component string, level int, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if len(message) > maxRecentErrorLength {
+		message = message[:maxRecentErrorLength] + "..."
+	}
+
+	entry := RecentError{
+		Time:      time.Now(),
+		Component: component,
+		Level:     LogLevelString(level),
+		Message:   message,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.errors) < recentErrorsSize {
+		r.errors = append(r.errors, entry)
+		return
+	}
+
+	r.errors[r.next] = entry
+	r.next = (r.next + 1) % recentErrorsSize
+}
+
+// list returns the recent errors, newest first
+func (r *recentErrors) list() []RecentError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]RecentError, 0, len(r.errors))
+
+	for i := len(r.errors) - 1; i >= 0; i-- {
+		list = append(list, r.errors[(r.next+i)%len(r.errors)])
+	}
+
+	return list
+}
+
+// RecentErrors returns the last errors logged by the components of the registry, newest first. Errors are kept
+// whatever the log level of their component, so they are available for diagnostics when errors are not logged.
+func (r *LevelRegistry) RecentErrors() []RecentError {
+	return r.recent.list()
+}
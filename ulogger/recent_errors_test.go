@@ -0,0 +1,63 @@
+package ulogger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelRegistry_RecentErrors(t *testing.T) {
+	t.Run("errors of all components, newest first", func(t *testing.T) {
+		r := NewLevelRegistry("INFO")
+
+		bval := r.Logger("bval", newRecordingLogger())
+		p2p := r.Logger("p2p", newRecordingLogger())
+
+		bval.Warnf("warn")
+		bval.Errorf("block %d invalid", 1)
+		p2p.Errorf("peer %s banned", "abc")
+
+		recent := r.RecentErrors()
+		require.Len(t, recent, 2, "only errors are kept")
+		assert.Equal(t, "p2p", recent[0].Component)
+		assert.Equal(t, "peer abc banned", recent[0].Message)
+		assert.Equal(t, "ERROR", recent[0].Level)
+		assert.Equal(t, "bval", recent[1].Component)
+		assert.Equal(t, "block 1 invalid", recent[1].Message)
+		assert.False(t, recent[1].Time.After(recent[0].Time))
+	})
+
+	t.Run("kept when errors are not logged", func(t *testing.T) {
+		r := NewLevelRegistry("FATAL")
+
+		logger := newRecordingLogger()
+		r.Logger("bval", logger).Errorf("error")
+
+		assert.Empty(t, logger.recorded())
+		assert.Len(t, r.RecentErrors(), 1)
+	})
+
+	t.Run("oldest errors are dropped", func(t *testing.T) {
+		r := NewLevelRegistry("INFO")
+		logger := r.Logger("bval", newRecordingLogger())
+
+		for i := 0; i < recentErrorsSize+10; i++ {
+			logger.Errorf("error %d", i)
+		}
+
+		recent := r.RecentErrors()
+		require.Len(t, recent, recentErrorsSize)
+		assert.Equal(t, fmt.Sprintf("error %d", recentErrorsSize+9), recent[0].Message)
+		assert.Equal(t, "error 10", recent[recentErrorsSize-1].Message)
+	})
+
+	t.Run("long messages are truncated", func(t *testing.T) {
+		r := NewLevelRegistry("INFO")
+		r.Logger("bval", newRecordingLogger()).Errorf("%s", strings.Repeat("x", 2*maxRecentErrorLength))
+
+		assert.Len(t, r.RecentErrors()[0].Message, maxRecentErrorLength+len("..."))
+	})
+}
@@ -0,0 +1,195 @@
+// Package audit records the privileged operations of the node, e.g. bans, block invalidations, FSM events and
+// runtime setting changes, as append-only audit records of who did what to which target and when.
+//
+// The records are persisted in a Store, implemented by the blockchain client and store, which keep them in the
+// audit_log table of the blockchain SQL store. The records of every actor are rate limited, so a misbehaving script
+// can not flood the store: the records over the limit are dropped, and the number of records dropped is kept on the
+// next record of the actor that is persisted.
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+)
+
+// Actions of the audit records
+const (
+	ActionBan               = "ban"
+	ActionUnban             = "unban"
+	ActionClearBans         = "clear_bans"
+	ActionInvalidateBlock   = "invalidate_block"
+	ActionRevalidateBlock   = "revalidate_block"
+	ActionFSMEvent          = "fsm_event"
+	ActionSetLogLevel       = "set_log_level"
+	ActionSetBandwidth      = "set_bandwidth"
+	ActionOperatorMessage   = "operator_message"
+	ActionDiagnosticsBundle = "diagnostics_bundle"
+)
+
+const (
+	// UnknownActor is the actor of the records of operations without an actor in their context
+	UnknownActor = "unknown"
+
+	// rateLimitWindow is the window the records of an actor are rate limited over
+	rateLimitWindow = time.Minute
+
+	// persistTimeout bounds the persisting of a record, which is not cancelled with the request of the operation
+	persistTimeout = 5 * time.Second
+)
+
+// Record is an audit record of a privileged operation
+type Record struct {
+	ID        int64     `json:"id"`                // Sequence number of the record, assigned by the store
+	Timestamp time.Time `json:"timestamp"`         // When the operation was performed
+	Actor     string    `json:"actor"`             // Who performed the operation, e.g. admin@10.0.0.1
+	Action    string    `json:"action"`            // What was done, one of the Action constants
+	Target    string    `json:"target"`            // What the operation was performed on, e.g. a peer or a block hash
+	Details   string    `json:"details,omitempty"` // Parameters and outcome of the operation
+	Dropped   int       `json:"dropped,omitempty"` // Records of the actor dropped by the rate limit before this record
+}
+
+// Store persists the audit records. Records are only appended, GetAuditRecords returns the records newest first,
+// skipping offset records, and the total number of records.
+type Store interface {
+	AddAuditRecord(ctx context.Context, record *Record) error
+	GetAuditRecords(ctx context.Context, offset, limit int) ([]*Record, int, error)
+}
+
+// actorWindow counts the records of an actor within the current rate limit window
+type actorWindow struct {
+	start   time.Time
+	count   int
+	dropped int
+}
+
+// Logger records audit records in a store, at most limit records per actor per minute. A nil Logger records
+// nothing, so services without an audit store do not need to check for one.
+type Logger struct {
+	logger ulogger.Logger
+	store  Store
+	limit  int
+
+	mu      sync.Mutex
+	windows map[string]*actorWindow
+	now     func() time.Time
+}
+
+// NewLogger creates a logger recording in the store, at most limit records per actor per minute, 0 for no limit
+func NewLogger(logger ulogger.Logger, store Store, limit int) *Logger {
+	initPrometheusMetrics()
+
+	return &Logger{
+		logger:  logger,
+		store:   store,
+		limit:   limit,
+		windows: make(map[string]*actorWindow),
+		now:     time.Now,
+	}
+}
+
+// Record persists an audit record of the operation. Failing to persist the record does not fail the operation, it
+// is logged and counted instead.
+func (l *Logger) Record(ctx context.Context, actor, action, target, details string) {
+	if l == nil {
+		return
+	}
+
+	if actor == "" {
+		actor = UnknownActor
+	}
+
+	now := l.now()
+
+	dropped, allowed := l.allow(actor, now)
+	if !allowed {
+		prometheusAuditRecordsDropped.Inc()
+		return
+	}
+
+	record := &Record{
+		Timestamp: now,
+		Actor:     actor,
+		Action:    action,
+		Target:    target,
+		Details:   details,
+		Dropped:   dropped,
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), persistTimeout)
+	defer cancel()
+
+	if err := l.store.AddAuditRecord(ctx, record); err != nil {
+		prometheusAuditRecordFailures.Inc()
+		l.logger.Errorf("[audit] failed to persist audit record %s %s %s: %v", actor, action, target, err)
+
+		return
+	}
+
+	prometheusAuditRecords.WithLabelValues(action).Inc()
+}
+
+// Records returns the persisted audit records, newest first, and the total number of records
+func (l *Logger) Records(ctx context.Context, offset, limit int) ([]*Record, int, error) {
+	if l == nil {
+		return nil, 0, nil
+	}
+
+	return l.store.GetAuditRecords(ctx, offset, limit)
+}
+
+// allow returns whether a record of the actor is within the rate limit, and the number of records of the actor
+// dropped since its last allowed record
+func (l *Logger) allow(actor string, now time.Time) (dropped int, allowed bool) {
+	if l.limit <= 0 {
+		return 0, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for a, w := range l.windows {
+		if now.Sub(w.start) >= rateLimitWindow && w.dropped == 0 {
+			delete(l.windows, a)
+		}
+	}
+
+	w, exists := l.windows[actor]
+	if !exists {
+		w = &actorWindow{start: now}
+		l.windows[actor] = w
+	} else if now.Sub(w.start) >= rateLimitWindow {
+		w.start = now
+		w.count = 0
+	}
+
+	if w.count >= l.limit {
+		w.dropped++
+		return 0, false
+	}
+
+	w.count++
+
+	dropped = w.dropped
+	w.dropped = 0
+
+	return dropped, true
+}
+
+type actorContextKey struct{}
+
+// WithActor returns a context carrying the actor of the operations performed with it
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by the context, UnknownActor when it carries none
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorContextKey{}).(string); ok && actor != "" {
+		return actor
+	}
+
+	return UnknownActor
+}
@@ -0,0 +1,124 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore keeps the audit records in memory
+type memoryStore struct {
+	mu      sync.Mutex
+	records []*Record
+	err     error
+}
+
+func (m *memoryStore) AddAuditRecord(_ context.Context, record *Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+
+	record.ID = int64(len(m.records) + 1)
+	m.records = append(m.records, record)
+
+	return nil
+}
+
+func (m *memoryStore) GetAuditRecords(_ context.Context, offset, limit int) ([]*Record, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	records := make([]*Record, 0, limit)
+
+	for i := len(m.records) - 1 - offset; i >= 0 && len(records) < limit; i-- {
+		records = append(records, m.records[i])
+	}
+
+	return records, len(m.records), nil
+}
+
+func TestLogger_Record(t *testing.T) {
+	store := &memoryStore{}
+	l := NewLogger(ulogger.TestLogger{}, store, 0)
+
+	l.Record(context.Background(), "admin@127.0.0.1", ActionBan, "10.0.0.1", "until=2030-01-01T00:00:00Z")
+	l.Record(context.Background(), "", ActionClearBans, "", "")
+
+	records, total, err := l.Records(context.Background(), 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, UnknownActor, records[0].Actor)
+	assert.Equal(t, ActionClearBans, records[0].Action)
+
+	assert.Equal(t, int64(1), records[1].ID)
+	assert.Equal(t, "admin@127.0.0.1", records[1].Actor)
+	assert.Equal(t, ActionBan, records[1].Action)
+	assert.Equal(t, "10.0.0.1", records[1].Target)
+	assert.False(t, records[1].Timestamp.IsZero())
+}
+
+func TestLogger_RateLimit(t *testing.T) {
+	store := &memoryStore{}
+	l := NewLogger(ulogger.TestLogger{}, store, 2)
+
+	now := time.Unix(1700000000, 0)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		l.Record(context.Background(), "script", ActionBan, "10.0.0.1", "")
+	}
+
+	// other actors have their own limit
+	l.Record(context.Background(), "admin", ActionUnban, "10.0.0.1", "")
+
+	require.Len(t, store.records, 3)
+
+	// the next window carries the number of records dropped
+	now = now.Add(rateLimitWindow)
+	l.Record(context.Background(), "script", ActionBan, "10.0.0.2", "")
+
+	require.Len(t, store.records, 4)
+	assert.Equal(t, "10.0.0.2", store.records[3].Target)
+	assert.Equal(t, 3, store.records[3].Dropped)
+
+	l.Record(context.Background(), "script", ActionBan, "10.0.0.3", "")
+
+	require.Len(t, store.records, 5)
+	assert.Equal(t, 0, store.records[4].Dropped)
+}
+
+func TestLogger_StoreFailure(t *testing.T) {
+	store := &memoryStore{err: errors.NewStorageError("database unavailable")}
+	l := NewLogger(ulogger.TestLogger{}, store, 0)
+
+	// failing to persist a record does not fail the operation
+	l.Record(context.Background(), "admin", ActionBan, "10.0.0.1", "")
+	assert.Empty(t, store.records)
+}
+
+func TestLogger_Nil(t *testing.T) {
+	var l *Logger
+
+	l.Record(context.Background(), "admin", ActionBan, "10.0.0.1", "")
+
+	records, total, err := l.Records(context.Background(), 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.Equal(t, 0, total)
+}
+
+func TestActorFromContext(t *testing.T) {
+	assert.Equal(t, UnknownActor, ActorFromContext(context.Background()))
+	assert.Equal(t, "admin@127.0.0.1", ActorFromContext(WithActor(context.Background(), "admin@127.0.0.1")))
+}
@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// prometheusAuditRecords counts the persisted audit records.
+	// Labels: action
+	prometheusAuditRecords *prometheus.CounterVec
+
+	// prometheusAuditRecordsDropped counts the audit records dropped by the rate limit
+	prometheusAuditRecordsDropped prometheus.Counter
+
+	// prometheusAuditRecordFailures counts the audit records that could not be persisted
+	prometheusAuditRecordFailures prometheus.Counter
+)
+
+var (
+	prometheusMetricsInitOnce sync.Once
+)
+
+func initPrometheusMetrics() {
+	prometheusMetricsInitOnce.Do(_initPrometheusMetrics)
+}
+
+func _initPrometheusMetrics() {
+	prometheusAuditRecords = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "audit",
+			Name:      "records_total",
+			Help:      "Number of audit records persisted per action",
+		},
+		[]string{"action"},
+	)
+
+	prometheusAuditRecordsDropped = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "audit",
+			Name:      "records_dropped_total",
+			Help:      "Number of audit records dropped because their actor exceeded the rate limit",
+		},
+	)
+
+	prometheusAuditRecordFailures = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "audit",
+			Name:      "record_failures_total",
+			Help:      "Number of audit records that could not be persisted",
+		},
+	)
+}
@@ -0,0 +1,98 @@
+package bandwidth
+
+import (
+	"context"
+	"io"
+)
+
+// writeChunkSize is the largest chunk a throttled writer writes at once, so large responses are paced
+// instead of being written in a single burst after a long wait
+const writeChunkSize = 32 * 1024
+
+type reader struct {
+	ctx       context.Context
+	scheduler *Scheduler
+	class     Class
+	r         io.Reader
+}
+
+// NewReader returns a reader accounting the bytes read from r to the class, blocking further reads
+// while the class is over its share of the budget
+func NewReader(ctx context.Context, scheduler *Scheduler, class Class, r io.Reader) io.Reader {
+	if scheduler == nil {
+		return r
+	}
+
+	return &reader{ctx: ctx, scheduler: scheduler, class: class, r: r}
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if waitErr := r.scheduler.Wait(r.ctx, r.class, n); waitErr != nil && err == nil {
+			err = waitErr
+		}
+	}
+
+	return n, err
+}
+
+type readCloser struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (rc *readCloser) Close() error {
+	return rc.closer.Close()
+}
+
+// NewReadCloser is NewReader for an io.ReadCloser, closing the underlying reader on Close
+func NewReadCloser(ctx context.Context, scheduler *Scheduler, class Class, rc io.ReadCloser) io.ReadCloser {
+	if scheduler == nil {
+		return rc
+	}
+
+	return &readCloser{Reader: NewReader(ctx, scheduler, class, rc), closer: rc}
+}
+
+type writer struct {
+	ctx       context.Context
+	scheduler *Scheduler
+	class     Class
+	w         io.Writer
+}
+
+// NewWriter returns a writer that waits for the class's share of the budget before writing to w
+func NewWriter(ctx context.Context, scheduler *Scheduler, class Class, w io.Writer) io.Writer {
+	if scheduler == nil {
+		return w
+	}
+
+	return &writer{ctx: ctx, scheduler: scheduler, class: class, w: w}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	written := 0
+
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > writeChunkSize {
+			chunk = chunk[:writeChunkSize]
+		}
+
+		if err := w.scheduler.Wait(w.ctx, w.class, len(chunk)); err != nil {
+			return written, err
+		}
+
+		n, err := w.w.Write(chunk)
+		written += n
+
+		if err != nil {
+			return written, err
+		}
+
+		p = p[n:]
+	}
+
+	return written, nil
+}
@@ -0,0 +1,304 @@
+// Package bandwidth provides a process-wide bandwidth budget shared by the services that move bulk
+// data over the network: catchup fetches, subtree fetches and DataHub serving responses.
+//
+// The budget is split between traffic classes according to their weights. Only classes that moved
+// data recently take part in the split, so a class gets the whole budget while the others are idle
+// and falls back to its weighted share as soon as they become active again. This allows catchup
+// traffic to be capped relative to block propagation and API serving on constrained links without
+// leaving bandwidth unused when catchup is the only traffic.
+package bandwidth
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"golang.org/x/time/rate"
+)
+
+// Class identifies a kind of bulk traffic sharing the bandwidth budget
+type Class string
+
+const (
+	// ClassCatchup is the traffic of blocks and subtrees fetched during catchup
+	ClassCatchup Class = "catchup"
+
+	// ClassSubtree is the traffic of subtrees fetched for block propagation
+	ClassSubtree Class = "subtree"
+
+	// ClassDataHub is the traffic of DataHub responses served to peers and API clients
+	ClassDataHub Class = "datahub"
+)
+
+const (
+	// activeWindow is how long a class keeps its share of the budget after its last transfer
+	activeWindow = 2 * time.Second
+
+	// minBurst is the smallest token bucket size, so small budgets do not split transfers into tiny chunks
+	minBurst = 64 * 1024
+)
+
+// Config holds the bandwidth budget configuration
+type Config struct {
+	Limit   uint64            // Total budget in bytes per second, 0 means unlimited
+	Weights map[Class]float64 // Relative share of each class, classes without a weight have weight 1
+}
+
+// ClassStats describes the current state of a traffic class
+type ClassStats struct {
+	Class      Class   `json:"class"`
+	Weight     float64 `json:"weight"`
+	Active     bool    `json:"active"`
+	RateLimit  uint64  `json:"rate_limit"` // Current share in bytes per second, 0 when the budget is unlimited
+	BytesTotal uint64  `json:"bytes_total"`
+}
+
+type classState struct {
+	limiter    *rate.Limiter
+	weight     float64
+	active     bool
+	lastActive time.Time
+	bytesTotal uint64
+}
+
+// Scheduler splits a bandwidth budget between traffic classes. A nil scheduler does not limit anything.
+type Scheduler struct {
+	mu      sync.Mutex
+	limit   uint64
+	classes map[Class]*classState
+	now     func() time.Time
+}
+
+var defaultScheduler = NewScheduler(Config{})
+
+// Default returns the scheduler shared by all services of this process. It is unlimited until configured.
+func Default() *Scheduler {
+	return defaultScheduler
+}
+
+// ConfigFromSettings returns the bandwidth budget configured in the settings
+func ConfigFromSettings(tSettings *settings.Settings) Config {
+	return Config{
+		Limit: tSettings.Bandwidth.Limit,
+		Weights: map[Class]float64{
+			ClassCatchup: tSettings.Bandwidth.CatchupWeight,
+			ClassSubtree: tSettings.Bandwidth.SubtreeWeight,
+			ClassDataHub: tSettings.Bandwidth.DataHubWeight,
+		},
+	}
+}
+
+// NewScheduler creates a new bandwidth scheduler
+func NewScheduler(config Config) *Scheduler {
+	s := &Scheduler{
+		classes: make(map[Class]*classState),
+		now:     time.Now,
+	}
+
+	s.Configure(config)
+
+	return s
+}
+
+// Configure replaces the budget and class weights at runtime
+func (s *Scheduler) Configure(config Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.limit = config.Limit
+
+	for class, weight := range config.Weights {
+		s.getClass(class).weight = validWeight(weight)
+	}
+
+	s.rebalance()
+}
+
+// SetLimit changes the total budget at runtime, 0 removes the limit
+func (s *Scheduler) SetLimit(bytesPerSecond uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.limit = bytesPerSecond
+	s.rebalance()
+}
+
+// SetWeight changes the weight of a class at runtime
+func (s *Scheduler) SetWeight(class Class, weight float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.getClass(class).weight = validWeight(weight)
+	s.rebalance()
+}
+
+// Limit returns the total budget in bytes per second, 0 means unlimited
+func (s *Scheduler) Limit() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.limit
+}
+
+// Stats returns the state of all known classes, sorted by class
+func (s *Scheduler) Stats() []ClassStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.expire(s.now()) {
+		s.rebalance()
+	}
+
+	stats := make([]ClassStats, 0, len(s.classes))
+
+	for class, state := range s.classes {
+		var rateLimit uint64
+		if s.limit > 0 {
+			rateLimit = uint64(state.limiter.Limit())
+		}
+
+		stats = append(stats, ClassStats{
+			Class:      class,
+			Weight:     state.weight,
+			Active:     state.active,
+			RateLimit:  rateLimit,
+			BytesTotal: state.bytesTotal,
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Class < stats[j].Class
+	})
+
+	return stats
+}
+
+// Wait accounts n bytes of traffic to the class and blocks until the class's share of the budget allows it
+func (s *Scheduler) Wait(ctx context.Context, class Class, n int) error {
+	if s == nil || n <= 0 {
+		return nil
+	}
+
+	limiter := s.acquire(class, n)
+	if limiter == nil {
+		return nil
+	}
+
+	for n > 0 {
+		chunk := n
+		if burst := limiter.Burst(); chunk > burst {
+			chunk = burst
+		}
+
+		if err := limiter.WaitN(ctx, chunk); err != nil {
+			return errors.NewContextCanceledError("waiting for %s bandwidth", class, err)
+		}
+
+		n -= chunk
+	}
+
+	return nil
+}
+
+// acquire marks the class as active, rebalancing the budget if the set of active classes changed, and
+// returns the limiter to wait on, or nil when the budget is unlimited
+func (s *Scheduler) acquire(class Class, n int) *rate.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	changed := s.expire(now)
+
+	state := s.getClass(class)
+	state.bytesTotal += uint64(n)
+	state.lastActive = now
+
+	if !state.active {
+		state.active = true
+		changed = true
+	}
+
+	if changed {
+		s.rebalance()
+	}
+
+	if s.limit == 0 {
+		return nil
+	}
+
+	return state.limiter
+}
+
+// expire deactivates classes that have not moved data within the active window and reports whether any
+// class was deactivated, must be called with the lock held
+func (s *Scheduler) expire(now time.Time) bool {
+	changed := false
+
+	for _, state := range s.classes {
+		if state.active && now.Sub(state.lastActive) > activeWindow {
+			state.active = false
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// rebalance splits the budget between the active classes by weight. Inactive classes get the share they
+// would have if they became active, must be called with the lock held.
+func (s *Scheduler) rebalance() {
+	var activeWeight float64
+
+	for _, state := range s.classes {
+		if state.active {
+			activeWeight += state.weight
+		}
+	}
+
+	for _, state := range s.classes {
+		if s.limit == 0 {
+			state.limiter.SetLimit(rate.Inf)
+			continue
+		}
+
+		totalWeight := activeWeight
+		if !state.active {
+			totalWeight += state.weight
+		}
+
+		share := float64(s.limit) * state.weight / totalWeight
+
+		burst := int(share)
+		if burst < minBurst {
+			burst = minBurst
+		}
+
+		state.limiter.SetLimit(rate.Limit(share))
+		state.limiter.SetBurst(burst)
+	}
+}
+
+// getClass returns the state of a class, creating it if needed, must be called with the lock held
+func (s *Scheduler) getClass(class Class) *classState {
+	state, ok := s.classes[class]
+	if !ok {
+		state = &classState{
+			limiter: rate.NewLimiter(rate.Inf, minBurst),
+			weight:  1,
+		}
+		s.classes[class] = state
+	}
+
+	return state
+}
+
+func validWeight(weight float64) float64 {
+	if weight <= 0 {
+		return 1
+	}
+
+	return weight
+}
@@ -0,0 +1,126 @@
+package bandwidth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func statsByClass(s *Scheduler) map[Class]ClassStats {
+	stats := make(map[Class]ClassStats)
+	for _, st := range s.Stats() {
+		stats[st.Class] = st
+	}
+
+	return stats
+}
+
+func TestScheduler_Unlimited(t *testing.T) {
+	s := NewScheduler(Config{})
+
+	require.NoError(t, s.Wait(context.Background(), ClassCatchup, 100*1024*1024))
+
+	stats := statsByClass(s)
+	assert.Equal(t, uint64(100*1024*1024), stats[ClassCatchup].BytesTotal)
+	assert.Zero(t, stats[ClassCatchup].RateLimit)
+}
+
+func TestScheduler_NilIsUnlimited(t *testing.T) {
+	var s *Scheduler
+	assert.NoError(t, s.Wait(context.Background(), ClassCatchup, 1024))
+}
+
+func TestScheduler_WeightedShares(t *testing.T) {
+	now := time.Now()
+
+	s := NewScheduler(Config{
+		Limit:   7_000_000,
+		Weights: map[Class]float64{ClassCatchup: 1, ClassSubtree: 4, ClassDataHub: 2},
+	})
+	s.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// A class that is the only active one gets the whole budget
+	require.NoError(t, s.Wait(ctx, ClassCatchup, 1))
+	assert.Equal(t, uint64(7_000_000), statsByClass(s)[ClassCatchup].RateLimit)
+
+	// Once all classes are active the budget is split by weight
+	require.NoError(t, s.Wait(ctx, ClassSubtree, 1))
+	require.NoError(t, s.Wait(ctx, ClassDataHub, 1))
+
+	stats := statsByClass(s)
+	assert.Equal(t, uint64(1_000_000), stats[ClassCatchup].RateLimit)
+	assert.Equal(t, uint64(4_000_000), stats[ClassSubtree].RateLimit)
+	assert.Equal(t, uint64(2_000_000), stats[ClassDataHub].RateLimit)
+
+	// Idle classes leave their share to the active ones
+	now = now.Add(activeWindow + time.Second)
+	require.NoError(t, s.Wait(ctx, ClassCatchup, 1))
+
+	stats = statsByClass(s)
+	assert.True(t, stats[ClassCatchup].Active)
+	assert.False(t, stats[ClassSubtree].Active)
+	assert.Equal(t, uint64(7_000_000), stats[ClassCatchup].RateLimit)
+}
+
+func TestScheduler_RuntimeAdjustment(t *testing.T) {
+	s := NewScheduler(Config{Limit: 1_000_000})
+	ctx := context.Background()
+
+	require.NoError(t, s.Wait(ctx, ClassCatchup, 1))
+	require.NoError(t, s.Wait(ctx, ClassDataHub, 1))
+
+	stats := statsByClass(s)
+	assert.Equal(t, uint64(500_000), stats[ClassCatchup].RateLimit, "classes without a weight have weight 1")
+
+	s.SetWeight(ClassDataHub, 3)
+	assert.Equal(t, uint64(250_000), statsByClass(s)[ClassCatchup].RateLimit)
+
+	s.SetLimit(2_000_000)
+	assert.Equal(t, uint64(2_000_000), s.Limit())
+	assert.Equal(t, uint64(500_000), statsByClass(s)[ClassCatchup].RateLimit)
+
+	s.SetLimit(0)
+	assert.Zero(t, statsByClass(s)[ClassCatchup].RateLimit)
+	require.NoError(t, s.Wait(ctx, ClassCatchup, 100*1024*1024))
+}
+
+func TestScheduler_WaitContextDeadline(t *testing.T) {
+	s := NewScheduler(Config{Limit: 128 * 1024})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := s.Wait(ctx, ClassCatchup, 10*1024*1024)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrContextCanceled))
+}
+
+func TestReaderAndWriter(t *testing.T) {
+	s := NewScheduler(Config{})
+	ctx := context.Background()
+
+	data := bytes.Repeat([]byte{1}, 100_000)
+
+	read, err := io.ReadAll(NewReadCloser(ctx, s, ClassSubtree, io.NopCloser(bytes.NewReader(data))))
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+
+	var buf bytes.Buffer
+
+	n, err := NewWriter(ctx, s, ClassDataHub, &buf).Write(data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, data, buf.Bytes())
+
+	stats := statsByClass(s)
+	assert.Equal(t, uint64(len(data)), stats[ClassSubtree].BytesTotal)
+	assert.Equal(t, uint64(len(data)), stats[ClassDataHub].BytesTotal)
+}
@@ -12,14 +12,21 @@ import (
 	"github.com/bsv-blockchain/teranode/util/retry"
 )
 
+// subscriptionTimeout is how long to wait on the state subscription for block assembly to catch up, about as long
+// as the polling fallback retries
+var subscriptionTimeout = 400 * time.Second
+
 // WaitForBlockAssemblyReady waits for the block assembly service to be ready to process
 // a block at the given height. This ensures that all necessary data (such as coinbase
 // transactions) has been processed before allowing block validation to proceed.
 //
-// The function implements a retry mechanism with linear backoff, checking if the
-// block assembly service is not too far behind the target height. This prevents the
-// blockchain state from running too far ahead of block assembly, which would cause
-// coinbase maturity checks to fail incorrectly in the UTXO store.
+// When the client supports it, the function waits on the state subscription of the
+// block assembly service, which pushes the chaintip every time it changes. Otherwise,
+// or when the subscription is unavailable, it falls back to a retry mechanism with
+// linear backoff, polling the state of the block assembly service. In both cases it
+// checks if the block assembly service is not too far behind the target height. This
+// prevents the blockchain state from running too far ahead of block assembly, which
+// would cause coinbase maturity checks to fail incorrectly in the UTXO store.
 //
 // Parameters:
 //   - ctx: Context for cancellation
@@ -51,6 +58,12 @@ func WaitForBlockAssemblyReady(
 	// We allow block assembly to run slightly behind as a performance optimization, but must ensure
 	// it stays within the coinbase maturity window to prevent block assembly state resets.
 	// This ensures all coinbase transactions have been properly processed before validation proceeds.
+	if subscriber, ok := blockAssemblyClient.(blockassembly.StateSubscriber); ok {
+		if done, err := waitOnStateSubscription(ctx, logger, subscriber, blockHeight, maxBlocksBehind); done {
+			return err
+		}
+	}
+
 	_, err := retry.Retry(ctx, logger, func() (uint32, error) {
 		blockAssemblyStatus, err := blockAssemblyClient.GetBlockAssemblyState(ctx)
 		if err != nil {
@@ -76,3 +89,54 @@ func WaitForBlockAssemblyReady(
 
 	return nil
 }
+
+// waitOnStateSubscription waits on the state subscription until block assembly is at most maxBlocksBehind blocks
+// behind the block height. It returns done false when the subscription is unavailable or ends before block assembly
+// caught up, in which case the caller falls back to polling.
+func waitOnStateSubscription(
+	ctx context.Context,
+	logger ulogger.Logger,
+	subscriber blockassembly.StateSubscriber,
+	blockHeight uint32,
+	maxBlocksBehind int,
+) (done bool, err error) {
+	subscriptionCtx, cancel := context.WithTimeout(ctx, subscriptionTimeout)
+	defer cancel()
+
+	notifications, err := subscriber.SubscribeState(subscriptionCtx)
+	if err != nil {
+		logger.Debugf("[WaitForBlockAssemblyReady] state subscription unavailable, polling instead: %v", err)
+		return false, nil
+	}
+
+	currentHeight := uint32(0)
+
+	for {
+		notification, ok := <-notifications
+		if !ok {
+			break
+		}
+
+		currentHeight = notification.CurrentHeight
+
+		if currentHeight+uint32(maxBlocksBehind) >= blockHeight {
+			return true, nil
+		}
+
+		logger.Debugf("[WaitForBlockAssemblyReady] block assembly block height %d is behind block height %d, waiting", currentHeight, blockHeight)
+	}
+
+	// the subscription ends when the context is done or when the stream failed
+	if ctx.Err() != nil {
+		return true, ctx.Err()
+	}
+
+	if subscriptionCtx.Err() != nil {
+		// block-assembly is still behind, so we cannot process this block
+		return true, errors.NewProcessingError("block assembly is behind, block height %d, block assembly height %d", blockHeight, currentHeight)
+	}
+
+	logger.Debugf("[WaitForBlockAssemblyReady] state subscription ended, polling instead")
+
+	return false, nil
+}
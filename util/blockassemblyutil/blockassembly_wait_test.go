@@ -183,3 +183,113 @@ func TestWaitForBlockAssemblyReadyContextCancellation(t *testing.T) {
 			errors.Is(err, errors.ErrContextCanceled),
 	)
 }
+
+// subscriberMock is a block assembly client supporting the state subscription, sending the given notifications
+type subscriberMock struct {
+	blockassembly.Mock
+	notifications []uint32
+	subscribeErr  error
+	keepOpen      bool
+}
+
+func (m *subscriberMock) SubscribeState(ctx context.Context) (<-chan *blockassembly_api.StateNotification, error) {
+	if m.subscribeErr != nil {
+		return nil, m.subscribeErr
+	}
+
+	ch := make(chan *blockassembly_api.StateNotification)
+
+	go func() {
+		defer close(ch)
+
+		for _, height := range m.notifications {
+			select {
+			case ch <- &blockassembly_api.StateNotification{CurrentHeight: height}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if m.keepOpen {
+			<-ctx.Done()
+		}
+	}()
+
+	return ch, nil
+}
+
+// TestWaitForBlockAssemblyReadySubscription tests waiting on the state subscription and the fallback to polling
+func TestWaitForBlockAssemblyReadySubscription(t *testing.T) {
+	logger := ulogger.TestLogger{}
+
+	t.Run("block assembly is ready", func(t *testing.T) {
+		client := &subscriberMock{notifications: []uint32{100}, keepOpen: true}
+
+		err := WaitForBlockAssemblyReady(context.Background(), logger, client, 100, 10)
+		assert.NoError(t, err)
+
+		// no polling when the subscription is available
+		client.AssertNotCalled(t, "GetBlockAssemblyState", mock.Anything)
+	})
+
+	t.Run("block assembly catches up", func(t *testing.T) {
+		client := &subscriberMock{notifications: []uint32{85, 88, 90}, keepOpen: true}
+
+		err := WaitForBlockAssemblyReady(context.Background(), logger, client, 100, 10)
+		assert.NoError(t, err)
+
+		client.AssertNotCalled(t, "GetBlockAssemblyState", mock.Anything)
+	})
+
+	t.Run("block assembly is persistently behind", func(t *testing.T) {
+		oldTimeout := subscriptionTimeout
+		subscriptionTimeout = 50 * time.Millisecond
+
+		t.Cleanup(func() {
+			subscriptionTimeout = oldTimeout
+		})
+
+		client := &subscriberMock{notifications: []uint32{88}, keepOpen: true}
+
+		err := WaitForBlockAssemblyReady(context.Background(), logger, client, 100, 10)
+		assert.ErrorContains(t, err, "block assembly is behind")
+
+		client.AssertNotCalled(t, "GetBlockAssemblyState", mock.Anything)
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		client := &subscriberMock{notifications: []uint32{88}, keepOpen: true}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := WaitForBlockAssemblyReady(ctx, logger, client, 100, 10)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("falls back to polling when the subscription is unavailable", func(t *testing.T) {
+		client := &subscriberMock{subscribeErr: errors.NewServiceError("unimplemented")}
+		client.On("GetBlockAssemblyState", mock.Anything).Return(
+			&blockassembly_api.StateMessage{CurrentHeight: 100},
+			nil,
+		).Once()
+
+		err := WaitForBlockAssemblyReady(context.Background(), logger, client, 100, 10)
+		assert.NoError(t, err)
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("falls back to polling when the subscription ends", func(t *testing.T) {
+		client := &subscriberMock{notifications: []uint32{85}}
+		client.On("GetBlockAssemblyState", mock.Anything).Return(
+			&blockassembly_api.StateMessage{CurrentHeight: 95},
+			nil,
+		).Once()
+
+		err := WaitForBlockAssemblyReady(context.Background(), logger, client, 100, 10)
+		assert.NoError(t, err)
+
+		client.AssertExpectations(t)
+	})
+}
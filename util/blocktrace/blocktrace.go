@@ -0,0 +1,233 @@
+// Package blocktrace assigns a lifecycle ID to a block when it is first announced, and carries it through the
+// processing of the block: fetch, subtree validation, validation, store commit and the block assembly update.
+//
+// Every stage a block reaches is logged with the lifecycle ID, added as an event to the tracing span of the stage,
+// and observed in a histogram with the ID as exemplar, so the complete processing timeline of a block is found
+// with a single query on the ID. The ID is propagated between services in the Kafka block messages, the gRPC
+// metadata (see UnaryClientInterceptor) and the metadata of the blockchain notifications.
+//
+// Within a process the ID of a block is kept in a registry, so stages that do not receive the context of the
+// announcement, e.g. blocks processed from a queue, still record the ID of the block. The timeline of the stages
+// recorded by the process is served by TimelineHandler.
+package blocktrace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MetadataKey is the key of the lifecycle ID in gRPC metadata and in the metadata of blockchain notifications
+const MetadataKey = "block_trace_id"
+
+// Stage is a step in the processing of a block
+type Stage string
+
+// stages of the lifecycle of a block, in processing order
+const (
+	StageAnnounced         Stage = "announced"          // the block was announced by a peer
+	StageFetched           Stage = "fetched"            // the block was fetched from the peer
+	StageSubtreesValidated Stage = "subtrees_validated" // the subtrees of the block were validated
+	StageValidated         Stage = "validated"          // the block passed validation
+	StageStored            Stage = "stored"             // the block was committed to the blockchain store
+	StageAssemblyUpdated   Stage = "assembly_updated"   // block assembly moved to the block
+)
+
+const (
+	registryTTL      = time.Hour
+	registryCapacity = 10_000
+	maxEvents        = 64 // stages kept per block, a block that is retried often records stages repeatedly
+)
+
+// Event is a stage reached by a block
+type Event struct {
+	Stage   Stage         `json:"stage"`
+	At      time.Time     `json:"at"`
+	Elapsed time.Duration `json:"elapsed_ns"` // since the start of the lifecycle in this process
+}
+
+// Timeline is the lifecycle of a block as recorded by this process
+type Timeline struct {
+	ID      string    `json:"id"`
+	Hash    string    `json:"hash"`
+	Started time.Time `json:"started"`
+	Events  []Event   `json:"events"`
+}
+
+// lifecycle is the registry entry of a block
+type lifecycle struct {
+	mu      sync.Mutex
+	id      string
+	hash    chainhash.Hash
+	started time.Time
+	events  []Event
+}
+
+type contextKey struct{}
+
+// registry holds the *lifecycle of the blocks seen recently, by block hash
+var registry = ttlcache.New[chainhash.Hash, *lifecycle](
+	ttlcache.WithTTL[chainhash.Hash, *lifecycle](registryTTL),
+	ttlcache.WithCapacity[chainhash.Hash, *lifecycle](registryCapacity),
+	ttlcache.WithDisableTouchOnHit[chainhash.Hash, *lifecycle](),
+)
+
+// NewID returns a new random lifecycle ID
+func NewID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// ContextWithID returns ctx carrying the lifecycle ID, ctx itself when the ID is empty
+func ContextWithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// IDFromContext returns the lifecycle ID carried by ctx, empty when there is none
+func IDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Start returns the lifecycle ID of a block. A block seen for the first time is assigned id, or a new ID when id
+// is empty; a block already seen keeps the ID it was assigned first.
+func Start(hash *chainhash.Hash, id string) string {
+	return get(hash, id).id
+}
+
+// Announce starts the lifecycle of an announced block and returns its lifecycle ID. StageAnnounced is recorded for
+// the first announcement only, the announcements of the block relayed by other peers keep the ID assigned first.
+func Announce(ctx context.Context, logger ulogger.Logger, hash *chainhash.Hash) string {
+	item, loaded := registry.GetOrSet(*hash, &lifecycle{id: NewID(), hash: *hash, started: time.Now()})
+	if !loaded {
+		Record(ctx, logger, hash, StageAnnounced)
+	}
+
+	return item.Value().id
+}
+
+// Lookup returns the lifecycle ID of a block, empty when the block was not seen recently
+func Lookup(hash *chainhash.Hash) string {
+	if hash == nil {
+		return ""
+	}
+
+	item := registry.Get(*hash)
+	if item == nil {
+		return ""
+	}
+
+	return item.Value().id
+}
+
+// ContextFor returns ctx carrying the lifecycle ID of a block, starting the lifecycle with the ID of ctx when the
+// block was not seen yet
+func ContextFor(ctx context.Context, hash *chainhash.Hash) context.Context {
+	return ContextWithID(ctx, Start(hash, IDFromContext(ctx)))
+}
+
+// Record records that a block reached a stage: the stage is logged with the lifecycle ID, added as an event to the
+// span of ctx and observed in the stage histogram with the lifecycle ID as exemplar
+func Record(ctx context.Context, logger ulogger.Logger, hash *chainhash.Hash, stage Stage) {
+	if hash == nil {
+		return
+	}
+
+	initPrometheusMetrics()
+
+	lc := get(hash, IDFromContext(ctx))
+	now := time.Now()
+
+	lc.mu.Lock()
+	elapsed := now.Sub(lc.started)
+
+	if len(lc.events) < maxEvents {
+		lc.events = append(lc.events, Event{Stage: stage, At: now, Elapsed: elapsed})
+	}
+	lc.mu.Unlock()
+
+	observer := prometheusBlockTraceStage.WithLabelValues(string(stage))
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok {
+		exemplarObserver.ObserveWithExemplar(elapsed.Seconds(), prometheus.Labels{MetadataKey: lc.id})
+	} else {
+		observer.Observe(elapsed.Seconds())
+	}
+
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.AddEvent("block."+string(stage), trace.WithAttributes(
+			attribute.String(MetadataKey, lc.id),
+			attribute.String("block_hash", hash.String()),
+		))
+	}
+
+	if logger != nil {
+		logger.Infof("[blocktrace][%s][%s] %s after %s", lc.id, hash.String(), stage, elapsed)
+	}
+}
+
+// Get returns the timeline of a block recorded by this process, and whether the block was seen recently
+func Get(hash *chainhash.Hash) (Timeline, bool) {
+	if hash == nil {
+		return Timeline{}, false
+	}
+
+	item := registry.Get(*hash)
+	if item == nil {
+		return Timeline{}, false
+	}
+
+	return item.Value().timeline(), true
+}
+
+// Find returns the timeline of the block with the lifecycle ID, and whether such a block was seen recently
+func Find(id string) (Timeline, bool) {
+	for _, item := range registry.Items() {
+		if lc := item.Value(); lc.id == id {
+			return lc.timeline(), true
+		}
+	}
+
+	return Timeline{}, false
+}
+
+// get returns the registry entry of a block, creating it with id, or a new ID when id is empty, when there is none
+func get(hash *chainhash.Hash, id string) *lifecycle {
+	if item := registry.Get(*hash); item != nil {
+		return item.Value()
+	}
+
+	if id == "" {
+		id = NewID()
+	}
+
+	item, _ := registry.GetOrSet(*hash, &lifecycle{id: id, hash: *hash, started: time.Now()})
+
+	return item.Value()
+}
+
+func (lc *lifecycle) timeline() Timeline {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	return Timeline{
+		ID:      lc.id,
+		Hash:    lc.hash.String(),
+		Started: lc.started,
+		Events:  append([]Event(nil), lc.events...),
+	}
+}
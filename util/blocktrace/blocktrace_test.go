@@ -0,0 +1,219 @@
+package blocktrace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func randomHash(t *testing.T) *chainhash.Hash {
+	var hash chainhash.Hash
+
+	_, err := rand.Read(hash[:])
+	require.NoError(t, err)
+
+	return &hash
+}
+
+func stages(timeline Timeline) []Stage {
+	result := make([]Stage, 0, len(timeline.Events))
+	for _, event := range timeline.Events {
+		result = append(result, event.Stage)
+	}
+
+	return result
+}
+
+func TestStart(t *testing.T) {
+	t.Run("assigns the given ID to a new block", func(t *testing.T) {
+		hash := randomHash(t)
+
+		assert.Equal(t, "abc", Start(hash, "abc"))
+		assert.Equal(t, "abc", Lookup(hash))
+	})
+
+	t.Run("keeps the ID assigned first", func(t *testing.T) {
+		hash := randomHash(t)
+
+		Start(hash, "first")
+
+		assert.Equal(t, "first", Start(hash, "second"))
+	})
+
+	t.Run("assigns a new ID when none is given", func(t *testing.T) {
+		hash := randomHash(t)
+
+		id := Start(hash, "")
+		assert.Len(t, id, 16)
+		assert.Equal(t, id, Lookup(hash))
+	})
+
+	t.Run("lookup of an unknown block", func(t *testing.T) {
+		assert.Empty(t, Lookup(randomHash(t)))
+		assert.Empty(t, Lookup(nil))
+	})
+}
+
+func TestContextFor(t *testing.T) {
+	hash := randomHash(t)
+
+	ctx := ContextFor(ContextWithID(context.Background(), "from-context"), hash)
+	assert.Equal(t, "from-context", IDFromContext(ctx))
+
+	// the ID of the registry takes precedence once the block was seen
+	ctx = ContextFor(ContextWithID(context.Background(), "other"), hash)
+	assert.Equal(t, "from-context", IDFromContext(ctx))
+
+	assert.Empty(t, IDFromContext(ContextWithID(context.Background(), "")))
+}
+
+func TestAnnounceAndRecord(t *testing.T) {
+	ctx := context.Background()
+	logger := ulogger.TestLogger{}
+	hash := randomHash(t)
+
+	id := Announce(ctx, logger, hash)
+	assert.Equal(t, id, Announce(ctx, logger, hash), "relayed announcements keep the ID")
+
+	Record(ctx, logger, hash, StageFetched)
+	Record(ctx, logger, hash, StageValidated)
+
+	timeline, ok := Get(hash)
+	require.True(t, ok)
+	assert.Equal(t, id, timeline.ID)
+	assert.Equal(t, hash.String(), timeline.Hash)
+	assert.Equal(t, []Stage{StageAnnounced, StageFetched, StageValidated}, stages(timeline))
+
+	for i := 1; i < len(timeline.Events); i++ {
+		assert.GreaterOrEqual(t, timeline.Events[i].Elapsed, timeline.Events[i-1].Elapsed)
+	}
+
+	found, ok := Find(id)
+	require.True(t, ok)
+	assert.Equal(t, hash.String(), found.Hash)
+
+	_, ok = Find("unknown")
+	assert.False(t, ok)
+}
+
+func TestRecord_KeepsBoundedEvents(t *testing.T) {
+	hash := randomHash(t)
+
+	for i := 0; i < maxEvents+10; i++ {
+		Record(context.Background(), nil, hash, StageValidated)
+	}
+
+	timeline, ok := Get(hash)
+	require.True(t, ok)
+	assert.Len(t, timeline.Events, maxEvents)
+}
+
+func TestRecord_UsesIDOfContext(t *testing.T) {
+	hash := randomHash(t)
+
+	Record(ContextWithID(context.Background(), "propagated"), nil, hash, StageStored)
+
+	assert.Equal(t, "propagated", Lookup(hash))
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Run("unary client sends the ID", func(t *testing.T) {
+		var sent metadata.MD
+
+		invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			sent, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		}
+
+		err := UnaryClientInterceptor()(ContextWithID(context.Background(), "id-1"), "/svc/Method", nil, nil, nil, invoker)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id-1"}, sent.Get(MetadataKey))
+	})
+
+	t.Run("unary client without ID", func(t *testing.T) {
+		var sent metadata.MD
+
+		invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			sent, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		}
+
+		err := UnaryClientInterceptor()(context.Background(), "/svc/Method", nil, nil, nil, invoker)
+		require.NoError(t, err)
+		assert.Empty(t, sent.Get(MetadataKey))
+	})
+
+	t.Run("unary server receives the ID", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "id-2"))
+
+		var received string
+
+		handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+			received = IDFromContext(ctx)
+			return nil, nil
+		}
+
+		_, err := UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "id-2", received)
+	})
+}
+
+func TestTimelineHandler(t *testing.T) {
+	hash := randomHash(t)
+	id := Start(hash, "")
+	Record(context.Background(), nil, hash, StageFetched)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		TimelineHandler(rec, httptest.NewRequest(http.MethodGet, "/debug/blocktrace"+query, nil))
+
+		return rec
+	}
+
+	t.Run("by hash", func(t *testing.T) {
+		rec := get("?hash=" + hash.String())
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var timeline Timeline
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &timeline))
+		assert.Equal(t, id, timeline.ID)
+		assert.Equal(t, []Stage{StageFetched}, stages(timeline))
+	})
+
+	t.Run("by id", func(t *testing.T) {
+		rec := get("?id=" + id)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var timeline Timeline
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &timeline))
+		assert.Equal(t, hash.String(), timeline.Hash)
+	})
+
+	t.Run("recent", func(t *testing.T) {
+		rec := get("")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var timelines []Timeline
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &timelines))
+		assert.NotEmpty(t, timelines)
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get("?hash="+randomHash(t).String()).Code)
+	})
+
+	t.Run("invalid hash", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("?hash=nothex").Code)
+	})
+}
@@ -0,0 +1,72 @@
+package blocktrace
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/bsv-blockchain/go-bt/v2/chainhash"
+)
+
+// maxListedTimelines is the number of timelines listed when no block is requested
+const maxListedTimelines = 100
+
+// TimelineHandler serves the timeline of a block recorded by this process as JSON, by block hash (?hash=) or
+// lifecycle ID (?id=). Without a query, the timelines of the most recently started lifecycles are listed.
+func TimelineHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+
+	var (
+		timeline Timeline
+		found    bool
+	)
+
+	switch {
+	case query.Get("hash") != "":
+		hash, err := chainhash.NewHashFromStr(query.Get("hash"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid block hash")
+			return
+		}
+
+		timeline, found = Get(hash)
+	case query.Get("id") != "":
+		timeline, found = Find(query.Get("id"))
+	default:
+		_ = json.NewEncoder(w).Encode(recentTimelines(maxListedTimelines))
+		return
+	}
+
+	if !found {
+		writeError(w, http.StatusNotFound, "block not seen recently")
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(timeline)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// recentTimelines returns the timelines of the most recently started lifecycles, newest first
+func recentTimelines(limit int) []Timeline {
+	items := registry.Items()
+
+	timelines := make([]Timeline, 0, len(items))
+	for _, item := range items {
+		timelines = append(timelines, item.Value().timeline())
+	}
+
+	sort.Slice(timelines, func(i, j int) bool { return timelines[i].Started.After(timelines[j].Started) })
+
+	if len(timelines) > limit {
+		timelines = timelines[:limit]
+	}
+
+	return timelines
+}
@@ -0,0 +1,36 @@
+package blocktrace
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// prometheusBlockTraceStage observes the time from the start of the lifecycle of a block until it reached a
+	// stage, with the lifecycle ID of the block as exemplar.
+	// Labels: stage
+	prometheusBlockTraceStage *prometheus.HistogramVec
+)
+
+var (
+	prometheusMetricsInitOnce sync.Once
+)
+
+func initPrometheusMetrics() {
+	prometheusMetricsInitOnce.Do(_initPrometheusMetrics)
+}
+
+func _initPrometheusMetrics() {
+	prometheusBlockTraceStage = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "blocktrace",
+			Name:      "stage_seconds",
+			Help:      "Time from the start of the lifecycle of a block until it reached a stage",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+		},
+		[]string{"stage"},
+	)
+}
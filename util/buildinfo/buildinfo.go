@@ -0,0 +1,186 @@
+// Package buildinfo describes the code a node runs: the build metadata of the binary, the chain network and the
+// services running in the process, with the protocol versions they speak. It is reported by the version endpoint
+// of the asset service, the gRPC health responses of the services and the startup banner of the daemon.
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bsv-blockchain/teranode/settings"
+)
+
+// ServiceInfo describes a service running in this process
+type ServiceInfo struct {
+	Name             string            `json:"name"`
+	ProtocolVersions map[string]string `json:"protocol_versions,omitempty"`
+}
+
+// Info is the build metadata of the binary and the services running in this process
+type Info struct {
+	Version   string        `json:"version"`
+	Commit    string        `json:"commit"`
+	BuildDate string        `json:"build_date"`
+	GoVersion string        `json:"go_version"`
+	Features  []string      `json:"features"`
+	Network   string        `json:"network"`
+	Services  []ServiceInfo `json:"services"`
+}
+
+var (
+	mu        sync.RWMutex
+	buildDate string
+	services  = make(map[string]map[string]string) // service name -> protocol -> version
+
+	featuresOnce sync.Once
+	features     []string
+)
+
+// SetBuildDate sets the date the binary was built, injected at build time
+func SetBuildDate(date string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	buildDate = date
+}
+
+// RegisterService records that the service runs in this process
+func RegisterService(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := services[name]; !ok {
+		services[name] = nil
+	}
+}
+
+// SetProtocolVersion records the version of a protocol the service speaks, the service is registered when it was
+// not yet
+func SetProtocolVersion(service, protocol, version string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if services[service] == nil {
+		services[service] = make(map[string]string)
+	}
+
+	services[service][protocol] = version
+}
+
+// Get returns the build metadata of the binary and the services running in this process, the version, commit
+// and network are taken from the settings
+func Get(tSettings *settings.Settings) Info {
+	info := Info{
+		GoVersion: runtime.Version(),
+		Features:  buildFeatures(),
+	}
+
+	if tSettings != nil {
+		info.Version = tSettings.Version
+		info.Commit = tSettings.Commit
+
+		if tSettings.ChainCfgParams != nil {
+			info.Network = tSettings.ChainCfgParams.Name
+		}
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	info.BuildDate = buildDate
+	info.Services = make([]ServiceInfo, 0, len(services))
+
+	for name, protocols := range services {
+		service := ServiceInfo{Name: name}
+
+		if len(protocols) > 0 {
+			service.ProtocolVersions = make(map[string]string, len(protocols))
+			for protocol, version := range protocols {
+				service.ProtocolVersions[protocol] = version
+			}
+		}
+
+		info.Services = append(info.Services, service)
+	}
+
+	sort.Slice(info.Services, func(i, j int) bool {
+		return info.Services[i].Name < info.Services[j].Name
+	})
+
+	return info
+}
+
+// JSON returns the build metadata as a JSON document, as added to the gRPC health responses
+func (i Info) JSON() string {
+	b, err := json.Marshal(i)
+	if err != nil {
+		return "{}"
+	}
+
+	return string(b)
+}
+
+// Banner returns the build metadata formatted for the startup log, one field per line
+func (i Info) Banner() string {
+	var sb strings.Builder
+
+	sb.WriteString("BUILD\n-----\n")
+	fmt.Fprintf(&sb, "version:    %s\n", i.Version)
+	fmt.Fprintf(&sb, "commit:     %s\n", i.Commit)
+	fmt.Fprintf(&sb, "build date: %s\n", i.BuildDate)
+	fmt.Fprintf(&sb, "go version: %s\n", i.GoVersion)
+	fmt.Fprintf(&sb, "features:   %s\n", strings.Join(i.Features, ", "))
+	fmt.Fprintf(&sb, "network:    %s\n", i.Network)
+	sb.WriteString("services:\n")
+
+	for _, service := range i.Services {
+		fmt.Fprintf(&sb, "  - %s", service.Name)
+
+		if len(service.ProtocolVersions) > 0 {
+			protocols := make([]string, 0, len(service.ProtocolVersions))
+			for protocol, version := range service.ProtocolVersions {
+				protocols = append(protocols, protocol+"="+version)
+			}
+
+			sort.Strings(protocols)
+			fmt.Fprintf(&sb, " (%s)", strings.Join(protocols, ", "))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// buildFeatures returns the build tags the binary was built with, e.g. aerospike
+func buildFeatures() []string {
+	featuresOnce.Do(func() {
+		features = []string{}
+
+		bi, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+
+		for _, s := range bi.Settings {
+			if s.Key != "-tags" {
+				continue
+			}
+
+			for _, tag := range strings.Split(s.Value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					features = append(features, tag)
+				}
+			}
+		}
+
+		sort.Strings(features)
+	})
+
+	return features
+}
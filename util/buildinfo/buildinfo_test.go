@@ -0,0 +1,59 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bsv-blockchain/go-chaincfg"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	tSettings := &settings.Settings{
+		Version:        "v1.2.3",
+		Commit:         "abc1234",
+		ChainCfgParams: &chaincfg.RegressionNetParams,
+	}
+
+	SetBuildDate("2025-01-02T03:04:05Z")
+	RegisterService("Validator")
+	RegisterService("P2P")
+	SetProtocolVersion("P2P", "bitcoin", "/teranode/bitcoin/regtest/1.0.0")
+	RegisterService("P2P") // registering again keeps the protocol versions
+
+	info := Get(tSettings)
+
+	assert.Equal(t, "v1.2.3", info.Version)
+	assert.Equal(t, "abc1234", info.Commit)
+	assert.Equal(t, "2025-01-02T03:04:05Z", info.BuildDate)
+	assert.Equal(t, "regtest", info.Network)
+	assert.NotEmpty(t, info.GoVersion)
+	assert.NotNil(t, info.Features)
+
+	require.Len(t, info.Services, 2)
+	assert.Equal(t, ServiceInfo{Name: "P2P", ProtocolVersions: map[string]string{"bitcoin": "/teranode/bitcoin/regtest/1.0.0"}}, info.Services[0])
+	assert.Equal(t, ServiceInfo{Name: "Validator"}, info.Services[1])
+
+	t.Run("json", func(t *testing.T) {
+		var decoded Info
+		require.NoError(t, json.Unmarshal([]byte(info.JSON()), &decoded))
+		assert.Equal(t, info, decoded)
+	})
+
+	t.Run("banner", func(t *testing.T) {
+		banner := info.Banner()
+		assert.Contains(t, banner, "version:    v1.2.3\n")
+		assert.Contains(t, banner, "network:    regtest\n")
+		assert.Contains(t, banner, "  - P2P (bitcoin=/teranode/bitcoin/regtest/1.0.0)\n")
+		assert.Contains(t, banner, "  - Validator\n")
+	})
+
+	t.Run("without settings", func(t *testing.T) {
+		info := Get(nil)
+		assert.Empty(t, info.Version)
+		assert.Empty(t, info.Network)
+		assert.Len(t, info.Services, 2)
+	})
+}
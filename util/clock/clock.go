@@ -0,0 +1,51 @@
+// Package clock provides the wall clock of the node, which chaos tests can skew to simulate a node whose clock is
+// ahead of or behind the rest of the network.
+//
+// The clock is read by the code applying wall clock rules, e.g. the acceptance of block timestamps and the expiry of
+// peer bans. Elapsed time, e.g. of timeouts and rates, is measured with time.Since and the context deadlines, which
+// are not affected by the skew, in the same way they are not affected by a step of the system clock. No skew is set
+// outside tests, in which case Now is time.Now after a single atomic load.
+package clock
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// skew is the duration the clock is ahead of the system clock, negative when it is behind
+var skew atomic.Int64
+
+// Now returns the current time of the node, the system time shifted by the skew
+func Now() time.Time {
+	if d := skew.Load(); d != 0 {
+		return time.Now().Add(time.Duration(d))
+	}
+
+	return time.Now()
+}
+
+// Since returns the time of the node elapsed since t
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// Until returns the time of the node until t
+func Until(t time.Time) time.Duration {
+	return t.Sub(Now())
+}
+
+// SetSkew sets the clock ahead of the system clock by d, or behind it when d is negative. Changing the skew steps the
+// clock, times read before the change are d apart from the times read after it.
+func SetSkew(d time.Duration) {
+	skew.Store(int64(d))
+}
+
+// Skew returns the duration the clock is ahead of the system clock, negative when it is behind
+func Skew() time.Duration {
+	return time.Duration(skew.Load())
+}
+
+// Reset removes the skew, the clock follows the system clock again
+func Reset() {
+	skew.Store(0)
+}
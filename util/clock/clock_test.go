@@ -0,0 +1,40 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	t.Cleanup(Reset)
+
+	t.Run("no skew set", func(t *testing.T) {
+		assert.Zero(t, Skew())
+		assert.WithinDuration(t, time.Now(), Now(), time.Second)
+	})
+
+	t.Run("ahead", func(t *testing.T) {
+		SetSkew(2 * time.Hour)
+
+		assert.Equal(t, 2*time.Hour, Skew())
+		assert.WithinDuration(t, time.Now().Add(2*time.Hour), Now(), time.Second)
+		assert.InDelta(t, 2*time.Hour, Since(time.Now()), float64(time.Second))
+	})
+
+	t.Run("behind", func(t *testing.T) {
+		SetSkew(-2 * time.Hour)
+
+		assert.WithinDuration(t, time.Now().Add(-2*time.Hour), Now(), time.Second)
+		assert.InDelta(t, 2*time.Hour, Until(time.Now()), float64(time.Second))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		SetSkew(time.Hour)
+		Reset()
+
+		assert.Zero(t, Skew())
+		assert.WithinDuration(t, time.Now(), Now(), time.Second)
+	})
+}
@@ -0,0 +1,105 @@
+// Package faultinject provides named fault injection points, used by chaos tests to make I/O paths fail the way
+// they fail in production, e.g. with ENOSPC when a disk is full.
+//
+// A fault point is checked on the code path that is to fail, and returns the injected error when a fault is set
+// for the point. No faults are set outside tests, in which case a check is a single atomic load.
+package faultinject
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Point is the name of a fault injection point
+type Point string
+
+// Fault injection points
+const (
+	PeerRegistryCacheWrite Point = "p2p.peer_registry_cache.write" // Writes of the P2P peer registry cache files
+	BlockchainStoreWrite   Point = "blockchain.store.write"        // Writes of blocks to the blockchain store
+)
+
+// fault is an error injected at a point, failing the remaining number of checks, or every check when it is negative
+type fault struct {
+	err       error
+	remaining int
+}
+
+var (
+	mu     sync.Mutex
+	faults = make(map[Point]*fault)
+	active atomic.Int32 // number of points with a fault set
+)
+
+// Set injects err at the point, failing every check of the point until the fault is cleared
+func Set(point Point, err error) {
+	SetTimes(point, err, -1)
+}
+
+// SetTimes injects err at the point, failing the next n checks of the point, or every check when n is negative.
+// A fault that was set at the point before is replaced.
+func SetTimes(point Point, err error, n int) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if n == 0 {
+		remove(point)
+		return
+	}
+
+	if _, exists := faults[point]; !exists {
+		active.Add(1)
+	}
+
+	faults[point] = &fault{err: err, remaining: n}
+}
+
+// Clear removes the fault injected at the point
+func Clear(point Point) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	remove(point)
+}
+
+// Reset removes the faults injected at all points
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	faults = make(map[Point]*fault)
+	active.Store(0)
+}
+
+// Check returns the error injected at the point, or nil when no fault is set for it
+func Check(point Point) error {
+	if active.Load() == 0 {
+		return nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	f, exists := faults[point]
+	if !exists {
+		return nil
+	}
+
+	if f.remaining > 0 {
+		f.remaining--
+
+		if f.remaining == 0 {
+			remove(point)
+		}
+	}
+
+	return f.err
+}
+
+// remove removes the fault injected at the point, the caller holds the lock
+func remove(point Point) {
+	if _, exists := faults[point]; exists {
+		delete(faults, point)
+		active.Add(-1)
+	}
+}
@@ -0,0 +1,52 @@
+package faultinject
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultInjection(t *testing.T) {
+	t.Cleanup(Reset)
+
+	t.Run("no fault set", func(t *testing.T) {
+		assert.NoError(t, Check(PeerRegistryCacheWrite))
+	})
+
+	t.Run("set and clear", func(t *testing.T) {
+		Set(PeerRegistryCacheWrite, syscall.ENOSPC)
+
+		for i := 0; i < 3; i++ {
+			assert.ErrorIs(t, Check(PeerRegistryCacheWrite), syscall.ENOSPC)
+		}
+
+		assert.NoError(t, Check(BlockchainStoreWrite), "other points are not affected")
+
+		Clear(PeerRegistryCacheWrite)
+		assert.NoError(t, Check(PeerRegistryCacheWrite))
+		assert.Equal(t, int32(0), active.Load())
+	})
+
+	t.Run("set times", func(t *testing.T) {
+		SetTimes(BlockchainStoreWrite, syscall.ENOSPC, 2)
+
+		assert.ErrorIs(t, Check(BlockchainStoreWrite), syscall.ENOSPC)
+		assert.ErrorIs(t, Check(BlockchainStoreWrite), syscall.ENOSPC)
+		assert.NoError(t, Check(BlockchainStoreWrite))
+		assert.Equal(t, int32(0), active.Load())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		Set(PeerRegistryCacheWrite, syscall.ENOSPC)
+		Set(BlockchainStoreWrite, syscall.EIO)
+		require.Equal(t, int32(2), active.Load())
+
+		Reset()
+
+		assert.NoError(t, Check(PeerRegistryCacheWrite))
+		assert.NoError(t, Check(BlockchainStoreWrite))
+		assert.Equal(t, int32(0), active.Load())
+	})
+}
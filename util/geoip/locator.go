@@ -0,0 +1,98 @@
+package geoip
+
+import (
+	"net/netip"
+)
+
+// Location is the country and autonomous system of an IP address
+type Location struct {
+	Country        string // ISO 3166-1 alpha-2 country code, e.g. US
+	ASN            uint32 // Autonomous system number
+	ASOrganization string // Organization the autonomous system is registered to
+}
+
+// IsZero returns whether nothing is known about the location
+func (l Location) IsZero() bool {
+	return l == Location{}
+}
+
+// Locator looks up the location of IP addresses in a country and an ASN database, either of which is optional.
+// A database containing both, e.g. a GeoIP2 Enterprise database, can be used for both.
+type Locator struct {
+	readers []*Reader
+}
+
+// NewLocator opens the country and ASN databases at the paths, an empty path is skipped.
+// Returns nil when both paths are empty.
+func NewLocator(countryDBPath, asnDBPath string) (*Locator, error) {
+	l := &Locator{}
+
+	for _, path := range []string{countryDBPath, asnDBPath} {
+		if path == "" {
+			continue
+		}
+
+		r, err := Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		l.readers = append(l.readers, r)
+	}
+
+	if len(l.readers) == 0 {
+		return nil, nil
+	}
+
+	return l, nil
+}
+
+// NewLocatorFromReaders returns a locator looking up IP addresses in the readers, e.g. readers of databases in memory
+func NewLocatorFromReaders(readers ...*Reader) *Locator {
+	return &Locator{readers: readers}
+}
+
+// Locate returns the location of ip, with the fields the databases have no record for left empty
+func (l *Locator) Locate(ip netip.Addr) Location {
+	var location Location
+
+	for _, r := range l.readers {
+		record, err := r.Lookup(ip)
+		if err != nil || record == nil {
+			continue
+		}
+
+		if location.Country == "" {
+			location.Country = countryCode(record)
+		}
+
+		if location.ASN == 0 {
+			if asn, ok := toUint64(record["autonomous_system_number"]); ok && asn <= uint64(^uint32(0)) {
+				location.ASN = uint32(asn)
+			}
+		}
+
+		if location.ASOrganization == "" {
+			location.ASOrganization, _ = record["autonomous_system_organization"].(string)
+		}
+	}
+
+	return location
+}
+
+// countryCode returns the ISO code of the country of a record, falling back to the country the network is
+// registered in, e.g. for anycast networks
+func countryCode(record map[string]interface{}) string {
+	for _, key := range []string{"country", "registered_country"} {
+		country, ok := record[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if code, ok := country["iso_code"].(string); ok && code != "" {
+			return code
+		}
+	}
+
+	return ""
+}
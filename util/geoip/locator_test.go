@@ -0,0 +1,49 @@
+package geoip
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocator_Locate(t *testing.T) {
+	dir := t.TempDir()
+
+	countryDB := filepath.Join(dir, "country.mmdb")
+	require.NoError(t, os.WriteFile(countryDB, buildTestDB(t, 6, 28, "GeoLite2-Country", []testNetwork{
+		{prefix: "203.0.113.0/24", record: map[string]interface{}{"country": map[string]interface{}{"iso_code": "NL"}}},
+		{prefix: "198.51.100.0/24", record: map[string]interface{}{"registered_country": map[string]interface{}{"iso_code": "US"}}},
+	}), 0o600))
+
+	asnDB := filepath.Join(dir, "asn.mmdb")
+	require.NoError(t, os.WriteFile(asnDB, buildTestDB(t, 6, 24, "GeoLite2-ASN", []testNetwork{
+		{prefix: "203.0.113.0/24", record: map[string]interface{}{
+			"autonomous_system_number":       uint32(64500),
+			"autonomous_system_organization": "Example Hosting",
+		}},
+	}), 0o600))
+
+	locator, err := NewLocator(countryDB, asnDB)
+	require.NoError(t, err)
+	require.NotNil(t, locator)
+
+	assert.Equal(t, Location{Country: "NL", ASN: 64500, ASOrganization: "Example Hosting"}, locator.Locate(netip.MustParseAddr("203.0.113.10")))
+	assert.Equal(t, Location{Country: "US"}, locator.Locate(netip.MustParseAddr("198.51.100.10")))
+	assert.True(t, locator.Locate(netip.MustParseAddr("192.0.2.1")).IsZero())
+
+	// either database is optional
+	locator, err = NewLocator("", asnDB)
+	require.NoError(t, err)
+	assert.Equal(t, Location{ASN: 64500, ASOrganization: "Example Hosting"}, locator.Locate(netip.MustParseAddr("203.0.113.10")))
+
+	locator, err = NewLocator("", "")
+	require.NoError(t, err)
+	assert.Nil(t, locator)
+
+	_, err = NewLocator(filepath.Join(dir, "missing.mmdb"), asnDB)
+	require.Error(t, err)
+}
@@ -0,0 +1,411 @@
+// Package geoip looks up the country and autonomous system of IP addresses in local MaxMind DB files, e.g. the
+// GeoLite2 Country and ASN databases.
+//
+// The reader implements the part of the MaxMind DB format needed for lookups: the binary search tree with 24, 28
+// and 32 bit records and the decoding of the data section into maps, arrays, strings, numbers and booleans. The
+// database is read into memory once and is safe for concurrent lookups.
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"os"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+const (
+	// dataSectionSeparatorSize is the size of the zero bytes between the search tree and the data section
+	dataSectionSeparatorSize = 16
+
+	// maxDecodeDepth limits the nesting of maps and arrays, so a corrupt database can not recurse without bound
+	maxDecodeDepth = 32
+)
+
+// metadataStartMarker precedes the metadata map at the end of the database
+var metadataStartMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// data section field types
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBool
+	typeFloat
+)
+
+// Reader looks up IP addresses in a MaxMind DB
+type Reader struct {
+	buf          []byte
+	data         []byte
+	nodeCount    uint64
+	recordSize   uint64
+	ipVersion    uint64
+	ipv4Start    uint64
+	databaseType string
+}
+
+// Open reads the MaxMind DB at path into memory
+func Open(path string) (*Reader, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewConfigurationError("failed to read MaxMind DB %s", path, err)
+	}
+
+	r, err := New(buf)
+	if err != nil {
+		return nil, errors.NewConfigurationError("invalid MaxMind DB %s", path, err)
+	}
+
+	return r, nil
+}
+
+// New returns a reader for the MaxMind DB in buf
+func New(buf []byte) (*Reader, error) {
+	metadataStart := bytes.LastIndex(buf, metadataStartMarker)
+	if metadataStart < 0 {
+		return nil, errors.NewProcessingError("metadata section not found")
+	}
+
+	metadataStart += len(metadataStartMarker)
+
+	value, _, err := decode(buf[metadataStart:], 0, 0)
+	if err != nil {
+		return nil, errors.NewProcessingError("failed to decode metadata", err)
+	}
+
+	metadata, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.NewProcessingError("metadata is not a map")
+	}
+
+	r := &Reader{buf: buf}
+
+	r.nodeCount, _ = toUint64(metadata["node_count"])
+	r.recordSize, _ = toUint64(metadata["record_size"])
+	r.ipVersion, _ = toUint64(metadata["ip_version"])
+	r.databaseType, _ = metadata["database_type"].(string)
+
+	if r.recordSize != 24 && r.recordSize != 28 && r.recordSize != 32 {
+		return nil, errors.NewProcessingError("unsupported record size %d", r.recordSize)
+	}
+
+	if r.ipVersion != 4 && r.ipVersion != 6 {
+		return nil, errors.NewProcessingError("unsupported IP version %d", r.ipVersion)
+	}
+
+	treeSize := r.nodeCount * r.recordSize / 4
+	if treeSize+dataSectionSeparatorSize > uint64(metadataStart-len(metadataStartMarker)) {
+		return nil, errors.NewProcessingError("search tree of %d nodes exceeds the database size", r.nodeCount)
+	}
+
+	r.data = buf[treeSize+dataSectionSeparatorSize : metadataStart-len(metadataStartMarker)]
+
+	// IPv4 addresses are stored in IPv6 databases as ::a.b.c.d, below 96 zero bits from the root
+	if r.ipVersion == 6 {
+		for i := 0; i < 96 && r.ipv4Start < r.nodeCount; i++ {
+			r.ipv4Start = r.readNode(r.ipv4Start, 0)
+		}
+	}
+
+	return r, nil
+}
+
+// DatabaseType returns the type of the database from its metadata, e.g. GeoLite2-Country
+func (r *Reader) DatabaseType() string {
+	return r.databaseType
+}
+
+// Lookup returns the record of the network containing ip, or nil when the database has no record for it
+func (r *Reader) Lookup(ip netip.Addr) (map[string]interface{}, error) {
+	ip = ip.Unmap()
+
+	var (
+		node uint64
+		bits []byte
+	)
+
+	switch {
+	case ip.Is4():
+		ip4 := ip.As4()
+		bits = ip4[:]
+		node = r.ipv4Start
+	case ip.Is6() && r.ipVersion == 6:
+		ip16 := ip.As16()
+		bits = ip16[:]
+	default:
+		return nil, nil
+	}
+
+	for i := 0; i < len(bits)*8 && node < r.nodeCount; i++ {
+		bit := (bits[i/8] >> (7 - uint(i%8))) & 1
+		node = r.readNode(node, bit)
+	}
+
+	if node == r.nodeCount {
+		return nil, nil
+	}
+
+	if node < r.nodeCount {
+		return nil, errors.NewProcessingError("search tree too deep for %s", ip)
+	}
+
+	offset := node - r.nodeCount - dataSectionSeparatorSize
+	if offset >= uint64(len(r.data)) {
+		return nil, errors.NewProcessingError("record of %s points outside the data section", ip)
+	}
+
+	value, _, err := decode(r.data, uint(offset), 0)
+	if err != nil {
+		return nil, errors.NewProcessingError("failed to decode record of %s", ip, err)
+	}
+
+	record, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.NewProcessingError("record of %s is not a map", ip)
+	}
+
+	return record, nil
+}
+
+// readNode returns the left (bit 0) or right (bit 1) record of a node of the search tree
+func (r *Reader) readNode(node uint64, bit byte) uint64 {
+	b := r.buf[node*r.recordSize/4:]
+
+	switch r.recordSize {
+	case 24:
+		if bit == 1 {
+			b = b[3:]
+		}
+
+		return uint64(b[0])<<16 | uint64(b[1])<<8 | uint64(b[2])
+	case 28:
+		if bit == 0 {
+			return uint64(b[3]&0xF0)<<20 | uint64(b[0])<<16 | uint64(b[1])<<8 | uint64(b[2])
+		}
+
+		return uint64(b[3]&0x0F)<<24 | uint64(b[4])<<16 | uint64(b[5])<<8 | uint64(b[6])
+	default:
+		if bit == 1 {
+			b = b[4:]
+		}
+
+		return uint64(binary.BigEndian.Uint32(b))
+	}
+}
+
+// decode decodes the field at offset of the data section, returning the value and the offset after the field
+func decode(data []byte, offset uint, depth int) (interface{}, uint, error) {
+	if depth > maxDecodeDepth {
+		return nil, 0, errors.NewProcessingError("data nested too deep")
+	}
+
+	fieldType, size, offset, err := decodeControl(data, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if fieldType == typePointer {
+		pointer, next, err := decodePointer(data, size, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		value, _, err := decode(data, pointer, depth+1)
+
+		return value, next, err
+	}
+
+	switch fieldType {
+	case typeMap:
+		m := make(map[string]interface{}, min(size, uint(len(data))))
+
+		for i := uint(0); i < size; i++ {
+			var key, value interface{}
+
+			if key, offset, err = decode(data, offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+
+			keyString, ok := key.(string)
+			if !ok {
+				return nil, 0, errors.NewProcessingError("map key at offset %d is not a string", offset)
+			}
+
+			if value, offset, err = decode(data, offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+
+			m[keyString] = value
+		}
+
+		return m, offset, nil
+	case typeArray:
+		a := make([]interface{}, 0, min(size, uint(len(data))))
+
+		for i := uint(0); i < size; i++ {
+			var value interface{}
+
+			if value, offset, err = decode(data, offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+
+			a = append(a, value)
+		}
+
+		return a, offset, nil
+	case typeBool:
+		return size != 0, offset, nil
+	case typeEndMarker, typeContainer:
+		return nil, offset, nil
+	}
+
+	if uint64(offset)+uint64(size) > uint64(len(data)) {
+		return nil, 0, errors.NewProcessingError("field of %d bytes at offset %d exceeds the data section", size, offset)
+	}
+
+	b := data[offset : offset+size]
+	next := offset + size
+
+	switch fieldType {
+	case typeString:
+		return string(b), next, nil
+	case typeBytes:
+		return bytes.Clone(b), next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, errors.NewProcessingError("double of %d bytes", size)
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, errors.NewProcessingError("float of %d bytes", size)
+		}
+
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), next, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, errors.NewProcessingError("unsigned integer of %d bytes", size)
+		}
+
+		var v uint64
+		for _, c := range b {
+			v = v<<8 | uint64(c)
+		}
+
+		return v, next, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, errors.NewProcessingError("signed integer of %d bytes", size)
+		}
+
+		var v uint32
+		for _, c := range b {
+			v = v<<8 | uint32(c)
+		}
+
+		return int64(int32(v)), next, nil //nolint:gosec // the bits are reinterpreted as two's complement
+	case typeUint128:
+		// no lookup needs 128 bit values, they are kept as their big-endian bytes
+		return bytes.Clone(b), next, nil
+	default:
+		return nil, 0, errors.NewProcessingError("unknown field type %d at offset %d", fieldType, offset)
+	}
+}
+
+// decodeControl decodes the control byte of the field at offset, returning the field type, its size and the offset
+// of its payload. For pointers the size are the 5 size bits of the control byte.
+func decodeControl(data []byte, offset uint) (fieldType uint, size uint, next uint, err error) {
+	if offset >= uint(len(data)) {
+		return 0, 0, 0, errors.NewProcessingError("offset %d exceeds the data section", offset)
+	}
+
+	control := data[offset]
+	offset++
+
+	fieldType = uint(control >> 5)
+	if fieldType == typeExtended {
+		if offset >= uint(len(data)) {
+			return 0, 0, 0, errors.NewProcessingError("extended type at offset %d exceeds the data section", offset)
+		}
+
+		fieldType = 7 + uint(data[offset])
+		offset++
+	}
+
+	size = uint(control & 0x1f)
+
+	if fieldType == typePointer || size < 29 {
+		return fieldType, size, offset, nil
+	}
+
+	extraBytes := size - 28
+	if offset+extraBytes > uint(len(data)) {
+		return 0, 0, 0, errors.NewProcessingError("field size at offset %d exceeds the data section", offset)
+	}
+
+	var extra uint
+	for _, c := range data[offset : offset+extraBytes] {
+		extra = extra<<8 | uint(c)
+	}
+
+	switch extraBytes {
+	case 1:
+		size = 29 + extra
+	case 2:
+		size = 285 + extra
+	default:
+		size = 65821 + extra
+	}
+
+	return fieldType, size, offset + extraBytes, nil
+}
+
+// decodePointer decodes a pointer from the size bits of its control byte and the bytes following it, returning the
+// offset it points to and the offset after the pointer
+func decodePointer(data []byte, sizeBits uint, offset uint) (pointer uint, next uint, err error) {
+	pointerSize := ((sizeBits >> 3) & 0x3) + 1
+	if offset+pointerSize > uint(len(data)) {
+		return 0, 0, errors.NewProcessingError("pointer at offset %d exceeds the data section", offset)
+	}
+
+	b := data[offset : offset+pointerSize]
+
+	var prefix uint
+	if pointerSize != 4 {
+		prefix = sizeBits & 0x7
+	}
+
+	for _, c := range b {
+		prefix = prefix<<8 | uint(c)
+	}
+
+	switch pointerSize {
+	case 2:
+		prefix += 2048
+	case 3:
+		prefix += 526336
+	}
+
+	return prefix, offset + pointerSize, nil
+}
+
+// toUint64 returns a decoded unsigned integer as uint64
+func toUint64(value interface{}) (uint64, bool) {
+	v, ok := value.(uint64)
+	return v, ok
+}
@@ -0,0 +1,299 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testNetwork is a network and its record written to a test database
+type testNetwork struct {
+	prefix string
+	record map[string]interface{}
+}
+
+// buildTestDB writes a MaxMind DB with the networks, for the IP version and record size
+func buildTestDB(t *testing.T, ipVersion, recordSize int, databaseType string, networks []testNetwork) []byte {
+	t.Helper()
+
+	const empty, leaf = -1, -2
+
+	type node struct {
+		children [2]int
+		records  [2]int
+	}
+
+	nodes := []node{{children: [2]int{empty, empty}}}
+
+	var data bytes.Buffer
+
+	for _, network := range networks {
+		prefix := netip.MustParsePrefix(network.prefix)
+
+		bits := prefix.Addr().AsSlice()
+		prefixLen := prefix.Bits()
+
+		// IPv4 networks are stored in IPv6 databases below ::/96
+		if prefix.Addr().Is4() && ipVersion == 6 {
+			bits = append(make([]byte, 12), bits...)
+			prefixLen += 96
+		}
+
+		recordOffset := data.Len()
+		data.Write(encodeTestValue(t, network.record))
+
+		current := 0
+
+		for i := 0; i < prefixLen; i++ {
+			bit := (bits[i/8] >> (7 - uint(i%8))) & 1
+
+			if i == prefixLen-1 {
+				nodes[current].children[bit] = leaf
+				nodes[current].records[bit] = recordOffset
+
+				break
+			}
+
+			if nodes[current].children[bit] == empty {
+				nodes = append(nodes, node{children: [2]int{empty, empty}})
+				nodes[current].children[bit] = len(nodes) - 1
+			}
+
+			current = nodes[current].children[bit]
+		}
+	}
+
+	nodeCount := len(nodes)
+
+	var db bytes.Buffer
+
+	for _, n := range nodes {
+		var records [2]uint64
+
+		for bit := 0; bit < 2; bit++ {
+			switch n.children[bit] {
+			case empty:
+				records[bit] = uint64(nodeCount)
+			case leaf:
+				records[bit] = uint64(nodeCount + dataSectionSeparatorSize + n.records[bit])
+			default:
+				records[bit] = uint64(n.children[bit])
+			}
+		}
+
+		switch recordSize {
+		case 24:
+			db.Write([]byte{byte(records[0] >> 16), byte(records[0] >> 8), byte(records[0])})
+			db.Write([]byte{byte(records[1] >> 16), byte(records[1] >> 8), byte(records[1])})
+		case 28:
+			db.Write([]byte{
+				byte(records[0] >> 16), byte(records[0] >> 8), byte(records[0]),
+				byte((records[0]>>24)<<4) | byte(records[1]>>24&0x0F),
+				byte(records[1] >> 16), byte(records[1] >> 8), byte(records[1]),
+			})
+		case 32:
+			db.Write(binary.BigEndian.AppendUint32(nil, uint32(records[0])))
+			db.Write(binary.BigEndian.AppendUint32(nil, uint32(records[1])))
+		}
+	}
+
+	db.Write(make([]byte, dataSectionSeparatorSize))
+	db.Write(data.Bytes())
+	db.Write(metadataStartMarker)
+	db.Write(encodeTestValue(t, map[string]interface{}{
+		"node_count":    uint32(nodeCount),
+		"record_size":   uint16(recordSize),
+		"ip_version":    uint16(ipVersion),
+		"database_type": databaseType,
+	}))
+
+	return db.Bytes()
+}
+
+// encodeTestValue encodes a value of the data section, without pointers
+func encodeTestValue(t *testing.T, value interface{}) []byte {
+	t.Helper()
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		b := encodeTestControl(typeMap, len(v))
+		for _, key := range keys {
+			b = append(b, encodeTestValue(t, key)...)
+			b = append(b, encodeTestValue(t, v[key])...)
+		}
+
+		return b
+	case string:
+		return append(encodeTestControl(typeString, len(v)), v...)
+	case uint16:
+		return append(encodeTestControl(typeUint16, 2), byte(v>>8), byte(v))
+	case uint32:
+		return append(encodeTestControl(typeUint32, 4), binary.BigEndian.AppendUint32(nil, v)...)
+	default:
+		t.Fatalf("unsupported test value %T", value)
+		return nil
+	}
+}
+
+// encodeTestControl encodes the control byte of a field, with sizes up to 284 bytes
+func encodeTestControl(fieldType int, size int) []byte {
+	sizeBits := size
+	if size >= 29 {
+		sizeBits = 29
+	}
+
+	var b []byte
+
+	if fieldType > 7 {
+		b = []byte{byte(sizeBits), byte(fieldType - 7)}
+	} else {
+		b = []byte{byte(fieldType<<5 | sizeBits)}
+	}
+
+	if size >= 29 {
+		b = append(b, byte(size-29))
+	}
+
+	return b
+}
+
+func TestReader_Lookup(t *testing.T) {
+	networks := []testNetwork{
+		{prefix: "203.0.113.0/24", record: map[string]interface{}{
+			"country": map[string]interface{}{"iso_code": "NL", "names": map[string]interface{}{"en": "Netherlands"}},
+		}},
+		{prefix: "198.51.100.128/25", record: map[string]interface{}{
+			"registered_country": map[string]interface{}{"iso_code": "US"},
+		}},
+	}
+
+	for _, recordSize := range []int{24, 28, 32} {
+		for _, ipVersion := range []int{4, 6} {
+			dbNetworks := networks
+			if ipVersion == 6 {
+				dbNetworks = append(dbNetworks, testNetwork{prefix: "2001:db8::/32", record: map[string]interface{}{
+					"country": map[string]interface{}{"iso_code": "DE"},
+				}})
+			}
+
+			r, err := New(buildTestDB(t, ipVersion, recordSize, "Test-Country", dbNetworks))
+			require.NoError(t, err, "record size %d, IPv%d", recordSize, ipVersion)
+
+			assert.Equal(t, "Test-Country", r.DatabaseType())
+
+			record, err := r.Lookup(netip.MustParseAddr("203.0.113.7"))
+			require.NoError(t, err)
+			require.NotNil(t, record, "record size %d, IPv%d", recordSize, ipVersion)
+			assert.Equal(t, "NL", record["country"].(map[string]interface{})["iso_code"])
+
+			// IPv4-mapped IPv6 addresses are looked up as IPv4 addresses
+			record, err = r.Lookup(netip.MustParseAddr("::ffff:198.51.100.200"))
+			require.NoError(t, err)
+			require.NotNil(t, record)
+			assert.Equal(t, "US", record["registered_country"].(map[string]interface{})["iso_code"])
+
+			record, err = r.Lookup(netip.MustParseAddr("198.51.100.1"))
+			require.NoError(t, err)
+			assert.Nil(t, record)
+
+			record, err = r.Lookup(netip.MustParseAddr("2001:db8::1"))
+			require.NoError(t, err)
+
+			if ipVersion == 6 {
+				require.NotNil(t, record)
+				assert.Equal(t, "DE", record["country"].(map[string]interface{})["iso_code"])
+			} else {
+				assert.Nil(t, record)
+			}
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	require.NoError(t, os.WriteFile(path, buildTestDB(t, 6, 24, "Test-ASN", nil), 0o600))
+
+	r, err := Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Test-ASN", r.DatabaseType())
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing.mmdb"))
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("not a database"), 0o600))
+
+	_, err = Open(path)
+	require.Error(t, err)
+}
+
+func TestNew_InvalidMetadata(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"record size": {"node_count": uint32(0), "record_size": uint16(20), "ip_version": uint16(6)},
+		"ip version":  {"node_count": uint32(0), "record_size": uint16(24), "ip_version": uint16(5)},
+		"tree size":   {"node_count": uint32(1000), "record_size": uint16(24), "ip_version": uint16(6)},
+	}
+
+	for name, metadata := range tests {
+		t.Run(name, func(t *testing.T) {
+			db := append(make([]byte, dataSectionSeparatorSize), metadataStartMarker...)
+			db = append(db, encodeTestValue(t, metadata)...)
+
+			_, err := New(db)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	longString := strings.Repeat("x", 300)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		offset   uint
+		expected interface{}
+	}{
+		{name: "bool", data: []byte{0x01, 0x07}, expected: true},
+		{name: "int32", data: []byte{0x04, 0x01, 0xff, 0xff, 0xff, 0xfe}, expected: int64(-2)},
+		{name: "uint64", data: []byte{0x02, 0x02, 0x01, 0x00}, expected: uint64(256)},
+		{name: "double", data: append([]byte{0x68}, binary.BigEndian.AppendUint64(nil, math.Float64bits(52.37))...), expected: 52.37},
+		{name: "array", data: []byte{0x02, 0x04, 0x41, 'a', 0x41, 'b'}, expected: []interface{}{"a", "b"}},
+		{name: "string of two size bytes", data: append([]byte{0x5e, 0x00, 0x0f}, longString...), expected: longString},
+		// a pointer to the string at offset 0 of the data section
+		{name: "pointer", data: []byte{0x42, 'n', 'l', 0x20, 0x00}, offset: 3, expected: "nl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, _, err := decode(tt.data, tt.offset, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		_, _, err := decode([]byte{0x45, 'a'}, 0, 0)
+		require.Error(t, err)
+	})
+
+	t.Run("pointer loop", func(t *testing.T) {
+		_, _, err := decode([]byte{0x20, 0x00}, 0, 0)
+		require.Error(t, err)
+	})
+}
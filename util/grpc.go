@@ -11,6 +11,7 @@ import (
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/loglevel_api"
 	"github.com/bsv-blockchain/teranode/util/servicemanager"
 	"github.com/ordishs/gocore"
 	"google.golang.org/grpc"
@@ -24,10 +25,14 @@ type AuthOptions struct {
 
 	// Map of method names that require authentication
 	ProtectedMethods map[string]bool
+
+	// Accept callers presenting a client certificate verified by the TLS handshake, security level 3, without API key
+	AcceptClientCert bool
 }
 
 // StartGRPCServer starts a gRPC server with the specified configuration and registration function.
-// It handles TLS setup, authentication, metrics, tracing, and graceful shutdown.
+// It handles TLS setup, authentication, rate limits, panic recovery, request logging, metrics, tracing, and graceful
+// shutdown.
 // The server will listen on the provided address and register services via the callback function.
 func StartGRPCServer(ctx context.Context, l ulogger.Logger, tSettings *settings.Settings, serviceName string, grpcListenerAddress string, register func(server *grpc.Server), authOptions *AuthOptions, maxConnectionAge ...time.Duration) error {
 	listener, address, _, err := GetListener(tSettings.Context, serviceName, "", grpcListenerAddress)
@@ -56,16 +61,24 @@ func StartGRPCServer(ctx context.Context, l ulogger.Logger, tSettings *settings.
 	// Create server options
 	var serverOptions []grpc.ServerOption
 
-	// Add authentication interceptor if auth options are provided
-	if authOptions != nil && authOptions.APIKey != "" {
-		authInterceptor := CreateAuthInterceptor(authOptions.APIKey, authOptions.ProtectedMethods)
-		serverOptions = append(serverOptions, grpc.UnaryInterceptor(authInterceptor))
+	authOptions = withLogLevelProtection(authOptions, tSettings.GRPCAdminAPIKey, tSettings.GRPCAuthClientCert)
+
+	// Add the interceptor chain shared by all services: panic recovery, request logging, authentication and rate limits
+	unaryInterceptors, streamInterceptors, err := serverInterceptors(l, serviceName, authOptions, tSettings.GRPCRateLimits)
+	if err != nil {
+		return errors.NewConfigurationError("[%s] invalid gRPC interceptor configuration", serviceName, err)
 	}
 
+	serverOptions = append(serverOptions,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+
 	connectionOptions := &ConnectionOptions{
 		SecurityLevel: securityLevel,
 		CertFile:      certFile,
 		KeyFile:       keyFile,
+		KeepaliveTime: tSettings.GRPCKeepaliveMinTime,
 	}
 
 	if len(maxConnectionAge) > 0 {
@@ -80,6 +93,9 @@ func StartGRPCServer(ctx context.Context, l ulogger.Logger, tSettings *settings.
 	// Register reflection service on gRPC server.
 	reflection.Register(grpcServer)
 
+	// Register the runtime log level control on every gRPC server
+	loglevel_api.RegisterLogLevelAPIServer(grpcServer, &logLevelServer{registry: ulogger.ComponentLevels()})
+
 	if securityLevel == 0 {
 		servicemanager.AddListenerInfo(fmt.Sprintf("%s GRPC listening on %s", serviceName, address))
 	} else {
@@ -12,11 +12,13 @@ import (
 	"github.com/bsv-blockchain/teranode/pkg/k8sresolver"
 	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/blocktrace"
 	"github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	prometheusgolang "github.com/prometheus/client_golang/prometheus"
 	"github.com/sercand/kuberesolver/v6"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
@@ -78,6 +80,10 @@ type ConnectionOptions struct {
 	Credentials      PasswordCredentials // Credentials to pass to downstream middleware (optional)
 	MaxConnectionAge time.Duration       // The maximum amount of time a connection may exist before it will be closed by sending a GoAway
 	APIKey           string              // API key for authentication
+	KeepaliveTime    time.Duration       // Client: ping the server after this much inactivity; server: minimum time between client pings
+	KeepaliveTimeout time.Duration       // Client: close the connection when a ping is not answered within this time
+	BackoffBaseDelay time.Duration       // Client: delay before the first reconnect attempt of a failed connection
+	BackoffMaxDelay  time.Duration       // Client: upper bound of the exponential delay between reconnect attempts
 }
 
 // ---------------------------------------------------------------------
@@ -122,8 +128,12 @@ func GetGRPCClient(_ context.Context, address string, connectionOptions *Connect
 	opts = append(opts, grpc.WithTransportCredentials(tlsCredentials))
 
 	// Preallocate interceptor slices with reasonable capacity
-	unaryClientInterceptors := make([]grpc.UnaryClientInterceptor, 0, 3)
-	streamClientInterceptors := make([]grpc.StreamClientInterceptor, 0, 3)
+	unaryClientInterceptors := make([]grpc.UnaryClientInterceptor, 0, 4)
+	streamClientInterceptors := make([]grpc.StreamClientInterceptor, 0, 4)
+
+	// the lifecycle ID of the block a call is made for is passed on to the service called
+	unaryClientInterceptors = append(unaryClientInterceptors, blocktrace.UnaryClientInterceptor())
+	streamClientInterceptors = append(streamClientInterceptors, blocktrace.StreamClientInterceptor())
 
 	if connectionOptions.APIKey != "" {
 		unaryClientInterceptors = append(unaryClientInterceptors,
@@ -174,6 +184,27 @@ func GetGRPCClient(_ context.Context, address string, connectionOptions *Connect
 		opts = append(opts, grpc.WithPerRPCCredentials(connectionOptions.Credentials))
 	}
 
+	// keepalive pings detect a dead connection before a call runs into it, also while no call is active
+	if connectionOptions.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                connectionOptions.KeepaliveTime,
+			Timeout:             connectionOptions.KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
+
+	// exponential backoff of the reconnect attempts of a failed connection
+	if connectionOptions.BackoffBaseDelay > 0 {
+		backoffConfig := backoff.DefaultConfig
+		backoffConfig.BaseDelay = connectionOptions.BackoffBaseDelay
+
+		if connectionOptions.BackoffMaxDelay > 0 {
+			backoffConfig.MaxDelay = max(connectionOptions.BackoffMaxDelay, connectionOptions.BackoffBaseDelay)
+		}
+
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{Backoff: backoffConfig}))
+	}
+
 	// Retry interceptor...
 	if connectionOptions.MaxRetries > 0 {
 		if connectionOptions.RetryBackoff == 0 {
@@ -226,9 +257,21 @@ func getGRPCServer(connectionOptions *ConnectionOptions, opts []grpc.ServerOptio
 		}))
 	}
 
+	// accept keepalive pings of clients, also on idle connections, instead of closing the connection of a client
+	// pinging more often than the gRPC default of every 5 minutes
+	if connectionOptions.KeepaliveTime > 0 {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             connectionOptions.KeepaliveTime,
+			PermitWithoutStream: true,
+		}))
+	}
+
 	// Interceptors.  The order may be important here.
-	unaryInterceptors := make([]grpc.UnaryServerInterceptor, 0, 2)
-	streamInterceptors := make([]grpc.StreamServerInterceptor, 0, 2)
+	unaryInterceptors := make([]grpc.UnaryServerInterceptor, 0, 3)
+	streamInterceptors := make([]grpc.StreamServerInterceptor, 0, 3)
+
+	unaryInterceptors = append(unaryInterceptors, blocktrace.UnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors, blocktrace.StreamServerInterceptor())
 
 	if tSettings.TracingEnabled {
 		opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
@@ -470,33 +513,5 @@ func InitGRPCResolver(logger ulogger.Logger, grpcResolver string) {
 // It validates API keys in request metadata and only applies authentication to specified methods.
 // Non-protected methods bypass authentication entirely.
 func CreateAuthInterceptor(apiKey string, protectedMethods map[string]bool) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// Skip authentication for non-protected methods
-		if !protectedMethods[info.FullMethod] {
-			return handler(ctx, req)
-		}
-
-		// Extract metadata from context
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "missing metadata")
-		}
-
-		// Get API key from metadata
-		keys := md.Get(apiKeyHeader)
-		if len(keys) == 0 {
-			return nil, status.Error(codes.Unauthenticated, "missing API key")
-		}
-
-		// Validate API key
-		if keys[0] != apiKey {
-			return nil, status.Error(codes.Unauthenticated, "invalid API key")
-		}
-
-		// Add authentication info to context for logging
-		newCtx := context.WithValue(ctx, authenticatedKey, true)
-
-		// Proceed with the handler
-		return handler(newCtx, req)
-	}
+	return createAuthInterceptor(&AuthOptions{APIKey: apiKey, ProtectedMethods: protectedMethods})
 }
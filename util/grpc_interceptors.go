@@ -0,0 +1,334 @@
+package util
+
+import (
+	"context"
+	"math"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// prometheusGRPCPanics counts the panics of gRPC handlers turned into errors.
+	// Labels: method
+	prometheusGRPCPanics *prometheus.CounterVec
+
+	// prometheusGRPCRateLimited counts the gRPC requests rejected by the rate limit of their method.
+	// Labels: method
+	prometheusGRPCRateLimited *prometheus.CounterVec
+
+	prometheusGRPCInterceptorMetricsOnce sync.Once
+)
+
+func initGRPCInterceptorMetrics() {
+	prometheusGRPCInterceptorMetricsOnce.Do(func() {
+		prometheusGRPCPanics = promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "teranode",
+				Subsystem: "grpc",
+				Name:      "panics_total",
+				Help:      "Number of panics of gRPC handlers recovered and returned as errors",
+			},
+			[]string{"method"},
+		)
+
+		prometheusGRPCRateLimited = promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "teranode",
+				Subsystem: "grpc",
+				Name:      "rate_limited_total",
+				Help:      "Number of gRPC requests rejected by the rate limit of their method",
+			},
+			[]string{"method"},
+		)
+	})
+}
+
+// NewAuthOptions returns the auth options protecting the methods, full gRPC method paths, with the API key and, when
+// acceptClientCert is set, a verified client certificate. Without either nil is returned and the methods are not
+// protected.
+func NewAuthOptions(apiKey string, acceptClientCert bool, protectedMethods ...string) *AuthOptions {
+	if apiKey == "" && !acceptClientCert {
+		return nil
+	}
+
+	authOptions := &AuthOptions{
+		APIKey:           apiKey,
+		ProtectedMethods: make(map[string]bool, len(protectedMethods)),
+		AcceptClientCert: acceptClientCert,
+	}
+
+	for _, method := range protectedMethods {
+		authOptions.ProtectedMethods[method] = true
+	}
+
+	return authOptions
+}
+
+// serverInterceptors returns the interceptor chain shared by the gRPC servers of all services, outermost first:
+// panic recovery, request logging, authentication of the protected methods and the per-method rate limits.
+func serverInterceptors(logger ulogger.Logger, serviceName string, authOptions *AuthOptions, rateLimits []string) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor, error) {
+	initGRPCInterceptorMetrics()
+
+	limiter, err := newMethodRateLimiter(rateLimits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		recoveryUnaryInterceptor(logger, serviceName),
+		loggingUnaryInterceptor(logger, serviceName),
+	}
+
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		recoveryStreamInterceptor(logger, serviceName),
+		loggingStreamInterceptor(logger, serviceName),
+	}
+
+	if authOptions != nil && (authOptions.APIKey != "" || authOptions.AcceptClientCert) {
+		unaryInterceptors = append(unaryInterceptors, createAuthInterceptor(authOptions))
+		streamInterceptors = append(streamInterceptors, createAuthStreamInterceptor(authOptions))
+	}
+
+	if limiter != nil {
+		unaryInterceptors = append(unaryInterceptors, limiter.unaryInterceptor())
+		streamInterceptors = append(streamInterceptors, limiter.streamInterceptor())
+	}
+
+	return unaryInterceptors, streamInterceptors, nil
+}
+
+// recoveryUnaryInterceptor turns a panic of a handler into an error, instead of crashing the service
+func recoveryUnaryInterceptor(logger ulogger.Logger, serviceName string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoveredError(logger, serviceName, info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// recoveryStreamInterceptor turns a panic of a stream handler into an error, instead of crashing the service
+func recoveryStreamInterceptor(logger ulogger.Logger, serviceName string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoveredError(logger, serviceName, info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func recoveredError(logger ulogger.Logger, serviceName, method string, r interface{}) error {
+	prometheusGRPCPanics.WithLabelValues(method).Inc()
+	logger.Errorf("[%s] recovered from panic in %s: %v\n%s", serviceName, method, r, debug.Stack())
+
+	return errors.WrapGRPC(errors.NewProcessingError("[%s] panic in %s: %v", serviceName, method, r))
+}
+
+// loggingUnaryInterceptor logs every request with its status code and latency at debug level
+func loggingUnaryInterceptor(logger ulogger.Logger, serviceName string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		logger.Debugf("[%s] %s %s in %s", serviceName, info.FullMethod, status.Code(err), time.Since(start))
+
+		return resp, err
+	}
+}
+
+// loggingStreamInterceptor logs every stream with its status code and duration at debug level
+func loggingStreamInterceptor(logger ulogger.Logger, serviceName string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		logger.Debugf("[%s] stream %s %s in %s", serviceName, info.FullMethod, status.Code(err), time.Since(start))
+
+		return err
+	}
+}
+
+// createAuthInterceptor creates the interceptor authenticating the requests of the protected methods
+func createAuthInterceptor(authOptions *AuthOptions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !authOptions.ProtectedMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		if err := authenticate(ctx, authOptions); err != nil {
+			return nil, err
+		}
+
+		return handler(context.WithValue(ctx, authenticatedKey, true), req)
+	}
+}
+
+// createAuthStreamInterceptor creates the interceptor authenticating the streams of the protected methods
+func createAuthStreamInterceptor(authOptions *AuthOptions) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !authOptions.ProtectedMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		if err := authenticate(ss.Context(), authOptions); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// authenticate accepts a caller presenting the API key in its metadata or, when client certificates are accepted, a
+// client certificate verified by the TLS handshake
+func authenticate(ctx context.Context, authOptions *AuthOptions) error {
+	if authOptions.AcceptClientCert && hasVerifiedClientCert(ctx) {
+		return nil
+	}
+
+	// without an API key only a client certificate is accepted
+	if authOptions.APIKey == "" {
+		return status.Error(codes.Unauthenticated, "missing client certificate")
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	keys := md.Get(apiKeyHeader)
+	if len(keys) == 0 {
+		return status.Error(codes.Unauthenticated, "missing API key")
+	}
+
+	if keys[0] != authOptions.APIKey {
+		return status.Error(codes.Unauthenticated, "invalid API key")
+	}
+
+	return nil
+}
+
+// hasVerifiedClientCert returns whether the caller presented a client certificate verified against the CA of the
+// server, which only happens with security level 3
+func hasVerifiedClientCert(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+
+	return ok && len(tlsInfo.State.VerifiedChains) > 0
+}
+
+// methodRateLimiter limits the requests per second of gRPC methods, configured per full method path or per service
+type methodRateLimiter struct {
+	methods  map[string]*rate.Limiter // Limits of full method paths, e.g. /p2p_api.PeerService/BanPeer
+	services map[string]*rate.Limiter // Limits shared by all methods of a service, e.g. /p2p_api.PeerService
+}
+
+// newMethodRateLimiter parses the rate limits, method=requests per second entries, nil without rate limits
+func newMethodRateLimiter(rateLimits []string) (*methodRateLimiter, error) {
+	if len(rateLimits) == 0 {
+		return nil, nil
+	}
+
+	limiter := &methodRateLimiter{
+		methods:  make(map[string]*rate.Limiter),
+		services: make(map[string]*rate.Limiter),
+	}
+
+	for _, entry := range rateLimits {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		method, limit, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, errors.NewConfigurationError("invalid gRPC rate limit %q, expected method=requests per second", entry)
+		}
+
+		method = strings.TrimSpace(method)
+
+		perSecond, err := strconv.ParseFloat(strings.TrimSpace(limit), 64)
+		if err != nil || perSecond <= 0 || !strings.HasPrefix(method, "/") {
+			return nil, errors.NewConfigurationError("invalid gRPC rate limit %q, expected /package.Service[/Method]=requests per second", entry)
+		}
+
+		// the burst allows a second worth of requests at once
+		l := rate.NewLimiter(rate.Limit(perSecond), int(math.Ceil(perSecond)))
+
+		if strings.Count(method, "/") == 1 {
+			limiter.services[method] = l
+		} else {
+			limiter.methods[method] = l
+		}
+	}
+
+	if len(limiter.methods) == 0 && len(limiter.services) == 0 {
+		return nil, nil
+	}
+
+	return limiter, nil
+}
+
+// allow returns whether a request of the method is within its rate limit. The limit of the method takes precedence
+// over the limit of its service, methods without either are not limited.
+func (m *methodRateLimiter) allow(fullMethod string) bool {
+	if l, ok := m.methods[fullMethod]; ok {
+		return l.Allow()
+	}
+
+	if idx := strings.LastIndex(fullMethod, "/"); idx > 0 {
+		if l, ok := m.services[fullMethod[:idx]]; ok {
+			return l.Allow()
+		}
+	}
+
+	return true
+}
+
+func (m *methodRateLimiter) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !m.allow(info.FullMethod) {
+			prometheusGRPCRateLimited.WithLabelValues(info.FullMethod).Inc()
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit of %s exceeded", info.FullMethod)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (m *methodRateLimiter) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !m.allow(info.FullMethod) {
+			prometheusGRPCRateLimited.WithLabelValues(info.FullMethod).Inc()
+			return status.Errorf(codes.ResourceExhausted, "rate limit of %s exceeded", info.FullMethod)
+		}
+
+		return handler(srv, ss)
+	}
+}
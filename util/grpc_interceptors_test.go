@@ -0,0 +1,185 @@
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// chainUnary runs the request through the interceptors, outermost first, the way grpc.ChainUnaryInterceptor does
+func chainUnary(interceptors []grpc.UnaryServerInterceptor, ctx context.Context, method string, handler grpc.UnaryHandler) (interface{}, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+
+	return handler(ctx, nil)
+}
+
+func TestNewAuthOptions(t *testing.T) {
+	assert.Nil(t, NewAuthOptions("", false, "/test.Service/Method"))
+
+	authOptions := NewAuthOptions("key", false, "/test.Service/A", "/test.Service/B")
+	require.NotNil(t, authOptions)
+	assert.Equal(t, "key", authOptions.APIKey)
+	assert.False(t, authOptions.AcceptClientCert)
+	assert.Equal(t, map[string]bool{"/test.Service/A": true, "/test.Service/B": true}, authOptions.ProtectedMethods)
+
+	// client certificates alone protect the methods
+	authOptions = NewAuthOptions("", true, "/test.Service/A")
+	require.NotNil(t, authOptions)
+	assert.Empty(t, authOptions.APIKey)
+	assert.True(t, authOptions.AcceptClientCert)
+	assert.True(t, authOptions.ProtectedMethods["/test.Service/A"])
+}
+
+func TestServerInterceptors_Recovery(t *testing.T) {
+	unary, _, err := serverInterceptors(ulogger.TestLogger{}, "test", nil, nil)
+	require.NoError(t, err)
+
+	_, err = chainUnary(unary, context.Background(), "/test.Service/Panic", func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	require.Error(t, err)
+
+	unwrapped := errors.UnwrapGRPC(err)
+	assert.True(t, errors.Is(unwrapped, errors.ErrProcessing))
+	assert.Contains(t, unwrapped.Error(), "boom")
+}
+
+func TestServerInterceptors_Auth(t *testing.T) {
+	authOptions := NewAuthOptions("secret", false, "/test.Service/Protected")
+
+	unary, _, err := serverInterceptors(ulogger.TestLogger{}, "test", authOptions, nil)
+	require.NoError(t, err)
+
+	ok := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiKeyHeader, key))
+	}
+
+	resp, err := chainUnary(unary, context.Background(), "/test.Service/Open", ok)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = chainUnary(unary, context.Background(), "/test.Service/Protected", ok)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = chainUnary(unary, withKey("wrong"), "/test.Service/Protected", ok)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = chainUnary(unary, withKey("secret"), "/test.Service/Protected", ok)
+	require.NoError(t, err)
+
+	t.Run("client certificate", func(t *testing.T) {
+		certCtx := peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{&x509.Certificate{}}},
+			}},
+		})
+
+		_, err = chainUnary(unary, certCtx, "/test.Service/Protected", ok)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "client certificates are not accepted by default")
+
+		authOptions.AcceptClientCert = true
+
+		_, err = chainUnary(unary, certCtx, "/test.Service/Protected", ok)
+		require.NoError(t, err)
+
+		// an unverified certificate, security level 2, is not enough
+		unverifiedCtx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})
+
+		_, err = chainUnary(unary, unverifiedCtx, "/test.Service/Protected", ok)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestServerInterceptors_AuthClientCertOnly(t *testing.T) {
+	unary, _, err := serverInterceptors(ulogger.TestLogger{}, "test", NewAuthOptions("", true, "/test.Service/Protected"), nil)
+	require.NoError(t, err)
+
+	ok := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+	certCtx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{&x509.Certificate{}}},
+		}},
+	})
+
+	_, err = chainUnary(unary, certCtx, "/test.Service/Protected", ok)
+	require.NoError(t, err)
+
+	_, err = chainUnary(unary, context.Background(), "/test.Service/Protected", ok)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// without an API key configured, an empty API key is not accepted
+	emptyKeyCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiKeyHeader, ""))
+
+	_, err = chainUnary(unary, emptyKeyCtx, "/test.Service/Protected", ok)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	resp, err := chainUnary(unary, context.Background(), "/test.Service/Open", ok)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+func TestServerInterceptors_RateLimits(t *testing.T) {
+	unary, _, err := serverInterceptors(ulogger.TestLogger{}, "test", nil, []string{
+		"/test.Service=2",
+		"/test.Service/Fast=1000",
+	})
+	require.NoError(t, err)
+
+	ok := func(context.Context, interface{}) (interface{}, error) { return nil, nil }
+
+	// the service limit is shared by its methods, with a burst of a second worth of requests
+	_, err = chainUnary(unary, context.Background(), "/test.Service/A", ok)
+	require.NoError(t, err)
+	_, err = chainUnary(unary, context.Background(), "/test.Service/B", ok)
+	require.NoError(t, err)
+	_, err = chainUnary(unary, context.Background(), "/test.Service/A", ok)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the limit of a method takes precedence over the limit of its service
+	for i := 0; i < 10; i++ {
+		_, err = chainUnary(unary, context.Background(), "/test.Service/Fast", ok)
+		require.NoError(t, err)
+	}
+
+	// methods of other services are not limited
+	_, err = chainUnary(unary, context.Background(), "/other.Service/A", ok)
+	require.NoError(t, err)
+}
+
+func TestNewMethodRateLimiter(t *testing.T) {
+	limiter, err := newMethodRateLimiter(nil)
+	require.NoError(t, err)
+	assert.Nil(t, limiter)
+
+	limiter, err = newMethodRateLimiter([]string{" /test.Service/A = 5 ", "/test.Service=0.5"})
+	require.NoError(t, err)
+	assert.Len(t, limiter.methods, 1)
+	assert.Len(t, limiter.services, 1)
+
+	for _, invalid := range []string{"/test.Service/A", "/test.Service/A=abc", "/test.Service/A=0", "test.Service=5"} {
+		_, err = newMethodRateLimiter([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,99 @@
+package util
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/loglevel_api"
+)
+
+// setLogLevelMethod is the full gRPC method path of the protected SetLogLevel method
+const setLogLevelMethod = "/loglevel_api.LogLevelAPI/SetLogLevel"
+
+// logLevelServer implements the LogLevelAPI, it is registered on the gRPC server of every service so the log
+// levels of the components of a process can be changed without a restart
+type logLevelServer struct {
+	loglevel_api.UnimplementedLogLevelAPIServer
+	registry *ulogger.LevelRegistry
+}
+
+// GetLogLevels returns the default log level and the level of every logger component
+func (s *logLevelServer) GetLogLevels(_ context.Context, _ *loglevel_api.GetLogLevelsRequest) (*loglevel_api.GetLogLevelsResponse, error) {
+	return s.levelsResponse(), nil
+}
+
+// SetLogLevel sets the log level of a component, or the default level when no component is given. An empty
+// level makes the component follow the default level again.
+func (s *logLevelServer) SetLogLevel(_ context.Context, req *loglevel_api.SetLogLevelRequest) (*loglevel_api.GetLogLevelsResponse, error) {
+	var err error
+
+	if req.Level == "" {
+		err = s.registry.ResetLevel(req.Component)
+	} else {
+		err = s.registry.SetLevel(req.Component, req.Level)
+	}
+
+	if err != nil {
+		return nil, errors.WrapGRPC(err)
+	}
+
+	return s.levelsResponse(), nil
+}
+
+func (s *logLevelServer) levelsResponse() *loglevel_api.GetLogLevelsResponse {
+	levels := s.registry.Levels()
+
+	resp := &loglevel_api.GetLogLevelsResponse{
+		DefaultLevel: s.registry.DefaultLevel(),
+		Components:   make([]*loglevel_api.ComponentLevel, 0, len(levels)),
+	}
+
+	for _, level := range levels {
+		resp.Components = append(resp.Components, &loglevel_api.ComponentLevel{
+			Component:  level.Component,
+			Level:      level.Level,
+			Overridden: level.Overridden,
+		})
+	}
+
+	return resp
+}
+
+// withLogLevelProtection adds the SetLogLevel method to the protected methods of the auth options and accepts
+// client certificates when acceptClientCert is set. Auth options without an API key use the admin API key. Without
+// an API key and client certificates the auth options are returned unchanged.
+func withLogLevelProtection(authOptions *AuthOptions, adminAPIKey string, acceptClientCert bool) *AuthOptions {
+	if authOptions == nil {
+		if adminAPIKey == "" && !acceptClientCert {
+			return nil
+		}
+
+		authOptions = &AuthOptions{}
+	}
+
+	apiKey := authOptions.APIKey
+	if apiKey == "" {
+		apiKey = adminAPIKey
+	}
+
+	acceptClientCert = acceptClientCert || authOptions.AcceptClientCert
+
+	if apiKey == "" && !acceptClientCert {
+		return authOptions
+	}
+
+	protectedMethods := make(map[string]bool, len(authOptions.ProtectedMethods)+1)
+
+	for method, protected := range authOptions.ProtectedMethods {
+		protectedMethods[method] = protected
+	}
+
+	protectedMethods[setLogLevelMethod] = true
+
+	return &AuthOptions{
+		APIKey:           apiKey,
+		ProtectedMethods: protectedMethods,
+		AcceptClientCert: acceptClientCert,
+	}
+}
@@ -0,0 +1,69 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/loglevel_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevelServer(t *testing.T) {
+	registry := ulogger.NewLevelRegistry("INFO")
+	registry.Logger("bval", ulogger.TestLogger{})
+	registry.Logger("p2p", ulogger.TestLogger{})
+
+	s := &logLevelServer{registry: registry}
+	ctx := context.Background()
+
+	resp, err := s.SetLogLevel(ctx, &loglevel_api.SetLogLevelRequest{Component: "bval", Level: "DEBUG"})
+	require.NoError(t, err)
+	assert.Equal(t, "INFO", resp.DefaultLevel)
+	require.Len(t, resp.Components, 2)
+	assert.Equal(t, "bval", resp.Components[0].Component)
+	assert.Equal(t, "DEBUG", resp.Components[0].Level)
+	assert.True(t, resp.Components[0].Overridden)
+	assert.Equal(t, "INFO", resp.Components[1].Level)
+
+	// an empty level resets the component to the default level
+	resp, err = s.SetLogLevel(ctx, &loglevel_api.SetLogLevelRequest{Component: "bval"})
+	require.NoError(t, err)
+	assert.Equal(t, "INFO", resp.Components[0].Level)
+	assert.False(t, resp.Components[0].Overridden)
+
+	_, err = s.SetLogLevel(ctx, &loglevel_api.SetLogLevelRequest{Component: "bval", Level: "LOUD"})
+	require.Error(t, err)
+
+	resp, err = s.GetLogLevels(ctx, &loglevel_api.GetLogLevelsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Components, 2)
+}
+
+func TestWithLogLevelProtection(t *testing.T) {
+	assert.Nil(t, withLogLevelProtection(nil, "", false), "unprotected without an admin API key")
+
+	authOptions := withLogLevelProtection(nil, "admin-key", false)
+	require.NotNil(t, authOptions)
+	assert.Equal(t, "admin-key", authOptions.APIKey)
+	assert.True(t, authOptions.ProtectedMethods[setLogLevelMethod])
+
+	serviceOptions := &AuthOptions{
+		APIKey:           "service-key",
+		ProtectedMethods: map[string]bool{"/p2p_api.PeerService/BanPeer": true},
+	}
+
+	authOptions = withLogLevelProtection(serviceOptions, "admin-key", false)
+	assert.Equal(t, "service-key", authOptions.APIKey)
+	assert.True(t, authOptions.ProtectedMethods["/p2p_api.PeerService/BanPeer"])
+	assert.True(t, authOptions.ProtectedMethods[setLogLevelMethod])
+	assert.False(t, serviceOptions.ProtectedMethods[setLogLevelMethod], "the options of the service are not modified")
+
+	// grpc_auth_client_cert protects the method without any API key
+	authOptions = withLogLevelProtection(nil, "", true)
+	require.NotNil(t, authOptions)
+	assert.Empty(t, authOptions.APIKey)
+	assert.True(t, authOptions.AcceptClientCert)
+	assert.True(t, authOptions.ProtectedMethods[setLogLevelMethod])
+}
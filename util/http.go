@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/ordishs/gocore"
 )
 
@@ -21,8 +22,16 @@ var (
 	// for operations that stream large responses. This is longer than httpRequestTimeout
 	// to accommodate large block/subtree downloads during catchup.
 	httpStreamingTimeout, _ = gocore.Config().GetInt("http_streaming_timeout", 300000) // 5 minutes default
+
+	// httpRequestPeerID is the P2P peer ID of this node, sent with every request in the PeerIDHeader
+	// so the serving node can account the data it serves to us.
+	httpRequestPeerID, _ = gocore.Config().Get("p2p_peer_id", "")
 )
 
+// PeerIDHeader is the request header a node identifies itself with when fetching data from another node.
+// The header is not authenticated, it is only used to account the data served to peers.
+const PeerIDHeader = "X-Teranode-Peer-Id"
+
 // DoHTTPRequest performs an HTTP GET or POST request and returns the response body as bytes.
 // Uses GET by default, switches to POST if requestBody is provided.
 // Automatically handles timeouts and validates response status codes.
@@ -128,6 +137,13 @@ func executeHTTPRequest(ctx context.Context, cancelFn context.CancelFunc, url st
 		return nil, cancelFn, errors.NewServiceError("failed to create http request", err)
 	}
 
+	if httpRequestPeerID != "" {
+		req.Header.Set(PeerIDHeader, httpRequestPeerID)
+	}
+
+	// propagate the trace context, so the spans of the serving node are part of the trace of this request
+	tracing.InjectHTTPHeaders(ctx, req.Header)
+
 	// If there is a request body assume we want a POST and write request body
 	if len(requestBody) > 0 && requestBody[0] != nil {
 		req.Body = io.NopCloser(bytes.NewReader(requestBody[0]))
@@ -143,8 +159,12 @@ func executeHTTPRequest(ctx context.Context, cancelFn context.CancelFunc, url st
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		errFn := errors.NewServiceError
-		if resp.StatusCode == http.StatusNotFound {
+		switch resp.StatusCode {
+		case http.StatusNotFound:
 			errFn = errors.NewNotFoundError
+		case http.StatusTooManyRequests:
+			// the serving node throttled us, e.g. for exceeding its catchup serving limit
+			errFn = errors.NewNetworkPeerThrottledError
 		}
 
 		if resp.Body != nil {
@@ -10,8 +10,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/tracing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestDoHTTPRequestGET(t *testing.T) {
@@ -32,6 +38,38 @@ func TestDoHTTPRequestGET(t *testing.T) {
 	assert.Equal(t, `{"message": "success"}`, string(response))
 }
 
+func TestDoHTTPRequestPropagatesTraceContext(t *testing.T) {
+	originalEnabled := tracing.IsTracingEnabled()
+	originalProvider := otel.GetTracerProvider()
+	originalPropagator := otel.GetTextMapPropagator()
+
+	defer func() {
+		tracing.SetTracingEnabled(originalEnabled)
+		otel.SetTracerProvider(originalProvider)
+		otel.SetTextMapPropagator(originalPropagator)
+	}()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	tracing.SetTracingEnabled(true)
+
+	var traceID trace.TraceID
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceID = trace.SpanContextFromContext(tracing.ExtractHTTPHeaders(r.Context(), r.Header)).TraceID()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, span, endSpan := tracing.Tracer("test").Start(context.Background(), "catchup")
+	defer endSpan()
+
+	_, err := DoHTTPRequest(ctx, server.URL)
+	require.NoError(t, err)
+
+	assert.Equal(t, span.SpanContext().TraceID(), traceID)
+}
+
 func TestDoHTTPRequestPOST(t *testing.T) {
 	requestBody := []byte(`{"data": "test"}`)
 
@@ -136,6 +174,22 @@ func TestDoHTTPRequestNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestDoHTTPRequestThrottled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, err := w.Write([]byte("catchup serving limit reached"))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	_, err := DoHTTPRequest(ctx, server.URL)
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.ErrNetworkPeerThrottled))
+	assert.Contains(t, err.Error(), "catchup serving limit reached")
+}
+
 func TestDoHTTPRequestServerError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// schedulers holds the started schedulers of the process, by name
+var schedulers sync.Map
+
+// register lists the scheduler in the statuses of the process, replacing an earlier scheduler of the same name
+func register(s *Scheduler) {
+	schedulers.Store(s.name, s)
+}
+
+// unregister removes the scheduler from the statuses of the process, unless it was already replaced
+func unregister(s *Scheduler) {
+	schedulers.CompareAndDelete(s.name, s)
+}
+
+// Statuses returns the state of the jobs of all started schedulers of the process, by scheduler and name
+func Statuses() []Status {
+	var all []*Scheduler
+
+	schedulers.Range(func(_, value any) bool {
+		all = append(all, value.(*Scheduler))
+		return true
+	})
+
+	sort.Slice(all, func(i, k int) bool {
+		return all[i].name < all[k].name
+	})
+
+	statuses := make([]Status, 0)
+
+	for _, s := range all {
+		statuses = append(statuses, s.Statuses()...)
+	}
+
+	return statuses
+}
+
+// StatusHandler serves the state of the jobs of all schedulers of the process as JSON, or of a single scheduler
+// with ?scheduler=
+func StatusHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	name := r.URL.Query().Get("scheduler")
+	if name == "" {
+		_ = json.NewEncoder(w).Encode(Statuses())
+		return
+	}
+
+	s, ok := schedulers.Load(name)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "scheduler not running"})
+
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(s.(*Scheduler).Statuses())
+}
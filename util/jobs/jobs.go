@@ -0,0 +1,400 @@
+// Package jobs schedules the recurring maintenance tasks of the services, e.g. saving caches, pruning stores,
+// expiring bans and rolling up statistics.
+//
+// Every service runs its jobs on a Scheduler. The scheduler runs each job on its own interval, with jitter so the
+// jobs of the services of a node, and of the nodes of a network, do not all run at the same moment. The last and
+// next run, the duration and the error of every job are persisted in a StateStore, backed by a SQL store, so a
+// restart does not run all jobs at once and the history of a job survives the restart. The jobs of all schedulers
+// of the process are listed by StatusHandler.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+)
+
+const (
+	// stateKeyPrefix prefixes the state key the jobs of a scheduler are persisted under
+	stateKeyPrefix = "jobs:"
+
+	// maxStateKeyLength is the length of the keys of the state table of the blockchain store
+	maxStateKeyLength = 32
+
+	// persistTimeout bounds the persisting of the job state when the scheduler stops
+	persistTimeout = 5 * time.Second
+)
+
+// results of a job run, used as the result label of the job metrics
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// StateStore persists the state of the jobs of a scheduler. It is implemented by the blockchain client and store,
+// which keep the state in the state table of the blockchain SQL store, and by SQLStateStore.
+type StateStore interface {
+	GetState(ctx context.Context, key string) ([]byte, error)
+	SetState(ctx context.Context, key string, data []byte) error
+}
+
+// Job is a recurring maintenance task
+type Job struct {
+	// Name identifies the job within its scheduler
+	Name string
+
+	// Interval is the time between the runs of the job
+	Interval time.Duration
+
+	// RetryInterval is the time before the next run after a failed run, the Interval when 0
+	RetryInterval time.Duration
+
+	// RunOnStop runs the job a final time when the scheduler stops, e.g. to save a cache before shutdown
+	RunOnStop bool
+
+	// Run runs the job, an error marks the run as failed
+	Run func(ctx context.Context) error
+}
+
+// record is the persisted state of a job
+type record struct {
+	LastRun      time.Time     `json:"last_run,omitzero"`
+	LastSuccess  time.Time     `json:"last_success,omitzero"`
+	LastDuration time.Duration `json:"last_duration"`
+	LastError    string        `json:"last_error,omitempty"`
+	NextRun      time.Time     `json:"next_run,omitzero"`
+	Runs         uint64        `json:"runs"`
+	Failures     uint64        `json:"failures"`
+}
+
+// Status is the state of a job, as listed by StatusHandler
+type Status struct {
+	Scheduler    string    `json:"scheduler"`
+	Name         string    `json:"name"`
+	Interval     string    `json:"interval"`
+	Running      bool      `json:"running"`
+	LastRun      time.Time `json:"last_run,omitzero"`
+	LastSuccess  time.Time `json:"last_success,omitzero"`
+	LastDuration string    `json:"last_duration,omitempty"`
+	LastError    string    `json:"last_error,omitempty"`
+	NextRun      time.Time `json:"next_run,omitzero"`
+	Runs         uint64    `json:"runs"`
+	Failures     uint64    `json:"failures"`
+}
+
+// job is a registered job with its state
+type job struct {
+	Job
+	record
+	running bool
+}
+
+// Scheduler runs the maintenance jobs of a service. All operations are thread-safe.
+type Scheduler struct {
+	name   string
+	logger ulogger.Logger
+	store  StateStore
+	jitter float64
+
+	mu      sync.Mutex
+	jobs    map[string]*job
+	started bool
+
+	// persistMu serializes the persisting of the job state, so an older state never overwrites a newer one
+	persistMu sync.Mutex
+}
+
+// NewScheduler creates a scheduler persisting the state of its jobs in the store, or in memory only when the store
+// is nil. Jitter is the fraction of the interval of a job its runs are moved by at random, between 0 and 1.
+func NewScheduler(name string, logger ulogger.Logger, store StateStore, jitter float64) (*Scheduler, error) {
+	if name == "" || len(stateKeyPrefix+name) > maxStateKeyLength {
+		return nil, errors.NewInvalidArgumentError("scheduler name %q must be between 1 and %d characters", name, maxStateKeyLength-len(stateKeyPrefix))
+	}
+
+	if jitter < 0 || jitter > 1 {
+		return nil, errors.NewConfigurationError("job jitter %v must be between 0 and 1", jitter)
+	}
+
+	initPrometheusMetrics()
+
+	return &Scheduler{
+		name:   name,
+		logger: logger,
+		store:  store,
+		jitter: jitter,
+		jobs:   make(map[string]*job),
+	}, nil
+}
+
+// Register adds a job to the scheduler, jobs can only be registered before the scheduler is started
+func (s *Scheduler) Register(j Job) error {
+	if j.Name == "" || j.Interval <= 0 || j.Run == nil {
+		return errors.NewInvalidArgumentError("job %q of scheduler %s needs a name, a positive interval and a run function", j.Name, s.name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return errors.NewProcessingError("scheduler %s is already started, job %s can not be registered", s.name, j.Name)
+	}
+
+	if _, exists := s.jobs[j.Name]; exists {
+		return errors.NewInvalidArgumentError("job %s is already registered with scheduler %s", j.Name, s.name)
+	}
+
+	s.jobs[j.Name] = &job{Job: j}
+
+	return nil
+}
+
+// Start restores the persisted state of the jobs and runs them until the context is cancelled. A job that was due
+// while the service was down runs shortly after the start, the others keep their persisted next run.
+func (s *Scheduler) Start(ctx context.Context) {
+	records := s.load(ctx)
+	now := time.Now()
+
+	s.mu.Lock()
+
+	s.started = true
+
+	for name, j := range s.jobs {
+		if rec, ok := records[name]; ok {
+			j.record = rec
+		}
+
+		switch {
+		case j.NextRun.IsZero() || j.NextRun.After(now.Add(j.Interval)):
+			// never ran, or the interval was shortened since the next run was scheduled
+			j.NextRun = now.Add(s.jittered(j.Interval))
+		case j.NextRun.Before(now):
+			// overdue, spread the catch up runs of the jobs over the jitter of their interval
+			j.NextRun = now.Add(time.Duration(rand.Float64() * s.jitter * float64(j.Interval))) //nolint:gosec // jitter does not need a secure random source
+		}
+	}
+
+	jobs := make([]*job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j)
+	}
+
+	s.mu.Unlock()
+
+	register(s)
+
+	var wg sync.WaitGroup
+
+	for _, j := range jobs {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			s.runLoop(ctx, j)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		unregister(s)
+	}()
+
+	s.logger.Infof("[jobs] started %d jobs of scheduler %s", len(jobs), s.name)
+}
+
+// runLoop runs the job at its next run until the context is cancelled
+func (s *Scheduler) runLoop(ctx context.Context, j *job) {
+	s.mu.Lock()
+	timer := time.NewTimer(time.Until(j.NextRun))
+	s.mu.Unlock()
+
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if j.RunOnStop {
+				stopCtx, cancel := context.WithTimeout(context.Background(), persistTimeout)
+				s.run(stopCtx, j)
+				cancel()
+			}
+
+			return
+		case <-timer.C:
+			timer.Reset(time.Until(s.run(ctx, j)))
+		}
+	}
+}
+
+// run runs the job once, records and persists the result, and returns the next run of the job
+func (s *Scheduler) run(ctx context.Context, j *job) time.Time {
+	s.mu.Lock()
+	j.running = true
+	s.mu.Unlock()
+
+	start := time.Now()
+	err := j.Run(ctx)
+	duration := time.Since(start)
+
+	result := resultSuccess
+	if err != nil {
+		result = resultFailure
+	}
+
+	prometheusJobRuns.WithLabelValues(s.name, j.Name, result).Inc()
+	prometheusJobDuration.WithLabelValues(s.name, j.Name).Observe(duration.Seconds())
+
+	s.mu.Lock()
+
+	failing := j.LastError != ""
+
+	j.running = false
+	j.LastRun = start
+	j.LastDuration = duration
+	j.Runs++
+
+	interval := j.Interval
+
+	if err != nil {
+		j.LastError = err.Error()
+		j.Failures++
+
+		if j.RetryInterval > 0 {
+			interval = j.RetryInterval
+		}
+	} else {
+		j.LastError = ""
+		j.LastSuccess = start.Add(duration)
+	}
+
+	j.NextRun = time.Now().Add(s.jittered(interval))
+	next := j.NextRun
+
+	s.mu.Unlock()
+
+	switch {
+	case err != nil && ctx.Err() == nil:
+		s.logger.Warnf("[jobs] job %s of scheduler %s failed, next run in %v: %v", j.Name, s.name, time.Until(next).Round(time.Second), err)
+	case err == nil && failing:
+		s.logger.Infof("[jobs] job %s of scheduler %s recovered", j.Name, s.name)
+	}
+
+	s.persist(ctx)
+
+	return next
+}
+
+// jittered returns the interval moved by a random fraction of at most the jitter of the scheduler
+func (s *Scheduler) jittered(interval time.Duration) time.Duration {
+	if s.jitter == 0 {
+		return interval
+	}
+
+	return interval + time.Duration((rand.Float64()*2-1)*s.jitter*float64(interval)) //nolint:gosec // jitter does not need a secure random source
+}
+
+// stateKey returns the key the state of the jobs is persisted under
+func (s *Scheduler) stateKey() string {
+	return stateKeyPrefix + s.name
+}
+
+// load returns the persisted state of the jobs by name, none when nothing was persisted
+func (s *Scheduler) load(ctx context.Context) map[string]record {
+	records := make(map[string]record)
+
+	if s.store == nil {
+		return records
+	}
+
+	data, err := s.store.GetState(ctx, s.stateKey())
+	if err != nil || len(data) == 0 {
+		// the store returns an error for a key that was never set
+		s.logger.Debugf("[jobs] no persisted state of scheduler %s: %v", s.name, err)
+		return records
+	}
+
+	if err = json.Unmarshal(data, &records); err != nil {
+		s.logger.Warnf("[jobs] ignoring invalid persisted state of scheduler %s: %v", s.name, err)
+		return make(map[string]record)
+	}
+
+	return records
+}
+
+// persist saves the state of the jobs to the store, a failure is logged and retried with the next run of a job
+func (s *Scheduler) persist(ctx context.Context) {
+	if s.store == nil {
+		return
+	}
+
+	s.persistMu.Lock()
+	defer s.persistMu.Unlock()
+
+	s.mu.Lock()
+
+	records := make(map[string]record, len(s.jobs))
+	for name, j := range s.jobs {
+		records[name] = j.record
+	}
+
+	s.mu.Unlock()
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		s.logger.Errorf("[jobs] failed to encode the state of scheduler %s: %v", s.name, err)
+		return
+	}
+
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(context.Background(), persistTimeout)
+		defer cancel()
+	}
+
+	if err = s.store.SetState(ctx, s.stateKey(), data); err != nil {
+		s.logger.Warnf("[jobs] failed to persist the state of scheduler %s: %v", s.name, err)
+	}
+}
+
+// Statuses returns the state of the jobs of the scheduler, by name
+func (s *Scheduler) Statuses() []Status {
+	s.mu.Lock()
+
+	statuses := make([]Status, 0, len(s.jobs))
+
+	for _, j := range s.jobs {
+		status := Status{
+			Scheduler:   s.name,
+			Name:        j.Name,
+			Interval:    j.Interval.String(),
+			Running:     j.running,
+			LastRun:     j.LastRun,
+			LastSuccess: j.LastSuccess,
+			LastError:   j.LastError,
+			NextRun:     j.NextRun,
+			Runs:        j.Runs,
+			Failures:    j.Failures,
+		}
+
+		if !j.LastRun.IsZero() {
+			status.LastDuration = j.LastDuration.String()
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	s.mu.Unlock()
+
+	sort.Slice(statuses, func(i, k int) bool {
+		return statuses[i].Name < statuses[k].Name
+	})
+
+	return statuses
+}
@@ -0,0 +1,358 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+// memoryStateStore is a state store in memory, returning an error for keys that were never set like the
+// blockchain store
+type memoryStateStore struct {
+	mu    sync.Mutex
+	state map[string][]byte
+}
+
+func newMemoryStateStore() *memoryStateStore {
+	return &memoryStateStore{state: make(map[string][]byte)}
+}
+
+func (m *memoryStateStore) GetState(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.state[key]
+	if !ok {
+		return nil, errors.NewNotFoundError("state %s not found", key)
+	}
+
+	return data, nil
+}
+
+func (m *memoryStateStore) SetState(_ context.Context, key string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state[key] = data
+
+	return nil
+}
+
+// records returns the persisted state of the jobs of the scheduler
+func (m *memoryStateStore) records(t *testing.T, scheduler string) map[string]record {
+	t.Helper()
+
+	data, err := m.GetState(context.Background(), stateKeyPrefix+scheduler)
+	require.NoError(t, err)
+
+	records := make(map[string]record)
+	require.NoError(t, json.Unmarshal(data, &records))
+
+	return records
+}
+
+// persistRecord persists the state of a job of the scheduler, as a previous run of the service would have
+func (m *memoryStateStore) persistRecord(t *testing.T, scheduler, name string, rec record) {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]record{name: rec})
+	require.NoError(t, err)
+
+	require.NoError(t, m.SetState(context.Background(), stateKeyPrefix+scheduler, data))
+}
+
+func newTestScheduler(t *testing.T, name string, store StateStore) *Scheduler {
+	t.Helper()
+
+	s, err := NewScheduler(name, ulogger.TestLogger{}, store, 0)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestNewScheduler(t *testing.T) {
+	_, err := NewScheduler("", ulogger.TestLogger{}, nil, 0)
+	assert.Error(t, err)
+
+	_, err = NewScheduler("a_scheduler_name_that_is_far_too_long", ulogger.TestLogger{}, nil, 0)
+	assert.Error(t, err)
+
+	_, err = NewScheduler("p2p", ulogger.TestLogger{}, nil, 1.5)
+	assert.True(t, errors.Is(err, errors.ErrConfiguration))
+}
+
+func TestScheduler_Register(t *testing.T) {
+	s := newTestScheduler(t, "register", nil)
+	run := func(context.Context) error { return nil }
+
+	require.NoError(t, s.Register(Job{Name: "a", Interval: time.Hour, Run: run}))
+
+	assert.Error(t, s.Register(Job{Name: "a", Interval: time.Hour, Run: run}), "duplicate name")
+	assert.Error(t, s.Register(Job{Name: "b", Run: run}), "no interval")
+	assert.Error(t, s.Register(Job{Name: "c", Interval: time.Hour}), "no run function")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Start(ctx)
+
+	assert.Error(t, s.Register(Job{Name: "d", Interval: time.Hour, Run: run}), "registered after the start")
+}
+
+func TestScheduler_RunsAndPersists(t *testing.T) {
+	store := newMemoryStateStore()
+	s := newTestScheduler(t, "runs", store)
+
+	var runs atomic.Int32
+
+	require.NoError(t, s.Register(Job{Name: "job", Interval: 10 * time.Millisecond, Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Start(ctx)
+
+	require.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	statuses := s.Statuses()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "runs", statuses[0].Scheduler)
+	assert.Equal(t, "job", statuses[0].Name)
+	assert.False(t, statuses[0].LastRun.IsZero())
+	assert.False(t, statuses[0].LastSuccess.IsZero())
+	assert.True(t, statuses[0].NextRun.After(statuses[0].LastRun))
+	assert.Empty(t, statuses[0].LastError)
+
+	rec := store.records(t, "runs")["job"]
+	assert.GreaterOrEqual(t, rec.Runs, uint64(2))
+	assert.Zero(t, rec.Failures)
+}
+
+func TestScheduler_RestoresPersistedState(t *testing.T) {
+	t.Run("next run in the future", func(t *testing.T) {
+		store := newMemoryStateStore()
+		lastRun := time.Now().Add(-time.Hour).UTC()
+		store.persistRecord(t, "restore", "job", record{LastRun: lastRun, NextRun: time.Now().Add(time.Hour), Runs: 7})
+
+		s := newTestScheduler(t, "restore", store)
+
+		var runs atomic.Int32
+
+		require.NoError(t, s.Register(Job{Name: "job", Interval: 2 * time.Hour, Run: func(context.Context) error {
+			runs.Add(1)
+			return nil
+		}}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s.Start(ctx)
+
+		time.Sleep(20 * time.Millisecond)
+		assert.Zero(t, runs.Load(), "the job is not due")
+
+		statuses := s.Statuses()
+		assert.Equal(t, uint64(7), statuses[0].Runs)
+		assert.True(t, lastRun.Equal(statuses[0].LastRun))
+	})
+
+	t.Run("overdue", func(t *testing.T) {
+		store := newMemoryStateStore()
+		store.persistRecord(t, "overdue", "job", record{NextRun: time.Now().Add(-time.Minute), Runs: 7})
+
+		s := newTestScheduler(t, "overdue", store)
+
+		var runs atomic.Int32
+
+		require.NoError(t, s.Register(Job{Name: "job", Interval: time.Hour, Run: func(context.Context) error {
+			runs.Add(1)
+			return nil
+		}}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s.Start(ctx)
+
+		require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, 5*time.Millisecond)
+		require.Eventually(t, func() bool { return s.Statuses()[0].Runs == 8 }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("interval shortened", func(t *testing.T) {
+		store := newMemoryStateStore()
+		store.persistRecord(t, "shortened", "job", record{NextRun: time.Now().Add(24 * time.Hour)})
+
+		s := newTestScheduler(t, "shortened", store)
+		require.NoError(t, s.Register(Job{Name: "job", Interval: time.Minute, Run: func(context.Context) error { return nil }}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		s.Start(ctx)
+
+		assert.True(t, time.Until(s.Statuses()[0].NextRun) <= time.Minute)
+	})
+}
+
+func TestScheduler_RetryInterval(t *testing.T) {
+	store := newMemoryStateStore()
+	store.persistRecord(t, "retry", "job", record{NextRun: time.Now().Add(-time.Minute)})
+
+	s := newTestScheduler(t, "retry", store)
+
+	var runs atomic.Int32
+
+	require.NoError(t, s.Register(Job{Name: "job", Interval: time.Hour, RetryInterval: 10 * time.Millisecond, Run: func(context.Context) error {
+		if runs.Add(1) < 3 {
+			return errors.NewStorageError("disk full")
+		}
+
+		return nil
+	}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Start(ctx)
+
+	require.Eventually(t, func() bool { return s.Statuses()[0].Runs == 3 }, time.Second, 5*time.Millisecond)
+
+	status := s.Statuses()[0]
+	assert.Equal(t, uint64(2), status.Failures)
+	assert.Empty(t, status.LastError, "the last run succeeded")
+	assert.True(t, time.Until(status.NextRun) > time.Minute, "back on the interval after a success")
+	assert.Equal(t, uint64(2), store.records(t, "retry")["job"].Failures)
+}
+
+func TestScheduler_RunOnStop(t *testing.T) {
+	store := newMemoryStateStore()
+	s := newTestScheduler(t, "stop", store)
+
+	var runs atomic.Int32
+
+	require.NoError(t, s.Register(Job{Name: "job", Interval: time.Hour, RunOnStop: true, Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return ctx.Err()
+	}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.Start(ctx)
+	cancel()
+
+	require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	// the final run is persisted although the context of the scheduler is cancelled
+	require.Eventually(t, func() bool {
+		_, err := store.GetState(context.Background(), stateKeyPrefix+"stop")
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, uint64(1), store.records(t, "stop")["job"].Runs)
+}
+
+func TestJittered(t *testing.T) {
+	s, err := NewScheduler("jitter", ulogger.TestLogger{}, nil, 0.1)
+	require.NoError(t, err)
+
+	for range 100 {
+		d := s.jittered(time.Minute)
+		assert.GreaterOrEqual(t, d, 54*time.Second)
+		assert.LessOrEqual(t, d, 66*time.Second)
+	}
+
+	assert.Equal(t, time.Minute, newTestScheduler(t, "no_jitter", nil).jittered(time.Minute))
+}
+
+func TestStatusHandler(t *testing.T) {
+	s := newTestScheduler(t, "handler", nil)
+	require.NoError(t, s.Register(Job{Name: "job", Interval: time.Hour, Run: func(context.Context) error { return nil }}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Start(ctx)
+
+	rec := httptest.NewRecorder()
+	StatusHandler(rec, httptest.NewRequest(http.MethodGet, "/debug/jobs", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var statuses []Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &statuses))
+
+	found := false
+
+	for _, status := range statuses {
+		if status.Scheduler == "handler" && status.Name == "job" {
+			found = true
+
+			assert.Equal(t, "1h0m0s", status.Interval)
+		}
+	}
+
+	assert.True(t, found)
+
+	rec = httptest.NewRecorder()
+	StatusHandler(rec, httptest.NewRequest(http.MethodGet, "/debug/jobs?scheduler=handler", nil))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &statuses))
+	assert.Len(t, statuses, 1)
+
+	rec = httptest.NewRecorder()
+	StatusHandler(rec, httptest.NewRequest(http.MethodGet, "/debug/jobs?scheduler=unknown", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// a stopped scheduler is no longer listed
+	cancel()
+
+	require.Eventually(t, func() bool {
+		_, ok := schedulers.Load("handler")
+		return !ok
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestSQLStateStore(t *testing.T) {
+	storeURL, err := url.Parse("sqlitememory:///jobs")
+	require.NoError(t, err)
+
+	db, err := util.InitSQLDB(ulogger.TestLogger{}, storeURL, settings.NewSettings())
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	store, err := NewSQLStateStore(db)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	data, err := store.GetState(ctx, "jobs:utxostore")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, store.SetState(ctx, "jobs:utxostore", []byte(`{"a":1}`)))
+	require.NoError(t, store.SetState(ctx, "jobs:utxostore", []byte(`{"a":2}`)))
+
+	data, err = store.GetState(ctx, "jobs:utxostore")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":2}`, string(data))
+
+	// the table already exists
+	_, err = NewSQLStateStore(db)
+	require.NoError(t, err)
+}
@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"sync"
+
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// prometheusJobRuns counts the runs of the maintenance jobs by result.
+	// Labels: scheduler, job, result (success, failure)
+	prometheusJobRuns *prometheus.CounterVec
+
+	// prometheusJobDuration observes the duration of the runs of the maintenance jobs.
+	// Labels: scheduler, job
+	prometheusJobDuration *prometheus.HistogramVec
+)
+
+var (
+	prometheusMetricsInitOnce sync.Once
+)
+
+func initPrometheusMetrics() {
+	prometheusMetricsInitOnce.Do(_initPrometheusMetrics)
+}
+
+func _initPrometheusMetrics() {
+	prometheusJobRuns = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "jobs",
+			Name:      "runs_total",
+			Help:      "Number of runs of the maintenance jobs per scheduler, job and result",
+		},
+		[]string{"scheduler", "job", "result"},
+	)
+
+	prometheusJobDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "jobs",
+			Name:      "run_duration_seconds",
+			Help:      "Duration of the runs of the maintenance jobs per scheduler and job",
+			Buckets:   util.MetricsBucketsSeconds,
+		},
+		[]string{"scheduler", "job"},
+	)
+}
@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/usql"
+)
+
+// SQLStateStore persists the state of the jobs in the maintenance_jobs table of a SQL database, for the services
+// that have a SQL store of their own and no blockchain client, e.g. the SQL UTXO store. It supports PostgreSQL and
+// SQLite.
+type SQLStateStore struct {
+	db *usql.DB
+}
+
+// NewSQLStateStore returns the state store of the database, creating its table when it does not exist
+func NewSQLStateStore(db *usql.DB) (*SQLStateStore, error) {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS maintenance_jobs (
+		 key          VARCHAR(64) PRIMARY KEY
+		,data         TEXT NOT NULL
+		,updated_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`); err != nil {
+		return nil, errors.NewStorageError("could not create maintenance_jobs table", err)
+	}
+
+	return &SQLStateStore{db: db}, nil
+}
+
+// GetState returns the state of the key, nil when it was never set
+func (s *SQLStateStore) GetState(ctx context.Context, key string) ([]byte, error) {
+	var data string
+
+	if err := s.db.QueryRowContext(ctx, `SELECT data FROM maintenance_jobs WHERE key = $1`, key).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, errors.NewStorageError("failed to get the state of %s", key, err)
+	}
+
+	return []byte(data), nil
+}
+
+// SetState stores the state of the key
+func (s *SQLStateStore) SetState(ctx context.Context, key string, data []byte) error {
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO maintenance_jobs (key, data) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET data = excluded.data, updated_at = CURRENT_TIMESTAMP
+	`, key, string(data)); err != nil {
+		return errors.NewStorageError("failed to set the state of %s", key, err)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -536,6 +537,18 @@ type consumerOptions struct {
 	backoffMultiplier     int
 	backoffDurationType   time.Duration
 	stopFn                func()
+
+	// rebalance hooks
+	onPartitionsAssigned func(topic string, partitions []int32)
+	onPartitionsRevoked  func(topic string, partitions []int32)
+
+	// backpressure
+	backlogFn       func() int
+	pauseThreshold  int
+	resumeThreshold int
+
+	// concurrent processing of a partition
+	workers int
 }
 
 // WithRetryAndMoveOn configures error behaviour for the consumer function
@@ -676,6 +689,10 @@ func (k *KafkaConsumerGroup) Start(ctx context.Context, consumerFn func(message
 		k.cancel.Store(cancel)
 		defer cancel()
 
+		if options.backlogFn != nil && options.pauseThreshold > 0 {
+			go k.runBackpressure(internalCtx, options.backlogFn, options.pauseThreshold, options.resumeThreshold)
+		}
+
 		go func() {
 			for {
 				select {
@@ -852,7 +869,12 @@ func (k *KafkaConsumerGroup) Start(ctx context.Context, consumerFn func(message
 					// If we reuse the same context, the next Consume() call will fail immediately
 					// We derive from internalCtx so that shutdown still works correctly
 					consumeCtx, consumeCancel := context.WithCancel(internalCtx)
-					err := currentConsumer.Consume(consumeCtx, topics, NewKafkaConsumer(k.Config, consumerFn, k.watchdog))
+					handler := NewKafkaConsumer(k.Config, consumerFn, k.watchdog)
+					handler.onPartitionsAssigned = options.onPartitionsAssigned
+					handler.onPartitionsRevoked = options.onPartitionsRevoked
+					handler.workers = options.workers
+
+					err := currentConsumer.Consume(consumeCtx, topics, handler)
 					consumeCancel() // Always clean up the context when Consume() returns
 
 					// Consume() returned - mark as no longer attempting
@@ -946,9 +968,16 @@ type KafkaConsumer struct {
 	consumerClosure func(*KafkaMessage) error
 	cfg             KafkaConsumerConfig
 	watchdog        *consumeWatchdog // Monitors for stuck RefreshMetadata and triggers force recovery
+
+	onPartitionsAssigned func(topic string, partitions []int32) // Called with the partitions claimed by a new session
+	onPartitionsRevoked  func(topic string, partitions []int32) // Called with the partitions released at the end of a session
+
+	workers int // Number of workers processing the messages of each claimed partition, see WithWorkers
 }
 
 func NewKafkaConsumer(cfg KafkaConsumerConfig, consumerClosureOrNil func(message *KafkaMessage) error, watchdog *consumeWatchdog) *KafkaConsumer {
+	InitPrometheusMetrics()
+
 	consumer := &KafkaConsumer{
 		consumerClosure: consumerClosureOrNil,
 		cfg:             cfg,
@@ -959,12 +988,21 @@ func NewKafkaConsumer(cfg KafkaConsumerConfig, consumerClosureOrNil func(message
 }
 
 // Setup is run at the beginning of a new session, before ConsumeClaim
-func (kc *KafkaConsumer) Setup(sarama.ConsumerGroupSession) error {
+func (kc *KafkaConsumer) Setup(session sarama.ConsumerGroupSession) error {
 	// This is called AFTER RefreshMetadata succeeds and consumer joins group
 	if kc.watchdog != nil {
 		kc.watchdog.markSetupCalled()
 		kc.cfg.Logger.Infof("[kafka] Consumer setup completed for topic %s - successfully joined group after RefreshMetadata", kc.cfg.Topic)
 	}
+
+	partitions := session.Claims()[kc.cfg.Topic]
+
+	prometheusKafkaConsumerAssignedPartitions.WithLabelValues(kc.cfg.Topic, kc.cfg.ConsumerGroupID).Set(float64(len(partitions)))
+
+	if kc.onPartitionsAssigned != nil {
+		kc.onPartitionsAssigned(kc.cfg.Topic, partitions)
+	}
+
 	return nil
 }
 
@@ -977,6 +1015,12 @@ func (kc *KafkaConsumer) Cleanup(session sarama.ConsumerGroupSession) error {
 		session.Commit()
 	}
 
+	prometheusKafkaConsumerAssignedPartitions.WithLabelValues(kc.cfg.Topic, kc.cfg.ConsumerGroupID).Set(0)
+
+	if kc.onPartitionsRevoked != nil {
+		kc.onPartitionsRevoked(kc.cfg.Topic, session.Claims()[kc.cfg.Topic])
+	}
+
 	return nil
 }
 
@@ -987,6 +1031,11 @@ func (kc *KafkaConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim
 	messageProcessedSinceLastCommit := false
 	messagesProcessed := atomic.Uint64{}
 
+	lag := prometheusKafkaConsumerLag.WithLabelValues(claim.Topic(), kc.cfg.ConsumerGroupID, strconv.Itoa(int(claim.Partition())))
+
+	// the partition may be assigned to another consumer after this claim ends, remove its lag
+	defer prometheusKafkaConsumerLag.DeleteLabelValues(claim.Topic(), kc.cfg.ConsumerGroupID, strconv.Itoa(int(claim.Partition())))
+
 	var mu sync.Mutex // Add mutex to protect messageProcessedSinceLastCommit
 
 	// Start a separate goroutine for commit ticker
@@ -1040,6 +1089,29 @@ func (kc *KafkaConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim
 		}
 	}()
 
+	if kc.workers > 1 {
+		return kc.consumeWithWorkers(session, claim, messages, func(message *sarama.ConsumerMessage, contiguous int64, advanced bool) {
+			messagesProcessed.Add(1)
+
+			if !advanced {
+				return
+			}
+
+			if !kc.cfg.AutoCommitEnabled {
+				// mark the offset of the next message to consume, all messages before it are processed
+				session.MarkOffset(message.Topic, message.Partition, contiguous+1, "")
+
+				mu.Lock()
+				messageProcessedSinceLastCommit = true
+				mu.Unlock()
+			}
+
+			if remaining := claim.HighWaterMarkOffset() - contiguous - 1; remaining >= 0 {
+				lag.Set(float64(remaining))
+			}
+		})
+	}
+
 	for {
 		select {
 		case <-session.Context().Done():
@@ -1083,6 +1155,11 @@ func (kc *KafkaConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim
 
 			// Increment message counter for heartbeat logging
 			messagesProcessed.Add(1)
+
+			// the high water mark is the offset of the next message that will be produced to the partition
+			if remaining := claim.HighWaterMarkOffset() - message.Offset - 1; remaining >= 0 {
+				lag.Set(float64(remaining))
+			}
 		}
 	}
 }
@@ -0,0 +1,95 @@
+// Package kafka provides Kafka consumer and producer implementations for message handling.
+package kafka
+
+import (
+	"context"
+	"time"
+)
+
+// backpressureCheckInterval is how often the backlog of a consumer with backpressure is checked
+const backpressureCheckInterval = 250 * time.Millisecond
+
+// WithRebalanceHooks registers functions called with the partitions of the topic assigned to this consumer
+// when a consumer group session starts, and with the partitions revoked when the session ends, e.g. on a
+// rebalance. Either hook may be nil. The hooks run on the Sarama session goroutines and must not block.
+func WithRebalanceHooks(onAssigned func(topic string, partitions []int32), onRevoked func(topic string, partitions []int32)) ConsumerOption {
+	return func(o *consumerOptions) {
+		o.onPartitionsAssigned = onAssigned
+		o.onPartitionsRevoked = onRevoked
+	}
+}
+
+// WithBackpressure pauses fetching from all partitions while backlogFn reports more than pauseThreshold
+// queued items, and resumes once the backlog drops to resumeThreshold or below. Pausing does not trigger a
+// rebalance, the consumer stays in the group. A pauseThreshold of 0 disables backpressure, a resumeThreshold
+// of 0 or above the pause threshold resumes at half the pause threshold.
+func WithBackpressure(backlogFn func() int, pauseThreshold int, resumeThreshold int) ConsumerOption {
+	return func(o *consumerOptions) {
+		if resumeThreshold <= 0 || resumeThreshold > pauseThreshold {
+			resumeThreshold = pauseThreshold / 2
+		}
+
+		o.backlogFn = backlogFn
+		o.pauseThreshold = pauseThreshold
+		o.resumeThreshold = resumeThreshold
+	}
+}
+
+// runBackpressure pauses and resumes the consumer group based on the backlog until the context is done
+func (k *KafkaConsumerGroup) runBackpressure(ctx context.Context, backlogFn func() int, pauseThreshold int, resumeThreshold int) {
+	ticker := time.NewTicker(backpressureCheckInterval)
+	defer ticker.Stop()
+
+	paused := false
+	pausedGauge := prometheusKafkaConsumerPaused.WithLabelValues(k.Config.Topic, k.Config.ConsumerGroupID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			backlog := backlogFn()
+
+			switch {
+			case !paused && backlog > pauseThreshold:
+				paused = true
+				pausedGauge.Set(1)
+				k.Config.Logger.Infof("[Kafka] %s: backlog of %d exceeds %d, pausing consumption of topic %s",
+					k.Config.ConsumerGroupID, backlog, pauseThreshold, k.Config.Topic)
+
+			case paused && backlog <= resumeThreshold:
+				paused = false
+				pausedGauge.Set(0)
+				k.Config.Logger.Infof("[Kafka] %s: backlog of %d at or below %d, resuming consumption of topic %s",
+					k.Config.ConsumerGroupID, backlog, resumeThreshold, k.Config.Topic)
+
+				k.setPaused(false)
+
+				continue
+			}
+
+			// pausing is re-applied on every check, so partitions claimed after a rebalance or a recreated
+			// consumer group are paused as well
+			if paused {
+				k.setPaused(true)
+			}
+		}
+	}
+}
+
+// setPaused pauses or resumes all partitions of the current consumer group
+func (k *KafkaConsumerGroup) setPaused(paused bool) {
+	k.consumerMu.Lock()
+	consumerGroup := k.ConsumerGroup
+	k.consumerMu.Unlock()
+
+	if consumerGroup == nil {
+		return
+	}
+
+	if paused {
+		consumerGroup.PauseAll()
+	} else {
+		consumerGroup.ResumeAll()
+	}
+}
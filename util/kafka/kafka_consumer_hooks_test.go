@@ -0,0 +1,124 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type claimsConsumerGroupSession struct {
+	mockConsumerGroupSession
+	claims map[string][]int32
+}
+
+func (s *claimsConsumerGroupSession) Claims() map[string][]int32 { return s.claims }
+
+// pauseRecordingConsumerGroup is a consumer group recording whether it is paused
+type pauseRecordingConsumerGroup struct {
+	mockSaramaConsumerGroup
+	paused atomic.Bool
+}
+
+func (m *pauseRecordingConsumerGroup) PauseAll()  { m.paused.Store(true) }
+func (m *pauseRecordingConsumerGroup) ResumeAll() { m.paused.Store(false) }
+
+func TestWithRebalanceHooks(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		assigned []int32
+		revoked  []int32
+	)
+
+	options := &consumerOptions{}
+	WithRebalanceHooks(
+		func(topic string, partitions []int32) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			assert.Equal(t, "blocks", topic)
+			assigned = partitions
+		},
+		func(topic string, partitions []int32) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			assert.Equal(t, "blocks", topic)
+			revoked = partitions
+		},
+	)(options)
+
+	consumer := NewKafkaConsumer(KafkaConsumerConfig{
+		Logger:            ulogger.TestLogger{},
+		Topic:             "blocks",
+		ConsumerGroupID:   "group",
+		AutoCommitEnabled: true,
+	}, func(*KafkaMessage) error { return nil }, nil)
+	consumer.onPartitionsAssigned = options.onPartitionsAssigned
+	consumer.onPartitionsRevoked = options.onPartitionsRevoked
+
+	session := &claimsConsumerGroupSession{claims: map[string][]int32{"blocks": {0, 2}, "other": {1}}}
+
+	require.NoError(t, consumer.Setup(session))
+	require.NoError(t, consumer.Cleanup(session))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, []int32{0, 2}, assigned)
+	assert.Equal(t, []int32{0, 2}, revoked)
+}
+
+func TestWithBackpressure(t *testing.T) {
+	t.Run("thresholds", func(t *testing.T) {
+		options := &consumerOptions{}
+		WithBackpressure(func() int { return 0 }, 100, 20)(options)
+		assert.Equal(t, 100, options.pauseThreshold)
+		assert.Equal(t, 20, options.resumeThreshold)
+
+		options = &consumerOptions{}
+		WithBackpressure(func() int { return 0 }, 100, 0)(options)
+		assert.Equal(t, 50, options.resumeThreshold, "defaults to half the pause threshold")
+
+		options = &consumerOptions{}
+		WithBackpressure(func() int { return 0 }, 100, 200)(options)
+		assert.Equal(t, 50, options.resumeThreshold, "resume threshold can not exceed the pause threshold")
+	})
+
+	t.Run("pauses and resumes on backlog", func(t *testing.T) {
+		InitPrometheusMetrics()
+
+		consumerGroup := &pauseRecordingConsumerGroup{}
+		k := &KafkaConsumerGroup{
+			Config:        KafkaConsumerConfig{Logger: ulogger.TestLogger{}, Topic: "blocks", ConsumerGroupID: "group"},
+			ConsumerGroup: consumerGroup,
+		}
+
+		var backlog atomic.Int64
+
+		backlog.Store(10)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go k.runBackpressure(ctx, func() int { return int(backlog.Load()) }, 5, 2)
+
+		require.Eventually(t, consumerGroup.paused.Load, 2*time.Second, 10*time.Millisecond)
+
+		// still above the resume threshold
+		backlog.Store(3)
+		time.Sleep(3 * backpressureCheckInterval)
+		assert.True(t, consumerGroup.paused.Load())
+
+		backlog.Store(2)
+		require.Eventually(t, func() bool { return !consumerGroup.paused.Load() }, 2*time.Second, 10*time.Millisecond)
+	})
+}
+
+var _ sarama.ConsumerGroup = (*pauseRecordingConsumerGroup)(nil)
@@ -0,0 +1,179 @@
+package kafka
+
+import (
+	"hash/fnv"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// workerQueueSize is the number of messages queued per worker of a partition before dispatching blocks
+const workerQueueSize = 16
+
+// WithWorkers processes the messages of each claimed partition with up to the given number of workers.
+// Messages with the same key are always handled by the same worker, in offset order, so ordering is
+// preserved per key within a partition, while messages with different keys are processed concurrently.
+// Partitions are always processed concurrently, one Sarama claim goroutine each.
+//
+// With manual commits the offset of a partition is only marked once all earlier messages of that partition
+// have been processed, so a restart never skips a message that was still in flight. A worker count of 1 or
+// lower keeps the sequential processing of each partition.
+func WithWorkers(workers int) ConsumerOption {
+	return func(o *consumerOptions) {
+		o.workers = workers
+	}
+}
+
+// offsetTracker tracks the in-flight messages of a partition and reports the highest offset up to which
+// all dispatched messages have been processed
+type offsetTracker struct {
+	mu      sync.Mutex
+	pending []int64        // dispatched offsets, in dispatch order
+	done    map[int64]bool // processed offsets that are not yet contiguous
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{
+		done: make(map[int64]bool),
+	}
+}
+
+// dispatched registers a message offset that is handed to a worker, offsets must be registered in order
+func (t *offsetTracker) dispatched(offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending = append(t.pending, offset)
+}
+
+// processed marks an offset as processed and returns the highest offset up to which all dispatched
+// messages are processed, or false when an earlier message is still in flight
+func (t *offsetTracker) processed(offset int64) (int64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.done[offset] = true
+
+	contiguous := int64(-1)
+	advanced := false
+
+	for len(t.pending) > 0 && t.done[t.pending[0]] {
+		contiguous = t.pending[0]
+		advanced = true
+
+		delete(t.done, t.pending[0])
+		t.pending = t.pending[1:]
+	}
+
+	return contiguous, advanced
+}
+
+// workerIndex returns the worker handling a message, messages without a key have no ordering requirement
+func workerIndex(message *sarama.ConsumerMessage, workers int) int {
+	if len(message.Key) == 0 {
+		return int(message.Offset % int64(workers))
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write(message.Key)
+
+	return int(h.Sum32() % uint32(workers)) //nolint:gosec // workers is always positive
+}
+
+// consumeWithWorkers dispatches the messages of a claim over the workers of the consumer until the session
+// ends or a message fails. onProcessed is called for every successfully processed message with the offset
+// up to which the partition is fully processed, and whether that offset advanced.
+func (kc *KafkaConsumer) consumeWithWorkers(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim,
+	messages <-chan *sarama.ConsumerMessage, onProcessed func(message *sarama.ConsumerMessage, contiguous int64, advanced bool)) error {
+	tracker := newOffsetTracker()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	failed := make(chan struct{})
+	queues := make([]chan *sarama.ConsumerMessage, kc.workers)
+
+	for i := range queues {
+		queues[i] = make(chan *sarama.ConsumerMessage, workerQueueSize)
+
+		wg.Add(1)
+
+		go func(queue <-chan *sarama.ConsumerMessage) {
+			defer wg.Done()
+
+			for message := range queue {
+				// once a message failed, the remaining queued messages are left for the next session
+				select {
+				case <-failed:
+					continue
+				default:
+				}
+
+				if err := kc.consumerClosure(&KafkaMessage{*message}); err != nil {
+					kc.cfg.Logger.Errorf("[kafka_consumer] failed to process message (topic: %s, partition: %d, offset: %d): %v",
+						message.Topic, message.Partition, message.Offset, err)
+
+					errOnce.Do(func() {
+						firstErr = err
+						close(failed)
+					})
+
+					continue
+				}
+
+				contiguous, advanced := tracker.processed(message.Offset)
+				onProcessed(message, contiguous, advanced)
+			}
+		}(queues[i])
+	}
+
+	stop := func() error {
+		for _, queue := range queues {
+			close(queue)
+		}
+
+		wg.Wait()
+
+		return firstErr
+	}
+
+	kc.cfg.Logger.Debugf("[kafka_consumer] processing partition %d of topic %s with %d workers", claim.Partition(), claim.Topic(), kc.workers)
+
+	for {
+		select {
+		case <-session.Context().Done():
+			err := stop()
+			if err == nil {
+				err = session.Context().Err()
+			}
+
+			return err
+
+		case <-failed:
+			return stop()
+
+		case message := <-messages:
+			if message == nil {
+				continue
+			}
+
+			tracker.dispatched(message.Offset)
+
+			select {
+			case queues[workerIndex(message, kc.workers)] <- message:
+			case <-failed:
+				return stop()
+			case <-session.Context().Done():
+				err := stop()
+				if err == nil {
+					err = session.Context().Err()
+				}
+
+				return err
+			}
+		}
+	}
+}
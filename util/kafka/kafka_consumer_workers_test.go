@@ -0,0 +1,200 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// offsetRecordingConsumerGroupSession is a session recording the marked offsets
+type offsetRecordingConsumerGroupSession struct {
+	mockConsumerGroupSession
+	ctx context.Context
+
+	mu     sync.Mutex
+	marked []int64
+}
+
+func (s *offsetRecordingConsumerGroupSession) Context() context.Context { return s.ctx }
+
+func (s *offsetRecordingConsumerGroupSession) MarkOffset(_ string, _ int32, offset int64, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.marked = append(s.marked, offset)
+}
+
+func (s *offsetRecordingConsumerGroupSession) markedOffsets() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]int64(nil), s.marked...)
+}
+
+type mockConsumerGroupClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *mockConsumerGroupClaim) Topic() string                            { return "subtrees" }
+func (c *mockConsumerGroupClaim) Partition() int32                         { return 0 }
+func (c *mockConsumerGroupClaim) InitialOffset() int64                     { return 0 }
+func (c *mockConsumerGroupClaim) HighWaterMarkOffset() int64               { return 0 }
+func (c *mockConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func TestOffsetTracker(t *testing.T) {
+	tracker := newOffsetTracker()
+
+	for offset := int64(10); offset < 14; offset++ {
+		tracker.dispatched(offset)
+	}
+
+	_, advanced := tracker.processed(11)
+	assert.False(t, advanced, "offset 10 is still in flight")
+
+	_, advanced = tracker.processed(13)
+	assert.False(t, advanced)
+
+	contiguous, advanced := tracker.processed(10)
+	assert.True(t, advanced)
+	assert.Equal(t, int64(11), contiguous)
+
+	contiguous, advanced = tracker.processed(12)
+	assert.True(t, advanced)
+	assert.Equal(t, int64(13), contiguous)
+}
+
+func TestWorkerIndex(t *testing.T) {
+	a := &sarama.ConsumerMessage{Key: []byte("block1"), Offset: 1}
+	b := &sarama.ConsumerMessage{Key: []byte("block1"), Offset: 2}
+
+	assert.Equal(t, workerIndex(a, 8), workerIndex(b, 8), "messages with the same key share a worker")
+
+	assert.Equal(t, 1, workerIndex(&sarama.ConsumerMessage{Offset: 5}, 4))
+	assert.Equal(t, 0, workerIndex(a, 1))
+}
+
+func TestWithWorkers(t *testing.T) {
+	options := &consumerOptions{}
+	WithWorkers(4)(options)
+	assert.Equal(t, 4, options.workers)
+}
+
+func TestKafkaConsumer_ConsumeClaimWithWorkers(t *testing.T) {
+	InitPrometheusMetrics()
+
+	const (
+		keys     = 4
+		messages = 40
+	)
+
+	newConsumer := func(closure func(*KafkaMessage) error) *KafkaConsumer {
+		consumer := NewKafkaConsumer(KafkaConsumerConfig{
+			Logger:            ulogger.TestLogger{},
+			Topic:             "subtrees",
+			ConsumerGroupID:   "group",
+			AutoCommitEnabled: false,
+			ChannelBufferSize: 8,
+		}, closure, nil)
+		consumer.workers = 4
+
+		return consumer
+	}
+
+	newClaim := func() *mockConsumerGroupClaim {
+		claim := &mockConsumerGroupClaim{messages: make(chan *sarama.ConsumerMessage, messages)}
+
+		for offset := 0; offset < messages; offset++ {
+			claim.messages <- &sarama.ConsumerMessage{
+				Topic:  "subtrees",
+				Key:    []byte(fmt.Sprintf("key%d", offset%keys)),
+				Value:  []byte{byte(offset)},
+				Offset: int64(offset),
+			}
+		}
+
+		return claim
+	}
+
+	t.Run("preserves key order and marks contiguous offsets", func(t *testing.T) {
+		var (
+			mu      sync.Mutex
+			order   = make(map[string][]int64)
+			running int
+			peak    int
+		)
+
+		consumer := newConsumer(func(msg *KafkaMessage) error {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+
+			// early messages take longer, so later messages of other keys overtake them
+			time.Sleep(time.Duration(messages-msg.Offset) * 100 * time.Microsecond)
+
+			mu.Lock()
+			running--
+			order[string(msg.Key)] = append(order[string(msg.Key)], msg.Offset)
+			mu.Unlock()
+
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		session := &offsetRecordingConsumerGroupSession{ctx: ctx}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- consumer.ConsumeClaim(session, newClaim())
+		}()
+
+		require.Eventually(t, func() bool {
+			marked := session.markedOffsets()
+			return len(marked) > 0 && marked[len(marked)-1] == messages
+		}, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for key, offsets := range order {
+			assert.IsIncreasing(t, offsets, "messages of %s processed out of order", key)
+		}
+
+		assert.Greater(t, peak, 1, "messages of different keys are processed concurrently")
+
+		marked := session.markedOffsets()
+		assert.IsIncreasing(t, marked, "marked offsets never move backwards")
+	})
+
+	t.Run("failure stops the claim without marking the failed message", func(t *testing.T) {
+		consumer := newConsumer(func(msg *KafkaMessage) error {
+			if msg.Offset == 5 {
+				return errors.NewProcessingError("invalid message")
+			}
+
+			return nil
+		})
+
+		session := &offsetRecordingConsumerGroupSession{ctx: context.Background()}
+
+		err := consumer.ConsumeClaim(session, newClaim())
+		require.Error(t, err)
+
+		for _, offset := range session.markedOffsets() {
+			assert.LessOrEqual(t, offset, int64(5), "offsets after the failed message are never marked")
+		}
+	})
+}
+
+var _ sarama.ConsumerGroupClaim = (*mockConsumerGroupClaim)(nil)
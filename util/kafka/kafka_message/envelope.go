@@ -0,0 +1,282 @@
+package kafkamessage
+
+import (
+	"encoding/binary"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Kafka messages are encoded in a versioned envelope: a marker byte, the schema ID and the version of the message as
+// uvarints, followed by the protobuf encoded message. The marker is 0, which a protobuf encoded message never starts
+// with as field number 0 is invalid, so messages produced before the envelopes, version 0, are still decoded.
+//
+// A consumer decodes the current version of a schema and the version before it, so nodes one version apart can
+// produce to and consume from the same topics during a rolling upgrade.
+
+const (
+	// envelopeMarker is the first byte of an enveloped message
+	envelopeMarker = 0x00
+
+	// maxEnvelopeHeaderSize is the size of the marker, schema ID and version of an envelope at most
+	maxEnvelopeHeaderSize = 1 + 2*binary.MaxVarintLen32
+)
+
+// Versions of the Kafka messages
+const (
+	LegacyMessageVersion uint32 = 0 // Version of the messages produced before the envelopes
+	LatestMessageVersion uint32 = 1 // Highest version of any schema
+)
+
+// Schema IDs of the Kafka messages. An ID is never reused, also not when its schema is removed.
+const (
+	SchemaBlock          uint32 = 1
+	SchemaSubtree        uint32 = 2
+	SchemaTxValidation   uint32 = 3
+	SchemaInvalidBlock   uint32 = 4
+	SchemaInvalidSubtree uint32 = 5
+	SchemaRejectedTx     uint32 = 6
+	SchemaDoubleSpend    uint32 = 7
+	SchemaTxMeta         uint32 = 8
+	SchemaInv            uint32 = 9
+	SchemaBlocksFinal    uint32 = 10
+)
+
+// Schema describes a Kafka message schema
+type Schema struct {
+	ID      uint32        // Identifies the schema in the envelope
+	Name    string        // Name of the schema in logs and metrics
+	Version uint32        // Current version of the schema, version 1 is the first enveloped version
+	Message proto.Message // Prototype of the message of the schema
+}
+
+// schemas is the registry of the Kafka message schemas
+var schemas = []Schema{
+	{ID: SchemaBlock, Name: "block", Version: 1, Message: &KafkaBlockTopicMessage{}},
+	{ID: SchemaSubtree, Name: "subtree", Version: 1, Message: &KafkaSubtreeTopicMessage{}},
+	{ID: SchemaTxValidation, Name: "tx_validation", Version: 1, Message: &KafkaTxValidationTopicMessage{}},
+	{ID: SchemaInvalidBlock, Name: "invalid_block", Version: 1, Message: &KafkaInvalidBlockTopicMessage{}},
+	{ID: SchemaInvalidSubtree, Name: "invalid_subtree", Version: 1, Message: &KafkaInvalidSubtreeTopicMessage{}},
+	{ID: SchemaRejectedTx, Name: "rejected_tx", Version: 1, Message: &KafkaRejectedTxTopicMessage{}},
+	{ID: SchemaDoubleSpend, Name: "double_spend", Version: 1, Message: &KafkaDoubleSpendTopicMessage{}},
+	{ID: SchemaTxMeta, Name: "tx_meta", Version: 1, Message: &KafkaTxMetaTopicMessage{}},
+	{ID: SchemaInv, Name: "inv", Version: 1, Message: &KafkaInvTopicMessage{}},
+	{ID: SchemaBlocksFinal, Name: "blocks_final", Version: 1, Message: &KafkaBlocksFinalTopicMessage{}},
+}
+
+var (
+	// schemasByMessage indexes the registry by message, built on first use as the message descriptors are only
+	// registered by the init of the generated code
+	schemasByMessage = sync.OnceValue(func() map[protoreflect.FullName]*Schema {
+		byMessage := make(map[protoreflect.FullName]*Schema, len(schemas))
+
+		for i := range schemas {
+			byMessage[schemas[i].Message.ProtoReflect().Descriptor().FullName()] = &schemas[i]
+		}
+
+		return byMessage
+	})
+
+	// produceVersion is the highest version messages are produced at, see Configure
+	produceVersion atomic.Uint32
+)
+
+func init() {
+	produceVersion.Store(LatestMessageVersion)
+}
+
+// Schemas returns the registered Kafka message schemas
+func Schemas() []Schema {
+	return append([]Schema(nil), schemas...)
+}
+
+// SchemaOf returns the schema of the message
+func SchemaOf(msg proto.Message) (Schema, bool) {
+	schema, ok := schemasByMessage()[msg.ProtoReflect().Descriptor().FullName()]
+	if !ok {
+		return Schema{}, false
+	}
+
+	return *schema, true
+}
+
+// CheckCompatibility checks the schema registry and whether messages produced at the version can be consumed by nodes
+// one version behind: every schema needs a unique ID and message, and messages of every schema must be produced at
+// its current version or the version before it.
+func CheckCompatibility(version uint32) error {
+	return checkCompatibility(schemas, version)
+}
+
+func checkCompatibility(registry []Schema, version uint32) error {
+	if version > LatestMessageVersion {
+		return errors.NewConfigurationError("kafka message version %d is newer than the latest version %d", version, LatestMessageVersion)
+	}
+
+	ids := make(map[uint32]string, len(registry))
+	messages := make(map[protoreflect.FullName]string, len(registry))
+
+	for _, schema := range registry {
+		if schema.ID == 0 {
+			return errors.NewConfigurationError("kafka message schema %s has no ID", schema.Name)
+		}
+
+		if other, exists := ids[schema.ID]; exists {
+			return errors.NewConfigurationError("kafka message schemas %s and %s share ID %d", other, schema.Name, schema.ID)
+		}
+
+		ids[schema.ID] = schema.Name
+
+		name := schema.Message.ProtoReflect().Descriptor().FullName()
+		if other, exists := messages[name]; exists {
+			return errors.NewConfigurationError("kafka message schemas %s and %s share message %s", other, schema.Name, name)
+		}
+
+		messages[name] = schema.Name
+
+		if schema.Version == 0 || schema.Version > LatestMessageVersion {
+			return errors.NewConfigurationError("kafka message schema %s has invalid version %d", schema.Name, schema.Version)
+		}
+
+		if produced := min(version, schema.Version); produced+1 < schema.Version {
+			return errors.NewConfigurationError("kafka message schema %s can not be produced at version %d, the oldest version nodes of the current version decode is %d", schema.Name, produced, schema.Version-1)
+		}
+	}
+
+	return nil
+}
+
+// Configure checks the compatibility of the schemas and sets the highest version messages are produced at. Producing
+// at the version before the latest lets nodes that are not upgraded yet consume the messages of upgraded nodes.
+func Configure(version uint32) error {
+	if err := CheckCompatibility(version); err != nil {
+		return err
+	}
+
+	produceVersion.Store(version)
+
+	return nil
+}
+
+// Encode encodes the message in an envelope at the version messages of its schema are produced at
+func Encode(msg proto.Message) ([]byte, error) {
+	schema, ok := schemasByMessage()[msg.ProtoReflect().Descriptor().FullName()]
+	if !ok {
+		return nil, errors.NewProcessingError("no kafka message schema registered for %s", msg.ProtoReflect().Descriptor().FullName())
+	}
+
+	version := min(produceVersion.Load(), schema.Version)
+	if version == LegacyMessageVersion {
+		return proto.Marshal(msg)
+	}
+
+	buf := make([]byte, 0, maxEnvelopeHeaderSize+proto.Size(msg))
+	buf = append(buf, envelopeMarker)
+	buf = binary.AppendUvarint(buf, uint64(schema.ID))
+	buf = binary.AppendUvarint(buf, uint64(version))
+
+	buf, err := proto.MarshalOptions{}.MarshalAppend(buf, msg)
+	if err != nil {
+		return nil, errors.NewProcessingError("failed to encode kafka %s message", schema.Name, err)
+	}
+
+	return buf, nil
+}
+
+// Decode decodes an enveloped message, or a message produced before the envelopes, into msg. Messages of another
+// schema and messages at a version not decoded by this node are rejected.
+func Decode(data []byte, msg proto.Message) error {
+	schema, ok := schemasByMessage()[msg.ProtoReflect().Descriptor().FullName()]
+	if !ok {
+		return errors.NewProcessingError("no kafka message schema registered for %s", msg.ProtoReflect().Descriptor().FullName())
+	}
+
+	initPrometheusMetrics()
+
+	id, version, payload, err := parseEnvelope(data)
+	if err != nil {
+		prometheusKafkaMessagesRejected.WithLabelValues(schema.Name).Inc()
+		return err
+	}
+
+	if version != LegacyMessageVersion && id != schema.ID {
+		prometheusKafkaMessagesRejected.WithLabelValues(schema.Name).Inc()
+		return errors.NewInvalidArgumentError("kafka message of schema %d, expected %s (%d)", id, schema.Name, schema.ID)
+	}
+
+	if version > schema.Version || version+1 < schema.Version {
+		prometheusKafkaMessagesRejected.WithLabelValues(schema.Name).Inc()
+		return errors.NewInvalidArgumentError("kafka %s message version %d is not supported, this node decodes versions %d to %d", schema.Name, version, schema.Version-1, schema.Version)
+	}
+
+	if err = proto.Unmarshal(payload, msg); err != nil {
+		prometheusKafkaMessagesRejected.WithLabelValues(schema.Name).Inc()
+		return errors.NewInvalidArgumentError("failed to decode kafka %s message version %d", schema.Name, version, err)
+	}
+
+	prometheusKafkaMessagesDecoded.WithLabelValues(schema.Name, strconv.FormatUint(uint64(version), 10)).Inc()
+
+	return nil
+}
+
+// parseEnvelope returns the schema ID, the version and the payload of the message. Messages without an envelope are
+// returned as is, at the legacy version.
+func parseEnvelope(data []byte) (id uint32, version uint32, payload []byte, err error) {
+	if len(data) == 0 || data[0] != envelopeMarker {
+		return 0, LegacyMessageVersion, data, nil
+	}
+
+	rawID, n := binary.Uvarint(data[1:])
+	if n <= 0 || rawID > uint64(^uint32(0)) {
+		return 0, 0, nil, errors.NewInvalidArgumentError("invalid kafka message envelope schema ID")
+	}
+
+	rawVersion, m := binary.Uvarint(data[1+n:])
+	if m <= 0 || rawVersion > uint64(^uint32(0)) {
+		return 0, 0, nil, errors.NewInvalidArgumentError("invalid kafka message envelope version")
+	}
+
+	return uint32(rawID), uint32(rawVersion), data[1+n+m:], nil
+}
+
+var (
+	// prometheusKafkaMessagesDecoded counts the decoded Kafka messages, the legacy version counts the messages of
+	// producers that are not upgraded yet.
+	// Labels: schema, version
+	prometheusKafkaMessagesDecoded *prometheus.CounterVec
+
+	// prometheusKafkaMessagesRejected counts the Kafka messages of another schema or an unsupported version.
+	// Labels: schema
+	prometheusKafkaMessagesRejected *prometheus.CounterVec
+
+	prometheusMetricsInitOnce sync.Once
+)
+
+func initPrometheusMetrics() {
+	prometheusMetricsInitOnce.Do(func() {
+		prometheusKafkaMessagesDecoded = promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "teranode",
+				Subsystem: "kafka",
+				Name:      "messages_decoded_total",
+				Help:      "Number of Kafka messages decoded per schema and version",
+			},
+			[]string{"schema", "version"},
+		)
+
+		prometheusKafkaMessagesRejected = promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "teranode",
+				Subsystem: "kafka",
+				Name:      "messages_rejected_total",
+				Help:      "Number of Kafka messages rejected for their schema or version",
+			},
+			[]string{"schema"},
+		)
+	})
+}
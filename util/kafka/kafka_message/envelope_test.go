@@ -0,0 +1,136 @@
+package kafkamessage
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	msg := &KafkaBlockTopicMessage{Hash: "0000abcd", URL: "http://peer", PeerId: "peer-1"}
+
+	t.Run("enveloped", func(t *testing.T) {
+		data, err := Encode(msg)
+		require.NoError(t, err)
+
+		id, version, _, err := parseEnvelope(data)
+		require.NoError(t, err)
+		assert.Equal(t, SchemaBlock, id)
+		assert.Equal(t, uint32(1), version)
+
+		var decoded KafkaBlockTopicMessage
+		require.NoError(t, Decode(data, &decoded))
+		assert.True(t, proto.Equal(msg, &decoded))
+	})
+
+	t.Run("message produced before the envelopes", func(t *testing.T) {
+		data, err := proto.Marshal(msg)
+		require.NoError(t, err)
+
+		var decoded KafkaBlockTopicMessage
+		require.NoError(t, Decode(data, &decoded))
+		assert.True(t, proto.Equal(msg, &decoded))
+	})
+
+	t.Run("produced at the legacy version", func(t *testing.T) {
+		require.NoError(t, Configure(LegacyMessageVersion))
+		defer func() { require.NoError(t, Configure(LatestMessageVersion)) }()
+
+		data, err := Encode(msg)
+		require.NoError(t, err)
+
+		legacy, err := proto.Marshal(msg)
+		require.NoError(t, err)
+		assert.Equal(t, legacy, data, "nodes before the envelopes decode the message")
+	})
+
+	t.Run("empty message", func(t *testing.T) {
+		data, err := Encode(&KafkaInvalidBlockTopicMessage{})
+		require.NoError(t, err)
+
+		var decoded KafkaInvalidBlockTopicMessage
+		require.NoError(t, Decode(data, &decoded))
+		require.NoError(t, Decode(nil, &decoded))
+	})
+}
+
+func TestDecode_Rejected(t *testing.T) {
+	envelope := func(id, version uint32, payload []byte) []byte {
+		data := []byte{envelopeMarker}
+		data = binary.AppendUvarint(data, uint64(id))
+		data = binary.AppendUvarint(data, uint64(version))
+
+		return append(data, payload...)
+	}
+
+	payload, err := proto.Marshal(&KafkaSubtreeTopicMessage{Hash: "abcd"})
+	require.NoError(t, err)
+
+	var decoded KafkaSubtreeTopicMessage
+
+	require.NoError(t, Decode(envelope(SchemaSubtree, 1, payload), &decoded))
+
+	assert.Error(t, Decode(envelope(SchemaBlock, 1, payload), &decoded), "message of another schema")
+	assert.Error(t, Decode(envelope(SchemaSubtree, 2, payload), &decoded), "message of a newer version")
+	assert.Error(t, Decode([]byte{envelopeMarker, 0x80}, &decoded), "truncated envelope")
+	assert.Error(t, Decode(envelope(SchemaSubtree, 1, []byte{0xff}), &decoded), "invalid payload")
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	require.NoError(t, CheckCompatibility(LatestMessageVersion))
+	require.NoError(t, CheckCompatibility(LegacyMessageVersion))
+	assert.Error(t, CheckCompatibility(LatestMessageVersion+1))
+
+	tests := []struct {
+		name     string
+		registry []Schema
+		version  uint32
+	}{
+		{
+			name:     "missing ID",
+			registry: []Schema{{Name: "block", Version: 1, Message: &KafkaBlockTopicMessage{}}},
+			version:  1,
+		},
+		{
+			name: "shared ID",
+			registry: []Schema{
+				{ID: 1, Name: "block", Version: 1, Message: &KafkaBlockTopicMessage{}},
+				{ID: 1, Name: "subtree", Version: 1, Message: &KafkaSubtreeTopicMessage{}},
+			},
+			version: 1,
+		},
+		{
+			name: "shared message",
+			registry: []Schema{
+				{ID: 1, Name: "block", Version: 1, Message: &KafkaBlockTopicMessage{}},
+				{ID: 2, Name: "block2", Version: 1, Message: &KafkaBlockTopicMessage{}},
+			},
+			version: 1,
+		},
+		{
+			name:     "invalid version",
+			registry: []Schema{{ID: 1, Name: "block", Version: 0, Message: &KafkaBlockTopicMessage{}}},
+			version:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, checkCompatibility(tt.registry, tt.version))
+		})
+	}
+}
+
+func TestSchemas(t *testing.T) {
+	for _, schema := range Schemas() {
+		found, ok := SchemaOf(schema.Message)
+		require.True(t, ok, schema.Name)
+		assert.Equal(t, schema.ID, found.ID)
+	}
+
+	_, ok := SchemaOf(&KafkaTxValidationOptions{})
+	assert.False(t, ok)
+}
@@ -21,6 +21,52 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type KafkaDoubleSpendSource int32
+
+const (
+	KafkaDoubleSpendSource_MEMPOOL KafkaDoubleSpendSource = 0 // conflicting spend of a transaction received for the mempool
+	KafkaDoubleSpendSource_BLOCK   KafkaDoubleSpendSource = 1 // conflicting spend of a transaction in a subtree of a block
+)
+
+// Enum value maps for KafkaDoubleSpendSource.
+var (
+	KafkaDoubleSpendSource_name = map[int32]string{
+		0: "MEMPOOL",
+		1: "BLOCK",
+	}
+	KafkaDoubleSpendSource_value = map[string]int32{
+		"MEMPOOL": 0,
+		"BLOCK":   1,
+	}
+)
+
+func (x KafkaDoubleSpendSource) Enum() *KafkaDoubleSpendSource {
+	p := new(KafkaDoubleSpendSource)
+	*p = x
+	return p
+}
+
+func (x KafkaDoubleSpendSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KafkaDoubleSpendSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_util_kafka_kafka_message_kafka_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (KafkaDoubleSpendSource) Type() protoreflect.EnumType {
+	return &file_util_kafka_kafka_message_kafka_messages_proto_enumTypes[0]
+}
+
+func (x KafkaDoubleSpendSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KafkaDoubleSpendSource.Descriptor instead.
+func (KafkaDoubleSpendSource) EnumDescriptor() ([]byte, []int) {
+	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{0}
+}
+
 type KafkaTxMetaActionType int32
 
 const (
@@ -51,11 +97,11 @@ func (x KafkaTxMetaActionType) String() string {
 }
 
 func (KafkaTxMetaActionType) Descriptor() protoreflect.EnumDescriptor {
-	return file_util_kafka_kafka_message_kafka_messages_proto_enumTypes[0].Descriptor()
+	return file_util_kafka_kafka_message_kafka_messages_proto_enumTypes[1].Descriptor()
 }
 
 func (KafkaTxMetaActionType) Type() protoreflect.EnumType {
-	return &file_util_kafka_kafka_message_kafka_messages_proto_enumTypes[0]
+	return &file_util_kafka_kafka_message_kafka_messages_proto_enumTypes[1]
 }
 
 func (x KafkaTxMetaActionType) Number() protoreflect.EnumNumber {
@@ -64,7 +110,7 @@ func (x KafkaTxMetaActionType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use KafkaTxMetaActionType.Descriptor instead.
 func (KafkaTxMetaActionType) EnumDescriptor() ([]byte, []int) {
-	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{0}
+	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{1}
 }
 
 type InvType int32
@@ -103,11 +149,11 @@ func (x InvType) String() string {
 }
 
 func (InvType) Descriptor() protoreflect.EnumDescriptor {
-	return file_util_kafka_kafka_message_kafka_messages_proto_enumTypes[1].Descriptor()
+	return file_util_kafka_kafka_message_kafka_messages_proto_enumTypes[2].Descriptor()
 }
 
 func (InvType) Type() protoreflect.EnumType {
-	return &file_util_kafka_kafka_message_kafka_messages_proto_enumTypes[1]
+	return &file_util_kafka_kafka_message_kafka_messages_proto_enumTypes[2]
 }
 
 func (x InvType) Number() protoreflect.EnumNumber {
@@ -116,14 +162,15 @@ func (x InvType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use InvType.Descriptor instead.
 func (InvType) EnumDescriptor() ([]byte, []int) {
-	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{1}
+	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{2}
 }
 
 type KafkaBlockTopicMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	URL           string                 `protobuf:"bytes,2,opt,name=URL,proto3" json:"URL,omitempty"`
-	PeerId        string                 `protobuf:"bytes,3,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"` // P2P peer identifier for peerMetrics tracking
+	PeerId        string                 `protobuf:"bytes,3,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`    // P2P peer identifier for peerMetrics tracking
+	TraceId       string                 `protobuf:"bytes,4,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"` // lifecycle ID of the block, see util/blocktrace
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -179,6 +226,13 @@ func (x *KafkaBlockTopicMessage) GetPeerId() string {
 	return ""
 }
 
+func (x *KafkaBlockTopicMessage) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
 type KafkaInvalidBlockTopicMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BlockHash     string                 `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
@@ -539,6 +593,142 @@ func (x *KafkaRejectedTxTopicMessage) GetPeerId() string {
 	return ""
 }
 
+type KafkaDoubleSpendOutput struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TxHash         string                 `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`                 // Transaction of the spent output
+	Vout           uint32                 `protobuf:"varint,2,opt,name=vout,proto3" json:"vout,omitempty"`                    // Index of the spent output
+	SpendingTxHash string                 `protobuf:"bytes,3,opt,name=spendingTxHash,proto3" json:"spendingTxHash,omitempty"` // Transaction that already spent the output
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *KafkaDoubleSpendOutput) Reset() {
+	*x = KafkaDoubleSpendOutput{}
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KafkaDoubleSpendOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KafkaDoubleSpendOutput) ProtoMessage() {}
+
+func (x *KafkaDoubleSpendOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KafkaDoubleSpendOutput.ProtoReflect.Descriptor instead.
+func (*KafkaDoubleSpendOutput) Descriptor() ([]byte, []int) {
+	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *KafkaDoubleSpendOutput) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *KafkaDoubleSpendOutput) GetVout() uint32 {
+	if x != nil {
+		return x.Vout
+	}
+	return 0
+}
+
+func (x *KafkaDoubleSpendOutput) GetSpendingTxHash() string {
+	if x != nil {
+		return x.SpendingTxHash
+	}
+	return ""
+}
+
+type KafkaDoubleSpendTopicMessage struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	TxHash        string                    `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`   // Transaction double spending the outputs
+	Outputs       []*KafkaDoubleSpendOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"` // Outputs spent by another transaction
+	Source        KafkaDoubleSpendSource    `protobuf:"varint,3,opt,name=source,proto3,enum=kafkamessage.KafkaDoubleSpendSource" json:"source,omitempty"`
+	PeerId        string                    `protobuf:"bytes,4,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`                 // Peer the transaction was received from, empty when unknown
+	BlockHeight   uint32                    `protobuf:"varint,5,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"` // Block height the transaction was validated at
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KafkaDoubleSpendTopicMessage) Reset() {
+	*x = KafkaDoubleSpendTopicMessage{}
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KafkaDoubleSpendTopicMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KafkaDoubleSpendTopicMessage) ProtoMessage() {}
+
+func (x *KafkaDoubleSpendTopicMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KafkaDoubleSpendTopicMessage.ProtoReflect.Descriptor instead.
+func (*KafkaDoubleSpendTopicMessage) Descriptor() ([]byte, []int) {
+	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *KafkaDoubleSpendTopicMessage) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *KafkaDoubleSpendTopicMessage) GetOutputs() []*KafkaDoubleSpendOutput {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *KafkaDoubleSpendTopicMessage) GetSource() KafkaDoubleSpendSource {
+	if x != nil {
+		return x.Source
+	}
+	return KafkaDoubleSpendSource_MEMPOOL
+}
+
+func (x *KafkaDoubleSpendTopicMessage) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *KafkaDoubleSpendTopicMessage) GetBlockHeight() uint32 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
 type KafkaTxMetaTopicMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TxHash        string                 `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
@@ -550,7 +740,7 @@ type KafkaTxMetaTopicMessage struct {
 
 func (x *KafkaTxMetaTopicMessage) Reset() {
 	*x = KafkaTxMetaTopicMessage{}
-	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[7]
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -562,7 +752,7 @@ func (x *KafkaTxMetaTopicMessage) String() string {
 func (*KafkaTxMetaTopicMessage) ProtoMessage() {}
 
 func (x *KafkaTxMetaTopicMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[7]
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -575,7 +765,7 @@ func (x *KafkaTxMetaTopicMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KafkaTxMetaTopicMessage.ProtoReflect.Descriptor instead.
 func (*KafkaTxMetaTopicMessage) Descriptor() ([]byte, []int) {
-	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{7}
+	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *KafkaTxMetaTopicMessage) GetTxHash() string {
@@ -609,7 +799,7 @@ type KafkaInvTopicMessage struct {
 
 func (x *KafkaInvTopicMessage) Reset() {
 	*x = KafkaInvTopicMessage{}
-	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[8]
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -621,7 +811,7 @@ func (x *KafkaInvTopicMessage) String() string {
 func (*KafkaInvTopicMessage) ProtoMessage() {}
 
 func (x *KafkaInvTopicMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[8]
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -634,7 +824,7 @@ func (x *KafkaInvTopicMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KafkaInvTopicMessage.ProtoReflect.Descriptor instead.
 func (*KafkaInvTopicMessage) Descriptor() ([]byte, []int) {
-	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{8}
+	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *KafkaInvTopicMessage) GetPeerAddress() string {
@@ -661,7 +851,7 @@ type Inv struct {
 
 func (x *Inv) Reset() {
 	*x = Inv{}
-	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[9]
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -673,7 +863,7 @@ func (x *Inv) String() string {
 func (*Inv) ProtoMessage() {}
 
 func (x *Inv) ProtoReflect() protoreflect.Message {
-	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[9]
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -686,7 +876,7 @@ func (x *Inv) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Inv.ProtoReflect.Descriptor instead.
 func (*Inv) Descriptor() ([]byte, []int) {
-	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{9}
+	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Inv) GetType() InvType {
@@ -717,7 +907,7 @@ type KafkaBlocksFinalTopicMessage struct {
 
 func (x *KafkaBlocksFinalTopicMessage) Reset() {
 	*x = KafkaBlocksFinalTopicMessage{}
-	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[10]
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -729,7 +919,7 @@ func (x *KafkaBlocksFinalTopicMessage) String() string {
 func (*KafkaBlocksFinalTopicMessage) ProtoMessage() {}
 
 func (x *KafkaBlocksFinalTopicMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[10]
+	mi := &file_util_kafka_kafka_message_kafka_messages_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -742,7 +932,7 @@ func (x *KafkaBlocksFinalTopicMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KafkaBlocksFinalTopicMessage.ProtoReflect.Descriptor instead.
 func (*KafkaBlocksFinalTopicMessage) Descriptor() ([]byte, []int) {
-	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{10}
+	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *KafkaBlocksFinalTopicMessage) GetHeader() []byte {
@@ -791,11 +981,12 @@ var File_util_kafka_kafka_message_kafka_messages_proto protoreflect.FileDescript
 
 const file_util_kafka_kafka_message_kafka_messages_proto_rawDesc = "" +
 	"\n" +
-	"-util/kafka/kafka_message/kafka_messages.proto\x12\fkafkamessage\"W\n" +
+	"-util/kafka/kafka_message/kafka_messages.proto\x12\fkafkamessage\"r\n" +
 	"\x16KafkaBlockTopicMessage\x12\x12\n" +
 	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x10\n" +
 	"\x03URL\x18\x02 \x01(\tR\x03URL\x12\x17\n" +
-	"\apeer_id\x18\x03 \x01(\tR\x06peerId\"U\n" +
+	"\apeer_id\x18\x03 \x01(\tR\x06peerId\x12\x19\n" +
+	"\btrace_id\x18\x04 \x01(\tR\atraceId\"U\n" +
 	"\x1dKafkaInvalidBlockTopicMessage\x12\x1c\n" +
 	"\tblockHash\x18\x01 \x01(\tR\tblockHash\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\"u\n" +
@@ -819,7 +1010,17 @@ const file_util_kafka_kafka_message_kafka_messages_proto_rawDesc = "" +
 	"\x1bKafkaRejectedTxTopicMessage\x12\x16\n" +
 	"\x06txHash\x18\x01 \x01(\tR\x06txHash\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x17\n" +
-	"\apeer_id\x18\x03 \x01(\tR\x06peerId\"\x88\x01\n" +
+	"\apeer_id\x18\x03 \x01(\tR\x06peerId\"l\n" +
+	"\x16KafkaDoubleSpendOutput\x12\x16\n" +
+	"\x06txHash\x18\x01 \x01(\tR\x06txHash\x12\x12\n" +
+	"\x04vout\x18\x02 \x01(\rR\x04vout\x12&\n" +
+	"\x0espendingTxHash\x18\x03 \x01(\tR\x0espendingTxHash\"\xf0\x01\n" +
+	"\x1cKafkaDoubleSpendTopicMessage\x12\x16\n" +
+	"\x06txHash\x18\x01 \x01(\tR\x06txHash\x12>\n" +
+	"\aoutputs\x18\x02 \x03(\v2$.kafkamessage.KafkaDoubleSpendOutputR\aoutputs\x12<\n" +
+	"\x06source\x18\x03 \x01(\x0e2$.kafkamessage.KafkaDoubleSpendSourceR\x06source\x12\x17\n" +
+	"\apeer_id\x18\x04 \x01(\tR\x06peerId\x12!\n" +
+	"\fblock_height\x18\x05 \x01(\rR\vblockHeight\"\x88\x01\n" +
 	"\x17KafkaTxMetaTopicMessage\x12\x16\n" +
 	"\x06txHash\x18\x01 \x01(\tR\x06txHash\x12;\n" +
 	"\x06action\x18\x02 \x01(\x0e2#.kafkamessage.KafkaTxMetaActionTypeR\x06action\x12\x18\n" +
@@ -837,7 +1038,10 @@ const file_util_kafka_kafka_message_kafka_messages_proto_rawDesc = "" +
 	"\x0esubtree_hashes\x18\x04 \x03(\fR\rsubtreeHashes\x12\x1f\n" +
 	"\vcoinbase_tx\x18\x05 \x01(\fR\n" +
 	"coinbaseTx\x12\x16\n" +
-	"\x06height\x18\x06 \x01(\rR\x06height*,\n" +
+	"\x06height\x18\x06 \x01(\rR\x06height*0\n" +
+	"\x16KafkaDoubleSpendSource\x12\v\n" +
+	"\aMEMPOOL\x10\x00\x12\t\n" +
+	"\x05BLOCK\x10\x01*,\n" +
 	"\x15KafkaTxMetaActionType\x12\a\n" +
 	"\x03ADD\x10\x00\x12\n" +
 	"\n" +
@@ -860,33 +1064,38 @@ func file_util_kafka_kafka_message_kafka_messages_proto_rawDescGZIP() []byte {
 	return file_util_kafka_kafka_message_kafka_messages_proto_rawDescData
 }
 
-var file_util_kafka_kafka_message_kafka_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_util_kafka_kafka_message_kafka_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_util_kafka_kafka_message_kafka_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_util_kafka_kafka_message_kafka_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_util_kafka_kafka_message_kafka_messages_proto_goTypes = []any{
-	(KafkaTxMetaActionType)(0),              // 0: kafkamessage.KafkaTxMetaActionType
-	(InvType)(0),                            // 1: kafkamessage.InvType
-	(*KafkaBlockTopicMessage)(nil),          // 2: kafkamessage.KafkaBlockTopicMessage
-	(*KafkaInvalidBlockTopicMessage)(nil),   // 3: kafkamessage.KafkaInvalidBlockTopicMessage
-	(*KafkaInvalidSubtreeTopicMessage)(nil), // 4: kafkamessage.KafkaInvalidSubtreeTopicMessage
-	(*KafkaSubtreeTopicMessage)(nil),        // 5: kafkamessage.KafkaSubtreeTopicMessage
-	(*KafkaTxValidationTopicMessage)(nil),   // 6: kafkamessage.KafkaTxValidationTopicMessage
-	(*KafkaTxValidationOptions)(nil),        // 7: kafkamessage.KafkaTxValidationOptions
-	(*KafkaRejectedTxTopicMessage)(nil),     // 8: kafkamessage.KafkaRejectedTxTopicMessage
-	(*KafkaTxMetaTopicMessage)(nil),         // 9: kafkamessage.KafkaTxMetaTopicMessage
-	(*KafkaInvTopicMessage)(nil),            // 10: kafkamessage.KafkaInvTopicMessage
-	(*Inv)(nil),                             // 11: kafkamessage.Inv
-	(*KafkaBlocksFinalTopicMessage)(nil),    // 12: kafkamessage.KafkaBlocksFinalTopicMessage
+	(KafkaDoubleSpendSource)(0),             // 0: kafkamessage.KafkaDoubleSpendSource
+	(KafkaTxMetaActionType)(0),              // 1: kafkamessage.KafkaTxMetaActionType
+	(InvType)(0),                            // 2: kafkamessage.InvType
+	(*KafkaBlockTopicMessage)(nil),          // 3: kafkamessage.KafkaBlockTopicMessage
+	(*KafkaInvalidBlockTopicMessage)(nil),   // 4: kafkamessage.KafkaInvalidBlockTopicMessage
+	(*KafkaInvalidSubtreeTopicMessage)(nil), // 5: kafkamessage.KafkaInvalidSubtreeTopicMessage
+	(*KafkaSubtreeTopicMessage)(nil),        // 6: kafkamessage.KafkaSubtreeTopicMessage
+	(*KafkaTxValidationTopicMessage)(nil),   // 7: kafkamessage.KafkaTxValidationTopicMessage
+	(*KafkaTxValidationOptions)(nil),        // 8: kafkamessage.KafkaTxValidationOptions
+	(*KafkaRejectedTxTopicMessage)(nil),     // 9: kafkamessage.KafkaRejectedTxTopicMessage
+	(*KafkaDoubleSpendOutput)(nil),          // 10: kafkamessage.KafkaDoubleSpendOutput
+	(*KafkaDoubleSpendTopicMessage)(nil),    // 11: kafkamessage.KafkaDoubleSpendTopicMessage
+	(*KafkaTxMetaTopicMessage)(nil),         // 12: kafkamessage.KafkaTxMetaTopicMessage
+	(*KafkaInvTopicMessage)(nil),            // 13: kafkamessage.KafkaInvTopicMessage
+	(*Inv)(nil),                             // 14: kafkamessage.Inv
+	(*KafkaBlocksFinalTopicMessage)(nil),    // 15: kafkamessage.KafkaBlocksFinalTopicMessage
 }
 var file_util_kafka_kafka_message_kafka_messages_proto_depIdxs = []int32{
-	7,  // 0: kafkamessage.KafkaTxValidationTopicMessage.options:type_name -> kafkamessage.KafkaTxValidationOptions
-	0,  // 1: kafkamessage.KafkaTxMetaTopicMessage.action:type_name -> kafkamessage.KafkaTxMetaActionType
-	11, // 2: kafkamessage.KafkaInvTopicMessage.inv:type_name -> kafkamessage.Inv
-	1,  // 3: kafkamessage.Inv.type:type_name -> kafkamessage.InvType
-	4,  // [4:4] is the sub-list for method output_type
-	4,  // [4:4] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	8,  // 0: kafkamessage.KafkaTxValidationTopicMessage.options:type_name -> kafkamessage.KafkaTxValidationOptions
+	10, // 1: kafkamessage.KafkaDoubleSpendTopicMessage.outputs:type_name -> kafkamessage.KafkaDoubleSpendOutput
+	0,  // 2: kafkamessage.KafkaDoubleSpendTopicMessage.source:type_name -> kafkamessage.KafkaDoubleSpendSource
+	1,  // 3: kafkamessage.KafkaTxMetaTopicMessage.action:type_name -> kafkamessage.KafkaTxMetaActionType
+	14, // 4: kafkamessage.KafkaInvTopicMessage.inv:type_name -> kafkamessage.Inv
+	2,  // 5: kafkamessage.Inv.type:type_name -> kafkamessage.InvType
+	6,  // [6:6] is the sub-list for method output_type
+	6,  // [6:6] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_util_kafka_kafka_message_kafka_messages_proto_init() }
@@ -899,8 +1108,8 @@ func file_util_kafka_kafka_message_kafka_messages_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_util_kafka_kafka_message_kafka_messages_proto_rawDesc), len(file_util_kafka_kafka_message_kafka_messages_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   11,
+			NumEnums:      3,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -196,9 +196,14 @@ func ConnectProducer(brokersURL []string, topic string, partitions int32, kafkaS
 	config.Producer.Return.Successes = true
 	config.Producer.Return.Errors = true
 	config.Producer.RequiredAcks = sarama.WaitForAll
-	config.Producer.Retry.Max = 5
 	config.Producer.Partitioner = sarama.NewManualPartitioner
 
+	configureProducerRetries(config, defaultProducerRetryMax, defaultProducerRetryBackoff, defaultProducerRetryMaxBackoff)
+
+	if kafkaSettings != nil && kafkaSettings.ProducerIdempotent {
+		configureIdempotentProducer(config)
+	}
+
 	// Apply authentication settings if kafkaSettings provided and TLS is enabled
 	if kafkaSettings != nil && kafkaSettings.EnableTLS {
 		if err := configureKafkaAuthFromFields(config, kafkaSettings.EnableTLS, kafkaSettings.TLSSkipVerify,
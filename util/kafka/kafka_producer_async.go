@@ -69,6 +69,20 @@ type KafkaProducerConfig struct {
 
 	// Debug logging
 	EnableDebugLogging bool // Enable verbose Sarama (Kafka client) debug logging
+
+	// Delivery guarantees
+	Idempotent          bool          // Enable the idempotent producer (acks=all, one in-flight request per broker)
+	SpoolDir            string        // Directory to spool undeliverable messages to for replay, empty disables spooling
+	SpoolMaxMessages    int           // Maximum number of spooled messages, 0 means unlimited
+	SpoolReplayInterval time.Duration // Interval between attempts to replay spooled messages
+
+	// Retries, circuit breaking and dead-lettering
+	RetryMax                int           // Maximum delivery retries per message, 0 uses the default
+	RetryBackoff            time.Duration // Backoff before the first retry, doubled on every retry, 0 uses the default
+	RetryMaxBackoff         time.Duration // Upper bound of the retry backoff, 0 uses the default
+	CircuitFailureThreshold int           // Consecutive delivery failures that open the circuit, 0 disables circuit breaking
+	CircuitCooldown         time.Duration // Time the circuit stays open before deliveries are attempted again
+	DeadLetterTopic         string        // Topic for messages that cannot be delivered, empty disables dead-lettering
 }
 
 // MessageStatus represents the status of a produced message.
@@ -86,12 +100,14 @@ type Message struct {
 
 // KafkaAsyncProducer implements asynchronous Kafka producer functionality.
 type KafkaAsyncProducer struct {
-	Config         KafkaProducerConfig  // Producer configuration
-	Producer       sarama.AsyncProducer // Underlying Sarama async producer
-	publishChannel chan *Message        // Channel for publishing messages
-	closed         atomic.Bool          // Flag indicating if producer is closed
-	channelMu      sync.RWMutex         // Mutex to protect publishChannel access
-	publishWg      sync.WaitGroup       // WaitGroup to track publish goroutine
+	Config         KafkaProducerConfig     // Producer configuration
+	Producer       sarama.AsyncProducer    // Underlying Sarama async producer
+	publishChannel chan *Message           // Channel for publishing messages
+	closed         atomic.Bool             // Flag indicating if producer is closed
+	channelMu      sync.RWMutex            // Mutex to protect publishChannel access
+	publishWg      sync.WaitGroup          // WaitGroup to track publish goroutine
+	spool          *diskSpool              // Disk spool for undeliverable messages, nil when spooling is disabled
+	breaker        *producerCircuitBreaker // Circuit breaker, nil when circuit breaking is disabled
 }
 
 // NewKafkaAsyncProducerFromURL creates a new async producer from a URL configuration.
@@ -102,7 +118,7 @@ type KafkaAsyncProducer struct {
 //   - ctx: Context for producer operations
 //   - logger: Logger instance
 //   - url: URL containing Kafka configuration
-//   - kafkaSettings: Kafka settings for TLS and debug logging (can be nil for defaults)
+//   - kafkaSettings: Kafka settings for TLS, debug logging and delivery guarantees (can be nil for defaults)
 //
 // Returns:
 //   - *KafkaAsyncProducer: Configured async producer
@@ -121,6 +137,13 @@ func NewKafkaAsyncProducerFromURL(ctx context.Context, logger ulogger.Logger, ur
 	// Extract TLS and debug logging settings from kafkaSettings (if provided)
 	var enableTLS, tlsSkipVerify, enableDebugLogging bool
 	var tlsCAFile, tlsCertFile, tlsKeyFile string
+
+	// Delivery guarantees, the idempotent and spool URL parameters enable them per topic
+	var idempotent bool
+	var spoolDir string
+	var spoolMaxMessages int
+	spoolReplayInterval := 10 * time.Second
+
 	if kafkaSettings != nil {
 		enableTLS = kafkaSettings.EnableTLS
 		tlsSkipVerify = kafkaSettings.TLSSkipVerify
@@ -128,6 +151,16 @@ func NewKafkaAsyncProducerFromURL(ctx context.Context, logger ulogger.Logger, ur
 		tlsCertFile = kafkaSettings.TLSCertFile
 		tlsKeyFile = kafkaSettings.TLSKeyFile
 		enableDebugLogging = kafkaSettings.EnableDebugLogging
+		idempotent = kafkaSettings.ProducerIdempotent
+		spoolMaxMessages = kafkaSettings.SpoolMaxMessages
+
+		if kafkaSettings.SpoolReplayInterval > 0 {
+			spoolReplayInterval = kafkaSettings.SpoolReplayInterval
+		}
+
+		if util.GetQueryParamBool(url, "spool", false) {
+			spoolDir = kafkaSettings.SpoolDir
+		}
 	}
 
 	producerConfig := KafkaProducerConfig{
@@ -149,6 +182,18 @@ func NewKafkaAsyncProducerFromURL(ctx context.Context, logger ulogger.Logger, ur
 		TLSCertFile:        tlsCertFile,
 		TLSKeyFile:         tlsKeyFile,
 		EnableDebugLogging: enableDebugLogging,
+		// Delivery guarantees
+		Idempotent:          util.GetQueryParamBool(url, "idempotent", idempotent),
+		SpoolDir:            spoolDir,
+		SpoolMaxMessages:    spoolMaxMessages,
+		SpoolReplayInterval: spoolReplayInterval,
+		// Retries, circuit breaking and dead-lettering
+		RetryMax:                util.GetQueryParamInt(url, "retry_max", defaultProducerRetryMax),
+		RetryBackoff:            util.GetQueryParamDuration(url, "retry_backoff", defaultProducerRetryBackoff),
+		RetryMaxBackoff:         util.GetQueryParamDuration(url, "retry_max_backoff", defaultProducerRetryMaxBackoff),
+		CircuitFailureThreshold: util.GetQueryParamInt(url, "circuit_failures", 0),
+		CircuitCooldown:         util.GetQueryParamDuration(url, "circuit_cooldown", defaultProducerCircuitCooldown),
+		DeadLetterTopic:         util.GetQueryParam(url, "dlq", ""),
 	}
 
 	producer, err := retry.Retry(ctx, logger, func() (*KafkaAsyncProducer, error) {
@@ -174,6 +219,13 @@ func NewKafkaAsyncProducerFromURL(ctx context.Context, logger ulogger.Logger, ur
 func NewKafkaAsyncProducer(logger ulogger.Logger, cfg KafkaProducerConfig) (*KafkaAsyncProducer, error) {
 	logger.Debugf("Starting async kafka producer for %v", cfg.URL)
 
+	InitPrometheusMetrics()
+
+	spool, err := newProducerSpool(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	if cfg.URL.Scheme == memoryScheme {
 		// --- Use the in-memory implementation ---
 		broker := inmemorykafka.GetSharedBroker() // Use alias 'imk'
@@ -187,6 +239,8 @@ func NewKafkaAsyncProducer(logger ulogger.Logger, cfg KafkaProducerConfig) (*Kaf
 		client := &KafkaAsyncProducer{
 			Producer: producer,
 			Config:   cfg,
+			spool:    spool,
+			breaker:  newProducerCircuitBreaker(cfg.CircuitFailureThreshold, cfg.CircuitCooldown),
 		}
 
 		return client, nil
@@ -198,7 +252,29 @@ func NewKafkaAsyncProducer(logger ulogger.Logger, cfg KafkaProducerConfig) (*Kaf
 	config.Producer.Flush.Bytes = cfg.FlushBytes
 	config.Producer.Flush.Messages = cfg.FlushMessages
 	config.Producer.Flush.Frequency = cfg.FlushFrequency
-	// config.Producer.Return.Successes = true
+	// successes are needed for the per-topic delivery metrics and to acknowledge replayed spool messages
+	config.Producer.Return.Successes = true
+
+	retryMax := cfg.RetryMax
+	if retryMax <= 0 {
+		retryMax = defaultProducerRetryMax
+	}
+
+	retryBackoff := cfg.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultProducerRetryBackoff
+	}
+
+	retryMaxBackoff := cfg.RetryMaxBackoff
+	if retryMaxBackoff <= 0 {
+		retryMaxBackoff = defaultProducerRetryMaxBackoff
+	}
+
+	configureProducerRetries(config, retryMax, retryBackoff, retryMaxBackoff)
+
+	if cfg.Idempotent {
+		configureIdempotentProducer(config)
+	}
 
 	// Enable Sarama debug logging if configured
 	if cfg.EnableDebugLogging {
@@ -236,6 +312,16 @@ func NewKafkaAsyncProducer(logger ulogger.Logger, cfg KafkaProducerConfig) (*Kaf
 		return nil, err
 	}
 
+	if cfg.DeadLetterTopic != "" {
+		deadLetterCfg := cfg
+		deadLetterCfg.Topic = cfg.DeadLetterTopic
+		deadLetterCfg.Partitions = 1
+
+		if err := createTopic(clusterAdmin, deadLetterCfg); err != nil {
+			return nil, err
+		}
+	}
+
 	producer, err := sarama.NewAsyncProducer(cfg.BrokersURL, config)
 	if err != nil {
 		return nil, errors.NewServiceError("Failed to create Kafka async producer for %s", cfg.Topic, err)
@@ -244,11 +330,33 @@ func NewKafkaAsyncProducer(logger ulogger.Logger, cfg KafkaProducerConfig) (*Kaf
 	client := &KafkaAsyncProducer{
 		Producer: producer,
 		Config:   cfg,
+		spool:    spool,
+		breaker:  newProducerCircuitBreaker(cfg.CircuitFailureThreshold, cfg.CircuitCooldown),
 	}
 
 	return client, nil
 }
 
+// newProducerSpool creates the disk spool of the producer, or returns nil when spooling is disabled
+func newProducerSpool(cfg KafkaProducerConfig) (*diskSpool, error) {
+	if cfg.SpoolDir == "" {
+		return nil, nil
+	}
+
+	spool, err := newDiskSpool(cfg.SpoolDir, cfg.Topic, cfg.SpoolMaxMessages)
+	if err != nil {
+		return nil, errors.NewConfigurationError("failed to create kafka spool for topic %s", cfg.Topic, err)
+	}
+
+	prometheusKafkaProducerSpoolSize.WithLabelValues(cfg.Topic).Set(float64(spool.Len()))
+
+	if pending := spool.Len(); pending > 0 {
+		cfg.Logger.Infof("[kafka] %d spooled messages pending replay for topic %s", pending, cfg.Topic)
+	}
+
+	return spool, nil
+}
+
 func (c *KafkaAsyncProducer) decodeKeyOrValue(encoder sarama.Encoder) string {
 	if encoder == nil {
 		return ""
@@ -285,24 +393,32 @@ func (c *KafkaAsyncProducer) Start(ctx context.Context, ch chan *Message) {
 
 		go func() {
 			for s := range c.Producer.Successes() {
-				key := c.decodeKeyOrValue(s.Key)
-				value := c.decodeKeyOrValue(s.Value)
-
-				c.Config.Logger.Debugf("Successfully sent message to topic %s, offset: %d, key: %v, value: %v",
-					s.Topic, s.Offset, key, value)
+				c.handleSuccess(s)
 			}
 		}()
 
 		go func() {
 			for err := range c.Producer.Errors() {
-				key := c.decodeKeyOrValue(err.Msg.Key)
-				value := c.decodeKeyOrValue(err.Msg.Value)
-
-				c.Config.Logger.Errorf("Failed to deliver message to topic %s: %v, Key: %v, Value: %v",
-					err.Msg.Topic, err.Err, key, value)
+				c.handleError(err)
 			}
 		}()
 
+		if c.spool != nil {
+			go func() {
+				ticker := time.NewTicker(c.Config.SpoolReplayInterval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-context.Done():
+						return
+					case <-ticker.C:
+						c.replaySpool()
+					}
+				}
+			}()
+		}
+
 		go func() {
 			defer c.publishWg.Done()
 			wg.Done()
@@ -322,9 +438,10 @@ func (c *KafkaAsyncProducer) Start(ctx context.Context, ch chan *Message) {
 				}
 
 				message := &sarama.ProducerMessage{
-					Topic: c.Config.Topic,
-					Key:   key,
-					Value: sarama.ByteEncoder(msgBytes.Value),
+					Topic:    c.Config.Topic,
+					Key:      key,
+					Value:    sarama.ByteEncoder(msgBytes.Value),
+					Metadata: &messageMetadata{published: time.Now()},
 				}
 
 				// Check if closed again right before sending to avoid race condition
@@ -333,16 +450,12 @@ func (c *KafkaAsyncProducer) Start(ctx context.Context, ch chan *Message) {
 					break
 				}
 
-				// Use a function with recover to safely handle sends to potentially closed channel
-				func() {
-					defer func() {
-						if r := recover(); r != nil {
-							// Channel was closed during send, this is expected during shutdown
-							c.Config.Logger.Debugf("[kafka] Recovered from send to closed channel during shutdown")
-						}
-					}()
-					c.Producer.Input() <- message
-				}()
+				if !c.breaker.Allow() {
+					c.handleCircuitOpen(message)
+					continue
+				}
+
+				c.send(message)
 			}
 		}()
 
@@ -368,6 +481,233 @@ func (c *KafkaAsyncProducer) Start(ctx context.Context, ch chan *Message) {
 	wg.Wait() // don't continue until we know we know the go func has started and is ready to accept messages on the PublishChannel
 }
 
+// send hands a message to the underlying producer and reports whether it was accepted.
+func (c *KafkaAsyncProducer) send(message *sarama.ProducerMessage) (sent bool) {
+	// Use a function with recover to safely handle sends to potentially closed channel
+	defer func() {
+		if r := recover(); r != nil {
+			// Channel was closed during send, this is expected during shutdown
+			c.Config.Logger.Debugf("[kafka] Recovered from send to closed channel during shutdown")
+
+			sent = false
+		}
+	}()
+
+	c.Producer.Input() <- message
+
+	return true
+}
+
+// handleSuccess records a message acknowledged by Kafka, removing it from the spool if it was a replay.
+func (c *KafkaAsyncProducer) handleSuccess(msg *sarama.ProducerMessage) {
+	c.Config.Logger.Debugf("Successfully sent message to topic %s, offset: %d, key: %v, value: %v",
+		msg.Topic, msg.Offset, c.decodeKeyOrValue(msg.Key), c.decodeKeyOrValue(msg.Value))
+
+	c.breaker.RecordSuccess()
+	c.updateCircuitMetric()
+
+	prometheusKafkaProducerDelivered.WithLabelValues(msg.Topic).Inc()
+
+	meta, ok := msg.Metadata.(*messageMetadata)
+	if !ok {
+		return
+	}
+
+	prometheusKafkaProducerPublishLatency.WithLabelValues(msg.Topic).Observe(time.Since(meta.published).Seconds())
+
+	if meta.deadLetter {
+		prometheusKafkaProducerDeadLettered.WithLabelValues(c.Config.Topic).Inc()
+	}
+
+	if meta.spoolFile == "" || c.spool == nil {
+		return
+	}
+
+	if err := c.spool.Remove(meta.spoolFile); err != nil {
+		c.Config.Logger.Errorf("[kafka] failed to remove replayed message from spool for topic %s: %v", c.Config.Topic, err)
+	}
+
+	if !meta.deadLetter {
+		prometheusKafkaProducerReplayed.WithLabelValues(c.Config.Topic).Inc()
+	}
+
+	prometheusKafkaProducerSpoolSize.WithLabelValues(c.Config.Topic).Set(float64(c.spool.Len()))
+}
+
+// handleError records a message Kafka failed to accept after all retries. Messages that can never be
+// delivered go to the dead-letter topic. Other messages are spooled to disk when spooling is enabled, so
+// they are replayed once Kafka recovers, or go to the dead-letter topic when one is configured.
+func (c *KafkaAsyncProducer) handleError(producerErr *sarama.ProducerError) {
+	msg := producerErr.Msg
+	meta, _ := msg.Metadata.(*messageMetadata)
+
+	if meta == nil {
+		meta = &messageMetadata{}
+	}
+
+	prometheusKafkaProducerFailed.WithLabelValues(msg.Topic).Inc()
+
+	poison := isPoisonError(producerErr.Err)
+	if !poison && c.breaker.RecordFailure() {
+		c.Config.Logger.Warnf("[kafka] circuit opened for topic %s after repeated delivery failures, pausing deliveries for %s",
+			c.Config.Topic, c.Config.CircuitCooldown)
+	}
+
+	c.updateCircuitMetric()
+
+	switch {
+	case meta.deadLetter:
+		if meta.spoolFile != "" {
+			// the message stays in the spool and is dead-lettered again on the next replay
+			c.spool.Release(meta.spoolFile)
+			return
+		}
+
+		c.dropMessage(msg, producerErr.Err)
+
+	case poison && c.Config.DeadLetterTopic != "":
+		c.deadLetter(msg, producerErr.Err, meta.spoolFile)
+
+	case meta.spoolFile != "":
+		// a replay failed again, the message stays in the spool for the next replay
+		c.spool.Release(meta.spoolFile)
+		c.Config.Logger.Debugf("[kafka] failed to replay spooled message to topic %s: %v", msg.Topic, producerErr.Err)
+
+	case c.spool != nil:
+		c.spoolMessage(msg, producerErr.Err)
+
+	case c.Config.DeadLetterTopic != "":
+		c.deadLetter(msg, producerErr.Err, "")
+
+	default:
+		c.Config.Logger.Errorf("Failed to deliver message to topic %s: %v, Key: %v, Value: %v",
+			msg.Topic, producerErr.Err, c.decodeKeyOrValue(msg.Key), c.decodeKeyOrValue(msg.Value))
+	}
+}
+
+// handleCircuitOpen handles a new message while the circuit is open, spooling it when spooling is enabled
+func (c *KafkaAsyncProducer) handleCircuitOpen(msg *sarama.ProducerMessage) {
+	prometheusKafkaProducerFailed.WithLabelValues(msg.Topic).Inc()
+
+	if c.spool != nil {
+		c.spoolMessage(msg, errors.NewServiceError("circuit open"))
+		return
+	}
+
+	c.dropMessage(msg, errors.NewServiceError("circuit open"))
+}
+
+// spoolMessage writes an undeliverable message to the disk spool for replay
+func (c *KafkaAsyncProducer) spoolMessage(msg *sarama.ProducerMessage, deliveryErr error) {
+	var key, value []byte
+
+	if msg.Key != nil {
+		key, _ = msg.Key.Encode()
+	}
+
+	if msg.Value != nil {
+		value, _ = msg.Value.Encode()
+	}
+
+	if err := c.spool.Add(key, value); err != nil {
+		c.Config.Logger.Errorf("[kafka] failed to spool undeliverable message for topic %s: %v", msg.Topic, err)
+		c.dropMessage(msg, deliveryErr)
+
+		return
+	}
+
+	prometheusKafkaProducerSpooled.WithLabelValues(msg.Topic).Inc()
+	prometheusKafkaProducerSpoolSize.WithLabelValues(msg.Topic).Set(float64(c.spool.Len()))
+
+	c.Config.Logger.Warnf("Failed to deliver message to topic %s, spooled to disk for replay: %v", msg.Topic, deliveryErr)
+}
+
+// deadLetter publishes a message that cannot be delivered to the dead-letter topic
+func (c *KafkaAsyncProducer) deadLetter(msg *sarama.ProducerMessage, deliveryErr error, spoolFile string) {
+	c.Config.Logger.Warnf("Failed to deliver message to topic %s, publishing to dead-letter topic %s: %v",
+		msg.Topic, c.Config.DeadLetterTopic, deliveryErr)
+
+	if !c.send(newDeadLetterMessage(msg, c.Config.DeadLetterTopic, deliveryErr, spoolFile)) {
+		if spoolFile != "" {
+			c.spool.Release(spoolFile)
+			return
+		}
+
+		c.dropMessage(msg, deliveryErr)
+	}
+}
+
+// dropMessage records a message that is lost because it could neither be delivered, spooled nor dead-lettered
+func (c *KafkaAsyncProducer) dropMessage(msg *sarama.ProducerMessage, deliveryErr error) {
+	prometheusKafkaProducerDropped.WithLabelValues(c.Config.Topic).Inc()
+
+	c.Config.Logger.Errorf("Failed to deliver message to topic %s, message is lost: %v, Key: %v, Value: %v",
+		msg.Topic, deliveryErr, c.decodeKeyOrValue(msg.Key), c.decodeKeyOrValue(msg.Value))
+}
+
+// updateCircuitMetric publishes the circuit state of the producer
+func (c *KafkaAsyncProducer) updateCircuitMetric() {
+	if c.breaker == nil {
+		return
+	}
+
+	open := 0.0
+	if c.breaker.IsOpen() {
+		open = 1
+	}
+
+	prometheusKafkaProducerCircuitOpen.WithLabelValues(c.Config.Topic).Set(open)
+}
+
+// replaySpool resends a batch of spooled messages. Messages are removed from the spool once Kafka
+// acknowledges them, failed replays are retried on the next replay interval.
+func (c *KafkaAsyncProducer) replaySpool() {
+	if !c.breaker.Allow() {
+		return
+	}
+
+	files, err := c.spool.Next(spoolReplayBatchSize)
+	if err != nil {
+		c.Config.Logger.Errorf("[kafka] failed to list spooled messages for topic %s: %v", c.Config.Topic, err)
+		return
+	}
+
+	for _, file := range files {
+		if c.closed.Load() {
+			c.spool.Release(file)
+			continue
+		}
+
+		key, value, err := c.spool.Read(file)
+		if err != nil {
+			c.Config.Logger.Errorf("[kafka] failed to read spooled message for topic %s: %v", c.Config.Topic, err)
+
+			if errors.Is(err, errors.ErrProcessing) {
+				// corrupt messages can never be replayed
+				_ = c.spool.Remove(file)
+			} else {
+				c.spool.Release(file)
+			}
+
+			continue
+		}
+
+		message := &sarama.ProducerMessage{
+			Topic:    c.Config.Topic,
+			Value:    sarama.ByteEncoder(value),
+			Metadata: &messageMetadata{published: time.Now(), spoolFile: file},
+		}
+
+		if key != nil {
+			message.Key = sarama.ByteEncoder(key)
+		}
+
+		if !c.send(message) {
+			c.spool.Release(file)
+		}
+	}
+}
+
 // Stop gracefully shuts down the async producer.
 func (c *KafkaAsyncProducer) Stop() error {
 	if c == nil {
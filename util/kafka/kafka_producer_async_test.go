@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/bsv-blockchain/teranode/settings"
 	"github.com/bsv-blockchain/teranode/ulogger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -190,6 +192,104 @@ func TestNewKafkaAsyncProducerFromURLInvalidConversion(t *testing.T) {
 	assert.Nil(t, producer)
 }
 
+func TestNewKafkaAsyncProducerFromURLDeliveryGuarantees(t *testing.T) {
+	logger := &mockAsyncLogger{}
+	ctx := context.Background()
+	kafkaSettings := &settings.KafkaSettings{
+		SpoolDir:            t.TempDir(),
+		SpoolMaxMessages:    100,
+		SpoolReplayInterval: time.Second,
+	}
+
+	t.Run("enabled by URL parameters", func(t *testing.T) {
+		kafkaURL, err := url.Parse("memory://localhost/blocks?idempotent=true&spool=true")
+		require.NoError(t, err)
+
+		producer, err := NewKafkaAsyncProducerFromURL(ctx, logger, kafkaURL, kafkaSettings)
+		require.NoError(t, err)
+
+		assert.True(t, producer.Config.Idempotent)
+		assert.Equal(t, kafkaSettings.SpoolDir, producer.Config.SpoolDir)
+		assert.Equal(t, 100, producer.Config.SpoolMaxMessages)
+		assert.Equal(t, time.Second, producer.Config.SpoolReplayInterval)
+		assert.NotNil(t, producer.spool)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		kafkaURL, err := url.Parse("memory://localhost/txmeta")
+		require.NoError(t, err)
+
+		producer, err := NewKafkaAsyncProducerFromURL(ctx, logger, kafkaURL, kafkaSettings)
+		require.NoError(t, err)
+
+		assert.False(t, producer.Config.Idempotent)
+		assert.Empty(t, producer.Config.SpoolDir)
+		assert.Nil(t, producer.spool)
+	})
+
+	t.Run("idempotent for all producers", func(t *testing.T) {
+		kafkaURL, err := url.Parse("memory://localhost/txmeta")
+		require.NoError(t, err)
+
+		producer, err := NewKafkaAsyncProducerFromURL(ctx, logger, kafkaURL, &settings.KafkaSettings{ProducerIdempotent: true})
+		require.NoError(t, err)
+
+		assert.True(t, producer.Config.Idempotent)
+	})
+}
+
+func TestKafkaAsyncProducerSpoolAndReplay(t *testing.T) {
+	InitPrometheusMetrics()
+
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+
+	mockProducer := mocks.NewAsyncProducer(t, config)
+	mockProducer.ExpectInputAndFail(sarama.ErrNotEnoughReplicas)
+	mockProducer.ExpectInputAndFail(sarama.ErrNotEnoughReplicas)
+	mockProducer.ExpectInputAndSucceed()
+
+	spool, err := newDiskSpool(t.TempDir(), "blocks", 0)
+	require.NoError(t, err)
+
+	producer := &KafkaAsyncProducer{
+		Producer: mockProducer,
+		Config:   KafkaProducerConfig{Logger: ulogger.TestLogger{}, Topic: "blocks"},
+		spool:    spool,
+	}
+
+	// the first delivery fails and the message is spooled
+	require.True(t, producer.send(&sarama.ProducerMessage{
+		Topic: "blocks",
+		Key:   sarama.ByteEncoder("key"),
+		Value: sarama.ByteEncoder("block"),
+	}))
+	producer.handleError(<-mockProducer.Errors())
+	assert.Equal(t, 1, spool.Len())
+
+	// a failed replay keeps the message in the spool
+	producer.replaySpool()
+	producer.handleError(<-mockProducer.Errors())
+	assert.Equal(t, 1, spool.Len())
+
+	// a successful replay removes it
+	producer.replaySpool()
+
+	msg := <-mockProducer.Successes()
+	value, err := msg.Value.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("block"), value)
+
+	key, err := msg.Key.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key"), key)
+
+	producer.handleSuccess(msg)
+	assert.Equal(t, 0, spool.Len())
+
+	require.NoError(t, mockProducer.Close())
+}
+
 func TestNewKafkaAsyncProducerMemoryScheme(t *testing.T) {
 	logger := &mockAsyncLogger{}
 	cfg := KafkaProducerConfig{
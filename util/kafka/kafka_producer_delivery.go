@@ -0,0 +1,200 @@
+// Package kafka provides Kafka consumer and producer implementations for message handling.
+package kafka
+
+import (
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+// Default delivery settings shared by the Kafka producers
+const (
+	defaultProducerRetryMax        = 5
+	defaultProducerRetryBackoff    = 100 * time.Millisecond
+	defaultProducerRetryMaxBackoff = 5 * time.Second
+	defaultProducerCircuitCooldown = 30 * time.Second
+)
+
+// Headers added to messages published to a dead-letter topic
+const (
+	deadLetterHeaderTopic    = "teranode-original-topic"
+	deadLetterHeaderError    = "teranode-error"
+	deadLetterHeaderFailedAt = "teranode-failed-at"
+)
+
+// messageMetadata travels with every produced message through the Sarama producer and is handed back
+// on the success and error channels.
+type messageMetadata struct {
+	published  time.Time // When the message was handed to the producer, used for the publish latency
+	spoolFile  string    // Spool file of a replayed message, empty for new messages
+	deadLetter bool      // Whether the message is being published to the dead-letter topic
+}
+
+// configureProducerRetries bounds the retries of the Sarama producer, backing off exponentially between
+// attempts up to maxBackoff.
+func configureProducerRetries(config *sarama.Config, retryMax int, backoff time.Duration, maxBackoff time.Duration) {
+	config.Producer.Retry.Max = retryMax
+	config.Producer.Retry.BackoffFunc = exponentialBackoff(backoff, maxBackoff)
+}
+
+// configureIdempotentProducer enables the idempotent producer. It requires acks from all in-sync replicas
+// and a single in-flight request per broker, so retries can neither duplicate nor reorder messages.
+func configureIdempotentProducer(config *sarama.Config) {
+	config.Producer.Idempotent = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Net.MaxOpenRequests = 1
+}
+
+// exponentialBackoff returns a Sarama backoff function doubling the backoff on every retry, capped at maxBackoff
+func exponentialBackoff(backoff time.Duration, maxBackoff time.Duration) func(retries, maxRetries int) time.Duration {
+	return func(retries, _ int) time.Duration {
+		d := backoff
+
+		for i := 0; i < retries && d < maxBackoff; i++ {
+			d *= 2
+		}
+
+		if d > maxBackoff {
+			d = maxBackoff
+		}
+
+		return d
+	}
+}
+
+// isPoisonError reports whether a delivery error is caused by the message itself, so retrying or replaying
+// it can never succeed. These messages go straight to the dead-letter topic.
+func isPoisonError(err error) bool {
+	var kErr sarama.KError
+	if !errors.As(err, &kErr) {
+		return false
+	}
+
+	switch kErr {
+	case sarama.ErrInvalidMessage, sarama.ErrInvalidMessageSize, sarama.ErrMessageSizeTooLarge,
+		sarama.ErrInvalidTimestamp, sarama.ErrInvalidRecord, sarama.ErrPolicyViolation:
+		return true
+	default:
+		return false
+	}
+}
+
+// newDeadLetterMessage wraps a message that could not be delivered for publishing to the dead-letter topic,
+// recording the original topic and the delivery error in the message headers.
+func newDeadLetterMessage(msg *sarama.ProducerMessage, deadLetterTopic string, deliveryErr error, spoolFile string) *sarama.ProducerMessage {
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers)+3)
+	headers = append(headers, msg.Headers...)
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderTopic), Value: []byte(msg.Topic)},
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderError), Value: []byte(deliveryErr.Error())},
+		sarama.RecordHeader{Key: []byte(deadLetterHeaderFailedAt), Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	)
+
+	return &sarama.ProducerMessage{
+		Topic:   deadLetterTopic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+		Metadata: &messageMetadata{
+			published:  time.Now(),
+			spoolFile:  spoolFile,
+			deadLetter: true,
+		},
+	}
+}
+
+// producerCircuitBreaker stops a producer from sending to Kafka after a number of consecutive delivery
+// failures. After the cooldown messages are let through again, the first delivery result decides whether
+// the circuit closes or opens for another cooldown. A nil breaker is always closed.
+type producerCircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	open      bool
+	openedAt  time.Time
+	now       func() time.Time
+}
+
+// newProducerCircuitBreaker creates a circuit breaker, or returns nil when threshold is 0 and circuit
+// breaking is disabled
+func newProducerCircuitBreaker(threshold int, cooldown time.Duration) *producerCircuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+
+	return &producerCircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a message may be sent to Kafka
+func (b *producerCircuitBreaker) Allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return !b.open || b.now().Sub(b.openedAt) >= b.cooldown
+}
+
+// RecordSuccess closes the circuit
+func (b *producerCircuitBreaker) RecordSuccess() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.open = false
+}
+
+// RecordFailure counts a delivery failure and reports whether it opened the circuit
+func (b *producerCircuitBreaker) RecordFailure() bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+
+	if b.open {
+		// a failure after the cooldown opens the circuit for another cooldown
+		if b.now().Sub(b.openedAt) >= b.cooldown {
+			b.openedAt = b.now()
+		}
+
+		return false
+	}
+
+	if b.failures < b.threshold {
+		return false
+	}
+
+	b.open = true
+	b.openedAt = b.now()
+
+	return true
+}
+
+// IsOpen reports whether the circuit is open
+func (b *producerCircuitBreaker) IsOpen() bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.open
+}
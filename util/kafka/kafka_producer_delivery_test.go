@@ -0,0 +1,167 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := exponentialBackoff(100*time.Millisecond, time.Second)
+
+	assert.Equal(t, 100*time.Millisecond, backoff(0, 5))
+	assert.Equal(t, 200*time.Millisecond, backoff(1, 5))
+	assert.Equal(t, 800*time.Millisecond, backoff(3, 5))
+	assert.Equal(t, time.Second, backoff(4, 5))
+	assert.Equal(t, time.Second, backoff(100, 5))
+}
+
+func TestIsPoisonError(t *testing.T) {
+	assert.True(t, isPoisonError(sarama.ErrMessageSizeTooLarge))
+	assert.False(t, isPoisonError(sarama.ErrNotEnoughReplicas))
+	assert.False(t, isPoisonError(sarama.ErrOutOfBrokers))
+	assert.True(t, isPoisonError(sarama.ErrInvalidRecord))
+}
+
+func TestProducerCircuitBreaker(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		breaker := newProducerCircuitBreaker(0, time.Second)
+		assert.Nil(t, breaker)
+		assert.False(t, breaker.RecordFailure())
+		assert.True(t, breaker.Allow())
+	})
+
+	t.Run("opens, cools down and closes", func(t *testing.T) {
+		now := time.Now()
+
+		breaker := newProducerCircuitBreaker(2, time.Minute)
+		breaker.now = func() time.Time { return now }
+
+		assert.False(t, breaker.RecordFailure())
+		assert.True(t, breaker.Allow())
+
+		assert.True(t, breaker.RecordFailure())
+		assert.True(t, breaker.IsOpen())
+		assert.False(t, breaker.Allow())
+
+		// after the cooldown deliveries are attempted again, a failure reopens the circuit
+		now = now.Add(time.Minute)
+		assert.True(t, breaker.Allow())
+		assert.False(t, breaker.RecordFailure())
+		assert.False(t, breaker.Allow())
+
+		now = now.Add(time.Minute)
+		assert.True(t, breaker.Allow())
+
+		breaker.RecordSuccess()
+		assert.False(t, breaker.IsOpen())
+		assert.True(t, breaker.Allow())
+	})
+}
+
+func newTestDeliveryProducer(t *testing.T, cfg KafkaProducerConfig) (*KafkaAsyncProducer, *mocks.AsyncProducer) {
+	InitPrometheusMetrics()
+
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+
+	mockProducer := mocks.NewAsyncProducer(t, config)
+
+	cfg.Logger = ulogger.TestLogger{}
+	cfg.Topic = "blocks"
+
+	producer := &KafkaAsyncProducer{
+		Producer: mockProducer,
+		Config:   cfg,
+		breaker:  newProducerCircuitBreaker(cfg.CircuitFailureThreshold, cfg.CircuitCooldown),
+	}
+
+	if cfg.SpoolDir != "" {
+		spool, err := newDiskSpool(cfg.SpoolDir, cfg.Topic, 0)
+		require.NoError(t, err)
+
+		producer.spool = spool
+	}
+
+	return producer, mockProducer
+}
+
+func TestKafkaAsyncProducerDeadLetter(t *testing.T) {
+	t.Run("poison messages skip the spool", func(t *testing.T) {
+		producer, mockProducer := newTestDeliveryProducer(t, KafkaProducerConfig{
+			SpoolDir:        t.TempDir(),
+			DeadLetterTopic: "blocks-dlq",
+		})
+
+		mockProducer.ExpectInputAndFail(sarama.ErrMessageSizeTooLarge)
+		mockProducer.ExpectInputAndSucceed()
+
+		require.True(t, producer.send(&sarama.ProducerMessage{
+			Topic:    "blocks",
+			Value:    sarama.ByteEncoder("block"),
+			Metadata: &messageMetadata{published: time.Now()},
+		}))
+		producer.handleError(<-mockProducer.Errors())
+
+		msg := <-mockProducer.Successes()
+		assert.Equal(t, "blocks-dlq", msg.Topic)
+		require.Len(t, msg.Headers, 3)
+		assert.Equal(t, deadLetterHeaderTopic, string(msg.Headers[0].Key))
+		assert.Equal(t, "blocks", string(msg.Headers[0].Value))
+		assert.Equal(t, sarama.ErrMessageSizeTooLarge.Error(), string(msg.Headers[1].Value))
+
+		producer.handleSuccess(msg)
+		assert.Equal(t, 0, producer.spool.Len())
+
+		require.NoError(t, mockProducer.Close())
+	})
+
+	t.Run("undeliverable messages without spool", func(t *testing.T) {
+		producer, mockProducer := newTestDeliveryProducer(t, KafkaProducerConfig{DeadLetterTopic: "blocks-dlq"})
+
+		mockProducer.ExpectInputAndFail(sarama.ErrNotEnoughReplicas)
+		mockProducer.ExpectInputAndSucceed()
+
+		require.True(t, producer.send(&sarama.ProducerMessage{Topic: "blocks", Value: sarama.ByteEncoder("block")}))
+		producer.handleError(<-mockProducer.Errors())
+
+		msg := <-mockProducer.Successes()
+		assert.Equal(t, "blocks-dlq", msg.Topic)
+
+		require.NoError(t, mockProducer.Close())
+	})
+}
+
+func TestKafkaAsyncProducerCircuitOpenSpools(t *testing.T) {
+	producer, mockProducer := newTestDeliveryProducer(t, KafkaProducerConfig{
+		SpoolDir:                t.TempDir(),
+		CircuitFailureThreshold: 1,
+		CircuitCooldown:         time.Hour,
+	})
+
+	mockProducer.ExpectInputAndFail(sarama.ErrNotEnoughReplicas)
+
+	require.True(t, producer.send(&sarama.ProducerMessage{Topic: "blocks", Value: sarama.ByteEncoder("block1")}))
+	producer.handleError(<-mockProducer.Errors())
+
+	assert.True(t, producer.breaker.IsOpen())
+	assert.False(t, producer.breaker.Allow())
+	assert.Equal(t, 1, producer.spool.Len())
+
+	// while the circuit is open new messages go straight to the spool and nothing is replayed
+	producer.handleCircuitOpen(&sarama.ProducerMessage{Topic: "blocks", Value: sarama.ByteEncoder("block2")})
+	assert.Equal(t, 2, producer.spool.Len())
+
+	producer.replaySpool()
+
+	files, err := producer.spool.Next(10)
+	require.NoError(t, err)
+	assert.Len(t, files, 2, "no message was handed to the producer for replay")
+
+	require.NoError(t, mockProducer.Close())
+}
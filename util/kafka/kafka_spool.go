@@ -0,0 +1,211 @@
+// Package kafka provides Kafka consumer and producer implementations for message handling.
+package kafka
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+)
+
+const (
+	// spoolFileExtension is the extension of spooled message files
+	spoolFileExtension = ".msg"
+
+	// spoolReplayBatchSize is the maximum number of spooled messages replayed per replay interval
+	spoolReplayBatchSize = 100
+)
+
+// diskSpool stores messages that could not be delivered to Kafka on disk, one file per message, so they
+// can be replayed once Kafka recovers. Files are named by the time they were spooled, which keeps the
+// replay order equal to the order in which deliveries failed.
+type diskSpool struct {
+	dir         string              // Directory holding the spooled messages of one topic
+	maxMessages int                 // Maximum number of spooled messages, 0 means unlimited
+	mu          sync.Mutex          // Protects the fields below
+	count       int                 // Number of spooled messages
+	seq         uint64              // Sequence number to keep file names unique
+	inFlight    map[string]struct{} // Spooled messages currently being replayed
+}
+
+// newDiskSpool creates a spool for the topic inside dir. Messages spooled by a previous run are picked up
+// and replayed as well.
+func newDiskSpool(dir string, topic string, maxMessages int) (*diskSpool, error) {
+	s := &diskSpool{
+		dir:         filepath.Join(dir, topic),
+		maxMessages: maxMessages,
+		inFlight:    make(map[string]struct{}),
+	}
+
+	files, err := s.list()
+	if err != nil {
+		return nil, err
+	}
+
+	s.count = len(files)
+
+	return s, nil
+}
+
+// Add writes a message to the spool
+func (s *diskSpool) Add(key []byte, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.maxMessages > 0 && s.count >= s.maxMessages {
+		return errors.NewStorageError("kafka spool %s is full (%d messages)", s.dir, s.count)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return errors.NewStorageError("failed to create kafka spool directory %s", s.dir, err)
+	}
+
+	s.seq++
+	name := filepath.Join(s.dir, fmt.Sprintf("%020d-%010d%s", time.Now().UnixNano(), s.seq, spoolFileExtension))
+
+	data := make([]byte, 4+len(key)+len(value))
+	binary.BigEndian.PutUint32(data, uint32(len(key))) //nolint:gosec // kafka keys are far below 4GB
+	copy(data[4:], key)
+	copy(data[4+len(key):], value)
+
+	// write to a temporary file first, so a crash never leaves a partially written message behind
+	tmpName := name + ".tmp"
+	if err := os.WriteFile(tmpName, data, 0o600); err != nil {
+		return errors.NewStorageError("failed to write kafka spool file %s", tmpName, err)
+	}
+
+	if err := os.Rename(tmpName, name); err != nil {
+		_ = os.Remove(tmpName)
+		return errors.NewStorageError("failed to rename kafka spool file %s", tmpName, err)
+	}
+
+	s.count++
+
+	return nil
+}
+
+// Len returns the number of spooled messages
+func (s *diskSpool) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.count
+}
+
+// Next returns up to limit spooled messages that are not being replayed, oldest first, and marks them
+// as in flight. Each message must be finished with Remove when delivered or Release when it failed again.
+func (s *diskSpool) Next(limit int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.count == 0 {
+		return nil, nil
+	}
+
+	files, err := s.list()
+	if err != nil {
+		return nil, err
+	}
+
+	next := make([]string, 0, limit)
+
+	for _, file := range files {
+		if len(next) >= limit {
+			break
+		}
+
+		if _, ok := s.inFlight[file]; ok {
+			continue
+		}
+
+		s.inFlight[file] = struct{}{}
+		next = append(next, file)
+	}
+
+	return next, nil
+}
+
+// Read returns the key and value of a spooled message
+func (s *diskSpool) Read(file string) ([]byte, []byte, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, errors.NewStorageError("failed to read kafka spool file %s", file, err)
+	}
+
+	if len(data) < 4 {
+		return nil, nil, errors.NewProcessingError("kafka spool file %s is corrupt", file)
+	}
+
+	keyLen := binary.BigEndian.Uint32(data)
+	if uint64(keyLen) > uint64(len(data)-4) {
+		return nil, nil, errors.NewProcessingError("kafka spool file %s is corrupt", file)
+	}
+
+	var key []byte
+	if keyLen > 0 {
+		key = data[4 : 4+keyLen]
+	}
+
+	return key, data[4+keyLen:], nil
+}
+
+// Remove deletes a spooled message after it has been delivered
+func (s *diskSpool) Remove(file string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.inFlight, file)
+
+	if err := os.Remove(file); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return errors.NewStorageError("failed to remove kafka spool file %s", file, err)
+	}
+
+	s.count--
+
+	return nil
+}
+
+// Release makes a spooled message available for replay again after its delivery failed
+func (s *diskSpool) Release(file string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.inFlight, file)
+}
+
+// list returns the spooled message files sorted oldest first, must be called with the lock held or
+// before the spool is shared
+func (s *diskSpool) list() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.NewStorageError("failed to read kafka spool directory %s", s.dir, err)
+	}
+
+	files := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), spoolFileExtension) {
+			continue
+		}
+
+		files = append(files, filepath.Join(s.dir, entry.Name()))
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskSpool(t *testing.T) {
+	t.Run("add, replay and remove in order", func(t *testing.T) {
+		spool, err := newDiskSpool(t.TempDir(), "blocks", 0)
+		require.NoError(t, err)
+
+		require.NoError(t, spool.Add([]byte("key1"), []byte("value1")))
+		require.NoError(t, spool.Add(nil, []byte("value2")))
+		assert.Equal(t, 2, spool.Len())
+
+		files, err := spool.Next(10)
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+
+		key, value, err := spool.Read(files[0])
+		require.NoError(t, err)
+		assert.Equal(t, []byte("key1"), key)
+		assert.Equal(t, []byte("value1"), value)
+
+		key, value, err = spool.Read(files[1])
+		require.NoError(t, err)
+		assert.Nil(t, key)
+		assert.Equal(t, []byte("value2"), value)
+
+		// in flight messages are not handed out twice
+		inFlight, err := spool.Next(10)
+		require.NoError(t, err)
+		assert.Empty(t, inFlight)
+
+		spool.Release(files[1])
+		require.NoError(t, spool.Remove(files[0]))
+		assert.Equal(t, 1, spool.Len())
+
+		again, err := spool.Next(10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{files[1]}, again)
+	})
+
+	t.Run("max messages", func(t *testing.T) {
+		spool, err := newDiskSpool(t.TempDir(), "blocks", 1)
+		require.NoError(t, err)
+
+		require.NoError(t, spool.Add(nil, []byte("value1")))
+
+		err = spool.Add(nil, []byte("value2"))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrStorageError))
+		assert.Equal(t, 1, spool.Len())
+	})
+
+	t.Run("survives restart", func(t *testing.T) {
+		dir := t.TempDir()
+
+		spool, err := newDiskSpool(dir, "blocks", 0)
+		require.NoError(t, err)
+		require.NoError(t, spool.Add([]byte("key"), []byte("value")))
+
+		// leftover temporary files of an interrupted write are ignored
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "blocks", "partial.msg.tmp"), []byte("x"), 0o600))
+
+		reopened, err := newDiskSpool(dir, "blocks", 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reopened.Len())
+	})
+
+	t.Run("corrupt file", func(t *testing.T) {
+		spool, err := newDiskSpool(t.TempDir(), "blocks", 0)
+		require.NoError(t, err)
+
+		require.NoError(t, os.MkdirAll(spool.dir, 0o755))
+
+		file := filepath.Join(spool.dir, "corrupt"+spoolFileExtension)
+		require.NoError(t, os.WriteFile(file, []byte{0, 0, 1, 0, 1}, 0o600))
+
+		_, _, err = spool.Read(file)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrProcessing))
+	})
+}
@@ -33,13 +33,61 @@ var (
 	// This histogram measures the duration between when Consume() was called and
 	// when the watchdog detected it as stuck, helping diagnose consumer hangs.
 	prometheusKafkaWatchdogStuckDuration *prometheus.HistogramVec
+
+	// prometheusKafkaProducerDelivered counts the messages acknowledged by Kafka per topic.
+	// Labels: topic
+	prometheusKafkaProducerDelivered *prometheus.CounterVec
+
+	// prometheusKafkaProducerFailed counts the messages Kafka failed to accept after all retries per topic.
+	// Labels: topic
+	prometheusKafkaProducerFailed *prometheus.CounterVec
+
+	// prometheusKafkaProducerSpooled counts the undeliverable messages written to the disk spool per topic.
+	// Labels: topic
+	prometheusKafkaProducerSpooled *prometheus.CounterVec
+
+	// prometheusKafkaProducerReplayed counts the spooled messages delivered after Kafka recovered per topic.
+	// Labels: topic
+	prometheusKafkaProducerReplayed *prometheus.CounterVec
+
+	// prometheusKafkaProducerDropped counts the undeliverable messages that could not be spooled and are lost.
+	// Labels: topic
+	prometheusKafkaProducerDropped *prometheus.CounterVec
+
+	// prometheusKafkaProducerSpoolSize tracks the number of messages waiting in the disk spool per topic.
+	// Labels: topic
+	prometheusKafkaProducerSpoolSize *prometheus.GaugeVec
+
+	// prometheusKafkaProducerPublishLatency tracks the time from publishing a message until Kafka acknowledged it.
+	// Labels: topic
+	prometheusKafkaProducerPublishLatency *prometheus.HistogramVec
+
+	// prometheusKafkaProducerDeadLettered counts the messages published to the dead-letter topic per original topic.
+	// Labels: topic
+	prometheusKafkaProducerDeadLettered *prometheus.CounterVec
+
+	// prometheusKafkaProducerCircuitOpen reports whether the circuit of the producer is open (1) or closed (0).
+	// Labels: topic
+	prometheusKafkaProducerCircuitOpen *prometheus.GaugeVec
+
+	// prometheusKafkaConsumerLag tracks the number of messages behind the high water mark per partition.
+	// Labels: topic, consumer_group, partition
+	prometheusKafkaConsumerLag *prometheus.GaugeVec
+
+	// prometheusKafkaConsumerAssignedPartitions tracks the number of partitions assigned to the consumer.
+	// Labels: topic, consumer_group
+	prometheusKafkaConsumerAssignedPartitions *prometheus.GaugeVec
+
+	// prometheusKafkaConsumerPaused reports whether the consumer is paused by backpressure (1) or not (0).
+	// Labels: topic, consumer_group
+	prometheusKafkaConsumerPaused *prometheus.GaugeVec
 )
 
 var (
 	prometheusMetricsInitOnce sync.Once
 )
 
-// InitPrometheusMetrics initializes Prometheus metrics for Kafka consumers.
+// InitPrometheusMetrics initializes Prometheus metrics for Kafka consumers and producers.
 // This function is idempotent and can be called multiple times safely.
 func InitPrometheusMetrics() {
 	prometheusMetricsInitOnce.Do(_initPrometheusMetrics)
@@ -66,4 +114,125 @@ func _initPrometheusMetrics() {
 		},
 		[]string{"topic"},
 	)
+
+	prometheusKafkaProducerDelivered = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "producer_delivered_total",
+			Help:      "Number of messages acknowledged by Kafka",
+		},
+		[]string{"topic"},
+	)
+
+	prometheusKafkaProducerFailed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "producer_failed_total",
+			Help:      "Number of messages that failed to be delivered to Kafka",
+		},
+		[]string{"topic"},
+	)
+
+	prometheusKafkaProducerSpooled = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "producer_spooled_total",
+			Help:      "Number of undeliverable messages written to the disk spool",
+		},
+		[]string{"topic"},
+	)
+
+	prometheusKafkaProducerReplayed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "producer_replayed_total",
+			Help:      "Number of spooled messages delivered to Kafka after recovery",
+		},
+		[]string{"topic"},
+	)
+
+	prometheusKafkaProducerDropped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "producer_dropped_total",
+			Help:      "Number of undeliverable messages that could not be spooled",
+		},
+		[]string{"topic"},
+	)
+
+	prometheusKafkaProducerSpoolSize = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "producer_spool_size",
+			Help:      "Number of messages waiting in the disk spool for replay",
+		},
+		[]string{"topic"},
+	)
+
+	prometheusKafkaProducerPublishLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "producer_publish_latency_seconds",
+			Help:      "Time from publishing a message until it was acknowledged by Kafka",
+			Buckets:   util.MetricsBucketsSeconds,
+		},
+		[]string{"topic"},
+	)
+
+	prometheusKafkaProducerDeadLettered = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "producer_dead_lettered_total",
+			Help:      "Number of undeliverable messages published to the dead-letter topic",
+		},
+		[]string{"topic"},
+	)
+
+	prometheusKafkaProducerCircuitOpen = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "producer_circuit_open",
+			Help:      "Whether the circuit of the producer is open (1) or closed (0)",
+		},
+		[]string{"topic"},
+	)
+
+	prometheusKafkaConsumerLag = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "consumer_lag",
+			Help:      "Number of messages the consumer is behind the high water mark of the partition",
+		},
+		[]string{"topic", "consumer_group", "partition"},
+	)
+
+	prometheusKafkaConsumerAssignedPartitions = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "consumer_assigned_partitions",
+			Help:      "Number of partitions assigned to the consumer",
+		},
+		[]string{"topic", "consumer_group"},
+	)
+
+	prometheusKafkaConsumerPaused = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "kafka",
+			Name:      "consumer_paused",
+			Help:      "Whether the consumer is paused because the internal backlog is too large (1) or not (0)",
+		},
+		[]string{"topic", "consumer_group"},
+	)
 }
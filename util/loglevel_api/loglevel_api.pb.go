@@ -0,0 +1,302 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: util/loglevel_api/loglevel_api.proto
+
+package loglevel_api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ComponentLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Component     string                 `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Overridden    bool                   `protobuf:"varint,3,opt,name=overridden,proto3" json:"overridden,omitempty"` // The level was set for this component and does not follow the default level
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentLevel) Reset() {
+	*x = ComponentLevel{}
+	mi := &file_util_loglevel_api_loglevel_api_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentLevel) ProtoMessage() {}
+
+func (x *ComponentLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_util_loglevel_api_loglevel_api_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentLevel.ProtoReflect.Descriptor instead.
+func (*ComponentLevel) Descriptor() ([]byte, []int) {
+	return file_util_loglevel_api_loglevel_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ComponentLevel) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *ComponentLevel) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *ComponentLevel) GetOverridden() bool {
+	if x != nil {
+		return x.Overridden
+	}
+	return false
+}
+
+type GetLogLevelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogLevelsRequest) Reset() {
+	*x = GetLogLevelsRequest{}
+	mi := &file_util_loglevel_api_loglevel_api_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogLevelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelsRequest) ProtoMessage() {}
+
+func (x *GetLogLevelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_util_loglevel_api_loglevel_api_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogLevelsRequest) Descriptor() ([]byte, []int) {
+	return file_util_loglevel_api_loglevel_api_proto_rawDescGZIP(), []int{1}
+}
+
+type GetLogLevelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DefaultLevel  string                 `protobuf:"bytes,1,opt,name=default_level,json=defaultLevel,proto3" json:"default_level,omitempty"`
+	Components    []*ComponentLevel      `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"` // Ordered by component name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogLevelsResponse) Reset() {
+	*x = GetLogLevelsResponse{}
+	mi := &file_util_loglevel_api_loglevel_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogLevelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelsResponse) ProtoMessage() {}
+
+func (x *GetLogLevelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_util_loglevel_api_loglevel_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelsResponse.ProtoReflect.Descriptor instead.
+func (*GetLogLevelsResponse) Descriptor() ([]byte, []int) {
+	return file_util_loglevel_api_loglevel_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetLogLevelsResponse) GetDefaultLevel() string {
+	if x != nil {
+		return x.DefaultLevel
+	}
+	return ""
+}
+
+func (x *GetLogLevelsResponse) GetComponents() []*ComponentLevel {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type SetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Component     string                 `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"` // Empty sets the default level of all components whose level has not been set
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`         // DEBUG, INFO, WARN, ERROR or FATAL, empty resets the component to the default level
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_util_loglevel_api_loglevel_api_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_util_loglevel_api_loglevel_api_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_util_loglevel_api_loglevel_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetLogLevelRequest) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+var File_util_loglevel_api_loglevel_api_proto protoreflect.FileDescriptor
+
+const file_util_loglevel_api_loglevel_api_proto_rawDesc = "" +
+	"\n" +
+	"$util/loglevel_api/loglevel_api.proto\x12\floglevel_api\"d\n" +
+	"\x0eComponentLevel\x12\x1c\n" +
+	"\tcomponent\x18\x01 \x01(\tR\tcomponent\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x1e\n" +
+	"\n" +
+	"overridden\x18\x03 \x01(\bR\n" +
+	"overridden\"\x15\n" +
+	"\x13GetLogLevelsRequest\"y\n" +
+	"\x14GetLogLevelsResponse\x12#\n" +
+	"\rdefault_level\x18\x01 \x01(\tR\fdefaultLevel\x12<\n" +
+	"\n" +
+	"components\x18\x02 \x03(\v2\x1c.loglevel_api.ComponentLevelR\n" +
+	"components\"H\n" +
+	"\x12SetLogLevelRequest\x12\x1c\n" +
+	"\tcomponent\x18\x01 \x01(\tR\tcomponent\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level2\xbd\x01\n" +
+	"\vLogLevelAPI\x12W\n" +
+	"\fGetLogLevels\x12!.loglevel_api.GetLogLevelsRequest\x1a\".loglevel_api.GetLogLevelsResponse\"\x00\x12U\n" +
+	"\vSetLogLevel\x12 .loglevel_api.SetLogLevelRequest\x1a\".loglevel_api.GetLogLevelsResponse\"\x00B\x11Z\x0f./;loglevel_apib\x06proto3"
+
+var (
+	file_util_loglevel_api_loglevel_api_proto_rawDescOnce sync.Once
+	file_util_loglevel_api_loglevel_api_proto_rawDescData []byte
+)
+
+func file_util_loglevel_api_loglevel_api_proto_rawDescGZIP() []byte {
+	file_util_loglevel_api_loglevel_api_proto_rawDescOnce.Do(func() {
+		file_util_loglevel_api_loglevel_api_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_util_loglevel_api_loglevel_api_proto_rawDesc), len(file_util_loglevel_api_loglevel_api_proto_rawDesc)))
+	})
+	return file_util_loglevel_api_loglevel_api_proto_rawDescData
+}
+
+var file_util_loglevel_api_loglevel_api_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_util_loglevel_api_loglevel_api_proto_goTypes = []any{
+	(*ComponentLevel)(nil),       // 0: loglevel_api.ComponentLevel
+	(*GetLogLevelsRequest)(nil),  // 1: loglevel_api.GetLogLevelsRequest
+	(*GetLogLevelsResponse)(nil), // 2: loglevel_api.GetLogLevelsResponse
+	(*SetLogLevelRequest)(nil),   // 3: loglevel_api.SetLogLevelRequest
+}
+var file_util_loglevel_api_loglevel_api_proto_depIdxs = []int32{
+	0, // 0: loglevel_api.GetLogLevelsResponse.components:type_name -> loglevel_api.ComponentLevel
+	1, // 1: loglevel_api.LogLevelAPI.GetLogLevels:input_type -> loglevel_api.GetLogLevelsRequest
+	3, // 2: loglevel_api.LogLevelAPI.SetLogLevel:input_type -> loglevel_api.SetLogLevelRequest
+	2, // 3: loglevel_api.LogLevelAPI.GetLogLevels:output_type -> loglevel_api.GetLogLevelsResponse
+	2, // 4: loglevel_api.LogLevelAPI.SetLogLevel:output_type -> loglevel_api.GetLogLevelsResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_util_loglevel_api_loglevel_api_proto_init() }
+func file_util_loglevel_api_loglevel_api_proto_init() {
+	if File_util_loglevel_api_loglevel_api_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_util_loglevel_api_loglevel_api_proto_rawDesc), len(file_util_loglevel_api_loglevel_api_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_util_loglevel_api_loglevel_api_proto_goTypes,
+		DependencyIndexes: file_util_loglevel_api_loglevel_api_proto_depIdxs,
+		MessageInfos:      file_util_loglevel_api_loglevel_api_proto_msgTypes,
+	}.Build()
+	File_util_loglevel_api_loglevel_api_proto = out.File
+	file_util_loglevel_api_loglevel_api_proto_goTypes = nil
+	file_util_loglevel_api_loglevel_api_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.33.0
+// source: util/loglevel_api/loglevel_api.proto
+
+package loglevel_api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LogLevelAPI_GetLogLevels_FullMethodName = "/loglevel_api.LogLevelAPI/GetLogLevels"
+	LogLevelAPI_SetLogLevel_FullMethodName  = "/loglevel_api.LogLevelAPI/SetLogLevel"
+)
+
+// LogLevelAPIClient is the client API for LogLevelAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LogLevelAPI controls the log levels of the components of a process at runtime
+type LogLevelAPIClient interface {
+	GetLogLevels(ctx context.Context, in *GetLogLevelsRequest, opts ...grpc.CallOption) (*GetLogLevelsResponse, error)
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*GetLogLevelsResponse, error)
+}
+
+type logLevelAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLogLevelAPIClient(cc grpc.ClientConnInterface) LogLevelAPIClient {
+	return &logLevelAPIClient{cc}
+}
+
+func (c *logLevelAPIClient) GetLogLevels(ctx context.Context, in *GetLogLevelsRequest, opts ...grpc.CallOption) (*GetLogLevelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLogLevelsResponse)
+	err := c.cc.Invoke(ctx, LogLevelAPI_GetLogLevels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logLevelAPIClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*GetLogLevelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLogLevelsResponse)
+	err := c.cc.Invoke(ctx, LogLevelAPI_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LogLevelAPIServer is the server API for LogLevelAPI service.
+// All implementations must embed UnimplementedLogLevelAPIServer
+// for forward compatibility.
+//
+// LogLevelAPI controls the log levels of the components of a process at runtime
+type LogLevelAPIServer interface {
+	GetLogLevels(context.Context, *GetLogLevelsRequest) (*GetLogLevelsResponse, error)
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*GetLogLevelsResponse, error)
+	mustEmbedUnimplementedLogLevelAPIServer()
+}
+
+// UnimplementedLogLevelAPIServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLogLevelAPIServer struct{}
+
+func (UnimplementedLogLevelAPIServer) GetLogLevels(context.Context, *GetLogLevelsRequest) (*GetLogLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogLevels not implemented")
+}
+func (UnimplementedLogLevelAPIServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*GetLogLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedLogLevelAPIServer) mustEmbedUnimplementedLogLevelAPIServer() {}
+func (UnimplementedLogLevelAPIServer) testEmbeddedByValue()                     {}
+
+// UnsafeLogLevelAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LogLevelAPIServer will
+// result in compilation errors.
+type UnsafeLogLevelAPIServer interface {
+	mustEmbedUnimplementedLogLevelAPIServer()
+}
+
+func RegisterLogLevelAPIServer(s grpc.ServiceRegistrar, srv LogLevelAPIServer) {
+	// If the following call pancis, it indicates UnimplementedLogLevelAPIServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LogLevelAPI_ServiceDesc, srv)
+}
+
+func _LogLevelAPI_GetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogLevelAPIServer).GetLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogLevelAPI_GetLogLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogLevelAPIServer).GetLogLevels(ctx, req.(*GetLogLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogLevelAPI_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogLevelAPIServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogLevelAPI_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogLevelAPIServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LogLevelAPI_ServiceDesc is the grpc.ServiceDesc for LogLevelAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LogLevelAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "loglevel_api.LogLevelAPI",
+	HandlerType: (*LogLevelAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLogLevels",
+			Handler:    _LogLevelAPI_GetLogLevels_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _LogLevelAPI_SetLogLevel_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "util/loglevel_api/loglevel_api.proto",
+}
@@ -0,0 +1,62 @@
+package retention
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// prometheusRetentionDiskUsage is the disk space used by the files of each artifact class after cleanup.
+	// Labels: class
+	prometheusRetentionDiskUsage *prometheus.GaugeVec
+
+	// prometheusRetentionFiles is the number of files of each artifact class after cleanup.
+	// Labels: class
+	prometheusRetentionFiles *prometheus.GaugeVec
+
+	// prometheusRetentionRemovedFiles counts the files removed per artifact class and reason.
+	// Labels: class, reason (age, size)
+	prometheusRetentionRemovedFiles *prometheus.CounterVec
+)
+
+var (
+	prometheusMetricsInitOnce sync.Once
+)
+
+func initPrometheusMetrics() {
+	prometheusMetricsInitOnce.Do(_initPrometheusMetrics)
+}
+
+func _initPrometheusMetrics() {
+	prometheusRetentionDiskUsage = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "retention",
+			Name:      "disk_usage_bytes",
+			Help:      "Disk space used by the files of each artifact class",
+		},
+		[]string{"class"},
+	)
+
+	prometheusRetentionFiles = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "teranode",
+			Subsystem: "retention",
+			Name:      "files",
+			Help:      "Number of files of each artifact class",
+		},
+		[]string{"class"},
+	)
+
+	prometheusRetentionRemovedFiles = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "retention",
+			Name:      "removed_files_total",
+			Help:      "Number of files removed per artifact class and reason",
+		},
+		[]string{"class", "reason"},
+	)
+}
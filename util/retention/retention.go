@@ -0,0 +1,231 @@
+// Package retention limits the disk space used by artifacts that accumulate on disk while the node runs, such as
+// the rotated files of the peer event log and of the message recordings. Every artifact class has a retention
+// period and a disk space cap, a Cleaner periodically removes the files that are older than the retention period
+// and then the oldest files while the class uses more disk space than the cap.
+package retention
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+)
+
+const (
+	reasonAge  = "age"
+	reasonSize = "size"
+)
+
+// Class is a kind of artifact kept on disk with its retention limits
+type Class struct {
+	// Name of the artifact class, as used in the logs and the metrics
+	Name string
+
+	// MaxAge is the retention period, files last modified longer ago are removed. 0 keeps files regardless of age.
+	MaxAge time.Duration
+
+	// MaxBytes is the disk space the files of the class may use, the oldest files are removed while the class uses
+	// more. 0 does not cap the disk space.
+	MaxBytes int64
+
+	// Files returns the files of the class
+	Files func() ([]string, error)
+
+	// InUse reports whether a file is in use, e.g. the file currently appended to. Files in use count towards the
+	// disk usage of the class but are never removed. Optional.
+	InUse func(path string) bool
+}
+
+// Usage is the disk usage of an artifact class after a cleanup
+type Usage struct {
+	Class        string
+	Files        int
+	Bytes        int64
+	RemovedFiles int
+	RemovedBytes int64
+}
+
+// Cleaner enforces the retention limits of a set of artifact classes
+type Cleaner struct {
+	logger   ulogger.Logger
+	interval time.Duration
+	classes  []Class
+	now      func() time.Time
+}
+
+// NewCleaner creates a cleaner enforcing the retention limits of the classes every interval
+func NewCleaner(logger ulogger.Logger, interval time.Duration, classes ...Class) *Cleaner {
+	initPrometheusMetrics()
+
+	return &Cleaner{
+		logger:   logger,
+		interval: interval,
+		classes:  classes,
+		now:      time.Now,
+	}
+}
+
+// Start runs a cleanup straight away and then every interval, until the context is done. Nothing is started when
+// the interval is 0 or lower or there are no classes.
+func (c *Cleaner) Start(ctx context.Context) {
+	if c.interval <= 0 || len(c.classes) == 0 {
+		c.logger.Infof("[Retention] cleanup disabled")
+		return
+	}
+
+	go func() {
+		c.Cleanup()
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.Cleanup()
+			}
+		}
+	}()
+
+	c.logger.Infof("[Retention] started cleanup of %d artifact classes with interval %v", len(c.classes), c.interval)
+}
+
+// Cleanup enforces the retention limits of all classes once and returns their disk usage afterwards. A class that
+// can not be cleaned up is logged and skipped.
+func (c *Cleaner) Cleanup() []Usage {
+	usages := make([]Usage, 0, len(c.classes))
+
+	for _, class := range c.classes {
+		usage, err := c.cleanupClass(class)
+		if err != nil {
+			c.logger.Warnf("[Retention] failed to clean up %s: %v", class.Name, err)
+			continue
+		}
+
+		if usage.RemovedFiles > 0 {
+			c.logger.Infof("[Retention] removed %d %s files (%d bytes), %d files (%d bytes) left", usage.RemovedFiles, class.Name, usage.RemovedBytes, usage.Files, usage.Bytes)
+		}
+
+		prometheusRetentionDiskUsage.WithLabelValues(class.Name).Set(float64(usage.Bytes))
+		prometheusRetentionFiles.WithLabelValues(class.Name).Set(float64(usage.Files))
+
+		usages = append(usages, usage)
+	}
+
+	return usages
+}
+
+type artifactFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+	inUse   bool
+}
+
+// cleanupClass removes the files of the class that exceed its retention limits
+func (c *Cleaner) cleanupClass(class Class) (Usage, error) {
+	usage := Usage{Class: class.Name}
+
+	paths, err := class.Files()
+	if err != nil {
+		return usage, err
+	}
+
+	files := make([]artifactFile, 0, len(paths))
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return usage, errors.NewStorageError("failed to stat %s", path, err)
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		files = append(files, artifactFile{
+			path:    path,
+			size:    info.Size(),
+			modTime: info.ModTime(),
+			inUse:   class.InUse != nil && class.InUse(path),
+		})
+
+		usage.Bytes += info.Size()
+	}
+
+	// oldest first
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	remove := func(file artifactFile, reason string) bool {
+		if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+			c.logger.Warnf("[Retention] failed to remove %s file %s: %v", class.Name, file.path, err)
+			return false
+		}
+
+		usage.RemovedFiles++
+		usage.RemovedBytes += file.size
+		usage.Bytes -= file.size
+
+		prometheusRetentionRemovedFiles.WithLabelValues(class.Name, reason).Inc()
+
+		return true
+	}
+
+	kept := files[:0]
+
+	for _, file := range files {
+		if !file.inUse && class.MaxAge > 0 && c.now().Sub(file.modTime) > class.MaxAge {
+			if remove(file, reasonAge) {
+				continue
+			}
+		}
+
+		kept = append(kept, file)
+	}
+
+	usage.Files = len(kept)
+
+	if class.MaxBytes > 0 {
+		for _, file := range kept {
+			if usage.Bytes <= class.MaxBytes {
+				break
+			}
+
+			if !file.inUse && remove(file, reasonSize) {
+				usage.Files--
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+// Glob returns a Files function listing the files matching the patterns, see filepath.Glob
+func Glob(patterns ...string) func() ([]string, error) {
+	return func() ([]string, error) {
+		files := make([]string, 0)
+
+		for _, pattern := range patterns {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, errors.NewConfigurationError("invalid retention file pattern %s", pattern, err)
+			}
+
+			files = append(files, matches...)
+		}
+
+		return files, nil
+	}
+}
@@ -0,0 +1,114 @@
+package retention
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeArtifact writes a file of the given size, last modified age ago
+func writeArtifact(t *testing.T, path string, size int, age time.Duration) {
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0o600))
+
+	modTime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestCleaner_Cleanup(t *testing.T) {
+	t.Run("removes files older than the retention period", func(t *testing.T) {
+		dir := t.TempDir()
+		writeArtifact(t, filepath.Join(dir, "a.log.1"), 10, 3*time.Hour)
+		writeArtifact(t, filepath.Join(dir, "a.log.2"), 10, 2*time.Hour)
+		writeArtifact(t, filepath.Join(dir, "a.log"), 10, 0)
+
+		cleaner := NewCleaner(ulogger.TestLogger{}, time.Minute, Class{
+			Name:   "age",
+			MaxAge: time.Hour,
+			Files:  Glob(filepath.Join(dir, "a.log*")),
+		})
+
+		usages := cleaner.Cleanup()
+		require.Len(t, usages, 1)
+		assert.Equal(t, Usage{Class: "age", Files: 1, Bytes: 10, RemovedFiles: 2, RemovedBytes: 20}, usages[0])
+
+		assert.FileExists(t, filepath.Join(dir, "a.log"))
+		assert.NoFileExists(t, filepath.Join(dir, "a.log.1"))
+	})
+
+	t.Run("removes the oldest files while the class exceeds its cap", func(t *testing.T) {
+		dir := t.TempDir()
+		writeArtifact(t, filepath.Join(dir, "b.1"), 100, 3*time.Minute)
+		writeArtifact(t, filepath.Join(dir, "b.2"), 100, 2*time.Minute)
+		writeArtifact(t, filepath.Join(dir, "b.3"), 100, time.Minute)
+
+		cleaner := NewCleaner(ulogger.TestLogger{}, time.Minute, Class{
+			Name:     "size",
+			MaxBytes: 150,
+			Files:    Glob(filepath.Join(dir, "b.*")),
+		})
+
+		usages := cleaner.Cleanup()
+		require.Len(t, usages, 1)
+		assert.Equal(t, Usage{Class: "size", Files: 1, Bytes: 100, RemovedFiles: 2, RemovedBytes: 200}, usages[0])
+
+		assert.FileExists(t, filepath.Join(dir, "b.3"))
+	})
+
+	t.Run("never removes files in use", func(t *testing.T) {
+		dir := t.TempDir()
+		current := filepath.Join(dir, "c")
+		writeArtifact(t, current, 100, 3*time.Hour)
+		writeArtifact(t, filepath.Join(dir, "c.1"), 100, time.Minute)
+
+		cleaner := NewCleaner(ulogger.TestLogger{}, time.Minute, Class{
+			Name:     "in_use",
+			MaxAge:   time.Hour,
+			MaxBytes: 50,
+			Files:    Glob(filepath.Join(dir, "c*")),
+			InUse: func(path string) bool {
+				return path == current
+			},
+		})
+
+		usages := cleaner.Cleanup()
+		require.Len(t, usages, 1)
+		assert.Equal(t, Usage{Class: "in_use", Files: 1, Bytes: 100, RemovedFiles: 1, RemovedBytes: 100}, usages[0])
+
+		assert.FileExists(t, current)
+	})
+
+	t.Run("skips classes that fail", func(t *testing.T) {
+		cleaner := NewCleaner(ulogger.TestLogger{}, time.Minute, Class{
+			Name:  "invalid",
+			Files: Glob("["),
+		})
+
+		assert.Empty(t, cleaner.Cleanup())
+	})
+}
+
+func TestCleaner_Start(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, filepath.Join(dir, "d.1"), 10, 2*time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	NewCleaner(ulogger.TestLogger{}, time.Hour, Class{
+		Name:   "start",
+		MaxAge: time.Hour,
+		Files:  Glob(filepath.Join(dir, "d.*")),
+	}).Start(ctx)
+
+	// the first cleanup runs straight away
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(dir, "d.1"))
+		return os.IsNotExist(err)
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,48 @@
+package retry
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// prometheusRetries counts the retries per call site, a steep rise points at a retry storm.
+	// Labels: call_site
+	prometheusRetries *prometheus.CounterVec
+
+	// prometheusRetryCalls counts the retried calls per call site and result.
+	// Labels: call_site, result (success, retried_success, given_up, cancelled)
+	prometheusRetryCalls *prometheus.CounterVec
+)
+
+var (
+	prometheusMetricsInitOnce sync.Once
+)
+
+func initPrometheusMetrics() {
+	prometheusMetricsInitOnce.Do(_initPrometheusMetrics)
+}
+
+func _initPrometheusMetrics() {
+	prometheusRetries = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "retry",
+			Name:      "retries_total",
+			Help:      "Number of retries per call site",
+		},
+		[]string{"call_site"},
+	)
+
+	prometheusRetryCalls = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "teranode",
+			Subsystem: "retry",
+			Name:      "calls_total",
+			Help:      "Number of retried calls per call site and result",
+		},
+		[]string{"call_site", "result"},
+	)
+}
@@ -15,6 +15,7 @@ type Options func(s *SetOptions)
 // ExponentialBackoff: If true, use exponential backoff instead of linear
 // BackoffFactor: The factor for exponential backoff (e.g., 2.0 for doubling)
 // MaxBackoff: The maximum backoff duration for exponential backoff
+// CallSite: The name the retry stats and metrics are recorded under, the calling function when empty
 // By default:
 // Message: "In RetryWithLogger, "
 // BackoffDurationType: time.Second
@@ -24,6 +25,7 @@ type Options func(s *SetOptions)
 // ExponentialBackoff: false
 // BackoffFactor: 2.0
 // MaxBackoff: 30 * time.Second
+// CallSite: ""
 type SetOptions struct {
 	Message             string
 	BackoffDurationType time.Duration
@@ -33,6 +35,7 @@ type SetOptions struct {
 	ExponentialBackoff  bool
 	BackoffFactor       float64
 	MaxBackoff          time.Duration
+	CallSite            string
 }
 
 func NewSetOptions(opts ...Options) *SetOptions {
@@ -104,3 +107,9 @@ func WithMaxBackoff(maxBackoff time.Duration) Options {
 		s.MaxBackoff = maxBackoff
 	}
 }
+
+func WithCallSite(callSite string) Options {
+	return func(s *SetOptions) {
+		s.CallSite = callSite
+	}
+}
@@ -19,6 +19,7 @@ const (
 // logger: The logger that will be used to log messages
 // f: The function that will be retried. It should return an error, which will be checked to determine if the function was successful
 // opts: The options that will be used to control the retry operation. These can be set using the WithMessage, WithBackoffDurationType, WithBackoffMultiplier, and WithRetryCount functions
+// The effective policy and the number of retries are recorded per call site, see Stats.
 // Returns:
 // T: The result of the function call, or the zero value of T if the function was not successful
 // error: The error returned by the function, or nil if the function was successful
@@ -36,10 +37,13 @@ func Retry[T any](ctx context.Context, logger ulogger.Logger, f func() (T, error
 	// and then applies the options provided in the opts slice
 	setOptions := NewSetOptions(opts...)
 
+	site := getCallSite(setOptions.CallSite, setOptions)
+
 	// Call the function for the first time
 	result, err = f()
 	if err == nil {
 		// This worked successfully first time, so return the result and nil
+		site.recordResult(resultSuccess)
 		return result, nil
 	}
 
@@ -60,6 +64,7 @@ func Retry[T any](ctx context.Context, logger ulogger.Logger, f func() (T, error
 	for i := 0; maxRetries == -1 || i < maxRetries; i++ {
 		select {
 		case <-ctx.Done(): // Check if the context has been cancelled
+			site.recordResult(resultCancelled)
 			return result, ctx.Err()
 
 		default:
@@ -92,6 +97,7 @@ func Retry[T any](ctx context.Context, logger ulogger.Logger, f func() (T, error
 				// Use exponential backoff
 				select {
 				case <-ctx.Done():
+					site.recordResult(resultCancelled)
 					return result, ctx.Err()
 				case <-time.After(currentBackoff):
 					// Update backoff for next iteration
@@ -106,20 +112,27 @@ func Retry[T any](ctx context.Context, logger ulogger.Logger, f func() (T, error
 				}
 				if err := BackoffAndSleep(ctx, retryCountForBackoff, setOptions.BackoffMultiplier, setOptions.BackoffDurationType); err != nil {
 					logger.Errorf("Context cancelled during backoff, stopping retries")
+					site.recordResult(resultCancelled)
+
 					return result, err
 				}
 			}
 
+			site.recordRetry()
+
 			// Call the function
 			result, err = f()
 
 			// If the function was successful, return result
 			if err == nil {
+				site.recordResult(resultRetriedSuccess)
 				return result, nil
 			}
 		}
 	}
 
+	site.recordResult(resultGivenUp)
+
 	// Log the retry message for finite retries
 	if !setOptions.InfiniteRetry {
 		logger.Warnf(setOptions.Message+" (given up after %d attempts): %v", setOptions.RetryCount, err)
@@ -0,0 +1,233 @@
+package retry
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// results of a retried call, used as the result label of the retry metrics
+const (
+	resultSuccess        = "success"         // the first attempt succeeded
+	resultRetriedSuccess = "retried_success" // an attempt after one or more retries succeeded
+	resultGivenUp        = "given_up"        // all retries failed
+	resultCancelled      = "cancelled"       // the context was cancelled while retrying
+)
+
+// Policy is the effective retry policy of a call site, after the defaults and options are applied
+type Policy struct {
+	RetryCount        int     `json:"retry_count"` // -1 when retrying until the context is cancelled
+	Backoff           string  `json:"backoff"`
+	BackoffMultiplier int     `json:"backoff_multiplier,omitempty"`
+	Exponential       bool    `json:"exponential"`
+	BackoffFactor     float64 `json:"backoff_factor,omitempty"`
+	MaxBackoff        string  `json:"max_backoff,omitempty"`
+	MaxWait           string  `json:"max_wait,omitempty"` // total time waited before giving up, unset for infinite retries
+}
+
+// CallSiteStats are the retry counters of a single call site since the process started
+type CallSiteStats struct {
+	CallSite         string    `json:"call_site"`
+	Policy           Policy    `json:"policy"`
+	Calls            uint64    `json:"calls"`
+	Retries          uint64    `json:"retries"`
+	Successes        uint64    `json:"successes"`
+	RetriedSuccesses uint64    `json:"retried_successes"`
+	GivenUp          uint64    `json:"given_up"`
+	Cancelled        uint64    `json:"cancelled"`
+	LastRetry        time.Time `json:"last_retry,omitzero"`
+}
+
+// callSite holds the live counters of a call site
+type callSite struct {
+	name             string
+	options          atomic.Pointer[SetOptions] // options of the last call, without the message
+	calls            atomic.Uint64
+	retries          atomic.Uint64
+	successes        atomic.Uint64
+	retriedSuccesses atomic.Uint64
+	givenUp          atomic.Uint64
+	cancelled        atomic.Uint64
+	lastRetry        atomic.Int64 // unix nanoseconds
+}
+
+// callSites holds the *callSite of every call site that called Retry, by name
+var callSites sync.Map
+
+// getCallSite returns the call site with the given name, or the name of the function calling Retry when the name is
+// empty, and records the policy it uses
+func getCallSite(name string, options *SetOptions) *callSite {
+	if name == "" {
+		name = callerName(3)
+	}
+
+	site, ok := callSites.Load(name)
+	if !ok {
+		site, _ = callSites.LoadOrStore(name, &callSite{name: name})
+	}
+
+	cs := site.(*callSite)
+
+	if current := cs.options.Load(); current == nil || !sameOptions(current, options) {
+		latest := *options
+		latest.Message = ""
+
+		cs.options.Store(&latest)
+	}
+
+	return cs
+}
+
+// sameOptions returns whether the options are the same, ignoring the message
+func sameOptions(a, b *SetOptions) bool {
+	withMessage := *a
+	withMessage.Message = b.Message
+
+	return withMessage == *b
+}
+
+// callerName returns the package qualified name of the function skip frames up the stack
+func callerName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name
+}
+
+// policy returns the effective retry policy of the options
+func (o *SetOptions) policy() Policy {
+	p := Policy{
+		RetryCount:  o.RetryCount,
+		Backoff:     o.BackoffDurationType.String(),
+		Exponential: o.ExponentialBackoff,
+	}
+
+	if o.InfiniteRetry {
+		p.RetryCount = -1
+	}
+
+	if o.ExponentialBackoff {
+		p.BackoffFactor = o.BackoffFactor
+		p.MaxBackoff = o.MaxBackoff.String()
+	} else {
+		p.BackoffMultiplier = o.BackoffMultiplier
+	}
+
+	if !o.InfiniteRetry {
+		p.MaxWait = o.maxWait().String()
+	}
+
+	return p
+}
+
+// maxWait returns the total time waited between the attempts of a finite retry that never succeeds
+func (o *SetOptions) maxWait() time.Duration {
+	var (
+		total   time.Duration
+		backoff = o.BackoffDurationType
+	)
+
+	for i := 0; i < o.RetryCount; i++ {
+		if o.ExponentialBackoff {
+			total += backoff
+			backoff = CappedExponentialBackoff(backoff, o.BackoffFactor, o.MaxBackoff)
+		} else {
+			total += time.Duration((o.BackoffMultiplier*i)+1) * o.BackoffDurationType
+		}
+	}
+
+	return total
+}
+
+func (cs *callSite) recordRetry() {
+	cs.retries.Add(1)
+	cs.lastRetry.Store(time.Now().UnixNano())
+
+	initPrometheusMetrics()
+	prometheusRetries.WithLabelValues(cs.name).Inc()
+}
+
+func (cs *callSite) recordResult(result string) {
+	cs.calls.Add(1)
+
+	switch result {
+	case resultSuccess:
+		cs.successes.Add(1)
+	case resultRetriedSuccess:
+		cs.retriedSuccesses.Add(1)
+	case resultGivenUp:
+		cs.givenUp.Add(1)
+	case resultCancelled:
+		cs.cancelled.Add(1)
+	}
+
+	initPrometheusMetrics()
+	prometheusRetryCalls.WithLabelValues(cs.name, result).Inc()
+}
+
+func (cs *callSite) stats() CallSiteStats {
+	s := CallSiteStats{
+		CallSite:         cs.name,
+		Calls:            cs.calls.Load(),
+		Retries:          cs.retries.Load(),
+		Successes:        cs.successes.Load(),
+		RetriedSuccesses: cs.retriedSuccesses.Load(),
+		GivenUp:          cs.givenUp.Load(),
+		Cancelled:        cs.cancelled.Load(),
+	}
+
+	if options := cs.options.Load(); options != nil {
+		s.Policy = options.policy()
+	}
+
+	if lastRetry := cs.lastRetry.Load(); lastRetry != 0 {
+		s.LastRetry = time.Unix(0, lastRetry).UTC()
+	}
+
+	return s
+}
+
+// Stats returns the effective retry policy and the retry counters of every call site that called Retry, sorted by
+// the number of retries, most first
+func Stats() []CallSiteStats {
+	stats := make([]CallSiteStats, 0)
+
+	callSites.Range(func(_, value any) bool {
+		stats = append(stats, value.(*callSite).stats())
+		return true
+	})
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Retries != stats[j].Retries {
+			return stats[i].Retries > stats[j].Retries
+		}
+
+		return stats[i].CallSite < stats[j].CallSite
+	})
+
+	return stats
+}
+
+// StatsHandler serves the retry policies and counters of all call sites as JSON
+func StatsHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(Stats())
+}
@@ -0,0 +1,130 @@
+package retry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/util/test/mocklogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findStats(t *testing.T, callSite string) CallSiteStats {
+	t.Helper()
+
+	for _, s := range Stats() {
+		if s.CallSite == callSite {
+			return s
+		}
+	}
+
+	require.Failf(t, "call site not found", "no retry stats for %s", callSite)
+
+	return CallSiteStats{}
+}
+
+func retryWithoutCallSite(ctx context.Context, logger *mocklogger.MockLogger) error {
+	_, err := Retry(ctx, logger, func() (int, error) { return 1, nil })
+	return err
+}
+
+func TestStats(t *testing.T) {
+	logger := mocklogger.NewTestLogger()
+	ctx := context.Background()
+
+	failing := func() (int, error) {
+		return 0, errors.NewProcessingError("error")
+	}
+
+	t.Run("counts retries and results per call site", func(t *testing.T) {
+		opts := []Options{WithCallSite("TestStats.counts"), WithRetryCount(2), WithBackoffDurationType(time.Millisecond), WithBackoffMultiplier(1)}
+
+		_, err := Retry(ctx, logger, func() (int, error) { return 1, nil }, opts...)
+		require.NoError(t, err)
+
+		_, err = Retry(ctx, logger, failing, opts...)
+		require.Error(t, err)
+
+		calls := 0
+		_, err = Retry(ctx, logger, func() (int, error) {
+			calls++
+			if calls == 1 {
+				return 0, errors.NewProcessingError("error")
+			}
+
+			return 1, nil
+		}, opts...)
+		require.NoError(t, err)
+
+		stats := findStats(t, "TestStats.counts")
+		assert.Equal(t, uint64(3), stats.Calls)
+		assert.Equal(t, uint64(3), stats.Retries)
+		assert.Equal(t, uint64(1), stats.Successes)
+		assert.Equal(t, uint64(1), stats.RetriedSuccesses)
+		assert.Equal(t, uint64(1), stats.GivenUp)
+		assert.False(t, stats.LastRetry.IsZero())
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := Retry(cancelled, logger, failing, WithCallSite("TestStats.cancelled"))
+		require.Error(t, err)
+
+		stats := findStats(t, "TestStats.cancelled")
+		assert.Equal(t, uint64(1), stats.Cancelled)
+		assert.Zero(t, stats.Retries)
+	})
+
+	t.Run("effective policy", func(t *testing.T) {
+		_, err := Retry(ctx, logger, func() (int, error) { return 1, nil },
+			WithCallSite("TestStats.linear"), WithRetryCount(4), WithBackoffDurationType(20*time.Millisecond), WithBackoffMultiplier(4))
+		require.NoError(t, err)
+
+		// 1 + 5 + 9 + 13 times 20ms
+		assert.Equal(t, Policy{RetryCount: 4, Backoff: "20ms", BackoffMultiplier: 4, MaxWait: "560ms"}, findStats(t, "TestStats.linear").Policy)
+
+		_, err = Retry(ctx, logger, func() (int, error) { return 1, nil },
+			WithCallSite("TestStats.exponential"), WithExponentialBackoff(), WithRetryCount(4),
+			WithBackoffDurationType(100*time.Millisecond), WithMaxBackoff(300*time.Millisecond))
+		require.NoError(t, err)
+
+		// 100 + 200 + 300 + 300ms
+		assert.Equal(t, Policy{RetryCount: 4, Backoff: "100ms", Exponential: true, BackoffFactor: 2, MaxBackoff: "300ms", MaxWait: "900ms"},
+			findStats(t, "TestStats.exponential").Policy)
+
+		_, err = Retry(ctx, logger, func() (int, error) { return 1, nil }, WithCallSite("TestStats.infinite"), WithInfiniteRetry())
+		require.NoError(t, err)
+
+		policy := findStats(t, "TestStats.infinite").Policy
+		assert.Equal(t, -1, policy.RetryCount)
+		assert.Empty(t, policy.MaxWait)
+	})
+
+	t.Run("call site defaults to the calling function", func(t *testing.T) {
+		require.NoError(t, retryWithoutCallSite(ctx, logger))
+
+		assert.Equal(t, uint64(1), findStats(t, "retry.retryWithoutCallSite").Successes)
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		StatsHandler(recorder, httptest.NewRequest(http.MethodGet, "/debug/retries", nil))
+
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+		var stats []CallSiteStats
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&stats))
+		require.NotEmpty(t, stats)
+
+		for i := 1; i < len(stats); i++ {
+			assert.GreaterOrEqual(t, stats[i-1].Retries, stats[i].Retries, "most retries first")
+		}
+	})
+}
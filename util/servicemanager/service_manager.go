@@ -16,6 +16,7 @@ import (
 
 	"github.com/bsv-blockchain/teranode/errors"
 	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util/buildinfo"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -152,6 +153,8 @@ func (sm *ServiceManager) AddService(name string, service Service) error {
 
 	sm.services = append(sm.services, sw)
 
+	buildinfo.RegisterService(name)
+
 	sm.logger.Infof("⚪️ Initializing service %s...", name)
 
 	if err := service.Init(sm.Ctx); err != nil {
@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// InjectHTTPHeaders adds the trace context of ctx to the headers of an outgoing HTTP request, so the
+// spans of the receiving service become part of the same trace. It is a no-op when tracing is disabled.
+func InjectHTTPHeaders(ctx context.Context, header http.Header) {
+	if !IsTracingEnabled() {
+		return
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ExtractHTTPHeaders returns ctx with the trace context propagated in the headers of an incoming HTTP
+// request, spans started from the returned context continue the trace of the caller. It returns ctx
+// unchanged when tracing is disabled.
+func ExtractHTTPHeaders(ctx context.Context, header http.Header) context.Context {
+	if !IsTracingEnabled() {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHTTPHeaderPropagation(t *testing.T) {
+	originalEnabled := IsTracingEnabled()
+	originalProvider := otel.GetTracerProvider()
+	originalPropagator := otel.GetTextMapPropagator()
+
+	defer func() {
+		SetTracingEnabled(originalEnabled)
+		otel.SetTracerProvider(originalProvider)
+		otel.SetTextMapPropagator(originalPropagator)
+	}()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Run("disabled", func(t *testing.T) {
+		SetTracingEnabled(false)
+
+		header := http.Header{}
+		InjectHTTPHeaders(context.Background(), header)
+		assert.Empty(t, header)
+
+		ctx := context.Background()
+		assert.Equal(t, ctx, ExtractHTTPHeaders(ctx, http.Header{"Traceparent": []string{"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}}))
+	})
+
+	t.Run("continues the trace of the caller", func(t *testing.T) {
+		SetTracingEnabled(true)
+
+		ctx, _, endClient := Tracer("client").Start(context.Background(), "fetch")
+
+		header := http.Header{}
+		InjectHTTPHeaders(ctx, header)
+		require.NotEmpty(t, header.Get("Traceparent"))
+
+		serverCtx := ExtractHTTPHeaders(context.Background(), header)
+		_, serverSpan, endServer := Tracer("server").Start(serverCtx, "serve")
+		endServer()
+		endClient()
+
+		clientSpanContext := trace.SpanContextFromContext(ctx)
+		assert.Equal(t, clientSpanContext.TraceID(), serverSpan.SpanContext().TraceID())
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "serve", spans[0].Name())
+		assert.Equal(t, clientSpanContext.SpanID(), spans[0].Parent().SpanID())
+	})
+}
@@ -0,0 +1,565 @@
+// Package migrate applies ordered, checksummed schema migrations to a SQL database and rolls them back.
+//
+// A migration has a version, a name, the statements applying it (Up) and the statements rolling it back (Down).
+// Versions start at 1 and are contiguous, the schema version of a database is the highest applied version. Applied
+// migrations are recorded in a tracking table with the checksum of their Up statements and their Down statements, so
+//   - a migration changed after it was applied is detected instead of leaving the schema different from what the
+//     migrations describe,
+//   - a database migrated by a newer binary can be rolled back by an older binary, which does not know the newer
+//     migrations, with the Down statements recorded by the newer binary.
+//
+// A migration and its record are applied in one transaction. Migrations that can not run in a transaction, e.g.
+// CREATE INDEX CONCURRENTLY, are recorded as dirty before they run and clean after they ran. A dirty migration failed
+// halfway, or the process stopped while it ran, and the schema is in an unknown state: no migrations are applied to,
+// or rolled back from, a dirty database until an operator repaired the schema and cleared the dirty state.
+//
+// On PostgreSQL the migrations are applied under an advisory lock, so nodes sharing a database do not migrate it
+// concurrently.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/teranode/errors"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/usql"
+)
+
+// tableName matches the names of tracking tables that can be used in statements without quoting
+var tableName = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// Migration is a versioned change of the schema of a database
+type Migration struct {
+	Version       uint32   // Version of the schema after the migration, starting at 1
+	Name          string   // Short description, e.g. "blocks_indexes"
+	Up            []string // Statements applying the migration, in order
+	Down          []string // Statements rolling the migration back, in order
+	NoTransaction bool     // The statements can not run in a transaction, e.g. CREATE INDEX CONCURRENTLY
+}
+
+// Checksum returns the hex encoded SHA-256 of the Up statements of the migration
+func (m Migration) Checksum() string {
+	h := sha256.New()
+
+	for _, statement := range m.Up {
+		h.Write([]byte(statement))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Status is a migration known by this binary, applied to the database, or both
+type Status struct {
+	Version   uint32    `json:"version"`
+	Name      string    `json:"name"`
+	Checksum  string    `json:"checksum"`
+	Known     bool      `json:"known"`   // the migration is known by this binary
+	Applied   bool      `json:"applied"` // the migration is applied to the database
+	AppliedAt time.Time `json:"applied_at,omitempty"`
+	Dirty     bool      `json:"dirty"`
+	Modified  bool      `json:"modified"` // the checksum of the applied migration differs from the known migration
+}
+
+// applied is the record of an applied migration in the tracking table
+type applied struct {
+	version   uint32
+	name      string
+	checksum  string
+	down      []string
+	noTx      bool
+	dirty     bool
+	appliedAt time.Time
+}
+
+// Migrator applies the migrations of a store to its database
+type Migrator struct {
+	logger     ulogger.Logger
+	db         *usql.DB
+	engine     util.SQLEngine
+	table      string
+	migrations []Migration
+}
+
+// New returns a migrator applying the migrations to the database, recording them in the tracking table. The versions
+// of the migrations must be 1, 2, 3, ... in order.
+func New(logger ulogger.Logger, db *usql.DB, engine util.SQLEngine, table string, migrations []Migration) (*Migrator, error) {
+	switch engine {
+	case util.Postgres, util.Sqlite, util.SqliteMemory:
+	default:
+		return nil, errors.NewConfigurationError("unknown database engine: %s", engine)
+	}
+
+	if !tableName.MatchString(table) {
+		return nil, errors.NewConfigurationError("invalid migrations table name %q", table)
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != uint32(i+1) {
+			return nil, errors.NewConfigurationError("migration %q has version %d, expected %d", migration.Name, migration.Version, i+1)
+		}
+
+		if migration.Name == "" || len(migration.Up) == 0 {
+			return nil, errors.NewConfigurationError("migration %d has no name or no up statements", migration.Version)
+		}
+	}
+
+	return &Migrator{
+		logger:     logger,
+		db:         db,
+		engine:     engine,
+		table:      table,
+		migrations: migrations,
+	}, nil
+}
+
+// Latest returns the version of the last migration known by this binary
+func (m *Migrator) Latest() uint32 {
+	return uint32(len(m.migrations))
+}
+
+// Version returns the schema version of the database and whether a migration is dirty, 0 when no migrations are
+// applied
+func (m *Migrator) Version(ctx context.Context) (uint32, bool, error) {
+	var (
+		version uint32
+		dirty   bool
+	)
+
+	err := m.withConn(ctx, func(conn *sql.Conn) error {
+		records, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		version, dirty = schemaVersion(records)
+
+		return nil
+	})
+
+	return version, dirty, err
+}
+
+// Status returns the known and the applied migrations, by version
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.withConn(ctx, func(conn *sql.Conn) error {
+		records, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		byVersion := make(map[uint32]applied, len(records))
+		for _, record := range records {
+			byVersion[record.version] = record
+		}
+
+		latest := m.Latest()
+		if version, _ := schemaVersion(records); version > latest {
+			latest = version
+		}
+
+		for version := uint32(1); version <= latest; version++ {
+			status := Status{Version: version}
+
+			if version <= m.Latest() {
+				migration := m.migrations[version-1]
+				status.Name = migration.Name
+				status.Checksum = migration.Checksum()
+				status.Known = true
+			}
+
+			if record, ok := byVersion[version]; ok {
+				status.Name = record.name
+				status.Applied = true
+				status.AppliedAt = record.appliedAt
+				status.Dirty = record.dirty
+				status.Modified = status.Known && record.checksum != status.Checksum
+				status.Checksum = record.checksum
+			}
+
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// Up applies the migrations not applied to the database yet. Fails when a migration is dirty, when an applied
+// migration was modified, or when the database was migrated by a newer binary.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.migrate(ctx, m.Latest(), false)
+}
+
+// MigrateTo applies the migrations up to the version, or rolls the migrations after the version back, in order.
+// Migrations are rolled back with the down statements recorded when they were applied, so a database migrated by a
+// newer binary can be rolled back to the versions known by this binary.
+func (m *Migrator) MigrateTo(ctx context.Context, target uint32) error {
+	return m.migrate(ctx, target, true)
+}
+
+// migrate applies or rolls back the migrations to the target version, rolling back migrations unknown by this binary
+// only when down is true
+func (m *Migrator) migrate(ctx context.Context, target uint32, down bool) error {
+	if target > m.Latest() {
+		return errors.NewInvalidArgumentError("unknown schema version %d, the latest migration of %s is %d", target, m.table, m.Latest())
+	}
+
+	return m.withConn(ctx, func(conn *sql.Conn) error {
+		records, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		current, dirty := schemaVersion(records)
+		if dirty {
+			return errors.NewStorageError("schema migration %s of %s is dirty, repair the schema and clear the dirty state before migrating", dirtyVersions(records), m.table)
+		}
+
+		if err = m.verify(records, target, down); err != nil {
+			return err
+		}
+
+		for version := current + 1; version <= target; version++ {
+			if err = m.apply(ctx, conn, m.migrations[version-1]); err != nil {
+				return err
+			}
+		}
+
+		for i := len(records) - 1; i >= 0 && records[i].version > target; i-- {
+			if err = m.rollback(ctx, conn, records[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Baseline records the migrations up to the version as applied without running them, for a database whose schema
+// was created before it was managed by migrations. Fails when migrations are recorded already.
+func (m *Migrator) Baseline(ctx context.Context, version uint32) error {
+	if version > m.Latest() {
+		return errors.NewInvalidArgumentError("unknown schema version %d, the latest migration of %s is %d", version, m.table, m.Latest())
+	}
+
+	return m.withConn(ctx, func(conn *sql.Conn) error {
+		records, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if len(records) > 0 {
+			return errors.NewStorageError("can not baseline %s, migrations are recorded already", m.table)
+		}
+
+		for _, migration := range m.migrations[:version] {
+			if err = m.record(ctx, conn, migration, false); err != nil {
+				return err
+			}
+
+			m.logger.Infof("[migrate] baselined %s migration %d %s", m.table, migration.Version, migration.Name)
+		}
+
+		return nil
+	})
+}
+
+// ClearDirty clears the dirty state of a migration after an operator repaired the schema. The migration is kept as
+// applied when applied is true, or removed otherwise, e.g. when its statements were reverted by hand.
+func (m *Migrator) ClearDirty(ctx context.Context, version uint32, applied bool) error {
+	return m.withConn(ctx, func(conn *sql.Conn) error {
+		var (
+			result sql.Result
+			err    error
+		)
+
+		if applied {
+			result, err = conn.ExecContext(ctx, `UPDATE `+m.table+` SET dirty = FALSE WHERE version = $1 AND dirty`, version)
+		} else {
+			result, err = conn.ExecContext(ctx, `DELETE FROM `+m.table+` WHERE version = $1 AND dirty`, version)
+		}
+
+		if err != nil {
+			return errors.NewStorageError("failed to clear the dirty state of %s migration %d", m.table, version, err)
+		}
+
+		if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+			return errors.NewInvalidArgumentError("%s migration %d is not dirty", m.table, version)
+		}
+
+		m.logger.Warnf("[migrate] cleared the dirty state of %s migration %d, kept as applied: %t", m.table, version, applied)
+
+		return nil
+	})
+}
+
+// verify checks the applied migrations that are kept by a migration to the target version are the known migrations,
+// and that migrations unknown by this binary are only rolled back when down is true
+func (m *Migrator) verify(records []applied, target uint32, down bool) error {
+	for _, record := range records {
+		if record.version > target {
+			// rolled back with its recorded down statements
+			continue
+		}
+
+		migration := m.migrations[record.version-1]
+		if record.checksum != migration.Checksum() {
+			return errors.NewStorageError("%s migration %d %s was modified after it was applied, checksum %s, expected %s", m.table, record.version, migration.Name, record.checksum, migration.Checksum())
+		}
+	}
+
+	if version, _ := schemaVersion(records); version > m.Latest() && !down {
+		return errors.NewStorageError("schema version %d of %s is newer than the latest migration %d of this binary, migrate it down to version %d first", version, m.table, m.Latest(), m.Latest())
+	}
+
+	return nil
+}
+
+// apply runs the up statements of the migration and records it
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	start := time.Now()
+
+	if migration.NoTransaction {
+		if err := m.record(ctx, conn, migration, true); err != nil {
+			return err
+		}
+
+		if err := execStatements(ctx, conn, migration.Up); err != nil {
+			return errors.NewStorageError("failed to apply %s migration %d %s, the migration is dirty", m.table, migration.Version, migration.Name, err)
+		}
+
+		if _, err := conn.ExecContext(ctx, `UPDATE `+m.table+` SET dirty = FALSE WHERE version = $1`, migration.Version); err != nil {
+			return errors.NewStorageError("failed to record %s migration %d %s", m.table, migration.Version, migration.Name, err)
+		}
+	} else {
+		err := inTx(ctx, conn, func(tx *sql.Tx) error {
+			if err := execStatements(ctx, tx, migration.Up); err != nil {
+				return err
+			}
+
+			return m.record(ctx, tx, migration, false)
+		})
+		if err != nil {
+			return errors.NewStorageError("failed to apply %s migration %d %s", m.table, migration.Version, migration.Name, err)
+		}
+	}
+
+	m.logger.Infof("[migrate] applied %s migration %d %s in %s", m.table, migration.Version, migration.Name, time.Since(start))
+
+	return nil
+}
+
+// rollback runs the recorded down statements of an applied migration and removes its record
+func (m *Migrator) rollback(ctx context.Context, conn *sql.Conn, record applied) error {
+	start := time.Now()
+
+	if record.noTx {
+		if _, err := conn.ExecContext(ctx, `UPDATE `+m.table+` SET dirty = TRUE WHERE version = $1`, record.version); err != nil {
+			return errors.NewStorageError("failed to record the rollback of %s migration %d %s", m.table, record.version, record.name, err)
+		}
+
+		if err := execStatements(ctx, conn, record.down); err != nil {
+			return errors.NewStorageError("failed to roll back %s migration %d %s, the migration is dirty", m.table, record.version, record.name, err)
+		}
+
+		if _, err := conn.ExecContext(ctx, `DELETE FROM `+m.table+` WHERE version = $1`, record.version); err != nil {
+			return errors.NewStorageError("failed to record the rollback of %s migration %d %s", m.table, record.version, record.name, err)
+		}
+	} else {
+		err := inTx(ctx, conn, func(tx *sql.Tx) error {
+			if err := execStatements(ctx, tx, record.down); err != nil {
+				return err
+			}
+
+			_, err := tx.ExecContext(ctx, `DELETE FROM `+m.table+` WHERE version = $1`, record.version)
+
+			return err
+		})
+		if err != nil {
+			return errors.NewStorageError("failed to roll back %s migration %d %s", m.table, record.version, record.name, err)
+		}
+	}
+
+	m.logger.Infof("[migrate] rolled back %s migration %d %s in %s", m.table, record.version, record.name, time.Since(start))
+
+	return nil
+}
+
+// execer is a connection or a transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// record inserts the record of an applied migration, with its down statements
+func (m *Migrator) record(ctx context.Context, db execer, migration Migration, dirty bool) error {
+	down, err := json.Marshal(migration.Down)
+	if err != nil {
+		return errors.NewProcessingError("failed to encode the down statements of migration %d", migration.Version, err)
+	}
+
+	if _, err = db.ExecContext(ctx, `
+		INSERT INTO `+m.table+` (version, name, checksum, down_sql, no_transaction, dirty, applied_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, migration.Version, migration.Name, migration.Checksum(), string(down), migration.NoTransaction, dirty, time.Now().Unix()); err != nil {
+		return errors.NewStorageError("failed to record %s migration %d %s", m.table, migration.Version, migration.Name, err)
+	}
+
+	return nil
+}
+
+// applied returns the applied migrations by ascending version
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) ([]applied, error) {
+	rows, err := conn.QueryContext(ctx, `
+		SELECT version, name, checksum, down_sql, no_transaction, dirty, applied_at
+		FROM `+m.table+`
+		ORDER BY version ASC
+	`)
+	if err != nil {
+		return nil, errors.NewStorageError("failed to read the %s table", m.table, err)
+	}
+
+	defer rows.Close()
+
+	var records []applied
+
+	for rows.Next() {
+		var (
+			record    applied
+			down      string
+			appliedAt int64
+		)
+
+		if err = rows.Scan(&record.version, &record.name, &record.checksum, &down, &record.noTx, &record.dirty, &appliedAt); err != nil {
+			return nil, errors.NewStorageError("failed to read the %s table", m.table, err)
+		}
+
+		if err = json.Unmarshal([]byte(down), &record.down); err != nil {
+			return nil, errors.NewStorageError("failed to decode the down statements of %s migration %d", m.table, record.version, err)
+		}
+
+		record.appliedAt = time.Unix(appliedAt, 0).UTC()
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.NewStorageError("failed to read the %s table", m.table, err)
+	}
+
+	return records, nil
+}
+
+// withConn runs fn on a dedicated connection holding the migration lock, after creating the tracking table
+func (m *Migrator) withConn(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return errors.NewStorageError("failed to get a database connection", err)
+	}
+
+	defer conn.Close()
+
+	if m.engine == util.Postgres {
+		lockID := m.lockID()
+
+		if _, err = conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+			return errors.NewStorageError("failed to take the %s migration lock", m.table, err)
+		}
+
+		defer func() {
+			// the lock is released with the session when the unlock fails, e.g. because the context is done
+			_, _ = conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID)
+		}()
+	}
+
+	if _, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS `+m.table+` (
+		 version         BIGINT PRIMARY KEY
+		,name            TEXT NOT NULL
+		,checksum        TEXT NOT NULL
+		,down_sql        TEXT NOT NULL
+		,no_transaction  BOOLEAN NOT NULL DEFAULT FALSE
+		,dirty           BOOLEAN NOT NULL DEFAULT FALSE
+		,applied_at      BIGINT NOT NULL
+	  );
+	`); err != nil {
+		return errors.NewStorageError("could not create %s table", m.table, err)
+	}
+
+	return fn(conn)
+}
+
+// lockID returns the PostgreSQL advisory lock key of the tracking table
+func (m *Migrator) lockID() int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("migrate:" + m.table))
+
+	return int64(h.Sum64())
+}
+
+// schemaVersion returns the highest applied version and whether any applied migration is dirty
+func schemaVersion(records []applied) (uint32, bool) {
+	var (
+		version uint32
+		dirty   bool
+	)
+
+	for _, record := range records {
+		if record.version > version {
+			version = record.version
+		}
+
+		dirty = dirty || record.dirty
+	}
+
+	return version, dirty
+}
+
+// dirtyVersions lists the dirty migrations, e.g. "2 blocks_indexes"
+func dirtyVersions(records []applied) string {
+	var versions []string
+
+	for _, record := range records {
+		if record.dirty {
+			versions = append(versions, strconv.FormatUint(uint64(record.version), 10)+" "+record.name)
+		}
+	}
+
+	return strings.Join(versions, ", ")
+}
+
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func execStatements(ctx context.Context, db execer, statements []string) error {
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
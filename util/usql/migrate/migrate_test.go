@@ -0,0 +1,236 @@
+package migrate
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/bsv-blockchain/teranode/settings"
+	"github.com/bsv-blockchain/teranode/ulogger"
+	"github.com/bsv-blockchain/teranode/util"
+	"github.com/bsv-blockchain/teranode/util/usql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func testMigrations() []Migration {
+	return []Migration{
+		{
+			Version: 1,
+			Name:    "items",
+			Up:      []string{`CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`},
+			Down:    []string{`DROP TABLE items`},
+		},
+		{
+			Version: 2,
+			Name:    "items_name_index",
+			Up:      []string{`CREATE INDEX idx_items_name ON items (name)`},
+			Down:    []string{`DROP INDEX idx_items_name`},
+		},
+	}
+}
+
+func newTestDB(t *testing.T) *usql.DB {
+	storeURL, err := url.Parse("sqlitememory:///migrate")
+	require.NoError(t, err)
+
+	db, err := util.InitSQLiteDB(ulogger.TestLogger{}, storeURL, &settings.Settings{})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return db
+}
+
+func newTestMigrator(t *testing.T, db *usql.DB, migrations []Migration) *Migrator {
+	m, err := New(ulogger.TestLogger{}, db, util.SqliteMemory, "schema_migrations", migrations)
+	require.NoError(t, err)
+
+	return m
+}
+
+func tableExists(t *testing.T, db *usql.DB, kind, name string) bool {
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = $1 AND name = $2`, kind, name).Scan(&count))
+
+	return count > 0
+}
+
+func TestNew(t *testing.T) {
+	db := newTestDB(t)
+
+	_, err := New(ulogger.TestLogger{}, db, util.SqliteMemory, "schema_migrations", testMigrations()[1:])
+	require.Error(t, err, "versions start at 1")
+
+	_, err = New(ulogger.TestLogger{}, db, util.SqliteMemory, "schema_migrations", []Migration{{Version: 1, Name: "empty"}})
+	require.Error(t, err, "a migration has up statements")
+
+	_, err = New(ulogger.TestLogger{}, db, util.SqliteMemory, "schema migrations", testMigrations())
+	require.Error(t, err)
+
+	_, err = New(ulogger.TestLogger{}, db, "mysql", "schema_migrations", testMigrations())
+	require.Error(t, err)
+}
+
+func TestMigrator_Up(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	m := newTestMigrator(t, db, testMigrations())
+
+	require.NoError(t, m.Up(ctx))
+	assert.True(t, tableExists(t, db, "table", "items"))
+	assert.True(t, tableExists(t, db, "index", "idx_items_name"))
+
+	version, dirty, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), version)
+	assert.False(t, dirty)
+
+	require.NoError(t, m.Up(ctx), "applied migrations are not applied again")
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+
+	for _, status := range statuses {
+		assert.True(t, status.Known)
+		assert.True(t, status.Applied)
+		assert.False(t, status.Modified)
+		assert.False(t, status.AppliedAt.IsZero())
+	}
+}
+
+func TestMigrator_MigrateTo(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	m := newTestMigrator(t, db, testMigrations())
+
+	require.NoError(t, m.MigrateTo(ctx, 1))
+	assert.True(t, tableExists(t, db, "table", "items"))
+	assert.False(t, tableExists(t, db, "index", "idx_items_name"))
+
+	require.NoError(t, m.Up(ctx))
+	require.NoError(t, m.MigrateTo(ctx, 0))
+	assert.False(t, tableExists(t, db, "table", "items"))
+
+	version, _, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), version)
+
+	require.Error(t, m.MigrateTo(ctx, 3), "unknown version")
+}
+
+func TestMigrator_FailedMigrationRolledBack(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	migrations := testMigrations()
+	migrations[1].Up = append(migrations[1].Up, `CREATE INDEX idx_missing ON missing (name)`)
+
+	m := newTestMigrator(t, db, migrations)
+	require.Error(t, m.Up(ctx))
+
+	assert.False(t, tableExists(t, db, "index", "idx_items_name"), "the statements of a failed migration are rolled back")
+
+	version, dirty, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), version)
+	assert.False(t, dirty)
+}
+
+func TestMigrator_Dirty(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	migrations := testMigrations()
+	migrations[1].NoTransaction = true
+	migrations[1].Up = append(migrations[1].Up, `CREATE INDEX idx_missing ON missing (name)`)
+
+	m := newTestMigrator(t, db, migrations)
+	require.Error(t, m.Up(ctx))
+
+	version, dirty, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), version)
+	assert.True(t, dirty, "a failed migration outside a transaction leaves the database dirty")
+
+	err = m.Up(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dirty")
+	require.Error(t, m.MigrateTo(ctx, 0), "a dirty database is not rolled back either")
+
+	// the operator reverts the partially applied migration by hand
+	_, err = db.Exec(`DROP INDEX idx_items_name`)
+	require.NoError(t, err)
+	require.NoError(t, m.ClearDirty(ctx, 2, false))
+	require.Error(t, m.ClearDirty(ctx, 2, false), "the migration is not dirty anymore")
+
+	version, dirty, err = m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), version)
+	assert.False(t, dirty)
+
+	require.NoError(t, newTestMigrator(t, db, testMigrations()).Up(ctx))
+}
+
+func TestMigrator_Modified(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	require.NoError(t, newTestMigrator(t, db, testMigrations()).Up(ctx))
+
+	migrations := testMigrations()
+	migrations[0].Up = []string{`CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT)`}
+
+	m := newTestMigrator(t, db, migrations)
+
+	err := m.Up(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "modified")
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Modified)
+	assert.False(t, statuses[1].Modified)
+}
+
+func TestMigrator_NewerDatabase(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	require.NoError(t, newTestMigrator(t, db, testMigrations()).Up(ctx))
+
+	older := newTestMigrator(t, db, testMigrations()[:1])
+
+	err := older.Up(ctx)
+	require.Error(t, err, "a database migrated by a newer binary is not used")
+	assert.Contains(t, err.Error(), "newer")
+
+	statuses, err := older.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.False(t, statuses[1].Known)
+	assert.True(t, statuses[1].Applied)
+	assert.Equal(t, "items_name_index", statuses[1].Name)
+
+	require.NoError(t, older.MigrateTo(ctx, 1), "the unknown migration is rolled back with its recorded down statements")
+	assert.False(t, tableExists(t, db, "index", "idx_items_name"))
+	require.NoError(t, older.Up(ctx))
+}
+
+func TestMigrator_Baseline(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// a schema created before it was managed by migrations
+	_, err := db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`)
+	require.NoError(t, err)
+
+	m := newTestMigrator(t, db, testMigrations())
+	require.NoError(t, m.Baseline(ctx, 1))
+	require.Error(t, m.Baseline(ctx, 1), "migrations are recorded already")
+
+	require.NoError(t, m.Up(ctx))
+	assert.True(t, tableExists(t, db, "index", "idx_items_name"))
+}